@@ -0,0 +1,224 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btclog"
+)
+
+// jsonEntry is a single log entry of the JSON log output.
+type jsonEntry struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Subsystem string `json:"subsystem"`
+	TraceID   string `json:"trace_id,omitempty"`
+	Message   string `json:"msg"`
+}
+
+// jsonBackend writes the log entries of all JSON subsystem loggers as one JSON
+// object per line to the shared writer.
+type jsonBackend struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// newJSONBackend creates a JSON log backend that writes to the given writer.
+func newJSONBackend(w io.Writer) *jsonBackend {
+	return &jsonBackend{
+		w: w,
+	}
+}
+
+// Logger returns a new JSON logger for the given subsystem. The logger starts
+// at the info level.
+func (b *jsonBackend) Logger(subsystem string) btclog.Logger {
+	level := uint32(btclog.LevelInfo)
+
+	return &jsonLogger{
+		backend:   b,
+		subsystem: subsystem,
+		level:     &level,
+	}
+}
+
+// write encodes the given entry and writes it to the writer of the backend.
+func (b *jsonBackend) write(entry *jsonEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, _ = b.w.Write(line)
+}
+
+// jsonLogger is a subsystem logger that writes structured JSON log entries.
+type jsonLogger struct {
+	backend   *jsonBackend
+	subsystem string
+	traceID   string
+
+	// level is shared between the loggers of a subsystem, so changing the
+	// level of the subsystem also changes the level of its trace loggers.
+	level *uint32
+}
+
+// A compile time check to ensure jsonLogger implements the btclog.Logger and
+// TraceLogger interfaces.
+var _ btclog.Logger = (*jsonLogger)(nil)
+var _ TraceLogger = (*jsonLogger)(nil)
+
+// jsonLevel returns the name of the given level in the JSON log output.
+func jsonLevel(level btclog.Level) string {
+	switch level {
+	case btclog.LevelTrace:
+		return "trace"
+	case btclog.LevelDebug:
+		return "debug"
+	case btclog.LevelInfo:
+		return "info"
+	case btclog.LevelWarn:
+		return "warn"
+	case btclog.LevelError:
+		return "error"
+	case btclog.LevelCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// print writes the given message at the given level if the level is enabled.
+func (l *jsonLogger) print(level btclog.Level, msg func() string) {
+	if level < l.Level() {
+		return
+	}
+
+	l.backend.write(&jsonEntry{
+		Time:      time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     jsonLevel(level),
+		Subsystem: l.subsystem,
+		TraceID:   l.traceID,
+		Message:   msg(),
+	})
+}
+
+// logf writes a message formatted according to the format specifier.
+func (l *jsonLogger) logf(level btclog.Level, format string,
+	params []interface{}) {
+
+	l.print(level, func() string {
+		return fmt.Sprintf(format, params...)
+	})
+}
+
+// log writes a message formatted using the default formats of its operands.
+func (l *jsonLogger) log(level btclog.Level, v []interface{}) {
+	l.print(level, func() string {
+		return strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+	})
+}
+
+// WithTraceID returns a logger that attaches the given trace ID to every log
+// entry.
+//
+// NOTE: This is part of the TraceLogger interface.
+func (l *jsonLogger) WithTraceID(traceID string) btclog.Logger {
+	return &jsonLogger{
+		backend:   l.backend,
+		subsystem: l.subsystem,
+		traceID:   traceID,
+		level:     l.level,
+	}
+}
+
+// Tracef formats message according to format specifier and writes to log with
+// LevelTrace.
+func (l *jsonLogger) Tracef(format string, params ...interface{}) {
+	l.logf(btclog.LevelTrace, format, params)
+}
+
+// Debugf formats message according to format specifier and writes to log with
+// LevelDebug.
+func (l *jsonLogger) Debugf(format string, params ...interface{}) {
+	l.logf(btclog.LevelDebug, format, params)
+}
+
+// Infof formats message according to format specifier and writes to log with
+// LevelInfo.
+func (l *jsonLogger) Infof(format string, params ...interface{}) {
+	l.logf(btclog.LevelInfo, format, params)
+}
+
+// Warnf formats message according to format specifier and writes to log with
+// LevelWarn.
+func (l *jsonLogger) Warnf(format string, params ...interface{}) {
+	l.logf(btclog.LevelWarn, format, params)
+}
+
+// Errorf formats message according to format specifier and writes to log with
+// LevelError.
+func (l *jsonLogger) Errorf(format string, params ...interface{}) {
+	l.logf(btclog.LevelError, format, params)
+}
+
+// Criticalf formats message according to format specifier and writes to log
+// with LevelCritical.
+func (l *jsonLogger) Criticalf(format string, params ...interface{}) {
+	l.logf(btclog.LevelCritical, format, params)
+}
+
+// Trace formats message using the default formats for its operands and writes
+// to log with LevelTrace.
+func (l *jsonLogger) Trace(v ...interface{}) {
+	l.log(btclog.LevelTrace, v)
+}
+
+// Debug formats message using the default formats for its operands and writes
+// to log with LevelDebug.
+func (l *jsonLogger) Debug(v ...interface{}) {
+	l.log(btclog.LevelDebug, v)
+}
+
+// Info formats message using the default formats for its operands and writes
+// to log with LevelInfo.
+func (l *jsonLogger) Info(v ...interface{}) {
+	l.log(btclog.LevelInfo, v)
+}
+
+// Warn formats message using the default formats for its operands and writes
+// to log with LevelWarn.
+func (l *jsonLogger) Warn(v ...interface{}) {
+	l.log(btclog.LevelWarn, v)
+}
+
+// Error formats message using the default formats for its operands and writes
+// to log with LevelError.
+func (l *jsonLogger) Error(v ...interface{}) {
+	l.log(btclog.LevelError, v)
+}
+
+// Critical formats message using the default formats for its operands and
+// writes to log with LevelCritical.
+func (l *jsonLogger) Critical(v ...interface{}) {
+	l.log(btclog.LevelCritical, v)
+}
+
+// Level returns the current logging level.
+func (l *jsonLogger) Level() btclog.Level {
+	return btclog.Level(atomic.LoadUint32(l.level))
+}
+
+// SetLevel changes the logging level to the passed level.
+func (l *jsonLogger) SetLevel(level btclog.Level) {
+	atomic.StoreUint32(l.level, uint32(level))
+}
@@ -0,0 +1,122 @@
+package build
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btclog"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeEntries decodes the JSON log entries written to the given buffer.
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []jsonEntry {
+	t.Helper()
+
+	var entries []jsonEntry
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		var entry jsonEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// TestJSONLogger tests that the JSON logger writes one entry per line with
+// the level, subsystem and trace ID of the entry.
+func TestJSONLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := newJSONBackend(&buf).Logger("HSWC")
+
+	// Entries below the info level are dropped by default.
+	logger.Debugf("dropped")
+	logger.Infof("forwarded %d htlcs", 2)
+	logger.Warn("link", "down")
+
+	// The trace logger shares the level of the subsystem logger and
+	// attaches the trace ID, also through a prefix logger.
+	prefixLog := NewPrefixLog("Link(1):", logger)
+	traceLog := NewTraceLog(PaymentTraceID([32]byte{1, 2}), prefixLog)
+	logger.SetLevel(btclog.LevelDebug)
+	traceLog.Debugf("settled")
+
+	entries := decodeEntries(t, &buf)
+	require.Len(t, entries, 3)
+
+	require.Equal(t, "info", entries[0].Level)
+	require.Equal(t, "HSWC", entries[0].Subsystem)
+	require.Equal(t, "forwarded 2 htlcs", entries[0].Message)
+	require.Empty(t, entries[0].TraceID)
+
+	require.Equal(t, "warn", entries[1].Level)
+	require.Equal(t, "link down", entries[1].Message)
+
+	require.Equal(t, "debug", entries[2].Level)
+	require.Equal(t, "Link(1): settled", entries[2].Message)
+	require.Equal(t, "0102000000000000", entries[2].TraceID)
+}
+
+// TestTraceLogPrefix tests that the trace ID is prefixed to the message of
+// loggers that don't support structured fields.
+func TestTraceLogPrefix(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := btclog.NewBackend(&buf).Logger("HSWC")
+
+	traceLog := NewTraceLog(PaymentTraceID([32]byte{1}), logger)
+	traceLog.Infof("settled")
+
+	require.Contains(
+		t, buf.String(), "HSWC: trace_id=0100000000000000: settled",
+	)
+}
+
+// TestSampledLog tests that the sampled logger writes the configured fraction
+// of the entries below the warn level and all other entries.
+func TestSampledLog(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := newJSONBackend(&buf).Logger("PEER")
+	sampled := NewSampledLog(0.25, logger)
+
+	// Entries at disabled levels don't count towards the sampling.
+	for i := 0; i < 8; i++ {
+		sampled.Debugf("dropped")
+		sampled.Infof("sampled")
+	}
+
+	// Warnings and errors are never sampled.
+	for i := 0; i < 3; i++ {
+		sampled.Warnf("warning")
+		sampled.Errorf("error")
+	}
+
+	// Trace loggers share the sampling of their subsystem.
+	traceLog := NewTraceLog("abcd", sampled)
+	for i := 0; i < 4; i++ {
+		traceLog.Infof("traced")
+	}
+
+	counts := make(map[string]int)
+	for _, entry := range decodeEntries(t, &buf) {
+		counts[entry.Message]++
+	}
+
+	require.Equal(t, map[string]int{
+		"sampled": 2,
+		"warning": 3,
+		"error":   3,
+		"traced":  1,
+	}, counts)
+}
@@ -18,6 +18,14 @@ type RotatingLogWriter struct {
 
 	backendLog *btclog.Backend
 
+	// jsonBackend is the backend of the subsystem loggers if the log
+	// entries are written as JSON.
+	jsonBackend *jsonBackend
+
+	// sampleRates holds the fraction of the log entries below the warn
+	// level that are written for the subsystems that are sampled.
+	sampleRates map[string]float64
+
 	logRotator *rotator.Rotator
 
 	subsystemLoggers SubLoggers
@@ -41,10 +49,42 @@ func NewRotatingLogWriter() *RotatingLogWriter {
 	}
 }
 
+// LogConfig holds the options of the log output of the subsystem loggers.
+type LogConfig struct {
+	// JSON indicates whether the log entries are written as JSON objects
+	// instead of plain text lines.
+	JSON bool
+
+	// SampleRates maps subsystems to the fraction of their log entries
+	// below the warn level that are written.
+	SampleRates map[string]float64
+}
+
+// SetLogConfig configures the log output of the subsystem loggers. It must be
+// called before the subsystem loggers are generated.
+func (r *RotatingLogWriter) SetLogConfig(cfg *LogConfig) {
+	r.jsonBackend = nil
+	if cfg.JSON {
+		r.jsonBackend = newJSONBackend(r.logWriter)
+	}
+
+	r.sampleRates = cfg.SampleRates
+}
+
 // GenSubLogger creates a new sublogger. A shutdown callback function
 // is provided to be able to shutdown in case of a critical error.
 func (r *RotatingLogWriter) GenSubLogger(tag string, shutdown func()) btclog.Logger {
-	logger := r.backendLog.Logger(tag)
+	var logger btclog.Logger
+	if r.jsonBackend != nil {
+		logger = r.jsonBackend.Logger(tag)
+	} else {
+		logger = r.backendLog.Logger(tag)
+	}
+
+	if rate, ok := r.sampleRates[tag]; ok {
+		logger = NewSampledLog(rate, logger)
+	}
+
 	return NewShutdownLogger(logger, shutdown)
 }
 
@@ -0,0 +1,123 @@
+package build
+
+import (
+	"sync"
+
+	"github.com/btcsuite/btclog"
+)
+
+// logSampler decides which of the log entries of a subsystem are written.
+type logSampler struct {
+	mu sync.Mutex
+
+	// rate is the fraction of the log entries that are written.
+	rate float64
+
+	// count is the number of log entries that were sampled so far.
+	count uint64
+}
+
+// sample returns true if the next log entry should be written. The entries
+// are sampled deterministically, so that exactly the given fraction of the
+// entries is written.
+func (s *logSampler) sample() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := uint64(float64(s.count) * s.rate)
+	s.count++
+
+	return uint64(float64(s.count)*s.rate) > prev
+}
+
+// SampledLog is a logger that only writes a fraction of the log entries below
+// the warn level. Warnings and errors are always written.
+type SampledLog struct {
+	btclog.Logger
+
+	sampler *logSampler
+}
+
+// A compile time check to ensure SampledLog implements the btclog.Logger and
+// TraceLogger interfaces.
+var _ btclog.Logger = (*SampledLog)(nil)
+var _ TraceLogger = (*SampledLog)(nil)
+
+// NewSampledLog creates a logger that only writes the given fraction of the
+// log entries below the warn level to the given logger.
+func NewSampledLog(rate float64, log btclog.Logger) *SampledLog {
+	return &SampledLog{
+		Logger:  log,
+		sampler: &logSampler{rate: rate},
+	}
+}
+
+// sample returns true if a log entry at the given level should be passed on.
+// Entries at disabled levels are not counted, so that the rate applies to the
+// entries that would otherwise be written.
+func (s *SampledLog) sample(level btclog.Level) bool {
+	if level < s.Level() {
+		return false
+	}
+
+	return s.sampler.sample()
+}
+
+// WithTraceID returns a logger that attaches the given trace ID to every log
+// entry. It shares the sampling of this logger.
+//
+// NOTE: This is part of the TraceLogger interface.
+func (s *SampledLog) WithTraceID(traceID string) btclog.Logger {
+	return &SampledLog{
+		Logger:  NewTraceLog(traceID, s.Logger),
+		sampler: s.sampler,
+	}
+}
+
+// Tracef formats message according to format specifier and writes to log with
+// LevelTrace if the entry is sampled.
+func (s *SampledLog) Tracef(format string, params ...interface{}) {
+	if s.sample(btclog.LevelTrace) {
+		s.Logger.Tracef(format, params...)
+	}
+}
+
+// Debugf formats message according to format specifier and writes to log with
+// LevelDebug if the entry is sampled.
+func (s *SampledLog) Debugf(format string, params ...interface{}) {
+	if s.sample(btclog.LevelDebug) {
+		s.Logger.Debugf(format, params...)
+	}
+}
+
+// Infof formats message according to format specifier and writes to log with
+// LevelInfo if the entry is sampled.
+func (s *SampledLog) Infof(format string, params ...interface{}) {
+	if s.sample(btclog.LevelInfo) {
+		s.Logger.Infof(format, params...)
+	}
+}
+
+// Trace formats message using the default formats for its operands and writes
+// to log with LevelTrace if the entry is sampled.
+func (s *SampledLog) Trace(v ...interface{}) {
+	if s.sample(btclog.LevelTrace) {
+		s.Logger.Trace(v...)
+	}
+}
+
+// Debug formats message using the default formats for its operands and writes
+// to log with LevelDebug if the entry is sampled.
+func (s *SampledLog) Debug(v ...interface{}) {
+	if s.sample(btclog.LevelDebug) {
+		s.Logger.Debug(v...)
+	}
+}
+
+// Info formats message using the default formats for its operands and writes
+// to log with LevelInfo if the entry is sampled.
+func (s *SampledLog) Info(v ...interface{}) {
+	if s.sample(btclog.LevelInfo) {
+		s.Logger.Info(v...)
+	}
+}
@@ -0,0 +1,52 @@
+package build
+
+import (
+	"encoding/hex"
+
+	"github.com/btcsuite/btclog"
+)
+
+// traceIDLen is the number of bytes of a payment hash that make up the trace
+// ID of the payment.
+const traceIDLen = 8
+
+// TraceLogger is implemented by loggers that can attach a trace ID to their
+// log entries.
+type TraceLogger interface {
+	// WithTraceID returns a logger that attaches the given trace ID to
+	// every log entry.
+	WithTraceID(traceID string) btclog.Logger
+}
+
+// PaymentTraceID returns the trace ID that ties together the log entries of
+// the lifecycle of the payment with the given hash across subsystems.
+func PaymentTraceID(hash [32]byte) string {
+	return hex.EncodeToString(hash[:traceIDLen])
+}
+
+// NewTraceLog returns a logger that attaches the given trace ID to every log
+// entry. Structured loggers record the trace ID as a separate field, all other
+// loggers prefix the log message with it.
+func NewTraceLog(traceID string, log btclog.Logger) btclog.Logger {
+	if traceLog, ok := log.(TraceLogger); ok {
+		return traceLog.WithTraceID(traceID)
+	}
+
+	return NewPrefixLog("trace_id="+traceID+":", log)
+}
+
+// WithTraceID returns a logger that attaches the given trace ID to every log
+// entry.
+//
+// NOTE: This is part of the TraceLogger interface.
+func (p *PrefixLog) WithTraceID(traceID string) btclog.Logger {
+	return NewPrefixLog(p.prefix, NewTraceLog(traceID, p.log))
+}
+
+// WithTraceID returns a logger that attaches the given trace ID to every log
+// entry.
+//
+// NOTE: This is part of the TraceLogger interface.
+func (s *ShutdownLogger) WithTraceID(traceID string) btclog.Logger {
+	return NewShutdownLogger(NewTraceLog(traceID, s.Logger), s.shutdown)
+}
@@ -313,6 +313,57 @@ func (s *SpendDetail) String() string {
 		s.SpenderInputIndex, s.SpentOutPoint, s.SpendingHeight)
 }
 
+// VerifySpendInBlock checks that the spend described by the given details is
+// included in the given block. The spending transaction must spend the
+// outpoint at the reported input index, it must be one of the transactions of
+// the block and the transactions of the block must commit to the merkle root
+// found in its header.
+func VerifySpendInBlock(spend *SpendDetail, block *wire.MsgBlock) error {
+	tx := spend.SpendingTx
+	if tx == nil || spend.SpentOutPoint == nil {
+		return fmt.Errorf("spend details are incomplete")
+	}
+
+	if uint32(len(tx.TxIn)) <= spend.SpenderInputIndex {
+		return fmt.Errorf("spender input index %d is out of range for "+
+			"tx %v", spend.SpenderInputIndex, tx.TxHash())
+	}
+
+	prevOut := tx.TxIn[spend.SpenderInputIndex].PreviousOutPoint
+	if prevOut != *spend.SpentOutPoint {
+		return fmt.Errorf("tx %v spends %v at input %d, expected %v",
+			tx.TxHash(), prevOut, spend.SpenderInputIndex,
+			spend.SpentOutPoint)
+	}
+
+	var (
+		txHash = tx.TxHash()
+		found  bool
+		txns   = make([]*btcutil.Tx, 0, len(block.Transactions))
+	)
+	for _, blockTx := range block.Transactions {
+		btcTx := btcutil.NewTx(blockTx)
+		if *btcTx.Hash() == txHash {
+			found = true
+		}
+
+		txns = append(txns, btcTx)
+	}
+	if !found {
+		return fmt.Errorf("tx %v not found in block %v", txHash,
+			block.BlockHash())
+	}
+
+	merkleRoot := blockchain.CalcMerkleRoot(txns, false)
+	if merkleRoot != block.Header.MerkleRoot {
+		return fmt.Errorf("merkle root %v of block %v doesn't match "+
+			"its transactions", block.Header.MerkleRoot,
+			block.BlockHash())
+	}
+
+	return nil
+}
+
 // SpendEvent encapsulates a spentness notification. Its only field 'Spend' will
 // be sent upon once the target output passed into RegisterSpendNtfn has been
 // spent on the blockchain.
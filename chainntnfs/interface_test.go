@@ -0,0 +1,94 @@
+package chainntnfs_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifySpendInBlock tests that a spend is only verified if the spending
+// transaction spends the outpoint and is committed to by the block.
+func TestVerifySpendInBlock(t *testing.T) {
+	t.Parallel()
+
+	spentOutPoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 2}
+
+	spendingTx := wire.NewMsgTx(2)
+	spendingTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{3}},
+	})
+	spendingTx.AddTxIn(&wire.TxIn{PreviousOutPoint: spentOutPoint})
+	spendingTx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: testRawScript})
+
+	otherTx := wire.NewMsgTx(2)
+	otherTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{4}},
+	})
+	otherTx.AddTxOut(&wire.TxOut{Value: 2000, PkScript: testRawScript})
+
+	makeBlock := func(txns ...*wire.MsgTx) *wire.MsgBlock {
+		block := &wire.MsgBlock{Transactions: txns}
+		btcTxns := make([]*btcutil.Tx, 0, len(txns))
+		for _, tx := range txns {
+			btcTxns = append(btcTxns, btcutil.NewTx(tx))
+		}
+		block.Header.MerkleRoot = blockchain.CalcMerkleRoot(
+			btcTxns, false,
+		)
+
+		return block
+	}
+
+	spendingHash := spendingTx.TxHash()
+	validSpend := func() *chainntnfs.SpendDetail {
+		return &chainntnfs.SpendDetail{
+			SpentOutPoint:     &spentOutPoint,
+			SpenderTxHash:     &spendingHash,
+			SpendingTx:        spendingTx,
+			SpenderInputIndex: 1,
+			SpendingHeight:    100,
+		}
+	}
+
+	// A spend that is included in the block is verified.
+	block := makeBlock(otherTx, spendingTx)
+	require.NoError(t, chainntnfs.VerifySpendInBlock(validSpend(), block))
+
+	// A spend that doesn't spend the outpoint at the reported input index
+	// is rejected.
+	spend := validSpend()
+	spend.SpenderInputIndex = 0
+	require.ErrorContains(
+		t, chainntnfs.VerifySpendInBlock(spend, block), "expected",
+	)
+
+	// An input index that is out of range is rejected.
+	spend = validSpend()
+	spend.SpenderInputIndex = 2
+	require.ErrorContains(
+		t, chainntnfs.VerifySpendInBlock(spend, block), "out of range",
+	)
+
+	// A spend that isn't part of the block is rejected.
+	otherBlock := makeBlock(otherTx)
+	require.ErrorContains(
+		t, chainntnfs.VerifySpendInBlock(validSpend(), otherBlock),
+		"not found",
+	)
+
+	// A block whose transactions don't commit to its merkle root is
+	// rejected.
+	tamperedBlock := makeBlock(otherTx)
+	tamperedBlock.Transactions = append(
+		tamperedBlock.Transactions, spendingTx,
+	)
+	require.ErrorContains(
+		t, chainntnfs.VerifySpendInBlock(validSpend(), tamperedBlock),
+		"merkle root",
+	)
+}
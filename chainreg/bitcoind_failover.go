@@ -0,0 +1,601 @@
+package chainreg
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+)
+
+const (
+	// maxBitcoindHeightLag is the number of blocks a bitcoind node may lag
+	// behind the best known height of all nodes to still be considered
+	// healthy.
+	maxBitcoindHeightLag = 2
+
+	// failoverCheckTimeout is the time after which the health check of a
+	// single bitcoind node fails.
+	failoverCheckTimeout = 10 * time.Second
+
+	// failoverDialTimeout is the timeout for establishing connections to
+	// the bitcoind nodes.
+	failoverDialTimeout = 5 * time.Second
+)
+
+var (
+	// errNoHealthyBitcoind is returned if none of the configured bitcoind
+	// nodes is healthy.
+	errNoHealthyBitcoind = errors.New("no healthy bitcoind node")
+)
+
+// bitcoindFailover relays the RPC and ZMQ connections to the active one of
+// multiple bitcoind nodes. The health of all nodes is checked periodically,
+// and whenever the active node becomes unhealthy, the connections are relayed
+// to the next healthy node in the configured order instead. RPC requests are
+// forwarded one by one, while the ZMQ connections are closed on failover, so
+// that the subscribers reconnect and re-subscribe to the new active node.
+type bitcoindFailover struct {
+	started sync.Once
+	stopped sync.Once
+
+	// nodes are the bitcoind nodes in the order of preference.
+	nodes []lncfg.BitcoindNode
+
+	// user and pass are the credentials that the clients of the relayed
+	// RPC interface must use.
+	user string
+	pass string
+
+	// zmq indicates whether ZMQ connections are relayed as well.
+	zmq bool
+
+	// checkInterval is the interval in which the health of all nodes is
+	// checked.
+	checkInterval time.Duration
+
+	// checkNode returns the best block height of the node with the given
+	// index, or an error if the node is unhealthy.
+	checkNode func(idx int) (int64, error)
+
+	httpClient *http.Client
+
+	// failoverMtx serializes health checks that are triggered by failed
+	// RPC requests.
+	failoverMtx sync.Mutex
+
+	// mu protects active.
+	mu sync.RWMutex
+
+	// active is the index of the node connections are relayed to.
+	active int
+
+	rpcListener net.Listener
+	rpcServer   *http.Server
+	blockRelay  *zmqRelay
+	txRelay     *zmqRelay
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newBitcoindFailover creates a new bitcoindFailover for the given nodes. The
+// relayed RPC interface requires the given credentials.
+func newBitcoindFailover(nodes []lncfg.BitcoindNode, user, pass string,
+	zmq bool, checkInterval time.Duration) *bitcoindFailover {
+
+	f := &bitcoindFailover{
+		nodes:         nodes,
+		user:          user,
+		pass:          pass,
+		zmq:           zmq,
+		checkInterval: checkInterval,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				DialContext: (&net.Dialer{
+					Timeout: failoverDialTimeout,
+				}).DialContext,
+			},
+		},
+		quit: make(chan struct{}),
+	}
+	f.checkNode = f.checkBitcoindNode
+
+	return f
+}
+
+// Start selects the first healthy node and starts relaying connections to it.
+func (f *bitcoindFailover) Start() error {
+	var startErr error
+	f.started.Do(func() {
+		startErr = f.start()
+	})
+
+	return startErr
+}
+
+// start selects the first healthy node and starts the RPC and ZMQ relays.
+func (f *bitcoindFailover) start() error {
+	active := selectBitcoindNode(f.checkNodes(), -1)
+	if active < 0 {
+		return errNoHealthyBitcoind
+	}
+	f.active = active
+
+	log.Infof("Using bitcoind node %v, %d failover nodes configured",
+		f.nodes[active].RPCHost, len(f.nodes)-1)
+
+	var err error
+	f.rpcListener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	f.rpcServer = &http.Server{
+		Handler:           f,
+		ReadHeaderTimeout: failoverCheckTimeout,
+	}
+
+	if f.zmq {
+		f.blockRelay, err = newZMQRelay(func() string {
+			return f.activeNode().ZMQPubRawBlock
+		})
+		if err != nil {
+			_ = f.rpcListener.Close()
+			return err
+		}
+
+		f.txRelay, err = newZMQRelay(func() string {
+			return f.activeNode().ZMQPubRawTx
+		})
+		if err != nil {
+			_ = f.rpcListener.Close()
+			_ = f.blockRelay.close()
+			return err
+		}
+
+		f.blockRelay.start()
+		f.txRelay.start()
+	}
+
+	f.wg.Add(2)
+	go func() {
+		defer f.wg.Done()
+
+		err := f.rpcServer.Serve(f.rpcListener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Bitcoind RPC relay failed: %v", err)
+		}
+	}()
+	go f.healthMonitor()
+
+	return nil
+}
+
+// Stop stops relaying connections.
+func (f *bitcoindFailover) Stop() error {
+	f.stopped.Do(func() {
+		close(f.quit)
+
+		if f.rpcServer != nil {
+			_ = f.rpcServer.Close()
+		}
+		if f.blockRelay != nil {
+			_ = f.blockRelay.close()
+		}
+		if f.txRelay != nil {
+			_ = f.txRelay.close()
+		}
+
+		f.wg.Wait()
+	})
+
+	return nil
+}
+
+// RPCHost returns the address of the relayed RPC interface.
+func (f *bitcoindFailover) RPCHost() string {
+	return f.rpcListener.Addr().String()
+}
+
+// ZMQPubRawBlock returns the address of the relayed ZMQ raw block
+// notifications.
+func (f *bitcoindFailover) ZMQPubRawBlock() string {
+	return "tcp://" + f.blockRelay.listener.Addr().String()
+}
+
+// ZMQPubRawTx returns the address of the relayed ZMQ raw transaction
+// notifications.
+func (f *bitcoindFailover) ZMQPubRawTx() string {
+	return "tcp://" + f.txRelay.listener.Addr().String()
+}
+
+// activeIndex returns the index of the active node.
+func (f *bitcoindFailover) activeIndex() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.active
+}
+
+// activeNode returns the active node.
+func (f *bitcoindFailover) activeNode() lncfg.BitcoindNode {
+	return f.nodes[f.activeIndex()]
+}
+
+// healthMonitor checks the health of all nodes once per check interval, and
+// fails over to another node if the active node is unhealthy.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *bitcoindFailover) healthMonitor() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.failover(f.activeIndex())
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// failover checks the health of all nodes and switches to another node if the
+// given node, which was active when a problem was detected, is unhealthy. The
+// index of the active node is returned.
+func (f *bitcoindFailover) failover(failed int) int {
+	f.failoverMtx.Lock()
+	defer f.failoverMtx.Unlock()
+
+	// Another caller already failed over while we were waiting.
+	if active := f.activeIndex(); active != failed {
+		return active
+	}
+
+	active := selectBitcoindNode(f.checkNodes(), failed)
+	switch {
+	case active < 0:
+		log.Errorf("None of the bitcoind nodes is healthy, keeping %v "+
+			"as the active node", f.nodes[failed].RPCHost)
+
+		return failed
+
+	case active == failed:
+		return failed
+	}
+
+	log.Warnf("Bitcoind node %v is unhealthy, failing over to %v",
+		f.nodes[failed].RPCHost, f.nodes[active].RPCHost)
+
+	f.mu.Lock()
+	f.active = active
+	f.mu.Unlock()
+
+	// The ZMQ subscribers reconnect to the new active node once their
+	// connections are closed.
+	if f.zmq {
+		f.blockRelay.closeConns()
+		f.txRelay.closeConns()
+	}
+
+	return active
+}
+
+// checkNodes returns the best block height of all nodes, or -1 for the nodes
+// that are unhealthy.
+func (f *bitcoindFailover) checkNodes() []int64 {
+	heights := make([]int64, len(f.nodes))
+	for i, node := range f.nodes {
+		height, err := f.checkNode(i)
+		if err != nil {
+			log.Debugf("Bitcoind node %v is unhealthy: %v",
+				node.RPCHost, err)
+
+			height = -1
+		}
+
+		heights[i] = height
+	}
+
+	return heights
+}
+
+// selectBitcoindNode returns the index of the node that connections should be
+// relayed to, given the best block heights of all nodes with -1 marking
+// unhealthy ones. Nodes that lag behind the best height are unhealthy as well.
+// The active node is kept if it is healthy, otherwise the first healthy node
+// is returned, or -1 if there is none.
+func selectBitcoindNode(heights []int64, active int) int {
+	var bestHeight int64
+	for _, height := range heights {
+		if height > bestHeight {
+			bestHeight = height
+		}
+	}
+
+	healthy := func(idx int) bool {
+		return heights[idx] >= 0 &&
+			heights[idx]+maxBitcoindHeightLag >= bestHeight
+	}
+
+	if active >= 0 && active < len(heights) && healthy(active) {
+		return active
+	}
+
+	for i := range heights {
+		if healthy(i) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// checkBitcoindNode returns the best block height of the node with the given
+// index. The node is unhealthy if it doesn't respond to RPC requests, or if
+// its ZMQ interfaces aren't reachable.
+func (f *bitcoindFailover) checkBitcoindNode(idx int) (int64, error) {
+	node := f.nodes[idx]
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), failoverCheckTimeout,
+	)
+	defer cancel()
+
+	reqBody := []byte(`{"jsonrpc":"1.0","id":0,"method":"getblockcount",` +
+		`"params":[]}`)
+	resp, err := f.forward(ctx, node, reqBody)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var rpcResp struct {
+		Result *int64 `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return 0, fmt.Errorf("invalid response with status %v: %w",
+			resp.Status, err)
+	}
+
+	switch {
+	case rpcResp.Error != nil:
+		return 0, errors.New(rpcResp.Error.Message)
+
+	case rpcResp.Result == nil:
+		return 0, errors.New("missing block count")
+	}
+
+	if f.zmq {
+		zmqAddrs := []string{node.ZMQPubRawBlock, node.ZMQPubRawTx}
+		for _, addr := range zmqAddrs {
+			conn, err := dialZMQ(addr)
+			if err != nil {
+				return 0, err
+			}
+			_ = conn.Close()
+		}
+	}
+
+	return *rpcResp.Result, nil
+}
+
+// forward sends the given RPC request body to the given node.
+func (f *bitcoindFailover) forward(ctx context.Context,
+	node lncfg.BitcoindNode, body []byte) (*http.Response, error) {
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, "http://"+node.RPCHost,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(node.RPCUser, node.RPCPass)
+
+	return f.httpClient.Do(req)
+}
+
+// ServeHTTP relays an RPC request to the active node. If the active node can't
+// be reached, the request is retried once with the next healthy node.
+//
+// NOTE: This is part of the http.Handler interface.
+func (f *bitcoindFailover) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, ok := r.BasicAuth()
+	userOk := subtle.ConstantTimeCompare([]byte(user), []byte(f.user))
+	passOk := subtle.ConstantTimeCompare([]byte(pass), []byte(f.pass))
+	if !ok || userOk&passOk != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	active := f.activeIndex()
+	resp, err := f.forward(r.Context(), f.nodes[active], body)
+	if err != nil && r.Context().Err() == nil {
+		log.Warnf("Unable to relay RPC request to bitcoind node %v: "+
+			"%v", f.nodes[active].RPCHost, err)
+
+		if newActive := f.failover(active); newActive != active {
+			resp, err = f.forward(
+				r.Context(), f.nodes[newActive], body,
+			)
+		}
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		log.Debugf("Unable to relay RPC response: %v", err)
+	}
+}
+
+// zmqRelay relays ZMQ connections to the ZMQ interface of the active bitcoind
+// node. As ZMQ subscriptions are established once per connection, the
+// connections must be closed whenever the active node changes.
+type zmqRelay struct {
+	listener net.Listener
+
+	// target returns the ZMQ address of the active node.
+	target func() string
+
+	// mu protects conns.
+	mu sync.Mutex
+
+	// conns are the relayed connections in both directions.
+	conns map[net.Conn]struct{}
+
+	wg sync.WaitGroup
+}
+
+// newZMQRelay creates a new zmqRelay that listens on a local port.
+func newZMQRelay(target func() string) (*zmqRelay, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	return &zmqRelay{
+		listener: listener,
+		target:   target,
+		conns:    make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// start starts accepting connections.
+func (z *zmqRelay) start() {
+	z.wg.Add(1)
+	go z.acceptConns()
+}
+
+// close stops accepting connections and closes all relayed connections.
+func (z *zmqRelay) close() error {
+	err := z.listener.Close()
+	z.closeConns()
+	z.wg.Wait()
+
+	return err
+}
+
+// closeConns closes all relayed connections.
+func (z *zmqRelay) closeConns() {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	for conn := range z.conns {
+		_ = conn.Close()
+	}
+	z.conns = make(map[net.Conn]struct{})
+}
+
+// acceptConns relays all incoming connections until the listener is closed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (z *zmqRelay) acceptConns() {
+	defer z.wg.Done()
+
+	for {
+		conn, err := z.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		z.wg.Add(1)
+		go z.relay(conn)
+	}
+}
+
+// relay relays the given connection to the ZMQ interface of the active node.
+//
+// NOTE: This MUST be run as a goroutine.
+func (z *zmqRelay) relay(conn net.Conn) {
+	defer z.wg.Done()
+
+	target := z.target()
+	targetConn, err := dialZMQ(target)
+	if err != nil {
+		log.Debugf("Unable to relay ZMQ connection to %v: %v", target,
+			err)
+
+		_ = conn.Close()
+		return
+	}
+
+	// If we failed over while dialing, the connection must be closed so
+	// that the subscriber reconnects to the new active node.
+	z.mu.Lock()
+	if z.target() != target {
+		z.mu.Unlock()
+		_ = conn.Close()
+		_ = targetConn.Close()
+
+		return
+	}
+	z.conns[conn] = struct{}{}
+	z.conns[targetConn] = struct{}{}
+	z.mu.Unlock()
+
+	var copyWg sync.WaitGroup
+	copyConn := func(dst, src net.Conn) {
+		defer copyWg.Done()
+
+		_, _ = io.Copy(dst, src)
+
+		// Once either side is closed, both connections are closed.
+		_ = dst.Close()
+		_ = src.Close()
+	}
+
+	copyWg.Add(2)
+	go copyConn(conn, targetConn)
+	go copyConn(targetConn, conn)
+	copyWg.Wait()
+
+	z.mu.Lock()
+	delete(z.conns, conn)
+	delete(z.conns, targetConn)
+	z.mu.Unlock()
+}
+
+// dialZMQ connects to the given ZMQ address, which is either a TCP address
+// with an optional tcp:// prefix, or a unix socket with an ipc:// or unix://
+// prefix.
+func dialZMQ(addr string) (net.Conn, error) {
+	for _, prefix := range []string{"ipc://", "unix://"} {
+		if path, ok := strings.CutPrefix(addr, prefix); ok {
+			return net.DialTimeout(
+				"unix", path, failoverDialTimeout,
+			)
+		}
+	}
+
+	return net.DialTimeout(
+		"tcp", strings.TrimPrefix(addr, "tcp://"), failoverDialTimeout,
+	)
+}
@@ -0,0 +1,300 @@
+package chainreg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSelectBitcoindNode checks that the active node is kept while it's
+// healthy, and that the first healthy node is selected otherwise.
+func TestSelectBitcoindNode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		heights  []int64
+		active   int
+		expected int
+	}{
+		{
+			name:     "initial selection",
+			heights:  []int64{-1, 100, 100},
+			active:   -1,
+			expected: 1,
+		},
+		{
+			name:     "keep healthy active node",
+			heights:  []int64{100, 100, 100},
+			active:   2,
+			expected: 2,
+		},
+		{
+			name:     "active node unreachable",
+			heights:  []int64{100, -1, 100},
+			active:   1,
+			expected: 0,
+		},
+		{
+			name:     "active node lagging behind",
+			heights:  []int64{100 - maxBitcoindHeightLag - 1, 100},
+			active:   0,
+			expected: 1,
+		},
+		{
+			name:     "active node slightly behind",
+			heights:  []int64{100 - maxBitcoindHeightLag, 100},
+			active:   0,
+			expected: 0,
+		},
+		{
+			name:     "no healthy node",
+			heights:  []int64{-1, -1},
+			active:   0,
+			expected: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		active := selectBitcoindNode(tc.heights, tc.active)
+		require.Equal(t, tc.expected, active, tc.name)
+	}
+}
+
+// newTestBitcoind creates an HTTP server that mimics the RPC interface of a
+// bitcoind node. The getblockcount call returns the given height, and all
+// other calls return the given name.
+func newTestBitcoind(t *testing.T, name string,
+	height int64) *httptest.Server {
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != name || pass != name+"pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			var req struct {
+				Method string `json:"method"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&req)
+			require.NoError(t, err)
+
+			var result interface{} = name
+			if req.Method == "getblockcount" {
+				result = height
+			}
+
+			err = json.NewEncoder(w).Encode(map[string]interface{}{
+				"result": result,
+				"error":  nil,
+				"id":     0,
+			})
+			require.NoError(t, err)
+		},
+	))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// relayedRPC sends an RPC request to the relayed RPC interface of the given
+// failover and returns the result.
+func relayedRPC(t *testing.T, f *bitcoindFailover, user,
+	pass string) (int, string) {
+
+	req, err := http.NewRequest(
+		http.MethodPost, "http://"+f.RPCHost(),
+		strings.NewReader(`{"method":"getbestblockhash"}`),
+	)
+	require.NoError(t, err)
+	req.SetBasicAuth(user, pass)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var rpcResp struct {
+		Result string `json:"result"`
+	}
+	if resp.StatusCode == http.StatusOK {
+		err = json.NewDecoder(resp.Body).Decode(&rpcResp)
+		require.NoError(t, err)
+	}
+
+	return resp.StatusCode, rpcResp.Result
+}
+
+// TestBitcoindFailoverRPC checks that RPC requests are relayed to the active
+// node with its credentials, and that requests are retried with the next
+// healthy node once the active node becomes unreachable.
+func TestBitcoindFailoverRPC(t *testing.T) {
+	t.Parallel()
+
+	// The first node lags behind, so the second node is selected.
+	servers := []*httptest.Server{
+		newTestBitcoind(t, "node1", 90),
+		newTestBitcoind(t, "node2", 100),
+		newTestBitcoind(t, "node3", 100),
+	}
+	nodes := make([]lncfg.BitcoindNode, 0, len(servers))
+	for i, server := range servers {
+		name := fmt.Sprintf("node%d", i+1)
+		nodes = append(nodes, lncfg.BitcoindNode{
+			RPCHost: strings.TrimPrefix(server.URL, "http://"),
+			RPCUser: name,
+			RPCPass: name + "pass",
+		})
+	}
+
+	f := newBitcoindFailover(nodes, "lnd", "secret", false, time.Hour)
+	require.NoError(t, f.Start())
+	t.Cleanup(func() {
+		require.NoError(t, f.Stop())
+	})
+
+	status, result := relayedRPC(t, f, "lnd", "secret")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "node2", result)
+
+	// Requests with the wrong credentials are rejected.
+	status, _ = relayedRPC(t, f, "lnd", "wrong")
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	// Once the active node goes down, the request is relayed to the next
+	// healthy node instead.
+	servers[1].Close()
+
+	status, result = relayedRPC(t, f, "lnd", "secret")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "node3", result)
+	require.Equal(t, 2, f.activeIndex())
+}
+
+// newTestZMQ creates a TCP server that writes the given name to every
+// incoming connection and keeps it open until the client disconnects.
+func newTestZMQ(t *testing.T, name string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				_, _ = conn.Write([]byte(name))
+				_, _ = io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	return "tcp://" + listener.Addr().String()
+}
+
+// readZMQ connects to the given relayed ZMQ address and returns the name of
+// the node that the connection is relayed to.
+func readZMQ(t *testing.T, addr string) (net.Conn, string) {
+	conn, err := dialZMQ(addr)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	name := make([]byte, len("nodeX"))
+	_, err = io.ReadFull(conn, name)
+	require.NoError(t, err)
+
+	return conn, string(name)
+}
+
+// TestBitcoindFailoverZMQ checks that ZMQ connections are relayed to the
+// active node, and that they are closed on failover so that subscribers
+// reconnect to the new active node.
+func TestBitcoindFailoverZMQ(t *testing.T) {
+	t.Parallel()
+
+	nodes := []lncfg.BitcoindNode{
+		{
+			RPCHost:        "node1",
+			ZMQPubRawBlock: newTestZMQ(t, "node1"),
+			ZMQPubRawTx:    newTestZMQ(t, "node1"),
+		},
+		{
+			RPCHost:        "node2",
+			ZMQPubRawBlock: newTestZMQ(t, "node2"),
+			ZMQPubRawTx:    newTestZMQ(t, "node2"),
+		},
+	}
+
+	var (
+		mu      sync.Mutex
+		healthy = []bool{true, true}
+	)
+	f := newBitcoindFailover(nodes, "lnd", "secret", true, time.Hour)
+	f.checkNode = func(idx int) (int64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !healthy[idx] {
+			return 0, fmt.Errorf("node%d down", idx+1)
+		}
+
+		return 100, nil
+	}
+	require.NoError(t, f.Start())
+	t.Cleanup(func() {
+		require.NoError(t, f.Stop())
+	})
+
+	blockConn, name := readZMQ(t, f.ZMQPubRawBlock())
+	require.Equal(t, "node1", name)
+	txConn, name := readZMQ(t, f.ZMQPubRawTx())
+	require.Equal(t, "node1", name)
+
+	// Nothing changes as long as the active node is healthy.
+	require.Equal(t, 0, f.failover(0))
+
+	mu.Lock()
+	healthy[0] = false
+	mu.Unlock()
+	require.Equal(t, 1, f.failover(0))
+
+	// The relayed connections are closed, and reconnecting relays them to
+	// the new active node.
+	for _, conn := range []net.Conn{blockConn, txConn} {
+		require.NoError(t, conn.SetReadDeadline(
+			time.Now().Add(5*time.Second),
+		))
+		_, err := conn.Read(make([]byte, 1))
+		require.ErrorIs(t, err, io.EOF)
+	}
+
+	_, name = readZMQ(t, f.ZMQPubRawBlock())
+	require.Equal(t, "node2", name)
+	_, name = readZMQ(t, f.ZMQPubRawTx())
+	require.Equal(t, "node2", name)
+
+	// Failing over again with the previously active node doesn't change
+	// the active node.
+	require.Equal(t, 1, f.failover(0))
+	require.Equal(t, nodes[1], f.activeNode())
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/chain"
 	"github.com/lightninglabs/neutrino"
 	"github.com/lightningnetwork/lnd/blockcache"
@@ -230,7 +231,21 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 		),
 	}
 
-	var err error
+	var (
+		err error
+
+		// mempoolEstimator is the fee estimator based on the mempool
+		// of the chain backend, if the backend supports it.
+		mempoolEstimator chainfee.Estimator
+
+		// blockEstimator is the fee estimator based on recent blocks,
+		// which is used by light clients.
+		blockEstimator chainfee.Estimator
+
+		// failover relays the bitcoind connections to the active
+		// bitcoind node if failover nodes are configured.
+		failover *bitcoindFailover
+	)
 	heightHintCacheConfig := channeldb.CacheConfig{
 		QueryDisable: cfg.HeightHintCacheQueryDisable,
 	}
@@ -285,6 +300,14 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			return err
 		}
 
+		// Without a mempool, we'll derive fee estimates from recent
+		// blocks. We keep the static fees on the test networks to make
+		// it easier to execute manual or automated test cases.
+		if !cfg.Bitcoin.RegTest && !cfg.Bitcoin.SimNet {
+			blockEstimator = newNeutrinoBlockEstimator(cfg)
+			cc.FeeEstimator = blockEstimator
+		}
+
 	case "bitcoind":
 		bitcoindMode := cfg.BitcoindMode
 
@@ -326,9 +349,45 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			}
 		}
 
+		// If failover nodes are configured, all connections are
+		// relayed to the active one of the healthy bitcoind nodes.
+		rpcHost := bitcoindHost
+		zmqPubRawBlock := bitcoindMode.ZMQPubRawBlock
+		zmqPubRawTx := bitcoindMode.ZMQPubRawTx
+		if len(bitcoindMode.Failover) > 0 {
+			failoverNodes, err := bitcoindMode.FailoverNodes()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			nodes := append([]lncfg.BitcoindNode{{
+				RPCHost:        bitcoindHost,
+				RPCUser:        bitcoindMode.RPCUser,
+				RPCPass:        bitcoindMode.RPCPass,
+				ZMQPubRawBlock: bitcoindMode.ZMQPubRawBlock,
+				ZMQPubRawTx:    bitcoindMode.ZMQPubRawTx,
+			}}, failoverNodes...)
+
+			failover = newBitcoindFailover(
+				nodes, bitcoindMode.RPCUser,
+				bitcoindMode.RPCPass, !bitcoindMode.RPCPolling,
+				bitcoindMode.FailoverCheckInterval,
+			)
+			if err := failover.Start(); err != nil {
+				return nil, nil, fmt.Errorf("unable to start "+
+					"bitcoind failover: %w", err)
+			}
+
+			rpcHost = failover.RPCHost()
+			if !bitcoindMode.RPCPolling {
+				zmqPubRawBlock = failover.ZMQPubRawBlock()
+				zmqPubRawTx = failover.ZMQPubRawTx()
+			}
+		}
+
 		bitcoindCfg := &chain.BitcoindConfig{
 			ChainParams:        cfg.ActiveNetParams.Params,
-			Host:               bitcoindHost,
+			Host:               rpcHost,
 			User:               bitcoindMode.RPCUser,
 			Pass:               bitcoindMode.RPCPass,
 			Dialer:             cfg.Dialer,
@@ -343,8 +402,8 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			}
 		} else {
 			bitcoindCfg.ZMQConfig = &chain.ZMQConfig{
-				ZMQBlockHost:           bitcoindMode.ZMQPubRawBlock,
-				ZMQTxHost:              bitcoindMode.ZMQPubRawTx,
+				ZMQBlockHost:           zmqPubRawBlock,
+				ZMQTxHost:              zmqPubRawTx,
 				ZMQReadDeadline:        bitcoindMode.ZMQReadDeadline,
 				MempoolPollingInterval: bitcoindMode.TxPollingInterval,
 				PollingIntervalJitter:  lncfg.DefaultTxPollingJitter,
@@ -379,7 +438,7 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 		// If we're not in regtest mode, then we'll attempt to use a
 		// proper fee estimator for testnet.
 		rpcConfig := &rpcclient.ConnConfig{
-			Host:                 bitcoindHost,
+			Host:                 rpcHost,
 			User:                 bitcoindMode.RPCUser,
 			Pass:                 bitcoindMode.RPCPass,
 			DisableConnectOnNew:  true,
@@ -387,6 +446,15 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			DisableTLS:           true,
 			HTTPPostMode:         true,
 		}
+		// The mempool of bitcoind can be used as an additional fee
+		// source.
+		mempoolEstimator, err = chainfee.NewBitcoindMempoolEstimator(
+			*rpcConfig, chainfee.DefaultMempoolRefreshInterval,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		if !cfg.Bitcoin.RegTest {
 			log.Infof("Initializing bitcoind backed fee estimator "+
 				"in %s mode", bitcoindMode.EstimateMode)
@@ -692,6 +760,11 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 	cc.BestBlockTracker =
 		chainntnfs.NewBestBlockTracker(cc.ChainNotifier)
 
+	// Keep the fee estimator of the chain backend around, as it might be
+	// combined with other fee sources below.
+	backendEstimator := cc.FeeEstimator
+
+	var webAPIEstimator chainfee.Estimator
 	switch {
 	// If the fee URL isn't set, and the user is running mainnet, then
 	// we'll return an error to instruct them to set a proper fee
@@ -713,9 +786,15 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			cfg.Fee.URL, cacheFees, cfg.Fee.MinUpdateTimeout,
 			cfg.Fee.MaxUpdateTimeout)
 
-		cc.FeeEstimator, err = chainfee.NewWebAPIEstimator(
+		feeURLPubKey, err := cfg.Fee.ParseURLPubKey()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		webAPIEstimator, err = chainfee.NewWebAPIEstimator(
 			chainfee.SparseConfFeeSource{
-				URL: cfg.Fee.URL,
+				URL:    cfg.Fee.URL,
+				PubKey: feeURLPubKey,
 			},
 			!cacheFees,
 			cfg.Fee.MinUpdateTimeout,
@@ -724,6 +803,57 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 		if err != nil {
 			return nil, nil, err
 		}
+		cc.FeeEstimator = webAPIEstimator
+	}
+
+	// If multiple fee sources are configured, or we're running a light
+	// client, we'll combine multiple fee estimators instead.
+	switch {
+	case len(cfg.Fee.Sources) > 0:
+		cc.FeeEstimator, err = newAggregateEstimator(
+			cfg.Fee, map[string]chainfee.Estimator{
+				lncfg.FeeSourceBackend: backendEstimator,
+				lncfg.FeeSourceWebAPI:  webAPIEstimator,
+				lncfg.FeeSourceMempool: mempoolEstimator,
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+	// Light clients use the fee estimates derived from recent blocks, with
+	// the fee API or the static fees as a fallback while not enough blocks
+	// are processed yet.
+	case blockEstimator != nil:
+		fallbackName := lncfg.FeeSourceWebAPI
+		fallback := webAPIEstimator
+		if fallback == nil {
+			fallbackName = "static"
+			fallback = chainfee.NewStaticEstimator(
+				DefaultBitcoinStaticFeePerKW,
+				DefaultBitcoinStaticMinRelayFeeRate,
+			)
+		}
+
+		log.Infof("Using block based fee estimator with %v fallback",
+			fallbackName)
+
+		cc.FeeEstimator, err = chainfee.NewAggregateEstimator(
+			[]chainfee.FeeSource{
+				{
+					Name:      lncfg.FeeSourceBackend,
+					Estimator: blockEstimator,
+					Weight:    1,
+				},
+				{
+					Name:      fallbackName,
+					Estimator: fallback,
+				},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	ccCleanup := func() {
@@ -733,6 +863,13 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 					err)
 			}
 		}
+
+		if failover != nil {
+			if err := failover.Stop(); err != nil {
+				log.Errorf("Failed to stop bitcoind "+
+					"failover: %v", err)
+			}
+		}
 	}
 
 	// Start fee estimator.
@@ -743,6 +880,74 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 	return cc, ccCleanup, nil
 }
 
+// newAggregateEstimator creates a fee estimator that combines the configured
+// fee sources. The given estimators are the available fee sources, keyed by
+// their name. Unavailable fee sources are nil.
+func newAggregateEstimator(cfg *lncfg.Fee,
+	estimators map[string]chainfee.Estimator) (chainfee.Estimator, error) {
+
+	configured, err := cfg.ParseSources()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]chainfee.FeeSource, 0, len(configured))
+	for _, source := range configured {
+		estimator := estimators[source.Name]
+		if estimator == nil {
+			return nil, fmt.Errorf("fee source %v is not "+
+				"available with the configured chain backend "+
+				"or fee.url", source.Name)
+		}
+
+		log.Infof("Using fee source %v with weight %d", source.Name,
+			source.Weight)
+
+		sources = append(sources, chainfee.FeeSource{
+			Name:      source.Name,
+			Estimator: estimator,
+			Weight:    source.Weight,
+		})
+	}
+
+	return chainfee.NewAggregateEstimator(sources)
+}
+
+// newNeutrinoBlockEstimator creates a fee estimator that derives its estimates
+// from the recent blocks fetched by the neutrino light client.
+func newNeutrinoBlockEstimator(cfg *Config) *chainfee.BlockEstimator {
+	feeFloor := chainfee.SatPerKVByte(
+		cfg.NeutrinoMode.FeeFloor * 1000,
+	).FeePerKWeight()
+
+	return chainfee.NewBlockEstimator(&chainfee.BlockEstimatorConfig{
+		ChainParams: cfg.ActiveNetParams.Params,
+		BestHeight: func() (int32, error) {
+			bestBlock, err := cfg.NeutrinoCS.BestBlock()
+			if err != nil {
+				return 0, err
+			}
+
+			return bestBlock.Height, nil
+		},
+		FetchBlock: func(height int32) (*wire.MsgBlock, error) {
+			hash, err := cfg.NeutrinoCS.GetBlockHash(int64(height))
+			if err != nil {
+				return nil, err
+			}
+
+			block, err := cfg.NeutrinoCS.GetBlock(*hash)
+			if err != nil {
+				return nil, err
+			}
+
+			return block.MsgBlock(), nil
+		},
+		FeeFloor: feeFloor,
+		Window:   cfg.NeutrinoMode.FeeWindow,
+	})
+}
+
 // NewChainControl attempts to create a ChainControl instance according
 // to the parameters in the passed configuration. Currently three
 // branches of ChainControl instances exist: one backed by a running btcd
@@ -13,6 +13,11 @@ const (
 	// channel backup fie.
 	DefaultBackupFileName = "channel.backup"
 
+	// DefaultRecoveredBackupFileName is the default name of the file that
+	// a channel backup returned by a peer is stored in if it contains
+	// channels that are missing from our own backup.
+	DefaultRecoveredBackupFileName = "channel.backup.recovered"
+
 	// DefaultTempBackupFileName is the default name of the temporary SCB
 	// file that we'll use to atomically update the primary back up file
 	// when new channel are detected.
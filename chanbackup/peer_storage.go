@@ -0,0 +1,153 @@
+package chanbackup
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// PeerStorageConfig holds the configuration of a PeerStorageSwapper.
+type PeerStorageConfig struct {
+	// KeyRing is used to decrypt the backups that our peers return to us.
+	KeyRing keychain.KeyRing
+
+	// BroadcastBackup sends the given backup to all connected peers that
+	// store backups for us.
+	BroadcastBackup func(backup PackedMulti)
+
+	// RecoveredBackup stores the backups returned by our peers that
+	// contain channels which are missing from our own backup, so that
+	// those channels can be restored.
+	//
+	// NOTE: The RecoveredBackup must not share its temporary file with the
+	// wrapped Swapper, unless both are only updated by the
+	// PeerStorageSwapper.
+	RecoveredBackup Swapper
+}
+
+// PeerStorageSwapper is a Swapper that distributes every backup that was
+// swapped in by the wrapped Swapper to our peers which signal
+// option_provide_storage. The peers return the latest backup they stored for
+// us whenever we reconnect, which lets us recover our channels if our local
+// backup was lost.
+type PeerStorageSwapper struct {
+	Swapper
+
+	cfg *PeerStorageConfig
+
+	// mu serializes the updates of the wrapped Swapper and the
+	// RecoveredBackup, and protects latest.
+	mu sync.Mutex
+
+	// latest is the latest backup distributed to our peers. It is nil if
+	// the latest backup is too large to be stored by our peers.
+	latest PackedMulti
+}
+
+// A compile-time check to ensure PeerStorageSwapper implements the Swapper
+// interface.
+var _ Swapper = (*PeerStorageSwapper)(nil)
+
+// NewPeerStorageSwapper creates a new PeerStorageSwapper that distributes the
+// backups swapped in by the given Swapper to our peers.
+func NewPeerStorageSwapper(swapper Swapper,
+	cfg *PeerStorageConfig) *PeerStorageSwapper {
+
+	return &PeerStorageSwapper{
+		Swapper: swapper,
+		cfg:     cfg,
+	}
+}
+
+// UpdateAndSwap swaps in the new backup using the wrapped Swapper, and then
+// sends it to our peers. Backups that are too large to be stored by our peers
+// aren't distributed.
+//
+// NOTE: This is part of the Swapper interface.
+func (p *PeerStorageSwapper) UpdateAndSwap(newBackup PackedMulti) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.Swapper.UpdateAndSwap(newBackup); err != nil {
+		return err
+	}
+
+	if len(newBackup) > lnwire.MaxPeerStorageBlobSize {
+		log.Warnf("Channel backup of %d bytes exceeds the peer "+
+			"storage limit of %d bytes, not distributing it to "+
+			"peers", len(newBackup), lnwire.MaxPeerStorageBlobSize)
+
+		p.latest = nil
+
+		return nil
+	}
+
+	p.latest = newBackup
+	p.cfg.BroadcastBackup(newBackup)
+
+	return nil
+}
+
+// LatestBackup returns the latest backup that is distributed to our peers, or
+// nil if there is none.
+func (p *PeerStorageSwapper) LatestBackup() PackedMulti {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.latest
+}
+
+// HandleRetrievedBackup processes the backup that the peer with the given
+// public key returned to us. If the backup contains channels that are missing
+// from our own backup, it is stored with the RecoveredBackup Swapper.
+func (p *PeerStorageSwapper) HandleRetrievedBackup(peer [33]byte,
+	blob []byte) error {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	packed := PackedMulti(blob)
+	retrieved, err := packed.Unpack(p.cfg.KeyRing)
+	if err != nil {
+		return fmt.Errorf("unable to unpack channel backup "+
+			"returned by peer %x: %w", peer, err)
+	}
+
+	current, err := p.Swapper.ExtractMulti(p.cfg.KeyRing)
+	if err != nil {
+		return fmt.Errorf("unable to extract channel backup: %w", err)
+	}
+
+	known := make(map[wire.OutPoint]struct{})
+	for _, single := range current.StaticBackups {
+		known[single.FundingOutpoint] = struct{}{}
+	}
+
+	var numMissing int
+	for _, single := range retrieved.StaticBackups {
+		if _, ok := known[single.FundingOutpoint]; !ok {
+			numMissing++
+		}
+	}
+
+	if numMissing == 0 {
+		log.Debugf("Channel backup returned by peer %x contains no "+
+			"unknown channels", peer)
+
+		return nil
+	}
+
+	log.Warnf("Channel backup returned by peer %x contains %d "+
+		"channel(s) missing from our backup, storing it as recovered "+
+		"backup", peer, numMissing)
+
+	if err := p.cfg.RecoveredBackup.UpdateAndSwap(packed); err != nil {
+		return fmt.Errorf("unable to store recovered channel "+
+			"backup: %w", err)
+	}
+
+	return nil
+}
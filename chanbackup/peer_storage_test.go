@@ -0,0 +1,95 @@
+package chanbackup
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnencrypt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// packTestMulti packs a multi backup of the given singles.
+func packTestMulti(t *testing.T, keyRing *lnencrypt.MockKeyRing,
+	singles ...Single) PackedMulti {
+
+	t.Helper()
+
+	multi := Multi{StaticBackups: singles}
+
+	var b bytes.Buffer
+	require.NoError(t, multi.PackToWriter(&b, keyRing))
+
+	return b.Bytes()
+}
+
+// TestPeerStorageSwapper tests that backups are distributed to our peers, and
+// that backups returned by our peers are recovered if they contain unknown
+// channels.
+func TestPeerStorageSwapper(t *testing.T) {
+	t.Parallel()
+
+	keyRing := &lnencrypt.MockKeyRing{}
+
+	singles := make([]Single, 0, 2)
+	for i := 0; i < 2; i++ {
+		channel, err := genRandomOpenChannelShell()
+		require.NoError(t, err)
+
+		singles = append(singles, NewSingle(channel, []net.Addr{addr1}))
+	}
+
+	var broadcasts []PackedMulti
+	backupFile := newMockSwapper(keyRing)
+	recovered := newMockSwapper(keyRing)
+	swapper := NewPeerStorageSwapper(backupFile, &PeerStorageConfig{
+		KeyRing: keyRing,
+		BroadcastBackup: func(backup PackedMulti) {
+			broadcasts = append(broadcasts, backup)
+		},
+		RecoveredBackup: recovered,
+	})
+
+	// Backups swapped in are written to the backup file and distributed
+	// to our peers.
+	backup := packTestMulti(t, keyRing, singles[0])
+	require.NoError(t, swapper.UpdateAndSwap(backup))
+	require.Equal(t, backup, <-backupFile.swaps)
+	require.Equal(t, []PackedMulti{backup}, broadcasts)
+	require.Equal(t, backup, swapper.LatestBackup())
+
+	// A peer returning our latest backup requires no action.
+	peer := [33]byte{1}
+	require.NoError(t, swapper.HandleRetrievedBackup(peer, backup))
+	require.Empty(t, recovered.swaps)
+
+	// A backup that contains a channel we don't know is stored as
+	// recovered backup.
+	retrieved := packTestMulti(t, keyRing, singles...)
+	require.NoError(t, swapper.HandleRetrievedBackup(peer, retrieved))
+	require.Equal(t, retrieved, <-recovered.swaps)
+
+	// Backups we can't decrypt are rejected.
+	err := swapper.HandleRetrievedBackup(peer, []byte("invalid"))
+	require.Error(t, err)
+	require.Empty(t, recovered.swaps)
+
+	// Backups exceeding the peer storage limit aren't distributed, and
+	// replace the latest backup.
+	swapper.Swapper = &unpackingFreeSwapper{mockSwapper: backupFile}
+	large := make(PackedMulti, lnwire.MaxPeerStorageBlobSize+1)
+	require.NoError(t, swapper.UpdateAndSwap(large))
+	require.Len(t, broadcasts, 1)
+	require.Nil(t, swapper.LatestBackup())
+}
+
+// unpackingFreeSwapper is a Swapper that accepts any backup without unpacking
+// it.
+type unpackingFreeSwapper struct {
+	*mockSwapper
+}
+
+func (u *unpackingFreeSwapper) UpdateAndSwap(newBackup PackedMulti) error {
+	return nil
+}
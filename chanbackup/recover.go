@@ -1,9 +1,11 @@
 package chanbackup
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/keychain"
@@ -84,6 +86,40 @@ func Recover(backups []Single, restorer ChannelRestorer,
 	return nil
 }
 
+// SelectSingles returns the backups of the channels with the given funding
+// outpoints, in the order they appear in backups. If no outpoints are given,
+// all backups are returned. An error is returned if any of the outpoints
+// doesn't belong to one of the backups.
+func SelectSingles(backups []Single, chanPoints []wire.OutPoint) ([]Single,
+	error) {
+
+	if len(chanPoints) == 0 {
+		return backups, nil
+	}
+
+	selection := make(map[wire.OutPoint]struct{}, len(chanPoints))
+	for _, chanPoint := range chanPoints {
+		selection[chanPoint] = struct{}{}
+	}
+
+	selected := make([]Single, 0, len(chanPoints))
+	for _, backup := range backups {
+		if _, ok := selection[backup.FundingOutpoint]; !ok {
+			continue
+		}
+
+		selected = append(selected, backup)
+		delete(selection, backup.FundingOutpoint)
+	}
+
+	for chanPoint := range selection {
+		return nil, fmt.Errorf("ChannelPoint(%v) not found in backup",
+			chanPoint)
+	}
+
+	return selected, nil
+}
+
 // TODO(roasbeef): more specific keychain interface?
 
 // UnpackAndRecoverSingles is a one-shot method, that given a set of packed
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/lnencrypt"
 	"github.com/stretchr/testify/require"
 )
@@ -228,3 +229,35 @@ func TestUnpackAndRecoverMulti(t *testing.T) {
 
 	// TODO(roasbeef): verify proper call args
 }
+
+// TestSelectSingles tests that only the backups of the selected channels are
+// returned, and that selecting a channel that isn't in the backups fails.
+func TestSelectSingles(t *testing.T) {
+	t.Parallel()
+
+	backups := make([]Single, 0, 3)
+	for i := 0; i < 3; i++ {
+		channel, err := genRandomOpenChannelShell()
+		require.NoError(t, err)
+
+		backups = append(backups, NewSingle(channel, nil))
+	}
+
+	// Without a selection, all backups are returned.
+	selected, err := SelectSingles(backups, nil)
+	require.NoError(t, err)
+	require.Equal(t, backups, selected)
+
+	// The selected backups are returned in their original order.
+	selected, err = SelectSingles(backups, []wire.OutPoint{
+		backups[2].FundingOutpoint, backups[0].FundingOutpoint,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Single{backups[0], backups[2]}, selected)
+
+	// Selecting an unknown channel fails.
+	_, err = SelectSingles(backups, []wire.OutPoint{
+		backups[1].FundingOutpoint, {Index: 1},
+	})
+	require.ErrorContains(t, err, "not found in backup")
+}
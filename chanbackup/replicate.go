@@ -0,0 +1,234 @@
+package chanbackup
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultReplicationTimeout is the default time after which an attempt
+	// to replicate a backup to a remote target is aborted.
+	DefaultReplicationTimeout = time.Minute
+
+	// DefaultMinReplicationBackoff is the default time we wait before
+	// retrying a failed replication for the first time.
+	DefaultMinReplicationBackoff = 5 * time.Second
+
+	// DefaultMaxReplicationBackoff is the default maximum time we wait
+	// between two attempts to replicate a backup.
+	DefaultMaxReplicationBackoff = 10 * time.Minute
+)
+
+// ErrReplicaMismatch is returned by a Replicator if the backup that was stored
+// by the remote target doesn't match the backup that was sent to it.
+var ErrReplicaMismatch = errors.New("replicated backup doesn't match the " +
+	"local backup")
+
+// Replicator is a remote target that a copy of the packed multi backup is
+// pushed to every time it is updated.
+type Replicator interface {
+	// Name returns a human-readable description of the target, used for
+	// logging.
+	Name() string
+
+	// Replicate stores the packed multi backup at the remote target,
+	// replacing the prior backup. An error must be returned if the target
+	// can't confirm that it stored the backup intact.
+	Replicate(ctx context.Context, backup PackedMulti) error
+}
+
+// ReplicationConfig holds the configuration of a ReplicatingSwapper.
+type ReplicationConfig struct {
+	// Replicators are the remote targets the backup is pushed to.
+	Replicators []Replicator
+
+	// Timeout is the time after which an attempt to replicate a backup
+	// to a target is aborted.
+	Timeout time.Duration
+
+	// MinBackoff is the time we wait before retrying a failed replication
+	// for the first time. The backoff is doubled after every failed
+	// attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum time we wait between two attempts to
+	// replicate a backup.
+	MaxBackoff time.Duration
+}
+
+// ReplicatingSwapper is a Swapper that pushes every backup that was swapped in
+// by the wrapped Swapper to a set of remote targets. Each target is served by
+// its own goroutine, which retries failed replications with an exponential
+// backoff until they succeed. If the backup is updated while a replication is
+// still pending, only the latest backup is pushed to the target.
+type ReplicatingSwapper struct {
+	started sync.Once
+	stopped sync.Once
+
+	Swapper
+
+	cfg *ReplicationConfig
+
+	// pending holds, for each target, the latest backup that still needs
+	// to be pushed to it. A new backup replaces a pending one.
+	pending []chan PackedMulti
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile-time check to ensure ReplicatingSwapper implements the Swapper
+// interface.
+var _ Swapper = (*ReplicatingSwapper)(nil)
+
+// NewReplicatingSwapper creates a new ReplicatingSwapper that replicates the
+// backups swapped in by the given Swapper.
+func NewReplicatingSwapper(swapper Swapper,
+	cfg *ReplicationConfig) *ReplicatingSwapper {
+
+	pending := make([]chan PackedMulti, len(cfg.Replicators))
+	for i := range pending {
+		pending[i] = make(chan PackedMulti, 1)
+	}
+
+	return &ReplicatingSwapper{
+		Swapper: swapper,
+		cfg:     cfg,
+		pending: pending,
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start launches the goroutines that replicate the backups to the remote
+// targets.
+func (r *ReplicatingSwapper) Start() error {
+	r.started.Do(func() {
+		log.Infof("chanbackup.ReplicatingSwapper starting with %d "+
+			"target(s)", len(r.cfg.Replicators))
+
+		for i, replicator := range r.cfg.Replicators {
+			r.wg.Add(1)
+			go r.replicationHandler(replicator, r.pending[i])
+		}
+	})
+
+	return nil
+}
+
+// Stop signals all replication goroutines to exit and waits for them to do
+// so. Replications that are still pending are abandoned.
+func (r *ReplicatingSwapper) Stop() error {
+	r.stopped.Do(func() {
+		log.Infof("chanbackup.ReplicatingSwapper shutting down...")
+		defer log.Debug("chanbackup.ReplicatingSwapper shutdown " +
+			"complete")
+
+		close(r.quit)
+		r.wg.Wait()
+	})
+
+	return nil
+}
+
+// UpdateAndSwap swaps in the new backup using the wrapped Swapper, and then
+// schedules its replication to all remote targets. The replication happens in
+// the background, so this method doesn't block on the remote targets.
+//
+// NOTE: This is part of the Swapper interface.
+func (r *ReplicatingSwapper) UpdateAndSwap(newBackup PackedMulti) error {
+	if err := r.Swapper.UpdateAndSwap(newBackup); err != nil {
+		return err
+	}
+
+	for _, pending := range r.pending {
+		// Drop the backup that is still waiting to be replicated, if
+		// any, as it's superseded by the new one.
+		select {
+		case <-pending:
+		default:
+		}
+
+		pending <- newBackup
+	}
+
+	return nil
+}
+
+// replicationHandler pushes the backups sent on the pending channel to the
+// given target, retrying until each of them succeeds or is superseded.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (r *ReplicatingSwapper) replicationHandler(replicator Replicator,
+	pending chan PackedMulti) {
+
+	defer r.wg.Done()
+
+	for {
+		var backup PackedMulti
+		select {
+		case backup = <-pending:
+		case <-r.quit:
+			return
+		}
+
+		backoff := r.cfg.MinBackoff
+		for {
+			err := r.replicate(replicator, backup)
+			if err == nil {
+				break
+			}
+
+			log.Errorf("Unable to replicate channel backup to %v, "+
+				"retrying in %v: %v", replicator.Name(),
+				backoff, err)
+
+			// Wait for the backoff to expire before we retry,
+			// unless a newer backup arrives in the meantime, in
+			// which case we'll replicate that one right away.
+			select {
+			case <-time.After(backoff):
+			case backup = <-pending:
+				backoff = r.cfg.MinBackoff
+				continue
+			case <-r.quit:
+				return
+			}
+
+			backoff *= 2
+			if backoff > r.cfg.MaxBackoff {
+				backoff = r.cfg.MaxBackoff
+			}
+		}
+	}
+}
+
+// replicate makes a single attempt to push the backup to the given target.
+func (r *ReplicatingSwapper) replicate(replicator Replicator,
+	backup PackedMulti) error {
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), r.cfg.Timeout,
+	)
+	defer cancel()
+
+	// Abort the attempt if we're shutting down.
+	go func() {
+		select {
+		case <-r.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := replicator.Replicate(ctx, backup); err != nil {
+		return err
+	}
+
+	log.Infof("Replicated channel backup (sha256=%x) to %v",
+		sha256.Sum256(backup), replicator.Name())
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package chanbackup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// BackupDigestHeader is the HTTP header that carries the hex encoded
+	// SHA-256 digest of the backup sent to a webhook. Webhooks must echo
+	// the digest of the backup they stored in the same header of their
+	// response, which is then verified against the backup that was sent.
+	BackupDigestHeader = "X-Backup-Sha256"
+
+	// maxResponseSize is the maximum number of bytes we read from the
+	// response body of a remote target, for logging purposes.
+	maxResponseSize = 1024
+)
+
+// HTTPReplicator replicates backups to a webhook by sending each backup as the
+// body of an HTTP PUT request.
+type HTTPReplicator struct {
+	// url is the URL of the webhook.
+	url *url.URL
+
+	// headers are the additional headers set on each request, for example
+	// to authenticate with the webhook.
+	headers http.Header
+
+	client *http.Client
+}
+
+// A compile-time check to ensure HTTPReplicator implements the Replicator
+// interface.
+var _ Replicator = (*HTTPReplicator)(nil)
+
+// NewHTTPReplicator creates a new HTTPReplicator that sends the backups to the
+// given URL. The headers are added to each request.
+func NewHTTPReplicator(rawURL string,
+	headers http.Header) (*HTTPReplicator, error) {
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid webhook URL scheme %q, must "+
+			"be http or https", u.Scheme)
+	}
+
+	return &HTTPReplicator{
+		url:     u,
+		headers: headers,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Name returns a human-readable description of the target.
+//
+// NOTE: This is part of the Replicator interface.
+func (h *HTTPReplicator) Name() string {
+	return fmt.Sprintf("webhook %v", h.url.Redacted())
+}
+
+// Replicate sends the backup to the webhook. The SHA-256 digest of the backup
+// is sent along in the BackupDigestHeader, and the webhook must echo the
+// digest of the backup it stored in the same header of its response.
+//
+// NOTE: This is part of the Replicator interface.
+func (h *HTTPReplicator) Replicate(ctx context.Context,
+	backup PackedMulti) error {
+
+	digest := sha256.Sum256(backup)
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, h.url.String(), bytes.NewReader(backup),
+	)
+	if err != nil {
+		return err
+	}
+	for name, values := range h.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set(BackupDigestHeader, hex.EncodeToString(digest[:]))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	echoed := resp.Header.Get(BackupDigestHeader)
+	if echoed == "" {
+		return fmt.Errorf("%w: webhook didn't confirm the digest of "+
+			"the stored backup", ErrReplicaMismatch)
+	}
+	if !strings.EqualFold(echoed, hex.EncodeToString(digest[:])) {
+		return fmt.Errorf("%w: webhook stored backup with digest %v",
+			ErrReplicaMismatch, echoed)
+	}
+
+	return nil
+}
+
+// checkResponse returns an error that includes the start of the response body
+// if the response doesn't have a 2xx status code.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+
+	return fmt.Errorf("unexpected response status %v: %s", resp.Status,
+		bytes.TrimSpace(body))
+}
@@ -0,0 +1,158 @@
+package chanbackup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config holds the configuration of an S3Replicator.
+type S3Config struct {
+	// Endpoint is the URL of the S3-compatible service, for example
+	// https://s3.us-east-1.amazonaws.com.
+	Endpoint string
+
+	// Region is the region of the bucket.
+	Region string
+
+	// Bucket is the name of the bucket the backup is stored in.
+	Bucket string
+
+	// Key is the object key the backup is stored under.
+	Key string
+
+	// AccessKeyID is the ID of the access key used to sign requests.
+	AccessKeyID string
+
+	// SecretAccessKey is the secret of the access key used to sign
+	// requests.
+	SecretAccessKey string
+}
+
+// S3Replicator replicates backups to an object in a bucket of an
+// S3-compatible object storage service. The service verifies the uploaded
+// object against the MD5 digest of the backup, and the stored object is read
+// back and compared against the backup after every upload.
+type S3Replicator struct {
+	cfg *S3Config
+
+	// key is the object key without a leading slash.
+	key string
+
+	client *minio.Client
+}
+
+// A compile-time check to ensure S3Replicator implements the Replicator
+// interface.
+var _ Replicator = (*S3Replicator)(nil)
+
+// NewS3Replicator creates a new S3Replicator from the given config.
+func NewS3Replicator(cfg *S3Config) (*S3Replicator, error) {
+	return newS3Replicator(cfg, nil)
+}
+
+// newS3Replicator creates a new S3Replicator from the given config that sends
+// its requests using the given transport. If the transport is nil, the
+// default transport is used.
+func newS3Replicator(cfg *S3Config,
+	transport http.RoundTripper) (*S3Replicator, error) {
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid S3 endpoint scheme %q, must "+
+			"be http or https", endpoint.Scheme)
+	}
+	if strings.Trim(endpoint.Path, "/") != "" {
+		return nil, fmt.Errorf("S3 endpoint must not have a path")
+	}
+
+	switch {
+	case cfg.Region == "":
+		return nil, fmt.Errorf("S3 region must be set")
+
+	case cfg.Bucket == "":
+		return nil, fmt.Errorf("S3 bucket must be set")
+
+	case strings.TrimPrefix(cfg.Key, "/") == "":
+		return nil, fmt.Errorf("S3 key must be set")
+
+	case cfg.AccessKeyID == "" || cfg.SecretAccessKey == "":
+		return nil, fmt.Errorf("S3 access key must be set")
+	}
+
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds: credentials.NewStaticV4(
+			cfg.AccessKeyID, cfg.SecretAccessKey, "",
+		),
+		Secure:       endpoint.Scheme == "https",
+		Region:       cfg.Region,
+		BucketLookup: minio.BucketLookupPath,
+		Transport:    transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create S3 client: %w", err)
+	}
+
+	return &S3Replicator{
+		cfg:    cfg,
+		key:    strings.TrimPrefix(cfg.Key, "/"),
+		client: client,
+	}, nil
+}
+
+// Name returns a human-readable description of the target.
+//
+// NOTE: This is part of the Replicator interface.
+func (s *S3Replicator) Name() string {
+	return fmt.Sprintf("S3 object %v/%v/%v", s.cfg.Endpoint, s.cfg.Bucket,
+		s.key)
+}
+
+// Replicate uploads the backup to the S3 object, replacing the prior backup,
+// and verifies that the stored object matches the backup.
+//
+// NOTE: This is part of the Replicator interface.
+func (s *S3Replicator) Replicate(ctx context.Context,
+	backup PackedMulti) error {
+
+	_, err := s.client.PutObject(
+		ctx, s.cfg.Bucket, s.key, bytes.NewReader(backup),
+		int64(len(backup)), minio.PutObjectOptions{
+			ContentType:    "application/octet-stream",
+			SendContentMd5: true,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	object, err := s.client.GetObject(
+		ctx, s.cfg.Bucket, s.key, minio.GetObjectOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to read back object: %w", err)
+	}
+	defer object.Close()
+
+	stored, err := io.ReadAll(object)
+	if err != nil {
+		return fmt.Errorf("unable to read back object: %w", err)
+	}
+	if sha256.Sum256(stored) != sha256.Sum256(backup) {
+		return fmt.Errorf("%w: object holds %d bytes, expected %d",
+			ErrReplicaMismatch, len(stored), len(backup))
+	}
+
+	return nil
+}
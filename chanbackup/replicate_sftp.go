@@ -0,0 +1,210 @@
+package chanbackup
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// sftpPosixRename is the name of the OpenSSH extension that renames a
+	// file, replacing the target if it exists.
+	sftpPosixRename = "posix-rename@openssh.com"
+
+	// sftpBackupPermission is the file mode of the backup on the server.
+	sftpBackupPermission = 0600
+)
+
+// SFTPConfig holds the configuration of an SFTPReplicator.
+type SFTPConfig struct {
+	// Addr is the host:port of the SSH server.
+	Addr string
+
+	// User is the name of the user to log in as.
+	User string
+
+	// PrivateKeyPath is the path of the private key used to authenticate
+	// with the server.
+	PrivateKeyPath string
+
+	// KnownHostsPath is the path of the known_hosts file used to
+	// authenticate the server.
+	KnownHostsPath string
+
+	// Path is the remote path the backup is stored at.
+	Path string
+}
+
+// SFTPReplicator replicates backups to a file on an SFTP server. The backup is
+// first written to a temporary file, which is read back and compared against
+// the backup before it is renamed to the target path. This ensures that the
+// target path always holds a complete backup.
+type SFTPReplicator struct {
+	cfg *SFTPConfig
+
+	sshCfg *ssh.ClientConfig
+}
+
+// A compile-time check to ensure SFTPReplicator implements the Replicator
+// interface.
+var _ Replicator = (*SFTPReplicator)(nil)
+
+// NewSFTPReplicator creates a new SFTPReplicator from the given config. The
+// private key and the known_hosts file are loaded right away.
+func NewSFTPReplicator(cfg *SFTPConfig) (*SFTPReplicator, error) {
+	if cfg.Addr == "" || cfg.User == "" || cfg.Path == "" {
+		return nil, fmt.Errorf("SFTP address, user and path must be " +
+			"set")
+	}
+
+	keyBytes, err := os.ReadFile(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read SFTP private key: %w",
+			err)
+	}
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse SFTP private key: %w",
+			err)
+	}
+
+	hostKeyCallback, err := knownhosts.New(cfg.KnownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SFTP known hosts: %w",
+			err)
+	}
+
+	return &SFTPReplicator{
+		cfg: cfg,
+		sshCfg: &ssh.ClientConfig{
+			User: cfg.User,
+			Auth: []ssh.AuthMethod{
+				ssh.PublicKeys(signer),
+			},
+			HostKeyCallback: hostKeyCallback,
+		},
+	}, nil
+}
+
+// Name returns a human-readable description of the target.
+//
+// NOTE: This is part of the Replicator interface.
+func (s *SFTPReplicator) Name() string {
+	return fmt.Sprintf("sftp://%s@%s%s", s.cfg.User, s.cfg.Addr,
+		s.cfg.Path)
+}
+
+// Replicate uploads the backup to the SFTP server, replacing the prior backup.
+//
+// NOTE: This is part of the Replicator interface.
+func (s *SFTPReplicator) Replicate(ctx context.Context,
+	backup PackedMulti) error {
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Tear down the connection if the context is canceled, which unblocks
+	// any pending reads or writes.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(
+		conn, s.cfg.Addr, s.sshCfg,
+	)
+	if err != nil {
+		return err
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+
+	sftpClient, err := sftp.NewClient(client)
+	if err != nil {
+		return err
+	}
+	defer sftpClient.Close()
+
+	return replicateSFTP(sftpClient, s.cfg.Path, backup)
+}
+
+// replicateSFTP writes the backup to a temporary file next to the given path,
+// verifies it, and then renames it to the given path.
+func replicateSFTP(c *sftp.Client, path string, backup PackedMulti) error {
+	tempPath := path + ".tmp"
+	if err := writeSFTPFile(c, tempPath, backup); err != nil {
+		return fmt.Errorf("unable to write %v: %w", tempPath, err)
+	}
+
+	stored, err := readSFTPFile(c, tempPath)
+	if err != nil {
+		return fmt.Errorf("unable to read back %v: %w", tempPath, err)
+	}
+	if sha256.Sum256(stored) != sha256.Sum256(backup) {
+		return fmt.Errorf("%w: %v holds %d bytes, expected %d",
+			ErrReplicaMismatch, tempPath, len(stored), len(backup))
+	}
+
+	// Prefer the POSIX rename extension, which atomically replaces the
+	// prior backup. Otherwise we need to remove the prior backup first,
+	// as a plain SFTP rename fails if the target exists.
+	if _, ok := c.HasExtension(sftpPosixRename); ok {
+		return c.PosixRename(tempPath, path)
+	}
+
+	err = c.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return c.Rename(tempPath, path)
+}
+
+// writeSFTPFile creates or truncates the file at the given path and writes
+// the data to it.
+func writeSFTPFile(c *sftp.Client, path string, data []byte) error {
+	f, err := c.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Chmod(sftpBackupPermission); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// readSFTPFile reads the full content of the file at the given path.
+func readSFTPFile(c *sftp.Client, path string) ([]byte, error) {
+	f, err := c.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
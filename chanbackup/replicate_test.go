@@ -0,0 +1,427 @@
+package chanbackup
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/require"
+)
+
+// mockReplicator is a Replicator that fails a configurable number of times
+// before it accepts backups.
+type mockReplicator struct {
+	mu sync.Mutex
+
+	// failures is the number of attempts that still fail.
+	failures int
+
+	// attempts is the number of attempts made so far.
+	attempts int
+
+	replicated chan PackedMulti
+}
+
+func (m *mockReplicator) Name() string {
+	return "mock"
+}
+
+func (m *mockReplicator) Replicate(_ context.Context,
+	backup PackedMulti) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.attempts++
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("target unavailable")
+	}
+
+	m.replicated <- backup
+
+	return nil
+}
+
+// TestReplicatingSwapper tests that backups swapped in are written to the
+// backup file and replicated to all targets, and that failed replications are
+// retried.
+func TestReplicatingSwapper(t *testing.T) {
+	t.Parallel()
+
+	backupFile := NewMultiFile(
+		filepath.Join(t.TempDir(), DefaultBackupFileName),
+	)
+
+	healthy := &mockReplicator{replicated: make(chan PackedMulti, 10)}
+	flaky := &mockReplicator{
+		failures:   2,
+		replicated: make(chan PackedMulti, 10),
+	}
+
+	swapper := NewReplicatingSwapper(backupFile, &ReplicationConfig{
+		Replicators: []Replicator{healthy, flaky},
+		Timeout:     time.Second,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	})
+	require.NoError(t, swapper.Start())
+	t.Cleanup(func() {
+		require.NoError(t, swapper.Stop())
+	})
+
+	backup := PackedMulti("backup")
+	require.NoError(t, swapper.UpdateAndSwap(backup))
+
+	onDisk, err := os.ReadFile(backupFile.fileName)
+	require.NoError(t, err)
+	require.Equal(t, []byte(backup), onDisk)
+
+	for _, replicator := range []*mockReplicator{healthy, flaky} {
+		select {
+		case replicated := <-replicator.replicated:
+			require.Equal(t, backup, replicated)
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("backup not replicated")
+		}
+	}
+
+	flaky.mu.Lock()
+	require.Equal(t, 3, flaky.attempts)
+	flaky.mu.Unlock()
+}
+
+// TestReplicatingSwapperSupersede tests that only the latest backup is
+// replicated if the backup is updated while a replication is pending.
+func TestReplicatingSwapperSupersede(t *testing.T) {
+	t.Parallel()
+
+	backupFile := NewMultiFile(
+		filepath.Join(t.TempDir(), DefaultBackupFileName),
+	)
+
+	// The target fails until we make it healthy, and the backoff is long
+	// enough for the second backup to arrive while we wait to retry.
+	replicator := &mockReplicator{
+		failures:   1,
+		replicated: make(chan PackedMulti, 10),
+	}
+	swapper := NewReplicatingSwapper(backupFile, &ReplicationConfig{
+		Replicators: []Replicator{replicator},
+		Timeout:     time.Second,
+		MinBackoff:  time.Hour,
+		MaxBackoff:  time.Hour,
+	})
+	require.NoError(t, swapper.Start())
+	t.Cleanup(func() {
+		require.NoError(t, swapper.Stop())
+	})
+
+	require.NoError(t, swapper.UpdateAndSwap(PackedMulti("old")))
+	require.Eventually(t, func() bool {
+		replicator.mu.Lock()
+		defer replicator.mu.Unlock()
+
+		return replicator.attempts == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The new backup is replicated right away, without waiting for the
+	// backoff to expire, and the old one is never replicated.
+	require.NoError(t, swapper.UpdateAndSwap(PackedMulti("new")))
+	select {
+	case replicated := <-replicator.replicated:
+		require.Equal(t, PackedMulti("new"), replicated)
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("backup not replicated")
+	}
+}
+
+// TestHTTPReplicator tests that the HTTP replicator sends the backup together
+// with its digest, and verifies the digest echoed by the webhook.
+func TestHTTPReplicator(t *testing.T) {
+	t.Parallel()
+
+	backup := PackedMulti("backup")
+	digest := sha256.Sum256(backup)
+
+	var (
+		received []byte
+		echo     string
+		status   = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			require.Equal(t, "secret", r.Header.Get("Auth"))
+			require.Equal(
+				t, hex.EncodeToString(digest[:]),
+				r.Header.Get(BackupDigestHeader),
+			)
+
+			var err error
+			received, err = io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			if echo != "" {
+				w.Header().Set(BackupDigestHeader, echo)
+			}
+			w.WriteHeader(status)
+		},
+	))
+	t.Cleanup(server.Close)
+
+	_, err := NewHTTPReplicator("ftp://example.com", nil)
+	require.ErrorContains(t, err, "scheme")
+
+	replicator, err := NewHTTPReplicator(
+		server.URL+"/backup", http.Header{"Auth": {"secret"}},
+	)
+	require.NoError(t, err)
+
+	// A webhook that doesn't echo the digest isn't trusted.
+	ctx := context.Background()
+	err = replicator.Replicate(ctx, backup)
+	require.ErrorIs(t, err, ErrReplicaMismatch)
+	require.Equal(t, []byte(backup), received)
+
+	// A matching digest is accepted.
+	echo = hex.EncodeToString(digest[:])
+	require.NoError(t, replicator.Replicate(ctx, backup))
+
+	// A digest that doesn't match is rejected.
+	wrongDigest := sha256.Sum256([]byte("other"))
+	echo = hex.EncodeToString(wrongDigest[:])
+	err = replicator.Replicate(ctx, backup)
+	require.ErrorIs(t, err, ErrReplicaMismatch)
+
+	// So is a response with an error status.
+	echo = ""
+	status = http.StatusInternalServerError
+	err = replicator.Replicate(ctx, backup)
+	require.ErrorContains(t, err, "500")
+}
+
+// TestS3Replicator tests that the S3 replicator uploads the backup to the
+// object with its MD5 digest, and verifies the stored object.
+func TestS3Replicator(t *testing.T) {
+	t.Parallel()
+
+	backup := PackedMulti("backup")
+	md5Digest := md5.Sum(backup) //nolint:gosec
+
+	var (
+		mu     sync.Mutex
+		stored []byte
+
+		// corrupt causes the server to drop the last byte of every
+		// stored object.
+		corrupt bool
+	)
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			require.Equal(
+				t, "/bucket/lnd/channel.backup", r.URL.Path,
+			)
+			require.True(t, strings.HasPrefix(
+				r.Header.Get("Authorization"),
+				"AWS4-HMAC-SHA256 Credential=key-id/",
+			))
+
+			switch r.Method {
+			case http.MethodPut:
+				require.Equal(
+					t, base64.StdEncoding.EncodeToString(
+						md5Digest[:],
+					), r.Header.Get("Content-Md5"),
+				)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				stored = body
+				if corrupt {
+					stored = body[:len(body)-1]
+				}
+				w.Header().Set("ETag", `"etag"`)
+
+			case http.MethodGet:
+				w.Header().Set("ETag", `"etag"`)
+				modTime := time.Now().UTC()
+				w.Header().Set(
+					"Last-Modified",
+					modTime.Format(http.TimeFormat),
+				)
+				w.Header().Set(
+					"Content-Length",
+					strconv.Itoa(len(stored)),
+				)
+				_, err := w.Write(stored)
+				require.NoError(t, err)
+
+			default:
+				t.Errorf("unexpected method %v", r.Method)
+			}
+		},
+	))
+	t.Cleanup(server.Close)
+
+	_, err := NewS3Replicator(&S3Config{Endpoint: server.URL})
+	require.Error(t, err)
+
+	replicator, err := newS3Replicator(&S3Config{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "bucket",
+		Key:             "/lnd/channel.backup",
+		AccessKeyID:     "key-id",
+		SecretAccessKey: "secret",
+	}, server.Client().Transport)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, replicator.Replicate(ctx, backup))
+	require.Equal(t, []byte(backup), stored)
+
+	// An object that isn't stored intact is detected.
+	mu.Lock()
+	corrupt = true
+	mu.Unlock()
+	err = replicator.Replicate(ctx, backup)
+	require.ErrorIs(t, err, ErrReplicaMismatch)
+}
+
+// truncatingWriter is an io.WriterAt that drops the last byte of every write.
+type truncatingWriter struct {
+	io.WriterAt
+}
+
+// WriteAt writes all but the last byte of the given data.
+func (w *truncatingWriter) WriteAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if _, err := w.WriterAt.WriteAt(p[:len(p)-1], off); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// truncatingFilePut is an sftp.FileWriter that drops the last byte of every
+// write once truncate is set.
+type truncatingFilePut struct {
+	sftp.FileWriter
+
+	truncate atomic.Bool
+}
+
+// Filewrite returns a writer that drops the last byte of every write if
+// truncate is set.
+func (f *truncatingFilePut) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	w, err := f.FileWriter.Filewrite(r)
+	if err != nil || !f.truncate.Load() {
+		return w, err
+	}
+
+	return &truncatingWriter{WriterAt: w}, nil
+}
+
+// newSFTPTestClient creates an SFTP client that is connected to an in-memory
+// SFTP server with the given files. If truncateWrites is set, the server drops
+// the last byte of every write.
+func newSFTPTestClient(t *testing.T, files map[string][]byte,
+	truncateWrites bool) *sftp.Client {
+
+	handlers := sftp.InMemHandler()
+	filePut := &truncatingFilePut{FileWriter: handlers.FilePut}
+	handlers.FilePut = filePut
+
+	clientConn, serverConn := net.Pipe()
+	server := sftp.NewRequestServer(serverConn, handlers)
+	go func() {
+		_ = server.Serve()
+	}()
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+	})
+
+	client, err := sftp.NewClientPipe(clientConn, clientConn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for path, content := range files {
+		require.NoError(t, writeSFTPFile(client, path, content))
+	}
+	filePut.truncate.Store(truncateWrites)
+
+	return client
+}
+
+// readTestFile reads the file at the given path from the SFTP server.
+func readTestFile(t *testing.T, client *sftp.Client, path string) []byte {
+	content, err := readSFTPFile(client, path)
+	require.NoError(t, err)
+
+	return content
+}
+
+// TestSFTPReplicate tests that backups are written to a temporary file,
+// verified and then moved to the target path.
+func TestSFTPReplicate(t *testing.T) {
+	t.Parallel()
+
+	// The backup spans multiple packets to exercise chunked reads and
+	// writes.
+	backup := PackedMulti(bytes.Repeat([]byte{1, 2, 3}, 64*1024))
+	const path = "/channel.backup"
+
+	client := newSFTPTestClient(t, map[string][]byte{
+		path: []byte("old backup"),
+	}, false)
+
+	require.NoError(t, replicateSFTP(client, path, backup))
+	require.Equal(t, []byte(backup), readTestFile(t, client, path))
+
+	_, err := client.Stat(path + ".tmp")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// TestSFTPReplicateMismatch tests that a backup that isn't stored intact isn't
+// moved to the target path.
+func TestSFTPReplicateMismatch(t *testing.T) {
+	t.Parallel()
+
+	const path = "/channel.backup"
+	client := newSFTPTestClient(t, map[string][]byte{
+		path: []byte("old backup"),
+	}, true)
+
+	err := replicateSFTP(client, path, PackedMulti("backup"))
+	require.ErrorIs(t, err, ErrReplicaMismatch)
+	require.Equal(t, []byte("old backup"), readTestFile(t, client, path))
+}
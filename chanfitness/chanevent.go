@@ -230,6 +230,17 @@ func (p *peerLog) channelCount() int {
 	return len(p.channels)
 }
 
+// channelPoints returns the channel points of the channels that we currently
+// have with the peer.
+func (p *peerLog) channelPoints() []wire.OutPoint {
+	chanPoints := make([]wire.OutPoint, 0, len(p.channels))
+	for chanPoint := range p.channels {
+		chanPoints = append(chanPoints, chanPoint)
+	}
+
+	return chanPoints
+}
+
 // channelUptime looks up a channel and returns the amount of time that the
 // channel has been monitored for and its uptime over this period.
 func (p *peerLog) channelUptime(channelPoint wire.OutPoint) (time.Duration,
@@ -94,6 +94,10 @@ type Config struct {
 	// FlapCountTicker is a ticker which controls how often we flush our
 	// peer's flap count to disk.
 	FlapCountTicker ticker.Ticker
+
+	// NotifyFlappingChannel is called for every channel with a peer that
+	// went offline while it is considered to be flapping.
+	NotifyFlappingChannel func(wire.OutPoint, int)
 }
 
 // peerFlapCountMap is the map used to map peers to flap counts, declared here
@@ -290,7 +294,8 @@ func (c *ChannelEventStore) closeChannel(channelPoint wire.OutPoint,
 }
 
 // peerEvent creates a peer monitor for a peer if we do not currently have
-// one, and adds an online event to it.
+// one, and adds an online event to it. If a flapping peer went offline, the
+// channels with the peer are reported as flapping.
 func (c *ChannelEventStore) peerEvent(peer route.Vertex, online bool) {
 	peerMonitor, err := c.getPeerMonitor(peer)
 	if err != nil {
@@ -299,6 +304,22 @@ func (c *ChannelEventStore) peerEvent(peer route.Vertex, online bool) {
 	}
 
 	peerMonitor.onlineEvent(online)
+
+	if online {
+		return
+	}
+
+	flapCount, _ := peerMonitor.getFlapCount()
+	if !isFlapping(flapCount) {
+		return
+	}
+
+	for _, chanPoint := range peerMonitor.channelPoints() {
+		log.Debugf("Channel %v inactive due to flapping peer %v "+
+			"(flap count %d)", chanPoint, peer, flapCount)
+
+		c.cfg.NotifyFlappingChannel(chanPoint, flapCount)
+	}
 }
 
 // subscriptions abstracts away from subscription clients to allow for mocking.
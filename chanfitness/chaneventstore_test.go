@@ -221,6 +221,49 @@ func TestStoreFlapCount(t *testing.T) {
 	testCtx.stop()
 }
 
+// TestFlappingChannel tests that the channels of a flapping peer are reported
+// when the peer goes offline.
+func TestFlappingChannel(t *testing.T) {
+	testCtx := newChanEventStoreTestCtx(t)
+	testCtx.start()
+
+	// A peer with a low flap count going offline should not be reported.
+	stablePeer, _, _ := testCtx.createChannel()
+	testCtx.peerEvent(stablePeer, false)
+
+	// Give our next peer a historical flap count that places it in a rate
+	// limited tier before we create a channel with it.
+	flappingPeer, pubKey, chanPoint := testCtx.newChannel()
+	testCtx.flapUpdates[flappingPeer] = &channeldb.FlapCount{
+		Count:    rateLimitScale,
+		LastFlap: testNow,
+	}
+	testCtx.sendChannelOpenedUpdate(pubKey, chanPoint)
+
+	// Coming online should not be reported.
+	testCtx.peerEvent(flappingPeer, true)
+
+	// Going offline again should report our channel as flapping.
+	testCtx.peerEvent(flappingPeer, false)
+
+	select {
+	case op := <-testCtx.flappingChannels:
+		require.Equal(t, chanPoint, op)
+
+	case <-time.After(timeout):
+		t.Fatalf("flapping channel not reported")
+	}
+
+	select {
+	case op := <-testCtx.flappingChannels:
+		t.Fatalf("unexpected flapping channel: %v", op)
+
+	default:
+	}
+
+	testCtx.stop()
+}
+
 // TestGetChanInfo tests the GetChanInfo function for the cases where a channel
 // is known and unknown to the store.
 func TestGetChanInfo(t *testing.T) {
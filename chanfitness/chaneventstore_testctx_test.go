@@ -45,6 +45,10 @@ type chanEventStoreTestCtx struct {
 	// flapCountUpdates is a channel which receives new flap counts.
 	flapCountUpdates chan peerFlapCountMap
 
+	// flappingChannels receives the channels that are reported as
+	// flapping.
+	flappingChannels chan wire.OutPoint
+
 	// stopped is closed when our test context is fully shutdown. It is
 	// used to prevent calling of functions which can only be called after
 	// shutdown.
@@ -61,6 +65,7 @@ func newChanEventStoreTestCtx(t *testing.T) *chanEventStoreTestCtx {
 		clock:               clock.NewTestClock(testNow),
 		flapUpdates:         make(peerFlapCountMap),
 		flapCountUpdates:    make(chan peerFlapCountMap),
+		flappingChannels:    make(chan wire.OutPoint, 1),
 		stopped:             make(chan struct{}),
 	}
 
@@ -97,6 +102,9 @@ func newChanEventStoreTestCtx(t *testing.T) *chanEventStoreTestCtx {
 			return count, nil
 		},
 		FlapCountTicker: ticker.NewForce(FlapCountFlushRate),
+		NotifyFlappingChannel: func(op wire.OutPoint, _ int) {
+			testCtx.flappingChannels <- op
+		},
 	}
 
 	testCtx.store = NewChannelEventStore(cfg)
@@ -22,6 +22,10 @@ type peerMonitor interface {
 	// with the peer.
 	channelCount() int
 
+	// channelPoints returns the channel points of the channels that we
+	// currently have with the peer.
+	channelPoints() []wire.OutPoint
+
 	// channelUptime looks up a channel and returns the amount of time that
 	// the channel has been monitored for and its uptime over this period.
 	channelUptime(channelPoint wire.OutPoint) (time.Duration,
@@ -50,6 +50,13 @@ func getRateLimit(flapCount int) time.Duration {
 	return rateLimits[tier]
 }
 
+// isFlapping returns true if a peer with the given flap count has moved past
+// the first rate limited tier, meaning that it frequently disconnects and
+// reconnects.
+func isFlapping(flapCount int) bool {
+	return getRateLimit(flapCount) > rateLimits[0]
+}
+
 // cooldownFlapCount takes a timestamped flap count, and returns its value
 // scaled down by our cooldown factor if at least our cooldown period has
 // elapsed since the peer last flapped. We do this because we store all-time
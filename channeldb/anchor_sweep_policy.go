@@ -0,0 +1,289 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+var (
+	// anchorSweepPolicyBucket is the database bucket used to store the
+	// policies that are applied when the anchor of a force closed channel
+	// is swept to CPFP the commitment transaction. Policies can be set for
+	// a single channel, keyed by its channel point, or for all channels
+	// with a peer, keyed by the peer's public key.
+	//
+	// anchor-sweep-policy
+	//      |
+	//      |-- channel
+	//      |      |-- <chan-point>: <anchor sweep policy>
+	//      |
+	//      |-- peer
+	//             |-- <peer-pubkey>: <anchor sweep policy>
+	anchorSweepPolicyBucket = []byte("anchor-sweep-policy")
+
+	// anchorSweepChanPolicyBucket is the sub-bucket of
+	// anchorSweepPolicyBucket that stores the policies of single channels.
+	anchorSweepChanPolicyBucket = []byte("channel")
+
+	// anchorSweepPeerPolicyBucket is the sub-bucket of
+	// anchorSweepPolicyBucket that stores the policies of peers.
+	anchorSweepPeerPolicyBucket = []byte("peer")
+
+	// ErrNoAnchorSweepPolicy is returned when neither the channel nor its
+	// peer have an anchor sweep policy.
+	ErrNoAnchorSweepPolicy = errors.New("anchor sweep policy not found")
+)
+
+// AnchorSweepPolicy overrides the deadline and the budget that are used to
+// CPFP the commitment transaction of a force closed channel by sweeping its
+// anchor. A zero value of any of the fields means that the global default is
+// used.
+type AnchorSweepPolicy struct {
+	// MaxDeadline is the maximum number of blocks the commitment
+	// transaction is given to confirm. If it's smaller than the deadline
+	// derived from the HTLCs at stake, the sweeper will raise the fee rate
+	// of the CPFP more aggressively.
+	MaxDeadline uint32
+
+	// BudgetRatio is the ratio of the value at stake on the commitment
+	// transaction that is used as the budget of the CPFP.
+	BudgetRatio float64
+
+	// MaxBudget caps the budget of the CPFP.
+	MaxBudget btcutil.Amount
+
+	// MinValueAtStake is the minimum value of the time-sensitive HTLCs on
+	// the commitment transaction that justifies a CPFP. If less value is
+	// at stake, the anchor isn't swept.
+	MinValueAtStake btcutil.Amount
+}
+
+// IsEmpty returns true if the policy doesn't override any of the defaults.
+func (p AnchorSweepPolicy) IsEmpty() bool {
+	return p == AnchorSweepPolicy{}
+}
+
+// serializeAnchorSweepPolicy writes the given policy to the writer.
+func serializeAnchorSweepPolicy(w io.Writer, p AnchorSweepPolicy) error {
+	return WriteElements(
+		w, p.MaxDeadline, math.Float64bits(p.BudgetRatio), p.MaxBudget,
+		p.MinValueAtStake,
+	)
+}
+
+// deserializeAnchorSweepPolicy reads a policy from the reader.
+func deserializeAnchorSweepPolicy(r io.Reader) (AnchorSweepPolicy, error) {
+	var (
+		p           AnchorSweepPolicy
+		budgetRatio uint64
+	)
+	err := ReadElements(
+		r, &p.MaxDeadline, &budgetRatio, &p.MaxBudget,
+		&p.MinValueAtStake,
+	)
+	if err != nil {
+		return AnchorSweepPolicy{}, err
+	}
+	p.BudgetRatio = math.Float64frombits(budgetRatio)
+
+	return p, nil
+}
+
+// putAnchorSweepPolicy stores the policy under the given key of the given
+// sub-bucket, or removes the stored policy if it's empty.
+func (c *ChannelStateDB) putAnchorSweepPolicy(subBucket, key []byte,
+	policy AnchorSweepPolicy) error {
+
+	var value bytes.Buffer
+	if err := serializeAnchorSweepPolicy(&value, policy); err != nil {
+		return err
+	}
+
+	return kvdb.Update(c.backend, func(tx kvdb.RwTx) error {
+		topBucket, err := tx.CreateTopLevelBucket(
+			anchorSweepPolicyBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		bucket, err := topBucket.CreateBucketIfNotExists(subBucket)
+		if err != nil {
+			return err
+		}
+
+		if policy.IsEmpty() {
+			return bucket.Delete(key)
+		}
+
+		return bucket.Put(key, value.Bytes())
+	}, func() {})
+}
+
+// SetChannelAnchorSweepPolicy stores the anchor sweep policy of the channel
+// with the given channel point. An empty policy removes the stored policy of
+// the channel.
+func (c *ChannelStateDB) SetChannelAnchorSweepPolicy(chanPoint wire.OutPoint,
+	policy AnchorSweepPolicy) error {
+
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &chanPoint); err != nil {
+		return err
+	}
+
+	return c.putAnchorSweepPolicy(
+		anchorSweepChanPolicyBucket, key.Bytes(), policy,
+	)
+}
+
+// SetPeerAnchorSweepPolicy stores the anchor sweep policy of all channels with
+// the given peer that don't have a policy of their own. An empty policy
+// removes the stored policy of the peer.
+func (c *ChannelStateDB) SetPeerAnchorSweepPolicy(peer route.Vertex,
+	policy AnchorSweepPolicy) error {
+
+	return c.putAnchorSweepPolicy(
+		anchorSweepPeerPolicyBucket, peer[:], policy,
+	)
+}
+
+// FetchAnchorSweepPolicy returns the anchor sweep policy that applies to the
+// channel with the given channel point and peer. The policy of the channel
+// takes precedence over the policy of the peer. ErrNoAnchorSweepPolicy is
+// returned if neither of them has a policy.
+func (c *ChannelStateDB) FetchAnchorSweepPolicy(chanPoint wire.OutPoint,
+	peer route.Vertex) (AnchorSweepPolicy, error) {
+
+	var chanKey bytes.Buffer
+	if err := writeOutpoint(&chanKey, &chanPoint); err != nil {
+		return AnchorSweepPolicy{}, err
+	}
+
+	var policy AnchorSweepPolicy
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		topBucket := tx.ReadBucket(anchorSweepPolicyBucket)
+		if topBucket == nil {
+			return ErrNoAnchorSweepPolicy
+		}
+
+		var policyBytes []byte
+		chanBucket := topBucket.NestedReadBucket(
+			anchorSweepChanPolicyBucket,
+		)
+		if chanBucket != nil {
+			policyBytes = chanBucket.Get(chanKey.Bytes())
+		}
+
+		peerBucket := topBucket.NestedReadBucket(
+			anchorSweepPeerPolicyBucket,
+		)
+		if policyBytes == nil && peerBucket != nil {
+			policyBytes = peerBucket.Get(peer[:])
+		}
+
+		if policyBytes == nil {
+			return ErrNoAnchorSweepPolicy
+		}
+
+		var err error
+		policy, err = deserializeAnchorSweepPolicy(
+			bytes.NewReader(policyBytes),
+		)
+
+		return err
+	}, func() {
+		policy = AnchorSweepPolicy{}
+	})
+	if err != nil {
+		return AnchorSweepPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// FetchAllAnchorSweepPolicies returns the anchor sweep policies of all
+// channels and all peers that have one.
+func (c *ChannelStateDB) FetchAllAnchorSweepPolicies() (
+	map[wire.OutPoint]AnchorSweepPolicy, map[route.Vertex]AnchorSweepPolicy,
+	error) {
+
+	var (
+		chanPolicies map[wire.OutPoint]AnchorSweepPolicy
+		peerPolicies map[route.Vertex]AnchorSweepPolicy
+	)
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		topBucket := tx.ReadBucket(anchorSweepPolicyBucket)
+		if topBucket == nil {
+			return nil
+		}
+
+		chanBucket := topBucket.NestedReadBucket(
+			anchorSweepChanPolicyBucket,
+		)
+		if chanBucket != nil {
+			err := chanBucket.ForEach(func(k, v []byte) error {
+				var chanPoint wire.OutPoint
+				err := readOutpoint(
+					bytes.NewReader(k), &chanPoint,
+				)
+				if err != nil {
+					return err
+				}
+
+				policy, err := deserializeAnchorSweepPolicy(
+					bytes.NewReader(v),
+				)
+				if err != nil {
+					return err
+				}
+
+				chanPolicies[chanPoint] = policy
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		peerBucket := topBucket.NestedReadBucket(
+			anchorSweepPeerPolicyBucket,
+		)
+		if peerBucket == nil {
+			return nil
+		}
+
+		return peerBucket.ForEach(func(k, v []byte) error {
+			peer, err := route.NewVertexFromBytes(k)
+			if err != nil {
+				return err
+			}
+
+			policy, err := deserializeAnchorSweepPolicy(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			peerPolicies[peer] = policy
+
+			return nil
+		})
+	}, func() {
+		chanPolicies = make(map[wire.OutPoint]AnchorSweepPolicy)
+		peerPolicies = make(map[route.Vertex]AnchorSweepPolicy)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return chanPolicies, peerPolicies, nil
+}
@@ -0,0 +1,81 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnchorSweepPolicy tests that anchor sweep policies can be stored,
+// fetched and removed, and that the policy of a channel takes precedence over
+// the policy of its peer.
+func TestAnchorSweepPolicy(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	cdb := fullDB.ChannelStateDB()
+
+	chanPoint1 := wire.OutPoint{Index: 1}
+	chanPoint2 := wire.OutPoint{Index: 2}
+	peer := route.Vertex{1}
+
+	// Without any policies stored, nothing should be returned.
+	_, err = cdb.FetchAnchorSweepPolicy(chanPoint1, peer)
+	require.ErrorIs(t, err, ErrNoAnchorSweepPolicy)
+
+	chanPolicies, peerPolicies, err := cdb.FetchAllAnchorSweepPolicies()
+	require.NoError(t, err)
+	require.Empty(t, chanPolicies)
+	require.Empty(t, peerPolicies)
+
+	chanPolicy := AnchorSweepPolicy{
+		MaxDeadline: 6,
+		BudgetRatio: 0.8,
+		MaxBudget:   100_000,
+	}
+	peerPolicy := AnchorSweepPolicy{
+		BudgetRatio:     0.1,
+		MinValueAtStake: 50_000,
+	}
+
+	require.NoError(t, cdb.SetChannelAnchorSweepPolicy(
+		chanPoint1, chanPolicy,
+	))
+	require.NoError(t, cdb.SetPeerAnchorSweepPolicy(peer, peerPolicy))
+
+	// The channel policy takes precedence over the peer policy, which
+	// applies to all other channels with the peer.
+	policy, err := cdb.FetchAnchorSweepPolicy(chanPoint1, peer)
+	require.NoError(t, err)
+	require.Equal(t, chanPolicy, policy)
+
+	policy, err = cdb.FetchAnchorSweepPolicy(chanPoint2, peer)
+	require.NoError(t, err)
+	require.Equal(t, peerPolicy, policy)
+
+	_, err = cdb.FetchAnchorSweepPolicy(chanPoint2, route.Vertex{2})
+	require.ErrorIs(t, err, ErrNoAnchorSweepPolicy)
+
+	chanPolicies, peerPolicies, err = cdb.FetchAllAnchorSweepPolicies()
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]AnchorSweepPolicy{
+		chanPoint1: chanPolicy,
+	}, chanPolicies)
+	require.Equal(t, map[route.Vertex]AnchorSweepPolicy{
+		peer: peerPolicy,
+	}, peerPolicies)
+
+	// Setting an empty policy removes the policy of the channel, so the
+	// peer policy applies again.
+	require.NoError(t, cdb.SetChannelAnchorSweepPolicy(
+		chanPoint1, AnchorSweepPolicy{},
+	))
+
+	policy, err = cdb.FetchAnchorSweepPolicy(chanPoint1, peer)
+	require.NoError(t, err)
+	require.Equal(t, peerPolicy, policy)
+}
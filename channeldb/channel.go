@@ -245,6 +245,10 @@ const (
 	// A tlv type definition used to serialize and deserialize the
 	// Memo for the channel channel.
 	channelMemoType tlv.Type = 5
+
+	// A tlv type definition used to serialize and deserialize the custom
+	// channel data that auxiliary components attached to the channel.
+	customBlobType tlv.Type = 6
 )
 
 // indexStatus is an enum-like type that describes what state the
@@ -856,6 +860,12 @@ type OpenChannel struct {
 	// channel that will be useful to our future selves.
 	Memo []byte
 
+	// CustomBlob is an optional blob of custom channel data that was
+	// attached to the channel by auxiliary funding controllers during the
+	// funding flow. The blob is opaque to lnd and is handed back to the
+	// auxiliary components that understand it.
+	CustomBlob []byte
+
 	// TODO(roasbeef): eww
 	Db *ChannelStateDB
 
@@ -1278,6 +1288,10 @@ func (c *OpenChannel) MarkAsOpen(openLoc lnwire.ShortChannelID) error {
 	c.ShortChannelID = openLoc
 	c.Packager = NewChannelPackager(openLoc)
 
+	c.mirrorState("channel open", func(m ChannelStateMirror) error {
+		return m.PutChannel(c)
+	})
+
 	return nil
 }
 
@@ -1972,9 +1986,50 @@ func (c *OpenChannel) SyncPending(addr net.Addr, pendingHeight uint32) error {
 
 	c.FundingBroadcastHeight = pendingHeight
 
-	return kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
 		return syncNewChannel(tx, c, []net.Addr{addr})
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorState("new channel", func(m ChannelStateMirror) error {
+		if err := m.PutChannel(c); err != nil {
+			return err
+		}
+
+		// A restored channel doesn't have any commitments.
+		if c.hasChanStatus(ChanStatusRestored) {
+			return nil
+		}
+
+		err := m.PutCommitment(c, &c.LocalCommitment, true)
+		if err != nil {
+			return err
+		}
+
+		return m.PutCommitment(c, &c.RemoteCommitment, false)
+	})
+
+	return nil
+}
+
+// mirrorState hands a channel state write that was just committed to the
+// backend to the channel state mirror, if one is set. As the backend stays
+// authoritative, a failure to mirror the write is only logged.
+//
+// NOTE: The channel's mutex must be held when calling this method.
+func (c *OpenChannel) mirrorState(desc string,
+	write func(ChannelStateMirror) error) {
+
+	if c.Db == nil || c.Db.mirror == nil {
+		return
+	}
+
+	if err := write(c.Db.mirror); err != nil {
+		log.Errorf("Unable to mirror %v of ChannelPoint(%v): %v", desc,
+			c.FundingOutpoint, err)
+	}
 }
 
 // syncNewChannel will write the passed channel to disk, and also create a
@@ -2163,6 +2218,10 @@ func (c *OpenChannel) UpdateCommitment(newCommitment *ChannelCommitment,
 
 	c.LocalCommitment = *newCommitment
 
+	c.mirrorState("local commitment", func(m ChannelStateMirror) error {
+		return m.PutCommitment(c, newCommitment, true)
+	})
+
 	return finalHtlcs, nil
 }
 
@@ -2328,13 +2387,29 @@ type HTLC struct {
 	// HTLC. It is stored in the ExtraData field, which is used to store
 	// a TLV stream of additional information associated with the HTLC.
 	BlindingPoint lnwire.BlindingPointRecord
+
+	// PaymentPoint is the optional experimental point the HTLC is locked
+	// to if it is a point time locked contract.
+	//
+	// Note: this field is not a part of on-disk representation of the
+	// HTLC. It is stored in the ExtraData field alongside the blinding
+	// point.
+	PaymentPoint *lnwire.PaymentPoint
+
+	// CustomRecords is the set of custom records an overlay protocol
+	// attached to the HTLC.
+	//
+	// Note: this field is not a part of on-disk representation of the
+	// HTLC. It is stored in the ExtraData field alongside the blinding
+	// point.
+	CustomRecords lnwire.CustomRecords
 }
 
 // serializeExtraData encodes a TLV stream of extra data to be stored with a
 // HTLC. It uses the update_add_htlc TLV types, because this is where extra
-// data is passed with a HTLC. At present blinding points are the only extra
-// data that we will store, and the function is a no-op if a nil blinding
-// point is provided.
+// data is passed with a HTLC. At present blinding points, payment points and
+// custom records are the only extra data that we will store, and the function
+// is a no-op if none of them is provided.
 //
 // This function MUST be called to persist all HTLC values when they are
 // serialized.
@@ -2346,6 +2421,15 @@ func (h *HTLC) serializeExtraData() error {
 		records = append(records, &b)
 	})
 
+	if h.PaymentPoint != nil {
+		records = append(records, h.PaymentPoint)
+	}
+
+	if err := h.CustomRecords.Validate(); err != nil {
+		return err
+	}
+	records = append(records, h.CustomRecords.RecordProducers()...)
+
 	return h.ExtraData.PackRecords(records...)
 }
 
@@ -2359,8 +2443,11 @@ func (h *HTLC) deserializeExtraData() error {
 		return nil
 	}
 
+	var paymentPoint lnwire.PaymentPoint
 	blindingPoint := h.BlindingPoint.Zero()
-	tlvMap, err := h.ExtraData.ExtractRecords(&blindingPoint)
+	tlvMap, err := h.ExtraData.ExtractRecords(
+		&blindingPoint, &paymentPoint,
+	)
 	if err != nil {
 		return err
 	}
@@ -2369,6 +2456,13 @@ func (h *HTLC) deserializeExtraData() error {
 		h.BlindingPoint = tlv.SomeRecordT(blindingPoint)
 	}
 
+	paymentPointType := lnwire.ExperimentalPaymentPointType
+	if val, ok := tlvMap[paymentPointType]; ok && val == nil {
+		h.PaymentPoint = &paymentPoint
+	}
+
+	h.CustomRecords = lnwire.NewCustomRecordsFromTlvTypeMap(tlvMap)
+
 	return nil
 }
 
@@ -2747,7 +2841,7 @@ func (c *OpenChannel) AppendRemoteCommitChain(diff *CommitDiff) error {
 		return ErrNoRestoredChannelMutation
 	}
 
-	return kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
 		// First, we'll grab the writable bucket where this channel's
 		// data resides.
 		chanBucket, err := fetchChanBucketRw(
@@ -2807,6 +2901,26 @@ func (c *OpenChannel) AppendRemoteCommitChain(diff *CommitDiff) error {
 		}
 		return chanBucket.Put(commitDiffKey, b2.Bytes())
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorState("forwarding acks", func(m ChannelStateMirror) error {
+		if len(diff.AddAcks) > 0 {
+			err := m.AckAddHtlcs(c, diff.AddAcks...)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(diff.SettleFailAcks) == 0 {
+			return nil
+		}
+
+		return m.AckSettleFails(diff.SettleFailAcks...)
+	})
+
+	return nil
 }
 
 // RemoteCommitChainTip returns the "tip" of the current remote commitment
@@ -3117,8 +3231,31 @@ func (c *OpenChannel) AdvanceCommitChainTail(fwdPkg *FwdPkg,
 	// With the db transaction complete, we'll swap over the in-memory
 	// pointer of the new remote commitment, which was previously the tip
 	// of the commit chain.
+	revokedCommit := c.RemoteCommitment
 	c.RemoteCommitment = *newRemoteCommit
 
+	c.mirrorState("revoked commitment", func(m ChannelStateMirror) error {
+		err := m.PutCommitment(c, newRemoteCommit, false)
+		if err != nil {
+			return err
+		}
+
+		rl, err := newRevocationLog(
+			&revokedCommit, ourOutputIndex, theirOutputIndex,
+			c.Db.parent.noRevLogAmtData,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = m.PutRevocationLog(c, revokedCommit.CommitHeight, rl)
+		if err != nil {
+			return err
+		}
+
+		return m.PutFwdPkg(c, fwdPkg)
+	})
+
 	return nil
 }
 
@@ -3203,9 +3340,18 @@ func (c *OpenChannel) AckAddHtlcs(addRefs ...AddRef) error {
 	c.Lock()
 	defer c.Unlock()
 
-	return kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
 		return c.Packager.AckAddHtlcs(tx, addRefs...)
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorState("add acks", func(m ChannelStateMirror) error {
+		return m.AckAddHtlcs(c, addRefs...)
+	})
+
+	return nil
 }
 
 // AckSettleFails updates the SettleFailFilter containing any of the provided
@@ -3216,9 +3362,18 @@ func (c *OpenChannel) AckSettleFails(settleFailRefs ...SettleFailRef) error {
 	c.Lock()
 	defer c.Unlock()
 
-	return kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
 		return c.Packager.AckSettleFails(tx, settleFailRefs...)
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorState("settle/fail acks", func(m ChannelStateMirror) error {
+		return m.AckSettleFails(settleFailRefs...)
+	})
+
+	return nil
 }
 
 // SetFwdFilter atomically sets the forwarding filter for the forwarding package
@@ -3227,9 +3382,18 @@ func (c *OpenChannel) SetFwdFilter(height uint64, fwdFilter *PkgFilter) error {
 	c.Lock()
 	defer c.Unlock()
 
-	return kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
 		return c.Packager.SetFwdFilter(tx, height, fwdFilter)
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorState("forwarding filter", func(m ChannelStateMirror) error {
+		return m.SetFwdFilter(c, height, fwdFilter)
+	})
+
+	return nil
 }
 
 // RemoveFwdPkgs atomically removes forwarding packages specified by the remote
@@ -3241,7 +3405,7 @@ func (c *OpenChannel) RemoveFwdPkgs(heights ...uint64) error {
 	c.Lock()
 	defer c.Unlock()
 
-	return kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
 		for _, height := range heights {
 			err := c.Packager.RemovePkg(tx, height)
 			if err != nil {
@@ -3251,6 +3415,17 @@ func (c *OpenChannel) RemoveFwdPkgs(heights ...uint64) error {
 
 		return nil
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorState("forwarding package removal",
+		func(m ChannelStateMirror) error {
+			return m.RemoveFwdPkgs(c, heights...)
+		},
+	)
+
+	return nil
 }
 
 // revocationLogTailCommitHeight returns the commit height at the end of the
@@ -3509,7 +3684,7 @@ func (c *OpenChannel) CloseChannel(summary *ChannelCloseSummary,
 	c.Lock()
 	defer c.Unlock()
 
-	return kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.Db.backend, func(tx kvdb.RwTx) error {
 		openChanBucket := tx.ReadWriteBucket(openChannelBucket)
 		if openChanBucket == nil {
 			return ErrNoChanDBExists
@@ -3645,6 +3820,15 @@ func (c *OpenChannel) CloseChannel(summary *ChannelCloseSummary,
 			tx, chanPointBuf.Bytes(), summary, chanState,
 		)
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorState("channel close", func(m ChannelStateMirror) error {
+		return m.CloseChannel(c, summary)
+	})
+
+	return nil
 }
 
 // ChannelSnapshot is a frozen snapshot of the current channel state. A
@@ -4026,6 +4210,7 @@ func putChanInfo(chanBucket kvdb.RwBucket, channel *OpenChannel) error {
 		),
 		MakeScidRecord(realScidType, &channel.confirmedScid),
 		tlv.MakePrimitiveRecord(channelMemoType, &channel.Memo),
+		tlv.MakePrimitiveRecord(customBlobType, &channel.CustomBlob),
 	)
 	if err != nil {
 		return err
@@ -4221,11 +4406,13 @@ func fetchChanInfo(chanBucket kvdb.RBucket, channel *OpenChannel) error {
 		}
 	}
 
-	// Create balance fields in uint64, and Memo field as byte slice.
+	// Create balance fields in uint64, and Memo and custom blob fields as
+	// byte slices.
 	var (
 		localBalance  uint64
 		remoteBalance uint64
 		memo          []byte
+		customBlob    []byte
 	)
 
 	// Create the tlv stream.
@@ -4243,6 +4430,7 @@ func fetchChanInfo(chanBucket kvdb.RBucket, channel *OpenChannel) error {
 		),
 		MakeScidRecord(realScidType, &channel.confirmedScid),
 		tlv.MakePrimitiveRecord(channelMemoType, &memo),
+		tlv.MakePrimitiveRecord(customBlobType, &customBlob),
 	)
 	if err != nil {
 		return err
@@ -4261,6 +4449,11 @@ func fetchChanInfo(chanBucket kvdb.RBucket, channel *OpenChannel) error {
 		channel.Memo = memo
 	}
 
+	// Attach the custom blob if non-empty.
+	if len(customBlob) > 0 {
+		channel.CustomBlob = customBlob
+	}
+
 	channel.Packager = NewChannelPackager(channel.ShortChannelID)
 
 	// Finally, read the optional shutdown scripts.
@@ -4472,18 +4665,31 @@ type ShutdownInfo struct {
 	// LocalInitiator is true if we sent a Shutdown message before ever
 	// receiving a Shutdown message from the remote peer.
 	LocalInitiator tlv.RecordT[tlv.TlvType1, bool]
+
+	// CloseOutputs are the additional close outputs that we have included
+	// in any previous Shutdown message, which should also be included in
+	// any future re-sends of the Shutdown message.
+	CloseOutputs tlv.OptionalRecordT[tlv.TlvType2, lnwire.CloseOutputs]
 }
 
 // NewShutdownInfo constructs a new ShutdownInfo object.
 func NewShutdownInfo(deliveryScript lnwire.DeliveryAddress,
-	locallyInitiated bool) *ShutdownInfo {
+	locallyInitiated bool, closeOutputs lnwire.CloseOutputs) *ShutdownInfo {
 
-	return &ShutdownInfo{
+	info := &ShutdownInfo{
 		DeliveryScript: tlv.NewRecordT[tlv.TlvType0](deliveryScript),
 		LocalInitiator: tlv.NewPrimitiveRecord[tlv.TlvType1](
 			locallyInitiated,
 		),
 	}
+
+	if len(closeOutputs) != 0 {
+		info.CloseOutputs = tlv.SomeRecordT(
+			tlv.NewRecordT[tlv.TlvType2](closeOutputs),
+		)
+	}
+
+	return info
 }
 
 // encode serialises the ShutdownInfo to the given io.Writer.
@@ -4492,6 +4698,11 @@ func (s *ShutdownInfo) encode(w io.Writer) error {
 		s.DeliveryScript.Record(),
 		s.LocalInitiator.Record(),
 	}
+	s.CloseOutputs.WhenSome(
+		func(r tlv.RecordT[tlv.TlvType2, lnwire.CloseOutputs]) {
+			records = append(records, r.Record())
+		},
+	)
 
 	stream, err := tlv.NewStream(records...)
 	if err != nil {
@@ -4507,12 +4718,21 @@ func decodeShutdownInfo(b []byte) (*ShutdownInfo, error) {
 	tlvStream := lnwire.ExtraOpaqueData(b)
 
 	var info ShutdownInfo
+	closeOutputs := info.CloseOutputs.Zero()
 	records := []tlv.RecordProducer{
 		&info.DeliveryScript,
 		&info.LocalInitiator,
+		&closeOutputs,
+	}
+
+	typeMap, err := tlvStream.ExtractRecords(records...)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := tlvStream.ExtractRecords(records...)
+	if val, ok := typeMap[info.CloseOutputs.TlvType()]; ok && val == nil {
+		info.CloseOutputs = tlv.SomeRecordT(closeOutputs)
+	}
 
-	return &info, err
+	return &info, nil
 }
@@ -0,0 +1,295 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// channelTagsBucket is the database bucket used to store the tags of
+	// channels, which allow operators to organize channels in named
+	// groups. The tags are keyed by the channel point and kept after the
+	// channel is closed.
+	//
+	// channel-tags
+	//      |
+	//      |-- <chan-point>: <num tags><tag>...
+	channelTagsBucket = []byte("channel-tags")
+
+	// ErrInvalidChannelTag is returned when a channel tag is empty or
+	// exceeds the maximum tag length.
+	ErrInvalidChannelTag = errors.New("invalid channel tag")
+)
+
+// MaxChannelTagLength is the maximum length in bytes of a single channel tag.
+const MaxChannelTagLength = 64
+
+// ValidateChannelTag returns an error if the given tag can't be stored as a
+// channel tag.
+func ValidateChannelTag(tag string) error {
+	if len(tag) == 0 || len(tag) > MaxChannelTagLength {
+		return fmt.Errorf("%w: tag must be between 1 and %d bytes "+
+			"long, got %q", ErrInvalidChannelTag,
+			MaxChannelTagLength, tag)
+	}
+
+	return nil
+}
+
+// normalizeTags returns the given tags sorted and without duplicates.
+func normalizeTags(tags []string) []string {
+	set := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if _, ok := set[tag]; ok {
+			continue
+		}
+
+		set[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+
+	return normalized
+}
+
+// serializeChannelTags writes the given tags to the writer.
+func serializeChannelTags(w io.Writer, tags []string) error {
+	if err := WriteElement(w, uint16(len(tags))); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if err := WriteElement(w, []byte(tag)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deserializeChannelTags reads a set of tags from the reader.
+func deserializeChannelTags(r io.Reader) ([]string, error) {
+	var numTags uint16
+	if err := ReadElement(r, &numTags); err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, numTags)
+	for i := uint16(0); i < numTags; i++ {
+		var tag []byte
+		if err := ReadElement(r, &tag); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, string(tag))
+	}
+
+	return tags, nil
+}
+
+// SetChannelTags replaces the tags of the channel with the given channel
+// point. Setting an empty set of tags removes all tags of the channel.
+func (c *ChannelStateDB) SetChannelTags(chanPoint wire.OutPoint,
+	tags []string) error {
+
+	for _, tag := range tags {
+		if err := ValidateChannelTag(tag); err != nil {
+			return err
+		}
+	}
+
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &chanPoint); err != nil {
+		return err
+	}
+
+	tags = normalizeTags(tags)
+
+	var value bytes.Buffer
+	if err := serializeChannelTags(&value, tags); err != nil {
+		return err
+	}
+
+	return kvdb.Update(c.backend, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(channelTagsBucket)
+		if err != nil {
+			return err
+		}
+
+		if len(tags) == 0 {
+			return bucket.Delete(key.Bytes())
+		}
+
+		return bucket.Put(key.Bytes(), value.Bytes())
+	}, func() {})
+}
+
+// UpdateChannelTags atomically removes the given tags from and adds the given
+// tags to the tags of the channel with the given channel point. If replace is
+// set, all existing tags are removed before the new tags are added. The tags
+// of the channel after the update are returned.
+func (c *ChannelStateDB) UpdateChannelTags(chanPoint wire.OutPoint, addTags,
+	removeTags []string, replace bool) ([]string, error) {
+
+	for _, tag := range addTags {
+		if err := ValidateChannelTag(tag); err != nil {
+			return nil, err
+		}
+	}
+
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &chanPoint); err != nil {
+		return nil, err
+	}
+
+	removeSet := make(map[string]struct{}, len(removeTags))
+	for _, tag := range removeTags {
+		removeSet[tag] = struct{}{}
+	}
+
+	var tags []string
+	err := kvdb.Update(c.backend, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(channelTagsBucket)
+		if err != nil {
+			return err
+		}
+
+		var oldTags []string
+		tagBytes := bucket.Get(key.Bytes())
+		if tagBytes != nil && !replace {
+			oldTags, err = deserializeChannelTags(
+				bytes.NewReader(tagBytes),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, tag := range oldTags {
+			if _, ok := removeSet[tag]; !ok {
+				tags = append(tags, tag)
+			}
+		}
+		tags = normalizeTags(append(tags, addTags...))
+
+		if len(tags) == 0 {
+			return bucket.Delete(key.Bytes())
+		}
+
+		var value bytes.Buffer
+		if err := serializeChannelTags(&value, tags); err != nil {
+			return err
+		}
+
+		return bucket.Put(key.Bytes(), value.Bytes())
+	}, func() {
+		tags = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// FetchChannelTags returns the tags of the channel with the given channel
+// point. Nil is returned if the channel doesn't have any tags.
+func (c *ChannelStateDB) FetchChannelTags(
+	chanPoint wire.OutPoint) ([]string, error) {
+
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &chanPoint); err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(channelTagsBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		tagBytes := bucket.Get(key.Bytes())
+		if tagBytes == nil {
+			return nil
+		}
+
+		var err error
+		tags, err = deserializeChannelTags(bytes.NewReader(tagBytes))
+
+		return err
+	}, func() {
+		tags = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// FetchAllChannelTags returns the tags of all channels that have at least one
+// tag, keyed by their channel point.
+func (c *ChannelStateDB) FetchAllChannelTags() (map[wire.OutPoint][]string,
+	error) {
+
+	var allTags map[wire.OutPoint][]string
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(channelTagsBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			var chanPoint wire.OutPoint
+			err := readOutpoint(bytes.NewReader(k), &chanPoint)
+			if err != nil {
+				return err
+			}
+
+			tags, err := deserializeChannelTags(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			allTags[chanPoint] = tags
+
+			return nil
+		})
+	}, func() {
+		allTags = make(map[wire.OutPoint][]string)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allTags, nil
+}
+
+// FetchChannelsByTag returns the channel points of all channels that are
+// tagged with the given tag.
+func (c *ChannelStateDB) FetchChannelsByTag(tag string) ([]wire.OutPoint,
+	error) {
+
+	allTags, err := c.FetchAllChannelTags()
+	if err != nil {
+		return nil, err
+	}
+
+	var chanPoints []wire.OutPoint
+	for chanPoint, tags := range allTags {
+		idx := sort.SearchStrings(tags, tag)
+		if idx < len(tags) && tags[idx] == tag {
+			chanPoints = append(chanPoints, chanPoint)
+		}
+	}
+
+	return chanPoints, nil
+}
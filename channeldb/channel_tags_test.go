@@ -0,0 +1,144 @@
+package channeldb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChannelTags tests that channel tags can be set, fetched and removed, and
+// that channels can be looked up by their tags.
+func TestChannelTags(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	cdb := fullDB.ChannelStateDB()
+
+	chanPoint1 := wire.OutPoint{Index: 1}
+	chanPoint2 := wire.OutPoint{Index: 2}
+
+	// Without any tags stored, nothing should be returned.
+	tags, err := cdb.FetchChannelTags(chanPoint1)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	chanPoints, err := cdb.FetchChannelsByTag("sink")
+	require.NoError(t, err)
+	require.Empty(t, chanPoints)
+
+	// The tags should be stored sorted and without duplicates.
+	err = cdb.SetChannelTags(
+		chanPoint1, []string{"sink", "lsp", "sink"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, cdb.SetChannelTags(chanPoint2, []string{"sink"}))
+
+	tags, err = cdb.FetchChannelTags(chanPoint1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"lsp", "sink"}, tags)
+
+	chanPoints, err = cdb.FetchChannelsByTag("sink")
+	require.NoError(t, err)
+	require.ElementsMatch(
+		t, []wire.OutPoint{chanPoint1, chanPoint2}, chanPoints,
+	)
+
+	chanPoints, err = cdb.FetchChannelsByTag("lsp")
+	require.NoError(t, err)
+	require.Equal(t, []wire.OutPoint{chanPoint1}, chanPoints)
+
+	// Setting new tags replaces the old ones, and setting no tags
+	// removes them.
+	require.NoError(t, cdb.SetChannelTags(chanPoint1, []string{"source"}))
+	require.NoError(t, cdb.SetChannelTags(chanPoint2, nil))
+
+	allTags, err := cdb.FetchAllChannelTags()
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint][]string{
+		chanPoint1: {"source"},
+	}, allTags)
+}
+
+// TestChannelTagsValidation tests that invalid tags are rejected.
+func TestChannelTagsValidation(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	cdb := fullDB.ChannelStateDB()
+
+	chanPoint := wire.OutPoint{Index: 1}
+	tooLong := strings.Repeat("a", MaxChannelTagLength+1)
+
+	err = cdb.SetChannelTags(chanPoint, []string{"sink", ""})
+	require.ErrorIs(t, err, ErrInvalidChannelTag)
+
+	err = cdb.SetChannelTags(chanPoint, []string{tooLong})
+	require.ErrorIs(t, err, ErrInvalidChannelTag)
+
+	// Nothing should have been stored.
+	tags, err := cdb.FetchChannelTags(chanPoint)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	maxLen := strings.Repeat("a", MaxChannelTagLength)
+	require.NoError(t, cdb.SetChannelTags(chanPoint, []string{maxLen}))
+}
+
+// TestUpdateChannelTags tests that tags can be added to and removed from the
+// tags of a channel.
+func TestUpdateChannelTags(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	cdb := fullDB.ChannelStateDB()
+
+	chanPoint := wire.OutPoint{Index: 1}
+
+	tags, err := cdb.UpdateChannelTags(
+		chanPoint, []string{"sink", "lsp"}, nil, false,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"lsp", "sink"}, tags)
+
+	// Tags are removed before the new ones are added.
+	tags, err = cdb.UpdateChannelTags(
+		chanPoint, []string{"source", "lsp"}, []string{"sink", "lsp"},
+		false,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"lsp", "source"}, tags)
+
+	storedTags, err := cdb.FetchChannelTags(chanPoint)
+	require.NoError(t, err)
+	require.Equal(t, tags, storedTags)
+
+	// Replacing the tags discards all existing tags.
+	tags, err = cdb.UpdateChannelTags(
+		chanPoint, []string{"exchange"}, nil, true,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"exchange"}, tags)
+
+	// Invalid tags are rejected without modifying the stored tags.
+	_, err = cdb.UpdateChannelTags(chanPoint, []string{""}, nil, true)
+	require.ErrorIs(t, err, ErrInvalidChannelTag)
+
+	// Removing the last tag removes the channel from the bucket.
+	tags, err = cdb.UpdateChannelTags(
+		chanPoint, nil, []string{"exchange"}, false,
+	)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	allTags, err := cdb.FetchAllChannelTags()
+	require.NoError(t, err)
+	require.Empty(t, allTags)
+}
@@ -354,6 +354,7 @@ func createTestChannelState(t *testing.T, cdb *ChannelStateDB) *OpenChannel {
 		ThawHeight:              uint32(defaultPendingHeight),
 		InitialLocalBalance:     lnwire.MilliSatoshi(9000),
 		InitialRemoteBalance:    lnwire.MilliSatoshi(3000),
+		CustomBlob:              []byte{1, 2, 3},
 	}
 }
 
@@ -1201,8 +1202,11 @@ func testShutdownInfo(t *testing.T, locallyInitiated bool) {
 	// Construct a new delivery script and create a new ShutdownInfo object.
 	script := []byte{1, 3, 4, 5}
 
-	// Create a ShutdownInfo struct.
-	shutdownInfo := NewShutdownInfo(script, locallyInitiated)
+	// Create a ShutdownInfo struct with an additional close output.
+	closeOutputs := lnwire.CloseOutputs{
+		{Value: 50_000, PkScript: []byte{6, 7, 8}},
+	}
+	shutdownInfo := NewShutdownInfo(script, locallyInitiated, closeOutputs)
 
 	// Persist the shutdown info.
 	require.NoError(t, channel.MarkShutdownSent(shutdownInfo))
@@ -1216,6 +1220,10 @@ func testShutdownInfo(t *testing.T, locallyInitiated bool) {
 	info.WhenSome(func(info ShutdownInfo) {
 		require.EqualValues(t, script, info.DeliveryScript.Val)
 		require.Equal(t, locallyInitiated, info.LocalInitiator.Val)
+
+		require.Equal(
+			t, closeOutputs, info.CloseOutputs.UnwrapOrFailV(t),
+		)
 	})
 }
 
@@ -1622,6 +1630,30 @@ func TestHTLCsExtraData(t *testing.T) {
 		),
 	}
 
+	// Lock a htlc to a payment point.
+	paymentPointHTLC := HTLC{
+		Signature:     testSig.Serialize(),
+		Incoming:      true,
+		Amt:           10,
+		RHash:         key,
+		RefundTimeout: 1,
+		OnionBlob:     lnmock.MockOnion(),
+		PaymentPoint:  lnwire.NewPaymentPoint(pubKey),
+	}
+
+	// Attach custom records of an overlay protocol to a htlc.
+	customRecordsHTLC := HTLC{
+		Signature:     testSig.Serialize(),
+		Incoming:      false,
+		Amt:           10,
+		RHash:         key,
+		RefundTimeout: 1,
+		OnionBlob:     lnmock.MockOnion(),
+		CustomRecords: lnwire.CustomRecords{
+			lnwire.MinCustomRecordsTlvType + 1: []byte{1, 2, 3},
+		},
+	}
+
 	testCases := []struct {
 		name        string
 		htlcs       []HTLC
@@ -1645,6 +1677,25 @@ func TestHTLCsExtraData(t *testing.T) {
 				mockHtlc,
 			},
 		},
+		{
+			// A PTLC stored along with regular HTLCs.
+			name: "payment point",
+			htlcs: []HTLC{
+				mockHtlc,
+				paymentPointHTLC,
+				blindingPointHTLC,
+			},
+		},
+		{
+			// A HTLC with custom records next to other extra
+			// data.
+			name: "custom records",
+			htlcs: []HTLC{
+				customRecordsHTLC,
+				paymentPointHTLC,
+				mockHtlc,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
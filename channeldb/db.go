@@ -528,6 +528,17 @@ type ChannelStateDB struct {
 	// backend points to the actual backend holding the channel state
 	// database. This may be a real backend or a cache middleware.
 	backend kvdb.Backend
+
+	// mirror is an optional secondary store that receives a copy of all
+	// channel state writes after they've been committed to the backend.
+	mirror ChannelStateMirror
+}
+
+// SetChannelStateMirror sets the secondary store that all channel state
+// writes are mirrored to, which is used to dual-write the channel state to a
+// native SQL database. It must be called before any channels are loaded.
+func (c *ChannelStateDB) SetChannelStateMirror(mirror ChannelStateMirror) {
+	c.mirror = mirror
 }
 
 // GetParentDB returns the "main" channeldb.DB object that is the owner of this
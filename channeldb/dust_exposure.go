@@ -0,0 +1,129 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// dustExposurePolicyBucket is the database bucket used to store the
+	// dust exposure policies of our channels, keyed by the channel point.
+	//
+	// dust-exposure-policies
+	//      |
+	//      |-- <chan-point>: <max exposure><fee rate multiplier>
+	dustExposurePolicyBucket = []byte("dust-exposure-policies")
+)
+
+// DustExposurePolicy limits the total amount of dust HTLCs of a channel. As
+// dust HTLCs aren't materialized on the commitment transaction, their amount
+// goes to miners if the channel is force closed. At most one of the limits is
+// set. If neither is set, the global dust threshold applies to the channel.
+type DustExposurePolicy struct {
+	// MaxExposure is a fixed limit for the dust exposure of the channel.
+	MaxExposure lnwire.MilliSatoshi
+
+	// FeeRateMultiplier, if non-zero, limits the dust exposure of the
+	// channel to the current commitment fee rate in sat/kw multiplied by
+	// this value, in milli-satoshis. The limit therefore grows with the
+	// fee rate, as more HTLCs are trimmed to dust at higher fee rates.
+	FeeRateMultiplier uint64
+}
+
+// IsEmpty returns true if the policy doesn't limit the dust exposure, so that
+// the global dust threshold applies.
+func (p DustExposurePolicy) IsEmpty() bool {
+	return p.MaxExposure == 0 && p.FeeRateMultiplier == 0
+}
+
+// serializeDustExposurePolicy writes the given policy to the writer.
+func serializeDustExposurePolicy(w io.Writer,
+	policy DustExposurePolicy) error {
+
+	return WriteElements(w, policy.MaxExposure, policy.FeeRateMultiplier)
+}
+
+// deserializeDustExposurePolicy reads a policy from the reader.
+func deserializeDustExposurePolicy(r io.Reader) (DustExposurePolicy, error) {
+	var policy DustExposurePolicy
+	err := ReadElements(r, &policy.MaxExposure, &policy.FeeRateMultiplier)
+	if err != nil {
+		return DustExposurePolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// SetDustExposurePolicy stores the dust exposure policy of the channel with
+// the given channel point. An empty policy removes the stored policy of the
+// channel.
+func (c *ChannelStateDB) SetDustExposurePolicy(chanPoint wire.OutPoint,
+	policy DustExposurePolicy) error {
+
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &chanPoint); err != nil {
+		return err
+	}
+
+	var value bytes.Buffer
+	if err := serializeDustExposurePolicy(&value, policy); err != nil {
+		return err
+	}
+
+	return kvdb.Update(c.backend, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(dustExposurePolicyBucket)
+		if err != nil {
+			return err
+		}
+
+		if policy.IsEmpty() {
+			return bucket.Delete(key.Bytes())
+		}
+
+		return bucket.Put(key.Bytes(), value.Bytes())
+	}, func() {})
+}
+
+// FetchAllDustExposurePolicies returns the dust exposure policies of all
+// channels that have one, keyed by their channel point.
+func (c *ChannelStateDB) FetchAllDustExposurePolicies() (
+	map[wire.OutPoint]DustExposurePolicy, error) {
+
+	var policies map[wire.OutPoint]DustExposurePolicy
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(dustExposurePolicyBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			var chanPoint wire.OutPoint
+			err := readOutpoint(bytes.NewReader(k), &chanPoint)
+			if err != nil {
+				return err
+			}
+
+			policy, err := deserializeDustExposurePolicy(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			policies[chanPoint] = policy
+
+			return nil
+		})
+	}, func() {
+		policies = make(map[wire.OutPoint]DustExposurePolicy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,51 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDustExposurePolicies tests that dust exposure policies can be stored,
+// fetched and removed.
+func TestDustExposurePolicies(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	cdb := fullDB.ChannelStateDB()
+
+	// Without any policies stored, nothing should be returned.
+	policies, err := cdb.FetchAllDustExposurePolicies()
+	require.NoError(t, err)
+	require.Empty(t, policies)
+
+	chanPoint1 := wire.OutPoint{Index: 1}
+	chanPoint2 := wire.OutPoint{Index: 2}
+
+	policy1 := DustExposurePolicy{MaxExposure: 100_000_000}
+	policy2 := DustExposurePolicy{FeeRateMultiplier: 5_000}
+
+	require.NoError(t, cdb.SetDustExposurePolicy(chanPoint1, policy1))
+	require.NoError(t, cdb.SetDustExposurePolicy(chanPoint2, policy2))
+
+	policies, err = cdb.FetchAllDustExposurePolicies()
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]DustExposurePolicy{
+		chanPoint1: policy1,
+		chanPoint2: policy2,
+	}, policies)
+
+	// Setting an empty policy removes the policy of the channel.
+	require.NoError(t, cdb.SetDustExposurePolicy(
+		chanPoint2, DustExposurePolicy{},
+	))
+
+	policies, err = cdb.FetchAllDustExposurePolicies()
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]DustExposurePolicy{
+		chanPoint1: policy1,
+	}, policies)
+}
@@ -0,0 +1,142 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// forwardingLimitsBucket is the database bucket used to store the
+	// limits for HTLCs forwarded over our channels, keyed by the channel
+	// point.
+	//
+	// forwarding-limits
+	//      |
+	//      |-- <chan-point>: <max htlcs><max in flight><rate><interval>
+	forwardingLimitsBucket = []byte("forwarding-limits")
+)
+
+// ForwardingLimits restricts the HTLCs that are forwarded over a channel to
+// protect it against jamming and HTLC slot exhaustion. A zero value of any of
+// the limits means that the limit isn't enforced.
+type ForwardingLimits struct {
+	// MaxHtlcs is the maximum number of forwarded HTLCs that may be in
+	// flight on the channel at the same time.
+	MaxHtlcs uint32
+
+	// MaxInFlight is the maximum total amount of the forwarded HTLCs that
+	// may be in flight on the channel at the same time.
+	MaxInFlight lnwire.MilliSatoshi
+
+	// RateLimit is the maximum number of HTLCs that are forwarded over the
+	// channel within RateInterval.
+	RateLimit uint32
+
+	// RateInterval is the interval that RateLimit applies to.
+	RateInterval time.Duration
+}
+
+// IsEmpty returns true if none of the limits is enforced.
+func (l ForwardingLimits) IsEmpty() bool {
+	return l.MaxHtlcs == 0 && l.MaxInFlight == 0 && l.RateLimit == 0
+}
+
+// serializeForwardingLimits writes the given limits to the writer.
+func serializeForwardingLimits(w io.Writer, limits ForwardingLimits) error {
+	return WriteElements(
+		w, limits.MaxHtlcs, limits.MaxInFlight, limits.RateLimit,
+		uint64(limits.RateInterval),
+	)
+}
+
+// deserializeForwardingLimits reads a set of limits from the reader.
+func deserializeForwardingLimits(r io.Reader) (ForwardingLimits, error) {
+	var (
+		limits       ForwardingLimits
+		rateInterval uint64
+	)
+	err := ReadElements(
+		r, &limits.MaxHtlcs, &limits.MaxInFlight, &limits.RateLimit,
+		&rateInterval,
+	)
+	if err != nil {
+		return ForwardingLimits{}, err
+	}
+	limits.RateInterval = time.Duration(rateInterval)
+
+	return limits, nil
+}
+
+// SetForwardingLimits stores the forwarding limits of the channel with the
+// given channel point. Empty limits remove the stored limits of the channel.
+func (c *ChannelStateDB) SetForwardingLimits(chanPoint wire.OutPoint,
+	limits ForwardingLimits) error {
+
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &chanPoint); err != nil {
+		return err
+	}
+
+	var value bytes.Buffer
+	if err := serializeForwardingLimits(&value, limits); err != nil {
+		return err
+	}
+
+	return kvdb.Update(c.backend, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(forwardingLimitsBucket)
+		if err != nil {
+			return err
+		}
+
+		if limits.IsEmpty() {
+			return bucket.Delete(key.Bytes())
+		}
+
+		return bucket.Put(key.Bytes(), value.Bytes())
+	}, func() {})
+}
+
+// FetchAllForwardingLimits returns the forwarding limits of all channels that
+// have limits, keyed by their channel point.
+func (c *ChannelStateDB) FetchAllForwardingLimits() (
+	map[wire.OutPoint]ForwardingLimits, error) {
+
+	var allLimits map[wire.OutPoint]ForwardingLimits
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(forwardingLimitsBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			var chanPoint wire.OutPoint
+			err := readOutpoint(bytes.NewReader(k), &chanPoint)
+			if err != nil {
+				return err
+			}
+
+			limits, err := deserializeForwardingLimits(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			allLimits[chanPoint] = limits
+
+			return nil
+		})
+	}, func() {
+		allLimits = make(map[wire.OutPoint]ForwardingLimits)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allLimits, nil
+}
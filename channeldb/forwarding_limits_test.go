@@ -0,0 +1,57 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForwardingLimits tests that forwarding limits can be stored, fetched
+// and removed.
+func TestForwardingLimits(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	cdb := fullDB.ChannelStateDB()
+
+	// Without any limits stored, nothing should be returned.
+	allLimits, err := cdb.FetchAllForwardingLimits()
+	require.NoError(t, err)
+	require.Empty(t, allLimits)
+
+	chanPoint1 := wire.OutPoint{Index: 1}
+	chanPoint2 := wire.OutPoint{Index: 2}
+
+	limits1 := ForwardingLimits{
+		MaxHtlcs:     10,
+		MaxInFlight:  1_000_000,
+		RateLimit:    5,
+		RateInterval: time.Minute,
+	}
+	limits2 := ForwardingLimits{MaxHtlcs: 20}
+
+	require.NoError(t, cdb.SetForwardingLimits(chanPoint1, limits1))
+	require.NoError(t, cdb.SetForwardingLimits(chanPoint2, limits2))
+
+	allLimits, err = cdb.FetchAllForwardingLimits()
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]ForwardingLimits{
+		chanPoint1: limits1,
+		chanPoint2: limits2,
+	}, allLimits)
+
+	// Setting empty limits removes the limits of the channel.
+	require.NoError(t, cdb.SetForwardingLimits(
+		chanPoint2, ForwardingLimits{},
+	))
+
+	allLimits, err = cdb.FetchAllForwardingLimits()
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]ForwardingLimits{
+		chanPoint1: limits1,
+	}, allLimits)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/btcsuite/btcwallet/walletdb"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
 )
 
 var (
@@ -18,6 +19,15 @@ var (
 	// bucket is a timestamp (in nano seconds since the unix epoch), and
 	// the value a slice of a forwarding event for that timestamp.
 	forwardingLogBucket = []byte("circuit-fwd-log")
+
+	// forwardingLogExtBucket is the bucket that stores the additional
+	// information of the events in the forwarding log that doesn't fit
+	// into the fixed size encoding of an event. Each key is the timestamp
+	// of an event in the forwarding log bucket, and the value a TLV
+	// stream of the HTLC expiries and whether the forward failed. Events
+	// that were logged before the bucket was introduced don't have an
+	// entry.
+	forwardingLogExtBucket = []byte("circuit-fwd-log-ext")
 )
 
 const (
@@ -31,6 +41,18 @@ const (
 	// total fee extract from a forwarding event.
 	forwardingEventSize = 32
 
+	// fwdEventIncomingExpiryType is the TLV type of the incoming HTLC
+	// expiry of a forwarding event.
+	fwdEventIncomingExpiryType tlv.Type = 0
+
+	// fwdEventOutgoingExpiryType is the TLV type of the outgoing HTLC
+	// expiry of a forwarding event.
+	fwdEventOutgoingExpiryType tlv.Type = 1
+
+	// fwdEventFailedType is the TLV type of the flag that marks a failed
+	// forwarding event.
+	fwdEventFailedType tlv.Type = 2
+
 	// MaxResponseEvents is the max number of forwarding events that will
 	// be returned by a single query response. This size was selected to
 	// safely remain under gRPC's 4MiB message size response limit. As each
@@ -60,7 +82,7 @@ type ForwardingLog struct {
 // ForwardingEvent is an event in the forwarding log's time series. Each
 // forwarding event logs the creation and tear-down of a payment circuit. A
 // circuit is created once an incoming HTLC has been fully forwarded, and
-// destroyed once the payment has been settled.
+// destroyed once the payment has been settled or failed.
 type ForwardingEvent struct {
 	// Timestamp is the settlement time of this payment circuit.
 	Timestamp time.Time
@@ -78,6 +100,67 @@ type ForwardingEvent struct {
 	// AmtOut is the amount of the outgoing HTLC. Subtracting the incoming
 	// amount from this gives the total fees for this payment circuit.
 	AmtOut lnwire.MilliSatoshi
+
+	// IncomingExpiry is the expiry height of the incoming HTLC. It is zero
+	// if it isn't known.
+	IncomingExpiry uint32
+
+	// OutgoingExpiry is the expiry height of the outgoing HTLC. It is zero
+	// if it isn't known.
+	OutgoingExpiry uint32
+
+	// Failed is true if the outgoing HTLC failed instead of being settled.
+	Failed bool
+}
+
+// encodeForwardingEventExt writes the additional information of the
+// forwarding event that is stored in the extension bucket to the passed
+// io.Writer as a TLV stream.
+func encodeForwardingEventExt(w io.Writer, f *ForwardingEvent) error {
+	var failed uint8
+	if f.Failed {
+		failed = 1
+	}
+
+	stream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(
+			fwdEventIncomingExpiryType, &f.IncomingExpiry,
+		),
+		tlv.MakePrimitiveRecord(
+			fwdEventOutgoingExpiryType, &f.OutgoingExpiry,
+		),
+		tlv.MakePrimitiveRecord(fwdEventFailedType, &failed),
+	)
+	if err != nil {
+		return err
+	}
+
+	return stream.Encode(w)
+}
+
+// decodeForwardingEventExt reads the additional information of the forwarding
+// event from the TLV stream in the passed io.Reader.
+func decodeForwardingEventExt(r io.Reader, f *ForwardingEvent) error {
+	var failed uint8
+	stream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(
+			fwdEventIncomingExpiryType, &f.IncomingExpiry,
+		),
+		tlv.MakePrimitiveRecord(
+			fwdEventOutgoingExpiryType, &f.OutgoingExpiry,
+		),
+		tlv.MakePrimitiveRecord(fwdEventFailedType, &failed),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Decode(r); err != nil {
+		return err
+	}
+	f.Failed = failed == 1
+
+	return nil
 }
 
 // encodeForwardingEvent writes out the target forwarding event to the passed
@@ -121,10 +204,19 @@ func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 			return err
 		}
 
-		// With the bucket obtained, we can now begin to write out the
+		extBucket, err := tx.CreateTopLevelBucket(
+			forwardingLogExtBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		// With the buckets obtained, we can now begin to write out the
 		// series of events.
 		for _, event := range events {
-			err := storeEvent(logBucket, event, timestamp[:])
+			err := storeEvent(
+				logBucket, extBucket, event, timestamp[:],
+			)
 			if err != nil {
 				return err
 			}
@@ -137,9 +229,10 @@ func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 // storeEvent tries to store a forwarding event into the given bucket by trying
 // to avoid collisions. If a key for the event timestamp already exists in the
 // database, the timestamp is incremented in nanosecond intervals until a "free"
-// slot is found.
-func storeEvent(bucket walletdb.ReadWriteBucket, event ForwardingEvent,
-	timestampScratchSpace []byte) error {
+// slot is found. The additional information of the event is stored in the
+// extension bucket under the same key.
+func storeEvent(bucket, extBucket walletdb.ReadWriteBucket,
+	event ForwardingEvent, timestampScratchSpace []byte) error {
 
 	// First, we'll serialize this timestamp into our
 	// timestamp buffer.
@@ -180,7 +273,17 @@ func storeEvent(bucket walletdb.ReadWriteBucket, event ForwardingEvent,
 	if err != nil {
 		return err
 	}
-	return bucket.Put(timestampScratchSpace, eventBuf.Bytes())
+	err = bucket.Put(timestampScratchSpace, eventBuf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	var extBuf bytes.Buffer
+	if err := encodeForwardingEventExt(&extBuf, &event); err != nil {
+		return err
+	}
+
+	return extBucket.Put(timestampScratchSpace, extBuf.Bytes())
 }
 
 // ForwardingEventQuery represents a query to the forwarding log payment
@@ -200,6 +303,38 @@ type ForwardingEventQuery struct {
 
 	// NumMaxEvents is the max number of events to return.
 	NumMaxEvents uint32
+
+	// IncludeFailures indicates whether failed forwarding events should
+	// be returned. If false, only settled forwards are returned. Note
+	// that the index offset only counts the events that match the query.
+	IncludeFailures bool
+
+	// ChanIDs optionally restricts the query to events whose incoming or
+	// outgoing channel is contained in the set. If empty, events of all
+	// channels are returned. Like failed events, events that don't match
+	// the set aren't counted towards the index offset.
+	ChanIDs map[lnwire.ShortChannelID]struct{}
+}
+
+// matchesChannels returns true if the query has no channel filter, or if any
+// of the given events has an incoming or outgoing channel in the filter set.
+func (q *ForwardingEventQuery) matchesChannels(
+	events []ForwardingEvent) bool {
+
+	if len(q.ChanIDs) == 0 {
+		return true
+	}
+
+	for _, event := range events {
+		if _, ok := q.ChanIDs[event.IncomingChanID]; ok {
+			return true
+		}
+		if _, ok := q.ChanIDs[event.OutgoingChanID]; ok {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ForwardingLogTimeSlice is the response to a forwarding query. It includes
@@ -244,6 +379,10 @@ func (f *ForwardingLog) Query(q ForwardingEventQuery) (ForwardingLogTimeSlice, e
 			return ErrNoForwardingEvents
 		}
 
+		// The extension bucket doesn't exist if no events were logged
+		// since it was introduced.
+		extBucket := tx.ReadBucket(forwardingLogExtBucket)
+
 		// We'll be using a cursor to seek into the database, so we'll
 		// populate byte slices that represent the start of the key
 		// space we're interested in, and the end.
@@ -264,10 +403,23 @@ func (f *ForwardingLog) Query(q ForwardingEventQuery) (ForwardingLogTimeSlice, e
 				return nil
 			}
 
-			// If we're not yet past the user defined offset, then
-			// we'll continue to seek forward.
-			if recordsToSkip > 0 {
-				recordsToSkip--
+			var ext []byte
+			if extBucket != nil {
+				ext = extBucket.Get(timestamp)
+			}
+
+			// Failed events don't match the query unless they were
+			// requested, so they aren't counted towards the offset.
+			var extEvent ForwardingEvent
+			if ext != nil {
+				err := decodeForwardingEventExt(
+					bytes.NewReader(ext), &extEvent,
+				)
+				if err != nil {
+					return err
+				}
+			}
+			if extEvent.Failed && !q.IncludeFailures {
 				continue
 			}
 
@@ -275,23 +427,40 @@ func (f *ForwardingLog) Query(q ForwardingEventQuery) (ForwardingLogTimeSlice, e
 				0, int64(byteOrder.Uint64(timestamp)),
 			)
 
-			// At this point, we've skipped enough records to start
-			// to collate our query. For each record, we'll
-			// increment the final record offset so the querier can
-			// utilize pagination to seek further.
+			// We decode the events of the record before skipping
+			// it, as the channel filter needs to be applied before
+			// the offset.
+			var recordEvents []ForwardingEvent
 			readBuf := bytes.NewReader(events)
 			for readBuf.Len() != 0 {
-				var event ForwardingEvent
+				event := extEvent
 				err := decodeForwardingEvent(readBuf, &event)
 				if err != nil {
 					return err
 				}
 
 				event.Timestamp = currentTime
-				resp.ForwardingEvents = append(resp.ForwardingEvents, event)
+				recordEvents = append(recordEvents, event)
+			}
+			if !q.matchesChannels(recordEvents) {
+				continue
+			}
 
-				recordOffset++
+			// If we're not yet past the user defined offset, then
+			// we'll continue to seek forward.
+			if recordsToSkip > 0 {
+				recordsToSkip--
+				continue
 			}
+
+			// At this point, we've skipped enough records to start
+			// to collate our query. For each record, we'll
+			// increment the final record offset so the querier can
+			// utilize pagination to seek further.
+			resp.ForwardingEvents = append(
+				resp.ForwardingEvents, recordEvents...,
+			)
+			recordOffset += uint32(len(recordEvents))
 		}
 
 		return nil
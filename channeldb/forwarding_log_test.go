@@ -360,3 +360,128 @@ func TestForwardingLogStoreEvent(t *testing.T) {
 		}
 	}
 }
+
+// TestForwardingLogFailures tests that the expiries of forwarding events are
+// stored and that failed events are only returned if they're requested.
+// It also tests that the events can be filtered by channel.
+func TestForwardingLogFailures(t *testing.T) {
+	t.Parallel()
+
+	db, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to make test db")
+
+	log := ForwardingLog{
+		db: db,
+	}
+
+	events := makeTestFailureEvents(10)
+	require.NoError(t, log.AddForwardingEvents(events))
+
+	assertFailureQueries(t, &log, events)
+	assertChannelQueries(t, &log, events)
+}
+
+// makeTestFailureEvents creates the given number of forwarding events spaced
+// one minute apart, where every odd event failed.
+func makeTestFailureEvents(numEvents int) []ForwardingEvent {
+	timestamp := time.Unix(1234, 0)
+	events := make([]ForwardingEvent, numEvents)
+	for i := range events {
+		scid := uint64(i + 1)
+		events[i] = ForwardingEvent{
+			Timestamp:      timestamp,
+			IncomingChanID: lnwire.NewShortChanIDFromInt(scid),
+			OutgoingChanID: lnwire.NewShortChanIDFromInt(scid + 1),
+			AmtIn:          lnwire.MilliSatoshi(2000 + i),
+			AmtOut:         1000,
+			IncomingExpiry: uint32(500 + i),
+			OutgoingExpiry: uint32(460 + i),
+			Failed:         i%2 == 1,
+		}
+
+		timestamp = timestamp.Add(time.Minute)
+	}
+
+	return events
+}
+
+// assertFailureQueries asserts that querying the events created by
+// makeTestFailureEvents from the given store respects the IncludeFailures flag
+// when paginating through them.
+func assertFailureQueries(t *testing.T, store ForwardingEventStore,
+	events []ForwardingEvent) {
+
+	t.Helper()
+
+	var settled []ForwardingEvent
+	for _, event := range events {
+		if !event.Failed {
+			settled = append(settled, event)
+		}
+	}
+
+	query := ForwardingEventQuery{
+		StartTime:    events[0].Timestamp,
+		EndTime:      events[len(events)-1].Timestamp,
+		NumMaxEvents: 1000,
+	}
+
+	// By default, only the settled events are returned.
+	timeSlice, err := store.Query(query)
+	require.NoError(t, err)
+	require.Equal(t, settled, timeSlice.ForwardingEvents)
+	require.EqualValues(t, len(settled), timeSlice.LastIndexOffset)
+
+	// The failed events are returned if requested.
+	query.IncludeFailures = true
+	timeSlice, err = store.Query(query)
+	require.NoError(t, err)
+	require.Equal(t, events, timeSlice.ForwardingEvents)
+
+	// The index offset only counts the events that match the query.
+	query.IncludeFailures = false
+	query.IndexOffset = 2
+	query.NumMaxEvents = 2
+	timeSlice, err = store.Query(query)
+	require.NoError(t, err)
+	require.Equal(t, settled[2:4], timeSlice.ForwardingEvents)
+	require.EqualValues(t, 4, timeSlice.LastIndexOffset)
+}
+
+// assertChannelQueries asserts that querying the events created by
+// makeTestFailureEvents from the given store respects the channel filter of
+// the query when paginating through them.
+func assertChannelQueries(t *testing.T, store ForwardingEventStore,
+	events []ForwardingEvent) {
+
+	t.Helper()
+
+	// The third channel is the outgoing channel of the second event, which
+	// failed, and the incoming channel of the third event.
+	query := ForwardingEventQuery{
+		StartTime:    events[0].Timestamp,
+		EndTime:      events[len(events)-1].Timestamp,
+		NumMaxEvents: 1000,
+		ChanIDs: map[lnwire.ShortChannelID]struct{}{
+			lnwire.NewShortChanIDFromInt(3): {},
+		},
+	}
+
+	timeSlice, err := store.Query(query)
+	require.NoError(t, err)
+	require.Equal(t, events[2:3], timeSlice.ForwardingEvents)
+	require.EqualValues(t, 1, timeSlice.LastIndexOffset)
+
+	query.IncludeFailures = true
+	timeSlice, err = store.Query(query)
+	require.NoError(t, err)
+	require.Equal(t, events[1:3], timeSlice.ForwardingEvents)
+
+	// The index offset only counts the events of the filtered channels.
+	query.IndexOffset = 1
+	query.NumMaxEvents = 1
+	timeSlice, err = store.Query(query)
+	require.NoError(t, err)
+	require.Equal(t, events[2:3], timeSlice.ForwardingEvents)
+	require.EqualValues(t, 2, timeSlice.LastIndexOffset)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/tlv"
 )
 
 var (
@@ -163,6 +164,25 @@ const (
 	MaxAllowedExtraOpaqueBytes = 10000
 )
 
+const (
+	// edgeVersionType is the type of the TLV record that stores the gossip
+	// version of an edge or policy that wasn't announced with the legacy
+	// gossip protocol.
+	edgeVersionType tlv.Type = 0
+
+	// edgeSignatureType is the type of the TLV record that stores the
+	// Schnorr signature of a GossipVersion2 edge.
+	edgeSignatureType tlv.Type = 2
+
+	// policyBlockHeightType is the type of the TLV record that stores the
+	// block height of a GossipVersion2 policy.
+	policyBlockHeightType tlv.Type = 2
+
+	// policyDisableFlagsType is the type of the TLV record that stores the
+	// disable flags of a GossipVersion2 policy.
+	policyDisableFlagsType tlv.Type = 4
+)
+
 // ChannelGraph is a persistent, on-disk graph representation of the Lightning
 // Network. This struct can be used to implement path finding algorithms on top
 // of, and also to update a node's view based on information received from the
@@ -186,6 +206,10 @@ type ChannelGraph struct {
 
 	chanScheduler batch.Scheduler
 	nodeScheduler batch.Scheduler
+
+	// mirror is an optional secondary store that receives a copy of all
+	// graph writes after they've been committed to the database.
+	mirror GraphMirror
 }
 
 // NewChannelGraph allocates a new ChannelGraph backed by a DB instance. The
@@ -249,6 +273,26 @@ func NewChannelGraph(db kvdb.Backend, rejectCacheSize, chanCacheSize int,
 	return g, nil
 }
 
+// SetGraphMirror sets the secondary store that all graph writes are mirrored
+// to, which is used to dual-write the channel graph to a native SQL database.
+// It must be called before the graph is modified.
+func (c *ChannelGraph) SetGraphMirror(mirror GraphMirror) {
+	c.mirror = mirror
+}
+
+// mirrorGraph hands a graph write that was just committed to the database to
+// the graph mirror, if one is set. As the kvdb graph stays authoritative, a
+// failure to mirror the write is only logged.
+func (c *ChannelGraph) mirrorGraph(desc string, write func(GraphMirror) error) {
+	if c.mirror == nil {
+		return
+	}
+
+	if err := write(c.mirror); err != nil {
+		log.Errorf("Unable to mirror %v to graph: %v", desc, err)
+	}
+}
+
 // channelMapKey is the key structure used for storing channel edge policies.
 type channelMapKey struct {
 	nodeKey route.Vertex
@@ -800,7 +844,7 @@ func (c *ChannelGraph) sourceNode(nodes kvdb.RBucket) (*LightningNode, error) {
 func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 	nodePubBytes := node.PubKeyBytes[:]
 
-	return kvdb.Update(c.db, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
 		nodes, err := tx.CreateTopLevelBucket(nodeBucket)
@@ -818,6 +862,15 @@ func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 		// itself.
 		return addLightningNode(tx, node)
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorGraph("source node", func(m GraphMirror) error {
+		return m.SetSourceNode(node)
+	})
+
+	return nil
 }
 
 // AddLightningNode adds a vertex/node to the graph database. If the node is not
@@ -845,6 +898,17 @@ func (c *ChannelGraph) AddLightningNode(node *LightningNode,
 
 			return addLightningNode(tx, node)
 		},
+		OnCommit: func(err error) error {
+			if err != nil {
+				return err
+			}
+
+			c.mirrorGraph("node", func(m GraphMirror) error {
+				return m.PutNode(node)
+			})
+
+			return nil
+		},
 	}
 
 	for _, f := range op {
@@ -915,7 +979,7 @@ func (c *ChannelGraph) LookupAlias(pub *btcec.PublicKey) (string, error) {
 // from the database according to the node's public key.
 func (c *ChannelGraph) DeleteLightningNode(nodePub route.Vertex) error {
 	// TODO(roasbeef): ensure dangling edges are removed...
-	return kvdb.Update(c.db, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
 		nodes := tx.ReadWriteBucket(nodeBucket)
 		if nodes == nil {
 			return ErrGraphNodeNotFound
@@ -927,6 +991,15 @@ func (c *ChannelGraph) DeleteLightningNode(nodePub route.Vertex) error {
 
 		return c.deleteLightningNode(nodes, nodePub[:])
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorGraph("node deletion", func(m GraphMirror) error {
+		return m.DeleteNode(nodePub)
+	})
+
+	return nil
 }
 
 // deleteLightningNode uses an existing database transaction to remove a
@@ -1008,6 +1081,11 @@ func (c *ChannelGraph) AddChannelEdge(edge *models.ChannelEdgeInfo,
 			default:
 				c.rejectCache.remove(edge.ChannelID)
 				c.chanCache.remove(edge.ChannelID)
+
+				c.mirrorGraph("channel", func(m GraphMirror) error {
+					return m.PutChannel(edge)
+				})
+
 				return nil
 			}
 		},
@@ -1240,7 +1318,7 @@ func (c *ChannelGraph) UpdateChannelEdge(edge *models.ChannelEdgeInfo) error {
 	var chanKey [8]byte
 	binary.BigEndian.PutUint64(chanKey[:], edge.ChannelID)
 
-	return kvdb.Update(c.db, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
 		edges := tx.ReadWriteBucket(edgeBucket)
 		if edge == nil {
 			return ErrEdgeNotFound
@@ -1261,6 +1339,15 @@ func (c *ChannelGraph) UpdateChannelEdge(edge *models.ChannelEdgeInfo) error {
 
 		return putChanEdgeInfo(edgeIndex, edge, chanKey)
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorGraph("channel update", func(m GraphMirror) error {
+		return m.PutChannel(edge)
+	})
+
+	return nil
 }
 
 const (
@@ -1391,11 +1478,18 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 		return nil, err
 	}
 
+	closedChanIDs := make([]uint64, 0, len(chansClosed))
 	for _, channel := range chansClosed {
 		c.rejectCache.remove(channel.ChannelID)
 		c.chanCache.remove(channel.ChannelID)
+
+		closedChanIDs = append(closedChanIDs, channel.ChannelID)
 	}
 
+	c.mirrorGraph("graph pruning", func(m GraphMirror) error {
+		return m.PruneGraph(closedChanIDs, blockHash, blockHeight)
+	})
+
 	if c.graphCache != nil {
 		log.Debugf("Pruned graph, cache now has %s",
 			c.graphCache.Stats())
@@ -1409,7 +1503,7 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 // that we only maintain a graph of reachable nodes. In the event that a pruned
 // node gains more channels, it will be re-added back to the graph.
 func (c *ChannelGraph) PruneGraphNodes() error {
-	return kvdb.Update(c.db, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
 		nodes := tx.ReadWriteBucket(nodeBucket)
 		if nodes == nil {
 			return ErrGraphNodesNotFound
@@ -1425,6 +1519,15 @@ func (c *ChannelGraph) PruneGraphNodes() error {
 
 		return c.pruneGraphNodes(nodes, edgeIndex)
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	c.mirrorGraph("node pruning", func(m GraphMirror) error {
+		return m.PruneGraphNodes()
+	})
+
+	return nil
 }
 
 // pruneGraphNodes attempts to remove any nodes from the graph who have had a
@@ -1656,11 +1759,18 @@ func (c *ChannelGraph) DisconnectBlockAtHeight(height uint32) (
 		return nil, err
 	}
 
+	removedChanIDs := make([]uint64, 0, len(removedChans))
 	for _, channel := range removedChans {
 		c.rejectCache.remove(channel.ChannelID)
 		c.chanCache.remove(channel.ChannelID)
+
+		removedChanIDs = append(removedChanIDs, channel.ChannelID)
 	}
 
+	c.mirrorGraph("block disconnect", func(m GraphMirror) error {
+		return m.DisconnectBlockAtHeight(height, removedChanIDs)
+	})
+
 	return removedChans, nil
 }
 
@@ -1725,6 +1835,11 @@ func (c *ChannelGraph) DeleteChannelEdges(strictZombiePruning, markZombie bool,
 	c.cacheMu.Lock()
 	defer c.cacheMu.Unlock()
 
+	// zombieKeys collects the node keys the deleted edges were marked as
+	// zombies with, so they can be mirrored once the deletion is
+	// committed.
+	zombieKeys := make(map[uint64][2][33]byte)
+
 	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
 		edges := tx.ReadWriteBucket(edgeBucket)
 		if edges == nil {
@@ -1757,10 +1872,19 @@ func (c *ChannelGraph) DeleteChannelEdges(strictZombiePruning, markZombie bool,
 			if err != nil {
 				return err
 			}
+
+			if !markZombie {
+				continue
+			}
+
+			_, pubKey1, pubKey2 := isZombieEdge(zombieIndex, chanID)
+			zombieKeys[chanID] = [2][33]byte{pubKey1, pubKey2}
 		}
 
 		return nil
-	}, func() {})
+	}, func() {
+		zombieKeys = make(map[uint64][2][33]byte)
+	})
 	if err != nil {
 		return err
 	}
@@ -1770,6 +1894,21 @@ func (c *ChannelGraph) DeleteChannelEdges(strictZombiePruning, markZombie bool,
 		c.chanCache.remove(chanID)
 	}
 
+	c.mirrorGraph("channel deletion", func(m GraphMirror) error {
+		if err := m.DeleteChannels(chanIDs...); err != nil {
+			return err
+		}
+
+		for chanID, keys := range zombieKeys {
+			err := m.MarkZombie(chanID, keys[0], keys[1])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
 	return nil
 }
 
@@ -2105,7 +2244,7 @@ func (c *ChannelGraph) NodeUpdatesInHorizon(startTime,
 func (c *ChannelGraph) FilterKnownChanIDs(chansInfo []ChannelUpdateInfo,
 	isZombieChan func(time.Time, time.Time) bool) ([]uint64, error) {
 
-	var newChanIDs []uint64
+	var newChanIDs, liveChanIDs []uint64
 
 	c.cacheMu.Lock()
 	defer c.cacheMu.Unlock()
@@ -2166,6 +2305,8 @@ func (c *ChannelGraph) FilterKnownChanIDs(chansInfo []ChannelUpdateInfo,
 					if err != nil {
 						return err
 					}
+
+					liveChanIDs = append(liveChanIDs, scid)
 				}
 			}
 
@@ -2175,6 +2316,7 @@ func (c *ChannelGraph) FilterKnownChanIDs(chansInfo []ChannelUpdateInfo,
 		return nil
 	}, func() {
 		newChanIDs = nil
+		liveChanIDs = nil
 	})
 	switch {
 	// If we don't know of any edges yet, then we'll return the entire set
@@ -2191,6 +2333,16 @@ func (c *ChannelGraph) FilterKnownChanIDs(chansInfo []ChannelUpdateInfo,
 		return nil, err
 	}
 
+	c.mirrorGraph("live channels", func(m GraphMirror) error {
+		for _, chanID := range liveChanIDs {
+			if err := m.MarkLive(chanID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
 	return newChanIDs, nil
 }
 
@@ -2682,6 +2834,13 @@ func (c *ChannelGraph) UpdateEdgePolicy(edge *models.ChannelEdgePolicy,
 				return ErrEdgeNotFound
 			default:
 				c.updateEdgeCache(edge, isUpdate1)
+
+				c.mirrorGraph("channel policy",
+					func(m GraphMirror) error {
+						return m.PutChannelPolicy(edge)
+					},
+				)
+
 				return nil
 			}
 		},
@@ -3516,6 +3675,33 @@ func genMultiSigP2WSH(aPub, bPub []byte) ([]byte, error) {
 	return bldr.Script()
 }
 
+// genFundingPkScript generates the funding pk script of the given edge. Edges
+// that were announced with GossipVersion2 are funded by a P2TR output, all
+// others by a P2WSH multi-sig output.
+func genFundingPkScript(edgeInfo *models.ChannelEdgeInfo) ([]byte, error) {
+	if edgeInfo.Version != lnwire.GossipVersion2 {
+		return genMultiSigP2WSH(
+			edgeInfo.BitcoinKey1Bytes[:],
+			edgeInfo.BitcoinKey2Bytes[:],
+		)
+	}
+
+	bitcoinKey1, err := edgeInfo.BitcoinKey1()
+	if err != nil {
+		return nil, err
+	}
+	bitcoinKey2, err := edgeInfo.BitcoinKey2()
+	if err != nil {
+		return nil, err
+	}
+
+	pkScript, _, err := input.GenTaprootFundingScript(
+		bitcoinKey1, bitcoinKey2, 0,
+	)
+
+	return pkScript, err
+}
+
 // EdgePoint couples the outpoint of a channel with the funding script that it
 // creates. The FilteredChainView will use this to watch for spends of this
 // edge point on chain. We require both of these values as depending on the
@@ -3576,10 +3762,7 @@ func (c *ChannelGraph) ChannelView() ([]EdgePoint, error) {
 				return err
 			}
 
-			pkScript, err := genMultiSigP2WSH(
-				edgeInfo.BitcoinKey1Bytes[:],
-				edgeInfo.BitcoinKey2Bytes[:],
-			)
+			pkScript, err := genFundingPkScript(&edgeInfo)
 			if err != nil {
 				return err
 			}
@@ -3633,6 +3816,10 @@ func (c *ChannelGraph) MarkEdgeZombie(chanID uint64,
 	c.rejectCache.remove(chanID)
 	c.chanCache.remove(chanID)
 
+	c.mirrorGraph("zombie channel", func(m GraphMirror) error {
+		return m.MarkZombie(chanID, pubKey1, pubKey2)
+	})
+
 	return nil
 }
 
@@ -3657,7 +3844,15 @@ func (c *ChannelGraph) MarkEdgeLive(chanID uint64) error {
 	c.cacheMu.Lock()
 	defer c.cacheMu.Unlock()
 
-	return c.markEdgeLiveUnsafe(nil, chanID)
+	if err := c.markEdgeLiveUnsafe(nil, chanID); err != nil {
+		return err
+	}
+
+	c.mirrorGraph("live channel", func(m GraphMirror) error {
+		return m.MarkLive(chanID)
+	})
+
+	return nil
 }
 
 // markEdgeLiveUnsafe clears an edge from the zombie index. This method can be
@@ -3802,13 +3997,113 @@ func (c *ChannelGraph) NumZombies() (uint64, error) {
 	return numZombies, nil
 }
 
+// forEachZombieEdge calls the given callback for each entry of the zombie
+// index along with the node public keys it was marked with.
+func (c *ChannelGraph) forEachZombieEdge(cb func(chanID uint64, pubKey1,
+	pubKey2 [33]byte) error) error {
+
+	return kvdb.View(c.db, func(tx kvdb.RTx) error {
+		edges := tx.ReadBucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		zombieIndex := edges.NestedReadBucket(zombieBucket)
+		if zombieIndex == nil {
+			return nil
+		}
+
+		return zombieIndex.ForEach(func(k, v []byte) error {
+			var pubKey1, pubKey2 [33]byte
+			copy(pubKey1[:], v[:33])
+			copy(pubKey2[:], v[33:])
+
+			return cb(byteOrder.Uint64(k), pubKey1, pubKey2)
+		})
+	}, func() {})
+}
+
+// forEachPruneLogEntry calls the given callback for each block the graph was
+// pruned with, in ascending order of their height.
+func (c *ChannelGraph) forEachPruneLogEntry(cb func(height uint32,
+	hash chainhash.Hash) error) error {
+
+	return kvdb.View(c.db, func(tx kvdb.RTx) error {
+		graphMeta := tx.ReadBucket(graphMetaBucket)
+		if graphMeta == nil {
+			return nil
+		}
+		pruneBucket := graphMeta.NestedReadBucket(pruneLogBucket)
+		if pruneBucket == nil {
+			return nil
+		}
+
+		return pruneBucket.ForEach(func(k, v []byte) error {
+			var hash chainhash.Hash
+			copy(hash[:], v)
+
+			return cb(byteOrder.Uint32(k), hash)
+		})
+	}, func() {})
+}
+
 func putLightningNode(nodeBucket kvdb.RwBucket, aliasBucket kvdb.RwBucket, // nolint:dupl
 	updateIndex kvdb.RwBucket, node *LightningNode) error {
 
-	var (
-		scratch [16]byte
-		b       bytes.Buffer
-	)
+	var b bytes.Buffer
+	if err := serializeLightningNode(&b, node); err != nil {
+		return err
+	}
+	nodePub := node.PubKeyBytes[:]
+
+	// If we got a node announcement for this node, we will have the rest
+	// of the data available. If not we only need to write the node itself.
+	if !node.HaveNodeAnnouncement {
+		return nodeBucket.Put(nodePub, b.Bytes())
+	}
+
+	if err := aliasBucket.Put(nodePub, []byte(node.Alias)); err != nil {
+		return err
+	}
+
+	// If the node has the update time set, write it, else write 0.
+	updateUnix := uint64(0)
+	if node.LastUpdate.Unix() > 0 {
+		updateUnix = uint64(node.LastUpdate.Unix())
+	}
+
+	// With the alias bucket updated, we'll now update the index that
+	// tracks the time series of node updates.
+	var indexKey [8 + 33]byte
+	byteOrder.PutUint64(indexKey[:8], updateUnix)
+	copy(indexKey[8:], nodePub)
+
+	// If there was already an old index entry for this node, then we'll
+	// delete the old one before we write the new entry.
+	if nodeBytes := nodeBucket.Get(nodePub); nodeBytes != nil {
+		// Extract out the old update time to we can reconstruct the
+		// prior index key to delete it from the index.
+		oldUpdateTime := nodeBytes[:8]
+
+		var oldIndexKey [8 + 33]byte
+		copy(oldIndexKey[:8], oldUpdateTime)
+		copy(oldIndexKey[8:], nodePub)
+
+		if err := updateIndex.Delete(oldIndexKey[:]); err != nil {
+			return err
+		}
+	}
+
+	if err := updateIndex.Put(indexKey[:], nil); err != nil {
+		return err
+	}
+
+	return nodeBucket.Put(nodePub, b.Bytes())
+}
+
+// serializeLightningNode writes the serialized node to the given buffer, in
+// the format that is stored in the node bucket.
+func serializeLightningNode(b *bytes.Buffer, node *LightningNode) error {
+	var scratch [16]byte
 
 	pub, err := node.PubKey()
 	if err != nil {
@@ -3836,11 +4131,9 @@ func putLightningNode(nodeBucket kvdb.RwBucket, aliasBucket kvdb.RwBucket, // no
 	if !node.HaveNodeAnnouncement {
 		// Write HaveNodeAnnouncement=0.
 		byteOrder.PutUint16(scratch[:2], 0)
-		if _, err := b.Write(scratch[:2]); err != nil {
-			return err
-		}
+		_, err := b.Write(scratch[:2])
 
-		return nodeBucket.Put(nodePub, b.Bytes())
+		return err
 	}
 
 	// Write HaveNodeAnnouncement=1.
@@ -3849,21 +4142,21 @@ func putLightningNode(nodeBucket kvdb.RwBucket, aliasBucket kvdb.RwBucket, // no
 		return err
 	}
 
-	if err := binary.Write(&b, byteOrder, node.Color.R); err != nil {
+	if err := binary.Write(b, byteOrder, node.Color.R); err != nil {
 		return err
 	}
-	if err := binary.Write(&b, byteOrder, node.Color.G); err != nil {
+	if err := binary.Write(b, byteOrder, node.Color.G); err != nil {
 		return err
 	}
-	if err := binary.Write(&b, byteOrder, node.Color.B); err != nil {
+	if err := binary.Write(b, byteOrder, node.Color.B); err != nil {
 		return err
 	}
 
-	if err := wire.WriteVarString(&b, 0, node.Alias); err != nil {
+	if err := wire.WriteVarString(b, 0, node.Alias); err != nil {
 		return err
 	}
 
-	if err := node.Features.Encode(&b); err != nil {
+	if err := node.Features.Encode(b); err != nil {
 		return err
 	}
 
@@ -3874,7 +4167,7 @@ func putLightningNode(nodeBucket kvdb.RwBucket, aliasBucket kvdb.RwBucket, // no
 	}
 
 	for _, address := range node.Addresses {
-		if err := serializeAddr(&b, address); err != nil {
+		if err := serializeAddr(b, address); err != nil {
 			return err
 		}
 	}
@@ -3885,7 +4178,7 @@ func putLightningNode(nodeBucket kvdb.RwBucket, aliasBucket kvdb.RwBucket, // no
 			sigLen)
 	}
 
-	err = wire.WriteVarBytes(&b, 0, node.AuthSigBytes)
+	err = wire.WriteVarBytes(b, 0, node.AuthSigBytes)
 	if err != nil {
 		return err
 	}
@@ -3893,42 +4186,8 @@ func putLightningNode(nodeBucket kvdb.RwBucket, aliasBucket kvdb.RwBucket, // no
 	if len(node.ExtraOpaqueData) > MaxAllowedExtraOpaqueBytes {
 		return ErrTooManyExtraOpaqueBytes(len(node.ExtraOpaqueData))
 	}
-	err = wire.WriteVarBytes(&b, 0, node.ExtraOpaqueData)
-	if err != nil {
-		return err
-	}
-
-	if err := aliasBucket.Put(nodePub, []byte(node.Alias)); err != nil {
-		return err
-	}
 
-	// With the alias bucket updated, we'll now update the index that
-	// tracks the time series of node updates.
-	var indexKey [8 + 33]byte
-	byteOrder.PutUint64(indexKey[:8], updateUnix)
-	copy(indexKey[8:], nodePub)
-
-	// If there was already an old index entry for this node, then we'll
-	// delete the old one before we write the new entry.
-	if nodeBytes := nodeBucket.Get(nodePub); nodeBytes != nil {
-		// Extract out the old update time to we can reconstruct the
-		// prior index key to delete it from the index.
-		oldUpdateTime := nodeBytes[:8]
-
-		var oldIndexKey [8 + 33]byte
-		copy(oldIndexKey[:8], oldUpdateTime)
-		copy(oldIndexKey[8:], nodePub)
-
-		if err := updateIndex.Delete(oldIndexKey[:]); err != nil {
-			return err
-		}
-	}
-
-	if err := updateIndex.Put(indexKey[:], nil); err != nil {
-		return err
-	}
-
-	return nodeBucket.Put(nodePub, b.Bytes())
+	return wire.WriteVarBytes(b, 0, node.ExtraOpaqueData)
 }
 
 func fetchLightningNode(nodeBucket kvdb.RBucket,
@@ -4099,6 +4358,17 @@ func putChanEdgeInfo(edgeIndex kvdb.RwBucket,
 	edgeInfo *models.ChannelEdgeInfo, chanID [8]byte) error {
 
 	var b bytes.Buffer
+	if err := serializeChanEdgeInfo(&b, edgeInfo, chanID); err != nil {
+		return err
+	}
+
+	return edgeIndex.Put(chanID[:], b.Bytes())
+}
+
+// serializeChanEdgeInfo writes the serialized edge info to the given buffer,
+// in the format that is stored in the edge index.
+func serializeChanEdgeInfo(b *bytes.Buffer, edgeInfo *models.ChannelEdgeInfo,
+	chanID [8]byte) error {
 
 	if _, err := b.Write(edgeInfo.NodeKey1Bytes[:]); err != nil {
 		return err
@@ -4113,7 +4383,7 @@ func putChanEdgeInfo(edgeIndex kvdb.RwBucket,
 		return err
 	}
 
-	if err := wire.WriteVarBytes(&b, 0, edgeInfo.Features); err != nil {
+	if err := wire.WriteVarBytes(b, 0, edgeInfo.Features); err != nil {
 		return err
 	}
 
@@ -4126,23 +4396,23 @@ func putChanEdgeInfo(edgeIndex kvdb.RwBucket,
 		bitcoinSig2 = authProof.BitcoinSig2Bytes
 	}
 
-	if err := wire.WriteVarBytes(&b, 0, nodeSig1); err != nil {
+	if err := wire.WriteVarBytes(b, 0, nodeSig1); err != nil {
 		return err
 	}
-	if err := wire.WriteVarBytes(&b, 0, nodeSig2); err != nil {
+	if err := wire.WriteVarBytes(b, 0, nodeSig2); err != nil {
 		return err
 	}
-	if err := wire.WriteVarBytes(&b, 0, bitcoinSig1); err != nil {
+	if err := wire.WriteVarBytes(b, 0, bitcoinSig1); err != nil {
 		return err
 	}
-	if err := wire.WriteVarBytes(&b, 0, bitcoinSig2); err != nil {
+	if err := wire.WriteVarBytes(b, 0, bitcoinSig2); err != nil {
 		return err
 	}
 
-	if err := writeOutpoint(&b, &edgeInfo.ChannelPoint); err != nil {
+	if err := writeOutpoint(b, &edgeInfo.ChannelPoint); err != nil {
 		return err
 	}
-	if err := binary.Write(&b, byteOrder, uint64(edgeInfo.Capacity)); err != nil {
+	if err := binary.Write(b, byteOrder, uint64(edgeInfo.Capacity)); err != nil {
 		return err
 	}
 	if _, err := b.Write(chanID[:]); err != nil {
@@ -4155,12 +4425,33 @@ func putChanEdgeInfo(edgeIndex kvdb.RwBucket,
 	if len(edgeInfo.ExtraOpaqueData) > MaxAllowedExtraOpaqueBytes {
 		return ErrTooManyExtraOpaqueBytes(len(edgeInfo.ExtraOpaqueData))
 	}
-	err := wire.WriteVarBytes(&b, 0, edgeInfo.ExtraOpaqueData)
+
+	err := wire.WriteVarBytes(b, 0, edgeInfo.ExtraOpaqueData)
 	if err != nil {
 		return err
 	}
 
-	return edgeIndex.Put(chanID[:], b.Bytes())
+	// Legacy edges end here, so that they can still be read by older
+	// versions.
+	if edgeInfo.Version == lnwire.GossipVersion1 {
+		return nil
+	}
+
+	var sig []byte
+	if authProof != nil {
+		sig = authProof.Signature
+	}
+
+	version := uint8(edgeInfo.Version)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(edgeVersionType, &version),
+		tlv.MakePrimitiveRecord(edgeSignatureType, &sig),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(b)
 }
 
 func fetchChanEdgeInfo(edgeIndex kvdb.RBucket,
@@ -4249,6 +4540,30 @@ func deserializeChanEdgeInfo(r io.Reader) (models.ChannelEdgeInfo, error) {
 		return models.ChannelEdgeInfo{}, err
 	}
 
+	// Edges announced with a newer gossip version are followed by a TLV
+	// stream that carries their version and signature.
+	var (
+		version uint8
+		sig     []byte
+	)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(edgeVersionType, &version),
+		tlv.MakePrimitiveRecord(edgeSignatureType, &sig),
+	)
+	if err != nil {
+		return models.ChannelEdgeInfo{}, err
+	}
+	if err := tlvStream.Decode(r); err != nil {
+		return models.ChannelEdgeInfo{}, err
+	}
+
+	edgeInfo.Version = lnwire.GossipVersion(version)
+	if len(sig) != 0 {
+		edgeInfo.AuthProof = &models.ChannelAuthProof{
+			Signature: sig,
+		}
+	}
+
 	return edgeInfo, nil
 }
 
@@ -4501,7 +4816,27 @@ func serializeChanEdgePolicy(w io.Writer, edge *models.ChannelEdgePolicy,
 	if err := wire.WriteVarBytes(w, 0, opaqueBuf.Bytes()); err != nil {
 		return err
 	}
-	return nil
+
+	// Legacy policies end here, so that they can still be read by older
+	// versions.
+	if edge.Version == lnwire.GossipVersion1 {
+		return nil
+	}
+
+	var (
+		version      = uint8(edge.Version)
+		disableFlags = uint8(edge.DisableFlags)
+	)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(edgeVersionType, &version),
+		tlv.MakePrimitiveRecord(policyBlockHeightType, &edge.BlockHeight),
+		tlv.MakePrimitiveRecord(policyDisableFlagsType, &disableFlags),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
 }
 
 func deserializeChanEdgePolicy(r io.Reader) (*models.ChannelEdgePolicy, error) {
@@ -4581,6 +4916,23 @@ func deserializeChanEdgePolicyRaw(r io.Reader) (*models.ChannelEdgePolicy,
 		return nil, err
 	}
 
+	// Policies received with a newer gossip version are followed by a TLV
+	// stream that carries their version specific fields.
+	var version, disableFlags uint8
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(edgeVersionType, &version),
+		tlv.MakePrimitiveRecord(policyBlockHeightType, &edge.BlockHeight),
+		tlv.MakePrimitiveRecord(policyDisableFlagsType, &disableFlags),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := tlvStream.Decode(r); err != nil {
+		return nil, err
+	}
+	edge.Version = lnwire.GossipVersion(version)
+	edge.DisableFlags = lnwire.ChanUpdateDisableFlags(disableFlags)
+
 	// See if optional fields are present.
 	if edge.MessageFlags.HasMaxHtlc() {
 		// The max_htlc field should be at the beginning of the opaque
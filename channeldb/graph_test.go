@@ -694,6 +694,58 @@ func createChannelEdge(db kvdb.Backend, node1, node2 *LightningNode) (
 	return edgeInfo, edge1, edge2
 }
 
+// TestGossipV2EdgeSerialization tests that the version specific fields of
+// channels and policies that were announced with gossip v2 are persisted.
+func TestGossipV2EdgeSerialization(t *testing.T) {
+	t.Parallel()
+
+	graph, err := MakeTestGraph(t)
+	require.NoError(t, err, "unable to make test database")
+
+	node1, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	node2, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	require.NoError(t, graph.AddLightningNode(node1))
+	require.NoError(t, graph.AddLightningNode(node2))
+
+	// Turn the edge into one that was announced with a single Schnorr
+	// signature.
+	edgeInfo, edge1, edge2 := createChannelEdge(graph.db, node1, node2)
+	edgeInfo.Version = lnwire.GossipVersion2
+	edgeInfo.AuthProof = &models.ChannelAuthProof{
+		Signature: bytes.Repeat([]byte{1}, 64),
+	}
+	require.NoError(t, graph.AddChannelEdge(edgeInfo))
+
+	edge1.Version = lnwire.GossipVersion2
+	edge1.BlockHeight = 800_000
+	edge1.DisableFlags = lnwire.ChanUpdateDisableOutgoing
+	edge1.ChannelFlags |= lnwire.ChanUpdateDisabled
+	require.NoError(t, graph.UpdateEdgePolicy(edge1))
+
+	// The second policy is a legacy one, which shouldn't be affected.
+	require.NoError(t, graph.UpdateEdgePolicy(edge2))
+
+	dbEdgeInfo, dbEdge1, dbEdge2, err := graph.FetchChannelEdgesByID(
+		edgeInfo.ChannelID,
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, lnwire.GossipVersion2, dbEdgeInfo.Version)
+	require.Equal(t, edgeInfo.AuthProof, dbEdgeInfo.AuthProof)
+	require.Equal(t, edgeInfo.ExtraOpaqueData, dbEdgeInfo.ExtraOpaqueData)
+
+	require.NoError(t, compareEdgePolicies(edge1, dbEdge1))
+	require.Equal(t, lnwire.GossipVersion2, dbEdge1.Version)
+	require.Equal(t, edge1.BlockHeight, dbEdge1.BlockHeight)
+	require.Equal(t, edge1.DisableFlags, dbEdge1.DisableFlags)
+
+	require.NoError(t, compareEdgePolicies(edge2, dbEdge2))
+	require.Equal(t, lnwire.GossipVersion1, dbEdge2.Version)
+	require.Zero(t, dbEdge2.BlockHeight)
+}
+
 func TestEdgeInfoUpdates(t *testing.T) {
 	t.Parallel()
 
@@ -102,6 +102,7 @@ const (
 	htlcAMPType      tlv.Type = 19
 	htlcHashType     tlv.Type = 21
 	htlcPreimageType tlv.Type = 23
+	htlcWireAmtType  tlv.Type = 25
 
 	// A set of tlv type definitions used to serialize invoice bodiees.
 	//
@@ -1320,6 +1321,15 @@ func serializeHtlcs(w io.Writer,
 			}
 		}
 
+		// The wire amount is only stored if an htlc modifier changed
+		// the amount the htlc pays to the invoice.
+		wireAmt := uint64(htlc.WireAmt)
+		if wireAmt != 0 {
+			records = append(records, tlv.MakePrimitiveRecord(
+				htlcWireAmtType, &wireAmt,
+			))
+		}
+
 		// Convert the custom records to tlv.Record types that are ready
 		// for serialization.
 		customRecords := tlv.MapToRecords(htlc.CustomRecords)
@@ -1974,6 +1984,7 @@ func deserializeHtlcs(r io.Reader) (map[models.CircuitKey]*invpkg.InvoiceHTLC,
 			state                   uint8
 			acceptTime, resolveTime uint64
 			amt, mppTotalAmt        uint64
+			wireAmt                 uint64
 			amp                     = &record.AMP{}
 			hash32                  = &[32]byte{}
 			preimage32              = &[32]byte{}
@@ -1996,6 +2007,7 @@ func deserializeHtlcs(r io.Reader) (map[models.CircuitKey]*invpkg.InvoiceHTLC,
 			),
 			tlv.MakePrimitiveRecord(htlcHashType, hash32),
 			tlv.MakePrimitiveRecord(htlcPreimageType, preimage32),
+			tlv.MakePrimitiveRecord(htlcWireAmtType, &wireAmt),
 		)
 		if err != nil {
 			return nil, err
@@ -2028,6 +2040,7 @@ func deserializeHtlcs(r io.Reader) (map[models.CircuitKey]*invpkg.InvoiceHTLC,
 		htlc.State = invpkg.HtlcState(state)
 		htlc.Amt = lnwire.MilliSatoshi(amt)
 		htlc.MppTotalAmt = lnwire.MilliSatoshi(mppTotalAmt)
+		htlc.WireAmt = lnwire.MilliSatoshi(wireAmt)
 		if amp != nil && hash != nil {
 			htlc.AMP = &invpkg.InvoiceHtlcAMPData{
 				Record:   *amp,
@@ -37,6 +37,11 @@ type ChannelAuthProof struct {
 	// BitcoinSig2Bytes are the raw bytes of the second bitcoin signature
 	// encoded in DER format.
 	BitcoinSig2Bytes []byte
+
+	// Signature is the raw Schnorr signature of a channel that was
+	// announced with a ChannelAnnouncement2. It replaces the four ECDSA
+	// signatures above, which are empty for such channels.
+	Signature []byte
 }
 
 // Node1Sig is the signature using the identity key of the node that is first
@@ -122,8 +127,13 @@ func (c *ChannelAuthProof) BitcoinSig2() (*ecdsa.Signature, error) {
 }
 
 // IsEmpty check is the authentication proof is empty Proof is empty if at
-// least one of the signatures are equal to nil.
+// least one of the signatures are equal to nil, unless it carries the single
+// Schnorr signature of a ChannelAnnouncement2.
 func (c *ChannelAuthProof) IsEmpty() bool {
+	if len(c.Signature) != 0 {
+		return false
+	}
+
 	return len(c.NodeSig1Bytes) == 0 ||
 		len(c.NodeSig2Bytes) == 0 ||
 		len(c.BitcoinSig1Bytes) == 0 ||
@@ -8,6 +8,7 @@ import (
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwire"
 )
 
 // ChannelEdgeInfo represents a fully authenticated channel along with all its
@@ -22,6 +23,12 @@ type ChannelEdgeInfo struct {
 	// and the last 2 bytes are the output index for the channel.
 	ChannelID uint64
 
+	// Version is the version of the gossip protocol the channel was
+	// announced with. Channels announced with GossipVersion2 are funded
+	// by a P2TR output and their AuthProof carries a single Schnorr
+	// signature.
+	Version lnwire.GossipVersion
+
 	// ChainHash is the hash that uniquely identifies the chain that this
 	// channel was opened within.
 	//
@@ -27,10 +27,24 @@ type ChannelEdgePolicy struct {
 	// and the last 2 bytes are the output index for the channel.
 	ChannelID uint64
 
+	// Version is the version of the gossip protocol the policy was
+	// received with.
+	Version lnwire.GossipVersion
+
 	// LastUpdate is the last time an authenticated edge for this channel
 	// was received.
 	LastUpdate time.Time
 
+	// BlockHeight is the block height of the ChannelUpdate2 the policy was
+	// created from, which is used in place of LastUpdate to order updates
+	// of GossipVersion2 policies.
+	BlockHeight uint32
+
+	// DisableFlags are the disable flags of the ChannelUpdate2 the policy
+	// was created from. If any of them is set, the disabled bit of the
+	// ChannelFlags is set as well.
+	DisableFlags lnwire.ChanUpdateDisableFlags
+
 	// MessageFlags is a bitfield which indicates the presence of optional
 	// fields (like max_htlc) in the policy.
 	MessageFlags lnwire.ChanUpdateMsgFlags
@@ -45,6 +45,23 @@ type HTLCAttemptInfo struct {
 	// in which the payment's PaymentHash in the PaymentCreationInfo should
 	// be used.
 	Hash *lntypes.Hash
+
+	// SplitInfo records how the payment splitting arrived at the amount of
+	// this attempt. It is nil for attempts that were sent along a given
+	// route and for attempts made by older versions.
+	SplitInfo *HTLCSplitInfo
+}
+
+// HTLCSplitInfo describes the split decision for a single HTLC attempt of a
+// payment.
+type HTLCSplitInfo struct {
+	// Strategy is the split strategy of the payment.
+	Strategy uint8
+
+	// RemainingAmt is the amount of the payment that still had to be sent
+	// when the attempt was created. If it is larger than the amount that
+	// the attempt delivers to the receiver, the payment was split.
+	RemainingAmt lnwire.MilliSatoshi
 }
 
 // NewHtlcAttempt creates a htlc attempt.
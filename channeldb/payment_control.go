@@ -30,6 +30,12 @@ var (
 	// existing payment that is not failed.
 	ErrPaymentExists = errors.New("payment already exists")
 
+	// ErrIdempotencyKeyExists is returned when we try to initialize a
+	// payment with an idempotency key that is already used by a payment
+	// for a different payment hash.
+	ErrIdempotencyKeyExists = errors.New("idempotency key already used " +
+		"by another payment")
+
 	// ErrPaymentInternal is returned when performing the payment has a
 	// conflicting state, such as,
 	// - payment has StatusSucceeded but remaining amount is not zero.
@@ -156,6 +162,24 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 		// from a previous execution of the batched db transaction.
 		updateErr = nil
 
+		// An idempotency key can only be used by a single payment, so
+		// we refuse to init a payment if its key already belongs to a
+		// payment for a different hash.
+		if len(info.IdempotencyKey) != 0 {
+			idempotencyIndex := tx.ReadWriteBucket(
+				paymentsIdempotencyIndexBucket,
+			)
+			var hash []byte
+			if idempotencyIndex != nil {
+				hash = idempotencyIndex.Get(info.IdempotencyKey)
+			}
+
+			if hash != nil && !bytes.Equal(hash, paymentHash[:]) {
+				updateErr = ErrIdempotencyKeyExists
+				return nil
+			}
+		}
+
 		prefetchPayment(tx, paymentHash)
 		bucket, err := createPaymentBucket(tx, paymentHash)
 		if err != nil {
@@ -175,6 +199,23 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 				return nil
 			}
 
+			// The previous attempt of the payment may have used a
+			// different idempotency key, which no longer refers to
+			// this payment.
+			oldKey, err := fetchIdempotencyKey(bucket)
+			if err != nil {
+				return err
+			}
+
+			if !bytes.Equal(oldKey, info.IdempotencyKey) {
+				err := deleteIdempotencyIndexEntries(
+					tx, [][]byte{oldKey},
+				)
+				if err != nil {
+					return err
+				}
+			}
+
 		// Otherwise, if the error is not `ErrPaymentNotInitiated`,
 		// we'll return the error.
 		case !errors.Is(err, ErrPaymentNotInitiated):
@@ -209,6 +250,24 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 			return err
 		}
 
+		// Map the idempotency key of the payment to its hash, so the
+		// payment can be found again by its key.
+		if len(info.IdempotencyKey) != 0 {
+			idempotencyIndex, err := tx.CreateTopLevelBucket(
+				paymentsIdempotencyIndexBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = idempotencyIndex.Put(
+				info.IdempotencyKey, paymentHash[:],
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Add the payment info to the bucket, which contains the
 		// static information for this payment
 		err = bucket.Put(paymentCreationInfoKey, infoBytes)
@@ -587,6 +646,40 @@ func (p *PaymentControl) FetchPayment(paymentHash lntypes.Hash) (
 	return payment, nil
 }
 
+// FetchPaymentHashByIdempotencyKey returns the hash of the payment that was
+// initialized with the given idempotency key. ErrPaymentNotInitiated is
+// returned if no payment uses the key.
+func (p *PaymentControl) FetchPaymentHashByIdempotencyKey(key []byte) (
+	lntypes.Hash, error) {
+
+	var paymentHash lntypes.Hash
+	err := kvdb.View(p.db, func(tx kvdb.RTx) error {
+		idempotencyIndex := tx.ReadBucket(
+			paymentsIdempotencyIndexBucket,
+		)
+		if idempotencyIndex == nil {
+			return ErrPaymentNotInitiated
+		}
+
+		hash := idempotencyIndex.Get(key)
+		if hash == nil {
+			return ErrPaymentNotInitiated
+		}
+
+		var err error
+		paymentHash, err = lntypes.MakeHash(hash)
+
+		return err
+	}, func() {
+		paymentHash = lntypes.Hash{}
+	})
+	if err != nil {
+		return lntypes.Hash{}, err
+	}
+
+	return paymentHash, nil
+}
+
 // prefetchPayment attempts to prefetch as much of the payment as possible to
 // reduce DB roundtrips.
 func prefetchPayment(tx kvdb.RTx, paymentHash lntypes.Hash) {
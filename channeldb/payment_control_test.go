@@ -647,6 +647,86 @@ func TestPaymentControlDeleteSinglePayment(t *testing.T) {
 	assertPayments(t, db, payments[3:])
 }
 
+// TestPaymentControlIdempotencyKey tests that payments can be looked up by
+// their idempotency key, that a key can only be used by a single payment and
+// that the key is released once its payment is deleted.
+func TestPaymentControlIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	db, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to init db")
+
+	pControl := NewPaymentControl(db)
+
+	key := []byte("idempotency-key")
+
+	// An unknown key isn't found.
+	_, err = pControl.FetchPaymentHashByIdempotencyKey(key)
+	require.ErrorIs(t, err, ErrPaymentNotInitiated)
+
+	info, _, _, err := genInfo()
+	require.NoError(t, err)
+	info.IdempotencyKey = key
+
+	require.NoError(t, pControl.InitPayment(info.PaymentIdentifier, info))
+	assertPaymentInfo(
+		t, pControl, info.PaymentIdentifier, info, nil, nil,
+	)
+
+	hash, err := pControl.FetchPaymentHashByIdempotencyKey(key)
+	require.NoError(t, err)
+	require.Equal(t, info.PaymentIdentifier, hash)
+
+	// A payment for a different hash can't use the same key.
+	otherInfo, _, _, err := genInfo()
+	require.NoError(t, err)
+	otherInfo.IdempotencyKey = key
+
+	err = pControl.InitPayment(otherInfo.PaymentIdentifier, otherInfo)
+	require.ErrorIs(t, err, ErrIdempotencyKeyExists)
+
+	_, err = pControl.FetchPayment(otherInfo.PaymentIdentifier)
+	require.ErrorIs(t, err, ErrPaymentNotInitiated)
+
+	// Once the payment failed, it can be retried with a different key,
+	// which releases the old one.
+	_, err = pControl.Fail(info.PaymentIdentifier, FailureReasonNoRoute)
+	require.NoError(t, err)
+
+	info.IdempotencyKey = []byte("other-idempotency-key")
+	require.NoError(t, pControl.InitPayment(info.PaymentIdentifier, info))
+
+	_, err = pControl.FetchPaymentHashByIdempotencyKey(key)
+	require.ErrorIs(t, err, ErrPaymentNotInitiated)
+
+	require.NoError(t, pControl.InitPayment(
+		otherInfo.PaymentIdentifier, otherInfo,
+	))
+
+	hash, err = pControl.FetchPaymentHashByIdempotencyKey(key)
+	require.NoError(t, err)
+	require.Equal(t, otherInfo.PaymentIdentifier, hash)
+
+	// Deleting a payment removes its key from the index.
+	otherHash := otherInfo.PaymentIdentifier
+	_, err = pControl.Fail(otherHash, FailureReasonNoRoute)
+	require.NoError(t, err)
+	require.NoError(t, db.DeletePayment(otherHash, false))
+
+	_, err = pControl.FetchPaymentHashByIdempotencyKey(key)
+	require.ErrorIs(t, err, ErrPaymentNotInitiated)
+
+	// The same goes for deleting payments in bulk.
+	_, err = pControl.Fail(info.PaymentIdentifier, FailureReasonNoRoute)
+	require.NoError(t, err)
+	require.NoError(t, db.DeletePayments(true, false))
+
+	_, err = pControl.FetchPaymentHashByIdempotencyKey(
+		info.IdempotencyKey,
+	)
+	require.ErrorIs(t, err, ErrPaymentNotInitiated)
+}
+
 // TestPaymentControlMultiShard checks the ability of payment control to
 // have multiple in-flight HTLCs for a single payment.
 func TestPaymentControlMultiShard(t *testing.T) {
@@ -101,6 +101,17 @@ var (
 	// 	|--...
 	// 	|--<sequence-number>: <payment hash>
 	paymentsIndexBucket = []byte("payments-index-bucket")
+
+	// paymentsIdempotencyIndexBucket is the name of the top-level bucket
+	// within the database that maps the idempotency keys supplied by the
+	// callers of payments to the payment hash of the payment.
+	// payments-idempotency-index-bucket
+	// 	|--<idempotency key>: <payment hash>
+	// 	|--...
+	// 	|--<idempotency key>: <payment hash>
+	paymentsIdempotencyIndexBucket = []byte(
+		"payments-idempotency-index-bucket",
+	)
 )
 
 var (
@@ -123,6 +134,30 @@ var (
 		"found")
 )
 
+const (
+	// htlcSplitStrategyType is the tlv type of the split strategy in the
+	// split info of an HTLC attempt.
+	htlcSplitStrategyType tlv.Type = 0
+
+	// htlcSplitRemainingAmtType is the tlv type of the remaining payment
+	// amount in the split info of an HTLC attempt.
+	htlcSplitRemainingAmtType tlv.Type = 2
+)
+
+const (
+	// paymentMetadataType is the tlv type of the payment metadata in the
+	// creation info of a payment.
+	paymentMetadataType tlv.Type = 0
+
+	// paymentDestCustomRecordsType is the tlv type of the custom records
+	// sent to the final hop in the creation info of a payment.
+	paymentDestCustomRecordsType tlv.Type = 2
+
+	// paymentIdempotencyKeyType is the tlv type of the idempotency key in
+	// the creation info of a payment.
+	paymentIdempotencyKeyType tlv.Type = 4
+)
+
 // FailureReason encodes the reason a payment ultimately failed.
 type FailureReason byte
 
@@ -191,6 +226,19 @@ type PaymentCreationInfo struct {
 
 	// PaymentRequest is the full payment request, if any.
 	PaymentRequest []byte
+
+	// DestCustomRecords are the custom TLV records that are sent to the
+	// final hop of the payment, as supplied by the sender.
+	DestCustomRecords record.CustomSet
+
+	// Metadata is the payment metadata that is sent to the final hop of
+	// the payment, if any.
+	Metadata []byte
+
+	// IdempotencyKey is an optional key supplied by the caller that
+	// uniquely identifies the payment. Only a single payment can be
+	// created for a given key.
+	IdempotencyKey []byte
 }
 
 // htlcBucketKey creates a composite key from prefix and id where the result is
@@ -267,6 +315,42 @@ func fetchCreationInfo(bucket kvdb.RBucket) (*PaymentCreationInfo, error) {
 	return deserializePaymentCreationInfo(r)
 }
 
+// fetchIdempotencyKey returns the idempotency key of the payment in the given
+// bucket, or nil if the payment doesn't have one.
+func fetchIdempotencyKey(bucket kvdb.RBucket) ([]byte, error) {
+	if bucket.Get(paymentCreationInfoKey) == nil {
+		return nil, nil
+	}
+
+	creationInfo, err := fetchCreationInfo(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return creationInfo.IdempotencyKey, nil
+}
+
+// deleteIdempotencyIndexEntries removes the given idempotency keys from the
+// idempotency index.
+func deleteIdempotencyIndexEntries(tx kvdb.RwTx, keys [][]byte) error {
+	idempotencyIndex := tx.ReadWriteBucket(paymentsIdempotencyIndexBucket)
+	if idempotencyIndex == nil {
+		return nil
+	}
+
+	for _, key := range keys {
+		if len(key) == 0 {
+			continue
+		}
+
+		if err := idempotencyIndex.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func fetchPayment(bucket kvdb.RBucket) (*MPPayment, error) {
 	seqBytes := bucket.Get(paymentSequenceKey)
 	if seqBytes == nil {
@@ -800,10 +884,22 @@ func (d *DB) DeletePayment(paymentHash lntypes.Hash,
 			return err
 		}
 
+		idempotencyKey, err := fetchIdempotencyKey(bucket)
+		if err != nil {
+			return err
+		}
+
 		if err := payments.DeleteNestedBucket(paymentHash[:]); err != nil {
 			return err
 		}
 
+		err = deleteIdempotencyIndexEntries(
+			tx, [][]byte{idempotencyKey},
+		)
+		if err != nil {
+			return err
+		}
+
 		indexBucket := tx.ReadWriteBucket(paymentsIndexBucket)
 		for _, k := range seqNrs {
 			if err := indexBucket.Delete(k); err != nil {
@@ -835,6 +931,11 @@ func (d *DB) DeletePayments(failedOnly, failedHtlcsOnly bool) error {
 			// payments that need to be deleted.
 			deleteIndexes [][]byte
 
+			// deleteIdempotencyKeys is the set of idempotency keys
+			// of these payments that need to be removed from the
+			// idempotency index.
+			deleteIdempotencyKeys [][]byte
+
 			// deleteHtlcs maps a payment hash to the HTLC IDs we
 			// want to delete for that payment.
 			deleteHtlcs = make(map[lntypes.Hash][][]byte)
@@ -897,6 +998,15 @@ func (d *DB) DeletePayments(failedOnly, failedHtlcsOnly bool) error {
 			}
 
 			deleteIndexes = append(deleteIndexes, seqNrs...)
+
+			idempotencyKey, err := fetchIdempotencyKey(bucket)
+			if err != nil {
+				return err
+			}
+			deleteIdempotencyKeys = append(
+				deleteIdempotencyKeys, idempotencyKey,
+			)
+
 			return nil
 		})
 		if err != nil {
@@ -946,7 +1056,7 @@ func (d *DB) DeletePayments(failedOnly, failedHtlcsOnly bool) error {
 			}
 		}
 
-		return nil
+		return deleteIdempotencyIndexEntries(tx, deleteIdempotencyKeys)
 	}, func() {})
 }
 
@@ -1006,6 +1116,91 @@ func serializePaymentCreationInfo(w io.Writer, c *PaymentCreationInfo) error {
 		return err
 	}
 
+	// The metadata, custom records and idempotency key are appended as a
+	// tlv stream, which older versions ignore.
+	if len(c.Metadata) == 0 && len(c.DestCustomRecords) == 0 &&
+		len(c.IdempotencyKey) == 0 {
+
+		return nil
+	}
+
+	return serializePaymentCreationExtra(w, c)
+}
+
+func serializePaymentCreationExtra(w io.Writer, c *PaymentCreationInfo) error {
+	var records []tlv.Record
+	if len(c.Metadata) != 0 {
+		records = append(records, tlv.MakePrimitiveRecord(
+			paymentMetadataType, &c.Metadata,
+		))
+	}
+
+	if len(c.DestCustomRecords) != 0 {
+		customRecords, err := lnwire.CustomRecords(
+			c.DestCustomRecords,
+		).Serialize()
+		if err != nil {
+			return err
+		}
+
+		records = append(records, tlv.MakePrimitiveRecord(
+			paymentDestCustomRecordsType, &customRecords,
+		))
+	}
+
+	if len(c.IdempotencyKey) != 0 {
+		records = append(records, tlv.MakePrimitiveRecord(
+			paymentIdempotencyKeyType, &c.IdempotencyKey,
+		))
+	}
+
+	tlvStream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
+}
+
+func deserializePaymentCreationExtra(r io.Reader,
+	c *PaymentCreationInfo) error {
+
+	var metadata, customRecords, idempotencyKey []byte
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(paymentMetadataType, &metadata),
+		tlv.MakePrimitiveRecord(
+			paymentDestCustomRecordsType, &customRecords,
+		),
+		tlv.MakePrimitiveRecord(
+			paymentIdempotencyKeyType, &idempotencyKey,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	typeMap, err := tlvStream.DecodeWithParsedTypes(r)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := typeMap[paymentMetadataType]; ok {
+		c.Metadata = metadata
+	}
+
+	if _, ok := typeMap[paymentDestCustomRecordsType]; ok {
+		records, err := lnwire.ParseCustomRecords(customRecords)
+		if err != nil {
+			return err
+		}
+
+		c.DestCustomRecords = record.CustomSet(records)
+	}
+
+	if _, ok := typeMap[paymentIdempotencyKeyType]; ok {
+		c.IdempotencyKey = idempotencyKey
+	}
+
 	return nil
 }
 
@@ -1042,6 +1237,21 @@ func deserializePaymentCreationInfo(r io.Reader) (*PaymentCreationInfo, error) {
 	}
 	c.PaymentRequest = payReq
 
+	// Payments of newer versions may carry their metadata and custom
+	// records after the payment request.
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) == 0 {
+		return c, nil
+	}
+
+	err = deserializePaymentCreationExtra(bytes.NewReader(rest), c)
+	if err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -1067,7 +1277,51 @@ func serializeHTLCAttemptInfo(w io.Writer, a *HTLCAttemptInfo) error {
 		return err
 	}
 
-	return nil
+	// The split info is appended as a tlv stream, which older versions
+	// ignore.
+	if a.SplitInfo == nil {
+		return nil
+	}
+
+	return serializeHTLCSplitInfo(w, a.SplitInfo)
+}
+
+func serializeHTLCSplitInfo(w io.Writer, s *HTLCSplitInfo) error {
+	remainingAmt := uint64(s.RemainingAmt)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(htlcSplitStrategyType, &s.Strategy),
+		tlv.MakePrimitiveRecord(
+			htlcSplitRemainingAmtType, &remainingAmt,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
+}
+
+func deserializeHTLCSplitInfo(r io.Reader) (*HTLCSplitInfo, error) {
+	var (
+		s            HTLCSplitInfo
+		remainingAmt uint64
+	)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(htlcSplitStrategyType, &s.Strategy),
+		tlv.MakePrimitiveRecord(
+			htlcSplitRemainingAmtType, &remainingAmt,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tlvStream.Decode(r); err != nil {
+		return nil, err
+	}
+	s.RemainingAmt = lnwire.MilliSatoshi(remainingAmt)
+
+	return &s, nil
 }
 
 func deserializeHTLCAttemptInfo(r io.Reader) (*HTLCAttemptInfo, error) {
@@ -1104,6 +1358,21 @@ func deserializeHTLCAttemptInfo(r io.Reader) (*HTLCAttemptInfo, error) {
 
 	a.Hash = &hash
 
+	// Attempts of newer versions may carry their split info after the
+	// hash.
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) == 0 {
+		return a, nil
+	}
+
+	a.SplitInfo, err = deserializeHTLCSplitInfo(bytes.NewReader(rest))
+	if err != nil {
+		return nil, err
+	}
+
 	return a, nil
 }
 
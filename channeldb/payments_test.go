@@ -171,6 +171,79 @@ func TestSentPaymentSerialization(t *testing.T) {
 	}
 }
 
+// TestPaymentCreationInfoExtraSerialization tests that the metadata and custom
+// records of a payment survive serialization and that payments without them
+// are still read correctly.
+func TestPaymentCreationInfoExtraSerialization(t *testing.T) {
+	t.Parallel()
+
+	c, _ := makeFakeInfo()
+	c.Metadata = []byte{1, 2, 3}
+	c.DestCustomRecords = record.CustomSet{
+		record.CustomTypeStart:     {4, 5},
+		record.CustomTypeStart + 1: {6},
+	}
+	c.IdempotencyKey = []byte{7, 8}
+
+	var b bytes.Buffer
+	require.NoError(t, serializePaymentCreationInfo(&b, c))
+
+	newInfo, err := deserializePaymentCreationInfo(&b)
+	require.NoError(t, err)
+	require.Equal(t, c, newInfo)
+
+	// Only setting one of them works as well.
+	c.Metadata = nil
+	b.Reset()
+	require.NoError(t, serializePaymentCreationInfo(&b, c))
+
+	newInfo, err = deserializePaymentCreationInfo(&b)
+	require.NoError(t, err)
+	require.Equal(t, c, newInfo)
+
+	// A payment without them is read back without them.
+	c.DestCustomRecords = nil
+	c.IdempotencyKey = nil
+	b.Reset()
+	require.NoError(t, serializePaymentCreationInfo(&b, c))
+
+	newInfo, err = deserializePaymentCreationInfo(&b)
+	require.NoError(t, err)
+	require.Nil(t, newInfo.Metadata)
+	require.Nil(t, newInfo.DestCustomRecords)
+	require.Nil(t, newInfo.IdempotencyKey)
+}
+
+// TestHTLCAttemptSplitInfoSerialization tests that the split info of an HTLC
+// attempt survives serialization and that attempts without it are still read
+// correctly.
+func TestHTLCAttemptSplitInfoSerialization(t *testing.T) {
+	t.Parallel()
+
+	_, s := makeFakeInfo()
+	s.SplitInfo = &HTLCSplitInfo{
+		Strategy:     2,
+		RemainingAmt: 5000,
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, serializeHTLCAttemptInfo(&b, s))
+
+	newInfo, err := deserializeHTLCAttemptInfo(&b)
+	require.NoError(t, err)
+	require.Equal(t, s.SplitInfo, newInfo.SplitInfo)
+	require.Equal(t, s.Hash, newInfo.Hash)
+
+	// An attempt without split info is read back without it.
+	s.SplitInfo = nil
+	b.Reset()
+	require.NoError(t, serializeHTLCAttemptInfo(&b, s))
+
+	newInfo, err = deserializeHTLCAttemptInfo(&b)
+	require.NoError(t, err)
+	require.Nil(t, newInfo.SplitInfo)
+}
+
 // assertRouteEquals compares to routes for equality and returns an error if
 // they are not equal.
 func assertRouteEqual(a, b *route.Route) error {
@@ -0,0 +1,60 @@
+package channeldb
+
+import (
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// peerStorageBucket is the database bucket used to store the blobs
+	// that our peers asked us to store on their behalf, keyed by the
+	// compressed public key of the peer.
+	//
+	// peer-storage
+	//      |
+	//      |-- <peer-pubkey>: <blob>
+	peerStorageBucket = []byte("peer-storage")
+)
+
+// SetPeerStorage stores the blob that the peer with the given public key asked
+// us to store, replacing the blob stored before. An empty blob removes the
+// stored blob of the peer.
+func (c *ChannelStateDB) SetPeerStorage(peer [33]byte, blob []byte) error {
+	return kvdb.Update(c.backend, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(peerStorageBucket)
+		if err != nil {
+			return err
+		}
+
+		if len(blob) == 0 {
+			return bucket.Delete(peer[:])
+		}
+
+		return bucket.Put(peer[:], blob)
+	}, func() {})
+}
+
+// FetchPeerStorage returns the blob stored for the peer with the given public
+// key. If no blob is stored for the peer, nil is returned.
+func (c *ChannelStateDB) FetchPeerStorage(peer [33]byte) ([]byte, error) {
+	var blob []byte
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(peerStorageBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		if stored := bucket.Get(peer[:]); stored != nil {
+			blob = make([]byte, len(stored))
+			copy(blob, stored)
+		}
+
+		return nil
+	}, func() {
+		blob = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
@@ -0,0 +1,51 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerStorage tests that the blobs of peers can be stored, replaced,
+// fetched and removed.
+func TestPeerStorage(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	cdb := fullDB.ChannelStateDB()
+
+	peer1 := [33]byte{1}
+	peer2 := [33]byte{2}
+
+	// Without any blob stored, nothing should be returned.
+	blob, err := cdb.FetchPeerStorage(peer1)
+	require.NoError(t, err)
+	require.Nil(t, blob)
+
+	require.NoError(t, cdb.SetPeerStorage(peer1, []byte{1, 2, 3}))
+	require.NoError(t, cdb.SetPeerStorage(peer2, []byte{4, 5}))
+
+	blob, err = cdb.FetchPeerStorage(peer1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, blob)
+
+	// A new blob replaces the previous one.
+	require.NoError(t, cdb.SetPeerStorage(peer1, []byte{6}))
+
+	blob, err = cdb.FetchPeerStorage(peer1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{6}, blob)
+
+	// Storing an empty blob removes the blob of the peer.
+	require.NoError(t, cdb.SetPeerStorage(peer1, nil))
+
+	blob, err = cdb.FetchPeerStorage(peer1)
+	require.NoError(t, err)
+	require.Nil(t, blob)
+
+	blob, err = cdb.FetchPeerStorage(peer2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4, 5}, blob)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/lightningnetwork/lnd/kvdb"
@@ -20,6 +21,9 @@ var (
 	//      |
 	//      |-- <peer-pubkey>
 	//      |        |--flap-count-key: <ts><flap count>
+	//      |        |--peer-policy-key: <peer policy>
+	//      |        |--peer-conn-policy-key: <peer connection policy>
+	//      |        |--peer-gossip-policy-key: <peer gossip policy>
 	//      |
 	//      |-- <peer-pubkey>
 	//      |        |--flap-count-key: <ts><flap count>
@@ -29,12 +33,37 @@ var (
 	// the timestamp of a peer's last flap count and its all time flap
 	// count.
 	flapCountKey = []byte("flap-count")
+
+	// peerPolicyKey is a key used in the peer pubkey sub-bucket that
+	// stores the default parameters of channels opened to the peer.
+	peerPolicyKey = []byte("peer-policy")
+
+	// peerConnPolicyKey is a key used in the peer pubkey sub-bucket that
+	// stores how we maintain the connection to the peer.
+	peerConnPolicyKey = []byte("peer-conn-policy")
+
+	// peerGossipPolicyKey is a key used in the peer pubkey sub-bucket that
+	// stores how we exchange gossip with the peer.
+	peerGossipPolicyKey = []byte("peer-gossip-policy")
 )
 
 var (
 	// ErrNoPeerBucket is returned when we try to read entries for a peer
 	// that is not tracked.
 	ErrNoPeerBucket = errors.New("peer bucket not found")
+
+	// ErrNoPeerPolicy is returned when we try to read the policy of a peer
+	// that doesn't have one.
+	ErrNoPeerPolicy = errors.New("peer policy not found")
+
+	// ErrNoPeerConnectionPolicy is returned when we try to read the
+	// connection policy of a peer that doesn't have one.
+	ErrNoPeerConnectionPolicy = errors.New("peer connection policy not " +
+		"found")
+
+	// ErrNoPeerGossipPolicy is returned when we try to read the gossip
+	// policy of a peer that doesn't have one.
+	ErrNoPeerGossipPolicy = errors.New("peer gossip policy not found")
 )
 
 // FlapCount contains information about a peer's flap count.
@@ -121,3 +150,485 @@ func (d *DB) ReadFlapCount(pubkey route.Vertex) (*FlapCount, error) {
 
 	return &flapCount, nil
 }
+
+// PeerPolicy contains the default parameters of the channels we open to a
+// peer. A zero value means that no default is set for the parameter.
+type PeerPolicy struct {
+	// SatPerVByte is the fee rate of the funding transaction.
+	SatPerVByte uint64
+
+	// CommitmentType is the commitment type of the channel, using the
+	// values of the lnrpc.CommitmentType enum.
+	CommitmentType uint8
+
+	// RemoteCsvDelay is the CSV delay the peer has to wait for to claim
+	// its funds after a force close.
+	RemoteCsvDelay uint16
+
+	// RemoteMaxHtlcs is the maximum number of HTLCs the peer may offer to
+	// us.
+	RemoteMaxHtlcs uint16
+
+	// Private indicates that the channel shouldn't be announced.
+	Private bool
+}
+
+// serializePeerPolicy writes the peer policy to the given writer.
+func serializePeerPolicy(w io.Writer, p *PeerPolicy) error {
+	return WriteElements(
+		w, p.SatPerVByte, p.CommitmentType, p.RemoteCsvDelay,
+		p.RemoteMaxHtlcs, p.Private,
+	)
+}
+
+// deserializePeerPolicy reads a peer policy from the given reader.
+func deserializePeerPolicy(r io.Reader) (*PeerPolicy, error) {
+	var p PeerPolicy
+	err := ReadElements(
+		r, &p.SatPerVByte, &p.CommitmentType, &p.RemoteCsvDelay,
+		&p.RemoteMaxHtlcs, &p.Private,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// WritePeerPolicy writes the policy of a peer to disk, creating a bucket for
+// the peer's pubkey if necessary. Any existing policy of the peer is
+// overwritten.
+func (d *DB) WritePeerPolicy(pubkey route.Vertex, policy *PeerPolicy) error {
+	var b bytes.Buffer
+	if err := serializePeerPolicy(&b, policy); err != nil {
+		return err
+	}
+
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		peers := tx.ReadWriteBucket(peersBucket)
+
+		peerBucket, err := peers.CreateBucketIfNotExists(pubkey[:])
+		if err != nil {
+			return err
+		}
+
+		return peerBucket.Put(peerPolicyKey, b.Bytes())
+	}, func() {})
+}
+
+// DeletePeerPolicy removes the policy of a peer. Deleting the policy of a peer
+// that doesn't have one is not an error.
+func (d *DB) DeletePeerPolicy(pubkey route.Vertex) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		peers := tx.ReadWriteBucket(peersBucket)
+
+		peerBucket := peers.NestedReadWriteBucket(pubkey[:])
+		if peerBucket == nil {
+			return nil
+		}
+
+		return peerBucket.Delete(peerPolicyKey)
+	}, func() {})
+}
+
+// ReadPeerPolicy reads the policy of a peer, returning ErrNoPeerPolicy if the
+// peer doesn't have one.
+func (d *DB) ReadPeerPolicy(pubkey route.Vertex) (*PeerPolicy, error) {
+	var policy *PeerPolicy
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		peers := tx.ReadBucket(peersBucket)
+
+		peerBucket := peers.NestedReadBucket(pubkey[:])
+		if peerBucket == nil {
+			return ErrNoPeerPolicy
+		}
+
+		policyBytes := peerBucket.Get(peerPolicyKey)
+		if policyBytes == nil {
+			return ErrNoPeerPolicy
+		}
+
+		var err error
+		policy, err = deserializePeerPolicy(
+			bytes.NewReader(policyBytes),
+		)
+
+		return err
+	}, func() {
+		policy = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ReadPeerPolicies returns the policies of all peers that have one.
+func (d *DB) ReadPeerPolicies() (map[route.Vertex]*PeerPolicy, error) {
+	var policies map[route.Vertex]*PeerPolicy
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		peers := tx.ReadBucket(peersBucket)
+
+		return peers.ForEach(func(k, v []byte) error {
+			// Only the peer sub-buckets are of interest.
+			if v != nil {
+				return nil
+			}
+
+			peerBucket := peers.NestedReadBucket(k)
+			policyBytes := peerBucket.Get(peerPolicyKey)
+			if policyBytes == nil {
+				return nil
+			}
+
+			pubkey, err := route.NewVertexFromBytes(k)
+			if err != nil {
+				return err
+			}
+
+			policy, err := deserializePeerPolicy(
+				bytes.NewReader(policyBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			policies[pubkey] = policy
+
+			return nil
+		})
+	}, func() {
+		policies = make(map[route.Vertex]*PeerPolicy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// PeerConnectionPolicy describes how we maintain the connection to a peer. A
+// zero value means that the default behavior applies to the peer, which is to
+// only keep reconnecting to peers that we have channels with.
+type PeerConnectionPolicy struct {
+	// AlwaysReconnect indicates that we keep reconnecting to the peer even
+	// if we don't have any channels with it.
+	AlwaysReconnect bool
+
+	// MaxBackoff is the ceiling of the backoff between two reconnection
+	// attempts. If zero, the global maximum backoff is used.
+	MaxBackoff time.Duration
+
+	// PreferredAddress is the address that is tried first when connecting
+	// to the peer, in addition to the addresses the peer advertises.
+	PreferredAddress string
+
+	// TorOnly indicates that we only connect to the peer over Tor.
+	TorOnly bool
+}
+
+// serializePeerConnectionPolicy writes the peer connection policy to the
+// given writer.
+func serializePeerConnectionPolicy(w io.Writer,
+	p *PeerConnectionPolicy) error {
+
+	return WriteElements(
+		w, p.AlwaysReconnect, uint64(p.MaxBackoff),
+		[]byte(p.PreferredAddress), p.TorOnly,
+	)
+}
+
+// deserializePeerConnectionPolicy reads a peer connection policy from the
+// given reader.
+func deserializePeerConnectionPolicy(
+	r io.Reader) (*PeerConnectionPolicy, error) {
+
+	var (
+		p                PeerConnectionPolicy
+		maxBackoff       uint64
+		preferredAddress []byte
+	)
+	err := ReadElements(
+		r, &p.AlwaysReconnect, &maxBackoff, &preferredAddress,
+		&p.TorOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.MaxBackoff = time.Duration(maxBackoff)
+	p.PreferredAddress = string(preferredAddress)
+
+	return &p, nil
+}
+
+// WritePeerConnectionPolicy writes the connection policy of a peer to disk,
+// creating a bucket for the peer's pubkey if necessary. Any existing
+// connection policy of the peer is overwritten.
+func (d *DB) WritePeerConnectionPolicy(pubkey route.Vertex,
+	policy *PeerConnectionPolicy) error {
+
+	var b bytes.Buffer
+	if err := serializePeerConnectionPolicy(&b, policy); err != nil {
+		return err
+	}
+
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		peers := tx.ReadWriteBucket(peersBucket)
+
+		peerBucket, err := peers.CreateBucketIfNotExists(pubkey[:])
+		if err != nil {
+			return err
+		}
+
+		return peerBucket.Put(peerConnPolicyKey, b.Bytes())
+	}, func() {})
+}
+
+// DeletePeerConnectionPolicy removes the connection policy of a peer.
+// Deleting the connection policy of a peer that doesn't have one is not an
+// error.
+func (d *DB) DeletePeerConnectionPolicy(pubkey route.Vertex) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		peers := tx.ReadWriteBucket(peersBucket)
+
+		peerBucket := peers.NestedReadWriteBucket(pubkey[:])
+		if peerBucket == nil {
+			return nil
+		}
+
+		return peerBucket.Delete(peerConnPolicyKey)
+	}, func() {})
+}
+
+// ReadPeerConnectionPolicy reads the connection policy of a peer, returning
+// ErrNoPeerConnectionPolicy if the peer doesn't have one.
+func (d *DB) ReadPeerConnectionPolicy(
+	pubkey route.Vertex) (*PeerConnectionPolicy, error) {
+
+	var policy *PeerConnectionPolicy
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		peers := tx.ReadBucket(peersBucket)
+
+		peerBucket := peers.NestedReadBucket(pubkey[:])
+		if peerBucket == nil {
+			return ErrNoPeerConnectionPolicy
+		}
+
+		policyBytes := peerBucket.Get(peerConnPolicyKey)
+		if policyBytes == nil {
+			return ErrNoPeerConnectionPolicy
+		}
+
+		var err error
+		policy, err = deserializePeerConnectionPolicy(
+			bytes.NewReader(policyBytes),
+		)
+
+		return err
+	}, func() {
+		policy = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ReadPeerConnectionPolicies returns the connection policies of all peers that
+// have one.
+func (d *DB) ReadPeerConnectionPolicies() (
+	map[route.Vertex]*PeerConnectionPolicy, error) {
+
+	var policies map[route.Vertex]*PeerConnectionPolicy
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		peers := tx.ReadBucket(peersBucket)
+
+		return peers.ForEach(func(k, v []byte) error {
+			// Only the peer sub-buckets are of interest.
+			if v != nil {
+				return nil
+			}
+
+			peerBucket := peers.NestedReadBucket(k)
+			policyBytes := peerBucket.Get(peerConnPolicyKey)
+			if policyBytes == nil {
+				return nil
+			}
+
+			pubkey, err := route.NewVertexFromBytes(k)
+			if err != nil {
+				return err
+			}
+
+			policy, err := deserializePeerConnectionPolicy(
+				bytes.NewReader(policyBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			policies[pubkey] = policy
+
+			return nil
+		})
+	}, func() {
+		policies = make(map[route.Vertex]*PeerConnectionPolicy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// PeerGossipPolicy describes how we exchange gossip with a peer. A zero value
+// means that the default behavior applies to the peer.
+type PeerGossipPolicy struct {
+	// DisableGossip indicates that we don't send any gossip to the peer.
+	// Its gossip queries are answered without any channels.
+	DisableGossip bool
+
+	// RateLimit is the maximum number of gossip messages per second that
+	// we send to the peer in reply to its gossip queries and gossip
+	// timestamp filter. If zero, the global gossip rate limit is used.
+	RateLimit uint32
+}
+
+// serializePeerGossipPolicy writes the peer gossip policy to the given writer.
+func serializePeerGossipPolicy(w io.Writer, p *PeerGossipPolicy) error {
+	return WriteElements(w, p.DisableGossip, p.RateLimit)
+}
+
+// deserializePeerGossipPolicy reads a peer gossip policy from the given
+// reader.
+func deserializePeerGossipPolicy(r io.Reader) (*PeerGossipPolicy, error) {
+	var p PeerGossipPolicy
+	if err := ReadElements(r, &p.DisableGossip, &p.RateLimit); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// WritePeerGossipPolicy writes the gossip policy of a peer to disk, creating a
+// bucket for the peer's pubkey if necessary. Any existing gossip policy of the
+// peer is overwritten.
+func (d *DB) WritePeerGossipPolicy(pubkey route.Vertex,
+	policy *PeerGossipPolicy) error {
+
+	var b bytes.Buffer
+	if err := serializePeerGossipPolicy(&b, policy); err != nil {
+		return err
+	}
+
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		peers := tx.ReadWriteBucket(peersBucket)
+
+		peerBucket, err := peers.CreateBucketIfNotExists(pubkey[:])
+		if err != nil {
+			return err
+		}
+
+		return peerBucket.Put(peerGossipPolicyKey, b.Bytes())
+	}, func() {})
+}
+
+// DeletePeerGossipPolicy removes the gossip policy of a peer. Deleting the
+// gossip policy of a peer that doesn't have one is not an error.
+func (d *DB) DeletePeerGossipPolicy(pubkey route.Vertex) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		peers := tx.ReadWriteBucket(peersBucket)
+
+		peerBucket := peers.NestedReadWriteBucket(pubkey[:])
+		if peerBucket == nil {
+			return nil
+		}
+
+		return peerBucket.Delete(peerGossipPolicyKey)
+	}, func() {})
+}
+
+// ReadPeerGossipPolicy reads the gossip policy of a peer, returning
+// ErrNoPeerGossipPolicy if the peer doesn't have one.
+func (d *DB) ReadPeerGossipPolicy(
+	pubkey route.Vertex) (*PeerGossipPolicy, error) {
+
+	var policy *PeerGossipPolicy
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		peers := tx.ReadBucket(peersBucket)
+
+		peerBucket := peers.NestedReadBucket(pubkey[:])
+		if peerBucket == nil {
+			return ErrNoPeerGossipPolicy
+		}
+
+		policyBytes := peerBucket.Get(peerGossipPolicyKey)
+		if policyBytes == nil {
+			return ErrNoPeerGossipPolicy
+		}
+
+		var err error
+		policy, err = deserializePeerGossipPolicy(
+			bytes.NewReader(policyBytes),
+		)
+
+		return err
+	}, func() {
+		policy = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ReadPeerGossipPolicies returns the gossip policies of all peers that have
+// one.
+func (d *DB) ReadPeerGossipPolicies() (map[route.Vertex]*PeerGossipPolicy,
+	error) {
+
+	var policies map[route.Vertex]*PeerGossipPolicy
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		peers := tx.ReadBucket(peersBucket)
+
+		return peers.ForEach(func(k, v []byte) error {
+			// Only the peer sub-buckets are of interest.
+			if v != nil {
+				return nil
+			}
+
+			peerBucket := peers.NestedReadBucket(k)
+			policyBytes := peerBucket.Get(peerGossipPolicyKey)
+			if policyBytes == nil {
+				return nil
+			}
+
+			pubkey, err := route.NewVertexFromBytes(k)
+			if err != nil {
+				return err
+			}
+
+			policy, err := deserializePeerGossipPolicy(
+				bytes.NewReader(policyBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			policies[pubkey] = policy
+
+			return nil
+		})
+	}, func() {
+		policies = make(map[route.Vertex]*PeerGossipPolicy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
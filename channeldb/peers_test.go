@@ -47,3 +47,180 @@ func TestFlapCount(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, peer2FlapCount, count)
 }
+
+// TestPeerPolicy tests writing, reading and deleting peer policies.
+func TestPeerPolicy(t *testing.T) {
+	db, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	// Reading the policy of an unknown peer should fail.
+	_, err = db.ReadPeerPolicy(testPub)
+	require.ErrorIs(t, err, ErrNoPeerPolicy)
+
+	// A peer that only has a flap count doesn't have a policy either.
+	testPub2 := route.Vertex{2, 2, 2}
+	err = db.WriteFlapCounts(map[route.Vertex]*FlapCount{
+		testPub2: {Count: 1, LastFlap: time.Unix(100, 0)},
+	})
+	require.NoError(t, err)
+
+	_, err = db.ReadPeerPolicy(testPub2)
+	require.ErrorIs(t, err, ErrNoPeerPolicy)
+
+	policies, err := db.ReadPeerPolicies()
+	require.NoError(t, err)
+	require.Empty(t, policies)
+
+	policy := &PeerPolicy{
+		SatPerVByte:    12,
+		CommitmentType: 3,
+		RemoteCsvDelay: 288,
+		RemoteMaxHtlcs: 100,
+		Private:        true,
+	}
+	require.NoError(t, db.WritePeerPolicy(testPub, policy))
+
+	readPolicy, err := db.ReadPeerPolicy(testPub)
+	require.NoError(t, err)
+	require.Equal(t, policy, readPolicy)
+
+	// Overwriting the policy must replace all parameters.
+	policy2 := &PeerPolicy{SatPerVByte: 5}
+	require.NoError(t, db.WritePeerPolicy(testPub2, policy))
+	require.NoError(t, db.WritePeerPolicy(testPub2, policy2))
+
+	policies, err = db.ReadPeerPolicies()
+	require.NoError(t, err)
+	require.Equal(t, map[route.Vertex]*PeerPolicy{
+		testPub:  policy,
+		testPub2: policy2,
+	}, policies)
+
+	// Deleting the policy must leave the flap count in place.
+	require.NoError(t, db.DeletePeerPolicy(testPub2))
+	require.NoError(t, db.DeletePeerPolicy(testPub2))
+
+	_, err = db.ReadPeerPolicy(testPub2)
+	require.ErrorIs(t, err, ErrNoPeerPolicy)
+
+	_, err = db.ReadFlapCount(testPub2)
+	require.NoError(t, err)
+}
+
+// TestPeerConnectionPolicy tests that the connection policies of peers are
+// stored independently of their other data.
+func TestPeerConnectionPolicy(t *testing.T) {
+	db, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	// Reading the connection policy of an unknown peer should fail.
+	_, err = db.ReadPeerConnectionPolicy(testPub)
+	require.ErrorIs(t, err, ErrNoPeerConnectionPolicy)
+
+	// A peer that only has a channel policy doesn't have a connection
+	// policy.
+	testPub2 := route.Vertex{2, 2, 2}
+	peerPolicy := &PeerPolicy{SatPerVByte: 5}
+	require.NoError(t, db.WritePeerPolicy(testPub2, peerPolicy))
+
+	_, err = db.ReadPeerConnectionPolicy(testPub2)
+	require.ErrorIs(t, err, ErrNoPeerConnectionPolicy)
+
+	policies, err := db.ReadPeerConnectionPolicies()
+	require.NoError(t, err)
+	require.Empty(t, policies)
+
+	policy := &PeerConnectionPolicy{
+		AlwaysReconnect:  true,
+		MaxBackoff:       5 * time.Minute,
+		PreferredAddress: "example.onion:9735",
+		TorOnly:          true,
+	}
+	require.NoError(t, db.WritePeerConnectionPolicy(testPub, policy))
+
+	readPolicy, err := db.ReadPeerConnectionPolicy(testPub)
+	require.NoError(t, err)
+	require.Equal(t, policy, readPolicy)
+
+	// Overwriting the connection policy must replace all parameters.
+	policy2 := &PeerConnectionPolicy{AlwaysReconnect: true}
+	require.NoError(t, db.WritePeerConnectionPolicy(testPub2, policy))
+	require.NoError(t, db.WritePeerConnectionPolicy(testPub2, policy2))
+
+	policies, err = db.ReadPeerConnectionPolicies()
+	require.NoError(t, err)
+	require.Equal(t, map[route.Vertex]*PeerConnectionPolicy{
+		testPub:  policy,
+		testPub2: policy2,
+	}, policies)
+
+	// Deleting the connection policy must leave the channel policy in
+	// place.
+	require.NoError(t, db.DeletePeerConnectionPolicy(testPub2))
+	require.NoError(t, db.DeletePeerConnectionPolicy(testPub2))
+
+	_, err = db.ReadPeerConnectionPolicy(testPub2)
+	require.ErrorIs(t, err, ErrNoPeerConnectionPolicy)
+
+	readPeerPolicy, err := db.ReadPeerPolicy(testPub2)
+	require.NoError(t, err)
+	require.Equal(t, peerPolicy, readPeerPolicy)
+}
+
+// TestPeerGossipPolicy tests writing, reading and deleting the gossip policies
+// of peers.
+func TestPeerGossipPolicy(t *testing.T) {
+	db, err := MakeTestDB(t)
+	require.NoError(t, err)
+
+	// Reading the gossip policy of an unknown peer should fail.
+	_, err = db.ReadPeerGossipPolicy(testPub)
+	require.ErrorIs(t, err, ErrNoPeerGossipPolicy)
+
+	// A peer that only has a connection policy doesn't have a gossip
+	// policy.
+	testPub2 := route.Vertex{2, 2, 2}
+	connPolicy := &PeerConnectionPolicy{AlwaysReconnect: true}
+	require.NoError(t, db.WritePeerConnectionPolicy(testPub2, connPolicy))
+
+	_, err = db.ReadPeerGossipPolicy(testPub2)
+	require.ErrorIs(t, err, ErrNoPeerGossipPolicy)
+
+	policies, err := db.ReadPeerGossipPolicies()
+	require.NoError(t, err)
+	require.Empty(t, policies)
+
+	policy := &PeerGossipPolicy{
+		DisableGossip: true,
+		RateLimit:     10,
+	}
+	require.NoError(t, db.WritePeerGossipPolicy(testPub, policy))
+
+	readPolicy, err := db.ReadPeerGossipPolicy(testPub)
+	require.NoError(t, err)
+	require.Equal(t, policy, readPolicy)
+
+	// Overwriting the gossip policy must replace all parameters.
+	policy2 := &PeerGossipPolicy{RateLimit: 5}
+	require.NoError(t, db.WritePeerGossipPolicy(testPub2, policy))
+	require.NoError(t, db.WritePeerGossipPolicy(testPub2, policy2))
+
+	policies, err = db.ReadPeerGossipPolicies()
+	require.NoError(t, err)
+	require.Equal(t, map[route.Vertex]*PeerGossipPolicy{
+		testPub:  policy,
+		testPub2: policy2,
+	}, policies)
+
+	// Deleting the gossip policy must leave the connection policy in
+	// place.
+	require.NoError(t, db.DeletePeerGossipPolicy(testPub2))
+	require.NoError(t, db.DeletePeerGossipPolicy(testPub2))
+
+	_, err = db.ReadPeerGossipPolicy(testPub2)
+	require.ErrorIs(t, err, ErrNoPeerGossipPolicy)
+
+	readConnPolicy, err := db.ReadPeerConnectionPolicy(testPub2)
+	require.NoError(t, err)
+	require.Equal(t, connPolicy, readConnPolicy)
+}
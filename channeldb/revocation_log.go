@@ -233,12 +233,34 @@ type RevocationLog struct {
 func putRevocationLog(bucket kvdb.RwBucket, commit *ChannelCommitment,
 	ourOutputIndex, theirOutputIndex uint32, noAmtData bool) error {
 
+	rl, err := newRevocationLog(
+		commit, ourOutputIndex, theirOutputIndex, noAmtData,
+	)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	err = serializeRevocationLog(&b, rl)
+	if err != nil {
+		return err
+	}
+
+	logEntrykey := makeLogKey(commit.CommitHeight)
+	return bucket.Put(logEntrykey[:], b.Bytes())
+}
+
+// newRevocationLog constructs the revocation log entry of the given revoked
+// commitment.
+func newRevocationLog(commit *ChannelCommitment, ourOutputIndex,
+	theirOutputIndex uint32, noAmtData bool) (*RevocationLog, error) {
+
 	// Sanity check that the output indexes can be safely converted.
 	if ourOutputIndex > math.MaxUint16 {
-		return ErrOutputIndexTooBig
+		return nil, ErrOutputIndexTooBig
 	}
 	if theirOutputIndex > math.MaxUint16 {
-		return ErrOutputIndexTooBig
+		return nil, ErrOutputIndexTooBig
 	}
 
 	rl := &RevocationLog{
@@ -262,7 +284,7 @@ func putRevocationLog(bucket kvdb.RwBucket, commit *ChannelCommitment,
 		// Sanity check that the output indexes can be safely
 		// converted.
 		if htlc.OutputIndex > math.MaxUint16 {
-			return ErrOutputIndexTooBig
+			return nil, ErrOutputIndexTooBig
 		}
 
 		entry := &HTLCEntry{
@@ -275,14 +297,7 @@ func putRevocationLog(bucket kvdb.RwBucket, commit *ChannelCommitment,
 		rl.HTLCEntries = append(rl.HTLCEntries, entry)
 	}
 
-	var b bytes.Buffer
-	err := serializeRevocationLog(&b, rl)
-	if err != nil {
-		return err
-	}
-
-	logEntrykey := makeLogKey(commit.CommitHeight)
-	return bucket.Put(logEntrykey[:], b.Bytes())
+	return rl, nil
 }
 
 // fetchRevocationLog queries the revocation log bucket to find an log entry.
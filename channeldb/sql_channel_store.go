@@ -0,0 +1,869 @@
+package channeldb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/lightningnetwork/lnd/sqldb/sqlc"
+)
+
+// ErrChannelNotMirrored is returned when the SQL channel store is queried for
+// a channel that hasn't been written to it.
+var ErrChannelNotMirrored = errors.New("channel not found in SQL channel " +
+	"store")
+
+// ChannelStateMirror is a secondary channel state store that receives a copy
+// of every channel state write once it has been committed to the kvdb backed
+// ChannelStateDB. It allows a native SQL channel store to be populated in a
+// dual-write mode while the kvdb store stays authoritative.
+type ChannelStateMirror interface {
+	// PutChannel writes the static information of the channel.
+	PutChannel(c *OpenChannel) error
+
+	// PutCommitment writes the latest local or remote commitment of the
+	// channel.
+	PutCommitment(c *OpenChannel, commit *ChannelCommitment,
+		local bool) error
+
+	// PutRevocationLog writes the revocation log entry of the revoked
+	// remote commitment at the given height.
+	PutRevocationLog(c *OpenChannel, commitHeight uint64,
+		rl *RevocationLog) error
+
+	// PutFwdPkg writes a new forwarding package of the channel.
+	PutFwdPkg(c *OpenChannel, fwdPkg *FwdPkg) error
+
+	// SetFwdFilter sets the forwarding filter of the forwarding package at
+	// the given height.
+	SetFwdFilter(c *OpenChannel, height uint64, fwdFilter *PkgFilter) error
+
+	// AckAddHtlcs marks the referenced adds of the channel's forwarding
+	// packages as acknowledged.
+	AckAddHtlcs(c *OpenChannel, addRefs ...AddRef) error
+
+	// AckSettleFails marks the referenced settles and fails as delivered
+	// to the incoming link.
+	AckSettleFails(settleFailRefs ...SettleFailRef) error
+
+	// RemoveFwdPkgs removes the forwarding packages of the channel at the
+	// given heights.
+	RemoveFwdPkgs(c *OpenChannel, heights ...uint64) error
+
+	// CloseChannel marks the channel as closed and removes the state that
+	// is no longer needed for a closed channel.
+	CloseChannel(c *OpenChannel, summary *ChannelCloseSummary) error
+}
+
+// SQLChannelQueries is an interface that defines the set of operations that
+// can be executed against the channel state SQL database.
+type SQLChannelQueries interface { //nolint:interfacebloat
+	UpsertChannel(ctx context.Context, arg sqlc.UpsertChannelParams) (int64,
+		error)
+
+	GetChannel(ctx context.Context, chanPoint []byte) (sqlc.Channel, error)
+
+	GetOpenChannelIDBySCID(ctx context.Context,
+		shortChannelID int64) (int64, error)
+
+	MarkChannelClosed(ctx context.Context,
+		arg sqlc.MarkChannelClosedParams) error
+
+	UpsertChannelCommitment(ctx context.Context,
+		arg sqlc.UpsertChannelCommitmentParams) error
+
+	InsertChannelCommitment(ctx context.Context,
+		arg sqlc.InsertChannelCommitmentParams) error
+
+	GetChannelCommitment(ctx context.Context,
+		arg sqlc.GetChannelCommitmentParams) (sqlc.ChannelCommitment,
+		error)
+
+	InsertRevocationLogEntry(ctx context.Context,
+		arg sqlc.InsertRevocationLogEntryParams) error
+
+	GetRevocationLogEntry(ctx context.Context,
+		arg sqlc.GetRevocationLogEntryParams) (
+		sqlc.ChannelRevocationLog, error)
+
+	CountRevocationLogEntries(ctx context.Context,
+		channelID int64) (int64, error)
+
+	CountRevocationLogEntriesInRange(ctx context.Context,
+		arg sqlc.CountRevocationLogEntriesInRangeParams) (int64, error)
+
+	DeleteRevocationLog(ctx context.Context, channelID int64) error
+
+	UpsertFwdPkg(ctx context.Context, arg sqlc.UpsertFwdPkgParams) error
+
+	InsertFwdPkg(ctx context.Context, arg sqlc.InsertFwdPkgParams) error
+
+	GetFwdPkg(ctx context.Context,
+		arg sqlc.GetFwdPkgParams) (sqlc.ChannelFwdPackage, error)
+
+	FetchFwdPkgs(ctx context.Context,
+		channelID int64) ([]sqlc.ChannelFwdPackage, error)
+
+	UpdateFwdPkgFwdFilter(ctx context.Context,
+		arg sqlc.UpdateFwdPkgFwdFilterParams) error
+
+	UpdateFwdPkgAckFilter(ctx context.Context,
+		arg sqlc.UpdateFwdPkgAckFilterParams) error
+
+	UpdateFwdPkgSettleFailFilter(ctx context.Context,
+		arg sqlc.UpdateFwdPkgSettleFailFilterParams) error
+
+	DeleteFwdPkg(ctx context.Context, arg sqlc.DeleteFwdPkgParams) error
+
+	DeleteFwdPkgs(ctx context.Context, channelID int64) error
+}
+
+// SQLChannelQueriesTxOptions defines the set of db txn options the
+// SQLChannelQueries understands.
+type SQLChannelQueriesTxOptions struct {
+	// readOnly governs if a read only transaction is needed or not.
+	readOnly bool
+}
+
+// ReadOnly returns true if the transaction should be read only.
+//
+// NOTE: This implements the TxOptions.
+func (a *SQLChannelQueriesTxOptions) ReadOnly() bool {
+	return a.readOnly
+}
+
+// NewSQLChannelQueryReadTx creates a new read transaction option set.
+func NewSQLChannelQueryReadTx() SQLChannelQueriesTxOptions {
+	return SQLChannelQueriesTxOptions{
+		readOnly: true,
+	}
+}
+
+// BatchedSQLChannelQueries is a version of the SQLChannelQueries that's
+// capable of batched database operations.
+type BatchedSQLChannelQueries interface {
+	SQLChannelQueries
+
+	sqldb.BatchedTx[SQLChannelQueries]
+}
+
+// SQLChannelStore is a native SQL store for the state of open and closed
+// channels: their commitments, revocation logs and forwarding packages. For
+// now it is only populated as a ChannelStateMirror of the kvdb channel state.
+type SQLChannelStore struct {
+	db BatchedSQLChannelQueries
+}
+
+// A compile-time constraint to ensure SQLChannelStore implements the
+// ChannelStateMirror interface.
+var _ ChannelStateMirror = (*SQLChannelStore)(nil)
+
+// NewSQLChannelStore creates a new SQLChannelStore instance given an open
+// BatchedSQLChannelQueries storage backend.
+func NewSQLChannelStore(db BatchedSQLChannelQueries) *SQLChannelStore {
+	return &SQLChannelStore{
+		db: db,
+	}
+}
+
+// serializeChanPoint returns the serialized channel point used as the unique
+// key of a channel.
+func serializeChanPoint(chanPoint *wire.OutPoint) ([]byte, error) {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// upsertChannel writes the static information of the channel and returns its
+// database ID.
+func upsertChannel(ctx context.Context, db SQLChannelQueries,
+	c *OpenChannel) (int64, error) {
+
+	chanPoint, err := serializeChanPoint(&c.FundingOutpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	return db.UpsertChannel(ctx, sqlc.UpsertChannelParams{
+		ChanPoint:      chanPoint,
+		ChainHash:      c.ChainHash[:],
+		PeerPubkey:     c.IdentityPub.SerializeCompressed(),
+		ShortChannelID: int64(c.ShortChannelID.ToUint64()),
+		ChanType:       int64(c.ChanType),
+		CapacitySat:    int64(c.Capacity),
+		IsInitiator:    c.IsInitiator,
+	})
+}
+
+// fetchChannelID returns the database ID of the channel with the given
+// channel point.
+func fetchChannelID(ctx context.Context, db SQLChannelQueries,
+	chanPoint *wire.OutPoint) (int64, error) {
+
+	key, err := serializeChanPoint(chanPoint)
+	if err != nil {
+		return 0, err
+	}
+
+	dbChan, err := db.GetChannel(ctx, key)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, ErrChannelNotMirrored
+
+	case err != nil:
+		return 0, err
+	}
+
+	return dbChan.ID, nil
+}
+
+// encodePkgFilter returns the serialized filter, or nil if no filter is set.
+func encodePkgFilter(f *PkgFilter) ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	var b bytes.Buffer
+	if err := f.Encode(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// decodePkgFilter deserializes a filter written by encodePkgFilter.
+func decodePkgFilter(filterBytes []byte) (*PkgFilter, error) {
+	f := &PkgFilter{}
+	if err := f.Decode(bytes.NewReader(filterBytes)); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// PutChannel writes the static information of the channel.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) PutChannel(c *OpenChannel) error {
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		_, err := upsertChannel(ctx, db, c)
+		return err
+	}, func() {})
+}
+
+// PutCommitment writes the latest local or remote commitment of the channel.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) PutCommitment(c *OpenChannel,
+	commit *ChannelCommitment, local bool) error {
+
+	var b bytes.Buffer
+	if err := serializeChanCommit(&b, commit); err != nil {
+		return err
+	}
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := upsertChannel(ctx, db, c)
+		if err != nil {
+			return err
+		}
+
+		return db.UpsertChannelCommitment(
+			ctx, sqlc.UpsertChannelCommitmentParams{
+				ChannelID:    chanID,
+				IsLocal:      local,
+				CommitHeight: int64(commit.CommitHeight),
+				Commitment:   b.Bytes(),
+			},
+		)
+	}, func() {})
+}
+
+// PutRevocationLog writes the revocation log entry of the revoked remote
+// commitment at the given height. Writing an entry that already exists is a
+// no-op.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) PutRevocationLog(c *OpenChannel,
+	commitHeight uint64, rl *RevocationLog) error {
+
+	var b bytes.Buffer
+	if err := serializeRevocationLog(&b, rl); err != nil {
+		return err
+	}
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := upsertChannel(ctx, db, c)
+		if err != nil {
+			return err
+		}
+
+		return db.InsertRevocationLogEntry(
+			ctx, sqlc.InsertRevocationLogEntryParams{
+				ChannelID:    chanID,
+				CommitHeight: int64(commitHeight),
+				CommitTxHash: rl.CommitTxHash[:],
+				Entry:        b.Bytes(),
+			},
+		)
+	}, func() {})
+}
+
+// PutFwdPkg writes a new forwarding package of the channel.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) PutFwdPkg(c *OpenChannel, fwdPkg *FwdPkg) error {
+	params, err := marshalFwdPkg(fwdPkg)
+	if err != nil {
+		return err
+	}
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := upsertChannel(ctx, db, c)
+		if err != nil {
+			return err
+		}
+
+		params.ChannelID = chanID
+
+		return db.UpsertFwdPkg(ctx, params)
+	}, func() {})
+}
+
+// marshalFwdPkg converts a forwarding package into the parameters used to
+// write it to the database. The channel ID of the parameters is left unset.
+func marshalFwdPkg(fwdPkg *FwdPkg) (sqlc.UpsertFwdPkgParams, error) {
+	var (
+		params              sqlc.UpsertFwdPkgParams
+		adds, settleFails   bytes.Buffer
+		fwdFilter           []byte
+		ackFilter, sfFilter []byte
+		err                 error
+	)
+
+	if err := serializeLogUpdates(&adds, fwdPkg.Adds); err != nil {
+		return params, err
+	}
+	err = serializeLogUpdates(&settleFails, fwdPkg.SettleFails)
+	if err != nil {
+		return params, err
+	}
+
+	// The forwarding filter is only written once the package has been
+	// processed, mirroring the kvdb forwarding packager.
+	if fwdPkg.State != FwdStateLockedIn {
+		fwdFilter, err = encodePkgFilter(fwdPkg.FwdFilter)
+		if err != nil {
+			return params, err
+		}
+	}
+
+	ackFilter, err = encodePkgFilter(fwdPkg.AckFilter)
+	if err != nil {
+		return params, err
+	}
+	sfFilter, err = encodePkgFilter(fwdPkg.SettleFailFilter)
+	if err != nil {
+		return params, err
+	}
+
+	return sqlc.UpsertFwdPkgParams{
+		Height:           int64(fwdPkg.Height),
+		State:            int16(fwdPkg.State),
+		Adds:             adds.Bytes(),
+		SettleFails:      settleFails.Bytes(),
+		FwdFilter:        fwdFilter,
+		AckFilter:        ackFilter,
+		SettleFailFilter: sfFilter,
+	}, nil
+}
+
+// SetFwdFilter sets the forwarding filter of the forwarding package at the
+// given height, which marks the package as processed.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) SetFwdFilter(c *OpenChannel, height uint64,
+	fwdFilter *PkgFilter) error {
+
+	filterBytes, err := encodePkgFilter(fwdFilter)
+	if err != nil {
+		return err
+	}
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := fetchChannelID(ctx, db, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		return db.UpdateFwdPkgFwdFilter(
+			ctx, sqlc.UpdateFwdPkgFwdFilterParams{
+				ChannelID: chanID,
+				Height:    int64(height),
+				State:     int16(FwdStateProcessed),
+				FwdFilter: filterBytes,
+			},
+		)
+	}, func() {})
+}
+
+// AckAddHtlcs marks the referenced adds of the channel's forwarding packages
+// as acknowledged.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) AckAddHtlcs(c *OpenChannel, addRefs ...AddRef) error {
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := fetchChannelID(ctx, db, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range addRefs {
+			pkg, err := db.GetFwdPkg(ctx, sqlc.GetFwdPkgParams{
+				ChannelID: chanID,
+				Height:    int64(ref.Height),
+			})
+			if err != nil {
+				return fmt.Errorf("unable to fetch forwarding "+
+					"package at height %d: %w", ref.Height,
+					err)
+			}
+
+			ackFilter, err := decodePkgFilter(pkg.AckFilter)
+			if err != nil {
+				return err
+			}
+			ackFilter.Set(ref.Index)
+
+			filterBytes, err := encodePkgFilter(ackFilter)
+			if err != nil {
+				return err
+			}
+
+			err = db.UpdateFwdPkgAckFilter(
+				ctx, sqlc.UpdateFwdPkgAckFilterParams{
+					ChannelID: chanID,
+					Height:    int64(ref.Height),
+					AckFilter: filterBytes,
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// AckSettleFails marks the referenced settles and fails as delivered to the
+// incoming link. References to channels that aren't in the SQL store are
+// skipped.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) AckSettleFails(
+	settleFailRefs ...SettleFailRef) error {
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		for _, ref := range settleFailRefs {
+			chanID, err := db.GetOpenChannelIDBySCID(
+				ctx, int64(ref.Source.ToUint64()),
+			)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				continue
+
+			case err != nil:
+				return err
+			}
+
+			pkg, err := db.GetFwdPkg(ctx, sqlc.GetFwdPkgParams{
+				ChannelID: chanID,
+				Height:    int64(ref.Height),
+			})
+			switch {
+			// The package may already be removed, in which case
+			// there's nothing to acknowledge.
+			case errors.Is(err, sql.ErrNoRows):
+				continue
+
+			case err != nil:
+				return err
+			}
+
+			filter, err := decodePkgFilter(pkg.SettleFailFilter)
+			if err != nil {
+				return err
+			}
+			filter.Set(ref.Index)
+
+			filterBytes, err := encodePkgFilter(filter)
+			if err != nil {
+				return err
+			}
+
+			err = db.UpdateFwdPkgSettleFailFilter(
+				ctx, sqlc.UpdateFwdPkgSettleFailFilterParams{
+					ChannelID:        chanID,
+					Height:           int64(ref.Height),
+					SettleFailFilter: filterBytes,
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// RemoveFwdPkgs removes the forwarding packages of the channel at the given
+// heights.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) RemoveFwdPkgs(c *OpenChannel,
+	heights ...uint64) error {
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := fetchChannelID(ctx, db, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		for _, height := range heights {
+			err := db.DeleteFwdPkg(ctx, sqlc.DeleteFwdPkgParams{
+				ChannelID: chanID,
+				Height:    int64(height),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// CloseChannel marks the channel as closed. Just like the kvdb store, the
+// revocation log and forwarding packages of the channel are removed, while
+// its latest commitments are kept for historical lookups.
+//
+// NOTE: This is part of the ChannelStateMirror interface.
+func (s *SQLChannelStore) CloseChannel(c *OpenChannel,
+	summary *ChannelCloseSummary) error {
+
+	var b bytes.Buffer
+	if err := serializeChannelCloseSummary(&b, summary); err != nil {
+		return err
+	}
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := upsertChannel(ctx, db, c)
+		if err != nil {
+			return err
+		}
+
+		err = db.MarkChannelClosed(ctx, sqlc.MarkChannelClosedParams{
+			ID:           chanID,
+			CloseSummary: b.Bytes(),
+			CloseHeight:  sqldb.SQLInt32(summary.CloseHeight),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := db.DeleteRevocationLog(ctx, chanID); err != nil {
+			return err
+		}
+
+		return db.DeleteFwdPkgs(ctx, chanID)
+	}, func() {})
+}
+
+// FetchCommitment returns the latest local or remote commitment of the
+// channel with the given channel point.
+func (s *SQLChannelStore) FetchCommitment(ctx context.Context,
+	chanPoint wire.OutPoint, local bool) (*ChannelCommitment, error) {
+
+	var commit *ChannelCommitment
+
+	readTxOpts := NewSQLChannelQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := fetchChannelID(ctx, db, &chanPoint)
+		if err != nil {
+			return err
+		}
+
+		dbCommit, err := db.GetChannelCommitment(
+			ctx, sqlc.GetChannelCommitmentParams{
+				ChannelID: chanID,
+				IsLocal:   local,
+			},
+		)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNoCommitmentsFound
+
+		case err != nil:
+			return err
+		}
+
+		c, err := deserializeChanCommit(
+			bytes.NewReader(dbCommit.Commitment),
+		)
+		if err != nil {
+			return err
+		}
+		commit = &c
+
+		return nil
+	}, func() {
+		commit = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commit, nil
+}
+
+// FetchRevocationLog returns the revocation log entry of the revoked remote
+// commitment at the given height.
+func (s *SQLChannelStore) FetchRevocationLog(ctx context.Context,
+	chanPoint wire.OutPoint, commitHeight uint64) (*RevocationLog, error) {
+
+	var rl *RevocationLog
+
+	readTxOpts := NewSQLChannelQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := fetchChannelID(ctx, db, &chanPoint)
+		if err != nil {
+			return err
+		}
+
+		entry, err := db.GetRevocationLogEntry(
+			ctx, sqlc.GetRevocationLogEntryParams{
+				ChannelID:    chanID,
+				CommitHeight: int64(commitHeight),
+			},
+		)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrLogEntryNotFound
+
+		case err != nil:
+			return err
+		}
+
+		revLog, err := deserializeRevocationLog(
+			bytes.NewReader(entry.Entry),
+		)
+		if err != nil {
+			return err
+		}
+		rl = &revLog
+
+		return nil
+	}, func() {
+		rl = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rl, nil
+}
+
+// NumRevocationLogEntries returns the number of revocation log entries stored
+// for the channel with the given channel point.
+func (s *SQLChannelStore) NumRevocationLogEntries(ctx context.Context,
+	chanPoint wire.OutPoint) (uint64, error) {
+
+	var numEntries int64
+
+	readTxOpts := NewSQLChannelQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := fetchChannelID(ctx, db, &chanPoint)
+		if err != nil {
+			return err
+		}
+
+		numEntries, err = db.CountRevocationLogEntries(ctx, chanID)
+
+		return err
+	}, func() {
+		numEntries = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(numEntries), nil
+}
+
+// FetchFwdPkgs returns all forwarding packages of the channel with the given
+// channel point, ordered by their height.
+func (s *SQLChannelStore) FetchFwdPkgs(ctx context.Context,
+	chanPoint wire.OutPoint) ([]*FwdPkg, error) {
+
+	var fwdPkgs []*FwdPkg
+
+	readTxOpts := NewSQLChannelQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLChannelQueries) error {
+		key, err := serializeChanPoint(&chanPoint)
+		if err != nil {
+			return err
+		}
+
+		dbChan, err := db.GetChannel(ctx, key)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrChannelNotMirrored
+
+		case err != nil:
+			return err
+		}
+
+		dbPkgs, err := db.FetchFwdPkgs(ctx, dbChan.ID)
+		if err != nil {
+			return err
+		}
+
+		source := lnwire.NewShortChanIDFromInt(
+			uint64(dbChan.ShortChannelID),
+		)
+		for _, dbPkg := range dbPkgs {
+			fwdPkg, err := unmarshalFwdPkg(source, dbPkg)
+			if err != nil {
+				return err
+			}
+
+			fwdPkgs = append(fwdPkgs, fwdPkg)
+		}
+
+		return nil
+	}, func() {
+		fwdPkgs = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fwdPkgs, nil
+}
+
+// unmarshalFwdPkg converts a forwarding package read from the database into a
+// FwdPkg. Like the kvdb forwarding packager, the completed state is derived
+// from the filters.
+func unmarshalFwdPkg(source lnwire.ShortChannelID,
+	dbPkg sqlc.ChannelFwdPackage) (*FwdPkg, error) {
+
+	adds, err := deserializeFwdPkgUpdates(dbPkg.Adds)
+	if err != nil {
+		return nil, err
+	}
+
+	settleFails, err := deserializeFwdPkgUpdates(dbPkg.SettleFails)
+	if err != nil {
+		return nil, err
+	}
+
+	ackFilter, err := decodePkgFilter(dbPkg.AckFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	settleFailFilter, err := decodePkgFilter(dbPkg.SettleFailFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	fwdPkg := &FwdPkg{
+		Source:           source,
+		Height:           uint64(dbPkg.Height),
+		State:            FwdStateLockedIn,
+		Adds:             adds,
+		AckFilter:        ackFilter,
+		SettleFails:      settleFails,
+		SettleFailFilter: settleFailFilter,
+	}
+
+	if dbPkg.FwdFilter == nil {
+		fwdPkg.FwdFilter = NewPkgFilter(uint16(len(adds)))
+
+		return fwdPkg, nil
+	}
+
+	fwdPkg.FwdFilter, err = decodePkgFilter(dbPkg.FwdFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	fwdPkg.State = FwdStateProcessed
+	if ackFilter.IsFull() && settleFailFilter.IsFull() {
+		fwdPkg.State = FwdStateCompleted
+	}
+
+	return fwdPkg, nil
+}
+
+// deserializeFwdPkgUpdates deserializes the adds or settles and fails of a
+// forwarding package. An empty list is returned as nil, just like the kvdb
+// forwarding packager does.
+func deserializeFwdPkgUpdates(updateBytes []byte) ([]LogUpdate, error) {
+	updates, err := deserializeLogUpdates(bytes.NewReader(updateBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,170 @@
+package channeldb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSQLChannelStoreMirror tests that the channel state writes of an
+// OpenChannel are mirrored to the SQL channel store when it's set as the
+// ChannelStateMirror of the ChannelStateDB.
+func TestSQLChannelStoreMirror(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to make test database")
+
+	cdb := fullDB.ChannelStateDB()
+
+	sqlDB := sqldb.NewTestSqliteDB(t).BaseDB
+	executor := sqldb.NewTransactionExecutor(
+		sqlDB, func(tx *sql.Tx) SQLChannelQueries {
+			return sqlDB.WithTx(tx)
+		},
+	)
+	store := NewSQLChannelStore(executor)
+	cdb.SetChannelStateMirror(store)
+
+	ctx := context.Background()
+
+	// Both commitments of a new channel should be mirrored.
+	channel := createTestChannel(t, cdb, openChannelOption())
+	chanPoint := channel.FundingOutpoint
+
+	localCommit, err := store.FetchCommitment(ctx, chanPoint, true)
+	require.NoError(t, err)
+	assertCommitmentEqual(t, &channel.LocalCommitment, localCommit)
+
+	remoteCommit, err := store.FetchCommitment(ctx, chanPoint, false)
+	require.NoError(t, err)
+	assertCommitmentEqual(t, &channel.RemoteCommitment, remoteCommit)
+
+	// An update of our commitment should replace the mirrored one.
+	newLocalCommit := channel.LocalCommitment
+	newLocalCommit.CommitHeight++
+	newLocalCommit.LocalBalance -= 1000
+	newLocalCommit.RemoteBalance += 1000
+	_, err = channel.UpdateCommitment(&newLocalCommit, nil)
+	require.NoError(t, err)
+
+	localCommit, err = store.FetchCommitment(ctx, chanPoint, true)
+	require.NoError(t, err)
+	assertCommitmentEqual(t, &newLocalCommit, localCommit)
+
+	// Next, we extend a new commitment to the remote party and let them
+	// revoke their prior one, which should add a revocation log entry and
+	// a forwarding package.
+	revokedCommit := channel.RemoteCommitment
+	commitDiff := &CommitDiff{
+		Commitment: revokedCommit,
+		CommitSig: &lnwire.CommitSig{
+			ChanID:    lnwire.ChannelID(key),
+			CommitSig: wireSig,
+		},
+		LogUpdates:        []LogUpdate{},
+		OpenedCircuitKeys: []models.CircuitKey{},
+		ClosedCircuitKeys: []models.CircuitKey{},
+	}
+	commitDiff.Commitment.CommitHeight++
+	require.NoError(t, channel.AppendRemoteCommitChain(commitDiff))
+
+	adds := []LogUpdate{{
+		LogIndex: 1,
+		UpdateMsg: &lnwire.UpdateAddHTLC{
+			ID:     1,
+			Amount: lnwire.NewMSatFromSatoshis(100),
+			Expiry: 25,
+		},
+	}}
+	fwdPkg := NewFwdPkg(
+		channel.ShortChanID(), revokedCommit.CommitHeight, adds, nil,
+	)
+	err = channel.AdvanceCommitChainTail(
+		fwdPkg, nil, dummyLocalOutputIndex, dummyRemoteOutIndex,
+	)
+	require.NoError(t, err)
+
+	remoteCommit, err = store.FetchCommitment(ctx, chanPoint, false)
+	require.NoError(t, err)
+	assertCommitmentEqual(t, &commitDiff.Commitment, remoteCommit)
+
+	revLog, err := store.FetchRevocationLog(
+		ctx, chanPoint, revokedCommit.CommitHeight,
+	)
+	require.NoError(t, err)
+	assertRevocationLogEntryEqual(t, &revokedCommit, revLog)
+	require.EqualValues(t, dummyLocalOutputIndex, revLog.OurOutputIndex)
+	require.EqualValues(t, dummyRemoteOutIndex, revLog.TheirOutputIndex)
+
+	numEntries, err := store.NumRevocationLogEntries(ctx, chanPoint)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, numEntries)
+
+	_, err = store.FetchRevocationLog(
+		ctx, chanPoint, revokedCommit.CommitHeight+1,
+	)
+	require.ErrorIs(t, err, ErrLogEntryNotFound)
+
+	// The forwarding package should match the one in the KV store as it
+	// moves through its states.
+	assertFwdPkgsMirrored := func() {
+		t.Helper()
+
+		var kvPkgs []*FwdPkg
+		err := kvdb.View(cdb.backend, func(tx kvdb.RTx) error {
+			var err error
+			kvPkgs, err = channel.Packager.LoadFwdPkgs(tx)
+			return err
+		}, func() {
+			kvPkgs = nil
+		})
+		require.NoError(t, err)
+
+		sqlPkgs, err := store.FetchFwdPkgs(ctx, chanPoint)
+		require.NoError(t, err)
+		require.Len(t, sqlPkgs, len(kvPkgs))
+		for i := range kvPkgs {
+			require.Equal(t, kvPkgs[i], sqlPkgs[i])
+		}
+	}
+	assertFwdPkgsMirrored()
+
+	fwdFilter := NewPkgFilter(1)
+	fwdFilter.Set(0)
+	err = channel.SetFwdFilter(revokedCommit.CommitHeight, fwdFilter)
+	require.NoError(t, err)
+	assertFwdPkgsMirrored()
+
+	err = channel.AckAddHtlcs(AddRef{
+		Height: revokedCommit.CommitHeight,
+		Index:  0,
+	})
+	require.NoError(t, err)
+	assertFwdPkgsMirrored()
+
+	err = channel.RemoveFwdPkgs(revokedCommit.CommitHeight)
+	require.NoError(t, err)
+	assertFwdPkgsMirrored()
+
+	// Finally, closing the channel should remove its revocation log.
+	closeSummary := &ChannelCloseSummary{
+		ChanPoint:      chanPoint,
+		RemotePub:      channel.IdentityPub,
+		SettledBalance: btcutil.Amount(500),
+		CloseHeight:    100,
+		CloseType:      CooperativeClose,
+	}
+	require.NoError(t, channel.CloseChannel(closeSummary))
+
+	numEntries, err = store.NumRevocationLogEntries(ctx, chanPoint)
+	require.NoError(t, err)
+	require.Zero(t, numEntries)
+}
@@ -0,0 +1,321 @@
+package channeldb
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/lightningnetwork/lnd/sqldb/sqlc"
+)
+
+// ForwardingEventStore is a store for the forwarding events of the node that
+// can be queried for a particular time slice.
+type ForwardingEventStore interface {
+	// AddForwardingEvents adds a series of forwarding events to the store.
+	AddForwardingEvents(events []ForwardingEvent) error
+
+	// Query returns the forwarding events that match the given query.
+	Query(q ForwardingEventQuery) (ForwardingLogTimeSlice, error)
+}
+
+// A compile-time constraint to ensure ForwardingLog implements the
+// ForwardingEventStore interface.
+var _ ForwardingEventStore = (*ForwardingLog)(nil)
+
+// SQLForwardingQueries is an interface that defines the set of operations
+// that can be executed against the forwarding log SQL database.
+type SQLForwardingQueries interface {
+	InsertForwardingEvent(ctx context.Context,
+		arg sqlc.InsertForwardingEventParams) error
+
+	QueryForwardingEvents(ctx context.Context,
+		arg sqlc.QueryForwardingEventsParams) ([]sqlc.ForwardingEvent,
+		error)
+
+	CountForwardingEvents(ctx context.Context) (int64, error)
+}
+
+// SQLForwardingQueriesTxOptions defines the set of db txn options the
+// SQLForwardingQueries understands.
+type SQLForwardingQueriesTxOptions struct {
+	// readOnly governs if a read only transaction is needed or not.
+	readOnly bool
+}
+
+// ReadOnly returns true if the transaction should be read only.
+//
+// NOTE: This implements the TxOptions.
+func (a *SQLForwardingQueriesTxOptions) ReadOnly() bool {
+	return a.readOnly
+}
+
+// NewSQLForwardingQueryReadTx creates a new read transaction option set.
+func NewSQLForwardingQueryReadTx() SQLForwardingQueriesTxOptions {
+	return SQLForwardingQueriesTxOptions{
+		readOnly: true,
+	}
+}
+
+// BatchedSQLForwardingQueries is a version of the SQLForwardingQueries that's
+// capable of batched database operations.
+type BatchedSQLForwardingQueries interface {
+	SQLForwardingQueries
+
+	sqldb.BatchedTx[SQLForwardingQueries]
+}
+
+// SQLForwardingLog is a native SQL implementation of the forwarding log.
+// Unlike the kvdb forwarding log, it supports paginating through large time
+// slices without scanning all the events before the requested offset.
+type SQLForwardingLog struct {
+	db BatchedSQLForwardingQueries
+}
+
+// A compile-time constraint to ensure SQLForwardingLog implements the
+// ForwardingEventStore interface.
+var _ ForwardingEventStore = (*SQLForwardingLog)(nil)
+
+// NewSQLForwardingLog creates a new SQLForwardingLog instance given an open
+// BatchedSQLForwardingQueries storage backend.
+func NewSQLForwardingLog(db BatchedSQLForwardingQueries) *SQLForwardingLog {
+	return &SQLForwardingLog{
+		db: db,
+	}
+}
+
+// insertForwardingEvents inserts the given forwarding events.
+func insertForwardingEvents(ctx context.Context, db SQLForwardingQueries,
+	events []ForwardingEvent) error {
+
+	for _, event := range events {
+		err := db.InsertForwardingEvent(
+			ctx, sqlc.InsertForwardingEventParams{
+				TimestampNs: event.Timestamp.UnixNano(),
+				IncomingChanID: int64(
+					event.IncomingChanID.ToUint64(),
+				),
+				OutgoingChanID: int64(
+					event.OutgoingChanID.ToUint64(),
+				),
+				AmtInMsat:      int64(event.AmtIn),
+				AmtOutMsat:     int64(event.AmtOut),
+				IncomingExpiry: int32(event.IncomingExpiry),
+				OutgoingExpiry: int32(event.OutgoingExpiry),
+				Failed:         event.Failed,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddForwardingEvents adds a series of forwarding events to the database.
+//
+// NOTE: This is part of the ForwardingEventStore interface.
+func (s *SQLForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
+	var writeTxOpts SQLForwardingQueriesTxOptions
+
+	ctx := context.TODO()
+
+	txBody := func(db SQLForwardingQueries) error {
+		return insertForwardingEvents(ctx, db, events)
+	}
+
+	return s.db.ExecTx(ctx, &writeTxOpts, txBody, func() {})
+}
+
+// Query returns the forwarding events that match the given query. The events
+// are ordered by their timestamp and the index offset is applied by the
+// database.
+//
+// NOTE: This is part of the ForwardingEventStore interface.
+func (s *SQLForwardingLog) Query(
+	q ForwardingEventQuery) (ForwardingLogTimeSlice, error) {
+
+	resp := ForwardingLogTimeSlice{
+		ForwardingEventQuery: q,
+		LastIndexOffset:      q.IndexOffset,
+	}
+
+	// A query for zero events never returns any, so we'll avoid passing a
+	// limit of zero, which some backends interpret as no limit.
+	numMaxEvents := q.NumMaxEvents
+	if numMaxEvents == 0 {
+		return resp, nil
+	}
+	if numMaxEvents > MaxResponseEvents {
+		numMaxEvents = MaxResponseEvents
+	}
+
+	readTxOpts := NewSQLForwardingQueryReadTx()
+
+	ctx := context.TODO()
+
+	txBody := func(db SQLForwardingQueries) error {
+		if len(q.ChanIDs) > 0 {
+			events, err := queryChannelEvents(
+				ctx, db, q, numMaxEvents,
+			)
+			resp.ForwardingEvents = events
+
+			return err
+		}
+
+		rows, err := db.QueryForwardingEvents(
+			ctx, sqlc.QueryForwardingEventsParams{
+				StartTime:       q.StartTime.UnixNano(),
+				EndTime:         q.EndTime.UnixNano(),
+				IncludeFailures: q.IncludeFailures,
+				NumOffset:       int32(q.IndexOffset),
+				NumLimit:        int32(numMaxEvents),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			event := unmarshalForwardingEvent(row)
+			resp.ForwardingEvents = append(
+				resp.ForwardingEvents, event,
+			)
+		}
+
+		return nil
+	}
+	err := s.db.ExecTx(ctx, &readTxOpts, txBody, func() {
+		resp.ForwardingEvents = nil
+	})
+	if err != nil {
+		return ForwardingLogTimeSlice{}, err
+	}
+
+	resp.LastIndexOffset += uint32(len(resp.ForwardingEvents))
+
+	return resp, nil
+}
+
+// queryChannelEvents returns the events that match a query with a channel
+// filter. As the filter is applied in memory, all matching events before the
+// index offset need to be scanned.
+func queryChannelEvents(ctx context.Context, db SQLForwardingQueries,
+	q ForwardingEventQuery, numMaxEvents uint32) ([]ForwardingEvent,
+	error) {
+
+	var (
+		events        []ForwardingEvent
+		recordsToSkip = q.IndexOffset
+		dbOffset      int32
+	)
+	for uint32(len(events)) < numMaxEvents {
+		rows, err := db.QueryForwardingEvents(
+			ctx, sqlc.QueryForwardingEventsParams{
+				StartTime:       q.StartTime.UnixNano(),
+				EndTime:         q.EndTime.UnixNano(),
+				IncludeFailures: q.IncludeFailures,
+				NumOffset:       dbOffset,
+				NumLimit:        MaxResponseEvents,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+		dbOffset += int32(len(rows))
+
+		for _, row := range rows {
+			event := unmarshalForwardingEvent(row)
+			if !q.matchesChannels([]ForwardingEvent{event}) {
+				continue
+			}
+
+			if recordsToSkip > 0 {
+				recordsToSkip--
+				continue
+			}
+
+			events = append(events, event)
+			if uint32(len(events)) == numMaxEvents {
+				break
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// unmarshalForwardingEvent converts a forwarding event read from the database
+// into a ForwardingEvent.
+func unmarshalForwardingEvent(row sqlc.ForwardingEvent) ForwardingEvent {
+	return ForwardingEvent{
+		Timestamp: time.Unix(0, row.TimestampNs),
+		IncomingChanID: lnwire.NewShortChanIDFromInt(
+			uint64(row.IncomingChanID),
+		),
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(
+			uint64(row.OutgoingChanID),
+		),
+		AmtIn:          lnwire.MilliSatoshi(row.AmtInMsat),
+		AmtOut:         lnwire.MilliSatoshi(row.AmtOutMsat),
+		IncomingExpiry: uint32(row.IncomingExpiry),
+		OutgoingExpiry: uint32(row.OutgoingExpiry),
+		Failed:         row.Failed,
+	}
+}
+
+// MigrateFromKV copies all events of the given kvdb forwarding log to the SQL
+// forwarding log, unless it already contains events. The events are copied
+// within a single transaction, so the migration either completes or is
+// retried from scratch on the next startup.
+func (s *SQLForwardingLog) MigrateFromKV(ctx context.Context,
+	kvLog *ForwardingLog) error {
+
+	var writeTxOpts SQLForwardingQueriesTxOptions
+
+	txBody := func(db SQLForwardingQueries) error {
+		numEvents, err := db.CountForwardingEvents(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The forwarding log has already been migrated.
+		if numEvents > 0 {
+			return nil
+		}
+
+		query := ForwardingEventQuery{
+			StartTime:       time.Unix(0, 0),
+			EndTime:         time.Unix(0, math.MaxInt64),
+			NumMaxEvents:    MaxResponseEvents,
+			IncludeFailures: true,
+		}
+		for {
+			timeSlice, err := kvLog.Query(query)
+			if err != nil {
+				return err
+			}
+
+			if len(timeSlice.ForwardingEvents) == 0 {
+				return nil
+			}
+
+			err = insertForwardingEvents(
+				ctx, db, timeSlice.ForwardingEvents,
+			)
+			if err != nil {
+				return err
+			}
+
+			query.IndexOffset = timeSlice.LastIndexOffset
+		}
+	}
+
+	return s.db.ExecTx(ctx, &writeTxOpts, txBody, func() {})
+}
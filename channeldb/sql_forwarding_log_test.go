@@ -0,0 +1,71 @@
+package channeldb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSQLForwardingLog creates a SQL forwarding log backed by a fresh
+// sqlite database.
+func newTestSQLForwardingLog(t *testing.T) *SQLForwardingLog {
+	sqlDB := sqldb.NewTestSqliteDB(t).BaseDB
+	executor := sqldb.NewTransactionExecutor(
+		sqlDB, func(tx *sql.Tx) SQLForwardingQueries {
+			return sqlDB.WithTx(tx)
+		},
+	)
+
+	return NewSQLForwardingLog(executor)
+}
+
+// TestSQLForwardingLog tests that the SQL forwarding log stores and queries
+// forwarding events like the kvdb forwarding log.
+func TestSQLForwardingLog(t *testing.T) {
+	t.Parallel()
+
+	log := newTestSQLForwardingLog(t)
+
+	events := makeTestFailureEvents(10)
+	require.NoError(t, log.AddForwardingEvents(events))
+
+	assertFailureQueries(t, log, events)
+	assertChannelQueries(t, log, events)
+
+	// Events outside of the time slice and failed events aren't
+	// returned.
+	timeSlice, err := log.Query(ForwardingEventQuery{
+		StartTime:    events[3].Timestamp,
+		EndTime:      events[5].Timestamp,
+		NumMaxEvents: 1000,
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t, []ForwardingEvent{events[4]}, timeSlice.ForwardingEvents,
+	)
+}
+
+// TestSQLForwardingLogMigrateFromKV tests that the events of the kvdb
+// forwarding log are copied to an empty SQL forwarding log.
+func TestSQLForwardingLogMigrateFromKV(t *testing.T) {
+	t.Parallel()
+
+	db, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to make test db")
+
+	kvLog := db.ForwardingLog()
+	events := makeTestFailureEvents(10)
+	require.NoError(t, kvLog.AddForwardingEvents(events))
+
+	ctx := context.Background()
+	sqlLog := newTestSQLForwardingLog(t)
+	require.NoError(t, sqlLog.MigrateFromKV(ctx, kvLog))
+	assertFailureQueries(t, sqlLog, events)
+
+	// Migrating again doesn't duplicate the events.
+	require.NoError(t, sqlLog.MigrateFromKV(ctx, kvLog))
+	assertFailureQueries(t, sqlLog, events)
+}
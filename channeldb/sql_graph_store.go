@@ -0,0 +1,1037 @@
+package channeldb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/lightningnetwork/lnd/sqldb/sqlc"
+)
+
+// GraphMirror is a secondary channel graph store that receives a copy of
+// every graph write once it has been committed to the kvdb backed
+// ChannelGraph. It allows a native SQL graph store to be populated in a
+// dual-write mode while the kvdb graph stays authoritative.
+type GraphMirror interface {
+	// PutNode writes the given node, replacing any prior version of it.
+	PutNode(node *LightningNode) error
+
+	// SetSourceNode writes the given node and marks it as the source node
+	// of the graph.
+	SetSourceNode(node *LightningNode) error
+
+	// DeleteNode removes the node with the given public key.
+	DeleteNode(nodePub route.Vertex) error
+
+	// PutChannel writes the static information of the given channel and
+	// adds shell nodes for any of its nodes that aren't known yet.
+	PutChannel(edge *models.ChannelEdgeInfo) error
+
+	// PutChannelPolicy writes the routing policy of one direction of a
+	// channel.
+	PutChannelPolicy(edge *models.ChannelEdgePolicy) error
+
+	// DeleteChannels removes the channels with the given IDs along with
+	// their policies.
+	DeleteChannels(chanIDs ...uint64) error
+
+	// MarkZombie adds the channel to the zombie index. Only the nodes
+	// with the given public keys may resurrect it.
+	MarkZombie(chanID uint64, pubKey1, pubKey2 [33]byte) error
+
+	// MarkLive removes the channel from the zombie index.
+	MarkLive(chanID uint64) error
+
+	// PruneGraph removes the channels that were closed by the given block,
+	// adds the block to the prune log and removes any nodes that are no
+	// longer connected.
+	PruneGraph(closedChanIDs []uint64, blockHash *chainhash.Hash,
+		blockHeight uint32) error
+
+	// PruneGraphNodes removes all nodes that are no longer connected to
+	// any channel, except for the source node.
+	PruneGraphNodes() error
+
+	// DisconnectBlockAtHeight removes the channels that were confirmed at
+	// or above the disconnected block, along with the prune log entries
+	// starting at its height.
+	DisconnectBlockAtHeight(height uint32, removedChanIDs []uint64) error
+}
+
+// SQLGraphQueries is an interface that defines the set of operations that can
+// be executed against the channel graph SQL database.
+type SQLGraphQueries interface { //nolint:interfacebloat
+	UpsertGraphNode(ctx context.Context,
+		arg sqlc.UpsertGraphNodeParams) (int64, error)
+
+	InsertGraphShellNode(ctx context.Context,
+		arg sqlc.InsertGraphShellNodeParams) error
+
+	GetGraphNode(ctx context.Context, pubKey []byte) (sqlc.GraphNode, error)
+
+	DeleteGraphNode(ctx context.Context, pubKey []byte) error
+
+	ListGraphNodes(ctx context.Context,
+		arg sqlc.ListGraphNodesParams) ([]sqlc.GraphNode, error)
+
+	CountGraphNodes(ctx context.Context) (int64, error)
+
+	DeleteUnconnectedGraphNodes(ctx context.Context) (sql.Result, error)
+
+	DeleteGraphSourceNode(ctx context.Context) error
+
+	InsertGraphSourceNode(ctx context.Context, nodeID int64) error
+
+	GetGraphSourceNode(ctx context.Context) (sqlc.GraphNode, error)
+
+	UpsertGraphChannel(ctx context.Context,
+		arg sqlc.UpsertGraphChannelParams) (int64, error)
+
+	GetGraphChannel(ctx context.Context, scid int64) (sqlc.GraphChannel,
+		error)
+
+	DeleteGraphChannel(ctx context.Context, scid int64) error
+
+	ListGraphChannels(ctx context.Context,
+		arg sqlc.ListGraphChannelsParams) ([]sqlc.GraphChannel, error)
+
+	ListGraphNodeChannels(ctx context.Context,
+		pubKey []byte) ([]sqlc.GraphChannel, error)
+
+	UpdateGraphChannelLastUpdate(ctx context.Context,
+		arg sqlc.UpdateGraphChannelLastUpdateParams) error
+
+	UpsertGraphChannelPolicy(ctx context.Context,
+		arg sqlc.UpsertGraphChannelPolicyParams) error
+
+	GetGraphChannelPolicies(ctx context.Context,
+		channelID int64) ([]sqlc.GraphChannelPolicy, error)
+
+	DeleteGraphChannelPolicies(ctx context.Context, channelID int64) error
+
+	UpsertGraphZombieChannel(ctx context.Context,
+		arg sqlc.UpsertGraphZombieChannelParams) error
+
+	GetGraphZombieChannel(ctx context.Context,
+		scid int64) (sqlc.GraphZombieChannel, error)
+
+	DeleteGraphZombieChannel(ctx context.Context, scid int64) error
+
+	UpsertGraphPruneLogEntry(ctx context.Context,
+		arg sqlc.UpsertGraphPruneLogEntryParams) error
+
+	GetGraphPruneTip(ctx context.Context) (sqlc.GraphPruneLog, error)
+
+	DeleteGraphPruneLogEntries(ctx context.Context, blockHeight int64) error
+}
+
+// SQLGraphQueriesTxOptions defines the set of db txn options the
+// SQLGraphQueries understands.
+type SQLGraphQueriesTxOptions struct {
+	// readOnly governs if a read only transaction is needed or not.
+	readOnly bool
+}
+
+// ReadOnly returns true if the transaction should be read only.
+//
+// NOTE: This implements the TxOptions.
+func (a *SQLGraphQueriesTxOptions) ReadOnly() bool {
+	return a.readOnly
+}
+
+// NewSQLGraphQueryReadTx creates a new read transaction option set.
+func NewSQLGraphQueryReadTx() SQLGraphQueriesTxOptions {
+	return SQLGraphQueriesTxOptions{
+		readOnly: true,
+	}
+}
+
+// BatchedSQLGraphQueries is a version of the SQLGraphQueries that's capable
+// of batched database operations.
+type BatchedSQLGraphQueries interface {
+	SQLGraphQueries
+
+	sqldb.BatchedTx[SQLGraphQueries]
+}
+
+// SQLGraphStore is a native SQL store for the channel graph: its nodes,
+// channels, policies, zombie index and prune log. The tables are indexed by
+// their last update, which allows the graph to be paginated and synced
+// incrementally. For now it is only populated as a GraphMirror of the kvdb
+// channel graph.
+type SQLGraphStore struct {
+	db BatchedSQLGraphQueries
+}
+
+// A compile-time constraint to ensure SQLGraphStore implements the
+// GraphMirror interface.
+var _ GraphMirror = (*SQLGraphStore)(nil)
+
+// NewSQLGraphStore creates a new SQLGraphStore instance given an open
+// BatchedSQLGraphQueries storage backend.
+func NewSQLGraphStore(db BatchedSQLGraphQueries) *SQLGraphStore {
+	return &SQLGraphStore{
+		db: db,
+	}
+}
+
+// nodeUpdateUnix returns the last update of the node as a unix timestamp, or
+// zero if it isn't set.
+func nodeUpdateUnix(node *LightningNode) int64 {
+	if node.LastUpdate.Unix() > 0 {
+		return node.LastUpdate.Unix()
+	}
+
+	return 0
+}
+
+// upsertGraphNode writes the given node and returns its database ID.
+func upsertGraphNode(ctx context.Context, db SQLGraphQueries,
+	node *LightningNode) (int64, error) {
+
+	var b bytes.Buffer
+	if err := serializeLightningNode(&b, node); err != nil {
+		return 0, err
+	}
+
+	return db.UpsertGraphNode(ctx, sqlc.UpsertGraphNodeParams{
+		PubKey:     node.PubKeyBytes[:],
+		LastUpdate: nodeUpdateUnix(node),
+		Alias:      node.Alias,
+		NodeData:   b.Bytes(),
+	})
+}
+
+// insertGraphShellNode writes a node without a node announcement for the
+// given public key, unless the node is already known.
+func insertGraphShellNode(ctx context.Context, db SQLGraphQueries,
+	nodePub [33]byte) error {
+
+	shellNode := &LightningNode{
+		PubKeyBytes:          nodePub,
+		HaveNodeAnnouncement: false,
+	}
+
+	var b bytes.Buffer
+	if err := serializeLightningNode(&b, shellNode); err != nil {
+		return err
+	}
+
+	return db.InsertGraphShellNode(ctx, sqlc.InsertGraphShellNodeParams{
+		PubKey:   nodePub[:],
+		NodeData: b.Bytes(),
+	})
+}
+
+// upsertGraphChannel writes the static information of the given channel,
+// including shell nodes for any of its unknown nodes.
+func upsertGraphChannel(ctx context.Context, db SQLGraphQueries,
+	edge *models.ChannelEdgeInfo) error {
+
+	var chanKey [8]byte
+	byteOrder.PutUint64(chanKey[:], edge.ChannelID)
+
+	var info bytes.Buffer
+	if err := serializeChanEdgeInfo(&info, edge, chanKey); err != nil {
+		return err
+	}
+
+	chanPoint, err := serializeChanPoint(&edge.ChannelPoint)
+	if err != nil {
+		return err
+	}
+
+	if err := insertGraphShellNode(ctx, db, edge.NodeKey1Bytes); err != nil {
+		return err
+	}
+	if err := insertGraphShellNode(ctx, db, edge.NodeKey2Bytes); err != nil {
+		return err
+	}
+
+	_, err = db.UpsertGraphChannel(ctx, sqlc.UpsertGraphChannelParams{
+		Scid:        int64(edge.ChannelID),
+		NodeKey1:    edge.NodeKey1Bytes[:],
+		NodeKey2:    edge.NodeKey2Bytes[:],
+		ChanPoint:   chanPoint,
+		CapacitySat: int64(edge.Capacity),
+		EdgeInfo:    info.Bytes(),
+	})
+
+	return err
+}
+
+// upsertGraphChannelPolicy writes the given policy to the direction of the
+// channel it belongs to.
+func upsertGraphChannelPolicy(ctx context.Context, db SQLGraphQueries,
+	edge *models.ChannelEdgePolicy) error {
+
+	dbChan, err := db.GetGraphChannel(ctx, int64(edge.ChannelID))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrEdgeNotFound
+
+	case err != nil:
+		return err
+	}
+
+	// The policy of the first node points to the second node and vice
+	// versa.
+	direction := int16(0)
+	toNode := dbChan.NodeKey2
+	if edge.ChannelFlags&lnwire.ChanUpdateDirection != 0 {
+		direction = 1
+		toNode = dbChan.NodeKey1
+	}
+
+	var b bytes.Buffer
+	if err := serializeChanEdgePolicy(&b, edge, toNode); err != nil {
+		return err
+	}
+
+	lastUpdate := edge.LastUpdate.Unix()
+	err = db.UpsertGraphChannelPolicy(
+		ctx, sqlc.UpsertGraphChannelPolicyParams{
+			ChannelID:     dbChan.ID,
+			Direction:     direction,
+			LastUpdate:    lastUpdate,
+			Disabled:      edge.IsDisabled(),
+			TimeLockDelta: int32(edge.TimeLockDelta),
+			MinHtlcMsat:   int64(edge.MinHTLC),
+			MaxHtlcMsat:   int64(edge.MaxHTLC),
+			FeeBaseMsat:   int64(edge.FeeBaseMSat),
+			FeeRatePpm:    int64(edge.FeeProportionalMillionths),
+			Policy:        b.Bytes(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return db.UpdateGraphChannelLastUpdate(
+		ctx, sqlc.UpdateGraphChannelLastUpdateParams{
+			LastUpdate: lastUpdate,
+			ID:         dbChan.ID,
+		},
+	)
+}
+
+// deleteGraphChannels removes the channels with the given IDs along with
+// their policies. Unknown channels are skipped.
+func deleteGraphChannels(ctx context.Context, db SQLGraphQueries,
+	chanIDs []uint64) error {
+
+	for _, chanID := range chanIDs {
+		dbChan, err := db.GetGraphChannel(ctx, int64(chanID))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			continue
+
+		case err != nil:
+			return err
+		}
+
+		if err := db.DeleteGraphChannelPolicies(ctx, dbChan.ID); err != nil {
+			return err
+		}
+
+		if err := db.DeleteGraphChannel(ctx, dbChan.Scid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneGraphNodes removes all nodes that are no longer connected to any
+// channel, except for the source node.
+func pruneGraphNodes(ctx context.Context, db SQLGraphQueries) error {
+	res, err := db.DeleteUnconnectedGraphNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	numPruned, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if numPruned > 0 {
+		log.Debugf("Pruned %v unconnected nodes from the SQL channel "+
+			"graph", numPruned)
+	}
+
+	return nil
+}
+
+// PutNode writes the given node, replacing any prior version of it.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) PutNode(node *LightningNode) error {
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		_, err := upsertGraphNode(ctx, db, node)
+		return err
+	}, func() {})
+}
+
+// SetSourceNode writes the given node and marks it as the source node of the
+// graph.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) SetSourceNode(node *LightningNode) error {
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		nodeID, err := upsertGraphNode(ctx, db, node)
+		if err != nil {
+			return err
+		}
+
+		if err := db.DeleteGraphSourceNode(ctx); err != nil {
+			return err
+		}
+
+		return db.InsertGraphSourceNode(ctx, nodeID)
+	}, func() {})
+}
+
+// DeleteNode removes the node with the given public key.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) DeleteNode(nodePub route.Vertex) error {
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		return db.DeleteGraphNode(ctx, nodePub[:])
+	}, func() {})
+}
+
+// PutChannel writes the static information of the given channel and adds
+// shell nodes for any of its nodes that aren't known yet.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) PutChannel(edge *models.ChannelEdgeInfo) error {
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		return upsertGraphChannel(ctx, db, edge)
+	}, func() {})
+}
+
+// PutChannelPolicy writes the routing policy of one direction of a channel.
+// ErrEdgeNotFound is returned if the channel isn't known.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) PutChannelPolicy(
+	edge *models.ChannelEdgePolicy) error {
+
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		return upsertGraphChannelPolicy(ctx, db, edge)
+	}, func() {})
+}
+
+// DeleteChannels removes the channels with the given IDs along with their
+// policies.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) DeleteChannels(chanIDs ...uint64) error {
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		return deleteGraphChannels(ctx, db, chanIDs)
+	}, func() {})
+}
+
+// MarkZombie adds the channel to the zombie index.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) MarkZombie(chanID uint64, pubKey1,
+	pubKey2 [33]byte) error {
+
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		return db.UpsertGraphZombieChannel(
+			ctx, sqlc.UpsertGraphZombieChannelParams{
+				Scid:     int64(chanID),
+				NodeKey1: pubKey1[:],
+				NodeKey2: pubKey2[:],
+			},
+		)
+	}, func() {})
+}
+
+// MarkLive removes the channel from the zombie index.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) MarkLive(chanID uint64) error {
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		return db.DeleteGraphZombieChannel(ctx, int64(chanID))
+	}, func() {})
+}
+
+// PruneGraph removes the channels that were closed by the given block, adds
+// the block to the prune log and removes any nodes that are no longer
+// connected.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) PruneGraph(closedChanIDs []uint64,
+	blockHash *chainhash.Hash, blockHeight uint32) error {
+
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		err := deleteGraphChannels(ctx, db, closedChanIDs)
+		if err != nil {
+			return err
+		}
+
+		err = db.UpsertGraphPruneLogEntry(
+			ctx, sqlc.UpsertGraphPruneLogEntryParams{
+				BlockHeight: int64(blockHeight),
+				BlockHash:   blockHash[:],
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		return pruneGraphNodes(ctx, db)
+	}, func() {})
+}
+
+// PruneGraphNodes removes all nodes that are no longer connected to any
+// channel, except for the source node.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) PruneGraphNodes() error {
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		return pruneGraphNodes(ctx, db)
+	}, func() {})
+}
+
+// DisconnectBlockAtHeight removes the channels that were confirmed at or
+// above the disconnected block, along with the prune log entries starting at
+// its height.
+//
+// NOTE: This is part of the GraphMirror interface.
+func (s *SQLGraphStore) DisconnectBlockAtHeight(height uint32,
+	removedChanIDs []uint64) error {
+
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	ctx := context.TODO()
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLGraphQueries) error {
+		err := deleteGraphChannels(ctx, db, removedChanIDs)
+		if err != nil {
+			return err
+		}
+
+		return db.DeleteGraphPruneLogEntries(ctx, int64(height))
+	}, func() {})
+}
+
+// unmarshalGraphNode deserializes a node read from the database.
+func unmarshalGraphNode(dbNode sqlc.GraphNode) (*LightningNode, error) {
+	node, err := deserializeLightningNode(
+		bytes.NewReader(dbNode.NodeData),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
+
+// fetchGraphChannel deserializes a channel read from the database and fetches
+// its policies. A policy that is unknown is returned as nil.
+func fetchGraphChannel(ctx context.Context, db SQLGraphQueries,
+	dbChan sqlc.GraphChannel) (*ChannelEdge, error) {
+
+	info, err := deserializeChanEdgeInfo(bytes.NewReader(dbChan.EdgeInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	dbPolicies, err := db.GetGraphChannelPolicies(ctx, dbChan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := &ChannelEdge{
+		Info: &info,
+	}
+	for _, dbPolicy := range dbPolicies {
+		policy, err := deserializeChanEdgePolicy(
+			bytes.NewReader(dbPolicy.Policy),
+		)
+		switch {
+		// Just like the kvdb graph, we treat a policy with a missing
+		// optional field as unknown.
+		case errors.Is(err, ErrEdgePolicyOptionalFieldNotFound):
+			continue
+
+		case err != nil:
+			return nil, err
+		}
+
+		if dbPolicy.Direction == 0 {
+			channel.Policy1 = policy
+		} else {
+			channel.Policy2 = policy
+		}
+	}
+
+	return channel, nil
+}
+
+// FetchNode returns the node with the given public key. ErrGraphNodeNotFound
+// is returned if the node isn't known.
+func (s *SQLGraphStore) FetchNode(ctx context.Context,
+	nodePub route.Vertex) (*LightningNode, error) {
+
+	var node *LightningNode
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		dbNode, err := db.GetGraphNode(ctx, nodePub[:])
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrGraphNodeNotFound
+
+		case err != nil:
+			return err
+		}
+
+		node, err = unmarshalGraphNode(dbNode)
+
+		return err
+	}, func() {
+		node = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// SourceNode returns the source node of the graph. ErrSourceNodeNotSet is
+// returned if it hasn't been set yet.
+func (s *SQLGraphStore) SourceNode(ctx context.Context) (*LightningNode,
+	error) {
+
+	var node *LightningNode
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		dbNode, err := db.GetGraphSourceNode(ctx)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrSourceNodeNotSet
+
+		case err != nil:
+			return err
+		}
+
+		node, err = unmarshalGraphNode(dbNode)
+
+		return err
+	}, func() {
+		node = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// NumNodes returns the number of nodes in the graph.
+func (s *SQLGraphStore) NumNodes(ctx context.Context) (uint64, error) {
+	var numNodes int64
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		var err error
+		numNodes, err = db.CountGraphNodes(ctx)
+
+		return err
+	}, func() {
+		numNodes = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(numNodes), nil
+}
+
+// ListNodes returns up to limit nodes with a public key greater than the given
+// one that were updated at or after the given time, ordered by their public
+// key. Passing the public key of the last returned node allows the caller to
+// page through the graph.
+func (s *SQLGraphStore) ListNodes(ctx context.Context, after route.Vertex,
+	updatedSince time.Time, limit uint32) ([]*LightningNode, error) {
+
+	var nodes []*LightningNode
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		dbNodes, err := db.ListGraphNodes(
+			ctx, sqlc.ListGraphNodesParams{
+				AfterPubKey:   after[:],
+				MinLastUpdate: updatedSince.Unix(),
+				NumLimit:      sqlLimit(limit),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, dbNode := range dbNodes {
+			node, err := unmarshalGraphNode(dbNode)
+			if err != nil {
+				return err
+			}
+
+			nodes = append(nodes, node)
+		}
+
+		return nil
+	}, func() {
+		nodes = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// FetchChannel returns the channel with the given ID along with its policies.
+// ErrEdgeNotFound is returned if the channel isn't known.
+func (s *SQLGraphStore) FetchChannel(ctx context.Context,
+	chanID uint64) (*ChannelEdge, error) {
+
+	var channel *ChannelEdge
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		dbChan, err := db.GetGraphChannel(ctx, int64(chanID))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEdgeNotFound
+
+		case err != nil:
+			return err
+		}
+
+		channel, err = fetchGraphChannel(ctx, db, dbChan)
+
+		return err
+	}, func() {
+		channel = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// ListChannels returns up to limit channels with an ID greater than the given
+// one that had a policy updated at or after the given time, ordered by their
+// ID. Passing the ID of the last returned channel allows the caller to page
+// through the graph. The nodes of the returned channels aren't set.
+func (s *SQLGraphStore) ListChannels(ctx context.Context, afterChanID uint64,
+	updatedSince time.Time, limit uint32) ([]*ChannelEdge, error) {
+
+	var channels []*ChannelEdge
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		dbChans, err := db.ListGraphChannels(
+			ctx, sqlc.ListGraphChannelsParams{
+				AfterScid:     int64(afterChanID),
+				MinLastUpdate: updatedSince.Unix(),
+				NumLimit:      sqlLimit(limit),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		return appendGraphChannels(ctx, db, dbChans, &channels)
+	}, func() {
+		channels = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// FetchNodeChannels returns all channels of the node with the given public
+// key, ordered by their ID. The nodes of the returned channels aren't set.
+func (s *SQLGraphStore) FetchNodeChannels(ctx context.Context,
+	nodePub route.Vertex) ([]*ChannelEdge, error) {
+
+	var channels []*ChannelEdge
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		dbChans, err := db.ListGraphNodeChannels(ctx, nodePub[:])
+		if err != nil {
+			return err
+		}
+
+		return appendGraphChannels(ctx, db, dbChans, &channels)
+	}, func() {
+		channels = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// appendGraphChannels fetches the policies of the given channels and appends
+// them to the passed slice.
+func appendGraphChannels(ctx context.Context, db SQLGraphQueries,
+	dbChans []sqlc.GraphChannel, channels *[]*ChannelEdge) error {
+
+	for _, dbChan := range dbChans {
+		channel, err := fetchGraphChannel(ctx, db, dbChan)
+		if err != nil {
+			return err
+		}
+
+		*channels = append(*channels, channel)
+	}
+
+	return nil
+}
+
+// IsZombieEdge returns whether the channel with the given ID is a zombie. If
+// it is, the public keys of the nodes that may resurrect it are returned as
+// well.
+func (s *SQLGraphStore) IsZombieEdge(ctx context.Context,
+	chanID uint64) (bool, [33]byte, [33]byte, error) {
+
+	var (
+		isZombie         bool
+		pubKey1, pubKey2 [33]byte
+	)
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		zombie, err := db.GetGraphZombieChannel(ctx, int64(chanID))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+
+		case err != nil:
+			return err
+		}
+
+		isZombie = true
+		copy(pubKey1[:], zombie.NodeKey1)
+		copy(pubKey2[:], zombie.NodeKey2)
+
+		return nil
+	}, func() {
+		isZombie = false
+		pubKey1, pubKey2 = [33]byte{}, [33]byte{}
+	})
+	if err != nil {
+		return false, [33]byte{}, [33]byte{}, err
+	}
+
+	return isZombie, pubKey1, pubKey2, nil
+}
+
+// PruneTip returns the block height and hash of the latest block the graph was
+// pruned with. ErrGraphNeverPruned is returned if the graph was never pruned.
+func (s *SQLGraphStore) PruneTip(ctx context.Context) (*chainhash.Hash,
+	uint32, error) {
+
+	var (
+		tipHash   chainhash.Hash
+		tipHeight uint32
+	)
+
+	readTxOpts := NewSQLGraphQueryReadTx()
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLGraphQueries) error {
+		tip, err := db.GetGraphPruneTip(ctx)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrGraphNeverPruned
+
+		case err != nil:
+			return err
+		}
+
+		copy(tipHash[:], tip.BlockHash)
+		tipHeight = uint32(tip.BlockHeight)
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &tipHash, tipHeight, nil
+}
+
+// sqlLimit converts a result limit to the type used by the queries, where a
+// limit of zero means no limit.
+func sqlLimit(limit uint32) int32 {
+	if limit == 0 || limit > math.MaxInt32 {
+		return math.MaxInt32
+	}
+
+	return int32(limit)
+}
+
+// MigrateFromKV copies the given kvdb channel graph to the SQL graph store,
+// unless it already contains any nodes. The graph is copied within a single
+// transaction, so the migration either completes or is retried from scratch
+// on the next startup.
+func (s *SQLGraphStore) MigrateFromKV(ctx context.Context,
+	graph *ChannelGraph) error {
+
+	var writeTxOpts SQLGraphQueriesTxOptions
+
+	txBody := func(db SQLGraphQueries) error {
+		numNodes, err := db.CountGraphNodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The graph has already been migrated.
+		if numNodes > 0 {
+			return nil
+		}
+
+		err = graph.ForEachNode(func(_ kvdb.RTx,
+			node *LightningNode) error {
+
+			_, err := upsertGraphNode(ctx, db, node)
+			return err
+		})
+		if err != nil && !errors.Is(err, ErrGraphNotFound) {
+			return err
+		}
+
+		sourceNode, err := graph.SourceNode()
+		switch {
+		case err == nil:
+			nodeID, err := upsertGraphNode(ctx, db, sourceNode)
+			if err != nil {
+				return err
+			}
+
+			if err := db.InsertGraphSourceNode(ctx, nodeID); err != nil {
+				return err
+			}
+
+		case !errors.Is(err, ErrSourceNodeNotSet) &&
+			!errors.Is(err, ErrGraphNotFound):
+
+			return err
+		}
+
+		err = graph.ForEachChannel(func(info *models.ChannelEdgeInfo,
+			policy1, policy2 *models.ChannelEdgePolicy) error {
+
+			if err := upsertGraphChannel(ctx, db, info); err != nil {
+				return err
+			}
+
+			policies := []*models.ChannelEdgePolicy{policy1, policy2}
+			for _, policy := range policies {
+				if policy == nil {
+					continue
+				}
+
+				err := upsertGraphChannelPolicy(ctx, db, policy)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil && !errors.Is(err, ErrGraphNoEdgesFound) {
+			return err
+		}
+
+		err = graph.forEachZombieEdge(func(chanID uint64, pubKey1,
+			pubKey2 [33]byte) error {
+
+			return db.UpsertGraphZombieChannel(
+				ctx, sqlc.UpsertGraphZombieChannelParams{
+					Scid:     int64(chanID),
+					NodeKey1: pubKey1[:],
+					NodeKey2: pubKey2[:],
+				},
+			)
+		})
+		if err != nil {
+			return err
+		}
+
+		return graph.forEachPruneLogEntry(func(height uint32,
+			hash chainhash.Hash) error {
+
+			return db.UpsertGraphPruneLogEntry(
+				ctx, sqlc.UpsertGraphPruneLogEntryParams{
+					BlockHeight: int64(height),
+					BlockHash:   hash[:],
+				},
+			)
+		})
+	}
+
+	return s.db.ExecTx(ctx, &writeTxOpts, txBody, func() {})
+}
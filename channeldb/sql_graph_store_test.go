@@ -0,0 +1,252 @@
+package channeldb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSQLGraphStore creates a new SQL graph store backed by a fresh sqlite
+// database.
+func newTestSQLGraphStore(t *testing.T) *SQLGraphStore {
+	sqlDB := sqldb.NewTestSqliteDB(t).BaseDB
+	executor := sqldb.NewTransactionExecutor(
+		sqlDB, func(tx *sql.Tx) SQLGraphQueries {
+			return sqlDB.WithTx(tx)
+		},
+	)
+
+	return NewSQLGraphStore(executor)
+}
+
+// TestSQLGraphStoreMirror tests that the writes to the channel graph are
+// mirrored to the SQL graph store when it's set as the GraphMirror of the
+// ChannelGraph.
+func TestSQLGraphStoreMirror(t *testing.T) {
+	t.Parallel()
+
+	graph, err := MakeTestGraph(t)
+	require.NoError(t, err, "unable to make test database")
+
+	store := newTestSQLGraphStore(t)
+	graph.SetGraphMirror(store)
+
+	ctx := context.Background()
+
+	sourceNode, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	require.NoError(t, graph.SetSourceNode(sourceNode))
+
+	dbSourceNode, err := store.SourceNode(ctx)
+	require.NoError(t, err)
+	require.NoError(t, compareNodes(sourceNode, dbSourceNode))
+
+	// Add two nodes along with a channel between them.
+	node1, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	node2, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	require.NoError(t, graph.AddLightningNode(node1))
+	require.NoError(t, graph.AddLightningNode(node2))
+
+	dbNode, err := store.FetchNode(ctx, node1.PubKeyBytes)
+	require.NoError(t, err)
+	require.NoError(t, compareNodes(node1, dbNode))
+
+	edgeInfo, policy1, policy2 := createChannelEdge(graph.db, node1, node2)
+	require.NoError(t, graph.AddChannelEdge(edgeInfo))
+
+	channel, err := store.FetchChannel(ctx, edgeInfo.ChannelID)
+	require.NoError(t, err)
+	assertEdgeInfoEqual(t, edgeInfo, channel.Info)
+	require.Nil(t, channel.Policy1)
+	require.Nil(t, channel.Policy2)
+
+	// Both policies should be mirrored to their direction of the channel.
+	require.NoError(t, graph.UpdateEdgePolicy(policy1))
+	require.NoError(t, graph.UpdateEdgePolicy(policy2))
+
+	channel, err = store.FetchChannel(ctx, edgeInfo.ChannelID)
+	require.NoError(t, err)
+	require.NoError(t, compareEdgePolicies(policy1, channel.Policy1))
+	require.NoError(t, compareEdgePolicies(policy2, channel.Policy2))
+
+	nodeChannels, err := store.FetchNodeChannels(ctx, node2.PubKeyBytes)
+	require.NoError(t, err)
+	require.Len(t, nodeChannels, 1)
+	require.Equal(t, edgeInfo.ChannelID, nodeChannels[0].Info.ChannelID)
+
+	// The channel is only returned if one of its policies was updated at
+	// or after the given time, which is the later one of the two.
+	channels, err := store.ListChannels(ctx, 0, policy1.LastUpdate, 0)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	channels, err = store.ListChannels(
+		ctx, 0, policy1.LastUpdate.Add(time.Second), 0,
+	)
+	require.NoError(t, err)
+	require.Empty(t, channels)
+
+	// Paging through the nodes one at a time should return all of them in
+	// the order of their public key.
+	var (
+		after    route.Vertex
+		numNodes int
+	)
+	for {
+		nodes, err := store.ListNodes(ctx, after, time.Unix(0, 0), 1)
+		require.NoError(t, err)
+		if len(nodes) == 0 {
+			break
+		}
+
+		require.Len(t, nodes, 1)
+		require.Positive(
+			t, bytes.Compare(nodes[0].PubKeyBytes[:], after[:]),
+		)
+
+		after = nodes[0].PubKeyBytes
+		numNodes++
+	}
+	require.Equal(t, 3, numNodes)
+
+	// Marking the channel as zombie and live again should be reflected in
+	// the zombie index.
+	err = graph.MarkEdgeZombie(
+		edgeInfo.ChannelID, node1.PubKeyBytes, node2.PubKeyBytes,
+	)
+	require.NoError(t, err)
+
+	isZombie, _, _, err := store.IsZombieEdge(ctx, edgeInfo.ChannelID)
+	require.NoError(t, err)
+	require.True(t, isZombie)
+
+	require.NoError(t, graph.MarkEdgeLive(edgeInfo.ChannelID))
+
+	isZombie, _, _, err = store.IsZombieEdge(ctx, edgeInfo.ChannelID)
+	require.NoError(t, err)
+	require.False(t, isZombie)
+
+	// Deleting the channel as a zombie should remove it along with its
+	// policies and add it to the zombie index with the same keys as the
+	// KV graph.
+	err = graph.DeleteChannelEdges(true, true, edgeInfo.ChannelID)
+	require.NoError(t, err)
+
+	_, err = store.FetchChannel(ctx, edgeInfo.ChannelID)
+	require.ErrorIs(t, err, ErrEdgeNotFound)
+
+	_, kvKey1, kvKey2 := graph.IsZombieEdge(edgeInfo.ChannelID)
+	isZombie, key1, key2, err := store.IsZombieEdge(
+		ctx, edgeInfo.ChannelID,
+	)
+	require.NoError(t, err)
+	require.True(t, isZombie)
+	require.Equal(t, kvKey1, key1)
+	require.Equal(t, kvKey2, key2)
+
+	// Pruning the graph should add the block to the prune log and remove
+	// the now unconnected nodes, except for the source node.
+	blockHash := chainhash.Hash{1}
+	_, err = graph.PruneGraph([]*wire.OutPoint{}, &blockHash, 100)
+	require.NoError(t, err)
+
+	tipHash, tipHeight, err := store.PruneTip(ctx)
+	require.NoError(t, err)
+	require.Equal(t, blockHash, *tipHash)
+	require.EqualValues(t, 100, tipHeight)
+
+	_, err = store.FetchNode(ctx, node1.PubKeyBytes)
+	require.ErrorIs(t, err, ErrGraphNodeNotFound)
+
+	numGraphNodes, err := store.NumNodes(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, numGraphNodes)
+
+	// Disconnecting the block should remove it from the prune log again.
+	_, err = graph.DisconnectBlockAtHeight(100)
+	require.NoError(t, err)
+
+	_, _, err = store.PruneTip(ctx)
+	require.ErrorIs(t, err, ErrGraphNeverPruned)
+}
+
+// TestSQLGraphStoreMigrateFromKV tests that an existing KV channel graph is
+// copied to the SQL graph store, and that the migration is only done once.
+func TestSQLGraphStoreMigrateFromKV(t *testing.T) {
+	t.Parallel()
+
+	graph, err := MakeTestGraph(t)
+	require.NoError(t, err, "unable to make test database")
+
+	sourceNode, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	require.NoError(t, graph.SetSourceNode(sourceNode))
+
+	node1, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	node2, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	require.NoError(t, graph.AddLightningNode(node1))
+	require.NoError(t, graph.AddLightningNode(node2))
+
+	edgeInfo, policy1, _ := createChannelEdge(graph.db, node1, node2)
+	require.NoError(t, graph.AddChannelEdge(edgeInfo))
+	require.NoError(t, graph.UpdateEdgePolicy(policy1))
+
+	var zombieKey1, zombieKey2 [33]byte
+	zombieKey1[0], zombieKey2[0] = 2, 3
+	require.NoError(t, graph.MarkEdgeZombie(1234, zombieKey1, zombieKey2))
+
+	blockHash := chainhash.Hash{1}
+	_, err = graph.PruneGraph(nil, &blockHash, 100)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store := newTestSQLGraphStore(t)
+	require.NoError(t, store.MigrateFromKV(ctx, graph))
+
+	numNodes, err := store.NumNodes(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, numNodes)
+
+	dbSourceNode, err := store.SourceNode(ctx)
+	require.NoError(t, err)
+	require.NoError(t, compareNodes(sourceNode, dbSourceNode))
+
+	channel, err := store.FetchChannel(ctx, edgeInfo.ChannelID)
+	require.NoError(t, err)
+	assertEdgeInfoEqual(t, edgeInfo, channel.Info)
+	require.NoError(t, compareEdgePolicies(policy1, channel.Policy1))
+	require.Nil(t, channel.Policy2)
+
+	isZombie, key1, key2, err := store.IsZombieEdge(ctx, 1234)
+	require.NoError(t, err)
+	require.True(t, isZombie)
+	require.Equal(t, zombieKey1, key1)
+	require.Equal(t, zombieKey2, key2)
+
+	tipHash, tipHeight, err := store.PruneTip(ctx)
+	require.NoError(t, err)
+	require.Equal(t, blockHash, *tipHash)
+	require.EqualValues(t, 100, tipHeight)
+
+	// A second migration is a no-op, so a node that was added to the KV
+	// graph in the meantime isn't copied.
+	node3, err := createTestVertex(graph.db)
+	require.NoError(t, err)
+	require.NoError(t, graph.AddLightningNode(node3))
+	require.NoError(t, store.MigrateFromKV(ctx, graph))
+
+	_, err = store.FetchNode(ctx, node3.PubKeyBytes)
+	require.ErrorIs(t, err, ErrGraphNodeNotFound)
+}
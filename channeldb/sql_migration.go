@@ -0,0 +1,388 @@
+package channeldb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/sqldb/sqlc"
+)
+
+const (
+	// MigrationBucketChannels is the name of the migration step that
+	// copies the channels, their commitments and forwarding packages.
+	MigrationBucketChannels = "channels"
+
+	// MigrationBucketRevocationLog is the name of the migration step that
+	// copies the revocation logs of all channels.
+	MigrationBucketRevocationLog = "revocation-log"
+
+	// DefaultMigrationBatchSize is the default number of records that are
+	// copied within a single SQL transaction.
+	DefaultMigrationBatchSize = 1000
+)
+
+// ErrDeprecatedRevocationLog is returned when migrating a channel that still
+// has entries in the deprecated revocation log format.
+var ErrDeprecatedRevocationLog = errors.New("channel has revocation log " +
+	"entries in the deprecated format, run the revocation log pruning " +
+	"migration (db.prune-revocation) first")
+
+// SQLMigrationProgress describes the progress of a migration of the channel
+// state to the SQL channel store.
+type SQLMigrationProgress struct {
+	// Bucket is the name of the migration step that is currently running.
+	Bucket string
+
+	// RecordsDone is the number of records of the bucket that have been
+	// migrated so far.
+	RecordsDone uint64
+
+	// RecordsTotal is the total number of records of the bucket.
+	RecordsTotal uint64
+}
+
+// revLogRecord is a serialized revocation log entry read from the kvdb store.
+type revLogRecord struct {
+	height uint64
+	entry  []byte
+}
+
+// MigrateToSQL copies the state of all open channels to the SQL channel store
+// in batches of the given size, calling the progress callback after each
+// batch. Records that already exist in the SQL store are never overwritten,
+// which makes the migration resumable and safe to run while the channel state
+// is mirrored to the SQL store in dual-write mode. The kvdb store stays
+// authoritative throughout, so the node remains consistent if the migration
+// is interrupted at any point.
+func (c *ChannelStateDB) MigrateToSQL(ctx context.Context,
+	store *SQLChannelStore, batchSize int,
+	progress func(SQLMigrationProgress)) error {
+
+	if batchSize <= 0 {
+		batchSize = DefaultMigrationBatchSize
+	}
+
+	channels, err := c.FetchAllOpenChannels()
+	if err != nil {
+		return err
+	}
+
+	// First, we copy the channels along with their commitments and
+	// forwarding packages, which are small in comparison to the
+	// revocation logs.
+	for i, channel := range channels {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var fwdPkgs []*FwdPkg
+		err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+			var err error
+			fwdPkgs, err = channel.Packager.LoadFwdPkgs(tx)
+
+			return err
+		}, func() {
+			fwdPkgs = nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to load forwarding packages "+
+				"of ChannelPoint(%v): %w",
+				channel.FundingOutpoint, err)
+		}
+
+		err = store.migrateChannel(ctx, channel, fwdPkgs)
+		if err != nil {
+			return fmt.Errorf("unable to migrate "+
+				"ChannelPoint(%v): %w", channel.FundingOutpoint,
+				err)
+		}
+
+		progress(SQLMigrationProgress{
+			Bucket:       MigrationBucketChannels,
+			RecordsDone:  uint64(i + 1),
+			RecordsTotal: uint64(len(channels)),
+		})
+	}
+
+	// Before copying the revocation logs, we count their entries so we
+	// can report the total.
+	var total uint64
+	for _, channel := range channels {
+		numEntries, err := c.numRevocationLogEntries(channel)
+		if err != nil {
+			return fmt.Errorf("unable to count revocation log of "+
+				"ChannelPoint(%v): %w", channel.FundingOutpoint,
+				err)
+		}
+
+		total += numEntries
+	}
+
+	var done uint64
+	for _, channel := range channels {
+		var startHeight uint64
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			batch, err := c.fetchRevocationLogBatch(
+				channel, startHeight, batchSize,
+			)
+			if err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				break
+			}
+
+			err = store.migrateRevocationLogBatch(
+				ctx, channel, batch,
+			)
+			if err != nil {
+				return fmt.Errorf("unable to migrate "+
+					"revocation log of ChannelPoint(%v): "+
+					"%w", channel.FundingOutpoint, err)
+			}
+
+			done += uint64(len(batch))
+			progress(SQLMigrationProgress{
+				Bucket:       MigrationBucketRevocationLog,
+				RecordsDone:  done,
+				RecordsTotal: total,
+			})
+
+			startHeight = batch[len(batch)-1].height + 1
+		}
+	}
+
+	return nil
+}
+
+// fetchRevocationLogReadBucket returns the revocation log bucket of the given
+// channel, or nil if the channel doesn't have one yet.
+func fetchRevocationLogReadBucket(tx kvdb.RTx,
+	channel *OpenChannel) (kvdb.RBucket, error) {
+
+	chanBucket, err := fetchChanBucket(
+		tx, channel.IdentityPub, &channel.FundingOutpoint,
+		channel.ChainHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// We can only migrate the current revocation log format, as the
+	// deprecated one lacks the output indexes.
+	oldBucket := chanBucket.NestedReadBucket(revocationLogBucketDeprecated)
+	if oldBucket != nil {
+		k, _ := oldBucket.ReadCursor().First()
+		if k != nil {
+			return nil, ErrDeprecatedRevocationLog
+		}
+	}
+
+	return chanBucket.NestedReadBucket(revocationLogBucket), nil
+}
+
+// numRevocationLogEntries returns the number of revocation log entries stored
+// for the given channel.
+func (c *ChannelStateDB) numRevocationLogEntries(
+	channel *OpenChannel) (uint64, error) {
+
+	var numEntries uint64
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		logBucket, err := fetchRevocationLogReadBucket(tx, channel)
+		if err != nil || logBucket == nil {
+			return err
+		}
+
+		return logBucket.ForEach(func(_, _ []byte) error {
+			numEntries++
+			return nil
+		})
+	}, func() {
+		numEntries = 0
+	})
+
+	return numEntries, err
+}
+
+// fetchRevocationLogBatch returns up to batchSize serialized revocation log
+// entries of the given channel, starting at the given commit height.
+func (c *ChannelStateDB) fetchRevocationLogBatch(channel *OpenChannel,
+	startHeight uint64, batchSize int) ([]revLogRecord, error) {
+
+	var batch []revLogRecord
+	err := kvdb.View(c.backend, func(tx kvdb.RTx) error {
+		logBucket, err := fetchRevocationLogReadBucket(tx, channel)
+		if err != nil || logBucket == nil {
+			return err
+		}
+
+		startKey := makeLogKey(startHeight)
+		cursor := logBucket.ReadCursor()
+		k, v := cursor.Seek(startKey[:])
+		for ; k != nil && len(batch) < batchSize; k, v = cursor.Next() {
+			batch = append(batch, revLogRecord{
+				height: byteOrder.Uint64(k),
+				entry:  append([]byte(nil), v...),
+			})
+		}
+
+		return nil
+	}, func() {
+		batch = nil
+	})
+
+	return batch, err
+}
+
+// migrateChannel copies the static information, the commitments and the
+// forwarding packages of the channel. Commitments and forwarding packages that
+// already exist aren't overwritten, as they may have been updated since the
+// channel was read from the kvdb store.
+func (s *SQLChannelStore) migrateChannel(ctx context.Context,
+	c *OpenChannel, fwdPkgs []*FwdPkg) error {
+
+	type commitment struct {
+		local bool
+		blob  []byte
+	}
+
+	var commits []commitment
+
+	// A restored channel doesn't have any commitments.
+	if !c.hasChanStatus(ChanStatusRestored) {
+		for _, local := range []bool{true, false} {
+			commit := &c.LocalCommitment
+			if !local {
+				commit = &c.RemoteCommitment
+			}
+
+			var b bytes.Buffer
+			if err := serializeChanCommit(&b, commit); err != nil {
+				return err
+			}
+
+			commits = append(commits, commitment{
+				local: local,
+				blob:  b.Bytes(),
+			})
+		}
+	}
+
+	pkgParams := make([]sqlc.UpsertFwdPkgParams, 0, len(fwdPkgs))
+	for _, fwdPkg := range fwdPkgs {
+		params, err := marshalFwdPkg(fwdPkg)
+		if err != nil {
+			return err
+		}
+
+		pkgParams = append(pkgParams, params)
+	}
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := upsertChannel(ctx, db, c)
+		if err != nil {
+			return err
+		}
+
+		for _, commit := range commits {
+			height := c.LocalCommitment.CommitHeight
+			if !commit.local {
+				height = c.RemoteCommitment.CommitHeight
+			}
+
+			err := db.InsertChannelCommitment(
+				ctx, sqlc.InsertChannelCommitmentParams{
+					ChannelID:    chanID,
+					IsLocal:      commit.local,
+					CommitHeight: int64(height),
+					Commitment:   commit.blob,
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, params := range pkgParams {
+			params.ChannelID = chanID
+
+			err := db.InsertFwdPkg(
+				ctx, sqlc.InsertFwdPkgParams(params),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// migrateRevocationLogBatch copies a batch of revocation log entries of the
+// channel within a single transaction. A batch that was fully copied before
+// is skipped.
+func (s *SQLChannelStore) migrateRevocationLogBatch(ctx context.Context,
+	c *OpenChannel, batch []revLogRecord) error {
+
+	params := make([]sqlc.InsertRevocationLogEntryParams, 0, len(batch))
+	for _, record := range batch {
+		rl, err := deserializeRevocationLog(
+			bytes.NewReader(record.entry),
+		)
+		if err != nil {
+			return err
+		}
+
+		params = append(params, sqlc.InsertRevocationLogEntryParams{
+			CommitHeight: int64(record.height),
+			CommitTxHash: rl.CommitTxHash[:],
+			Entry:        record.entry,
+		})
+	}
+
+	var writeTxOpts SQLChannelQueriesTxOptions
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLChannelQueries) error {
+		chanID, err := upsertChannel(ctx, db, c)
+		if err != nil {
+			return err
+		}
+
+		firstHeight := batch[0].height
+		lastHeight := batch[len(batch)-1].height
+		numMigrated, err := db.CountRevocationLogEntriesInRange(
+			ctx, sqlc.CountRevocationLogEntriesInRangeParams{
+				ChannelID:      chanID,
+				CommitHeight:   int64(firstHeight),
+				CommitHeight_2: int64(lastHeight),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		if numMigrated == int64(len(batch)) {
+			return nil
+		}
+
+		for _, p := range params {
+			p.ChannelID = chanID
+
+			err := db.InsertRevocationLogEntry(ctx, p)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
@@ -0,0 +1,145 @@
+package channeldb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrateToSQL tests that the state of the open channels is copied to the
+// SQL channel store in batches, and that the migration can be run again
+// without altering the already migrated records.
+func TestMigrateToSQL(t *testing.T) {
+	t.Parallel()
+
+	fullDB, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to make test database")
+
+	cdb := fullDB.ChannelStateDB()
+
+	// We create a channel and let the remote party revoke a number of
+	// commitments before the SQL store is set up.
+	const numStates = 5
+	channel := createTestChannel(t, cdb, openChannelOption())
+	for i := 0; i < numStates; i++ {
+		commitDiff := &CommitDiff{
+			Commitment: channel.RemoteCommitment,
+			CommitSig: &lnwire.CommitSig{
+				ChanID:    lnwire.ChannelID(key),
+				CommitSig: wireSig,
+			},
+			LogUpdates:        []LogUpdate{},
+			OpenedCircuitKeys: []models.CircuitKey{},
+			ClosedCircuitKeys: []models.CircuitKey{},
+		}
+		commitDiff.Commitment.CommitHeight++
+		require.NoError(t, channel.AppendRemoteCommitChain(commitDiff))
+
+		fwdPkg := NewFwdPkg(
+			channel.ShortChanID(),
+			channel.RemoteCommitment.CommitHeight, nil, nil,
+		)
+		err = channel.AdvanceCommitChainTail(
+			fwdPkg, nil, dummyLocalOutputIndex, dummyRemoteOutIndex,
+		)
+		require.NoError(t, err)
+	}
+
+	sqlDB := sqldb.NewTestSqliteDB(t).BaseDB
+	executor := sqldb.NewTransactionExecutor(
+		sqlDB, func(tx *sql.Tx) SQLChannelQueries {
+			return sqlDB.WithTx(tx)
+		},
+	)
+	store := NewSQLChannelStore(executor)
+
+	ctx := context.Background()
+
+	var updates []SQLMigrationProgress
+	progress := func(p SQLMigrationProgress) {
+		updates = append(updates, p)
+	}
+
+	// With a batch size of two, the five revocation log entries should be
+	// copied in three batches.
+	require.NoError(t, cdb.MigrateToSQL(ctx, store, 2, progress))
+	require.Equal(t, []SQLMigrationProgress{{
+		Bucket:       MigrationBucketChannels,
+		RecordsDone:  1,
+		RecordsTotal: 1,
+	}, {
+		Bucket:       MigrationBucketRevocationLog,
+		RecordsDone:  2,
+		RecordsTotal: numStates,
+	}, {
+		Bucket:       MigrationBucketRevocationLog,
+		RecordsDone:  4,
+		RecordsTotal: numStates,
+	}, {
+		Bucket:       MigrationBucketRevocationLog,
+		RecordsDone:  numStates,
+		RecordsTotal: numStates,
+	}}, updates)
+
+	assertMigrated := func() {
+		t.Helper()
+
+		chanPoint := channel.FundingOutpoint
+
+		localCommit, err := store.FetchCommitment(ctx, chanPoint, true)
+		require.NoError(t, err)
+		assertCommitmentEqual(t, &channel.LocalCommitment, localCommit)
+
+		remoteCommit, err := store.FetchCommitment(
+			ctx, chanPoint, false,
+		)
+		require.NoError(t, err)
+		assertCommitmentEqual(
+			t, &channel.RemoteCommitment, remoteCommit,
+		)
+
+		numEntries, err := store.NumRevocationLogEntries(
+			ctx, chanPoint,
+		)
+		require.NoError(t, err)
+		require.EqualValues(t, numStates, numEntries)
+
+		for height := uint64(0); height < numStates; height++ {
+			kvLog, _, err := channel.FindPreviousState(height)
+			require.NoError(t, err)
+
+			sqlLog, err := store.FetchRevocationLog(
+				ctx, chanPoint, height,
+			)
+			require.NoError(t, err)
+			require.Equal(t, kvLog, sqlLog)
+		}
+
+		var kvPkgs []*FwdPkg
+		err = kvdb.View(cdb.backend, func(tx kvdb.RTx) error {
+			var err error
+			kvPkgs, err = channel.Packager.LoadFwdPkgs(tx)
+			return err
+		}, func() {
+			kvPkgs = nil
+		})
+		require.NoError(t, err)
+
+		sqlPkgs, err := store.FetchFwdPkgs(ctx, chanPoint)
+		require.NoError(t, err)
+		require.Equal(t, kvPkgs, sqlPkgs)
+	}
+	assertMigrated()
+
+	// Running the migration again should leave the SQL store untouched.
+	updates = nil
+	require.NoError(t, cdb.MigrateToSQL(ctx, store, 0, progress))
+	require.Len(t, updates, 2)
+	assertMigrated()
+}
@@ -2,9 +2,11 @@ package channelnotifier
 
 import (
 	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/models"
 	"github.com/lightningnetwork/lnd/subscribe"
 )
 
@@ -72,6 +74,72 @@ type ClosedChannelEvent struct {
 	CloseSummary *channeldb.ChannelCloseSummary
 }
 
+// FundingTimeoutEvent represents a new event where a pending channel is
+// forgotten because its funding transaction didn't confirm in time.
+type FundingTimeoutEvent struct {
+	// CloseSummary is the summary of the canceled channel.
+	CloseSummary *channeldb.ChannelCloseSummary
+}
+
+// CloseConfirmedEvent represents a new event where the closing transaction of
+// a channel confirmed. The channel remains pending close until our commitment
+// output matures.
+type CloseConfirmedEvent struct {
+	// CloseSummary is the summary of the channel close that has occurred.
+	CloseSummary *channeldb.ChannelCloseSummary
+
+	// MaturityHeight is the height at which our output of the closing
+	// transaction can be swept. It equals the close height if our output
+	// isn't time locked.
+	MaturityHeight uint32
+}
+
+// PolicyUpdateEvent represents a new event where a new forwarding policy was
+// applied to a channel.
+type PolicyUpdateEvent struct {
+	// ChannelPoint is the channel point of the updated channel.
+	ChannelPoint *wire.OutPoint
+
+	// Policy is the forwarding policy that was applied to the channel.
+	Policy models.ForwardingPolicy
+}
+
+// ForwardingStatusEvent represents a new event where HTLC forwarding over a
+// channel was enabled or disabled by announcing a channel update.
+type ForwardingStatusEvent struct {
+	// ChannelPoint is the channel point of the channel.
+	ChannelPoint *wire.OutPoint
+
+	// Disabled is true if forwarding over the channel was disabled.
+	Disabled bool
+}
+
+// FailureDisabledChannelEvent represents a new event where a channel was
+// temporarily disabled because of consecutive local forwarding failures.
+type FailureDisabledChannelEvent struct {
+	// ChannelPoint is the channel point of the disabled channel.
+	ChannelPoint *wire.OutPoint
+
+	// NumFailures is the number of consecutive forwarding failures that
+	// caused the channel to be disabled.
+	NumFailures uint32
+
+	// ReenableTime is the earliest time at which the channel is enabled
+	// again.
+	ReenableTime time.Time
+}
+
+// FlappingChannelEvent represents a new event where a channel became inactive
+// because its remote peer is flapping, meaning that it frequently disconnects
+// and reconnects.
+type FlappingChannelEvent struct {
+	// ChannelPoint is the channel point of the inactive channel.
+	ChannelPoint *wire.OutPoint
+
+	// FlapCount is the number of flaps recorded for the remote peer.
+	FlapCount int
+}
+
 // FullyResolvedChannelEvent represents a new event where a channel becomes
 // fully resolved.
 type FullyResolvedChannelEvent struct {
@@ -172,6 +240,24 @@ func (c *ChannelNotifier) NotifyClosedChannelEvent(chanPoint wire.OutPoint) {
 	}
 }
 
+// NotifyFundingTimeoutEvent notifies the channelEventNotifier goroutine that a
+// pending channel was forgotten because its funding transaction didn't confirm
+// in time.
+func (c *ChannelNotifier) NotifyFundingTimeoutEvent(chanPoint wire.OutPoint) {
+	// Fetch the summary of the canceled channel from the database.
+	closeSummary, err := c.chanDB.FetchClosedChannel(&chanPoint)
+	if err != nil {
+		log.Warnf("Unable to fetch closed channel summary from the "+
+			"db: %v", err)
+	}
+
+	// Send the timeout event to all channel event subscribers.
+	event := FundingTimeoutEvent{CloseSummary: closeSummary}
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send funding timeout update: %v", err)
+	}
+}
+
 // NotifyFullyResolvedChannelEvent notifies the channelEventNotifier goroutine
 // that a channel was fully resolved on chain.
 func (c *ChannelNotifier) NotifyFullyResolvedChannelEvent(
@@ -219,3 +305,87 @@ func (c *ChannelNotifier) NotifyInactiveChannelEvent(chanPoint wire.OutPoint) {
 		log.Warnf("Unable to send inactive channel update: %v", err)
 	}
 }
+
+// NotifyCloseConfirmedEvent notifies the channelEventNotifier goroutine that
+// the closing transaction of a channel confirmed and that our output of it
+// matures at the given height.
+func (c *ChannelNotifier) NotifyCloseConfirmedEvent(chanPoint wire.OutPoint,
+	maturityHeight uint32) {
+
+	// Fetch the relevant closed channel from the database.
+	closeSummary, err := c.chanDB.FetchClosedChannel(&chanPoint)
+	if err != nil {
+		log.Warnf("Unable to fetch closed channel summary from the "+
+			"db: %v", err)
+
+		return
+	}
+
+	// Send the close confirmed event to all channel event subscribers.
+	event := CloseConfirmedEvent{
+		CloseSummary:   closeSummary,
+		MaturityHeight: maturityHeight,
+	}
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send close confirmed update: %v", err)
+	}
+}
+
+// NotifyPolicyUpdateEvent notifies the channelEventNotifier goroutine that a
+// new forwarding policy was applied to a channel.
+func (c *ChannelNotifier) NotifyPolicyUpdateEvent(chanPoint wire.OutPoint,
+	policy models.ForwardingPolicy) {
+
+	event := PolicyUpdateEvent{
+		ChannelPoint: &chanPoint,
+		Policy:       policy,
+	}
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send policy update: %v", err)
+	}
+}
+
+// NotifyForwardingStatusEvent notifies the channelEventNotifier goroutine that
+// HTLC forwarding over a channel was enabled or disabled.
+func (c *ChannelNotifier) NotifyForwardingStatusEvent(chanPoint wire.OutPoint,
+	disabled bool) {
+
+	event := ForwardingStatusEvent{
+		ChannelPoint: &chanPoint,
+		Disabled:     disabled,
+	}
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send forwarding status update: %v", err)
+	}
+}
+
+// NotifyFailureDisabledChannelEvent notifies the channelEventNotifier
+// goroutine that a channel was temporarily disabled because of consecutive
+// local forwarding failures.
+func (c *ChannelNotifier) NotifyFailureDisabledChannelEvent(
+	chanPoint wire.OutPoint, numFailures uint32, reenableTime time.Time) {
+
+	event := FailureDisabledChannelEvent{
+		ChannelPoint: &chanPoint,
+		NumFailures:  numFailures,
+		ReenableTime: reenableTime,
+	}
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send failure disabled channel update: %v",
+			err)
+	}
+}
+
+// NotifyFlappingChannelEvent notifies the channelEventNotifier goroutine that
+// a channel became inactive because its remote peer is flapping.
+func (c *ChannelNotifier) NotifyFlappingChannelEvent(chanPoint wire.OutPoint,
+	flapCount int) {
+
+	event := FlappingChannelEvent{
+		ChannelPoint: &chanPoint,
+		FlapCount:    flapCount,
+	}
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send flapping channel update: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+//go:build accountingrpc
+// +build accountingrpc
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc/accountingrpc"
+	"github.com/urfave/cli"
+)
+
+// accountingCommands will return the set of commands to enable for
+// accountingrpc builds.
+func accountingCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:     "accounting",
+			Category: "Accounting",
+			Usage:    "Exports the financial history of the node",
+			Subcommands: []cli.Command{
+				exportLedgerCommand,
+			},
+		},
+	}
+}
+
+func getAccountingClient(ctx *cli.Context) (accountingrpc.AccountingClient,
+	func()) {
+
+	conn := getClientConn(ctx, false)
+	cleanUp := func() {
+		conn.Close()
+	}
+	return accountingrpc.NewAccountingClient(conn), cleanUp
+}
+
+var exportLedgerCommand = cli.Command{
+	Name:     "exportledger",
+	Category: "Accounting",
+	Usage:    "Export a double-entry ledger of the node's events.",
+	Description: `
+	Export a double-entry ledger of the on-chain and off-chain events of
+	the node within a time window: channel opens and closes, sweeps,
+	on-chain fees, forwarding fees, settled invoices and sent payments.
+
+	Every entry moves an amount from a credit account to a debit account
+	and has an ID that is stable across exports, so overlapping exports
+	can be merged.
+
+	The times are expressed as unix timestamps or relative, e.g. "-1M".
+	By default, the whole history of the node is exported.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "start_time",
+			Usage: "(optional) the start of the time window, " +
+				`e.g. "-1y"`,
+		},
+		cli.StringFlag{
+			Name: "end_time",
+			Usage: "(optional) the end of the time window, " +
+				"defaults to now",
+		},
+		cli.BoolFlag{
+			Name:  "csv",
+			Usage: "print the ledger as CSV instead of JSON",
+		},
+	},
+	Action: actionDecorator(exportLedger),
+}
+
+func exportLedger(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getAccountingClient(ctx)
+	defer cleanUp()
+
+	req := &accountingrpc.ExportLedgerRequest{
+		Format: accountingrpc.LedgerFormat_JSON,
+	}
+
+	now := time.Now()
+	if ctx.IsSet("start_time") {
+		startTime, err := parseTime(ctx.String("start_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode start_time: %w",
+				err)
+		}
+		req.StartTime = startTime
+	}
+	if ctx.IsSet("end_time") {
+		endTime, err := parseTime(ctx.String("end_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode end_time: %w", err)
+		}
+		req.EndTime = endTime
+	}
+
+	if ctx.Bool("csv") {
+		req.Format = accountingrpc.LedgerFormat_CSV
+	}
+
+	resp, err := client.ExportLedger(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	if req.Format == accountingrpc.LedgerFormat_CSV {
+		fmt.Print(resp.Csv)
+
+		return nil
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !accountingrpc
+// +build !accountingrpc
+
+package main
+
+import "github.com/urfave/cli"
+
+// accountingCommands will return nil for non-accountingrpc builds.
+func accountingCommands() []cli.Command {
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var setAcceptorPolicyCommand = cli.Command{
+	Name:     "setacceptorpolicy",
+	Category: "Channels",
+	Usage:    "Set the policy inbound channel requests are checked against.",
+	Description: `
+	Replace the declarative policy that inbound channel requests are checked
+	against, in addition to any external channel acceptor. Every rule is
+	optional, calling the command without any flags resets the policy to
+	accept all channels.
+
+	The policy is kept in memory only and needs to be set again after lnd
+	is restarted.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "min_capacity",
+			Usage: "the minimum capacity in satoshis of an inbound " +
+				"channel",
+		},
+		cli.UintFlag{
+			Name: "max_pending_per_peer",
+			Usage: "the maximum number of pending channels a " +
+				"single peer may have with us",
+		},
+		cli.StringSliceFlag{
+			Name: "channel_type",
+			Usage: fmt.Sprintf("a channel type an inbound channel "+
+				"may use, one of '%s', '%s' or '%s', can be "+
+				"specified multiple times",
+				channelTypeTweakless, channelTypeAnchors,
+				channelTypeSimpleTaproot),
+		},
+		cli.StringSliceFlag{
+			Name: "allowed_ip_range",
+			Usage: "an IP range in CIDR notation a peer must be " +
+				"connected from to open a channel, can be " +
+				"specified multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: "zero_conf_peer",
+			Usage: "the hex encoded public key of a peer we accept " +
+				"zero-conf channels from, can be specified " +
+				"multiple times",
+		},
+	},
+	Action: actionDecorator(setAcceptorPolicy),
+}
+
+func setAcceptorPolicy(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ChannelAcceptorPolicy{
+		MinCapacitySat:    uint64(ctx.Int64("min_capacity")),
+		MaxPendingPerPeer: uint32(ctx.Uint("max_pending_per_peer")),
+		AllowedIpRanges:   ctx.StringSlice("allowed_ip_range"),
+	}
+
+	for _, channelType := range ctx.StringSlice("channel_type") {
+		var commitType lnrpc.CommitmentType
+		switch channelType {
+		case channelTypeTweakless:
+			commitType = lnrpc.CommitmentType_STATIC_REMOTE_KEY
+		case channelTypeAnchors:
+			commitType = lnrpc.CommitmentType_ANCHORS
+		case channelTypeSimpleTaproot:
+			commitType = lnrpc.CommitmentType_SIMPLE_TAPROOT
+		default:
+			return fmt.Errorf("unsupported channel type %v",
+				channelType)
+		}
+
+		req.CommitmentTypes = append(req.CommitmentTypes, commitType)
+	}
+
+	for _, peer := range ctx.StringSlice("zero_conf_peer") {
+		pubKey, err := hex.DecodeString(peer)
+		if err != nil {
+			return fmt.Errorf("unable to decode zero-conf peer "+
+				"%v: %w", peer, err)
+		}
+
+		req.ZeroConfPeers = append(req.ZeroConfPeers, pubKey)
+	}
+
+	resp, err := client.SetChannelAcceptorPolicy(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var getAcceptorPolicyCommand = cli.Command{
+	Name:     "getacceptorpolicy",
+	Category: "Channels",
+	Usage: "Show the policy inbound channel requests are checked " +
+		"against.",
+	Action: actionDecorator(getAcceptorPolicy),
+}
+
+func getAcceptorPolicy(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.GetChannelAcceptorPolicy(
+		ctxc, &lnrpc.GetChannelAcceptorPolicyRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
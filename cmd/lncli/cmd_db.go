@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var dbCommand = cli.Command{
+	Name:     "db",
+	Category: "Database",
+	Usage:    "Manage the databases of lnd.",
+	Subcommands: []cli.Command{
+		dbMigrateCommand,
+		dbInfoCommand,
+		dbBackupCommand,
+	},
+}
+
+var dbMigrateCommand = cli.Command{
+	Name:  "migrate",
+	Usage: "Migrate the channel state to the native SQL store.",
+	Description: `
+	Copy the channel state from the kvdb store to the native SQL store in
+	batches, printing the progress of the migration after each batch.
+
+	The kvdb store stays authoritative during the migration, which can be
+	interrupted and restarted at any time. Records that were already copied
+	are skipped. lnd needs to run with --db.dual-write-channel-state so the
+	SQL store is kept up to date with channel updates.`,
+	Flags: []cli.Flag{
+		cli.UintFlag{
+			Name: "batch_size",
+			Usage: "the number of records copied within a single " +
+				"database transaction, if not set a default " +
+				"of 1000 is used",
+		},
+	},
+	Action: actionDecorator(dbMigrate),
+}
+
+func dbMigrate(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.MigrateDatabase(
+		ctxc, &lnrpc.MigrateDatabaseRequest{
+			BatchSize: uint32(ctx.Uint("batch_size")),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(update)
+
+		if update.Done {
+			return nil
+		}
+	}
+}
+
+var dbInfoCommand = cli.Command{
+	Name:  "info",
+	Usage: "Show the database backend and connection pool statistics.",
+	Description: `
+	Show the database backend lnd is running with. If the native SQL store
+	is used, the statistics of its connection pool are shown as well, such
+	as the number of connections in use and the number of times and total
+	duration queries had to wait for a free connection.`,
+	Action: actionDecorator(dbInfo),
+}
+
+func dbInfo(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.GetDatabaseInfo(
+		ctxc, &lnrpc.GetDatabaseInfoRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var dbBackupCommand = cli.Command{
+	Name:      "backup",
+	Usage:     "Write a snapshot of the SQL databases to a directory.",
+	ArgsUsage: "dest_dir",
+	Description: `
+	Write a consistent snapshot of all SQL databases to a directory on the
+	host lnd runs on, without stopping lnd. SQLite databases are copied
+	with SQLite's online backup API, Postgres databases are dumped with
+	pg_dump, which needs to be installed on the host.
+
+	The directory must be given as an absolute path and must exist. Files
+	that already exist in the directory are never overwritten.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "dest_dir",
+			Usage: "the absolute path of the directory the " +
+				"snapshot is written to",
+		},
+	},
+	Action: actionDecorator(dbBackup),
+}
+
+func dbBackup(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var destDir string
+	switch {
+	case ctx.IsSet("dest_dir"):
+		destDir = ctx.String("dest_dir")
+
+	case ctx.Args().Present():
+		destDir = ctx.Args().First()
+
+	default:
+		return fmt.Errorf("dest_dir argument missing")
+	}
+
+	resp, err := client.BackupDatabase(ctxc, &lnrpc.BackupDatabaseRequest{
+		DestDir: destDir,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
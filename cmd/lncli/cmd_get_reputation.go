@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/urfave/cli"
+)
+
+var getReputationCommand = cli.Command{
+	Name:     "getreputation",
+	Category: "Channels",
+	Usage:    "Show the local reputation of peers and channel revenue.",
+	Description: `
+	Show the local reputation that the node tracks for its peers, along
+	with the revenue and general slot usage of its channels. Endorsed HTLCs
+	of peers whose reputation exceeds the revenue of the outgoing channel
+	are forwarded endorsed, all other HTLCs are forwarded unendorsed and
+	may only use the general slots of the outgoing channel.`,
+	Action: actionDecorator(getReputation),
+}
+
+func getReputation(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+	resp, err := client.GetReputation(
+		ctxc, &routerrpc.GetReputationRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/urfave/cli"
+	"google.golang.org/grpc"
 )
 
 const argsStr = "[source node] [dest node] [unix ts seconds] [amount in msat]"
@@ -18,15 +22,25 @@ var importMissionControlCommand = cli.Command{
 	Category:  "Payments",
 	Usage:     "Import a result to the internal mission control state.",
 	ArgsUsage: fmt.Sprintf("importmc %v", argsStr),
-	Action:    actionDecorator(importMissionControl),
+	Description: `
+	Import a single result to the internal mission control state, or the
+	pair history exported by 'lncli exportmc' if the --file flag is set.
+	`,
+	Action: actionDecorator(importMissionControl),
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name:  "failure",
 			Usage: "whether the routing history entry was a failure",
 		},
 		cli.BoolFlag{
-			Name:  "force",
-			Usage: "whether to force the history entry import",
+			Name: "force",
+			Usage: "whether to force the history entry import, " +
+				"replacing the existing history of the pair",
+		},
+		cli.StringFlag{
+			Name: "file",
+			Usage: "the path to a JSON file with the pair " +
+				"history exported by 'lncli exportmc'",
 		},
 	},
 }
@@ -35,6 +49,10 @@ func importMissionControl(ctx *cli.Context) error {
 	conn := getClientConn(ctx, false)
 	defer conn.Close()
 
+	if ctx.IsSet("file") {
+		return importMissionControlFile(ctx, conn)
+	}
+
 	if ctx.NArg() != 4 {
 		return fmt.Errorf("please provide args: %v", argsStr)
 	}
@@ -98,3 +116,35 @@ func importMissionControl(ctx *cli.Context) error {
 	_, err = client.XImportMissionControl(rpcCtx, req)
 	return err
 }
+
+// importMissionControlFile imports the pair history of a JSON file that was
+// created by the exportmc command.
+func importMissionControlFile(ctx *cli.Context, conn *grpc.ClientConn) error {
+	if ctx.NArg() != 0 {
+		return errors.New("no args allowed when importing from file")
+	}
+
+	jsonFile := lncfg.CleanAndExpandPath(ctx.String("file"))
+	jsonBytes, err := os.ReadFile(jsonFile)
+	if err != nil {
+		return fmt.Errorf("error reading JSON from file %v: %w",
+			jsonFile, err)
+	}
+
+	history := &routerrpc.XExportMissionControlResponse{}
+	err = lnrpc.ProtoJSONUnmarshalOpts.Unmarshal(jsonBytes, history)
+	if err != nil {
+		return fmt.Errorf("error parsing JSON: %w", err)
+	}
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.XImportMissionControlRequest{
+		Pairs: history.Pairs,
+		Force: ctx.IsSet("force"),
+	}
+
+	_, err = client.XImportMissionControl(getContext(), req)
+
+	return err
+}
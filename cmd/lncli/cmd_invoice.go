@@ -9,6 +9,47 @@ import (
 	"github.com/urfave/cli"
 )
 
+var hintStrategyFlag = cli.StringFlag{
+	Name: "hint_strategy",
+	Usage: "(optional) the strategy to use for selecting the private " +
+		"channels included as routing hints if --private is set. " +
+		"Possible values are 'default', 'highest-inbound', " +
+		"'most-reliable' or 'random-k'",
+	Value: "default",
+}
+
+var numHintsFlag = cli.Uint64Flag{
+	Name: "num_hints",
+	Usage: "(optional) the maximum number of routing hints for " +
+		"private channels to include if --private is set",
+}
+
+// parseHintStrategy parses the hint strategy flag into its RPC
+// representation.
+func parseHintStrategy(ctx *cli.Context) (lnrpc.HintStrategy, error) {
+	strategy := ctx.String(hintStrategyFlag.Name)
+	if !ctx.IsSet(hintStrategyFlag.Name) {
+		return lnrpc.HintStrategy_HINTS_DEFAULT, nil
+	}
+
+	switch strategy {
+	case "default":
+		return lnrpc.HintStrategy_HINTS_DEFAULT, nil
+
+	case "highest-inbound":
+		return lnrpc.HintStrategy_HINTS_HIGHEST_INBOUND, nil
+
+	case "most-reliable":
+		return lnrpc.HintStrategy_HINTS_MOST_RELIABLE, nil
+
+	case "random-k":
+		return lnrpc.HintStrategy_HINTS_RANDOM_K, nil
+
+	default:
+		return 0, fmt.Errorf("unknown hint strategy %v", strategy)
+	}
+}
+
 var addInvoiceCommand = cli.Command{
 	Name:     "addinvoice",
 	Category: "Invoices",
@@ -77,6 +118,8 @@ var addInvoiceCommand = cli.Command{
 				"these channels can be included, which " +
 				"might not be desirable.",
 		},
+		hintStrategyFlag,
+		numHintsFlag,
 		cli.BoolFlag{
 			Name: "amp",
 			Usage: "creates an AMP invoice. If true, preimage " +
@@ -127,6 +170,11 @@ func addInvoice(ctx *cli.Context) error {
 		return fmt.Errorf("unable to parse description_hash: %w", err)
 	}
 
+	hintStrategy, err := parseHintStrategy(ctx)
+	if err != nil {
+		return err
+	}
+
 	invoice := &lnrpc.Invoice{
 		Memo:            ctx.String("memo"),
 		RPreimage:       preimage,
@@ -138,6 +186,8 @@ func addInvoice(ctx *cli.Context) error {
 		CltvExpiry:      ctx.Uint64("cltv_expiry_delta"),
 		Private:         ctx.Bool("private"),
 		IsAmp:           ctx.Bool("amp"),
+		HintStrategy:    hintStrategy,
+		NumHints:        uint32(ctx.Uint64(numHintsFlag.Name)),
 	}
 
 	resp, err := client.AddInvoice(ctxc, invoice)
@@ -284,6 +334,83 @@ func listInvoices(ctx *cli.Context) error {
 	return nil
 }
 
+var updateKeysendPolicyCommand = cli.Command{
+	Name:     "updatekeysendpolicy",
+	Category: "Invoices",
+	Usage: "Update the policy spontaneous keysend and AMP payments are " +
+		"checked against.",
+	Description: `
+	Replace the policy spontaneous keysend and AMP payments, which don't pay
+	to an invoice created by this node, are checked against. Calling the
+	command without any flags rejects all spontaneous payments.
+
+	Keysend and AMP payments don't identify their sender, so the allowed
+	senders are checked against the channel peer a payment arrives from.
+
+	The policy is kept in memory only and reset to the --accept-keysend and
+	--accept-amp startup options when lnd is restarted.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "accept_keysend",
+			Usage: "accept spontaneous keysend payments",
+		},
+		cli.BoolFlag{
+			Name:  "accept_amp",
+			Usage: "accept spontaneous AMP payments",
+		},
+		cli.Uint64Flag{
+			Name: "min_amt_msat",
+			Usage: "the minimum amount in millisatoshis a " +
+				"spontaneous payment must pay",
+		},
+		cli.Uint64Flag{
+			Name: "max_amt_msat",
+			Usage: "the maximum amount in millisatoshis a " +
+				"spontaneous payment may pay, 0 disables the " +
+				"limit",
+		},
+		cli.StringSliceFlag{
+			Name: "allowed_sender",
+			Usage: "the hex encoded public key of a peer " +
+				"spontaneous payments are accepted from, can " +
+				"be specified multiple times",
+		},
+	},
+	Action: actionDecorator(updateKeysendPolicy),
+}
+
+func updateKeysendPolicy(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.UpdateKeysendPolicyRequest{
+		AcceptKeysend: ctx.Bool("accept_keysend"),
+		AcceptAmp:     ctx.Bool("accept_amp"),
+		MinAmtMsat:    ctx.Uint64("min_amt_msat"),
+		MaxAmtMsat:    ctx.Uint64("max_amt_msat"),
+	}
+
+	for _, sender := range ctx.StringSlice("allowed_sender") {
+		pubKey, err := hex.DecodeString(sender)
+		if err != nil {
+			return fmt.Errorf("unable to decode allowed sender "+
+				"%v: %w", sender, err)
+		}
+
+		req.AllowedSenders = append(req.AllowedSenders, pubKey)
+	}
+
+	resp, err := client.UpdateKeysendPolicy(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var decodePayReqCommand = cli.Command{
 	Name:        "decodepayreq",
 	Category:    "Invoices",
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/urfave/cli"
+)
+
+var listCircuitsCommand = cli.Command{
+	Name:     "listcircuits",
+	Category: "Channels",
+	Usage:    "List the in-flight HTLC circuits of the switch.",
+	Description: `
+	List the in-flight HTLC circuits of the switch, ordered from the oldest
+	to the newest. Each circuit links an incoming HTLC to the outgoing HTLC
+	it was forwarded as, along with their amounts and expiry heights. HTLCs
+	that stay in flight for a long time may cause channels to be force
+	closed once their expiry height approaches.`,
+	Action: actionDecorator(listCircuits),
+}
+
+func listCircuits(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+	resp, err := client.ListCircuits(
+		ctxc, &routerrpc.ListCircuitsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
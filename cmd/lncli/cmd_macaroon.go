@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"net"
 	"os"
 	"strconv"
@@ -38,6 +39,23 @@ var (
 		Usage: "the condition of the custom caveat to add, can be " +
 			"empty if custom caveat doesn't need a value",
 	}
+	macMaxPaymentFlag = cli.Uint64Flag{
+		Name: "max_payment_msat",
+		Usage: "the maximum amount in millisatoshis a single " +
+			"payment made with the macaroon can spend, including " +
+			"fees",
+	}
+	macDailySpendFlag = cli.Uint64Flag{
+		Name: "daily_spend_limit_msat",
+		Usage: "the maximum amount in millisatoshis all payments " +
+			"made with the macaroon can spend within 24 hours, " +
+			"including fees",
+	}
+	macCallsPerMinuteFlag = cli.Uint64Flag{
+		Name: "calls_per_minute",
+		Usage: "the maximum number of RPC calls that can be made " +
+			"with the macaroon within a minute",
+	}
 )
 
 var bakeMacaroonCommand = cli.Command{
@@ -47,11 +65,22 @@ var bakeMacaroonCommand = cli.Command{
 		"and restrictions.",
 	ArgsUsage: "[--save_to=] [--timeout=] [--ip_address=] " +
 		"[--custom_caveat_name= [--custom_caveat_condition=]] " +
-		"[--root_key_id=] [--allow_external_permissions] " +
-		"permissions...",
+		"[--max_payment_msat=] [--daily_spend_limit_msat=] " +
+		"[--calls_per_minute=] [--root_key_id=] " +
+		"[--allow_external_permissions] permissions...",
 	Description: `
 	Bake a new macaroon that grants the provided permissions and
-	optionally adds restrictions (timeout, IP address) to it.
+	optionally adds restrictions (timeout, IP address, spend and rate
+	limits) to it.
+
+	Macaroons with spend limits can only be used for the payment RPCs
+	that enforce them (SendPaymentSync, SendToRouteSync, SendPaymentV2
+	and SendToRouteV2). They are rejected for any other RPC that can move
+	funds, for example:
+
+	lncli bakemacaroon --max_payment_msat=100000 \
+		--daily_spend_limit_msat=5000000 --calls_per_minute=10 \
+		offchain:read offchain:write invoices:read
 
 	The new macaroon can either be shown on command line in hex serialized
 	format or it can be saved directly to a file using the --save_to
@@ -85,6 +114,9 @@ var bakeMacaroonCommand = cli.Command{
 		macIPAddressFlag,
 		macCustomCaveatNameFlag,
 		macCustomCaveatConditionFlag,
+		macMaxPaymentFlag,
+		macDailySpendFlag,
+		macCallsPerMinuteFlag,
 		cli.Uint64Flag{
 			Name: "root_key_id",
 			Usage: "the numerical root key ID used to create the " +
@@ -284,6 +316,76 @@ func deleteMacaroonID(ctx *cli.Context) error {
 	return nil
 }
 
+var rotateMacaroonRootKeyCommand = cli.Command{
+	Name:     "rotatemacaroonrootkey",
+	Category: "Macaroons",
+	Usage:    "Rotate a macaroon root key.",
+	ArgsUsage: "[--root_key_id=] [--skip_rotation] " +
+		"[--invalidate_before_version=]",
+	Description: `
+	Create a new version of a macaroon root key. All macaroons baked with
+	the root key ID from now on use the new version, while macaroons baked
+	with previous versions stay valid until they are invalidated.
+
+	If the default root key ID 0 is rotated, new admin, read-only and
+	invoice macaroons are issued and returned.
+
+	Previous versions can be invalidated in the same call or later with
+	--invalidate_before_version. For example, to rotate the default root
+	key and invalidate all macaroons baked before the rotation after all
+	clients received the new macaroons:
+
+	lncli rotatemacaroonrootkey
+	lncli rotatemacaroonrootkey --skip_rotation \
+		--invalidate_before_version=1
+
+	WARNING
+	Invalidating a version invalidates all macaroons baked with it.
+	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "root_key_id",
+			Usage: "the numerical root key ID to rotate",
+		},
+		cli.BoolFlag{
+			Name: "skip_rotation",
+			Usage: "don't create a new version, only invalidate " +
+				"previous versions",
+		},
+		cli.UintFlag{
+			Name: "invalidate_before_version",
+			Usage: "invalidate all versions of the root key that " +
+				"are older than this version",
+		},
+	},
+	Action: actionDecorator(rotateMacaroonRootKey),
+}
+
+func rotateMacaroonRootKey(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if ctx.NArg() != 0 {
+		return cli.ShowCommandHelp(ctx, "rotatemacaroonrootkey")
+	}
+
+	req := &lnrpc.RotateMacaroonRootKeyRequest{
+		RootKeyId:    ctx.Uint64("root_key_id"),
+		SkipRotation: ctx.Bool("skip_rotation"),
+		InvalidateBeforeVersion: uint32(
+			ctx.Uint("invalidate_before_version"),
+		),
+	}
+	resp, err := client.RotateMacaroonRootKey(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var listPermissionsCommand = cli.Command{
 	Name:     "listpermissions",
 	Category: "Macaroons",
@@ -416,8 +518,9 @@ var constrainMacaroonCommand = cli.Command{
 	Category: "Macaroons",
 	Usage:    "Adds one or more restriction(s) to an existing macaroon",
 	ArgsUsage: "[--timeout=] [--ip_address=] [--custom_caveat_name= " +
-		"[--custom_caveat_condition=]] input-macaroon-file " +
-		"constrained-macaroon-file",
+		"[--custom_caveat_condition=]] [--max_payment_msat=] " +
+		"[--daily_spend_limit_msat=] [--calls_per_minute=] " +
+		"input-macaroon-file constrained-macaroon-file",
 	Description: `
 	Add one or more first-party caveat(s) (a.k.a. constraints/restrictions)
 	to an existing macaroon.
@@ -427,6 +530,9 @@ var constrainMacaroonCommand = cli.Command{
 		macIPAddressFlag,
 		macCustomCaveatNameFlag,
 		macCustomCaveatConditionFlag,
+		macMaxPaymentFlag,
+		macDailySpendFlag,
+		macCallsPerMinuteFlag,
 	},
 	Action: actionDecorator(constrainMacaroon),
 }
@@ -548,6 +654,36 @@ func applyMacaroonConstraints(ctx *cli.Context,
 		)
 	}
 
+	if ctx.IsSet(macMaxPaymentFlag.Name) {
+		macConstraints = append(
+			macConstraints, macaroons.MaxPaymentAmountConstraint(
+				ctx.Uint64(macMaxPaymentFlag.Name),
+			),
+		)
+	}
+
+	if ctx.IsSet(macDailySpendFlag.Name) {
+		macConstraints = append(
+			macConstraints, macaroons.DailySpendLimitConstraint(
+				ctx.Uint64(macDailySpendFlag.Name),
+			),
+		)
+	}
+
+	if ctx.IsSet(macCallsPerMinuteFlag.Name) {
+		callsPerMinute := ctx.Uint64(macCallsPerMinuteFlag.Name)
+		if callsPerMinute > math.MaxUint32 {
+			return nil, fmt.Errorf("calls_per_minute must not be "+
+				"greater than %d", uint32(math.MaxUint32))
+		}
+
+		macConstraints = append(
+			macConstraints, macaroons.RateLimitConstraint(
+				uint32(callsPerMinute),
+			),
+		)
+	}
+
 	constrainedMac, err := macaroons.AddConstraints(mac, macConstraints...)
 	if err != nil {
 		return nil, fmt.Errorf("error adding constraints: %w", err)
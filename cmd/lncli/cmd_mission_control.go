@@ -272,7 +272,10 @@ var queryMissionControlCommand = cli.Command{
 	Name:     "querymc",
 	Category: "Mission Control",
 	Usage:    "Query the internal mission control state.",
-	Action:   actionDecorator(queryMissionControl),
+	Flags: []cli.Flag{
+		namespaceFlag,
+	},
+	Action: actionDecorator(queryMissionControl),
 }
 
 func queryMissionControl(ctx *cli.Context) error {
@@ -282,7 +285,9 @@ func queryMissionControl(ctx *cli.Context) error {
 
 	client := routerrpc.NewRouterClient(conn)
 
-	req := &routerrpc.QueryMissionControlRequest{}
+	req := &routerrpc.QueryMissionControlRequest{
+		MissionControlNamespace: ctx.String(namespaceFlag.Name),
+	}
 	snapshot, err := client.QueryMissionControl(ctxc, req)
 	if err != nil {
 		return err
@@ -293,6 +298,37 @@ func queryMissionControl(ctx *cli.Context) error {
 	return nil
 }
 
+var exportMissionControlCommand = cli.Command{
+	Name:     "exportmc",
+	Category: "Mission Control",
+	Usage:    "Export the internal mission control pair history.",
+	Description: `
+	Export the pair history of the internal mission control state as JSON.
+	The output can be imported into another node with
+	'lncli importmc --file', which allows sharing the knowledge about the
+	liquidity in the network between nodes.
+	`,
+	Action: actionDecorator(exportMissionControl),
+}
+
+func exportMissionControl(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.XExportMissionControlRequest{}
+	history, err := client.XExportMissionControl(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(history)
+
+	return nil
+}
+
 var queryProbCommand = cli.Command{
 	Name:      "queryprob",
 	Category:  "Mission Control",
@@ -354,7 +390,10 @@ var resetMissionControlCommand = cli.Command{
 	Name:     "resetmc",
 	Category: "Mission Control",
 	Usage:    "Reset internal mission control state.",
-	Action:   actionDecorator(resetMissionControl),
+	Flags: []cli.Flag{
+		namespaceFlag,
+	},
+	Action: actionDecorator(resetMissionControl),
 }
 
 func resetMissionControl(ctx *cli.Context) error {
@@ -364,7 +403,103 @@ func resetMissionControl(ctx *cli.Context) error {
 
 	client := routerrpc.NewRouterClient(conn)
 
-	req := &routerrpc.ResetMissionControlRequest{}
+	req := &routerrpc.ResetMissionControlRequest{
+		MissionControlNamespace: ctx.String(namespaceFlag.Name),
+	}
 	_, err := client.ResetMissionControl(ctxc, req)
 	return err
 }
+
+var namespaceFlag = cli.StringFlag{
+	Name: "namespace",
+	Usage: "(optional) the mission control namespace, the default " +
+		"namespace is used if not set",
+}
+
+var addMissionControlNamespaceCommand = cli.Command{
+	Name:      "addmcnamespace",
+	Category:  "Mission Control",
+	Usage:     "Add a mission control namespace.",
+	ArgsUsage: "namespace",
+	Description: `
+	Add a mission control namespace with its own reliability history.
+	Payments select the namespace with the --mc_namespace flag, which keeps
+	for example probes, rebalances and customer payments apart.
+	`,
+	Action: actionDecorator(addMissionControlNamespace),
+}
+
+func addMissionControlNamespace(ctx *cli.Context) error {
+	ctxc := getContext()
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "addmcnamespace")
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.AddMissionControlNamespaceRequest{
+		Namespace: ctx.Args().First(),
+	}
+	_, err := client.AddMissionControlNamespace(ctxc, req)
+
+	return err
+}
+
+var deleteMissionControlNamespaceCommand = cli.Command{
+	Name:      "deletemcnamespace",
+	Category:  "Mission Control",
+	Usage:     "Delete a mission control namespace.",
+	ArgsUsage: "namespace",
+	Description: `
+	Delete a mission control namespace together with its reliability
+	history. The default namespace can't be deleted.
+	`,
+	Action: actionDecorator(deleteMissionControlNamespace),
+}
+
+func deleteMissionControlNamespace(ctx *cli.Context) error {
+	ctxc := getContext()
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "deletemcnamespace")
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.DeleteMissionControlNamespaceRequest{
+		Namespace: ctx.Args().First(),
+	}
+	_, err := client.DeleteMissionControlNamespace(ctxc, req)
+
+	return err
+}
+
+var listMissionControlNamespacesCommand = cli.Command{
+	Name:     "listmcnamespaces",
+	Category: "Mission Control",
+	Usage:    "List the mission control namespaces.",
+	Action:   actionDecorator(listMissionControlNamespaces),
+}
+
+func listMissionControlNamespaces(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.ListMissionControlNamespacesRequest{}
+	resp, err := client.ListMissionControlNamespaces(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
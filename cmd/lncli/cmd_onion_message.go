@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var sendOnionMessageCommand = cli.Command{
+	Name:     "sendonionmessage",
+	Category: "Peers",
+	Usage:    "Send an onion message to a connected peer.",
+	Description: `
+	Send a pre-built onion message to a connected peer. The onion must be
+	constructed along a blinded path that starts at the given peer.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "peer",
+			Usage: "the hex encoded pubkey of the peer",
+		},
+		cli.StringFlag{
+			Name: "path_key",
+			Usage: "the hex encoded path key (blinding point) of " +
+				"the first hop",
+		},
+		cli.StringFlag{
+			Name:  "onion",
+			Usage: "the hex encoded onion message packet",
+		},
+	},
+	Action: actionDecorator(sendOnionMessage),
+}
+
+func sendOnionMessage(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	peer, err := hex.DecodeString(ctx.String("peer"))
+	if err != nil {
+		return fmt.Errorf("unable to decode peer: %w", err)
+	}
+
+	pathKey, err := hex.DecodeString(ctx.String("path_key"))
+	if err != nil {
+		return fmt.Errorf("unable to decode path key: %w", err)
+	}
+
+	onion, err := hex.DecodeString(ctx.String("onion"))
+	if err != nil {
+		return fmt.Errorf("unable to decode onion: %w", err)
+	}
+
+	_, err = client.SendOnionMessage(
+		ctxc, &lnrpc.SendOnionMessageRequest{
+			Peer:    peer,
+			PathKey: pathKey,
+			Onion:   onion,
+		},
+	)
+
+	return err
+}
+
+var subscribeOnionMessagesCommand = cli.Command{
+	Name:     "subscribeonionmessages",
+	Category: "Peers",
+	Usage:    "Subscribe to onion messages addressed to this node.",
+	Action:   actionDecorator(subscribeOnionMessages),
+}
+
+func subscribeOnionMessages(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.SubscribeOnionMessages(
+		ctxc, &lnrpc.SubscribeOnionMessagesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(msg)
+	}
+}
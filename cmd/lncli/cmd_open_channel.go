@@ -98,7 +98,12 @@ var openChannelCommand = cli.Command{
 	One can also specify a short string memo to record some useful
 	information about the channel using the --memo argument. This is stored
 	locally only, and is purely for reference. It has no bearing on the
-	channel's operation. Max allowed length is 500 characters.`,
+	channel's operation. Max allowed length is 500 characters.
+
+	With the --dry_run flag, no channel is opened. Instead the coin selection
+	of the funding transaction is run without locking any UTXOs and the
+	selected inputs, the change amount, the fee and the resulting channel
+	capacity are shown.`,
 	ArgsUsage: "node-key local-amt push-amt",
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -133,6 +138,13 @@ var openChannelCommand = cli.Command{
 				"selecting a fraction of the sum of the " +
 				"outpoints in local_amt",
 		},
+		cli.StringFlag{
+			Name: "account",
+			Usage: "(optional) the name of the wallet account " +
+				"whose utxos should be used to fund the " +
+				"channel; if not set, the default account is " +
+				"used",
+		},
 		cli.Uint64Flag{
 			Name: "base_fee_msat",
 			Usage: "the base fee in milli-satoshis that will " +
@@ -147,6 +159,22 @@ var openChannelCommand = cli.Command{
 				"possible rate is 0 with a granularity of " +
 				"0.000001 (millionths)",
 		},
+		cli.Uint64Flag{
+			Name: "time_lock_delta",
+			Usage: "the CLTV delta of the initial forwarding " +
+				"policy, if not set the default time lock " +
+				"delta is used",
+		},
+		cli.Uint64Flag{
+			Name: "fwd_min_htlc_msat",
+			Usage: "the smallest HTLC in milli-satoshis that " +
+				"will be forwarded over the channel",
+		},
+		cli.Uint64Flag{
+			Name: "fwd_max_htlc_msat",
+			Usage: "the largest HTLC in milli-satoshis that " +
+				"will be forwarded over the channel",
+		},
 		cli.IntFlag{
 			Name: "push_amt",
 			Usage: "the number of satoshis to give the remote " +
@@ -228,6 +256,12 @@ var openChannelCommand = cli.Command{
 				"funds to be added and signed from a " +
 				"hardware or other offline device.",
 		},
+		cli.BoolFlag{
+			Name: "dry_run",
+			Usage: "(optional) only estimate the fee of the " +
+				"funding transaction and show the selected " +
+				"inputs without opening the channel",
+		},
 		cli.StringFlag{
 			Name: "base_psbt",
 			Usage: "when using the interactive PSBT mode to open " +
@@ -326,6 +360,10 @@ func openChannel(ctx *cli.Context) error {
 		RemoteChanReserveSat:       ctx.Uint64("remote_reserve_sats"),
 		FundMax:                    ctx.Bool("fundmax"),
 		Memo:                       ctx.String("memo"),
+		Account:                    ctx.String("account"),
+		TimeLockDelta:              uint32(ctx.Uint64("time_lock_delta")),
+		FwdMinHtlcMsat:             ctx.Uint64("fwd_min_htlc_msat"),
+		FwdMaxHtlcMsat:             ctx.Uint64("fwd_max_htlc_msat"),
 	}
 
 	switch {
@@ -397,6 +435,12 @@ func openChannel(ctx *cli.Context) error {
 			"to commit the maximum amount out of the wallet")
 	}
 
+	// The account flag is NOT allowed to be combined with the psbt flag.
+	if ctx.IsSet("account") && ctx.Bool("psbt") {
+		return fmt.Errorf("psbt cannot be set if restricting the " +
+			"funding to a wallet account")
+	}
+
 	// The fundmax flag is NOT allowed to be combined with the psbt flag.
 	if ctx.Bool("fundmax") && ctx.Bool("psbt") {
 		return fmt.Errorf("psbt cannot be set if attempting " +
@@ -450,6 +494,23 @@ func openChannel(ctx *cli.Context) error {
 		return fmt.Errorf("unsupported channel type %v", channelType)
 	}
 
+	// A dry run only estimates the funding transaction, so it can't be
+	// combined with the interactive PSBT funding.
+	if ctx.Bool("dry_run") {
+		if ctx.Bool("psbt") {
+			return fmt.Errorf("psbt cannot be set for a dry run")
+		}
+
+		resp, err := client.EstimateChannelOpenFee(ctxc, req)
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(resp)
+
+		return nil
+	}
+
 	// PSBT funding is a more involved, interactive process that is too
 	// large to also fit into this already long function.
 	if ctx.Bool("psbt") {
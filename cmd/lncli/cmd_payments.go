@@ -67,6 +67,30 @@ var (
 		Value: routerrpc.DefaultMaxParts,
 	}
 
+	splitStrategyFlag = cli.StringFlag{
+		Name: "split_strategy",
+		Usage: "(optional) the strategy to split the payment into " +
+			"multiple parts if no route is found for the full " +
+			"amount. Possible values are 'halving', 'equal' or " +
+			"'capacity'",
+		Value: "halving",
+	}
+
+	mcNamespaceFlag = cli.StringFlag{
+		Name: "mc_namespace",
+		Usage: "(optional) the mission control namespace whose " +
+			"reliability history is used for the payment, the " +
+			"default namespace is used if not set",
+	}
+
+	idempotencyKeyFlag = cli.StringFlag{
+		Name: "idempotency_key",
+		Usage: "(optional) a unique key that is stored with the " +
+			"payment; if a payment with the same key already " +
+			"exists, its status is returned instead of sending " +
+			"the payment again",
+	}
+
 	jsonFlag = cli.BoolFlag{
 		Name: "json",
 		Usage: "if set, payment updates are printed as json " +
@@ -99,6 +123,24 @@ var (
 		Usage: "(optional) expresses time preference (range -1 to 1)",
 	}
 
+	feeWeightFlag = cli.Float64Flag{
+		Name: "fee_weight",
+		Usage: "(optional) the weight of the routing fees in path " +
+			"finding, defaults to 1 if any weight is set",
+	}
+
+	timeLockWeightFlag = cli.Float64Flag{
+		Name: "time_lock_weight",
+		Usage: "(optional) the weight of the time lock penalty in " +
+			"path finding, defaults to 1 if any weight is set",
+	}
+
+	probabilityWeightFlag = cli.Float64Flag{
+		Name: "probability_weight",
+		Usage: "(optional) the weight of the success probability in " +
+			"path finding, defaults to 1 if any weight is set",
+	}
+
 	introductionNodeFlag = cli.StringFlag{
 		Name: "introduction_node",
 		Usage: "(blinded paths) the hex encoded, cleartext node ID " +
@@ -186,10 +228,62 @@ func paymentFlags() []cli.Flag {
 		},
 		dataFlag, inflightUpdatesFlag, maxPartsFlag, jsonFlag,
 		maxShardSizeSatFlag, maxShardSizeMsatFlag, ampFlag,
-		timePrefFlag,
+		timePrefFlag, feeWeightFlag, timeLockWeightFlag,
+		probabilityWeightFlag, splitStrategyFlag, mcNamespaceFlag,
+		idempotencyKeyFlag,
+	}
+}
+
+// parseSplitStrategy parses the split strategy flag into its RPC
+// representation.
+func parseSplitStrategy(ctx *cli.Context) (lnrpc.PaymentSplitStrategy,
+	error) {
+
+	strategy := ctx.String(splitStrategyFlag.Name)
+	switch strategy {
+	case "halving":
+		return lnrpc.PaymentSplitStrategy_SPLIT_HALVING, nil
+
+	case "equal":
+		return lnrpc.PaymentSplitStrategy_SPLIT_EQUAL, nil
+
+	case "capacity":
+		return lnrpc.PaymentSplitStrategy_SPLIT_CAPACITY, nil
+
+	default:
+		return 0, fmt.Errorf("unknown split strategy %v", strategy)
 	}
 }
 
+// parseCostWeights returns the path finding cost weights that were set on the
+// command line. Weights that aren't set default to 1. If no weight is set at
+// all, nil is returned so that lnd uses its defaults.
+func parseCostWeights(ctx *cli.Context) *lnrpc.CostWeights {
+	if !ctx.IsSet(feeWeightFlag.Name) &&
+		!ctx.IsSet(timeLockWeightFlag.Name) &&
+		!ctx.IsSet(probabilityWeightFlag.Name) {
+
+		return nil
+	}
+
+	weights := &lnrpc.CostWeights{
+		Fee:         1,
+		TimeLock:    1,
+		Probability: 1,
+	}
+	if ctx.IsSet(feeWeightFlag.Name) {
+		weights.Fee = ctx.Float64(feeWeightFlag.Name)
+	}
+	if ctx.IsSet(timeLockWeightFlag.Name) {
+		weights.TimeLock = ctx.Float64(timeLockWeightFlag.Name)
+	}
+	if ctx.IsSet(probabilityWeightFlag.Name) {
+		weights.Probability = ctx.Float64(probabilityWeightFlag.Name)
+	}
+
+	return weights
+}
+
 var sendPaymentCommand = cli.Command{
 	Name:     "sendpayment",
 	Category: "Payments",
@@ -496,6 +590,18 @@ func sendPaymentRequest(ctx *cli.Context,
 
 	req.MaxParts = uint32(ctx.Uint(maxPartsFlag.Name))
 
+	splitStrategy, err := parseSplitStrategy(ctx)
+	if err != nil {
+		return err
+	}
+	req.SplitStrategy = splitStrategy
+
+	req.MissionControlNamespace = ctx.String(mcNamespaceFlag.Name)
+
+	if ctx.IsSet(idempotencyKeyFlag.Name) {
+		req.IdempotencyKey = []byte(ctx.String(idempotencyKeyFlag.Name))
+	}
+
 	switch {
 	// If the max shard size is specified, then it should either be in sat
 	// or msat, but not both.
@@ -584,6 +690,9 @@ func sendPaymentRequest(ctx *cli.Context,
 	// Set time pref.
 	req.TimePref = ctx.Float64(timePrefFlag.Name)
 
+	// Set the path finding cost weights.
+	req.CostWeights = parseCostWeights(ctx)
+
 	// Always print in-flight updates for the table output.
 	printJSON := ctx.Bool(jsonFlag.Name)
 	req.NoInflightUpdates = !ctx.Bool(inflightUpdatesFlag.Name) && printJSON
@@ -1104,6 +1213,9 @@ var queryRoutesCommand = cli.Command{
 				"to be ignored",
 		},
 		timePrefFlag,
+		feeWeightFlag,
+		timeLockWeightFlag,
+		probabilityWeightFlag,
 		cltvLimitFlag,
 		introductionNodeFlag,
 		blindingPointFlag,
@@ -1201,6 +1313,7 @@ func queryRoutes(ctx *cli.Context) error {
 		CltvLimit:           uint32(ctx.Uint64(cltvLimitFlag.Name)),
 		OutgoingChanId:      ctx.Uint64("outgoing_chan_id"),
 		TimePref:            ctx.Float64(timePrefFlag.Name),
+		CostWeights:         parseCostWeights(ctx),
 		IgnoredPairs:        ignoredPairs,
 		BlindedPaymentPaths: blindedRoutes,
 	}
@@ -1441,6 +1554,12 @@ var forwardingHistoryCommand = cli.Command{
 	Finally, callers can skip a series of events using the --index_offset
 	parameter. Each response will contain the offset index of the last
 	entry. Using this callers can manually paginate within a time slice.
+
+	By default, only settled forwards are returned. Failed forwards can be
+	included using the --include_failures flag.
+
+	The --tag flag restricts the result to the forwards into or out of the
+	channels of a channel group, see the updatechantags command.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -1466,6 +1585,15 @@ var forwardingHistoryCommand = cli.Command{
 			Usage: "skip the peer alias lookup per forwarding " +
 				"event in order to improve performance",
 		},
+		cli.BoolFlag{
+			Name:  "include_failures",
+			Usage: "also return failed forwarding events",
+		},
+		cli.StringFlag{
+			Name: "tag",
+			Usage: "only return the events whose incoming or " +
+				"outgoing channel is tagged with this tag",
+		},
 	},
 	Action: actionDecorator(forwardingHistory),
 }
@@ -1545,6 +1673,8 @@ func forwardingHistory(ctx *cli.Context) error {
 		IndexOffset:     indexOffset,
 		NumMaxEvents:    maxEvents,
 		PeerAliasLookup: lookupPeerAlias,
+		IncludeFailures: ctx.Bool("include_failures"),
+		Tag:             ctx.String("tag"),
 	}
 	resp, err := client.ForwardingHistory(ctxc, req)
 	if err != nil {
@@ -1569,6 +1699,11 @@ var buildRouteCommand = cli.Command{
 
 	Moreover a payment_addr has to be provided if the invoice supplied it as
 	well otherwise the payment will be rejected by the receiving node.
+
+	A '*' in the list of hops is a placeholder that is replaced by a path
+	found by pathfinding between the hops before and after it, for example
+	--hops=*,<pubkey_1>,*,<pubkey_2>. Placeholders require the amount to
+	be set.
 	`,
 	Action: actionDecorator(buildRoute),
 	Flags: []cli.Flag{
@@ -1584,8 +1719,9 @@ var buildRouteCommand = cli.Command{
 				"final_cltv_delta is used",
 		},
 		cli.StringFlag{
-			Name:  "hops",
-			Usage: "comma separated hex pubkeys",
+			Name: "hops",
+			Usage: "comma separated hex pubkeys, a '*' is " +
+				"resolved by pathfinding",
 		},
 		cli.Uint64Flag{
 			Name: "outgoing_chan_id",
@@ -1612,15 +1748,26 @@ func buildRoute(ctx *cli.Context) error {
 		return errors.New("hops required")
 	}
 
-	// Build list of hop addresses for the rpc.
+	// Build list of hop addresses for the rpc. Placeholders are passed as
+	// hops without a pubkey.
 	hops := strings.Split(ctx.String("hops"), ",")
-	rpcHops := make([][]byte, 0, len(hops))
+	rpcHops := make([]*routerrpc.BuildRouteHop, 0, len(hops))
+	hasPlaceholder := false
 	for _, k := range hops {
+		if k == "*" {
+			rpcHops = append(rpcHops, &routerrpc.BuildRouteHop{})
+			hasPlaceholder = true
+
+			continue
+		}
+
 		pubkey, err := route.NewVertexFromStr(k)
 		if err != nil {
 			return fmt.Errorf("error parsing %v: %w", k, err)
 		}
-		rpcHops = append(rpcHops, pubkey[:])
+		rpcHops = append(rpcHops, &routerrpc.BuildRouteHop{
+			Pubkey: pubkey[:],
+		})
 	}
 
 	var amtMsat int64
@@ -1648,11 +1795,20 @@ func buildRoute(ctx *cli.Context) error {
 	req := &routerrpc.BuildRouteRequest{
 		AmtMsat:        amtMsat,
 		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
-		HopPubkeys:     rpcHops,
 		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
 		PaymentAddr:    payAddr,
 	}
 
+	// Only use the hops field if needed, so that the command keeps
+	// working with nodes that don't support it.
+	if hasPlaceholder {
+		req.Hops = rpcHops
+	} else {
+		for _, hop := range rpcHops {
+			req.HopPubkeys = append(req.HopPubkeys, hop.Pubkey)
+		}
+	}
+
 	route, err := client.BuildRoute(ctxc, req)
 	if err != nil {
 		return err
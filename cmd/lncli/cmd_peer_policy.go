@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var setPeerPolicyCommand = cli.Command{
+	Name:      "setpeerpolicy",
+	Category:  "Peers",
+	Usage:     "Set the default parameters of channels opened to a peer.",
+	ArgsUsage: "node_key",
+	Description: `
+	Set the default parameters that are used by every channel opening to
+	the peer that doesn't set the respective parameter explicitly. The
+	policy is persisted, calling the command without any parameter flags
+	removes the policy of the peer.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node_key",
+			Usage: "the hex encoded identity public key of the peer",
+		},
+		cli.Uint64Flag{
+			Name: "sat_per_vbyte",
+			Usage: "the fee rate in sat/vbyte of the funding " +
+				"transaction",
+		},
+		cli.StringFlag{
+			Name: "channel_type",
+			Usage: fmt.Sprintf("the channel type of the channels, "+
+				"one of '%s', '%s' or '%s'",
+				channelTypeTweakless, channelTypeAnchors,
+				channelTypeSimpleTaproot),
+		},
+		cli.Uint64Flag{
+			Name: "remote_csv_delay",
+			Usage: "the number of blocks the peer has to wait for " +
+				"to claim its funds after a force close",
+		},
+		cli.Uint64Flag{
+			Name: "remote_max_htlcs",
+			Usage: "the maximum number of concurrent HTLCs the peer " +
+				"may offer to us",
+		},
+		cli.BoolFlag{
+			Name:  "private",
+			Usage: "make the channels to the peer private",
+		},
+	},
+	Action: actionDecorator(setPeerPolicy),
+}
+
+func setPeerPolicy(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var nodeKey string
+	switch {
+	case ctx.IsSet("node_key"):
+		nodeKey = ctx.String("node_key")
+
+	case ctx.Args().Present():
+		nodeKey = ctx.Args().First()
+
+	default:
+		return fmt.Errorf("node_key argument missing")
+	}
+
+	pubKey, err := hex.DecodeString(nodeKey)
+	if err != nil {
+		return fmt.Errorf("unable to decode node key: %w", err)
+	}
+
+	policy := &lnrpc.PeerPolicy{
+		SatPerVbyte:    ctx.Uint64("sat_per_vbyte"),
+		RemoteCsvDelay: uint32(ctx.Uint64("remote_csv_delay")),
+		RemoteMaxHtlcs: uint32(ctx.Uint64("remote_max_htlcs")),
+		Private:        ctx.Bool("private"),
+	}
+
+	if ctx.IsSet("channel_type") {
+		channelType := ctx.String("channel_type")
+		switch channelType {
+		case channelTypeTweakless:
+			policy.CommitmentType =
+				lnrpc.CommitmentType_STATIC_REMOTE_KEY
+
+		case channelTypeAnchors:
+			policy.CommitmentType = lnrpc.CommitmentType_ANCHORS
+
+		case channelTypeSimpleTaproot:
+			policy.CommitmentType =
+				lnrpc.CommitmentType_SIMPLE_TAPROOT
+
+		default:
+			return fmt.Errorf("unsupported channel type %v",
+				channelType)
+		}
+	}
+
+	resp, err := client.SetPeerPolicy(ctxc, &lnrpc.SetPeerPolicyRequest{
+		PubKey: pubKey,
+		Policy: policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listPeerPoliciesCommand = cli.Command{
+	Name:     "listpeerpolicies",
+	Category: "Peers",
+	Usage: "List the default channel parameters of all peers that have " +
+		"a policy set.",
+	Action: actionDecorator(listPeerPolicies),
+}
+
+func listPeerPolicies(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListPeerPolicies(
+		ctxc, &lnrpc.ListPeerPoliciesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var updatePeerConnPolicyCommand = cli.Command{
+	Name:      "updatepeerconnpolicy",
+	Category:  "Peers",
+	Usage:     "Set how the connection to a peer is maintained.",
+	ArgsUsage: "node_key",
+	Description: `
+	Set the connection policy of a peer. Without a policy, only peers that
+	we have channels with are reconnected to. The policy is persisted,
+	calling the command without any parameter flags removes the policy of
+	the peer.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node_key",
+			Usage: "the hex encoded identity public key of the peer",
+		},
+		cli.BoolFlag{
+			Name: "always_reconnect",
+			Usage: "keep reconnecting to the peer even without " +
+				"channels",
+		},
+		cli.DurationFlag{
+			Name: "max_backoff",
+			Usage: "the ceiling of the backoff between two " +
+				"reconnection attempts, e.g. 5m",
+		},
+		cli.StringFlag{
+			Name: "preferred_address",
+			Usage: "the host:port address that is tried first " +
+				"when connecting to the peer",
+		},
+		cli.BoolFlag{
+			Name:  "tor_only",
+			Usage: "only connect to the peer over Tor",
+		},
+	},
+	Action: actionDecorator(updatePeerConnPolicy),
+}
+
+func updatePeerConnPolicy(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var nodeKey string
+	switch {
+	case ctx.IsSet("node_key"):
+		nodeKey = ctx.String("node_key")
+
+	case ctx.Args().Present():
+		nodeKey = ctx.Args().First()
+
+	default:
+		return fmt.Errorf("node_key argument missing")
+	}
+
+	pubKey, err := hex.DecodeString(nodeKey)
+	if err != nil {
+		return fmt.Errorf("unable to decode node key: %w", err)
+	}
+
+	policy := &lnrpc.PeerConnectionPolicy{
+		AlwaysReconnect: ctx.Bool("always_reconnect"),
+		MaxBackoffSeconds: uint32(
+			ctx.Duration("max_backoff") / time.Second,
+		),
+		PreferredAddress: ctx.String("preferred_address"),
+		TorOnly:          ctx.Bool("tor_only"),
+	}
+
+	resp, err := client.UpdatePeerConnectionPolicy(
+		ctxc, &lnrpc.UpdatePeerConnectionPolicyRequest{
+			PubKey: pubKey,
+			Policy: policy,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listPeerConnPoliciesCommand = cli.Command{
+	Name:     "listpeerconnpolicies",
+	Category: "Peers",
+	Usage:    "List the connection policies of all peers that have one.",
+	Action:   actionDecorator(listPeerConnPolicies),
+}
+
+func listPeerConnPolicies(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListPeerConnectionPolicies(
+		ctxc, &lnrpc.ListPeerConnectionPoliciesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var updatePeerGossipPolicyCommand = cli.Command{
+	Name:      "updatepeergossippolicy",
+	Category:  "Peers",
+	Usage:     "Set how gossip is exchanged with a peer.",
+	ArgsUsage: "node_key",
+	Description: `
+	Set the gossip policy of a peer. Gossip to the peer can be disabled, or
+	the rate at which gossip is sent in reply to its gossip queries can be
+	limited. The policy is persisted, calling the command without any
+	parameter flags removes the policy of the peer.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node_key",
+			Usage: "the hex encoded identity public key of the peer",
+		},
+		cli.BoolFlag{
+			Name:  "disable_gossip",
+			Usage: "stop sending any gossip to the peer",
+		},
+		cli.Uint64Flag{
+			Name: "rate_limit",
+			Usage: "the maximum number of gossip messages per " +
+				"second sent to the peer in reply to its " +
+				"gossip queries",
+		},
+	},
+	Action: actionDecorator(updatePeerGossipPolicy),
+}
+
+func updatePeerGossipPolicy(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var nodeKey string
+	switch {
+	case ctx.IsSet("node_key"):
+		nodeKey = ctx.String("node_key")
+
+	case ctx.Args().Present():
+		nodeKey = ctx.Args().First()
+
+	default:
+		return fmt.Errorf("node_key argument missing")
+	}
+
+	pubKey, err := hex.DecodeString(nodeKey)
+	if err != nil {
+		return fmt.Errorf("unable to decode node key: %w", err)
+	}
+
+	policy := &lnrpc.PeerGossipPolicy{
+		DisableGossip: ctx.Bool("disable_gossip"),
+		RateLimit:     uint32(ctx.Uint64("rate_limit")),
+	}
+
+	resp, err := client.UpdatePeerGossipPolicy(
+		ctxc, &lnrpc.UpdatePeerGossipPolicyRequest{
+			PubKey: pubKey,
+			Policy: policy,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listPeerGossipPoliciesCommand = cli.Command{
+	Name:     "listpeergossippolicies",
+	Category: "Peers",
+	Usage:    "List the gossip policies of all peers that have one.",
+	Action:   actionDecorator(listPeerGossipPolicies),
+}
+
+func listPeerGossipPolicies(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListPeerGossipPolicies(
+		ctxc, &lnrpc.ListPeerGossipPoliciesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
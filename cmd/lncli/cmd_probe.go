@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/urfave/cli"
+)
+
+var startProbeCommand = cli.Command{
+	Name:      "startprobe",
+	Category:  "Payments",
+	Usage:     "Schedule a background probe to a destination.",
+	ArgsUsage: "dest amt_msat",
+	Description: `
+	Schedule a background probe that periodically sends a payment with a
+	random payment hash to the destination. The probes keep mission control
+	up to date and track whether the destination is reachable for the given
+	amount. Scheduled probes are not persisted and need to be started again
+	after a restart.
+	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "interval",
+			Usage: "the interval in seconds between two probes, " +
+				"defaults to ten minutes",
+		},
+		cli.Uint64Flag{
+			Name: "timeout",
+			Usage: "the maximum time in seconds that a single " +
+				"probe may take, defaults to one minute",
+		},
+		cli.Int64Flag{
+			Name:  "fee_limit",
+			Usage: "the maximum fee in satoshis of a probe route",
+		},
+		cli.StringFlag{
+			Name: "mc_namespace",
+			Usage: "the mission control namespace the probes " +
+				"report their results to",
+		},
+	},
+	Action: actionDecorator(startProbe),
+}
+
+func startProbe(ctx *cli.Context) error {
+	ctxc := getContext()
+	if ctx.NArg() != 2 {
+		return cli.ShowCommandHelp(ctx, "startprobe")
+	}
+
+	dest, err := hex.DecodeString(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("unable to decode dest: %w", err)
+	}
+
+	amtMsat, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to decode amt_msat: %w", err)
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.StartProbeRequest{
+		Dest:                    dest,
+		AmtMsat:                 amtMsat,
+		IntervalSeconds:         uint32(ctx.Uint64("interval")),
+		TimeoutSeconds:          uint32(ctx.Uint64("timeout")),
+		FeeLimitSat:             ctx.Int64("fee_limit"),
+		MissionControlNamespace: ctx.String("mc_namespace"),
+	}
+	resp, err := client.StartProbe(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var stopProbeCommand = cli.Command{
+	Name:      "stopprobe",
+	Category:  "Payments",
+	Usage:     "Stop a scheduled probe.",
+	ArgsUsage: "probe_id",
+	Action:    actionDecorator(stopProbe),
+}
+
+func stopProbe(ctx *cli.Context) error {
+	ctxc := getContext()
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "stopprobe")
+	}
+
+	probeID, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to decode probe_id: %w", err)
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.StopProbeRequest{
+		ProbeId: probeID,
+	}
+	_, err = client.StopProbe(ctxc, req)
+
+	return err
+}
+
+var listProbeResultsCommand = cli.Command{
+	Name:     "listproberesults",
+	Category: "Payments",
+	Usage: "List the scheduled probes together with their " +
+		"reachability statistics.",
+	Action: actionDecorator(listProbeResults),
+}
+
+func listProbeResults(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.ListProbeResultsRequest{}
+	resp, err := client.ListProbeResults(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
@@ -267,6 +268,14 @@ var txLabelFlag = cli.StringFlag{
 	Usage: "(optional) a label for the transaction",
 }
 
+var respectAnchorReserveFlag = cli.BoolFlag{
+	Name: "respect_anchor_reserve",
+	Usage: "(optional) if set, the amount sent is reduced instead " +
+		"of the send failing in case it would leave the wallet " +
+		"with less than the reserve required to fee bump anchor " +
+		"channels",
+}
+
 var sendCoinsCommand = cli.Command{
 	Name:      "sendcoins",
 	Category:  "On-chain",
@@ -297,6 +306,13 @@ var sendCoinsCommand = cli.Command{
 			Name:  "amt",
 			Usage: "the number of bitcoin denominated in satoshis to send",
 		},
+		cli.Int64Flag{
+			Name: "reserve_amt",
+			Usage: "(optional) only used together with --sweepall, " +
+				"the number of satoshis to keep in the wallet " +
+				"while sweeping everything else to the target " +
+				"address",
+		},
 		cli.Int64Flag{
 			Name: "conf_target",
 			Usage: "(optional) the number of blocks that the " +
@@ -331,6 +347,7 @@ var sendCoinsCommand = cli.Command{
 		},
 		coinSelectionStrategyFlag,
 		txLabelFlag,
+		respectAnchorReserveFlag,
 	},
 	Action: actionDecorator(sendCoins),
 }
@@ -392,6 +409,12 @@ func sendCoins(ctx *cli.Context) error {
 			"sweep all coins out of the wallet")
 	}
 
+	reserveAmt := ctx.Int64("reserve_amt")
+	if reserveAmt != 0 && !ctx.Bool("sweepall") {
+		return fmt.Errorf("reserve_amt can only be set if attempting " +
+			"to sweep all coins out of the wallet")
+	}
+
 	coinSelectionStrategy, err := parseCoinSelectionStrategy(ctx)
 	if err != nil {
 		return err
@@ -414,7 +437,7 @@ func sendCoins(ctx *cli.Context) error {
 			return fmt.Errorf("unable to retrieve wallet balance:"+
 				" %w", err)
 		}
-		displayAmt = balanceResponse.GetConfirmedBalance()
+		displayAmt = balanceResponse.GetConfirmedBalance() - reserveAmt
 	}
 
 	// Ask for confirmation if we're on an actual terminal and the output is
@@ -440,6 +463,8 @@ func sendCoins(ctx *cli.Context) error {
 		MinConfs:              minConfs,
 		SpendUnconfirmed:      minConfs == 0,
 		CoinSelectionStrategy: coinSelectionStrategy,
+		RespectAnchorReserve:  ctx.Bool(respectAnchorReserveFlag.Name),
+		ReserveAmount:         reserveAmt,
 	}
 	txid, err := client.SendCoins(ctxc, req)
 	if err != nil {
@@ -610,6 +635,7 @@ var sendManyCommand = cli.Command{
 		},
 		coinSelectionStrategyFlag,
 		txLabelFlag,
+		respectAnchorReserveFlag,
 	},
 	Action: actionDecorator(sendMany),
 }
@@ -656,6 +682,7 @@ func sendMany(ctx *cli.Context) error {
 		MinConfs:              minConfs,
 		SpendUnconfirmed:      minConfs == 0,
 		CoinSelectionStrategy: coinSelectionStrategy,
+		RespectAnchorReserve:  ctx.Bool(respectAnchorReserveFlag.Name),
 	})
 	if err != nil {
 		return err
@@ -793,11 +820,31 @@ var closeChannelCommand = cli.Command{
 	it's always x3 of the starting value. Increasing this value increases
 	the chance of a successful negotiation.
 
+	In the case of a unilateral closure of a channel with anchor outputs,
+	the same arguments control how urgently the commitment transaction is
+	fee bumped using its anchor output. The --conf_target is the deadline
+	of the CPFP, --sat_per_vbyte its starting fee rate and --max_fee_rate
+	the fee rate of the whole package that determines the budget of the
+	CPFP, which is x3 of the starting fee rate if not specified.
+
 	In the case of a cooperative closure, one can manually set the address
 	to deliver funds to upon closure. This is optional, and may only be used
 	if an upfront shutdown address has not already been set. If neither are
 	set the funds will be delivered to a new wallet address.
 
+	In the case of a cooperative closure, one can also split the funds
+	across additional outputs by specifying --close_output one or more
+	times, e.g. to pay directly to cold storage and to an operations
+	wallet. The remainder of the funds is delivered to the delivery
+	address. This requires the peer to support split close outputs and may
+	only be used if an upfront shutdown address has not been set.
+
+	If a cooperative closing transaction has already been broadcast for a
+	channel that is closed using the RBF cooperative close protocol, then
+	running the command again bumps the fee of the closing transaction to
+	the fee rate given by --conf_target or --sat_per_vbyte. The peer must
+	be online to sign the replacement transaction.
+
 	To view which funding_txids/output_indexes can be used for a channel close,
 	see the channel_point values within the listchannels command output.
 	The format for a channel_point is 'funding_txid:output_index'.`,
@@ -861,6 +908,14 @@ var closeChannelCommand = cli.Command{
 				"success pobability of the negotiation if " +
 				"set higher",
 		},
+		cli.StringSliceFlag{
+			Name: "close_output",
+			Usage: "(optional) an additional output of the " +
+				"cooperative close transaction in the format " +
+				"address=amount_sat that part of the funds " +
+				"are delivered to, can be specified multiple " +
+				"times",
+		},
 	},
 	Action: actionDecorator(closeChannel),
 }
@@ -890,6 +945,11 @@ func closeChannel(ctx *cli.Context) error {
 		return err
 	}
 
+	closeOutputs, err := parseCloseOutputs(ctx.StringSlice("close_output"))
+	if err != nil {
+		return err
+	}
+
 	// TODO(roasbeef): implement time deadline within server
 	req := &lnrpc.CloseChannelRequest{
 		ChannelPoint:    channelPoint,
@@ -898,6 +958,7 @@ func closeChannel(ctx *cli.Context) error {
 		SatPerVbyte:     ctx.Uint64(feeRateFlag),
 		DeliveryAddress: ctx.String("delivery_addr"),
 		MaxFeePerVbyte:  ctx.Uint64("max_fee_rate"),
+		CloseOutputs:    closeOutputs,
 	}
 
 	// After parsing the request, we'll spin up a goroutine that will
@@ -932,6 +993,32 @@ func closeChannel(ctx *cli.Context) error {
 	return nil
 }
 
+// parseCloseOutputs parses additional close outputs in the format
+// address=amount_sat.
+func parseCloseOutputs(outputs []string) ([]*lnrpc.CloseOutput, error) {
+	closeOutputs := make([]*lnrpc.CloseOutput, 0, len(outputs))
+	for _, output := range outputs {
+		addr, amtStr, ok := strings.Cut(output, "=")
+		if !ok || addr == "" {
+			return nil, fmt.Errorf("invalid close output %v, "+
+				"expected address=amount_sat", output)
+		}
+
+		amt, err := strconv.ParseInt(amtStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount of close "+
+				"output %v: %w", output, err)
+		}
+
+		closeOutputs = append(closeOutputs, &lnrpc.CloseOutput{
+			Address:   addr,
+			AmountSat: amt,
+		})
+	}
+
+	return closeOutputs, nil
+}
+
 // executeChannelClose attempts to close the channel from a request. The closing
 // transaction ID is sent through `txidChan` as soon as it is broadcasted to the
 // network. The block boolean is used to determine if we should block until the
@@ -1240,9 +1327,10 @@ var abandonChannelCommand = cli.Command{
 	summary. This method can be used to get rid of permanently unusable
 	channels due to bugs fixed in newer versions of lnd.
 
-	Only available when lnd is built in debug mode. The flag
-	--i_know_what_i_am_doing can be set to override the debug/dev mode
-	requirement.
+	Unless lnd is built in debug mode, the channel is only abandoned if
+	its funding output is provably spent on chain. The flag
+	--i_know_what_i_am_doing can be set to override this requirement,
+	while the flag --require_spend_proof enforces it in debug mode too.
 
 	To view which funding_txids/output_indexes can be used for this command,
 	see the channel_point values within the listchannels command output.
@@ -1274,6 +1362,12 @@ var abandonChannelCommand = cli.Command{
 				"loss of funds if the channel funding TX " +
 				"ever confirms (or was confirmed)",
 		},
+		cli.BoolFlag{
+			Name: "require_spend_proof",
+			Usage: "only abandon the channel if its funding " +
+				"output is provably spent on chain, even if " +
+				"lnd is in dev/debug mode",
+		},
 	},
 	Action: actionDecorator(abandonChannel),
 }
@@ -1297,6 +1391,7 @@ func abandonChannel(ctx *cli.Context) error {
 	req := &lnrpc.AbandonChannelRequest{
 		ChannelPoint:      channelPoint,
 		IKnowWhatIAmDoing: ctx.Bool("i_know_what_i_am_doing"),
+		RequireSpendProof: ctx.Bool("require_spend_proof"),
 	}
 
 	resp, err := client.AbandonChannel(ctxc, req)
@@ -1355,16 +1450,41 @@ func parseChannelPoint(ctx *cli.Context) (*lnrpc.ChannelPoint, error) {
 	return channelPoint, nil
 }
 
+// paginationFlags returns the flags that are used to paginate the items of the
+// given kind returned by a list command. The items are identified by the given
+// key, which is used as the offset of a page.
+func paginationFlags(key, keyDesc, items string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name: key + "_offset",
+			Usage: "the " + keyDesc + " that will be used as " +
+				"either the start or end of a query to " +
+				"determine which " + items + " should be " +
+				"returned in the response",
+		},
+		cli.Uint64Flag{
+			Name: "max_" + items,
+			Usage: "the max number of " + items + " to return, " +
+				"all " + items + " are returned if not set",
+		},
+		cli.BoolFlag{
+			Name: "reversed",
+			Usage: "if set, the " + items + " preceding the " +
+				key + "_offset will be returned",
+		},
+	}
+}
+
 var listPeersCommand = cli.Command{
 	Name:     "listpeers",
 	Category: "Peers",
 	Usage:    "List all active, currently connected peers.",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "list_errors",
 			Usage: "list a full set of most recent errors for the peer",
 		},
-	},
+	}, paginationFlags("pub_key", "public key of a peer", "peers")...),
 	Action: actionDecorator(listPeers),
 }
 
@@ -1376,7 +1496,10 @@ func listPeers(ctx *cli.Context) error {
 	// By default, we display a single error on the cli. If the user
 	// specifically requests a full error set, then we will provide it.
 	req := &lnrpc.ListPeersRequest{
-		LatestError: !ctx.IsSet("list_errors"),
+		LatestError:  !ctx.IsSet("list_errors"),
+		PubKeyOffset: ctx.String("pub_key_offset"),
+		NumMaxPeers:  ctx.Uint64("max_peers"),
+		Reversed:     ctx.Bool("reversed"),
 	}
 	resp, err := client.ListPeers(ctxc, req)
 	if err != nil {
@@ -1523,13 +1646,15 @@ var pendingChannelsCommand = cli.Command{
 	Name:     "pendingchannels",
 	Category: "Channels",
 	Usage:    "Display information pertaining to pending channels.",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name: "include_raw_tx",
 			Usage: "include the raw transaction hex for " +
 				"waiting_close_channels.",
 		},
-	},
+	}, paginationFlags(
+		"chan_point", "channel point of a channel", "channels",
+	)...),
 	Action: actionDecorator(pendingChannels),
 }
 
@@ -1540,7 +1665,10 @@ func pendingChannels(ctx *cli.Context) error {
 
 	includeRawTx := ctx.Bool("include_raw_tx")
 	req := &lnrpc.PendingChannelsRequest{
-		IncludeRawTx: includeRawTx,
+		IncludeRawTx:    includeRawTx,
+		ChanPointOffset: ctx.String("chan_point_offset"),
+		NumMaxChannels:  ctx.Uint64("max_channels"),
+		Reversed:        ctx.Bool("reversed"),
 	}
 	resp, err := client.PendingChannels(ctxc, req)
 	if err != nil {
@@ -1556,7 +1684,7 @@ var listChannelsCommand = cli.Command{
 	Name:     "listchannels",
 	Category: "Channels",
 	Usage:    "List all open channels.",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "active_only",
 			Usage: "only list channels which are currently active",
@@ -1584,7 +1712,14 @@ var listChannelsCommand = cli.Command{
 			Usage: "skip the peer alias lookup per channel in " +
 				"order to improve performance",
 		},
-	},
+		cli.StringFlag{
+			Name: "tag",
+			Usage: "(optional) only display channels that are " +
+				"tagged with this tag",
+		},
+	}, paginationFlags(
+		"chan_point", "channel point of a channel", "channels",
+	)...),
 	Action: actionDecorator(listChannels),
 }
 
@@ -1643,6 +1778,10 @@ func listChannels(ctx *cli.Context) error {
 		PrivateOnly:     ctx.Bool("private_only"),
 		Peer:            peerKey,
 		PeerAliasLookup: lookupPeerAlias,
+		Tag:             ctx.String("tag"),
+		ChanPointOffset: ctx.String("chan_point_offset"),
+		NumMaxChannels:  ctx.Uint64("max_channels"),
+		Reversed:        ctx.Bool("reversed"),
 	}
 
 	resp, err := client.ListChannels(ctxc, req)
@@ -1659,7 +1798,7 @@ var closedChannelsCommand = cli.Command{
 	Name:     "closedchannels",
 	Category: "Channels",
 	Usage:    "List all closed channels.",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "cooperative",
 			Usage: "list channels that were closed cooperatively",
@@ -1689,7 +1828,9 @@ var closedChannelsCommand = cli.Command{
 			Usage: "list channels that were abandoned by " +
 				"the local node",
 		},
-	},
+	}, paginationFlags(
+		"chan_point", "channel point of a channel", "channels",
+	)...),
 	Action: actionDecorator(closedChannels),
 }
 
@@ -1705,6 +1846,9 @@ func closedChannels(ctx *cli.Context) error {
 		Breach:          ctx.Bool("breach"),
 		FundingCanceled: ctx.Bool("funding_canceled"),
 		Abandoned:       ctx.Bool("abandoned"),
+		ChanPointOffset: ctx.String("chan_point_offset"),
+		NumMaxChannels:  ctx.Uint64("max_channels"),
+		Reversed:        ctx.Bool("reversed"),
 	}
 
 	resp, err := client.ClosedChannels(ctxc, req)
@@ -1730,6 +1874,30 @@ var describeGraphCommand = cli.Command{
 				"graph. Unannounced channels are both private channels, and " +
 				"public channels that are not yet announced to the network.",
 		},
+		cli.Uint64Flag{
+			Name: "since_timestamp",
+			Usage: "if set, only the nodes and channels that were " +
+				"updated at or after this unix timestamp are " +
+				"returned",
+		},
+		cli.StringFlag{
+			Name: "node_offset",
+			Usage: "the public key of the last node of the previous " +
+				"page, only nodes after it are returned",
+		},
+		cli.Uint64Flag{
+			Name:  "max_nodes",
+			Usage: "the maximum number of nodes to return",
+		},
+		cli.Uint64Flag{
+			Name: "chan_id_offset",
+			Usage: "the channel ID of the last channel of the " +
+				"previous page, only channels after it are returned",
+		},
+		cli.Uint64Flag{
+			Name:  "max_edges",
+			Usage: "the maximum number of channels to return",
+		},
 	},
 	Action: actionDecorator(describeGraph),
 }
@@ -1741,6 +1909,11 @@ func describeGraph(ctx *cli.Context) error {
 
 	req := &lnrpc.ChannelGraphRequest{
 		IncludeUnannounced: ctx.Bool("include_unannounced"),
+		SinceTimestamp:     ctx.Uint64("since_timestamp"),
+		NodeOffset:         ctx.String("node_offset"),
+		MaxNodes:           uint32(ctx.Uint64("max_nodes")),
+		ChanIdOffset:       ctx.Uint64("chan_id_offset"),
+		MaxEdges:           uint32(ctx.Uint64("max_edges")),
 	}
 
 	graph, err := client.DescribeGraph(ctxc, req)
@@ -1844,6 +2017,61 @@ func getChanInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var refreshChannelEdgeCommand = cli.Command{
+	Name:     "refreshchanneledge",
+	Category: "Graph",
+	Usage:    "Query the gossip peers for the state of a channel.",
+	Description: `
+	Query all synced gossip peers for the announcement and the latest
+	updates of a channel. If the channel is known as a zombie, it is marked
+	as live again, so that it is added to the graph once a peer sends its
+	announcement.`,
+	ArgsUsage: "chan_id",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "chan_id",
+			Usage: "The 8-byte compact channel ID to refresh.",
+		},
+	},
+	Action: actionDecorator(refreshChannelEdge),
+}
+
+func refreshChannelEdge(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		chanID uint64
+		err    error
+	)
+	switch {
+	case ctx.IsSet("chan_id"):
+		chanID = ctx.Uint64("chan_id")
+
+	case ctx.Args().Present():
+		chanID, err = strconv.ParseUint(ctx.Args().First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing chan_id: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("chan_id argument missing")
+	}
+
+	resp, err := client.RefreshChannelEdge(
+		ctxc, &lnrpc.RefreshChannelEdgeRequest{
+			ChanId: chanID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var getNodeInfoCommand = cli.Command{
 	Name:     "getnodeinfo",
 	Category: "Graph",
@@ -2154,7 +2382,36 @@ var feeReportCommand = cli.Command{
 	Usage:    "Display the current fee policies of all active channels.",
 	Description: `
 	Returns the current fee policies of all active channels.
-	Fee policies can be updated using the updatechanpolicy command.`,
+	Fee policies can be updated using the updatechanpolicy command.
+
+	If --tag is set, only the channels of the given channel group are
+	reported, and the fee sums only include the fees earned by forwarding
+	HTLCs out through these channels.
+
+	If --start_time or --end_time is set, the report includes the profit
+	and loss of each channel within the time window: the fees earned by
+	forwarding HTLCs out through the channel, the fees paid for circular
+	rebalances into the channel and the amortized on-chain fees paid to
+	open and close the channel. Channels that were closed within the window
+	are reported as well. The times are expressed as unix timestamps or
+	relative, e.g. "-1w".`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "tag",
+			Usage: "(optional) only report the channels that are " +
+				"tagged with this tag",
+		},
+		cli.StringFlag{
+			Name: "start_time",
+			Usage: "(optional) the start of the profit and loss " +
+				`time window, e.g. "-1M"`,
+		},
+		cli.StringFlag{
+			Name: "end_time",
+			Usage: "(optional) the end of the profit and loss " +
+				"time window, defaults to now",
+		},
+	},
 	Action: actionDecorator(feeReport),
 }
 
@@ -2163,7 +2420,27 @@ func feeReport(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.FeeReportRequest{}
+	req := &lnrpc.FeeReportRequest{
+		Tag: ctx.String("tag"),
+	}
+
+	now := time.Now()
+	if ctx.IsSet("start_time") {
+		startTime, err := parseTime(ctx.String("start_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode start_time: %w",
+				err)
+		}
+		req.StartTime = startTime
+	}
+	if ctx.IsSet("end_time") {
+		endTime, err := parseTime(ctx.String("end_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode end_time: %w", err)
+		}
+		req.EndTime = endTime
+	}
+
 	resp, err := client.FeeReport(ctxc, req)
 	if err != nil {
 		return err
@@ -2176,8 +2453,8 @@ func feeReport(ctx *cli.Context) error {
 var updateChannelPolicyCommand = cli.Command{
 	Name:     "updatechanpolicy",
 	Category: "Channels",
-	Usage: "Update the channel policy for all channels, or a single " +
-		"channel.",
+	Usage: "Update the channel policy for all channels, a single " +
+		"channel, or all channels with a peer or in a channel group.",
 	ArgsUsage: "base_fee_msat fee_rate time_lock_delta " +
 		"[--max_htlc_msat=N] [channel_point]",
 	Description: `
@@ -2185,6 +2462,10 @@ var updateChannelPolicyCommand = cli.Command{
         channel identified by its channel point. The update will be committed, 
 	and broadcast to the rest of the network within the next batch. Channel
         points are encoded as: funding_txid:output_index
+
+	Alternatively, the update can target all open channels with a peer by
+	setting --peer, or all open channels tagged with a channel group name
+	by setting --channel_group.
 	`,
 	Flags: []cli.Flag{
 		cli.Int64Flag{
@@ -2260,6 +2541,40 @@ var updateChannelPolicyCommand = cli.Command{
 				"channels will be updated. Takes the form of " +
 				"txid:output_index",
 		},
+		cli.StringFlag{
+			Name: "peer",
+			Usage: "(optional) the hex encoded public key of the " +
+				"peer whose open channels this policy update " +
+				"should be applied to",
+		},
+		cli.StringFlag{
+			Name: "channel_group",
+			Usage: "(optional) the name of the channel group " +
+				"whose open channels this policy update " +
+				"should be applied to",
+		},
+		cli.DurationFlag{
+			Name: "ramp_duration",
+			Usage: "(optional) if set, the base fee and fee rate " +
+				"are moved towards the new values in several " +
+				"steps over the given duration, e.g. 2h, " +
+				"instead of being applied right away",
+		},
+		cli.Uint64Flag{
+			Name: "max_dust_exposure_msat",
+			Usage: "(optional) if set, the maximum total amount " +
+				"of dust HTLCs of the channels, which is " +
+				"lost to miners in case of a force close. " +
+				"Set to 0 to use the global dust-threshold",
+		},
+		cli.Uint64Flag{
+			Name: "dust_exposure_fee_rate_multiplier",
+			Usage: "(optional) if set, the maximum dust " +
+				"exposure of the channels is the commitment " +
+				"fee rate in sat/kw multiplied by this " +
+				"value, in msat. Set to 0 to use the global " +
+				"dust-threshold",
+		},
 	},
 	Action: actionDecorator(updateChannelPolicy),
 }
@@ -2431,11 +2746,64 @@ func updateChannelPolicy(ctx *cli.Context) error {
 		req.MinHtlcMsatSpecified = true
 	}
 
-	if chanPoint != nil {
+	if ctx.IsSet("ramp_duration") {
+		rampDuration := ctx.Duration("ramp_duration")
+		if rampDuration < time.Second ||
+			rampDuration.Seconds() > math.MaxUint32 {
+
+			return errors.New("ramp_duration out of range")
+		}
+		req.RampDurationSeconds = uint32(rampDuration.Seconds())
+	}
+
+	if ctx.IsSet("max_dust_exposure_msat") ||
+		ctx.IsSet("dust_exposure_fee_rate_multiplier") {
+
+		req.DustExposure = &lnrpc.DustExposurePolicy{
+			MaxDustHtlcExposureMsat: ctx.Uint64(
+				"max_dust_exposure_msat",
+			),
+			FeeRateMultiplier: ctx.Uint64(
+				"dust_exposure_fee_rate_multiplier",
+			),
+		}
+	}
+
+	numScopes := 0
+	for _, isSet := range []bool{
+		chanPoint != nil, ctx.IsSet("peer"),
+		ctx.IsSet("channel_group"),
+	} {
+		if isSet {
+			numScopes++
+		}
+	}
+	if numScopes > 1 {
+		return errors.New("only one of chan_point, peer and " +
+			"channel_group can be set")
+	}
+
+	switch {
+	case chanPoint != nil:
 		req.Scope = &lnrpc.PolicyUpdateRequest_ChanPoint{
 			ChanPoint: chanPoint,
 		}
-	} else {
+
+	case ctx.IsSet("peer"):
+		peer, err := hex.DecodeString(ctx.String("peer"))
+		if err != nil {
+			return fmt.Errorf("unable to decode peer: %w", err)
+		}
+		req.Scope = &lnrpc.PolicyUpdateRequest_PeerPubkey{
+			PeerPubkey: peer,
+		}
+
+	case ctx.IsSet("channel_group"):
+		req.Scope = &lnrpc.PolicyUpdateRequest_ChannelGroup{
+			ChannelGroup: ctx.String("channel_group"),
+		}
+
+	default:
 		req.Scope = &lnrpc.PolicyUpdateRequest_Global{
 			Global: true,
 		}
@@ -2471,6 +2839,320 @@ func updateChannelPolicy(ctx *cli.Context) error {
 	return nil
 }
 
+var updateChannelConstraintsCommand = cli.Command{
+	Name:     "updatechanconstraints",
+	Category: "Channels",
+	Usage:    "Limit the HTLCs that are forwarded over a channel.",
+	ArgsUsage: "chan_point [--max_htlcs=N] [--max_in_flight_msat=N] " +
+		"[--htlc_rate=N --htlc_rate_interval=D]",
+	Description: `
+	Sets the limits for the HTLCs that are forwarded over the channel
+	identified by its channel point, to protect it against jamming and
+	HTLC slot exhaustion. Limits that are not set are not enforced, so
+	calling this command with only the channel point removes all limits
+	of the channel. Channel points are encoded as:
+	funding_txid:output_index
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel whose forwarding limits should " +
+				"be updated. Takes the form of " +
+				"txid:output_index",
+		},
+		cli.Uint64Flag{
+			Name: "max_htlcs",
+			Usage: "the maximum number of forwarded HTLCs that " +
+				"may be in flight on the channel at the same " +
+				"time",
+		},
+		cli.Uint64Flag{
+			Name: "max_in_flight_msat",
+			Usage: "the maximum total amount in milli-satoshis " +
+				"of the forwarded HTLCs that may be in " +
+				"flight on the channel at the same time",
+		},
+		cli.Uint64Flag{
+			Name: "htlc_rate",
+			Usage: "the maximum number of HTLCs that are " +
+				"forwarded over the channel within " +
+				"htlc_rate_interval",
+		},
+		cli.DurationFlag{
+			Name: "htlc_rate_interval",
+			Usage: "the interval that htlc_rate applies to, " +
+				"e.g. 1m",
+		},
+	},
+	Action: actionDecorator(updateChannelConstraints),
+}
+
+func updateChannelConstraints(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var chanPointStr string
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+
+	case ctx.Args().Present():
+		chanPointStr = ctx.Args().First()
+
+	default:
+		return cli.ShowCommandHelp(ctx, "updatechanconstraints")
+	}
+
+	chanPoint, err := parseChanPoint(chanPointStr)
+	if err != nil {
+		return fmt.Errorf("unable to parse chan_point: %w", err)
+	}
+
+	if ctx.Uint64("max_htlcs") > math.MaxUint32 ||
+		ctx.Uint64("htlc_rate") > math.MaxUint32 {
+
+		return fmt.Errorf("max_htlcs and htlc_rate must fit into " +
+			"32 bits")
+	}
+
+	rateInterval := ctx.Duration("htlc_rate_interval")
+	if rateInterval%time.Second != 0 {
+		return fmt.Errorf("htlc_rate_interval must be a whole " +
+			"number of seconds")
+	}
+
+	req := &lnrpc.UpdateChannelConstraintsRequest{
+		ChanPoint:                chanPoint,
+		MaxForwardedHtlcs:        uint32(ctx.Uint64("max_htlcs")),
+		MaxForwardedInFlightMsat: ctx.Uint64("max_in_flight_msat"),
+		HtlcRateLimit:            uint32(ctx.Uint64("htlc_rate")),
+		HtlcRateIntervalSeconds:  uint32(rateInterval / time.Second),
+	}
+
+	resp, err := client.UpdateChannelConstraints(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var updateChannelTagsCommand = cli.Command{
+	Name:     "updatechantags",
+	Category: "Channels",
+	Usage:    "Add tags to or remove tags from a channel.",
+	ArgsUsage: "chan_point [--add=TAG...] [--remove=TAG...] " +
+		"[--replace]",
+	Description: `
+	Adds tags to or removes tags from the channel identified by its
+	channel point. Tags allow organizing channels in named groups, which
+	can be used with the --tag flag of the listchannels, feereport and
+	fwdinghistory commands and the --channel_group flag of the
+	updatechanpolicy command. The flags can be repeated to add or remove
+	multiple tags. Tags are removed before new tags are added. Channel
+	points are encoded as: funding_txid:output_index
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel whose tags should be updated. " +
+				"Takes the form of txid:output_index",
+		},
+		cli.StringSliceFlag{
+			Name:  "add",
+			Usage: "a tag to add to the channel",
+		},
+		cli.StringSliceFlag{
+			Name:  "remove",
+			Usage: "a tag to remove from the channel",
+		},
+		cli.BoolFlag{
+			Name: "replace",
+			Usage: "remove all existing tags of the channel " +
+				"before adding the new ones",
+		},
+	},
+	Action: actionDecorator(updateChannelTags),
+}
+
+func updateChannelTags(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var chanPointStr string
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+
+	case ctx.Args().Present():
+		chanPointStr = ctx.Args().First()
+
+	default:
+		return cli.ShowCommandHelp(ctx, "updatechantags")
+	}
+
+	chanPoint, err := parseChanPoint(chanPointStr)
+	if err != nil {
+		return fmt.Errorf("unable to parse chan_point: %w", err)
+	}
+
+	req := &lnrpc.UpdateChannelTagsRequest{
+		ChanPoint:  chanPoint,
+		AddTags:    ctx.StringSlice("add"),
+		RemoveTags: ctx.StringSlice("remove"),
+		Replace:    ctx.Bool("replace"),
+	}
+
+	resp, err := client.UpdateChannelTags(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var setAnchorSweepPolicyCommand = cli.Command{
+	Name:     "setanchorsweeppolicy",
+	Category: "Channels",
+	Usage: "Set the deadline and budget of the anchor CPFP of a channel " +
+		"or of all channels with a peer.",
+	ArgsUsage: "[--chan_point=txid:output_index | --node_key=pubkey] " +
+		"[--max_deadline=N] [--budget_ratio=F] [--max_budget=N] " +
+		"[--min_value_at_stake=N]",
+	Description: `
+	Overrides the deadline and the budget that are used to CPFP the
+	commitment transaction of a force closed channel by sweeping its
+	anchor. The policy can be set for a single channel, identified by its
+	channel point, or for all channels with a peer, identified by its
+	public key. The policy of a channel takes precedence over the policy
+	of its peer. Values that are not set fall back to the global sweeper
+	budget config, so calling this command without any of them removes
+	the policy.
+
+	Channels with large HTLCs in flight can be given a short deadline and
+	a large budget to get their commitment confirmed quickly, while a
+	minimum value at stake prevents burning fees on dusty channels.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel the policy applies to. Takes the " +
+				"form of txid:output_index",
+		},
+		cli.StringFlag{
+			Name: "node_key",
+			Usage: "the hex encoded identity public key of the " +
+				"peer whose channels the policy applies to",
+		},
+		cli.Uint64Flag{
+			Name: "max_deadline",
+			Usage: "the maximum number of blocks the commitment " +
+				"transaction is given to confirm",
+		},
+		cli.Float64Flag{
+			Name: "budget_ratio",
+			Usage: "the ratio of the value at stake that is used " +
+				"as the budget of the CPFP",
+		},
+		cli.Int64Flag{
+			Name:  "max_budget",
+			Usage: "the maximum budget of the CPFP in satoshis",
+		},
+		cli.Int64Flag{
+			Name: "min_value_at_stake",
+			Usage: "the minimum value of the time-sensitive " +
+				"HTLCs in satoshis that justifies a CPFP",
+		},
+	},
+	Action: actionDecorator(setAnchorSweepPolicy),
+}
+
+func setAnchorSweepPolicy(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if ctx.Uint64("max_deadline") > math.MaxUint32 {
+		return fmt.Errorf("max_deadline must fit into 32 bits")
+	}
+
+	req := &lnrpc.SetAnchorSweepPolicyRequest{
+		Policy: &lnrpc.AnchorSweepPolicy{
+			MaxDeadlineBlocks:  uint32(ctx.Uint64("max_deadline")),
+			BudgetRatio:        ctx.Float64("budget_ratio"),
+			MaxBudgetSat:       ctx.Int64("max_budget"),
+			MinValueAtStakeSat: ctx.Int64("min_value_at_stake"),
+		},
+	}
+
+	switch {
+	case ctx.IsSet("chan_point") && ctx.IsSet("node_key"):
+		return fmt.Errorf("only one of chan_point and node_key can " +
+			"be set")
+
+	case ctx.IsSet("chan_point"):
+		chanPoint, err := parseChanPoint(ctx.String("chan_point"))
+		if err != nil {
+			return fmt.Errorf("unable to parse chan_point: %w", err)
+		}
+		req.Target = &lnrpc.SetAnchorSweepPolicyRequest_ChanPoint{
+			ChanPoint: chanPoint,
+		}
+
+	case ctx.IsSet("node_key"):
+		pubKey, err := hex.DecodeString(ctx.String("node_key"))
+		if err != nil {
+			return fmt.Errorf("unable to decode node key: %w", err)
+		}
+		req.Target = &lnrpc.SetAnchorSweepPolicyRequest_Peer{
+			Peer: pubKey,
+		}
+
+	default:
+		return cli.ShowCommandHelp(ctx, "setanchorsweeppolicy")
+	}
+
+	resp, err := client.SetAnchorSweepPolicy(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listAnchorSweepPoliciesCommand = cli.Command{
+	Name:     "listanchorsweeppolicies",
+	Category: "Channels",
+	Usage: "List the anchor sweep policies of all channels and peers " +
+		"that have one.",
+	Action: actionDecorator(listAnchorSweepPolicies),
+}
+
+func listAnchorSweepPolicies(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListAnchorSweepPolicies(
+		ctxc, &lnrpc.ListAnchorSweepPoliciesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var fishCompletionCommand = cli.Command{
 	Name:   "fish-completion",
 	Hidden: true,
@@ -2775,6 +3457,11 @@ var restoreChanBackupCommand = cli.Command{
 	   * A file path which points to a packed multi-channel backup within a
 	     file, using the same format that lnd does in its channel.backup
 	     file.
+
+	The channels contained in the backup are printed. With --dry_run, the
+	channels are only printed without restoring any of them. To restore
+	only some of the channels, specify their funding outpoints with
+	--chan_point.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -2799,10 +3486,93 @@ var restoreChanBackupCommand = cli.Command{
 			Usage:     "the path to a multi-channel back up file",
 			TakesFile: true,
 		},
+		cli.BoolFlag{
+			Name: "dry_run",
+			Usage: "only list the channels contained in the " +
+				"backup without restoring them",
+		},
+		cli.StringSliceFlag{
+			Name: "chan_point",
+			Usage: "the funding outpoint (txid:index) of a " +
+				"channel to restore, can be specified " +
+				"multiple times; if not set, all channels " +
+				"are restored",
+		},
 	},
 	Action: actionDecorator(restoreChanBackup),
 }
 
+var rescueChannelsCommand = cli.Command{
+	Name:     "rescuechannels",
+	Category: "Channels",
+	Usage: "Recover the funds of channels restored from a static " +
+		"channel backup.",
+	ArgsUsage: "[--chan_point=] [--publish_local_commitment] " +
+		"[--status_only]",
+	Description: `
+	Attempts to recover the funds of channels that were restored from a
+	static channel backup, or that lost their state, and prints the
+	recovery progress of each channel.
+
+	As we can't publish a commitment for these channels, their peers are
+	asked to force close them. Peers that aren't connected are connected
+	to. Channels of which we still have the full state are only force
+	closed if --publish_local_commitment is set.
+
+	The command can be run repeatedly, for example with --status_only, to
+	learn which channels were recovered and which are stuck.
+	`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "chan_point",
+			Usage: "the funding outpoint (txid:index) of a " +
+				"channel to recover, can be specified " +
+				"multiple times; if not set, all channels " +
+				"that lost their state are recovered",
+		},
+		cli.BoolFlag{
+			Name: "publish_local_commitment",
+			Usage: "publish our latest commitment for the " +
+				"selected channels of which we still have " +
+				"the full state",
+		},
+		cli.BoolFlag{
+			Name: "status_only",
+			Usage: "only print the recovery progress without " +
+				"taking any action",
+		},
+	},
+	Action: actionDecorator(rescueChannels),
+}
+
+func rescueChannels(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.RequestForceCloseFromBackupRequest{
+		PublishLocalCommitment: ctx.Bool("publish_local_commitment"),
+		StatusOnly:             ctx.Bool("status_only"),
+	}
+	for _, chanPointStr := range ctx.StringSlice("chan_point") {
+		chanPoint, err := parseChanPoint(chanPointStr)
+		if err != nil {
+			return fmt.Errorf("unable to parse chan_point %v: %w",
+				chanPointStr, err)
+		}
+		req.ChanPoints = append(req.ChanPoints, chanPoint)
+	}
+
+	resp, err := client.RequestForceCloseFromBackup(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 // errMissingChanBackup is an error returned when we attempt to parse a channel
 // backup from a CLI command and it is missing.
 var errMissingChanBackup = errors.New("missing channel backup")
@@ -2899,11 +3669,23 @@ func restoreChanBackup(ctx *cli.Context) error {
 	}
 
 	req.Backup = backups.Backup
+	req.DryRun = ctx.Bool("dry_run")
 
-	_, err = client.RestoreChannelBackups(ctxc, &req)
+	for _, chanPointStr := range ctx.StringSlice("chan_point") {
+		chanPoint, err := parseChanPoint(chanPointStr)
+		if err != nil {
+			return fmt.Errorf("unable to parse chan_point %v: %w",
+				chanPointStr, err)
+		}
+		req.ChanPoints = append(req.ChanPoints, chanPoint)
+	}
+
+	resp, err := client.RestoreChannelBackups(ctxc, &req)
 	if err != nil {
 		return fmt.Errorf("unable to restore chan backups: %w", err)
 	}
 
+	printRespJSON(resp)
+
 	return nil
 }
@@ -198,14 +198,36 @@ var addHoldInvoiceCommand = cli.Command{
 				"private channels in order to assist the " +
 				"payer in reaching you",
 		},
+		hintStrategyFlag,
+		numHintsFlag,
+		cli.Uint64Flag{
+			Name: "auto_settle_after",
+			Usage: "the number of seconds after the invoice is " +
+				"accepted after which it is settled " +
+				"automatically with the " +
+				"--auto_settle_preimage",
+		},
+		cli.StringFlag{
+			Name: "auto_settle_preimage",
+			Usage: "the hex-encoded preimage to settle the " +
+				"invoice with once --auto_settle_after " +
+				"seconds have elapsed",
+		},
+		cli.Uint64Flag{
+			Name: "auto_cancel_after",
+			Usage: "the number of seconds after the invoice is " +
+				"accepted after which it is canceled " +
+				"automatically",
+		},
 	},
 	Action: actionDecorator(addHoldInvoice),
 }
 
 func addHoldInvoice(ctx *cli.Context) error {
 	var (
-		descHash []byte
-		err      error
+		descHash           []byte
+		autoSettlePreimage []byte
+		err                error
 	)
 
 	ctxc := getContext()
@@ -241,6 +263,21 @@ func addHoldInvoice(ctx *cli.Context) error {
 		return fmt.Errorf("unable to parse description_hash: %w", err)
 	}
 
+	if ctx.IsSet("auto_settle_preimage") {
+		autoSettlePreimage, err = hex.DecodeString(
+			ctx.String("auto_settle_preimage"),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to parse "+
+				"auto_settle_preimage: %w", err)
+		}
+	}
+
+	hintStrategy, err := parseHintStrategy(ctx)
+	if err != nil {
+		return err
+	}
+
 	invoice := &invoicesrpc.AddHoldInvoiceRequest{
 		Memo:            ctx.String("memo"),
 		Hash:            hash,
@@ -251,6 +288,12 @@ func addHoldInvoice(ctx *cli.Context) error {
 		Expiry:          ctx.Int64("expiry"),
 		CltvExpiry:      ctx.Uint64("cltv_expiry_delta"),
 		Private:         ctx.Bool("private"),
+
+		AutoSettleAfterSeconds: ctx.Uint64("auto_settle_after"),
+		AutoSettlePreimage:     autoSettlePreimage,
+		AutoCancelAfterSeconds: ctx.Uint64("auto_cancel_after"),
+		HintStrategy:           hintStrategy,
+		NumHints:               uint32(ctx.Uint64(numHintsFlag.Name)),
 	}
 
 	resp, err := client.AddHoldInvoice(ctxc, invoice)
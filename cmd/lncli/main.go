@@ -456,6 +456,14 @@ func main() {
 		closeChannelCommand,
 		closeAllChannelsCommand,
 		abandonChannelCommand,
+		setAcceptorPolicyCommand,
+		getAcceptorPolicyCommand,
+		setPeerPolicyCommand,
+		listPeerPoliciesCommand,
+		updatePeerConnPolicyCommand,
+		listPeerConnPoliciesCommand,
+		updatePeerGossipPolicyCommand,
+		listPeerGossipPoliciesCommand,
 		listPeersCommand,
 		walletBalanceCommand,
 		channelBalanceCommand,
@@ -471,12 +479,14 @@ func main() {
 		addInvoiceCommand,
 		lookupInvoiceCommand,
 		listInvoicesCommand,
+		updateKeysendPolicyCommand,
 		listChannelsCommand,
 		closedChannelsCommand,
 		listPaymentsCommand,
 		describeGraphCommand,
 		getNodeMetricsCommand,
 		getChanInfoCommand,
+		refreshChannelEdgeCommand,
 		getNodeInfoCommand,
 		queryRoutesCommand,
 		getNetworkInfoCommand,
@@ -488,13 +498,19 @@ func main() {
 		verifyMessageCommand,
 		feeReportCommand,
 		updateChannelPolicyCommand,
+		updateChannelConstraintsCommand,
+		updateChannelTagsCommand,
+		setAnchorSweepPolicyCommand,
+		listAnchorSweepPoliciesCommand,
 		forwardingHistoryCommand,
 		exportChanBackupCommand,
 		verifyChanBackupCommand,
 		restoreChanBackupCommand,
+		rescueChannelsCommand,
 		bakeMacaroonCommand,
 		listMacaroonIDsCommand,
 		deleteMacaroonIDCommand,
+		rotateMacaroonRootKeyCommand,
 		listPermissionsCommand,
 		printMacaroonCommand,
 		constrainMacaroonCommand,
@@ -505,9 +521,12 @@ func main() {
 		deletePaymentsCommand,
 		sendCustomCommand,
 		subscribeCustomCommand,
+		sendOnionMessageCommand,
+		subscribeOnionMessagesCommand,
 		fishCompletionCommand,
 		listAliasesCommand,
 		estimateRouteFeeCommand,
+		dbCommand,
 		generateManPageCommand,
 	}
 
@@ -521,6 +540,7 @@ func main() {
 	app.Commands = append(app.Commands, wtclientCommands()...)
 	app.Commands = append(app.Commands, devCommands()...)
 	app.Commands = append(app.Commands, peersCommands()...)
+	app.Commands = append(app.Commands, accountingCommands()...)
 	app.Commands = append(app.Commands, chainCommands()...)
 
 	if err := app.Run(os.Args); err != nil {
@@ -7,11 +7,20 @@ func routerCommands() []cli.Command {
 	return []cli.Command{
 		queryMissionControlCommand,
 		importMissionControlCommand,
+		exportMissionControlCommand,
 		queryProbCommand,
 		resetMissionControlCommand,
+		addMissionControlNamespaceCommand,
+		deleteMissionControlNamespaceCommand,
+		listMissionControlNamespacesCommand,
 		buildRouteCommand,
 		getCfgCommand,
 		setCfgCommand,
 		updateChanStatusCommand,
+		getReputationCommand,
+		listCircuitsCommand,
+		startProbeCommand,
+		stopProbeCommand,
+		listProbeResultsCommand,
 	}
 }
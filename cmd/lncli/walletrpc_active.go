@@ -81,6 +81,8 @@ func walletCommands() []cli.Command {
 			Subcommands: []cli.Command{
 				estimateFeeRateCommand,
 				pendingSweepsCommand,
+				pendingSweepsDetailCommand,
+				removeSweepCommand,
 				bumpFeeCommand,
 				bumpCloseFeeCommand,
 				bumpForceCloseFeeCommand,
@@ -166,11 +168,13 @@ func estimateFeeRate(ctx *cli.Context) error {
 	rateVB := rateKW.FeePerVByte()
 
 	printJSON(struct {
-		SatPerKw    int64 `json:"sat_per_kw"`
-		SatPerVByte int64 `json:"sat_per_vbyte"`
+		SatPerKw    int64    `json:"sat_per_kw"`
+		SatPerVByte int64    `json:"sat_per_vbyte"`
+		FeeSources  []string `json:"fee_sources,omitempty"`
 	}{
 		SatPerKw:    int64(rateKW),
 		SatPerVByte: int64(rateVB),
+		FeeSources:  resp.FeeSources,
 	})
 
 	return nil
@@ -224,6 +228,85 @@ func pendingSweeps(ctx *cli.Context) error {
 	return nil
 }
 
+var pendingSweepsDetailCommand = cli.Command{
+	Name: "pendingsweepsdetail",
+	Usage: "Preview the batches pending outputs are going to be swept " +
+		"in.",
+	ArgsUsage: "",
+	Description: `
+	Show the batches lnd's central batching engine currently intends to
+	sweep its pending outputs in. For each batch, the projected fee rate
+	trajectory until its deadline and the height of the next broadcast
+	attempt are shown. Outputs that are still timelocked are listed
+	separately along with the height at which they can first be swept.
+	`,
+	Flags:  []cli.Flag{},
+	Action: actionDecorator(pendingSweepsDetail),
+}
+
+func pendingSweepsDetail(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	req := &walletrpc.PendingSweepsDetailRequest{}
+	resp, err := client.PendingSweepsDetail(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var removeSweepCommand = cli.Command{
+	Name:      "removesweep",
+	Usage:     "Permanently remove an output from the sweeper.",
+	ArgsUsage: "outpoint",
+	Description: `
+	Permanently remove an output that lnd is attempting to sweep, for
+	example an uneconomical dust output of a force close. The output won't
+	be swept again, even after a restart, and is abandoned by the subsystem
+	that offered it to the sweeper.
+
+	The outpoint must be provided in the form txid:index. Outputs that are
+	part of a published sweeping transaction can't be removed.
+	`,
+	Action: actionDecorator(removeSweep),
+}
+
+func removeSweep(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "removesweep")
+	}
+
+	protoOutPoint, err := NewProtoOutPoint(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	client, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.RemoveSweepInput(
+		ctxc, &walletrpc.RemoveSweepInputRequest{
+			Outpoint: protoOutPoint,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var bumpFeeCommand = cli.Command{
 	Name:      "bumpfee",
 	Usage:     "Bumps the fee of an arbitrary input/transaction.",
@@ -302,6 +385,14 @@ var bumpFeeCommand = cli.Command{
 	the budget for fee bumping; for existing inputs, their current budgets
 	will be retained.`,
 		},
+		cli.Uint64Flag{
+			Name: "deadline_height",
+			Usage: `
+	The absolute block height by which the input should be spent. This is
+	an alternative to conf_target and cannot be set together with it. The
+	sweeper's fee function raises the fee rate with every block until the
+	full budget is used at the deadline.`,
+		},
 	},
 	Action: actionDecorator(bumpFee),
 }
@@ -339,11 +430,12 @@ func bumpFee(ctx *cli.Context) error {
 	}
 
 	resp, err := client.BumpFee(ctxc, &walletrpc.BumpFeeRequest{
-		Outpoint:    protoOutPoint,
-		TargetConf:  uint32(ctx.Uint64("conf_target")),
-		Immediate:   immediate,
-		Budget:      ctx.Uint64("budget"),
-		SatPerVbyte: ctx.Uint64("sat_per_vbyte"),
+		Outpoint:       protoOutPoint,
+		TargetConf:     uint32(ctx.Uint64("conf_target")),
+		DeadlineHeight: uint32(ctx.Uint64("deadline_height")),
+		Immediate:      immediate,
+		Budget:         ctx.Uint64("budget"),
+		SatPerVbyte:    ctx.Uint64("sat_per_vbyte"),
 	})
 	if err != nil {
 		return err
@@ -594,6 +686,28 @@ var listSweepsCommand = cli.Command{
 				"to -1 the result will only include " +
 				"unconfirmed sweeps (at the time of the call).",
 		},
+		cli.IntFlag{
+			Name: "endheight",
+			Usage: "The end height (inclusive) to use when " +
+				"fetching sweeps. If not specified (0), the " +
+				"result will include all sweeps up to the " +
+				"chain tip, including unconfirmed ones.",
+		},
+		cli.UintFlag{
+			Name: "max_sweeps",
+			Usage: "The maximum number of sweeps to return. If " +
+				"not specified (0), all sweeps are returned.",
+		},
+		cli.StringFlag{
+			Name: "page_token",
+			Usage: "The next_page_token of a previous call, to " +
+				"continue listing the sweeps after it.",
+		},
+		cli.BoolFlag{
+			Name: "include_fees",
+			Usage: "Include the fee and fee rate paid by each " +
+				"sweep.",
+		},
 	},
 	Description: `
 	Get a list of the hex-encoded transaction ids of every sweep that our
@@ -602,6 +716,10 @@ var listSweepsCommand = cli.Command{
 
 	If the verbose flag is set, the full set of transactions will be 
 	returned, otherwise only the sweep transaction ids will be returned. 
+
+	Large result sets can be fetched in pages using the max_sweeps flag,
+	passing the returned next_page_token as page_token to fetch the next
+	page.
 	`,
 	Action: actionDecorator(listSweeps),
 }
@@ -615,6 +733,10 @@ func listSweeps(ctx *cli.Context) error {
 		ctxc, &walletrpc.ListSweepsRequest{
 			Verbose:     ctx.IsSet("verbose"),
 			StartHeight: int32(ctx.Int("startheight")),
+			EndHeight:   int32(ctx.Int("endheight")),
+			MaxSweeps:   uint32(ctx.Uint("max_sweeps")),
+			PageToken:   ctx.String("page_token"),
+			IncludeFees: ctx.Bool("include_fees"),
 		},
 	)
 	if err != nil {
@@ -16,6 +16,7 @@ func watchtowerCommands() []cli.Command {
 			Category: "Watchtower",
 			Subcommands: []cli.Command{
 				towerInfoCommand,
+				towerClientsCommand,
 			},
 		},
 	}
@@ -54,3 +55,35 @@ func towerInfo(ctx *cli.Context) error {
 
 	return nil
 }
+
+var towerClientsCommand = cli.Command{
+	Name:  "clients",
+	Usage: "Lists the clients of the active watchtower.",
+	Description: `
+	Lists the clients of the active watchtower together with the number of
+	state updates and the amount of disk space that is used by each of
+	them. Since clients use a fresh key for every session they negotiate
+	with the tower, each session is listed as a separate client.
+	`,
+	Action: actionDecorator(towerClients),
+}
+
+func towerClients(ctx *cli.Context) error {
+	ctxc := getContext()
+	if ctx.NArg() != 0 || ctx.NumFlags() > 0 {
+		return cli.ShowCommandHelp(ctx, "clients")
+	}
+
+	client, cleanup := getWatchtowerClient(ctx)
+	defer cleanup()
+
+	req := &watchtowerrpc.ListClientsRequest{}
+	resp, err := client.ListClients(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
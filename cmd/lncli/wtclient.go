@@ -22,9 +22,11 @@ func wtclientCommands() []cli.Command {
 				addTowerCommand,
 				removeTowerCommand,
 				deactivateTowerCommand,
+				migrateSessionsCommand,
 				listTowersCommand,
 				getTowerCommand,
 				statsCommand,
+				subscribeHealthCommand,
 				policyCommand,
 				sessionCommands,
 			},
@@ -125,6 +127,66 @@ func deactivateTower(ctx *cli.Context) error {
 	return nil
 }
 
+var migrateSessionsCommand = cli.Command{
+	Name: "migrate",
+	Usage: "Back up the latest state of all channels to a newly added " +
+		"watchtower.",
+	Description: "Re-backs up the latest revoked state of all channels " +
+		"to the given watchtower, so that they are protected by it " +
+		"and not only the states created after it was added. The " +
+		"watchtower must have been added with `wtclient add` before. " +
+		"If --from is set, the sessions with the given watchtower are " +
+		"terminated and it is deactivated first.",
+	ArgsUsage: "pubkey",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "from",
+			Usage: "the public key of a watchtower that is " +
+				"decommissioned",
+		},
+	},
+	Action: actionDecorator(migrateSessions),
+}
+
+func migrateSessions(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if the number of arguments/flags
+	// is not what we expect.
+	if ctx.NArg() != 1 || ctx.NumFlags() > 1 {
+		return cli.ShowCommandHelp(ctx, "migrate")
+	}
+
+	toPubKey, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+
+	var fromPubKey []byte
+	if ctx.IsSet("from") {
+		fromPubKey, err = hex.DecodeString(ctx.String("from"))
+		if err != nil {
+			return fmt.Errorf("invalid from public key: %w", err)
+		}
+	}
+
+	client, cleanUp := getWtclient(ctx)
+	defer cleanUp()
+
+	req := &wtclientrpc.MigrateSessionsRequest{
+		ToPubkey:   toPubKey,
+		FromPubkey: fromPubKey,
+	}
+	resp, err := client.MigrateSessions(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var removeTowerCommand = cli.Command{
 	Name: "remove",
 	Usage: "Remove a watchtower to prevent its use for future " +
@@ -314,6 +376,47 @@ func stats(ctx *cli.Context) error {
 	return nil
 }
 
+var subscribeHealthCommand = cli.Command{
+	Name:  "subscribehealth",
+	Usage: "Subscribe to reachability changes of the watchtowers.",
+	Description: `
+	Prints an update every time a watchtower becomes reachable or
+	unreachable. The reachability of the towers is determined by the
+	outcome of the backups sent to them and, if enabled with
+	--wtclient.health-check-interval, periodic probes of all active
+	towers.
+	`,
+	Action: actionDecorator(subscribeHealth),
+}
+
+func subscribeHealth(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if the number of arguments/flags
+	// is not what we expect.
+	if ctx.NArg() > 0 || ctx.NumFlags() > 0 {
+		return cli.ShowCommandHelp(ctx, "subscribehealth")
+	}
+
+	client, cleanUp := getWtclient(ctx)
+	defer cleanUp()
+
+	req := &wtclientrpc.SubscribeTowerHealthRequest{}
+	stream, err := client.SubscribeTowerHealth(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		health, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(health)
+	}
+}
+
 var policyCommand = cli.Command{
 	Name:   "policy",
 	Usage:  "Display the active watchtower client policy configuration.",
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/jessevdk/go-flags"
+	"github.com/lightningnetwork/lnd/chainreg"
+	"github.com/lightningnetwork/lnd/lncfg"
+)
+
+const (
+	defaultRPCListen          = "localhost:10019"
+	defaultTLSCertFilename    = "tls.cert"
+	defaultTLSKeyFilename     = "tls.key"
+	defaultMacaroonFilename   = "signer.macaroon"
+	defaultRootKeyFilename    = "macaroon.key"
+	defaultWalletFilename     = "signer.wallet"
+	defaultTLSCertDuration    = 14 * 30 * 24 * time.Hour
+	defaultTLSCertOrg         = "lndsigner autogenerated cert"
+	defaultDebugLevel         = "info"
+	defaultNetwork            = "mainnet"
+	defaultDataDirPermissions = 0700
+)
+
+var (
+	defaultDataDir = btcutil.AppDataDir("lndsigner", false)
+
+	// netParams maps the supported network names to their parameters.
+	netParams = map[string]*chainreg.BitcoinNetParams{
+		"mainnet": &chainreg.BitcoinMainNetParams,
+		"testnet": &chainreg.BitcoinTestNetParams,
+		"regtest": &chainreg.BitcoinRegTestNetParams,
+		"simnet":  &chainreg.BitcoinSimNetParams,
+		"signet":  &chainreg.BitcoinSigNetParams,
+	}
+)
+
+// config is the configuration of the standalone remote signer.
+//
+//nolint:lll
+type config struct {
+	DataDir            string   `long:"datadir" description:"The directory to store the encrypted wallet, the TLS certificate, the macaroon and its root key in"`
+	Create             bool     `long:"create" description:"Create the wallet from the 24 word aezeed mnemonic of the wallet to sign for, which is read from the terminal together with the password to encrypt it with, and exit"`
+	WalletPasswordFile string   `long:"wallet-unlock-password-file" description:"The file that contains the password to decrypt the wallet with on startup; if not set, the password is read from the terminal"`
+	Network            string   `long:"network" description:"The network the wallet is used on" choice:"mainnet" choice:"testnet" choice:"regtest" choice:"simnet" choice:"signet"`
+	RPCListen          string   `long:"rpclisten" description:"The interface/port to listen for gRPC connections of the watch-only node on"`
+	TLSExtraIPs        []string `long:"tlsextraip" description:"Adds an extra IP to the generated certificate"`
+	TLSExtraDomains    []string `long:"tlsextradomain" description:"Adds an extra domain to the generated certificate"`
+	AllowedPaths       []string `long:"allowedpath" description:"A derivation path pattern the signer is allowed to sign with, for example m/84'/0'/*'/*/*, where * matches any index; can be specified multiple times; defaults to the paths of all keys lnd uses"`
+	ExportAccounts     string   `long:"exportaccounts" description:"Write the accounts of the wallet to the given file in the JSON format expected by 'lncli createwatchonly' and exit"`
+	DebugLevel         string   `long:"debuglevel" description:"Logging level for all subsystems" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error" choice:"critical"`
+
+	SignerPolicy *lncfg.SignerPolicy `group:"signerpolicy" namespace:"signerpolicy"`
+}
+
+// loadConfig parses the command line options and applies the defaults.
+func loadConfig() (*config, error) {
+	cfg := &config{
+		DataDir:      defaultDataDir,
+		Network:      defaultNetwork,
+		RPCListen:    defaultRPCListen,
+		DebugLevel:   defaultDebugLevel,
+		SignerPolicy: &lncfg.SignerPolicy{},
+	}
+
+	if _, err := flags.Parse(cfg); err != nil {
+		return nil, err
+	}
+
+	cfg.DataDir = lncfg.CleanAndExpandPath(cfg.DataDir)
+	cfg.WalletPasswordFile = lncfg.CleanAndExpandPath(
+		cfg.WalletPasswordFile,
+	)
+	cfg.ExportAccounts = lncfg.CleanAndExpandPath(cfg.ExportAccounts)
+
+	if err := cfg.SignerPolicy.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := os.MkdirAll(cfg.DataDir, defaultDataDirPermissions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create data directory: %w",
+			err)
+	}
+
+	return cfg, nil
+}
+
+// netParams returns the parameters of the configured network.
+func (c *config) netParams() *chainreg.BitcoinNetParams {
+	return netParams[c.Network]
+}
+
+// path returns the path of the file with the given name in the data
+// directory.
+func (c *config) path(fileName string) string {
+	return filepath.Join(c.DataDir, fileName)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// rootKeyLen is the length of the macaroon root key in bytes.
+	rootKeyLen = 32
+
+	// macaroonLocation is the location hint of the signer macaroon.
+	macaroonLocation = "lndsigner"
+)
+
+// loadRootKey reads the macaroon root key from the given file or creates a
+// new random one if the file doesn't exist yet.
+func loadRootKey(fileName string) ([]byte, error) {
+	rootKey, err := os.ReadFile(fileName)
+	switch {
+	case err == nil:
+		if len(rootKey) != rootKeyLen {
+			return nil, fmt.Errorf("invalid macaroon root key "+
+				"length %d in %s", len(rootKey), fileName)
+		}
+
+		return rootKey, nil
+
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	rootKey = make([]byte, rootKeyLen)
+	if _, err := rand.Read(rootKey); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(fileName, rootKey, 0600); err != nil {
+		return nil, err
+	}
+
+	return rootKey, nil
+}
+
+// writeMacaroon mints the macaroon the watch-only node authenticates with and
+// writes it to the given file, unless it already exists.
+func writeMacaroon(rootKey []byte, fileName string) error {
+	if lnrpc.FileExists(fileName) {
+		return nil
+	}
+
+	mac, err := macaroon.New(
+		rootKey, []byte("0"), macaroonLocation, macaroon.LatestVersion,
+	)
+	if err != nil {
+		return err
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, macBytes, 0600)
+}
+
+// checkMacaroon verifies the macaroon of an incoming request against the
+// root key. The signer only ever mints macaroons without any caveats, so any
+// caveat is rejected.
+func checkMacaroon(ctx context.Context, rootKey []byte) error {
+	macHex, err := macaroons.RawMacaroonFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	macBytes, err := hex.DecodeString(macHex)
+	if err != nil {
+		return err
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return err
+	}
+
+	rejectCaveats := func(caveat string) error {
+		return fmt.Errorf("unsupported caveat %q", caveat)
+	}
+
+	return mac.Verify(rootKey, rejectCaveats, nil)
+}
+
+// macaroonInterceptor returns a unary gRPC interceptor that rejects any
+// request without a valid macaroon.
+func macaroonInterceptor(rootKey []byte) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		if err := checkMacaroon(ctx, rootKey); err != nil {
+			return nil, status.Errorf(codes.Unauthenticated,
+				"invalid macaroon: %v", err)
+		}
+
+		return handler(ctx, req)
+	}
+}
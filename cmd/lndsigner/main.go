@@ -0,0 +1,248 @@
+// lndsigner is a standalone remote signer for a watch-only lnd node. It holds
+// nothing but the wallet seed and serves the signerrpc.RemoteSigner service,
+// signing only with keys whose derivation paths are explicitly allowed.
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btclog"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/jessevdk/go-flags"
+	"github.com/lightningnetwork/lnd/cert"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signerrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/seedsigner"
+	"github.com/lightningnetwork/lnd/lnwallet/signpolicy"
+	"github.com/lightningnetwork/lnd/signal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func main() {
+	if err := run(); err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			os.Exit(0)
+		}
+
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run starts the signer and blocks until it is shut down.
+func run() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	log, err := setupLogging(cfg.DebugLevel)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Create {
+		return createWallet(cfg)
+	}
+
+	params := cfg.netParams()
+	rootKey, err := loadRootKeyFromWallet(cfg)
+	if err != nil {
+		return err
+	}
+
+	signer, err := seedsigner.New(rootKey, params.CoinType)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ExportAccounts != "" {
+		return exportAccounts(
+			signer, params.CoinType, cfg.ExportAccounts,
+		)
+	}
+
+	allowedPaths := cfg.AllowedPaths
+	if len(allowedPaths) == 0 {
+		allowedPaths = signerrpc.DefaultAllowedPaths(params.CoinType)
+	}
+	policy, err := signerrpc.NewPathPolicy(params.CoinType, allowedPaths)
+	if err != nil {
+		return err
+	}
+
+	signPolicy, err := signpolicy.FromConfig(
+		cfg.SignerPolicy, signer.DeriveKeyByPath,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create signer policy: %w", err)
+	}
+
+	server, err := signerrpc.New(&signerrpc.Config{
+		Network:      params.Params.Name,
+		Policy:       policy,
+		PsbtSigner:   signer,
+		KeyRing:      signer,
+		MuSig2Signer: signer,
+		SignPolicy:   signPolicy,
+	})
+	if err != nil {
+		return err
+	}
+
+	macRootKey, err := loadRootKey(cfg.path(defaultRootKeyFilename))
+	if err != nil {
+		return fmt.Errorf("unable to load macaroon root key: %w", err)
+	}
+	err = writeMacaroon(macRootKey, cfg.path(defaultMacaroonFilename))
+	if err != nil {
+		return fmt.Errorf("unable to write macaroon: %w", err)
+	}
+
+	tlsCreds, err := loadTLSCredentials(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to load TLS credentials: %w", err)
+	}
+
+	interceptor, err := signal.Intercept()
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.Creds(tlsCreds),
+		grpc.UnaryInterceptor(macaroonInterceptor(macRootKey)),
+	)
+	signerrpc.RegisterRemoteSignerServer(grpcServer, server)
+
+	listener, err := net.Listen("tcp", cfg.RPCListen)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Remote signer for %s listening on %s, allowed paths: %s",
+		cfg.Network, listener.Addr(), strings.Join(allowedPaths, ", "))
+
+	go func() {
+		<-interceptor.ShutdownChannel()
+		grpcServer.GracefulStop()
+	}()
+
+	return grpcServer.Serve(listener)
+}
+
+// setupLogging initializes the loggers of all subsystems the signer uses and
+// returns the logger of the signer itself.
+func setupLogging(level string) (btclog.Logger, error) {
+	logLevel, ok := btclog.LevelFromString(level)
+	if !ok {
+		return nil, fmt.Errorf("invalid debug level %q", level)
+	}
+
+	backend := btclog.NewBackend(os.Stdout)
+	newLogger := func(subsystem string) btclog.Logger {
+		logger := backend.Logger(subsystem)
+		logger.SetLevel(logLevel)
+
+		return logger
+	}
+
+	signerrpc.UseLogger(newLogger(signerrpc.Subsystem))
+	signpolicy.UseLogger(newLogger(signpolicy.Subsystem))
+	btcwallet.UseLogger(newLogger("BTWL"))
+
+	return newLogger("LSGN"), nil
+}
+
+// loadTLSCredentials loads the TLS certificate and key from the data directory
+// or generates a new self-signed pair if they don't exist yet.
+func loadTLSCredentials(cfg *config) (credentials.TransportCredentials,
+	error) {
+
+	certPath := cfg.path(defaultTLSCertFilename)
+	keyPath := cfg.path(defaultTLSKeyFilename)
+	if !lnrpc.FileExists(certPath) || !lnrpc.FileExists(keyPath) {
+		certBytes, keyBytes, err := cert.GenCertPair(
+			defaultTLSCertOrg, cfg.TLSExtraIPs, cfg.TLSExtraDomains,
+			false, defaultTLSCertDuration,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cert.WriteCertPair(certPath, keyPath, certBytes, keyBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	certData, _, err := cert.LoadCert(certPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(cert.TLSConfFromCert(certData)), nil
+}
+
+// exportAccounts writes the extended public keys of all accounts a watch-only
+// lnd node needs to the given file, in the JSON format 'lncli createwatchonly'
+// expects.
+func exportAccounts(signer *seedsigner.Signer, coinType uint32,
+	fileName string) error {
+
+	fingerprint, err := signer.MasterKeyFingerprint()
+	if err != nil {
+		return err
+	}
+
+	var accounts []*walletrpc.Account
+	addAccount := func(purpose, coinType, account uint32) error {
+		xpub, err := signer.AccountPubKey(purpose, coinType, account)
+		if err != nil {
+			return err
+		}
+
+		accounts = append(accounts, &walletrpc.Account{
+			ExtendedPublicKey: xpub.String(),
+			DerivationPath: fmt.Sprintf("m/%d'/%d'/%d'", purpose,
+				coinType, account),
+			MasterKeyFingerprint: fingerprint,
+		})
+
+		return nil
+	}
+
+	// The default on-chain wallet accounts always use the coin type 0.
+	for _, scope := range []waddrmgr.KeyScope{
+		waddrmgr.KeyScopeBIP0049Plus, waddrmgr.KeyScopeBIP0084,
+		waddrmgr.KeyScopeBIP0086,
+	} {
+		if err := addAccount(scope.Purpose, scope.Coin, 0); err != nil {
+			return err
+		}
+	}
+
+	// Each of lnd's internal key families is an account of its own.
+	for family := uint32(0); family <= 255; family++ {
+		err := addAccount(keychain.BIP0043Purpose, coinType, family)
+		if err != nil {
+			return err
+		}
+	}
+
+	jsonBytes, err := lnrpc.ProtoJSONMarshalOpts.Marshal(
+		&walletrpc.ListAccountsResponse{Accounts: accounts},
+	)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, jsonBytes, 0600)
+}
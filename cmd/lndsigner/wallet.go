@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcwallet/snacl"
+	"github.com/lightningnetwork/lnd/aezeed"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"golang.org/x/term"
+)
+
+const (
+	// minPasswordLength is the minimum length of the password the wallet
+	// is encrypted with, the same that lnd requires for its wallet.
+	minPasswordLength = 8
+
+	// walletFilePermissions are the permissions the wallet file is created
+	// with.
+	walletFilePermissions = 0600
+
+	// encryptionParamsSize is the size of the serialized parameters that
+	// derive the encryption key from the wallet password.
+	encryptionParamsSize = snacl.KeySize + sha256.Size + 24
+)
+
+// createWallet reads the aezeed mnemonic of the wallet to sign for, its
+// optional passphrase and a new wallet password from the terminal. The seed is
+// then stored in the wallet file, encrypted with the password, so it never
+// needs to be kept in plaintext.
+func createWallet(cfg *config) error {
+	walletPath := cfg.path(defaultWalletFilename)
+	if lnrpc.FileExists(walletPath) {
+		return fmt.Errorf("wallet %v already exists", walletPath)
+	}
+
+	fmt.Printf("Input your 24-word mnemonic separated by spaces: ")
+	reader := bufio.NewReader(os.Stdin)
+	mnemonicStr, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	words := strings.Fields(strings.ToLower(mnemonicStr))
+	var mnemonic aezeed.Mnemonic
+	if len(words) != len(mnemonic) {
+		return fmt.Errorf("mnemonic must contain %d words, found %d",
+			len(mnemonic), len(words))
+	}
+	copy(mnemonic[:], words)
+
+	passphrase, err := readPassword("Input your cipher seed passphrase " +
+		"(press enter if your seed doesn't have a passphrase): ")
+	if err != nil {
+		return err
+	}
+
+	cipherSeed, err := mnemonic.ToCipherSeed(passphrase)
+	if err != nil {
+		return fmt.Errorf("unable to decipher seed: %w", err)
+	}
+
+	password, err := readPassword("Input wallet password: ")
+	if err != nil {
+		return err
+	}
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("password must have at least %d characters",
+			minPasswordLength)
+	}
+	confirmed, err := readPassword("Confirm password: ")
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(password, confirmed) {
+		return errors.New("passwords don't match")
+	}
+
+	secretKey, err := snacl.NewSecretKey(
+		&password, snacl.DefaultN, snacl.DefaultR, snacl.DefaultP,
+	)
+	if err != nil {
+		return err
+	}
+	defer secretKey.Zero()
+
+	encryptedSeed, err := secretKey.Encrypt(cipherSeed.Entropy[:])
+	if err != nil {
+		return err
+	}
+
+	walletBytes := append(secretKey.Marshal(), encryptedSeed...)
+	err = os.WriteFile(walletPath, walletBytes, walletFilePermissions)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wallet created at %v\n", walletPath)
+
+	return nil
+}
+
+// loadRootKeyFromWallet decrypts the seed stored in the wallet file with the
+// wallet password and returns the extended master root key of the wallet. The
+// password is read from the password file if one is configured, otherwise
+// from the terminal.
+func loadRootKeyFromWallet(cfg *config) (*hdkeychain.ExtendedKey, error) {
+	walletPath := cfg.path(defaultWalletFilename)
+	walletBytes, err := os.ReadFile(walletPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read wallet, create it with "+
+			"--create first: %w", err)
+	}
+	if len(walletBytes) <= encryptionParamsSize {
+		return nil, fmt.Errorf("wallet %v is malformed", walletPath)
+	}
+
+	var password []byte
+	if cfg.WalletPasswordFile != "" {
+		password, err = os.ReadFile(cfg.WalletPasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read wallet "+
+				"password file: %w", err)
+		}
+		password = bytes.TrimSpace(password)
+	} else {
+		password, err = readPassword("Input wallet password: ")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var secretKey snacl.SecretKey
+	err = secretKey.Unmarshal(walletBytes[:encryptionParamsSize])
+	if err != nil {
+		return nil, err
+	}
+	if err := secretKey.DeriveKey(&password); err != nil {
+		return nil, fmt.Errorf("unable to unlock wallet: %w", err)
+	}
+	defer secretKey.Zero()
+
+	entropy, err := secretKey.Decrypt(walletBytes[encryptionParamsSize:])
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt seed: %w", err)
+	}
+
+	return hdkeychain.NewMaster(entropy, cfg.netParams().Params)
+}
+
+// readPassword reads a password from the terminal. This requires there to be an
+// actual TTY so passing in a password from stdin won't work.
+func readPassword(text string) ([]byte, error) {
+	fmt.Print(text)
+
+	// The variable syscall.Stdin is of a different type in the Windows API
+	// that's why we need the explicit cast. And of course the linter
+	// doesn't like it either.
+	pw, err := term.ReadPassword(int(syscall.Stdin)) // nolint:unconvert
+	fmt.Println()
+	return pw, err
+}
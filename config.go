@@ -35,11 +35,13 @@ import (
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/accountingrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/peersrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/netann"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/signal"
 	"github.com/lightningnetwork/lnd/tor"
@@ -63,12 +65,16 @@ const (
 	defaultPeerPort           = 9735
 	defaultRPCHost            = "localhost"
 
+	defaultLNCPairingPhraseFilename = "lnc-pairing-phrase"
+
 	defaultNoSeedBackup                  = false
 	defaultPaymentsExpirationGracePeriod = time.Duration(0)
 	defaultTrickleDelay                  = 90 * 1000
 	defaultChanStatusSampleInterval      = time.Minute
 	defaultChanEnableTimeout             = 19 * time.Minute
 	defaultChanDisableTimeout            = 20 * time.Minute
+	defaultChanFailureReenableTimeout    = 10 * time.Minute
+	defaultChanFailureReenableMaxTimeout = 4 * time.Hour
 	defaultHeightHintCacheQueryDisable   = false
 	defaultMaxLogFiles                   = 3
 	defaultMaxLogFileSize                = 10
@@ -88,6 +94,11 @@ const (
 	// both the block and tx ZMQ subscriptions.
 	defaultZMQReadDeadline = 5 * time.Second
 
+	// defaultFailoverCheckInterval is the default interval in which the
+	// health of the bitcoind nodes is checked if failover nodes are
+	// configured.
+	defaultFailoverCheckInterval = lncfg.DefaultFailoverCheckInterval
+
 	// DefaultAutogenValidity is the default validity of a self-signed
 	// certificate. The value corresponds to 14 months
 	// (14 months * 30 days * 24 hours).
@@ -306,6 +317,7 @@ type Config struct {
 	MaxLogFiles     int           `long:"maxlogfiles" description:"Maximum logfiles to keep (0 for no rotation)"`
 	MaxLogFileSize  int           `long:"maxlogfilesize" description:"Maximum logfile size in MB"`
 	AcceptorTimeout time.Duration `long:"acceptortimeout" description:"Time after which an RPCAcceptor will time out and return false if it hasn't yet received a response"`
+	AcceptorPolicy  bool          `long:"acceptorpolicy" description:"If true, inbound channel requests are checked against the channel acceptor policy set with SetChannelAcceptorPolicy"`
 
 	LetsEncryptDir    string `long:"letsencryptdir" description:"The directory to store Let's Encrypt certificates within"`
 	LetsEncryptListen string `long:"letsencryptlisten" description:"The IP:port on which lnd will listen for Let's Encrypt challenges. Let's Encrypt will always try to contact on port 80. Often non-root processes are not allowed to bind to ports lower than 1024. This configuration option allows a different port to be used, but must be used in combination with port forwarding from port 80. This configuration can also be used to specify another IP address to listen on, for example an IPv6 address."`
@@ -377,11 +389,17 @@ type Config struct {
 
 	CoinSelectionStrategy string `long:"coin-selection-strategy" description:"The strategy to use for selecting coins for wallet transactions." choice:"largest" choice:"random"`
 
+	RequiredReservePerChannel uint64 `long:"required-reserve-per-channel" description:"The amount in satoshis that is kept in the wallet for each public anchor channel to fee bump its commitment on force close."`
+	RequiredReserveMax        uint64 `long:"required-reserve-max" description:"The maximum amount in satoshis that is kept in the wallet to fee bump anchor channels, regardless of the number of channels. Must not be below required-reserve-per-channel."`
+
 	PaymentsExpirationGracePeriod time.Duration `long:"payments-expiration-grace-period" description:"A period to wait before force closing channels with outgoing htlcs that have timed-out and are a result of this node initiated payments."`
 	TrickleDelay                  int           `long:"trickledelay" description:"Time in milliseconds between each release of announcements to the network"`
 	ChanEnableTimeout             time.Duration `long:"chan-enable-timeout" description:"The duration that a peer connection must be stable before attempting to send a channel update to re-enable or cancel a pending disables of the peer's channels on the network."`
 	ChanDisableTimeout            time.Duration `long:"chan-disable-timeout" description:"The duration that must elapse after first detecting that an already active channel is actually inactive and sending channel update disabling it to the network. The pending disable can be canceled if the peer reconnects and becomes stable for chan-enable-timeout before the disable update is sent."`
 	ChanStatusSampleInterval      time.Duration `long:"chan-status-sample-interval" description:"The polling interval between attempts to detect if an active channel has become inactive due to its peer going offline."`
+	ChanDisableForwardingFailures uint32        `long:"chan-disable-forwarding-failures" description:"The number of consecutive local forwarding failures over a channel, e.g. because its peer is stuck, after which a channel update temporarily disabling the channel is sent to the network. Set to 0 to never disable channels due to forwarding failures."`
+	ChanFailureReenableTimeout    time.Duration `long:"chan-failure-reenable-timeout" description:"The duration a channel stays disabled after it was disabled due to forwarding failures. The duration is doubled each time the channel is disabled again without a successful forward in between."`
+	ChanFailureReenableMaxTimeout time.Duration `long:"chan-failure-reenable-max-timeout" description:"The maximum duration a channel stays disabled due to forwarding failures."`
 	HeightHintCacheQueryDisable   bool          `long:"height-hint-cache-query-disable" description:"Disable queries from the height-hint cache to try to recover channels stuck in the pending close state. Disabling height hint queries may cause longer chain rescans, resulting in a performance hit. Unset this after channels are unstuck so you can get better performance again."`
 	Alias                         string        `long:"alias" description:"The node alias. Used as a moniker by peers and intelligence services"`
 	Color                         string        `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
@@ -460,6 +478,8 @@ type Config struct {
 
 	Watchtower *lncfg.Watchtower `group:"watchtower" namespace:"watchtower"`
 
+	SCBReplication *lncfg.SCBReplication `group:"scbreplication" namespace:"scbreplication"`
+
 	ProtocolOptions *lncfg.ProtocolOptions `group:"protocol" namespace:"protocol"`
 
 	AllowCircularRoute bool `long:"allow-circular-route" description:"If true, our node will allow htlc forwards that arrive and depart on the same channel."`
@@ -472,12 +492,22 @@ type Config struct {
 
 	RPCMiddleware *lncfg.RPCMiddleware `group:"rpcmiddleware" namespace:"rpcmiddleware"`
 
+	LNC *lncfg.LNC `group:"lnc" namespace:"lnc"`
+
 	RemoteSigner *lncfg.RemoteSigner `group:"remotesigner" namespace:"remotesigner"`
 
+	SignerPolicy *lncfg.SignerPolicy `group:"signerpolicy" namespace:"signerpolicy"`
+
+	HWI *lncfg.HWI `group:"hwi" namespace:"hwi"`
+
 	Sweeper *lncfg.Sweeper `group:"sweeper" namespace:"sweeper"`
 
 	Htlcswitch *lncfg.Htlcswitch `group:"htlcswitch" namespace:"htlcswitch"`
 
+	Funding *lncfg.Funding `group:"funding" namespace:"funding"`
+
+	Logging *lncfg.Logging `group:"logging" namespace:"logging"`
+
 	GRPC *GRPCConfig `group:"grpc" namespace:"grpc"`
 
 	// LogWriter is the root logger that all of the daemon's subloggers are
@@ -573,10 +603,16 @@ func DefaultConfig() Config {
 			EstimateMode:       defaultBitcoindEstimateMode,
 			PrunedNodeMaxPeers: defaultPrunedNodeMaxPeers,
 			ZMQReadDeadline:    defaultZMQReadDeadline,
+
+			FailoverCheckInterval: defaultFailoverCheckInterval,
 		},
 		NeutrinoMode: &lncfg.Neutrino{
-			UserAgentName:    neutrino.UserAgentName,
-			UserAgentVersion: neutrino.UserAgentVersion,
+			UserAgentName:            neutrino.UserAgentName,
+			UserAgentVersion:         neutrino.UserAgentVersion,
+			GraphValidationWorkers:   lncfg.DefaultGraphValidationWorkers,
+			GraphValidationCacheSize: lncfg.DefaultGraphValidationCacheSize,
+			FeeFloor:                 lncfg.DefaultNeutrinoFeeFloor,
+			FeeWindow:                lncfg.DefaultNeutrinoFeeWindow,
 		},
 		BlockCacheSize:     defaultBlockCacheSize,
 		MaxPendingChannels: lncfg.DefaultMaxPendingChannels,
@@ -591,9 +627,10 @@ func DefaultConfig() Config {
 		},
 
 		SubRPCServers: &subRPCServerConfigs{
-			SignRPC:   &signrpc.Config{},
-			RouterRPC: routerrpc.DefaultConfig(),
-			PeersRPC:  &peersrpc.Config{},
+			SignRPC:       &signrpc.Config{},
+			RouterRPC:     routerrpc.DefaultConfig(),
+			PeersRPC:      &peersrpc.Config{},
+			AccountingRPC: &accountingrpc.Config{},
 		},
 		Autopilot: &lncfg.AutoPilot{
 			MaxChannels:    5,
@@ -611,6 +648,8 @@ func DefaultConfig() Config {
 		ChanStatusSampleInterval:      defaultChanStatusSampleInterval,
 		ChanEnableTimeout:             defaultChanEnableTimeout,
 		ChanDisableTimeout:            defaultChanDisableTimeout,
+		ChanFailureReenableTimeout:    defaultChanFailureReenableTimeout,
+		ChanFailureReenableMaxTimeout: defaultChanFailureReenableMaxTimeout,
 		HeightHintCacheQueryDisable:   defaultHeightHintCacheQueryDisable,
 		Alias:                         defaultAlias,
 		Color:                         defaultColor,
@@ -680,6 +719,12 @@ func DefaultConfig() Config {
 		Invoices: &lncfg.Invoices{
 			HoldExpiryDelta: lncfg.DefaultHoldInvoiceExpiryDelta,
 		},
+		Routing: &lncfg.Routing{
+			ZombieHorizon:      routing.DefaultChannelPruneExpiry,
+			GraphPruneInterval: routing.DefaultGraphPruneInterval,
+			MaxHtlcMinChange:   netann.DefaultMaxHTLCMinChange,
+			MaxHtlcInterval:    netann.DefaultMaxHTLCInterval,
+		},
 		MaxOutgoingCltvExpiry:     htlcswitch.DefaultMaxOutgoingCltvExpiry,
 		MaxChannelFeeAllocation:   htlcswitch.DefaultMaxLinkFeeAllocation,
 		MaxCommitFeeRateAnchors:   lnwallet.DefaultAnchorsCommitMaxFeeRateSatPerVByte,
@@ -688,25 +733,35 @@ func DefaultConfig() Config {
 		DB:                        lncfg.DefaultDB(),
 		Cluster:                   lncfg.DefaultCluster(),
 		RPCMiddleware:             lncfg.DefaultRPCMiddleware(),
+		LNC:                       lncfg.DefaultLNC(),
 		ActiveNetParams:           chainreg.BitcoinTestNetParams,
 		ChannelCommitInterval:     defaultChannelCommitInterval,
 		PendingCommitInterval:     defaultPendingCommitInterval,
 		ChannelCommitBatchSize:    defaultChannelCommitBatchSize,
 		CoinSelectionStrategy:     defaultCoinSelectionStrategy,
+		RequiredReservePerChannel: uint64(lnwallet.AnchorChanReservedValue),
+		RequiredReserveMax:        uint64(lnwallet.MaxAnchorChanReservedValue),
 		KeepFailedPaymentAttempts: defaultKeepFailedPaymentAttempts,
 		RemoteSigner: &lncfg.RemoteSigner{
 			Timeout: lncfg.DefaultRemoteSignerRPCTimeout,
 		},
-		Sweeper: lncfg.DefaultSweeperConfig(),
+		SignerPolicy: &lncfg.SignerPolicy{},
+		HWI:          lncfg.DefaultHWI(),
+		Sweeper:      lncfg.DefaultSweeperConfig(),
+		Logging:      lncfg.DefaultLogging(),
 		Htlcswitch: &lncfg.Htlcswitch{
 			MailboxDeliveryTimeout: htlcswitch.DefaultMailboxDeliveryTimeout,
+			ResolutionPeriod:       htlcswitch.DefaultResolutionPeriod,
+			GeneralSlots:           htlcswitch.DefaultGeneralSlots,
 		},
+		Funding: lncfg.DefaultFundingConfig(),
 		GRPC: &GRPCConfig{
 			ServerPingTime:    defaultGrpcServerPingTime,
 			ServerPingTimeout: defaultGrpcServerPingTimeout,
 			ClientPingMinWait: defaultGrpcClientPingMinWait,
 		},
 		WtClient:          lncfg.DefaultWtClientCfg(),
+		SCBReplication:    lncfg.DefaultSCBReplication(),
 		HTTPHeaderTimeout: DefaultHTTPHeaderTimeout,
 	}
 }
@@ -938,6 +993,9 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 	cfg.AdminMacPath = CleanAndExpandPath(cfg.AdminMacPath)
 	cfg.ReadMacPath = CleanAndExpandPath(cfg.ReadMacPath)
 	cfg.InvoiceMacPath = CleanAndExpandPath(cfg.InvoiceMacPath)
+	cfg.LNC.PairingPhraseFile = CleanAndExpandPath(
+		cfg.LNC.PairingPhraseFile,
+	)
 	cfg.LogDir = CleanAndExpandPath(cfg.LogDir)
 	cfg.BtcdMode.Dir = CleanAndExpandPath(cfg.BtcdMode.Dir)
 	cfg.BitcoindMode.Dir = CleanAndExpandPath(cfg.BitcoindMode.Dir)
@@ -949,6 +1007,12 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 	cfg.Tor.WatchtowerKeyPath = CleanAndExpandPath(cfg.Tor.WatchtowerKeyPath)
 	cfg.Watchtower.TowerDir = CleanAndExpandPath(cfg.Watchtower.TowerDir)
 	cfg.BackupFilePath = CleanAndExpandPath(cfg.BackupFilePath)
+	cfg.SCBReplication.SFTPPrivateKeyPath = CleanAndExpandPath(
+		cfg.SCBReplication.SFTPPrivateKeyPath,
+	)
+	cfg.SCBReplication.SFTPKnownHostsPath = CleanAndExpandPath(
+		cfg.SCBReplication.SFTPKnownHostsPath,
+	)
 	cfg.WalletUnlockPasswordFile = CleanAndExpandPath(
 		cfg.WalletUnlockPasswordFile,
 	)
@@ -1052,6 +1116,12 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 			cfg.MaxCommitFeeRateAnchors)
 	}
 
+	if cfg.RequiredReserveMax < cfg.RequiredReservePerChannel {
+		return nil, mkErr("invalid required reserve max: %v, must "+
+			"not be below required reserve per channel %v",
+			cfg.RequiredReserveMax, cfg.RequiredReservePerChannel)
+	}
+
 	// Validate the Tor config parameters.
 	socks, err := lncfg.ParseAddressString(
 		cfg.Tor.SOCKS, strconv.Itoa(defaultTorSOCKSPort),
@@ -1365,6 +1435,11 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 			cfg.networkDir, defaultInvoiceMacFilename,
 		)
 	}
+	if cfg.LNC.PairingPhraseFile == "" {
+		cfg.LNC.PairingPhraseFile = filepath.Join(
+			cfg.networkDir, defaultLNCPairingPhraseFilename,
+		)
+	}
 
 	towerDir := filepath.Join(
 		cfg.Watchtower.TowerDir, BitcoinChainName,
@@ -1417,8 +1492,33 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		os.Exit(0)
 	}
 
+	// Configure the output of the subsystem loggers before they are
+	// created.
+	sampleRates, err := cfg.Logging.SampleRates()
+	if err != nil {
+		return nil, &usageError{mkErr("error parsing logging: %v", err)}
+	}
+	cfg.LogWriter.SetLogConfig(&build.LogConfig{
+		JSON:        cfg.Logging.JSON,
+		SampleRates: sampleRates,
+	})
+
 	// Initialize logging at the default logging level.
 	SetupLoggers(cfg.LogWriter, interceptor)
+
+	// Only now that the subsystem loggers are registered can we make sure
+	// the sampled subsystems exist.
+	subLoggers := cfg.LogWriter.SubLoggers()
+	for subsystem := range sampleRates {
+		if _, ok := subLoggers[subsystem]; !ok {
+			str := "the sampled log subsystem [%v] is invalid -- " +
+				"supported subsystems are %v"
+			return nil, &usageError{mkErr(
+				str, subsystem,
+				cfg.LogWriter.SupportedSubsystems(),
+			)}
+		}
+	}
 	err = cfg.LogWriter.InitLogRotator(
 		filepath.Join(cfg.LogDir, defaultLogFilename),
 		cfg.MaxLogFileSize, cfg.MaxLogFiles,
@@ -1665,6 +1765,11 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 			lncfg.DefaultIncomingBroadcastDelta)
 	}
 
+	if cfg.Invoices.HoldExpiryGracePeriod < 0 {
+		return nil, mkErr("invoices.holdexpirygraceperiod must not be "+
+			"negative, got %v", cfg.Invoices.HoldExpiryGracePeriod)
+	}
+
 	// If the experimental protocol options specify any protocol messages
 	// that we want to handle as custom messages, set them now.
 	customMsg := cfg.ProtocolOptions.CustomMessageOverrides()
@@ -1680,18 +1785,34 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		cfg.Workers,
 		cfg.Caches,
 		cfg.WtClient,
+		cfg.SCBReplication,
 		cfg.DB,
 		cfg.Cluster,
 		cfg.HealthChecks,
 		cfg.RPCMiddleware,
+		cfg.LNC,
 		cfg.RemoteSigner,
+		cfg.SignerPolicy,
+		cfg.HWI,
 		cfg.Sweeper,
 		cfg.Htlcswitch,
+		cfg.Funding,
+		cfg.Routing,
+		cfg.NeutrinoMode,
+		cfg.BitcoindMode,
+		cfg.Fee,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// A watch-only wallet doesn't have the channel keys a hardware wallet
+	// setup still signs with locally.
+	if cfg.HWI.Active && cfg.RemoteSigner.Enable {
+		return nil, mkErr("hwi.active and remotesigner.enable are " +
+			"mutually exclusive, only one should be selected")
+	}
+
 	// Finally, ensure that the user's color is correctly formatted,
 	// otherwise the server will not be able to start after the unlocking
 	// the wallet.
@@ -1704,6 +1825,15 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 	return &cfg, nil
 }
 
+// anchorReservePolicy returns the configured policy that determines the amount
+// kept in the wallet to fee bump anchor channels.
+func (c *Config) anchorReservePolicy() *lnwallet.AnchorReservePolicy {
+	return &lnwallet.AnchorReservePolicy{
+		PerChannel: btcutil.Amount(c.RequiredReservePerChannel),
+		Max:        btcutil.Amount(c.RequiredReserveMax),
+	}
+}
+
 // graphDatabaseDir returns the default directory where the local bolt graph db
 // files are stored.
 func (c *Config) graphDatabaseDir() string {
@@ -40,8 +40,11 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/hwi"
 	"github.com/lightningnetwork/lnd/lnwallet/rpcwallet"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/monitoring"
+	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/rpcperms"
 	"github.com/lightningnetwork/lnd/signal"
 	"github.com/lightningnetwork/lnd/sqldb"
@@ -552,8 +555,10 @@ func (d *DefaultWalletImpl) BuildWalletConfig(ctx context.Context,
 		FeeURL:                      d.cfg.FeeURL,
 		Fee: &lncfg.Fee{
 			URL:              d.cfg.Fee.URL,
+			URLPubKey:        d.cfg.Fee.URLPubKey,
 			MinUpdateTimeout: d.cfg.Fee.MinUpdateTimeout,
 			MaxUpdateTimeout: d.cfg.Fee.MaxUpdateTimeout,
+			Sources:          d.cfg.Fee.Sources,
 		},
 		Dialer: func(addr string) (net.Conn, error) {
 			return d.cfg.net.Dial(
@@ -590,6 +595,21 @@ func (d *DefaultWalletImpl) BuildWalletConfig(ctx context.Context,
 		ChainSource:      partialChainControl.ChainSource,
 		WatchOnly:        d.watchOnly,
 		MigrateWatchOnly: d.migrateWatchOnly,
+		AnchorReserve:    d.cfg.anchorReservePolicy(),
+	}
+
+	// The on-chain funds can be held by a hardware wallet that is accessed
+	// through HWI.
+	if d.cfg.HWI.Active {
+		device, err := hwi.New(d.cfg.HWI, d.cfg.ActiveNetParams.Params)
+		if err != nil {
+			err := fmt.Errorf("unable to set up HWI: %w", err)
+			d.logger.Error(err)
+			return nil, nil, nil, err
+		}
+
+		walletConfig.ExternalSigner = device
+		walletConfig.ExternalSignerAccount = d.cfg.HWI.Account
 	}
 
 	// Parse coin selection strategy.
@@ -859,6 +879,16 @@ type DatabaseInstances struct {
 	// InvoiceDB is the database that stores information about invoices.
 	InvoiceDB invoices.InvoiceDB
 
+	// ForwardingLog is the store of the forwarding events of the switch.
+	// It is the native SQL forwarding log if the use-native-sql flag was
+	// set, and the kvdb forwarding log of the channel DB otherwise.
+	ForwardingLog channeldb.ForwardingEventStore
+
+	// MissionControlPairStore persists the pair history of mission
+	// control across restarts. It is only set if the use-native-sql flag
+	// was set.
+	MissionControlPairStore routing.MissionControlPairStore
+
 	// MacaroonDB is the database that stores macaroon root keys.
 	MacaroonDB kvdb.Backend
 
@@ -882,6 +912,21 @@ type DatabaseInstances struct {
 	// for native SQL queries for tables that already support it. This may
 	// be nil if the use-native-sql flag was not set.
 	NativeSQLStore *sqldb.BaseDB
+
+	// ChanStateSQLStore is the native SQL store the channel state is
+	// mirrored to in dual-write mode. This may be nil if the
+	// dual-write-channel-state flag was not set.
+	ChanStateSQLStore *channeldb.SQLChannelStore
+
+	// GraphSQLStore is the native SQL store the channel graph is mirrored
+	// to in dual-write mode. This may be nil if the dual-write-graph flag
+	// was not set.
+	GraphSQLStore *channeldb.SQLGraphStore
+
+	// Backup writes a consistent snapshot of the SQL databases to the
+	// given directory and returns the paths of the written files. This is
+	// nil if lnd doesn't run with a SQL database backend.
+	Backup func(ctx context.Context, destDir string) ([]string, error)
 }
 
 // DefaultDatabaseBuilder is a type that builds the default database backends
@@ -932,6 +977,21 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 			"backends: %v", err)
 	}
 
+	// If Prometheus monitoring is enabled, we record the latency of the
+	// transactions of the main databases. The graph and channel state DB
+	// instances are both created from the graph DB backend below.
+	if cfg.Prometheus.Enabled() {
+		databaseBackends.GraphDB = monitoring.InstrumentBackend(
+			"channeldb", databaseBackends.GraphDB,
+		)
+		databaseBackends.HeightHintDB = monitoring.InstrumentBackend(
+			"heighthint", databaseBackends.HeightHintDB,
+		)
+		databaseBackends.DecayedLogDB = monitoring.InstrumentBackend(
+			"decayedlog", databaseBackends.DecayedLogDB,
+		)
+	}
+
 	// With the full remote mode we made sure both the graph and channel
 	// state DB point to the same local or remote DB and the same namespace
 	// within that DB.
@@ -941,6 +1001,7 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 		DecayedLogDB:   databaseBackends.DecayedLogDB,
 		WalletDB:       databaseBackends.WalletDB,
 		NativeSQLStore: databaseBackends.NativeSQLStore,
+		Backup:         databaseBackends.Backup,
 	}
 	cleanUp := func() {
 		// We can just close the returned close functions directly. Even
@@ -1065,8 +1126,92 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 		dbs.InvoiceDB = invoices.NewSQLStore(
 			executor, clock.NewDefaultClock(),
 		)
+
+		fwdExecutor := sqldb.NewTransactionExecutor(
+			dbs.NativeSQLStore,
+			func(tx *sql.Tx) channeldb.SQLForwardingQueries {
+				return dbs.NativeSQLStore.WithTx(tx)
+			},
+		)
+		sqlFwdLog := channeldb.NewSQLForwardingLog(fwdExecutor)
+
+		// Copy the events of the KV forwarding log over, which is
+		// a no-op once the SQL forwarding log contains any events.
+		err = sqlFwdLog.MigrateFromKV(
+			ctx, dbs.ChanStateDB.ForwardingLog(),
+		)
+		if err != nil {
+			cleanUp()
+
+			err := fmt.Errorf("unable to migrate forwarding log "+
+				"to native SQL: %w", err)
+			d.logger.Error(err)
+
+			return nil, nil, err
+		}
+		dbs.ForwardingLog = sqlFwdLog
+
+		mcExecutor := sqldb.NewTransactionExecutor(
+			dbs.NativeSQLStore,
+			func(tx *sql.Tx) routing.SQLMissionControlQueries {
+				return dbs.NativeSQLStore.WithTx(tx)
+			},
+		)
+		mcPairStore := routing.NewSQLMissionControlPairStore(
+			mcExecutor,
+		)
+		dbs.MissionControlPairStore = mcPairStore
+
+		// In dual-write mode, all channel state writes are mirrored
+		// to the native SQL channel store while the KV store stays
+		// authoritative.
+		if d.cfg.DB.DualWriteChannelState {
+			chanExecutor := sqldb.NewTransactionExecutor(
+				dbs.NativeSQLStore,
+				func(tx *sql.Tx) channeldb.SQLChannelQueries {
+					return dbs.NativeSQLStore.WithTx(tx)
+				},
+			)
+
+			dbs.ChanStateSQLStore = channeldb.NewSQLChannelStore(
+				chanExecutor,
+			)
+			dbs.ChanStateDB.ChannelStateDB().SetChannelStateMirror(
+				dbs.ChanStateSQLStore,
+			)
+		}
+
+		// In dual-write mode, all graph writes are mirrored to the
+		// native SQL graph store while the KV graph stays
+		// authoritative.
+		if d.cfg.DB.DualWriteGraph {
+			graphExecutor := sqldb.NewTransactionExecutor(
+				dbs.NativeSQLStore,
+				func(tx *sql.Tx) channeldb.SQLGraphQueries {
+					return dbs.NativeSQLStore.WithTx(tx)
+				},
+			)
+			sqlGraph := channeldb.NewSQLGraphStore(graphExecutor)
+
+			// Copy the KV graph over, which is a no-op once the
+			// SQL graph contains any nodes.
+			graph := dbs.GraphDB.ChannelGraph()
+			if err := sqlGraph.MigrateFromKV(ctx, graph); err != nil {
+				cleanUp()
+
+				err := fmt.Errorf("unable to migrate channel "+
+					"graph to native SQL: %w", err)
+				d.logger.Error(err)
+
+				return nil, nil, err
+			}
+
+			graph.SetGraphMirror(sqlGraph)
+			dbs.GraphSQLStore = sqlGraph
+		}
 	} else {
 		dbs.InvoiceDB = dbs.GraphDB
+		dbs.ForwardingLog = dbs.ChanStateDB.ForwardingLog()
 	}
 
 	// Wrap the watchtower client DB and make sure we clean up.
@@ -1086,7 +1231,7 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 
 	// Wrap the watchtower server DB and make sure we clean up.
 	if cfg.Watchtower.Active {
-		dbs.TowerServerDB, err = wtdb.OpenTowerDB(
+		kvTowerDB, err := wtdb.OpenTowerDB(
 			databaseBackends.TowerServerDB,
 		)
 		if err != nil {
@@ -1097,6 +1242,36 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 			d.logger.Error(err)
 			return nil, nil, err
 		}
+		dbs.TowerServerDB = kvTowerDB
+
+		// With native SQL, the tower server state is stored in the
+		// SQL database instead.
+		if d.cfg.DB.UseNativeSQL {
+			towerExecutor := sqldb.NewTransactionExecutor(
+				dbs.NativeSQLStore,
+				func(tx *sql.Tx) wtdb.SQLTowerQueries {
+					return dbs.NativeSQLStore.WithTx(tx)
+				},
+			)
+			sqlTowerDB := wtdb.NewSQLTowerDB(
+				towerExecutor, clock.NewDefaultClock(),
+			)
+
+			// Copy the sessions of the KV tower DB over, which is
+			// a no-op once the SQL tower DB contains any
+			// sessions.
+			err := sqlTowerDB.MigrateFromKV(ctx, kvTowerDB)
+			if err != nil {
+				cleanUp()
+
+				err := fmt.Errorf("unable to migrate tower "+
+					"server DB to native SQL: %w", err)
+				d.logger.Error(err)
+
+				return nil, nil, err
+			}
+			dbs.TowerServerDB = sqlTowerDB
+		}
 	}
 
 	openTime := time.Since(startOpenTime)
@@ -1325,6 +1500,19 @@ func initNeutrinoBackend(ctx context.Context, cfg *Config, chainDir string,
 	// is the inverse of each other. Therefore both cannot be true. For
 	// every other case, the neutrino.validatechannels overwrites the
 	// routing.assumechanvalid value.
+	//
+	// The strict graph validation mode always validates channels, so it
+	// implies neutrino.validatechannels.
+	if cfg.NeutrinoMode.StrictGraphValidation {
+		if cfg.Routing.AssumeChannelValid {
+			return nil, nil, fmt.Errorf("can't set both " +
+				"neutrino.strictgraphvalidation and " +
+				"routing.assumechanvalid to true at the same " +
+				"time")
+		}
+
+		cfg.NeutrinoMode.ValidateChannels = true
+	}
 	if cfg.NeutrinoMode.ValidateChannels && cfg.Routing.AssumeChannelValid {
 		return nil, nil, fmt.Errorf("can't set both " +
 			"neutrino.validatechannels and routing." +
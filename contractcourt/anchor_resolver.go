@@ -149,6 +149,13 @@ func (c *anchorResolver) Resolve(_ bool) (ContractResolver, error) {
 			c.log.Warnf("our anchor spent by someone else")
 			outcome = channeldb.ResolverOutcomeUnclaimed
 
+		// Anchor was removed from the sweeper by the user, so we won't
+		// ever attempt to claim it.
+		case sweep.ErrInputRemoved:
+			c.log.Infof("anchor removed from sweeper, " +
+				"abandoning it")
+			outcome = channeldb.ResolverOutcomeAbandoned
+
 		// An unexpected error occurred.
 		default:
 			c.log.Errorf("unable to sweep anchor: %v", sweepRes.Err)
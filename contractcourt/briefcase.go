@@ -13,6 +13,7 @@ import (
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
 // ContractResolutions is a wrapper struct around the two forms of resolutions
@@ -118,6 +119,16 @@ type ArbitratorLog interface {
 	// introduced.
 	FetchChainActions() (ChainActionMap, error)
 
+	// LogForceCloseFeePreference stores the fee preference of a user
+	// requested force close, so it can be used to CPFP the commitment
+	// after a restart.
+	LogForceCloseFeePreference(ForceCloseFeePreference) error
+
+	// FetchForceCloseFeePreference fetches the previously stored fee
+	// preference of a user requested force close. The zero value is
+	// returned if no preference was stored.
+	FetchForceCloseFeePreference() (ForceCloseFeePreference, error)
+
 	// WipeHistory is to be called ONLY once *all* contracts have been
 	// fully resolved, and the channel closure if finalized. This method
 	// will delete all on-disk state within the persistent log.
@@ -366,6 +377,10 @@ var (
 	// taprootDataKey is the key we'll use to store taproot specific data
 	// for the set of channels we'll need to sweep/claim.
 	taprootDataKey = []byte("taproot-data")
+
+	// forceCloseFeePrefKey is the key under the logScope that we'll use
+	// to store the fee preference of a user requested force close.
+	forceCloseFeePrefKey = []byte("force-close-fee-pref")
 )
 
 var (
@@ -1109,6 +1124,62 @@ func (b *boltArbitratorLog) fetchConfirmedCommitSet(tx kvdb.RTx) (*CommitSet,
 	return decodeCommitSet(bytes.NewReader(commitSetBytes))
 }
 
+// LogForceCloseFeePreference stores the fee preference of a user requested
+// force close, so it can be used to CPFP the commitment after a restart.
+//
+// NOTE: Part of the ContractResolver interface.
+func (b *boltArbitratorLog) LogForceCloseFeePreference(
+	f ForceCloseFeePreference) error {
+
+	return kvdb.Update(b.db, func(tx kvdb.RwTx) error {
+		scopeBucket, err := tx.CreateTopLevelBucket(b.scopeKey[:])
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := encodeForceCloseFeePref(&b, f); err != nil {
+			return err
+		}
+
+		return scopeBucket.Put(forceCloseFeePrefKey, b.Bytes())
+	}, func() {})
+}
+
+// FetchForceCloseFeePreference fetches the previously stored fee preference of
+// a user requested force close. The zero value is returned if no preference
+// was stored.
+//
+// NOTE: Part of the ContractResolver interface.
+func (b *boltArbitratorLog) FetchForceCloseFeePreference() (
+	ForceCloseFeePreference, error) {
+
+	var f ForceCloseFeePreference
+	err := kvdb.View(b.db, func(tx kvdb.RTx) error {
+		scopeBucket := tx.ReadBucket(b.scopeKey[:])
+		if scopeBucket == nil {
+			return nil
+		}
+
+		feePrefBytes := scopeBucket.Get(forceCloseFeePrefKey)
+		if feePrefBytes == nil {
+			return nil
+		}
+
+		var err error
+		f, err = decodeForceCloseFeePref(bytes.NewReader(feePrefBytes))
+
+		return err
+	}, func() {
+		f = ForceCloseFeePreference{}
+	})
+	if err != nil {
+		return ForceCloseFeePreference{}, err
+	}
+
+	return f, nil
+}
+
 // WipeHistory is to be called ONLY once *all* contracts have been fully
 // resolved, and the channel closure if finalized. This method will delete all
 // on-disk state within the persistent log.
@@ -1546,6 +1617,41 @@ func decodeCommitSet(r io.Reader) (*CommitSet, error) {
 	return c, nil
 }
 
+func encodeForceCloseFeePref(w io.Writer, f ForceCloseFeePreference) error {
+	if err := binary.Write(w, endian, f.ConfTarget); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, endian, uint64(f.FeeRate)); err != nil {
+		return err
+	}
+
+	return binary.Write(w, endian, uint64(f.MaxFeeRate))
+}
+
+func decodeForceCloseFeePref(r io.Reader) (ForceCloseFeePreference, error) {
+	var (
+		f                   ForceCloseFeePreference
+		feeRate, maxFeeRate uint64
+	)
+	if err := binary.Read(r, endian, &f.ConfTarget); err != nil {
+		return f, err
+	}
+
+	if err := binary.Read(r, endian, &feeRate); err != nil {
+		return f, err
+	}
+
+	if err := binary.Read(r, endian, &maxFeeRate); err != nil {
+		return f, err
+	}
+
+	f.FeeRate = chainfee.SatPerKWeight(feeRate)
+	f.MaxFeeRate = chainfee.SatPerKWeight(maxFeeRate)
+
+	return f, nil
+}
+
 func encodeTaprootAuxData(w io.Writer, c *ContractResolutions) error {
 	tapCase := newTaprootBriefcase()
 
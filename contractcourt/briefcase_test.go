@@ -782,6 +782,42 @@ func TestCommitSetStorage(t *testing.T) {
 
 }
 
+// TestForceCloseFeePrefStorage tests that the fee preference of a user
+// requested force close is properly stored, and removed once the history is
+// wiped.
+func TestForceCloseFeePrefStorage(t *testing.T) {
+	t.Parallel()
+
+	testLog, err := newTestBoltArbLog(
+		t, testChainHash, testChanPoint1,
+	)
+	require.NoError(t, err, "unable to create test log")
+
+	// Without a stored preference, the zero value is returned.
+	feePref, err := testLog.FetchForceCloseFeePreference()
+	require.NoError(t, err)
+	require.True(t, feePref.IsEmpty())
+
+	expected := ForceCloseFeePreference{
+		ConfTarget: 6,
+		FeeRate:    2_500,
+		MaxFeeRate: 10_000,
+	}
+	require.NoError(t, testLog.LogForceCloseFeePreference(expected))
+
+	feePref, err = testLog.FetchForceCloseFeePreference()
+	require.NoError(t, err)
+	require.Equal(t, expected, feePref)
+
+	// Once the history is wiped, the preference is gone as well.
+	require.NoError(t, testLog.CommitState(StateFullyResolved))
+	require.NoError(t, testLog.WipeHistory())
+
+	feePref, err = testLog.FetchForceCloseFeePreference()
+	require.NoError(t, err)
+	require.True(t, feePref.IsEmpty())
+}
+
 func init() {
 	testSignDesc.KeyDesc.PubKey, _ = btcec.ParsePubKey(key1)
 
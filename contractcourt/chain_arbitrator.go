@@ -22,6 +22,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
 )
 
 // ErrChainArbExiting signals that the chain arbitrator is shutting down.
@@ -164,6 +165,12 @@ type ChainArbitratorConfig struct {
 	// will use to notify the ChannelNotifier about a newly closed channel.
 	NotifyClosedChannel func(wire.OutPoint)
 
+	// NotifyCloseConfirmed is a function closure that the ChainArbitrator
+	// will use to notify the ChannelNotifier about the confirmation of a
+	// closing transaction, along with the height at which our output of it
+	// matures.
+	NotifyCloseConfirmed func(wire.OutPoint, uint32)
+
 	// NotifyFullyResolvedChannel is a function closure that the
 	// ChainArbitrator will use to notify the ChannelNotifier about a newly
 	// resolved channel. The main difference to NotifyClosedChannel is that
@@ -355,6 +362,44 @@ func (a *arbChannel) ForceCloseChan() (*lnwallet.LocalForceCloseSummary, error)
 	return chanMachine.ForceClose()
 }
 
+// closeMaturityHeight returns the height at which our commitment output of the
+// closing transaction described by the summary can be swept. For closes that
+// don't time lock our output, the close height is returned.
+func closeMaturityHeight(channel *channeldb.OpenChannel,
+	summary *channeldb.ChannelCloseSummary) uint32 {
+
+	chanType := channel.ChanType
+	leased := chanType.HasLeaseExpiration() && channel.IsInitiator
+
+	var maturity uint32
+	switch summary.CloseType {
+	// Our output on our own commitment is delayed by our CSV delay. As the
+	// initiator of a leased channel, it is also locked until the lease
+	// expires.
+	case channeldb.LocalForceClose:
+		maturity = summary.CloseHeight +
+			uint32(channel.LocalChanCfg.CsvDelay)
+
+	// Our output on the remote commitment is delayed by one block for
+	// anchor channels, and until the lease expires for the initiator of a
+	// leased channel.
+	case channeldb.RemoteForceClose:
+		maturity = summary.CloseHeight
+		if chanType.HasAnchors() {
+			maturity++
+		}
+
+	default:
+		return summary.CloseHeight
+	}
+
+	if leased && channel.ThawHeight > maturity {
+		maturity = channel.ThawHeight
+	}
+
+	return maturity
+}
+
 // newActiveChannelArbitrator creates a new instance of an active channel
 // arbitrator given the state of the target channel.
 func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
@@ -383,6 +428,10 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 				return err
 			}
 			c.cfg.NotifyClosedChannel(summary.ChanPoint)
+			c.cfg.NotifyCloseConfirmed(
+				summary.ChanPoint,
+				closeMaturityHeight(channel, summary),
+			)
 			return nil
 		},
 		IsPendingClose:        false,
@@ -406,6 +455,14 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 				channel.ShortChanID(), htlc,
 			)
 		},
+		FetchAnchorSweepPolicy: func() (channeldb.AnchorSweepPolicy,
+			error) {
+
+			chanStateDB := c.chanSource.ChannelStateDB()
+			return chanStateDB.FetchAnchorSweepPolicy(
+				chanPoint, route.NewVertex(channel.IdentityPub),
+			)
+		},
 	}
 
 	// The final component needed is an arbitrator log that the arbitrator
@@ -631,6 +688,16 @@ func (c *ChainArbitrator) Start() error {
 					closeChanInfo.ShortChanID, htlc,
 				)
 			},
+			FetchAnchorSweepPolicy: func() (
+				channeldb.AnchorSweepPolicy, error) {
+
+				peer := route.NewVertex(closeChanInfo.RemotePub)
+				chanStateDB := c.chanSource.ChannelStateDB()
+
+				return chanStateDB.FetchAnchorSweepPolicy(
+					chanPoint, peer,
+				)
+			},
 		}
 		chanLog, err := newBoltArbitratorLog(
 			c.chanSource.Backend, arbCfg, c.cfg.ChainHash, chanPoint,
@@ -1090,15 +1157,46 @@ type forceCloseReq struct {
 	// closeTx is a channel that carries the transaction which ultimately
 	// closed out the channel.
 	closeTx chan *wire.MsgTx
+
+	// feePref is the fee preference used to CPFP the commitment
+	// transaction using its anchor.
+	feePref ForceCloseFeePreference
+}
+
+// ForceCloseFeePreference expresses how urgently the commitment transaction
+// of a force closed channel should confirm. It is used to CPFP the commitment
+// using its anchor output, so it has no effect on channels without anchors.
+// The zero value uses the deadline and budget derived from the HTLCs at stake.
+type ForceCloseFeePreference struct {
+	// ConfTarget is the number of blocks within which the commitment
+	// should confirm. If the HTLCs at stake require an earlier
+	// confirmation, their deadline is used instead.
+	ConfTarget uint32
+
+	// FeeRate is the fee rate the CPFP starts with.
+	FeeRate chainfee.SatPerKWeight
+
+	// MaxFeeRate is the fee rate of the commitment and its CPFP that is
+	// used to calculate the budget of the CPFP. If zero, a multiple of
+	// FeeRate is used.
+	MaxFeeRate chainfee.SatPerKWeight
+}
+
+// IsEmpty returns true if the fee preference doesn't override the defaults.
+func (f ForceCloseFeePreference) IsEmpty() bool {
+	return f == ForceCloseFeePreference{}
 }
 
 // ForceCloseContract attempts to force close the channel infield by the passed
 // channel point. A force close will immediately terminate the contract,
 // causing it to enter the resolution phase. If the force close was successful,
-// then the force close transaction itself will be returned.
+// then the force close transaction itself will be returned. The fee preference
+// determines how urgently the commitment is CPFPed using its anchor.
 //
 // TODO(roasbeef): just return the summary itself?
-func (c *ChainArbitrator) ForceCloseContract(chanPoint wire.OutPoint) (*wire.MsgTx, error) {
+func (c *ChainArbitrator) ForceCloseContract(chanPoint wire.OutPoint,
+	feePref ForceCloseFeePreference) (*wire.MsgTx, error) {
+
 	c.Lock()
 	arbitrator, ok := c.activeChannels[chanPoint]
 	c.Unlock()
@@ -1125,6 +1223,7 @@ func (c *ChainArbitrator) ForceCloseContract(chanPoint wire.OutPoint) (*wire.Msg
 	case arbitrator.forceCloseReqs <- &forceCloseReq{
 		errResp: errChan,
 		closeTx: respChan,
+		feePref: feePref,
 	}:
 	case <-c.quit:
 		return nil, ErrChainArbExiting
@@ -226,3 +226,88 @@ func TestResolveContract(t *testing.T) {
 	err = chainArb.ResolveContract(channel.FundingOutpoint)
 	require.NoError(t, err, "second resolve call shouldn't fail")
 }
+
+// TestCloseMaturityHeight tests that the maturity height of our commitment
+// output is derived correctly from the close type and channel type.
+func TestCloseMaturityHeight(t *testing.T) {
+	t.Parallel()
+
+	const (
+		closeHeight = 100
+		csvDelay    = 144
+		thawHeight  = 1000
+	)
+
+	testCases := []struct {
+		name      string
+		closeType channeldb.ClosureType
+		chanType  channeldb.ChannelType
+		initiator bool
+		expected  uint32
+	}{
+		{
+			name:      "cooperative close",
+			closeType: channeldb.CooperativeClose,
+			chanType:  channeldb.AnchorOutputsBit,
+			expected:  closeHeight,
+		},
+		{
+			name:      "local force close",
+			closeType: channeldb.LocalForceClose,
+			chanType:  channeldb.AnchorOutputsBit,
+			expected:  closeHeight + csvDelay,
+		},
+		{
+			name:      "remote force close legacy",
+			closeType: channeldb.RemoteForceClose,
+			expected:  closeHeight,
+		},
+		{
+			name:      "remote force close anchors",
+			closeType: channeldb.RemoteForceClose,
+			chanType:  channeldb.AnchorOutputsBit,
+			expected:  closeHeight + 1,
+		},
+		{
+			name:      "local force close leased initiator",
+			closeType: channeldb.LocalForceClose,
+			chanType: channeldb.AnchorOutputsBit |
+				channeldb.LeaseExpirationBit,
+			initiator: true,
+			expected:  thawHeight,
+		},
+		{
+			name:      "local force close leased responder",
+			closeType: channeldb.LocalForceClose,
+			chanType: channeldb.AnchorOutputsBit |
+				channeldb.LeaseExpirationBit,
+			expected: closeHeight + csvDelay,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			channel := &channeldb.OpenChannel{
+				ChanType:    tc.chanType,
+				IsInitiator: tc.initiator,
+				ThawHeight:  thawHeight,
+			}
+			channel.LocalChanCfg.CsvDelay = csvDelay
+
+			summary := &channeldb.ChannelCloseSummary{
+				CloseType:   tc.closeType,
+				CloseHeight: closeHeight,
+			}
+
+			require.Equal(
+				t, tc.expected, closeMaturityHeight(
+					channel, summary,
+				),
+			)
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/lightningnetwork/lnd/labels"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/sweep"
 )
@@ -40,6 +41,11 @@ const (
 	// arbitratorBlockBufferSize is the size of the buffer we give to each
 	// channel arbitrator.
 	arbitratorBlockBufferSize = 20
+
+	// defaultMaxFeeRateMultiplier is the multiplier applied to the fee
+	// rate of a user requested force close to derive the max fee rate of
+	// the anchor CPFP, if the user didn't specify one.
+	defaultMaxFeeRateMultiplier = 3
 )
 
 // WitnessSubscription represents an intent to be notified once new witnesses
@@ -172,6 +178,12 @@ type ChannelArbitratorConfig struct {
 	// spend his/her outgoing HTLC via the timeout path.
 	FindOutgoingHTLCDeadline func(htlc channeldb.HTLC) fn.Option[int32]
 
+	// FetchAnchorSweepPolicy returns the policy that overrides the
+	// deadline and the budget used to CPFP the commitment transaction of
+	// the channel by sweeping its anchor. If it's nil, the global budget
+	// config is used.
+	FetchAnchorSweepPolicy func() (channeldb.AnchorSweepPolicy, error)
+
 	ChainArbitratorConfig
 }
 
@@ -372,6 +384,11 @@ type ChannelArbitrator struct {
 	// contract will be sent over.
 	forceCloseReqs chan *forceCloseReq
 
+	// forceCloseFeePref is the fee preference of the last user requested
+	// force close. It's used to CPFP the commitment using its anchor, and
+	// is persisted in the arbitrator log so it survives restarts.
+	forceCloseFeePref ForceCloseFeePreference
+
 	// state is the current state of the arbitrator. This state is examined
 	// upon start up to decide which actions to take.
 	state ArbitratorState
@@ -473,6 +490,14 @@ func (c *ChannelArbitrator) Start(state *chanArbStartState) error {
 	// Set our state from our starting state.
 	c.state = state.currentState
 
+	// Restore the fee preference of a user requested force close, so the
+	// commitment is CPFPed the same way it was before the restart.
+	feePref, err := c.log.FetchForceCloseFeePreference()
+	if err != nil {
+		return err
+	}
+	c.forceCloseFeePref = feePref
+
 	_, bestHeight, err := c.cfg.ChainIO.GetBestBlock()
 	if err != nil {
 		return err
@@ -1309,6 +1334,22 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 	// anchors from being batched together.
 	exclusiveGroup := c.cfg.ShortChanID.ToUint64()
 
+	// The anchor sweep policy of the channel may override the deadline
+	// and the budget of the CPFP.
+	policy := c.anchorSweepPolicy()
+	budgetRatio := c.cfg.Budget.AnchorCPFPRatio
+	if policy.BudgetRatio != 0 {
+		budgetRatio = policy.BudgetRatio
+	}
+	maxBudget := c.cfg.Budget.AnchorCPFP
+	if policy.MaxBudget != 0 {
+		maxBudget = policy.MaxBudget
+	}
+
+	// The fee preference of a user requested force close may require the
+	// commitment to confirm sooner than the HTLCs at stake do.
+	feePref := c.forceCloseFeePref
+
 	// sweepWithDeadline is a helper closure that takes an anchor
 	// resolution and sweeps it with its corresponding deadline.
 	sweepWithDeadline := func(anchor *lnwallet.AnchorResolution,
@@ -1322,6 +1363,15 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 			return err
 		}
 
+		// If the user requested a confirmation target, the commitment
+		// must confirm within it even if no HTLCs are at stake.
+		if feePref.ConfTarget != 0 {
+			confTarget := int32(feePref.ConfTarget)
+			deadline = fn.Some(
+				min(deadline.UnwrapOr(confTarget), confTarget),
+			)
+		}
+
 		// If we cannot find a deadline, it means there's no HTLCs at
 		// stake, which means we can relax our anchor sweeping as we
 		// don't have any time sensitive outputs to sweep.
@@ -1332,6 +1382,25 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 			return nil
 		}
 
+		// If the value at stake is too small to justify a CPFP, we'll
+		// relax our anchor sweeping as well, unless the user asked for
+		// a specific urgency.
+		if value < policy.MinValueAtStake && feePref.IsEmpty() {
+			log.Infof("ChannelArbitrator(%v): value at stake %v "+
+				"below minimum %v, skipped anchor CPFP",
+				c.cfg.ChanPoint, value, policy.MinValueAtStake)
+
+			return nil
+		}
+
+		// Shorten the deadline if the policy requires the commitment
+		// to confirm sooner.
+		if policy.MaxDeadline != 0 {
+			deadline = fn.MapOption(func(d int32) int32 {
+				return min(d, int32(policy.MaxDeadline))
+			})(deadline)
+		}
+
 		witnessType := input.CommitmentAnchor
 
 		// For taproot channels, we need to use the proper witness
@@ -1367,10 +1436,15 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 		// Calculate the budget based on the value under protection,
 		// which is the sum of all HTLCs on this commitment subtracted
 		// by their budgets.
-		budget := calculateBudget(
-			value, c.cfg.Budget.AnchorCPFPRatio,
-			c.cfg.Budget.AnchorCPFP,
-		)
+		budget := calculateBudget(value, budgetRatio, maxBudget)
+
+		// Make sure the budget allows the CPFP to reach the fee rate
+		// requested by the user.
+		var startingFeeRate fn.Option[chainfee.SatPerKWeight]
+		if feePref.FeeRate != 0 {
+			startingFeeRate = fn.Some(feePref.FeeRate)
+			budget = max(budget, anchorCPFPBudget(anchor, feePref))
+		}
 
 		log.Infof("ChannelArbitrator(%v): offering anchor from %s "+
 			"commitment %v to sweeper with deadline=%v, budget=%v",
@@ -1385,9 +1459,10 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 		_, err = c.cfg.Sweeper.SweepInput(
 			&anchorInput,
 			sweep.Params{
-				ExclusiveGroup: &exclusiveGroup,
-				Budget:         budget,
-				DeadlineHeight: deadlineHeight,
+				ExclusiveGroup:  &exclusiveGroup,
+				Budget:          budget,
+				DeadlineHeight:  deadlineHeight,
+				StartingFeeRate: startingFeeRate,
 			},
 		)
 		if err != nil {
@@ -1434,6 +1509,70 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 	return nil
 }
 
+// anchorCPFPBudget returns the budget that is required to CPFP the commitment
+// of the given anchor resolution at the max fee rate of the fee preference. If
+// the preference doesn't specify a max fee rate, a multiple of its fee rate is
+// used to give the fee function room to bump the CPFP.
+func anchorCPFPBudget(anchor *lnwallet.AnchorResolution,
+	feePref ForceCloseFeePreference) btcutil.Amount {
+
+	maxFeeRate := feePref.MaxFeeRate
+	if maxFeeRate == 0 {
+		maxFeeRate = feePref.FeeRate * defaultMaxFeeRateMultiplier
+	}
+
+	// Estimate the weight of a CPFP that spends the anchor and a wallet
+	// utxo, and sends the change back to the wallet.
+	var estimator input.TxWeightEstimator
+	if txscript.IsPayToTaproot(
+		anchor.AnchorSignDescriptor.Output.PkScript,
+	) {
+
+		estimator.AddWitnessInput(input.TaprootAnchorWitnessSize)
+	} else {
+		estimator.AddWitnessInput(input.AnchorWitnessSize)
+	}
+	estimator.AddTaprootKeySpendInput(txscript.SigHashDefault)
+	estimator.AddP2TROutput()
+
+	// The CPFP needs to pay for the weight of the whole package, minus
+	// the fee that is already paid by the commitment.
+	packageWeight := estimator.Weight() + anchor.CommitWeight
+	budget := maxFeeRate.FeeForWeight(packageWeight) - anchor.CommitFee
+	if budget < 0 {
+		return 0
+	}
+
+	return budget
+}
+
+// anchorSweepPolicy returns the anchor sweep policy of the channel. An empty
+// policy, which doesn't override the global budget config, is returned if the
+// channel doesn't have a policy or it can't be fetched.
+func (c *ChannelArbitrator) anchorSweepPolicy() channeldb.AnchorSweepPolicy {
+	if c.cfg.FetchAnchorSweepPolicy == nil {
+		return channeldb.AnchorSweepPolicy{}
+	}
+
+	policy, err := c.cfg.FetchAnchorSweepPolicy()
+	switch {
+	case errors.Is(err, channeldb.ErrNoAnchorSweepPolicy):
+		return channeldb.AnchorSweepPolicy{}
+
+	case err != nil:
+		log.Errorf("ChannelArbitrator(%v): unable to fetch anchor "+
+			"sweep policy, using defaults: %v", c.cfg.ChanPoint,
+			err)
+
+		return channeldb.AnchorSweepPolicy{}
+	}
+
+	log.Debugf("ChannelArbitrator(%v): using anchor sweep policy %+v",
+		c.cfg.ChanPoint, policy)
+
+	return policy
+}
+
 // findCommitmentDeadlineAndValue finds the deadline (relative block height)
 // for a commitment transaction by extracting the minimum CLTV from its HTLCs.
 // From our PoV, the deadline delta is defined to be the smaller of,
@@ -3106,6 +3245,14 @@ func (c *ChannelArbitrator) channelAttendant(bestHeight int32) {
 				continue
 			}
 
+			feePref := closeReq.feePref
+			err := c.log.LogForceCloseFeePreference(feePref)
+			if err != nil {
+				log.Errorf("Unable to log force close fee "+
+					"preference: %v", err)
+			}
+			c.forceCloseFeePref = feePref
+
 			nextState, closeTx, err := c.advanceState(
 				uint32(bestHeight), userTrigger, nil,
 			)
@@ -45,6 +45,8 @@ type mockArbitratorLog struct {
 
 	commitSet *CommitSet
 
+	forceCloseFeePref ForceCloseFeePreference
+
 	sync.Mutex
 }
 
@@ -145,6 +147,19 @@ func (b *mockArbitratorLog) FetchConfirmedCommitSet(kvdb.RTx) (*CommitSet, error
 	return b.commitSet, nil
 }
 
+func (b *mockArbitratorLog) LogForceCloseFeePreference(
+	f ForceCloseFeePreference) error {
+
+	b.forceCloseFeePref = f
+	return nil
+}
+
+func (b *mockArbitratorLog) FetchForceCloseFeePreference() (
+	ForceCloseFeePreference, error) {
+
+	return b.forceCloseFeePref, nil
+}
+
 func (b *mockArbitratorLog) WipeHistory() error {
 	return nil
 }
@@ -2594,6 +2609,128 @@ func TestSweepAnchors(t *testing.T) {
 	)
 }
 
+// TestSweepAnchorsWithPolicy checks that the anchor sweep policy of a channel
+// overrides the deadline and the budget of the CPFP, and that the CPFP is
+// skipped if the value at stake is below the minimum of the policy.
+func TestSweepAnchorsWithPolicy(t *testing.T) {
+	log := &mockArbitratorLog{
+		state:     StateDefault,
+		newStates: make(chan ArbitratorState, 5),
+	}
+	chanArbCtx, err := createTestChannelArbitrator(t, log)
+	require.NoError(t, err, "unable to create ChannelArbitrator")
+
+	rHash := [lntypes.PreimageSize]byte{1, 2, 3}
+	mockPreimageDB := newMockWitnessBeacon()
+	mockPreimageDB.lookupPreimage[rHash] = rHash
+
+	chanArb := chanArbCtx.chanArb
+	chanArb.cfg.PreimageDB = mockPreimageDB
+	chanArb.cfg.Registry = &mockRegistry{}
+
+	heightHint := uint32(1000)
+	chanArbCtx.chanArb.blocks <- int32(heightHint)
+
+	// Add an incoming HTLC that we know the preimage of, which gives the
+	// local commitment a deadline of 10 blocks.
+	htlc := channeldb.HTLC{
+		HtlcIndex:     1,
+		RefundTimeout: heightHint + 20,
+		RHash:         rHash,
+		Amt:           lnwire.MilliSatoshi(10_000_000),
+	}
+	chanArb.activeHTLCs[LocalHtlcSet] = htlcSet{
+		incomingHTLCs: map[uint64]channeldb.HTLC{
+			htlc.HtlcIndex: htlc,
+		},
+	}
+	chanArb.unmergedSet[LocalHtlcSet] = chanArb.activeHTLCs[LocalHtlcSet]
+
+	anchors := &lnwallet.AnchorResolutions{
+		Local: &lnwallet.AnchorResolution{
+			AnchorSignDescriptor: input.SignDescriptor{
+				Output: &wire.TxOut{Value: 1},
+			},
+		},
+	}
+
+	// The policy shortens the deadline and caps the budget.
+	policy := channeldb.AnchorSweepPolicy{
+		MaxDeadline: 3,
+		BudgetRatio: 0.9,
+		MaxBudget:   1_000,
+	}
+	chanArb.cfg.FetchAnchorSweepPolicy = func() (
+		channeldb.AnchorSweepPolicy, error) {
+
+		return policy, nil
+	}
+
+	require.NoError(t, chanArb.sweepAnchors(anchors, heightHint))
+	require.Equal(
+		t, []int{int(heightHint) + 3}, chanArbCtx.sweeper.deadlines,
+	)
+	require.Equal(
+		t, []btcutil.Amount{1_000}, chanArbCtx.sweeper.budgets,
+	)
+
+	// If the value at stake is below the minimum of the policy, the
+	// anchor isn't swept.
+	policy = channeldb.AnchorSweepPolicy{MinValueAtStake: 100_000}
+	require.NoError(t, chanArb.sweepAnchors(anchors, heightHint))
+	require.Len(t, chanArbCtx.sweeper.budgets, 1)
+}
+
+// TestSweepAnchorsWithFeePreference asserts that the fee preference of a user
+// requested force close determines the deadline and the budget of the anchor
+// CPFP, even if no HTLCs are at stake.
+func TestSweepAnchorsWithFeePreference(t *testing.T) {
+	log := &mockArbitratorLog{
+		state:     StateDefault,
+		newStates: make(chan ArbitratorState, 5),
+	}
+	chanArbCtx, err := createTestChannelArbitrator(t, log)
+	require.NoError(t, err, "unable to create ChannelArbitrator")
+
+	chanArb := chanArbCtx.chanArb
+	heightHint := uint32(1000)
+
+	anchor := &lnwallet.AnchorResolution{
+		AnchorSignDescriptor: input.SignDescriptor{
+			Output: &wire.TxOut{Value: 1},
+		},
+		CommitFee:    1_000,
+		CommitWeight: 1_000,
+	}
+	anchors := &lnwallet.AnchorResolutions{Local: anchor}
+
+	// Without any HTLCs at stake and no fee preference, the anchor isn't
+	// used to CPFP the commitment.
+	require.NoError(t, chanArb.sweepAnchors(anchors, heightHint))
+	require.Empty(t, chanArbCtx.sweeper.budgets)
+
+	// With a fee preference, the anchor is swept using the requested
+	// confirmation target and a budget that covers the max fee rate.
+	chanArb.forceCloseFeePref = ForceCloseFeePreference{
+		ConfTarget: 6,
+		FeeRate:    2_500,
+	}
+	require.NoError(t, chanArb.sweepAnchors(anchors, heightHint))
+	require.Equal(
+		t, []int{int(heightHint) + 6}, chanArbCtx.sweeper.deadlines,
+	)
+
+	budget := anchorCPFPBudget(anchor, chanArb.forceCloseFeePref)
+	require.Greater(t, budget, btcutil.Amount(0))
+	require.Equal(t, []btcutil.Amount{budget}, chanArbCtx.sweeper.budgets)
+
+	// An explicit max fee rate results in a smaller budget than the
+	// default multiple of the fee rate.
+	maxFeePref := chanArb.forceCloseFeePref
+	maxFeePref.MaxFeeRate = 5_000
+	require.Less(t, anchorCPFPBudget(anchor, maxFeePref), budget)
+}
+
 // TestChannelArbitratorAnchors asserts that the commitment tx anchor is swept.
 func TestChannelArbitratorAnchors(t *testing.T) {
 	log := &mockArbitratorLog{
@@ -367,7 +367,7 @@ func (c *commitSweepResolver) Resolve(_ bool) (ContractResolver, error) {
 		return nil, err
 	}
 
-	var sweepTxID chainhash.Hash
+	var sweepTxID *chainhash.Hash
 
 	// Sweeper is going to join this input with other inputs if possible
 	// and publish the sweep tx. When the sweep tx confirms, it signals us
@@ -385,10 +385,24 @@ func (c *commitSweepResolver) Resolve(_ bool) (ContractResolver, error) {
 				"remote party via %v", sweepResult.Tx.TxHash())
 			outcome = channeldb.ResolverOutcomeUnclaimed
 
+			txid := sweepResult.Tx.TxHash()
+			sweepTxID = &txid
+
+		// If the output was removed from the sweeper by the user, we
+		// abandon it as it won't ever be swept.
+		case sweep.ErrInputRemoved:
+			c.log.Infof("local commitment output removed from " +
+				"sweeper, abandoning it")
+			outcome = channeldb.ResolverOutcomeAbandoned
+
 		// No errors, therefore continue processing.
 		case nil:
 			c.log.Infof("local commitment output fully resolved by "+
 				"sweep tx: %v", sweepResult.Tx.TxHash())
+
+			txid := sweepResult.Tx.TxHash()
+			sweepTxID = &txid
+
 		// Unknown errors.
 		default:
 			c.log.Errorf("unable to sweep input: %v",
@@ -397,8 +411,6 @@ func (c *commitSweepResolver) Resolve(_ bool) (ContractResolver, error) {
 			return nil, sweepResult.Err
 		}
 
-		sweepTxID = sweepResult.Tx.TxHash()
-
 	case <-c.quit:
 		return nil, errResolverShuttingDown
 	}
@@ -413,7 +425,7 @@ func (c *commitSweepResolver) Resolve(_ bool) (ContractResolver, error) {
 	c.currentReport.LimboBalance = 0
 	c.reportLock.Unlock()
 	report := c.currentReport.resolverReport(
-		&sweepTxID, channeldb.ResolverTypeCommit, outcome,
+		sweepTxID, channeldb.ResolverTypeCommit, outcome,
 	)
 	c.resolved = true
 
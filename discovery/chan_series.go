@@ -5,6 +5,7 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/channeldb/models"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/netann"
 	"github.com/lightningnetwork/lnd/routing"
@@ -124,6 +125,28 @@ func (c *ChanSeries) UpdatesInHorizon(chain chainhash.Hash,
 			continue
 		}
 
+		// Gossip v2 channels are sent along with their updates as is,
+		// as their fields were already validated when we received
+		// them.
+		if channel.Info.Version == lnwire.GossipVersion2 {
+			chanAnn, edge1, edge2, err := createChanAnnMsgs(
+				channel.Info, channel.Policy1, channel.Policy2,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			updates = append(updates, chanAnn)
+			if edge1 != nil {
+				updates = append(updates, edge1)
+			}
+			if edge2 != nil {
+				updates = append(updates, edge2)
+			}
+
+			continue
+		}
+
 		chanAnn, edge1, edge2, err := netann.CreateChanAnnouncement(
 			channel.Info.AuthProof, channel.Info, channel.Policy1,
 			channel.Policy2,
@@ -268,9 +291,8 @@ func (c *ChanSeries) FetchChanAnns(chain chainhash.Hash,
 			continue
 		}
 
-		chanAnn, edge1, edge2, err := netann.CreateChanAnnouncement(
-			channel.Info.AuthProof, channel.Info, channel.Policy1,
-			channel.Policy2,
+		chanAnn, edge1, edge2, err := createChanAnnMsgs(
+			channel.Info, channel.Policy1, channel.Policy2,
 		)
 		if err != nil {
 			return nil, err
@@ -335,7 +357,12 @@ func (c *ChanSeries) FetchChanUpdates(chain chainhash.Hash,
 		return nil, err
 	}
 
+	// Gossip v2 channels don't have any legacy channel updates.
 	chanUpdates := make([]*lnwire.ChannelUpdate, 0, 2)
+	if chanInfo.Version != lnwire.GossipVersion1 {
+		return chanUpdates, nil
+	}
+
 	if e1 != nil {
 		chanUpdate, err := netann.ChannelUpdateFromEdge(chanInfo, e1)
 		if err != nil {
@@ -356,6 +383,50 @@ func (c *ChanSeries) FetchChanUpdates(chain chainhash.Hash,
 	return chanUpdates, nil
 }
 
+// createChanAnnMsgs re-creates the channel announcement of the given channel
+// along with the channel updates of its known policies, using the messages of
+// the gossip version the channel was announced with. The returned updates are
+// nil if the corresponding policy is unknown.
+func createChanAnnMsgs(info *models.ChannelEdgeInfo,
+	e1, e2 *models.ChannelEdgePolicy) (lnwire.Message, lnwire.Message,
+	lnwire.Message, error) {
+
+	var edge1, edge2 lnwire.Message
+	if info.Version == lnwire.GossipVersion2 {
+		chanAnn, upd1, upd2, err := netann.CreateChanAnnouncement2(
+			info, e1, e2,
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if upd1 != nil {
+			edge1 = upd1
+		}
+		if upd2 != nil {
+			edge2 = upd2
+		}
+
+		return chanAnn, edge1, edge2, nil
+	}
+
+	chanAnn, upd1, upd2, err := netann.CreateChanAnnouncement(
+		info.AuthProof, info, e1, e2,
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if upd1 != nil {
+		edge1 = upd1
+	}
+	if upd2 != nil {
+		edge2 = upd2
+	}
+
+	return chanAnn, edge1, edge2, nil
+}
+
 // A compile-time assertion to ensure that ChanSeries meets the
 // ChannelGraphTimeSeries interface.
 var _ ChannelGraphTimeSeries = (*ChanSeries)(nil)
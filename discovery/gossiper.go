@@ -340,6 +340,11 @@ type Config struct {
 	// updates for a channel and returns true if the channel should be
 	// considered a zombie based on these timestamps.
 	IsStillZombieChannel func(time.Time, time.Time) bool
+
+	// PeerRateLimit is the maximum number of gossip messages per second
+	// that we send to a single peer in reply to its gossip queries and
+	// gossip timestamp filter. A value of zero disables the rate limit.
+	PeerRateLimit uint32
 }
 
 // processedNetworkMsg is a wrapper around networkMsg and a boolean. It is
@@ -525,6 +530,7 @@ func New(cfg Config, selfKeyDesc *keychain.KeyDescriptor) *AuthenticatedGossiper
 		BestHeight:              gossiper.latestHeight,
 		PinnedSyncers:           cfg.PinnedSyncers,
 		IsStillZombieChannel:    cfg.IsStillZombieChannel,
+		PeerRateLimit:           cfg.PeerRateLimit,
 	})
 
 	gossiper.reliableSender = newReliableSender(&reliableSenderCfg{
@@ -843,6 +849,19 @@ func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 			errChan <- ownErr
 			return errChan
 		}
+
+	case *lnwire.ChannelAnnouncement2:
+		ownKey := d.selfKey.SerializeCompressed()
+		ownErr := fmt.Errorf("ignoring remote ChannelAnnouncement2 " +
+			"for own channel")
+
+		if bytes.Equal(m.NodeID1[:], ownKey) ||
+			bytes.Equal(m.NodeID2[:], ownKey) {
+
+			log.Warn(ownErr)
+			errChan <- ownErr
+			return errChan
+		}
 	}
 
 	nMsg := &networkMsg{
@@ -952,6 +971,11 @@ type deDupedAnnouncements struct {
 	// channelUpdates are identified by the channel update id field.
 	channelUpdates map[channelUpdateID]msgWithSenders
 
+	// channelUpdates2 holds the gossip v2 channel updates, which are
+	// identified by the channel update id field as well, but are ordered
+	// by their block height instead of their timestamp.
+	channelUpdates2 map[channelUpdateID]msgWithSenders
+
 	// nodeAnnouncements are identified by the Vertex field.
 	nodeAnnouncements map[route.Vertex]msgWithSenders
 
@@ -975,6 +999,7 @@ func (d *deDupedAnnouncements) reset() {
 	// appropriate key points to the corresponding lnwire.Message.
 	d.channelAnnouncements = make(map[lnwire.ShortChannelID]msgWithSenders)
 	d.channelUpdates = make(map[channelUpdateID]msgWithSenders)
+	d.channelUpdates2 = make(map[channelUpdateID]msgWithSenders)
 	d.nodeAnnouncements = make(map[route.Vertex]msgWithSenders)
 }
 
@@ -994,26 +1019,10 @@ func (d *deDupedAnnouncements) addMsg(message networkMsg) {
 
 	// Channel announcements are identified by the short channel id field.
 	case *lnwire.ChannelAnnouncement:
-		deDupKey := msg.ShortChannelID
-		sender := route.NewVertex(message.source)
-
-		mws, ok := d.channelAnnouncements[deDupKey]
-		if !ok {
-			mws = msgWithSenders{
-				msg:     msg,
-				isLocal: !message.isRemote,
-				senders: make(map[route.Vertex]struct{}),
-			}
-			mws.senders[sender] = struct{}{}
+		d.addChanAnn(msg.ShortChannelID, message)
 
-			d.channelAnnouncements[deDupKey] = mws
-
-			return
-		}
-
-		mws.msg = msg
-		mws.senders[sender] = struct{}{}
-		d.channelAnnouncements[deDupKey] = mws
+	case *lnwire.ChannelAnnouncement2:
+		d.addChanAnn(msg.ShortChannelID, message)
 
 	// Channel updates are identified by the (short channel id,
 	// channelflags) tuple.
@@ -1067,6 +1076,47 @@ func (d *deDupedAnnouncements) addMsg(message networkMsg) {
 		mws.senders[sender] = struct{}{}
 		d.channelUpdates[deDupKey] = mws
 
+	// Gossip v2 channel updates are identified by the same tuple, with
+	// the direction taken from the second peer field.
+	case *lnwire.ChannelUpdate2:
+		sender := route.NewVertex(message.source)
+		deDupKey := channelUpdateID{
+			msg.ShortChannelID,
+			msg.Direction(),
+		}
+
+		// Discard the message if we already have one with a strictly
+		// newer block height.
+		mws, ok := d.channelUpdates2[deDupKey]
+		if ok {
+			oldUpd := mws.msg.(*lnwire.ChannelUpdate2)
+			if oldUpd.BlockHeight > msg.BlockHeight {
+				log.Debugf("Ignored outdated network "+
+					"message: peer=%v, msg=%s",
+					message.peer, msg.MsgType())
+				return
+			}
+
+			// Add the sender to the map of senders if it's the
+			// same message we had.
+			if oldUpd.BlockHeight == msg.BlockHeight {
+				mws.msg = msg
+				mws.senders[sender] = struct{}{}
+				d.channelUpdates2[deDupKey] = mws
+
+				return
+			}
+		}
+
+		mws = msgWithSenders{
+			msg:     msg,
+			isLocal: !message.isRemote,
+			senders: make(map[route.Vertex]struct{}),
+		}
+		mws.senders[sender] = struct{}{}
+
+		d.channelUpdates2[deDupKey] = mws
+
 	// Node announcements are identified by the Vertex field.  Use the
 	// NodeID to create the corresponding Vertex.
 	case *lnwire.NodeAnnouncement:
@@ -1108,6 +1158,32 @@ func (d *deDupedAnnouncements) addMsg(message networkMsg) {
 	}
 }
 
+// addChanAnn adds the channel announcement of the given network message,
+// which is identified by the given short channel ID, to the current batch.
+func (d *deDupedAnnouncements) addChanAnn(deDupKey lnwire.ShortChannelID,
+	message networkMsg) {
+
+	sender := route.NewVertex(message.source)
+
+	mws, ok := d.channelAnnouncements[deDupKey]
+	if !ok {
+		mws = msgWithSenders{
+			msg:     message.msg,
+			isLocal: !message.isRemote,
+			senders: make(map[route.Vertex]struct{}),
+		}
+		mws.senders[sender] = struct{}{}
+
+		d.channelAnnouncements[deDupKey] = mws
+
+		return
+	}
+
+	mws.msg = message.msg
+	mws.senders[sender] = struct{}{}
+	d.channelAnnouncements[deDupKey] = mws
+}
+
 // AddMsgs is a helper method to add multiple messages to the announcement
 // batch.
 func (d *deDupedAnnouncements) AddMsgs(msgs ...networkMsg) {
@@ -1162,7 +1238,7 @@ func (d *deDupedAnnouncements) Emit() msgsToBroadcast {
 
 	// Get the total number of announcements.
 	numAnnouncements := len(d.channelAnnouncements) + len(d.channelUpdates) +
-		len(d.nodeAnnouncements)
+		len(d.channelUpdates2) + len(d.nodeAnnouncements)
 
 	// Create an empty array of lnwire.Messages with a length equal to
 	// the total number of announcements.
@@ -1180,6 +1256,9 @@ func (d *deDupedAnnouncements) Emit() msgsToBroadcast {
 	for _, message := range d.channelUpdates {
 		msgs.addMsg(message)
 	}
+	for _, message := range d.channelUpdates2 {
+		msgs.addMsg(message)
+	}
 
 	// Finally add the node announcements.
 	for _, message := range d.nodeAnnouncements {
@@ -1570,6 +1649,12 @@ func (d *AuthenticatedGossiper) isRecentlyRejectedMsg(msg lnwire.Message,
 	case *lnwire.ChannelAnnouncement:
 		scid = m.ShortChannelID.ToUint64()
 
+	case *lnwire.ChannelUpdate2:
+		scid = m.ShortChannelID.ToUint64()
+
+	case *lnwire.ChannelAnnouncement2:
+		scid = m.ShortChannelID.ToUint64()
+
 	default:
 		return false
 	}
@@ -2020,6 +2105,15 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(
 	case *lnwire.ChannelUpdate:
 		return d.handleChanUpdate(nMsg, msg, schedulerOp)
 
+	// A new gossip v2 channel announcement or channel update has arrived,
+	// which is handled in the same way as its legacy counterpart, but
+	// authenticated with a Schnorr signature.
+	case *lnwire.ChannelAnnouncement2:
+		return d.handleChanAnnouncement2(nMsg, msg, schedulerOp)
+
+	case *lnwire.ChannelUpdate2:
+		return d.handleChanUpdate2(nMsg, msg, schedulerOp)
+
 	// A new signature announcement has been received. This indicates
 	// willingness of nodes involved in the funding of a channel to
 	// announce this new channel to the rest of the world.
@@ -2586,7 +2680,34 @@ func (d *AuthenticatedGossiper) handleChanAnnouncement(nMsg *networkMsg,
 
 	// If we earlier received any ChannelUpdates for this channel, we can
 	// now process them, as the channel is added to the graph.
-	shortChanID := ann.ShortChannelID.ToUint64()
+	d.reprocessPrematureUpdates(ann.ShortChannelID.ToUint64())
+
+	// Channel announcement was successfully processed and now it might be
+	// broadcast to other connected nodes if it was an announcement with
+	// proof (remote).
+	var announcements []networkMsg
+
+	if proof != nil {
+		announcements = append(announcements, networkMsg{
+			peer:     nMsg.peer,
+			isRemote: nMsg.isRemote,
+			source:   nMsg.source,
+			msg:      ann,
+		})
+	}
+
+	nMsg.err <- nil
+
+	log.Debugf("Processed ChannelAnnouncement: peer=%v, short_chan_id=%v",
+		nMsg.peer, ann.ShortChannelID.ToUint64())
+
+	return announcements, true
+}
+
+// reprocessPrematureUpdates sends any channel updates for the given channel
+// that were received before its channel announcement back into the gossiper
+// to be processed, now that the channel has been added to the graph.
+func (d *AuthenticatedGossiper) reprocessPrematureUpdates(shortChanID uint64) {
 	var channelUpdates []*processedNetworkMsg
 
 	earlyChanUpdates, err := d.prematureChannelUpdates.Get(shortChanID)
@@ -2620,10 +2741,9 @@ func (d *AuthenticatedGossiper) handleChanAnnouncement(nMsg *networkMsg,
 			// Reprocess the message, making sure we return an
 			// error to the original caller in case the gossiper
 			// shuts down.
-			case *lnwire.ChannelUpdate:
-				log.Debugf("Reprocessing ChannelUpdate for "+
-					"shortChanID=%v",
-					msg.ShortChannelID.ToUint64())
+			case *lnwire.ChannelUpdate, *lnwire.ChannelUpdate2:
+				log.Debugf("Reprocessing %v for shortChanID=%v",
+					msg.MsgType(), shortChanID)
 
 				select {
 				case d.networkMsgs <- updMsg:
@@ -2632,34 +2752,43 @@ func (d *AuthenticatedGossiper) handleChanAnnouncement(nMsg *networkMsg,
 				}
 
 			// We don't expect any other message type than
-			// ChannelUpdate to be in this cache.
+			// ChannelUpdate and ChannelUpdate2 to be in this
+			// cache.
 			default:
 				log.Errorf("Unsupported message type found "+
 					"among ChannelUpdates: %T", msg)
 			}
 		}(cu.msg)
 	}
+}
 
-	// Channel announcement was successfully processed and now it might be
-	// broadcast to other connected nodes if it was an announcement with
-	// proof (remote).
-	var announcements []networkMsg
-
-	if proof != nil {
-		announcements = append(announcements, networkMsg{
-			peer:     nMsg.peer,
-			isRemote: nMsg.isRemote,
-			source:   nMsg.source,
-			msg:      ann,
-		})
-	}
+// addPrematureUpdate stashes the given channel update for a channel that
+// isn't yet known to our graph, so that it can be reprocessed once the
+// channel announcement has been processed.
+func (d *AuthenticatedGossiper) addPrematureUpdate(shortChanID uint64,
+	nMsg *networkMsg) {
 
-	nMsg.err <- nil
+	pMsg := &processedNetworkMsg{msg: nMsg}
 
-	log.Debugf("Processed ChannelAnnouncement: peer=%v, short_chan_id=%v",
-		nMsg.peer, ann.ShortChannelID.ToUint64())
+	earlyMsgs, err := d.prematureChannelUpdates.Get(shortChanID)
+	switch {
+	// Nothing in the cache yet, we can just directly insert this element.
+	case err == cache.ErrElementNotFound:
+		_, _ = d.prematureChannelUpdates.Put(
+			shortChanID, &cachedNetworkMsg{
+				msgs: []*processedNetworkMsg{pMsg},
+			})
 
-	return announcements, true
+	// There's already something in the cache, so we'll combine the set of
+	// messages into a single value.
+	default:
+		msgs := earlyMsgs.msgs
+		msgs = append(msgs, pMsg)
+		_, _ = d.prematureChannelUpdates.Put(
+			shortChanID, &cachedNetworkMsg{
+				msgs: msgs,
+			})
+	}
 }
 
 // handleChanUpdate processes a new channel update.
@@ -2790,28 +2919,7 @@ func (d *AuthenticatedGossiper) handleChanUpdate(nMsg *networkMsg,
 		// since we don't have an edge in the graph and if the peer is
 		// not buggy, we should be able to use it once the gossiper
 		// receives the local announcement.
-		pMsg := &processedNetworkMsg{msg: nMsg}
-
-		earlyMsgs, err := d.prematureChannelUpdates.Get(shortChanID)
-		switch {
-		// Nothing in the cache yet, we can just directly insert this
-		// element.
-		case err == cache.ErrElementNotFound:
-			_, _ = d.prematureChannelUpdates.Put(
-				shortChanID, &cachedNetworkMsg{
-					msgs: []*processedNetworkMsg{pMsg},
-				})
-
-		// There's already something in the cache, so we'll combine the
-		// set of messages into a single value.
-		default:
-			msgs := earlyMsgs.msgs
-			msgs = append(msgs, pMsg)
-			_, _ = d.prematureChannelUpdates.Put(
-				shortChanID, &cachedNetworkMsg{
-					msgs: msgs,
-				})
-		}
+		d.addPrematureUpdate(shortChanID, nMsg)
 
 		log.Debugf("Got ChannelUpdate for edge not found in graph"+
 			"(shortChanID=%v), saving for reprocessing later",
@@ -2837,6 +2945,17 @@ func (d *AuthenticatedGossiper) handleChanUpdate(nMsg *networkMsg,
 		return nil, false
 	}
 
+	// Channels that were announced with gossip v2 can only be updated
+	// with a ChannelUpdate2.
+	if chanInfo.Version != lnwire.GossipVersion1 {
+		err := fmt.Errorf("ignoring ChannelUpdate for %v channel "+
+			"short_chan_id=%v", chanInfo.Version, shortChanID)
+		log.Debug(err)
+		nMsg.err <- err
+
+		return nil, false
+	}
+
 	// The least-significant bit in the flag on the channel update
 	// announcement tells us "which" side of the channels directed edge is
 	// being updated.
@@ -2900,21 +3019,7 @@ func (d *AuthenticatedGossiper) handleChanUpdate(nMsg *networkMsg,
 			// multiple aliases for a channel and we may otherwise
 			// rate-limit only a single alias of the channel,
 			// instead of the whole channel.
-			baseScid := chanInfo.ChannelID
-			d.Lock()
-			rls, ok := d.chanUpdateRateLimiter[baseScid]
-			if !ok {
-				r := rate.Every(d.cfg.ChannelUpdateInterval)
-				b := d.cfg.MaxChannelUpdateBurst
-				rls = [2]*rate.Limiter{
-					rate.NewLimiter(r, b),
-					rate.NewLimiter(r, b),
-				}
-				d.chanUpdateRateLimiter[baseScid] = rls
-			}
-			d.Unlock()
-
-			if !rls[direction].Allow() {
+			if !d.allowChanUpdate(chanInfo.ChannelID, direction) {
 				log.Debugf("Rate limiting update for channel "+
 					"%v from direction %x", shortChanID,
 					pubKey.SerializeCompressed())
@@ -3050,6 +3155,398 @@ func (d *AuthenticatedGossiper) handleChanUpdate(nMsg *networkMsg,
 	return announcements, true
 }
 
+// allowChanUpdate returns true if an update for the given direction of the
+// channel with the given ID is allowed by the rate limiter. We'll allow an
+// update per ChannelUpdateInterval with a maximum burst of
+// MaxChannelUpdateBurst.
+func (d *AuthenticatedGossiper) allowChanUpdate(chanID uint64,
+	direction lnwire.ChanUpdateChanFlags) bool {
+
+	d.Lock()
+	rls, ok := d.chanUpdateRateLimiter[chanID]
+	if !ok {
+		r := rate.Every(d.cfg.ChannelUpdateInterval)
+		b := d.cfg.MaxChannelUpdateBurst
+		rls = [2]*rate.Limiter{
+			rate.NewLimiter(r, b),
+			rate.NewLimiter(r, b),
+		}
+		d.chanUpdateRateLimiter[chanID] = rls
+	}
+	d.Unlock()
+
+	return rls[direction].Allow()
+}
+
+// handleChanAnnouncement2 processes a new gossip v2 channel announcement.
+// Since we don't announce our own channels with gossip v2 yet, these are
+// always remote announcements.
+func (d *AuthenticatedGossiper) handleChanAnnouncement2(nMsg *networkMsg,
+	ann *lnwire.ChannelAnnouncement2,
+	ops []batch.SchedulerOption) ([]networkMsg, bool) {
+
+	shortChanID := ann.ShortChannelID.ToUint64()
+
+	log.Debugf("Processing ChannelAnnouncement2: peer=%v, "+
+		"short_chan_id=%v", nMsg.peer, shortChanID)
+
+	rejectAnn := func(err error) ([]networkMsg, bool) {
+		log.Error(err)
+
+		key := newRejectCacheKey(shortChanID, sourceToPub(nMsg.source))
+		_, _ = d.recentRejects.Put(key, &cachedReject{})
+
+		nMsg.err <- err
+		return nil, false
+	}
+
+	// We'll ignore any channel announcements that target any chain other
+	// than the set of chains we know of.
+	if !bytes.Equal(ann.ChainHash[:], d.cfg.ChainHash[:]) {
+		return rejectAnn(fmt.Errorf("ignoring ChannelAnnouncement2 "+
+			"from chain=%v, gossiper on chain=%v", ann.ChainHash,
+			d.cfg.ChainHash))
+	}
+
+	// As the router accepts alias SCIDs, not erroring out here would be a
+	// DoS vector.
+	if d.cfg.IsAlias(ann.ShortChannelID) {
+		return rejectAnn(fmt.Errorf("ignoring remote alias channel=%v",
+			ann.ShortChannelID))
+	}
+
+	// If the advertised inclusionary block is beyond our knowledge of the
+	// chain tip, then we'll ignore it for now.
+	d.Lock()
+	if d.isPremature(ann.ShortChannelID, 0, nMsg) {
+		log.Warnf("Announcement for chan_id=(%v), is premature: "+
+			"advertises height %v, only height %v is known",
+			shortChanID, ann.ShortChannelID.BlockHeight,
+			d.bestHeight)
+		d.Unlock()
+		nMsg.err <- nil
+		return nil, false
+	}
+	d.Unlock()
+
+	// At this point, we'll now ask the router if this is a zombie/known
+	// edge. If so we can skip all the processing below.
+	if d.cfg.Router.IsKnownEdge(ann.ShortChannelID) {
+		nMsg.err <- nil
+		return nil, true
+	}
+
+	// We can only verify the funding output of channels that announce
+	// both bitcoin keys and don't commit to a script path, so we reject
+	// all other ones for now.
+	if ann.BitcoinKey1.IsNone() || ann.BitcoinKey2.IsNone() {
+		return rejectAnn(fmt.Errorf("ignoring ChannelAnnouncement2 "+
+			"without bitcoin keys for short_chan_id=%v",
+			shortChanID))
+	}
+	if ann.MerkleRootHash.IsSome() {
+		return rejectAnn(fmt.Errorf("ignoring ChannelAnnouncement2 "+
+			"with merkle root hash for short_chan_id=%v",
+			shortChanID))
+	}
+
+	if err := routing.ValidateChannelAnn2(ann); err != nil {
+		return rejectAnn(fmt.Errorf("unable to validate "+
+			"announcement: %w", err))
+	}
+
+	var featureBuf bytes.Buffer
+	if err := ann.Features.Encode(&featureBuf); err != nil {
+		log.Errorf("unable to encode features: %v", err)
+		nMsg.err <- err
+		return nil, false
+	}
+
+	edge := &models.ChannelEdgeInfo{
+		ChannelID:     shortChanID,
+		Version:       lnwire.GossipVersion2,
+		ChainHash:     ann.ChainHash,
+		NodeKey1Bytes: ann.NodeID1,
+		NodeKey2Bytes: ann.NodeID2,
+		AuthProof: &models.ChannelAuthProof{
+			Signature: ann.Signature.RawBytes(),
+		},
+		Features:        featureBuf.Bytes(),
+		Capacity:        btcutil.Amount(ann.Capacity),
+		ExtraOpaqueData: ann.ExtraOpaqueData,
+	}
+	edge.BitcoinKey1Bytes = ann.BitcoinKey1.UnwrapOr(edge.BitcoinKey1Bytes)
+	edge.BitcoinKey2Bytes = ann.BitcoinKey2.UnwrapOr(edge.BitcoinKey2Bytes)
+
+	log.Debugf("Adding v2 edge for short_chan_id: %v", shortChanID)
+
+	// Before we add the edge to the database, we obtain the mutex for this
+	// channel ID to ensure no other goroutine is making decisions based on
+	// the current DB state.
+	d.channelMtx.Lock(shortChanID)
+	err := d.cfg.Router.AddEdge(edge, ops...)
+	d.channelMtx.Unlock(shortChanID)
+	if err != nil {
+		log.Debugf("Router rejected v2 edge for short_chan_id(%v): %v",
+			shortChanID, err)
+
+		// An edge that is already known doesn't affect its dependants.
+		if routing.IsError(err, routing.ErrIgnored) {
+			nMsg.err <- nil
+			return nil, true
+		}
+
+		key := newRejectCacheKey(shortChanID, sourceToPub(nMsg.source))
+		_, _ = d.recentRejects.Put(key, &cachedReject{})
+
+		nMsg.err <- err
+		return nil, false
+	}
+
+	// If we earlier received any ChannelUpdate2 messages for this channel,
+	// we can now process them, as the channel is added to the graph.
+	d.reprocessPrematureUpdates(shortChanID)
+
+	nMsg.err <- nil
+
+	log.Debugf("Processed ChannelAnnouncement2: peer=%v, "+
+		"short_chan_id=%v", nMsg.peer, shortChanID)
+
+	return []networkMsg{{
+		peer:     nMsg.peer,
+		isRemote: nMsg.isRemote,
+		source:   nMsg.source,
+		msg:      ann,
+	}}, true
+}
+
+// handleChanUpdate2 processes a new gossip v2 channel update. Since we don't
+// announce our own channels with gossip v2 yet, these are always remote
+// updates.
+func (d *AuthenticatedGossiper) handleChanUpdate2(nMsg *networkMsg,
+	upd *lnwire.ChannelUpdate2,
+	ops []batch.SchedulerOption) ([]networkMsg, bool) {
+
+	shortChanID := upd.ShortChannelID.ToUint64()
+
+	log.Debugf("Processing ChannelUpdate2: peer=%v, short_chan_id=%v, "+
+		"block_height=%v", nMsg.peer, shortChanID, upd.BlockHeight)
+
+	rejectUpd := func(err error) ([]networkMsg, bool) {
+		log.Error(err)
+
+		key := newRejectCacheKey(shortChanID, sourceToPub(nMsg.source))
+		_, _ = d.recentRejects.Put(key, &cachedReject{})
+
+		nMsg.err <- err
+		return nil, false
+	}
+
+	// We'll ignore any channel updates that target any chain other than
+	// the set of chains we know of.
+	if !bytes.Equal(upd.ChainHash[:], d.cfg.ChainHash[:]) {
+		return rejectUpd(fmt.Errorf("ignoring ChannelUpdate2 from "+
+			"chain=%v, gossiper on chain=%v", upd.ChainHash,
+			d.cfg.ChainHash))
+	}
+
+	// If the advertised inclusionary block is beyond our knowledge of the
+	// chain tip, then we'll put the update in limbo to be fully verified
+	// once we advance forward in the chain. Updates that claim a block
+	// height we don't know of yet are ignored, as they must not be
+	// created before that block.
+	d.Lock()
+	if d.isPremature(upd.ShortChannelID, 0, nMsg) {
+		log.Warnf("Update announcement for short_chan_id(%v), is "+
+			"premature: advertises height %v, only height %v is "+
+			"known", shortChanID, upd.ShortChannelID.BlockHeight,
+			d.bestHeight)
+		d.Unlock()
+		nMsg.err <- nil
+		return nil, false
+	}
+	if upd.BlockHeight > d.bestHeight {
+		log.Debugf("Ignoring ChannelUpdate2 for short_chan_id(%v) "+
+			"with block height %v above best height %v",
+			shortChanID, upd.BlockHeight, d.bestHeight)
+		d.Unlock()
+		nMsg.err <- nil
+		return nil, false
+	}
+	d.Unlock()
+
+	// We make sure to obtain the mutex for this channel ID before we
+	// access the database. This ensures the state we read from the
+	// database has not changed between this point and when we call
+	// UpdateEdge() later.
+	d.channelMtx.Lock(shortChanID)
+	defer d.channelMtx.Unlock(shortChanID)
+
+	chanInfo, e1, e2, err := d.cfg.Router.GetChannelByID(
+		upd.ShortChannelID,
+	)
+	switch {
+	case err == nil:
+
+	// We can't verify an update for a zombie channel without resurrecting
+	// it, which isn't supported for gossip v2 channels yet.
+	case errors.Is(err, channeldb.ErrZombieEdge):
+		log.Debugf("Ignoring ChannelUpdate2 for zombie "+
+			"short_chan_id=%v", shortChanID)
+		nMsg.err <- nil
+		return nil, false
+
+	// If the edge isn't in the graph yet, we stash the update and
+	// reprocess it once we've received the channel announcement.
+	case errors.Is(err, channeldb.ErrGraphNotFound),
+		errors.Is(err, channeldb.ErrGraphNoEdgesFound),
+		errors.Is(err, channeldb.ErrEdgeNotFound):
+
+		d.addPrematureUpdate(shortChanID, nMsg)
+
+		log.Debugf("Got ChannelUpdate2 for edge not found in graph"+
+			"(shortChanID=%v), saving for reprocessing later",
+			shortChanID)
+
+		// NOTE: We don't return anything on the error channel for this
+		// message, as we expect that will be done when this
+		// ChannelUpdate2 is later reprocessed.
+		return nil, false
+
+	default:
+		return rejectUpd(fmt.Errorf("unable to validate channel "+
+			"update short_chan_id=%v: %w", shortChanID, err))
+	}
+
+	if chanInfo.Version != lnwire.GossipVersion2 {
+		return rejectUpd(fmt.Errorf("ignoring ChannelUpdate2 for %v "+
+			"channel short_chan_id=%v", chanInfo.Version,
+			shortChanID))
+	}
+
+	var (
+		pubKey       *btcec.PublicKey
+		edgeToUpdate *models.ChannelEdgePolicy
+		direction    = upd.Direction()
+	)
+	switch direction {
+	case 0:
+		pubKey, _ = chanInfo.NodeKey1()
+		edgeToUpdate = e1
+	default:
+		pubKey, _ = chanInfo.NodeKey2()
+		edgeToUpdate = e2
+	}
+
+	// Updates of gossip v2 channels are ordered by their block height, so
+	// we ignore any update that isn't strictly newer than the one we know
+	// of.
+	if edgeToUpdate != nil && edgeToUpdate.BlockHeight >= upd.BlockHeight {
+		log.Debugf("Ignored stale ChannelUpdate2 for "+
+			"short_chan_id(%v): peer=%v, block_height=%v",
+			shortChanID, nMsg.peer, upd.BlockHeight)
+
+		nMsg.err <- nil
+		return nil, true
+	}
+
+	err = routing.ValidateChannelUpdate2Ann(pubKey, chanInfo.Capacity, upd)
+	if err != nil {
+		rErr := fmt.Errorf("unable to validate channel update "+
+			"announcement for short_chan_id=%v: %w", shortChanID,
+			err)
+
+		log.Error(rErr)
+		nMsg.err <- rErr
+		return nil, false
+	}
+
+	// If we have a previous version of the edge being updated, we'll want
+	// to rate limit its updates to prevent spam throughout the network.
+	if edgeToUpdate != nil &&
+		!d.allowChanUpdate(chanInfo.ChannelID, direction) {
+
+		log.Debugf("Rate limiting update for channel %v from "+
+			"direction %x", shortChanID, pubKey.SerializeCompressed())
+		nMsg.err <- nil
+		return nil, false
+	}
+
+	// The max_htlc field defaults to the capacity of the channel. We only
+	// set the max_htlc message flag if the field was set explicitly, so
+	// that we're able to re-create the signed update from the policy. The
+	// channel is disabled for path finding if it's disabled in any
+	// direction.
+	var msgFlags lnwire.ChanUpdateMsgFlags
+	maxHtlc := upd.HTLCMaximumMsat
+	if maxHtlc == 0 {
+		maxHtlc = lnwire.NewMSatFromSatoshis(chanInfo.Capacity)
+	} else {
+		msgFlags |= lnwire.ChanUpdateRequiredMaxHtlc
+	}
+	chanFlags := direction
+	if !upd.DisableFlags.IsEnabled() {
+		chanFlags |= lnwire.ChanUpdateDisabled
+	}
+
+	// As gossip v2 updates don't carry a timestamp, we use the time we
+	// received the update at as its last update time, which is what the
+	// update horizon of our gossip syncers is based on.
+	feeRate := lnwire.MilliSatoshi(upd.FeeProportionalMillionths)
+	update := &models.ChannelEdgePolicy{
+		SigBytes:                  upd.Signature.RawBytes(),
+		ChannelID:                 chanInfo.ChannelID,
+		Version:                   lnwire.GossipVersion2,
+		LastUpdate:                time.Now(),
+		BlockHeight:               upd.BlockHeight,
+		DisableFlags:              upd.DisableFlags,
+		MessageFlags:              msgFlags,
+		ChannelFlags:              chanFlags,
+		TimeLockDelta:             upd.CLTVExpiryDelta,
+		MinHTLC:                   upd.HTLCMinimumMsat,
+		MaxHTLC:                   maxHtlc,
+		FeeBaseMSat:               lnwire.MilliSatoshi(upd.FeeBaseMsat),
+		FeeProportionalMillionths: feeRate,
+		ExtraOpaqueData:           upd.ExtraOpaqueData,
+	}
+
+	if err := d.cfg.Router.UpdateEdge(update, ops...); err != nil {
+		if routing.IsError(
+			err, routing.ErrOutdated,
+			routing.ErrIgnored,
+			routing.ErrVBarrierShuttingDown,
+		) {
+
+			log.Debugf("Update edge for short_chan_id(%v) got: %v",
+				shortChanID, err)
+		} else {
+			key := newRejectCacheKey(
+				chanInfo.ChannelID,
+				sourceToPub(nMsg.source),
+			)
+			_, _ = d.recentRejects.Put(key, &cachedReject{})
+
+			log.Errorf("Update edge for short_chan_id(%v) got: %v",
+				shortChanID, err)
+		}
+
+		nMsg.err <- err
+		return nil, false
+	}
+
+	nMsg.err <- nil
+
+	log.Debugf("Processed ChannelUpdate2: peer=%v, short_chan_id=%v, "+
+		"block_height=%v", nMsg.peer, shortChanID, upd.BlockHeight)
+
+	return []networkMsg{{
+		peer:     nMsg.peer,
+		source:   nMsg.source,
+		isRemote: nMsg.isRemote,
+		msg:      upd,
+	}}, true
+}
+
 // handleAnnSig processes a new announcement signatures message.
 func (d *AuthenticatedGossiper) handleAnnSig(nMsg *networkMsg,
 	ann *lnwire.AnnounceSignatures) ([]networkMsg, bool) {
@@ -15,6 +15,8 @@ import (
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
@@ -25,6 +27,7 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/fn"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lnpeer"
@@ -4139,3 +4142,228 @@ func TestFutureMsgCacheEviction(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, 2, item.height, "should be the second item")
 }
+
+// createChannelAnnouncement2 creates a gossip v2 channel announcement between
+// the two remote test nodes that is signed with the MuSig2 aggregate of their
+// node and bitcoin keys.
+func createChannelAnnouncement2(t *testing.T,
+	blockHeight uint32) *lnwire.ChannelAnnouncement2 {
+
+	t.Helper()
+
+	nodeKey1, nodeKey2 := remoteKeyPriv1, remoteKeyPriv2
+	btcKey1, btcKey2 := bitcoinKeyPriv1, bitcoinKeyPriv2
+	if bytes.Compare(
+		nodeKey1.PubKey().SerializeCompressed(),
+		nodeKey2.PubKey().SerializeCompressed(),
+	) > 0 {
+
+		nodeKey1, nodeKey2 = nodeKey2, nodeKey1
+		btcKey1, btcKey2 = btcKey2, btcKey1
+	}
+
+	ann := &lnwire.ChannelAnnouncement2{
+		Features: lnwire.NewRawFeatureVector(),
+		ShortChannelID: lnwire.ShortChannelID{
+			BlockHeight: blockHeight,
+			TxIndex:     0,
+			TxPosition:  0,
+		},
+		Capacity: 100_000,
+		BitcoinKey1: fn.Some(
+			[33]byte(btcKey1.PubKey().SerializeCompressed()),
+		),
+		BitcoinKey2: fn.Some(
+			[33]byte(btcKey2.PubKey().SerializeCompressed()),
+		),
+	}
+	copy(ann.NodeID1[:], nodeKey1.PubKey().SerializeCompressed())
+	copy(ann.NodeID2[:], nodeKey2.PubKey().SerializeCompressed())
+
+	digest, err := ann.DigestToSign()
+	require.NoError(t, err)
+
+	// Every signer creates a nonce, after which each of them creates a
+	// partial signature with the aggregate nonce.
+	signers := []*btcec.PrivateKey{nodeKey1, nodeKey2, btcKey1, btcKey2}
+	pubKeys := make([]*btcec.PublicKey, 0, len(signers))
+	nonces := make([]*musig2.Nonces, 0, len(signers))
+	pubNonces := make([][musig2.PubNonceSize]byte, 0, len(signers))
+	for _, signer := range signers {
+		nonce, err := musig2.GenNonces(
+			musig2.WithPublicKey(signer.PubKey()),
+		)
+		require.NoError(t, err)
+
+		pubKeys = append(pubKeys, signer.PubKey())
+		nonces = append(nonces, nonce)
+		pubNonces = append(pubNonces, nonce.PubNonce)
+	}
+
+	combinedNonce, err := musig2.AggregateNonces(pubNonces)
+	require.NoError(t, err)
+
+	partialSigs := make([]*musig2.PartialSignature, 0, len(signers))
+	for i, signer := range signers {
+		partialSig, err := musig2.Sign(
+			nonces[i].SecNonce, signer, combinedNonce, pubKeys,
+			*digest, musig2.WithSortedKeys(),
+		)
+		require.NoError(t, err)
+
+		partialSigs = append(partialSigs, partialSig)
+	}
+
+	sig := musig2.CombineSigs(partialSigs[0].R, partialSigs)
+	ann.Signature, err = lnwire.NewSigFromSignature(sig)
+	require.NoError(t, err)
+
+	return ann
+}
+
+// createChannelUpdate2 creates a gossip v2 channel update for the given
+// channel announcement that is signed by the node with the given key.
+func createChannelUpdate2(t *testing.T, ann *lnwire.ChannelAnnouncement2,
+	nodeKey *btcec.PrivateKey,
+	blockHeight uint32) *lnwire.ChannelUpdate2 {
+
+	t.Helper()
+
+	upd := &lnwire.ChannelUpdate2{
+		ShortChannelID:            ann.ShortChannelID,
+		BlockHeight:               blockHeight,
+		CLTVExpiryDelta:           lnwire.DefaultCLTVExpiryDelta,
+		HTLCMinimumMsat:           lnwire.DefaultHTLCMinimumMsat,
+		FeeBaseMsat:               lnwire.DefaultFeeBaseMsat,
+		FeeProportionalMillionths: 100,
+	}
+
+	nodeID := nodeKey.PubKey().SerializeCompressed()
+	upd.SecondPeer = !bytes.Equal(ann.NodeID1[:], nodeID)
+
+	digest, err := upd.DigestToSign()
+	require.NoError(t, err)
+
+	sig, err := schnorr.Sign(nodeKey, digest[:])
+	require.NoError(t, err)
+
+	upd.Signature, err = lnwire.NewSigFromSignature(sig)
+	require.NoError(t, err)
+
+	return upd
+}
+
+// TestProcessChannelAnnouncement2 tests that gossip v2 channel announcements
+// and updates are validated, added to the graph and broadcast, and that
+// updates received before their channel announcement are processed once the
+// channel is known.
+func TestProcessChannelAnnouncement2(t *testing.T) {
+	t.Parallel()
+
+	const blockHeight = 100
+
+	ctx, err := createTestCtx(t, blockHeight)
+	require.NoError(t, err, "can't create context")
+
+	nodePeer := &mockPeer{remoteKeyPriv1.PubKey(), nil, nil}
+
+	ann := createChannelAnnouncement2(t, blockHeight)
+	upd := createChannelUpdate2(t, ann, remoteKeyPriv1, blockHeight)
+
+	// An update with a block height that is beyond our best height must
+	// not be accepted.
+	futureUpd := createChannelUpdate2(
+		t, ann, remoteKeyPriv1, blockHeight+1,
+	)
+	sendRemoteMsg(t, ctx, futureUpd, nodePeer)
+	assertBroadcast(t, ctx, 0)
+
+	// Sending the update before the announcement doesn't return a result
+	// yet, as it is held back until we know of the channel.
+	updResult := ctx.gossiper.ProcessRemoteAnnouncement(upd, nodePeer)
+	select {
+	case err := <-updResult:
+		t.Fatalf("premature update was processed: %v", err)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Once the announcement is processed, both messages should be added
+	// to the graph and broadcast.
+	sendRemoteMsg(t, ctx, ann, nodePeer)
+	assertProcessAnnouncement(t, updResult)
+
+	msgs := assertBroadcast(t, ctx, 2)
+	require.Contains(t, msgs, ann)
+	require.Contains(t, msgs, upd)
+
+	chanInfo, e1, e2, err := ctx.router.GetChannelByID(ann.ShortChannelID)
+	require.NoError(t, err)
+	require.Equal(t, lnwire.GossipVersion2, chanInfo.Version)
+	require.EqualValues(t, ann.Capacity, chanInfo.Capacity)
+
+	edge := e1
+	if upd.SecondPeer {
+		edge = e2
+	}
+	require.NotNil(t, edge)
+	require.Equal(t, lnwire.GossipVersion2, edge.Version)
+	require.EqualValues(t, blockHeight, edge.BlockHeight)
+
+	// The channel announcement and update should be re-created from the
+	// graph exactly as they were received.
+	newAnn, newUpd1, newUpd2, err := netann.CreateChanAnnouncement2(
+		chanInfo, e1, e2,
+	)
+	require.NoError(t, err)
+	require.NoError(t, routing.ValidateChannelAnn2(newAnn))
+
+	newUpd := newUpd1
+	if upd.SecondPeer {
+		newUpd = newUpd2
+	}
+	remotePub := remoteKeyPriv1.PubKey()
+	require.NoError(t, routing.VerifyChannelUpdate2Signature(
+		newUpd, remotePub,
+	))
+
+	// Resending the same update is ignored as it's not newer than the one
+	// we know of.
+	sendRemoteMsg(t, ctx, upd, nodePeer)
+	assertBroadcast(t, ctx, 0)
+
+	// A legacy channel update for the channel is rejected, even if it's
+	// newer than the policy we know of.
+	legacyUpd := &lnwire.ChannelUpdate{
+		ShortChannelID: ann.ShortChannelID,
+		Timestamp:      uint32(time.Now().Add(time.Hour).Unix()),
+		MessageFlags:   lnwire.ChanUpdateRequiredMaxHtlc,
+		ChannelFlags:   upd.Direction(),
+		HtlcMaximumMsat: lnwire.NewMSatFromSatoshis(
+			chanInfo.Capacity,
+		),
+		ExtraOpaqueData: make([]byte, 0),
+	}
+	require.NoError(t, signUpdate(remoteKeyPriv1, legacyUpd))
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+		legacyUpd, nodePeer,
+	):
+		require.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("did not process legacy update")
+	}
+
+	// Finally, an announcement with an invalid signature is rejected.
+	invalidAnn := createChannelAnnouncement2(t, blockHeight)
+	invalidAnn.ShortChannelID.TxIndex = 1
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+		invalidAnn, nodePeer,
+	):
+		require.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("did not process invalid announcement")
+	}
+	assertBroadcast(t, ctx, 0)
+}
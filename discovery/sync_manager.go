@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnpeer"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
@@ -107,6 +108,12 @@ type SyncManagerCfg struct {
 	// updates for a channel and returns true if the channel should be
 	// considered a zombie based on these timestamps.
 	IsStillZombieChannel func(time.Time, time.Time) bool
+
+	// PeerRateLimit is the maximum number of gossip messages per second
+	// that we send to a single peer in reply to its gossip queries and
+	// gossip timestamp filter. A value of zero disables the rate limit. The
+	// gossip policy of a peer may override it.
+	PeerRateLimit uint32
 }
 
 // SyncManager is a subsystem of the gossiper that manages the gossip syncers
@@ -142,8 +149,8 @@ type SyncManager struct {
 	// GossipSyncers for disconnected peers.
 	staleSyncers chan *staleSyncer
 
-	// syncersMu guards the read and write access to the activeSyncers and
-	// inactiveSyncers maps below.
+	// syncersMu guards the read and write access to the activeSyncers,
+	// inactiveSyncers and gossipPolicies maps below.
 	syncersMu sync.Mutex
 
 	// activeSyncers is the set of all syncers for which we are currently
@@ -161,6 +168,10 @@ type SyncManager struct {
 	// duration of the connection.
 	pinnedActiveSyncers map[route.Vertex]*GossipSyncer
 
+	// gossipPolicies holds the gossip policies of peers that don't use the
+	// default gossip behavior.
+	gossipPolicies map[route.Vertex]*channeldb.PeerGossipPolicy
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -178,6 +189,9 @@ func newSyncManager(cfg *SyncManagerCfg) *SyncManager {
 		pinnedActiveSyncers: make(
 			map[route.Vertex]*GossipSyncer, len(cfg.PinnedSyncers),
 		),
+		gossipPolicies: make(
+			map[route.Vertex]*channeldb.PeerGossipPolicy,
+		),
 		quit: make(chan struct{}),
 	}
 }
@@ -275,6 +289,7 @@ func (m *SyncManager) syncerHandler() {
 			attemptHistoricalSync := false
 
 			m.syncersMu.Lock()
+			m.applyGossipPolicy(s)
 			switch {
 			// For pinned syncers, we will immediately transition
 			// the peer into an active (pinned) sync state.
@@ -735,6 +750,45 @@ func (m *SyncManager) PruneSyncState(peer route.Vertex) {
 	}
 }
 
+// applyGossipPolicy applies the gossip policy of the syncer's peer, falling
+// back to the default gossip behavior if the peer doesn't have one.
+//
+// NOTE: This must be called with the syncersMu lock held.
+func (m *SyncManager) applyGossipPolicy(s *GossipSyncer) {
+	var (
+		disabled  bool
+		rateLimit = m.cfg.PeerRateLimit
+	)
+	if policy, ok := m.gossipPolicies[s.cfg.peerPub]; ok {
+		disabled = policy.DisableGossip
+		if policy.RateLimit != 0 {
+			rateLimit = policy.RateLimit
+		}
+	}
+
+	s.setGossipPolicy(disabled, rateLimit)
+}
+
+// SetPeerGossipPolicy sets the gossip policy of a peer and applies it to the
+// gossip syncer of the peer if it is connected. A nil policy restores the
+// default gossip behavior for the peer.
+func (m *SyncManager) SetPeerGossipPolicy(peer route.Vertex,
+	policy *channeldb.PeerGossipPolicy) {
+
+	m.syncersMu.Lock()
+	defer m.syncersMu.Unlock()
+
+	if policy == nil {
+		delete(m.gossipPolicies, peer)
+	} else {
+		m.gossipPolicies[peer] = policy
+	}
+
+	if s, ok := m.gossipSyncer(peer); ok {
+		m.applyGossipPolicy(s)
+	}
+}
+
 // GossipSyncer returns the associated gossip syncer of a peer. The boolean
 // returned signals whether there exists a gossip syncer for the peer.
 func (m *SyncManager) GossipSyncer(peer route.Vertex) (*GossipSyncer, bool) {
@@ -783,6 +837,36 @@ func (m *SyncManager) gossipSyncers() map[route.Vertex]*GossipSyncer {
 	return syncers
 }
 
+// QueryChanIDs queries all gossip peers whose syncer finished its initial
+// sync for the given channels, and returns the number of peers that were
+// queried. Peers whose syncer is still busy syncing are skipped.
+func (m *SyncManager) QueryChanIDs(chanIDs ...lnwire.ShortChannelID) (int,
+	error) {
+
+	var numQueried int
+	for _, s := range m.GossipSyncers() {
+		state := s.syncState()
+		if state != chansSynced && state != syncerIdle {
+			continue
+		}
+
+		err := s.QueryChanIDs(chanIDs...)
+		if err != nil {
+			log.Debugf("Unable to query peer %x for channels: %v",
+				s.cfg.peerPub, err)
+			continue
+		}
+
+		numQueried++
+	}
+
+	if numQueried == 0 {
+		return 0, errors.New("no synced gossip peer to query")
+	}
+
+	return numQueried, nil
+}
+
 // markGraphSynced allows us to report that the initial historical sync has
 // completed.
 func (m *SyncManager) markGraphSynced() {
@@ -10,11 +10,13 @@ import (
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lntest/wait"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/ticker"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 // randPeer creates a random peer.
@@ -683,3 +685,60 @@ func assertActiveSyncerTransition(t *testing.T, s *GossipSyncer, peer *mockPeer)
 	})
 	assertSyncerStatus(t, s, chansSynced, PassiveSync)
 }
+
+// TestSyncManagerPeerGossipPolicy tests that the gossip policy of a peer is
+// applied to its gossip syncer, both when the peer connects and when the
+// policy is updated while the peer is connected.
+func TestSyncManagerPeerGossipPolicy(t *testing.T) {
+	t.Parallel()
+
+	const defaultRateLimit = 5
+
+	syncMgr := newTestSyncManager(0)
+	syncMgr.cfg.PeerRateLimit = defaultRateLimit
+	syncMgr.Start()
+	defer syncMgr.Stop()
+
+	assertPolicy := func(s *GossipSyncer, disabled bool,
+		rateLimit rate.Limit) {
+
+		t.Helper()
+
+		require.Equal(t, disabled, s.gossipDisabled.Load())
+		require.Equal(t, rateLimit, s.sendLimiter.Limit())
+	}
+
+	// A peer without a gossip policy gets the default rate limit.
+	peer := randPeer(t, syncMgr.quit)
+	require.NoError(t, syncMgr.InitSyncState(peer))
+
+	s := assertSyncerExistence(t, syncMgr, peer)
+	assertPolicy(s, false, defaultRateLimit)
+
+	// Updating the policy of a connected peer applies it right away.
+	vertex := route.Vertex(peer.PubKey())
+	syncMgr.SetPeerGossipPolicy(vertex, &channeldb.PeerGossipPolicy{
+		DisableGossip: true,
+		RateLimit:     20,
+	})
+	assertPolicy(s, true, 20)
+
+	// Removing the policy restores the default behavior.
+	syncMgr.SetPeerGossipPolicy(vertex, nil)
+	assertPolicy(s, false, defaultRateLimit)
+
+	// A policy without a rate limit keeps the default rate limit, and the
+	// policy of a peer is applied once it connects.
+	pubKey := randPubKey(t)
+	syncMgr.SetPeerGossipPolicy(
+		route.NewVertex(pubKey), &channeldb.PeerGossipPolicy{
+			DisableGossip: true,
+		},
+	)
+
+	peer2 := peerWithPubkey(pubKey, syncMgr.quit)
+	require.NoError(t, syncMgr.InitSyncState(peer2))
+
+	s2 := assertSyncerExistence(t, syncMgr, peer2)
+	assertPolicy(s2, true, defaultRateLimit)
+}
@@ -220,6 +220,17 @@ type historicalSyncReq struct {
 	doneChan chan struct{}
 }
 
+// chanQueryReq encapsulates a request for a gossip syncer to query the remote
+// peer for a set of channels.
+type chanQueryReq struct {
+	// chanIDs is the set of channels to query the remote peer for.
+	chanIDs []lnwire.ShortChannelID
+
+	// doneChan is a channel that serves as a signal and is closed to ensure
+	// the query is attempted by the time we return to the caller.
+	doneChan chan struct{}
+}
+
 // gossipSyncerCfg is a struct that packages all the information a GossipSyncer
 // needs to carry out its duties.
 type gossipSyncerCfg struct {
@@ -345,6 +356,12 @@ type GossipSyncer struct {
 	// machine behaves as expected.
 	historicalSyncReqs chan *historicalSyncReq
 
+	// chanQueryReqs is a channel that serves as a signal for the gossip
+	// syncer to query the remote peer for a set of channels. Like
+	// historical syncs, these can only be done once the gossip syncer is
+	// in a chansSynced state.
+	chanQueryReqs chan *chanQueryReq
+
 	// genHistoricalChanRangeQuery when true signals to the gossip syncer
 	// that it should request the remote peer for all of its known channel
 	// IDs starting from the genesis block of the chain. This can only
@@ -395,6 +412,15 @@ type GossipSyncer struct {
 	// number of queries.
 	rateLimiter *rate.Limiter
 
+	// gossipDisabled is true if we don't send any gossip to the peer, as
+	// dictated by its gossip policy.
+	gossipDisabled atomic.Bool
+
+	// sendLimiter paces the gossip that we send to the peer in reply to
+	// its gossip queries and gossip timestamp filter. It doesn't limit the
+	// rate unless a gossip rate limit applies to the peer.
+	sendLimiter *rate.Limiter
+
 	// syncedSignal is a channel that, if set, will be closed when the
 	// GossipSyncer reaches its terminal chansSynced state.
 	syncedSignal chan struct{}
@@ -439,8 +465,10 @@ func newGossipSyncer(cfg gossipSyncerCfg) *GossipSyncer {
 	return &GossipSyncer{
 		cfg:                cfg,
 		rateLimiter:        rateLimiter,
+		sendLimiter:        rate.NewLimiter(rate.Inf, 0),
 		syncTransitionReqs: make(chan *syncTransitionReq),
 		historicalSyncReqs: make(chan *historicalSyncReq),
+		chanQueryReqs:      make(chan *chanQueryReq),
 		gossipMsgs:         make(chan lnwire.Message, 100),
 		queryMsgs:          make(chan lnwire.Message, 100),
 		gossipFilterSema:   filterSema,
@@ -652,6 +680,9 @@ func (g *GossipSyncer) channelGraphSyncer() {
 			case req := <-g.historicalSyncReqs:
 				g.handleHistoricalSync(req)
 
+			case req := <-g.chanQueryReqs:
+				g.handleChanQuery(req)
+
 			case <-g.quit:
 				return
 			}
@@ -1000,9 +1031,89 @@ func (g *GossipSyncer) genChanRangeQuery(
 	return query, nil
 }
 
+// setGossipPolicy applies the gossip policy of the peer. If gossip is
+// disabled, we don't send any gossip to the peer and answer its queries
+// without any channels. A non-zero rate limit caps the number of gossip
+// messages per second that we send to the peer in reply to its gossip queries
+// and gossip timestamp filter.
+func (g *GossipSyncer) setGossipPolicy(disabled bool, rateLimit uint32) {
+	g.gossipDisabled.Store(disabled)
+
+	limit := rate.Inf
+	if rateLimit > 0 {
+		limit = rate.Limit(rateLimit)
+	}
+
+	// The burst allows sending a second worth of messages at once.
+	g.sendLimiter.SetLimit(limit)
+	g.sendLimiter.SetBurst(int(rateLimit))
+
+	log.Debugf("GossipSyncer(%x): applied gossip policy: disabled=%v, "+
+		"rate_limit=%v", g.cfg.peerPub[:], disabled, rateLimit)
+}
+
+// sendToPeerThrottled sends the given messages to the remote peer, blocking
+// until all messages have been sent. The messages are paced by the gossip
+// rate limit of the peer, if there is one.
+func (g *GossipSyncer) sendToPeerThrottled(msgs ...lnwire.Message) error {
+	if g.sendLimiter.Limit() == rate.Inf {
+		return g.cfg.sendToPeerSync(msgs...)
+	}
+
+	for _, msg := range msgs {
+		delay := g.sendLimiter.Reserve().Delay()
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-g.quit:
+				return ErrGossipSyncerExiting
+			}
+		}
+
+		if err := g.cfg.sendToPeerSync(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replyPeerQueriesWithoutGossip answers a query by the remote peer without
+// any channels, signaling that we don't maintain up-to-date channel
+// information for it. This is used if gossip is disabled for the peer, so
+// that it doesn't wait for our reply.
+func (g *GossipSyncer) replyPeerQueriesWithoutGossip(msg lnwire.Message) error {
+	log.Debugf("GossipSyncer(%x): gossip disabled, replying to %v "+
+		"without channels", g.cfg.peerPub[:], msg.MsgType())
+
+	switch msg := msg.(type) {
+	case *lnwire.QueryChannelRange:
+		return g.cfg.sendToPeerSync(&lnwire.ReplyChannelRange{
+			ChainHash:        msg.ChainHash,
+			FirstBlockHeight: msg.FirstBlockHeight,
+			NumBlocks:        msg.NumBlocks,
+			Complete:         0,
+			EncodingType:     g.cfg.encodingType,
+		})
+
+	case *lnwire.QueryShortChanIDs:
+		return g.cfg.sendToPeerSync(&lnwire.ReplyShortChanIDsEnd{
+			ChainHash: msg.ChainHash,
+			Complete:  0,
+		})
+
+	default:
+		return fmt.Errorf("unknown message: %T", msg)
+	}
+}
+
 // replyPeerQueries is called in response to any query by the remote peer.
 // We'll examine our state and send back our best response.
 func (g *GossipSyncer) replyPeerQueries(msg lnwire.Message) error {
+	if g.gossipDisabled.Load() {
+		return g.replyPeerQueriesWithoutGossip(msg)
+	}
+
 	reservation := g.rateLimiter.Reserve()
 	delay := reservation.Delay()
 
@@ -1125,7 +1236,7 @@ func (g *GossipSyncer) replyChanRangeQuery(query *lnwire.QueryChannelRange) erro
 			)
 		}
 
-		return g.cfg.sendToPeerSync(&lnwire.ReplyChannelRange{
+		return g.sendToPeerThrottled(&lnwire.ReplyChannelRange{
 			ChainHash:        query.ChainHash,
 			NumBlocks:        numBlocks,
 			FirstBlockHeight: firstHeight,
@@ -1260,7 +1371,7 @@ func (g *GossipSyncer) replyShortChanIDs(query *lnwire.QueryShortChanIDs) error
 	// each one individually and synchronously to throttle the sends and
 	// perform buffering of responses in the syncer as opposed to the peer.
 	for _, msg := range replyMsgs {
-		err := g.cfg.sendToPeerSync(msg)
+		err := g.sendToPeerThrottled(msg)
 		if err != nil {
 			return err
 		}
@@ -1295,6 +1406,12 @@ func (g *GossipSyncer) ApplyGossipFilter(filter *lnwire.GossipTimestampRange) er
 		return nil
 	}
 
+	// We also don't reply with historical gossip data if gossip is
+	// disabled for the peer.
+	if g.gossipDisabled.Load() {
+		return nil
+	}
+
 	// Now that the remote peer has applied their filter, we'll query the
 	// database for all the messages that are beyond this filter.
 	newUpdatestoSend, err := g.cfg.channelSeries.UpdatesInHorizon(
@@ -1328,7 +1445,7 @@ func (g *GossipSyncer) ApplyGossipFilter(filter *lnwire.GossipTimestampRange) er
 		}()
 
 		for _, msg := range newUpdatestoSend {
-			err := g.cfg.sendToPeerSync(msg)
+			err := g.sendToPeerThrottled(msg)
 			switch {
 			case err == ErrGossipSyncerExiting:
 				return
@@ -1358,6 +1475,14 @@ func (g *GossipSyncer) FilterGossipMsgs(msgs ...msgWithSenders) {
 		return
 	}
 
+	// We don't send any new update messages to peers that we don't
+	// exchange gossip with.
+	if g.gossipDisabled.Load() {
+		log.Tracef("GossipSyncer(%x): skipped due to disabled gossip",
+			g.cfg.peerPub[:])
+		return
+	}
+
 	// If we've been signaled to exit, or are exiting, then we'll stop
 	// short.
 	select {
@@ -1452,6 +1577,14 @@ func (g *GossipSyncer) FilterGossipMsgs(msgs ...msgWithSenders) {
 				msgsToSend = append(msgsToSend, msg)
 			}
 
+		// Gossip v2 messages don't carry a timestamp, so we'll only
+		// send them if the time we received them at, which is now, is
+		// between our time range.
+		case *lnwire.ChannelAnnouncement2, *lnwire.ChannelUpdate2:
+			if passesFilter(uint32(time.Now().Unix())) {
+				msgsToSend = append(msgsToSend, msg)
+			}
+
 		// Similarly, we only send node announcements if the update
 		// timestamp ifs between our set gossip filter time range.
 		case *lnwire.NodeAnnouncement:
@@ -1654,3 +1787,39 @@ func (g *GossipSyncer) handleHistoricalSync(req *historicalSyncReq) {
 	g.setSyncState(syncingChans)
 	close(req.doneChan)
 }
+
+// QueryChanIDs requests the gossip syncer to query the remote peer for the
+// given channels, regardless of whether we already know of them. This is used
+// to refresh our view of channels, e.g. to resurrect zombie channels.
+func (g *GossipSyncer) QueryChanIDs(chanIDs ...lnwire.ShortChannelID) error {
+	done := make(chan struct{})
+
+	select {
+	case g.chanQueryReqs <- &chanQueryReq{
+		chanIDs:  chanIDs,
+		doneChan: done,
+	}:
+	case <-time.After(syncTransitionTimeout):
+		return ErrSyncTransitionTimeout
+	case <-g.quit:
+		return ErrGossiperShuttingDown
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-g.quit:
+		return ErrGossiperShuttingDown
+	}
+}
+
+// handleChanQuery handles a request to the gossip syncer to query the remote
+// peer for a set of channels.
+func (g *GossipSyncer) handleChanQuery(req *chanQueryReq) {
+	// We'll reuse the queryNewChannels state which sends out the queries
+	// for the channels in newChansToQuery, and returns to the chansSynced
+	// state once the remote peer replied to all of them.
+	g.newChansToQuery = append(g.newChansToQuery, req.chanIDs...)
+	g.setSyncState(queryNewChannels)
+	close(req.doneChan)
+}
@@ -2310,6 +2310,53 @@ func TestGossipSyncerHistoricalSync(t *testing.T) {
 	}
 }
 
+// TestGossipSyncerQueryChanIDs tests that a gossip syncer queries the remote
+// peer for the requested channels and returns to its chansSynced state once
+// the peer replied.
+func TestGossipSyncerQueryChanIDs(t *testing.T) {
+	t.Parallel()
+
+	msgChan, syncer, _ := newTestSyncer(
+		lnwire.ShortChannelID{BlockHeight: latestKnownHeight},
+		defaultEncoding, defaultChunkSize, true, true, true,
+	)
+	syncer.setSyncType(PassiveSync)
+	syncer.setSyncState(chansSynced)
+
+	syncer.Start()
+	defer syncer.Stop()
+
+	chanID := lnwire.NewShortChanIDFromInt(1234)
+	require.NoError(t, syncer.QueryChanIDs(chanID))
+
+	// We should expect to see a single lnwire.QueryShortChanIDs message
+	// for the channel be sent to the remote peer.
+	select {
+	case msgs := <-msgChan:
+		require.Len(t, msgs, 1)
+
+		query, ok := msgs[0].(*lnwire.QueryShortChanIDs)
+		require.True(t, ok, "unexpected message %T", msgs[0])
+		require.Equal(
+			t, []lnwire.ShortChannelID{chanID}, query.ShortChanIDs,
+		)
+
+	case <-time.After(time.Second):
+		t.Fatalf("expected to send a lnwire.QueryShortChanIDs message")
+	}
+
+	// Once the peer replied, the syncer should return to its chansSynced
+	// state.
+	err := syncer.ProcessQueryMsg(&lnwire.ReplyShortChanIDsEnd{
+		Complete: 1,
+	}, nil)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return syncer.syncState() == chansSynced
+	}, time.Second, 10*time.Millisecond)
+}
+
 // TestGossipSyncerSyncedSignal ensures that we receive a signal when a gossip
 // syncer reaches its terminal chansSynced state.
 func TestGossipSyncerSyncedSignal(t *testing.T) {
@@ -2440,3 +2487,110 @@ func TestGossipSyncerMaxChannelRangeReplies(t *testing.T) {
 		},
 	}, nil))
 }
+
+// TestGossipSyncerDisabledGossip tests that we don't send any gossip to a peer
+// that gossip is disabled for, and that its queries are answered without any
+// channels.
+func TestGossipSyncerDisabledGossip(t *testing.T) {
+	t.Parallel()
+
+	msgChan, syncer, _ := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), defaultEncoding,
+		defaultChunkSize,
+	)
+	syncer.setGossipPolicy(true, 0)
+
+	// Applying a gossip filter must not query the chan series for the
+	// backlog, as the mock would block otherwise.
+	remoteHorizon := &lnwire.GossipTimestampRange{
+		FirstTimestamp: unixStamp(25000),
+		TimestampRange: uint32(1000),
+	}
+	require.NoError(t, syncer.ApplyGossipFilter(remoteHorizon))
+
+	// New gossip messages within the horizon aren't sent to the peer.
+	msgs := []msgWithSenders{{
+		msg: &lnwire.NodeAnnouncement{Timestamp: unixStamp(25001)},
+	}}
+	syncer.FilterGossipMsgs(msgs...)
+
+	select {
+	case msg := <-msgChan:
+		t.Fatalf("received message but shouldn't have: %v",
+			spew.Sdump(msg))
+
+	case <-time.After(time.Millisecond * 10):
+	}
+
+	// Queries are answered without any channels.
+	rangeQuery := &lnwire.QueryChannelRange{
+		FirstBlockHeight: 0,
+		NumBlocks:        math.MaxUint32,
+	}
+	require.NoError(t, syncer.replyPeerQueries(rangeQuery))
+	require.Equal(t, []lnwire.Message{&lnwire.ReplyChannelRange{
+		FirstBlockHeight: 0,
+		NumBlocks:        math.MaxUint32,
+		Complete:         0,
+		EncodingType:     defaultEncoding,
+	}}, <-msgChan)
+
+	scidQuery := &lnwire.QueryShortChanIDs{
+		ShortChanIDs: []lnwire.ShortChannelID{
+			lnwire.NewShortChanIDFromInt(1),
+		},
+	}
+	require.NoError(t, syncer.replyPeerQueries(scidQuery))
+	require.Equal(t, []lnwire.Message{&lnwire.ReplyShortChanIDsEnd{
+		Complete: 0,
+	}}, <-msgChan)
+
+	// Once gossip is enabled again, new gossip messages are sent to the
+	// peer.
+	syncer.setGossipPolicy(false, 0)
+	syncer.FilterGossipMsgs(msgs...)
+
+	select {
+	case sent := <-msgChan:
+		require.Equal(t, []lnwire.Message{msgs[0].msg}, sent)
+
+	case <-time.After(time.Second):
+		t.Fatalf("gossip message not sent")
+	}
+}
+
+// TestGossipSyncerRateLimit tests that the gossip that we send to a peer in
+// reply to its queries is paced by the gossip rate limit of the peer.
+func TestGossipSyncerRateLimit(t *testing.T) {
+	t.Parallel()
+
+	// The rate limit allows a burst of a second worth of messages, the
+	// remaining messages are sent at the rate limit.
+	const (
+		rateLimit   = 20
+		numMsgs     = rateLimit + 5
+		minDuration = 5 * time.Second / rateLimit
+	)
+
+	_, syncer, _ := newTestSyncer(
+		lnwire.NewShortChanIDFromInt(10), defaultEncoding,
+		defaultChunkSize,
+	)
+
+	var numSent int
+	syncer.cfg.sendToPeerSync = func(msgs ...lnwire.Message) error {
+		numSent += len(msgs)
+		return nil
+	}
+	syncer.setGossipPolicy(false, rateLimit)
+
+	msgs := make([]lnwire.Message, numMsgs)
+	for i := range msgs {
+		msgs[i] = &lnwire.NodeAnnouncement{}
+	}
+
+	start := time.Now()
+	require.NoError(t, syncer.sendToPeerThrottled(msgs...))
+	require.Equal(t, numMsgs, numSent)
+	require.GreaterOrEqual(t, time.Since(start), minDuration*9/10)
+}
@@ -79,6 +79,9 @@ var deps = depDesc{
 		lnwire.AnchorsZeroFeeHtlcTxOptional: {},
 		lnwire.ExplicitChannelTypeOptional:  {},
 	},
+	lnwire.PtlcOptionalStaging: {
+		lnwire.SimpleTaprootChannelsOptionalStaging: {},
+	},
 	lnwire.RouteBlindingOptional: {
 		lnwire.TLVOnionPayloadOptional: {},
 	},
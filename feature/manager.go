@@ -63,6 +63,25 @@ type Config struct {
 	// NoRouteBlinding unsets route blinding feature bits.
 	NoRouteBlinding bool
 
+	// NoOnionMessages unsets onion message feature bits.
+	NoOnionMessages bool
+
+	// NoPeerStorage unsets peer storage feature bits.
+	NoPeerStorage bool
+
+	// NoRbfCoopClose unsets any bits signalling support for the RBF based
+	// cooperative close protocol.
+	NoRbfCoopClose bool
+
+	// NoSplitCloseOutputs unsets any bits signalling support for splitting
+	// the settled balance of a cooperative close across additional close
+	// outputs.
+	NoSplitCloseOutputs bool
+
+	// NoPtlc unsets any bits signalling support for the experimental
+	// point time locked contracts.
+	NoPtlc bool
+
 	// CustomFeatures is a set of custom features to advertise in each
 	// set.
 	CustomFeatures map[Set][]lnwire.FeatureBit
@@ -188,6 +207,26 @@ func newManager(cfg Config, desc setDesc) (*Manager, error) {
 			raw.Unset(lnwire.RouteBlindingOptional)
 			raw.Unset(lnwire.RouteBlindingRequired)
 		}
+		if cfg.NoOnionMessages {
+			raw.Unset(lnwire.OnionMessagesOptional)
+			raw.Unset(lnwire.OnionMessagesRequired)
+		}
+		if cfg.NoPeerStorage {
+			raw.Unset(lnwire.ProvideStorageOptional)
+			raw.Unset(lnwire.ProvideStorageRequired)
+		}
+		if cfg.NoRbfCoopClose {
+			raw.Unset(lnwire.RbfCoopCloseOptional)
+			raw.Unset(lnwire.RbfCoopCloseRequired)
+		}
+		if cfg.NoSplitCloseOutputs {
+			raw.Unset(lnwire.SplitCloseOutputsOptional)
+			raw.Unset(lnwire.SplitCloseOutputsRequired)
+		}
+		if cfg.NoPtlc {
+			raw.Unset(lnwire.PtlcOptionalStaging)
+			raw.Unset(lnwire.PtlcRequiredStaging)
+		}
 		for _, custom := range cfg.CustomFeatures[set] {
 			if custom > set.Maximum() {
 				return nil, fmt.Errorf("feature bit: %v "+
@@ -0,0 +1,243 @@
+package funding
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/chanacceptor"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnpeer"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// AcceptorPolicy is a set of declarative rules that inbound channel open
+// requests must satisfy. Every rule is optional, the zero value policy
+// accepts all channels.
+type AcceptorPolicy struct {
+	// MinCapacity is the minimum capacity of an inbound channel.
+	MinCapacity btcutil.Amount
+
+	// MaxPendingPerPeer is the maximum number of pending channels a
+	// single peer may have with us. A new request from a peer that already
+	// reached this number is rejected. Zero means no limit.
+	MaxPendingPerPeer uint32
+
+	// CommitmentTypes, if non-empty, is the list of commitment types an
+	// inbound channel may use.
+	CommitmentTypes []lnwallet.CommitmentType
+
+	// AllowedIPRanges, if non-empty, restricts inbound channels to peers
+	// that are connected to us from an IP address within one of the
+	// ranges. Peers connected over Tor never match.
+	AllowedIPRanges []*net.IPNet
+
+	// ZeroConfPeers is the set of peers we accept zero-conf channels from.
+	ZeroConfPeers map[route.Vertex]struct{}
+}
+
+// PolicyAcceptorConfig houses the dependencies of the PolicyAcceptor.
+type PolicyAcceptorConfig struct {
+	// FindPeer returns the connected peer with the given identity key.
+	FindPeer func(*btcec.PublicKey) (lnpeer.Peer, error)
+
+	// FetchPendingChannels returns all channels that are still waiting
+	// for their funding transaction to confirm.
+	FetchPendingChannels func() ([]*channeldb.OpenChannel, error)
+}
+
+// PolicyAcceptor is a chanacceptor.ChannelAcceptor that evaluates inbound
+// channel requests against an AcceptorPolicy. It allows operators to enforce
+// simple rules without running an external channel acceptor. As it is
+// evaluated together with all other acceptors, it can only ever restrict the
+// channels accepted, except for zero-conf channels which it can allow on its
+// own.
+type PolicyAcceptor struct {
+	cfg PolicyAcceptorConfig
+
+	policy    AcceptorPolicy
+	policyMtx sync.RWMutex
+}
+
+// A compile-time constraint to ensure PolicyAcceptor implements the
+// chanacceptor.ChannelAcceptor interface.
+var _ chanacceptor.ChannelAcceptor = (*PolicyAcceptor)(nil)
+
+// NewPolicyAcceptor creates a new PolicyAcceptor with an empty policy.
+func NewPolicyAcceptor(cfg PolicyAcceptorConfig) *PolicyAcceptor {
+	return &PolicyAcceptor{
+		cfg: cfg,
+	}
+}
+
+// SetPolicy replaces the policy used to evaluate future channel requests.
+func (p *PolicyAcceptor) SetPolicy(policy AcceptorPolicy) {
+	p.policyMtx.Lock()
+	defer p.policyMtx.Unlock()
+
+	p.policy = policy
+}
+
+// Policy returns the policy that's currently in use.
+func (p *PolicyAcceptor) Policy() AcceptorPolicy {
+	p.policyMtx.RLock()
+	defer p.policyMtx.RUnlock()
+
+	return p.policy
+}
+
+// Accept evaluates the channel request against the current policy. Zero-conf
+// is only signaled for peers that are explicitly allowed by the policy.
+//
+// NOTE: Part of the chanacceptor.ChannelAcceptor interface.
+func (p *PolicyAcceptor) Accept(req *chanacceptor.ChannelAcceptRequest) (
+	resp *chanacceptor.ChannelAcceptResponse) {
+
+	policy := p.Policy()
+
+	zeroConf, err := p.evaluate(&policy, req)
+	if err != nil {
+		log.Infof("Rejecting channel request from peer %x due to "+
+			"acceptor policy: %v", req.Node.SerializeCompressed(),
+			err)
+
+		return chanacceptor.NewChannelAcceptResponse(
+			false, err, nil, 0, 0, 0, 0, 0, 0, false,
+		)
+	}
+
+	return chanacceptor.NewChannelAcceptResponse(
+		true, nil, nil, 0, 0, 0, 0, 0, 0, zeroConf,
+	)
+}
+
+// evaluate checks the request against the given policy. It returns an error
+// describing the first violated rule, otherwise whether the channel may be
+// zero-conf.
+func (p *PolicyAcceptor) evaluate(policy *AcceptorPolicy,
+	req *chanacceptor.ChannelAcceptRequest) (bool, error) {
+
+	msg := req.OpenChanMsg
+
+	if msg.FundingAmount < policy.MinCapacity {
+		return false, fmt.Errorf("channel capacity %v is below the "+
+			"minimum of %v", msg.FundingAmount, policy.MinCapacity)
+	}
+
+	if policy.MaxPendingPerPeer > 0 {
+		numPending, err := p.numPendingChannels(req.Node)
+		if err != nil {
+			return false, err
+		}
+
+		if numPending >= policy.MaxPendingPerPeer {
+			return false, fmt.Errorf("peer already has %d pending "+
+				"channels, the maximum is %d", numPending,
+				policy.MaxPendingPerPeer)
+		}
+	}
+
+	// The remaining rules depend on the connection to the peer, so we only
+	// look it up if any of them is set.
+	if len(policy.CommitmentTypes) > 0 || len(policy.AllowedIPRanges) > 0 {
+		peer, err := p.cfg.FindPeer(req.Node)
+		if err != nil {
+			return false, fmt.Errorf("unable to find peer: %w", err)
+		}
+
+		err = checkCommitmentType(policy.CommitmentTypes, msg, peer)
+		if err != nil {
+			return false, err
+		}
+
+		err = checkPeerAddress(policy.AllowedIPRanges, peer.Address())
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Zero-conf is only signaled if the peer requests it, the funding
+	// manager will reject the request if no acceptor allows it.
+	if msg.ChannelType == nil {
+		return false, nil
+	}
+
+	features := lnwire.RawFeatureVector(*msg.ChannelType)
+	if !features.IsSet(lnwire.ZeroConfRequired) {
+		return false, nil
+	}
+
+	_, zeroConf := policy.ZeroConfPeers[route.NewVertex(req.Node)]
+
+	return zeroConf, nil
+}
+
+// numPendingChannels returns the number of pending channels we have with the
+// given peer.
+func (p *PolicyAcceptor) numPendingChannels(node *btcec.PublicKey) (uint32,
+	error) {
+
+	pendingChans, err := p.cfg.FetchPendingChannels()
+	if err != nil {
+		return 0, fmt.Errorf("unable to fetch pending channels: %w",
+			err)
+	}
+
+	var numPending uint32
+	for _, c := range pendingChans {
+		if c.IdentityPub.IsEqual(node) {
+			numPending++
+		}
+	}
+
+	return numPending, nil
+}
+
+// checkCommitmentType returns an error if the commitment type negotiated for
+// the request isn't among the allowed ones. An empty list allows all types.
+func checkCommitmentType(allowed []lnwallet.CommitmentType,
+	msg *lnwire.OpenChannel, peer lnpeer.Peer) error {
+
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	_, commitType, err := negotiateCommitmentType(
+		msg.ChannelType, peer.LocalFeatures(), peer.RemoteFeatures(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range allowed {
+		if t == commitType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("commitment type %v is not allowed", commitType)
+}
+
+// checkPeerAddress returns an error if the peer's address isn't within any of
+// the allowed IP ranges. An empty list allows all addresses.
+func checkPeerAddress(allowed []*net.IPNet, addr net.Addr) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if ok {
+		for _, ipNet := range allowed {
+			if ipNet.Contains(tcpAddr.IP) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("peer address %v is not in an allowed IP range",
+		addr)
+}
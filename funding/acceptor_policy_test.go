@@ -0,0 +1,208 @@
+package funding
+
+import (
+	"net"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/chanacceptor"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnpeer"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPolicyAcceptor tests that the PolicyAcceptor enforces each of the rules
+// of an AcceptorPolicy.
+func TestPolicyAcceptor(t *testing.T) {
+	t.Parallel()
+
+	peerPriv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	peerKey := peerPriv.PubKey()
+
+	otherPriv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	features := lnwire.NewFeatureVector(
+		lnwire.NewRawFeatureVector(
+			lnwire.StaticRemoteKeyOptional,
+			lnwire.AnchorsZeroFeeHtlcTxOptional,
+			lnwire.ExplicitChannelTypeOptional,
+			lnwire.ScidAliasOptional,
+			lnwire.ZeroConfOptional,
+		), lnwire.Features,
+	)
+
+	// The peer has a single pending channel with us, while another peer
+	// has two of them.
+	pendingChans := []*channeldb.OpenChannel{
+		{IdentityPub: peerKey},
+		{IdentityPub: otherPriv.PubKey()},
+		{IdentityPub: otherPriv.PubKey()},
+	}
+
+	zeroConfType := lnwire.ChannelType(*lnwire.NewRawFeatureVector(
+		lnwire.StaticRemoteKeyRequired,
+		lnwire.AnchorsZeroFeeHtlcTxRequired,
+		lnwire.ZeroConfRequired,
+	))
+
+	anchors := []lnwallet.CommitmentType{
+		lnwallet.CommitmentTypeAnchorsZeroFeeHtlcTx,
+	}
+	taproot := []lnwallet.CommitmentType{
+		lnwallet.CommitmentTypeSimpleTaproot,
+	}
+
+	_, allowedRange, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		policy       AcceptorPolicy
+		chanType     *lnwire.ChannelType
+		peerIP       string
+		reject       bool
+		wantZeroConf bool
+	}{
+		{
+			name:   "empty policy",
+			peerIP: "192.168.1.1",
+		},
+		{
+			name: "capacity above minimum",
+			policy: AcceptorPolicy{
+				MinCapacity: 100_000,
+			},
+			peerIP: "192.168.1.1",
+		},
+		{
+			name: "capacity below minimum",
+			policy: AcceptorPolicy{
+				MinCapacity: 200_000,
+			},
+			peerIP: "192.168.1.1",
+			reject: true,
+		},
+		{
+			name: "pending channels below limit",
+			policy: AcceptorPolicy{
+				MaxPendingPerPeer: 2,
+			},
+			peerIP: "192.168.1.1",
+		},
+		{
+			name: "pending channels limit reached",
+			policy: AcceptorPolicy{
+				MaxPendingPerPeer: 1,
+			},
+			peerIP: "192.168.1.1",
+			reject: true,
+		},
+		{
+			name: "commitment type allowed",
+			policy: AcceptorPolicy{
+				CommitmentTypes: anchors,
+			},
+			peerIP: "192.168.1.1",
+		},
+		{
+			name: "commitment type not allowed",
+			policy: AcceptorPolicy{
+				CommitmentTypes: taproot,
+			},
+			peerIP: "192.168.1.1",
+			reject: true,
+		},
+		{
+			name: "ip in allowed range",
+			policy: AcceptorPolicy{
+				AllowedIPRanges: []*net.IPNet{allowedRange},
+			},
+			peerIP: "10.1.2.3",
+		},
+		{
+			name: "ip not in allowed range",
+			policy: AcceptorPolicy{
+				AllowedIPRanges: []*net.IPNet{allowedRange},
+			},
+			peerIP: "192.168.1.1",
+			reject: true,
+		},
+		{
+			name: "zero conf peer",
+			policy: AcceptorPolicy{
+				ZeroConfPeers: map[route.Vertex]struct{}{
+					route.NewVertex(peerKey): {},
+				},
+			},
+			chanType:     &zeroConfType,
+			peerIP:       "192.168.1.1",
+			wantZeroConf: true,
+		},
+		{
+			name: "zero conf not requested",
+			policy: AcceptorPolicy{
+				ZeroConfPeers: map[route.Vertex]struct{}{
+					route.NewVertex(peerKey): {},
+				},
+			},
+			peerIP: "192.168.1.1",
+		},
+		{
+			name: "zero conf peer not allowed",
+			policy: AcceptorPolicy{
+				ZeroConfPeers: map[route.Vertex]struct{}{
+					route.NewVertex(otherPriv.PubKey()): {},
+				},
+			},
+			chanType: &zeroConfType,
+			peerIP:   "192.168.1.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			peer := &lnpeer.MockPeer{}
+			peer.On("Address").Return(&net.TCPAddr{
+				IP:   net.ParseIP(tc.peerIP),
+				Port: 9735,
+			}).Maybe()
+			peer.On("LocalFeatures").Return(features).Maybe()
+			peer.On("RemoteFeatures").Return(features).Maybe()
+
+			acceptor := NewPolicyAcceptor(PolicyAcceptorConfig{
+				FindPeer: func(*btcec.PublicKey) (lnpeer.Peer,
+					error) {
+
+					return peer, nil
+				},
+				FetchPendingChannels: func() (
+					[]*channeldb.OpenChannel, error) {
+
+					return pendingChans, nil
+				},
+			})
+			acceptor.SetPolicy(tc.policy)
+
+			req := &chanacceptor.ChannelAcceptRequest{
+				Node: peerKey,
+				OpenChanMsg: &lnwire.OpenChannel{
+					FundingAmount: 150_000,
+					ChannelType:   tc.chanType,
+				},
+			}
+			resp := acceptor.Accept(req)
+
+			require.Equal(t, tc.reject, resp.RejectChannel())
+			require.Equal(t, tc.wantZeroConf, resp.ZeroConf)
+		})
+	}
+}
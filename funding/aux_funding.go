@@ -0,0 +1,204 @@
+package funding
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// AuxFundingControllerVersion is the current version of the
+// AuxFundingController interface. It is bumped whenever the interface changes
+// in a way that isn't backwards compatible. Controllers that were built
+// against a different version are refused at registration time.
+const AuxFundingControllerVersion uint32 = 1
+
+// AuxFundingController is a plugin interface that allows overlay protocols to
+// attach custom data to channels during the funding flow without forking
+// lnd. The data of all controllers is stored with the channel state as a TLV
+// stream, where each controller owns a single type in the custom TLV range.
+type AuxFundingController interface {
+	// Name returns the unique name of the controller.
+	Name() string
+
+	// Version returns the version of the AuxFundingController interface
+	// the controller was built against.
+	Version() uint32
+
+	// ChannelDataType returns the TLV type the custom channel data of the
+	// controller is stored under. It must be in the custom TLV range and
+	// unique among all registered controllers.
+	ChannelDataType() tlv.Type
+
+	// DescFromPendingChanID is called when a reservation for a new
+	// channel is created. It returns the custom channel data the
+	// controller wants to attach to the channel, if any. Returning an
+	// error fails the funding flow.
+	DescFromPendingChanID(pendingChanID [32]byte, peer *btcec.PublicKey,
+		commitType lnwallet.CommitmentType,
+		initiator bool) (fn.Option[[]byte], error)
+
+	// ChannelReady is called once the channel is confirmed and marked as
+	// open in the database.
+	ChannelReady(openChan *channeldb.OpenChannel) error
+
+	// FundingFailed is called if the funding flow of a channel the
+	// controller was queried for is canceled before the channel is open.
+	FundingFailed(pendingChanID [32]byte) error
+}
+
+var (
+	// auxFundingControllers is the set of all registered aux funding
+	// controllers, keyed by their name.
+	auxFundingControllers = make(map[string]AuxFundingController)
+
+	// auxFundingMtx is a mutex that protects access to the above map.
+	auxFundingMtx sync.Mutex
+)
+
+// RegisterAuxFundingController registers an aux funding controller with the
+// funding package. Overlay protocols are meant to call this from their
+// package's init() method so that the controller is picked up when lnd
+// creates its funding manager.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisterAuxFundingController(controller AuxFundingController) error {
+	auxFundingMtx.Lock()
+	defer auxFundingMtx.Unlock()
+
+	name := controller.Name()
+	if controller.Version() != AuxFundingControllerVersion {
+		return fmt.Errorf("aux funding controller %v has version %v, "+
+			"expected %v", name, controller.Version(),
+			AuxFundingControllerVersion)
+	}
+
+	dataType := controller.ChannelDataType()
+	if dataType < lnwire.MinCustomRecordsTlvType {
+		return fmt.Errorf("aux funding controller %v uses channel "+
+			"data type %v below the custom range", name, dataType)
+	}
+
+	if _, ok := auxFundingControllers[name]; ok {
+		return fmt.Errorf("aux funding controller %v already "+
+			"registered", name)
+	}
+
+	for _, c := range auxFundingControllers {
+		if c.ChannelDataType() == dataType {
+			return fmt.Errorf("channel data type %v already used "+
+				"by aux funding controller %v", dataType,
+				c.Name())
+		}
+	}
+
+	auxFundingControllers[name] = controller
+
+	return nil
+}
+
+// RegisteredAuxFundingControllers returns all registered aux funding
+// controllers, sorted by their channel data type.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisteredAuxFundingControllers() []AuxFundingController {
+	auxFundingMtx.Lock()
+	defer auxFundingMtx.Unlock()
+
+	controllers := make(
+		[]AuxFundingController, 0, len(auxFundingControllers),
+	)
+	for _, controller := range auxFundingControllers {
+		controllers = append(controllers, controller)
+	}
+
+	sort.Slice(controllers, func(i, j int) bool {
+		return controllers[i].ChannelDataType() <
+			controllers[j].ChannelDataType()
+	})
+
+	return controllers
+}
+
+// AuxChannelData returns the custom channel data the controller with the given
+// channel data type attached to the channel, if any.
+func AuxChannelData(channel *channeldb.OpenChannel,
+	dataType tlv.Type) (fn.Option[[]byte], error) {
+
+	if len(channel.CustomBlob) == 0 {
+		return fn.None[[]byte](), nil
+	}
+
+	customRecords, err := lnwire.ParseCustomRecords(channel.CustomBlob)
+	if err != nil {
+		return fn.None[[]byte](), err
+	}
+
+	data, ok := customRecords[uint64(dataType)]
+	if !ok {
+		return fn.None[[]byte](), nil
+	}
+
+	return fn.Some(data), nil
+}
+
+// auxChannelData queries all aux funding controllers for the custom data they
+// want to attach to the channel with the given pending channel ID. The data is
+// returned as a serialized TLV stream, or nil if no controller attached
+// anything.
+func (f *Manager) auxChannelData(pendingChanID [32]byte,
+	peer *btcec.PublicKey, commitType lnwallet.CommitmentType,
+	initiator bool) ([]byte, error) {
+
+	customRecords := make(lnwire.CustomRecords)
+	for _, controller := range f.cfg.AuxFundingControllers {
+		desc, err := controller.DescFromPendingChanID(
+			pendingChanID, peer, commitType, initiator,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("aux funding controller %v: %w",
+				controller.Name(), err)
+		}
+
+		desc.WhenSome(func(data []byte) {
+			dataType := uint64(controller.ChannelDataType())
+			customRecords[dataType] = data
+		})
+	}
+
+	if len(customRecords) == 0 {
+		return nil, nil
+	}
+
+	return customRecords.Serialize()
+}
+
+// notifyAuxChannelReady informs all aux funding controllers that the given
+// channel is now open.
+func (f *Manager) notifyAuxChannelReady(channel *channeldb.OpenChannel) {
+	for _, controller := range f.cfg.AuxFundingControllers {
+		if err := controller.ChannelReady(channel); err != nil {
+			log.Errorf("Aux funding controller %v failed to "+
+				"handle ready channel %v: %v",
+				controller.Name(), channel.FundingOutpoint, err)
+		}
+	}
+}
+
+// notifyAuxFundingFailed informs all aux funding controllers that the funding
+// flow of the channel with the given pending channel ID failed.
+func (f *Manager) notifyAuxFundingFailed(pendingChanID [32]byte) {
+	for _, controller := range f.cfg.AuxFundingControllers {
+		if err := controller.FundingFailed(pendingChanID); err != nil {
+			log.Errorf("Aux funding controller %v failed to "+
+				"handle failed funding of pending channel %x: "+
+				"%v", controller.Name(), pendingChanID[:], err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package funding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAuxFundingController is a mock implementation of the
+// AuxFundingController interface that attaches its name to every channel.
+type mockAuxFundingController struct {
+	name     string
+	version  uint32
+	dataType tlv.Type
+
+	readyChans chan *channeldb.OpenChannel
+}
+
+func (m *mockAuxFundingController) Name() string {
+	return m.name
+}
+
+func (m *mockAuxFundingController) Version() uint32 {
+	return m.version
+}
+
+func (m *mockAuxFundingController) ChannelDataType() tlv.Type {
+	return m.dataType
+}
+
+func (m *mockAuxFundingController) DescFromPendingChanID([32]byte,
+	*btcec.PublicKey, lnwallet.CommitmentType,
+	bool) (fn.Option[[]byte], error) {
+
+	return fn.Some([]byte(m.name)), nil
+}
+
+func (m *mockAuxFundingController) ChannelReady(
+	openChan *channeldb.OpenChannel) error {
+
+	m.readyChans <- openChan
+
+	return nil
+}
+
+func (m *mockAuxFundingController) FundingFailed([32]byte) error {
+	return nil
+}
+
+// TestRegisterAuxFundingController tests that only controllers with the
+// current version and a unique channel data type in the custom range can be
+// registered.
+func TestRegisterAuxFundingController(t *testing.T) {
+	newController := func(name string, version uint32,
+		dataType tlv.Type) *mockAuxFundingController {
+
+		return &mockAuxFundingController{
+			name:     name,
+			version:  version,
+			dataType: dataType,
+		}
+	}
+
+	t.Cleanup(func() {
+		auxFundingMtx.Lock()
+		defer auxFundingMtx.Unlock()
+
+		delete(auxFundingControllers, "a")
+		delete(auxFundingControllers, "b")
+	})
+
+	const dataType = lnwire.MinCustomRecordsTlvType + 1
+
+	require.NoError(t, RegisterAuxFundingController(newController(
+		"b", AuxFundingControllerVersion, dataType+2,
+	)))
+	require.NoError(t, RegisterAuxFundingController(newController(
+		"a", AuxFundingControllerVersion, dataType+4,
+	)))
+
+	// Duplicate names and channel data types are refused.
+	require.Error(t, RegisterAuxFundingController(newController(
+		"a", AuxFundingControllerVersion, dataType,
+	)))
+	require.Error(t, RegisterAuxFundingController(newController(
+		"c", AuxFundingControllerVersion, dataType+2,
+	)))
+
+	// So are unknown versions and types outside of the custom range.
+	require.Error(t, RegisterAuxFundingController(newController(
+		"d", AuxFundingControllerVersion+1, dataType,
+	)))
+	require.Error(t, RegisterAuxFundingController(newController(
+		"e", AuxFundingControllerVersion, 1,
+	)))
+
+	// The registered controllers are sorted by their channel data type.
+	controllers := RegisteredAuxFundingControllers()
+	require.Len(t, controllers, 2)
+	require.Equal(t, "b", controllers[0].Name())
+	require.Equal(t, "a", controllers[1].Name())
+}
+
+// TestAuxFundingControllerChannelData tests that the custom channel data of
+// an aux funding controller is stored with the channel state of both parties
+// and handed to the controller once the channel is open.
+func TestAuxFundingControllerChannelData(t *testing.T) {
+	t.Parallel()
+
+	controller := &mockAuxFundingController{
+		name:       "mock",
+		version:    AuxFundingControllerVersion,
+		dataType:   lnwire.MinCustomRecordsTlvType + 1,
+		readyChans: make(chan *channeldb.OpenChannel, 2),
+	}
+
+	alice, bob := setupFundingManagers(t, func(cfg *Config) {
+		cfg.AuxFundingControllers = []AuxFundingController{controller}
+	})
+	t.Cleanup(func() {
+		tearDownFundingManagers(t, alice, bob)
+	})
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, btcutil.Amount(500000), 0, 1, updateChan, true,
+		nil,
+	)
+
+	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	bob.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	assertMarkedOpen(t, alice, bob, fundingOutPoint)
+
+	// Both parties should have notified the controller about the open
+	// channel, which carries the custom data it attached.
+	for i := 0; i < 2; i++ {
+		select {
+		case channel := <-controller.readyChans:
+			require.Equal(
+				t, *fundingOutPoint, channel.FundingOutpoint,
+			)
+
+			data, err := AuxChannelData(
+				channel, controller.ChannelDataType(),
+			)
+			require.NoError(t, err)
+			require.Equal(
+				t, fn.Some([]byte(controller.name)), data,
+			)
+
+		case <-time.After(time.Second * 5):
+			t.Fatalf("controller not notified about open channel")
+		}
+	}
+}
@@ -253,6 +253,9 @@ func (b *Batcher) BatchFund(ctx context.Context,
 			FeeRate:                    rpcChannel.FeeRate,
 			UseBaseFee:                 rpcChannel.UseBaseFee,
 			UseFeeRate:                 rpcChannel.UseFeeRate,
+			TimeLockDelta:              rpcChannel.TimeLockDelta,
+			FwdMinHtlcMsat:             rpcChannel.FwdMinHtlcMsat,
+			FwdMaxHtlcMsat:             rpcChannel.FwdMaxHtlcMsat,
 			RemoteChanReserveSat:       rpcChannel.RemoteChanReserveSat,
 			Memo:                       rpcChannel.Memo,
 			FundingShim: &lnrpc.FundingShim{
@@ -28,12 +28,14 @@ import (
 	"github.com/lightningnetwork/lnd/labels"
 	"github.com/lightningnetwork/lnd/lnpeer"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnutils"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/sweep"
 	"golang.org/x/crypto/salsa20"
 )
 
@@ -118,9 +120,9 @@ var (
 	ErrFundingManagerShuttingDown = errors.New("funding manager shutting " +
 		"down")
 
-	// ErrConfirmationTimeout is an error returned when we as a responder
-	// are waiting for a funding transaction to confirm, but too many
-	// blocks pass without confirmation.
+	// ErrConfirmationTimeout is an error returned when we are waiting for
+	// a funding transaction to confirm, but more blocks than the
+	// configured funding timeout pass without confirmation.
 	ErrConfirmationTimeout = errors.New("timeout waiting for funding " +
 		"confirmation")
 
@@ -222,6 +224,18 @@ type InitFundingMsg struct {
 	// (millionths).
 	FeeRate *uint64
 
+	// TimeLockDelta is the CLTV delta of the initial forwarding policy. If
+	// zero, the default time lock delta is used.
+	TimeLockDelta uint32
+
+	// FwdMinHTLC is the smallest HTLC we forward over the channel. If
+	// zero, the default minimum is used.
+	FwdMinHTLC lnwire.MilliSatoshi
+
+	// FwdMaxHTLC is the largest HTLC we forward over the channel. If zero,
+	// the default maximum is used.
+	FwdMaxHTLC lnwire.MilliSatoshi
+
 	// PushAmt is the amount pushed to the counterparty.
 	PushAmt lnwire.MilliSatoshi
 
@@ -278,6 +292,10 @@ type InitFundingMsg struct {
 	// allocated towards channel funding.
 	Outpoints []wire.OutPoint
 
+	// Account is the name of the wallet account whose UTXOs should be used
+	// for funding the channel. If empty, the default account is used.
+	Account string
+
 	// ChanFunder is an optional channel funder that allows the caller to
 	// control exactly how the channel funding is carried out. If not
 	// specified, then the default chanfunding.WalletAssembler will be
@@ -543,6 +561,32 @@ type Config struct {
 	// backed funding flow to not use utxos still being swept by the sweeper
 	// subsystem.
 	IsSweeperOutpoint func(wire.OutPoint) bool
+
+	// InitiatorFundingTimeout is the number of blocks after which a
+	// pending channel we initiated is forgotten if its funding transaction
+	// didn't confirm. A value of zero disables the timeout.
+	InitiatorFundingTimeout uint32
+
+	// ResponderFundingTimeout is the number of blocks after which a
+	// pending channel opened by a remote peer is forgotten if its funding
+	// transaction didn't confirm. If zero, MaxWaitNumBlocksFundingConf is
+	// used.
+	ResponderFundingTimeout uint32
+
+	// DoubleSpendTimedOutFunding specifies whether the wallet inputs of
+	// the funding transaction of a timed out channel we initiated should
+	// be double spent back to the wallet.
+	DoubleSpendTimedOutFunding bool
+
+	// NotifyFundingTimeoutEvent informs the ChannelNotifier when a pending
+	// channel is forgotten because its funding transaction didn't confirm
+	// in time.
+	NotifyFundingTimeoutEvent func(wire.OutPoint)
+
+	// AuxFundingControllers is the set of aux funding controllers that
+	// attach custom data to new channels. They are queried in order for
+	// each funding flow.
+	AuxFundingControllers []AuxFundingController
 }
 
 // Manager acts as an orchestrator/bridge between the wallet's
@@ -1276,6 +1320,7 @@ func (f *Manager) advancePendingChannelState(
 		// Inform the ChannelNotifier that the channel has transitioned
 		// from pending open to open.
 		f.cfg.NotifyOpenChannelEvent(channel.FundingOutpoint)
+		f.notifyAuxChannelReady(channel)
 
 		// Find and close the discoverySignal for this channel such
 		// that ChannelReady messages will be processed.
@@ -1626,9 +1671,22 @@ func (f *Manager) fundeeProcessOpenChannel(peer lnpeer.Peer,
 		ScidAliasFeature: scidFeatureVal,
 	}
 
+	// Give the aux funding controllers the chance to attach custom data
+	// to the channel.
+	req.CustomBlob, err = f.auxChannelData(
+		msg.PendingChannelID, peer.IdentityKey(), commitType, false,
+	)
+	if err != nil {
+		log.Errorf("Unable to obtain aux channel data: %v", err)
+		f.notifyAuxFundingFailed(msg.PendingChannelID)
+		f.failFundingFlow(peer, cid, err)
+		return
+	}
+
 	reservation, err := f.cfg.Wallet.InitChannelReservation(req)
 	if err != nil {
 		log.Errorf("Unable to initialize reservation: %v", err)
+		f.notifyAuxFundingFailed(msg.PendingChannelID)
 		f.failFundingFlow(peer, cid, err)
 		return
 	}
@@ -2744,14 +2802,13 @@ type confirmedChannel struct {
 
 // fundingTimeout is called when callers of waitForFundingWithTimeout receive
 // an ErrConfirmationTimeout. It is used to clean-up channel state and mark the
-// channel as closed. The error is only returned for the responder of the
-// channel flow.
+// channel as closed. If we initiated the channel, the inputs of the funding
+// transaction are released as well.
 func (f *Manager) fundingTimeout(c *channeldb.OpenChannel,
 	pendingID [32]byte) error {
 
 	// We'll get a timeout if the number of blocks mined since the channel
-	// was initiated reaches MaxWaitNumBlocksFundingConf and we are not the
-	// channel initiator.
+	// was initiated reaches the configured funding timeout.
 	localBalance := c.LocalCommitment.LocalBalance.ToSatoshis()
 	closeInfo := &channeldb.ChannelCloseSummary{
 		ChainHash:               c.ChainHash,
@@ -2765,6 +2822,17 @@ func (f *Manager) fundingTimeout(c *channeldb.OpenChannel,
 		LocalChanConfig:         c.LocalChanCfg,
 	}
 
+	// If we funded the channel, our wallet inputs are still locked by the
+	// funding transaction, so we'll release them now. If the inputs were
+	// double spent back to our wallet, we record the double spend as the
+	// closing transaction of the channel.
+	if c.IsInitiator && c.ChanType.HasFundingTx() && c.FundingTxn != nil {
+		doubleSpendTxid := f.cleanupTimedOutFunding(c)
+		if doubleSpendTxid != nil {
+			closeInfo.ClosingTXID = *doubleSpendTxid
+		}
+	}
+
 	// Close the channel with us as the initiator because we are timing the
 	// channel out.
 	if err := c.CloseChannel(
@@ -2774,6 +2842,9 @@ func (f *Manager) fundingTimeout(c *channeldb.OpenChannel,
 			c.FundingOutpoint, err)
 	}
 
+	// Inform the ChannelNotifier that the pending channel was forgotten.
+	f.cfg.NotifyFundingTimeoutEvent(c.FundingOutpoint)
+
 	timeoutErr := fmt.Errorf("timeout waiting for funding tx (%v) to "+
 		"confirm", c.FundingOutpoint)
 
@@ -2814,11 +2885,159 @@ func (f *Manager) fundingTimeout(c *channeldb.OpenChannel,
 	return timeoutErr
 }
 
+// cleanupTimedOutFunding releases the wallet inputs of the funding transaction
+// of a timed out channel we initiated. If configured, the inputs are double
+// spent back to our wallet afterwards, in which case the txid of the double
+// spend is returned.
+func (f *Manager) cleanupTimedOutFunding(
+	c *channeldb.OpenChannel) *chainhash.Hash {
+
+	fundingTx := c.FundingTxn
+	fundingTxid := fundingTx.TxHash()
+
+	// We no longer want to rebroadcast the funding transaction. We also
+	// remove it from the wallet's tx store, so its inputs are considered
+	// unspent again.
+	f.cfg.Wallet.CancelRebroadcast(fundingTxid)
+	if err := f.cfg.Wallet.RemoveDescendants(fundingTx); err != nil {
+		log.Errorf("Unable to remove funding tx %v from wallet: %v",
+			fundingTxid, err)
+	}
+
+	var walletUtxos []*lnwallet.Utxo
+	for _, txIn := range fundingTx.TxIn {
+		prevOut := txIn.PreviousOutPoint
+
+		// Inputs that don't belong to our wallet, for example those of
+		// an externally funded channel, are skipped.
+		utxo, err := f.cfg.Wallet.FetchInputInfo(&prevOut)
+		if err != nil {
+			log.Debugf("Skipping funding input %v not owned by "+
+				"wallet: %v", prevOut, err)
+
+			continue
+		}
+		walletUtxos = append(walletUtxos, utxo)
+
+		err = f.cfg.Wallet.ReleaseOutput(
+			chanfunding.LndInternalLockID, prevOut,
+		)
+		if err != nil {
+			log.Debugf("Unable to release funding input %v: %v",
+				prevOut, err)
+		}
+	}
+
+	log.Infof("Released %d wallet inputs of timed out funding tx %v",
+		len(walletUtxos), fundingTxid)
+
+	if !f.cfg.DoubleSpendTimedOutFunding || len(walletUtxos) == 0 {
+		return nil
+	}
+
+	txid, err := f.doubleSpendFundingInputs(fundingTx, walletUtxos)
+	if err != nil {
+		log.Errorf("Unable to double spend inputs of funding tx %v: "+
+			"%v", fundingTxid, err)
+
+		return nil
+	}
+
+	log.Infof("Double spent inputs of timed out funding tx %v with "+
+		"tx %v", fundingTxid, txid)
+
+	return txid
+}
+
+// doubleSpendFundingInputs spends the given wallet inputs of a timed out
+// funding transaction back to a fresh wallet address, making sure the funding
+// transaction can never confirm. To be able to replace the funding
+// transaction in the mempool, the double spend pays a higher absolute fee
+// than the funding transaction.
+func (f *Manager) doubleSpendFundingInputs(fundingTx *wire.MsgTx,
+	utxos []*lnwallet.Utxo) (*chainhash.Hash, error) {
+
+	// Determine the fee paid by the funding transaction. As we only know
+	// the value of our own inputs, this is a lower bound if the funding
+	// transaction also spends foreign inputs.
+	var inputTotal, outputTotal btcutil.Amount
+	for _, utxo := range utxos {
+		inputTotal += utxo.Value
+	}
+	for _, txOut := range fundingTx.TxOut {
+		outputTotal += btcutil.Amount(txOut.Value)
+	}
+
+	var fundingFee btcutil.Amount
+	if inputTotal > outputTotal {
+		fundingFee = inputTotal - outputTotal
+	}
+
+	feeRate, err := f.cfg.FeeEstimator.EstimateFeePerKW(6)
+	if err != nil {
+		return nil, fmt.Errorf("unable to estimate fee rate: %w", err)
+	}
+
+	_, bestHeight, err := f.cfg.Wallet.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch best block: %w", err)
+	}
+
+	addr, err := f.cfg.Wallet.NewAddress(
+		lnwallet.TaprootPubkey, false, lnwallet.DefaultAccountName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create address: %w", err)
+	}
+
+	craftTx := func(rate chainfee.SatPerKWeight) (*wire.MsgTx,
+		btcutil.Amount, error) {
+
+		return sweep.CraftWalletUtxoSweepTx(
+			utxos, rate, rate, uint32(bestHeight), addr,
+			f.cfg.Wallet.Cfg.Signer,
+		)
+	}
+
+	doubleSpendTx, fee, err := craftTx(feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The replacement needs to pay for its own relay bandwidth on top of
+	// the fee of the replaced funding transaction. If the estimated fee
+	// rate doesn't achieve that, we'll raise it accordingly.
+	weight := lntypes.WeightUnit(
+		blockchain.GetTransactionWeight(btcutil.NewTx(doubleSpendTx)),
+	)
+	minFee := fundingFee + chainfee.FeePerKwFloor.FeeForWeight(weight)
+	if fee < minFee {
+		feeRate = chainfee.SatPerKWeight(
+			minFee*1000/btcutil.Amount(weight),
+		) + 1
+
+		doubleSpendTx, _, err = craftTx(feeRate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	label := labels.MakeLabel(labels.LabelTypeSweepTransaction, nil)
+	if err := f.cfg.PublishTransaction(doubleSpendTx, label); err != nil {
+		return nil, fmt.Errorf("unable to publish double spend: %w",
+			err)
+	}
+
+	txid := doubleSpendTx.TxHash()
+
+	return &txid, nil
+}
+
 // waitForFundingWithTimeout is a wrapper around waitForFundingConfirmation and
-// waitForTimeout that will return ErrConfirmationTimeout if we are not the
-// channel initiator and the MaxWaitNumBlocksFundingConf has passed from the
-// funding broadcast height. In case of confirmation, the short channel ID of
-// the channel and the funding transaction will be returned.
+// waitForTimeout that will return ErrConfirmationTimeout if the configured
+// funding timeout for our role in the channel has passed from the funding
+// broadcast height. In case of confirmation, the short channel ID of the
+// channel and the funding transaction will be returned.
 func (f *Manager) waitForFundingWithTimeout(
 	ch *channeldb.OpenChannel) (*confirmedChannel, error) {
 
@@ -2831,10 +3050,12 @@ func (f *Manager) waitForFundingWithTimeout(
 
 	// If we are not the initiator, we have no money at stake and will
 	// timeout waiting for the funding transaction to confirm after a
-	// while.
-	if !ch.IsInitiator && !ch.IsZeroConf() {
+	// while. As the initiator, we only give up on the funding transaction
+	// if a timeout was configured explicitly.
+	timeout := f.fundingTimeoutBlocks(ch)
+	if timeout != 0 && !ch.IsZeroConf() {
 		f.wg.Add(1)
-		go f.waitForTimeout(ch, cancelChan, timeoutChan)
+		go f.waitForTimeout(ch, timeout, cancelChan, timeoutChan)
 	}
 	defer close(cancelChan)
 
@@ -2859,6 +3080,21 @@ func (f *Manager) waitForFundingWithTimeout(
 	}
 }
 
+// fundingTimeoutBlocks returns the number of blocks after which we give up
+// waiting for the funding transaction of the given channel to confirm. A
+// return value of zero means we'll wait indefinitely.
+func (f *Manager) fundingTimeoutBlocks(ch *channeldb.OpenChannel) uint32 {
+	if ch.IsInitiator {
+		return f.cfg.InitiatorFundingTimeout
+	}
+
+	if f.cfg.ResponderFundingTimeout == 0 {
+		return MaxWaitNumBlocksFundingConf
+	}
+
+	return f.cfg.ResponderFundingTimeout
+}
+
 // makeFundingScript re-creates the funding script for the funding transaction
 // of the target channel.
 func makeFundingScript(channel *channeldb.OpenChannel) ([]byte, error) {
@@ -2987,15 +3223,15 @@ func (f *Manager) waitForFundingConfirmation(
 	}
 }
 
-// waitForTimeout will close the timeout channel if MaxWaitNumBlocksFundingConf
-// has passed from the broadcast height of the given channel. In case of error,
-// the error is sent on timeoutChan. The wait can be canceled by closing the
-// cancelChan.
+// waitForTimeout will close the timeout channel if numBlocks have passed from
+// the broadcast height of the given channel. In case of error, the error is
+// sent on timeoutChan. The wait can be canceled by closing the cancelChan.
 //
 // NOTE: timeoutChan MUST be buffered.
 // NOTE: This MUST be run as a goroutine.
 func (f *Manager) waitForTimeout(completeChan *channeldb.OpenChannel,
-	cancelChan <-chan struct{}, timeoutChan chan<- error) {
+	numBlocks uint32, cancelChan <-chan struct{},
+	timeoutChan chan<- error) {
 
 	defer f.wg.Done()
 
@@ -3010,7 +3246,7 @@ func (f *Manager) waitForTimeout(completeChan *channeldb.OpenChannel,
 
 	// On block maxHeight we will cancel the funding confirmation wait.
 	broadcastHeight := completeChan.BroadcastHeight()
-	maxHeight := broadcastHeight + MaxWaitNumBlocksFundingConf
+	maxHeight := broadcastHeight + numBlocks
 	for {
 		select {
 		case epoch, ok := <-epochClient.Epochs:
@@ -3025,18 +3261,13 @@ func (f *Manager) waitForTimeout(completeChan *channeldb.OpenChannel,
 			if uint32(epoch.Height) >= maxHeight {
 				log.Warnf("Waited for %v blocks without "+
 					"seeing funding transaction confirmed,"+
-					" cancelling.",
-					MaxWaitNumBlocksFundingConf)
+					" cancelling.", numBlocks)
 
 				// Notify the caller of the timeout.
 				close(timeoutChan)
 				return
 			}
 
-			// TODO: If we are the channel initiator implement
-			// a method for recovering the funds from the funding
-			// transaction
-
 		case <-cancelChan:
 			return
 
@@ -3142,6 +3373,7 @@ func (f *Manager) handleFundingConfirmation(
 	// Inform the ChannelNotifier that the channel has transitioned from
 	// pending open to open.
 	f.cfg.NotifyOpenChannelEvent(completeChan.FundingOutpoint)
+	f.notifyAuxChannelReady(completeChan)
 
 	// Close the discoverySignal channel, indicating to a separate
 	// goroutine that the channel now is marked as open in the database
@@ -4237,6 +4469,26 @@ func (f *Manager) newChanAnnouncement(localPubKey,
 		chanUpdateAnn.BaseFee = uint32(storedFwdingPolicy.BaseFee)
 		chanUpdateAnn.FeeRate = uint32(storedFwdingPolicy.FeeRate)
 
+		if storedFwdingPolicy.TimeLockDelta != 0 {
+			chanUpdateAnn.TimeLockDelta = uint16(
+				storedFwdingPolicy.TimeLockDelta,
+			)
+		}
+
+		// The HTLC limits of the stored policy may only narrow the
+		// range that the channel constraints allow for.
+		maxHTLC := storedFwdingPolicy.MaxHTLC
+		if maxHTLC != 0 && maxHTLC < chanUpdateAnn.HtlcMaximumMsat {
+			chanUpdateAnn.HtlcMaximumMsat = maxHTLC
+		}
+
+		minHTLC := storedFwdingPolicy.MinHTLCOut
+		if minHTLC > chanUpdateAnn.HtlcMinimumMsat &&
+			minHTLC <= chanUpdateAnn.HtlcMaximumMsat {
+
+			chanUpdateAnn.HtlcMinimumMsat = minHTLC
+		}
+
 	default:
 		log.Infof("No channel forwarding policy specified for channel "+
 			"announcement of ChannelID(%v). "+
@@ -4456,6 +4708,75 @@ func getUpfrontShutdownScript(enableUpfrontShutdown bool, peer lnpeer.Peer,
 	return getScript(taprootOK)
 }
 
+// allowUtxoForFunding returns true if the given utxo may be used to fund a
+// channel. Unconfirmed utxos which are marked by the sweeper subsystem are
+// excluded from the coin selection because they are not final and can be
+// RBFed by the sweeper subsystem.
+func (f *Manager) allowUtxoForFunding(u lnwallet.Utxo) bool {
+	// Utxos with at least 1 confirmation are safe to use for channel
+	// openings because they don't bare the risk of being replaced (BIP 125
+	// RBF).
+	if u.Confirmations > 0 {
+		return true
+	}
+
+	// Query the sweeper storage to make sure we don't use an unconfirmed
+	// utxo still in use by the sweeper subsystem.
+	return !f.cfg.IsSweeperOutpoint(u.OutPoint)
+}
+
+// EstimateFundingFee runs the coin selection of the funding transaction that
+// would be created for the given funding request in dry run mode. No
+// reservation is created and no coins are leased. The peer of the request
+// must be set as the commitment type is negotiated the same way as for an
+// actual channel opening.
+func (f *Manager) EstimateFundingFee(
+	msg *InitFundingMsg) (*lnwallet.FundingEstimate, error) {
+
+	if msg.Peer == nil {
+		return nil, fmt.Errorf("peer must be set")
+	}
+
+	chanType, commitType, err := negotiateCommitmentType(
+		msg.ChannelType, msg.Peer.LocalFeatures(),
+		msg.Peer.RemoteFeatures(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var zeroConf bool
+	if chanType != nil {
+		featureVec := lnwire.RawFeatureVector(*chanType)
+		zeroConf = featureVec.IsSet(lnwire.ZeroConfRequired)
+	}
+
+	var channelFlags lnwire.FundingFlag
+	if !msg.Private {
+		channelFlags = lnwire.FFAnnounceChannel
+	}
+
+	req := &lnwallet.InitFundingReserveMsg{
+		ChainHash:           &msg.ChainHash,
+		SubtractFees:        msg.SubtractFees,
+		LocalFundingAmt:     msg.LocalFundingAmt,
+		FundUpToMaxAmt:      msg.FundUpToMaxAmt,
+		MinFundAmt:          msg.MinFundAmt,
+		RemoteChanReserve:   msg.RemoteChanReserve,
+		Outpoints:           msg.Outpoints,
+		Account:             msg.Account,
+		FundingFeePerKw:     msg.FundingFeePerKw,
+		PushMSat:            msg.PushAmt,
+		Flags:               channelFlags,
+		MinConfs:            msg.MinConfs,
+		CommitType:          commitType,
+		AllowUtxoForFunding: f.allowUtxoForFunding,
+		ZeroConf:            zeroConf,
+	}
+
+	return f.cfg.Wallet.EstimateFundingTx(req)
+}
+
 // handleInitFundingMsg creates a channel reservation within the daemon's
 // wallet, then sends a funding request to the remote peer kicking off the
 // funding workflow.
@@ -4604,6 +4925,7 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 		MinFundAmt:        msg.MinFundAmt,
 		RemoteChanReserve: chanReserve,
 		Outpoints:         outpoints,
+		Account:           msg.Account,
 		CommitFeePerKw:    commitFeePerKw,
 		FundingFeePerKw:   msg.FundingFeePerKw,
 		PushMSat:          msg.PushAmt,
@@ -4612,29 +4934,28 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 		CommitType:        commitType,
 		ChanFunder:        msg.ChanFunder,
 		// Unconfirmed Utxos which are marked by the sweeper subsystem
-		// are excluded from the coin selection because they are not
-		// final and can be RBFed by the sweeper subsystem.
-		AllowUtxoForFunding: func(u lnwallet.Utxo) bool {
-			// Utxos with at least 1 confirmation are safe to use
-			// for channel openings because they don't bare the risk
-			// of being replaced (BIP 125 RBF).
-			if u.Confirmations > 0 {
-				return true
-			}
-
-			// Query the sweeper storage to make sure we don't use
-			// an unconfirmed utxo still in use by the sweeper
-			// subsystem.
-			return !f.cfg.IsSweeperOutpoint(u.OutPoint)
-		},
-		ZeroConf:         zeroConf,
-		OptionScidAlias:  scid,
-		ScidAliasFeature: scidFeatureVal,
-		Memo:             msg.Memo,
+		// are excluded from the coin selection.
+		AllowUtxoForFunding: f.allowUtxoForFunding,
+		ZeroConf:            zeroConf,
+		OptionScidAlias:     scid,
+		ScidAliasFeature:    scidFeatureVal,
+		Memo:                msg.Memo,
+	}
+
+	// Give the aux funding controllers the chance to attach custom data
+	// to the channel.
+	req.CustomBlob, err = f.auxChannelData(
+		chanID, peerKey, commitType, true,
+	)
+	if err != nil {
+		f.notifyAuxFundingFailed(chanID)
+		msg.Err <- err
+		return
 	}
 
 	reservation, err := f.cfg.Wallet.InitChannelReservation(req)
 	if err != nil {
+		f.notifyAuxFundingFailed(chanID)
 		msg.Err <- err
 		return
 	}
@@ -4702,6 +5023,20 @@ func (f *Manager) handleInitFundingMsg(msg *InitFundingMsg) {
 		forwardingPolicy.FeeRate = lnwire.MilliSatoshi(*feeRate)
 	}
 
+	// The remaining parameters of the initial forwarding policy are only
+	// overwritten if they were provided by the client.
+	if msg.TimeLockDelta != 0 {
+		forwardingPolicy.TimeLockDelta = msg.TimeLockDelta
+	}
+
+	if msg.FwdMinHTLC != 0 {
+		forwardingPolicy.MinHTLCOut = msg.FwdMinHTLC
+	}
+
+	if msg.FwdMaxHTLC != 0 {
+		forwardingPolicy.MaxHTLC = msg.FwdMaxHTLC
+	}
+
 	// Fetch our dust limit which is part of the default channel
 	// constraints, and log it.
 	ourDustLimit := ourContribution.DustLimit
@@ -4959,6 +5294,9 @@ func (f *Manager) cancelReservationCtx(peerKey *btcec.PublicKey,
 	if len(nodeReservations) == 0 {
 		delete(f.activeReservations, peerIDKey)
 	}
+
+	f.notifyAuxFundingFailed(pendingChanID)
+
 	return ctx, nil
 }
 
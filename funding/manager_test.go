@@ -228,6 +228,7 @@ func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint, _ []byte,
 type mockChanEvent struct {
 	openEvent        chan wire.OutPoint
 	pendingOpenEvent chan channelnotifier.PendingOpenChannelEvent
+	timeoutEvent     chan wire.OutPoint
 }
 
 func (m *mockChanEvent) NotifyOpenChannelEvent(outpoint wire.OutPoint) {
@@ -243,6 +244,10 @@ func (m *mockChanEvent) NotifyPendingOpenChannelEvent(outpoint wire.OutPoint,
 	}
 }
 
+func (m *mockChanEvent) NotifyFundingTimeoutEvent(outpoint wire.OutPoint) {
+	m.timeoutEvent <- outpoint
+}
+
 // mockZeroConfAcceptor always accepts the channel open request for zero-conf
 // channels. It will set the ZeroConf bool in the ChannelAcceptResponse. This
 // is needed to properly unit test the zero-conf logic in the funding manager.
@@ -421,6 +426,7 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 			chan channelnotifier.PendingOpenChannelEvent,
 			maxPending,
 		),
+		timeoutEvent: make(chan wire.OutPoint, maxPending),
 	}
 
 	dbDir := filepath.Join(tempTestDir, "cdb")
@@ -550,6 +556,7 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		NotifyOpenChannelEvent:        evt.NotifyOpenChannelEvent,
 		OpenChannelPredicate:          chainedAcceptor,
 		NotifyPendingOpenChannelEvent: evt.NotifyPendingOpenChannelEvent,
+		NotifyFundingTimeoutEvent:     evt.NotifyFundingTimeoutEvent,
 		DeleteAliasEdge: func(scid lnwire.ShortChannelID) (
 			*models.ChannelEdgePolicy, error) {
 
@@ -670,6 +677,11 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		OpenChannelPredicate:  chainedAcceptor,
 		DeleteAliasEdge:       oldCfg.DeleteAliasEdge,
 		AliasManager:          oldCfg.AliasManager,
+
+		InitiatorFundingTimeout:    oldCfg.InitiatorFundingTimeout,
+		ResponderFundingTimeout:    oldCfg.ResponderFundingTimeout,
+		DoubleSpendTimedOutFunding: oldCfg.DoubleSpendTimedOutFunding,
+		NotifyFundingTimeoutEvent:  oldCfg.NotifyFundingTimeoutEvent,
 	})
 	require.NoError(t, err, "failed recreating aliceFundingManager")
 
@@ -2415,6 +2427,99 @@ func TestFundingManagerFundingNotTimeoutInitiator(t *testing.T) {
 	assertNumPendingChannelsBecomes(t, bob, 0)
 }
 
+// TestFundingManagerFundingTimeoutInitiator checks that a pending channel we
+// initiated is forgotten once the configured initiator funding timeout has
+// passed, and that the inputs of the funding transaction are double spent back
+// to our wallet if configured.
+func TestFundingManagerFundingTimeoutInitiator(t *testing.T) {
+	t.Parallel()
+
+	const timeout = 144
+
+	alice, bob := setupFundingManagers(t, func(cfg *Config) {
+		cfg.InitiatorFundingTimeout = timeout
+		cfg.DoubleSpendTimedOutFunding = true
+	})
+	t.Cleanup(func() {
+		tearDownFundingManagers(t, alice, bob)
+	})
+
+	// Give Alice's wallet a spendable coin with a proper outpoint, so the
+	// funding inputs can be double spent.
+	aliceWallet := alice.fundingMgr.cfg.Wallet
+	wc, ok := aliceWallet.WalletController.(*mock.WalletController)
+	require.True(t, ok)
+	wc.Utxos = []*lnwallet.Utxo{{
+		AddressType: lnwallet.WitnessPubKey,
+		Value:       10 * btcutil.SatoshiPerBitcoin,
+		PkScript:    mock.CoinPkScript,
+		OutPoint: wire.OutPoint{
+			Hash:  chainhash.Hash{1},
+			Index: 1,
+		},
+	}}
+
+	// We will consume the channel updates as we go, so no buffering is
+	// needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, 500000, 0, 1, updateChan, true, nil,
+	)
+
+	// One block before the timeout, Alice should still be waiting for the
+	// channel to open.
+	alice.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
+		Height: fundingBroadcastHeight + timeout - 1,
+	}
+	assertNumPendingChannelsRemains(t, alice, 1)
+
+	alice.mockNotifier.epochChan <- &chainntnfs.BlockEpoch{
+		Height: fundingBroadcastHeight + timeout,
+	}
+
+	// Alice should now double spend the inputs of the funding transaction
+	// back to her wallet.
+	var doubleSpendTx *wire.MsgTx
+	select {
+	case doubleSpendTx = <-alice.publTxChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not publish double spend tx")
+	}
+
+	require.Len(t, doubleSpendTx.TxIn, len(fundingTx.TxIn))
+	for i, txIn := range doubleSpendTx.TxIn {
+		require.Equal(
+			t, fundingTx.TxIn[i].PreviousOutPoint,
+			txIn.PreviousOutPoint,
+		)
+	}
+
+	// A funding timeout event should be emitted for the channel.
+	select {
+	case op := <-alice.mockChanEvent.timeoutEvent:
+		require.Equal(t, *fundingOutPoint, op)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not emit funding timeout event")
+	}
+
+	// Alice should have sent an Error message to Bob.
+	assertErrorSent(t, alice.msgChan)
+
+	// The channel should no longer be pending, and its close summary
+	// should reference the double spend.
+	assertNumPendingChannelsBecomes(t, alice, 0)
+
+	closeSummary, err := alice.fundingMgr.cfg.ChannelDB.FetchClosedChannel(
+		fundingOutPoint,
+	)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.FundingCanceled, closeSummary.CloseType)
+	require.Equal(t, doubleSpendTx.TxHash(), closeSummary.ClosingTXID)
+}
+
 // TestFundingManagerReceiveChannelReadyTwice checks that the fundingManager
 // continues to operate as expected in case we receive a duplicate channelReady
 // message.
@@ -3019,6 +3124,14 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		feeRate uint64 = 1337
 	)
 
+	// Use a custom time lock delta and HTLC limits for the initial
+	// forwarding policy as well.
+	const (
+		timeLockDelta = 80
+		fwdMinHtlc    = 2000
+		fwdMaxHtlc    = 1_000_000_000
+	)
+
 	// We will consume the channel updates as we go, so no buffering is
 	// needed.
 	updateChan := make(chan *lnrpc.OpenStatusUpdate)
@@ -3045,6 +3158,9 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 		Err:               errChan,
 		BaseFee:           &baseFee,
 		FeeRate:           &feeRate,
+		TimeLockDelta:     timeLockDelta,
+		FwdMinHTLC:        fwdMinHtlc,
+		FwdMaxHTLC:        fwdMaxHtlc,
 	}
 
 	alice.fundingMgr.InitFundingWorkflow(initReq)
@@ -3340,21 +3456,19 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 
 	// Make sure both fundingManagers send the expected channel
 	// announcements.
-	// Alice should advertise the default MinHTLC value of
-	// 5, while bob should advertise the value minHtlc, since Alice
-	// required him to use it.
-	minHtlcArr := []lnwire.MilliSatoshi{5, minHtlcIn}
+	// Alice should advertise her custom MinHTLC value instead of the
+	// default value of 5, while bob should advertise the value minHtlc,
+	// since Alice required him to use it.
+	minHtlcArr := []lnwire.MilliSatoshi{fwdMinHtlc, minHtlcIn}
 
-	// For maxHltc Alice should advertise the default MaxHtlc value of
-	// maxValueAcceptChannel, while bob should advertise the value
-	// maxValueInFlight since Alice required him to use it.
-	maxHtlcArr := []lnwire.MilliSatoshi{
-		maxValueAcceptChannel, maxValueInFlight,
-	}
+	// For maxHltc Alice should advertise her custom MaxHtlc value, as it
+	// is below the default value of maxValueAcceptChannel, while bob
+	// should advertise the value maxValueInFlight since Alice required him
+	// to use it.
+	maxHtlcArr := []lnwire.MilliSatoshi{fwdMaxHtlc, maxValueInFlight}
 
 	// Alice should have custom fees set whereas Bob should see his
 	// configured default fees announced.
-	defaultDelta := bob.fundingMgr.cfg.DefaultRoutingPolicy.TimeLockDelta
 	defaultBaseFee := bob.fundingMgr.cfg.DefaultRoutingPolicy.BaseFee
 	defaultFeeRate := bob.fundingMgr.cfg.DefaultRoutingPolicy.FeeRate
 	baseFees := []lnwire.MilliSatoshi{
@@ -3385,7 +3499,7 @@ func TestFundingManagerCustomChannelParameters(t *testing.T) {
 						MinHTLCOut:    minHtlcArr[0],
 						BaseFee:       baseFees[0],
 						FeeRate:       feeRates[0],
-						TimeLockDelta: defaultDelta,
+						TimeLockDelta: timeLockDelta,
 					},
 				)
 			}
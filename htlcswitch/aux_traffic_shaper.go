@@ -0,0 +1,244 @@
+package htlcswitch
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/htlcswitch/hop"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// AuxTrafficShaperVersion is the current version of the AuxTrafficShaper
+// interface. It is bumped whenever the interface changes in a way that isn't
+// backwards compatible. Shapers that were built against a different version
+// are refused at registration time.
+const AuxTrafficShaperVersion uint32 = 1
+
+// AuxTrafficShaper is a plugin interface that allows overlay protocols to
+// shape the outgoing htlc traffic of channels they attached custom channel
+// data to during funding. This allows them to track their own balances within
+// the channel and to attach custom records to the htlcs they offer.
+type AuxTrafficShaper interface {
+	// Name returns the unique name of the shaper.
+	Name() string
+
+	// Version returns the version of the AuxTrafficShaper interface the
+	// shaper was built against.
+	Version() uint32
+
+	// ChannelDataType returns the TLV type of the custom channel data the
+	// shaper is interested in. It must be in the custom TLV range.
+	ChannelDataType() tlv.Type
+
+	// ShouldHandleTraffic returns true if the shaper wants to handle the
+	// traffic of the channel with the given short channel ID. The custom
+	// channel data that was stored under the shaper's type is passed in,
+	// if the channel has any.
+	ShouldHandleTraffic(cid lnwire.ShortChannelID,
+		channelData fn.Option[[]byte]) (bool, error)
+
+	// PaymentBandwidth returns the bandwidth available for an htlc with
+	// the given custom records, given the channel's custom data and the
+	// bandwidth lnd computed for the channel itself.
+	PaymentBandwidth(htlcRecords lnwire.CustomRecords,
+		channelData fn.Option[[]byte],
+		linkBandwidth lnwire.MilliSatoshi) (lnwire.MilliSatoshi, error)
+
+	// ProduceHtlcExtraData returns the amount and the custom records of
+	// the htlc that is offered to the remote peer, given its original
+	// amount and custom records.
+	ProduceHtlcExtraData(totalAmount lnwire.MilliSatoshi,
+		htlcRecords lnwire.CustomRecords) (lnwire.MilliSatoshi,
+		lnwire.CustomRecords, error)
+}
+
+var (
+	// auxTrafficShapers is the set of all registered aux traffic shapers,
+	// keyed by their name.
+	auxTrafficShapers = make(map[string]AuxTrafficShaper)
+
+	// auxTrafficShaperMtx is a mutex that protects access to the above
+	// map.
+	auxTrafficShaperMtx sync.Mutex
+)
+
+// RegisterAuxTrafficShaper registers an aux traffic shaper with the
+// htlcswitch package. Overlay protocols are meant to call this from their
+// package's init() method so that the shaper is handed to every channel link
+// lnd creates.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisterAuxTrafficShaper(shaper AuxTrafficShaper) error {
+	auxTrafficShaperMtx.Lock()
+	defer auxTrafficShaperMtx.Unlock()
+
+	name := shaper.Name()
+	if shaper.Version() != AuxTrafficShaperVersion {
+		return fmt.Errorf("aux traffic shaper %v has version %v, "+
+			"expected %v", name, shaper.Version(),
+			AuxTrafficShaperVersion)
+	}
+
+	if shaper.ChannelDataType() < lnwire.MinCustomRecordsTlvType {
+		return fmt.Errorf("aux traffic shaper %v uses channel data "+
+			"type %v below the custom range", name,
+			shaper.ChannelDataType())
+	}
+
+	if _, ok := auxTrafficShapers[name]; ok {
+		return fmt.Errorf("aux traffic shaper %v already registered",
+			name)
+	}
+
+	auxTrafficShapers[name] = shaper
+
+	return nil
+}
+
+// RegisteredAuxTrafficShapers returns all registered aux traffic shapers,
+// sorted by their channel data type and name.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisteredAuxTrafficShapers() []AuxTrafficShaper {
+	auxTrafficShaperMtx.Lock()
+	defer auxTrafficShaperMtx.Unlock()
+
+	shapers := make([]AuxTrafficShaper, 0, len(auxTrafficShapers))
+	for _, shaper := range auxTrafficShapers {
+		shapers = append(shapers, shaper)
+	}
+
+	sort.Slice(shapers, func(i, j int) bool {
+		typeI := shapers[i].ChannelDataType()
+		typeJ := shapers[j].ChannelDataType()
+		if typeI != typeJ {
+			return typeI < typeJ
+		}
+
+		return shapers[i].Name() < shapers[j].Name()
+	})
+
+	return shapers
+}
+
+// auxTrafficShaper returns the first aux traffic shaper that wants to handle
+// the traffic of the link's channel, together with the custom channel data
+// stored under its type.
+func (l *channelLink) auxTrafficShaper() (fn.Option[AuxTrafficShaper],
+	fn.Option[[]byte], error) {
+
+	none := fn.None[AuxTrafficShaper]()
+	if len(l.cfg.AuxTrafficShapers) == 0 {
+		return none, fn.None[[]byte](), nil
+	}
+
+	customRecords, err := lnwire.ParseCustomRecords(
+		l.channel.State().CustomBlob,
+	)
+	if err != nil {
+		return none, fn.None[[]byte](), err
+	}
+
+	for _, shaper := range l.cfg.AuxTrafficShapers {
+		channelData := fn.None[[]byte]()
+		data, ok := customRecords[uint64(shaper.ChannelDataType())]
+		if ok {
+			channelData = fn.Some(data)
+		}
+
+		handle, err := shaper.ShouldHandleTraffic(
+			l.ShortChanID(), channelData,
+		)
+		if err != nil {
+			return none, fn.None[[]byte](), fmt.Errorf("aux "+
+				"traffic shaper %v: %w", shaper.Name(), err)
+		}
+
+		if handle {
+			return fn.Some(shaper), channelData, nil
+		}
+	}
+
+	return none, fn.None[[]byte](), nil
+}
+
+// shapeDownstreamAdd lets the aux traffic shaper of the link's channel, if
+// any, check the bandwidth available for the given outgoing htlc and set its
+// amount and custom records. The custom records of the incoming htlc are
+// handed to the shaper for forwarded htlcs, the ones of the htlc itself for
+// locally initiated payments.
+func (l *channelLink) shapeDownstreamAdd(pkt *htlcPacket,
+	htlc *lnwire.UpdateAddHTLC) *LinkError {
+
+	shaper, channelData, err := l.auxTrafficShaper()
+	if err != nil {
+		l.log.Errorf("Unable to query aux traffic shapers: %v", err)
+
+		return NewDetailedLinkError(
+			lnwire.NewTemporaryChannelFailure(nil),
+			OutgoingFailureLinkNotEligible,
+		)
+	}
+
+	htlcRecords := htlc.CustomRecords
+	if pkt.incomingChanID != hop.Source {
+		htlcRecords = pkt.incomingRecords
+	}
+
+	var linkErr *LinkError
+	shaper.WhenSome(func(s AuxTrafficShaper) {
+		bandwidth, err := s.PaymentBandwidth(
+			htlcRecords, channelData, l.Bandwidth(),
+		)
+		if err != nil {
+			l.log.Errorf("Aux traffic shaper %v unable to "+
+				"determine bandwidth: %v", s.Name(), err)
+
+			linkErr = NewDetailedLinkError(
+				lnwire.NewTemporaryChannelFailure(nil),
+				OutgoingFailureLinkNotEligible,
+			)
+
+			return
+		}
+
+		if htlc.Amount > bandwidth {
+			l.log.Warnf("Aux traffic shaper %v reports "+
+				"insufficient bandwidth for htlc: %v < %v",
+				s.Name(), bandwidth, htlc.Amount)
+
+			linkErr = NewDetailedLinkError(
+				lnwire.NewTemporaryChannelFailure(nil),
+				OutgoingFailureInsufficientBalance,
+			)
+
+			return
+		}
+
+		amt, customRecords, err := s.ProduceHtlcExtraData(
+			htlc.Amount, htlcRecords,
+		)
+		if err == nil {
+			err = customRecords.Validate()
+		}
+		if err != nil {
+			l.log.Errorf("Aux traffic shaper %v unable to "+
+				"produce htlc extra data: %v", s.Name(), err)
+
+			linkErr = NewDetailedLinkError(
+				lnwire.NewTemporaryChannelFailure(nil),
+				OutgoingFailureLinkNotEligible,
+			)
+
+			return
+		}
+
+		htlc.Amount = amt
+		htlc.CustomRecords = customRecords
+	})
+
+	return linkErr
+}
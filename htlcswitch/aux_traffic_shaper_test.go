@@ -0,0 +1,181 @@
+package htlcswitch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/htlcswitch/hop"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAuxTrafficShaper is a mock implementation of the AuxTrafficShaper
+// interface that reports a fixed bandwidth and attaches a fixed set of custom
+// records to every htlc.
+type mockAuxTrafficShaper struct {
+	name      string
+	version   uint32
+	dataType  tlv.Type
+	handle    bool
+	bandwidth lnwire.MilliSatoshi
+	records   lnwire.CustomRecords
+}
+
+func (m *mockAuxTrafficShaper) Name() string {
+	return m.name
+}
+
+func (m *mockAuxTrafficShaper) Version() uint32 {
+	return m.version
+}
+
+func (m *mockAuxTrafficShaper) ChannelDataType() tlv.Type {
+	return m.dataType
+}
+
+func (m *mockAuxTrafficShaper) ShouldHandleTraffic(lnwire.ShortChannelID,
+	fn.Option[[]byte]) (bool, error) {
+
+	return m.handle, nil
+}
+
+func (m *mockAuxTrafficShaper) PaymentBandwidth(lnwire.CustomRecords,
+	fn.Option[[]byte], lnwire.MilliSatoshi) (lnwire.MilliSatoshi, error) {
+
+	return m.bandwidth, nil
+}
+
+func (m *mockAuxTrafficShaper) ProduceHtlcExtraData(amt lnwire.MilliSatoshi,
+	_ lnwire.CustomRecords) (lnwire.MilliSatoshi, lnwire.CustomRecords,
+	error) {
+
+	return amt, m.records, nil
+}
+
+// TestRegisterAuxTrafficShaper tests that only shapers with the current
+// version and a channel data type in the custom range can be registered once.
+func TestRegisterAuxTrafficShaper(t *testing.T) {
+	t.Cleanup(func() {
+		auxTrafficShaperMtx.Lock()
+		defer auxTrafficShaperMtx.Unlock()
+
+		delete(auxTrafficShapers, "a")
+		delete(auxTrafficShapers, "b")
+	})
+
+	const dataType = lnwire.MinCustomRecordsTlvType + 1
+
+	require.NoError(t, RegisterAuxTrafficShaper(&mockAuxTrafficShaper{
+		name: "b", version: AuxTrafficShaperVersion, dataType: dataType,
+	}))
+	require.NoError(t, RegisterAuxTrafficShaper(&mockAuxTrafficShaper{
+		name: "a", version: AuxTrafficShaperVersion, dataType: dataType,
+	}))
+
+	require.Error(t, RegisterAuxTrafficShaper(&mockAuxTrafficShaper{
+		name: "a", version: AuxTrafficShaperVersion, dataType: dataType,
+	}))
+	require.Error(t, RegisterAuxTrafficShaper(&mockAuxTrafficShaper{
+		name: "c", version: AuxTrafficShaperVersion + 1,
+		dataType: dataType,
+	}))
+	require.Error(t, RegisterAuxTrafficShaper(&mockAuxTrafficShaper{
+		name: "d", version: AuxTrafficShaperVersion, dataType: 1,
+	}))
+
+	shapers := RegisteredAuxTrafficShapers()
+	require.Len(t, shapers, 2)
+	require.Equal(t, "a", shapers[0].Name())
+	require.Equal(t, "b", shapers[1].Name())
+}
+
+// TestChannelLinkAuxTrafficShaper tests that the link consults the aux
+// traffic shaper of its channel before offering an htlc, and that it attaches
+// the custom records produced by the shaper.
+func TestChannelLinkAuxTrafficShaper(t *testing.T) {
+	t.Parallel()
+
+	const chanAmt = btcutil.SatoshiPerBitcoin * 5
+
+	harness, err := newSingleLinkTestHarness(t, chanAmt, 0)
+	require.NoError(t, err)
+
+	coreLink, ok := harness.aliceLink.(*channelLink)
+	require.True(t, ok)
+
+	customRecords := lnwire.CustomRecords{
+		lnwire.MinCustomRecordsTlvType + 1: []byte{1, 2, 3},
+	}
+	shaper := &mockAuxTrafficShaper{
+		name:      "mock",
+		version:   AuxTrafficShaperVersion,
+		dataType:  lnwire.MinCustomRecordsTlvType + 1,
+		handle:    true,
+		bandwidth: lnwire.NewMSatFromSatoshis(chanAmt),
+		records:   customRecords,
+	}
+	coreLink.cfg.AuxTrafficShapers = []AuxTrafficShaper{shaper}
+
+	require.NoError(t, harness.start())
+
+	htlcAmt := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	newAddPkt := func() *htlcPacket {
+		_, htlc, _, err := generatePayment(
+			htlcAmt, htlcAmt, 5, [lnwire.OnionPacketSize]byte{},
+		)
+		require.NoError(t, err)
+
+		return &htlcPacket{
+			htlc:           htlc,
+			incomingChanID: hop.Source,
+			obfuscator:     NewMockObfuscator(),
+		}
+	}
+
+	// If the shaper reports less bandwidth than the htlc needs, the add is
+	// refused.
+	shaper.bandwidth = htlcAmt - 1
+	addPkt := newAddPkt()
+	linkErr := coreLink.shapeDownstreamAdd(
+		addPkt, addPkt.htlc.(*lnwire.UpdateAddHTLC),
+	)
+	require.NotNil(t, linkErr)
+	require.Equal(
+		t, OutgoingFailureInsufficientBalance, linkErr.FailureDetail,
+	)
+
+	// A shaper that doesn't want to handle the channel's traffic is
+	// ignored.
+	shaper.handle = false
+	require.Nil(t, coreLink.shapeDownstreamAdd(
+		addPkt, addPkt.htlc.(*lnwire.UpdateAddHTLC),
+	))
+
+	// With enough bandwidth, the htlc is offered to the remote peer with
+	// the custom records of the shaper.
+	shaper.handle = true
+	shaper.bandwidth = htlcAmt
+	addPkt = newAddPkt()
+	circuit := makePaymentCircuit(
+		&addPkt.htlc.(*lnwire.UpdateAddHTLC).PaymentHash, addPkt,
+	)
+	_, err = harness.aliceSwitch.commitCircuits(&circuit)
+	require.NoError(t, err)
+
+	addPkt.circuit = &circuit
+	require.NoError(t, harness.aliceLink.handleSwitchPacket(addPkt))
+
+	aliceMsgs := coreLink.cfg.Peer.(*mockPeer).sentMsgs
+	select {
+	case msg := <-aliceMsgs:
+		addHtlc, ok := msg.(*lnwire.UpdateAddHTLC)
+		require.True(t, ok)
+		require.Equal(t, customRecords, addHtlc.CustomRecords)
+
+	case <-time.After(15 * time.Second):
+		t.Fatalf("did not receive message")
+	}
+}
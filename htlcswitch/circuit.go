@@ -3,6 +3,7 @@ package htlcswitch
 import (
 	"encoding/binary"
 	"io"
+	"time"
 
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/channeldb/models"
@@ -55,6 +56,24 @@ type PaymentCircuit struct {
 	// sending it back to the originator of the payment.
 	ErrorEncrypter hop.ErrorEncrypter
 
+	// IncomingTimeout is the expiry height of the incoming HTLC.
+	//
+	// NOTE: This value is not persisted, so it is zero for circuits
+	// loaded from disk.
+	IncomingTimeout uint32
+
+	// OutgoingTimeout is the expiry height of the outgoing HTLC.
+	//
+	// NOTE: This value is not persisted, so it is zero for circuits
+	// loaded from disk.
+	OutgoingTimeout uint32
+
+	// AddedAt is the time the circuit was added to the circuit map, or
+	// the time it was restored if it was loaded from disk.
+	//
+	// NOTE: This value is not persisted.
+	AddedAt time.Time
+
 	// LoadedFromDisk is set true for any circuits loaded after the circuit
 	// map is reloaded from disk.
 	//
@@ -83,10 +102,12 @@ func newPaymentCircuit(hash *[32]byte, pkt *htlcPacket) *PaymentCircuit {
 			ChanID: pkt.incomingChanID,
 			HtlcID: pkt.incomingHTLCID,
 		},
-		PaymentHash:    *hash,
-		IncomingAmount: pkt.incomingAmount,
-		OutgoingAmount: pkt.amount,
-		ErrorEncrypter: pkt.obfuscator,
+		PaymentHash:     *hash,
+		IncomingAmount:  pkt.incomingAmount,
+		OutgoingAmount:  pkt.amount,
+		ErrorEncrypter:  pkt.obfuscator,
+		IncomingTimeout: pkt.incomingTimeout,
+		OutgoingTimeout: pkt.outgoingTimeout,
 	}
 }
 
@@ -104,10 +125,12 @@ func makePaymentCircuit(hash *[32]byte, pkt *htlcPacket) PaymentCircuit {
 			ChanID: pkt.incomingChanID,
 			HtlcID: pkt.incomingHTLCID,
 		},
-		PaymentHash:    *hash,
-		IncomingAmount: pkt.incomingAmount,
-		OutgoingAmount: pkt.amount,
-		ErrorEncrypter: pkt.obfuscator,
+		PaymentHash:     *hash,
+		IncomingAmount:  pkt.incomingAmount,
+		OutgoingAmount:  pkt.amount,
+		ErrorEncrypter:  pkt.obfuscator,
+		IncomingTimeout: pkt.incomingTimeout,
+		OutgoingTimeout: pkt.outgoingTimeout,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
@@ -129,6 +130,11 @@ type CircuitMap interface {
 	// NumOpen returns the number of circuits with HTLCs that have been
 	// forwarded via an outgoing link.
 	NumOpen() int
+
+	// Circuits returns a snapshot of all active circuits added by
+	// CommitCircuits, including those that have been forwarded via an
+	// outgoing link.
+	Circuits() []PaymentCircuit
 }
 
 var (
@@ -501,6 +507,7 @@ func (cm *circuitMap) restoreMemState() error {
 		pending map[CircuitKey]*PaymentCircuit
 	)
 
+	now := time.Now()
 	if err := kvdb.Update(cm.cfg.DB, func(tx kvdb.RwTx) error {
 		// Restore any of the circuits persisted in the circuit bucket
 		// back into memory.
@@ -516,6 +523,7 @@ func (cm *circuitMap) restoreMemState() error {
 			}
 
 			circuit.LoadedFromDisk = true
+			circuit.AddedAt = now
 			pending[circuit.Incoming] = circuit
 
 			return nil
@@ -856,6 +864,7 @@ func (cm *circuitMap) CommitCircuits(circuits ...*PaymentCircuit) (
 			continue
 		}
 
+		circuit.AddedAt = time.Now()
 		cm.pending[inKey] = circuit
 		adds = append(adds, circuit)
 		addFails = append(addFails, circuit)
@@ -1210,3 +1219,24 @@ func (cm *circuitMap) NumOpen() int {
 
 	return len(cm.opened)
 }
+
+// Circuits returns a snapshot of all active circuits added to the circuit map.
+// Circuits that have been forwarded via an outgoing link have their keystone
+// set.
+func (cm *circuitMap) Circuits() []PaymentCircuit {
+	cm.mtx.RLock()
+	defer cm.mtx.RUnlock()
+
+	circuits := make([]PaymentCircuit, 0, len(cm.pending))
+	for _, circuit := range cm.pending {
+		c := *circuit
+		if circuit.Outgoing != nil {
+			outKey := *circuit.Outgoing
+			c.Outgoing = &outKey
+		}
+
+		circuits = append(circuits, c)
+	}
+
+	return circuits
+}
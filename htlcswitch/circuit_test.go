@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
@@ -601,19 +602,25 @@ func assertHasCircuit(t *testing.T, cm htlcswitch.CircuitMap,
 }
 
 // equalIgnoreLFD compares two payment circuits, but ignores the current value
-// of LoadedFromDisk. The value is temporarily set to false for the comparison
-// and then restored.
+// of LoadedFromDisk and AddedAt. The values are temporarily reset for the
+// comparison and then restored.
 func equalIgnoreLFD(c, c2 *htlcswitch.PaymentCircuit) bool {
 	ogLFD := c.LoadedFromDisk
 	ogLFD2 := c2.LoadedFromDisk
+	ogAddedAt := c.AddedAt
+	ogAddedAt2 := c2.AddedAt
 
 	c.LoadedFromDisk = false
 	c2.LoadedFromDisk = false
+	c.AddedAt = time.Time{}
+	c2.AddedAt = time.Time{}
 
 	isEqual := reflect.DeepEqual(c, c2)
 
 	c.LoadedFromDisk = ogLFD
 	c2.LoadedFromDisk = ogLFD2
+	c.AddedAt = ogAddedAt
+	c2.AddedAt = ogAddedAt2
 
 	return isEqual
 }
@@ -1361,3 +1368,81 @@ func TestCircuitMapDeleteOpenCircuit(t *testing.T) {
 			circuit2, nil)
 	}
 }
+
+// TestCircuitMapCircuits tests that the circuit map returns a snapshot of all
+// active circuits, both before and after a restart.
+func TestCircuitMapCircuits(t *testing.T) {
+	t.Parallel()
+
+	chan1 := lnwire.NewShortChanIDFromInt(1)
+	chan2 := lnwire.NewShortChanIDFromInt(2)
+
+	cfg, circuitMap := newCircuitMap(t, false)
+	require.Empty(t, circuitMap.Circuits())
+
+	circuits := []*htlcswitch.PaymentCircuit{
+		{
+			Incoming: htlcswitch.CircuitKey{
+				ChanID: chan1,
+				HtlcID: 1,
+			},
+			PaymentHash:    hash1,
+			IncomingAmount: 2000,
+			OutgoingAmount: 1000,
+			ErrorEncrypter: testExtracter,
+		},
+		{
+			Incoming: htlcswitch.CircuitKey{
+				ChanID: chan1,
+				HtlcID: 2,
+			},
+			PaymentHash:    hash2,
+			ErrorEncrypter: testExtracter,
+		},
+	}
+	_, err := circuitMap.CommitCircuits(circuits...)
+	require.NoError(t, err)
+
+	// Only the first circuit is forwarded via an outgoing link.
+	keystone := htlcswitch.Keystone{
+		InKey: circuits[0].Incoming,
+		OutKey: htlcswitch.CircuitKey{
+			ChanID: chan2,
+			HtlcID: 0,
+		},
+	}
+	require.NoError(t, circuitMap.OpenCircuits(keystone))
+
+	assertCircuits := func(cm htlcswitch.CircuitMap, loaded bool) {
+		t.Helper()
+
+		snapshot := cm.Circuits()
+		require.Len(t, snapshot, len(circuits))
+
+		for _, circuit := range snapshot {
+			require.Equal(t, loaded, circuit.LoadedFromDisk)
+			require.False(t, circuit.AddedAt.IsZero())
+
+			switch circuit.Incoming {
+			case circuits[0].Incoming:
+				require.True(t, circuit.HasKeystone())
+				require.Equal(t, keystone.OutKey,
+					*circuit.Outgoing)
+				require.Equal(t, circuits[0].IncomingAmount,
+					circuit.IncomingAmount)
+
+			case circuits[1].Incoming:
+				require.False(t, circuit.HasKeystone())
+
+			default:
+				t.Fatalf("unexpected circuit: %v",
+					circuit.Incoming)
+			}
+		}
+	}
+	assertCircuits(circuitMap, false)
+
+	// After a restart, the circuits are restored from disk.
+	_, circuitMap = restartCircuitMap(t, cfg)
+	assertCircuits(circuitMap, true)
+}
@@ -54,6 +54,15 @@ const (
 	// OutgoingFailureForwardsDisabled is returned when the switch is
 	// configured to disallow forwards.
 	OutgoingFailureForwardsDisabled
+
+	// OutgoingFailureForwardingLimit is returned when forwarding an htlc
+	// would exceed the forwarding limits of the outgoing channel.
+	OutgoingFailureForwardingLimit
+
+	// OutgoingFailureGeneralSlotsExhausted is returned when an htlc would
+	// be forwarded unendorsed but all general slots of the outgoing
+	// channel are in use.
+	OutgoingFailureGeneralSlotsExhausted
 )
 
 // FailureString returns the string representation of a failure detail.
@@ -91,6 +100,12 @@ func (fd OutgoingFailure) FailureString() string {
 	case OutgoingFailureForwardsDisabled:
 		return "node configured to disallow forwards"
 
+	case OutgoingFailureForwardingLimit:
+		return "forwarding limit of outgoing channel reached"
+
+	case OutgoingFailureGeneralSlotsExhausted:
+		return "general slots of outgoing channel exhausted"
+
 	default:
 		return "unknown failure detail"
 	}
@@ -0,0 +1,176 @@
+package htlcswitch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"golang.org/x/time/rate"
+)
+
+// fwdReservation is a forwarded HTLC that counts towards the in-flight limits
+// of its outgoing channel.
+type fwdReservation struct {
+	chanID lnwire.ChannelID
+	amt    lnwire.MilliSatoshi
+}
+
+// fwdInFlight tracks the forwarded HTLCs that are in flight on a channel.
+type fwdInFlight struct {
+	numHtlcs uint32
+	amt      lnwire.MilliSatoshi
+}
+
+// channelLimits are the forwarding limits of a channel along with the rate
+// limiter that enforces its HTLC rate.
+type channelLimits struct {
+	channeldb.ForwardingLimits
+
+	// rateLimiter is nil if the HTLC rate isn't limited.
+	rateLimiter *rate.Limiter
+}
+
+// forwardingLimiter enforces the forwarding limits of the outgoing channels of
+// forwarded HTLCs. It keeps track of all forwarded HTLCs from the time they
+// are handed to the outgoing link until their circuit is closed, so that
+// limits also account for HTLCs that were forwarded before they were set.
+//
+// NOTE: The in-flight HTLCs are only tracked in memory, so HTLCs that were
+// forwarded before a restart don't count towards the limits.
+type forwardingLimiter struct {
+	mu sync.Mutex
+
+	// limits holds the limits of all channels that have any.
+	limits map[lnwire.ChannelID]*channelLimits
+
+	// inFlight holds the forwarded HTLCs that are in flight per outgoing
+	// channel.
+	inFlight map[lnwire.ChannelID]*fwdInFlight
+
+	// reservations maps the incoming circuit key of each forwarded HTLC
+	// to its outgoing channel and amount.
+	reservations map[CircuitKey]fwdReservation
+}
+
+// newForwardingLimiter creates a new forwarding limiter without any limits.
+func newForwardingLimiter() *forwardingLimiter {
+	return &forwardingLimiter{
+		limits:       make(map[lnwire.ChannelID]*channelLimits),
+		inFlight:     make(map[lnwire.ChannelID]*fwdInFlight),
+		reservations: make(map[CircuitKey]fwdReservation),
+	}
+}
+
+// setLimits replaces the limits of the given channel. Empty limits remove
+// the limits of the channel.
+func (f *forwardingLimiter) setLimits(chanID lnwire.ChannelID,
+	limits channeldb.ForwardingLimits) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if limits.IsEmpty() {
+		delete(f.limits, chanID)
+		return
+	}
+
+	chanLimits := &channelLimits{
+		ForwardingLimits: limits,
+	}
+
+	// The rate limiter allows a burst of the full rate limit and refills
+	// evenly over the rate interval.
+	if limits.RateLimit > 0 && limits.RateInterval > 0 {
+		refill := limits.RateInterval / time.Duration(limits.RateLimit)
+		chanLimits.rateLimiter = rate.NewLimiter(
+			rate.Every(refill), int(limits.RateLimit),
+		)
+	}
+
+	f.limits[chanID] = chanLimits
+}
+
+// reserve records an HTLC that is about to be forwarded over the given
+// channel. It returns false without recording the HTLC if forwarding it would
+// exceed any of the limits of the channel.
+func (f *forwardingLimiter) reserve(inKey CircuitKey,
+	chanID lnwire.ChannelID, amt lnwire.MilliSatoshi) bool {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// An HTLC that is already tracked isn't counted twice.
+	if _, ok := f.reservations[inKey]; ok {
+		return true
+	}
+
+	inFlight, ok := f.inFlight[chanID]
+	if !ok {
+		inFlight = &fwdInFlight{}
+	}
+
+	if limits, ok := f.limits[chanID]; ok {
+		if limits.MaxHtlcs > 0 &&
+			inFlight.numHtlcs+1 > limits.MaxHtlcs {
+
+			log.Debugf("Max forwarded HTLCs of %v reached "+
+				"for ChannelID(%v)", limits.MaxHtlcs, chanID)
+
+			return false
+		}
+
+		if limits.MaxInFlight > 0 &&
+			inFlight.amt+amt > limits.MaxInFlight {
+
+			log.Debugf("Max forwarded in flight of %v exceeded "+
+				"for ChannelID(%v)", limits.MaxInFlight, chanID)
+
+			return false
+		}
+
+		if limits.rateLimiter != nil && !limits.rateLimiter.Allow() {
+			log.Debugf("HTLC rate limit of %v per %v reached for "+
+				"ChannelID(%v)", limits.RateLimit,
+				limits.RateInterval, chanID)
+
+			return false
+		}
+	}
+
+	inFlight.numHtlcs++
+	inFlight.amt += amt
+	f.inFlight[chanID] = inFlight
+
+	f.reservations[inKey] = fwdReservation{
+		chanID: chanID,
+		amt:    amt,
+	}
+
+	return true
+}
+
+// release removes the forwarded HTLC with the given incoming circuit key from
+// the in-flight HTLCs of its outgoing channel. HTLCs that aren't tracked are
+// ignored.
+func (f *forwardingLimiter) release(inKey CircuitKey) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reservation, ok := f.reservations[inKey]
+	if !ok {
+		return
+	}
+	delete(f.reservations, inKey)
+
+	inFlight, ok := f.inFlight[reservation.chanID]
+	if !ok {
+		return
+	}
+
+	inFlight.numHtlcs--
+	inFlight.amt -= reservation.amt
+	if inFlight.numHtlcs == 0 {
+		delete(f.inFlight, reservation.chanID)
+	}
+}
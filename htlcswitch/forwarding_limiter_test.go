@@ -0,0 +1,114 @@
+package htlcswitch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForwardingLimiter tests that the forwarding limiter enforces the limits
+// of a channel and frees up capacity once HTLCs are released.
+func TestForwardingLimiter(t *testing.T) {
+	t.Parallel()
+
+	chanID := lnwire.ChannelID{1}
+	otherChanID := lnwire.ChannelID{2}
+
+	inKey := func(htlcID uint64) CircuitKey {
+		return CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: htlcID,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		limits channeldb.ForwardingLimits
+
+		// amts are the amounts of the HTLCs that are forwarded, all
+		// of which are expected to be accepted.
+		amts []lnwire.MilliSatoshi
+
+		// rejectedAmt is the amount of an HTLC that is expected to be
+		// rejected after amts were forwarded.
+		rejectedAmt lnwire.MilliSatoshi
+
+		// releaseFrees indicates whether releasing an HTLC allows the
+		// rejected HTLC to be forwarded.
+		releaseFrees bool
+	}{
+		{
+			name:         "max htlcs",
+			limits:       channeldb.ForwardingLimits{MaxHtlcs: 2},
+			amts:         []lnwire.MilliSatoshi{1000, 1000},
+			rejectedAmt:  1000,
+			releaseFrees: true,
+		},
+		{
+			name: "max in flight",
+			limits: channeldb.ForwardingLimits{
+				MaxInFlight: 5000,
+			},
+			amts:         []lnwire.MilliSatoshi{2000, 3000},
+			rejectedAmt:  1,
+			releaseFrees: true,
+		},
+		{
+			name: "rate limit",
+			limits: channeldb.ForwardingLimits{
+				RateLimit:    2,
+				RateInterval: time.Hour,
+			},
+			amts:         []lnwire.MilliSatoshi{1000, 1000},
+			rejectedAmt:  1000,
+			releaseFrees: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := newForwardingLimiter()
+			limiter.setLimits(chanID, tc.limits)
+
+			for i, amt := range tc.amts {
+				require.True(t, limiter.reserve(
+					inKey(uint64(i)), chanID, amt,
+				))
+			}
+
+			// An HTLC that is already reserved is accepted again
+			// without being counted twice.
+			require.True(t, limiter.reserve(inKey(0), chanID, 1000))
+
+			rejectedKey := inKey(uint64(len(tc.amts)))
+			require.False(t, limiter.reserve(
+				rejectedKey, chanID, tc.rejectedAmt,
+			))
+
+			// Other channels aren't affected by the limits.
+			require.True(t, limiter.reserve(
+				rejectedKey, otherChanID, tc.rejectedAmt,
+			))
+			limiter.release(rejectedKey)
+
+			limiter.release(inKey(0))
+			require.Equal(t, tc.releaseFrees, limiter.reserve(
+				rejectedKey, chanID, tc.rejectedAmt,
+			))
+
+			// Once the limits are removed, HTLCs are always
+			// accepted.
+			limiter.setLimits(chanID, channeldb.ForwardingLimits{})
+			require.True(t, limiter.reserve(
+				inKey(100), chanID, tc.rejectedAmt,
+			))
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package hop
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	sphinx "github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	// onionMsgReplyPathType is the tlv type of the blinded reply path
+	// that the sender of an onion message can include for the recipient.
+	onionMsgReplyPathType tlv.Type = 2
+
+	// onionMsgEncryptedDataType is the tlv type of the encrypted recipient
+	// data of an onion message hop.
+	onionMsgEncryptedDataType tlv.Type = 4
+
+	// OnionMsgContentTypeStart is the first tlv type of the final hop
+	// payload that carries the content of an onion message. All types
+	// starting from this one are handed to the recipient untouched.
+	OnionMsgContentTypeStart tlv.Type = 64
+
+	// The tlv types of the encrypted recipient data of an onion message
+	// hop.
+	onionMsgShortChanIDType tlv.Type = 2
+	onionMsgNextNodeIDType  tlv.Type = 4
+	onionMsgPathIDType      tlv.Type = 6
+	onionMsgNextPathKeyType tlv.Type = 8
+)
+
+var (
+	// ErrOnionMsgNoEncryptedData is returned when an onion message hop
+	// payload doesn't carry the encrypted recipient data.
+	ErrOnionMsgNoEncryptedData = errors.New("onion message payload " +
+		"without encrypted data")
+
+	// ErrOnionMsgNoNextHop is returned when an onion message should be
+	// forwarded, but its encrypted data specifies neither the next node
+	// nor the next channel.
+	ErrOnionMsgNoNextHop = errors.New("onion message without next hop")
+)
+
+// ProcessedOnionMessage is an onion message after our layer of its onion was
+// decrypted. It either needs to be relayed to the next hop of its path, or we
+// are its final recipient.
+type ProcessedOnionMessage struct {
+	// IsFinal is true if we are the final recipient of the message.
+	IsFinal bool
+
+	// NextNode is the node that the message should be relayed to. If it
+	// is nil, the message should be relayed to the peer of NextChannel.
+	NextNode *btcec.PublicKey
+
+	// NextChannel is the channel whose peer the message should be relayed
+	// to if the next node isn't specified directly.
+	NextChannel lnwire.ShortChannelID
+
+	// NextMessage is the message that should be relayed to the next hop.
+	NextMessage *lnwire.OnionMessage
+
+	// PathID is the path id that the recipient included in its own hop of
+	// the blinded path. It allows the recipient to verify that the message
+	// was sent over a path it created. Only set for the final hop.
+	PathID []byte
+
+	// ReplyPath is the encoded blinded path that the sender included to
+	// receive a reply. Only set for the final hop.
+	ReplyPath []byte
+
+	// Contents are the tlv records of the final hop payload, starting at
+	// OnionMsgContentTypeStart, that form the actual message.
+	Contents map[uint64][]byte
+}
+
+// ProcessOnionMessage decrypts our layer of the onion of the given onion
+// message and determines whether it needs to be relayed or whether we are its
+// final recipient.
+//
+// NOTE: Onion messages are not protected against replays, as they don't carry
+// any value that could be extracted by replaying them.
+func (p *OnionProcessor) ProcessOnionMessage(msg *lnwire.OnionMessage) (
+	*ProcessedOnionMessage, error) {
+
+	// We only support onion message packets that have the same size as
+	// the onion packets of HTLCs.
+	if len(msg.OnionBlob) != lnwire.OnionPacketSize {
+		return nil, fmt.Errorf("unsupported onion message packet "+
+			"size: %v", len(msg.OnionBlob))
+	}
+
+	onionPkt := &sphinx.OnionPacket{}
+	err := onionPkt.Decode(bytes.NewReader(msg.OnionBlob))
+	if err != nil {
+		return nil, err
+	}
+
+	// Every hop of an onion message is part of a blinded path, so the
+	// path key is always needed to derive our shared secret.
+	packet, err := p.router.ReconstructOnionPacket(
+		onionPkt, nil, sphinx.WithBlindingPoint(msg.PathKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if packet.Payload.Type != sphinx.PayloadTLV {
+		return nil, errors.New("onion message with legacy payload")
+	}
+
+	var (
+		replyPath     []byte
+		encryptedData []byte
+	)
+	stream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(onionMsgReplyPathType, &replyPath),
+		tlv.MakePrimitiveRecord(
+			onionMsgEncryptedDataType, &encryptedData,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedTypes, err := stream.DecodeWithParsedTypesP2P(
+		bytes.NewReader(packet.Payload.Payload),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := parsedTypes[onionMsgEncryptedDataType]; !ok {
+		return nil, ErrOnionMsgNoEncryptedData
+	}
+
+	isFinal := packet.Action == sphinx.ExitNode
+	contents := make(map[uint64][]byte)
+	for t, value := range parsedTypes {
+		switch {
+		// Known types have a nil value.
+		case value == nil:
+			continue
+
+		// Relaying nodes must not be given any other data than their
+		// encrypted data.
+		case !isFinal:
+			return nil, fmt.Errorf("unexpected type %d in onion "+
+				"message relay payload", t)
+
+		case t >= OnionMsgContentTypeStart:
+			contents[uint64(t)] = value
+
+		// We can't process messages with required types that we
+		// don't understand.
+		case t%2 == 0:
+			return nil, fmt.Errorf("unknown required type %d in "+
+				"onion message payload", t)
+		}
+	}
+
+	if _, ok := parsedTypes[onionMsgReplyPathType]; ok && !isFinal {
+		return nil, errors.New("reply path in onion message relay " +
+			"payload")
+	}
+
+	plainText, err := p.router.DecryptBlindedHopData(
+		msg.PathKey, encryptedData,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecodeFailed, err)
+	}
+
+	routeData, err := decodeOnionMsgRouteData(plainText)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecodeFailed, err)
+	}
+
+	if isFinal {
+		return &ProcessedOnionMessage{
+			IsFinal:   true,
+			PathID:    routeData.pathID,
+			ReplyPath: replyPath,
+			Contents:  contents,
+		}, nil
+	}
+
+	// A path id is only meant for the recipient, a relaying node must
+	// ignore messages that carry one.
+	switch {
+	case routeData.pathID != nil:
+		return nil, errors.New("path id in onion message relay data")
+
+	case routeData.nextNode == nil && routeData.nextChannel == nil:
+		return nil, ErrOnionMsgNoNextHop
+	}
+
+	// The path key of the next hop is either overridden by the creator of
+	// the path, which allows concatenating blinded paths, or derived from
+	// the current one.
+	nextPathKey := routeData.nextPathKey
+	if nextPathKey == nil {
+		nextPathKey, err = p.router.NextEphemeral(msg.PathKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var nextOnion bytes.Buffer
+	if err := packet.NextPacket.Encode(&nextOnion); err != nil {
+		return nil, err
+	}
+
+	processed := &ProcessedOnionMessage{
+		NextNode: routeData.nextNode,
+		NextMessage: lnwire.NewOnionMessage(
+			nextPathKey, nextOnion.Bytes(),
+		),
+	}
+	if routeData.nextChannel != nil {
+		processed.NextChannel = *routeData.nextChannel
+	}
+
+	return processed, nil
+}
+
+// onionMsgRouteData is the decrypted recipient data of an onion message hop.
+type onionMsgRouteData struct {
+	nextChannel *lnwire.ShortChannelID
+	nextNode    *btcec.PublicKey
+	pathID      []byte
+	nextPathKey *btcec.PublicKey
+}
+
+// decodeOnionMsgRouteData decodes the decrypted recipient data of an onion
+// message hop.
+func decodeOnionMsgRouteData(plainText []byte) (*onionMsgRouteData, error) {
+	var (
+		scid        uint64
+		nextNode    *btcec.PublicKey
+		pathID      []byte
+		nextPathKey *btcec.PublicKey
+	)
+	stream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(onionMsgShortChanIDType, &scid),
+		tlv.MakePrimitiveRecord(onionMsgNextNodeIDType, &nextNode),
+		tlv.MakePrimitiveRecord(onionMsgPathIDType, &pathID),
+		tlv.MakePrimitiveRecord(onionMsgNextPathKeyType, &nextPathKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedTypes, err := stream.DecodeWithParsedTypesP2P(
+		bytes.NewReader(plainText),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var data onionMsgRouteData
+	for t, value := range parsedTypes {
+		switch t {
+		case onionMsgShortChanIDType:
+			chanID := lnwire.NewShortChanIDFromInt(scid)
+			data.nextChannel = &chanID
+
+		case onionMsgNextNodeIDType:
+			data.nextNode = nextNode
+
+		case onionMsgPathIDType:
+			data.pathID = pathID
+
+		case onionMsgNextPathKeyType:
+			data.nextPathKey = nextPathKey
+
+		default:
+			if value != nil && t%2 == 0 {
+				return nil, fmt.Errorf("unknown required "+
+					"type %d in onion message data", t)
+			}
+		}
+	}
+
+	return &data, nil
+}
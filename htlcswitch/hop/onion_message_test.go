@@ -0,0 +1,264 @@
+package hop
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+	sphinx "github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeTLVs encodes the given records into a tlv stream.
+func encodeTLVs(t *testing.T, records ...tlv.Record) []byte {
+	t.Helper()
+
+	stream, err := tlv.NewStream(records...)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, stream.Encode(&b))
+
+	return b.Bytes()
+}
+
+// onionMsgHop describes a hop of an onion message path used in tests.
+type onionMsgHop struct {
+	key           *btcec.PrivateKey
+	encryptedData []byte
+	payload       []tlv.Record
+}
+
+// buildOnionMessage creates an onion message that travels along a blinded
+// path over the given hops.
+func buildOnionMessage(t *testing.T, hops []onionMsgHop) *lnwire.OnionMessage {
+	t.Helper()
+
+	hopInfo := make([]*sphinx.HopInfo, len(hops))
+	for i, hop := range hops {
+		hopInfo[i] = &sphinx.HopInfo{
+			NodePub:   hop.key.PubKey(),
+			PlainText: hop.encryptedData,
+		}
+	}
+
+	blindingKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	blindedPath, err := sphinx.BuildBlindedPath(blindingKey, hopInfo)
+	require.NoError(t, err)
+
+	var path sphinx.PaymentPath
+	for i, hop := range blindedPath.BlindedHops {
+		cipherText := hop.CipherText
+		records := append([]tlv.Record{
+			tlv.MakePrimitiveRecord(
+				onionMsgEncryptedDataType, &cipherText,
+			),
+		}, hops[i].payload...)
+		tlv.SortRecords(records)
+
+		path[i] = sphinx.OnionHop{
+			NodePub: *hop.BlindedNodePub,
+			HopPayload: sphinx.HopPayload{
+				Type:    sphinx.PayloadTLV,
+				Payload: encodeTLVs(t, records...),
+			},
+		}
+	}
+
+	sessionKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	onion, err := sphinx.NewOnionPacket(
+		&path, sessionKey, nil, sphinx.DeterministicPacketFiller,
+	)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, onion.Encode(&b))
+
+	return lnwire.NewOnionMessage(blindedPath.BlindingPoint, b.Bytes())
+}
+
+// newTestOnionProcessor creates an onion processor for the given node key.
+func newTestOnionProcessor(key *btcec.PrivateKey) *OnionProcessor {
+	return NewOnionProcessor(sphinx.NewRouter(
+		&keychain.PrivKeyECDH{PrivKey: key},
+		&chaincfg.RegressionNetParams, sphinx.NewMemoryReplayLog(),
+	))
+}
+
+// TestProcessOnionMessage tests that an onion message is relayed along its
+// blinded path and that the final recipient receives its contents.
+func TestProcessOnionMessage(t *testing.T) {
+	t.Parallel()
+
+	alice, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	bob, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	carol, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	bobPub := bob.PubKey()
+	carolChan := lnwire.NewShortChanIDFromInt(1234).ToUint64()
+	pathID := []byte{1, 2, 3}
+	replyPath := []byte{4, 5, 6}
+	content := []byte("hello")
+
+	msg := buildOnionMessage(t, []onionMsgHop{
+		{
+			key: alice,
+			encryptedData: encodeTLVs(t, tlv.MakePrimitiveRecord(
+				onionMsgNextNodeIDType, &bobPub,
+			)),
+		},
+		{
+			key: bob,
+			encryptedData: encodeTLVs(t, tlv.MakePrimitiveRecord(
+				onionMsgShortChanIDType, &carolChan,
+			)),
+		},
+		{
+			key: carol,
+			encryptedData: encodeTLVs(t, tlv.MakePrimitiveRecord(
+				onionMsgPathIDType, &pathID,
+			)),
+			payload: []tlv.Record{
+				tlv.MakePrimitiveRecord(
+					onionMsgReplyPathType, &replyPath,
+				),
+				tlv.MakePrimitiveRecord(
+					OnionMsgContentTypeStart, &content,
+				),
+			},
+		},
+	})
+
+	// Alice relays the message to Bob by his node id.
+	processed, err := newTestOnionProcessor(alice).ProcessOnionMessage(
+		msg,
+	)
+	require.NoError(t, err)
+	require.False(t, processed.IsFinal)
+	require.True(t, processed.NextNode.IsEqual(bobPub))
+
+	// Bob relays the message over his channel with Carol.
+	processed, err = newTestOnionProcessor(bob).ProcessOnionMessage(
+		processed.NextMessage,
+	)
+	require.NoError(t, err)
+	require.False(t, processed.IsFinal)
+	require.Nil(t, processed.NextNode)
+	require.EqualValues(t, carolChan, processed.NextChannel.ToUint64())
+
+	// Carol is the final recipient of the message.
+	processed, err = newTestOnionProcessor(carol).ProcessOnionMessage(
+		processed.NextMessage,
+	)
+	require.NoError(t, err)
+	require.True(t, processed.IsFinal)
+	require.Equal(t, pathID, processed.PathID)
+	require.Equal(t, replyPath, processed.ReplyPath)
+	require.Equal(t, map[uint64][]byte{
+		uint64(OnionMsgContentTypeStart): content,
+	}, processed.Contents)
+
+	// A node that isn't part of the path can't decrypt the message.
+	_, err = newTestOnionProcessor(bob).ProcessOnionMessage(msg)
+	require.Error(t, err)
+}
+
+// TestProcessOnionMessageInvalid tests that invalid onion messages are
+// rejected.
+func TestProcessOnionMessageInvalid(t *testing.T) {
+	t.Parallel()
+
+	alice, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	bob, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	bobPub := bob.PubKey()
+	pathID := []byte{1}
+	content := []byte("hello")
+
+	tests := []struct {
+		name         string
+		aliceData    []byte
+		alicePayload []tlv.Record
+		expectedErr  string
+	}{
+		{
+			name:        "no next hop",
+			aliceData:   []byte{},
+			expectedErr: ErrOnionMsgNoNextHop.Error(),
+		},
+		{
+			name: "path id for relay",
+			aliceData: encodeTLVs(t,
+				tlv.MakePrimitiveRecord(
+					onionMsgNextNodeIDType, &bobPub,
+				),
+				tlv.MakePrimitiveRecord(
+					onionMsgPathIDType, &pathID,
+				),
+			),
+			expectedErr: "path id in onion message relay data",
+		},
+		{
+			name: "content for relay",
+			aliceData: encodeTLVs(t, tlv.MakePrimitiveRecord(
+				onionMsgNextNodeIDType, &bobPub,
+			)),
+			alicePayload: []tlv.Record{
+				tlv.MakePrimitiveRecord(
+					OnionMsgContentTypeStart, &content,
+				),
+			},
+			expectedErr: "unexpected type 64",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg := buildOnionMessage(t, []onionMsgHop{
+				{
+					key:           alice,
+					encryptedData: test.aliceData,
+					payload:       test.alicePayload,
+				},
+				{
+					key: bob,
+					encryptedData: encodeTLVs(t,
+						tlv.MakePrimitiveRecord(
+							onionMsgPathIDType,
+							&pathID,
+						),
+					),
+				},
+			})
+
+			_, err := newTestOnionProcessor(
+				alice,
+			).ProcessOnionMessage(msg)
+			require.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+
+	// Packets of an unsupported size are rejected.
+	pathKey := alice.PubKey()
+	_, err = newTestOnionProcessor(alice).ProcessOnionMessage(
+		lnwire.NewOnionMessage(pathKey, make([]byte, 100)),
+	)
+	require.ErrorContains(t, err, "unsupported onion message packet")
+}
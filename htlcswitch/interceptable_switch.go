@@ -50,6 +50,10 @@ type InterceptableSwitch struct {
 	// client connect and disconnect.
 	interceptorRegistration chan ForwardInterceptor
 
+	// filterRegistration is a channel that we use to synchronize setting
+	// the filter of the current interceptor.
+	filterRegistration chan InterceptFilter
+
 	// requireInterceptor indicates whether processing should block if no
 	// interceptor is connected.
 	requireInterceptor bool
@@ -57,6 +61,10 @@ type InterceptableSwitch struct {
 	// interceptor is the handler for intercepted packets.
 	interceptor ForwardInterceptor
 
+	// interceptFilter decides which packets are passed to the interceptor.
+	// It is reset whenever the interceptor changes.
+	interceptFilter InterceptFilter
+
 	// heldHtlcSet keeps track of outstanding intercepted forwards.
 	heldHtlcSet *heldHtlcSet
 
@@ -175,6 +183,7 @@ func NewInterceptableSwitch(cfg *InterceptableSwitchConfig) (
 		intercepted:             make(chan *interceptedPackets),
 		onchainIntercepted:      make(chan InterceptedForward),
 		interceptorRegistration: make(chan ForwardInterceptor),
+		filterRegistration:      make(chan InterceptFilter),
 		heldHtlcSet:             newHeldHtlcSet(),
 		resolutionChan:          make(chan *fwdResolution),
 		requireInterceptor:      cfg.RequireInterceptor,
@@ -200,6 +209,19 @@ func (s *InterceptableSwitch) SetInterceptor(
 	}
 }
 
+// SetInterceptFilter sets the filter that decides which packets are passed to
+// the current interceptor. A nil argument passes all packets. The filter is
+// reset when the interceptor changes.
+func (s *InterceptableSwitch) SetInterceptFilter(filter InterceptFilter) {
+	// Synchronize setting the filter with the main loop to prevent race
+	// conditions.
+	select {
+	case s.filterRegistration <- filter:
+
+	case <-s.quit:
+	}
+}
+
 func (s *InterceptableSwitch) Start() error {
 	blockEpochStream, err := s.notifier.RegisterBlockEpochNtfn(nil)
 	if err != nil {
@@ -252,6 +274,10 @@ func (s *InterceptableSwitch) run() error {
 		case interceptor := <-s.interceptorRegistration:
 			s.setInterceptor(interceptor)
 
+		// The filter of the current interceptor is updated.
+		case filter := <-s.filterRegistration:
+			s.interceptFilter = filter
+
 		case packets := <-s.intercepted:
 			var notIntercepted []*htlcPacket
 			for _, p := range packets.packets {
@@ -332,6 +358,7 @@ func (s *InterceptableSwitch) sendForward(fwd InterceptedForward) {
 
 func (s *InterceptableSwitch) setInterceptor(interceptor ForwardInterceptor) {
 	s.interceptor = interceptor
+	s.interceptFilter = nil
 
 	// Replay all currently held htlcs. When an interceptor is not required,
 	// there may be none because they've been cleared after the previous
@@ -542,6 +569,12 @@ func (s *InterceptableSwitch) forward(
 		return true, nil
 	}
 
+	// The interceptor isn't interested in packets that don't match its
+	// filter, so they are processed normally.
+	if s.interceptFilter != nil && !s.interceptFilter(fwd.Packet()) {
+		return false, nil
+	}
+
 	// There is an interceptor registered. We can forward the packet right now.
 	// Hold it in the queue too to track what is outstanding.
 	if err := s.heldHtlcSet.push(inKey, fwd); err != nil {
@@ -310,6 +310,11 @@ type InterceptableHtlcForwarder interface {
 	// SetInterceptor sets a ForwardInterceptor.
 	SetInterceptor(interceptor ForwardInterceptor)
 
+	// SetInterceptFilter sets the filter that decides which htlcs are
+	// passed to the current ForwardInterceptor. A nil filter passes all
+	// htlcs.
+	SetInterceptFilter(filter InterceptFilter)
+
 	// Resolve resolves an intercepted packet.
 	Resolve(res *FwdResolution) error
 }
@@ -322,6 +327,11 @@ type InterceptableHtlcForwarder interface {
 // and resolve it later or let the switch execute its default behavior.
 type ForwardInterceptor func(InterceptedPacket) error
 
+// InterceptFilter is a function that decides whether an htlc is passed to the
+// ForwardInterceptor. Htlcs that don't match the filter are processed by the
+// switch as if no interceptor was registered.
+type InterceptFilter func(InterceptedPacket) bool
+
 // InterceptedPacket contains the relevant information for the interceptor about
 // an htlc.
 type InterceptedPacket struct {
@@ -278,6 +278,17 @@ type ChannelLinkConfig struct {
 	// by failing back any blinding-related payloads as if they were
 	// invalid.
 	DisallowRouteBlinding bool
+
+	// AllowPTLCs is true if both we and the remote peer signal support for
+	// the experimental point time locked contracts on this channel. If it
+	// isn't set, adds that are locked to a payment point are rejected in
+	// both directions.
+	AllowPTLCs bool
+
+	// AuxTrafficShapers is the set of aux traffic shapers that are asked,
+	// in order, whether they want to shape the outgoing htlcs of the
+	// channel. The first one that does is used for all outgoing htlcs.
+	AuxTrafficShapers []AuxTrafficShaper
 }
 
 // channelLink is the service which drives a channel's commitment update
@@ -1505,6 +1516,24 @@ func (l *channelLink) processHtlcResolution(resolution invoices.HtlcResolution,
 		l.log.Debugf("received settle resolution for %v "+
 			"with outcome: %v", circuitKey, res.Outcome)
 
+		// An htlc that is locked to a payment point can only be
+		// settled with the discrete logarithm of the point, so we fail
+		// it back if the invoice preimage doesn't match.
+		paymentPoint := htlc.pd.PaymentPoint
+		if paymentPoint != nil && !paymentPoint.IsPaidBy(res.Preimage) {
+			l.log.Errorf("preimage of %v doesn't match payment "+
+				"point", circuitKey)
+
+			failure := NewLinkError(lnwire.NewFailIncorrectDetails(
+				htlc.pd.Amount, 0,
+			))
+			l.sendHTLCError(
+				htlc.pd, failure, htlc.obfuscator, true,
+			)
+
+			return nil
+		}
+
 		return l.settleHTLC(res.Preimage, htlc.pd)
 
 	// For htlc failures, we get the relevant failure message based
@@ -1591,6 +1620,28 @@ func (l *channelLink) handleDownstreamUpdateAdd(pkt *htlcPacket) error {
 		return nil
 	}
 
+	// We can only offer htlcs that are locked to a payment point if the
+	// remote peer understands them, otherwise it would fail the link.
+	if htlc.PaymentPoint != nil && !l.cfg.AllowPTLCs {
+		l.log.Warnf("Unable to add ptlc with payment hash %x to "+
+			"link without ptlc support", htlc.PaymentHash[:])
+
+		l.mailBox.FailAdd(pkt)
+
+		return NewDetailedLinkError(
+			&lnwire.FailRequiredChannelFeatureMissing{},
+			OutgoingFailureLinkNotEligible,
+		)
+	}
+
+	// Let the aux traffic shaper of the channel, if any, check the
+	// bandwidth available for the htlc and set its custom records.
+	if linkErr := l.shapeDownstreamAdd(pkt, htlc); linkErr != nil {
+		l.mailBox.FailAdd(pkt)
+
+		return linkErr
+	}
+
 	// A new payment has been initiated via the downstream channel,
 	// so we add the new HTLC to our local log, then update the
 	// commitment chains.
@@ -1606,8 +1657,8 @@ func (l *channelLink) handleDownstreamUpdateAdd(pkt *htlcPacket) error {
 		// The HTLC was unable to be added to the state machine,
 		// as a result, we'll signal the switch to cancel the
 		// pending payment.
-		l.log.Warnf("Unable to handle downstream add HTLC: %v",
-			err)
+		l.htlcLog(htlc.PaymentHash).Warnf("Unable to handle "+
+			"downstream add HTLC: %v", err)
 
 		// Remove this packet from the link's mailbox, this
 		// prevents it from being reprocessed if the link
@@ -1625,8 +1676,8 @@ func (l *channelLink) handleDownstreamUpdateAdd(pkt *htlcPacket) error {
 		)
 	}
 
-	l.log.Tracef("received downstream htlc: payment_hash=%x, "+
-		"local_log_index=%v, pend_updates=%v",
+	l.htlcLog(htlc.PaymentHash).Tracef("received downstream htlc: "+
+		"payment_hash=%x, local_log_index=%v, pend_updates=%v",
 		htlc.PaymentHash[:], index,
 		l.channel.PendingLocalUpdateCount())
 
@@ -1659,6 +1710,12 @@ func (l *channelLink) handleDownstreamUpdateAdd(pkt *htlcPacket) error {
 	return nil
 }
 
+// htlcLog returns the logger of the link that attaches the trace ID of the
+// payment with the given hash to every log entry.
+func (l *channelLink) htlcLog(hash [32]byte) btclog.Logger {
+	return build.NewTraceLog(build.PaymentTraceID(hash), l.log)
+}
+
 // handleDownstreamPkt processes an HTLC packet sent from the downstream HTLC
 // Switch. Possible messages sent by the switch include requests to forward new
 // HTLCs, timeout previously cleared HTLCs, and finally to settle currently
@@ -1958,6 +2015,16 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 			return
 		}
 
+		// Disallow htlcs that are locked to a payment point if we
+		// haven't negotiated the experimental PTLC feature.
+		if msg.PaymentPoint != nil && !l.cfg.AllowPTLCs {
+			l.fail(LinkFailureError{code: ErrInvalidUpdate},
+				"payment point included when ptlcs are "+
+					"disabled")
+
+			return
+		}
+
 		// We just received an add request from an upstream peer, so we
 		// add it to our state machine, then add the HTLC to our
 		// "settle" list in the event that we know the preimage.
@@ -1968,8 +2035,9 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 			return
 		}
 
-		l.log.Tracef("receive upstream htlc with payment hash(%x), "+
-			"assigning index: %v", msg.PaymentHash[:], index)
+		l.htlcLog(msg.PaymentHash).Tracef("receive upstream htlc "+
+			"with payment hash(%x), assigning index: %v",
+			msg.PaymentHash[:], index)
 
 	case *lnwire.UpdateFulfillHTLC:
 		pre := msg.PaymentPreimage
@@ -1996,6 +2064,11 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 			return
 		}
 
+		preimage := lntypes.Preimage(pre)
+		hash := preimage.Hash()
+		l.htlcLog(hash).Tracef("receive upstream settle of htlc %v "+
+			"with payment hash(%v)", idx, hash)
+
 		if err := l.channel.ReceiveHTLCSettle(pre, idx); err != nil {
 			l.fail(
 				LinkFailureError{
@@ -3433,6 +3506,7 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 					Amount:        fwdInfo.AmountToForward,
 					PaymentHash:   pd.RHash,
 					BlindingPoint: fwdInfo.NextBlinding,
+					PaymentPoint:  pd.PaymentPoint,
 				}
 
 				// Finally, we'll encode the onion packet for
@@ -3446,20 +3520,23 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				chanIterator.EncodeNextHop(buf)
 
 				inboundFee := l.cfg.FwrdingPolicy.InboundFee
+				endorsed := pd.Endorsement.IsEndorsed()
 
 				updatePacket := &htlcPacket{
-					incomingChanID:  l.ShortChanID(),
-					incomingHTLCID:  pd.HtlcIndex,
-					outgoingChanID:  fwdInfo.NextHop,
-					sourceRef:       pd.SourceRef,
-					incomingAmount:  pd.Amount,
-					amount:          addMsg.Amount,
-					htlc:            addMsg,
-					obfuscator:      obfuscator,
-					incomingTimeout: pd.Timeout,
-					outgoingTimeout: fwdInfo.OutgoingCTLV,
-					customRecords:   pld.CustomRecords(),
-					inboundFee:      inboundFee,
+					incomingChanID:   l.ShortChanID(),
+					incomingHTLCID:   pd.HtlcIndex,
+					outgoingChanID:   fwdInfo.NextHop,
+					sourceRef:        pd.SourceRef,
+					incomingAmount:   pd.Amount,
+					amount:           addMsg.Amount,
+					htlc:             addMsg,
+					obfuscator:       obfuscator,
+					incomingTimeout:  pd.Timeout,
+					outgoingTimeout:  fwdInfo.OutgoingCTLV,
+					customRecords:    pld.CustomRecords(),
+					inboundFee:       inboundFee,
+					incomingEndorsed: endorsed,
+					incomingRecords:  pd.CustomRecords,
 				}
 				switchPackets = append(
 					switchPackets, updatePacket,
@@ -3479,6 +3556,7 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				Amount:        fwdInfo.AmountToForward,
 				PaymentHash:   pd.RHash,
 				BlindingPoint: fwdInfo.NextBlinding,
+				PaymentPoint:  pd.PaymentPoint,
 			}
 
 			// Finally, we'll encode the onion packet for the
@@ -3514,20 +3592,23 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// section.
 			if fwdPkg.State == channeldb.FwdStateLockedIn {
 				inboundFee := l.cfg.FwrdingPolicy.InboundFee
+				endorsed := pd.Endorsement.IsEndorsed()
 
 				updatePacket := &htlcPacket{
-					incomingChanID:  l.ShortChanID(),
-					incomingHTLCID:  pd.HtlcIndex,
-					outgoingChanID:  fwdInfo.NextHop,
-					sourceRef:       pd.SourceRef,
-					incomingAmount:  pd.Amount,
-					amount:          addMsg.Amount,
-					htlc:            addMsg,
-					obfuscator:      obfuscator,
-					incomingTimeout: pd.Timeout,
-					outgoingTimeout: fwdInfo.OutgoingCTLV,
-					customRecords:   pld.CustomRecords(),
-					inboundFee:      inboundFee,
+					incomingChanID:   l.ShortChanID(),
+					incomingHTLCID:   pd.HtlcIndex,
+					outgoingChanID:   fwdInfo.NextHop,
+					sourceRef:        pd.SourceRef,
+					incomingAmount:   pd.Amount,
+					amount:           addMsg.Amount,
+					htlc:             addMsg,
+					obfuscator:       obfuscator,
+					incomingTimeout:  pd.Timeout,
+					outgoingTimeout:  fwdInfo.OutgoingCTLV,
+					customRecords:    pld.CustomRecords(),
+					inboundFee:       inboundFee,
+					incomingEndorsed: endorsed,
+					incomingRecords:  pd.CustomRecords,
 				}
 
 				fwdPkg.FwdFilter.Set(idx)
@@ -3652,7 +3733,7 @@ func (l *channelLink) settleHTLC(preimage lntypes.Preimage,
 
 	hash := preimage.Hash()
 
-	l.log.Infof("settling htlc %v as exit hop", hash)
+	l.htlcLog(hash).Infof("settling htlc %v as exit hop", hash)
 
 	err := l.channel.SettleHTLC(
 		preimage, pd.HtlcIndex, pd.SourceRef, nil, nil,
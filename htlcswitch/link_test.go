@@ -5872,6 +5872,29 @@ func TestChannelLinkFail(t *testing.T) {
 			false,
 			false,
 		},
+		{
+			"don't force close the channel if we receive a ptlc " +
+				"without having negotiated ptlcs",
+			func(c *channelLink) {
+			},
+			func(_ *testing.T, s *Switch, c *channelLink,
+				_ *lnwallet.LightningChannel) {
+
+				// Receive an htlc that is locked to a payment
+				// point.
+				_, point := btcec.PrivKeyFromBytes(
+					bytes.Repeat([]byte{0x1}, 32),
+				)
+				htlc := generateHtlc(t, c, 0)
+				htlc.PaymentPoint = lnwire.NewPaymentPoint(
+					point,
+				)
+				c.HandleChannelUpdate(htlc)
+			},
+			true,
+			false,
+			false,
+		},
 		{
 			"force close the channel if we receive an invalid " +
 				"CommitSig, not containing enough HTLC sigs",
@@ -999,7 +999,7 @@ func newMockRegistry(minDelta uint32) *mockInvoiceRegistry {
 	registry := invoices.NewRegistry(
 		cdb,
 		invoices.NewInvoiceExpiryWatcher(
-			clock.NewDefaultClock(), 0, 0, nil,
+			clock.NewDefaultClock(), 0, 0, 0, nil,
 			&mockChainNotifier{},
 		),
 		&invoices.RegistryConfig{
@@ -1121,6 +1121,10 @@ func (m *mockCircuitMap) NumOpen() int {
 	return 0
 }
 
+func (m *mockCircuitMap) Circuits() []PaymentCircuit {
+	return nil
+}
+
 type mockOnionErrorDecryptor struct {
 	sourceIdx int
 	message   []byte
@@ -98,6 +98,11 @@ type htlcPacket struct {
 	// were included in the payload.
 	customRecords record.CustomSet
 
+	// incomingRecords are the custom records an overlay protocol attached
+	// to the incoming htlc in update_add_htlc. They are only set for
+	// forwarded htlcs.
+	incomingRecords lnwire.CustomRecords
+
 	// originalOutgoingChanID is used when sending back failure messages.
 	// It is only used for forwarded Adds on option_scid_alias channels.
 	// This is to avoid possible confusion if a payer uses the public SCID
@@ -107,6 +112,10 @@ type htlcPacket struct {
 
 	// inboundFee is the fee schedule of the incoming channel.
 	inboundFee models.InboundFee
+
+	// incomingEndorsed indicates whether the incoming htlc was endorsed by
+	// the upstream peer.
+	incomingEndorsed bool
 }
 
 // inKey returns the circuit key used to identify the incoming htlc.
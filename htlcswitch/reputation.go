@@ -0,0 +1,374 @@
+package htlcswitch
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// DefaultResolutionPeriod is the default time within which a forwarded
+	// HTLC is expected to be resolved. Endorsed HTLCs that are held longer
+	// damage the reputation of the peer that endorsed them.
+	DefaultResolutionPeriod = 90 * time.Second
+
+	// DefaultGeneralSlots is the default number of HTLCs that may be
+	// forwarded unendorsed over a channel at the same time, which is half
+	// of the number of HTLCs a channel accepts in one direction.
+	DefaultGeneralSlots = 241
+
+	// reputationHalfLife is the half-life of the reputation of our peers
+	// and the revenue of our channels. Older forwards are weighted less,
+	// so that peers can't build up their reputation once and abuse it
+	// indefinitely.
+	reputationHalfLife = 14 * 24 * time.Hour
+)
+
+// decayingValue is a value that decays exponentially over time.
+type decayingValue struct {
+	value      float64
+	lastUpdate time.Time
+}
+
+// get returns the value decayed up to the given time.
+func (d *decayingValue) get(now time.Time) float64 {
+	if d.lastUpdate.IsZero() || !now.After(d.lastUpdate) {
+		return d.value
+	}
+
+	elapsed := now.Sub(d.lastUpdate).Seconds()
+	halfLife := reputationHalfLife.Seconds()
+	d.value *= math.Pow(0.5, elapsed/halfLife)
+	d.lastUpdate = now
+
+	return d.value
+}
+
+// add adds delta to the value decayed up to the given time.
+func (d *decayingValue) add(delta float64, now time.Time) {
+	d.value = d.get(now) + delta
+	d.lastUpdate = now
+}
+
+// peerReputation is the local reputation of a peer, which is built up by the
+// fees of the HTLCs it sends us.
+type peerReputation struct {
+	// reputation is the sum of the effective fees that the HTLCs of the
+	// peer earned us.
+	reputation decayingValue
+
+	// endorsedInFlight is the number of HTLCs endorsed by the peer that
+	// are currently forwarded.
+	endorsedInFlight uint32
+}
+
+// channelRevenue is the revenue of an outgoing channel along with the number
+// of general slots in use.
+type channelRevenue struct {
+	// revenue is the sum of the fees earned by forwards over the channel.
+	revenue decayingValue
+
+	// generalInFlight is the number of unendorsed HTLCs that are currently
+	// forwarded over the channel.
+	generalInFlight uint32
+}
+
+// trackedForward is a forwarded HTLC whose resolution is tracked to update
+// the reputation of the peer that sent it.
+type trackedForward struct {
+	incomingPeer     [33]byte
+	outgoingChanID   lnwire.ShortChannelID
+	fee              lnwire.MilliSatoshi
+	incomingEndorsed bool
+	endorsed         bool
+	addedAt          time.Time
+}
+
+// PeerReputation is a snapshot of the local reputation of a peer.
+type PeerReputation struct {
+	// PeerPubKey is the public key of the peer.
+	PeerPubKey [33]byte
+
+	// Reputation is the decayed sum of the effective fees that the HTLCs
+	// of the peer earned us. It is negative if the peer's endorsed HTLCs
+	// were held longer than the resolution period.
+	Reputation int64
+
+	// EndorsedInFlight is the number of HTLCs endorsed by the peer that
+	// are currently forwarded.
+	EndorsedInFlight uint32
+}
+
+// ChannelRevenue is a snapshot of the revenue and general slot usage of an
+// outgoing channel.
+type ChannelRevenue struct {
+	// ChanID is the short channel ID of the channel.
+	ChanID lnwire.ShortChannelID
+
+	// Revenue is the decayed sum of the fees earned by forwards over the
+	// channel.
+	Revenue int64
+
+	// GeneralSlotsInUse is the number of unendorsed HTLCs that are
+	// currently forwarded over the channel.
+	GeneralSlotsInUse uint32
+}
+
+// ReputationReport is a snapshot of the reputation tracked by the switch.
+type ReputationReport struct {
+	// Peers holds the reputation of all peers that sent us HTLCs.
+	Peers []PeerReputation
+
+	// Channels holds the revenue of all channels that we forwarded HTLCs
+	// over.
+	Channels []ChannelRevenue
+
+	// GeneralSlots is the number of HTLCs that may be forwarded
+	// unendorsed over a channel at the same time. Zero means that the
+	// general slots aren't limited.
+	GeneralSlots uint32
+}
+
+// reputationTracker decides whether forwarded HTLCs are endorsed to the next
+// hop, based on the endorsement signal of the incoming HTLC and the local
+// reputation of the peer that sent it. A peer has a good reputation if the
+// effective fees its HTLCs earned us exceed the revenue of the outgoing
+// channel, meaning that the peer contributed more than it can take away by
+// occupying the channel's resources.
+//
+// Endorsed HTLCs of peers with a good reputation are forwarded endorsed and
+// may use all slots of the outgoing channel. All other HTLCs are forwarded
+// unendorsed and only get a limited number of general slots. They are
+// scot-free, i.e. holding them doesn't hurt the reputation of the sender,
+// while endorsed HTLCs that are held longer than the resolution period
+// decrease it.
+//
+// NOTE: Forwarded HTLCs are only tracked in memory, so HTLCs that were
+// forwarded before a restart don't affect the reputation of their sender.
+type reputationTracker struct {
+	mu sync.Mutex
+
+	// resolutionPeriod is the time within which a forwarded HTLC is
+	// expected to be resolved.
+	resolutionPeriod time.Duration
+
+	// generalSlots is the number of general slots per outgoing channel.
+	generalSlots uint32
+
+	clock clock.Clock
+
+	// peers holds the reputation of all peers that sent us HTLCs.
+	peers map[[33]byte]*peerReputation
+
+	// channels holds the revenue of all channels that we forwarded HTLCs
+	// over.
+	channels map[lnwire.ShortChannelID]*channelRevenue
+
+	// forwards holds the forwarded HTLCs keyed by their incoming circuit
+	// key.
+	forwards map[CircuitKey]*trackedForward
+}
+
+// newReputationTracker creates a new reputation tracker.
+func newReputationTracker(resolutionPeriod time.Duration,
+	generalSlots uint32, clock clock.Clock) *reputationTracker {
+
+	channels := make(map[lnwire.ShortChannelID]*channelRevenue)
+
+	return &reputationTracker{
+		resolutionPeriod: resolutionPeriod,
+		generalSlots:     generalSlots,
+		clock:            clock,
+		peers:            make(map[[33]byte]*peerReputation),
+		channels:         channels,
+		forwards:         make(map[CircuitKey]*trackedForward),
+	}
+}
+
+// peer returns the reputation of the given peer, creating it if necessary.
+func (r *reputationTracker) peer(pubKey [33]byte) *peerReputation {
+	peer, ok := r.peers[pubKey]
+	if !ok {
+		peer = &peerReputation{}
+		r.peers[pubKey] = peer
+	}
+
+	return peer
+}
+
+// channel returns the revenue of the given channel, creating it if necessary.
+func (r *reputationTracker) channel(
+	chanID lnwire.ShortChannelID) *channelRevenue {
+
+	channel, ok := r.channels[chanID]
+	if !ok {
+		channel = &channelRevenue{}
+		r.channels[chanID] = channel
+	}
+
+	return channel
+}
+
+// addForward records an HTLC that is about to be forwarded and returns the
+// endorsement signal that it should be forwarded with. It returns false
+// without recording the HTLC if it would be forwarded unendorsed but all
+// general slots of the outgoing channel are in use.
+func (r *reputationTracker) addForward(inKey CircuitKey, incomingPeer [33]byte,
+	outgoingChanID lnwire.ShortChannelID, fee lnwire.MilliSatoshi,
+	incomingEndorsed bool) (lnwire.Endorsement, bool) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// An HTLC that is already tracked keeps its endorsement.
+	if fwd, ok := r.forwards[inKey]; ok {
+		if fwd.endorsed {
+			return lnwire.ExperimentalEndorsed, true
+		}
+
+		return lnwire.ExperimentalUnendorsed, true
+	}
+
+	now := r.clock.Now()
+	peer := r.peer(incomingPeer)
+	channel := r.channel(outgoingChanID)
+
+	goodReputation := peer.reputation.get(now) >
+		channel.revenue.get(now)
+	endorsed := incomingEndorsed && goodReputation
+
+	if !endorsed && r.generalSlots > 0 &&
+		channel.generalInFlight >= r.generalSlots {
+
+		log.Debugf("General slots of ChannelID(%v) exhausted, "+
+			"rejecting unendorsed HTLC %v", outgoingChanID, inKey)
+
+		return lnwire.ExperimentalUnendorsed, false
+	}
+
+	r.forwards[inKey] = &trackedForward{
+		incomingPeer:     incomingPeer,
+		outgoingChanID:   outgoingChanID,
+		fee:              fee,
+		incomingEndorsed: incomingEndorsed,
+		endorsed:         endorsed,
+		addedAt:          now,
+	}
+
+	if incomingEndorsed {
+		peer.endorsedInFlight++
+	}
+
+	if !endorsed {
+		channel.generalInFlight++
+		return lnwire.ExperimentalUnendorsed, true
+	}
+
+	return lnwire.ExperimentalEndorsed, true
+}
+
+// removeForward stops tracking the forwarded HTLC with the given incoming
+// circuit key without updating any reputation. This is used for HTLCs that
+// couldn't be handed to the outgoing link.
+func (r *reputationTracker) removeForward(inKey CircuitKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.untrack(inKey)
+}
+
+// resolveForward updates the reputation of the sender of the forwarded HTLC
+// with the given incoming circuit key and the revenue of its outgoing channel
+// once the HTLC is resolved. HTLCs that aren't tracked are ignored.
+func (r *reputationTracker) resolveForward(inKey CircuitKey, settled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fwd := r.untrack(inKey)
+	if fwd == nil {
+		return
+	}
+
+	now := r.clock.Now()
+	holdTime := now.Sub(fwd.addedAt)
+
+	// The effective fees of the HTLC are the fees that it earned us, minus
+	// the opportunity cost of holding it for longer than the resolution
+	// period if it was endorsed. Unendorsed HTLCs can't hurt the
+	// reputation of the sender.
+	var effectiveFees float64
+	if settled {
+		effectiveFees = float64(fwd.fee)
+		r.channel(fwd.outgoingChanID).revenue.add(effectiveFees, now)
+	}
+
+	if fwd.incomingEndorsed && holdTime > r.resolutionPeriod {
+		periods := math.Ceil(
+			float64(holdTime-r.resolutionPeriod) /
+				float64(r.resolutionPeriod),
+		)
+		effectiveFees -= periods * float64(fwd.fee)
+	}
+
+	if effectiveFees != 0 {
+		r.peer(fwd.incomingPeer).reputation.add(effectiveFees, now)
+	}
+
+	log.Tracef("Resolved forward %v after %v (settled=%v, endorsed=%v), "+
+		"effective fees: %v", inKey, holdTime, settled, fwd.endorsed,
+		effectiveFees)
+}
+
+// untrack removes the forwarded HTLC with the given incoming circuit key from
+// the in-flight HTLCs and returns it, or nil if it isn't tracked.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (r *reputationTracker) untrack(inKey CircuitKey) *trackedForward {
+	fwd, ok := r.forwards[inKey]
+	if !ok {
+		return nil
+	}
+	delete(r.forwards, inKey)
+
+	if fwd.incomingEndorsed {
+		r.peer(fwd.incomingPeer).endorsedInFlight--
+	}
+
+	if !fwd.endorsed {
+		r.channel(fwd.outgoingChanID).generalInFlight--
+	}
+
+	return fwd
+}
+
+// report returns a snapshot of the tracked reputation.
+func (r *reputationTracker) report() *ReputationReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	report := &ReputationReport{
+		GeneralSlots: r.generalSlots,
+	}
+
+	for pubKey, peer := range r.peers {
+		report.Peers = append(report.Peers, PeerReputation{
+			PeerPubKey:       pubKey,
+			Reputation:       int64(peer.reputation.get(now)),
+			EndorsedInFlight: peer.endorsedInFlight,
+		})
+	}
+
+	for chanID, channel := range r.channels {
+		report.Channels = append(report.Channels, ChannelRevenue{
+			ChanID:            chanID,
+			Revenue:           int64(channel.revenue.get(now)),
+			GeneralSlotsInUse: channel.generalInFlight,
+		})
+	}
+
+	return report
+}
@@ -0,0 +1,143 @@
+package htlcswitch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReputationTracker tests that the reputation tracker builds up the
+// reputation of peers, endorses their HTLCs once it exceeds the revenue of the
+// outgoing channel and penalizes slowly resolved endorsed HTLCs.
+func TestReputationTracker(t *testing.T) {
+	t.Parallel()
+
+	const period = time.Minute
+
+	startTime := time.Unix(1_000_000, 0)
+	testClock := clock.NewTestClock(startTime)
+	tracker := newReputationTracker(period, 1, testClock)
+
+	peerA := [33]byte{1}
+	peerB := [33]byte{2}
+	chanA := lnwire.NewShortChanIDFromInt(1)
+	chanB := lnwire.NewShortChanIDFromInt(2)
+	outChan := lnwire.NewShortChanIDFromInt(3)
+	otherChan := lnwire.NewShortChanIDFromInt(4)
+
+	htlcID := uint64(0)
+	inKey := func(chanID lnwire.ShortChannelID) CircuitKey {
+		htlcID++
+		return CircuitKey{ChanID: chanID, HtlcID: htlcID}
+	}
+
+	// Without any reputation, an endorsed HTLC of peer A is forwarded
+	// unendorsed and takes the only general slot.
+	keyA1 := inKey(chanA)
+	endorsement, ok := tracker.addForward(keyA1, peerA, outChan, 100, true)
+	require.True(t, ok)
+	require.Equal(t, lnwire.ExperimentalUnendorsed, endorsement)
+
+	// Adding the same HTLC again doesn't take another slot.
+	_, ok = tracker.addForward(keyA1, peerA, outChan, 100, true)
+	require.True(t, ok)
+
+	// With the general slot in use, an HTLC of peer B is rejected.
+	_, ok = tracker.addForward(inKey(chanB), peerB, outChan, 100, false)
+	require.False(t, ok)
+
+	// Failing the HTLC quickly frees the slot without affecting the
+	// reputation of peer A.
+	tracker.resolveForward(keyA1, false)
+
+	// An HTLC of peer A over another channel is settled quickly, which
+	// builds up its reputation.
+	keyA2 := inKey(chanA)
+	_, ok = tracker.addForward(keyA2, peerA, otherChan, 100, true)
+	require.True(t, ok)
+	tracker.resolveForward(keyA2, true)
+
+	// Peer A's reputation now exceeds the revenue of the outgoing
+	// channel, so its endorsed HTLCs are forwarded endorsed.
+	keyA3 := inKey(chanA)
+	keyA3Fee := lnwire.MilliSatoshi(1000)
+	endorsement, ok = tracker.addForward(
+		keyA3, peerA, outChan, keyA3Fee, true,
+	)
+	require.True(t, ok)
+	require.Equal(t, lnwire.ExperimentalEndorsed, endorsement)
+
+	// Endorsed HTLCs don't use general slots, so peer B's endorsed HTLC
+	// can still be forwarded unendorsed.
+	keyB1 := inKey(chanB)
+	endorsement, ok = tracker.addForward(keyB1, peerB, outChan, 100, true)
+	require.True(t, ok)
+	require.Equal(t, lnwire.ExperimentalUnendorsed, endorsement)
+
+	// An unendorsed HTLC of peer B is scot-free.
+	keyB2 := inKey(chanB)
+	_, ok = tracker.addForward(keyB2, peerB, otherChan, 100, false)
+	require.True(t, ok)
+
+	report := tracker.report()
+	require.Len(t, report.Peers, 2)
+	require.Len(t, report.Channels, 2)
+	require.EqualValues(t, 1, report.GeneralSlots)
+
+	// All HTLCs are held for two and a half resolution periods before
+	// they fail. The endorsed HTLCs cost their senders twice their fee,
+	// while the unendorsed HTLC doesn't affect the reputation of peer B.
+	testClock.SetTime(startTime.Add(5 * period / 2))
+	tracker.resolveForward(keyA3, false)
+	tracker.resolveForward(keyB1, false)
+	tracker.resolveForward(keyB2, false)
+
+	reputations := make(map[[33]byte]int64)
+	for _, peer := range tracker.report().Peers {
+		reputations[peer.PeerPubKey] = peer.Reputation
+		require.Zero(t, peer.EndorsedInFlight)
+	}
+
+	// The decay over a few minutes is negligible compared to the
+	// half-life, so the values are only checked to be within a delta.
+	require.InDelta(t, 100-2*int64(keyA3Fee), reputations[peerA], 1)
+	require.InDelta(t, -2*100, reputations[peerB], 1)
+
+	// With a negative reputation, peer A's endorsed HTLCs are forwarded
+	// unendorsed again.
+	endorsement, ok = tracker.addForward(
+		inKey(chanA), peerA, outChan, 100, true,
+	)
+	require.True(t, ok)
+	require.Equal(t, lnwire.ExperimentalUnendorsed, endorsement)
+
+	// Removing an HTLC frees its general slot without affecting any
+	// reputation.
+	keyB3 := inKey(chanB)
+	_, ok = tracker.addForward(keyB3, peerB, chanA, 100, false)
+	require.True(t, ok)
+	tracker.removeForward(keyB3)
+	_, ok = tracker.addForward(inKey(chanB), peerB, chanA, 100, false)
+	require.True(t, ok)
+}
+
+// TestDecayingValue tests that a decaying value halves once per half-life.
+func TestDecayingValue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_000_000, 0)
+
+	var value decayingValue
+	value.add(1000, now)
+	require.InDelta(t, 1000, value.get(now), 0.001)
+
+	now = now.Add(reputationHalfLife)
+	require.InDelta(t, 500, value.get(now), 0.001)
+
+	value.add(500, now)
+	now = now.Add(2 * reputationHalfLife)
+	require.InDelta(t, 250, value.get(now), 0.001)
+}
@@ -116,6 +116,11 @@ type ChanClose struct {
 	// DeliveryScript is an optional delivery script to pay funds out to.
 	DeliveryScript lnwire.DeliveryAddress
 
+	// CloseOutputs is an optional set of outputs that our settled balance
+	// is split across. The remainder is paid to the delivery script. This
+	// value is only utilized if the closure type is CloseRegular.
+	CloseOutputs lnwire.CloseOutputs
+
 	// Updates is used by request creator to receive the notifications about
 	// execution of the close channel request.
 	Updates chan interface{}
@@ -222,6 +227,17 @@ type Config struct {
 
 	// IsAlias returns whether or not a given SCID is an alias.
 	IsAlias func(scid lnwire.ShortChannelID) bool
+
+	// ResolutionPeriod is the time within which forwarded HTLCs are
+	// expected to be resolved. Endorsed HTLCs that are held longer damage
+	// the reputation of the peer that sent them. If zero,
+	// DefaultResolutionPeriod is used.
+	ResolutionPeriod time.Duration
+
+	// GeneralSlots is the number of HTLCs that may be forwarded
+	// unendorsed over a channel at the same time. Zero means that the
+	// number isn't limited.
+	GeneralSlots uint32
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -259,6 +275,21 @@ type Switch struct {
 	// forward the settle/fail htlc updates back to the add htlc initiator.
 	circuits CircuitMap
 
+	// fwdLimiter enforces the forwarding limits of the outgoing channels
+	// of forwarded HTLCs.
+	fwdLimiter *forwardingLimiter
+
+	// dustPolicies holds the dust exposure policies of the channels that
+	// don't use the global DustThreshold.
+	dustPolicies map[lnwire.ChannelID]channeldb.DustExposurePolicy
+
+	// dustPoliciesMtx guards dustPolicies.
+	dustPoliciesMtx sync.RWMutex
+
+	// reputation tracks the local reputation of our peers and decides
+	// whether forwarded HTLCs are endorsed.
+	reputation *reputationTracker
+
 	// mailOrchestrator manages the lifecycle of mailboxes used throughout
 	// the switch, and facilitates delayed delivery of packets to links that
 	// later come online.
@@ -364,10 +395,21 @@ func New(cfg Config, currentHeight uint32) (*Switch, error) {
 		return nil, err
 	}
 
+	resolutionPeriod := cfg.ResolutionPeriod
+	if resolutionPeriod == 0 {
+		resolutionPeriod = DefaultResolutionPeriod
+	}
+	reputation := newReputationTracker(
+		resolutionPeriod, cfg.GeneralSlots, cfg.Clock,
+	)
+
 	s := &Switch{
 		bestHeight:        currentHeight,
 		cfg:               &cfg,
 		circuits:          circuitMap,
+		fwdLimiter:        newForwardingLimiter(),
+		dustPolicies:      make(map[lnwire.ChannelID]channeldb.DustExposurePolicy),
+		reputation:        reputation,
 		linkIndex:         make(map[lnwire.ChannelID]ChannelLink),
 		forwardingIndex:   make(map[lnwire.ShortChannelID]ChannelLink),
 		interfaceIndex:    make(map[[33]byte]map[lnwire.ChannelID]ChannelLink),
@@ -636,6 +678,127 @@ func (s *Switch) UpdateForwardingPolicies(
 	s.indexMtx.RUnlock()
 }
 
+// UpdateForwardingLimits replaces the forwarding limits of the given channels.
+// Empty limits remove the limits of a channel. The limits are kept for
+// channels that don't have an active link yet.
+func (s *Switch) UpdateForwardingLimits(
+	chanLimits map[wire.OutPoint]channeldb.ForwardingLimits) {
+
+	for chanPoint, limits := range chanLimits {
+		log.Debugf("Updating forwarding limits of ChannelPoint(%v): "+
+			"%+v", chanPoint, limits)
+
+		s.fwdLimiter.setLimits(
+			lnwire.NewChanIDFromOutPoint(chanPoint), limits,
+		)
+	}
+}
+
+// UpdateDustExposurePolicies replaces the dust exposure policies of the given
+// channels. Empty policies make a channel use the global dust threshold again.
+// The policies are kept for channels that don't have an active link yet.
+func (s *Switch) UpdateDustExposurePolicies(
+	policies map[wire.OutPoint]channeldb.DustExposurePolicy) {
+
+	s.dustPoliciesMtx.Lock()
+	defer s.dustPoliciesMtx.Unlock()
+
+	for chanPoint, policy := range policies {
+		log.Debugf("Updating dust exposure policy of "+
+			"ChannelPoint(%v): %+v", chanPoint, policy)
+
+		chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+		if policy.IsEmpty() {
+			delete(s.dustPolicies, chanID)
+			continue
+		}
+
+		s.dustPolicies[chanID] = policy
+	}
+}
+
+// dustThreshold returns the maximum dust exposure of the given channel at the
+// given commitment fee rate.
+func (s *Switch) dustThreshold(chanID lnwire.ChannelID,
+	feeRate chainfee.SatPerKWeight) lnwire.MilliSatoshi {
+
+	s.dustPoliciesMtx.RLock()
+	policy := s.dustPolicies[chanID]
+	s.dustPoliciesMtx.RUnlock()
+
+	return lnwallet.DustExposureLimit(policy, feeRate, s.cfg.DustThreshold)
+}
+
+// Reputation returns a snapshot of the local reputation of our peers and the
+// revenue of our channels that is used to decide whether forwarded HTLCs are
+// endorsed.
+func (s *Switch) Reputation() *ReputationReport {
+	return s.reputation.report()
+}
+
+// ListCircuits returns a snapshot of all circuits of the switch, i.e. the
+// HTLCs that are in flight through this node. The HTLC expiries aren't
+// persisted as part of a circuit, so for circuits that were loaded from disk
+// they are looked up in the local commitments of the open channels.
+func (s *Switch) ListCircuits() ([]PaymentCircuit, error) {
+	circuits := s.circuits.Circuits()
+
+	var loadedFromDisk bool
+	for _, circuit := range circuits {
+		if circuit.LoadedFromDisk {
+			loadedFromDisk = true
+			break
+		}
+	}
+	if !loadedFromDisk {
+		return circuits, nil
+	}
+
+	channels, err := s.cfg.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	// htlcKey identifies an HTLC in the commitment of a channel. The HTLC
+	// indexes of incoming and outgoing HTLCs are independent.
+	type htlcKey struct {
+		CircuitKey
+		incoming bool
+	}
+
+	expiries := make(map[htlcKey]uint32)
+	for _, channel := range channels {
+		for _, htlc := range channel.LocalCommitment.Htlcs {
+			key := htlcKey{
+				CircuitKey: CircuitKey{
+					ChanID: channel.ShortChannelID,
+					HtlcID: htlc.HtlcIndex,
+				},
+				incoming: htlc.Incoming,
+			}
+			expiries[key] = htlc.RefundTimeout
+		}
+	}
+
+	for i := range circuits {
+		circuit := &circuits[i]
+		if circuit.IncomingTimeout == 0 {
+			circuit.IncomingTimeout = expiries[htlcKey{
+				CircuitKey: circuit.Incoming,
+				incoming:   true,
+			}]
+		}
+
+		if circuit.OutgoingTimeout == 0 && circuit.HasKeystone() {
+			circuit.OutgoingTimeout = expiries[htlcKey{
+				CircuitKey: *circuit.Outgoing,
+			}]
+		}
+	}
+
+	return circuits, nil
+}
+
 // IsForwardedHTLC checks for a given channel and htlc index if it is related
 // to an opened circuit that represents a forwarded payment.
 func (s *Switch) IsForwardedHTLC(chanID lnwire.ShortChannelID,
@@ -1276,10 +1439,54 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return s.failAddPacket(packet, linkErr)
 		}
 
+		// Make sure that forwarding this HTLC doesn't exceed the
+		// forwarding limits of the destination channel.
+		if !s.fwdLimiter.reserve(
+			packet.inKey(), destination.ChanID(), packet.amount,
+		) {
+
+			linkErr := NewDetailedLinkError(
+				&lnwire.FailTemporaryChannelFailure{},
+				OutgoingFailureForwardingLimit,
+			)
+
+			return s.failAddPacket(packet, linkErr)
+		}
+
+		// Decide whether the HTLC is endorsed to the next hop based on
+		// the reputation of the incoming peer. Unendorsed HTLCs are
+		// only forwarded if a general slot of the destination channel
+		// is available.
+		var fee lnwire.MilliSatoshi
+		if packet.incomingAmount > packet.amount {
+			fee = packet.incomingAmount - packet.amount
+		}
+		endorsement, ok := s.reputation.addForward(
+			packet.inKey(), incomingLink.PeerPubKey(),
+			destination.ShortChanID(), fee, packet.incomingEndorsed,
+		)
+		if !ok {
+			s.fwdLimiter.release(packet.inKey())
+
+			linkErr := NewDetailedLinkError(
+				&lnwire.FailTemporaryChannelFailure{},
+				OutgoingFailureGeneralSlotsExhausted,
+			)
+
+			return s.failAddPacket(packet, linkErr)
+		}
+		htlc.Endorsement = &endorsement
+
 		// Send the packet to the destination channel link which
 		// manages the channel.
 		packet.outgoingChanID = destination.ShortChanID()
-		return destination.handleSwitchPacket(packet)
+		err = destination.handleSwitchPacket(packet)
+		if err != nil {
+			s.fwdLimiter.release(packet.inKey())
+			s.reputation.removeForward(packet.inKey())
+		}
+
+		return err
 
 	case *lnwire.UpdateFailHTLC, *lnwire.UpdateFulfillHTLC:
 		// If the source of this packet has not been set, use the
@@ -1295,7 +1502,13 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return nil
 		}
 
+		// The HTLC no longer counts towards the forwarding limits of
+		// its outgoing channel, and its resolution is reflected in the
+		// reputation of its sender.
+		s.fwdLimiter.release(circuit.Incoming)
+
 		fail, isFail := htlc.(*lnwire.UpdateFailHTLC)
+		s.reputation.resolveForward(circuit.Incoming, !isFail)
 		if isFail && !packet.hasSource {
 			// HTLC resolutions and messages restored from disk
 			// don't have the obfuscator set from the original htlc
@@ -1343,33 +1556,43 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 					fail.Reason,
 				)
 			}
-		} else if !isFail && circuit.Outgoing != nil {
-			// If this is an HTLC settle, and it wasn't from a
-			// locally initiated HTLC, then we'll log a forwarding
-			// event so we can flush it to disk later.
-			//
-			// TODO(roasbeef): only do this once link actually
-			// fully settles?
-			localHTLC := packet.incomingChanID == hop.Source
-			if !localHTLC {
-				log.Infof("Forwarded HTLC(%x) of %v (fee: %v) "+
-					"from IncomingChanID(%v) to OutgoingChanID(%v)",
-					circuit.PaymentHash[:], circuit.OutgoingAmount,
-					circuit.IncomingAmount-circuit.OutgoingAmount,
-					circuit.Incoming.ChanID, circuit.Outgoing.ChanID)
-				s.fwdEventMtx.Lock()
-				s.pendingFwdingEvents = append(
-					s.pendingFwdingEvents,
-					channeldb.ForwardingEvent{
-						Timestamp:      time.Now(),
-						IncomingChanID: circuit.Incoming.ChanID,
-						OutgoingChanID: circuit.Outgoing.ChanID,
-						AmtIn:          circuit.IncomingAmount,
-						AmtOut:         circuit.OutgoingAmount,
-					},
-				)
-				s.fwdEventMtx.Unlock()
+		}
+
+		// If the HTLC was forwarded, i.e. it wasn't from a locally
+		// initiated HTLC, then we'll log a forwarding event for the
+		// settle or fail so we can flush it to disk later.
+		//
+		// TODO(roasbeef): only do this once link actually fully
+		// settles?
+		localHTLC := packet.incomingChanID == hop.Source
+		if circuit.Outgoing != nil && !localHTLC {
+			outcome := "Forwarded"
+			if isFail {
+				outcome = "Failed to forward"
 			}
+			log.Infof("%s HTLC(%x) of %v (fee: %v) from "+
+				"IncomingChanID(%v) to OutgoingChanID(%v)",
+				outcome, circuit.PaymentHash[:],
+				circuit.OutgoingAmount,
+				circuit.IncomingAmount-circuit.OutgoingAmount,
+				circuit.Incoming.ChanID,
+				circuit.Outgoing.ChanID)
+
+			s.fwdEventMtx.Lock()
+			s.pendingFwdingEvents = append(
+				s.pendingFwdingEvents,
+				channeldb.ForwardingEvent{
+					Timestamp:      time.Now(),
+					IncomingChanID: circuit.Incoming.ChanID,
+					OutgoingChanID: circuit.Outgoing.ChanID,
+					AmtIn:          circuit.IncomingAmount,
+					AmtOut:         circuit.OutgoingAmount,
+					IncomingExpiry: circuit.IncomingTimeout,
+					OutgoingExpiry: circuit.OutgoingTimeout,
+					Failed:         isFail,
+				},
+			)
+			s.fwdEventMtx.Unlock()
 		}
 
 		// A blank IncomingChanID in a circuit indicates that it is a pending
@@ -1675,11 +1898,14 @@ func (s *Switch) teardownCircuit(pkt *htlcPacket) error {
 // directing the specified closure type. If the closure type is CloseRegular,
 // targetFeePerKw parameter should be the ideal fee-per-kw that will be used as
 // a starting point for close negotiation. The deliveryScript parameter is an
-// optional parameter which sets a user specified script to close out to.
+// optional parameter which sets a user specified script to close out to. The
+// closeOutputs parameter optionally splits our settled balance across
+// additional outputs.
 func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
 	closeType contractcourt.ChannelCloseType,
 	targetFeePerKw, maxFee chainfee.SatPerKWeight,
-	deliveryScript lnwire.DeliveryAddress) (chan interface{}, chan error) {
+	deliveryScript lnwire.DeliveryAddress,
+	closeOutputs lnwire.CloseOutputs) (chan interface{}, chan error) {
 
 	// TODO(roasbeef) abstract out the close updates.
 	updateChan := make(chan interface{}, 2)
@@ -1692,6 +1918,7 @@ func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
 		TargetFeePerKw: targetFeePerKw,
 		MaxFee:         maxFee,
 		DeliveryScript: deliveryScript,
+		CloseOutputs:   closeOutputs,
 		Err:            errChan,
 	}
 
@@ -2774,9 +3001,11 @@ func (s *Switch) BestHeight() uint32 {
 // determine whether the default dust threshold has been exceeded. This
 // heuristic takes into account the trimmed-to-dust mechanism. The sum of the
 // commitment's dust with the mailbox's dust with the amount is checked against
-// the default threshold. If incoming is true, then the amount is not included
-// in the sum as it was already included in the commitment's dust. A boolean is
-// returned telling the caller whether the HTLC should be failed back.
+// the dust exposure limit of the channel, which is derived from its dust
+// exposure policy or the default threshold. If incoming is true, then the
+// amount is not included in the sum as it was already included in the
+// commitment's dust. A boolean is returned telling the caller whether the HTLC
+// should be failed back.
 func (s *Switch) evaluateDustThreshold(link ChannelLink,
 	amount lnwire.MilliSatoshi, incoming bool) bool {
 
@@ -2796,6 +3025,7 @@ func (s *Switch) evaluateDustThreshold(link ChannelLink,
 
 	// Fetch the dust sums currently in the mailbox for this link.
 	cid := link.ChanID()
+	threshold := s.dustThreshold(cid, feeRate)
 	sid := link.ShortChanID()
 	mailbox := s.mailOrchestrator.GetOrCreateMailBox(cid, sid)
 	localMailDust, remoteMailDust := mailbox.DustPackets()
@@ -2812,8 +3042,8 @@ func (s *Switch) evaluateDustThreshold(link ChannelLink,
 			localSum += amount
 		}
 
-		// Finally check against the defined dust threshold.
-		if localSum > s.cfg.DustThreshold {
+		// Finally check against the channel's dust threshold.
+		if localSum > threshold {
 			return true
 		}
 	}
@@ -2830,8 +3060,8 @@ func (s *Switch) evaluateDustThreshold(link ChannelLink,
 			remoteSum += amount
 		}
 
-		// Finally check against the defined dust threshold.
-		if remoteSum > s.cfg.DustThreshold {
+		// Finally check against the channel's dust threshold.
+		if remoteSum > threshold {
 			return true
 		}
 	}
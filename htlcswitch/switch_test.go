@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
@@ -3001,6 +3002,13 @@ func TestMultiHopPaymentForwardingEvents(t *testing.T) {
 			t.Fatalf("outgoing amt mismatch: expected %v, got %v",
 				event.AmtOut, finalAmt)
 		}
+
+		// The expiries of both HTLCs should be known, and the
+		// forwards should be logged as settled.
+		require.Equal(t, totalTimelock, event.IncomingExpiry)
+		require.Equal(t, hops[0].ForwardingInfo().OutgoingCTLV,
+			event.OutgoingExpiry)
+		require.False(t, event.Failed)
 	}
 }
 
@@ -4258,6 +4266,82 @@ func TestInterceptableSwitchWatchDog(t *testing.T) {
 	}))
 }
 
+// TestInterceptableSwitchFilter tests that only packets matching the filter of
+// the interceptor are intercepted, while all others are forwarded directly.
+func TestInterceptableSwitchFilter(t *testing.T) {
+	t.Parallel()
+
+	c := newInterceptableSwitchTestContext(t)
+	defer c.finish()
+
+	notifier := &mock.ChainNotifier{
+		EpochChan: make(chan *chainntnfs.BlockEpoch, 1),
+	}
+	notifier.EpochChan <- &chainntnfs.BlockEpoch{Height: testStartingHeight}
+
+	switchForwardInterceptor, err := NewInterceptableSwitch(
+		&InterceptableSwitchConfig{
+			Switch:             c.s,
+			CltvRejectDelta:    c.cltvRejectDelta,
+			CltvInterceptDelta: c.cltvInterceptDelta,
+			Notifier:           notifier,
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, switchForwardInterceptor.Start())
+
+	switchForwardInterceptor.SetInterceptor(
+		c.forwardInterceptor.InterceptForwardHtlc,
+	)
+
+	// Only intercept packets above the amount of our test packets.
+	testPacket := c.createTestPacket()
+	amt := testPacket.htlc.(*lnwire.UpdateAddHTLC).Amount
+	switchForwardInterceptor.SetInterceptFilter(
+		func(p InterceptedPacket) bool {
+			return p.OutgoingAmount > amt
+		},
+	)
+
+	// The packet doesn't match the filter, so it is forwarded straight to
+	// the outgoing link.
+	linkQuit := make(chan struct{})
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false, testPacket,
+	))
+	receivedPkt := assertOutgoingLinkReceive(t, c.bobChannelLink, true)
+	assertNumCircuits(t, c.s, 1, 1)
+
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false,
+		c.createSettlePacket(receivedPkt.outgoingHTLCID),
+	))
+	assertOutgoingLinkReceive(t, c.aliceChannelLink, true)
+	assertNumCircuits(t, c.s, 0, 0)
+
+	// Reconnecting the interceptor resets the filter, so the next packet
+	// is intercepted.
+	switchForwardInterceptor.SetInterceptor(nil)
+	switchForwardInterceptor.SetInterceptor(
+		c.forwardInterceptor.InterceptForwardHtlc,
+	)
+
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false, c.createTestPacket(),
+	))
+	assertOutgoingLinkReceive(t, c.bobChannelLink, false)
+
+	require.NoError(t, switchForwardInterceptor.Resolve(&FwdResolution{
+		Key:      c.forwardInterceptor.getIntercepted().IncomingCircuit,
+		Action:   FwdActionSettle,
+		Preimage: c.preimage,
+	}))
+	assertOutgoingLinkReceive(t, c.aliceChannelLink, true)
+	assertNumCircuits(t, c.s, 0, 0)
+
+	require.NoError(t, switchForwardInterceptor.Stop())
+}
+
 // TestSwitchDustForwarding tests that the switch properly fails HTLC's which
 // have incoming or outgoing links that breach their dust thresholds.
 func TestSwitchDustForwarding(t *testing.T) {
@@ -4671,6 +4755,64 @@ func TestSwitchMailboxDust(t *testing.T) {
 	}
 }
 
+// TestSwitchDustExposurePolicy tests that the switch evaluates the dust
+// exposure of a channel against its dust exposure policy, if it has one.
+func TestSwitchDustExposurePolicy(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithTempDB(t, testStartingHeight)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer func() {
+		_ = s.Stop()
+	}()
+
+	chanPoint := wire.OutPoint{Index: 1}
+	chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+	_, aliceChanID := genID()
+
+	aliceLink := newMockChannelLink(
+		s, chanID, aliceChanID, emptyScid, alicePeer, true, false,
+		false, false,
+	)
+	require.NoError(t, s.AddLink(aliceLink))
+
+	// mockChannelLink sets the dust limits to 400 satoshis, so the HTLC is
+	// dust. With the default threshold, it can be forwarded.
+	amt := lnwire.NewMSatFromSatoshis(350)
+	require.False(t, s.evaluateDustThreshold(aliceLink, amt, false))
+
+	// A fixed limit below the amount of the HTLC is exceeded by it.
+	s.UpdateDustExposurePolicies(
+		map[wire.OutPoint]channeldb.DustExposurePolicy{
+			chanPoint: {MaxExposure: amt - 1},
+		},
+	)
+	require.True(t, s.evaluateDustThreshold(aliceLink, amt, false))
+
+	// The mockChannelLink has a fee rate of zero, so a fee rate based
+	// limit doesn't allow any dust.
+	s.UpdateDustExposurePolicies(
+		map[wire.OutPoint]channeldb.DustExposurePolicy{
+			chanPoint: {FeeRateMultiplier: 5_000},
+		},
+	)
+	require.True(t, s.evaluateDustThreshold(aliceLink, amt, false))
+
+	// An empty policy makes the channel use the default threshold again.
+	s.UpdateDustExposurePolicies(
+		map[wire.OutPoint]channeldb.DustExposurePolicy{
+			chanPoint: {},
+		},
+	)
+	require.False(t, s.evaluateDustThreshold(aliceLink, amt, false))
+}
+
 // TestSwitchResolution checks the ability of the switch to persist and handle
 // resolution messages.
 func TestSwitchResolution(t *testing.T) {
@@ -0,0 +1,311 @@
+package input
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// MuSig2AdaptorSigSize is the size of a serialized MuSig2 adaptor
+	// (pre-)signature. It consists of the 33 byte compressed final nonce
+	// point, which includes the adaptor point, and the 32 byte scalar.
+	MuSig2AdaptorSigSize = 65
+)
+
+// MuSig2AdaptorSigner is implemented by signers that support creating MuSig2
+// adaptor signatures and committing to the public nonces of the other signers
+// before they are revealed. Adaptor signatures are only supported for the
+// MuSig2 BIP draft version 1.0.0rc2.
+type MuSig2AdaptorSigner interface {
+	// MuSig2CreateAdaptorSession creates a new MuSig2 signing session
+	// that produces an adaptor signature for the given adaptor point. The
+	// session otherwise behaves like a session created by
+	// MuSig2CreateSession, but its signatures need to be combined with
+	// MuSig2CombineAdaptorSig.
+	MuSig2CreateAdaptorSession(keychain.KeyLocator, []*btcec.PublicKey,
+		*MuSig2Tweaks, [][musig2.PubNonceSize]byte, *musig2.Nonces,
+		*btcec.PublicKey) (*MuSig2SessionInfo, error)
+
+	// MuSig2RegisterNonceCommitments registers the commitments to the
+	// public nonces of all other signing participants of a session. Once
+	// registered, only nonces that match one of the commitments are
+	// accepted by MuSig2RegisterNonces.
+	MuSig2RegisterNonceCommitments(MuSig2SessionID,
+		[][sha256.Size]byte) error
+
+	// MuSig2CombineAdaptorSig combines the given partial signature(s) of
+	// an adaptor session with the local one. Once a partial signature of
+	// all participants is registered, the adaptor signature is combined,
+	// verified and returned.
+	MuSig2CombineAdaptorSig(MuSig2SessionID,
+		[]*musig2.PartialSignature) (*MuSig2AdaptorSignature, bool,
+		error)
+}
+
+// MuSig2AdaptorSignature is a MuSig2 signature that is only valid once it is
+// adapted with the discrete logarithm of the adaptor point it was created
+// for. Publishing the final signature then reveals that secret to anyone
+// knowing the adaptor signature.
+type MuSig2AdaptorSignature struct {
+	// R is the final nonce point of the signature, which already includes
+	// the adaptor point. The full point is kept as its parity determines
+	// how the signature is adapted.
+	R *btcec.PublicKey
+
+	// S is the scalar of the adaptor signature.
+	S btcec.ModNScalar
+}
+
+// Serialize encodes the adaptor signature to a fixed size byte array.
+func (a *MuSig2AdaptorSignature) Serialize() [MuSig2AdaptorSigSize]byte {
+	var result [MuSig2AdaptorSigSize]byte
+	copy(result[:33], a.R.SerializeCompressed())
+
+	sBytes := a.S.Bytes()
+	copy(result[33:], sBytes[:])
+
+	return result
+}
+
+// ParseMuSig2AdaptorSignature decodes an adaptor signature from a byte slice.
+func ParseMuSig2AdaptorSignature(sigBytes []byte) (*MuSig2AdaptorSignature,
+	error) {
+
+	if len(sigBytes) != MuSig2AdaptorSigSize {
+		return nil, fmt.Errorf("invalid adaptor signature length, got "+
+			"%d wanted %d", len(sigBytes), MuSig2AdaptorSigSize)
+	}
+
+	r, err := btcec.ParsePubKey(sigBytes[:33])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing nonce point: %w", err)
+	}
+
+	sig := &MuSig2AdaptorSignature{R: r}
+	if overflow := sig.S.SetByteSlice(sigBytes[33:]); overflow {
+		return nil, fmt.Errorf("adaptor signature scalar overflows")
+	}
+
+	return sig, nil
+}
+
+// MuSig2NonceCommitment returns the commitment to a public nonce that signers
+// exchange before revealing their nonces.
+func MuSig2NonceCommitment(
+	pubNonce [musig2.PubNonceSize]byte) [sha256.Size]byte {
+
+	return sha256.Sum256(pubNonce[:])
+}
+
+// MuSig2AdaptSignature turns an adaptor signature into a valid Schnorr
+// signature by adding the discrete logarithm of the adaptor point.
+func MuSig2AdaptSignature(adaptorSig *MuSig2AdaptorSignature,
+	secret *btcec.ModNScalar) *schnorr.Signature {
+
+	r, oddR := affineCoords(adaptorSig.R)
+
+	// The signers negated their nonces if the final nonce has an odd y
+	// coordinate, which also negated the adaptor point.
+	var s btcec.ModNScalar
+	s.Set(secret)
+	if oddR {
+		s.Negate()
+	}
+	s.Add(&adaptorSig.S)
+
+	return schnorr.NewSignature(&r.X, &s)
+}
+
+// MuSig2ExtractAdaptorSecret extracts the discrete logarithm of the adaptor
+// point from an adaptor signature and the final signature that was created by
+// adapting it.
+func MuSig2ExtractAdaptorSecret(adaptorSig *MuSig2AdaptorSignature,
+	sig *schnorr.Signature) (*btcec.ModNScalar, error) {
+
+	r, oddR := affineCoords(adaptorSig.R)
+
+	sigBytes := sig.Serialize()
+	var sigR btcec.FieldVal
+	sigR.SetByteSlice(sigBytes[:32])
+	if !sigR.Equals(&r.X) {
+		return nil, fmt.Errorf("signature nonce doesn't match adaptor " +
+			"signature nonce")
+	}
+
+	var secret, adaptorS btcec.ModNScalar
+	secret.SetByteSlice(sigBytes[32:])
+	adaptorS.Set(&adaptorSig.S)
+	secret.Add(adaptorS.Negate())
+	if oddR {
+		secret.Negate()
+	}
+
+	return &secret, nil
+}
+
+// MuSig2VerifyAdaptorSignature verifies that the given adaptor signature of
+// the message becomes a valid signature for the combined key once adapted with
+// the discrete logarithm of the adaptor point.
+func MuSig2VerifyAdaptorSignature(adaptorSig *MuSig2AdaptorSignature,
+	adaptorPoint, combinedKey *btcec.PublicKey,
+	msg [sha256.Size]byte) error {
+
+	r, oddR := affineCoords(adaptorSig.R)
+
+	// The final signature is verified against the x-only combined key, so
+	// we need its even y variant.
+	evenKey, err := schnorr.ParsePubKey(schnorr.SerializePubKey(
+		combinedKey,
+	))
+	if err != nil {
+		return err
+	}
+
+	var rBytes [32]byte
+	r.X.PutBytesUnchecked(rBytes[:])
+	challenge := chainhash.TaggedHash(
+		chainhash.TagBIP0340Challenge, rBytes[:],
+		schnorr.SerializePubKey(evenKey), msg[:],
+	)
+	var e btcec.ModNScalar
+	e.SetByteSlice(challenge[:])
+
+	// The adapted signature s = s' +/- t is valid if
+	// s*G = R_even + e*P. We therefore check that s'*G - e*P equals
+	// R - T, negated if R has an odd y coordinate.
+	var sG, eP, lhs btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(&adaptorSig.S, &sG)
+	evenKey.AsJacobian(&eP)
+	btcec.ScalarMultNonConst(&e, &eP, &eP)
+	negatePoint(&eP)
+	btcec.AddNonConst(&sG, &eP, &lhs)
+
+	var t, rhs btcec.JacobianPoint
+	adaptorPoint.AsJacobian(&t)
+	negatePoint(&t)
+	btcec.AddNonConst(&r, &t, &rhs)
+	if oddR {
+		negatePoint(&rhs)
+	}
+
+	lhs.ToAffine()
+	rhs.ToAffine()
+	if (lhs.X.IsZero() && lhs.Y.IsZero()) ||
+		!lhs.X.Equals(&rhs.X) || !lhs.Y.Equals(&rhs.Y) {
+
+		return fmt.Errorf("invalid adaptor signature")
+	}
+
+	return nil
+}
+
+// muSig2AdaptorNonce aggregates the given public nonces and adds the adaptor
+// point to the first nonce point of the aggregate. If all signers sign with
+// this aggregate nonce, the combined signature is an adaptor signature.
+func muSig2AdaptorNonce(pubNonces [][musig2.PubNonceSize]byte,
+	adaptorPoint *btcec.PublicKey) ([musig2.PubNonceSize]byte, error) {
+
+	combinedNonce, err := musig2.AggregateNonces(pubNonces)
+	if err != nil {
+		return combinedNonce, fmt.Errorf("error aggregating nonces: %w",
+			err)
+	}
+
+	r1, err := btcec.ParsePubKey(
+		combinedNonce[:btcec.PubKeyBytesLenCompressed],
+	)
+	if err != nil {
+		return combinedNonce, fmt.Errorf("error parsing aggregate "+
+			"nonce: %w", err)
+	}
+
+	var r1J, t, sum btcec.JacobianPoint
+	r1.AsJacobian(&r1J)
+	adaptorPoint.AsJacobian(&t)
+	btcec.AddNonConst(&r1J, &t, &sum)
+	sum.ToAffine()
+	if sum.X.IsZero() && sum.Y.IsZero() {
+		return combinedNonce, fmt.Errorf("adaptor point cancels out " +
+			"aggregate nonce")
+	}
+
+	adaptorR1 := btcec.NewPublicKey(&sum.X, &sum.Y)
+	copy(
+		combinedNonce[:btcec.PubKeyBytesLenCompressed],
+		adaptorR1.SerializeCompressed(),
+	)
+
+	return combinedNonce, nil
+}
+
+// toSignOptions converts the tweak descriptor to signing options. The tweaks
+// take precedence in the same order as in a musig2.Session.
+func (t *MuSig2Tweaks) toSignOptions() []musig2.SignOption {
+	switch {
+	case t.TaprootBIP0086Tweak:
+		return []musig2.SignOption{musig2.WithBip86SignTweak()}
+
+	case len(t.TaprootTweak) > 0:
+		return []musig2.SignOption{
+			musig2.WithTaprootSignTweak(t.TaprootTweak),
+		}
+
+	case len(t.GenericTweaks) > 0:
+		return []musig2.SignOption{
+			musig2.WithTweaks(t.GenericTweaks...),
+		}
+	}
+
+	return nil
+}
+
+// toCombineOptions converts the tweak descriptor to signature combination
+// options for the given message and sorted set of signing keys.
+func (t *MuSig2Tweaks) toCombineOptions(msg [sha256.Size]byte,
+	keys []*btcec.PublicKey) []musig2.CombineOption {
+
+	switch {
+	case t.TaprootBIP0086Tweak:
+		return []musig2.CombineOption{
+			musig2.WithBip86TweakedCombine(msg, keys, true),
+		}
+
+	case len(t.TaprootTweak) > 0:
+		return []musig2.CombineOption{
+			musig2.WithTaprootTweakedCombine(
+				msg, keys, t.TaprootTweak, true,
+			),
+		}
+
+	case len(t.GenericTweaks) > 0:
+		return []musig2.CombineOption{
+			musig2.WithTweakedCombine(
+				msg, keys, t.GenericTweaks, true,
+			),
+		}
+	}
+
+	return nil
+}
+
+// affineCoords returns the given public key as an affine Jacobian point and
+// whether its y coordinate is odd.
+func affineCoords(pubKey *btcec.PublicKey) (btcec.JacobianPoint, bool) {
+	var p btcec.JacobianPoint
+	pubKey.AsJacobian(&p)
+	p.ToAffine()
+
+	return p, p.Y.IsOdd()
+}
+
+// negatePoint negates the given point in place.
+func negatePoint(p *btcec.JacobianPoint) {
+	p.ToAffine()
+	p.Y.Negate(1).Normalize()
+}
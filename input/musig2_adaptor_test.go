@@ -0,0 +1,235 @@
+package input
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// testTweakRoot is a tweak used in the adaptor signature tests.
+var testTweakRoot = sha256.Sum256([]byte("tweak"))
+
+// newTestSessionManager returns a session manager that signs with the given
+// private key.
+func newTestSessionManager(privKey *btcec.PrivateKey) *MusigSessionManager {
+	return NewMusigSessionManager(
+		func(*keychain.KeyDescriptor) (*btcec.PrivateKey, error) {
+			return privKey, nil
+		},
+	)
+}
+
+// TestMuSig2AdaptorSignature tests creating an adaptor signature with two
+// signers, adapting it with the adaptor secret and extracting the secret
+// again, for all types of tweaks.
+func TestMuSig2AdaptorSignature(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		tweaks *MuSig2Tweaks
+	}{{
+		name:   "no tweak",
+		tweaks: &MuSig2Tweaks{},
+	}, {
+		name: "bip86 tweak",
+		tweaks: &MuSig2Tweaks{
+			TaprootBIP0086Tweak: true,
+		},
+	}, {
+		name: "taproot tweak",
+		tweaks: &MuSig2Tweaks{
+			TaprootTweak: testTweakRoot[:],
+		},
+	}, {
+		name: "generic tweak",
+		tweaks: &MuSig2Tweaks{
+			GenericTweaks: []musig2.KeyTweakDesc{{
+				Tweak:   testTweakRoot,
+				IsXOnly: true,
+			}},
+		},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The final nonce has an odd y coordinate in roughly
+			// half of the runs, so we sign a couple of times to
+			// cover both cases.
+			for i := 0; i < 8; i++ {
+				testMuSig2AdaptorSignature(t, tc.tweaks)
+			}
+		})
+	}
+}
+
+func testMuSig2AdaptorSignature(t *testing.T, tweaks *MuSig2Tweaks) {
+	alice, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	bob, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	secret, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	var (
+		aliceMgr     = newTestSessionManager(alice)
+		bobMgr       = newTestSessionManager(bob)
+		pubKeys      = []*btcec.PublicKey{alice.PubKey(), bob.PubKey()}
+		adaptorPoint = secret.PubKey()
+		msg          = sha256.Sum256([]byte("adaptor"))
+	)
+
+	aliceSession, err := aliceMgr.MuSig2CreateAdaptorSession(
+		keychain.KeyLocator{}, pubKeys, tweaks, nil, nil, adaptorPoint,
+	)
+	require.NoError(t, err)
+	bobSession, err := bobMgr.MuSig2CreateAdaptorSession(
+		keychain.KeyLocator{}, pubKeys, tweaks,
+		[][musig2.PubNonceSize]byte{aliceSession.PublicNonce}, nil,
+		adaptorPoint,
+	)
+	require.NoError(t, err)
+	require.True(t, bobSession.HaveAllNonces)
+
+	haveAll, err := aliceMgr.MuSig2RegisterNonces(
+		aliceSession.SessionID,
+		[][musig2.PubNonceSize]byte{bobSession.PublicNonce},
+	)
+	require.NoError(t, err)
+	require.True(t, haveAll)
+
+	bobSig, err := bobMgr.MuSig2Sign(bobSession.SessionID, msg, true)
+	require.NoError(t, err)
+	_, err = aliceMgr.MuSig2Sign(aliceSession.SessionID, msg, false)
+	require.NoError(t, err)
+
+	// Signing twice with the same nonce must not be possible.
+	_, err = aliceMgr.MuSig2Sign(aliceSession.SessionID, msg, false)
+	require.ErrorIs(t, err, musig2.ErrSigningContextReuse)
+
+	// Adaptor sessions can only be combined into an adaptor signature.
+	_, _, err = aliceMgr.MuSig2CombineSig(
+		aliceSession.SessionID, []*musig2.PartialSignature{bobSig},
+	)
+	require.ErrorContains(t, err, "is an adaptor session")
+
+	adaptorSig, haveAll, err := aliceMgr.MuSig2CombineAdaptorSig(
+		aliceSession.SessionID, []*musig2.PartialSignature{bobSig},
+	)
+	require.NoError(t, err)
+	require.True(t, haveAll)
+
+	// The adaptor signature is not a valid signature by itself, but it
+	// verifies as adaptor signature for the adaptor point.
+	combinedKey := aliceSession.CombinedKey
+	require.NoError(t, MuSig2VerifyAdaptorSignature(
+		adaptorSig, adaptorPoint, combinedKey, msg,
+	))
+	require.Error(t, MuSig2VerifyAdaptorSignature(
+		adaptorSig, alice.PubKey(), combinedKey, msg,
+	))
+
+	serialized := adaptorSig.Serialize()
+	parsed, err := ParseMuSig2AdaptorSignature(serialized[:])
+	require.NoError(t, err)
+	require.Equal(t, serialized, parsed.Serialize())
+
+	// Once adapted with the secret, the signature is valid and reveals
+	// the secret.
+	finalSig := MuSig2AdaptSignature(adaptorSig, &secret.Key)
+	require.True(t, finalSig.Verify(msg[:], combinedKey))
+
+	extracted, err := MuSig2ExtractAdaptorSecret(adaptorSig, finalSig)
+	require.NoError(t, err)
+	require.True(t, extracted.Equals(&secret.Key))
+
+	// A signature with a different nonce doesn't reveal anything.
+	otherSig, err := schnorr.Sign(alice, msg[:])
+	require.NoError(t, err)
+	_, err = MuSig2ExtractAdaptorSecret(adaptorSig, otherSig)
+	require.Error(t, err)
+}
+
+// TestMuSig2NonceCommitments tests that only nonces matching the registered
+// nonce commitments are accepted.
+func TestMuSig2NonceCommitments(t *testing.T) {
+	t.Parallel()
+
+	privKeys := make([]*btcec.PrivateKey, 3)
+	pubKeys := make([]*btcec.PublicKey, 3)
+	for i := range privKeys {
+		var err error
+		privKeys[i], err = btcec.NewPrivateKey()
+		require.NoError(t, err)
+		pubKeys[i] = privKeys[i].PubKey()
+	}
+
+	mgr := newTestSessionManager(privKeys[0])
+	session, err := mgr.MuSig2CreateSession(
+		MuSig2Version100RC2, keychain.KeyLocator{}, pubKeys,
+		&MuSig2Tweaks{}, nil, nil,
+	)
+	require.NoError(t, err)
+
+	nonces := make([][musig2.PubNonceSize]byte, 2)
+	for i := range nonces {
+		n, err := musig2.GenNonces(
+			musig2.WithPublicKey(pubKeys[i+1]),
+		)
+		require.NoError(t, err)
+		nonces[i] = n.PubNonce
+	}
+
+	// We need a commitment for each other signer.
+	err = mgr.MuSig2RegisterNonceCommitments(
+		session.SessionID, [][sha256.Size]byte{
+			MuSig2NonceCommitment(nonces[0]),
+		},
+	)
+	require.ErrorContains(t, err, "expected 2 nonce commitments")
+
+	err = mgr.MuSig2RegisterNonceCommitments(
+		session.SessionID, [][sha256.Size]byte{
+			MuSig2NonceCommitment(nonces[1]),
+			MuSig2NonceCommitment(nonces[0]),
+		},
+	)
+	require.NoError(t, err)
+
+	// A nonce that wasn't committed to is rejected, as is the same nonce
+	// registered twice.
+	unknown, err := musig2.GenNonces(musig2.WithPublicKey(pubKeys[1]))
+	require.NoError(t, err)
+	_, err = mgr.MuSig2RegisterNonces(
+		session.SessionID,
+		[][musig2.PubNonceSize]byte{unknown.PubNonce},
+	)
+	require.ErrorContains(t, err, "doesn't match any nonce commitment")
+
+	_, err = mgr.MuSig2RegisterNonces(
+		session.SessionID,
+		[][musig2.PubNonceSize]byte{nonces[0], nonces[0]},
+	)
+	require.ErrorContains(t, err, "doesn't match any nonce commitment")
+
+	haveAll, err := mgr.MuSig2RegisterNonces(
+		session.SessionID, [][musig2.PubNonceSize]byte{nonces[0]},
+	)
+	require.NoError(t, err)
+	require.False(t, haveAll)
+
+	haveAll, err = mgr.MuSig2RegisterNonces(
+		session.SessionID, [][musig2.PubNonceSize]byte{nonces[1]},
+	)
+	require.NoError(t, err)
+	require.True(t, haveAll)
+}
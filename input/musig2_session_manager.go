@@ -26,6 +26,39 @@ type MuSig2State struct {
 	// session is the signing session responsible for keeping track of the
 	// nonces and partial signatures involved in the signing process.
 	session MuSig2Session
+
+	// otherNonces are the public nonces of the other signing participants
+	// registered so far.
+	otherNonces [][musig2.PubNonceSize]byte
+
+	// nonceCommitments are the commitments to the public nonces of the
+	// other signing participants that haven't been revealed yet. This is
+	// only set if the commitments were registered with the session.
+	nonceCommitments [][sha256.Size]byte
+
+	// adaptorPoint is the adaptor point of an adaptor session. All fields
+	// below are only set for adaptor sessions, which can't use the signing
+	// session above as it doesn't support adaptor signatures.
+	adaptorPoint *btcec.PublicKey
+
+	// privKey is the local signing key of an adaptor session.
+	privKey *btcec.PrivateKey
+
+	// tweaks are the tweaks applied to the combined key of an adaptor
+	// session.
+	tweaks *MuSig2Tweaks
+
+	// localNonces are the local nonces of an adaptor session. They are
+	// removed once the local partial signature was created to prevent
+	// nonce reuse.
+	localNonces *musig2.Nonces
+
+	// msg is the message signed in an adaptor session.
+	msg [sha256.Size]byte
+
+	// partialSigs are the partial signatures of an adaptor session, with
+	// the local one being the first.
+	partialSigs []*musig2.PartialSignature
 }
 
 // PrivKeyFetcher is used to fetch a private key that matches a given key desc.
@@ -78,6 +111,84 @@ func (m *MusigSessionManager) MuSig2CreateSession(bipVersion MuSig2Version,
 		return nil, fmt.Errorf("error deriving private key: %w", err)
 	}
 
+	session, err := newMuSig2State(
+		bipVersion, privKey, allSignerPubKeys, tweaks,
+		otherSignerNonces, localNonces,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Since we generate new nonces for every session, there is no way that
+	// a session with the same ID already exists. So even if we call the API
+	// twice with the same signers, we still get a new ID.
+	//
+	// We'll use just all zeroes as the session ID for the mutex, as this
+	// is a "global" action.
+	m.musig2Sessions.Store(session.SessionID, session)
+
+	return &session.MuSig2SessionInfo, nil
+}
+
+// MuSig2CreateAdaptorSession creates a new MuSig2 signing session that
+// produces an adaptor signature for the given adaptor point. The session
+// otherwise behaves like a session created by MuSig2CreateSession, but its
+// signatures need to be combined with MuSig2CombineAdaptorSig.
+func (m *MusigSessionManager) MuSig2CreateAdaptorSession(
+	keyLoc keychain.KeyLocator, allSignerPubKeys []*btcec.PublicKey,
+	tweaks *MuSig2Tweaks, otherSignerNonces [][musig2.PubNonceSize]byte,
+	localNonces *musig2.Nonces,
+	adaptorPoint *btcec.PublicKey) (*MuSig2SessionInfo, error) {
+
+	if adaptorPoint == nil {
+		return nil, fmt.Errorf("adaptor point is required")
+	}
+
+	privKey, err := m.keyFetcher(&keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deriving private key: %w", err)
+	}
+
+	// The signing session doesn't give us access to the secret nonce we
+	// need for the adaptor signature, so we generate it ourselves if the
+	// caller didn't.
+	if localNonces == nil {
+		localNonces, err = musig2.GenNonces(
+			musig2.WithPublicKey(privKey.PubKey()),
+			musig2.WithNonceSecretKeyAux(privKey),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error generating nonces: %w",
+				err)
+		}
+	}
+
+	session, err := newMuSig2State(
+		MuSig2Version100RC2, privKey, allSignerPubKeys, tweaks,
+		otherSignerNonces, localNonces,
+	)
+	if err != nil {
+		return nil, err
+	}
+	session.adaptorPoint = adaptorPoint
+	session.privKey = privKey
+	session.tweaks = tweaks
+	session.localNonces = localNonces
+
+	m.musig2Sessions.Store(session.SessionID, session)
+
+	return &session.MuSig2SessionInfo, nil
+}
+
+// newMuSig2State creates the state of a new MuSig2 signing session with the
+// given private key and list of all known signer public keys.
+func newMuSig2State(bipVersion MuSig2Version, privKey *btcec.PrivateKey,
+	allSignerPubKeys []*btcec.PublicKey, tweaks *MuSig2Tweaks,
+	otherSignerNonces [][musig2.PubNonceSize]byte,
+	localNonces *musig2.Nonces) (*MuSig2State, error) {
+
 	// Create a signing context and session with the given private key and
 	// list of all known signer public keys.
 	musigContext, musigSession, err := MuSig2CreateContext(
@@ -100,7 +211,7 @@ func (m *MusigSessionManager) MuSig2CreateSession(bipVersion MuSig2Version,
 		}
 	}
 
-	// Register the new session.
+	// Assemble the state of the new session.
 	combinedKey, err := musigContext.CombinedKey()
 	if err != nil {
 		return nil, fmt.Errorf("error getting combined key: %w", err)
@@ -118,6 +229,9 @@ func (m *MusigSessionManager) MuSig2CreateSession(bipVersion MuSig2Version,
 		},
 		context: musigContext,
 		session: musigSession,
+		otherNonces: append(
+			[][musig2.PubNonceSize]byte{}, otherSignerNonces...,
+		),
 	}
 
 	// The internal key is only calculated if we are using a taproot tweak
@@ -131,15 +245,7 @@ func (m *MusigSessionManager) MuSig2CreateSession(bipVersion MuSig2Version,
 		session.TaprootInternalKey = internalKey
 	}
 
-	// Since we generate new nonces for every session, there is no way that
-	// a session with the same ID already exists. So even if we call the API
-	// twice with the same signers, we still get a new ID.
-	//
-	// We'll use just all zeroes as the session ID for the mutex, as this
-	// is a "global" action.
-	m.musig2Sessions.Store(session.SessionID, session)
-
-	return &session.MuSig2SessionInfo, nil
+	return session, nil
 }
 
 // MuSig2Sign creates a partial signature using the local signing key
@@ -172,7 +278,15 @@ func (m *MusigSessionManager) MuSig2Sign(sessionID MuSig2SessionID,
 	}
 
 	// Create our own partial signature with the local signing key.
-	partialSig, err := MuSig2Sign(session.session, msg, true)
+	var (
+		partialSig *musig2.PartialSignature
+		err        error
+	)
+	if session.adaptorPoint != nil {
+		partialSig, err = adaptorSign(session, msg)
+	} else {
+		partialSig, err = MuSig2Sign(session.session, msg, true)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error signing with local key: %w", err)
 	}
@@ -205,6 +319,11 @@ func (m *MusigSessionManager) MuSig2CombineSig(sessionID MuSig2SessionID,
 			sessionID[:])
 	}
 
+	if session.adaptorPoint != nil {
+		return nil, false, fmt.Errorf("session with ID %x is an "+
+			"adaptor session", sessionID[:])
+	}
+
 	// Make sure we don't exceed the number of expected partial signatures
 	// as that would indicate something is wrong with the signing setup.
 	if session.HaveAllSigs {
@@ -288,6 +407,15 @@ func (m *MusigSessionManager) MuSig2RegisterNonces(sessionID MuSig2SessionID,
 			len(otherSignerNonces))
 	}
 
+	// If the other signers committed to their nonces, we only accept the
+	// nonces they committed to.
+	if len(session.nonceCommitments) > 0 {
+		err := session.openNonceCommitments(otherSignerNonces)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	// Add all nonces we've learned so far.
 	var err error
 	for _, otherSignerNonce := range otherSignerNonces {
@@ -298,7 +426,202 @@ func (m *MusigSessionManager) MuSig2RegisterNonces(sessionID MuSig2SessionID,
 			return false, fmt.Errorf("error registering other "+
 				"signer public nonce: %v", err)
 		}
+
+		session.otherNonces = append(
+			session.otherNonces, otherSignerNonce,
+		)
 	}
 
 	return session.HaveAllNonces, nil
 }
+
+// MuSig2RegisterNonceCommitments registers the commitments to the public
+// nonces of all other signing participants of a session. Once registered, only
+// nonces that match one of the commitments are accepted by
+// MuSig2RegisterNonces. The commitments need to be registered before any nonce
+// of the other participants is.
+func (m *MusigSessionManager) MuSig2RegisterNonceCommitments(
+	sessionID MuSig2SessionID,
+	commitments [][sha256.Size]byte) error {
+
+	// We hold the lock during the whole operation, we don't want any
+	// interference with calls that might come through in parallel for the
+	// same session.
+	m.sessionMtx.Lock(sessionID)
+	defer m.sessionMtx.Unlock(sessionID)
+
+	session, ok := m.musig2Sessions.Load(sessionID)
+	if !ok {
+		return fmt.Errorf("session with ID %x not found", sessionID[:])
+	}
+
+	if len(session.nonceCommitments) > 0 {
+		return fmt.Errorf("nonce commitments already registered")
+	}
+	if len(session.otherNonces) > 0 {
+		return fmt.Errorf("nonces of other signers already registered")
+	}
+
+	numOthers := len(session.context.SigningKeys()) - 1
+	if len(commitments) != numOthers {
+		return fmt.Errorf("expected %d nonce commitments but got %d",
+			numOthers, len(commitments))
+	}
+
+	session.nonceCommitments = append(
+		[][sha256.Size]byte{}, commitments...,
+	)
+
+	return nil
+}
+
+// MuSig2CombineAdaptorSig combines the given partial signature(s) of an adaptor
+// session with the local one, which must already exist. Once a partial
+// signature of all participants is registered, the adaptor signature is
+// combined, verified and returned.
+func (m *MusigSessionManager) MuSig2CombineAdaptorSig(sessionID MuSig2SessionID,
+	partialSigs []*musig2.PartialSignature) (*MuSig2AdaptorSignature, bool,
+	error) {
+
+	// We hold the lock during the whole operation, we don't want any
+	// interference with calls that might come through in parallel for the
+	// same session.
+	m.sessionMtx.Lock(sessionID)
+	defer m.sessionMtx.Unlock(sessionID)
+
+	session, ok := m.musig2Sessions.Load(sessionID)
+	if !ok {
+		return nil, false, fmt.Errorf("session with ID %x not found",
+			sessionID[:])
+	}
+
+	switch {
+	case session.adaptorPoint == nil:
+		return nil, false, fmt.Errorf("session with ID %x is not an "+
+			"adaptor session", sessionID[:])
+
+	case len(session.partialSigs) == 0:
+		return nil, false, fmt.Errorf("local partial signature " +
+			"missing")
+
+	case session.HaveAllSigs:
+		return nil, true, fmt.Errorf("already have all partial " +
+			"signatures")
+	}
+
+	keys := session.context.SigningKeys()
+	if len(session.partialSigs)+len(partialSigs) > len(keys) {
+		return nil, false, fmt.Errorf("only %d partial signatures "+
+			"remaining but trying to register %d more",
+			len(keys)-len(session.partialSigs), len(partialSigs))
+	}
+
+	session.partialSigs = append(session.partialSigs, partialSigs...)
+	session.HaveAllSigs = len(session.partialSigs) == len(keys)
+	if !session.HaveAllSigs {
+		return nil, false, nil
+	}
+
+	// All signers signed with the same final nonce, which includes the
+	// adaptor point.
+	finalNonce := session.partialSigs[0].R
+	sig := musig2.CombineSigs(
+		finalNonce, session.partialSigs,
+		session.tweaks.toCombineOptions(session.msg, keys)...,
+	)
+
+	sigBytes := sig.Serialize()
+	adaptorSig := &MuSig2AdaptorSignature{R: finalNonce}
+	adaptorSig.S.SetByteSlice(sigBytes[32:])
+
+	// An invalid partial signature of any signer results in an invalid
+	// adaptor signature, which we detect here.
+	err := MuSig2VerifyAdaptorSignature(
+		adaptorSig, session.adaptorPoint, session.CombinedKey,
+		session.msg,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("error combining partial "+
+			"signatures: %w", err)
+	}
+
+	m.musig2Sessions.Delete(sessionID)
+
+	return adaptorSig, true, nil
+}
+
+// openNonceCommitments makes sure each of the given nonces matches one of the
+// registered nonce commitments and removes the matched commitments.
+func (s *MuSig2State) openNonceCommitments(
+	nonces [][musig2.PubNonceSize]byte) error {
+
+	remaining := append([][sha256.Size]byte{}, s.nonceCommitments...)
+	for _, nonce := range nonces {
+		commitment := MuSig2NonceCommitment(nonce)
+
+		found := false
+		for idx := range remaining {
+			if remaining[idx] != commitment {
+				continue
+			}
+
+			remaining = append(
+				remaining[:idx], remaining[idx+1:]...,
+			)
+			found = true
+
+			break
+		}
+
+		if !found {
+			return fmt.Errorf("public nonce %x doesn't match any "+
+				"nonce commitment", nonce[:])
+		}
+	}
+
+	s.nonceCommitments = remaining
+
+	return nil
+}
+
+// adaptorSign creates the local partial signature of an adaptor session. All
+// signers sign with an aggregate nonce that includes the adaptor point, which
+// turns their combined signature into an adaptor signature.
+func adaptorSign(session *MuSig2State,
+	msg [sha256.Size]byte) (*musig2.PartialSignature, error) {
+
+	// We blank out the local nonces once we signed, so we can never sign
+	// twice with the same nonce.
+	if session.localNonces == nil {
+		return nil, musig2.ErrSigningContextReuse
+	}
+
+	pubNonces := append(
+		[][musig2.PubNonceSize]byte{session.localNonces.PubNonce},
+		session.otherNonces...,
+	)
+	combinedNonce, err := muSig2AdaptorNonce(
+		pubNonces, session.adaptorPoint,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	signOpts := append(
+		[]musig2.SignOption{musig2.WithSortedKeys()},
+		session.tweaks.toSignOptions()...,
+	)
+	partialSig, err := musig2.Sign(
+		session.localNonces.SecNonce, session.privKey, combinedNonce,
+		session.context.SigningKeys(), msg, signOpts...,
+	)
+	session.localNonces = nil
+	if err != nil {
+		return nil, err
+	}
+
+	session.msg = msg
+	session.partialSigs = []*musig2.PartialSignature{partialSig}
+
+	return partialSig, nil
+}
@@ -0,0 +1,283 @@
+package input
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// The scripts in this file implement experimental point time locked contracts
+// (PTLCs) for simple taproot channels. Instead of a payment hash, the success
+// path of a PTLC requires a signature of both parties. The party that doesn't
+// claim the PTLC only hands out an adaptor signature for the success path
+// that is locked to the payment point. Completing and publishing that
+// signature reveals the discrete logarithm of the payment point, which
+// replaces the preimage of a regular HTLC. The timeout paths are identical to
+// the ones of taproot HTLCs.
+
+// SenderPTLCTapLeafSuccess returns the full tapscript leaf for the success
+// path of the sender PTLC. This is a small script that allows the receiver to
+// redeem the PTLC with the sender's signature, which can only be completed
+// with knowledge of the discrete logarithm of the payment point:
+//
+//	<local_htlcpubkey> OP_CHECKSIGVERIFY
+//	<remote_htlcpubkey> OP_CHECKSIG
+//	1 OP_CHECKSEQUENCEVERIFY OP_DROP
+func SenderPTLCTapLeafSuccess(senderHtlcKey,
+	receiverHtlcKey *btcec.PublicKey) (txscript.TapLeaf, error) {
+
+	builder := txscript.NewScriptBuilder()
+
+	// Verify the "2-of-2" multi-sig. The sender's signature is the one
+	// that was adapted with the payment point secret.
+	builder.AddData(schnorr.SerializePubKey(senderHtlcKey))
+	builder.AddOp(txscript.OP_CHECKSIGVERIFY)
+	builder.AddData(schnorr.SerializePubKey(receiverHtlcKey))
+	builder.AddOp(txscript.OP_CHECKSIG)
+
+	// Make the receiver wait 1 block after confirmation to properly
+	// sweep. This also makes the script different from the timeout leaf
+	// that requires the same two signatures.
+	builder.AddOp(txscript.OP_1)
+	builder.AddOp(txscript.OP_CHECKSEQUENCEVERIFY)
+	builder.AddOp(txscript.OP_DROP)
+
+	successLeafScript, err := builder.Script()
+	if err != nil {
+		return txscript.TapLeaf{}, err
+	}
+
+	return txscript.NewBaseTapLeaf(successLeafScript), nil
+}
+
+// ReceiverPTLCTapLeafSuccess returns the full tapscript leaf for the success
+// path for a PTLC on the receiver's commitment transaction. This script
+// allows the receiver to redeem the PTLC with the sender's signature, which
+// can only be completed with knowledge of the discrete logarithm of the
+// payment point:
+//
+//	<local_htlcpubkey> OP_CHECKSIGVERIFY
+//	<remote_htlcpubkey> OP_CHECKSIG
+func ReceiverPTLCTapLeafSuccess(receiverHtlcKey,
+	senderHtlcKey *btcec.PublicKey) (txscript.TapLeaf, error) {
+
+	builder := txscript.NewScriptBuilder()
+
+	// Verify the "2-of-2" multi-sig that requires both parties to sign
+	// off.
+	builder.AddData(schnorr.SerializePubKey(receiverHtlcKey))
+	builder.AddOp(txscript.OP_CHECKSIGVERIFY)
+	builder.AddData(schnorr.SerializePubKey(senderHtlcKey))
+	builder.AddOp(txscript.OP_CHECKSIG)
+
+	successLeafScript, err := builder.Script()
+	if err != nil {
+		return txscript.TapLeaf{}, err
+	}
+
+	return txscript.NewBaseTapLeaf(successLeafScript), nil
+}
+
+// newPtlcScriptTree assembles the tapscript tree of a PTLC from the given
+// leaves and tweaks the revocation key with its root to obtain the output key.
+func newPtlcScriptTree(revokeKey *btcec.PublicKey, successTapLeaf,
+	timeoutTapLeaf txscript.TapLeaf, hType htlcType,
+	leaves ...txscript.TapLeaf) *HtlcScriptTree {
+
+	tapscriptTree := txscript.AssembleTaprootScriptTree(leaves...)
+	tapScriptRoot := tapscriptTree.RootNode.TapHash()
+
+	ptlcKey := txscript.ComputeTaprootOutputKey(
+		revokeKey, tapScriptRoot[:],
+	)
+
+	return &HtlcScriptTree{
+		ScriptTree: ScriptTree{
+			TaprootKey:    ptlcKey,
+			TapscriptTree: tapscriptTree,
+			TapscriptRoot: tapScriptRoot[:],
+			InternalKey:   revokeKey,
+		},
+		SuccessTapLeaf: successTapLeaf,
+		TimeoutTapLeaf: timeoutTapLeaf,
+		htlcType:       hType,
+	}
+}
+
+// SenderPTLCScriptTaproot constructs the taproot witness program (schnorr key)
+// for an outgoing PTLC on the sender's version of the commitment transaction.
+//
+// The returned key commits to a tapscript tree with two possible paths:
+//
+//   - Timeout path:
+//     <local_key> OP_CHECKSIGVERIFY
+//     <remote_key> OP_CHECKSIG
+//
+//   - Success path:
+//     <local_htlcpubkey> OP_CHECKSIGVERIFY
+//     <remote_htlcpubkey> OP_CHECKSIG
+//     1 OP_CHECKSEQUENCEVERIFY OP_DROP
+//
+// The timeout path is spent with SenderHTLCScriptTaprootTimeout. The success
+// path can be spent with a valid control block, and a witness of (receiver
+// redeem):
+//
+//	<receiver sig> <adapted sender sig> <success_script> <control_block>
+//
+// The top level keyspend key is the revocation key, which allows a defender to
+// unilaterally spend the created output.
+func SenderPTLCScriptTaproot(senderHtlcKey, receiverHtlcKey,
+	revokeKey *btcec.PublicKey, localCommit bool) (*HtlcScriptTree,
+	error) {
+
+	hType := htlcRemoteIncoming
+	if localCommit {
+		hType = htlcLocalOutgoing
+	}
+
+	successTapLeaf, err := SenderPTLCTapLeafSuccess(
+		senderHtlcKey, receiverHtlcKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	timeoutTapLeaf, err := SenderHTLCTapLeafTimeout(
+		senderHtlcKey, receiverHtlcKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPtlcScriptTree(
+		revokeKey, successTapLeaf, timeoutTapLeaf, hType,
+		successTapLeaf, timeoutTapLeaf,
+	), nil
+}
+
+// ReceiverPTLCScriptTaproot constructs the taproot witness program (schnorr
+// key) for an incoming PTLC on the receiver's version of the commitment
+// transaction.
+//
+// The returned key commits to a tapscript tree with two possible paths:
+//
+//   - The timeout path:
+//     <remote_htlcpubkey> OP_CHECKSIG
+//     1 OP_CHECKSEQUENCEVERIFY OP_DROP
+//     <cltv_expiry> OP_CHECKLOCKTIMEVERIFY OP_DROP
+//
+//   - Success path:
+//     <local_htlcpubkey> OP_CHECKSIGVERIFY
+//     <remote_htlcpubkey> OP_CHECKSIG
+//
+// The timeout path is spent with ReceiverHTLCScriptTaprootTimeout. The success
+// path can be spent with a witness of:
+//   - <adapted sender sig> <receiver sig> <success_script> <control_block>
+//
+// The top level keyspend key is the revocation key, which allows a defender to
+// unilaterally spend the created output.
+func ReceiverPTLCScriptTaproot(cltvExpiry uint32,
+	senderHtlcKey, receiverHtlcKey, revocationKey *btcec.PublicKey,
+	ourCommit bool) (*HtlcScriptTree, error) {
+
+	hType := htlcRemoteOutgoing
+	if ourCommit {
+		hType = htlcLocalIncoming
+	}
+
+	successTapLeaf, err := ReceiverPTLCTapLeafSuccess(
+		receiverHtlcKey, senderHtlcKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	timeoutTapLeaf, err := ReceiverHtlcTapLeafTimeout(
+		senderHtlcKey, cltvExpiry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPtlcScriptTree(
+		revocationKey, successTapLeaf, timeoutTapLeaf, hType,
+		timeoutTapLeaf, successTapLeaf,
+	), nil
+}
+
+// ptlcCtrlBlock returns the serialized control block of the sign descriptor
+// or creates one for its witness script if it isn't set.
+func ptlcCtrlBlock(signDesc *SignDescriptor, revokeKey *btcec.PublicKey,
+	tapscriptTree *txscript.IndexedTapScriptTree) ([]byte, error) {
+
+	if signDesc.ControlBlock != nil {
+		return signDesc.ControlBlock, nil
+	}
+
+	ctrlBlock := MakeTaprootCtrlBlock(
+		signDesc.WitnessScript, revokeKey, tapscriptTree,
+	)
+
+	return ctrlBlock.ToBytes()
+}
+
+// SenderPTLCScriptTaprootRedeem creates a valid witness needed to redeem a
+// sender taproot PTLC with the sender's adapted signature. The returned
+// witness is valid and includes the control block required to spend the
+// output. This is the offered PTLC claimed by the remote party.
+func SenderPTLCScriptTaprootRedeem(senderSig Signature,
+	senderSigHash txscript.SigHashType, signer Signer,
+	signDesc *SignDescriptor, sweepTx *wire.MsgTx,
+	revokeKey *btcec.PublicKey,
+	tapscriptTree *txscript.IndexedTapScriptTree) (wire.TxWitness, error) {
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrlBlock, err := ptlcCtrlBlock(signDesc, revokeKey, tapscriptTree)
+	if err != nil {
+		return nil, err
+	}
+
+	// The final witness stack is:
+	//  <receiver sig> <sender sig> <success_script> <control_block>
+	witnessStack := make(wire.TxWitness, 4)
+	witnessStack[0] = maybeAppendSighash(sweepSig, signDesc.HashType)
+	witnessStack[1] = maybeAppendSighash(senderSig, senderSigHash)
+	witnessStack[2] = signDesc.WitnessScript
+	witnessStack[3] = ctrlBlock
+
+	return witnessStack, nil
+}
+
+// ReceiverPTLCScriptTaprootRedeem creates a valid witness needed to redeem a
+// receiver taproot PTLC with the sender's adapted signature. The returned
+// witness is valid and includes the control block required to spend the
+// output.
+func ReceiverPTLCScriptTaprootRedeem(senderSig Signature,
+	senderSigHash txscript.SigHashType, signer Signer,
+	signDesc *SignDescriptor, htlcSuccessTx *wire.MsgTx,
+	revokeKey *btcec.PublicKey,
+	tapscriptTree *txscript.IndexedTapScriptTree) (wire.TxWitness, error) {
+
+	sweepSig, err := signer.SignOutputRaw(htlcSuccessTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrlBlock, err := ptlcCtrlBlock(signDesc, revokeKey, tapscriptTree)
+	if err != nil {
+		return nil, err
+	}
+
+	// The final witness stack is:
+	//  <sender sig> <receiver sig> <success_script> <control_block>
+	witnessStack := make(wire.TxWitness, 4)
+	witnessStack[0] = maybeAppendSighash(senderSig, senderSigHash)
+	witnessStack[1] = maybeAppendSighash(sweepSig, signDesc.HashType)
+	witnessStack[2] = signDesc.WitnessScript
+	witnessStack[3] = ctrlBlock
+
+	return witnessStack, nil
+}
@@ -0,0 +1,380 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+type testPtlcScriptTree struct {
+	senderKey *btcec.PrivateKey
+
+	receiverKey *btcec.PrivateKey
+
+	revokeKey *btcec.PrivateKey
+
+	ptlcTxOut *wire.TxOut
+
+	*HtlcScriptTree
+
+	ptlcAmt int64
+
+	lockTime int32
+
+	offered bool
+}
+
+func newTestPtlcScriptTree(t *testing.T, offered bool) *testPtlcScriptTree {
+	senderKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	receiverKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	revokeKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	const cltvExpiry = 144
+
+	var scriptTree *HtlcScriptTree
+	if offered {
+		scriptTree, err = SenderPTLCScriptTaproot(
+			senderKey.PubKey(), receiverKey.PubKey(),
+			revokeKey.PubKey(), false,
+		)
+	} else {
+		scriptTree, err = ReceiverPTLCScriptTaproot(
+			cltvExpiry, senderKey.PubKey(), receiverKey.PubKey(),
+			revokeKey.PubKey(), false,
+		)
+	}
+	require.NoError(t, err)
+
+	const ptlcAmt = 100
+	pkScript, err := PayToTaprootScript(scriptTree.TaprootKey)
+	require.NoError(t, err)
+
+	return &testPtlcScriptTree{
+		senderKey:   senderKey,
+		receiverKey: receiverKey,
+		revokeKey:   revokeKey,
+		ptlcTxOut: &wire.TxOut{
+			Value:    ptlcAmt,
+			PkScript: pkScript,
+		},
+		HtlcScriptTree: scriptTree,
+		ptlcAmt:        ptlcAmt,
+		lockTime:       cltvExpiry,
+		offered:        offered,
+	}
+}
+
+// scriptSpendDesc returns a sign descriptor and signer for a script path
+// spend of the PTLC with the given key.
+func (p *testPtlcScriptTree) scriptSpendDesc(key *btcec.PrivateKey,
+	leaf txscript.TapLeaf, sigHash txscript.SigHashType,
+	hashCache *txscript.TxSigHashes,
+	prevOuts txscript.PrevOutputFetcher) (*MockSigner, *SignDescriptor) {
+
+	signer := &MockSigner{
+		Privkeys: []*btcec.PrivateKey{key},
+	}
+
+	return signer, &SignDescriptor{
+		KeyDesc: keychain.KeyDescriptor{
+			PubKey: key.PubKey(),
+		},
+		WitnessScript:     leaf.Script,
+		Output:            p.ptlcTxOut,
+		HashType:          sigHash,
+		InputIndex:        0,
+		SigHashes:         hashCache,
+		SignMethod:        TaprootScriptSpendSignMethod,
+		PrevOutputFetcher: prevOuts,
+	}
+}
+
+func ptlcSuccessWitnessGen(sigHash txscript.SigHashType,
+	ptlcScriptTree *testPtlcScriptTree) witnessGen {
+
+	return func(spendTx *wire.MsgTx, hashCache *txscript.TxSigHashes,
+		prevOuts txscript.PrevOutputFetcher) (wire.TxWitness, error) {
+
+		successLeaf := ptlcScriptTree.SuccessTapLeaf
+
+		// The sender's signature is the one that would be adapted
+		// with the payment point secret before it is used.
+		senderSigner, senderDesc := ptlcScriptTree.scriptSpendDesc(
+			ptlcScriptTree.senderKey, successLeaf, sigHash,
+			hashCache, prevOuts,
+		)
+		senderSig, err := senderSigner.SignOutputRaw(
+			spendTx, senderDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, signDesc := ptlcScriptTree.scriptSpendDesc(
+			ptlcScriptTree.receiverKey, successLeaf, sigHash,
+			hashCache, prevOuts,
+		)
+
+		if ptlcScriptTree.offered {
+			return SenderPTLCScriptTaprootRedeem(
+				senderSig, sigHash, signer, signDesc, spendTx,
+				ptlcScriptTree.revokeKey.PubKey(),
+				ptlcScriptTree.TapscriptTree,
+			)
+		}
+
+		return ReceiverPTLCScriptTaprootRedeem(
+			senderSig, sigHash, signer, signDesc, spendTx,
+			ptlcScriptTree.revokeKey.PubKey(),
+			ptlcScriptTree.TapscriptTree,
+		)
+	}
+}
+
+func ptlcTimeoutWitnessGen(sigHash txscript.SigHashType,
+	ptlcScriptTree *testPtlcScriptTree) witnessGen {
+
+	return func(spendTx *wire.MsgTx, hashCache *txscript.TxSigHashes,
+		prevOuts txscript.PrevOutputFetcher) (wire.TxWitness, error) {
+
+		timeoutLeaf := ptlcScriptTree.TimeoutTapLeaf
+
+		signer, signDesc := ptlcScriptTree.scriptSpendDesc(
+			ptlcScriptTree.senderKey, timeoutLeaf, sigHash,
+			hashCache, prevOuts,
+		)
+
+		if !ptlcScriptTree.offered {
+			return ReceiverHTLCScriptTaprootTimeout(
+				signer, signDesc, spendTx,
+				ptlcScriptTree.lockTime,
+				ptlcScriptTree.revokeKey.PubKey(),
+				ptlcScriptTree.TapscriptTree,
+			)
+		}
+
+		receiverSigner, receiverDesc := ptlcScriptTree.scriptSpendDesc(
+			ptlcScriptTree.receiverKey, timeoutLeaf, sigHash,
+			hashCache, prevOuts,
+		)
+		receiverSig, err := receiverSigner.SignOutputRaw(
+			spendTx, receiverDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return SenderHTLCScriptTaprootTimeout(
+			receiverSig, sigHash, signer, signDesc, spendTx,
+			ptlcScriptTree.revokeKey.PubKey(),
+			ptlcScriptTree.TapscriptTree,
+		)
+	}
+}
+
+func ptlcRevocationWitnessGen(sigHash txscript.SigHashType,
+	ptlcScriptTree *testPtlcScriptTree) witnessGen {
+
+	return func(spendTx *wire.MsgTx, hashCache *txscript.TxSigHashes,
+		prevOuts txscript.PrevOutputFetcher) (wire.TxWitness, error) {
+
+		revokeKey := ptlcScriptTree.revokeKey
+		signer := &MockSigner{
+			Privkeys: []*btcec.PrivateKey{revokeKey},
+		}
+
+		signDesc := &SignDescriptor{
+			KeyDesc: keychain.KeyDescriptor{
+				PubKey: revokeKey.PubKey(),
+			},
+			Output:            ptlcScriptTree.ptlcTxOut,
+			HashType:          sigHash,
+			InputIndex:        0,
+			SigHashes:         hashCache,
+			SignMethod:        TaprootKeySpendSignMethod,
+			TapTweak:          ptlcScriptTree.TapscriptRoot,
+			PrevOutputFetcher: prevOuts,
+		}
+
+		return SenderHTLCScriptTaprootRevoke(signer, signDesc, spendTx)
+	}
+}
+
+type ptlcSpendTestCase struct {
+	name string
+
+	witnessGen witnessGen
+
+	txInMutator func(txIn *wire.TxIn)
+
+	witnessMutator func(witness wire.TxWitness)
+
+	valid bool
+}
+
+// TestTaprootPtlcSpend tests that all the positive and negative paths of the
+// offered and accepted PTLC tapscript trees work as expected.
+func TestTaprootPtlcSpend(t *testing.T) {
+	t.Parallel()
+
+	for _, offered := range []bool{true, false} {
+		offered := offered
+
+		name := "accepted"
+		if offered {
+			name = "offered"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testTaprootPtlcSpend(t, offered)
+		})
+	}
+}
+
+func testTaprootPtlcSpend(t *testing.T, offered bool) {
+	ptlcScriptTree := newTestPtlcScriptTree(t, offered)
+
+	// The offered PTLC can only be redeemed after a one block delay, the
+	// accepted one can only be timed out after a one block delay.
+	successTxIn := func(txIn *wire.TxIn) {
+		if offered {
+			txIn.Sequence = 1
+		}
+	}
+	timeoutTxIn := func(txIn *wire.TxIn) {
+		if !offered {
+			txIn.Sequence = 1
+		}
+	}
+
+	testCases := []ptlcSpendTestCase{
+		{
+			name: "success spend valid sighash default",
+			witnessGen: ptlcSuccessWitnessGen(
+				txscript.SigHashDefault, ptlcScriptTree,
+			),
+			txInMutator: successTxIn,
+			valid:       true,
+		},
+		{
+			name: "success spend valid sighash single" +
+				"|anyonecanpay",
+			witnessGen: ptlcSuccessWitnessGen(
+				txscript.SigHashSingle|
+					txscript.SigHashAnyOneCanPay,
+				ptlcScriptTree,
+			),
+			txInMutator: successTxIn,
+			valid:       true,
+		},
+
+		// Invalid spend, the sender signature is modified, which is
+		// what happens if the receiver doesn't know the payment point
+		// secret to complete the adaptor signature.
+		{
+			name: "success spend invalid sender sig",
+			witnessGen: ptlcSuccessWitnessGen(
+				txscript.SigHashDefault, ptlcScriptTree,
+			),
+			txInMutator: successTxIn,
+			witnessMutator: func(wit wire.TxWitness) {
+				if offered {
+					wit[1][0] ^= 1
+				} else {
+					wit[0][0] ^= 1
+				}
+			},
+			valid: false,
+		},
+		{
+			name: "revocation spend valid",
+			witnessGen: ptlcRevocationWitnessGen(
+				txscript.SigHashDefault, ptlcScriptTree,
+			),
+			valid: true,
+		},
+		{
+			name: "timeout spend valid",
+			witnessGen: ptlcTimeoutWitnessGen(
+				txscript.SigHashDefault, ptlcScriptTree,
+			),
+			txInMutator: timeoutTxIn,
+			valid:       true,
+		},
+	}
+
+	// The offered PTLC success path and the accepted PTLC timeout path
+	// both require the CSV delay.
+	if offered {
+		testCases = append(testCases, ptlcSpendTestCase{
+			name: "success spend invalid wrong sequence",
+			witnessGen: ptlcSuccessWitnessGen(
+				txscript.SigHashDefault, ptlcScriptTree,
+			),
+			valid: false,
+		})
+	} else {
+		testCases = append(testCases, ptlcSpendTestCase{
+			name: "timeout spend invalid wrong sequence",
+			witnessGen: ptlcTimeoutWitnessGen(
+				txscript.SigHashDefault, ptlcScriptTree,
+			),
+			valid: false,
+		})
+	}
+
+	for i, testCase := range testCases {
+		i := i
+		testCase := testCase
+
+		spendTx := wire.NewMsgTx(2)
+		spendTx.AddTxIn(&wire.TxIn{})
+		spendTx.AddTxOut(&wire.TxOut{
+			Value: ptlcScriptTree.ptlcAmt,
+		})
+
+		t.Run(testCase.name, func(t *testing.T) {
+			if testCase.txInMutator != nil {
+				testCase.txInMutator(spendTx.TxIn[0])
+			}
+
+			prevOuts := txscript.NewCannedPrevOutputFetcher(
+				ptlcScriptTree.ptlcTxOut.PkScript,
+				ptlcScriptTree.ptlcAmt,
+			)
+			hashCache := txscript.NewTxSigHashes(spendTx, prevOuts)
+
+			var err error
+			spendTx.TxIn[0].Witness, err = testCase.witnessGen(
+				spendTx, hashCache, prevOuts,
+			)
+			require.NoError(t, err)
+
+			if testCase.witnessMutator != nil {
+				testCase.witnessMutator(spendTx.TxIn[0].Witness)
+			}
+
+			newEngine := func() (*txscript.Engine, error) {
+				return txscript.NewEngine(
+					ptlcScriptTree.ptlcTxOut.PkScript,
+					spendTx, 0,
+					txscript.StandardVerifyFlags, nil,
+					hashCache, ptlcScriptTree.ptlcAmt,
+					prevOuts,
+				)
+			}
+			assertEngineExecution(t, i, testCase.valid, newEngine)
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package invoices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/queue"
+	"github.com/lightningnetwork/lnd/record"
+)
+
+var (
+	// ErrHtlcModifierExists is returned when an htlc modifier is
+	// registered while another one is still active.
+	ErrHtlcModifierExists = errors.New("htlc modifier already registered")
+
+	// ErrHtlcNotHeld is returned when a modification is received for an
+	// htlc that isn't held for the htlc modifier.
+	ErrHtlcNotHeld = errors.New("htlc not held for modification")
+)
+
+// HtlcModifyRequest describes an htlc paying to one of the invoices selected
+// by the htlc modifier. The htlc is held until the modifier decided how it is
+// processed.
+type HtlcModifyRequest struct {
+	// Invoice is the invoice the htlc pays to, as it was when the htlc
+	// arrived.
+	Invoice Invoice
+
+	// CircuitKey identifies the htlc.
+	CircuitKey CircuitKey
+
+	// Amt is the amount the htlc carries.
+	Amt lnwire.MilliSatoshi
+
+	// Expiry is the expiry height of the htlc.
+	Expiry uint32
+
+	// CurrentHeight is the block height at which the htlc arrived.
+	CurrentHeight int32
+
+	// CustomRecords are the custom records of the onion payload of the
+	// htlc.
+	CustomRecords record.CustomSet
+
+	// MPP is the mpp record of the onion payload of the htlc, if any.
+	MPP *record.MPP
+
+	// AMP is the amp record of the onion payload of the htlc, if any.
+	AMP *record.AMP
+
+	// Metadata is the payment metadata of the onion payload of the htlc,
+	// if any.
+	Metadata []byte
+}
+
+// HtlcModification is the decision of the htlc modifier about how a held
+// htlc is processed.
+type HtlcModification struct {
+	// CircuitKey identifies the held htlc.
+	CircuitKey CircuitKey
+
+	// AmtPaid, if non-zero, overrides the amount the htlc pays to its
+	// invoice. This allows an htlc whose amount was reduced on its way,
+	// for example by the fee of a just-in-time channel, to still settle
+	// its part of the invoice. The amount the htlc actually carried is
+	// stored with the htlc as a receipt.
+	AmtPaid lnwire.MilliSatoshi
+
+	// Cancel fails the htlc back instead of processing it.
+	Cancel bool
+}
+
+// heldHtlc is an htlc that is held until the htlc modifier decided how it is
+// processed.
+type heldHtlc struct {
+	ctx      invoiceUpdateCtx
+	hodlChan chan<- interface{}
+	modifier *HtlcModifier
+}
+
+// HtlcModifier is a registered htlc modifier. Htlcs paying to the invoices
+// it selects are held and delivered through Requests, until the modifier
+// decides how each of them is processed.
+//
+// NOTE: Htlcs are only held in memory. They are delivered again after a
+// restart, as the links replay them.
+type HtlcModifier struct {
+	registry *InvoiceRegistry
+
+	// Requests delivers the htlcs that are held for the modifier.
+	Requests chan *HtlcModifyRequest
+
+	ntfnQueue *queue.ConcurrentQueue
+
+	// selectionMtx guards allInvoices and hashes.
+	selectionMtx sync.Mutex
+	allInvoices  bool
+	hashes       map[lntypes.Hash]struct{}
+
+	cancelOnce sync.Once
+	quit       chan struct{}
+}
+
+// SelectInvoices updates the set of invoices whose htlcs are held for the
+// modifier. If all is set, the htlcs of all invoices are held. Otherwise, the
+// given hashes are added to and removed from the selected invoices.
+func (m *HtlcModifier) SelectInvoices(all bool, add,
+	remove []lntypes.Hash) {
+
+	m.selectionMtx.Lock()
+	defer m.selectionMtx.Unlock()
+
+	m.allInvoices = all
+	for _, hash := range add {
+		m.hashes[hash] = struct{}{}
+	}
+	for _, hash := range remove {
+		delete(m.hashes, hash)
+	}
+}
+
+// selects returns true if the htlcs of the invoice with the given hash are
+// held for the modifier.
+func (m *HtlcModifier) selects(hash lntypes.Hash) bool {
+	m.selectionMtx.Lock()
+	defer m.selectionMtx.Unlock()
+
+	_, ok := m.hashes[hash]
+
+	return m.allInvoices || ok
+}
+
+// Modify processes the held htlc of the modification as the modifier
+// decided.
+func (m *HtlcModifier) Modify(mod HtlcModification) error {
+	return m.registry.modifyHtlc(m, mod)
+}
+
+// Cancel unregisters the modifier. The htlcs that are still held for it are
+// processed without modification.
+func (m *HtlcModifier) Cancel() {
+	m.cancelOnce.Do(func() {
+		close(m.quit)
+		m.ntfnQueue.Stop()
+		m.registry.unregisterHtlcModifier(m)
+	})
+}
+
+// notify queues the request of a newly held htlc for the modifier.
+func (m *HtlcModifier) notify(req *HtlcModifyRequest) error {
+	select {
+	case m.ntfnQueue.ChanIn() <- req:
+		return nil
+
+	case <-m.quit:
+		return ErrShuttingDown
+	}
+}
+
+// deliverRequests forwards the queued requests to the modifier until it is
+// canceled.
+func (m *HtlcModifier) deliverRequests(quit chan struct{}) {
+	for {
+		select {
+		case item, ok := <-m.ntfnQueue.ChanOut():
+			if !ok {
+				return
+			}
+
+			req, ok := item.(*HtlcModifyRequest)
+			if !ok {
+				continue
+			}
+
+			select {
+			case m.Requests <- req:
+			case <-m.quit:
+				return
+			case <-quit:
+				return
+			}
+
+		case <-m.quit:
+			return
+
+		case <-quit:
+			return
+		}
+	}
+}
+
+// RegisterHtlcModifier registers an htlc modifier. Only a single modifier can
+// be registered at a time. It doesn't select any invoices initially.
+func (i *InvoiceRegistry) RegisterHtlcModifier() (*HtlcModifier, error) {
+	i.htlcModifierMtx.Lock()
+	defer i.htlcModifierMtx.Unlock()
+
+	if i.htlcModifier != nil {
+		return nil, ErrHtlcModifierExists
+	}
+
+	modifier := &HtlcModifier{
+		registry:  i,
+		Requests:  make(chan *HtlcModifyRequest),
+		ntfnQueue: queue.NewConcurrentQueue(20),
+		hashes:    make(map[lntypes.Hash]struct{}),
+		quit:      make(chan struct{}),
+	}
+	modifier.ntfnQueue.Start()
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+
+		modifier.deliverRequests(i.quit)
+	}()
+
+	i.htlcModifier = modifier
+
+	log.Infof("Htlc modifier registered")
+
+	return modifier, nil
+}
+
+// unregisterHtlcModifier removes the given htlc modifier and processes the
+// htlcs that are still held for it without modification.
+func (i *InvoiceRegistry) unregisterHtlcModifier(m *HtlcModifier) {
+	i.htlcModifierMtx.Lock()
+	if i.htlcModifier == m {
+		i.htlcModifier = nil
+	}
+
+	var held []*heldHtlc
+	for key, htlc := range i.heldHtlcs {
+		if htlc.modifier != m {
+			continue
+		}
+
+		held = append(held, htlc)
+		delete(i.heldHtlcs, key)
+	}
+	i.htlcModifierMtx.Unlock()
+
+	log.Infof("Htlc modifier unregistered, resuming %d held htlcs",
+		len(held))
+
+	for _, htlc := range held {
+		i.resumeHeldHtlc(htlc)
+	}
+}
+
+// holdForModifier holds the htlc of the given update context if the
+// registered htlc modifier selected its invoice. It returns true if the htlc
+// is held, in which case its resolution is delivered over the hodl channel
+// once the modifier decided how it is processed.
+func (i *InvoiceRegistry) holdForModifier(ctx *invoiceUpdateCtx,
+	hodlChan chan<- interface{}) bool {
+
+	i.htlcModifierMtx.Lock()
+	defer i.htlcModifierMtx.Unlock()
+
+	modifier := i.htlcModifier
+	if modifier == nil || !modifier.selects(ctx.hash) {
+		return false
+	}
+
+	// A replayed htlc that is already held only needs to be resolved over
+	// the new hodl channel.
+	if htlc, ok := i.heldHtlcs[ctx.circuitKey]; ok {
+		htlc.hodlChan = hodlChan
+		i.hodlSubscribe(hodlChan, ctx.circuitKey)
+
+		return true
+	}
+
+	// Htlcs to unknown invoices aren't held, they are failed as usual.
+	invoice, err := i.idb.LookupInvoice(
+		context.Background(), ctx.invoiceRef(),
+	)
+	if err != nil {
+		ctx.log(fmt.Sprintf("not held for htlc modifier: %v", err))
+
+		return false
+	}
+
+	err = modifier.notify(&HtlcModifyRequest{
+		Invoice:       invoice,
+		CircuitKey:    ctx.circuitKey,
+		Amt:           ctx.amtPaid,
+		Expiry:        ctx.expiry,
+		CurrentHeight: ctx.currentHeight,
+		CustomRecords: ctx.customRecords,
+		MPP:           ctx.mpp,
+		AMP:           ctx.amp,
+		Metadata:      ctx.metadata,
+	})
+	if err != nil {
+		return false
+	}
+
+	i.heldHtlcs[ctx.circuitKey] = &heldHtlc{
+		ctx:      *ctx,
+		hodlChan: hodlChan,
+		modifier: modifier,
+	}
+	i.hodlSubscribe(hodlChan, ctx.circuitKey)
+
+	ctx.log("held for htlc modifier")
+
+	return true
+}
+
+// modifyHtlc processes a held htlc as decided by the htlc modifier.
+func (i *InvoiceRegistry) modifyHtlc(m *HtlcModifier,
+	mod HtlcModification) error {
+
+	i.htlcModifierMtx.Lock()
+	htlc, ok := i.heldHtlcs[mod.CircuitKey]
+	if !ok || htlc.modifier != m {
+		i.htlcModifierMtx.Unlock()
+
+		return fmt.Errorf("%w: %v", ErrHtlcNotHeld, mod.CircuitKey)
+	}
+	delete(i.heldHtlcs, mod.CircuitKey)
+	i.htlcModifierMtx.Unlock()
+
+	ctx := &htlc.ctx
+	if mod.Cancel {
+		ctx.log("canceled by htlc modifier")
+
+		i.notifyHodlSubscribers(
+			ctx.failRes(ResultCanceledByModifier),
+		)
+
+		return nil
+	}
+
+	// Keep the amount the htlc actually carried as a receipt if the
+	// modifier changed the amount it pays to the invoice.
+	if mod.AmtPaid != 0 && mod.AmtPaid != ctx.amtPaid {
+		ctx.log(fmt.Sprintf("amount paid modified to %v", mod.AmtPaid))
+
+		ctx.wireAmt = ctx.amtPaid
+		ctx.amtPaid = mod.AmtPaid
+	}
+
+	i.resumeHeldHtlc(htlc)
+
+	return nil
+}
+
+// resumeHeldHtlc processes a held htlc and delivers its resolution over the
+// hodl channel if it is resolved right away.
+func (i *InvoiceRegistry) resumeHeldHtlc(htlc *heldHtlc) {
+	resolution, err := i.processExitHopHtlc(&htlc.ctx, htlc.hodlChan)
+	if err != nil {
+		// The htlc stays unresolved until it is replayed by its link.
+		log.Errorf("Unable to process held htlc %v: %v",
+			htlc.ctx.circuitKey, err)
+
+		return
+	}
+
+	if resolution != nil {
+		i.notifyHodlSubscribers(resolution)
+	}
+}
@@ -27,6 +27,11 @@ type invoiceExpiryTs struct {
 	PaymentHash lntypes.Hash
 	Expiry      time.Time
 	Keysend     bool
+
+	// Accepted indicates that the invoice had already accepted htlcs when
+	// it was added, meaning that the entry was created to fail them once
+	// the grace period after the invoice's expiry has elapsed.
+	Accepted bool
 }
 
 // Less implements PriorityQueueItem.Less such that the top item in the
@@ -43,6 +48,11 @@ var _ invoiceExpiry = (*invoiceExpiryHeight)(nil)
 type invoiceExpiryHeight struct {
 	paymentHash  lntypes.Hash
 	expiryHeight uint32
+
+	// invoiceExpiry is the time at which the invoice itself expires. It
+	// is used to fail the accepted htlcs of the invoice after a grace
+	// period rather than holding them until their expiry height.
+	invoiceExpiry time.Time
 }
 
 // Less implements PriorityQueueItem.Less such that the top item in the
@@ -81,6 +91,12 @@ type InvoiceExpiryWatcher struct {
 	// before this to prevent force closes.
 	blockExpiryDelta uint32
 
+	// expiryGracePeriod is the time after an accepted hold invoice's
+	// expiry that we cancel it and fail its htlcs, instead of holding
+	// them until they approach their expiry height. A zero value disables
+	// the time-based cancellation of accepted invoices.
+	expiryGracePeriod time.Duration
+
 	// currentHeight is the current block height.
 	currentHeight uint32
 
@@ -115,17 +131,19 @@ type InvoiceExpiryWatcher struct {
 
 // NewInvoiceExpiryWatcher creates a new InvoiceExpiryWatcher instance.
 func NewInvoiceExpiryWatcher(clock clock.Clock,
-	expiryDelta, startHeight uint32, startHash *chainhash.Hash,
+	expiryDelta uint32, expiryGracePeriod time.Duration,
+	startHeight uint32, startHash *chainhash.Hash,
 	notifier chainntnfs.ChainNotifier) *InvoiceExpiryWatcher {
 
 	return &InvoiceExpiryWatcher{
-		clock:            clock,
-		notifier:         notifier,
-		blockExpiryDelta: expiryDelta,
-		currentHeight:    startHeight,
-		currentHash:      startHash,
-		newInvoices:      make(chan []invoiceExpiry),
-		quit:             make(chan struct{}),
+		clock:             clock,
+		notifier:          notifier,
+		blockExpiryDelta:  expiryDelta,
+		expiryGracePeriod: expiryGracePeriod,
+		currentHeight:     startHeight,
+		currentHash:       startHash,
+		newInvoices:       make(chan []invoiceExpiry),
+		quit:              make(chan struct{}),
 	}
 }
 
@@ -209,7 +227,12 @@ func makeInvoiceExpiry(paymentHash lntypes.Hash,
 			}
 		}
 
-		return makeHeightExpiry(paymentHash, minHeight)
+		expiry := makeHeightExpiry(paymentHash, minHeight)
+		if expiry != nil {
+			expiry.invoiceExpiry = invoiceExpiryTime(invoice)
+		}
+
+		return expiry
 
 	default:
 		log.Debugf("Invoice not added to expiry watcher: %v",
@@ -227,17 +250,21 @@ func makeTimestampExpiry(paymentHash lntypes.Hash,
 		return nil
 	}
 
+	return &invoiceExpiryTs{
+		PaymentHash: paymentHash,
+		Expiry:      invoiceExpiryTime(invoice),
+		Keysend:     len(invoice.PaymentRequest) == 0,
+	}
+}
+
+// invoiceExpiryTime returns the time at which the given invoice expires.
+func invoiceExpiryTime(invoice *Invoice) time.Time {
 	realExpiry := invoice.Terms.Expiry
 	if realExpiry == 0 {
 		realExpiry = zpay32.DefaultInvoiceExpiry
 	}
 
-	expiry := invoice.CreationDate.Add(realExpiry)
-	return &invoiceExpiryTs{
-		PaymentHash: paymentHash,
-		Expiry:      expiry,
-		Keysend:     len(invoice.PaymentRequest) == 0,
-	}
+	return invoice.CreationDate.Add(realExpiry)
 }
 
 // makeHeightExpiry creates height-based expiry for an invoice based on its
@@ -316,8 +343,10 @@ func (ew *InvoiceExpiryWatcher) cancelNextExpiredInvoice() {
 		// to the Accepted state directly after being opened, the expiry
 		// field would never be used. Enabling cancellation for accepted
 		// keysend invoices creates a safety mechanism that can prevents
-		// channel force-closes.
-		ew.expireInvoice(top.PaymentHash, top.Keysend)
+		// channel force-closes. Accepted hold invoices that outlived
+		// their expiry by the grace period are canceled as well, so
+		// that their htlcs don't get stuck until their expiry height.
+		ew.expireInvoice(top.PaymentHash, top.Keysend || top.Accepted)
 		ew.timestampExpiryQueue.Pop()
 	}
 }
@@ -375,10 +404,30 @@ func (ew *InvoiceExpiryWatcher) pushInvoices(invoices []invoiceExpiry) {
 			}
 
 		case *invoiceExpiryHeight:
-			if expiry != nil {
-				ew.blockExpiryQueue.Push(expiry)
+			if expiry == nil {
+				continue
 			}
 
+			ew.blockExpiryQueue.Push(expiry)
+
+			// If a grace period is configured, we'll also cancel
+			// the invoice once the grace period after its expiry
+			// has elapsed, failing its accepted htlcs early.
+			if ew.expiryGracePeriod == 0 ||
+				expiry.invoiceExpiry.IsZero() {
+
+				continue
+			}
+
+			graceExpiry := expiry.invoiceExpiry.Add(
+				ew.expiryGracePeriod,
+			)
+			ew.timestampExpiryQueue.Push(&invoiceExpiryTs{
+				PaymentHash: expiry.paymentHash,
+				Expiry:      graceExpiry,
+				Accepted:    true,
+			})
+
 		default:
 			log.Errorf("unexpected queue item: %T", inv)
 		}
@@ -28,7 +28,7 @@ func newInvoiceExpiryWatcherTest(t *testing.T, now time.Time,
 	mockNotifier := newMockNotifier()
 	test := &invoiceExpiryWatcherTest{
 		watcher: NewInvoiceExpiryWatcher(
-			clock.NewTestClock(testTime), 0,
+			clock.NewTestClock(testTime), 0, 0,
 			uint32(testCurrentHeight), nil, mockNotifier,
 		),
 		testData: generateInvoiceExpiryTestData(
@@ -88,8 +88,8 @@ func (t *invoiceExpiryWatcherTest) checkExpectations() {
 // Tests that InvoiceExpiryWatcher can be started and stopped.
 func TestInvoiceExpiryWatcherStartStop(t *testing.T) {
 	watcher := NewInvoiceExpiryWatcher(
-		clock.NewTestClock(testTime), 0, uint32(testCurrentHeight), nil,
-		newMockNotifier(),
+		clock.NewTestClock(testTime), 0, 0, uint32(testCurrentHeight),
+		nil, newMockNotifier(),
 	)
 	cancel := func(lntypes.Hash, bool) error {
 		t.Fatalf("unexpected call")
@@ -188,7 +188,7 @@ func TestExpiredHodlInv(t *testing.T) {
 	expiry := time.Hour
 
 	test := setupHodlExpiry(
-		t, creationDate, expiry, 0, ContractOpen, nil,
+		t, creationDate, expiry, 0, 0, ContractOpen, nil,
 	)
 
 	test.assertCanceled(t, test.hash)
@@ -206,7 +206,7 @@ func TestAcceptedHodlNotExpired(t *testing.T) {
 	expiry := time.Hour
 
 	test := setupHodlExpiry(
-		t, creationDate, expiry, 0, ContractAccepted, nil,
+		t, creationDate, expiry, 0, 0, ContractAccepted, nil,
 	)
 	defer test.watcher.Stop()
 
@@ -238,7 +238,7 @@ func TestHeightAlreadyExpired(t *testing.T) {
 	}
 
 	test := setupHodlExpiry(
-		t, testTime, time.Hour, 0, ContractAccepted,
+		t, testTime, time.Hour, 0, 0, ContractAccepted,
 		expiredHtlc,
 	)
 	defer test.watcher.Stop()
@@ -259,7 +259,7 @@ func TestExpiryHeightArrives(t *testing.T) {
 
 	// Start out with a hodl invoice that is open, and has no htlcs.
 	test := setupHodlExpiry(
-		t, creationDate, expiry, delta, ContractOpen, nil,
+		t, creationDate, expiry, delta, 0, ContractOpen, nil,
 	)
 	defer test.watcher.Stop()
 
@@ -290,3 +290,47 @@ func TestExpiryHeightArrives(t *testing.T) {
 	test.announceBlock(t, htlc2-delta)
 	test.assertCanceled(t, test.hash)
 }
+
+// TestAcceptedHodlGracePeriod tests that an accepted hodl invoice is canceled
+// once the grace period after its expiry has elapsed, long before its htlcs
+// reach their expiry height.
+func TestAcceptedHodlGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	var (
+		creationDate = testTime
+		expiry       = time.Hour
+		gracePeriod  = time.Minute * 10
+	)
+
+	acceptedHtlc := []*InvoiceHTLC{
+		{
+			State:  HtlcStateAccepted,
+			Expiry: uint32(testCurrentHeight + 100),
+		},
+	}
+
+	test := setupHodlExpiry(
+		t, creationDate, expiry, 0, gracePeriod, ContractAccepted,
+		acceptedHtlc,
+	)
+	defer test.watcher.Stop()
+
+	// Add another invoice that expires within the grace period as a
+	// control value, which is canceled before our accepted invoice.
+	tsExpires := &invoiceExpiryTs{
+		PaymentHash: lntypes.Hash{1, 2, 3},
+		Expiry:      creationDate.Add(expiry + gracePeriod/2),
+		Keysend:     true,
+	}
+	test.watcher.AddInvoices(tsExpires)
+
+	// Once the invoice's expiry has elapsed, only the control invoice is
+	// expired.
+	test.mockClock.SetTime(creationDate.Add(expiry + gracePeriod/2 + 1))
+	test.assertCanceled(t, tsExpires.PaymentHash)
+
+	// After the grace period, our accepted invoice is canceled.
+	test.mockClock.SetTime(creationDate.Add(expiry + gracePeriod + 1))
+	test.assertCanceled(t, test.hash)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/queue"
 	"github.com/lightningnetwork/lnd/record"
+	"github.com/lightningnetwork/lnd/routing/route"
 )
 
 var (
@@ -56,13 +57,20 @@ type RegistryConfig struct {
 	Clock clock.Clock
 
 	// AcceptKeySend indicates whether we want to accept spontaneous key
-	// send payments.
+	// send payments. It is the initial value of the keysend policy, which
+	// can be updated at runtime.
 	AcceptKeySend bool
 
 	// AcceptAMP indicates whether we want to accept spontaneous AMP
-	// payments.
+	// payments. It is the initial value of the keysend policy, which can
+	// be updated at runtime.
 	AcceptAMP bool
 
+	// ChannelPeer returns the public key of the peer of the channel with
+	// the given short channel ID. It is used to enforce the sender
+	// allowlist of the keysend policy.
+	ChannelPeer func(lnwire.ShortChannelID) (route.Vertex, error)
+
 	// GcCanceledInvoicesOnStartup if set, we'll attempt to garbage collect
 	// all canceled invoices upon start.
 	GcCanceledInvoicesOnStartup bool
@@ -97,6 +105,39 @@ func (r *htlcReleaseEvent) Less(other queue.PriorityQueueItem) bool {
 	return r.releaseTime.Before(other.(*htlcReleaseEvent).releaseTime)
 }
 
+// HoldInvoiceTimeout describes how an accepted hold invoice is resolved if it
+// isn't settled or canceled in time.
+type HoldInvoiceTimeout struct {
+	// Timeout is the duration after the invoice is accepted after which
+	// it is resolved automatically.
+	Timeout time.Duration
+
+	// Preimage is the preimage to settle the invoice with once the
+	// timeout has elapsed. If it is nil, the invoice is canceled instead.
+	Preimage *lntypes.Preimage
+}
+
+// holdTimeoutEvent describes the automatic resolution of an accepted hold
+// invoice.
+type holdTimeoutEvent struct {
+	// hash is the payment hash of the invoice to resolve.
+	hash lntypes.Hash
+
+	// timeout holds the resolution of the invoice.
+	timeout HoldInvoiceTimeout
+
+	// resolveTime is the time at which to resolve the invoice.
+	resolveTime time.Time
+}
+
+// Less is used to order PriorityQueueItem's by their resolve time such that
+// items with the older resolve time are at the top of the queue.
+//
+// NOTE: Part of the queue.PriorityQueueItem interface.
+func (h *holdTimeoutEvent) Less(other queue.PriorityQueueItem) bool {
+	return h.resolveTime.Before(other.(*holdTimeoutEvent).resolveTime)
+}
+
 // InvoiceRegistry is a central registry of all the outstanding invoices
 // created by the daemon. The registry is a thin wrapper around a map in order
 // to ensure that all updates/reads are thread safe.
@@ -144,8 +185,33 @@ type InvoiceRegistry struct {
 	// auto-released.
 	htlcAutoReleaseChan chan *htlcReleaseEvent
 
+	// holdTimeouts holds the timeouts of hold invoices that haven't been
+	// accepted yet, keyed by their payment hash.
+	//
+	// NOTE: The timeouts are only kept in memory, so hold invoices that
+	// are accepted after a restart aren't resolved automatically.
+	holdTimeouts map[lntypes.Hash]HoldInvoiceTimeout
+
+	// holdTimeoutChan contains the accepted hold invoices that need to be
+	// resolved automatically.
+	holdTimeoutChan chan *holdTimeoutEvent
+
 	expiryWatcher *InvoiceExpiryWatcher
 
+	// keysendPolicy is the policy spontaneous keysend and AMP payments
+	// are checked against. It is guarded by keysendPolicyMtx.
+	//
+	// NOTE: The policy is only kept in memory and reset to the startup
+	// configuration on restart.
+	keysendPolicy    KeysendPolicy
+	keysendPolicyMtx sync.RWMutex
+
+	// htlcModifier is the registered htlc modifier, if any. Together with
+	// the htlcs that are held for it, it is guarded by htlcModifierMtx.
+	htlcModifier    *HtlcModifier
+	heldHtlcs       map[CircuitKey]*heldHtlc
+	htlcModifierMtx sync.Mutex
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -172,9 +238,46 @@ func NewRegistry(idb InvoiceDB, expiryWatcher *InvoiceExpiryWatcher,
 		),
 		cfg:                 cfg,
 		htlcAutoReleaseChan: make(chan *htlcReleaseEvent),
+		holdTimeouts:        make(map[lntypes.Hash]HoldInvoiceTimeout),
+		holdTimeoutChan:     make(chan *holdTimeoutEvent),
 		expiryWatcher:       expiryWatcher,
-		quit:                make(chan struct{}),
+		keysendPolicy: KeysendPolicy{
+			AcceptKeySend: cfg.AcceptKeySend,
+			AcceptAMP:     cfg.AcceptAMP,
+		},
+		heldHtlcs: make(map[CircuitKey]*heldHtlc),
+		quit:      make(chan struct{}),
+	}
+}
+
+// KeysendPolicy returns the policy spontaneous keysend and AMP payments are
+// currently checked against.
+func (i *InvoiceRegistry) KeysendPolicy() KeysendPolicy {
+	i.keysendPolicyMtx.RLock()
+	defer i.keysendPolicyMtx.RUnlock()
+
+	return i.keysendPolicy.copy()
+}
+
+// UpdateKeysendPolicy replaces the policy spontaneous keysend and AMP
+// payments are checked against. It applies to all htlcs that arrive after the
+// update.
+func (i *InvoiceRegistry) UpdateKeysendPolicy(policy KeysendPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
 	}
+
+	i.keysendPolicyMtx.Lock()
+	defer i.keysendPolicyMtx.Unlock()
+
+	i.keysendPolicy = policy.copy()
+
+	log.Infof("Updated keysend policy: accept_keysend=%v, "+
+		"accept_amp=%v, min_amt=%v, max_amt=%v, allowed_senders=%v",
+		policy.AcceptKeySend, policy.AcceptAMP, policy.MinAmt,
+		policy.MaxAmt, len(policy.AllowedSenders))
+
+	return nil
 }
 
 // scanInvoicesOnStart will scan all invoices on start and add active invoices
@@ -276,6 +379,9 @@ func (i *InvoiceRegistry) invoiceEventLoop() {
 	// Set up a heap for htlc auto-releases.
 	autoReleaseHeap := &queue.PriorityQueue{}
 
+	// Set up a heap for the automatic resolution of hold invoices.
+	holdTimeoutHeap := &queue.PriorityQueue{}
+
 	for {
 		// If there is something to release, set up a release tick
 		// channel.
@@ -285,6 +391,14 @@ func (i *InvoiceRegistry) invoiceEventLoop() {
 			nextReleaseTick = i.tickAt(head.releaseTime)
 		}
 
+		// If there is a hold invoice to resolve, set up a resolve tick
+		// channel.
+		var nextHoldTimeoutTick <-chan time.Time
+		if holdTimeoutHeap.Len() > 0 {
+			head := holdTimeoutHeap.Top().(*holdTimeoutEvent)
+			nextHoldTimeoutTick = i.tickAt(head.resolveTime)
+		}
+
 		select {
 		// A sub-systems has just modified the invoice state, so we'll
 		// dispatch notifications to all registered clients.
@@ -322,6 +436,21 @@ func (i *InvoiceRegistry) invoiceEventLoop() {
 				log.Errorf("HTLC timer: %v", err)
 			}
 
+		// A hold invoice was accepted that needs to be resolved
+		// automatically.
+		case event := <-i.holdTimeoutChan:
+			log.Debugf("Scheduling automatic resolution of hold "+
+				"invoice %v at %v", event.hash,
+				event.resolveTime)
+
+			holdTimeoutHeap.Push(event)
+
+		// The hold invoice at the top of the heap needs to be
+		// resolved.
+		case <-nextHoldTimeoutTick:
+			event := holdTimeoutHeap.Pop().(*holdTimeoutEvent)
+			i.resolveHoldTimeout(event)
+
 		case <-i.quit:
 			return
 		}
@@ -616,6 +745,82 @@ func (i *InvoiceRegistry) startHtlcTimer(invoiceRef InvoiceRef,
 	}
 }
 
+// AddHoldInvoiceTimeout registers a timeout for the hold invoice with the given
+// payment hash. Once the invoice is accepted and isn't settled or canceled
+// within the timeout, it is settled with the timeout's preimage or canceled
+// if no preimage is given.
+func (i *InvoiceRegistry) AddHoldInvoiceTimeout(hash lntypes.Hash,
+	timeout HoldInvoiceTimeout) error {
+
+	if timeout.Preimage != nil && timeout.Preimage.Hash() != hash {
+		return fmt.Errorf("preimage %v doesn't match payment hash %v",
+			timeout.Preimage, hash)
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	i.holdTimeouts[hash] = timeout
+
+	return nil
+}
+
+// startHoldTimeout starts the timer of the hold invoice with the given payment
+// hash via the invoice registry main loop, if a timeout was registered for it.
+func (i *InvoiceRegistry) startHoldTimeout(hash lntypes.Hash) error {
+	i.Lock()
+	timeout, ok := i.holdTimeouts[hash]
+	delete(i.holdTimeouts, hash)
+	i.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	event := &holdTimeoutEvent{
+		hash:        hash,
+		timeout:     timeout,
+		resolveTime: i.cfg.Clock.Now().Add(timeout.Timeout),
+	}
+
+	select {
+	case i.holdTimeoutChan <- event:
+		return nil
+
+	case <-i.quit:
+		return ErrShuttingDown
+	}
+}
+
+// resolveHoldTimeout settles or cancels a hold invoice whose timeout has
+// elapsed. Invoices that were already resolved are left untouched.
+func (i *InvoiceRegistry) resolveHoldTimeout(event *holdTimeoutEvent) {
+	ctx := context.Background()
+
+	var err error
+	if event.timeout.Preimage != nil {
+		log.Infof("Hold invoice %v timed out, settling", event.hash)
+		err = i.SettleHodlInvoice(ctx, *event.timeout.Preimage)
+	} else {
+		log.Infof("Hold invoice %v timed out, canceling", event.hash)
+		err = i.CancelInvoice(ctx, event.hash)
+	}
+
+	switch {
+	case err == nil:
+
+	case errors.Is(err, ErrInvoiceAlreadySettled):
+
+	case errors.Is(err, ErrInvoiceAlreadyCanceled):
+
+	case errors.Is(err, ErrInvoiceNotFound):
+
+	default:
+		log.Errorf("Unable to resolve timed out hold invoice %v: %v",
+			event.hash, err)
+	}
+}
+
 // cancelSingleHtlc cancels a single accepted htlc on an invoice. It takes
 // a resolution result which will be used to notify subscribed links and
 // resolvers of the details of the htlc cancellation.
@@ -731,7 +936,9 @@ func (i *InvoiceRegistry) cancelSingleHtlc(invoiceRef InvoiceRef,
 
 // processKeySend just-in-time inserts an invoice if this htlc is a keysend
 // htlc.
-func (i *InvoiceRegistry) processKeySend(ctx invoiceUpdateCtx) error {
+func (i *InvoiceRegistry) processKeySend(ctx invoiceUpdateCtx,
+	policy *KeysendPolicy) error {
+
 	// Retrieve keysend record if present.
 	preimageSlice, ok := ctx.customRecords[record.KeySendType]
 	if !ok {
@@ -756,6 +963,15 @@ func (i *InvoiceRegistry) processKeySend(ctx invoiceUpdateCtx) error {
 	// Create an invoice for the htlc amount.
 	amt := ctx.amtPaid
 
+	// Check that the keysend policy allows this payment.
+	if err := policy.checkAmount(amt); err != nil {
+		return err
+	}
+	err = policy.checkSender(ctx.circuitKey.ChanID, i.cfg.ChannelPeer)
+	if err != nil {
+		return err
+	}
+
 	// Set tlv required feature vector on the invoice. Otherwise we wouldn't
 	// be able to pay to it with keysend.
 	rawFeatures := lnwire.NewRawFeatureVector(
@@ -810,12 +1026,39 @@ func (i *InvoiceRegistry) processKeySend(ctx invoiceUpdateCtx) error {
 
 // processAMP just-in-time inserts an invoice if this htlc is a keysend
 // htlc.
-func (i *InvoiceRegistry) processAMP(ctx invoiceUpdateCtx) error {
+func (i *InvoiceRegistry) processAMP(ctx invoiceUpdateCtx,
+	policy *KeysendPolicy) error {
+
 	// AMP payments MUST also include an MPP record.
 	if ctx.mpp == nil {
 		return errors.New("no MPP record for AMP")
 	}
 
+	// If an invoice with the payment address already exists, this htlc
+	// pays to an AMP invoice we created or belongs to a spontaneous AMP
+	// payment that already passed the keysend policy.
+	_, err := i.idb.LookupInvoice(
+		context.Background(), InvoiceRefByAddr(ctx.mpp.PaymentAddr()),
+	)
+	isNotFound := errors.Is(err, ErrInvoiceNotFound)
+	isNotCreated := errors.Is(err, ErrNoInvoicesCreated)
+	switch {
+	case err == nil:
+		return nil
+
+	case !isNotFound && !isNotCreated:
+		return err
+	}
+
+	// Otherwise, check that the keysend policy allows this payment.
+	if err := policy.checkAmount(ctx.mpp.TotalMsat()); err != nil {
+		return err
+	}
+	err = policy.checkSender(ctx.circuitKey.ChanID, i.cfg.ChannelPeer)
+	if err != nil {
+		return err
+	}
+
 	// Create an invoice for the total amount expected, provided in the MPP
 	// record.
 	amt := ctx.mpp.TotalMsat()
@@ -858,7 +1101,7 @@ func (i *InvoiceRegistry) processAMP(ctx invoiceUpdateCtx) error {
 	// Insert invoice into database. Ignore duplicates payment hashes and
 	// payment addrs, this may be a replay or a different HTLC for the AMP
 	// invoice.
-	_, err := i.AddInvoice(context.Background(), invoice, ctx.hash)
+	_, err = i.AddInvoice(context.Background(), invoice, ctx.hash)
 	isDuplicatedInvoice := errors.Is(err, ErrDuplicateInvoice)
 	isDuplicatedPayAddr := errors.Is(err, ErrDuplicatePayAddr)
 	switch {
@@ -904,12 +1147,14 @@ func (i *InvoiceRegistry) NotifyExitHopHtlc(rHash lntypes.Hash,
 		metadata:             payload.Metadata(),
 	}
 
+	policy := i.KeysendPolicy()
+
 	switch {
 	// If we are accepting spontaneous AMP payments and this payload
 	// contains an AMP record, create an AMP invoice that will be settled
 	// below.
-	case i.cfg.AcceptAMP && ctx.amp != nil:
-		err := i.processAMP(ctx)
+	case policy.AcceptAMP && ctx.amp != nil:
+		err := i.processAMP(ctx, &policy)
 		if err != nil {
 			ctx.log(fmt.Sprintf("amp error: %v", err))
 
@@ -922,8 +1167,8 @@ func (i *InvoiceRegistry) NotifyExitHopHtlc(rHash lntypes.Hash,
 	// invoice that will be settled below. We also enforce that this is only
 	// done when no AMP payload is present since it will only be settle-able
 	// by regular HTLCs.
-	case i.cfg.AcceptKeySend && ctx.amp == nil:
-		err := i.processKeySend(ctx)
+	case policy.AcceptKeySend && ctx.amp == nil:
+		err := i.processKeySend(ctx, &policy)
 		if err != nil {
 			ctx.log(fmt.Sprintf("keysend error: %v", err))
 
@@ -933,10 +1178,25 @@ func (i *InvoiceRegistry) NotifyExitHopHtlc(rHash lntypes.Hash,
 		}
 	}
 
+	// If the htlc modifier selected the invoice, the htlc is held until
+	// the modifier decided how it is processed. Held htlcs are represented
+	// by a nil resolution.
+	if i.holdForModifier(&ctx, hodlChan) {
+		return nil, nil
+	}
+
+	return i.processExitHopHtlc(&ctx, hodlChan)
+}
+
+// processExitHopHtlc attempts to apply the htlc of the given update context
+// to its invoice. A nil resolution is returned if the htlc is held.
+func (i *InvoiceRegistry) processExitHopHtlc(ctx *invoiceUpdateCtx,
+	hodlChan chan<- interface{}) (HtlcResolution, error) {
+
 	// Execute locked notify exit hop logic.
 	i.Lock()
 	resolution, invoiceToExpire, err := i.notifyExitHopHtlcLocked(
-		&ctx, hodlChan,
+		ctx, hodlChan,
 	)
 	i.Unlock()
 	if err != nil {
@@ -961,13 +1221,22 @@ func (i *InvoiceRegistry) NotifyExitHopHtlc(rHash lntypes.Hash,
 			}
 
 			err := i.startHtlcTimer(
-				invRef, circuitKey, r.acceptTime,
+				invRef, ctx.circuitKey, r.acceptTime,
 			)
 			if err != nil {
 				return nil, err
 			}
 		}
 
+		// Once the set of htlcs of a hold invoice is complete, start
+		// its timeout if it has one.
+		if r.outcome == resultAccepted {
+			err := i.startHoldTimeout(ctx.hash)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// We return a nil resolution because htlc acceptances are
 		// represented as nil resolutions externally.
 		// TODO(carla) update calling code to handle accept resolutions.
@@ -1240,6 +1509,10 @@ func (i *InvoiceRegistry) SettleHodlInvoice(ctx context.Context,
 	log.Debugf("Invoice%v: settled with preimage %v", invoiceRef,
 		invoice.Terms.PaymentPreimage)
 
+	// A hold invoice that is settled doesn't need to be resolved
+	// automatically anymore.
+	delete(i.holdTimeouts, hash)
+
 	// In the callback, we marked the invoice as settled. UpdateInvoice will
 	// have seen this and should have moved all htlcs that were accepted to
 	// the settled state. In the loop below, we go through all of these and
@@ -1335,6 +1608,10 @@ func (i *InvoiceRegistry) cancelInvoiceImpl(ctx context.Context,
 
 	log.Debugf("Invoice%v: canceled", ref)
 
+	// A hold invoice that is canceled doesn't need to be resolved
+	// automatically anymore.
+	delete(i.holdTimeouts, payHash)
+
 	// In the callback, some htlcs may have been moved to the canceled
 	// state. We now go through all of these and notify links and resolvers
 	// that are waiting for resolution. Any htlcs that were already canceled
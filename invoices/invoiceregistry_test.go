@@ -20,6 +20,7 @@ import (
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/record"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/sqldb"
 	"github.com/stretchr/testify/require"
 )
@@ -57,6 +58,10 @@ func TestInvoiceRegistry(t *testing.T) {
 			name: "CancelHoldInvoice",
 			test: testCancelHoldInvoice,
 		},
+		{
+			name: "HoldInvoiceTimeout",
+			test: testHoldInvoiceTimeout,
+		},
 		{
 			name: "UnknownInvoice",
 			test: testUnknownInvoice,
@@ -69,6 +74,14 @@ func TestInvoiceRegistry(t *testing.T) {
 			name: "HoldKeysend",
 			test: testHoldKeysend,
 		},
+		{
+			name: "KeySendPolicy",
+			test: testKeySendPolicy,
+		},
+		{
+			name: "HtlcModifier",
+			test: testHtlcModifier,
+		},
 		{
 			name: "MppPayment",
 			test: testMppPayment,
@@ -529,7 +542,8 @@ func testSettleHoldInvoice(t *testing.T,
 	}
 
 	expiryWatcher := invpkg.NewInvoiceExpiryWatcher(
-		cfg.Clock, 0, uint32(testCurrentHeight), nil, newMockNotifier(),
+		cfg.Clock, 0, 0, uint32(testCurrentHeight), nil,
+		newMockNotifier(),
 	)
 	registry := invpkg.NewRegistry(idb, expiryWatcher, &cfg)
 
@@ -694,7 +708,8 @@ func testCancelHoldInvoice(t *testing.T,
 		Clock:                testClock,
 	}
 	expiryWatcher := invpkg.NewInvoiceExpiryWatcher(
-		cfg.Clock, 0, uint32(testCurrentHeight), nil, newMockNotifier(),
+		cfg.Clock, 0, 0, uint32(testCurrentHeight), nil,
+		newMockNotifier(),
 	)
 	registry := invpkg.NewRegistry(idb, expiryWatcher, &cfg)
 
@@ -754,6 +769,95 @@ func testCancelHoldInvoice(t *testing.T,
 	require.Equal(t, testCurrentHeight, failResolution.AcceptHeight)
 }
 
+// testHoldInvoiceTimeout tests that accepted hold invoices are settled or
+// canceled automatically once their timeout has elapsed.
+func testHoldInvoiceTimeout(t *testing.T,
+	makeDB func(t *testing.T) (invpkg.InvoiceDB, *clock.TestClock)) {
+
+	t.Parallel()
+	defer timeout()()
+
+	const holdTimeout = time.Minute
+
+	testCases := []struct {
+		name     string
+		preimage *lntypes.Preimage
+	}{
+		{
+			name:     "settle",
+			preimage: &testInvoicePreimage,
+		},
+		{
+			name: "cancel",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := newTestContext(t, nil, makeDB)
+			ctxb := context.Background()
+
+			invoice := newInvoice(t, true)
+			_, err := ctx.registry.AddInvoice(
+				ctxb, invoice, testInvoicePaymentHash,
+			)
+			require.NoError(t, err)
+
+			// A preimage that doesn't match the payment hash is
+			// rejected.
+			err = ctx.registry.AddHoldInvoiceTimeout(
+				testInvoicePaymentHash,
+				invpkg.HoldInvoiceTimeout{
+					Timeout:  holdTimeout,
+					Preimage: &lntypes.Preimage{2},
+				},
+			)
+			require.Error(t, err)
+
+			err = ctx.registry.AddHoldInvoiceTimeout(
+				testInvoicePaymentHash,
+				invpkg.HoldInvoiceTimeout{
+					Timeout:  holdTimeout,
+					Preimage: tc.preimage,
+				},
+			)
+			require.NoError(t, err)
+
+			// The htlc paying the invoice is held.
+			hodlChan := make(chan interface{}, 1)
+			resolution, err := ctx.registry.NotifyExitHopHtlc(
+				testInvoicePaymentHash, testInvoiceAmount,
+				testHtlcExpiry, testCurrentHeight,
+				getCircuitKey(0), hodlChan, testPayload,
+			)
+			require.NoError(t, err)
+			require.Nil(t, resolution)
+
+			// Once the timeout elapses, the htlc is resolved.
+			ctx.clock.SetTime(testNow.Add(holdTimeout + 1))
+
+			res := <-hodlChan
+			htlcResolution, ok := res.(invpkg.HtlcResolution)
+			require.True(t, ok)
+
+			if tc.preimage == nil {
+				checkFailResolution(
+					t, htlcResolution,
+					invpkg.ResultCanceled,
+				)
+
+				return
+			}
+
+			checkSettleResolution(
+				t, htlcResolution, *tc.preimage,
+			)
+		})
+	}
+}
+
 // testUnknownInvoice tests that invoice registry returns an error when the
 // invoice is unknown. This is to guard against returning a cancel htlc
 // resolution for forwarded htlcs. In the link, NotifyExitHopHtlc is only called
@@ -915,6 +1019,121 @@ func testKeySendImpl(t *testing.T, keySendEnabled bool,
 	checkSubscription()
 }
 
+// testKeySendPolicy tests that spontaneous keysend payments are checked
+// against the keysend policy of the registry, which can be updated at runtime.
+func testKeySendPolicy(t *testing.T,
+	makeDB func(t *testing.T) (invpkg.InvoiceDB, *clock.TestClock)) {
+
+	t.Parallel()
+	defer timeout()()
+
+	peer := route.Vertex{1}
+	cfg := defaultRegistryConfig()
+	cfg.AcceptKeySend = true
+	cfg.ChannelPeer = func(lnwire.ShortChannelID) (route.Vertex, error) {
+		return peer, nil
+	}
+	ctx := newTestContext(t, &cfg, makeDB)
+
+	// The initial policy is taken from the registry config.
+	require.Equal(t, invpkg.KeysendPolicy{
+		AcceptKeySend: true,
+	}, ctx.registry.KeysendPolicy())
+
+	// A policy with a minimum above the maximum amount is refused.
+	err := ctx.registry.UpdateKeysendPolicy(invpkg.KeysendPolicy{
+		AcceptKeySend: true,
+		MinAmt:        2000,
+		MaxAmt:        1000,
+	})
+	require.Error(t, err)
+
+	const (
+		amt    = lnwire.MilliSatoshi(1000)
+		expiry = uint32(testCurrentHeight + 20)
+	)
+
+	preimage := lntypes.Preimage{1, 2, 3}
+	keySendPayload := &mockPayload{
+		customRecords: map[uint64][]byte{
+			record.KeySendType: preimage[:],
+		},
+	}
+
+	notifyKeySend := func() invpkg.HtlcResolution {
+		t.Helper()
+
+		resolution, err := ctx.registry.NotifyExitHopHtlc(
+			preimage.Hash(), amt, expiry, testCurrentHeight,
+			getCircuitKey(10), make(chan interface{}, 1),
+			keySendPayload,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, resolution)
+
+		return resolution
+	}
+
+	tests := []struct {
+		name   string
+		policy invpkg.KeysendPolicy
+		result invpkg.FailResolutionResult
+	}{
+		{
+			name:   "disabled",
+			policy: invpkg.KeysendPolicy{},
+			result: invpkg.ResultInvoiceNotFound,
+		},
+		{
+			name: "amount too low",
+			policy: invpkg.KeysendPolicy{
+				AcceptKeySend: true,
+				MinAmt:        amt + 1,
+			},
+			result: invpkg.ResultKeySendError,
+		},
+		{
+			name: "amount too high",
+			policy: invpkg.KeysendPolicy{
+				AcceptKeySend: true,
+				MaxAmt:        amt - 1,
+			},
+			result: invpkg.ResultKeySendError,
+		},
+		{
+			name: "sender not allowed",
+			policy: invpkg.KeysendPolicy{
+				AcceptKeySend: true,
+				AllowedSenders: map[route.Vertex]struct{}{
+					{2}: {},
+				},
+			},
+			result: invpkg.ResultKeySendError,
+		},
+	}
+
+	for _, test := range tests {
+		err := ctx.registry.UpdateKeysendPolicy(test.policy)
+		require.NoError(t, err, test.name)
+
+		checkFailResolution(t, notifyKeySend(), test.result)
+	}
+
+	// Finally, allow payments within the limits from our peer, which
+	// should settle the payment.
+	err = ctx.registry.UpdateKeysendPolicy(invpkg.KeysendPolicy{
+		AcceptKeySend: true,
+		MinAmt:        amt,
+		MaxAmt:        amt,
+		AllowedSenders: map[route.Vertex]struct{}{
+			peer: {},
+		},
+	})
+	require.NoError(t, err)
+
+	checkSettleResolution(t, notifyKeySend(), preimage)
+}
+
 // testHoldKeysend tests receiving a spontaneous payment that is held.
 func testHoldKeysend(t *testing.T,
 	makeDB func(t *testing.T) (invpkg.InvoiceDB, *clock.TestClock)) {
@@ -1021,6 +1240,156 @@ func testHoldKeysendImpl(t *testing.T, timeoutKeysend bool,
 	require.Equal(t, settledInvoice.State, invpkg.ContractSettled)
 }
 
+// testHtlcModifier tests that the htlcs of invoices selected by the htlc
+// modifier are held until the modifier decided how they are processed, and
+// that the amount they pay to the invoice can be modified.
+func testHtlcModifier(t *testing.T,
+	makeDB func(t *testing.T) (invpkg.InvoiceDB, *clock.TestClock)) {
+
+	t.Parallel()
+	defer timeout()()
+
+	ctx := newTestContext(t, nil, makeDB)
+	ctxb := context.Background()
+
+	modifier, err := ctx.registry.RegisterHtlcModifier()
+	require.NoError(t, err)
+
+	// Only a single modifier can be registered.
+	_, err = ctx.registry.RegisterHtlcModifier()
+	require.ErrorIs(t, err, invpkg.ErrHtlcModifierExists)
+
+	testInvoice := newInvoice(t, false)
+	_, err = ctx.registry.AddInvoice(
+		ctxb, testInvoice, testInvoicePaymentHash,
+	)
+	require.NoError(t, err)
+
+	modifier.SelectInvoices(
+		false, []lntypes.Hash{testInvoicePaymentHash}, nil,
+	)
+
+	// The htlcs of the payment are reduced by a fee on their way.
+	const fee = lnwire.MilliSatoshi(100)
+	partAmt := testInvoice.Terms.Value / 2
+
+	mppPayload := &mockPayload{
+		mpp: record.NewMPP(testInvoiceAmount, [32]byte{}),
+	}
+
+	// holdHtlc sends an htlc that is expected to be held for the modifier
+	// and returns its hodl channel.
+	holdHtlc := func(htlcID uint64) chan interface{} {
+		t.Helper()
+
+		hodlChan := make(chan interface{}, 1)
+		resolution, err := ctx.registry.NotifyExitHopHtlc(
+			testInvoicePaymentHash, partAmt-fee, testHtlcExpiry,
+			testCurrentHeight, getCircuitKey(htlcID), hodlChan,
+			mppPayload,
+		)
+		require.NoError(t, err)
+		require.Nil(t, resolution)
+
+		select {
+		case req := <-modifier.Requests:
+			require.Equal(t, getCircuitKey(htlcID), req.CircuitKey)
+			require.Equal(t, partAmt-fee, req.Amt)
+			require.Equal(t, mppPayload.mpp, req.MPP)
+			require.Equal(
+				t, testInvoice.Terms.Value,
+				req.Invoice.Terms.Value,
+			)
+
+		case <-time.After(testTimeout):
+			t.Fatal("htlc not held for modifier")
+		}
+
+		return hodlChan
+	}
+
+	// Both htlcs are credited with the full part amount, so that they
+	// complete the set.
+	hodlChan1 := holdHtlc(10)
+	require.NoError(t, modifier.Modify(invpkg.HtlcModification{
+		CircuitKey: getCircuitKey(10),
+		AmtPaid:    partAmt,
+	}))
+
+	hodlChan2 := holdHtlc(11)
+	require.NoError(t, modifier.Modify(invpkg.HtlcModification{
+		CircuitKey: getCircuitKey(11),
+		AmtPaid:    partAmt,
+	}))
+
+	for _, hodlChan := range []chan interface{}{hodlChan1, hodlChan2} {
+		select {
+		case resolution := <-hodlChan:
+			checkSettleResolution(
+				t, resolution.(invpkg.HtlcResolution),
+				testInvoicePreimage,
+			)
+
+		case <-time.After(testTimeout):
+			t.Fatal("htlc not settled")
+		}
+	}
+
+	// The invoice records the amounts the htlcs actually carried as a
+	// receipt of the modification.
+	inv, err := ctx.registry.LookupInvoice(ctxb, testInvoicePaymentHash)
+	require.NoError(t, err)
+	require.Equal(t, invpkg.ContractSettled, inv.State)
+	require.Equal(t, testInvoice.Terms.Value, inv.AmtPaid)
+	require.Len(t, inv.Htlcs, 2)
+	for _, htlc := range inv.Htlcs {
+		require.Equal(t, partAmt, htlc.Amt)
+		require.Equal(t, partAmt-fee, htlc.WireAmt)
+	}
+
+	// Htlcs that aren't held can't be modified.
+	err = modifier.Modify(invpkg.HtlcModification{
+		CircuitKey: getCircuitKey(10),
+	})
+	require.ErrorIs(t, err, invpkg.ErrHtlcNotHeld)
+
+	// The modifier can cancel held htlcs.
+	hodlChan := holdHtlc(12)
+	require.NoError(t, modifier.Modify(invpkg.HtlcModification{
+		CircuitKey: getCircuitKey(12),
+		Cancel:     true,
+	}))
+
+	select {
+	case resolution := <-hodlChan:
+		checkFailResolution(
+			t, resolution.(invpkg.HtlcResolution),
+			invpkg.ResultCanceledByModifier,
+		)
+
+	case <-time.After(testTimeout):
+		t.Fatal("htlc not canceled")
+	}
+
+	// Once the modifier is unregistered, the htlcs that are still held
+	// for it are processed without modification.
+	hodlChan = holdHtlc(13)
+	modifier.Cancel()
+
+	select {
+	case resolution := <-hodlChan:
+		require.IsType(t, &invpkg.HtlcFailResolution{}, resolution)
+
+	case <-time.After(testTimeout):
+		t.Fatal("htlc not resumed")
+	}
+
+	// A new modifier can be registered afterwards.
+	modifier, err = ctx.registry.RegisterHtlcModifier()
+	require.NoError(t, err)
+	modifier.Cancel()
+}
+
 // testMppPayment tests settling of an invoice with multiple partial payments.
 // It covers the case where there is a mpp timeout before the whole invoice is
 // paid and the case where the invoice is settled in time.
@@ -1212,7 +1581,8 @@ func testInvoiceExpiryWithRegistry(t *testing.T,
 	}
 
 	expiryWatcher := invpkg.NewInvoiceExpiryWatcher(
-		cfg.Clock, 0, uint32(testCurrentHeight), nil, newMockNotifier(),
+		cfg.Clock, 0, 0, uint32(testCurrentHeight), nil,
+		newMockNotifier(),
 	)
 	registry := invpkg.NewRegistry(idb, expiryWatcher, &cfg)
 
@@ -1322,7 +1692,8 @@ func testOldInvoiceRemovalOnStart(t *testing.T,
 	}
 
 	expiryWatcher := invpkg.NewInvoiceExpiryWatcher(
-		cfg.Clock, 0, uint32(testCurrentHeight), nil, newMockNotifier(),
+		cfg.Clock, 0, 0, uint32(testCurrentHeight), nil,
+		newMockNotifier(),
 	)
 	registry := invpkg.NewRegistry(idb, expiryWatcher, &cfg)
 
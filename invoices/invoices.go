@@ -506,9 +506,16 @@ const (
 
 // InvoiceHTLC contains details about an htlc paying to this invoice.
 type InvoiceHTLC struct {
-	// Amt is the amount that is carried by this htlc.
+	// Amt is the amount that is carried by this htlc. If an htlc modifier
+	// changed the amount the htlc pays to the invoice, this is the
+	// modified amount.
 	Amt lnwire.MilliSatoshi
 
+	// WireAmt is the amount the htlc actually carried if an htlc modifier
+	// changed the amount it pays to the invoice. It serves as a receipt of
+	// the modification and is zero if the amount wasn't modified.
+	WireAmt lnwire.MilliSatoshi
+
 	// MppTotalAmt is a field for mpp that indicates the expected total
 	// amount.
 	MppTotalAmt lnwire.MilliSatoshi
@@ -636,6 +643,11 @@ type HtlcAcceptDesc struct {
 	// Amt is the amount that is carried by this htlc.
 	Amt lnwire.MilliSatoshi
 
+	// WireAmt is the amount the htlc actually carried if an htlc modifier
+	// changed the amount it pays to the invoice, which is then stored as
+	// Amt.
+	WireAmt lnwire.MilliSatoshi
+
 	// MppTotalAmt is a field for mpp that indicates the expected total
 	// amount.
 	MppTotalAmt lnwire.MilliSatoshi
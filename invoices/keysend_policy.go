@@ -0,0 +1,121 @@
+package invoices
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+var (
+	// ErrKeysendAmountTooLow is returned when a spontaneous payment pays
+	// less than the minimum amount of the keysend policy.
+	ErrKeysendAmountTooLow = errors.New("spontaneous payment amount " +
+		"below policy minimum")
+
+	// ErrKeysendAmountTooHigh is returned when a spontaneous payment pays
+	// more than the maximum amount of the keysend policy.
+	ErrKeysendAmountTooHigh = errors.New("spontaneous payment amount " +
+		"above policy maximum")
+
+	// ErrKeysendSenderNotAllowed is returned when a spontaneous payment
+	// arrives from a peer that isn't on the allowlist of the keysend
+	// policy.
+	ErrKeysendSenderNotAllowed = errors.New("spontaneous payment sender " +
+		"not allowed by policy")
+)
+
+// KeysendPolicy is the policy spontaneous keysend and AMP payments, which
+// don't pay to an invoice we created, are checked against before the registry
+// creates an invoice for them.
+type KeysendPolicy struct {
+	// AcceptKeySend indicates whether we accept spontaneous keysend
+	// payments.
+	AcceptKeySend bool
+
+	// AcceptAMP indicates whether we accept spontaneous AMP payments.
+	AcceptAMP bool
+
+	// MinAmt is the minimum amount a spontaneous payment must pay. Zero
+	// disables the limit.
+	MinAmt lnwire.MilliSatoshi
+
+	// MaxAmt is the maximum amount a spontaneous payment may pay. Zero
+	// disables the limit.
+	MaxAmt lnwire.MilliSatoshi
+
+	// AllowedSenders is the set of peers spontaneous payments may be
+	// received from. Keysend and AMP payments don't identify their
+	// sender, so the peer the payment arrives over is checked. All peers
+	// are allowed if the set is empty.
+	AllowedSenders map[route.Vertex]struct{}
+}
+
+// Validate checks that the policy is consistent.
+func (p *KeysendPolicy) Validate() error {
+	if p.MaxAmt != 0 && p.MinAmt > p.MaxAmt {
+		return fmt.Errorf("minimum amount %v above maximum amount %v",
+			p.MinAmt, p.MaxAmt)
+	}
+
+	return nil
+}
+
+// copy returns a deep copy of the policy.
+func (p *KeysendPolicy) copy() KeysendPolicy {
+	policy := *p
+	if p.AllowedSenders != nil {
+		policy.AllowedSenders = make(
+			map[route.Vertex]struct{}, len(p.AllowedSenders),
+		)
+		for sender := range p.AllowedSenders {
+			policy.AllowedSenders[sender] = struct{}{}
+		}
+	}
+
+	return policy
+}
+
+// checkAmount checks that the given total amount of a spontaneous payment is
+// within the limits of the policy.
+func (p *KeysendPolicy) checkAmount(amt lnwire.MilliSatoshi) error {
+	switch {
+	case amt < p.MinAmt:
+		return fmt.Errorf("%w: %v < %v", ErrKeysendAmountTooLow, amt,
+			p.MinAmt)
+
+	case p.MaxAmt != 0 && amt > p.MaxAmt:
+		return fmt.Errorf("%w: %v > %v", ErrKeysendAmountTooHigh, amt,
+			p.MaxAmt)
+	}
+
+	return nil
+}
+
+// checkSender checks that the peer that forwarded a spontaneous payment over
+// the given channel is allowed to send us spontaneous payments.
+func (p *KeysendPolicy) checkSender(chanID lnwire.ShortChannelID,
+	channelPeer func(lnwire.ShortChannelID) (route.Vertex, error)) error {
+
+	if len(p.AllowedSenders) == 0 {
+		return nil
+	}
+
+	if channelPeer == nil {
+		return fmt.Errorf("%w: unable to identify peer",
+			ErrKeysendSenderNotAllowed)
+	}
+
+	peer, err := channelPeer(chanID)
+	if err != nil {
+		return fmt.Errorf("%w: unable to identify peer of channel "+
+			"%v: %v", ErrKeysendSenderNotAllowed, chanID, err)
+	}
+
+	if _, ok := p.AllowedSenders[peer]; !ok {
+		return fmt.Errorf("%w: %v", ErrKeysendSenderNotAllowed, peer)
+	}
+
+	return nil
+}
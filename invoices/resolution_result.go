@@ -120,6 +120,10 @@ const (
 	// ResultAmpReconstruction is returned when the derived child
 	// hash/preimage pairs were invalid for at least one HTLC in the set.
 	ResultAmpReconstruction
+
+	// ResultCanceledByModifier is returned when the htlc modifier canceled
+	// an htlc that was held for it.
+	ResultCanceledByModifier
 )
 
 // String returns a string representation of the result.
@@ -189,6 +193,9 @@ func (f FailResolutionResult) FailureString() string {
 	case ResultAmpReconstruction:
 		return "amp reconstruction failed"
 
+	case ResultCanceledByModifier:
+		return "canceled by htlc modifier"
+
 	default:
 		return "unknown failure resolution result"
 	}
@@ -124,6 +124,10 @@ var _ InvoiceDB = (*SQLStore)(nil)
 type SQLInvoiceQueriesTxOptions struct {
 	// readOnly governs if a read only transaction is needed or not.
 	readOnly bool
+
+	// allowReplica governs if a read only transaction may be served by a
+	// read replica of the database.
+	allowReplica bool
 }
 
 // ReadOnly returns true if the transaction should be read only.
@@ -133,6 +137,14 @@ func (a *SQLInvoiceQueriesTxOptions) ReadOnly() bool {
 	return a.readOnly
 }
 
+// AllowReplica returns true if the transaction may be served by a read
+// replica.
+//
+// NOTE: This implements the ReplicaTxOptions.
+func (a *SQLInvoiceQueriesTxOptions) AllowReplica() bool {
+	return a.allowReplica
+}
+
 // NewSQLInvoiceQueryReadTx creates a new read transaction option set.
 func NewSQLInvoiceQueryReadTx() SQLInvoiceQueriesTxOptions {
 	return SQLInvoiceQueriesTxOptions{
@@ -140,6 +152,16 @@ func NewSQLInvoiceQueryReadTx() SQLInvoiceQueriesTxOptions {
 	}
 }
 
+// NewSQLInvoiceQueryReplicaReadTx creates a new read transaction option set
+// for reads that tolerate slightly stale data and may therefore be served by
+// a read replica of the database.
+func NewSQLInvoiceQueryReplicaReadTx() SQLInvoiceQueriesTxOptions {
+	return SQLInvoiceQueriesTxOptions{
+		readOnly:     true,
+		allowReplica: true,
+	}
+}
+
 // BatchedSQLInvoiceQueries is a version of the SQLInvoiceQueries that's capable
 // of batched database operations.
 type BatchedSQLInvoiceQueries interface {
@@ -494,6 +516,12 @@ func fetchAmpState(ctx context.Context, db SQLInvoiceQueries, invoiceID int64,
 			)
 		}
 
+		if row.WireAmountMsat.Valid {
+			htlc.WireAmt = lnwire.MilliSatoshi(
+				row.WireAmountMsat.Int64,
+			)
+		}
+
 		if row.ResolveTime.Valid {
 			htlc.ResolveTime = row.ResolveTime.Time.Local()
 		}
@@ -849,7 +877,9 @@ func (i *SQLStore) QueryInvoices(ctx context.Context,
 			"be non-zero")
 	}
 
-	readTxOpt := NewSQLInvoiceQueryReadTx()
+	// Listing invoices is one of the heaviest read paths, so we let it be
+	// served by a read replica if one is configured.
+	readTxOpt := NewSQLInvoiceQueryReplicaReadTx()
 	err := i.db.ExecTx(ctx, &readTxOpt, func(db SQLInvoiceQueries) error {
 		limit := queryPaginationLimit
 
@@ -989,6 +1019,10 @@ func (s *sqlInvoiceUpdater) AddHtlc(circuitKey models.CircuitKey,
 			ExpiryHeight: int32(newHtlc.Expiry),
 			State:        int16(newHtlc.State),
 			InvoiceID:    int64(s.invoice.AddIndex),
+			WireAmountMsat: sql.NullInt64{
+				Int64: int64(newHtlc.WireAmt),
+				Valid: newHtlc.WireAmt != 0,
+			},
 		},
 	)
 	if err != nil {
@@ -1646,6 +1680,10 @@ func unmarshalInvoiceHTLC(row sqlc.InvoiceHtlc) (CircuitKey,
 		htlc.MppTotalAmt = lnwire.MilliSatoshi(row.TotalMppMsat.Int64)
 	}
 
+	if row.WireAmountMsat.Valid {
+		htlc.WireAmt = lnwire.MilliSatoshi(row.WireAmountMsat.Int64)
+	}
+
 	if row.ResolveTime.Valid {
 		htlc.ResolveTime = row.ResolveTime.Time.Local()
 	}
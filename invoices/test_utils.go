@@ -215,7 +215,7 @@ func (h *hodlExpiryTest) assertCanceled(t *testing.T, expected lntypes.Hash) {
 // setupHodlExpiry creates a hodl invoice in our expiry watcher and runs an
 // arbitrary update function which advances the invoices's state.
 func setupHodlExpiry(t *testing.T, creationDate time.Time,
-	expiry time.Duration, heightDelta uint32,
+	expiry time.Duration, heightDelta uint32, gracePeriod time.Duration,
 	startState ContractState,
 	startHtlcs []*InvoiceHTLC) *hodlExpiryTest {
 
@@ -227,8 +227,8 @@ func setupHodlExpiry(t *testing.T, creationDate time.Time,
 	test := &hodlExpiryTest{
 		state: startState,
 		watcher: NewInvoiceExpiryWatcher(
-			mockClock, heightDelta, uint32(testCurrentHeight), nil,
-			mockNotifier,
+			mockClock, heightDelta, gracePeriod,
+			uint32(testCurrentHeight), nil, mockNotifier,
 		),
 		cancelChan:   make(chan lntypes.Hash),
 		mockNotifier: mockNotifier,
@@ -157,7 +157,7 @@ func newTestContext(t *testing.T,
 	notifier := newMockNotifier()
 
 	expiryWatcher := invpkg.NewInvoiceExpiryWatcher(
-		clock, 0, uint32(testCurrentHeight), nil, notifier,
+		clock, 0, 0, uint32(testCurrentHeight), nil, notifier,
 	)
 
 	cfg := defaultRegistryConfig()
@@ -16,6 +16,7 @@ type invoiceUpdateCtx struct {
 	hash                 lntypes.Hash
 	circuitKey           CircuitKey
 	amtPaid              lnwire.MilliSatoshi
+	wireAmt              lnwire.MilliSatoshi
 	expiry               uint32
 	currentHeight        int32
 	finalCltvRejectDelta int32
@@ -161,6 +162,7 @@ func updateMpp(ctx *invoiceUpdateCtx, inv *Invoice) (*InvoiceUpdateDesc,
 	// Start building the accept descriptor.
 	acceptDesc := &HtlcAcceptDesc{
 		Amt:           ctx.amtPaid,
+		WireAmt:       ctx.wireAmt,
 		Expiry:        ctx.expiry,
 		AcceptHeight:  ctx.currentHeight,
 		MppTotalAmt:   ctx.mpp.TotalMsat(),
@@ -416,6 +418,7 @@ func updateLegacy(ctx *invoiceUpdateCtx,
 	newHtlcs := map[CircuitKey]*HtlcAcceptDesc{
 		ctx.circuitKey: {
 			Amt:           ctx.amtPaid,
+			WireAmt:       ctx.wireAmt,
 			Expiry:        ctx.expiry,
 			AcceptHeight:  ctx.currentHeight,
 			CustomRecords: ctx.customRecords,
@@ -223,6 +223,7 @@ func addHTLCs(invoice *Invoice, hash *lntypes.Hash, updateTime time.Time,
 
 		htlc := &InvoiceHTLC{
 			Amt:           htlcUpdate.Amt,
+			WireAmt:       htlcUpdate.WireAmt,
 			MppTotalAmt:   htlcUpdate.MppTotalAmt,
 			Expiry:        htlcUpdate.Expiry,
 			AcceptHeight:  uint32(htlcUpdate.AcceptHeight),
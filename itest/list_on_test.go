@@ -269,6 +269,10 @@ var allTestCases = []*lntest.TestCase{
 		Name:     "anchors reserved value",
 		TestFunc: testAnchorReservedValue,
 	},
+	{
+		Name:     "sendcoins respect anchor reserve",
+		TestFunc: testSendCoinsRespectAnchorReserve,
+	},
 	{
 		Name:     "3rd party anchor spend",
 		TestFunc: testAnchorThirdPartySpend,
@@ -606,6 +610,10 @@ var allTestCases = []*lntest.TestCase{
 		Name:     "open channel locked balance",
 		TestFunc: testOpenChannelLockedBalance,
 	},
+	{
+		Name:     "estimate channel open fee",
+		TestFunc: testEstimateChannelOpenFee,
+	},
 	{
 		Name:     "nativesql no migration",
 		TestFunc: testNativeSQLNoMigration,
@@ -622,6 +630,10 @@ var allTestCases = []*lntest.TestCase{
 		Name:     "sweep htlcs",
 		TestFunc: testSweepHTLCs,
 	},
+	{
+		Name:     "sweep htlcs aggregated",
+		TestFunc: testSweepHTLCsAggregated,
+	},
 	{
 		Name:     "sweep commit output and anchor",
 		TestFunc: testSweepCommitOutputAndAnchor,
@@ -630,4 +642,20 @@ var allTestCases = []*lntest.TestCase{
 		Name:     "coop close with external delivery",
 		TestFunc: testCoopCloseWithExternalDelivery,
 	},
+	{
+		Name:     "mixed chain backends",
+		TestFunc: testMixedChainBackends,
+	},
+	{
+		Name:     "restart node with extra args",
+		TestFunc: testRestartNodeWithExtraArgs,
+	},
+	{
+		Name:     "channel acceptor policy",
+		TestFunc: testChannelAcceptorPolicy,
+	},
+	{
+		Name:     "keysend policy",
+		TestFunc: testKeysendPolicy,
+	},
 }
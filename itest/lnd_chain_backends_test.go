@@ -0,0 +1,38 @@
+//go:build chainbackends
+// +build chainbackends
+
+package itest
+
+import (
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lntest"
+)
+
+// testMixedChainBackends checks that nodes using different chain backends can
+// interact with each other within the same test. Carol runs on an additional
+// btcd backend while Dave uses neutrino, regardless of the chain backend the
+// itest was built with.
+func testMixedChainBackends(ht *lntest.HarnessTest) {
+	btcdBackend := ht.NewChainBackend(lntest.ChainBackendBtcd)
+	neutrinoBackend := ht.NewChainBackend(lntest.ChainBackendNeutrino)
+
+	carol := ht.NewNodeWithChainBackend("Carol", nil, btcdBackend)
+	dave := ht.NewNodeWithChainBackend("Dave", nil, neutrinoBackend)
+
+	// Both nodes should be synced to the same chain tip as the miner.
+	ht.WaitForBlockchainSync(carol)
+	ht.WaitForBlockchainSync(dave)
+
+	// Fund Carol so the channel can be opened.
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, carol)
+
+	// Open a channel from Carol to Dave and close it again, which requires
+	// both backends to track the funding and closing transactions.
+	ht.EnsureConnected(carol, dave)
+	chanPoint := ht.OpenChannel(
+		carol, dave, lntest.OpenChannelParams{
+			Amt: btcutil.SatoshiPerBitcoin / 2,
+		},
+	)
+	ht.CloseChannel(carol, chanPoint)
+}
@@ -7,13 +7,11 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/lightningnetwork/lnd"
-	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"github.com/lightningnetwork/lnd/lntest"
 	"github.com/lightningnetwork/lnd/lntest/node"
 	"github.com/lightningnetwork/lnd/lnwallet"
-	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/stretchr/testify/require"
 )
@@ -104,7 +102,11 @@ func testChannelFundMax(ht *lntest.HarnessTest) {
 			// subtracted from Alice's balance.
 			// (since wallet balance < max-chan-size)
 			expectedBalanceAlice: btcutil.Amount(37_000) -
-				fundingFee(1, false),
+				lntest.FundingFee(
+					lntest.P2WKHInputs(1),
+					lnrpc.CommitmentType_STATIC_REMOTE_KEY,
+					false,
+				),
 		},
 		{
 			name: "wallet amount > max chan size " +
@@ -154,7 +156,10 @@ func testChannelFundMax(ht *lntest.HarnessTest) {
 			initialWalletBalance: 100_000,
 			commitmentType:       lnrpc.CommitmentType_ANCHORS,
 			expectedBalanceAlice: btcutil.Amount(100_000) -
-				fundingFee(1, true) - reserveAmount,
+				lntest.FundingFee(
+					lntest.P2WKHInputs(1),
+					lnrpc.CommitmentType_ANCHORS, true,
+				) - reserveAmount,
 		},
 		// Funding a private anchor channel should omit the achor
 		// reserve and produce no change output.
@@ -165,7 +170,10 @@ func testChannelFundMax(ht *lntest.HarnessTest) {
 			initialWalletBalance: 100_000,
 			commitmentType:       lnrpc.CommitmentType_ANCHORS,
 			expectedBalanceAlice: btcutil.Amount(100_000) -
-				fundingFee(1, false),
+				lntest.FundingFee(
+					lntest.P2WKHInputs(1),
+					lnrpc.CommitmentType_ANCHORS, false,
+				),
 		},
 	}
 
@@ -287,33 +295,6 @@ func checkChannelBalance(ht *lntest.HarnessTest, node *node.HarnessNode,
 	ht.AssertChannelBalanceResp(node, expectedResponse)
 }
 
-// fundingFee returns the fee estimate used for a tx with the given number of
-// inputs and the optional change output. This matches the estimate done by the
-// wallet.
-func fundingFee(numInput int, change bool) btcutil.Amount {
-	var weightEstimate input.TxWeightEstimator
-
-	// The standard fee rate used for a funding transaction.
-	var feeRate = chainfee.SatPerKWeight(12500)
-
-	// All inputs.
-	for i := 0; i < numInput; i++ {
-		weightEstimate.AddP2WKHInput()
-	}
-
-	// The multisig funding output.
-	weightEstimate.AddP2WSHOutput()
-
-	// Optionally count a change output.
-	if change {
-		weightEstimate.AddP2TROutput()
-	}
-
-	totalWeight := weightEstimate.Weight()
-
-	return feeRate.FeeForWeight(totalWeight)
-}
-
 // sweepNodeWalletAndAssert sweeps funds from a node wallet.
 func sweepNodeWalletAndAssert(ht *lntest.HarnessTest, node *node.HarnessNode) {
 	// New miner address we will sweep all funds to.
@@ -132,7 +132,12 @@ func testChannelUtxoSelection(ht *lntest.HarnessTest) {
 			localAmt:        btcutil.Amount(250_000),
 			expectedBalance: btcutil.Amount(250_000),
 			remainingWalletBalance: btcutil.Amount(350_000) -
-				btcutil.Amount(250_000) - fundingFee(2, true),
+				btcutil.Amount(250_000) -
+				lntest.FundingFee(
+					lntest.P2WKHInputs(2),
+					lnrpc.CommitmentType_STATIC_REMOTE_KEY,
+					true,
+				),
 		},
 		// We are spending the entirety of two selected coins out of
 		// three available in the wallet and expect no change output and
@@ -147,7 +152,12 @@ func testChannelUtxoSelection(ht *lntest.HarnessTest) {
 				200_000, 50_000,
 			},
 			expectedBalance: btcutil.Amount(200_000) +
-				btcutil.Amount(50_000) - fundingFee(2, false),
+				btcutil.Amount(50_000) -
+				lntest.FundingFee(
+					lntest.P2WKHInputs(2),
+					lnrpc.CommitmentType_STATIC_REMOTE_KEY,
+					false,
+				),
 			remainingWalletBalance: btcutil.Amount(100_000),
 		},
 		// Select all coins in wallet and use the maximum available
@@ -161,9 +171,17 @@ func testChannelUtxoSelection(ht *lntest.HarnessTest) {
 			selectedCoins:  []btcutil.Amount{200_000, 100_000},
 			commitmentType: lnrpc.CommitmentType_ANCHORS,
 			localAmt: btcutil.Amount(300_000) -
-				reserveAmount - fundingFee(2, true),
+				reserveAmount -
+				lntest.FundingFee(
+					lntest.P2WKHInputs(2),
+					lnrpc.CommitmentType_ANCHORS, true,
+				),
 			expectedBalance: btcutil.Amount(300_000) -
-				reserveAmount - fundingFee(2, true),
+				reserveAmount -
+				lntest.FundingFee(
+					lntest.P2WKHInputs(2),
+					lnrpc.CommitmentType_ANCHORS, true,
+				),
 			remainingWalletBalance: reserveAmount,
 		},
 		// Select all coins in wallet towards local amount except for an
@@ -179,9 +197,15 @@ func testChannelUtxoSelection(ht *lntest.HarnessTest) {
 			},
 			commitmentType: lnrpc.CommitmentType_ANCHORS,
 			localAmt: btcutil.Amount(300_000) -
-				fundingFee(2, true),
+				lntest.FundingFee(
+					lntest.P2WKHInputs(2),
+					lnrpc.CommitmentType_ANCHORS, true,
+				),
 			expectedBalance: btcutil.Amount(300_000) -
-				fundingFee(2, true),
+				lntest.FundingFee(
+					lntest.P2WKHInputs(2),
+					lnrpc.CommitmentType_ANCHORS, true,
+				),
 			remainingWalletBalance: reserveAmount,
 		},
 		// Select all coins in wallet and use more than the maximum
@@ -195,7 +219,11 @@ func testChannelUtxoSelection(ht *lntest.HarnessTest) {
 			selectedCoins:  []btcutil.Amount{200_000, 100_000},
 			commitmentType: lnrpc.CommitmentType_ANCHORS,
 			localAmt: btcutil.Amount(300_000) -
-				reserveAmount + 1 - fundingFee(2, true),
+				reserveAmount + 1 -
+				lntest.FundingFee(
+					lntest.P2WKHInputs(2),
+					lnrpc.CommitmentType_ANCHORS, true,
+				),
 			chanOpenShouldFail: true,
 			expectedErrStr: "reserved wallet balance " +
 				"invalidated: transaction would leave " +
@@ -212,7 +240,10 @@ func testChannelUtxoSelection(ht *lntest.HarnessTest) {
 			selectedCoins:  []btcutil.Amount{200_000},
 			commitmentType: lnrpc.CommitmentType_ANCHORS,
 			expectedBalance: btcutil.Amount(200_000) -
-				fundingFee(1, false),
+				lntest.FundingFee(
+					lntest.P2WKHInputs(1),
+					lnrpc.CommitmentType_ANCHORS, false,
+				),
 			remainingWalletBalance: reserveAmount,
 		},
 		// We fund an anchor channel with a single coin and expect the
@@ -226,7 +257,11 @@ func testChannelUtxoSelection(ht *lntest.HarnessTest) {
 			selectedCoins:  []btcutil.Amount{200_000},
 			commitmentType: lnrpc.CommitmentType_ANCHORS,
 			expectedBalance: btcutil.Amount(200_000) -
-				reserveAmount - fundingFee(1, true),
+				reserveAmount -
+				lntest.FundingFee(
+					lntest.P2WKHInputs(1),
+					lnrpc.CommitmentType_ANCHORS, true,
+				),
 			remainingWalletBalance: reserveAmount,
 		},
 		// Confirm that already spent outputs can't be reused to fund
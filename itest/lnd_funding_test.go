@@ -1420,7 +1420,10 @@ func testChannelFundingWithUnstableUtxos(ht *lntest.HarnessTest) {
 	// channels.
 	// NOTE: The TotalBalance includes the unconfirmed balance as well.
 	chanSize = btcutil.Amount(carolBalance.TotalBalance) -
-		fundingFee(2, false)
+		lntest.FundingFee(
+			lntest.P2WKHInputs(2),
+			lnrpc.CommitmentType_STATIC_REMOTE_KEY, false,
+		)
 
 	// We are trying to open a channel with the maximum amount and expect it
 	// to fail because one of the utxos cannot be used because it is
@@ -937,6 +937,35 @@ func testSweepAllCoins(ht *lntest.HarnessTest) {
 		TargetConf: 6,
 	})
 
+	// Setting a reserve amount without sweeping all coins should fail.
+	const reserveAmt = 100_000
+	ainz.RPC.SendCoinsAssertErr(&lnrpc.SendCoinsRequest{
+		Addr:          ht.Miner.NewMinerAddress().String(),
+		Amount:        10000,
+		ReserveAmount: reserveAmt,
+		TargetConf:    6,
+	})
+
+	// We'll now fund Ainz again and sweep everything but the reserve
+	// amount.
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, ainz)
+	sweepResp := ainz.RPC.SendCoins(&lnrpc.SendCoinsRequest{
+		Addr:          ht.Miner.NewMinerAddress().String(),
+		SendAll:       true,
+		ReserveAmount: reserveAmt,
+		TargetConf:    6,
+	})
+	block = ht.MineBlocksAndAssertNumTxes(1, 1)[0]
+	sweepTx = block.Transactions[1]
+	require.Equal(ht, sweepTx.TxHash().String(), sweepResp.Txid)
+
+	// The sweep should have one output to the miner and one output
+	// keeping the reserve amount in the wallet.
+	require.Len(ht, sweepTx.TxOut, 2, "expected 2 outputs")
+	resp = ainz.RPC.WalletBalance()
+	require.EqualValues(ht, reserveAmt, resp.ConfirmedBalance,
+		"wrong confirmed balance")
+
 	// With all the edge cases tested, we'll now test the happy paths of
 	// change output types.
 	// We'll be using a "main" address where we send the funds to and from
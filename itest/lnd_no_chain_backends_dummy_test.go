@@ -0,0 +1,10 @@
+//go:build !chainbackends
+// +build !chainbackends
+
+package itest
+
+import "github.com/lightningnetwork/lnd/lntest"
+
+// testMixedChainBackends is an empty itest when the itest is not compiled
+// with the chainbackends tag.
+func testMixedChainBackends(ht *lntest.HarnessTest) {}
@@ -209,6 +209,73 @@ func testChainKitSendOutputsAnchorReserve(ht *lntest.HarnessTest) {
 	ht.CloseChannel(charlie, outpoint)
 }
 
+// testSendCoinsRespectAnchorReserve checks that SendCoins rejects a send that
+// would take the wallet balance below the anchor reserve, unless it is asked
+// to respect the reserve, in which case the amount sent is reduced instead.
+func testSendCoinsRespectAnchorReserve(ht *lntest.HarnessTest) {
+	// Start two nodes supporting anchor channels.
+	args := lntest.NodeArgsForCommitType(lnrpc.CommitmentType_ANCHORS)
+
+	// NOTE: we cannot reuse the standby node here as the test requires the
+	// node to start with no UTXOs.
+	charlie := ht.NewNode("Charlie", args)
+	bob := ht.Bob
+	ht.RestartNodeWithExtraArgs(bob, args)
+
+	// We'll start the test by sending Charlie some coins.
+	fundingAmount := btcutil.Amount(100_000)
+	ht.FundCoins(fundingAmount, charlie)
+
+	// Before opening the channel we ensure that the nodes are connected.
+	ht.EnsureConnected(charlie, bob)
+
+	// We'll get the anchor reserve that is required for a single channel.
+	reserve := charlie.RPC.RequiredReserve(
+		&walletrpc.RequiredReserveRequest{
+			AdditionalPublicChannels: 1,
+		},
+	)
+
+	// Charlie opens an anchor channel and keeps twice the amount of the
+	// anchor reserve in her wallet.
+	chanAmt := fundingAmount - 2*btcutil.Amount(reserve.RequiredReserve)
+	outpoint := ht.OpenChannel(charlie, bob, lntest.OpenChannelParams{
+		Amt:            chanAmt,
+		CommitmentType: lnrpc.CommitmentType_ANCHORS,
+		SatPerVByte:    1,
+	})
+
+	// Charlie now tries to send an amount to the miner that would
+	// undershoot her reserve requirement.
+	minerAddr := ht.Miner.NewMinerAddress()
+	balance := charlie.RPC.WalletBalance()
+	amt := balance.ConfirmedBalance - reserve.RequiredReserve/2
+
+	req := &lnrpc.SendCoinsRequest{
+		Addr:        minerAddr.String(),
+		Amount:      amt,
+		SatPerVbyte: 1,
+	}
+	charlie.RPC.SendCoinsAssertErr(req)
+
+	// If she asks lnd to respect the reserve, the amount is reduced and
+	// the send succeeds.
+	req.RespectAnchorReserve = true
+	resp := charlie.RPC.SendCoins(req)
+	require.Less(ht, resp.AmountSent, amt)
+
+	ht.MineBlocksAndAssertNumTxes(1, 1)
+
+	// The reserve must still be in Charlie's wallet.
+	balance = charlie.RPC.WalletBalance()
+	require.GreaterOrEqual(
+		ht, balance.ConfirmedBalance, reserve.RequiredReserve,
+	)
+
+	// Clean up our test setup.
+	ht.CloseChannel(charlie, outpoint)
+}
+
 // testAnchorReservedValue tests that we won't allow sending transactions when
 // that would take the value we reserve for anchor fee bumping out of our
 // wallet.
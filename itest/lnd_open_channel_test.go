@@ -1,6 +1,7 @@
 package itest
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -885,3 +886,101 @@ func testOpenChannelLockedBalance(ht *lntest.HarnessTest) {
 	// Finally, we check to make sure the balance is unlocked again.
 	ht.AssertWalletLockedBalance(alice, 0)
 }
+
+// testEstimateChannelOpenFee tests that the EstimateChannelOpenFee RPC returns
+// the same coin selection as an actual channel opening, without locking any
+// UTXOs.
+func testEstimateChannelOpenFee(ht *lntest.HarnessTest) {
+	const (
+		chanAmt     = btcutil.Amount(500_000)
+		satPerVbyte = 10
+	)
+
+	bob := ht.Bob
+
+	// Create a new node so we know exactly which UTXOs are available.
+	alice := ht.NewNode("alice", nil)
+	defer ht.Shutdown(alice)
+
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, alice)
+	ht.EnsureConnected(alice, bob)
+
+	req := &lnrpc.OpenChannelRequest{
+		NodePubkey:         bob.PubKey[:],
+		LocalFundingAmount: int64(chanAmt),
+		SatPerVbyte:        satPerVbyte,
+	}
+	estimate := alice.RPC.EstimateChannelOpenFee(req)
+
+	// The single UTXO of Alice is used and the rest of it, minus the fee,
+	// is sent back as change.
+	require.Len(ht, estimate.Inputs, 1)
+	require.EqualValues(ht, chanAmt, estimate.LocalFundingAmount)
+	require.EqualValues(ht, chanAmt, estimate.Capacity)
+	require.Positive(ht, estimate.FeeSat)
+	require.EqualValues(
+		ht, btcutil.SatoshiPerBitcoin-int64(chanAmt)-estimate.FeeSat,
+		estimate.ChangeAmountSat,
+	)
+	require.InDelta(ht, satPerVbyte, estimate.SatPerVbyte, 1)
+
+	// The estimation must not lock any coins.
+	ht.AssertWalletLockedBalance(alice, 0)
+
+	// Now we actually open the channel and make sure the funding
+	// transaction pays the estimated fee.
+	chanPoint := ht.OpenChannel(alice, bob, lntest.OpenChannelParams{
+		Amt:         chanAmt,
+		SatPerVByte: satPerVbyte,
+	})
+
+	fundingTxid := ht.GetChanPointFundingTxid(chanPoint)
+	fundingTx := ht.Miner.GetRawTransaction(fundingTxid).MsgTx()
+	require.EqualValues(ht, estimate.FeeSat, ht.CalculateTxFee(fundingTx))
+
+	ht.CloseChannel(alice, chanPoint)
+}
+
+// testChannelAcceptorPolicy tests that the channel acceptor policy set over
+// RPC is enforced for inbound channel requests.
+func testChannelAcceptorPolicy(ht *lntest.HarnessTest) {
+	const minCapacity = btcutil.Amount(300_000)
+
+	// The policy is only enforced if it is enabled, so we use a new node
+	// Bob instead of the standby one.
+	alice := ht.Alice
+	bob := ht.NewNode("Bob", []string{"--acceptorpolicy"})
+	ht.EnsureConnected(alice, bob)
+
+	// Bob only accepts channels of at least the minimum capacity and only
+	// from peers connected over localhost.
+	policy := &lnrpc.ChannelAcceptorPolicy{
+		MinCapacitySat:  uint64(minCapacity),
+		AllowedIpRanges: []string{"127.0.0.0/8"},
+	}
+	bob.RPC.SetChannelAcceptorPolicy(policy)
+
+	resp := bob.RPC.GetChannelAcceptorPolicy()
+	require.EqualValues(ht, minCapacity, resp.MinCapacitySat)
+	require.Equal(ht, policy.AllowedIpRanges, resp.AllowedIpRanges)
+
+	// A channel below the minimum capacity is rejected.
+	ht.OpenChannelAssertErr(alice, bob, lntest.OpenChannelParams{
+		Amt: minCapacity - 1,
+	}, errors.New("below the minimum"))
+
+	// A channel of the minimum capacity is accepted.
+	chanPoint := ht.OpenChannel(alice, bob, lntest.OpenChannelParams{
+		Amt: minCapacity,
+	})
+	ht.CloseChannel(alice, chanPoint)
+
+	// Restricting the IP range to one Alice isn't connected from makes Bob
+	// reject channels from Alice.
+	policy.AllowedIpRanges = []string{"10.0.0.0/8"}
+	bob.RPC.SetChannelAcceptorPolicy(policy)
+
+	ht.OpenChannelAssertErr(alice, bob, lntest.OpenChannelParams{
+		Amt: minCapacity,
+	}, errors.New("not in an allowed IP range"))
+}
@@ -1698,7 +1698,10 @@ func testPsbtChanFundingWithUnstableUtxos(ht *lntest.HarnessTest) {
 	// NOTE: We need to always account for a change here, because their is
 	// an inaccurarcy in the backend code.
 	chanSize = btcutil.Amount(carolBalance.TotalBalance) -
-		fundingFee(2, true)
+		lntest.FundingFee(
+			lntest.P2WKHInputs(2),
+			lnrpc.CommitmentType_STATIC_REMOTE_KEY, true,
+		)
 
 	// Now open a channel of this amount via a psbt workflow.
 	// At this point, we can begin our PSBT channel funding workflow. We'll
@@ -1822,7 +1825,10 @@ func testPsbtChanFundingWithUnstableUtxos(ht *lntest.HarnessTest) {
 	// one output transaction, it always account for a channge in that case
 	// as well.
 	chanSize = btcutil.Amount(carolBalance.TotalBalance) -
-		fundingFee(2, true)
+		lntest.FundingFee(
+			lntest.P2WKHInputs(2),
+			lnrpc.CommitmentType_STATIC_REMOTE_KEY, true,
+		)
 
 	// Now open a channel of this amount via a psbt workflow.
 	// At this point, we can begin our PSBT channel funding workflow. We'll
@@ -0,0 +1,29 @@
+package itest
+
+import (
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lntest"
+	"github.com/stretchr/testify/require"
+)
+
+// testRestartNodeWithExtraArgs checks that a node restarted with a modified
+// set of command line flags picks up the new flags while keeping its identity
+// and wallet.
+func testRestartNodeWithExtraArgs(ht *lntest.HarnessTest) {
+	carol := ht.NewNode("Carol", []string{"--alias=carol-before"})
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, carol)
+
+	infoBefore := carol.RPC.GetInfo()
+	require.Equal(ht, "carol-before", infoBefore.Alias)
+
+	// Restart Carol with a different alias.
+	ht.RestartNodeWithExtraArgs(carol, []string{"--alias=carol-after"})
+
+	// The new flag must be in effect while the node identity and the
+	// wallet balance are unchanged.
+	infoAfter := carol.RPC.GetInfo()
+	require.Equal(ht, "carol-after", infoAfter.Alias)
+	require.Equal(ht, infoBefore.IdentityPubkey, infoAfter.IdentityPubkey)
+
+	ht.WaitForBalanceConfirmed(carol, btcutil.SatoshiPerBitcoin)
+}
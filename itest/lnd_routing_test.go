@@ -1139,6 +1139,16 @@ func testMissionControlImport(ht *lntest.HarnessTest, hn *node.HarnessNode,
 	require.Equal(ht, importHistory.FailTime, resp2.History.FailTime)
 	require.Equal(ht, importHistory.FailAmtMsat, resp2.History.FailAmtMsat)
 
+	// The imported pair is exported as it was imported.
+	export := hn.RPC.XExportMissionControl()
+	require.Len(ht, export.Pairs, 1)
+	require.Equal(ht, fromNode, export.Pairs[0].NodeFrom)
+	require.Equal(ht, toNode, export.Pairs[0].NodeTo)
+
+	exported := export.Pairs[0].History
+	require.Equal(ht, importHistory.FailTime, exported.FailTime)
+	require.Equal(ht, importHistory.FailAmtMsat, exported.FailAmtMsat)
+
 	// Finally, check that we will fail if inconsistent sat/msat values are
 	// set.
 	importHistory.FailAmtSat = amount * 2
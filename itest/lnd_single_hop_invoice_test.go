@@ -87,7 +87,10 @@ func testSingleHopInvoice(ht *lntest.HarnessTest) {
 		TimeoutSeconds: 60,
 		FeeLimitMsat:   noFeeLimitMsat,
 	}
-	ht.SendPaymentAssertSettled(alice, req)
+	payment := ht.SendPaymentAssertSettled(alice, req)
+
+	// The custom records sent to Bob are stored with the payment.
+	require.Equal(ht, req.DestCustomRecords, payment.DestCustomRecords)
 
 	// The keysend payment should also have succeeded, with the balances
 	// being update accordingly.
@@ -139,3 +142,63 @@ func testSingleHopInvoice(ht *lntest.HarnessTest) {
 
 	ht.CloseChannel(alice, cp)
 }
+
+// testKeysendPolicy tests that the keysend policy set over RPC is enforced for
+// spontaneous keysend payments.
+func testKeysendPolicy(ht *lntest.HarnessTest) {
+	const paymentAmt = 1000
+
+	// Payments rejected by the keysend policy fail with incorrect payment
+	// details.
+	//nolint:lll
+	failReason := lnrpc.PaymentFailureReason_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS
+
+	alice, bob := ht.Alice, ht.Bob
+	cp := ht.OpenChannel(
+		alice, bob, lntest.OpenChannelParams{Amt: 100000},
+	)
+	defer ht.CloseChannel(alice, cp)
+
+	// Reset the policy to the startup configuration so it doesn't affect
+	// other tests.
+	defer bob.RPC.UpdateKeysendPolicy(&lnrpc.UpdateKeysendPolicyRequest{
+		AcceptKeysend: true,
+	})
+
+	keySendRequest := func() *routerrpc.SendPaymentRequest {
+		preimage, err := lntypes.MakePreimage(ht.Random32Bytes())
+		require.NoError(ht, err)
+		hash := preimage.Hash()
+
+		return &routerrpc.SendPaymentRequest{
+			Dest:           bob.PubKey[:],
+			Amt:            paymentAmt,
+			FinalCltvDelta: 40,
+			PaymentHash:    hash[:],
+			DestCustomRecords: map[uint64][]byte{
+				record.KeySendType: preimage[:],
+			},
+			TimeoutSeconds: 60,
+			FeeLimitMsat:   noFeeLimitMsat,
+		}
+	}
+
+	// Bob only accepts keysend payments above the payment amount, so
+	// Alice's payment is rejected.
+	bob.RPC.UpdateKeysendPolicy(&lnrpc.UpdateKeysendPolicyRequest{
+		AcceptKeysend: true,
+		MinAmtMsat:    paymentAmt*1000 + 1,
+	})
+	ht.SendPaymentAssertFail(alice, keySendRequest(), failReason)
+
+	// Only allowing keysend payments from Alice lets the payment through.
+	bob.RPC.UpdateKeysendPolicy(&lnrpc.UpdateKeysendPolicyRequest{
+		AcceptKeysend:  true,
+		AllowedSenders: [][]byte{alice.PubKey[:]},
+	})
+	ht.SendPaymentAssertSettled(alice, keySendRequest())
+
+	// Disabling keysend at runtime rejects the payment again.
+	bob.RPC.UpdateKeysendPolicy(&lnrpc.UpdateKeysendPolicyRequest{})
+	ht.SendPaymentAssertFail(alice, keySendRequest(), failReason)
+}
@@ -1198,6 +1198,162 @@ func testSweepHTLCs(ht *lntest.HarnessTest) {
 	ht.MineBlocksAndAssertNumTxes(1, 2)
 }
 
+// testSweepHTLCsAggregated checks that when a node force closes multiple
+// channels, the second-level HTLC success claims from the different channels
+// are swept in a single transaction as long as their deadlines are within the
+// configured HTLC aggregation window.
+//
+// Setup:
+//  1. Bob is configured with an HTLC aggregation window.
+//  2. Alice and Carol each open a channel with Bob.
+//  3. Alice and Carol each pay a hold invoice of Bob, using different final
+//     CLTV deltas so the HTLCs have different deadlines.
+//  4. Alice and Carol go offline and Bob settles both invoices.
+//
+// Test:
+//  1. Bob force closes both channels.
+//  2. Bob sweeps both incoming HTLCs in the same sweeping tx.
+func testSweepHTLCsAggregated(ht *lntest.HarnessTest) {
+	// Invoice is 100k sats.
+	invoiceAmt := btcutil.Amount(100_000)
+
+	// aggregationWindow is the HTLC aggregation window used by Bob. It
+	// must be large enough to cover the difference between the CLTV
+	// deltas of the two invoices.
+	aggregationWindow := 10
+	cltvDelta1 := int64(finalCltvDelta)
+	cltvDelta2 := cltvDelta1 + 5
+
+	// Prepare the node params. We use a very large CSV so the to_local
+	// outputs are never swept, which allows us to focus on the HTLCs.
+	cfg := []string{
+		"--protocol.anchors",
+		fmt.Sprintf("--bitcoin.defaultremotedelay=%v",
+			finalCltvDelta*10),
+	}
+	bobCfg := append([]string{
+		fmt.Sprintf("--sweeper.htlcaggregationwindow=%v",
+			aggregationWindow),
+	}, cfg...)
+
+	alice := ht.NewNode("Alice", cfg)
+	bob := ht.NewNode("Bob", bobCfg)
+	carol := ht.NewNode("Carol", cfg)
+
+	// Alice and Carol need one utxo each to fund their channels. Bob
+	// needs wallet utxos to pay the fees of his anchor and HTLC sweeps.
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, alice)
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, carol)
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, bob)
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, bob)
+	ht.FundCoins(btcutil.SatoshiPerBitcoin, bob)
+
+	// Open the channels Alice -> Bob and Carol -> Bob.
+	ht.EnsureConnected(alice, bob)
+	ht.EnsureConnected(carol, bob)
+
+	params := lntest.OpenChannelParams{
+		Amt: invoiceAmt * 10,
+	}
+	abChanPoint := ht.OpenChannel(alice, bob, params)
+	cbChanPoint := ht.OpenChannel(carol, bob, params)
+
+	// Create the preimages of the two hold invoices.
+	var preimage1, preimage2 lntypes.Preimage
+	copy(preimage1[:], ht.Random32Bytes())
+	copy(preimage2[:], ht.Random32Bytes())
+	payHash1 := preimage1.Hash()
+	payHash2 := preimage2.Hash()
+
+	// Subscribe the invoices.
+	stream1 := bob.RPC.SubscribeSingleInvoice(payHash1[:])
+	stream2 := bob.RPC.SubscribeSingleInvoice(payHash2[:])
+
+	// Add two hold invoices at Bob's end, using different final CLTV
+	// deltas.
+	invoice1 := bob.RPC.AddHoldInvoice(&invoicesrpc.AddHoldInvoiceRequest{
+		Value:      int64(invoiceAmt),
+		CltvExpiry: uint64(cltvDelta1),
+		Hash:       payHash1[:],
+	})
+	invoice2 := bob.RPC.AddHoldInvoice(&invoicesrpc.AddHoldInvoiceRequest{
+		Value:      int64(invoiceAmt),
+		CltvExpiry: uint64(cltvDelta2),
+		Hash:       payHash2[:],
+	})
+
+	// Let Alice pay the first invoice and Carol pay the second one.
+	req1 := &routerrpc.SendPaymentRequest{
+		PaymentRequest: invoice1.PaymentRequest,
+		TimeoutSeconds: 60,
+		FeeLimitMsat:   noFeeLimitMsat,
+	}
+	req2 := &routerrpc.SendPaymentRequest{
+		PaymentRequest: invoice2.PaymentRequest,
+		TimeoutSeconds: 60,
+		FeeLimitMsat:   noFeeLimitMsat,
+	}
+	ht.SendPaymentAndAssertStatus(alice, req1, lnrpc.Payment_IN_FLIGHT)
+	ht.SendPaymentAndAssertStatus(carol, req2, lnrpc.Payment_IN_FLIGHT)
+
+	// Wait for Bob to mark the invoices as accepted.
+	ht.AssertInvoiceState(stream1, lnrpc.Invoice_ACCEPTED)
+	ht.AssertInvoiceState(stream2, lnrpc.Invoice_ACCEPTED)
+
+	// Let Alice and Carol go offline, so Bob can only claim the HTLCs
+	// onchain once he settles the invoices.
+	ht.Shutdown(alice)
+	ht.Shutdown(carol)
+
+	bob.RPC.SettleInvoice(preimage1[:])
+	bob.RPC.SettleInvoice(preimage2[:])
+
+	// Bob now force closes both channels.
+	_, closeTxid1 := ht.CloseChannelAssertPending(bob, abChanPoint, true)
+	_, closeTxid2 := ht.CloseChannelAssertPending(bob, cbChanPoint, true)
+	closeTx1 := ht.Miner.AssertTxInMempool(closeTxid1)
+	closeTx2 := ht.Miner.AssertTxInMempool(closeTxid2)
+
+	// Mine a block to confirm both force close txns.
+	ht.MineBlocksAndAssertNumTxes(1, 2)
+
+	// findHTLCOutpoint is a helper closure that returns the outpoint of
+	// the HTLC output on the given commitment tx.
+	findHTLCOutpoint := func(tx *wire.MsgTx) wire.OutPoint {
+		txid := tx.TxHash()
+		for i, txOut := range tx.TxOut {
+			if txOut.Value != int64(invoiceAmt) {
+				continue
+			}
+
+			return wire.OutPoint{Hash: txid, Index: uint32(i)}
+		}
+
+		require.Failf(ht, "htlc output not found", "tx=%v", txid)
+
+		return wire.OutPoint{}
+	}
+	htlcOutpoint1 := findHTLCOutpoint(closeTx1)
+	htlcOutpoint2 := findHTLCOutpoint(closeTx2)
+
+	// Once the CSV of the HTLC outputs has been reached, both incoming
+	// HTLCs are offered to Bob's sweeper with different deadlines.
+	ht.MineEmptyBlocks(1)
+
+	// Bob's sweeper should claim both HTLCs in the same sweeping tx.
+	sweepTx := ht.Miner.AssertOutpointInMempool(htlcOutpoint1)
+
+	spentOutpoints := make(map[wire.OutPoint]struct{}, len(sweepTx.TxIn))
+	for _, txIn := range sweepTx.TxIn {
+		spentOutpoints[txIn.PreviousOutPoint] = struct{}{}
+	}
+	require.Contains(ht, spentOutpoints, htlcOutpoint2,
+		"htlc claims not aggregated in tx=%v", sweepTx.TxHash())
+
+	// Mine a block to confirm the HTLC sweep.
+	ht.Miner.MineBlockWithTx(sweepTx)
+}
+
 // testSweepCommitOutputAndAnchor checks when a channel is force closed without
 // any time-sensitive HTLCs, the anchor output is swept without any CPFP
 // attempts. In addition, the to_local output should be swept using the
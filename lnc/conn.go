@@ -0,0 +1,338 @@
+package lnc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// maxFramePayload is the maximum number of bytes sent in a single
+	// mailbox message. Larger writes are split into multiple messages.
+	maxFramePayload = 32 * 1024
+
+	// frameData is the type of a frame that carries data.
+	frameData byte = 0
+
+	// frameClose is the type of a frame that signals the other party
+	// closed the connection.
+	frameClose byte = 1
+)
+
+// Addr is the address of a connection through a mailbox server.
+type Addr struct {
+	// MailboxServer is the address of the mailbox server.
+	MailboxServer string
+}
+
+// A compile time check to ensure Addr implements the net.Addr interface.
+var _ net.Addr = (*Addr)(nil)
+
+// Network returns the name of the network.
+//
+// NOTE: This is part of the net.Addr interface.
+func (a *Addr) Network() string {
+	return "lnc"
+}
+
+// String returns the address of the mailbox server.
+//
+// NOTE: This is part of the net.Addr interface.
+func (a *Addr) String() string {
+	return a.MailboxServer
+}
+
+// Conn is an end-to-end encrypted connection between the node and a client
+// that is relayed through a mailbox server.
+type Conn struct {
+	mb   MailboxConn
+	addr *Addr
+
+	sendMtx sync.Mutex
+	send    *cipherState
+	recv    *cipherState
+
+	// msgs delivers the decrypted data received by readLoop to Read.
+	msgs    chan []byte
+	readBuf []byte
+
+	// readDone is closed once readLoop exits, readErr is the reason.
+	readDone chan struct{}
+	readErr  error
+
+	deadlineMtx   sync.Mutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+
+	closeOnce sync.Once
+	quit      chan struct{}
+}
+
+// A compile time check to ensure Conn implements the net.Conn interface.
+var _ net.Conn = (*Conn)(nil)
+
+// newConn creates a new connection that encrypts and decrypts the messages
+// sent through the given mailbox connection with the given cipher states.
+func newConn(mb MailboxConn, addr string, send,
+	recv *cipherState) *Conn {
+
+	c := &Conn{
+		mb:       mb,
+		addr:     &Addr{MailboxServer: addr},
+		send:     send,
+		recv:     recv,
+		msgs:     make(chan []byte),
+		readDone: make(chan struct{}),
+		quit:     make(chan struct{}),
+	}
+	go c.readLoop()
+
+	return c
+}
+
+// Dial pairs with the node that serves the given pairing phrase through the
+// given mailbox server. The auth data sent by the node, usually the macaroon
+// to use, is returned along with the connection.
+func Dial(ctx context.Context, mailbox Mailbox,
+	phrase PairingPhrase) (*Conn, []byte, error) {
+
+	secrets, err := phrase.secrets()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mb, err := mailbox.Dial(
+		ctx, secrets.serverToClient, secrets.clientToServer,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Abort the handshake if the context is canceled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = mb.Close()
+		case <-done:
+		}
+	}()
+
+	keys, authData, err := clientHandshake(mb, secrets)
+	if err != nil {
+		_ = mb.Close()
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+
+		return nil, nil, err
+	}
+
+	conn := newConn(
+		mb, mailbox.Addr(), keys.clientToServer, keys.serverToClient,
+	)
+
+	return conn, authData, nil
+}
+
+// readLoop receives and decrypts the messages of the mailbox connection until
+// the connection is closed.
+func (c *Conn) readLoop() {
+	defer close(c.readDone)
+
+	for {
+		msg, err := c.mb.Recv()
+		if err != nil {
+			c.readErr = err
+			return
+		}
+
+		frame, err := c.recv.open(msg, nil)
+		if err != nil {
+			c.readErr = err
+			return
+		}
+
+		if len(frame) == 0 || frame[0] == frameClose {
+			c.readErr = io.EOF
+			return
+		}
+
+		select {
+		case c.msgs <- frame[1:]:
+		case <-c.quit:
+			c.readErr = net.ErrClosed
+			return
+		}
+	}
+}
+
+// Read reads data from the connection.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) Read(b []byte) (int, error) {
+	if len(c.readBuf) == 0 {
+		var timeout <-chan time.Time
+
+		c.deadlineMtx.Lock()
+		deadline := c.readDeadline
+		c.deadlineMtx.Unlock()
+
+		if !deadline.IsZero() {
+			timer := time.NewTimer(time.Until(deadline))
+			defer timer.Stop()
+
+			timeout = timer.C
+		}
+
+		select {
+		case msg := <-c.msgs:
+			c.readBuf = msg
+
+		case <-c.readDone:
+			return 0, c.readErr
+
+		case <-c.quit:
+			return 0, net.ErrClosed
+
+		case <-timeout:
+			return 0, os.ErrDeadlineExceeded
+		}
+	}
+
+	n := copy(b, c.readBuf)
+	c.readBuf = c.readBuf[n:]
+
+	return n, nil
+}
+
+// Write writes data to the connection. Data larger than the maximum payload of
+// a mailbox message is split into multiple messages.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) Write(b []byte) (int, error) {
+	select {
+	case <-c.quit:
+		return 0, net.ErrClosed
+	default:
+	}
+
+	c.deadlineMtx.Lock()
+	deadline := c.writeDeadline
+	c.deadlineMtx.Unlock()
+
+	var written int
+	for len(b) > 0 {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return written, os.ErrDeadlineExceeded
+		}
+
+		n := min(len(b), maxFramePayload)
+		if err := c.writeFrame(frameData, b[:n]); err != nil {
+			return written, err
+		}
+
+		written += n
+		b = b[n:]
+	}
+
+	return written, nil
+}
+
+// writeFrame encrypts a frame of the given type and sends it.
+func (c *Conn) writeFrame(frameType byte, payload []byte) error {
+	frame := make([]byte, 0, 1+len(payload))
+	frame = append(frame, frameType)
+	frame = append(frame, payload...)
+
+	c.sendMtx.Lock()
+	defer c.sendMtx.Unlock()
+
+	return c.mb.Send(c.send.seal(frame, nil))
+}
+
+// Close notifies the other party and closes the connection.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		// Letting the other party know is best effort only, it'll
+		// eventually notice through its keepalive otherwise. If it
+		// already closed the connection, we don't leave a stale
+		// message in the stream the next session would receive.
+		select {
+		case <-c.readDone:
+		default:
+			err := c.writeFrame(frameClose, nil)
+			if err != nil && !errors.Is(err, net.ErrClosed) {
+				log.Debugf("Unable to send close frame: %v",
+					err)
+			}
+		}
+
+		close(c.quit)
+		err = c.mb.Close()
+	})
+
+	return err
+}
+
+// LocalAddr returns the address of the mailbox server.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+// RemoteAddr returns the address of the mailbox server, since the address of
+// the other party is unknown.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// SetDeadline sets the read and write deadlines of the connection.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) SetDeadline(t time.Time) error {
+	c.deadlineMtx.Lock()
+	defer c.deadlineMtx.Unlock()
+
+	c.readDeadline = t
+	c.writeDeadline = t
+
+	return nil
+}
+
+// SetReadDeadline sets the read deadline of the connection. The deadline only
+// applies to calls to Read that are made after it was set.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.deadlineMtx.Lock()
+	defer c.deadlineMtx.Unlock()
+
+	c.readDeadline = t
+
+	return nil
+}
+
+// SetWriteDeadline sets the write deadline of the connection. It is only
+// checked before each mailbox message is sent.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.deadlineMtx.Lock()
+	defer c.deadlineMtx.Unlock()
+
+	c.writeDeadline = t
+
+	return nil
+}
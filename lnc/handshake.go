@@ -0,0 +1,329 @@
+package lnc
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// handshakeVersion is the version of the handshake and transport
+	// protocol.
+	handshakeVersion byte = 0
+
+	// actOneSize is the size of the first handshake message sent by the
+	// client. It consists of the version and the masked ephemeral key of
+	// the client.
+	actOneSize = 1 + btcec.PubKeyBytesLenCompressed
+
+	// actTwoMinSize is the minimum size of the second handshake message
+	// sent by the node. It consists of the version, the ephemeral key of
+	// the node and the encrypted auth data.
+	actTwoMinSize = 1 + btcec.PubKeyBytesLenCompressed +
+		chacha20poly1305.Overhead
+
+	// actThreeSize is the size of the third handshake message sent by the
+	// client. It is an empty payload that proves the client derived the
+	// same keys as the node.
+	actThreeSize = chacha20poly1305.Overhead
+
+	// maskPointTag is the tag that is hashed to derive the point the
+	// ephemeral key of the client is masked with.
+	maskPointTag = "lnc-pake-mask"
+)
+
+var (
+	// ErrHandshakeFailed is returned if the handshake fails, usually
+	// because the other party doesn't know the same pairing phrase.
+	ErrHandshakeFailed = errors.New("lnc handshake failed")
+
+	// maskPoint is the point the ephemeral key of the client is masked
+	// with. It is derived by hashing a fixed tag until the hash is a valid
+	// x coordinate, so that its discrete logarithm is unknown.
+	maskPoint = deriveMaskPoint()
+)
+
+// deriveMaskPoint derives the point the ephemeral key of the client is masked
+// with.
+func deriveMaskPoint() btcec.JacobianPoint {
+	var counter [4]byte
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		x := taggedHash(maskPointTag, counter[:])
+
+		pubKey, err := btcec.ParsePubKey(append([]byte{0x02}, x[:]...))
+		if err != nil {
+			continue
+		}
+
+		var point btcec.JacobianPoint
+		pubKey.AsJacobian(&point)
+
+		return point
+	}
+}
+
+// maskKey masks the given public key with the password of the pairing phrase.
+// Masking the same key twice with a negated password unmasks it again.
+func maskKey(key *btcec.PublicKey, password *btcec.ModNScalar,
+	negate bool) (*btcec.PublicKey, error) {
+
+	var keyPoint, passwordPoint, result btcec.JacobianPoint
+	key.AsJacobian(&keyPoint)
+
+	btcec.ScalarMultNonConst(password, &maskPoint, &passwordPoint)
+	if negate {
+		passwordPoint.ToAffine()
+		passwordPoint.Y.Negate(1).Normalize()
+	}
+
+	btcec.AddNonConst(&keyPoint, &passwordPoint, &result)
+	if (result.X.IsZero() && result.Y.IsZero()) || result.Z.IsZero() {
+		return nil, fmt.Errorf("%w: masked key is the point at "+
+			"infinity", ErrHandshakeFailed)
+	}
+	result.ToAffine()
+
+	return btcec.NewPublicKey(&result.X, &result.Y), nil
+}
+
+// cipherState encrypts or decrypts the messages sent in one direction.
+type cipherState struct {
+	aead  cipher.AEAD
+	nonce uint64
+}
+
+// newCipherState creates a new cipher state for the given key.
+func newCipherState(key []byte) (*cipherState, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cipherState{aead: aead}, nil
+}
+
+// nextNonce returns the nonce for the next message and increments the
+// counter.
+func (c *cipherState) nextNonce() []byte {
+	var nonce [chacha20poly1305.NonceSize]byte
+	binary.LittleEndian.PutUint64(nonce[4:], c.nonce)
+	c.nonce++
+
+	return nonce[:]
+}
+
+// seal encrypts the given plaintext.
+func (c *cipherState) seal(plaintext, ad []byte) []byte {
+	return c.aead.Seal(nil, c.nextNonce(), plaintext, ad)
+}
+
+// open decrypts the given ciphertext.
+func (c *cipherState) open(ciphertext, ad []byte) ([]byte, error) {
+	return c.aead.Open(nil, c.nextNonce(), ciphertext, ad)
+}
+
+// sessionKeys are the keys derived by the handshake.
+type sessionKeys struct {
+	// clientToServer encrypts the messages sent by the client.
+	clientToServer *cipherState
+
+	// serverToClient encrypts the messages sent by the node.
+	serverToClient *cipherState
+
+	// transcript is the hash of the public handshake values that is used
+	// as associated data of the handshake messages.
+	transcript [32]byte
+}
+
+// deriveSessionKeys derives the transport keys from the shared secret of the
+// ephemeral keys and the pairing phrase.
+func deriveSessionKeys(secrets *pairingSecrets, sharedSecret, maskedKey,
+	serverKey []byte) (*sessionKeys, error) {
+
+	transcript := taggedHash(
+		"lnc-transcript", []byte{handshakeVersion}, maskedKey,
+		serverKey,
+	)
+
+	var keys [64]byte
+	kdf := hkdf.New(
+		sha256.New, sharedSecret, secrets.authKey[:], transcript[:],
+	)
+	if _, err := io.ReadFull(kdf, keys[:]); err != nil {
+		return nil, err
+	}
+
+	clientToServer, err := newCipherState(keys[:32])
+	if err != nil {
+		return nil, err
+	}
+	serverToClient, err := newCipherState(keys[32:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionKeys{
+		clientToServer: clientToServer,
+		serverToClient: serverToClient,
+		transcript:     transcript,
+	}, nil
+}
+
+// clientHandshake performs the handshake as the client on the given mailbox
+// connection. The auth data sent by the node is returned.
+func clientHandshake(mb MailboxConn, secrets *pairingSecrets) (*sessionKeys,
+	[]byte, error) {
+
+	ephemeral, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Act one: we send our ephemeral key masked with the password, so only
+	// a party that knows the pairing phrase can unmask it.
+	masked, err := maskKey(ephemeral.PubKey(), &secrets.password, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	maskedKey := masked.SerializeCompressed()
+
+	actOne := append([]byte{handshakeVersion}, maskedKey...)
+	if err := mb.Send(actOne); err != nil {
+		return nil, nil, err
+	}
+
+	// Act two: the node responds with its ephemeral key and the auth data
+	// encrypted with the derived keys. We can only decrypt it if the node
+	// unmasked the right key, which proves it knows the pairing phrase.
+	actTwo, err := mb.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(actTwo) < actTwoMinSize {
+		return nil, nil, fmt.Errorf("%w: act two has invalid size %d",
+			ErrHandshakeFailed, len(actTwo))
+	}
+	if actTwo[0] != handshakeVersion {
+		return nil, nil, fmt.Errorf("%w: unknown version %d",
+			ErrHandshakeFailed, actTwo[0])
+	}
+
+	serverKey := actTwo[1:actOneSize]
+	serverPubKey, err := btcec.ParsePubKey(serverKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrHandshakeFailed, err)
+	}
+
+	sharedSecret := btcec.GenerateSharedSecret(ephemeral, serverPubKey)
+	keys, err := deriveSessionKeys(
+		secrets, sharedSecret, maskedKey, serverKey,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authData, err := keys.serverToClient.open(
+		actTwo[actOneSize:], keys.transcript[:],
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: unable to decrypt auth data",
+			ErrHandshakeFailed)
+	}
+
+	// Act three: we prove to the node that we derived the same keys.
+	actThree := keys.clientToServer.seal(nil, keys.transcript[:])
+	if err := mb.Send(actThree); err != nil {
+		return nil, nil, err
+	}
+
+	return keys, authData, nil
+}
+
+// serverHandshake performs the handshake as the node on the given mailbox
+// connection and sends the given auth data to the client. The given function
+// is called once the node waits for the final handshake message of the
+// client.
+func serverHandshake(mb MailboxConn, secrets *pairingSecrets, authData []byte,
+	awaitingActThree func()) (*sessionKeys, error) {
+
+	actOne, err := mb.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if len(actOne) != actOneSize {
+		return nil, fmt.Errorf("%w: act one has invalid size %d",
+			ErrHandshakeFailed, len(actOne))
+	}
+	if actOne[0] != handshakeVersion {
+		return nil, fmt.Errorf("%w: unknown version %d",
+			ErrHandshakeFailed, actOne[0])
+	}
+
+	maskedKey := actOne[1:]
+	masked, err := btcec.ParsePubKey(maskedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHandshakeFailed, err)
+	}
+
+	clientKey, err := maskKey(masked, &secrets.password, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	serverKey := ephemeral.PubKey().SerializeCompressed()
+
+	sharedSecret := btcec.GenerateSharedSecret(ephemeral, clientKey)
+	keys, err := deriveSessionKeys(
+		secrets, sharedSecret, maskedKey, serverKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	actTwo := make([]byte, 0, actTwoMinSize+len(authData))
+	actTwo = append(actTwo, handshakeVersion)
+	actTwo = append(actTwo, serverKey...)
+	encryptedAuthData := keys.serverToClient.seal(
+		authData, keys.transcript[:],
+	)
+	actTwo = append(actTwo, encryptedAuthData...)
+	if err := mb.Send(actTwo); err != nil {
+		return nil, err
+	}
+
+	if awaitingActThree != nil {
+		awaitingActThree()
+	}
+
+	// The client can only encrypt act three with the right key if it
+	// knows the discrete logarithm of the key we unmasked, which requires
+	// knowledge of the pairing phrase.
+	actThree, err := mb.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if len(actThree) != actThreeSize {
+		return nil, fmt.Errorf("%w: act three has invalid size %d",
+			ErrHandshakeFailed, len(actThree))
+	}
+
+	_, err = keys.clientToServer.open(actThree, keys.transcript[:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: client doesn't know the pairing "+
+			"phrase", ErrHandshakeFailed)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,242 @@
+package lnc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultHandshakeTimeout is the time we wait for the final handshake
+	// message of a client before we give up on it.
+	defaultHandshakeTimeout = 30 * time.Second
+
+	// minRedialBackoff is the time we wait before we connect to the
+	// mailbox server again after a failure.
+	minRedialBackoff = time.Second
+
+	// maxRedialBackoff is the maximum time we wait before we connect to the
+	// mailbox server again after repeated failures.
+	maxRedialBackoff = time.Minute
+)
+
+var (
+	// ErrListenerClosed is returned by Accept if the listener was closed.
+	ErrListenerClosed = errors.New("lnc listener closed")
+)
+
+// ListenerConfig holds the configuration of a Listener.
+type ListenerConfig struct {
+	// Mailbox is the mailbox server that relays the connections.
+	Mailbox Mailbox
+
+	// PairingPhrase is the phrase clients use to pair with the node.
+	PairingPhrase PairingPhrase
+
+	// AuthData is sent to every client that completes the handshake,
+	// usually the macaroon the client should use for its calls.
+	AuthData []byte
+
+	// HandshakeTimeout is the time the listener waits for the final
+	// handshake message of a client. If zero, a default is used.
+	HandshakeTimeout time.Duration
+}
+
+// Listener is a net.Listener that accepts the connections of clients that pair
+// with the node through a mailbox server. Since the mailbox streams are
+// derived from the pairing phrase, only one client can be connected at a time.
+type Listener struct {
+	cfg     *ListenerConfig
+	secrets *pairingSecrets
+
+	conns chan *Conn
+
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	quit      chan struct{}
+}
+
+// A compile time check to ensure Listener implements the net.Listener
+// interface.
+var _ net.Listener = (*Listener)(nil)
+
+// NewListener creates a new listener and starts waiting for clients on the
+// mailbox streams of the configured pairing phrase.
+func NewListener(cfg *ListenerConfig) (*Listener, error) {
+	secrets, err := cfg.PairingPhrase.secrets()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.HandshakeTimeout == 0 {
+		cfg.HandshakeTimeout = defaultHandshakeTimeout
+	}
+
+	l := &Listener{
+		cfg:     cfg,
+		secrets: secrets,
+		conns:   make(chan *Conn),
+		quit:    make(chan struct{}),
+	}
+
+	l.wg.Add(1)
+	go l.serve()
+
+	return l, nil
+}
+
+// serve waits for clients to pair and hands their connections to Accept.
+//
+// NOTE: This MUST be run as a goroutine.
+func (l *Listener) serve() {
+	defer l.wg.Done()
+
+	backoff := minRedialBackoff
+	for {
+		conn, err := l.acceptClient()
+		switch {
+		case errors.Is(err, ErrListenerClosed):
+			return
+
+		case err != nil:
+			log.Errorf("Unable to accept LNC client through %v: %v",
+				l.cfg.Mailbox.Addr(), err)
+
+			select {
+			case <-time.After(backoff):
+			case <-l.quit:
+				return
+			}
+
+			backoff = min(2*backoff, maxRedialBackoff)
+			continue
+		}
+
+		backoff = minRedialBackoff
+		log.Infof("LNC client paired through %v", l.cfg.Mailbox.Addr())
+
+		select {
+		case l.conns <- conn:
+		case <-l.quit:
+			_ = conn.Close()
+			return
+		}
+
+		// The mailbox streams can only be used by one client at a
+		// time, so we wait for the client to disconnect before we wait
+		// for the next one.
+		select {
+		case <-conn.readDone:
+		case <-conn.quit:
+		case <-l.quit:
+			_ = conn.Close()
+			return
+		}
+
+		_ = conn.Close()
+		log.Infof("LNC client disconnected")
+	}
+}
+
+// acceptClient connects to the mailbox server and blocks until a client
+// completes the handshake.
+func (l *Listener) acceptClient() (*Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-l.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	mb, err := l.cfg.Mailbox.Dial(
+		ctx, l.secrets.clientToServer, l.secrets.serverToClient,
+	)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ErrListenerClosed
+		}
+
+		return nil, err
+	}
+
+	// Closing the mailbox connection aborts the handshake if the listener
+	// is closed or the client doesn't finish the handshake in time.
+	var timeout *time.Timer
+	handshakeDone := make(chan struct{})
+	go func() {
+		select {
+		case <-l.quit:
+			_ = mb.Close()
+		case <-handshakeDone:
+		}
+	}()
+
+	keys, err := serverHandshake(
+		mb, l.secrets, l.cfg.AuthData, func() {
+			timeout = time.AfterFunc(
+				l.cfg.HandshakeTimeout, func() {
+					_ = mb.Close()
+				},
+			)
+		},
+	)
+	close(handshakeDone)
+	if timeout != nil && !timeout.Stop() {
+		err = errors.New("client handshake timed out")
+	}
+	if err != nil {
+		_ = mb.Close()
+
+		select {
+		case <-l.quit:
+			return nil, ErrListenerClosed
+		default:
+			return nil, err
+		}
+	}
+
+	conn := newConn(
+		mb, l.cfg.Mailbox.Addr(), keys.serverToClient,
+		keys.clientToServer,
+	)
+
+	return conn, nil
+}
+
+// Accept waits for the next client to pair with the node.
+//
+// NOTE: This is part of the net.Listener interface.
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+
+	case <-l.quit:
+		return nil, ErrListenerClosed
+	}
+}
+
+// Close stops the listener and closes the connection of a paired client.
+//
+// NOTE: This is part of the net.Listener interface.
+func (l *Listener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.quit)
+		l.wg.Wait()
+	})
+
+	return nil
+}
+
+// Addr returns the address of the mailbox server.
+//
+// NOTE: This is part of the net.Listener interface.
+func (l *Listener) Addr() net.Addr {
+	return &Addr{MailboxServer: l.cfg.Mailbox.Addr()}
+}
@@ -0,0 +1,273 @@
+package lnc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memMailbox is an in-memory Mailbox used for testing.
+type memMailbox struct {
+	mu      sync.Mutex
+	streams map[StreamID]chan []byte
+}
+
+// newMemMailbox creates a new in-memory mailbox.
+func newMemMailbox() *memMailbox {
+	return &memMailbox{
+		streams: make(map[StreamID]chan []byte),
+	}
+}
+
+// stream returns the channel of the stream with the given ID.
+func (m *memMailbox) stream(sid StreamID) chan []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.streams[sid]; !ok {
+		m.streams[sid] = make(chan []byte, 100)
+	}
+
+	return m.streams[sid]
+}
+
+// Dial returns a connection to the given streams.
+func (m *memMailbox) Dial(_ context.Context, recvSID,
+	sendSID StreamID) (MailboxConn, error) {
+
+	return &memMailboxConn{
+		recv: m.stream(recvSID),
+		send: m.stream(sendSID),
+		quit: make(chan struct{}),
+	}, nil
+}
+
+// Addr returns a dummy address.
+func (m *memMailbox) Addr() string {
+	return "memory"
+}
+
+// memMailboxConn is a connection to the streams of a memMailbox.
+type memMailboxConn struct {
+	recv      chan []byte
+	send      chan []byte
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+// Send sends a message to the send stream.
+func (m *memMailboxConn) Send(msg []byte) error {
+	select {
+	case m.send <- msg:
+		return nil
+	case <-m.quit:
+		return net.ErrClosed
+	}
+}
+
+// Recv receives a message from the receive stream.
+func (m *memMailboxConn) Recv() ([]byte, error) {
+	select {
+	case msg := <-m.recv:
+		return msg, nil
+	case <-m.quit:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close closes the connection.
+func (m *memMailboxConn) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.quit)
+	})
+
+	return nil
+}
+
+// TestPairingPhrase tests that pairing phrases are parsed correctly and that
+// different phrases result in different secrets.
+func TestPairingPhrase(t *testing.T) {
+	t.Parallel()
+
+	phrase, err := NewPairingPhrase()
+	require.NoError(t, err)
+
+	parsed, err := ParsePairingPhrase(
+		"  " + strings.ToUpper(phrase.String()) + "\n",
+	)
+	require.NoError(t, err)
+	require.Equal(t, phrase, parsed)
+
+	_, err = ParsePairingPhrase(strings.Join(phrase[1:], " "))
+	require.ErrorIs(t, err, ErrInvalidPairingPhrase)
+
+	invalid := phrase
+	invalid[3] = "notaword"
+	_, err = ParsePairingPhrase(invalid.String())
+	require.ErrorIs(t, err, ErrInvalidPairingPhrase)
+
+	_, err = PairingPhrase{}.secrets()
+	require.ErrorIs(t, err, ErrInvalidPairingPhrase)
+
+	// Changing a single word must change all derived secrets.
+	other := phrase
+	if other[9] == "abandon" {
+		other[9] = "ability"
+	} else {
+		other[9] = "abandon"
+	}
+
+	secrets, err := phrase.secrets()
+	require.NoError(t, err)
+	otherSecrets, err := other.secrets()
+	require.NoError(t, err)
+
+	require.NotEqual(t, secrets.clientToServer, secrets.serverToClient)
+	require.NotEqual(t, secrets.clientToServer, otherSecrets.clientToServer)
+	require.NotEqual(t, secrets.authKey, otherSecrets.authKey)
+	require.False(t, secrets.password.Equals(&otherSecrets.password))
+}
+
+// TestListenerDial tests that a client can pair with a listener, exchange data
+// in both directions and that the next client can pair once it disconnected.
+func TestListenerDial(t *testing.T) {
+	t.Parallel()
+
+	phrase, err := NewPairingPhrase()
+	require.NoError(t, err)
+
+	mailbox := newMemMailbox()
+	authData := []byte("macaroon")
+	listener, err := NewListener(&ListenerConfig{
+		Mailbox:       mailbox,
+		PairingPhrase: phrase,
+		AuthData:      authData,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+	})
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 10*time.Second,
+	)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		client, receivedAuthData, err := Dial(ctx, mailbox, phrase)
+		require.NoError(t, err)
+		require.Equal(t, authData, receivedAuthData)
+
+		server, err := listener.Accept()
+		require.NoError(t, err)
+
+		// Data larger than a single frame is split and reassembled.
+		request := bytes.Repeat([]byte{byte(i)}, 3*maxFramePayload+1)
+		_, err = client.Write(request)
+		require.NoError(t, err)
+
+		received := make([]byte, len(request))
+		_, err = io.ReadFull(server, received)
+		require.NoError(t, err)
+		require.Equal(t, request, received)
+
+		_, err = server.Write([]byte("response"))
+		require.NoError(t, err)
+
+		received = make([]byte, len("response"))
+		_, err = io.ReadFull(client, received)
+		require.NoError(t, err)
+		require.Equal(t, []byte("response"), received)
+
+		// Once the client disconnects, the node reads EOF.
+		require.NoError(t, client.Close())
+		_, err = server.Read(received)
+		require.ErrorIs(t, err, io.EOF)
+	}
+}
+
+// TestHandshakeWrongPhrase tests that the handshake fails for both parties if
+// the client doesn't know the pairing phrase of the node.
+func TestHandshakeWrongPhrase(t *testing.T) {
+	t.Parallel()
+
+	phrase, err := NewPairingPhrase()
+	require.NoError(t, err)
+	wrongPhrase, err := NewPairingPhrase()
+	require.NoError(t, err)
+
+	secrets, err := phrase.secrets()
+	require.NoError(t, err)
+	wrongSecrets, err := wrongPhrase.secrets()
+	require.NoError(t, err)
+
+	// We let the client use the streams of the node, so that only the
+	// handshake itself can detect the wrong phrase.
+	mailbox := newMemMailbox()
+	serverConn, err := mailbox.Dial(
+		context.Background(), secrets.clientToServer,
+		secrets.serverToClient,
+	)
+	require.NoError(t, err)
+	clientConn, err := mailbox.Dial(
+		context.Background(), secrets.serverToClient,
+		secrets.clientToServer,
+	)
+	require.NoError(t, err)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		_, err := serverHandshake(
+			serverConn, secrets, []byte("macaroon"), nil,
+		)
+		serverErr <- err
+	}()
+
+	_, _, err = clientHandshake(clientConn, wrongSecrets)
+	require.ErrorIs(t, err, ErrHandshakeFailed)
+
+	// The client doesn't send act three, so we close the connection like
+	// the handshake timeout of the listener would.
+	require.NoError(t, serverConn.Close())
+	require.Error(t, <-serverErr)
+
+	// A client that sends a random act three is rejected by the node.
+	serverConn, err = mailbox.Dial(
+		context.Background(), secrets.clientToServer,
+		secrets.serverToClient,
+	)
+	require.NoError(t, err)
+
+	go func() {
+		_, err := serverHandshake(serverConn, secrets, nil, nil)
+		serverErr <- err
+	}()
+
+	actOne := append(
+		[]byte{handshakeVersion}, maskPointBytes(t)...,
+	)
+	require.NoError(t, clientConn.Send(actOne))
+	_, err = clientConn.Recv()
+	require.NoError(t, err)
+	require.NoError(t, clientConn.Send(make([]byte, actThreeSize)))
+	require.ErrorIs(t, <-serverErr, ErrHandshakeFailed)
+}
+
+// maskPointBytes returns the serialized mask point, which is a valid key the
+// client doesn't know the discrete logarithm of.
+func maskPointBytes(t *testing.T) []byte {
+	t.Helper()
+
+	point := maskPoint
+	point.ToAffine()
+
+	return append([]byte{0x02 | byte(point.Y.IsOddBit())},
+		point.X.Bytes()[:]...)
+}
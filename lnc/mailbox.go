@@ -0,0 +1,271 @@
+package lnc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// hashMailPath is the REST path of the hashmail API of the mailbox
+	// server.
+	hashMailPath = "/v1/lightning-node-connect/hashmail"
+
+	// hashMailAlreadyExists is the gRPC status code the mailbox server
+	// returns if a stream that should be created already exists.
+	hashMailAlreadyExists = 6
+)
+
+// MailboxConn is a connection to a mailbox server that receives messages from
+// one stream and sends messages to another one.
+type MailboxConn interface {
+	// Send sends a message to the send stream.
+	Send(msg []byte) error
+
+	// Recv blocks until a message is received from the receive stream.
+	Recv() ([]byte, error)
+
+	// Close closes the connection. Blocked calls to Recv return an error.
+	Close() error
+}
+
+// Mailbox is a server that relays messages between the node and its clients.
+// The mailbox server only ever sees encrypted messages.
+type Mailbox interface {
+	// Dial connects to the given receive and send streams of the mailbox
+	// server.
+	Dial(ctx context.Context, recvSID, sendSID StreamID) (MailboxConn,
+		error)
+
+	// Addr returns the address of the mailbox server.
+	Addr() string
+}
+
+// HashMailbox is a Mailbox that connects to the hashmail API of a mailbox
+// server like aperture through websockets.
+type HashMailbox struct {
+	server string
+	dialer *websocket.Dialer
+	client *http.Client
+}
+
+// A compile time check to ensure HashMailbox implements the Mailbox interface.
+var _ Mailbox = (*HashMailbox)(nil)
+
+// NewHashMailbox creates a new HashMailbox that connects to the mailbox server
+// at the given host:port.
+func NewHashMailbox(server string) *HashMailbox {
+	return &HashMailbox{
+		server: server,
+		dialer: websocket.DefaultDialer,
+		client: http.DefaultClient,
+	}
+}
+
+// Addr returns the address of the mailbox server.
+//
+// NOTE: This is part of the Mailbox interface.
+func (h *HashMailbox) Addr() string {
+	return h.server
+}
+
+// streamDesc is the JSON encoding of a hashmail stream descriptor.
+type streamDesc struct {
+	StreamID []byte `json:"stream_id"`
+}
+
+// hashMailSend is the JSON encoding of a message sent to a hashmail stream.
+type hashMailSend struct {
+	Desc streamDesc `json:"desc"`
+	Msg  []byte     `json:"msg"`
+}
+
+// hashMailError is the JSON encoding of an error returned by the hashmail API.
+type hashMailError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// hashMailRecv is the JSON encoding of a message received from a hashmail
+// stream.
+type hashMailRecv struct {
+	Result *struct {
+		Msg []byte `json:"msg"`
+	} `json:"result"`
+	Error *hashMailError `json:"error"`
+}
+
+// Dial connects to the given receive and send streams of the mailbox server.
+// The receive stream is created if it doesn't exist yet.
+//
+// NOTE: This is part of the Mailbox interface.
+func (h *HashMailbox) Dial(ctx context.Context, recvSID,
+	sendSID StreamID) (MailboxConn, error) {
+
+	if err := h.createStream(ctx, recvSID); err != nil {
+		return nil, fmt.Errorf("unable to create receive stream: %w",
+			err)
+	}
+
+	recvConn, err := h.dialStream(ctx, "receive")
+	if err != nil {
+		return nil, err
+	}
+
+	// The receive endpoint expects the descriptor of the stream to read
+	// from as its first message.
+	err = recvConn.WriteJSON(&streamDesc{StreamID: recvSID[:]})
+	if err != nil {
+		_ = recvConn.Close()
+		return nil, err
+	}
+
+	sendConn, err := h.dialStream(ctx, "send")
+	if err != nil {
+		_ = recvConn.Close()
+		return nil, err
+	}
+
+	return &hashMailConn{
+		recvConn: recvConn,
+		sendConn: sendConn,
+		sendSID:  sendSID,
+	}, nil
+}
+
+// createStream creates the stream with the given ID on the mailbox server. A
+// stream that already exists is not treated as an error.
+func (h *HashMailbox) createStream(ctx context.Context, sid StreamID) error {
+	body, err := json.Marshal(&struct {
+		Desc streamDesc `json:"desc"`
+	}{
+		Desc: streamDesc{StreamID: sid[:]},
+	})
+	if err != nil {
+		return err
+	}
+
+	reqURL := url.URL{Scheme: "https", Host: h.server, Path: hashMailPath}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var hashMailErr hashMailError
+	err = json.Unmarshal(respBody, &hashMailErr)
+	if err == nil && hashMailErr.Code == hashMailAlreadyExists {
+		return nil
+	}
+
+	return fmt.Errorf("mailbox server returned status %d: %s",
+		resp.StatusCode, respBody)
+}
+
+// dialStream opens a websocket to the given streaming endpoint of the hashmail
+// API.
+func (h *HashMailbox) dialStream(ctx context.Context,
+	endpoint string) (*websocket.Conn, error) {
+
+	streamURL := url.URL{
+		Scheme:   "wss",
+		Host:     h.server,
+		Path:     hashMailPath + "/" + endpoint,
+		RawQuery: "method=POST",
+	}
+	conn, _, err := h.dialer.DialContext(ctx, streamURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial %v stream: %w",
+			endpoint, err)
+	}
+
+	return conn, nil
+}
+
+// hashMailConn is a MailboxConn that sends and receives messages through the
+// websockets of the hashmail API.
+type hashMailConn struct {
+	recvConn *websocket.Conn
+	sendConn *websocket.Conn
+	sendSID  StreamID
+
+	// sendMtx serializes writes to the send websocket, which doesn't
+	// support concurrent writers.
+	sendMtx sync.Mutex
+
+	closeOnce sync.Once
+}
+
+// Send sends a message to the send stream.
+//
+// NOTE: This is part of the MailboxConn interface.
+func (h *hashMailConn) Send(msg []byte) error {
+	h.sendMtx.Lock()
+	defer h.sendMtx.Unlock()
+
+	return h.sendConn.WriteJSON(&hashMailSend{
+		Desc: streamDesc{StreamID: h.sendSID[:]},
+		Msg:  msg,
+	})
+}
+
+// Recv blocks until a message is received from the receive stream.
+//
+// NOTE: This is part of the MailboxConn interface.
+func (h *hashMailConn) Recv() ([]byte, error) {
+	var msg hashMailRecv
+	if err := h.recvConn.ReadJSON(&msg); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case msg.Error != nil:
+		return nil, fmt.Errorf("mailbox server error %d: %s",
+			msg.Error.Code, msg.Error.Message)
+
+	case msg.Result == nil:
+		return nil, fmt.Errorf("mailbox server sent empty message")
+	}
+
+	return msg.Result.Msg, nil
+}
+
+// Close closes both websockets.
+//
+// NOTE: This is part of the MailboxConn interface.
+func (h *hashMailConn) Close() error {
+	var err error
+	h.closeOnce.Do(func() {
+		recvErr := h.recvConn.Close()
+		err = h.sendConn.Close()
+		if recvErr != nil {
+			err = recvErr
+		}
+	})
+
+	return err
+}
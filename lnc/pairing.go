@@ -0,0 +1,206 @@
+package lnc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/aezeed"
+)
+
+const (
+	// NumPhraseWords is the number of words a pairing phrase consists of.
+	NumPhraseWords = 10
+
+	// bitsPerWord is the number of bits of entropy encoded by every word
+	// of a pairing phrase.
+	bitsPerWord = 11
+
+	// phraseEntropySize is the number of bytes the entropy of a pairing
+	// phrase is packed into.
+	phraseEntropySize = (NumPhraseWords*bitsPerWord + 7) / 8
+
+	// StreamIDSize is the size of a mailbox stream ID.
+	StreamIDSize = 64
+
+	// phraseFilePermissions are the file permissions the pairing phrase
+	// is stored with. Anyone who knows the phrase can pair with the node,
+	// so only the owner may read it.
+	phraseFilePermissions = 0600
+)
+
+var (
+	// ErrInvalidPairingPhrase is returned if a pairing phrase doesn't
+	// consist of NumPhraseWords words of the aezeed word list.
+	ErrInvalidPairingPhrase = errors.New("invalid pairing phrase")
+)
+
+// StreamID identifies a stream on the mailbox server.
+type StreamID [StreamIDSize]byte
+
+// PairingPhrase is the secret a client needs to know to pair with the node.
+// It is used to derive the mailbox streams both parties communicate through
+// and to authenticate the handshake between them.
+type PairingPhrase [NumPhraseWords]string
+
+// NewPairingPhrase creates a new random pairing phrase.
+func NewPairingPhrase() (PairingPhrase, error) {
+	var (
+		phrase   PairingPhrase
+		numWords = big.NewInt(int64(len(aezeed.DefaultWordList)))
+	)
+	for i := range phrase {
+		idx, err := rand.Int(rand.Reader, numWords)
+		if err != nil {
+			return phrase, err
+		}
+
+		phrase[i] = aezeed.DefaultWordList[idx.Int64()]
+	}
+
+	return phrase, nil
+}
+
+// ParsePairingPhrase parses a pairing phrase from its space separated words.
+func ParsePairingPhrase(phrase string) (PairingPhrase, error) {
+	var pairingPhrase PairingPhrase
+
+	words := strings.Fields(strings.ToLower(phrase))
+	if len(words) != NumPhraseWords {
+		return pairingPhrase, fmt.Errorf("%w: expected %d words, got "+
+			"%d", ErrInvalidPairingPhrase, NumPhraseWords,
+			len(words))
+	}
+
+	for i, word := range words {
+		if _, ok := aezeed.ReverseWordMap[word]; !ok {
+			return pairingPhrase, fmt.Errorf("%w: unknown word %q",
+				ErrInvalidPairingPhrase, word)
+		}
+
+		pairingPhrase[i] = word
+	}
+
+	return pairingPhrase, nil
+}
+
+// ReadOrCreatePairingPhrase reads the pairing phrase stored in the file at the
+// given path. If the file doesn't exist, a new pairing phrase is created and
+// stored in it.
+func ReadOrCreatePairingPhrase(path string) (PairingPhrase, error) {
+	phraseBytes, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		return ParsePairingPhrase(string(phraseBytes))
+
+	case !errors.Is(err, os.ErrNotExist):
+		return PairingPhrase{}, err
+	}
+
+	phrase, err := NewPairingPhrase()
+	if err != nil {
+		return phrase, err
+	}
+
+	err = os.WriteFile(
+		path, []byte(phrase.String()+"\n"), phraseFilePermissions,
+	)
+	if err != nil {
+		return phrase, err
+	}
+
+	return phrase, nil
+}
+
+// String returns the space separated words of the pairing phrase.
+func (p PairingPhrase) String() string {
+	return strings.Join(p[:], " ")
+}
+
+// entropy packs the word indices of the pairing phrase into bytes.
+func (p PairingPhrase) entropy() ([phraseEntropySize]byte, error) {
+	var entropy [phraseEntropySize]byte
+
+	bit := 0
+	for _, word := range p {
+		idx, ok := aezeed.ReverseWordMap[word]
+		if !ok {
+			return entropy, fmt.Errorf("%w: unknown word %q",
+				ErrInvalidPairingPhrase, word)
+		}
+
+		for i := bitsPerWord - 1; i >= 0; i-- {
+			if idx&(1<<i) != 0 {
+				entropy[bit/8] |= 1 << (7 - bit%8)
+			}
+			bit++
+		}
+	}
+
+	return entropy, nil
+}
+
+// pairingSecrets are the values derived from a pairing phrase.
+type pairingSecrets struct {
+	// clientToServer is the stream the client sends messages to the node
+	// on.
+	clientToServer StreamID
+
+	// serverToClient is the stream the node sends messages to the client
+	// on.
+	serverToClient StreamID
+
+	// password is the scalar the ephemeral key of the client is masked
+	// with during the handshake.
+	password btcec.ModNScalar
+
+	// authKey is mixed into the derivation of the transport keys, so that
+	// they can only be derived with knowledge of the pairing phrase.
+	authKey [32]byte
+}
+
+// secrets derives the stream IDs and handshake secrets from the pairing
+// phrase.
+func (p PairingPhrase) secrets() (*pairingSecrets, error) {
+	entropy, err := p.entropy()
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets pairingSecrets
+
+	// Both streams are derived from the same hash and only differ in the
+	// last bit, just like the streams of the original LNC protocol.
+	sid := sha512.Sum512(entropy[:])
+	secrets.clientToServer = sid
+	secrets.serverToClient = sid
+	secrets.serverToClient[StreamIDSize-1] ^= 0x01
+
+	password := taggedHash("lnc-pake-password", entropy[:])
+	secrets.password.SetBytes(&password)
+
+	secrets.authKey = taggedHash("lnc-pake-auth", entropy[:])
+
+	return &secrets, nil
+}
+
+// taggedHash returns the sha256 hash of the given tag followed by the given
+// data.
+func taggedHash(tag string, data ...[]byte) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(tag))
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	var hash [32]byte
+	copy(hash[:], h.Sum(nil))
+
+	return hash
+}
@@ -1,11 +1,21 @@
 package lncfg
 
-import "time"
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
 
 const (
 	// DefaultTxPollingJitter defines the default TxPollingIntervalJitter
 	// to be used for bitcoind backend.
 	DefaultTxPollingJitter = 0.5
+
+	// DefaultFailoverCheckInterval is the default interval in which the
+	// health of the bitcoind nodes is checked if failover nodes are
+	// configured.
+	DefaultFailoverCheckInterval = 30 * time.Second
 )
 
 // Bitcoind holds the configuration options for the daemon's connection to
@@ -27,4 +37,86 @@ type Bitcoind struct {
 	RPCPolling           bool          `long:"rpcpolling" description:"Poll the bitcoind RPC interface for block and transaction notifications instead of using the ZMQ interface"`
 	BlockPollingInterval time.Duration `long:"blockpollinginterval" description:"The interval that will be used to poll bitcoind for new blocks. Only used if rpcpolling is true."`
 	TxPollingInterval    time.Duration `long:"txpollinginterval" description:"The interval that will be used to poll bitcoind for new tx. Only used if rpcpolling is true."`
+
+	Failover              []string      `long:"failover" description:"An additional bitcoind node to fail over to if the active node becomes unhealthy, in the form [user:pass@]host:port[?zmqpubrawblock=addr&zmqpubrawtx=addr]. The credentials default to rpcuser and rpcpass. The ZMQ addresses are required unless rpcpolling is set. Nodes are preferred in the order they are specified, after the node set with rpchost. Can be specified multiple times."`
+	FailoverCheckInterval time.Duration `long:"failover-check-interval" description:"The interval in which the health of all bitcoind nodes is checked if failover nodes are configured."`
+}
+
+// BitcoindNode holds the connection details of a single bitcoind node.
+type BitcoindNode struct {
+	// RPCHost is the host:port of the RPC interface of the node.
+	RPCHost string
+
+	// RPCUser is the username for RPC connections to the node.
+	RPCUser string
+
+	// RPCPass is the password for RPC connections to the node.
+	RPCPass string
+
+	// ZMQPubRawBlock is the address of the ZMQ raw block notifications of
+	// the node.
+	ZMQPubRawBlock string
+
+	// ZMQPubRawTx is the address of the ZMQ raw transaction notifications
+	// of the node.
+	ZMQPubRawTx string
+}
+
+// FailoverNodes parses the configured failover nodes. Nodes without
+// credentials inherit the configured rpcuser and rpcpass.
+func (b *Bitcoind) FailoverNodes() ([]BitcoindNode, error) {
+	nodes := make([]BitcoindNode, 0, len(b.Failover))
+	for _, failover := range b.Failover {
+		// Parse the node as a URL without scheme, so that the
+		// credentials and ZMQ addresses can be percent-encoded.
+		nodeURL, err := url.Parse("//" + failover)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitcoind failover "+
+				"node: %w", err)
+		}
+
+		if _, _, err := net.SplitHostPort(nodeURL.Host); err != nil {
+			return nil, fmt.Errorf("invalid bitcoind failover "+
+				"host %q, host:port expected", nodeURL.Host)
+		}
+
+		node := BitcoindNode{
+			RPCHost:        nodeURL.Host,
+			RPCUser:        b.RPCUser,
+			RPCPass:        b.RPCPass,
+			ZMQPubRawBlock: nodeURL.Query().Get("zmqpubrawblock"),
+			ZMQPubRawTx:    nodeURL.Query().Get("zmqpubrawtx"),
+		}
+		if nodeURL.User != nil {
+			node.RPCUser = nodeURL.User.Username()
+			node.RPCPass, _ = nodeURL.User.Password()
+		}
+
+		if !b.RPCPolling && (node.ZMQPubRawBlock == "" ||
+			node.ZMQPubRawTx == "") {
+
+			return nil, fmt.Errorf("bitcoind failover node %v "+
+				"requires zmqpubrawblock and zmqpubrawtx",
+				node.RPCHost)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// Validate checks the values configured for the bitcoind backend.
+func (b *Bitcoind) Validate() error {
+	if len(b.Failover) == 0 {
+		return nil
+	}
+
+	if b.FailoverCheckInterval <= 0 {
+		return fmt.Errorf("failover-check-interval must be positive")
+	}
+
+	_, err := b.FailoverNodes()
+
+	return err
 }
@@ -0,0 +1,81 @@
+package lncfg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBitcoindFailoverNodes asserts that failover nodes are parsed with their
+// credentials and ZMQ addresses, and that invalid nodes are rejected.
+func TestBitcoindFailoverNodes(t *testing.T) {
+	t.Parallel()
+
+	cfg := &lncfg.Bitcoind{
+		RPCUser: "user",
+		RPCPass: "pass",
+		Failover: []string{
+			"10.0.0.2:8332?zmqpubrawblock=tcp://10.0.0.2:28332&" +
+				"zmqpubrawtx=tcp://10.0.0.2:28333",
+			"other:p%40ss@10.0.0.3:8332?zmqpubrawblock=" +
+				"tcp://10.0.0.3:28332&zmqpubrawtx=" +
+				"tcp://10.0.0.3:28333",
+		},
+		FailoverCheckInterval: time.Minute,
+	}
+	require.NoError(t, cfg.Validate())
+
+	nodes, err := cfg.FailoverNodes()
+	require.NoError(t, err)
+	require.Equal(t, []lncfg.BitcoindNode{
+		{
+			RPCHost:        "10.0.0.2:8332",
+			RPCUser:        "user",
+			RPCPass:        "pass",
+			ZMQPubRawBlock: "tcp://10.0.0.2:28332",
+			ZMQPubRawTx:    "tcp://10.0.0.2:28333",
+		},
+		{
+			RPCHost:        "10.0.0.3:8332",
+			RPCUser:        "other",
+			RPCPass:        "p@ss",
+			ZMQPubRawBlock: "tcp://10.0.0.3:28332",
+			ZMQPubRawTx:    "tcp://10.0.0.3:28333",
+		},
+	}, nodes)
+
+	// The ZMQ addresses aren't needed when polling the RPC interface.
+	cfg = &lncfg.Bitcoind{
+		RPCPolling:            true,
+		Failover:              []string{"10.0.0.2:8332"},
+		FailoverCheckInterval: time.Minute,
+	}
+	require.NoError(t, cfg.Validate())
+
+	invalid := []*lncfg.Bitcoind{
+		// Missing port.
+		{
+			RPCPolling:            true,
+			Failover:              []string{"10.0.0.2"},
+			FailoverCheckInterval: time.Minute,
+		},
+		// Missing ZMQ addresses.
+		{
+			Failover: []string{
+				"10.0.0.2:8332?zmqpubrawblock=" +
+					"tcp://10.0.0.2:28332",
+			},
+			FailoverCheckInterval: time.Minute,
+		},
+		// Missing check interval.
+		{
+			RPCPolling: true,
+			Failover:   []string{"10.0.0.2:8332"},
+		},
+	}
+	for _, cfg := range invalid {
+		require.Error(t, cfg.Validate(), "failover=%v", cfg.Failover)
+	}
+}
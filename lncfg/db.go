@@ -32,6 +32,10 @@ const (
 	SqliteTowerDBName    = "watchtower.sqlite"
 	SqliteNativeDBName   = "lnd.sqlite"
 
+	// PostgresBackupFileName is the name of the file a backup of the
+	// Postgres database is written to.
+	PostgresBackupFileName = "lnd.pgdump"
+
 	BoltBackend                = "bolt"
 	EtcdBackend                = "etcd"
 	PostgresBackend            = "postgres"
@@ -87,6 +91,10 @@ type DB struct {
 
 	UseNativeSQL bool `long:"use-native-sql" description:"Use native SQL for tables that already support it."`
 
+	DualWriteChannelState bool `long:"dual-write-channel-state" description:"Mirror all channel state writes (commitments, revocation logs and forwarding packages) to the native SQL channel store. The KV store stays authoritative. Requires use-native-sql to be set."`
+
+	DualWriteGraph bool `long:"dual-write-graph" description:"Mirror all channel graph writes (nodes, channels, policies and the zombie index) to the native SQL graph store. The KV store stays authoritative. Requires use-native-sql to be set."`
+
 	NoGraphCache bool `long:"no-graph-cache" description:"Don't use the in-memory graph cache for path finding. Much slower but uses less RAM. Can only be used with a bolt database backend."`
 
 	PruneRevocation bool `long:"prune-revocation" description:"Run the optional migration that prunes the revocation logs to save disk space."`
@@ -110,6 +118,7 @@ func DefaultDB() *DB {
 		},
 		Postgres: &sqldb.PostgresConfig{
 			MaxConnections: defaultPostgresMaxConnections,
+			ReplicaMaxLag:  sqldb.DefaultReplicaMaxLag,
 		},
 		Sqlite: &sqldb.SqliteConfig{
 			MaxConnections: defaultSqliteMaxConnections,
@@ -150,6 +159,16 @@ func (db *DB) Validate() error {
 			PostgresBackend, SqliteBackend)
 	}
 
+	if db.DualWriteChannelState && !db.UseNativeSQL {
+		return fmt.Errorf("cannot use dual-write-channel-state " +
+			"without use-native-sql")
+	}
+
+	if db.DualWriteGraph && !db.UseNativeSQL {
+		return fmt.Errorf("cannot use dual-write-graph without " +
+			"use-native-sql")
+	}
+
 	// The path finding uses a manual read transaction that's open for a
 	// potentially long time. That works fine with the locking model of
 	// bbolt but can lead to locks or rolled back transactions with etcd or
@@ -240,6 +259,11 @@ type DatabaseBackends struct {
 	// replicated instances or local bbolt or sqlite backed databases.
 	Remote bool
 
+	// Backup writes a consistent snapshot of the SQL databases to the
+	// given directory and returns the paths of the written files. This is
+	// nil for the bolt and etcd backends.
+	Backup func(ctx context.Context, destDir string) ([]string, error)
+
 	// CloseFuncs is a map of close functions for each of the initialized
 	// DB backends keyed by their namespace name.
 	CloseFuncs map[string]func() error
@@ -265,6 +289,45 @@ func GetSqliteConfigKVDB(cfg *sqldb.SqliteConfig) *sqlite.Config {
 	}
 }
 
+// backupPostgres returns a function that writes a snapshot of the Postgres
+// database, which contains both the kvdb and the native SQL tables, to a file
+// in the given directory.
+func backupPostgres(dsn string) func(context.Context, string) ([]string,
+	error) {
+
+	return func(ctx context.Context, destDir string) ([]string, error) {
+		destPath := path.Join(destDir, PostgresBackupFileName)
+		err := sqldb.BackupPostgres(ctx, dsn, destPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{destPath}, nil
+	}
+}
+
+// backupSqliteFiles returns a function that writes a snapshot of each of the
+// given SQLite databases to a file with the same name in the given directory.
+func backupSqliteFiles(srcPaths ...string) func(context.Context,
+	string) ([]string, error) {
+
+	return func(ctx context.Context, destDir string) ([]string, error) {
+		destPaths := make([]string, 0, len(srcPaths))
+		for _, srcPath := range srcPaths {
+			destPath := path.Join(destDir, path.Base(srcPath))
+			err := sqldb.BackupSqliteFile(ctx, srcPath, destPath)
+			if err != nil {
+				return nil, fmt.Errorf("unable to back up %v: "+
+					"%w", srcPath, err)
+			}
+
+			destPaths = append(destPaths, destPath)
+		}
+
+		return destPaths, nil
+	}
+}
+
 // GetBackends returns a set of kvdb.Backends as set in the DB config.
 func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 	walletDBPath, towerServerDBPath string, towerClientEnabled,
@@ -492,6 +555,7 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 			),
 			NativeSQLStore: nativeSQLStore,
 			Remote:         true,
+			Backup:         backupPostgres(db.Postgres.Dsn),
 			CloseFuncs:     closeFuncs,
 		}, nil
 
@@ -571,11 +635,19 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 		}
 		closeFuncs[NSWalletDB] = sqliteWalletBackend.Close
 
+		sqliteFiles := []string{
+			path.Join(chanDBPath, SqliteChannelDBName),
+			path.Join(walletDBPath, SqliteChainDBName),
+			path.Join(towerServerDBPath, SqliteTowerDBName),
+		}
+
 		var nativeSQLStore *sqldb.BaseDB
 		if db.UseNativeSQL {
+			nativeDBPath := path.Join(
+				chanDBPath, SqliteNativeDBName,
+			)
 			nativeSQLiteStore, err := sqldb.NewSqliteStore(
-				db.Sqlite,
-				path.Join(chanDBPath, SqliteNativeDBName),
+				db.Sqlite, nativeDBPath,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("error opening "+
@@ -584,6 +656,7 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 
 			nativeSQLStore = nativeSQLiteStore.BaseDB
 			closeFuncs[SqliteBackend] = nativeSQLiteStore.Close
+			sqliteFiles = append(sqliteFiles, nativeDBPath)
 		}
 
 		// Warn if the user is trying to switch over to a sqlite DB
@@ -614,6 +687,7 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 				sqliteWalletBackend,
 			),
 			NativeSQLStore: nativeSQLStore,
+			Backup:         backupSqliteFiles(sqliteFiles...),
 			CloseFuncs:     closeFuncs,
 		}, nil
 	}
@@ -1,6 +1,14 @@
 package lncfg
 
-import "time"
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
 
 // DefaultMinUpdateTimeout represents the minimum interval in which a
 // WebAPIEstimator will request fresh fees from its API.
@@ -10,11 +18,108 @@ const DefaultMinUpdateTimeout = 5 * time.Minute
 // WebAPIEstimator will request fresh fees from its API.
 const DefaultMaxUpdateTimeout = 20 * time.Minute
 
+const (
+	// FeeSourceBackend is the fee source backed by the fee estimates of
+	// the chain backend, e.g. bitcoind's estimatesmartfee.
+	FeeSourceBackend = "backend"
+
+	// FeeSourceWebAPI is the fee source backed by the external fee API
+	// configured with fee.url.
+	FeeSourceWebAPI = "webapi"
+
+	// FeeSourceMempool is the fee source that derives fee estimates from
+	// the mempool of the chain backend.
+	FeeSourceMempool = "mempool"
+)
+
 // Fee holds the configuration options for fee estimation.
 //
 //nolint:lll
 type Fee struct {
 	URL              string        `long:"url" description:"Optional URL for external fee estimation. If no URL is specified, the method for fee estimation will depend on the chosen backend and network. Must be set for neutrino on mainnet."`
+	URLPubKey        string        `long:"url-pubkey" description:"Optional hex encoded public key that the responses of the fee URL must be signed with. If set, responses are only accepted if the X-Fee-Signature header carries a valid hex encoded BIP-340 signature over the SHA-256 hash of the response body, and the body contains an issued_at unix timestamp that is at most 10 minutes old."`
 	MinUpdateTimeout time.Duration `long:"min-update-timeout" description:"The minimum interval in which fees will be updated from the specified fee URL."`
 	MaxUpdateTimeout time.Duration `long:"max-update-timeout" description:"The maximum interval in which fees will be updated from the specified fee URL."`
+	Sources          []string      `long:"source" description:"A fee source to combine with the other configured fee sources, in the form name[:weight]. Valid names are backend (the fee estimates of the chain backend), webapi (the fee API set with fee.url) and mempool (fee estimates derived from the mempool of a bitcoind backend). The estimates of all sources with a non-zero weight are averaged according to their weights, sources with a weight of 0 are only used as fallbacks in the order they are specified. The weight defaults to 1. Can be specified multiple times. If not set, a single fee source is used depending on the chosen backend and fee.url."`
+}
+
+// FeeSource is a fee source parsed from the fee.source option.
+type FeeSource struct {
+	// Name is the name of the fee source.
+	Name string
+
+	// Weight is the weight of the fee source.
+	Weight uint32
+}
+
+// ParseSources parses the configured fee sources.
+func (f *Fee) ParseSources() ([]FeeSource, error) {
+	sources := make([]FeeSource, 0, len(f.Sources))
+	names := make(map[string]struct{}, len(f.Sources))
+	for _, source := range f.Sources {
+		name, weightStr, hasWeight := strings.Cut(source, ":")
+
+		switch name {
+		case FeeSourceBackend, FeeSourceWebAPI, FeeSourceMempool:
+		default:
+			return nil, fmt.Errorf("unknown fee source %q", name)
+		}
+
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate fee source %q", name)
+		}
+		names[name] = struct{}{}
+
+		weight := uint64(1)
+		if hasWeight {
+			var err error
+			weight, err = strconv.ParseUint(weightStr, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight of fee "+
+					"source %q: %w", name, err)
+			}
+		}
+
+		sources = append(sources, FeeSource{
+			Name:   name,
+			Weight: uint32(weight),
+		})
+	}
+
+	return sources, nil
+}
+
+// ParseURLPubKey parses the public key that the responses of the fee URL must
+// be signed with. Nil is returned if no public key is configured.
+func (f *Fee) ParseURLPubKey() (*btcec.PublicKey, error) {
+	if f.URLPubKey == "" {
+		return nil, nil
+	}
+
+	pubKeyBytes, err := hex.DecodeString(f.URLPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee url pubkey: %w", err)
+	}
+
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee url pubkey: %w", err)
+	}
+
+	return pubKey, nil
+}
+
+// Validate checks the values configured for fee estimation.
+func (f *Fee) Validate() error {
+	if _, err := f.ParseSources(); err != nil {
+		return err
+	}
+
+	if f.URLPubKey != "" && f.URL == "" {
+		return fmt.Errorf("fee.url-pubkey requires fee.url to be set")
+	}
+
+	_, err := f.ParseURLPubKey()
+
+	return err
 }
@@ -0,0 +1,69 @@
+package lncfg_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseFeeSources asserts that fee sources are parsed with their weights,
+// and that invalid fee sources are rejected.
+func TestParseFeeSources(t *testing.T) {
+	t.Parallel()
+
+	cfg := &lncfg.Fee{
+		Sources: []string{"backend:3", "mempool", "webapi:0"},
+	}
+	sources, err := cfg.ParseSources()
+	require.NoError(t, err)
+	require.Equal(t, []lncfg.FeeSource{
+		{Name: lncfg.FeeSourceBackend, Weight: 3},
+		{Name: lncfg.FeeSourceMempool, Weight: 1},
+		{Name: lncfg.FeeSourceWebAPI, Weight: 0},
+	}, sources)
+
+	invalid := [][]string{
+		{"unknown"},
+		{"backend", "backend:2"},
+		{"backend:-1"},
+		{"backend:"},
+	}
+	for _, sources := range invalid {
+		cfg := &lncfg.Fee{Sources: sources}
+		require.Error(t, cfg.Validate(), "sources=%v", sources)
+	}
+}
+
+// TestParseFeeURLPubKey asserts that the public key of the fee URL is parsed,
+// and that invalid public keys are rejected.
+func TestParseFeeURLPubKey(t *testing.T) {
+	t.Parallel()
+
+	cfg := &lncfg.Fee{URL: "https://example.com/fees"}
+	pubKey, err := cfg.ParseURLPubKey()
+	require.NoError(t, err)
+	require.Nil(t, pubKey)
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	cfg.URLPubKey = hex.EncodeToString(
+		privKey.PubKey().SerializeCompressed(),
+	)
+	require.NoError(t, cfg.Validate())
+	pubKey, err = cfg.ParseURLPubKey()
+	require.NoError(t, err)
+	require.True(t, privKey.PubKey().IsEqual(pubKey))
+
+	invalid := []*lncfg.Fee{
+		{URL: "https://example.com/fees", URLPubKey: "nothex"},
+		{URL: "https://example.com/fees", URLPubKey: "02abcd"},
+		{URLPubKey: cfg.URLPubKey},
+	}
+	for _, cfg := range invalid {
+		require.Error(t, cfg.Validate(), "cfg=%v", cfg)
+	}
+}
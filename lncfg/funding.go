@@ -0,0 +1,57 @@
+package lncfg
+
+import "fmt"
+
+const (
+	// DefaultResponderFundingTimeout is the default number of blocks we
+	// wait for the funding transaction of a channel opened by a remote
+	// peer to confirm before we forget about the channel.
+	DefaultResponderFundingTimeout = 2016
+
+	// MinInitiatorFundingTimeout is the smallest non-zero number of blocks
+	// that can be configured as the funding timeout of channels we
+	// initiated. We don't allow a shorter timeout to make sure we don't
+	// give up on a funding transaction that is merely delayed by a
+	// temporary fee spike.
+	MinInitiatorFundingTimeout = 144
+)
+
+// Funding holds the configuration options for the pending channel timeout
+// and cleanup policy of the funding manager.
+//
+//nolint:lll
+type Funding struct {
+	InitiatorTimeout uint32 `long:"initiatortimeout" description:"The number of blocks after which a pending channel we initiated is forgotten if its funding transaction did not confirm. When the timeout fires, the inputs of the funding transaction are unlocked and a channel event is emitted. Set to 0 to never time out channels we initiated, which is the default."`
+
+	ResponderTimeout uint32 `long:"respondertimeout" description:"The number of blocks after which a pending channel opened by a remote peer is forgotten if its funding transaction did not confirm."`
+
+	DoubleSpendOnTimeout bool `long:"doublespendontimeout" description:"If set, the wallet inputs of a funding transaction of a timed out channel we initiated are double spent back to the wallet, making sure the funding transaction can never confirm anymore. The double spend pays a higher absolute fee than the funding transaction to be able to replace it in the mempool."`
+}
+
+// DefaultFundingConfig returns the default funding timeout configuration.
+func DefaultFundingConfig() *Funding {
+	return &Funding{
+		ResponderTimeout: DefaultResponderFundingTimeout,
+	}
+}
+
+// Validate checks the values configured for the funding timeout policy.
+func (f *Funding) Validate() error {
+	if f.InitiatorTimeout != 0 &&
+		f.InitiatorTimeout < MinInitiatorFundingTimeout {
+
+		return fmt.Errorf("initiatortimeout must be 0 or >= %v",
+			MinInitiatorFundingTimeout)
+	}
+
+	if f.ResponderTimeout == 0 {
+		return fmt.Errorf("respondertimeout must be positive")
+	}
+
+	if f.DoubleSpendOnTimeout && f.InitiatorTimeout == 0 {
+		return fmt.Errorf("doublespendontimeout requires " +
+			"initiatortimeout to be set")
+	}
+
+	return nil
+}
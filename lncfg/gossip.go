@@ -18,6 +18,8 @@ type Gossip struct {
 	ChannelUpdateInterval time.Duration `long:"channel-update-interval" description:"The interval used to determine how often lnd should allow a burst of new updates for a specific channel and direction."`
 
 	SubBatchDelay time.Duration `long:"sub-batch-delay" description:"The duration to wait before sending the next announcement batch if there are multiple. Use a small value if there are a lot announcements and they need to be broadcast quickly."`
+
+	PeerRateLimit uint32 `long:"peer-rate-limit" description:"The maximum number of gossip messages per second that lnd sends to a single peer in reply to its gossip queries and gossip timestamp filter. Peers that request full graph syncs are slowed down accordingly. Set to 0 to disable the rate limit. The limit can be overridden per peer with UpdatePeerGossipPolicy."`
 }
 
 // Parse the pubkeys for the pinned syncers.
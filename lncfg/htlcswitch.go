@@ -16,6 +16,10 @@ var (
 //nolint:lll
 type Htlcswitch struct {
 	MailboxDeliveryTimeout time.Duration `long:"mailboxdeliverytimeout" description:"The timeout value when delivering HTLCs to a channel link. Setting this value too small will result in local payment failures if large number of payments are sent over a short period."`
+
+	ResolutionPeriod time.Duration `long:"resolutionperiod" description:"The time within which forwarded HTLCs are expected to be resolved. Endorsed HTLCs that are held longer damage the local reputation of the peer that sent them. Setting this value to 0 uses the default."`
+
+	GeneralSlots uint32 `long:"generalslots" description:"The number of HTLCs that may be forwarded unendorsed over a channel at the same time. Setting this value to 0 doesn't limit the number of unendorsed HTLCs."`
 }
 
 // Validate checks the values configured for htlcswitch.
@@ -30,5 +34,11 @@ func (h *Htlcswitch) Validate() error {
 			MaxMailboxDeliveryTimeout)
 	}
 
+	// A zero resolution period is allowed, the switch uses its default
+	// in that case.
+	if h.ResolutionPeriod < 0 {
+		return fmt.Errorf("resolutionperiod must not be negative")
+	}
+
 	return nil
 }
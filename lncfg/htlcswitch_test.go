@@ -0,0 +1,39 @@
+package lncfg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHtlcswitchResolutionPeriod asserts that a zero resolution period is
+// accepted, so that the switch falls back to its default, and that only
+// negative values are rejected.
+func TestHtlcswitchResolutionPeriod(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		period time.Duration
+		valid  bool
+	}{
+		{period: 0, valid: true},
+		{period: time.Minute, valid: true},
+		{period: -time.Second, valid: false},
+	}
+
+	for _, tc := range testCases {
+		cfg := &lncfg.Htlcswitch{
+			MailboxDeliveryTimeout: time.Minute,
+			ResolutionPeriod:       tc.period,
+		}
+
+		err := cfg.Validate()
+		if tc.valid {
+			require.NoError(t, err, "period=%v", tc.period)
+		} else {
+			require.Error(t, err, "period=%v", tc.period)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package lncfg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultHWICommand is the default name of the HWI executable that is
+	// looked up in the PATH.
+	DefaultHWICommand = "hwi"
+
+	// DefaultHWIAccount is the default name of the wallet account that
+	// holds the on-chain funds of the hardware wallet.
+	DefaultHWIAccount = "hwi"
+
+	// DefaultHWITimeout is the default timeout for a single HWI call. It
+	// needs to be long enough for the user to confirm a transaction on the
+	// device.
+	DefaultHWITimeout = 5 * time.Minute
+)
+
+// HWI holds the configuration options for signing on-chain transactions with
+// a hardware wallet through the Hardware Wallet Interface (HWI) tool.
+//
+//nolint:lll
+type HWI struct {
+	Active      bool          `long:"active" description:"Use a hardware wallet through HWI for receiving and signing on-chain funds. Channel keys are still derived from the seed of the lnd wallet."`
+	Command     string        `long:"command" description:"The HWI executable to call, either as an absolute path or a name that is looked up in the PATH"`
+	Fingerprint string        `long:"fingerprint" description:"The hex encoded master key fingerprint of the hardware wallet, as reported by 'hwi enumerate'"`
+	Account     string        `long:"account" description:"The name of the wallet account the hardware wallet's funds are tracked in. The account is imported from the device on first startup."`
+	Timeout     time.Duration `long:"timeout" description:"The timeout for a single call to the hardware wallet, including the time needed to confirm a transaction on the device. Valid time units are {s, m, h}."`
+}
+
+// DefaultHWI returns the default HWI configuration.
+func DefaultHWI() *HWI {
+	return &HWI{
+		Command: DefaultHWICommand,
+		Account: DefaultHWIAccount,
+		Timeout: DefaultHWITimeout,
+	}
+}
+
+// Validate checks the values configured for the hardware wallet.
+func (h *HWI) Validate() error {
+	if !h.Active {
+		return nil
+	}
+
+	fingerprint, err := hex.DecodeString(h.Fingerprint)
+	if err != nil || len(fingerprint) != 4 {
+		return fmt.Errorf("hwi: invalid fingerprint '%s', must be 4 "+
+			"bytes hex encoded", h.Fingerprint)
+	}
+
+	if h.Command == "" {
+		return fmt.Errorf("hwi: command cannot be empty")
+	}
+
+	if h.Account == "" {
+		return fmt.Errorf("hwi: account cannot be empty")
+	}
+
+	if h.Timeout < time.Second {
+		return fmt.Errorf("hwi: timeout of %v is invalid, cannot be "+
+			"smaller than %v", h.Timeout, time.Second)
+	}
+
+	return nil
+}
@@ -1,5 +1,7 @@
 package lncfg
 
+import "time"
+
 // DefaultHoldInvoiceExpiryDelta defines the number of blocks before the expiry
 // height of a hold invoice's htlc that lnd will automatically cancel the
 // invoice to prevent the channel from force closing. This value *must* be
@@ -11,4 +13,6 @@ const DefaultHoldInvoiceExpiryDelta = DefaultIncomingBroadcastDelta + 2
 //nolint:lll
 type Invoices struct {
 	HoldExpiryDelta uint32 `long:"holdexpirydelta" description:"The number of blocks before a hold invoice's htlc expires that the invoice should be canceled to prevent a force close. Force closes will not be prevented if this value is not greater than DefaultIncomingBroadcastDelta."`
+
+	HoldExpiryGracePeriod time.Duration `long:"holdexpirygraceperiod" description:"The time after a hold invoice's expiry that the invoice is canceled and its accepted htlcs are failed, instead of holding them until holdexpirydelta blocks before they expire. Set to 0 to disable."`
 }
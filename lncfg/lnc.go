@@ -0,0 +1,44 @@
+package lncfg
+
+import (
+	"fmt"
+	"net"
+)
+
+const (
+	// DefaultLNCMailboxServer is the default mailbox server Lightning Node
+	// Connect clients are paired through.
+	DefaultLNCMailboxServer = "mailbox.terminal.lightning.today:443"
+)
+
+// LNC holds the configuration of the built-in Lightning Node Connect mailbox
+// transport.
+//
+//nolint:lll
+type LNC struct {
+	Active            bool   `long:"active" description:"Serve the gRPC API through an end-to-end encrypted Lightning Node Connect mailbox, so browser and mobile clients can pair with the node using a pairing phrase without access to the RPC port."`
+	MailboxServer     string `long:"mailboxserver" description:"The host:port of the mailbox server that relays the encrypted traffic between the node and its clients."`
+	PairingPhraseFile string `long:"pairingphrasefile" description:"Path to the file the pairing phrase is stored in. A new pairing phrase is created if the file doesn't exist. Delete the file and restart lnd to revoke the pairing phrase. Defaults to lnc-pairing-phrase in the network directory."`
+	ReadOnly          bool   `long:"readonly" description:"Hand out a read-only macaroon instead of an admin macaroon to clients that pair with the node."`
+}
+
+// Validate checks the values configured for Lightning Node Connect.
+func (l *LNC) Validate() error {
+	if !l.Active {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(l.MailboxServer); err != nil {
+		return fmt.Errorf("invalid LNC mailbox server %q: %w",
+			l.MailboxServer, err)
+	}
+
+	return nil
+}
+
+// DefaultLNC returns the default configuration of Lightning Node Connect.
+func DefaultLNC() *LNC {
+	return &LNC{
+		MailboxServer: DefaultLNCMailboxServer,
+	}
+}
@@ -0,0 +1,49 @@
+package lncfg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Logging holds the configuration options of the log output.
+//
+//nolint:lll
+type Logging struct {
+	JSON bool `long:"json" description:"Write the log entries as JSON objects, one per line, instead of plain text lines. Every entry has the fields time, level, subsystem and msg. Entries of the lifecycle of a payment have a trace_id field that ties them together across the payment, switch and peer subsystems."`
+
+	Sample []string `long:"sample" description:"Only write a fraction of the log entries below the warn level of a subsystem, in the form <subsystem>=<rate> where the rate is between 0 and 1, e.g. HSWC=0.1 to write every tenth entry of the switch. Warnings and errors are always written. Can be specified multiple times."`
+}
+
+// DefaultLogging returns the default configuration of the log output.
+func DefaultLogging() *Logging {
+	return &Logging{}
+}
+
+// SampleRates parses the configured sampling rates of the subsystems.
+func (l *Logging) SampleRates() (map[string]float64, error) {
+	rates := make(map[string]float64, len(l.Sample))
+	for _, sample := range l.Sample {
+		subsystem, rateStr, ok := strings.Cut(sample, "=")
+		if !ok || subsystem == "" {
+			return nil, fmt.Errorf("invalid log sample %q, use "+
+				"the format <subsystem>=<rate>", sample)
+		}
+
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid log sample rate %q of "+
+				"subsystem %v, must be between 0 and 1",
+				rateStr, subsystem)
+		}
+
+		if _, ok := rates[subsystem]; ok {
+			return nil, fmt.Errorf("duplicate log sample rate of "+
+				"subsystem %v", subsystem)
+		}
+
+		rates[subsystem] = rate
+	}
+
+	return rates, nil
+}
@@ -12,9 +12,9 @@ type Prometheus struct {
 	// Prometheus server to scrape our metrics.
 	Listen string `long:"listen" description:"the interface we should listen on for Prometheus"`
 
-	// Enable indicates whether to export lnd gRPC performance metrics to
-	// Prometheus. Default is false.
-	Enable bool `long:"enable" description:"enable Prometheus exporting of lnd gRPC performance metrics."`
+	// Enable indicates whether to export lnd gRPC performance metrics and
+	// the metrics of the node to Prometheus. Default is false.
+	Enable bool `long:"enable" description:"enable Prometheus exporting of lnd gRPC performance metrics and of the node metrics (HTLC switch throughput, path finding latency, sweep fees, database transaction latency and peer counts)."`
 
 	// PerfHistograms indicates if the additional histogram information for
 	// latency, and handling time of gRPC calls should be enabled. This
@@ -1,6 +1,28 @@
 package lncfg
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultGraphValidationWorkers is the default maximum number of
+	// concurrent block fetches used to validate channel announcements in
+	// strict graph validation mode.
+	DefaultGraphValidationWorkers = 4
+
+	// DefaultGraphValidationCacheSize is the default number of funding
+	// transactions that are cached in strict graph validation mode.
+	DefaultGraphValidationCacheSize = 10000
+
+	// DefaultNeutrinoFeeFloor is the default lowest fee rate in sat/vbyte
+	// that is estimated from recent blocks.
+	DefaultNeutrinoFeeFloor = 1
+
+	// DefaultNeutrinoFeeWindow is the default number of recent blocks fee
+	// estimates are derived from.
+	DefaultNeutrinoFeeWindow = 12
+)
 
 // Neutrino holds the configuration options for the daemon's connection to
 // neutrino.
@@ -18,4 +40,34 @@ type Neutrino struct {
 	ValidateChannels   bool          `long:"validatechannels" description:"Validate every channel in the graph during sync by downloading the containing block. This is the inverse of routing.assumechanvalid, meaning that for Neutrino the validation is turned off by default for massively increased graph sync performance. This speedup comes at the risk of using an unvalidated view of the network for routing. Overwrites the value of routing.assumechanvalid if Neutrino is used. (default: false)"`
 	BroadcastTimeout   time.Duration `long:"broadcasttimeout" description:"The amount of time to wait before giving up on a transaction broadcast attempt."`
 	PersistFilters     bool          `long:"persistfilters" description:"Whether compact filters fetched from the P2P network should be persisted to disk."`
+
+	StrictGraphValidation    bool `long:"strictgraphvalidation" description:"Validate the funding output of every announced channel by fetching the block it was confirmed in and checking that the output is unspent. This implies neutrino.validatechannels, but bounds the number of concurrent block fetches and caches the fetched funding transactions to protect the node from graph spam without overwhelming the P2P network. Can't be used together with routing.assumechanvalid."`
+	GraphValidationWorkers   int  `long:"graphvalidationworkers" description:"The maximum number of blocks that are fetched concurrently to validate channel announcements if neutrino.strictgraphvalidation is set."`
+	GraphValidationCacheSize int  `long:"graphvalidationcachesize" description:"The number of funding transactions of validated channels that are cached if neutrino.strictgraphvalidation is set. Set to 0 to disable the cache."`
+
+	FeeFloor  uint64 `long:"feefloor" description:"The lowest fee rate in sat/vbyte that is estimated from the fee rates of recent blocks. Fee estimates of neutrino nodes are derived from recent blocks unless fee.source is set, with fee.url as a fallback if it is set."`
+	FeeWindow uint32 `long:"feewindow" description:"The number of recent blocks that fee estimates are derived from."`
+}
+
+// Validate checks the values configured for the neutrino backend.
+func (n *Neutrino) Validate() error {
+	if n.FeeWindow == 0 {
+		return fmt.Errorf("feewindow must be positive")
+	}
+
+	if !n.StrictGraphValidation {
+		return nil
+	}
+
+	if n.GraphValidationWorkers <= 0 {
+		return fmt.Errorf("graphvalidationworkers must be positive, "+
+			"got %d", n.GraphValidationWorkers)
+	}
+
+	if n.GraphValidationCacheSize < 0 {
+		return fmt.Errorf("graphvalidationcachesize must not be "+
+			"negative, got %d", n.GraphValidationCacheSize)
+	}
+
+	return nil
 }
@@ -7,3 +7,9 @@ package lncfg
 // features that also require a build-tag to activate.
 type ExperimentalProtocol struct {
 }
+
+// PtlcEnabled returns true if the experimental support for point time locked
+// contracts is enabled. PTLCs can only be enabled with the dev build tag.
+func (p ExperimentalProtocol) PtlcEnabled() bool {
+	return false
+}
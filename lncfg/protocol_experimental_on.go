@@ -5,5 +5,16 @@ package lncfg
 
 // ExperimentalProtocol is a sub-config that houses any experimental protocol
 // features that also require a build-tag to activate.
+//
+//nolint:lll
 type ExperimentalProtocol struct {
+	// Ptlc should be set if we want to enable the experimental support
+	// for point time locked contracts on taproot channels.
+	Ptlc bool `long:"ptlc" description:"if set, then lnd will signal support for and accept point time locked contracts (PTLCs) on simple taproot channels"`
+}
+
+// PtlcEnabled returns true if the experimental support for point time locked
+// contracts is enabled.
+func (p ExperimentalProtocol) PtlcEnabled() bool {
+	return p.Ptlc
 }
@@ -66,6 +66,23 @@ type ProtocolOptions struct {
 	// NoRouteBlindingOption disables forwarding of payments in blinded routes.
 	NoRouteBlindingOption bool `long:"no-route-blinding" description:"do not forward payments that are a part of a blinded route"`
 
+	// NoOnionMessagesOption disables relaying and receiving onion
+	// messages.
+	NoOnionMessagesOption bool `long:"no-onion-messages" description:"do not relay or receive onion messages"`
+
+	// NoPeerStorageOption disables storing blobs for our peers and
+	// distributing our channel backup to them.
+	NoPeerStorageOption bool `long:"no-peer-storage" description:"do not store backups for peers or distribute our channel backup to peers"`
+
+	// RbfCoopClose should be set if we want to signal support for the RBF
+	// based cooperative close protocol.
+	RbfCoopClose bool `long:"rbf-coop-close" description:"if set, then lnd will signal support for the experimental RBF based co-op close protocol, which allows either party to bump the fee of a pending co-op close"`
+
+	// SplitCloseOutputs should be set if we want to signal support for
+	// splitting the settled balance of a co-op close across additional
+	// close outputs.
+	SplitCloseOutputs bool `long:"split-close-outputs" description:"if set, then lnd will signal support for the experimental split close outputs extension of the co-op close protocol, which allows either party to split its settled balance across multiple close outputs"`
+
 	// CustomMessage allows the custom message APIs to handle messages with
 	// the provided protocol numbers, which fall outside the custom message
 	// number range.
@@ -118,11 +135,59 @@ func (l *ProtocolOptions) NoAnySegwit() bool {
 	return l.NoOptionAnySegwit
 }
 
+// RbfCoopCloseEnabled returns true if we signal support for the RBF based
+// cooperative close protocol.
+func (l *ProtocolOptions) RbfCoopCloseEnabled() bool {
+	return l.RbfCoopClose
+}
+
+// SplitCloseOutputsEnabled returns true if we signal support for splitting
+// the settled balance of a co-op close across additional close outputs.
+func (l *ProtocolOptions) SplitCloseOutputsEnabled() bool {
+	return l.SplitCloseOutputs
+}
+
 // NoRouteBlinding returns true if forwarding of blinded payments is disabled.
 func (l *ProtocolOptions) NoRouteBlinding() bool {
 	return l.NoRouteBlindingOption
 }
 
+// NoOnionMessages returns true if relaying and receiving onion messages is
+// disabled. This is also the case if onion messages are handled by an external
+// application through the custom message APIs.
+func (l *ProtocolOptions) NoOnionMessages() bool {
+	if l.NoOnionMessagesOption {
+		return true
+	}
+
+	for _, msgType := range l.CustomMessage {
+		if msgType == lnwire.MsgOnionMessage {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NoPeerStorage returns true if storing blobs for our peers and distributing
+// our channel backup to them is disabled. This is also the case if the peer
+// storage messages are handled by an external application through the custom
+// message APIs.
+func (l *ProtocolOptions) NoPeerStorage() bool {
+	if l.NoPeerStorageOption {
+		return true
+	}
+
+	for _, msgType := range l.CustomMessage {
+		switch msgType {
+		case lnwire.MsgPeerStorage, lnwire.MsgPeerStorageRetrieval:
+			return true
+		}
+	}
+
+	return false
+}
+
 // CustomMessageOverrides returns the set of protocol messages that we override
 // to allow custom handling.
 func (l ProtocolOptions) CustomMessageOverrides() []uint16 {
@@ -1,5 +1,10 @@
 package lncfg
 
+import (
+	"fmt"
+	"time"
+)
+
 // Routing holds the configuration options for routing.
 //
 //nolint:lll
@@ -7,4 +12,52 @@ type Routing struct {
 	AssumeChannelValid bool `long:"assumechanvalid" description:"DEPRECATED: Skip checking channel spentness during graph validation. This speedup comes at the risk of using an unvalidated view of the network for routing. (default: false)" hidden:"true"`
 
 	StrictZombiePruning bool `long:"strictgraphpruning" description:"If true, then the graph will be pruned more aggressively for zombies. In practice this means that edges with a single stale edge will be considered a zombie."`
+
+	ZombieHorizon time.Duration `long:"zombiehorizon" description:"The duration after which a channel without any channel update is considered a zombie and pruned from the graph."`
+
+	NewChanZombieHorizon time.Duration `long:"newchanzombiehorizon" description:"The zombie horizon of channels that were confirmed less than newchanage blocks ago. If not set, the zombiehorizon applies to all channels."`
+
+	NewChanAge uint32 `long:"newchanage" description:"The number of blocks since their confirmation for which channels are subject to the newchanzombiehorizon."`
+
+	GraphPruneInterval time.Duration `long:"graphpruneinterval" description:"The interval at which the graph is pruned of zombie channels."`
+
+	MaxHtlcPercent uint32 `long:"maxhtlcpercent" description:"If set, the max_htlc of each channel is automatically kept at this percentage of its spendable outbound balance, so that senders don't attempt to route HTLCs over depleted channels. Overrides any max_htlc set with updatechanpolicy. Set to 0 to disable."`
+
+	MaxHtlcMinChange uint32 `long:"maxhtlcminchange" description:"The minimum change of the max_htlc of a channel, in percent of its current value, for which a new channel update is announced if maxhtlcpercent is set."`
+
+	MaxHtlcInterval time.Duration `long:"maxhtlcinterval" description:"The interval at which the max_htlc of all channels is updated if maxhtlcpercent is set. Should not be smaller than gossip.channel-update-interval, so that the channel updates aren't rate limited by our peers."`
+}
+
+// Validate checks the values configured for the graph pruning.
+func (r *Routing) Validate() error {
+	if r.ZombieHorizon <= 0 {
+		return fmt.Errorf("zombiehorizon must be positive")
+	}
+
+	if r.NewChanZombieHorizon < 0 {
+		return fmt.Errorf("newchanzombiehorizon must not be negative")
+	}
+
+	if r.NewChanZombieHorizon != 0 && r.NewChanAge == 0 {
+		return fmt.Errorf("newchanage must be set if " +
+			"newchanzombiehorizon is set")
+	}
+
+	if r.GraphPruneInterval <= 0 {
+		return fmt.Errorf("graphpruneinterval must be positive")
+	}
+
+	if r.MaxHtlcPercent > 100 {
+		return fmt.Errorf("maxhtlcpercent must not exceed 100")
+	}
+
+	if r.MaxHtlcMinChange > 100 {
+		return fmt.Errorf("maxhtlcminchange must not exceed 100")
+	}
+
+	if r.MaxHtlcPercent != 0 && r.MaxHtlcInterval <= 0 {
+		return fmt.Errorf("maxhtlcinterval must be positive")
+	}
+
+	return nil
 }
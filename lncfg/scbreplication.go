@@ -0,0 +1,101 @@
+package lncfg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chanbackup"
+)
+
+// SCBReplication holds the configuration options for replicating the static
+// channel backup file to remote targets.
+//
+//nolint:lll
+type SCBReplication struct {
+	WebhookURLs    []string `long:"webhook" description:"A URL that the channel backup is uploaded to with an HTTP PUT request every time it is updated. The SHA-256 digest of the backup is sent in the X-Backup-Sha256 header, and the webhook must echo the digest of the stored backup in its response. Can be specified multiple times."`
+	WebhookHeaders []string `long:"webhookheader" description:"An HTTP header in the form 'Name: value' that is added to every request to the webhooks, for example to authenticate. Can be specified multiple times."`
+
+	S3Endpoint        string `long:"s3endpoint" description:"The URL of the S3-compatible object storage service the channel backup is uploaded to, for example https://s3.us-east-1.amazonaws.com."`
+	S3Region          string `long:"s3region" description:"The region of the S3 bucket."`
+	S3Bucket          string `long:"s3bucket" description:"The name of the S3 bucket the channel backup is uploaded to. Enables S3 replication if set."`
+	S3Key             string `long:"s3key" description:"The object key the channel backup is stored under in the S3 bucket."`
+	S3AccessKeyID     string `long:"s3accesskeyid" description:"The ID of the access key used to authenticate with the S3 service."`
+	S3SecretAccessKey string `long:"s3secretaccesskey" description:"The secret of the access key used to authenticate with the S3 service."`
+
+	SFTPAddr           string `long:"sftpaddr" description:"The host:port of the SFTP server the channel backup is uploaded to. Enables SFTP replication if set."`
+	SFTPUser           string `long:"sftpuser" description:"The user to log in to the SFTP server as."`
+	SFTPPrivateKeyPath string `long:"sftpprivatekeypath" description:"The path of the SSH private key used to authenticate with the SFTP server."`
+	SFTPKnownHostsPath string `long:"sftpknownhostspath" description:"The path of the known_hosts file used to authenticate the SFTP server."`
+	SFTPPath           string `long:"sftppath" description:"The remote path the channel backup is stored at on the SFTP server."`
+
+	Timeout    time.Duration `long:"timeout" description:"The time after which an attempt to upload the channel backup to a target is aborted."`
+	MinBackoff time.Duration `long:"minbackoff" description:"The time to wait before retrying a failed upload for the first time. The wait time is doubled after every failed attempt."`
+	MaxBackoff time.Duration `long:"maxbackoff" description:"The maximum time to wait between two attempts to upload the channel backup to a target."`
+}
+
+// DefaultSCBReplication returns the default SCB replication config, which
+// doesn't replicate the backup to any target.
+func DefaultSCBReplication() *SCBReplication {
+	return &SCBReplication{
+		Timeout:    chanbackup.DefaultReplicationTimeout,
+		MinBackoff: chanbackup.DefaultMinReplicationBackoff,
+		MaxBackoff: chanbackup.DefaultMaxReplicationBackoff,
+	}
+}
+
+// S3Enabled returns true if the backup should be replicated to S3.
+func (s *SCBReplication) S3Enabled() bool {
+	return s.S3Bucket != ""
+}
+
+// SFTPEnabled returns true if the backup should be replicated to an SFTP
+// server.
+func (s *SCBReplication) SFTPEnabled() bool {
+	return s.SFTPAddr != ""
+}
+
+// Validate checks the values configured for the SCB replication.
+//
+// NOTE: Part of the Validator interface.
+func (s *SCBReplication) Validate() error {
+	if len(s.WebhookURLs) == 0 && !s.S3Enabled() && !s.SFTPEnabled() {
+		return nil
+	}
+
+	if s.Timeout <= 0 {
+		return fmt.Errorf("scbreplication.timeout must be positive")
+	}
+
+	if s.MinBackoff <= 0 {
+		return fmt.Errorf("scbreplication.minbackoff must be positive")
+	}
+
+	if s.MaxBackoff < s.MinBackoff {
+		return fmt.Errorf("scbreplication.maxbackoff must not be " +
+			"smaller than scbreplication.minbackoff")
+	}
+
+	if s.S3Enabled() && (s.S3Endpoint == "" || s.S3Region == "" ||
+		s.S3Key == "" || s.S3AccessKeyID == "" ||
+		s.S3SecretAccessKey == "") {
+
+		return fmt.Errorf("scbreplication.s3endpoint, s3region, " +
+			"s3key, s3accesskeyid and s3secretaccesskey must be " +
+			"set to replicate to S3")
+	}
+
+	if s.SFTPEnabled() && (s.SFTPUser == "" ||
+		s.SFTPPrivateKeyPath == "" || s.SFTPKnownHostsPath == "" ||
+		s.SFTPPath == "") {
+
+		return fmt.Errorf("scbreplication.sftpuser, " +
+			"sftpprivatekeypath, sftpknownhostspath and sftppath " +
+			"must be set to replicate to an SFTP server")
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure SCBReplication implements the Validator
+// interface.
+var _ Validator = (*SCBReplication)(nil)
@@ -0,0 +1,34 @@
+package lncfg
+
+import "fmt"
+
+// SignerPolicy holds the configuration options of the policy a signer node
+// enforces before signing any transaction that spends funds of its on-chain
+// wallet.
+//
+//nolint:lll
+type SignerPolicy struct {
+	MaxOutputValuePerHour uint64   `long:"maxoutputvalueperhour" description:"The maximum total value in satoshis of outputs that don't pay back to the wallet, plus the fees paid, the signer signs for within any one hour window. Transactions with a valid co-signature don't count towards the limit. Set to 0 to disable the limit."`
+	MaxFee                uint64   `long:"maxfee" description:"The maximum absolute fee in satoshis a transaction spending wallet funds may pay. Set to 0 to disable the limit."`
+	MaxFeeRate            uint64   `long:"maxfeerate" description:"The maximum fee rate in sat/vbyte a transaction spending wallet funds may pay. The fee rate is calculated for the transaction once it is signed. Set to 0 to disable the limit."`
+	AllowedScriptClasses  []string `long:"allowedscriptclass" description:"An output script class that outputs not paying back to the wallet are allowed to use. Can be specified multiple times. If not set, all script classes are allowed." choice:"p2pkh" choice:"p2sh" choice:"p2wkh" choice:"p2wsh" choice:"p2tr" choice:"op_return"`
+	CoSignThreshold       uint64   `long:"cosignthreshold" description:"The value in satoshis from which on P2WSH and P2TR outputs that don't pay back to the wallet, such as channel funding outputs, require a co-signature of the co-signer key in the PSBT. Set to 0 to never require a co-signature."`
+	CoSignerPubKey        string   `long:"cosignerpubkey" description:"The hex encoded public key of the co-signer whose signature over the transaction ID is required for outputs above the co-sign threshold."`
+}
+
+// Enabled returns true if any of the policy rules is configured.
+func (s *SignerPolicy) Enabled() bool {
+	return s.MaxOutputValuePerHour > 0 || s.MaxFee > 0 ||
+		s.MaxFeeRate > 0 || len(s.AllowedScriptClasses) > 0 ||
+		s.CoSignThreshold > 0
+}
+
+// Validate checks the values configured for the signer policy.
+func (s *SignerPolicy) Validate() error {
+	if s.CoSignThreshold > 0 && s.CoSignerPubKey == "" {
+		return fmt.Errorf("signer policy: a co-signer public key is " +
+			"required if the co-sign threshold is set")
+	}
+
+	return nil
+}
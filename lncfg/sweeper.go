@@ -17,6 +17,10 @@ const (
 	// MaxAllowedFeeRate is the largest fee rate in sat/vb that we allow
 	// when configuring the MaxFeeRate.
 	MaxAllowedFeeRate = 10_000
+
+	// MaxHTLCAggregationWindow is the largest number of blocks that we
+	// allow when configuring the HTLCAggregationWindow.
+	MaxHTLCAggregationWindow = 144
 )
 
 //nolint:lll
@@ -26,6 +30,8 @@ type Sweeper struct {
 
 	NoDeadlineConfTarget uint32 `long:"nodeadlineconftarget" description:"The conf target to use when sweeping non-time-sensitive outputs. This is useful for sweeping outputs that are not time-sensitive, and can be swept at a lower fee rate."`
 
+	HTLCAggregationWindow uint32 `long:"htlcaggregationwindow" description:"The number of blocks by which the deadlines of second-level HTLC claims may differ for them to still be swept in the same transaction, for example after many channels were force closed. The aggregated claims are swept using the earliest of their deadlines. Zero means only claims with the same deadline are swept together."`
+
 	Budget *contractcourt.BudgetConfig `group:"sweeper.budget" namespace:"budget" long:"budget" description:"An optional config group that's used for the automatic sweep fee estimation. The Budget config gives options to limits ones fee exposure when sweeping unilateral close outputs and the fee rate calculated from budgets is capped at sweeper.maxfeerate. Check the budget config options for more details."`
 }
 
@@ -50,6 +56,11 @@ func (s *Sweeper) Validate() error {
 		return fmt.Errorf("nodeadlineconftarget must be at least 144")
 	}
 
+	if s.HTLCAggregationWindow > MaxHTLCAggregationWindow {
+		return fmt.Errorf("htlcaggregationwindow must be <= %v",
+			MaxHTLCAggregationWindow)
+	}
+
 	// Validate the budget configuration.
 	if err := s.Budget.Validate(); err != nil {
 		return fmt.Errorf("invalid budget config: %w", err)
@@ -2,6 +2,7 @@ package lncfg
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/lightningnetwork/lnd/watchtower/wtclient"
 	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
@@ -31,6 +32,11 @@ type WtClient struct {
 	// MaxUpdates is the maximum number of updates to be backed up in a
 	// single tower sessions.
 	MaxUpdates uint16 `long:"max-updates" description:"The maximum number of updates to be backed up in a single session."`
+
+	// HealthCheckInterval is the interval at which all active towers are
+	// probed to check that they are still reachable. Probes are disabled
+	// if it is zero.
+	HealthCheckInterval time.Duration `long:"health-check-interval" description:"The interval at which all active towers are probed to check that they are still reachable. Set to 0 to disable the probes."`
 }
 
 // DefaultWtClientCfg returns the WtClient config struct with some default
@@ -70,6 +76,10 @@ func (c *WtClient) Validate() error {
 		return fmt.Errorf("session-close-range must be non-zero")
 	}
 
+	if c.HealthCheckInterval < 0 {
+		return fmt.Errorf("health-check-interval must not be negative")
+	}
+
 	return nil
 }
 
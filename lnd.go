@@ -25,6 +25,7 @@ import (
 	"github.com/lightningnetwork/lnd/chanacceptor"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnc"
 	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -336,12 +337,12 @@ func Main(cfg *Config, lisCfg ListenerCfg, implCfg *ImplementationCfg,
 	}
 
 	rpcServerOpts := interceptorChain.CreateServerOpts()
-	serverOpts = append(serverOpts, rpcServerOpts...)
-	serverOpts = append(
-		serverOpts, grpc.MaxRecvMsgSize(lnrpc.MaxGrpcMsgSize),
+	rpcServerOpts = append(
+		rpcServerOpts, grpc.MaxRecvMsgSize(lnrpc.MaxGrpcMsgSize),
 		grpc.KeepaliveParams(serverKeepalive),
 		grpc.KeepaliveEnforcementPolicy(clientKeepalive),
 	)
+	serverOpts = append(serverOpts, rpcServerOpts...)
 
 	grpcServer := grpc.NewServer(serverOpts...)
 	defer grpcServer.Stop()
@@ -585,6 +586,12 @@ func Main(cfg *Config, lisCfg ListenerCfg, implCfg *ImplementationCfg,
 		return mkErr("unable to create server: %v", err)
 	}
 
+	// If enabled, evaluate the channel acceptor policy configured over RPC
+	// alongside any external channel acceptors.
+	if server.acceptorPolicy != nil {
+		multiAcceptor.AddAcceptor(server.acceptorPolicy)
+	}
+
 	// Set up an autopilot manager from the current config. This will be
 	// used to manage the underlying autopilot agent, starting and stopping
 	// it at will.
@@ -628,6 +635,18 @@ func Main(cfg *Config, lisCfg ListenerCfg, implCfg *ImplementationCfg,
 	// We transition the RPC state to Active, as the RPC server is up.
 	interceptorChain.SetRPCActive()
 
+	// If Lightning Node Connect is active, we also serve the gRPC API to
+	// clients that pair with the node through the mailbox server.
+	if cfg.LNC.Active {
+		stopLNC, err := startLNCListener(
+			ctx, cfg, interceptorChain, rpcServer, rpcServerOpts,
+		)
+		if err != nil {
+			return mkErr("unable to start LNC listener: %v", err)
+		}
+		defer stopLNC()
+	}
+
 	if err := interceptor.Notifier.NotifyReady(true); err != nil {
 		return mkErr("error notifying ready: %v", err)
 	}
@@ -683,6 +702,23 @@ func Main(cfg *Config, lisCfg ListenerCfg, implCfg *ImplementationCfg,
 	// We transition the server state to Active, as the server is up.
 	interceptorChain.SetServerActive()
 
+	// If Prometheus monitoring is enabled, we start collecting the metrics
+	// of the running node.
+	if cfg.Prometheus.Enabled() {
+		htlcNotifier := server.htlcNotifier
+		metricsCfg := monitoring.NodeMetricsConfig{
+			NumPeers: func() int {
+				return len(server.Peers())
+			},
+			SubscribeHtlcEvents: htlcNotifier.SubscribeHtlcEvents,
+		}
+		stopMetrics, err := monitoring.StartNodeMetrics(metricsCfg)
+		if err != nil {
+			return mkErr("unable to start node metrics: %v", err)
+		}
+		defer stopMetrics()
+	}
+
 	// Now that the server has started, if the autopilot mode is currently
 	// active, then we'll start the autopilot agent immediately. It will be
 	// stopped together with the autopilot service.
@@ -845,6 +881,63 @@ func startGrpcListen(cfg *Config, grpcServer *grpc.Server,
 	return nil
 }
 
+// startLNCListener starts a gRPC server that serves the clients that pair with
+// the node through the Lightning Node Connect mailbox server. The returned
+// function stops the server.
+func startLNCListener(ctx context.Context, cfg *Config,
+	interceptorChain *rpcperms.InterceptorChain, rpcServer *rpcServer,
+	serverOpts []grpc.ServerOption) (func(), error) {
+
+	phrase, err := lnc.ReadOrCreatePairingPhrase(cfg.LNC.PairingPhraseFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load pairing phrase: %w", err)
+	}
+
+	// Paired clients can't read the macaroon files of the node, so we send
+	// them the macaroon to use as part of the handshake.
+	var authData []byte
+	if macService := interceptorChain.MacaroonService(); macService != nil {
+		permissions := adminPermissions()
+		if cfg.LNC.ReadOnly {
+			permissions = readPermissions
+		}
+
+		authData, err = bakeMacaroon(ctx, macService, permissions)
+		if err != nil {
+			return nil, fmt.Errorf("unable to bake LNC "+
+				"macaroon: %w", err)
+		}
+	}
+
+	// The connections are encrypted and authenticated end-to-end by the
+	// handshake, so this server doesn't use TLS. Apart from that, it is
+	// set up just like the main gRPC server.
+	lncServer := grpc.NewServer(serverOpts...)
+	lnrpc.RegisterStateServer(lncServer, interceptorChain)
+	if err := rpcServer.RegisterWithGrpcServer(lncServer); err != nil {
+		return nil, err
+	}
+
+	listener, err := lnc.NewListener(&lnc.ListenerConfig{
+		Mailbox:       lnc.NewHashMailbox(cfg.LNC.MailboxServer),
+		PairingPhrase: phrase,
+		AuthData:      authData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		_ = lncServer.Serve(listener)
+	}()
+
+	rpcsLog.Infof("Lightning Node Connect active through mailbox server "+
+		"%v, pairing phrase stored in %v", cfg.LNC.MailboxServer,
+		cfg.LNC.PairingPhraseFile)
+
+	return lncServer.Stop, nil
+}
+
 // startRestProxy starts the given REST proxy on the listeners found in the
 // config.
 func startRestProxy(cfg *Config, rpcServer *rpcServer, restDialOpts []grpc.DialOption,
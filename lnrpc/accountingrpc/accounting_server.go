@@ -0,0 +1,443 @@
+//go:build accountingrpc
+// +build accountingrpc
+
+package accountingrpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/labels"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	// subServerName is the name of the sub rpc server. We'll use this name
+	// to register ourselves, and we also require that the main
+	// SubServerConfigDispatcher instance recognize it as the name of our
+	// RPC service.
+	subServerName = "AccountingRPC"
+
+	// queryBatchSize is the number of payments and invoices that are
+	// fetched from the database at once.
+	queryBatchSize = 1000
+)
+
+var (
+	// macPermissions maps RPC calls to the permissions they require. As
+	// the ledger contains on-chain and off-chain events, reading both is
+	// required.
+	macPermissions = map[string][]bakery.Op{
+		"/accountingrpc.Accounting/ExportLedger": {{
+			Entity: "onchain",
+			Action: "read",
+		}, {
+			Entity: "offchain",
+			Action: "read",
+		}, {
+			Entity: "invoices",
+			Action: "read",
+		}},
+	}
+)
+
+// ServerShell is a shell struct holding a reference to the actual sub-server.
+// It is used to register the gRPC sub-server with the root server before we
+// have the necessary dependencies to populate the actual sub-server.
+type ServerShell struct {
+	AccountingServer
+}
+
+// Server is a sub-server of the main RPC server: the accounting RPC. This sub
+// RPC server exports the financial history of the node.
+type Server struct {
+	started  int32 // To be used atomically.
+	shutdown int32 // To be used atomically.
+
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	// Must be after the atomically used variables to not break struct
+	// alignment.
+	UnimplementedAccountingServer
+
+	cfg *Config
+}
+
+// A compile time check to ensure that Server fully implements the
+// AccountingServer gRPC service.
+var _ AccountingServer = (*Server)(nil)
+
+// New returns a new instance of the accountingrpc Accounting sub-server. We
+// also return the set of permissions for the macaroons that we may create
+// within this method.
+func New(cfg *Config) (*Server, lnrpc.MacaroonPerms, error) {
+	server := &Server{
+		cfg: cfg,
+	}
+
+	return server, macPermissions, nil
+}
+
+// Start launches any helper goroutines required for the Server to function.
+//
+// NOTE: This is part of the lnrpc.SubServer interface.
+func (s *Server) Start() error {
+	if atomic.AddInt32(&s.started, 1) != 1 {
+		return nil
+	}
+
+	return nil
+}
+
+// Stop signals any active goroutines for a graceful closure.
+//
+// NOTE: This is part of the lnrpc.SubServer interface.
+func (s *Server) Stop() error {
+	if atomic.AddInt32(&s.shutdown, 1) != 1 {
+		return nil
+	}
+
+	return nil
+}
+
+// Name returns a unique string representation of the sub-server. This can be
+// used to identify the sub-server and also de-duplicate them.
+//
+// NOTE: This is part of the lnrpc.SubServer interface.
+func (s *Server) Name() string {
+	return subServerName
+}
+
+// RegisterWithRootServer will be called by the root gRPC server to direct a
+// sub RPC server to register itself with the main gRPC root server. Until this
+// is called, each sub-server won't be able to have requests routed towards it.
+//
+// NOTE: This is part of the lnrpc.GrpcHandler interface.
+func (r *ServerShell) RegisterWithRootServer(grpcServer *grpc.Server) error {
+	// We make sure that we register it with the main gRPC server to ensure
+	// all our methods are routed properly.
+	RegisterAccountingServer(grpcServer, r)
+
+	log.Debugf("Accounting RPC server successfully registered with root " +
+		"gRPC server")
+
+	return nil
+}
+
+// RegisterWithRestServer will be called by the root REST mux to direct a sub
+// RPC server to register itself with the main REST mux server. Until this is
+// called, each sub-server won't be able to have requests routed towards it.
+//
+// NOTE: This is part of the lnrpc.GrpcHandler interface.
+func (r *ServerShell) RegisterWithRestServer(ctx context.Context,
+	mux *runtime.ServeMux, dest string, opts []grpc.DialOption) error {
+
+	// We make sure that we register it with the main REST server to ensure
+	// all our methods are routed properly.
+	err := RegisterAccountingHandlerFromEndpoint(ctx, mux, dest, opts)
+	if err != nil {
+		log.Errorf("Could not register Accounting REST server "+
+			"with root REST server: %v", err)
+		return err
+	}
+
+	log.Debugf("Accounting REST server successfully registered with " +
+		"root REST server")
+	return nil
+}
+
+// CreateSubServer populates the subserver's dependencies using the passed
+// SubServerConfigDispatcher. This method should fully initialize the
+// sub-server instance, making it ready for action. It returns the macaroon
+// permissions that the sub-server wishes to pass on to the root server for all
+// methods routed towards it.
+//
+// NOTE: This is part of the lnrpc.GrpcHandler interface.
+func (r *ServerShell) CreateSubServer(
+	configRegistry lnrpc.SubServerConfigDispatcher) (lnrpc.SubServer,
+	lnrpc.MacaroonPerms, error) {
+
+	subServer, macPermissions, err := createNewSubServer(configRegistry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.AccountingServer = subServer
+	return subServer, macPermissions, nil
+}
+
+// ExportLedger returns a double-entry ledger of the on-chain and off-chain
+// events of the node within a time window.
+func (s *Server) ExportLedger(ctx context.Context,
+	req *ExportLedgerRequest) (*ExportLedgerResponse, error) {
+
+	startTime := time.Unix(int64(req.StartTime), 0)
+	endTime := time.Now()
+	if req.EndTime != 0 {
+		endTime = time.Unix(int64(req.EndTime), 0)
+	}
+
+	if startTime.After(endTime) {
+		return nil, fmt.Errorf("start_time must not be after end_time")
+	}
+
+	// Before we query the forwarding log, we'll instruct the switch to
+	// flush any pending events to disk.
+	if err := s.cfg.FlushForwardingEvents(); err != nil {
+		return nil, fmt.Errorf("unable to flush forwarding events: %w",
+			err)
+	}
+
+	l := newLedger(startTime, endTime)
+	if err := s.addForwards(l); err != nil {
+		return nil, fmt.Errorf("unable to add forwards: %w", err)
+	}
+
+	// The payments need to be added before the invoices, so that the
+	// invoices of our rebalances can be recognized.
+	if err := s.addPayments(l); err != nil {
+		return nil, fmt.Errorf("unable to add payments: %w", err)
+	}
+	if err := s.addInvoices(ctx, l); err != nil {
+		return nil, fmt.Errorf("unable to add invoices: %w", err)
+	}
+	if err := s.addChannels(l); err != nil {
+		return nil, fmt.Errorf("unable to add channels: %w", err)
+	}
+	if err := s.addSweeps(l); err != nil {
+		return nil, fmt.Errorf("unable to add sweeps: %w", err)
+	}
+
+	entries := l.sortedEntries()
+
+	switch req.Format {
+	case LedgerFormat_CSV:
+		var csvLedger strings.Builder
+		if err := writeLedgerCSV(&csvLedger, entries); err != nil {
+			return nil, err
+		}
+
+		return &ExportLedgerResponse{
+			Csv: csvLedger.String(),
+		}, nil
+
+	case LedgerFormat_JSON:
+		return &ExportLedgerResponse{
+			Entries: entries,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown ledger format: %v", req.Format)
+	}
+}
+
+// addForwards adds the fees of the settled forwards within the time window of
+// the ledger.
+func (s *Server) addForwards(l *ledger) error {
+	query := channeldb.ForwardingEventQuery{
+		StartTime:    l.startTime,
+		EndTime:      l.endTime,
+		NumMaxEvents: channeldb.MaxResponseEvents,
+	}
+	for {
+		timeSlice, err := s.cfg.ForwardingLog.Query(query)
+		if err != nil {
+			return err
+		}
+
+		if len(timeSlice.ForwardingEvents) == 0 {
+			return nil
+		}
+
+		for _, event := range timeSlice.ForwardingEvents {
+			l.addForward(event)
+		}
+
+		query.IndexOffset = timeSlice.LastIndexOffset
+	}
+}
+
+// addPayments adds the payments that succeeded within the time window of the
+// ledger.
+func (s *Server) addPayments(l *ledger) error {
+	// Payments that were created before the window might have succeeded
+	// within it, so we can only restrict the end of the query.
+	query := channeldb.PaymentsQuery{
+		MaxPayments:     queryBatchSize,
+		CreationDateEnd: l.endTime.Unix(),
+	}
+	for {
+		resp, err := s.cfg.PaymentsDB.QueryPayments(query)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Payments) == 0 {
+			return nil
+		}
+
+		for _, payment := range resp.Payments {
+			l.addPayment(payment, s.cfg.SelfNode)
+		}
+
+		query.IndexOffset = resp.LastIndexOffset
+	}
+}
+
+// addInvoices adds the invoices that were settled within the time window of
+// the ledger.
+func (s *Server) addInvoices(ctx context.Context, l *ledger) error {
+	query := invoices.InvoiceQuery{
+		NumMaxInvoices:  queryBatchSize,
+		CreationDateEnd: l.endTime.Unix(),
+	}
+	for {
+		slice, err := s.cfg.InvoiceDB.QueryInvoices(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		if len(slice.Invoices) == 0 {
+			return nil
+		}
+
+		for i := range slice.Invoices {
+			l.addInvoice(&slice.Invoices[i])
+		}
+
+		query.IndexOffset = slice.LastIndexOffset
+	}
+}
+
+// addChannels adds the opens of the channels we initiated and the funds that
+// closing transactions paid out to the wallet.
+func (s *Server) addChannels(l *ledger) error {
+	openChannels, err := s.cfg.ChanStateDB.FetchAllOpenChannels()
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range openChannels {
+		if channel.IsPending {
+			continue
+		}
+
+		if err := s.addChannelOpen(l, channel); err != nil {
+			return err
+		}
+	}
+
+	closedChannels, err := s.cfg.ChanStateDB.FetchClosedChannels(false)
+	if err != nil {
+		return err
+	}
+
+	for _, summary := range closedChannels {
+		// The funding transaction of a canceled channel never
+		// confirmed, so there's nothing to record.
+		if summary.CloseType == channeldb.FundingCanceled {
+			continue
+		}
+
+		// The historical channel isn't available for channels that
+		// were closed by old versions of lnd.
+		channel, err := s.cfg.ChanStateDB.FetchHistoricalChannel(
+			&summary.ChanPoint,
+		)
+		if err != nil {
+			log.Debugf("Unable to fetch historical channel %v: %v",
+				summary.ChanPoint, err)
+		} else if err := s.addChannelOpen(l, channel); err != nil {
+			return err
+		}
+
+		if summary.IsPending {
+			continue
+		}
+
+		// Closing transactions that don't pay out to the wallet
+		// aren't known to it.
+		closingTx, err := s.cfg.Wallet.GetTransactionDetails(
+			&summary.ClosingTXID,
+		)
+		if err != nil {
+			log.Debugf("Unable to fetch closing transaction %v: %v",
+				summary.ClosingTXID, err)
+
+			continue
+		}
+
+		l.addChannelClose(summary, closingTx)
+	}
+
+	return nil
+}
+
+// addChannelOpen adds the open of the given channel if we initiated it.
+func (s *Server) addChannelOpen(l *ledger,
+	channel *channeldb.OpenChannel) error {
+
+	if !channel.IsInitiator {
+		return nil
+	}
+
+	fundingTx, err := s.cfg.Wallet.GetTransactionDetails(
+		&channel.FundingOutpoint.Hash,
+	)
+	if err == nil {
+		l.addChannelOpen(channel, fundingTx, time.Time{})
+
+		return nil
+	}
+
+	// The funding transaction isn't known to the wallet, so the channel
+	// was funded externally. We'll use the time of the block that
+	// confirmed it instead.
+	confHeight := channel.ShortChanID().BlockHeight
+	if channel.IsZeroConf() {
+		if !channel.ZeroConfConfirmed() {
+			return nil
+		}
+
+		confHeight = channel.ZeroConfRealScid().BlockHeight
+	}
+
+	blockHash, err := s.cfg.ChainIO.GetBlockHash(int64(confHeight))
+	if err != nil {
+		return err
+	}
+	header, err := s.cfg.ChainIO.GetBlockHeader(blockHash)
+	if err != nil {
+		return err
+	}
+
+	l.addChannelOpen(channel, nil, header.Timestamp)
+
+	return nil
+}
+
+// addSweeps adds the confirmed sweep transactions of the wallet.
+func (s *Server) addSweeps(l *ledger) error {
+	txs, err := s.cfg.Wallet.ListTransactionDetails(0, -1, "")
+	if err != nil {
+		return err
+	}
+
+	sweepLabel := labels.MakeLabel(labels.LabelTypeSweepTransaction, nil)
+	for _, tx := range txs {
+		if tx.Label != sweepLabel || tx.NumConfirmations == 0 {
+			continue
+		}
+
+		l.addSweep(tx)
+	}
+
+	return nil
+}
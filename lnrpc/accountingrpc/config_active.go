@@ -0,0 +1,45 @@
+//go:build accountingrpc
+// +build accountingrpc
+
+package accountingrpc
+
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// Config is the primary configuration struct for the accounting RPC
+// subserver. It contains all the items required for the server to carry out
+// its duties. The fields with struct tags are meant to be parsed as normal
+// configuration options, while if able to be populated, the latter fields
+// MUST also be specified.
+type Config struct {
+	// ChanStateDB is used to fetch the open, closed and historical
+	// channels of the node.
+	ChanStateDB *channeldb.ChannelStateDB
+
+	// PaymentsDB is used to query the payments sent by the node.
+	PaymentsDB *channeldb.DB
+
+	// InvoiceDB is used to query the invoices of the node.
+	InvoiceDB invoices.InvoiceDB
+
+	// ForwardingLog is used to query the HTLCs forwarded by the node.
+	ForwardingLog channeldb.ForwardingEventStore
+
+	// FlushForwardingEvents writes the forwarding events that are still
+	// buffered by the switch to the forwarding log.
+	FlushForwardingEvents func() error
+
+	// Wallet is used to look up the on-chain transactions of the node.
+	Wallet lnwallet.WalletController
+
+	// ChainIO is used to look up the time of blocks.
+	ChainIO lnwallet.BlockChainIO
+
+	// SelfNode is the public key of our node, which is used to detect
+	// payments to ourselves.
+	SelfNode route.Vertex
+}
@@ -0,0 +1,7 @@
+//go:build !accountingrpc
+// +build !accountingrpc
+
+package accountingrpc
+
+// Config is empty for non-accountingrpc builds.
+type Config struct{}
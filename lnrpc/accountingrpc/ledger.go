@@ -0,0 +1,349 @@
+package accountingrpc
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// AccountOnChain is the asset account of the funds in the on-chain
+	// wallet.
+	AccountOnChain = "assets:onchain"
+
+	// AccountLightning is the asset account of our balance in channels.
+	AccountLightning = "assets:lightning"
+
+	// AccountExternal is the account of funds that entered or left the
+	// node through transactions that weren't funded by the wallet, for
+	// example channels funded with an external PSBT.
+	AccountExternal = "equity:external"
+
+	// AccountForwardingIncome is the income account of the fees earned by
+	// forwarding HTLCs.
+	AccountForwardingIncome = "income:forwarding_fees"
+
+	// AccountInvoiceIncome is the income account of settled invoices.
+	AccountInvoiceIncome = "income:invoices"
+
+	// AccountOnChainFees is the expense account of on-chain fees.
+	AccountOnChainFees = "expenses:onchain_fees"
+
+	// AccountRoutingFees is the expense account of the routing fees paid
+	// for payments and rebalances.
+	AccountRoutingFees = "expenses:routing_fees"
+
+	// AccountPayments is the expense account of sent payments.
+	AccountPayments = "expenses:payments"
+)
+
+// ledgerCSVHeader is the header row of the CSV encoding of the ledger.
+var ledgerCSVHeader = []string{
+	"id", "timestamp", "type", "debit_account", "credit_account",
+	"amount_msat", "reference",
+}
+
+// ledger collects the entries of the events within a time window. Entries are
+// deduplicated by their ID, as some events, like the funding transaction of a
+// batch of channels, are shared between several objects.
+type ledger struct {
+	startTime time.Time
+	endTime   time.Time
+
+	entries []*LedgerEntry
+	ids     map[string]struct{}
+
+	// rebalances holds the payment hashes of the payments to ourselves.
+	// The invoices paid by them are not income.
+	rebalances map[lntypes.Hash]struct{}
+}
+
+// newLedger creates a ledger for the events within the given time window.
+func newLedger(startTime, endTime time.Time) *ledger {
+	return &ledger{
+		startTime:  startTime,
+		endTime:    endTime,
+		ids:        make(map[string]struct{}),
+		rebalances: make(map[lntypes.Hash]struct{}),
+	}
+}
+
+// add adds an entry for an event at the given time to the ledger. Events
+// outside of the time window, entries without an amount and entries that were
+// already added are ignored.
+func (l *ledger) add(timestamp time.Time, entry *LedgerEntry) {
+	if timestamp.Before(l.startTime) || timestamp.After(l.endTime) {
+		return
+	}
+
+	if entry.AmountMsat == 0 {
+		return
+	}
+
+	if _, ok := l.ids[entry.Id]; ok {
+		return
+	}
+
+	entry.Timestamp = uint64(timestamp.Unix())
+	l.ids[entry.Id] = struct{}{}
+	l.entries = append(l.entries, entry)
+}
+
+// sortedEntries returns the entries of the ledger ordered by their timestamp
+// and ID.
+func (l *ledger) sortedEntries() []*LedgerEntry {
+	sort.SliceStable(l.entries, func(i, j int) bool {
+		if l.entries[i].Timestamp != l.entries[j].Timestamp {
+			return l.entries[i].Timestamp < l.entries[j].Timestamp
+		}
+
+		return l.entries[i].Id < l.entries[j].Id
+	})
+
+	return l.entries
+}
+
+// addForward adds the fee earned by a settled forward to the ledger.
+func (l *ledger) addForward(event channeldb.ForwardingEvent) {
+	if event.Failed || event.AmtIn <= event.AmtOut {
+		return
+	}
+
+	l.add(event.Timestamp, &LedgerEntry{
+		Id: fmt.Sprintf("forward:%d:%d:%d",
+			event.Timestamp.UnixNano(),
+			event.IncomingChanID.ToUint64(),
+			event.OutgoingChanID.ToUint64()),
+		Type:          LedgerEntryType_FORWARD_FEE,
+		DebitAccount:  AccountLightning,
+		CreditAccount: AccountForwardingIncome,
+		AmountMsat:    uint64(event.AmtIn - event.AmtOut),
+		Reference: fmt.Sprintf("%v:%v", event.IncomingChanID,
+			event.OutgoingChanID),
+	})
+}
+
+// isRebalance returns true if the given settled HTLC attempt was sent to our
+// own node.
+func isRebalance(htlc *channeldb.HTLCAttempt, self route.Vertex) bool {
+	hops := htlc.Route.Hops
+
+	return len(hops) > 0 && hops[len(hops)-1].PubKeyBytes == self
+}
+
+// addPayment adds a succeeded payment and the routing fees paid for it to the
+// ledger. Only the fees are recorded for payments to ourselves, as the amount
+// stays within our channels. Payments need to be added before invoices, so
+// that the invoices paid by those payments can be skipped.
+func (l *ledger) addPayment(payment *channeldb.MPPayment, self route.Vertex) {
+	if payment.Status != channeldb.StatusSucceeded {
+		return
+	}
+
+	settled, _ := payment.TerminalInfo()
+	if settled == nil {
+		return
+	}
+
+	// The payment is completed with its last settled HTLC.
+	var settleTime time.Time
+	for _, htlc := range payment.HTLCs {
+		if htlc.Settle != nil && htlc.Settle.SettleTime.After(
+			settleTime,
+		) {
+
+			settleTime = htlc.Settle.SettleTime
+		}
+	}
+
+	hash := payment.Info.PaymentIdentifier.String()
+	amt, fees := payment.SentAmt()
+
+	if isRebalance(settled, self) {
+		l.rebalances[payment.Info.PaymentIdentifier] = struct{}{}
+		l.add(settleTime, &LedgerEntry{
+			Id:            "rebalance_fee:" + hash,
+			Type:          LedgerEntryType_REBALANCE_FEE,
+			DebitAccount:  AccountRoutingFees,
+			CreditAccount: AccountLightning,
+			AmountMsat:    uint64(fees),
+			Reference:     hash,
+		})
+
+		return
+	}
+
+	l.add(settleTime, &LedgerEntry{
+		Id:            "payment:" + hash,
+		Type:          LedgerEntryType_PAYMENT,
+		DebitAccount:  AccountPayments,
+		CreditAccount: AccountLightning,
+		AmountMsat:    uint64(amt),
+		Reference:     hash,
+	})
+	l.add(settleTime, &LedgerEntry{
+		Id:            "payment_fee:" + hash,
+		Type:          LedgerEntryType_PAYMENT_FEE,
+		DebitAccount:  AccountRoutingFees,
+		CreditAccount: AccountLightning,
+		AmountMsat:    uint64(fees),
+		Reference:     hash,
+	})
+}
+
+// addInvoice adds a settled invoice to the ledger, unless it was paid by
+// ourselves.
+func (l *ledger) addInvoice(invoice *invoices.Invoice) {
+	if invoice.State != invoices.ContractSettled {
+		return
+	}
+
+	if preimage := invoice.Terms.PaymentPreimage; preimage != nil {
+		if _, ok := l.rebalances[preimage.Hash()]; ok {
+			return
+		}
+	}
+
+	addIndex := strconv.FormatUint(invoice.AddIndex, 10)
+	l.add(invoice.SettleDate, &LedgerEntry{
+		Id:            "invoice:" + addIndex,
+		Type:          LedgerEntryType_INVOICE,
+		DebitAccount:  AccountLightning,
+		CreditAccount: AccountInvoiceIncome,
+		AmountMsat:    uint64(invoice.AmtPaid),
+		Reference:     addIndex,
+	})
+}
+
+// addOnChainFee adds the fee of a transaction that was funded by the wallet to
+// the ledger.
+func (l *ledger) addOnChainFee(tx *lnwallet.TransactionDetail) {
+	fee := lnwire.NewMSatFromSatoshis(btcutil.Amount(tx.TotalFees))
+	l.add(time.Unix(tx.Timestamp, 0), &LedgerEntry{
+		Id:            "onchain_fee:" + tx.Hash.String(),
+		Type:          LedgerEntryType_ONCHAIN_FEE,
+		DebitAccount:  AccountOnChainFees,
+		CreditAccount: AccountOnChain,
+		AmountMsat:    uint64(fee),
+		Reference:     tx.Hash.String(),
+	})
+}
+
+// addChannelOpen adds the funds moved into a channel we opened to the ledger,
+// along with the fee of the funding transaction. The funding transaction is
+// nil if it isn't known to the wallet, in which case the channel was funded
+// externally. The funding time is only used in that case.
+func (l *ledger) addChannelOpen(channel *channeldb.OpenChannel,
+	fundingTx *lnwallet.TransactionDetail, fundingTime time.Time) {
+
+	if !channel.IsInitiator {
+		return
+	}
+
+	creditAccount := AccountExternal
+	if fundingTx != nil {
+		creditAccount = AccountOnChain
+		fundingTime = time.Unix(fundingTx.Timestamp, 0)
+
+		l.addOnChainFee(fundingTx)
+	}
+
+	chanPoint := channel.FundingOutpoint.String()
+	amt := lnwire.NewMSatFromSatoshis(channel.Capacity)
+	l.add(fundingTime, &LedgerEntry{
+		Id:            "open:" + chanPoint,
+		Type:          LedgerEntryType_CHANNEL_OPEN,
+		DebitAccount:  AccountLightning,
+		CreditAccount: creditAccount,
+		AmountMsat:    uint64(amt),
+		Reference:     chanPoint,
+	})
+}
+
+// addChannelClose adds the funds that the closing transaction of a channel
+// paid out to the wallet to the ledger. Funds that are swept later on are
+// recorded as sweeps.
+func (l *ledger) addChannelClose(summary *channeldb.ChannelCloseSummary,
+	closingTx *lnwallet.TransactionDetail) {
+
+	if closingTx.Value <= 0 {
+		return
+	}
+
+	chanPoint := summary.ChanPoint.String()
+	amt := lnwire.NewMSatFromSatoshis(closingTx.Value)
+	l.add(time.Unix(closingTx.Timestamp, 0), &LedgerEntry{
+		Id:            "close:" + chanPoint,
+		Type:          LedgerEntryType_CHANNEL_CLOSE,
+		DebitAccount:  AccountOnChain,
+		CreditAccount: AccountLightning,
+		AmountMsat:    uint64(amt),
+		Reference:     chanPoint,
+	})
+}
+
+// addSweep adds a sweep transaction to the ledger. Sweeps that credit the
+// wallet move the funds of closed channels to the wallet. Sweeps that debit
+// the wallet, like the CPFP of an anchor, are recorded as on-chain fees.
+func (l *ledger) addSweep(tx *lnwallet.TransactionDetail) {
+	if tx.Value < 0 {
+		fee := lnwire.NewMSatFromSatoshis(-tx.Value)
+		l.add(time.Unix(tx.Timestamp, 0), &LedgerEntry{
+			Id:            "onchain_fee:" + tx.Hash.String(),
+			Type:          LedgerEntryType_ONCHAIN_FEE,
+			DebitAccount:  AccountOnChainFees,
+			CreditAccount: AccountOnChain,
+			AmountMsat:    uint64(fee),
+			Reference:     tx.Hash.String(),
+		})
+
+		return
+	}
+
+	l.add(time.Unix(tx.Timestamp, 0), &LedgerEntry{
+		Id:            "sweep:" + tx.Hash.String(),
+		Type:          LedgerEntryType_SWEEP,
+		DebitAccount:  AccountOnChain,
+		CreditAccount: AccountLightning,
+		AmountMsat:    uint64(lnwire.NewMSatFromSatoshis(tx.Value)),
+		Reference:     tx.Hash.String(),
+	})
+}
+
+// writeLedgerCSV writes the given ledger entries as CSV with a header row.
+func writeLedgerCSV(w io.Writer, entries []*LedgerEntry) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(ledgerCSVHeader); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err := csvWriter.Write([]string{
+			entry.Id,
+			strconv.FormatUint(entry.Timestamp, 10),
+			entry.Type.String(),
+			entry.DebitAccount,
+			entry.CreditAccount,
+			strconv.FormatUint(entry.AmountMsat, 10),
+			entry.Reference,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
@@ -0,0 +1,248 @@
+package accountingrpc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testStart = time.Unix(1_000_000, 0)
+	testEnd   = time.Unix(2_000_000, 0)
+	testTime  = time.Unix(1_500_000, 0)
+
+	selfNode  = route.Vertex{1}
+	otherNode = route.Vertex{2}
+)
+
+// testPayment creates a succeeded payment that paid the given amount plus
+// fees to the given destination.
+func testPayment(preimage lntypes.Preimage, dest route.Vertex, amt,
+	fees lnwire.MilliSatoshi) *channeldb.MPPayment {
+
+	return &channeldb.MPPayment{
+		Info: &channeldb.PaymentCreationInfo{
+			PaymentIdentifier: preimage.Hash(),
+		},
+		HTLCs: []channeldb.HTLCAttempt{{
+			HTLCAttemptInfo: channeldb.HTLCAttemptInfo{
+				Route: route.Route{
+					TotalAmount: amt + fees,
+					Hops: []*route.Hop{{
+						PubKeyBytes:  dest,
+						AmtToForward: amt,
+					}},
+				},
+			},
+			Settle: &channeldb.HTLCSettleInfo{
+				Preimage:   preimage,
+				SettleTime: testTime,
+			},
+		}},
+		Status: channeldb.StatusSucceeded,
+	}
+}
+
+// testInvoice creates an invoice in the given state that was paid with the
+// given amount.
+func testInvoice(preimage lntypes.Preimage, addIndex uint64,
+	state invoices.ContractState,
+	amt lnwire.MilliSatoshi) *invoices.Invoice {
+
+	return &invoices.Invoice{
+		Terms: invoices.ContractTerm{
+			PaymentPreimage: &preimage,
+		},
+		AddIndex:   addIndex,
+		SettleDate: testTime,
+		State:      state,
+		AmtPaid:    amt,
+	}
+}
+
+// TestLedgerOffChain asserts that the off-chain events are recorded with the
+// right accounts and that the invoices of rebalances are skipped.
+func TestLedgerOffChain(t *testing.T) {
+	t.Parallel()
+
+	l := newLedger(testStart, testEnd)
+
+	l.addForward(channeldb.ForwardingEvent{
+		Timestamp:      testTime,
+		IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+		AmtIn:          1_010,
+		AmtOut:         1_000,
+	})
+
+	// Failed forwards don't earn any fees.
+	l.addForward(channeldb.ForwardingEvent{
+		Timestamp: testTime,
+		AmtIn:     1_010,
+		AmtOut:    1_000,
+		Failed:    true,
+	})
+
+	rebalance := lntypes.Preimage{1}
+	payment := lntypes.Preimage{2}
+	l.addPayment(testPayment(rebalance, selfNode, 5_000, 7), selfNode)
+	l.addPayment(testPayment(payment, otherNode, 3_000, 3), selfNode)
+
+	l.addInvoice(testInvoice(rebalance, 1, invoices.ContractSettled, 5_000))
+	l.addInvoice(testInvoice(lntypes.Preimage{3}, 2,
+		invoices.ContractSettled, 2_000))
+	l.addInvoice(testInvoice(lntypes.Preimage{4}, 3,
+		invoices.ContractCanceled, 2_000))
+
+	entries := l.sortedEntries()
+	require.Len(t, entries, 5)
+
+	byID := make(map[string]*LedgerEntry)
+	for _, entry := range entries {
+		require.EqualValues(t, testTime.Unix(), entry.Timestamp)
+		byID[entry.Id] = entry
+	}
+
+	forward := byID["forward:1500000000000000:1:2"]
+	require.NotNil(t, forward)
+	require.EqualValues(t, 10, forward.AmountMsat)
+	require.Equal(t, AccountForwardingIncome, forward.CreditAccount)
+
+	rebalanceFee := byID["rebalance_fee:"+rebalance.Hash().String()]
+	require.NotNil(t, rebalanceFee)
+	require.EqualValues(t, 7, rebalanceFee.AmountMsat)
+	require.Equal(t, AccountRoutingFees, rebalanceFee.DebitAccount)
+
+	sent := byID["payment:"+payment.Hash().String()]
+	require.NotNil(t, sent)
+	require.EqualValues(t, 3_000, sent.AmountMsat)
+
+	paymentFee := byID["payment_fee:"+payment.Hash().String()]
+	require.NotNil(t, paymentFee)
+	require.EqualValues(t, 3, paymentFee.AmountMsat)
+
+	invoice := byID["invoice:2"]
+	require.NotNil(t, invoice)
+	require.EqualValues(t, 2_000, invoice.AmountMsat)
+	require.Equal(t, AccountInvoiceIncome, invoice.CreditAccount)
+}
+
+// TestLedgerOnChain asserts that the on-chain events are recorded once, even
+// if they're shared between several channels, and only within the window.
+func TestLedgerOnChain(t *testing.T) {
+	t.Parallel()
+
+	l := newLedger(testStart, testEnd)
+
+	fundingTx := &lnwallet.TransactionDetail{
+		Hash:      [32]byte{1},
+		Value:     -2_100,
+		TotalFees: 100,
+		Timestamp: testTime.Unix(),
+	}
+
+	// Both channels are funded by the same batch transaction.
+	for i := uint32(0); i < 2; i++ {
+		l.addChannelOpen(&channeldb.OpenChannel{
+			FundingOutpoint: wire.OutPoint{
+				Hash:  fundingTx.Hash,
+				Index: i,
+			},
+			Capacity:    1_000,
+			IsInitiator: true,
+		}, fundingTx, time.Time{})
+	}
+
+	// Channels we didn't initiate aren't recorded.
+	l.addChannelOpen(&channeldb.OpenChannel{
+		FundingOutpoint: wire.OutPoint{Hash: [32]byte{2}},
+		Capacity:        1_000,
+	}, nil, testTime)
+
+	// Externally funded channels are credited from outside the wallet.
+	l.addChannelOpen(&channeldb.OpenChannel{
+		FundingOutpoint: wire.OutPoint{Hash: [32]byte{3}},
+		Capacity:        1_000,
+		IsInitiator:     true,
+	}, nil, testTime)
+
+	l.addChannelClose(&channeldb.ChannelCloseSummary{
+		ChanPoint: wire.OutPoint{Hash: fundingTx.Hash},
+	}, &lnwallet.TransactionDetail{
+		Hash:      [32]byte{4},
+		Value:     600,
+		Timestamp: testTime.Unix(),
+	})
+
+	l.addSweep(&lnwallet.TransactionDetail{
+		Hash:      [32]byte{5},
+		Value:     300,
+		Timestamp: testTime.Unix(),
+	})
+	l.addSweep(&lnwallet.TransactionDetail{
+		Hash:      [32]byte{6},
+		Value:     -50,
+		Timestamp: testTime.Unix(),
+	})
+
+	// Events outside of the window are ignored.
+	l.addSweep(&lnwallet.TransactionDetail{
+		Hash:      [32]byte{7},
+		Value:     300,
+		Timestamp: testEnd.Unix() + 1,
+	})
+
+	entries := l.sortedEntries()
+
+	types := make(map[LedgerEntryType]int)
+	for _, entry := range entries {
+		types[entry.Type]++
+	}
+	require.Equal(t, map[LedgerEntryType]int{
+		LedgerEntryType_CHANNEL_OPEN:  3,
+		LedgerEntryType_ONCHAIN_FEE:   2,
+		LedgerEntryType_CHANNEL_CLOSE: 1,
+		LedgerEntryType_SWEEP:         1,
+	}, types)
+
+	external := "open:" + wire.OutPoint{Hash: [32]byte{3}}.String()
+	for _, entry := range entries {
+		if entry.Id != external {
+			continue
+		}
+
+		require.Equal(t, AccountExternal, entry.CreditAccount)
+		require.EqualValues(t, 1_000_000, entry.AmountMsat)
+	}
+}
+
+// TestWriteLedgerCSV asserts the CSV encoding of the ledger.
+func TestWriteLedgerCSV(t *testing.T) {
+	t.Parallel()
+
+	var csvLedger strings.Builder
+	err := writeLedgerCSV(&csvLedger, []*LedgerEntry{{
+		Id:            "invoice:1",
+		Timestamp:     1,
+		Type:          LedgerEntryType_INVOICE,
+		DebitAccount:  AccountLightning,
+		CreditAccount: AccountInvoiceIncome,
+		AmountMsat:    1_000,
+		Reference:     "1",
+	}})
+	require.NoError(t, err)
+
+	require.Equal(t, "id,timestamp,type,debit_account,credit_account,"+
+		"amount_msat,reference\n"+
+		"invoice:1,1,INVOICE,assets:lightning,income:invoices,1000,1\n",
+		csvLedger.String())
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -15,6 +16,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/fn"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"google.golang.org/grpc"
@@ -86,6 +88,17 @@ var (
 	// finished the startup process.
 	ErrChainNotifierServerNotActive = errors.New("chain notifier RPC is " +
 		"still in the process of starting")
+
+	// ErrMempoolNotSupported is returned when mempool spend notifications
+	// are requested, but the chain backend doesn't support them.
+	ErrMempoolNotSupported = errors.New("mempool spend notifications " +
+		"are not supported by the chain backend")
+
+	// ErrMempoolOutpointRequired is returned when mempool spend
+	// notifications are requested for an output script instead of an
+	// outpoint.
+	ErrMempoolOutpointRequired = errors.New("mempool spend " +
+		"notifications require an outpoint")
 )
 
 // ServerShell is a shell struct holding a reference to the actual sub-server.
@@ -198,6 +211,14 @@ func (s *Server) Name() string {
 	return subServerName
 }
 
+// DefaultMacaroon returns the path of the chain notifier macaroon file and the
+// operations it is baked with.
+//
+// NOTE: This is part of the lnrpc.DefaultMacaroonBaker interface.
+func (s *Server) DefaultMacaroon() (string, []bakery.Op) {
+	return s.cfg.ChainNotifierMacPath, macaroonOps
+}
+
 // RegisterWithRootServer will be called by the root gRPC server to direct a RPC
 // sub-server to register itself with the main gRPC root server. Until this is
 // called, each sub-server won't be able to have requests routed towards it.
@@ -483,6 +504,10 @@ func (s *Server) RegisterConfirmationsNtfn(in *ConfRequest,
 // A client can specify whether the spend request should be for a particular
 // outpoint  or for an output script by specifying a zero outpoint.
 //
+// If include_mempool is set, the client is additionally notified of
+// unconfirmed spends of the outpoint as soon as they enter the mempool of the
+// backing node. This is only supported by the bitcoind and btcd backends.
+//
 // NOTE: This is part of the chainrpc.ChainNotifierService interface.
 func (s *Server) RegisterSpendNtfn(in *SpendRequest,
 	spendStream ChainNotifier_RegisterSpendNtfnServer) error {
@@ -500,6 +525,17 @@ func (s *Server) RegisterSpendNtfn(in *SpendRequest,
 		op = &wire.OutPoint{Hash: txid, Index: in.Outpoint.Index}
 	}
 
+	// If unconfirmed spends were requested, we'll make sure that they can
+	// be delivered before registering anything.
+	if in.IncludeMempool {
+		if s.cfg.MempoolNotifier == nil {
+			return ErrMempoolNotSupported
+		}
+		if op == nil || *op == chainntnfs.ZeroOutPoint {
+			return ErrMempoolOutpointRequired
+		}
+	}
+
 	// We'll then register for the spend notification of the request.
 	spendEvent, err := s.cfg.ChainNotifier.RegisterSpendNtfn(
 		op, in.Script, in.HeightHint,
@@ -509,10 +545,62 @@ func (s *Server) RegisterSpendNtfn(in *SpendRequest,
 	}
 	defer spendEvent.Cancel()
 
+	// We'll also subscribe to spends of the outpoint in the mempool if
+	// requested. The subscription stays active until the spend confirms,
+	// so that replacements of the spending transaction are reported as
+	// well.
+	var (
+		mempoolSpends <-chan *chainntnfs.SpendDetail
+		notifiedTxs   = make(map[chainhash.Hash]struct{})
+	)
+	if in.IncludeMempool {
+		mempool := s.cfg.MempoolNotifier
+		mempoolEvent, err := mempool.SubscribeMempoolSpent(*op)
+		if err != nil {
+			return err
+		}
+		defer mempool.CancelMempoolSpendEvent(mempoolEvent)
+		mempoolSpends = mempoolEvent.Spend
+
+		// The outpoint might already have been spent by a transaction
+		// in the mempool before we subscribed.
+		spendingTx := mempool.LookupInputMempoolSpend(*op)
+		err = fn.MapOptionZ(spendingTx, func(tx wire.MsgTx) error {
+			details, err := mempoolSpendDetails(&tx, *op)
+			if err != nil {
+				return err
+			}
+			notifiedTxs[*details.SpenderTxHash] = struct{}{}
+
+			return sendMempoolSpend(spendStream, details)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// With the request registered, we'll wait for its spend notification to
 	// be dispatched.
 	for {
 		select {
+		// A transaction that spends the given outpoint was seen in the
+		// mempool. We'll return an event to the caller for every
+		// spending transaction we haven't reported yet.
+		case details, ok := <-mempoolSpends:
+			if !ok {
+				return chainntnfs.ErrChainNotifierShuttingDown
+			}
+
+			if _, ok := notifiedTxs[*details.SpenderTxHash]; ok {
+				continue
+			}
+			notifiedTxs[*details.SpenderTxHash] = struct{}{}
+
+			err := sendMempoolSpend(spendStream, details)
+			if err != nil {
+				return err
+			}
+
 		// A transaction that spends the given has confirmed on-chain.
 		// We'll return an event to the caller indicating so that
 		// includes the details of the spending transaction.
@@ -521,23 +609,11 @@ func (s *Server) RegisterSpendNtfn(in *SpendRequest,
 				return chainntnfs.ErrChainNotifierShuttingDown
 			}
 
-			var rawSpendingTxBuf bytes.Buffer
-			err := details.SpendingTx.Serialize(&rawSpendingTxBuf)
+			rpcSpendDetails, err := marshallSpendDetails(details)
 			if err != nil {
 				return err
 			}
 
-			rpcSpendDetails := &SpendDetails{
-				SpendingOutpoint: &Outpoint{
-					Hash:  details.SpentOutPoint.Hash[:],
-					Index: details.SpentOutPoint.Index,
-				},
-				RawSpendingTx:      rawSpendingTxBuf.Bytes(),
-				SpendingTxHash:     details.SpenderTxHash[:],
-				SpendingInputIndex: details.SpenderInputIndex,
-				SpendingHeight:     uint32(details.SpendingHeight),
-			}
-
 			spend := &SpendEvent{
 				Event: &SpendEvent_Spend{
 					Spend: rpcSpendDetails,
@@ -547,6 +623,10 @@ func (s *Server) RegisterSpendNtfn(in *SpendRequest,
 				return err
 			}
 
+			// The spend has confirmed, so there is no need to
+			// report any further mempool spends.
+			mempoolSpends = nil
+
 		// The spending transaction of the request has been reorged of
 		// the chain. We'll return an event to the caller indicating so.
 		case _, ok := <-spendEvent.Reorg:
@@ -587,6 +667,70 @@ func (s *Server) RegisterSpendNtfn(in *SpendRequest,
 	}
 }
 
+// marshallSpendDetails converts the given spend details into their RPC
+// counterpart.
+func marshallSpendDetails(details *chainntnfs.SpendDetail) (*SpendDetails,
+	error) {
+
+	var rawSpendingTxBuf bytes.Buffer
+	err := details.SpendingTx.Serialize(&rawSpendingTxBuf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpendDetails{
+		SpendingOutpoint: &Outpoint{
+			Hash:  details.SpentOutPoint.Hash[:],
+			Index: details.SpentOutPoint.Index,
+		},
+		RawSpendingTx:      rawSpendingTxBuf.Bytes(),
+		SpendingTxHash:     details.SpenderTxHash[:],
+		SpendingInputIndex: details.SpenderInputIndex,
+		SpendingHeight:     uint32(details.SpendingHeight),
+	}, nil
+}
+
+// mempoolSpendDetails returns the spend details of the given unconfirmed
+// transaction that spends the given outpoint.
+func mempoolSpendDetails(tx *wire.MsgTx,
+	op wire.OutPoint) (*chainntnfs.SpendDetail, error) {
+
+	for i, txIn := range tx.TxIn {
+		if txIn.PreviousOutPoint != op {
+			continue
+		}
+
+		txid := tx.TxHash()
+
+		return &chainntnfs.SpendDetail{
+			SpentOutPoint:     &op,
+			SpenderTxHash:     &txid,
+			SpendingTx:        tx,
+			SpenderInputIndex: uint32(i),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("transaction %v does not spend %v",
+		tx.TxHash(), op)
+}
+
+// sendMempoolSpend sends a mempool spend event with the given spend details
+// to the client.
+func sendMempoolSpend(spendStream ChainNotifier_RegisterSpendNtfnServer,
+	details *chainntnfs.SpendDetail) error {
+
+	rpcSpendDetails, err := marshallSpendDetails(details)
+	if err != nil {
+		return err
+	}
+
+	return spendStream.Send(&SpendEvent{
+		Event: &SpendEvent_MempoolSpend{
+			MempoolSpend: rpcSpendDetails,
+		},
+	})
+}
+
 // RegisterBlockEpochNtfn is a synchronous response-streaming RPC that registers
 // an intent for a client to be notified of blocks in the chain. The stream will
 // return a hash and height tuple of a block for each new/stale block in the
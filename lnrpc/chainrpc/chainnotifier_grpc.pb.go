@@ -34,6 +34,10 @@ type ChainNotifierClient interface {
 	//
 	// A client can specify whether the spend request should be for a particular
 	// outpoint  or for an output script by specifying a zero outpoint.
+	//
+	// If include_mempool is set, the client is additionally notified of
+	// unconfirmed spends of the outpoint as soon as they enter the mempool of the
+	// backing node. This is only supported by the bitcoind and btcd backends.
 	RegisterSpendNtfn(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterSpendNtfnClient, error)
 	// RegisterBlockEpochNtfn is a synchronous response-streaming RPC that
 	// registers an intent for a client to be notified of blocks in the chain. The
@@ -171,6 +175,10 @@ type ChainNotifierServer interface {
 	//
 	// A client can specify whether the spend request should be for a particular
 	// outpoint  or for an output script by specifying a zero outpoint.
+	//
+	// If include_mempool is set, the client is additionally notified of
+	// unconfirmed spends of the outpoint as soon as they enter the mempool of the
+	// backing node. This is only supported by the bitcoind and btcd backends.
 	RegisterSpendNtfn(*SpendRequest, ChainNotifier_RegisterSpendNtfnServer) error
 	// RegisterBlockEpochNtfn is a synchronous response-streaming RPC that
 	// registers an intent for a client to be notified of blocks in the chain. The
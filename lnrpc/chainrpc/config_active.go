@@ -34,6 +34,11 @@ type Config struct {
 	// simply to proxy valid requests to the active chain notifier instance.
 	ChainNotifier chainntnfs.ChainNotifier
 
+	// MempoolNotifier is used to notify clients of unconfirmed spends in
+	// the mempool. It is nil if the chain backend doesn't support mempool
+	// notifications.
+	MempoolNotifier chainntnfs.MempoolWatcher
+
 	// Chain provides access to the most up-to-date blockchain data.
 	Chain lnwallet.BlockChainIO
 }
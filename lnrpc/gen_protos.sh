@@ -48,11 +48,11 @@ function generate() {
     --custom_opt="$opts" \
     lightning.proto stateservice.proto walletunlocker.proto
   
-  PACKAGES="autopilotrpc chainrpc invoicesrpc neutrinorpc peersrpc routerrpc signrpc verrpc walletrpc watchtowerrpc wtclientrpc devrpc"
+  PACKAGES="accountingrpc autopilotrpc chainrpc invoicesrpc neutrinorpc peersrpc routerrpc signerrpc signrpc verrpc walletrpc watchtowerrpc wtclientrpc devrpc"
   for package in $PACKAGES; do
-    # Special import for the wallet kit.
+    # Special import for the wallet kit and the remote signer.
     manual_import=""
-    if [[ "$package" == "walletrpc" ]]; then
+    if [[ "$package" == "walletrpc" || "$package" == "signerrpc" ]]; then
       manual_import="github.com/lightningnetwork/lnd/lnrpc/signrpc"
     fi
 
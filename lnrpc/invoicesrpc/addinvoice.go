@@ -23,6 +23,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/netann"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/zpay32"
 )
 
@@ -84,6 +85,55 @@ type AddInvoiceConfig struct {
 	// GetAlias allows the peer's alias SCID to be retrieved for private
 	// option_scid_alias channels.
 	GetAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)
+
+	// GetChannelUptime returns the time the peer of the given channel was
+	// observed online and the total time the channel was monitored. It is
+	// used to select the most reliable channels as hop hints.
+	GetChannelUptime func(chanPoint wire.OutPoint,
+		peer route.Vertex) (time.Duration, time.Duration, error)
+}
+
+// HintStrategy determines how the private channels that are included as hop
+// hints in an invoice are selected.
+type HintStrategy uint8
+
+const (
+	// HintStrategyDefault selects the private channels with the highest
+	// remote balance until their combined remote balance covers the
+	// invoice amount scaled by the hop hint factor.
+	HintStrategyDefault HintStrategy = iota
+
+	// HintStrategyHighestInbound selects the private channels with the
+	// highest remote balance, regardless of the invoice amount.
+	HintStrategyHighestInbound
+
+	// HintStrategyMostReliable selects the private channels whose peers
+	// were observed online for the largest share of the time the channel
+	// was monitored.
+	HintStrategyMostReliable
+
+	// HintStrategyRandom selects random private channels.
+	HintStrategyRandom
+)
+
+// String returns a human-readable name of the hint strategy.
+func (h HintStrategy) String() string {
+	switch h {
+	case HintStrategyDefault:
+		return "default"
+
+	case HintStrategyHighestInbound:
+		return "highest-inbound"
+
+	case HintStrategyMostReliable:
+		return "most-reliable"
+
+	case HintStrategyRandom:
+		return "random-k"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(h))
+	}
 }
 
 // AddInvoiceData contains the required data to create a new invoice.
@@ -137,6 +187,15 @@ type AddInvoiceData struct {
 	// RouteHints are optional route hints that can each be individually
 	// used to assist in reaching the invoice's destination.
 	RouteHints [][]zpay32.HopHint
+
+	// HintStrategy is the strategy used to select the private channels
+	// that are included as hop hints if Private is set.
+	HintStrategy HintStrategy
+
+	// NumHints is the maximum number of hop hints for private channels
+	// that are included if Private is set. If zero, up to maxHopHints
+	// hints are included.
+	NumHints uint32
 }
 
 // paymentHashAndPreimage returns the payment hash and preimage for this invoice
@@ -386,6 +445,19 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 			"not exceed maximum of %v", maxHopHints)
 	}
 
+	// The hint selection can only be influenced if we include hints for
+	// our private channels.
+	if !invoice.Private && (invoice.HintStrategy != HintStrategyDefault ||
+		invoice.NumHints != 0) {
+
+		return nil, nil, errors.New("hint strategy and number of " +
+			"hints require private routing hints")
+	}
+	if invoice.NumHints > maxHopHints {
+		return nil, nil, fmt.Errorf("number of hints must not exceed "+
+			"maximum of %v", maxHopHints)
+	}
+
 	// Include route hints if needed.
 	if len(invoice.RouteHints) > 0 || invoice.Private {
 		// Validate provided hop hints.
@@ -397,11 +469,19 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 		}
 
 		totalHopHints := len(invoice.RouteHints)
-		if invoice.Private {
+		switch {
+		case invoice.Private && invoice.NumHints != 0:
+			totalHopHints += int(invoice.NumHints)
+			if totalHopHints > maxHopHints {
+				totalHopHints = maxHopHints
+			}
+
+		case invoice.Private:
 			totalHopHints = maxHopHints
 		}
 
 		hopHintsCfg := newSelectHopHintsCfg(cfg, totalHopHints)
+		hopHintsCfg.Strategy = invoice.HintStrategy
 		hopHints, err := PopulateHopHints(
 			hopHintsCfg, amtMSat, invoice.RouteHints,
 		)
@@ -644,8 +724,16 @@ type SelectHopHintsCfg struct {
 	// ChannelID is considered active.
 	IsChannelActive func(chanID lnwire.ChannelID) bool
 
+	// GetChannelUptime returns the time the peer of the given channel was
+	// observed online and the total time the channel was monitored.
+	GetChannelUptime func(chanPoint wire.OutPoint,
+		peer route.Vertex) (time.Duration, time.Duration, error)
+
 	// MaxHopHints is the maximum number of hop hints we are interested in.
 	MaxHopHints int
+
+	// Strategy is the strategy used to select the hop hints.
+	Strategy HintStrategy
 }
 
 func newSelectHopHintsCfg(invoicesCfg *AddInvoiceConfig,
@@ -657,6 +745,7 @@ func newSelectHopHintsCfg(invoicesCfg *AddInvoiceConfig,
 		IsPublicNode:          invoicesCfg.Graph.IsPublicNode,
 		FetchChannelEdgesByID: invoicesCfg.Graph.FetchChannelEdgesByID,
 		GetAlias:              invoicesCfg.GetAlias,
+		GetChannelUptime:      invoicesCfg.GetChannelUptime,
 		MaxHopHints:           maxHopHints,
 	}
 }
@@ -719,6 +808,43 @@ func getPotentialHints(cfg *SelectHopHintsCfg) ([]*channeldb.OpenChannel,
 	return privateChannels, nil
 }
 
+// sortByReliability sorts the given channels in descending order of the share
+// of time their peers were observed online. Channels without uptime
+// information are considered to be never online. Channels with the same
+// uptime keep their order.
+func sortByReliability(cfg *SelectHopHintsCfg,
+	channels []*channeldb.OpenChannel) {
+
+	uptimeRatios := make(map[wire.OutPoint]float64, len(channels))
+	for _, channel := range channels {
+		if cfg.GetChannelUptime == nil || channel.IdentityPub == nil {
+			continue
+		}
+
+		chanPoint := channel.FundingOutpoint
+		uptime, lifetime, err := cfg.GetChannelUptime(
+			chanPoint, route.NewVertex(channel.IdentityPub),
+		)
+		if err != nil {
+			log.Debugf("Unable to get uptime of channel %v: %v",
+				chanPoint, err)
+
+			continue
+		}
+
+		if lifetime > 0 {
+			uptimeRatios[chanPoint] = float64(uptime) /
+				float64(lifetime)
+		}
+	}
+
+	sort.SliceStable(channels, func(i, j int) bool {
+		iRatio := uptimeRatios[channels[i].FundingOutpoint]
+		jRatio := uptimeRatios[channels[j].FundingOutpoint]
+		return iRatio > jRatio
+	})
+}
+
 // shouldIncludeChannel returns true if the channel passes all the checks to
 // be a hopHint in a given invoice.
 func shouldIncludeChannel(cfg *SelectHopHintsCfg,
@@ -831,7 +957,31 @@ func PopulateHopHints(cfg *SelectHopHintsCfg, amtMSat lnwire.MilliSatoshi,
 		return nil, err
 	}
 
-	targetBandwidth := amtMSat * hopHintFactor
+	// Only the default strategy limits the hints to the bandwidth that is
+	// needed to receive the invoice amount. The other strategies select
+	// hints until the maximum number of hints is reached.
+	var targetBandwidth lnwire.MilliSatoshi
+	switch cfg.Strategy {
+	case HintStrategyDefault:
+		targetBandwidth = amtMSat * hopHintFactor
+
+	// The potential hints are already sorted by their remote balance.
+	case HintStrategyHighestInbound:
+
+	case HintStrategyMostReliable:
+		sortByReliability(cfg, potentialHints)
+
+	case HintStrategyRandom:
+		mathRand.Shuffle(len(potentialHints), func(i, j int) {
+			potentialHints[i], potentialHints[j] =
+				potentialHints[j], potentialHints[i]
+		})
+
+	default:
+		return nil, fmt.Errorf("unknown hint strategy: %v",
+			cfg.Strategy)
+	}
+
 	selectedHints := selectHopHints(
 		cfg, nHintsLeft, targetBandwidth, potentialHints,
 		alreadyIncluded,
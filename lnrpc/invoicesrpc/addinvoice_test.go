@@ -4,12 +4,14 @@ import (
 	"encoding/hex"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/channeldb/models"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -82,6 +84,16 @@ func (h *hopHintsConfigMock) FetchChannelEdgesByID(chanID uint64) (
 	return edgeInfo, policy1, policy2, err
 }
 
+// GetChannelUptime returns the time the peer of the given channel was observed
+// online and the total time the channel was monitored.
+func (h *hopHintsConfigMock) GetChannelUptime(chanPoint wire.OutPoint,
+	peer route.Vertex) (time.Duration, time.Duration, error) {
+
+	args := h.Mock.Called(chanPoint, peer)
+	return args.Get(0).(time.Duration), args.Get(1).(time.Duration),
+		args.Error(2)
+}
+
 // getTestPubKey returns a valid parsed pub key to be used in our tests.
 func getTestPubKey() *btcec.PublicKey {
 	pubkeyBytes, _ := hex.DecodeString(
@@ -518,6 +530,7 @@ var populateHopHintsTestCases = []struct {
 	setupMock        func(*hopHintsConfigMock)
 	amount           lnwire.MilliSatoshi
 	maxHopHints      int
+	strategy         HintStrategy
 	forcedHints      [][]zpay32.HopHint
 	expectedHopHints [][]zpay32.HopHint
 }{{
@@ -823,6 +836,94 @@ var populateHopHintsTestCases = []struct {
 			},
 		},
 	},
+}, {
+	name: "highest inbound strategy doesn't stop at the targeted " +
+		"bandwidth",
+	setupMock: func(h *hopHintsConfigMock) {
+		chanID1, chanID2 := setupMockTwoChannels(h)
+
+		// Prepare the mock for the both channels.
+		h.Mock.On(
+			"IsChannelActive", chanID1,
+		).Once().Return(true)
+
+		h.Mock.On(
+			"IsChannelActive", chanID2,
+		).Once().Return(true)
+
+		h.Mock.On(
+			"IsPublicNode", mock.Anything,
+		).Twice().Return(true, nil)
+
+		h.Mock.On(
+			"FetchChannelEdgesByID", mock.Anything,
+		).Twice().Return(
+			&models.ChannelEdgeInfo{},
+			&models.ChannelEdgePolicy{},
+			&models.ChannelEdgePolicy{}, nil,
+		)
+	},
+	maxHopHints: 10,
+	amount:      1_000_000,
+	strategy:    HintStrategyHighestInbound,
+	expectedHopHints: [][]zpay32.HopHint{
+		{
+			{
+				NodeID:    getTestPubKey(),
+				ChannelID: 9,
+			},
+		}, {
+			{
+				NodeID:    getTestPubKey(),
+				ChannelID: 2,
+			},
+		},
+	},
+}, {
+	name: "most reliable strategy uses the channels with higher uptime " +
+		"first",
+	setupMock: func(h *hopHintsConfigMock) {
+		_, chanID2 := setupMockTwoChannels(h)
+
+		// The channel with the lower remote balance has the higher
+		// uptime, so it is selected first.
+		h.Mock.On(
+			"GetChannelUptime", wire.OutPoint{Index: 9},
+			mock.Anything,
+		).Once().Return(time.Hour, 2*time.Hour, nil)
+
+		h.Mock.On(
+			"GetChannelUptime", wire.OutPoint{Index: 2},
+			mock.Anything,
+		).Once().Return(2*time.Hour, 2*time.Hour, nil)
+
+		h.Mock.On(
+			"IsChannelActive", chanID2,
+		).Once().Return(true)
+
+		h.Mock.On(
+			"IsPublicNode", mock.Anything,
+		).Once().Return(true, nil)
+
+		h.Mock.On(
+			"FetchChannelEdgesByID", mock.Anything,
+		).Once().Return(
+			&models.ChannelEdgeInfo{},
+			&models.ChannelEdgePolicy{},
+			&models.ChannelEdgePolicy{}, nil,
+		)
+	},
+	maxHopHints: 1,
+	amount:      1_000_000,
+	strategy:    HintStrategyMostReliable,
+	expectedHopHints: [][]zpay32.HopHint{
+		{
+			{
+				NodeID:    getTestPubKey(),
+				ChannelID: 2,
+			},
+		},
+	},
 }}
 
 func setupMockTwoChannels(h *hopHintsConfigMock) (lnwire.ChannelID,
@@ -884,7 +985,9 @@ func TestPopulateHopHints(t *testing.T) {
 				FetchChannelEdgesByID: mock.FetchChannelEdgesByID,
 				GetAlias:              mock.GetAlias,
 				FetchAllChannels:      mock.FetchAllChannels,
+				GetChannelUptime:      mock.GetChannelUptime,
 				MaxHopHints:           tc.maxHopHints,
+				Strategy:              tc.strategy,
 			}
 			hopHints, err := PopulateHopHints(
 				cfg, tc.amount, tc.forcedHints,
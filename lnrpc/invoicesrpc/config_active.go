@@ -4,12 +4,16 @@
 package invoicesrpc
 
 import (
+	"time"
+
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/invoices"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/netann"
+	"github.com/lightningnetwork/lnd/routing/route"
 )
 
 // Config is the primary configuration struct for the invoices RPC server. It
@@ -64,4 +68,9 @@ type Config struct {
 	// GetAlias returns the peer's alias SCID if it exists given the
 	// 32-byte ChannelID.
 	GetAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)
+
+	// GetChannelUptime returns the time the peer of the given channel was
+	// observed online and the total time the channel was monitored.
+	GetChannelUptime func(chanPoint wire.OutPoint,
+		peer route.Vertex) (time.Duration, time.Duration, error)
 }
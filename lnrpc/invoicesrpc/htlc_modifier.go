@@ -0,0 +1,208 @@
+//go:build invoicesrpc
+// +build invoicesrpc
+
+package invoicesrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// errMissingModifierMessage is returned when the client sends a
+	// message without a selection or modification.
+	errMissingModifierMessage = errors.New("either a selection or a " +
+		"modification must be set")
+)
+
+// htlcModifier is a helper struct that handles the lifecycle of an rpc
+// htlc modifier stream. The htlcs held for the modifier are sent to the
+// client, and the selections and modifications received from the client are
+// applied to the registered invoices.HtlcModifier.
+type htlcModifier struct {
+	// modifier is the modifier registered with the invoice registry.
+	modifier *invoices.HtlcModifier
+
+	// chainParams are required to marshall the invoices of held htlcs.
+	chainParams *chaincfg.Params
+
+	// stream is the bidirectional RPC stream.
+	stream Invoices_HtlcModifierServer
+
+	// quit is closed when the invoices sub-server shuts down.
+	quit chan struct{}
+}
+
+// newHtlcModifier creates a new htlcModifier.
+func newHtlcModifier(modifier *invoices.HtlcModifier,
+	chainParams *chaincfg.Params, stream Invoices_HtlcModifierServer,
+	quit chan struct{}) *htlcModifier {
+
+	return &htlcModifier{
+		modifier:    modifier,
+		chainParams: chainParams,
+		stream:      stream,
+		quit:        quit,
+	}
+}
+
+// run sends the held htlcs to the client and handles the messages received
+// from it, until the stream is closed or an error occurs.
+func (h *htlcModifier) run() error {
+	// Receive the client messages in a separate goroutine, as the stream
+	// doesn't allow to wait on them in a select.
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := h.stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			if err := h.handleClientMessage(resp); err != nil {
+				errChan <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case req := <-h.modifier.Requests:
+			rpcReq, err := marshallHtlcModifyRequest(
+				req, h.chainParams,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err := h.stream.Send(rpcReq); err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-h.stream.Context().Done():
+			return h.stream.Context().Err()
+
+		case <-h.quit:
+			return nil
+		}
+	}
+}
+
+// handleClientMessage applies a selection or modification received from the
+// client.
+func (h *htlcModifier) handleClientMessage(resp *HtlcModifyResponse) error {
+	switch {
+	case resp.GetSelection() != nil:
+		selection := resp.GetSelection()
+
+		add, err := unmarshallPaymentHashes(selection.AddPaymentHashes)
+		if err != nil {
+			return err
+		}
+
+		remove, err := unmarshallPaymentHashes(
+			selection.RemovePaymentHashes,
+		)
+		if err != nil {
+			return err
+		}
+
+		log.Debugf("Updating htlc modifier selection: all_invoices=%v, "+
+			"add=%v, remove=%v", selection.AllInvoices, add, remove)
+
+		h.modifier.SelectInvoices(selection.AllInvoices, add, remove)
+
+		return nil
+
+	case resp.GetModification() != nil:
+		modification := resp.GetModification()
+		if modification.CircuitKey == nil {
+			return errors.New("circuit key must be set")
+		}
+
+		return h.modifier.Modify(invoices.HtlcModification{
+			CircuitKey: invoices.CircuitKey{
+				ChanID: lnwire.NewShortChanIDFromInt(
+					modification.CircuitKey.ChanId,
+				),
+				HtlcID: modification.CircuitKey.HtlcId,
+			},
+			AmtPaid: lnwire.MilliSatoshi(
+				modification.AmtPaidMsat,
+			),
+			Cancel: modification.Cancel,
+		})
+
+	default:
+		return errMissingModifierMessage
+	}
+}
+
+// unmarshallPaymentHashes parses the given raw payment hashes.
+func unmarshallPaymentHashes(rawHashes [][]byte) ([]lntypes.Hash, error) {
+	hashes := make([]lntypes.Hash, 0, len(rawHashes))
+	for _, rawHash := range rawHashes {
+		hash, err := lntypes.MakeHash(rawHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment hash: %w", err)
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// marshallHtlcModifyRequest converts a held htlc to its rpc representation.
+func marshallHtlcModifyRequest(req *invoices.HtlcModifyRequest,
+	chainParams *chaincfg.Params) (*HtlcModifyRequest, error) {
+
+	rpcInvoice, err := CreateRPCInvoice(&req.Invoice, chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcReq := &HtlcModifyRequest{
+		Invoice: rpcInvoice,
+		ExitHtlcCircuitKey: &CircuitKey{
+			ChanId: req.CircuitKey.ChanID.ToUint64(),
+			HtlcId: req.CircuitKey.HtlcID,
+		},
+		ExitHtlcAmt:           uint64(req.Amt),
+		ExitHtlcExpiry:        req.Expiry,
+		CurrentHeight:         uint32(req.CurrentHeight),
+		ExitHtlcCustomRecords: req.CustomRecords,
+		ExitHtlcMetadata:      req.Metadata,
+	}
+
+	if req.MPP != nil {
+		payAddr := req.MPP.PaymentAddr()
+		rpcReq.ExitHtlcMpp = &lnrpc.MPPRecord{
+			PaymentAddr:  payAddr[:],
+			TotalAmtMsat: int64(req.MPP.TotalMsat()),
+		}
+	}
+
+	if req.AMP != nil {
+		rootShare := req.AMP.RootShare()
+		setID := req.AMP.SetID()
+		rpcReq.ExitHtlcAmp = &lnrpc.AMPRecord{
+			RootShare:  rootShare[:],
+			SetId:      setID[:],
+			ChildIndex: req.AMP.ChildIndex(),
+		}
+	}
+
+	return rpcReq, nil
+}
@@ -5,10 +5,12 @@ package invoicesrpc
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/lightningnetwork/lnd/invoices"
@@ -18,6 +20,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
@@ -65,6 +68,10 @@ var (
 			Entity: "invoices",
 			Action: "write",
 		}},
+		"/invoicesrpc.Invoices/HtlcModifier": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
 	}
 
 	// DefaultInvoicesMacFilename is the default name of the invoices
@@ -170,6 +177,16 @@ func (s *Server) Name() string {
 	return subServerName
 }
 
+// DefaultMacaroon returns the path of the invoices macaroon file and the
+// operations it is baked with.
+//
+// NOTE: This is part of the lnrpc.DefaultMacaroonBaker interface.
+func (s *Server) DefaultMacaroon() (string, []bakery.Op) {
+	return filepath.Join(
+		s.cfg.NetworkDir, DefaultInvoicesMacFilename,
+	), macaroonOps
+}
+
 // RegisterWithRootServer will be called by the root gRPC server to direct a sub
 // RPC server to register itself with the main gRPC root server. Until this is
 // called, each sub-server won't be able to have requests routed towards it.
@@ -237,6 +254,18 @@ func (s *Server) SubscribeSingleInvoice(req *SubscribeSingleInvoiceRequest,
 		return err
 	}
 
+	// If a set ID is given, we'll only send updates of that HTLC set.
+	var setID *invoices.SetID
+	if len(req.SetId) != 0 {
+		if len(req.SetId) != len(invoices.SetID{}) {
+			return status.Errorf(codes.InvalidArgument, "invalid "+
+				"set ID length: %d", len(req.SetId))
+		}
+
+		setID = &invoices.SetID{}
+		copy(setID[:], req.SetId)
+	}
+
 	invoiceClient, err := s.cfg.InvoiceRegistry.SubscribeSingleInvoice(
 		updateStream.Context(), hash,
 	)
@@ -247,15 +276,42 @@ func (s *Server) SubscribeSingleInvoice(req *SubscribeSingleInvoiceRequest,
 
 	log.Debugf("Created new single invoice(pay_hash=%v) subscription", hash)
 
+	// lastSetState is the state of the HTLC set we filter by that was last
+	// sent, used to skip updates that don't affect the set.
+	var lastSetState *lnrpc.AMPInvoiceState
+
 	for {
 		select {
 		case newInvoice := <-invoiceClient.Updates:
+			if setID != nil {
+				newInvoice = filterAMPSet(newInvoice, *setID)
+			}
+
 			rpcInvoice, err := CreateRPCInvoice(
 				newInvoice, s.cfg.ChainParams,
 			)
 			if err != nil {
 				return err
 			}
+			populateAMPSetHtlcs(rpcInvoice)
+
+			var setFinal bool
+			if setID != nil {
+				setIDStr := hex.EncodeToString(setID[:])
+				setState := rpcInvoice.AmpInvoiceState[setIDStr]
+
+				// Skip updates before the set arrived and
+				// updates that don't change it.
+				if setState == nil ||
+					proto.Equal(setState, lastSetState) {
+
+					continue
+				}
+				lastSetState = setState
+
+				setFinal = setState.State !=
+					lnrpc.InvoiceHTLCState_ACCEPTED
+			}
 
 			if err := updateStream.Send(rpcInvoice); err != nil {
 				return err
@@ -263,7 +319,7 @@ func (s *Server) SubscribeSingleInvoice(req *SubscribeSingleInvoiceRequest,
 
 			// If we have reached a terminal state, close the
 			// stream with no error.
-			if newInvoice.State.IsFinal() {
+			if newInvoice.State.IsFinal() || setFinal {
 				return nil
 			}
 
@@ -334,6 +390,7 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 		GenInvoiceFeatures:    s.cfg.GenInvoiceFeatures,
 		GenAmpInvoiceFeatures: s.cfg.GenAmpInvoiceFeatures,
 		GetAlias:              s.cfg.GetAlias,
+		GetChannelUptime:      s.cfg.GetChannelUptime,
 	}
 
 	hash, err := lntypes.MakeHash(invoice.Hash)
@@ -341,6 +398,11 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 		return nil, err
 	}
 
+	holdTimeout, err := unmarshallHoldInvoiceTimeout(invoice, hash)
+	if err != nil {
+		return nil, err
+	}
+
 	value, err := lnrpc.UnmarshallAmt(invoice.Value, invoice.ValueMsat)
 	if err != nil {
 		return nil, err
@@ -351,6 +413,11 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+
+	hintStrategy, err := UnmarshalHintStrategy(invoice.HintStrategy)
+	if err != nil {
+		return nil, err
+	}
 	addInvoiceData := &AddInvoiceData{
 		Memo:            invoice.Memo,
 		Hash:            &hash,
@@ -363,6 +430,8 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 		HodlInvoice:     true,
 		Preimage:        nil,
 		RouteHints:      routeHints,
+		HintStrategy:    hintStrategy,
+		NumHints:        invoice.NumHints,
 	}
 
 	_, dbInvoice, err := AddInvoice(ctx, addInvoiceCfg, addInvoiceData)
@@ -370,6 +439,15 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 		return nil, err
 	}
 
+	if holdTimeout != nil {
+		err := s.cfg.InvoiceRegistry.AddHoldInvoiceTimeout(
+			hash, *holdTimeout,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &AddHoldInvoiceResp{
 		AddIndex:       dbInvoice.AddIndex,
 		PaymentRequest: string(dbInvoice.PaymentRequest),
@@ -377,6 +455,55 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 	}, nil
 }
 
+// unmarshallHoldInvoiceTimeout returns the timeout of a hold invoice that is
+// requested in the given request for the invoice with the given hash, or nil if
+// none is requested.
+func unmarshallHoldInvoiceTimeout(req *AddHoldInvoiceRequest,
+	hash lntypes.Hash) (*invoices.HoldInvoiceTimeout, error) {
+
+	settleAfter := req.AutoSettleAfterSeconds
+	cancelAfter := req.AutoCancelAfterSeconds
+
+	switch {
+	case settleAfter != 0 && cancelAfter != 0:
+		return nil, errors.New("auto_settle_after_seconds and " +
+			"auto_cancel_after_seconds are mutually exclusive")
+
+	case settleAfter != 0:
+		if len(req.AutoSettlePreimage) == 0 {
+			return nil, errors.New("auto_settle_preimage is " +
+				"required with auto_settle_after_seconds")
+		}
+
+		preimage, err := lntypes.MakePreimage(req.AutoSettlePreimage)
+		if err != nil {
+			return nil, err
+		}
+
+		if !preimage.Matches(hash) {
+			return nil, errors.New("auto_settle_preimage doesn't " +
+				"match hash")
+		}
+
+		return &invoices.HoldInvoiceTimeout{
+			Timeout:  time.Duration(settleAfter) * time.Second,
+			Preimage: &preimage,
+		}, nil
+
+	case cancelAfter != 0:
+		return &invoices.HoldInvoiceTimeout{
+			Timeout: time.Duration(cancelAfter) * time.Second,
+		}, nil
+
+	case len(req.AutoSettlePreimage) != 0:
+		return nil, errors.New("auto_settle_preimage requires " +
+			"auto_settle_after_seconds")
+
+	default:
+		return nil, nil
+	}
+}
+
 // LookupInvoiceV2 attempts to look up at invoice. An invoice can be referenced
 // using either its payment hash, payment address, or set ID.
 func (s *Server) LookupInvoiceV2(ctx context.Context,
@@ -444,5 +571,35 @@ func (s *Server) LookupInvoiceV2(ctx context.Context,
 		return nil, err
 	}
 
-	return CreateRPCInvoice(&invoice, s.cfg.ChainParams)
+	rpcInvoice, err := CreateRPCInvoice(&invoice, s.cfg.ChainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reconstruct the HTLC sets of AMP invoices, so that they can be
+	// reconciled like regular invoices.
+	populateAMPSetHtlcs(rpcInvoice)
+
+	return rpcInvoice, nil
+}
+
+// HtlcModifier is a bidirectional streaming RPC that allows a client to hold
+// the htlcs paying to selected invoices and to decide how each of them is
+// processed. Only a single modifier can be registered at a time.
+func (s *Server) HtlcModifier(stream Invoices_HtlcModifierServer) error {
+	modifier, err := s.cfg.InvoiceRegistry.RegisterHtlcModifier()
+	switch {
+	case errors.Is(err, invoices.ErrHtlcModifierExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		return err
+	}
+
+	// Htlcs that are still held when the stream is closed are processed
+	// without modification.
+	defer modifier.Cancel()
+
+	return newHtlcModifier(
+		modifier, s.cfg.ChainParams, stream, s.quit,
+	).run()
 }
@@ -3,6 +3,7 @@ package invoicesrpc
 import (
 	"encoding/hex"
 	"fmt"
+	"sort"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -121,6 +122,7 @@ func CreateRPCInvoice(invoice *invoices.Invoice,
 			State:           state,
 			CustomRecords:   htlc.CustomRecords,
 			MppTotalAmtMsat: uint64(htlc.MppTotalAmt),
+			WireAmtMsat:     uint64(htlc.WireAmt),
 		}
 
 		// Populate any fields relevant to AMP payments.
@@ -217,6 +219,75 @@ func CreateRPCInvoice(invoice *invoices.Invoice,
 	return rpcInvoice, nil
 }
 
+// UnmarshalHintStrategy converts the given lnrpc.HintStrategy into the
+// HintStrategy used to select the hop hints of an invoice.
+func UnmarshalHintStrategy(strategy lnrpc.HintStrategy) (HintStrategy, error) {
+	switch strategy {
+	case lnrpc.HintStrategy_HINTS_DEFAULT:
+		return HintStrategyDefault, nil
+
+	case lnrpc.HintStrategy_HINTS_HIGHEST_INBOUND:
+		return HintStrategyHighestInbound, nil
+
+	case lnrpc.HintStrategy_HINTS_MOST_RELIABLE:
+		return HintStrategyMostReliable, nil
+
+	case lnrpc.HintStrategy_HINTS_RANDOM_K:
+		return HintStrategyRandom, nil
+
+	default:
+		return 0, fmt.Errorf("unknown hint strategy: %v", strategy)
+	}
+}
+
+// populateAMPSetHtlcs adds the HTLCs of the given AMP invoice to the AMP
+// invoice state of the HTLC set they belong to, ordered by their child index.
+func populateAMPSetHtlcs(rpcInvoice *lnrpc.Invoice) {
+	for _, htlc := range rpcInvoice.Htlcs {
+		if htlc.Amp == nil {
+			continue
+		}
+
+		setIDStr := hex.EncodeToString(htlc.Amp.SetId)
+		ampState, ok := rpcInvoice.AmpInvoiceState[setIDStr]
+		if !ok {
+			continue
+		}
+
+		ampState.Htlcs = append(ampState.Htlcs, htlc)
+	}
+
+	for _, ampState := range rpcInvoice.AmpInvoiceState {
+		sort.Slice(ampState.Htlcs, func(i, j int) bool {
+			return ampState.Htlcs[i].Amp.ChildIndex <
+				ampState.Htlcs[j].Amp.ChildIndex
+		})
+	}
+}
+
+// filterAMPSet returns a copy of the given invoice that only holds the HTLCs
+// and the AMP state of the HTLC set with the given set ID.
+func filterAMPSet(invoice *invoices.Invoice,
+	setID invoices.SetID) *invoices.Invoice {
+
+	filtered := *invoice
+	filtered.Htlcs = make(map[invoices.CircuitKey]*invoices.InvoiceHTLC)
+	for key, htlc := range invoice.Htlcs {
+		if htlc.AMP == nil || htlc.AMP.Record.SetID() != setID {
+			continue
+		}
+
+		filtered.Htlcs[key] = htlc
+	}
+
+	filtered.AMPState = make(invoices.AMPInvoiceState)
+	if ampState, ok := invoice.AMPState[setID]; ok {
+		filtered.AMPState[setID] = ampState
+	}
+
+	return &filtered
+}
+
 // CreateRPCFeatures maps a feature vector into a list of lnrpc.Features.
 func CreateRPCFeatures(fv *lnwire.FeatureVector) map[uint32]*lnrpc.Feature {
 	if fv == nil {
@@ -0,0 +1,110 @@
+package invoicesrpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/record"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAMPSetHtlcs tests that the HTLCs of an AMP invoice are grouped by their
+// HTLC set and that an invoice can be restricted to a single set.
+func TestAMPSetHtlcs(t *testing.T) {
+	t.Parallel()
+
+	setA := invoices.SetID{1}
+	setB := invoices.SetID{2}
+
+	ampHtlc := func(setID invoices.SetID, childIndex uint32,
+		state invoices.HtlcState) *invoices.InvoiceHTLC {
+
+		preimage := lntypes.Preimage{byte(childIndex)}
+
+		return &invoices.InvoiceHTLC{
+			Amt:   1000,
+			State: state,
+			AMP: &invoices.InvoiceHtlcAMPData{
+				Record: *record.NewAMP(
+					[32]byte{}, setID, childIndex,
+				),
+				Hash:     preimage.Hash(),
+				Preimage: &preimage,
+			},
+		}
+	}
+
+	chanID := lnwire.NewShortChanIDFromInt(1)
+	invoice := &invoices.Invoice{
+		State: invoices.ContractOpen,
+		Htlcs: map[invoices.CircuitKey]*invoices.InvoiceHTLC{
+			{ChanID: chanID, HtlcID: 0}: ampHtlc(
+				setA, 1, invoices.HtlcStateSettled,
+			),
+			{ChanID: chanID, HtlcID: 1}: ampHtlc(
+				setA, 0, invoices.HtlcStateSettled,
+			),
+			{ChanID: chanID, HtlcID: 2}: ampHtlc(
+				setB, 0, invoices.HtlcStateAccepted,
+			),
+		},
+		AMPState: invoices.AMPInvoiceState{
+			setA: invoices.InvoiceStateAMP{
+				State:   invoices.HtlcStateSettled,
+				AmtPaid: 2000,
+			},
+			setB: invoices.InvoiceStateAMP{
+				State:   invoices.HtlcStateAccepted,
+				AmtPaid: 1000,
+			},
+		},
+	}
+
+	params := &chaincfg.RegressionNetParams
+	rpcInvoice, err := CreateRPCInvoice(invoice, params)
+	require.NoError(t, err)
+
+	populateAMPSetHtlcs(rpcInvoice)
+
+	setAStr := hex.EncodeToString(setA[:])
+	setBStr := hex.EncodeToString(setB[:])
+	require.Len(t, rpcInvoice.AmpInvoiceState, 2)
+
+	// The HTLCs of set A are ordered by their child index and carry the
+	// child payment hashes and preimages.
+	stateA := rpcInvoice.AmpInvoiceState[setAStr]
+	require.Equal(t, lnrpc.InvoiceHTLCState_SETTLED, stateA.State)
+	require.Len(t, stateA.Htlcs, 2)
+	for i, htlc := range stateA.Htlcs {
+		preimage := lntypes.Preimage{byte(i)}
+		hash := preimage.Hash()
+
+		require.EqualValues(t, i, htlc.Amp.ChildIndex)
+		require.Equal(t, setA[:], htlc.Amp.SetId)
+		require.Equal(t, hash[:], htlc.Amp.Hash)
+		require.Equal(t, preimage[:], htlc.Amp.Preimage)
+	}
+
+	stateB := rpcInvoice.AmpInvoiceState[setBStr]
+	require.Equal(t, lnrpc.InvoiceHTLCState_ACCEPTED, stateB.State)
+	require.Len(t, stateB.Htlcs, 1)
+
+	// Restricting the invoice to set B only leaves its HTLC and state,
+	// without modifying the original invoice.
+	filtered := filterAMPSet(invoice, setB)
+	require.Len(t, filtered.Htlcs, 1)
+	require.Len(t, filtered.AMPState, 1)
+	require.Contains(t, filtered.AMPState, setB)
+	require.Len(t, invoice.Htlcs, 3)
+	require.Len(t, invoice.AMPState, 2)
+
+	// Restricting the invoice to an unknown set leaves no HTLCs.
+	filtered = filterAMPSet(invoice, invoices.SetID{3})
+	require.Empty(t, filtered.Htlcs)
+	require.Empty(t, filtered.AMPState)
+}
@@ -146,6 +146,14 @@ type LightningClient interface {
 	// arguments specified in the OpenChannelRequest, this pending channel ID can
 	// then be used to manually progress the channel funding flow.
 	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
+	// lncli: `openchannel --dry_run`
+	// EstimateChannelOpenFee runs the coin selection of the funding transaction
+	// for the given OpenChannelRequest in dry run mode. It returns the inputs that
+	// would be spent, the change amount, the fee and the resulting channel
+	// capacity without creating a pending channel or locking any UTXOs. The peer
+	// must be connected as the channel type is negotiated the same way as for an
+	// actual channel opening. Funding shims are not supported.
+	EstimateChannelOpenFee(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*EstimateChannelOpenFeeResponse, error)
 	// lncli: `batchopenchannel`
 	// BatchOpenChannel attempts to open multiple single-funded channels in a
 	// single transaction in an atomic way. This means either all channel open
@@ -168,6 +176,47 @@ type LightningClient interface {
 	// node operators to specify their own criteria for accepting inbound channels
 	// through a single persistent connection.
 	ChannelAcceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_ChannelAcceptorClient, error)
+	// lncli: `setacceptorpolicy`
+	// SetChannelAcceptorPolicy replaces the declarative policy that inbound
+	// channel requests are checked against, in addition to any client connected
+	// through ChannelAcceptor. This allows node operators to enforce simple rules
+	// without running an external channel acceptor. The policy is kept in memory
+	// only and needs to be set again after a restart.
+	SetChannelAcceptorPolicy(ctx context.Context, in *ChannelAcceptorPolicy, opts ...grpc.CallOption) (*SetChannelAcceptorPolicyResponse, error)
+	// lncli: `getacceptorpolicy`
+	// GetChannelAcceptorPolicy returns the channel acceptor policy that is
+	// currently in use.
+	GetChannelAcceptorPolicy(ctx context.Context, in *GetChannelAcceptorPolicyRequest, opts ...grpc.CallOption) (*ChannelAcceptorPolicy, error)
+	// lncli: `setpeerpolicy`
+	// SetPeerPolicy sets the default parameters of the channels we open to a
+	// peer. The defaults are used by every channel opening request to the peer
+	// that doesn't set the respective parameter explicitly. The policy is
+	// persisted in the database, setting an empty policy removes it.
+	SetPeerPolicy(ctx context.Context, in *SetPeerPolicyRequest, opts ...grpc.CallOption) (*SetPeerPolicyResponse, error)
+	// lncli: `listpeerpolicies`
+	// ListPeerPolicies returns the default channel parameters of all peers that
+	// have a policy set.
+	ListPeerPolicies(ctx context.Context, in *ListPeerPoliciesRequest, opts ...grpc.CallOption) (*ListPeerPoliciesResponse, error)
+	// lncli: `updatepeerconnpolicy`
+	// UpdatePeerConnectionPolicy sets how the connection to a peer is
+	// maintained. Without a policy, only peers that we have channels with are
+	// reconnected to. The policy is persisted in the database, setting an empty
+	// policy removes it.
+	UpdatePeerConnectionPolicy(ctx context.Context, in *UpdatePeerConnectionPolicyRequest, opts ...grpc.CallOption) (*UpdatePeerConnectionPolicyResponse, error)
+	// lncli: `listpeerconnpolicies`
+	// ListPeerConnectionPolicies returns the connection policies of all peers
+	// that have one.
+	ListPeerConnectionPolicies(ctx context.Context, in *ListPeerConnectionPoliciesRequest, opts ...grpc.CallOption) (*ListPeerConnectionPoliciesResponse, error)
+	// lncli: `updatepeergossippolicy`
+	// UpdatePeerGossipPolicy sets how gossip is exchanged with a peer. Gossip to
+	// the peer can be disabled, or the rate at which we reply to its gossip
+	// queries can be limited. The policy is persisted in the database, setting
+	// an empty policy removes it.
+	UpdatePeerGossipPolicy(ctx context.Context, in *UpdatePeerGossipPolicyRequest, opts ...grpc.CallOption) (*UpdatePeerGossipPolicyResponse, error)
+	// lncli: `listpeergossippolicies`
+	// ListPeerGossipPolicies returns the gossip policies of all peers that have
+	// one.
+	ListPeerGossipPolicies(ctx context.Context, in *ListPeerGossipPoliciesRequest, opts ...grpc.CallOption) (*ListPeerGossipPoliciesResponse, error)
 	// lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
@@ -175,7 +224,12 @@ type LightningClient interface {
 	// inactive peer. If a non-force close (cooperative closure) is requested,
 	// then the user can specify either a target number of blocks until the
 	// closure transaction is confirmed, or a manual fee rate. If neither are
-	// specified, then a default lax, block confirmation target is used.
+	// specified, then a default lax, block confirmation target is used. If the
+	// channel is being closed using the RBF cooperative close protocol and a
+	// closing transaction has already been broadcast, then a non-force close
+	// bumps the fee of the closing transaction to the given fee rate instead. In
+	// that case, the stream ends once the replacement transaction has been
+	// broadcast.
 	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
 	// lncli: `abandonchannel`
 	// AbandonChannel removes all channel state from the database except for a
@@ -238,6 +292,13 @@ type LightningClient interface {
 	// of these fields can be set. If no fields are set, then we'll only send out
 	// the latest add/settle events.
 	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
+	// lncli: `updatekeysendpolicy`
+	// UpdateKeysendPolicy replaces the policy spontaneous keysend and AMP
+	// payments, which don't pay to an invoice created by this node, are checked
+	// against. It allows enabling and disabling the acceptance of such payments
+	// at runtime and restricting them by amount and sending peer. The policy is
+	// kept in memory only and reset to the startup configuration on restart.
+	UpdateKeysendPolicy(ctx context.Context, in *UpdateKeysendPolicyRequest, opts ...grpc.CallOption) (*UpdateKeysendPolicyResponse, error)
 	// lncli: `decodepayreq`
 	// DecodePayReq takes an encoded payment request string and attempts to decode
 	// it, returning a full description of the conditions encoded within the
@@ -260,7 +321,9 @@ type LightningClient interface {
 	// components: all the nodes/vertexes, and all the edges that connect the
 	// vertexes themselves. As this is a directed graph, the edges also contain
 	// the node directional specific routing policy which includes: the time lock
-	// delta, fee information, etc.
+	// delta, fee information, etc. The since_timestamp and offset/limit fields
+	// of the request can be used to only fetch the parts of the graph that
+	// changed since a previous call, page by page.
 	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
 	// lncli: `getnodemetrics`
 	// GetNodeMetrics returns node metrics calculated from the graph. Currently
@@ -272,6 +335,12 @@ type LightningClient interface {
 	// uniquely identifies the location of transaction's funding output within the
 	// blockchain.
 	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
+	// lncli: `refreshchanneledge`
+	// RefreshChannelEdge queries our gossip peers for the announcement and the
+	// latest updates of a channel. If the channel is known as a zombie, it is
+	// resurrected first, so that it is added to the graph again once a peer
+	// sends us its announcement.
+	RefreshChannelEdge(ctx context.Context, in *RefreshChannelEdgeRequest, opts ...grpc.CallOption) (*RefreshChannelEdgeResponse, error)
 	// lncli: `getnodeinfo`
 	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
 	// channel information for the specified node identified by its public key.
@@ -317,6 +386,33 @@ type LightningClient interface {
 	// UpdateChannelPolicy allows the caller to update the fee schedule and
 	// channel policies for all channels globally, or a particular channel.
 	UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error)
+	// lncli: `updatechanconstraints`
+	// UpdateChannelConstraints sets the limits for HTLCs that are forwarded over
+	// a channel, to protect it against jamming and HTLC slot exhaustion. HTLCs
+	// that would exceed a limit are failed back with a temporary channel
+	// failure. The limits are persisted in the database, setting all limits to
+	// zero removes them.
+	UpdateChannelConstraints(ctx context.Context, in *UpdateChannelConstraintsRequest, opts ...grpc.CallOption) (*UpdateChannelConstraintsResponse, error)
+	// lncli: `updatechantags`
+	// UpdateChannelTags adds tags to or removes tags from a channel. Tags allow
+	// operators to organize their channels in named groups. The channels of a
+	// group can be listed with ListChannels, targeted by UpdateChannelPolicy,
+	// and reported on by FeeReport and ForwardingHistory. The tags are
+	// persisted in the database and retained after the channel is closed.
+	UpdateChannelTags(ctx context.Context, in *UpdateChannelTagsRequest, opts ...grpc.CallOption) (*UpdateChannelTagsResponse, error)
+	// lncli: `setanchorsweeppolicy`
+	// SetAnchorSweepPolicy overrides the deadline and the budget that are used
+	// to CPFP the commitment transaction of a force closed channel by sweeping
+	// its anchor, either for a single channel or for all channels with a peer.
+	// The policy of a channel takes precedence over the policy of its peer. The
+	// policy applies to anchors that are offered to the sweeper after it was
+	// set. Policies are persisted in the database, setting an empty policy
+	// removes it.
+	SetAnchorSweepPolicy(ctx context.Context, in *SetAnchorSweepPolicyRequest, opts ...grpc.CallOption) (*SetAnchorSweepPolicyResponse, error)
+	// lncli: `listanchorsweeppolicies`
+	// ListAnchorSweepPolicies returns the anchor sweep policies of all channels
+	// and peers that have one.
+	ListAnchorSweepPolicies(ctx context.Context, in *ListAnchorSweepPoliciesRequest, opts ...grpc.CallOption) (*ListAnchorSweepPoliciesResponse, error)
 	// lncli: `fwdinghistory`
 	// ForwardingHistory allows the caller to query the htlcswitch for a record of
 	// all HTLCs forwarded within the target time range, and integer offset
@@ -354,7 +450,21 @@ type LightningClient interface {
 	// single encrypted multi-chan backup and attempts to recover any funds
 	// remaining within the channel. If we are able to unpack the backup, then the
 	// new channel will be shown under listchannels, as well as pending channels.
+	// The channels contained in the backup are returned, and can be listed
+	// without restoring them with a dry run. A subset of the channels can be
+	// restored by selecting their funding outpoints.
 	RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
+	// lncli: `rescuechannels`
+	// RequestForceCloseFromBackup attempts to recover the funds of channels that
+	// were restored from a static channel backup, or that lost their state. As
+	// we can't publish a commitment for these channels, the remote peer is asked
+	// to force close them, by resending our channel reestablish message and
+	// sending an error for the channel. If the peer isn't connected, a persistent
+	// connection attempt is made. Channels of which we still have the full state
+	// are force closed by publishing our latest commitment, if requested. The
+	// recovery progress of every channel is returned, so that the method can be
+	// polled to learn which channels were recovered and which are stuck.
+	RequestForceCloseFromBackup(ctx context.Context, in *RequestForceCloseFromBackupRequest, opts ...grpc.CallOption) (*RequestForceCloseFromBackupResponse, error)
 	// SubscribeChannelBackups allows a client to sub-subscribe to the most up to
 	// date information concerning the state of all channel backups. Each time a
 	// new channel is added, we return the new set of channels, along with a
@@ -375,6 +485,13 @@ type LightningClient interface {
 	// DeleteMacaroonID deletes the specified macaroon ID and invalidates all
 	// macaroons derived from that ID.
 	DeleteMacaroonID(ctx context.Context, in *DeleteMacaroonIDRequest, opts ...grpc.CallOption) (*DeleteMacaroonIDResponse, error)
+	// lncli: `rotatemacaroonrootkey`
+	// RotateMacaroonRootKey creates a new version of a macaroon root key. All
+	// macaroons baked with the root key ID from now on use the new version, while
+	// macaroons baked with previous versions stay valid until they are
+	// invalidated by version. Rotating the default root key re-issues the default
+	// macaroons, which allows credentials to be rotated without downtime.
+	RotateMacaroonRootKey(ctx context.Context, in *RotateMacaroonRootKeyRequest, opts ...grpc.CallOption) (*RotateMacaroonRootKeyResponse, error)
 	// lncli: `listpermissions`
 	// ListPermissions lists all RPC method URIs and their required macaroon
 	// permissions to access them.
@@ -407,6 +524,16 @@ type LightningClient interface {
 	// needs to be compiled with  the `dev` build tag, and the message type to
 	// override should be specified in lnd's experimental protocol configuration.
 	SubscribeCustomMessages(ctx context.Context, in *SubscribeCustomMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeCustomMessagesClient, error)
+	// lncli: `sendonionmessage`
+	// SendOnionMessage sends an onion message to a connected peer. The onion
+	// needs to be constructed by the caller along a blinded path that starts at
+	// the given peer.
+	SendOnionMessage(ctx context.Context, in *SendOnionMessageRequest, opts ...grpc.CallOption) (*SendOnionMessageResponse, error)
+	// lncli: `subscribeonionmessages`
+	// SubscribeOnionMessages subscribes to a stream of incoming onion messages
+	// that are addressed to this node. Onion messages that are addressed to other
+	// nodes are relayed automatically.
+	SubscribeOnionMessages(ctx context.Context, in *SubscribeOnionMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeOnionMessagesClient, error)
 	// lncli: `listaliases`
 	// ListAliases returns the set of all aliases that have ever existed with
 	// their confirmed SCID (if it exists) and/or the base SCID (in the case of
@@ -416,6 +543,26 @@ type LightningClient interface {
 	// If the htlc has no final resolution yet, a NotFound grpc status code is
 	// returned.
 	LookupHtlcResolution(ctx context.Context, in *LookupHtlcResolutionRequest, opts ...grpc.CallOption) (*LookupHtlcResolutionResponse, error)
+	// lncli: `db migrate`
+	// MigrateDatabase copies the channel state from the kvdb store to the native
+	// SQL store in resumable batches and streams the progress of the migration.
+	// The kvdb store stays authoritative, so the migration can be interrupted and
+	// restarted at any time. This requires lnd to run with the
+	// db.dual-write-channel-state option so the SQL store is kept up to date
+	// with channel updates that happen during and after the migration.
+	MigrateDatabase(ctx context.Context, in *MigrateDatabaseRequest, opts ...grpc.CallOption) (Lightning_MigrateDatabaseClient, error)
+	// lncli: `db info`
+	// GetDatabaseInfo returns the database backend lnd is running with and the
+	// connection pool statistics of the native SQL store, which can be used to
+	// detect a saturated database on busy nodes.
+	GetDatabaseInfo(ctx context.Context, in *GetDatabaseInfoRequest, opts ...grpc.CallOption) (*GetDatabaseInfoResponse, error)
+	// lncli: `db backup`
+	// BackupDatabase writes a consistent snapshot of all SQL databases to a
+	// directory on the host lnd runs on, while lnd keeps running. SQLite
+	// databases are copied with SQLite's online backup API, Postgres databases
+	// are dumped with pg_dump, which needs to be installed on the host. This
+	// call is not supported for the bolt and etcd backends.
+	BackupDatabase(ctx context.Context, in *BackupDatabaseRequest, opts ...grpc.CallOption) (*BackupDatabaseResponse, error)
 }
 
 type lightningClient struct {
@@ -734,6 +881,15 @@ func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
 	return m, nil
 }
 
+func (c *lightningClient) EstimateChannelOpenFee(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*EstimateChannelOpenFeeResponse, error) {
+	out := new(EstimateChannelOpenFeeResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/EstimateChannelOpenFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) BatchOpenChannel(ctx context.Context, in *BatchOpenChannelRequest, opts ...grpc.CallOption) (*BatchOpenChannelResponse, error) {
 	out := new(BatchOpenChannelResponse)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/BatchOpenChannel", in, out, opts...)
@@ -783,6 +939,78 @@ func (x *lightningChannelAcceptorClient) Recv() (*ChannelAcceptRequest, error) {
 	return m, nil
 }
 
+func (c *lightningClient) SetChannelAcceptorPolicy(ctx context.Context, in *ChannelAcceptorPolicy, opts ...grpc.CallOption) (*SetChannelAcceptorPolicyResponse, error) {
+	out := new(SetChannelAcceptorPolicyResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/SetChannelAcceptorPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetChannelAcceptorPolicy(ctx context.Context, in *GetChannelAcceptorPolicyRequest, opts ...grpc.CallOption) (*ChannelAcceptorPolicy, error) {
+	out := new(ChannelAcceptorPolicy)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/GetChannelAcceptorPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SetPeerPolicy(ctx context.Context, in *SetPeerPolicyRequest, opts ...grpc.CallOption) (*SetPeerPolicyResponse, error) {
+	out := new(SetPeerPolicyResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/SetPeerPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeerPolicies(ctx context.Context, in *ListPeerPoliciesRequest, opts ...grpc.CallOption) (*ListPeerPoliciesResponse, error) {
+	out := new(ListPeerPoliciesResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ListPeerPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdatePeerConnectionPolicy(ctx context.Context, in *UpdatePeerConnectionPolicyRequest, opts ...grpc.CallOption) (*UpdatePeerConnectionPolicyResponse, error) {
+	out := new(UpdatePeerConnectionPolicyResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/UpdatePeerConnectionPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeerConnectionPolicies(ctx context.Context, in *ListPeerConnectionPoliciesRequest, opts ...grpc.CallOption) (*ListPeerConnectionPoliciesResponse, error) {
+	out := new(ListPeerConnectionPoliciesResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ListPeerConnectionPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdatePeerGossipPolicy(ctx context.Context, in *UpdatePeerGossipPolicyRequest, opts ...grpc.CallOption) (*UpdatePeerGossipPolicyResponse, error) {
+	out := new(UpdatePeerGossipPolicyResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/UpdatePeerGossipPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeerGossipPolicies(ctx context.Context, in *ListPeerGossipPoliciesRequest, opts ...grpc.CallOption) (*ListPeerGossipPoliciesResponse, error) {
+	out := new(ListPeerGossipPoliciesResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ListPeerGossipPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[5], "/lnrpc.Lightning/CloseChannel", opts...)
 	if err != nil {
@@ -965,6 +1193,15 @@ func (x *lightningSubscribeInvoicesClient) Recv() (*Invoice, error) {
 	return m, nil
 }
 
+func (c *lightningClient) UpdateKeysendPolicy(ctx context.Context, in *UpdateKeysendPolicyRequest, opts ...grpc.CallOption) (*UpdateKeysendPolicyResponse, error) {
+	out := new(UpdateKeysendPolicyResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/UpdateKeysendPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error) {
 	out := new(PayReq)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/DecodePayReq", in, out, opts...)
@@ -1028,6 +1265,15 @@ func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest,
 	return out, nil
 }
 
+func (c *lightningClient) RefreshChannelEdge(ctx context.Context, in *RefreshChannelEdgeRequest, opts ...grpc.CallOption) (*RefreshChannelEdgeResponse, error) {
+	out := new(RefreshChannelEdgeResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/RefreshChannelEdge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
 	out := new(NodeInfo)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, opts...)
@@ -1123,6 +1369,42 @@ func (c *lightningClient) UpdateChannelPolicy(ctx context.Context, in *PolicyUpd
 	return out, nil
 }
 
+func (c *lightningClient) UpdateChannelConstraints(ctx context.Context, in *UpdateChannelConstraintsRequest, opts ...grpc.CallOption) (*UpdateChannelConstraintsResponse, error) {
+	out := new(UpdateChannelConstraintsResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/UpdateChannelConstraints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateChannelTags(ctx context.Context, in *UpdateChannelTagsRequest, opts ...grpc.CallOption) (*UpdateChannelTagsResponse, error) {
+	out := new(UpdateChannelTagsResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/UpdateChannelTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SetAnchorSweepPolicy(ctx context.Context, in *SetAnchorSweepPolicyRequest, opts ...grpc.CallOption) (*SetAnchorSweepPolicyResponse, error) {
+	out := new(SetAnchorSweepPolicyResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/SetAnchorSweepPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListAnchorSweepPolicies(ctx context.Context, in *ListAnchorSweepPoliciesRequest, opts ...grpc.CallOption) (*ListAnchorSweepPoliciesResponse, error) {
+	out := new(ListAnchorSweepPoliciesResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ListAnchorSweepPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error) {
 	out := new(ForwardingHistoryResponse)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ForwardingHistory", in, out, opts...)
@@ -1168,6 +1450,15 @@ func (c *lightningClient) RestoreChannelBackups(ctx context.Context, in *Restore
 	return out, nil
 }
 
+func (c *lightningClient) RequestForceCloseFromBackup(ctx context.Context, in *RequestForceCloseFromBackupRequest, opts ...grpc.CallOption) (*RequestForceCloseFromBackupResponse, error) {
+	out := new(RequestForceCloseFromBackupResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/RequestForceCloseFromBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) SubscribeChannelBackups(ctx context.Context, in *ChannelBackupSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelBackupsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[10], "/lnrpc.Lightning/SubscribeChannelBackups", opts...)
 	if err != nil {
@@ -1227,6 +1518,15 @@ func (c *lightningClient) DeleteMacaroonID(ctx context.Context, in *DeleteMacaro
 	return out, nil
 }
 
+func (c *lightningClient) RotateMacaroonRootKey(ctx context.Context, in *RotateMacaroonRootKeyRequest, opts ...grpc.CallOption) (*RotateMacaroonRootKeyResponse, error) {
+	out := new(RotateMacaroonRootKeyResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/RotateMacaroonRootKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) ListPermissions(ctx context.Context, in *ListPermissionsRequest, opts ...grpc.CallOption) (*ListPermissionsResponse, error) {
 	out := new(ListPermissionsResponse)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ListPermissions", in, out, opts...)
@@ -1317,6 +1617,47 @@ func (x *lightningSubscribeCustomMessagesClient) Recv() (*CustomMessage, error)
 	return m, nil
 }
 
+func (c *lightningClient) SendOnionMessage(ctx context.Context, in *SendOnionMessageRequest, opts ...grpc.CallOption) (*SendOnionMessageResponse, error) {
+	out := new(SendOnionMessageResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/SendOnionMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeOnionMessages(ctx context.Context, in *SubscribeOnionMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeOnionMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[13], "/lnrpc.Lightning/SubscribeOnionMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeOnionMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeOnionMessagesClient interface {
+	Recv() (*OnionMessageUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeOnionMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeOnionMessagesClient) Recv() (*OnionMessageUpdate, error) {
+	m := new(OnionMessageUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lightningClient) ListAliases(ctx context.Context, in *ListAliasesRequest, opts ...grpc.CallOption) (*ListAliasesResponse, error) {
 	out := new(ListAliasesResponse)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ListAliases", in, out, opts...)
@@ -1335,6 +1676,56 @@ func (c *lightningClient) LookupHtlcResolution(ctx context.Context, in *LookupHt
 	return out, nil
 }
 
+func (c *lightningClient) MigrateDatabase(ctx context.Context, in *MigrateDatabaseRequest, opts ...grpc.CallOption) (Lightning_MigrateDatabaseClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[14], "/lnrpc.Lightning/MigrateDatabase", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningMigrateDatabaseClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_MigrateDatabaseClient interface {
+	Recv() (*MigrateDatabaseUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningMigrateDatabaseClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningMigrateDatabaseClient) Recv() (*MigrateDatabaseUpdate, error) {
+	m := new(MigrateDatabaseUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) GetDatabaseInfo(ctx context.Context, in *GetDatabaseInfoRequest, opts ...grpc.CallOption) (*GetDatabaseInfoResponse, error) {
+	out := new(GetDatabaseInfoResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/GetDatabaseInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) BackupDatabase(ctx context.Context, in *BackupDatabaseRequest, opts ...grpc.CallOption) (*BackupDatabaseResponse, error) {
+	out := new(BackupDatabaseResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/BackupDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LightningServer is the server API for Lightning service.
 // All implementations must embed UnimplementedLightningServer
 // for forward compatibility
@@ -1467,6 +1858,14 @@ type LightningServer interface {
 	// arguments specified in the OpenChannelRequest, this pending channel ID can
 	// then be used to manually progress the channel funding flow.
 	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	// lncli: `openchannel --dry_run`
+	// EstimateChannelOpenFee runs the coin selection of the funding transaction
+	// for the given OpenChannelRequest in dry run mode. It returns the inputs that
+	// would be spent, the change amount, the fee and the resulting channel
+	// capacity without creating a pending channel or locking any UTXOs. The peer
+	// must be connected as the channel type is negotiated the same way as for an
+	// actual channel opening. Funding shims are not supported.
+	EstimateChannelOpenFee(context.Context, *OpenChannelRequest) (*EstimateChannelOpenFeeResponse, error)
 	// lncli: `batchopenchannel`
 	// BatchOpenChannel attempts to open multiple single-funded channels in a
 	// single transaction in an atomic way. This means either all channel open
@@ -1489,6 +1888,47 @@ type LightningServer interface {
 	// node operators to specify their own criteria for accepting inbound channels
 	// through a single persistent connection.
 	ChannelAcceptor(Lightning_ChannelAcceptorServer) error
+	// lncli: `setacceptorpolicy`
+	// SetChannelAcceptorPolicy replaces the declarative policy that inbound
+	// channel requests are checked against, in addition to any client connected
+	// through ChannelAcceptor. This allows node operators to enforce simple rules
+	// without running an external channel acceptor. The policy is kept in memory
+	// only and needs to be set again after a restart.
+	SetChannelAcceptorPolicy(context.Context, *ChannelAcceptorPolicy) (*SetChannelAcceptorPolicyResponse, error)
+	// lncli: `getacceptorpolicy`
+	// GetChannelAcceptorPolicy returns the channel acceptor policy that is
+	// currently in use.
+	GetChannelAcceptorPolicy(context.Context, *GetChannelAcceptorPolicyRequest) (*ChannelAcceptorPolicy, error)
+	// lncli: `setpeerpolicy`
+	// SetPeerPolicy sets the default parameters of the channels we open to a
+	// peer. The defaults are used by every channel opening request to the peer
+	// that doesn't set the respective parameter explicitly. The policy is
+	// persisted in the database, setting an empty policy removes it.
+	SetPeerPolicy(context.Context, *SetPeerPolicyRequest) (*SetPeerPolicyResponse, error)
+	// lncli: `listpeerpolicies`
+	// ListPeerPolicies returns the default channel parameters of all peers that
+	// have a policy set.
+	ListPeerPolicies(context.Context, *ListPeerPoliciesRequest) (*ListPeerPoliciesResponse, error)
+	// lncli: `updatepeerconnpolicy`
+	// UpdatePeerConnectionPolicy sets how the connection to a peer is
+	// maintained. Without a policy, only peers that we have channels with are
+	// reconnected to. The policy is persisted in the database, setting an empty
+	// policy removes it.
+	UpdatePeerConnectionPolicy(context.Context, *UpdatePeerConnectionPolicyRequest) (*UpdatePeerConnectionPolicyResponse, error)
+	// lncli: `listpeerconnpolicies`
+	// ListPeerConnectionPolicies returns the connection policies of all peers
+	// that have one.
+	ListPeerConnectionPolicies(context.Context, *ListPeerConnectionPoliciesRequest) (*ListPeerConnectionPoliciesResponse, error)
+	// lncli: `updatepeergossippolicy`
+	// UpdatePeerGossipPolicy sets how gossip is exchanged with a peer. Gossip to
+	// the peer can be disabled, or the rate at which we reply to its gossip
+	// queries can be limited. The policy is persisted in the database, setting
+	// an empty policy removes it.
+	UpdatePeerGossipPolicy(context.Context, *UpdatePeerGossipPolicyRequest) (*UpdatePeerGossipPolicyResponse, error)
+	// lncli: `listpeergossippolicies`
+	// ListPeerGossipPolicies returns the gossip policies of all peers that have
+	// one.
+	ListPeerGossipPolicies(context.Context, *ListPeerGossipPoliciesRequest) (*ListPeerGossipPoliciesResponse, error)
 	// lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
@@ -1496,7 +1936,12 @@ type LightningServer interface {
 	// inactive peer. If a non-force close (cooperative closure) is requested,
 	// then the user can specify either a target number of blocks until the
 	// closure transaction is confirmed, or a manual fee rate. If neither are
-	// specified, then a default lax, block confirmation target is used.
+	// specified, then a default lax, block confirmation target is used. If the
+	// channel is being closed using the RBF cooperative close protocol and a
+	// closing transaction has already been broadcast, then a non-force close
+	// bumps the fee of the closing transaction to the given fee rate instead. In
+	// that case, the stream ends once the replacement transaction has been
+	// broadcast.
 	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
 	// lncli: `abandonchannel`
 	// AbandonChannel removes all channel state from the database except for a
@@ -1559,6 +2004,13 @@ type LightningServer interface {
 	// of these fields can be set. If no fields are set, then we'll only send out
 	// the latest add/settle events.
 	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
+	// lncli: `updatekeysendpolicy`
+	// UpdateKeysendPolicy replaces the policy spontaneous keysend and AMP
+	// payments, which don't pay to an invoice created by this node, are checked
+	// against. It allows enabling and disabling the acceptance of such payments
+	// at runtime and restricting them by amount and sending peer. The policy is
+	// kept in memory only and reset to the startup configuration on restart.
+	UpdateKeysendPolicy(context.Context, *UpdateKeysendPolicyRequest) (*UpdateKeysendPolicyResponse, error)
 	// lncli: `decodepayreq`
 	// DecodePayReq takes an encoded payment request string and attempts to decode
 	// it, returning a full description of the conditions encoded within the
@@ -1581,7 +2033,9 @@ type LightningServer interface {
 	// components: all the nodes/vertexes, and all the edges that connect the
 	// vertexes themselves. As this is a directed graph, the edges also contain
 	// the node directional specific routing policy which includes: the time lock
-	// delta, fee information, etc.
+	// delta, fee information, etc. The since_timestamp and offset/limit fields
+	// of the request can be used to only fetch the parts of the graph that
+	// changed since a previous call, page by page.
 	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
 	// lncli: `getnodemetrics`
 	// GetNodeMetrics returns node metrics calculated from the graph. Currently
@@ -1593,6 +2047,12 @@ type LightningServer interface {
 	// uniquely identifies the location of transaction's funding output within the
 	// blockchain.
 	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
+	// lncli: `refreshchanneledge`
+	// RefreshChannelEdge queries our gossip peers for the announcement and the
+	// latest updates of a channel. If the channel is known as a zombie, it is
+	// resurrected first, so that it is added to the graph again once a peer
+	// sends us its announcement.
+	RefreshChannelEdge(context.Context, *RefreshChannelEdgeRequest) (*RefreshChannelEdgeResponse, error)
 	// lncli: `getnodeinfo`
 	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
 	// channel information for the specified node identified by its public key.
@@ -1638,6 +2098,33 @@ type LightningServer interface {
 	// UpdateChannelPolicy allows the caller to update the fee schedule and
 	// channel policies for all channels globally, or a particular channel.
 	UpdateChannelPolicy(context.Context, *PolicyUpdateRequest) (*PolicyUpdateResponse, error)
+	// lncli: `updatechanconstraints`
+	// UpdateChannelConstraints sets the limits for HTLCs that are forwarded over
+	// a channel, to protect it against jamming and HTLC slot exhaustion. HTLCs
+	// that would exceed a limit are failed back with a temporary channel
+	// failure. The limits are persisted in the database, setting all limits to
+	// zero removes them.
+	UpdateChannelConstraints(context.Context, *UpdateChannelConstraintsRequest) (*UpdateChannelConstraintsResponse, error)
+	// lncli: `updatechantags`
+	// UpdateChannelTags adds tags to or removes tags from a channel. Tags allow
+	// operators to organize their channels in named groups. The channels of a
+	// group can be listed with ListChannels, targeted by UpdateChannelPolicy,
+	// and reported on by FeeReport and ForwardingHistory. The tags are
+	// persisted in the database and retained after the channel is closed.
+	UpdateChannelTags(context.Context, *UpdateChannelTagsRequest) (*UpdateChannelTagsResponse, error)
+	// lncli: `setanchorsweeppolicy`
+	// SetAnchorSweepPolicy overrides the deadline and the budget that are used
+	// to CPFP the commitment transaction of a force closed channel by sweeping
+	// its anchor, either for a single channel or for all channels with a peer.
+	// The policy of a channel takes precedence over the policy of its peer. The
+	// policy applies to anchors that are offered to the sweeper after it was
+	// set. Policies are persisted in the database, setting an empty policy
+	// removes it.
+	SetAnchorSweepPolicy(context.Context, *SetAnchorSweepPolicyRequest) (*SetAnchorSweepPolicyResponse, error)
+	// lncli: `listanchorsweeppolicies`
+	// ListAnchorSweepPolicies returns the anchor sweep policies of all channels
+	// and peers that have one.
+	ListAnchorSweepPolicies(context.Context, *ListAnchorSweepPoliciesRequest) (*ListAnchorSweepPoliciesResponse, error)
 	// lncli: `fwdinghistory`
 	// ForwardingHistory allows the caller to query the htlcswitch for a record of
 	// all HTLCs forwarded within the target time range, and integer offset
@@ -1675,7 +2162,21 @@ type LightningServer interface {
 	// single encrypted multi-chan backup and attempts to recover any funds
 	// remaining within the channel. If we are able to unpack the backup, then the
 	// new channel will be shown under listchannels, as well as pending channels.
+	// The channels contained in the backup are returned, and can be listed
+	// without restoring them with a dry run. A subset of the channels can be
+	// restored by selecting their funding outpoints.
 	RestoreChannelBackups(context.Context, *RestoreChanBackupRequest) (*RestoreBackupResponse, error)
+	// lncli: `rescuechannels`
+	// RequestForceCloseFromBackup attempts to recover the funds of channels that
+	// were restored from a static channel backup, or that lost their state. As
+	// we can't publish a commitment for these channels, the remote peer is asked
+	// to force close them, by resending our channel reestablish message and
+	// sending an error for the channel. If the peer isn't connected, a persistent
+	// connection attempt is made. Channels of which we still have the full state
+	// are force closed by publishing our latest commitment, if requested. The
+	// recovery progress of every channel is returned, so that the method can be
+	// polled to learn which channels were recovered and which are stuck.
+	RequestForceCloseFromBackup(context.Context, *RequestForceCloseFromBackupRequest) (*RequestForceCloseFromBackupResponse, error)
 	// SubscribeChannelBackups allows a client to sub-subscribe to the most up to
 	// date information concerning the state of all channel backups. Each time a
 	// new channel is added, we return the new set of channels, along with a
@@ -1696,6 +2197,13 @@ type LightningServer interface {
 	// DeleteMacaroonID deletes the specified macaroon ID and invalidates all
 	// macaroons derived from that ID.
 	DeleteMacaroonID(context.Context, *DeleteMacaroonIDRequest) (*DeleteMacaroonIDResponse, error)
+	// lncli: `rotatemacaroonrootkey`
+	// RotateMacaroonRootKey creates a new version of a macaroon root key. All
+	// macaroons baked with the root key ID from now on use the new version, while
+	// macaroons baked with previous versions stay valid until they are
+	// invalidated by version. Rotating the default root key re-issues the default
+	// macaroons, which allows credentials to be rotated without downtime.
+	RotateMacaroonRootKey(context.Context, *RotateMacaroonRootKeyRequest) (*RotateMacaroonRootKeyResponse, error)
 	// lncli: `listpermissions`
 	// ListPermissions lists all RPC method URIs and their required macaroon
 	// permissions to access them.
@@ -1728,6 +2236,16 @@ type LightningServer interface {
 	// needs to be compiled with  the `dev` build tag, and the message type to
 	// override should be specified in lnd's experimental protocol configuration.
 	SubscribeCustomMessages(*SubscribeCustomMessagesRequest, Lightning_SubscribeCustomMessagesServer) error
+	// lncli: `sendonionmessage`
+	// SendOnionMessage sends an onion message to a connected peer. The onion
+	// needs to be constructed by the caller along a blinded path that starts at
+	// the given peer.
+	SendOnionMessage(context.Context, *SendOnionMessageRequest) (*SendOnionMessageResponse, error)
+	// lncli: `subscribeonionmessages`
+	// SubscribeOnionMessages subscribes to a stream of incoming onion messages
+	// that are addressed to this node. Onion messages that are addressed to other
+	// nodes are relayed automatically.
+	SubscribeOnionMessages(*SubscribeOnionMessagesRequest, Lightning_SubscribeOnionMessagesServer) error
 	// lncli: `listaliases`
 	// ListAliases returns the set of all aliases that have ever existed with
 	// their confirmed SCID (if it exists) and/or the base SCID (in the case of
@@ -1737,6 +2255,26 @@ type LightningServer interface {
 	// If the htlc has no final resolution yet, a NotFound grpc status code is
 	// returned.
 	LookupHtlcResolution(context.Context, *LookupHtlcResolutionRequest) (*LookupHtlcResolutionResponse, error)
+	// lncli: `db migrate`
+	// MigrateDatabase copies the channel state from the kvdb store to the native
+	// SQL store in resumable batches and streams the progress of the migration.
+	// The kvdb store stays authoritative, so the migration can be interrupted and
+	// restarted at any time. This requires lnd to run with the
+	// db.dual-write-channel-state option so the SQL store is kept up to date
+	// with channel updates that happen during and after the migration.
+	MigrateDatabase(*MigrateDatabaseRequest, Lightning_MigrateDatabaseServer) error
+	// lncli: `db info`
+	// GetDatabaseInfo returns the database backend lnd is running with and the
+	// connection pool statistics of the native SQL store, which can be used to
+	// detect a saturated database on busy nodes.
+	GetDatabaseInfo(context.Context, *GetDatabaseInfoRequest) (*GetDatabaseInfoResponse, error)
+	// lncli: `db backup`
+	// BackupDatabase writes a consistent snapshot of all SQL databases to a
+	// directory on the host lnd runs on, while lnd keeps running. SQLite
+	// databases are copied with SQLite's online backup API, Postgres databases
+	// are dumped with pg_dump, which needs to be installed on the host. This
+	// call is not supported for the bolt and etcd backends.
+	BackupDatabase(context.Context, *BackupDatabaseRequest) (*BackupDatabaseResponse, error)
 	mustEmbedUnimplementedLightningServer()
 }
 
@@ -1816,6 +2354,9 @@ func (UnimplementedLightningServer) OpenChannelSync(context.Context, *OpenChanne
 func (UnimplementedLightningServer) OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error {
 	return status.Errorf(codes.Unimplemented, "method OpenChannel not implemented")
 }
+func (UnimplementedLightningServer) EstimateChannelOpenFee(context.Context, *OpenChannelRequest) (*EstimateChannelOpenFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateChannelOpenFee not implemented")
+}
 func (UnimplementedLightningServer) BatchOpenChannel(context.Context, *BatchOpenChannelRequest) (*BatchOpenChannelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchOpenChannel not implemented")
 }
@@ -1825,6 +2366,30 @@ func (UnimplementedLightningServer) FundingStateStep(context.Context, *FundingTr
 func (UnimplementedLightningServer) ChannelAcceptor(Lightning_ChannelAcceptorServer) error {
 	return status.Errorf(codes.Unimplemented, "method ChannelAcceptor not implemented")
 }
+func (UnimplementedLightningServer) SetChannelAcceptorPolicy(context.Context, *ChannelAcceptorPolicy) (*SetChannelAcceptorPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetChannelAcceptorPolicy not implemented")
+}
+func (UnimplementedLightningServer) GetChannelAcceptorPolicy(context.Context, *GetChannelAcceptorPolicyRequest) (*ChannelAcceptorPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelAcceptorPolicy not implemented")
+}
+func (UnimplementedLightningServer) SetPeerPolicy(context.Context, *SetPeerPolicyRequest) (*SetPeerPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPeerPolicy not implemented")
+}
+func (UnimplementedLightningServer) ListPeerPolicies(context.Context, *ListPeerPoliciesRequest) (*ListPeerPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerPolicies not implemented")
+}
+func (UnimplementedLightningServer) UpdatePeerConnectionPolicy(context.Context, *UpdatePeerConnectionPolicyRequest) (*UpdatePeerConnectionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePeerConnectionPolicy not implemented")
+}
+func (UnimplementedLightningServer) ListPeerConnectionPolicies(context.Context, *ListPeerConnectionPoliciesRequest) (*ListPeerConnectionPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerConnectionPolicies not implemented")
+}
+func (UnimplementedLightningServer) UpdatePeerGossipPolicy(context.Context, *UpdatePeerGossipPolicyRequest) (*UpdatePeerGossipPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePeerGossipPolicy not implemented")
+}
+func (UnimplementedLightningServer) ListPeerGossipPolicies(context.Context, *ListPeerGossipPoliciesRequest) (*ListPeerGossipPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerGossipPolicies not implemented")
+}
 func (UnimplementedLightningServer) CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error {
 	return status.Errorf(codes.Unimplemented, "method CloseChannel not implemented")
 }
@@ -1855,6 +2420,9 @@ func (UnimplementedLightningServer) LookupInvoice(context.Context, *PaymentHash)
 func (UnimplementedLightningServer) SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeInvoices not implemented")
 }
+func (UnimplementedLightningServer) UpdateKeysendPolicy(context.Context, *UpdateKeysendPolicyRequest) (*UpdateKeysendPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateKeysendPolicy not implemented")
+}
 func (UnimplementedLightningServer) DecodePayReq(context.Context, *PayReqString) (*PayReq, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DecodePayReq not implemented")
 }
@@ -1876,6 +2444,9 @@ func (UnimplementedLightningServer) GetNodeMetrics(context.Context, *NodeMetrics
 func (UnimplementedLightningServer) GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChanInfo not implemented")
 }
+func (UnimplementedLightningServer) RefreshChannelEdge(context.Context, *RefreshChannelEdgeRequest) (*RefreshChannelEdgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshChannelEdge not implemented")
+}
 func (UnimplementedLightningServer) GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
 }
@@ -1900,6 +2471,18 @@ func (UnimplementedLightningServer) FeeReport(context.Context, *FeeReportRequest
 func (UnimplementedLightningServer) UpdateChannelPolicy(context.Context, *PolicyUpdateRequest) (*PolicyUpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateChannelPolicy not implemented")
 }
+func (UnimplementedLightningServer) UpdateChannelConstraints(context.Context, *UpdateChannelConstraintsRequest) (*UpdateChannelConstraintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChannelConstraints not implemented")
+}
+func (UnimplementedLightningServer) UpdateChannelTags(context.Context, *UpdateChannelTagsRequest) (*UpdateChannelTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChannelTags not implemented")
+}
+func (UnimplementedLightningServer) SetAnchorSweepPolicy(context.Context, *SetAnchorSweepPolicyRequest) (*SetAnchorSweepPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAnchorSweepPolicy not implemented")
+}
+func (UnimplementedLightningServer) ListAnchorSweepPolicies(context.Context, *ListAnchorSweepPoliciesRequest) (*ListAnchorSweepPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAnchorSweepPolicies not implemented")
+}
 func (UnimplementedLightningServer) ForwardingHistory(context.Context, *ForwardingHistoryRequest) (*ForwardingHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ForwardingHistory not implemented")
 }
@@ -1915,6 +2498,9 @@ func (UnimplementedLightningServer) VerifyChanBackup(context.Context, *ChanBacku
 func (UnimplementedLightningServer) RestoreChannelBackups(context.Context, *RestoreChanBackupRequest) (*RestoreBackupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestoreChannelBackups not implemented")
 }
+func (UnimplementedLightningServer) RequestForceCloseFromBackup(context.Context, *RequestForceCloseFromBackupRequest) (*RequestForceCloseFromBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestForceCloseFromBackup not implemented")
+}
 func (UnimplementedLightningServer) SubscribeChannelBackups(*ChannelBackupSubscription, Lightning_SubscribeChannelBackupsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeChannelBackups not implemented")
 }
@@ -1927,6 +2513,9 @@ func (UnimplementedLightningServer) ListMacaroonIDs(context.Context, *ListMacaro
 func (UnimplementedLightningServer) DeleteMacaroonID(context.Context, *DeleteMacaroonIDRequest) (*DeleteMacaroonIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMacaroonID not implemented")
 }
+func (UnimplementedLightningServer) RotateMacaroonRootKey(context.Context, *RotateMacaroonRootKeyRequest) (*RotateMacaroonRootKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateMacaroonRootKey not implemented")
+}
 func (UnimplementedLightningServer) ListPermissions(context.Context, *ListPermissionsRequest) (*ListPermissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPermissions not implemented")
 }
@@ -1942,12 +2531,27 @@ func (UnimplementedLightningServer) SendCustomMessage(context.Context, *SendCust
 func (UnimplementedLightningServer) SubscribeCustomMessages(*SubscribeCustomMessagesRequest, Lightning_SubscribeCustomMessagesServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeCustomMessages not implemented")
 }
+func (UnimplementedLightningServer) SendOnionMessage(context.Context, *SendOnionMessageRequest) (*SendOnionMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendOnionMessage not implemented")
+}
+func (UnimplementedLightningServer) SubscribeOnionMessages(*SubscribeOnionMessagesRequest, Lightning_SubscribeOnionMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOnionMessages not implemented")
+}
 func (UnimplementedLightningServer) ListAliases(context.Context, *ListAliasesRequest) (*ListAliasesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAliases not implemented")
 }
 func (UnimplementedLightningServer) LookupHtlcResolution(context.Context, *LookupHtlcResolutionRequest) (*LookupHtlcResolutionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LookupHtlcResolution not implemented")
 }
+func (UnimplementedLightningServer) MigrateDatabase(*MigrateDatabaseRequest, Lightning_MigrateDatabaseServer) error {
+	return status.Errorf(codes.Unimplemented, "method MigrateDatabase not implemented")
+}
+func (UnimplementedLightningServer) GetDatabaseInfo(context.Context, *GetDatabaseInfoRequest) (*GetDatabaseInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDatabaseInfo not implemented")
+}
+func (UnimplementedLightningServer) BackupDatabase(context.Context, *BackupDatabaseRequest) (*BackupDatabaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupDatabase not implemented")
+}
 func (UnimplementedLightningServer) mustEmbedUnimplementedLightningServer() {}
 
 // UnsafeLightningServer may be embedded to opt out of forward compatibility for this service.
@@ -2405,6 +3009,24 @@ func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_EstimateChannelOpenFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).EstimateChannelOpenFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/EstimateChannelOpenFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).EstimateChannelOpenFee(ctx, req.(*OpenChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_BatchOpenChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BatchOpenChannelRequest)
 	if err := dec(in); err != nil {
@@ -2467,46 +3089,190 @@ func (x *lightningChannelAcceptorServer) Recv() (*ChannelAcceptResponse, error)
 	return m, nil
 }
 
-func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CloseChannelRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Lightning_SetChannelAcceptorPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelAcceptorPolicy)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
-}
-
-type Lightning_CloseChannelServer interface {
-	Send(*CloseStatusUpdate) error
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(LightningServer).SetChannelAcceptorPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SetChannelAcceptorPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SetChannelAcceptorPolicy(ctx, req.(*ChannelAcceptorPolicy))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type lightningCloseChannelServer struct {
-	grpc.ServerStream
+func _Lightning_GetChannelAcceptorPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelAcceptorPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetChannelAcceptorPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetChannelAcceptorPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetChannelAcceptorPolicy(ctx, req.(*GetChannelAcceptorPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
-	return x.ServerStream.SendMsg(m)
+func _Lightning_SetPeerPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPeerPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SetPeerPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SetPeerPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SetPeerPolicy(ctx, req.(*SetPeerPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_AbandonChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AbandonChannelRequest)
+func _Lightning_ListPeerPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeerPoliciesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).AbandonChannel(ctx, in)
+		return srv.(LightningServer).ListPeerPolicies(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/AbandonChannel",
+		FullMethod: "/lnrpc.Lightning/ListPeerPolicies",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).AbandonChannel(ctx, req.(*AbandonChannelRequest))
+		return srv.(LightningServer).ListPeerPolicies(ctx, req.(*ListPeerPoliciesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+func _Lightning_UpdatePeerConnectionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePeerConnectionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdatePeerConnectionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdatePeerConnectionPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdatePeerConnectionPolicy(ctx, req.(*UpdatePeerConnectionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPeerConnectionPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeerConnectionPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPeerConnectionPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPeerConnectionPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPeerConnectionPolicies(ctx, req.(*ListPeerConnectionPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_UpdatePeerGossipPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePeerGossipPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdatePeerGossipPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdatePeerGossipPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdatePeerGossipPolicy(ctx, req.(*UpdatePeerGossipPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPeerGossipPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeerGossipPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPeerGossipPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPeerGossipPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPeerGossipPolicies(ctx, req.(*ListPeerGossipPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CloseChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
+}
+
+type Lightning_CloseChannelServer interface {
+	Send(*CloseStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningCloseChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_AbandonChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbandonChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).AbandonChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/AbandonChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).AbandonChannel(ctx, req.(*AbandonChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
 }
 
@@ -2669,6 +3435,24 @@ func (x *lightningSubscribeInvoicesServer) Send(m *Invoice) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_UpdateKeysendPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateKeysendPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateKeysendPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateKeysendPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateKeysendPolicy(ctx, req.(*UpdateKeysendPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_DecodePayReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PayReqString)
 	if err := dec(in); err != nil {
@@ -2795,6 +3579,24 @@ func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_RefreshChannelEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshChannelEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).RefreshChannelEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/RefreshChannelEdge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).RefreshChannelEdge(ctx, req.(*RefreshChannelEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NodeInfoRequest)
 	if err := dec(in); err != nil {
@@ -2942,6 +3744,78 @@ func _Lightning_UpdateChannelPolicy_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_UpdateChannelConstraints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChannelConstraintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateChannelConstraints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateChannelConstraints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateChannelConstraints(ctx, req.(*UpdateChannelConstraintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_UpdateChannelTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChannelTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateChannelTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateChannelTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateChannelTags(ctx, req.(*UpdateChannelTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SetAnchorSweepPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAnchorSweepPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SetAnchorSweepPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SetAnchorSweepPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SetAnchorSweepPolicy(ctx, req.(*SetAnchorSweepPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListAnchorSweepPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAnchorSweepPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListAnchorSweepPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListAnchorSweepPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListAnchorSweepPolicies(ctx, req.(*ListAnchorSweepPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ForwardingHistoryRequest)
 	if err := dec(in); err != nil {
@@ -3032,6 +3906,24 @@ func _Lightning_RestoreChannelBackups_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_RequestForceCloseFromBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestForceCloseFromBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).RequestForceCloseFromBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/RequestForceCloseFromBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).RequestForceCloseFromBackup(ctx, req.(*RequestForceCloseFromBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_SubscribeChannelBackups_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ChannelBackupSubscription)
 	if err := stream.RecvMsg(m); err != nil {
@@ -3107,6 +3999,24 @@ func _Lightning_DeleteMacaroonID_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_RotateMacaroonRootKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateMacaroonRootKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).RotateMacaroonRootKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/RotateMacaroonRootKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).RotateMacaroonRootKey(ctx, req.(*RotateMacaroonRootKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_ListPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPermissionsRequest)
 	if err := dec(in); err != nil {
@@ -3208,6 +4118,45 @@ func (x *lightningSubscribeCustomMessagesServer) Send(m *CustomMessage) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_SendOnionMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOnionMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendOnionMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendOnionMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendOnionMessage(ctx, req.(*SendOnionMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeOnionMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOnionMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeOnionMessages(m, &lightningSubscribeOnionMessagesServer{stream})
+}
+
+type Lightning_SubscribeOnionMessagesServer interface {
+	Send(*OnionMessageUpdate) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeOnionMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeOnionMessagesServer) Send(m *OnionMessageUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_ListAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListAliasesRequest)
 	if err := dec(in); err != nil {
@@ -3244,6 +4193,63 @@ func _Lightning_LookupHtlcResolution_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_MigrateDatabase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MigrateDatabaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).MigrateDatabase(m, &lightningMigrateDatabaseServer{stream})
+}
+
+type Lightning_MigrateDatabaseServer interface {
+	Send(*MigrateDatabaseUpdate) error
+	grpc.ServerStream
+}
+
+type lightningMigrateDatabaseServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningMigrateDatabaseServer) Send(m *MigrateDatabaseUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_GetDatabaseInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDatabaseInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetDatabaseInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetDatabaseInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetDatabaseInfo(ctx, req.(*GetDatabaseInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_BackupDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupDatabaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BackupDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BackupDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BackupDatabase(ctx, req.(*BackupDatabaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Lightning_ServiceDesc is the grpc.ServiceDesc for Lightning service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3331,6 +4337,10 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OpenChannelSync",
 			Handler:    _Lightning_OpenChannelSync_Handler,
 		},
+		{
+			MethodName: "EstimateChannelOpenFee",
+			Handler:    _Lightning_EstimateChannelOpenFee_Handler,
+		},
 		{
 			MethodName: "BatchOpenChannel",
 			Handler:    _Lightning_BatchOpenChannel_Handler,
@@ -3339,6 +4349,38 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FundingStateStep",
 			Handler:    _Lightning_FundingStateStep_Handler,
 		},
+		{
+			MethodName: "SetChannelAcceptorPolicy",
+			Handler:    _Lightning_SetChannelAcceptorPolicy_Handler,
+		},
+		{
+			MethodName: "GetChannelAcceptorPolicy",
+			Handler:    _Lightning_GetChannelAcceptorPolicy_Handler,
+		},
+		{
+			MethodName: "SetPeerPolicy",
+			Handler:    _Lightning_SetPeerPolicy_Handler,
+		},
+		{
+			MethodName: "ListPeerPolicies",
+			Handler:    _Lightning_ListPeerPolicies_Handler,
+		},
+		{
+			MethodName: "UpdatePeerConnectionPolicy",
+			Handler:    _Lightning_UpdatePeerConnectionPolicy_Handler,
+		},
+		{
+			MethodName: "ListPeerConnectionPolicies",
+			Handler:    _Lightning_ListPeerConnectionPolicies_Handler,
+		},
+		{
+			MethodName: "UpdatePeerGossipPolicy",
+			Handler:    _Lightning_UpdatePeerGossipPolicy_Handler,
+		},
+		{
+			MethodName: "ListPeerGossipPolicies",
+			Handler:    _Lightning_ListPeerGossipPolicies_Handler,
+		},
 		{
 			MethodName: "AbandonChannel",
 			Handler:    _Lightning_AbandonChannel_Handler,
@@ -3363,6 +4405,10 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LookupInvoice",
 			Handler:    _Lightning_LookupInvoice_Handler,
 		},
+		{
+			MethodName: "UpdateKeysendPolicy",
+			Handler:    _Lightning_UpdateKeysendPolicy_Handler,
+		},
 		{
 			MethodName: "DecodePayReq",
 			Handler:    _Lightning_DecodePayReq_Handler,
@@ -3391,6 +4437,10 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChanInfo",
 			Handler:    _Lightning_GetChanInfo_Handler,
 		},
+		{
+			MethodName: "RefreshChannelEdge",
+			Handler:    _Lightning_RefreshChannelEdge_Handler,
+		},
 		{
 			MethodName: "GetNodeInfo",
 			Handler:    _Lightning_GetNodeInfo_Handler,
@@ -3419,6 +4469,22 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateChannelPolicy",
 			Handler:    _Lightning_UpdateChannelPolicy_Handler,
 		},
+		{
+			MethodName: "UpdateChannelConstraints",
+			Handler:    _Lightning_UpdateChannelConstraints_Handler,
+		},
+		{
+			MethodName: "UpdateChannelTags",
+			Handler:    _Lightning_UpdateChannelTags_Handler,
+		},
+		{
+			MethodName: "SetAnchorSweepPolicy",
+			Handler:    _Lightning_SetAnchorSweepPolicy_Handler,
+		},
+		{
+			MethodName: "ListAnchorSweepPolicies",
+			Handler:    _Lightning_ListAnchorSweepPolicies_Handler,
+		},
 		{
 			MethodName: "ForwardingHistory",
 			Handler:    _Lightning_ForwardingHistory_Handler,
@@ -3439,6 +4505,10 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RestoreChannelBackups",
 			Handler:    _Lightning_RestoreChannelBackups_Handler,
 		},
+		{
+			MethodName: "RequestForceCloseFromBackup",
+			Handler:    _Lightning_RequestForceCloseFromBackup_Handler,
+		},
 		{
 			MethodName: "BakeMacaroon",
 			Handler:    _Lightning_BakeMacaroon_Handler,
@@ -3451,6 +4521,10 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMacaroonID",
 			Handler:    _Lightning_DeleteMacaroonID_Handler,
 		},
+		{
+			MethodName: "RotateMacaroonRootKey",
+			Handler:    _Lightning_RotateMacaroonRootKey_Handler,
+		},
 		{
 			MethodName: "ListPermissions",
 			Handler:    _Lightning_ListPermissions_Handler,
@@ -3463,6 +4537,10 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendCustomMessage",
 			Handler:    _Lightning_SendCustomMessage_Handler,
 		},
+		{
+			MethodName: "SendOnionMessage",
+			Handler:    _Lightning_SendOnionMessage_Handler,
+		},
 		{
 			MethodName: "ListAliases",
 			Handler:    _Lightning_ListAliases_Handler,
@@ -3471,6 +4549,14 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LookupHtlcResolution",
 			Handler:    _Lightning_LookupHtlcResolution_Handler,
 		},
+		{
+			MethodName: "GetDatabaseInfo",
+			Handler:    _Lightning_GetDatabaseInfo_Handler,
+		},
+		{
+			MethodName: "BackupDatabase",
+			Handler:    _Lightning_BackupDatabase_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3542,6 +4628,16 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Lightning_SubscribeCustomMessages_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeOnionMessages",
+			Handler:       _Lightning_SubscribeOnionMessages_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "MigrateDatabase",
+			Handler:       _Lightning_MigrateDatabase_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "lightning.proto",
 }
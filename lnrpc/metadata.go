@@ -14,6 +14,7 @@ var (
 		regexp.MustCompile("^/v1/channels/transaction-stream$"),
 		regexp.MustCompile("^/v2/router/htlcinterceptor$"),
 		regexp.MustCompile("^/v1/middleware$"),
+		regexp.MustCompile("^/v2/invoices/htlcmodifier$"),
 	}
 
 	// MaxGrpcMsgSize is used when we configure both server and clients to
@@ -0,0 +1,157 @@
+package lnrpc_test
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/accountingrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/autopilotrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/chainrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/devrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/neutrinorpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/peersrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/verrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/watchtowerrpc"
+	_ "github.com/lightningnetwork/lnd/lnrpc/wtclientrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var (
+	// deprecatedStreamingRPCs are the streaming RPCs that are deprecated
+	// and intentionally have no REST endpoint.
+	deprecatedStreamingRPCs = map[string]bool{
+		"lnrpc.Lightning.SendToRoute":   true,
+		"routerrpc.Router.SendPayment":  true,
+		"routerrpc.Router.TrackPayment": true,
+	}
+
+	// selectorRegex matches the selector of an HTTP rule in a REST
+	// mapping file.
+	selectorRegex = regexp.MustCompile(`^\s*- selector: (\S+)\s*$`)
+
+	// pathRegex matches the HTTP method and path of an HTTP rule in a
+	// REST mapping file.
+	pathRegex = regexp.MustCompile(
+		`^\s*(get|post|put|delete|patch): "(\S+)"\s*$`,
+	)
+)
+
+// readRESTMappings reads the HTTP rules of all REST mapping files and returns
+// the path of every mapped RPC.
+func readRESTMappings(t *testing.T) map[string]string {
+	t.Helper()
+
+	files, err := filepath.Glob("*.yaml")
+	require.NoError(t, err)
+	subServerFiles, err := filepath.Glob("*/*.yaml")
+	require.NoError(t, err)
+
+	mappings := make(map[string]string)
+	for _, file := range append(files, subServerFiles...) {
+		f, err := os.Open(file)
+		require.NoError(t, err)
+
+		var selector string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			match := selectorRegex.FindStringSubmatch(line)
+			if match != nil {
+				selector = match[1]
+				continue
+			}
+
+			match = pathRegex.FindStringSubmatch(line)
+			if match != nil && selector != "" {
+				mappings[selector] = match[2]
+				selector = ""
+			}
+		}
+		require.NoError(t, scanner.Err())
+		require.NoError(t, f.Close())
+	}
+
+	return mappings
+}
+
+// TestStreamingRPCsRESTMapping makes sure every streaming RPC can be reached
+// through the WebSocket proxy of the REST API and that the client-streaming
+// RPCs are known to the proxy.
+func TestStreamingRPCsRESTMapping(t *testing.T) {
+	t.Parallel()
+
+	mappings := readRESTMappings(t)
+
+	// We only look at the packages that have REST mappings, which are all
+	// packages of lnd's RPC services.
+	packages := make(map[string]bool)
+	for selector := range mappings {
+		packages[strings.Split(selector, ".")[0]] = true
+	}
+
+	isClientStreamingURI := func(path string) bool {
+		for _, pattern := range lnrpc.LndClientStreamingURIs {
+			if pattern.MatchString(path) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// Collect all streaming RPCs of lnd's services.
+	var streamingRPCs []protoreflect.MethodDescriptor
+	protoregistry.GlobalFiles.RangeFiles(
+		func(file protoreflect.FileDescriptor) bool {
+			if !packages[string(file.Package())] {
+				return true
+			}
+
+			services := file.Services()
+			for i := 0; i < services.Len(); i++ {
+				methods := services.Get(i).Methods()
+				for j := 0; j < methods.Len(); j++ {
+					method := methods.Get(j)
+					if method.IsStreamingServer() ||
+						method.IsStreamingClient() {
+
+						streamingRPCs = append(
+							streamingRPCs, method,
+						)
+					}
+				}
+			}
+
+			return true
+		},
+	)
+	require.NotEmpty(t, streamingRPCs)
+
+	for _, method := range streamingRPCs {
+		name := string(method.FullName())
+		if deprecatedStreamingRPCs[name] {
+			continue
+		}
+
+		path, ok := mappings[name]
+		require.Truef(t, ok, "streaming RPC %v has no REST mapping",
+			name)
+
+		require.Equalf(
+			t, method.IsStreamingClient(),
+			isClientStreamingURI(path),
+			"client-streaming URI of %v", name,
+		)
+	}
+}
@@ -0,0 +1,50 @@
+package lnrpc
+
+import "sort"
+
+// PaginateAfterCursor returns the page of at most maxItems of the given items
+// that follow the cursor, or precede it if reversed is set. The items must be
+// sorted by the key the cursor refers to. The cmpCursor function compares an
+// item to the cursor and returns a negative number if the item sorts before
+// the cursor, zero if it is the cursor and a positive number if it sorts after
+// it. A nil cmpCursor refers to the start of the items, or their end if
+// reversed is set, and a maxItems of zero returns all remaining items.
+//
+// As the cursor identifies an item by its key instead of its position, pages
+// stay consistent if items are added or removed between two queries, and the
+// cursor doesn't need to refer to an item that still exists.
+func PaginateAfterCursor[T any](items []T, cmpCursor func(T) int,
+	maxItems uint64, reversed bool) []T {
+
+	start, end := 0, len(items)
+	if cmpCursor != nil {
+		// Find the first item that doesn't sort before the cursor.
+		idx := sort.Search(len(items), func(i int) bool {
+			return cmpCursor(items[i]) >= 0
+		})
+
+		switch {
+		// Seeking backwards, the page ends right before the cursor.
+		case reversed:
+			end = idx
+
+		// The cursor itself was already returned with the previous
+		// page, so we skip it.
+		case idx < len(items) && cmpCursor(items[idx]) == 0:
+			start = idx + 1
+
+		default:
+			start = idx
+		}
+	}
+
+	if maxItems != 0 && uint64(end-start) > maxItems {
+		if reversed {
+			start = end - int(maxItems)
+		} else {
+			end = start + int(maxItems)
+		}
+	}
+
+	return items[start:end]
+}
@@ -0,0 +1,126 @@
+package lnrpc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaginateAfterCursor tests that pages of items are selected relative to
+// the key of the cursor, whether or not the cursor refers to an existing item.
+func TestPaginateAfterCursor(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"b", "d", "f", "h", "j"}
+
+	testCases := []struct {
+		name     string
+		cursor   string
+		maxItems uint64
+		reversed bool
+		expected []string
+	}{
+		{
+			name:     "all items",
+			expected: items,
+		},
+		{
+			name:     "first page",
+			maxItems: 2,
+			expected: []string{"b", "d"},
+		},
+		{
+			name:     "next page",
+			cursor:   "d",
+			maxItems: 2,
+			expected: []string{"f", "h"},
+		},
+		{
+			name:     "last partial page",
+			cursor:   "h",
+			maxItems: 2,
+			expected: []string{"j"},
+		},
+		{
+			name:     "cursor at end",
+			cursor:   "j",
+			maxItems: 2,
+			expected: []string{},
+		},
+		{
+			name:     "removed cursor",
+			cursor:   "e",
+			maxItems: 2,
+			expected: []string{"f", "h"},
+		},
+		{
+			name:     "cursor beyond end",
+			cursor:   "z",
+			expected: []string{},
+		},
+		{
+			name:     "reversed from end",
+			maxItems: 2,
+			reversed: true,
+			expected: []string{"h", "j"},
+		},
+		{
+			name:     "reversed previous page",
+			cursor:   "h",
+			maxItems: 2,
+			reversed: true,
+			expected: []string{"d", "f"},
+		},
+		{
+			name:     "reversed first partial page",
+			cursor:   "d",
+			maxItems: 2,
+			reversed: true,
+			expected: []string{"b"},
+		},
+		{
+			name:     "reversed cursor at start",
+			cursor:   "b",
+			reversed: true,
+			expected: []string{},
+		},
+		{
+			name:     "reversed removed cursor",
+			cursor:   "g",
+			maxItems: 2,
+			reversed: true,
+			expected: []string{"d", "f"},
+		},
+		{
+			name:     "reversed cursor beyond end",
+			cursor:   "z",
+			maxItems: 1,
+			reversed: true,
+			expected: []string{"j"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cmpCursor func(string) int
+			if tc.cursor != "" {
+				cmpCursor = func(item string) int {
+					return strings.Compare(item, tc.cursor)
+				}
+			}
+
+			page := PaginateAfterCursor(
+				items, cmpCursor, tc.maxItems, tc.reversed,
+			)
+			require.Equal(t, tc.expected, page)
+		})
+	}
+
+	page := PaginateAfterCursor[string](nil, nil, 10, false)
+	require.Empty(t, page)
+}
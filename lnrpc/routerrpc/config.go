@@ -58,6 +58,8 @@ func DefaultConfig() *Config {
 		AttemptCostPPM:  routing.DefaultAttemptCostPPM,
 		MaxMcHistory:    routing.DefaultMaxMcHistory,
 		McFlushInterval: routing.DefaultMcFlushInterval,
+
+		McHistoryHalfLife: routing.DefaultMcHistoryHalfLife,
 		AprioriConfig: &AprioriConfig{
 			HopProbability:   routing.DefaultAprioriHopProbability,
 			Weight:           routing.DefaultAprioriWeight,
@@ -86,6 +88,7 @@ func GetRoutingConfig(cfg *Config) *RoutingConfig {
 		AttemptCostPPM:           cfg.AttemptCostPPM,
 		MaxMcHistory:             cfg.MaxMcHistory,
 		McFlushInterval:          cfg.McFlushInterval,
+		McHistoryHalfLife:        cfg.McHistoryHalfLife,
 		AprioriConfig: &AprioriConfig{
 			HopProbability:   cfg.AprioriConfig.HopProbability,
 			Weight:           cfg.AprioriConfig.Weight,
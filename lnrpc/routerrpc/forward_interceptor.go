@@ -1,6 +1,7 @@
 package routerrpc
 
 import (
+	"bytes"
 	"errors"
 
 	"github.com/lightningnetwork/lnd/channeldb/models"
@@ -59,7 +60,7 @@ func (r *forwardInterceptor) run() error {
 			return err
 		}
 
-		if err := r.resolveFromClient(resp); err != nil {
+		if err := r.handleClientMessage(resp); err != nil {
 			return err
 		}
 	}
@@ -96,6 +97,91 @@ func (r *forwardInterceptor) onIntercept(
 	return r.stream.Send(interceptionRequest)
 }
 
+// handleClientMessage handles a message arrived from the client. It either
+// updates the filter of the interceptor, or resolves a single or a batch of
+// intercepted htlcs.
+func (r *forwardInterceptor) handleClientMessage(
+	in *ForwardHtlcInterceptResponse) error {
+
+	switch {
+	case in.Filter != nil:
+		filter, err := newInterceptFilter(in.Filter)
+		if err != nil {
+			return err
+		}
+
+		log.Debugf("Updating interceptor filter: %v", in.Filter)
+
+		r.htlcSwitch.SetInterceptFilter(filter)
+
+		return nil
+
+	case len(in.Batch) > 0:
+		for _, res := range in.Batch {
+			if res.Filter != nil || len(res.Batch) > 0 {
+				return status.Errorf(codes.InvalidArgument,
+					"batched resolution must not contain "+
+						"a filter or a batch")
+			}
+
+			if err := r.resolveFromClient(res); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	default:
+		return r.resolveFromClient(in)
+	}
+}
+
+// newInterceptFilter converts the filter sent by the client into a filter for
+// the switch.
+func newInterceptFilter(
+	in *HtlcInterceptFilter) (htlcswitch.InterceptFilter, error) {
+
+	if len(in.PaymentHashPrefix) > lntypes.HashSize {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"payment hash prefix exceeds %v bytes",
+			lntypes.HashSize)
+	}
+
+	minAmt := lnwire.MilliSatoshi(in.MinOutgoingAmountMsat)
+	maxAmt := lnwire.MilliSatoshi(in.MaxOutgoingAmountMsat)
+	if maxAmt != 0 && maxAmt < minAmt {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"max outgoing amount is below min outgoing amount")
+	}
+
+	chanIDs := make(map[uint64]struct{}, len(in.IncomingChanIds))
+	for _, chanID := range in.IncomingChanIds {
+		chanIDs[chanID] = struct{}{}
+	}
+
+	hashPrefix := make([]byte, len(in.PaymentHashPrefix))
+	copy(hashPrefix, in.PaymentHashPrefix)
+
+	return func(htlc htlcswitch.InterceptedPacket) bool {
+		if len(chanIDs) > 0 {
+			chanID := htlc.IncomingCircuit.ChanID.ToUint64()
+			if _, ok := chanIDs[chanID]; !ok {
+				return false
+			}
+		}
+
+		if !bytes.HasPrefix(htlc.Hash[:], hashPrefix) {
+			return false
+		}
+
+		if htlc.OutgoingAmount < minAmt {
+			return false
+		}
+
+		return maxAmt == 0 || htlc.OutgoingAmount <= maxAmt
+	}, nil
+}
+
 // resolveFromClient handles a resolution arrived from the client.
 func (r *forwardInterceptor) resolveFromClient(
 	in *ForwardHtlcInterceptResponse) error {
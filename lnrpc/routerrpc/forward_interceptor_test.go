@@ -0,0 +1,117 @@
+package routerrpc
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInterceptFilter tests that the filter sent by an interceptor client is
+// validated and matches the expected htlcs.
+func TestInterceptFilter(t *testing.T) {
+	t.Parallel()
+
+	hash := lntypes.Hash{0xaa, 0xbb, 0xcc}
+	htlc := htlcswitch.InterceptedPacket{
+		IncomingCircuit: models.CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+		},
+		Hash:           hash,
+		OutgoingAmount: 1000,
+	}
+
+	testCases := []struct {
+		name      string
+		filter    *HtlcInterceptFilter
+		expectErr bool
+		match     bool
+	}{
+		{
+			name:   "empty filter",
+			filter: &HtlcInterceptFilter{},
+			match:  true,
+		},
+		{
+			name: "matching channel",
+			filter: &HtlcInterceptFilter{
+				IncomingChanIds: []uint64{2, 1},
+			},
+			match: true,
+		},
+		{
+			name: "other channel",
+			filter: &HtlcInterceptFilter{
+				IncomingChanIds: []uint64{2},
+			},
+		},
+		{
+			name: "matching hash prefix",
+			filter: &HtlcInterceptFilter{
+				PaymentHashPrefix: []byte{0xaa, 0xbb},
+			},
+			match: true,
+		},
+		{
+			name: "other hash prefix",
+			filter: &HtlcInterceptFilter{
+				PaymentHashPrefix: []byte{0xaa, 0xcc},
+			},
+		},
+		{
+			name: "hash prefix too long",
+			filter: &HtlcInterceptFilter{
+				PaymentHashPrefix: make([]byte, 33),
+			},
+			expectErr: true,
+		},
+		{
+			name: "amount in range",
+			filter: &HtlcInterceptFilter{
+				MinOutgoingAmountMsat: 1000,
+				MaxOutgoingAmountMsat: 1000,
+			},
+			match: true,
+		},
+		{
+			name: "amount below range",
+			filter: &HtlcInterceptFilter{
+				MinOutgoingAmountMsat: 1001,
+			},
+		},
+		{
+			name: "amount above range",
+			filter: &HtlcInterceptFilter{
+				MaxOutgoingAmountMsat: 999,
+			},
+		},
+		{
+			name: "invalid amount range",
+			filter: &HtlcInterceptFilter{
+				MinOutgoingAmountMsat: 2000,
+				MaxOutgoingAmountMsat: 1000,
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := newInterceptFilter(tc.filter)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tc.match, filter(htlc))
+		})
+	}
+}
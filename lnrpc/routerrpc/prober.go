@@ -0,0 +1,267 @@
+package routerrpc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/ticker"
+)
+
+const (
+	// DefaultProbeInterval is the interval between two probes of a
+	// scheduled probe if no interval is specified.
+	DefaultProbeInterval = 10 * time.Minute
+
+	// DefaultProbeTimeout is the maximum time a single probe may take if
+	// no timeout is specified.
+	DefaultProbeTimeout = time.Minute
+)
+
+var (
+	// errUnknownProbe is returned when a scheduled probe is requested that
+	// doesn't exist.
+	errUnknownProbe = errors.New("unknown probe")
+
+	// errProberShuttingDown is returned when a probe is scheduled while the
+	// prober is shutting down.
+	errProberShuttingDown = errors.New("prober shutting down")
+)
+
+// probeTarget describes the destination and the parameters of a scheduled
+// probe.
+type probeTarget struct {
+	// dest is the destination that is probed.
+	dest route.Vertex
+
+	// amt is the amount the destination is probed with.
+	amt lnwire.MilliSatoshi
+
+	// interval is the time between two probes.
+	interval time.Duration
+
+	// timeout is the maximum time a single probe may take.
+	timeout time.Duration
+
+	// feeLimit is the maximum fee that a probe route may have.
+	feeLimit btcutil.Amount
+
+	// namespace is the mission control namespace that the probes report
+	// their results to.
+	namespace string
+}
+
+// probeOutcome is the outcome of a single probe.
+type probeOutcome struct {
+	// timestamp is the time at which the probe finished.
+	timestamp time.Time
+
+	// reachable is true if the probe reached the destination.
+	reachable bool
+
+	// failureReason is the reason why the probe didn't reach the
+	// destination.
+	failureReason lnrpc.PaymentFailureReason
+
+	// routingFee is the fee of the route that reached the destination.
+	routingFee lnwire.MilliSatoshi
+
+	// timeLockDelay is the total time lock of the route that reached the
+	// destination.
+	timeLockDelay int64
+
+	// duration is the time that the probe took.
+	duration time.Duration
+
+	// err is set if the probe couldn't be sent.
+	err error
+}
+
+// scheduledProbe is a probe that is periodically sent to a destination.
+type scheduledProbe struct {
+	// id identifies the scheduled probe.
+	id uint64
+
+	// target is the destination and the parameters of the probes.
+	target probeTarget
+
+	// numReachable is the number of probes that reached the destination.
+	numReachable uint64
+
+	// numUnreachable is the number of probes that failed to reach the
+	// destination.
+	numUnreachable uint64
+
+	// lastOutcome is the outcome of the latest probe. It is nil if no
+	// probe finished yet.
+	lastOutcome *probeOutcome
+
+	quit chan struct{}
+}
+
+// proberConfig contains the dependencies of the prober.
+type proberConfig struct {
+	// sendProbe sends a single probe to the given target and blocks until
+	// its outcome is known.
+	sendProbe func(target *probeTarget) *probeOutcome
+
+	// newTicker creates the ticker that triggers the probes of a scheduled
+	// probe.
+	newTicker func(interval time.Duration) ticker.Ticker
+}
+
+// prober periodically sends probes with a random payment hash to a set of
+// destinations. This keeps mission control up to date and tracks whether the
+// destinations are reachable.
+type prober struct {
+	cfg *proberConfig
+
+	// nextID is the id of the next scheduled probe.
+	nextID uint64
+
+	// probes holds the active scheduled probes by their id.
+	probes map[uint64]*scheduledProbe
+
+	// stopped is true once the prober was stopped.
+	stopped bool
+
+	sync.Mutex
+}
+
+// newProber creates a new prober without any scheduled probes.
+func newProber(cfg *proberConfig) *prober {
+	return &prober{
+		cfg:    cfg,
+		nextID: 1,
+		probes: make(map[uint64]*scheduledProbe),
+	}
+}
+
+// startProbe schedules a probe for the given target and returns its id. The
+// first probe is sent right away.
+func (p *prober) startProbe(target probeTarget) (uint64, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.stopped {
+		return 0, errProberShuttingDown
+	}
+
+	probe := &scheduledProbe{
+		id:     p.nextID,
+		target: target,
+		quit:   make(chan struct{}),
+	}
+	p.nextID++
+	p.probes[probe.id] = probe
+
+	go p.probeLoop(probe)
+
+	log.Infof("Scheduled probe %v to %v for %v every %v", probe.id,
+		target.dest, target.amt, target.interval)
+
+	return probe.id, nil
+}
+
+// probeLoop sends the probes of the given scheduled probe until it is
+// stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (p *prober) probeLoop(probe *scheduledProbe) {
+	probeTicker := p.cfg.newTicker(probe.target.interval)
+	probeTicker.Resume()
+	defer probeTicker.Stop()
+
+	for {
+		outcome := p.cfg.sendProbe(&probe.target)
+		p.recordOutcome(probe, outcome)
+
+		select {
+		case <-probeTicker.Ticks():
+
+		case <-probe.quit:
+			return
+		}
+	}
+}
+
+// recordOutcome updates the statistics of the scheduled probe with the
+// outcome of its latest probe.
+func (p *prober) recordOutcome(probe *scheduledProbe, outcome *probeOutcome) {
+	p.Lock()
+	defer p.Unlock()
+
+	switch {
+	case outcome.err != nil:
+		log.Warnf("Unable to send probe %v to %v: %v", probe.id,
+			probe.target.dest, outcome.err)
+
+	case outcome.reachable:
+		probe.numReachable++
+
+		log.Debugf("Probe %v reached %v with a fee of %v",
+			probe.id, probe.target.dest, outcome.routingFee)
+
+	default:
+		probe.numUnreachable++
+
+		log.Debugf("Probe %v failed to reach %v: %v", probe.id,
+			probe.target.dest, outcome.failureReason)
+	}
+
+	probe.lastOutcome = outcome
+}
+
+// stopProbe stops the scheduled probe with the given id.
+func (p *prober) stopProbe(id uint64) error {
+	p.Lock()
+	probe, ok := p.probes[id]
+	if ok {
+		delete(p.probes, id)
+		close(probe.quit)
+	}
+	p.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %v", errUnknownProbe, id)
+	}
+
+	log.Infof("Stopped probe %v to %v", id, probe.target.dest)
+
+	return nil
+}
+
+// listProbes returns a copy of the scheduled probes ordered by their id.
+func (p *prober) listProbes() []scheduledProbe {
+	p.Lock()
+	defer p.Unlock()
+
+	probes := make([]scheduledProbe, 0, len(p.probes))
+	for _, probe := range p.probes {
+		probes = append(probes, *probe)
+	}
+
+	sort.Slice(probes, func(i, j int) bool {
+		return probes[i].id < probes[j].id
+	})
+
+	return probes
+}
+
+// stop stops all scheduled probes. It doesn't wait for in-flight probes, as
+// they only resolve once their HTLC is failed back, which may take a while.
+func (p *prober) stop() {
+	p.Lock()
+	p.stopped = true
+	for id, probe := range p.probes {
+		delete(p.probes, id)
+		close(probe.quit)
+	}
+	p.Unlock()
+}
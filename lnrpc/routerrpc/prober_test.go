@@ -0,0 +1,114 @@
+package routerrpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/ticker"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProber tests that scheduled probes are sent on every tick and that their
+// outcomes are recorded.
+func TestProber(t *testing.T) {
+	t.Parallel()
+
+	outcomes := make(chan *probeOutcome)
+	sent := make(chan *probeTarget)
+	tickers := make(chan *ticker.Force, 2)
+
+	p := newProber(&proberConfig{
+		sendProbe: func(target *probeTarget) *probeOutcome {
+			sent <- target
+			return <-outcomes
+		},
+		newTicker: func(interval time.Duration) ticker.Ticker {
+			probeTicker := ticker.NewForce(interval)
+			tickers <- probeTicker
+
+			return probeTicker
+		},
+	})
+	t.Cleanup(p.stop)
+
+	target := probeTarget{
+		amt:       1000,
+		interval:  time.Minute,
+		namespace: "probes",
+	}
+	id, err := p.startProbe(target)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, id)
+
+	probeTicker := <-tickers
+
+	// sendAndRecord waits for a probe to be sent, lets it finish with the
+	// given outcome and waits for the outcome to be recorded.
+	sendAndRecord := func(outcome *probeOutcome) {
+		t.Helper()
+
+		require.Equal(t, target, *<-sent)
+		outcomes <- outcome
+
+		require.Eventually(t, func() bool {
+			probes := p.listProbes()
+			return len(probes) == 1 &&
+				probes[0].lastOutcome == outcome
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// The first probe is sent right away.
+	sendAndRecord(&probeOutcome{
+		reachable:  true,
+		routingFee: 10,
+	})
+
+	// The following probes are sent on every tick.
+	noRoute := lnrpc.PaymentFailureReason_FAILURE_REASON_NO_ROUTE
+	probeTicker.Force <- time.Now()
+	sendAndRecord(&probeOutcome{
+		failureReason: noRoute,
+	})
+
+	// Probes that couldn't be sent don't count towards the statistics.
+	probeTicker.Force <- time.Now()
+	sendAndRecord(&probeOutcome{
+		err: errors.New("probe failed"),
+	})
+
+	probes := p.listProbes()
+	require.Len(t, probes, 1)
+	require.EqualValues(t, 1, probes[0].numReachable)
+	require.EqualValues(t, 1, probes[0].numUnreachable)
+
+	// A second probe gets the next id and probes are listed in order.
+	id, err = p.startProbe(target)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, id)
+
+	<-tickers
+	require.Equal(t, target, *<-sent)
+	outcomes <- &probeOutcome{reachable: true}
+
+	probes = p.listProbes()
+	require.Len(t, probes, 2)
+	require.EqualValues(t, 1, probes[0].id)
+	require.EqualValues(t, 2, probes[1].id)
+
+	// Stopped probes are no longer listed.
+	require.NoError(t, p.stopProbe(1))
+	require.ErrorIs(t, p.stopProbe(1), errUnknownProbe)
+
+	probes = p.listProbes()
+	require.Len(t, probes, 1)
+	require.EqualValues(t, 2, probes[0].id)
+
+	// No new probes can be scheduled once the prober is stopped.
+	p.stop()
+	require.Empty(t, p.listProbes())
+
+	_, err = p.startProbe(target)
+	require.ErrorIs(t, err, errProberShuttingDown)
+}
@@ -34,6 +34,10 @@ const (
 	// TODO(roasbeef): make this value dynamic based on expected number of
 	// attempts for given amount.
 	DefaultMaxParts = 16
+
+	// MaxIdempotencyKeySize is the maximum size of the idempotency key a
+	// payment can be sent with.
+	MaxIdempotencyKeySize = 64
 )
 
 // RouterBackend contains the backend implementation of the router rpc sub
@@ -62,6 +66,10 @@ type RouterBackend struct {
 
 	MissionControl MissionControl
 
+	// MissionControlNamespaces manages the mission control namespaces
+	// that payments can select.
+	MissionControlNamespaces MissionControlNamespaces
+
 	// ActiveNetParams are the network parameters of the primary network
 	// that the route is operating on. This is necessary so we can ensure
 	// that we receive payment requests that send to destinations on our
@@ -72,6 +80,10 @@ type RouterBackend struct {
 	// payments.
 	Tower routing.ControlTower
 
+	// DeletePayment deletes the payment with the given hash from the
+	// payment database.
+	DeletePayment func(hash lntypes.Hash) error
+
 	// MaxTotalTimelock is the maximum total time lock a route is allowed to
 	// have.
 	MaxTotalTimelock uint32
@@ -98,6 +110,14 @@ type RouterBackend struct {
 	// management after manually setting channel status.
 	SetChannelAuto func(wire.OutPoint) error
 
+	// Reputation returns a snapshot of the local reputation of our peers
+	// and the revenue of our channels.
+	Reputation func() *htlcswitch.ReputationReport
+
+	// ListCircuits returns a snapshot of the in-flight circuits of the
+	// switch.
+	ListCircuits func() ([]htlcswitch.PaymentCircuit, error)
+
 	// UseStatusInitiated is a boolean that indicates whether the router
 	// should use the new status code `Payment_INITIATED`.
 	//
@@ -139,6 +159,29 @@ type MissionControl interface {
 	SetConfig(cfg *routing.MissionControlConfig) error
 }
 
+// MissionControlNamespaces defines the mission control namespace management
+// dependencies of routerrpc.
+type MissionControlNamespaces interface {
+	// GetNamespacedStore returns the mission control of the given
+	// namespace. An empty namespace selects the default namespace.
+	GetNamespacedStore(namespace string) (*routing.MissionControl, error)
+
+	// AddNamespace adds a new namespace with an empty reliability
+	// history.
+	AddNamespace(namespace string) error
+
+	// DeleteNamespace deletes the given namespace together with its
+	// reliability history.
+	DeleteNamespace(namespace string) error
+
+	// ListNamespaces returns the names of all namespaces.
+	ListNamespaces() []string
+
+	// SetConfig applies the config to the mission control of every
+	// namespace, if it is valid.
+	SetConfig(cfg *routing.MissionControlConfig) error
+}
+
 // QueryRoutes attempts to query the daemons' Channel Router for a possible
 // route to a target destination capable of carrying a specific amount of
 // satoshis within the route's flow. The returned route contains the full
@@ -361,6 +404,11 @@ func (r *RouterBackend) parseQueryRoutesRequest(in *lnrpc.QueryRoutesRequest) (
 		return nil, err
 	}
 
+	costWeights, err := UnmarshalCostWeights(in.CostWeights)
+	if err != nil {
+		return nil, err
+	}
+
 	restrictions := &routing.RestrictParams{
 		FeeLimit: feeLimit,
 		ProbabilitySource: func(fromNode, toNode route.Vertex,
@@ -391,6 +439,7 @@ func (r *RouterBackend) parseQueryRoutesRequest(in *lnrpc.QueryRoutesRequest) (
 		CltvLimit:         cltvLimit,
 		DestFeatures:      destinationFeatures,
 		BlindedPayment:    blindedPmt,
+		CostWeights:       costWeights,
 	}
 
 	// Pass along an outgoing channel restriction if specified.
@@ -787,6 +836,13 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 	}
 	payIntent.TimePref = rpcPayReq.TimePref
 
+	// Pass along the path finding cost weights.
+	costWeights, err := UnmarshalCostWeights(rpcPayReq.CostWeights)
+	if err != nil {
+		return nil, err
+	}
+	payIntent.CostWeights = costWeights
+
 	// Pass along restrictions on the outgoing channels that may be used.
 	payIntent.OutgoingChannelIDs = rpcPayReq.OutgoingChanIds
 
@@ -831,6 +887,34 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 	}
 	payIntent.MaxParts = maxParts
 
+	// Pass along the split strategy.
+	payIntent.SplitStrategy, err = unmarshalSplitStrategy(
+		rpcPayReq.SplitStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store the idempotency key of the caller with the payment.
+	if len(rpcPayReq.IdempotencyKey) > MaxIdempotencyKeySize {
+		return nil, fmt.Errorf("idempotency key of %d bytes exceeds "+
+			"maximum of %d bytes", len(rpcPayReq.IdempotencyKey),
+			MaxIdempotencyKeySize)
+	}
+	payIntent.IdempotencyKey = rpcPayReq.IdempotencyKey
+
+	// Use the mission control of the requested namespace. The router
+	// falls back to the default namespace if none is set.
+	if rpcPayReq.MissionControlNamespace != "" {
+		mc, err := r.MissionControlNamespaces.GetNamespacedStore(
+			rpcPayReq.MissionControlNamespace,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payIntent.MissionControl = mc
+	}
+
 	// If this payment had a max shard amount specified, then we'll apply
 	// that now, which'll force us to always make payment splits smaller
 	// than this.
@@ -1181,6 +1265,48 @@ func unmarshallHopHint(rpcHint *lnrpc.HopHint) (zpay32.HopHint, error) {
 	}, nil
 }
 
+// UnmarshalCostWeights converts the given rpc cost weights into the weights
+// used by path finding. A nil value is returned if no weights are given, which
+// instructs path finding to use its defaults.
+func UnmarshalCostWeights(rpcWeights *lnrpc.CostWeights) (
+	*routing.CostWeights, error) {
+
+	if rpcWeights == nil {
+		return nil, nil
+	}
+
+	weights := &routing.CostWeights{
+		Fee:         rpcWeights.Fee,
+		TimeLock:    rpcWeights.TimeLock,
+		Probability: rpcWeights.Probability,
+	}
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+
+	return weights, nil
+}
+
+// unmarshalSplitStrategy converts the given rpc split strategy into the split
+// strategy used by the router.
+func unmarshalSplitStrategy(strategy lnrpc.PaymentSplitStrategy) (
+	routing.SplitStrategy, error) {
+
+	switch strategy {
+	case lnrpc.PaymentSplitStrategy_SPLIT_HALVING:
+		return routing.SplitStrategyHalving, nil
+
+	case lnrpc.PaymentSplitStrategy_SPLIT_EQUAL:
+		return routing.SplitStrategyEqual, nil
+
+	case lnrpc.PaymentSplitStrategy_SPLIT_CAPACITY:
+		return routing.SplitStrategyCapacity, nil
+
+	default:
+		return 0, fmt.Errorf("unknown split strategy: %v", strategy)
+	}
+}
+
 // MarshalFeatures converts a feature vector into a list of uint32's.
 func MarshalFeatures(feats *lnwire.FeatureVector) []lnrpc.FeatureBit {
 	var featureBits []lnrpc.FeatureBit
@@ -1323,6 +1449,15 @@ func (r *RouterBackend) MarshalHTLCAttempt(
 		Route:         route,
 	}
 
+	if htlc.SplitInfo != nil {
+		rpcAttempt.SplitStrategy = lnrpc.PaymentSplitStrategy(
+			htlc.SplitInfo.Strategy,
+		)
+		rpcAttempt.SplitRemainingAmtMsat = uint64(
+			htlc.SplitInfo.RemainingAmt,
+		)
+	}
+
 	switch {
 	case htlc.Settle != nil:
 		rpcAttempt.Status = lnrpc.HTLCAttempt_SUCCEEDED
@@ -1564,6 +1699,21 @@ func marshallChannelUpdate(update *lnwire.ChannelUpdate) *lnrpc.ChannelUpdate {
 	}
 }
 
+// PaymentFailed returns true if the payment with the given hash was never
+// initiated or has failed, which means that it didn't spend any funds.
+func (r *RouterBackend) PaymentFailed(hash lntypes.Hash) (bool, error) {
+	payment, err := r.Tower.FetchPayment(hash)
+	switch {
+	case errors.Is(err, channeldb.ErrPaymentNotInitiated):
+		return true, nil
+
+	case err != nil:
+		return false, err
+	}
+
+	return payment.GetStatus() == channeldb.StatusFailed, nil
+}
+
 // MarshallPayment marshall a payment to its rpc representation.
 func (r *RouterBackend) MarshallPayment(payment *channeldb.MPPayment) (
 	*lnrpc.Payment, error) {
@@ -1629,6 +1779,10 @@ func (r *RouterBackend) MarshallPayment(payment *channeldb.MPPayment) (
 		Htlcs:           htlcs,
 		PaymentIndex:    payment.SequenceNum,
 		FailureReason:   failureReason,
+
+		DestCustomRecords: payment.Info.DestCustomRecords,
+		PaymentMetadata:   payment.Info.Metadata,
+		IdempotencyKey:    payment.Info.IdempotencyKey,
 	}, nil
 }
 
@@ -35,11 +35,26 @@ type RouterClient interface {
 	// subscription, the start of the payment stream could produce out-of-order
 	// and/or duplicate events. In order to get updates for every in-flight
 	// payment attempt make sure to subscribe to this method before initiating any
-	// payments.
+	// payments. The updates can be filtered on the server side by status, amount
+	// and creation date, and the hops of the htlc attempts can be omitted.
 	TrackPayments(ctx context.Context, in *TrackPaymentsRequest, opts ...grpc.CallOption) (Router_TrackPaymentsClient, error)
 	// EstimateRouteFee allows callers to obtain a lower bound w.r.t how much it
 	// may cost to send an HTLC to the target end destination.
 	EstimateRouteFee(ctx context.Context, in *RouteFeeRequest, opts ...grpc.CallOption) (*RouteFeeResponse, error)
+	// lncli: `startprobe`
+	// StartProbe schedules a background probe that periodically sends a payment
+	// with a random payment hash to a destination. The probes keep mission
+	// control up to date and track whether the destination is reachable for the
+	// given amount. Scheduled probes are not persisted and need to be started
+	// again after a restart.
+	StartProbe(ctx context.Context, in *StartProbeRequest, opts ...grpc.CallOption) (*StartProbeResponse, error)
+	// lncli: `stopprobe`
+	// StopProbe stops a scheduled probe.
+	StopProbe(ctx context.Context, in *StopProbeRequest, opts ...grpc.CallOption) (*StopProbeResponse, error)
+	// lncli: `listproberesults`
+	// ListProbeResults returns the scheduled probes together with the outcome of
+	// their latest probe and their reachability statistics.
+	ListProbeResults(ctx context.Context, in *ListProbeResultsRequest, opts ...grpc.CallOption) (*ListProbeResultsResponse, error)
 	// Deprecated: Do not use.
 	//
 	// Deprecated, use SendToRouteV2. SendToRoute attempts to make a payment via
@@ -57,6 +72,17 @@ type RouterClient interface {
 	// ResetMissionControl clears all mission control state and starts with a clean
 	// slate.
 	ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error)
+	// lncli: `addmcnamespace`
+	// AddMissionControlNamespace adds a mission control namespace with its own
+	// reliability history that payments can select.
+	AddMissionControlNamespace(ctx context.Context, in *AddMissionControlNamespaceRequest, opts ...grpc.CallOption) (*AddMissionControlNamespaceResponse, error)
+	// lncli: `deletemcnamespace`
+	// DeleteMissionControlNamespace deletes a mission control namespace together
+	// with its reliability history. The default namespace can't be deleted.
+	DeleteMissionControlNamespace(ctx context.Context, in *DeleteMissionControlNamespaceRequest, opts ...grpc.CallOption) (*DeleteMissionControlNamespaceResponse, error)
+	// lncli: `listmcnamespaces`
+	// ListMissionControlNamespaces lists all mission control namespaces.
+	ListMissionControlNamespaces(ctx context.Context, in *ListMissionControlNamespacesRequest, opts ...grpc.CallOption) (*ListMissionControlNamespacesResponse, error)
 	// lncli: `querymc`
 	// QueryMissionControl exposes the internal mission control state to callers.
 	// It is a development feature.
@@ -64,9 +90,14 @@ type RouterClient interface {
 	// lncli: `importmc`
 	// XImportMissionControl is an experimental API that imports the state provided
 	// to the internal mission control's state, using all results which are more
-	// recent than our existing values. These values will only be imported
-	// in-memory, and will not be persisted across restarts.
+	// recent than our existing values. These values are only persisted across
+	// restarts if lnd runs on a native SQL database backend.
 	XImportMissionControl(ctx context.Context, in *XImportMissionControlRequest, opts ...grpc.CallOption) (*XImportMissionControlResponse, error)
+	// lncli: `exportmc`
+	// XExportMissionControl is an experimental API that exports the pair history
+	// of the internal mission control's state in a format that can be imported
+	// into another node using XImportMissionControl without loss of information.
+	XExportMissionControl(ctx context.Context, in *XExportMissionControlRequest, opts ...grpc.CallOption) (*XExportMissionControlResponse, error)
 	// lncli: `getmccfg`
 	// GetMissionControlConfig returns mission control's current config.
 	GetMissionControlConfig(ctx context.Context, in *GetMissionControlConfigRequest, opts ...grpc.CallOption) (*GetMissionControlConfigResponse, error)
@@ -83,7 +114,8 @@ type RouterClient interface {
 	// lncli: `buildroute`
 	// BuildRoute builds a fully specified route based on a list of hop public
 	// keys. It retrieves the relevant channel policies from the graph in order to
-	// calculate the correct fees and time locks.
+	// calculate the correct fees and time locks. Hops can carry custom records
+	// and parts of the route can be left to pathfinding with placeholder hops.
 	// Note that LND will use its default final_cltv_delta if no value is supplied.
 	// Make sure to add the correct final_cltv_delta depending on the invoice
 	// restriction. Moreover the caller has to make sure to provide the
@@ -109,6 +141,8 @@ type RouterClient interface {
 	// a boolean that tells LND if this htlc should be intercepted.
 	// In case of interception, the htlc can be either settled, cancelled or
 	// resumed later by using the ResolveHoldForward endpoint.
+	// The client can send a filter to only receive the htlcs it is interested in
+	// and resolve many htlcs at once using a batch of resolutions.
 	HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Router_HtlcInterceptorClient, error)
 	// lncli: `updatechanstatus`
 	// UpdateChanStatus attempts to manually set the state of a channel
@@ -116,6 +150,23 @@ type RouterClient interface {
 	// channel to stay disabled until a subsequent manual request of either
 	// "enable" or "auto".
 	UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error)
+	// lncli: `getreputation`
+	// GetReputation returns the local reputation that this node tracks for its
+	// peers, along with the revenue and general slot usage of its channels. The
+	// reputation is used to decide whether forwarded HTLCs are endorsed to the
+	// next hop.
+	GetReputation(ctx context.Context, in *GetReputationRequest, opts ...grpc.CallOption) (*GetReputationResponse, error)
+	// SubscribeForwards creates a uni-directional stream from the server to the
+	// client which delivers an update for every forward attempt of this node:
+	// when an HTLC is forwarded, and when it is settled or failed. Failures
+	// include the reason if the HTLC failed locally, and whether it failed
+	// locally or downstream.
+	SubscribeForwards(ctx context.Context, in *SubscribeForwardsRequest, opts ...grpc.CallOption) (Router_SubscribeForwardsClient, error)
+	// lncli: `listcircuits`
+	// ListCircuits returns the in-flight HTLC circuits of the switch, which link
+	// incoming HTLCs to the outgoing HTLCs they were forwarded as. It helps to
+	// diagnose stuck HTLCs before they cause channels to be force closed.
+	ListCircuits(ctx context.Context, in *ListCircuitsRequest, opts ...grpc.CallOption) (*ListCircuitsResponse, error)
 }
 
 type routerClient struct {
@@ -231,6 +282,33 @@ func (c *routerClient) EstimateRouteFee(ctx context.Context, in *RouteFeeRequest
 	return out, nil
 }
 
+func (c *routerClient) StartProbe(ctx context.Context, in *StartProbeRequest, opts ...grpc.CallOption) (*StartProbeResponse, error) {
+	out := new(StartProbeResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/StartProbe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) StopProbe(ctx context.Context, in *StopProbeRequest, opts ...grpc.CallOption) (*StopProbeResponse, error) {
+	out := new(StopProbeResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/StopProbe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) ListProbeResults(ctx context.Context, in *ListProbeResultsRequest, opts ...grpc.CallOption) (*ListProbeResultsResponse, error) {
+	out := new(ListProbeResultsResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/ListProbeResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Deprecated: Do not use.
 func (c *routerClient) SendToRoute(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendToRouteResponse, error) {
 	out := new(SendToRouteResponse)
@@ -259,6 +337,33 @@ func (c *routerClient) ResetMissionControl(ctx context.Context, in *ResetMission
 	return out, nil
 }
 
+func (c *routerClient) AddMissionControlNamespace(ctx context.Context, in *AddMissionControlNamespaceRequest, opts ...grpc.CallOption) (*AddMissionControlNamespaceResponse, error) {
+	out := new(AddMissionControlNamespaceResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/AddMissionControlNamespace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) DeleteMissionControlNamespace(ctx context.Context, in *DeleteMissionControlNamespaceRequest, opts ...grpc.CallOption) (*DeleteMissionControlNamespaceResponse, error) {
+	out := new(DeleteMissionControlNamespaceResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/DeleteMissionControlNamespace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) ListMissionControlNamespaces(ctx context.Context, in *ListMissionControlNamespacesRequest, opts ...grpc.CallOption) (*ListMissionControlNamespacesResponse, error) {
+	out := new(ListMissionControlNamespacesResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/ListMissionControlNamespaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routerClient) QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error) {
 	out := new(QueryMissionControlResponse)
 	err := c.cc.Invoke(ctx, "/routerrpc.Router/QueryMissionControl", in, out, opts...)
@@ -277,6 +382,15 @@ func (c *routerClient) XImportMissionControl(ctx context.Context, in *XImportMis
 	return out, nil
 }
 
+func (c *routerClient) XExportMissionControl(ctx context.Context, in *XExportMissionControlRequest, opts ...grpc.CallOption) (*XExportMissionControlResponse, error) {
+	out := new(XExportMissionControlResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/XExportMissionControl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routerClient) GetMissionControlConfig(ctx context.Context, in *GetMissionControlConfigRequest, opts ...grpc.CallOption) (*GetMissionControlConfigResponse, error) {
 	out := new(GetMissionControlConfigResponse)
 	err := c.cc.Invoke(ctx, "/routerrpc.Router/GetMissionControlConfig", in, out, opts...)
@@ -451,6 +565,56 @@ func (c *routerClient) UpdateChanStatus(ctx context.Context, in *UpdateChanStatu
 	return out, nil
 }
 
+func (c *routerClient) GetReputation(ctx context.Context, in *GetReputationRequest, opts ...grpc.CallOption) (*GetReputationResponse, error) {
+	out := new(GetReputationResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/GetReputation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) SubscribeForwards(ctx context.Context, in *SubscribeForwardsRequest, opts ...grpc.CallOption) (Router_SubscribeForwardsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[7], "/routerrpc.Router/SubscribeForwards", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routerSubscribeForwardsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Router_SubscribeForwardsClient interface {
+	Recv() (*ForwardUpdate, error)
+	grpc.ClientStream
+}
+
+type routerSubscribeForwardsClient struct {
+	grpc.ClientStream
+}
+
+func (x *routerSubscribeForwardsClient) Recv() (*ForwardUpdate, error) {
+	m := new(ForwardUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routerClient) ListCircuits(ctx context.Context, in *ListCircuitsRequest, opts ...grpc.CallOption) (*ListCircuitsResponse, error) {
+	out := new(ListCircuitsResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/ListCircuits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RouterServer is the server API for Router service.
 // All implementations must embed UnimplementedRouterServer
 // for forward compatibility
@@ -471,11 +635,26 @@ type RouterServer interface {
 	// subscription, the start of the payment stream could produce out-of-order
 	// and/or duplicate events. In order to get updates for every in-flight
 	// payment attempt make sure to subscribe to this method before initiating any
-	// payments.
+	// payments. The updates can be filtered on the server side by status, amount
+	// and creation date, and the hops of the htlc attempts can be omitted.
 	TrackPayments(*TrackPaymentsRequest, Router_TrackPaymentsServer) error
 	// EstimateRouteFee allows callers to obtain a lower bound w.r.t how much it
 	// may cost to send an HTLC to the target end destination.
 	EstimateRouteFee(context.Context, *RouteFeeRequest) (*RouteFeeResponse, error)
+	// lncli: `startprobe`
+	// StartProbe schedules a background probe that periodically sends a payment
+	// with a random payment hash to a destination. The probes keep mission
+	// control up to date and track whether the destination is reachable for the
+	// given amount. Scheduled probes are not persisted and need to be started
+	// again after a restart.
+	StartProbe(context.Context, *StartProbeRequest) (*StartProbeResponse, error)
+	// lncli: `stopprobe`
+	// StopProbe stops a scheduled probe.
+	StopProbe(context.Context, *StopProbeRequest) (*StopProbeResponse, error)
+	// lncli: `listproberesults`
+	// ListProbeResults returns the scheduled probes together with the outcome of
+	// their latest probe and their reachability statistics.
+	ListProbeResults(context.Context, *ListProbeResultsRequest) (*ListProbeResultsResponse, error)
 	// Deprecated: Do not use.
 	//
 	// Deprecated, use SendToRouteV2. SendToRoute attempts to make a payment via
@@ -493,6 +672,17 @@ type RouterServer interface {
 	// ResetMissionControl clears all mission control state and starts with a clean
 	// slate.
 	ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error)
+	// lncli: `addmcnamespace`
+	// AddMissionControlNamespace adds a mission control namespace with its own
+	// reliability history that payments can select.
+	AddMissionControlNamespace(context.Context, *AddMissionControlNamespaceRequest) (*AddMissionControlNamespaceResponse, error)
+	// lncli: `deletemcnamespace`
+	// DeleteMissionControlNamespace deletes a mission control namespace together
+	// with its reliability history. The default namespace can't be deleted.
+	DeleteMissionControlNamespace(context.Context, *DeleteMissionControlNamespaceRequest) (*DeleteMissionControlNamespaceResponse, error)
+	// lncli: `listmcnamespaces`
+	// ListMissionControlNamespaces lists all mission control namespaces.
+	ListMissionControlNamespaces(context.Context, *ListMissionControlNamespacesRequest) (*ListMissionControlNamespacesResponse, error)
 	// lncli: `querymc`
 	// QueryMissionControl exposes the internal mission control state to callers.
 	// It is a development feature.
@@ -500,9 +690,14 @@ type RouterServer interface {
 	// lncli: `importmc`
 	// XImportMissionControl is an experimental API that imports the state provided
 	// to the internal mission control's state, using all results which are more
-	// recent than our existing values. These values will only be imported
-	// in-memory, and will not be persisted across restarts.
+	// recent than our existing values. These values are only persisted across
+	// restarts if lnd runs on a native SQL database backend.
 	XImportMissionControl(context.Context, *XImportMissionControlRequest) (*XImportMissionControlResponse, error)
+	// lncli: `exportmc`
+	// XExportMissionControl is an experimental API that exports the pair history
+	// of the internal mission control's state in a format that can be imported
+	// into another node using XImportMissionControl without loss of information.
+	XExportMissionControl(context.Context, *XExportMissionControlRequest) (*XExportMissionControlResponse, error)
 	// lncli: `getmccfg`
 	// GetMissionControlConfig returns mission control's current config.
 	GetMissionControlConfig(context.Context, *GetMissionControlConfigRequest) (*GetMissionControlConfigResponse, error)
@@ -519,7 +714,8 @@ type RouterServer interface {
 	// lncli: `buildroute`
 	// BuildRoute builds a fully specified route based on a list of hop public
 	// keys. It retrieves the relevant channel policies from the graph in order to
-	// calculate the correct fees and time locks.
+	// calculate the correct fees and time locks. Hops can carry custom records
+	// and parts of the route can be left to pathfinding with placeholder hops.
 	// Note that LND will use its default final_cltv_delta if no value is supplied.
 	// Make sure to add the correct final_cltv_delta depending on the invoice
 	// restriction. Moreover the caller has to make sure to provide the
@@ -545,6 +741,8 @@ type RouterServer interface {
 	// a boolean that tells LND if this htlc should be intercepted.
 	// In case of interception, the htlc can be either settled, cancelled or
 	// resumed later by using the ResolveHoldForward endpoint.
+	// The client can send a filter to only receive the htlcs it is interested in
+	// and resolve many htlcs at once using a batch of resolutions.
 	HtlcInterceptor(Router_HtlcInterceptorServer) error
 	// lncli: `updatechanstatus`
 	// UpdateChanStatus attempts to manually set the state of a channel
@@ -552,6 +750,23 @@ type RouterServer interface {
 	// channel to stay disabled until a subsequent manual request of either
 	// "enable" or "auto".
 	UpdateChanStatus(context.Context, *UpdateChanStatusRequest) (*UpdateChanStatusResponse, error)
+	// lncli: `getreputation`
+	// GetReputation returns the local reputation that this node tracks for its
+	// peers, along with the revenue and general slot usage of its channels. The
+	// reputation is used to decide whether forwarded HTLCs are endorsed to the
+	// next hop.
+	GetReputation(context.Context, *GetReputationRequest) (*GetReputationResponse, error)
+	// SubscribeForwards creates a uni-directional stream from the server to the
+	// client which delivers an update for every forward attempt of this node:
+	// when an HTLC is forwarded, and when it is settled or failed. Failures
+	// include the reason if the HTLC failed locally, and whether it failed
+	// locally or downstream.
+	SubscribeForwards(*SubscribeForwardsRequest, Router_SubscribeForwardsServer) error
+	// lncli: `listcircuits`
+	// ListCircuits returns the in-flight HTLC circuits of the switch, which link
+	// incoming HTLCs to the outgoing HTLCs they were forwarded as. It helps to
+	// diagnose stuck HTLCs before they cause channels to be force closed.
+	ListCircuits(context.Context, *ListCircuitsRequest) (*ListCircuitsResponse, error)
 	mustEmbedUnimplementedRouterServer()
 }
 
@@ -571,6 +786,15 @@ func (UnimplementedRouterServer) TrackPayments(*TrackPaymentsRequest, Router_Tra
 func (UnimplementedRouterServer) EstimateRouteFee(context.Context, *RouteFeeRequest) (*RouteFeeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EstimateRouteFee not implemented")
 }
+func (UnimplementedRouterServer) StartProbe(context.Context, *StartProbeRequest) (*StartProbeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartProbe not implemented")
+}
+func (UnimplementedRouterServer) StopProbe(context.Context, *StopProbeRequest) (*StopProbeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopProbe not implemented")
+}
+func (UnimplementedRouterServer) ListProbeResults(context.Context, *ListProbeResultsRequest) (*ListProbeResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProbeResults not implemented")
+}
 func (UnimplementedRouterServer) SendToRoute(context.Context, *SendToRouteRequest) (*SendToRouteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendToRoute not implemented")
 }
@@ -580,12 +804,24 @@ func (UnimplementedRouterServer) SendToRouteV2(context.Context, *SendToRouteRequ
 func (UnimplementedRouterServer) ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetMissionControl not implemented")
 }
+func (UnimplementedRouterServer) AddMissionControlNamespace(context.Context, *AddMissionControlNamespaceRequest) (*AddMissionControlNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMissionControlNamespace not implemented")
+}
+func (UnimplementedRouterServer) DeleteMissionControlNamespace(context.Context, *DeleteMissionControlNamespaceRequest) (*DeleteMissionControlNamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMissionControlNamespace not implemented")
+}
+func (UnimplementedRouterServer) ListMissionControlNamespaces(context.Context, *ListMissionControlNamespacesRequest) (*ListMissionControlNamespacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMissionControlNamespaces not implemented")
+}
 func (UnimplementedRouterServer) QueryMissionControl(context.Context, *QueryMissionControlRequest) (*QueryMissionControlResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryMissionControl not implemented")
 }
 func (UnimplementedRouterServer) XImportMissionControl(context.Context, *XImportMissionControlRequest) (*XImportMissionControlResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method XImportMissionControl not implemented")
 }
+func (UnimplementedRouterServer) XExportMissionControl(context.Context, *XExportMissionControlRequest) (*XExportMissionControlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method XExportMissionControl not implemented")
+}
 func (UnimplementedRouterServer) GetMissionControlConfig(context.Context, *GetMissionControlConfigRequest) (*GetMissionControlConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMissionControlConfig not implemented")
 }
@@ -613,6 +849,15 @@ func (UnimplementedRouterServer) HtlcInterceptor(Router_HtlcInterceptorServer) e
 func (UnimplementedRouterServer) UpdateChanStatus(context.Context, *UpdateChanStatusRequest) (*UpdateChanStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateChanStatus not implemented")
 }
+func (UnimplementedRouterServer) GetReputation(context.Context, *GetReputationRequest) (*GetReputationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReputation not implemented")
+}
+func (UnimplementedRouterServer) SubscribeForwards(*SubscribeForwardsRequest, Router_SubscribeForwardsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeForwards not implemented")
+}
+func (UnimplementedRouterServer) ListCircuits(context.Context, *ListCircuitsRequest) (*ListCircuitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCircuits not implemented")
+}
 func (UnimplementedRouterServer) mustEmbedUnimplementedRouterServer() {}
 
 // UnsafeRouterServer may be embedded to opt out of forward compatibility for this service.
@@ -707,6 +952,60 @@ func _Router_EstimateRouteFee_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_StartProbe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).StartProbe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/StartProbe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).StartProbe(ctx, req.(*StartProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_StopProbe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).StopProbe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/StopProbe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).StopProbe(ctx, req.(*StopProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_ListProbeResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProbeResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).ListProbeResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/ListProbeResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).ListProbeResults(ctx, req.(*ListProbeResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_SendToRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendToRouteRequest)
 	if err := dec(in); err != nil {
@@ -761,6 +1060,60 @@ func _Router_ResetMissionControl_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_AddMissionControlNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMissionControlNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).AddMissionControlNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/AddMissionControlNamespace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).AddMissionControlNamespace(ctx, req.(*AddMissionControlNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_DeleteMissionControlNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMissionControlNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).DeleteMissionControlNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/DeleteMissionControlNamespace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).DeleteMissionControlNamespace(ctx, req.(*DeleteMissionControlNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_ListMissionControlNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMissionControlNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).ListMissionControlNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/ListMissionControlNamespaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).ListMissionControlNamespaces(ctx, req.(*ListMissionControlNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_QueryMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryMissionControlRequest)
 	if err := dec(in); err != nil {
@@ -797,6 +1150,24 @@ func _Router_XImportMissionControl_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_XExportMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XExportMissionControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).XExportMissionControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/XExportMissionControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).XExportMissionControl(ctx, req.(*XExportMissionControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_GetMissionControlConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMissionControlConfigRequest)
 	if err := dec(in); err != nil {
@@ -976,6 +1347,63 @@ func _Router_UpdateChanStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_GetReputation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReputationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).GetReputation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/GetReputation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).GetReputation(ctx, req.(*GetReputationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_SubscribeForwards_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeForwardsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RouterServer).SubscribeForwards(m, &routerSubscribeForwardsServer{stream})
+}
+
+type Router_SubscribeForwardsServer interface {
+	Send(*ForwardUpdate) error
+	grpc.ServerStream
+}
+
+type routerSubscribeForwardsServer struct {
+	grpc.ServerStream
+}
+
+func (x *routerSubscribeForwardsServer) Send(m *ForwardUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Router_ListCircuits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCircuitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).ListCircuits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/ListCircuits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).ListCircuits(ctx, req.(*ListCircuitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Router_ServiceDesc is the grpc.ServiceDesc for Router service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -987,6 +1415,18 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EstimateRouteFee",
 			Handler:    _Router_EstimateRouteFee_Handler,
 		},
+		{
+			MethodName: "StartProbe",
+			Handler:    _Router_StartProbe_Handler,
+		},
+		{
+			MethodName: "StopProbe",
+			Handler:    _Router_StopProbe_Handler,
+		},
+		{
+			MethodName: "ListProbeResults",
+			Handler:    _Router_ListProbeResults_Handler,
+		},
 		{
 			MethodName: "SendToRoute",
 			Handler:    _Router_SendToRoute_Handler,
@@ -999,6 +1439,18 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResetMissionControl",
 			Handler:    _Router_ResetMissionControl_Handler,
 		},
+		{
+			MethodName: "AddMissionControlNamespace",
+			Handler:    _Router_AddMissionControlNamespace_Handler,
+		},
+		{
+			MethodName: "DeleteMissionControlNamespace",
+			Handler:    _Router_DeleteMissionControlNamespace_Handler,
+		},
+		{
+			MethodName: "ListMissionControlNamespaces",
+			Handler:    _Router_ListMissionControlNamespaces_Handler,
+		},
 		{
 			MethodName: "QueryMissionControl",
 			Handler:    _Router_QueryMissionControl_Handler,
@@ -1007,6 +1459,10 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "XImportMissionControl",
 			Handler:    _Router_XImportMissionControl_Handler,
 		},
+		{
+			MethodName: "XExportMissionControl",
+			Handler:    _Router_XExportMissionControl_Handler,
+		},
 		{
 			MethodName: "GetMissionControlConfig",
 			Handler:    _Router_GetMissionControlConfig_Handler,
@@ -1027,6 +1483,14 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateChanStatus",
 			Handler:    _Router_UpdateChanStatus_Handler,
 		},
+		{
+			MethodName: "GetReputation",
+			Handler:    _Router_GetReputation_Handler,
+		},
+		{
+			MethodName: "ListCircuits",
+			Handler:    _Router_ListCircuits_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1065,6 +1529,11 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "SubscribeForwards",
+			Handler:       _Router_SubscribeForwards_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "routerrpc/router.proto",
 }
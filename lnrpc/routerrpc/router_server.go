@@ -8,20 +8,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync/atomic"
 	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/lightningnetwork/lnd/build"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/ticker"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -93,6 +97,18 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/routerrpc.Router/StartProbe": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/routerrpc.Router/StopProbe": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/routerrpc.Router/ListProbeResults": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/routerrpc.Router/QueryMissionControl": {{
 			Entity: "offchain",
 			Action: "read",
@@ -101,6 +117,10 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/XExportMissionControl": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/routerrpc.Router/GetMissionControlConfig": {{
 			Entity: "offchain",
 			Action: "read",
@@ -117,6 +137,18 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/AddMissionControlNamespace": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/routerrpc.Router/DeleteMissionControlNamespace": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/routerrpc.Router/ListMissionControlNamespaces": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/routerrpc.Router/BuildRoute": {{
 			Entity: "offchain",
 			Action: "read",
@@ -141,6 +173,25 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/GetReputation": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/routerrpc.Router/SubscribeForwards": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/routerrpc.Router/ListCircuits": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+	}
+
+	// spendLimitedMethods are the RPC calls that enforce the spend limits
+	// of the macaroon they are called with.
+	spendLimitedMethods = []string{
+		"/routerrpc.Router/SendPaymentV2",
+		"/routerrpc.Router/SendToRouteV2",
 	}
 
 	// DefaultRouterMacFilename is the default name of the router macaroon
@@ -170,6 +221,9 @@ type Server struct {
 
 	cfg *Config
 
+	// prober sends the scheduled probes.
+	prober *prober
+
 	quit chan struct{}
 }
 
@@ -222,10 +276,24 @@ func New(cfg *Config) (*Server, lnrpc.MacaroonPerms, error) {
 		}
 	}
 
+	// The payment RPCs enforce the spend limits of the macaroons used to
+	// call them, so macaroons with such limits can be used for them.
+	if cfg.MacService != nil {
+		for _, method := range spendLimitedMethods {
+			cfg.MacService.RegisterSpendLimitedMethod(method)
+		}
+	}
+
 	routerServer := &Server{
 		cfg:  cfg,
 		quit: make(chan struct{}),
 	}
+	routerServer.prober = newProber(&proberConfig{
+		sendProbe: routerServer.sendScheduledProbe,
+		newTicker: func(interval time.Duration) ticker.Ticker {
+			return ticker.New(interval)
+		},
+	})
 
 	return routerServer, macPermissions, nil
 }
@@ -249,6 +317,8 @@ func (s *Server) Stop() error {
 		return nil
 	}
 
+	s.prober.stop()
+
 	close(s.quit)
 	return nil
 }
@@ -261,6 +331,14 @@ func (s *Server) Name() string {
 	return subServerName
 }
 
+// DefaultMacaroon returns the path of the router macaroon file and the
+// operations it is baked with.
+//
+// NOTE: This is part of the lnrpc.DefaultMacaroonBaker interface.
+func (s *Server) DefaultMacaroon() (string, []bakery.Op) {
+	return s.cfg.RouterMacPath, macaroonOps
+}
+
 // RegisterWithRootServer will be called by the root gRPC server to direct a
 // sub RPC server to register itself with the main gRPC root server. Until this
 // is called, each sub-server won't be able to have requests routed towards it.
@@ -326,24 +404,70 @@ func (r *ServerShell) CreateSubServer(configRegistry lnrpc.SubServerConfigDispat
 func (s *Server) SendPaymentV2(req *SendPaymentRequest,
 	stream Router_SendPaymentV2Server) error {
 
+	// If a payment was already created with the idempotency key of the
+	// request, we don't send another one but return the update stream of
+	// the existing payment. This allows callers to safely retry after a
+	// crash.
+	if len(req.IdempotencyKey) != 0 {
+		tower := s.cfg.RouterBackend.Tower
+		payHash, err := tower.FetchPaymentHashByIdempotencyKey(
+			req.IdempotencyKey,
+		)
+		switch {
+		case err == nil:
+			log.Debugf("SendPaymentV2 called with idempotency key "+
+				"%x of existing payment %v", req.IdempotencyKey,
+				payHash)
+
+			sub, err := s.subscribePayment(payHash)
+			if err != nil {
+				return err
+			}
+
+			return s.trackPayment(
+				sub, payHash, stream, req.NoInflightUpdates,
+			)
+
+		case !errors.Is(err, channeldb.ErrPaymentNotInitiated):
+			return err
+		}
+	}
+
 	payment, err := s.cfg.RouterBackend.extractIntentFromSendRequest(req)
 	if err != nil {
 		return err
 	}
 
+	// Make sure the payment, including the maximum fee it can pay, is
+	// within the spend limits of the macaroon used for the call.
+	refund, err := s.authorizeSpend(
+		stream.Context(), payment.Amount+payment.FeeLimit,
+	)
+	if err != nil {
+		return err
+	}
+
 	// Get the payment hash.
 	payHash := payment.Identifier()
 
+	// Tie the log entries of the payment together with the ones of its
+	// lifecycle in the router and the switch.
+	payLog := build.NewTraceLog(build.PaymentTraceID(payHash), log)
+	payLog.Debugf("SendPaymentV2 called for payment %v", payHash)
+
 	// Init the payment in db.
 	paySession, shardTracker, err := s.cfg.Router.PreparePayment(payment)
 	if err != nil {
-		log.Errorf("SendPayment async error for payment %x: %v",
+		payLog.Errorf("SendPayment async error for payment %x: %v",
 			payment.Identifier(), err)
 
+		s.refundIfFailed(payHash, refund)
+
 		// Transform user errors to grpc code.
 		if errors.Is(err, channeldb.ErrPaymentExists) ||
 			errors.Is(err, channeldb.ErrPaymentInFlight) ||
-			errors.Is(err, channeldb.ErrAlreadyPaid) {
+			errors.Is(err, channeldb.ErrAlreadyPaid) ||
+			errors.Is(err, channeldb.ErrIdempotencyKeyExists) {
 
 			return status.Error(
 				codes.AlreadyExists, err.Error(),
@@ -360,6 +484,15 @@ func (s *Server) SendPaymentV2(req *SendPaymentRequest,
 		return err
 	}
 
+	// Refund the spend limits once the payment failed, which needs its
+	// own subscription as the client may stop tracking the payment.
+	refundSub, err := s.subscribePayment(payHash)
+	if err != nil {
+		sub.Close()
+		return err
+	}
+	go s.refundOnFailure(refundSub, payHash, refund)
+
 	// Send the payment asynchronously.
 	s.cfg.Router.SendPaymentAsync(payment, paySession, shardTracker)
 
@@ -369,6 +502,72 @@ func (s *Server) SendPaymentV2(req *SendPaymentRequest,
 	)
 }
 
+// authorizeSpend checks a payment of the given amount against the spend limits
+// of the macaroon used for the call, if any. The returned function refunds the
+// amount to the spend limits and must be called if the payment fails.
+func (s *Server) authorizeSpend(ctx context.Context,
+	amt lnwire.MilliSatoshi) (func() error, error) {
+
+	if s.cfg.MacService == nil {
+		return func() error { return nil }, nil
+	}
+
+	return s.cfg.MacService.AuthorizeSpend(ctx, uint64(amt))
+}
+
+// refundIfFailed refunds the spend limits charged for the payment with the
+// given hash if the payment failed or was never initiated.
+func (s *Server) refundIfFailed(payHash lntypes.Hash, refund func() error) {
+	failed, err := s.cfg.RouterBackend.PaymentFailed(payHash)
+	if err != nil {
+		log.Errorf("Unable to fetch status of payment %v: %v",
+			payHash, err)
+
+		return
+	}
+
+	if !failed {
+		return
+	}
+
+	if err := refund(); err != nil {
+		log.Errorf("Unable to refund spend limit of payment %v: %v",
+			payHash, err)
+	}
+}
+
+// refundOnFailure refunds the spend limits charged for the payment with the
+// given hash once the payment failed. The payment is tracked independently of
+// the RPC stream, so that a client that disconnects still gets the refund.
+func (s *Server) refundOnFailure(sub routing.ControlTowerSubscriber,
+	payHash lntypes.Hash, refund func() error) {
+
+	defer sub.Close()
+
+	for {
+		select {
+		case item, ok := <-sub.Updates():
+			if !ok {
+				// No more payment updates, so the payment
+				// reached its final state.
+				s.refundIfFailed(payHash, refund)
+
+				return
+			}
+
+			payment := item.(*channeldb.MPPayment)
+			if payment.Status == channeldb.StatusFailed {
+				s.refundIfFailed(payHash, refund)
+
+				return
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
 // EstimateRouteFee allows callers to obtain an expected value w.r.t how much it
 // may cost to send an HTLC to the target end destination. This method sends
 // probe payments to the target node, based on target invoice parameters and a
@@ -815,6 +1014,195 @@ func timelockAndFee(p *lnrpc.Payment) (int64, int64, error) {
 	return lastRoute.TotalFeesMsat, int64(lastRoute.TotalTimeLock), nil
 }
 
+// StartProbe schedules a background probe that periodically sends a payment
+// with a random payment hash to a destination.
+func (s *Server) StartProbe(ctx context.Context,
+	req *StartProbeRequest) (*StartProbeResponse, error) {
+
+	dest, err := route.NewVertexFromBytes(req.Dest)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AmtMsat == 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+
+	if req.FeeLimitSat < 0 {
+		return nil, errors.New("fee limit must not be negative")
+	}
+
+	// Make sure that the namespace exists, so that the probes don't fail
+	// later on.
+	if _, err := s.missionControl(req.MissionControlNamespace); err != nil {
+		return nil, err
+	}
+
+	target := probeTarget{
+		dest:      dest,
+		amt:       lnwire.MilliSatoshi(req.AmtMsat),
+		interval:  time.Duration(req.IntervalSeconds) * time.Second,
+		timeout:   time.Duration(req.TimeoutSeconds) * time.Second,
+		feeLimit:  btcutil.Amount(req.FeeLimitSat),
+		namespace: req.MissionControlNamespace,
+	}
+	if target.interval == 0 {
+		target.interval = DefaultProbeInterval
+	}
+	if target.timeout == 0 {
+		target.timeout = DefaultProbeTimeout
+	}
+	if target.feeLimit == 0 {
+		target.feeLimit = routeFeeLimitSat
+	}
+
+	id, err := s.prober.startProbe(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StartProbeResponse{
+		ProbeId: id,
+	}, nil
+}
+
+// StopProbe stops a scheduled probe.
+func (s *Server) StopProbe(ctx context.Context,
+	req *StopProbeRequest) (*StopProbeResponse, error) {
+
+	if err := s.prober.stopProbe(req.ProbeId); err != nil {
+		return nil, err
+	}
+
+	return &StopProbeResponse{}, nil
+}
+
+// ListProbeResults returns the scheduled probes together with the outcome of
+// their latest probe and their reachability statistics.
+func (s *Server) ListProbeResults(ctx context.Context,
+	req *ListProbeResultsRequest) (*ListProbeResultsResponse, error) {
+
+	probes := s.prober.listProbes()
+
+	resp := &ListProbeResultsResponse{
+		Probes: make([]*ScheduledProbe, 0, len(probes)),
+	}
+	for _, probe := range probes {
+		rpcProbe := &ScheduledProbe{
+			ProbeId: probe.id,
+			Dest:    probe.target.dest[:],
+			AmtMsat: uint64(probe.target.amt),
+			IntervalSeconds: uint32(
+				probe.target.interval / time.Second,
+			),
+			MissionControlNamespace: probe.target.namespace,
+			NumReachable:            probe.numReachable,
+			NumUnreachable:          probe.numUnreachable,
+		}
+
+		if probe.lastOutcome != nil {
+			rpcProbe.LastResult = marshallProbeOutcome(
+				probe.lastOutcome,
+			)
+		}
+
+		resp.Probes = append(resp.Probes, rpcProbe)
+	}
+
+	return resp, nil
+}
+
+// marshallProbeOutcome converts the outcome of a probe into its rpc
+// representation.
+func marshallProbeOutcome(outcome *probeOutcome) *ProbeResult {
+	result := &ProbeResult{
+		Timestamp:      outcome.timestamp.Unix(),
+		Reachable:      outcome.reachable,
+		FailureReason:  outcome.failureReason,
+		RoutingFeeMsat: int64(outcome.routingFee),
+		TimeLockDelay:  outcome.timeLockDelay,
+		DurationMs:     outcome.duration.Milliseconds(),
+	}
+
+	if outcome.err != nil {
+		result.Error = outcome.err.Error()
+	}
+
+	return result
+}
+
+// sendScheduledProbe sends a single probe with a random payment hash to the
+// destination of a scheduled probe. The probe payment is deleted once it
+// failed, so that scheduled probes don't fill up the payment database.
+func (s *Server) sendScheduledProbe(target *probeTarget) *probeOutcome {
+	start := time.Now()
+
+	outcome := &probeOutcome{}
+	defer func() {
+		outcome.timestamp = time.Now()
+		outcome.duration = outcome.timestamp.Sub(start)
+	}()
+
+	// Generate a random payment hash, so we can be sure that the
+	// destination doesn't have the preimage to settle the htlc. A random
+	// payment address makes sure that destinations that require one can be
+	// probed as well.
+	var paymentHash lntypes.Hash
+	if _, err := crand.Read(paymentHash[:]); err != nil {
+		outcome.err = fmt.Errorf("cannot generate random probe "+
+			"preimage: %w", err)
+
+		return outcome
+	}
+
+	var paymentAddr [32]byte
+	if _, err := crand.Read(paymentAddr[:]); err != nil {
+		outcome.err = fmt.Errorf("cannot generate random probe "+
+			"payment address: %w", err)
+
+		return outcome
+	}
+
+	probeRequest := &SendPaymentRequest{
+		Dest:                    target.dest[:],
+		AmtMsat:                 int64(target.amt),
+		PaymentHash:             paymentHash[:],
+		PaymentAddr:             paymentAddr[:],
+		FeeLimitSat:             int64(target.feeLimit),
+		TimeoutSeconds:          int32(target.timeout / time.Second),
+		MaxParts:                1,
+		MissionControlNamespace: target.namespace,
+	}
+
+	// Cancel the context once the probe finished to stop tracking the
+	// probe payment.
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := s.sendProbePayment(ctx, probeRequest)
+	cancel()
+	if err != nil {
+		outcome.err = err
+
+		return outcome
+	}
+
+	failureReason := resp.FailureReason
+	outcome.reachable = failureReason ==
+		lnrpc.PaymentFailureReason_FAILURE_REASON_NONE
+	outcome.failureReason = failureReason
+	outcome.routingFee = lnwire.MilliSatoshi(resp.RoutingFeeMsat)
+	outcome.timeLockDelay = resp.TimeLockDelay
+
+	if s.cfg.RouterBackend.DeletePayment != nil {
+		err := s.cfg.RouterBackend.DeletePayment(paymentHash)
+		if err != nil {
+			log.Errorf("Unable to delete probe payment %v: %v",
+				paymentHash, err)
+		}
+	}
+
+	return outcome
+}
+
 // SendToRouteV2 sends a payment through a predefined route. The response of
 // this call contains structured error information.
 func (s *Server) SendToRouteV2(ctx context.Context,
@@ -834,6 +1222,13 @@ func (s *Server) SendToRouteV2(ctx context.Context,
 		return nil, err
 	}
 
+	// The total amount of the route includes all fees, so that's what we
+	// check against the spend limits of the macaroon used for the call.
+	refund, err := s.authorizeSpend(ctx, route.TotalAmount)
+	if err != nil {
+		return nil, err
+	}
+
 	var attempt *channeldb.HTLCAttempt
 
 	// Pass route to the router. This call returns the full htlc attempt
@@ -847,6 +1242,17 @@ func (s *Server) SendToRouteV2(ctx context.Context,
 	} else {
 		attempt, err = s.cfg.Router.SendToRoute(hash, route)
 	}
+
+	// A failed attempt didn't spend anything, and neither did a call
+	// that returned before the attempt was made, so the amount no
+	// longer counts towards the spend limits.
+	if attempt == nil || attempt.Failure != nil {
+		if err := refund(); err != nil {
+			log.Errorf("Unable to refund spend limit of payment "+
+				"%v: %v", hash, err)
+		}
+	}
+
 	if attempt != nil {
 		rpcAttempt, err := s.cfg.RouterBackend.MarshalHTLCAttempt(
 			*attempt,
@@ -879,14 +1285,74 @@ func (s *Server) SendToRouteV2(ctx context.Context,
 func (s *Server) ResetMissionControl(ctx context.Context,
 	req *ResetMissionControlRequest) (*ResetMissionControlResponse, error) {
 
-	err := s.cfg.RouterBackend.MissionControl.ResetHistory()
+	mc, err := s.missionControl(req.MissionControlNamespace)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := mc.ResetHistory(); err != nil {
+		return nil, err
+	}
+
 	return &ResetMissionControlResponse{}, nil
 }
 
+// missionControl returns the mission control of the given namespace. An empty
+// namespace selects the default namespace.
+func (s *Server) missionControl(namespace string) (MissionControl, error) {
+	if namespace == "" {
+		return s.cfg.RouterBackend.MissionControl, nil
+	}
+
+	namespaces := s.cfg.RouterBackend.MissionControlNamespaces
+	mc, err := namespaces.GetNamespacedStore(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return mc, nil
+}
+
+// AddMissionControlNamespace adds a mission control namespace with its own
+// reliability history that payments can select.
+func (s *Server) AddMissionControlNamespace(ctx context.Context,
+	req *AddMissionControlNamespaceRequest) (
+	*AddMissionControlNamespaceResponse, error) {
+
+	namespaces := s.cfg.RouterBackend.MissionControlNamespaces
+	if err := namespaces.AddNamespace(req.Namespace); err != nil {
+		return nil, err
+	}
+
+	return &AddMissionControlNamespaceResponse{}, nil
+}
+
+// DeleteMissionControlNamespace deletes a mission control namespace together
+// with its reliability history.
+func (s *Server) DeleteMissionControlNamespace(ctx context.Context,
+	req *DeleteMissionControlNamespaceRequest) (
+	*DeleteMissionControlNamespaceResponse, error) {
+
+	namespaces := s.cfg.RouterBackend.MissionControlNamespaces
+	if err := namespaces.DeleteNamespace(req.Namespace); err != nil {
+		return nil, err
+	}
+
+	return &DeleteMissionControlNamespaceResponse{}, nil
+}
+
+// ListMissionControlNamespaces lists all mission control namespaces.
+func (s *Server) ListMissionControlNamespaces(ctx context.Context,
+	_ *ListMissionControlNamespacesRequest) (
+	*ListMissionControlNamespacesResponse, error) {
+
+	namespaces := s.cfg.RouterBackend.MissionControlNamespaces
+
+	return &ListMissionControlNamespacesResponse{
+		Namespaces: namespaces.ListNamespaces(),
+	}, nil
+}
+
 // GetMissionControlConfig returns our current mission control config.
 func (s *Server) GetMissionControlConfig(ctx context.Context,
 	req *GetMissionControlConfigRequest) (*GetMissionControlConfigResponse,
@@ -1026,8 +1492,9 @@ func (s *Server) SetMissionControlConfig(ctx context.Context,
 			req.Config.Model)
 	}
 
+	// The config applies to the mission control of every namespace.
 	return &SetMissionControlConfigResponse{},
-		s.cfg.RouterBackend.MissionControl.SetConfig(mcCfg)
+		s.cfg.RouterBackend.MissionControlNamespaces.SetConfig(mcCfg)
 }
 
 // QueryMissionControl exposes the internal mission control state to callers. It
@@ -1035,7 +1502,12 @@ func (s *Server) SetMissionControlConfig(ctx context.Context,
 func (s *Server) QueryMissionControl(ctx context.Context,
 	req *QueryMissionControlRequest) (*QueryMissionControlResponse, error) {
 
-	snapshot := s.cfg.RouterBackend.MissionControl.GetHistorySnapshot()
+	mc, err := s.missionControl(req.MissionControlNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := mc.GetHistorySnapshot()
 
 	rpcPairs := make([]*PairHistory, 0, len(snapshot.Pairs))
 	for _, p := range snapshot.Pairs {
@@ -1078,8 +1550,63 @@ func toRPCPairData(data *routing.TimedPairResult) *PairData {
 	return &rpcData
 }
 
+// XExportMissionControl exports the pair history of our internal mission
+// control in a format that XImportMissionControl accepts without loss of
+// information.
+func (s *Server) XExportMissionControl(ctx context.Context,
+	req *XExportMissionControlRequest) (*XExportMissionControlResponse,
+	error) {
+
+	snapshot := s.cfg.RouterBackend.MissionControl.GetHistorySnapshot()
+
+	rpcPairs := make([]*PairHistory, 0, len(snapshot.Pairs))
+	for _, pair := range snapshot.Pairs {
+		history := &PairData{
+			FailAmtMsat:    int64(pair.FailAmt),
+			SuccessAmtMsat: int64(pair.SuccessAmt),
+		}
+
+		if !pair.FailTime.IsZero() {
+			history.FailTime = pair.FailTime.Unix()
+		}
+
+		// A success that was cleared by a later amount-independent
+		// failure has no amount left. Its time is omitted, as only
+		// successes with an amount can be imported.
+		if !pair.SuccessTime.IsZero() && pair.SuccessAmt != 0 {
+			history.SuccessTime = pair.SuccessTime.Unix()
+		}
+
+		// Skip pairs that don't carry any result anymore.
+		if history.FailTime == 0 && history.SuccessTime == 0 {
+			continue
+		}
+
+		rpcPairs = append(rpcPairs, &PairHistory{
+			NodeFrom: pair.Pair.From[:],
+			NodeTo:   pair.Pair.To[:],
+			History:  history,
+		})
+	}
+
+	// Sort the pairs so that the export is deterministic.
+	sort.Slice(rpcPairs, func(i, j int) bool {
+		cmp := bytes.Compare(rpcPairs[i].NodeFrom, rpcPairs[j].NodeFrom)
+		if cmp != 0 {
+			return cmp < 0
+		}
+
+		return bytes.Compare(rpcPairs[i].NodeTo, rpcPairs[j].NodeTo) < 0
+	})
+
+	return &XExportMissionControlResponse{
+		Pairs: rpcPairs,
+	}, nil
+}
+
 // XImportMissionControl imports the state provided to our internal mission
-// control. Only entries that are fresher than our existing state will be used.
+// control. Only entries that are fresher than our existing state will be used,
+// unless the import is forced.
 func (s *Server) XImportMissionControl(ctx context.Context,
 	req *XImportMissionControlRequest) (*XImportMissionControlResponse,
 	error) {
@@ -1155,7 +1682,7 @@ func toPairSnapshot(pairResult *PairHistory) (*routing.MissionControlPairSnapsho
 			err)
 	}
 
-	if successAmt == 0 && failAmt == 0 {
+	if successTime.IsZero() && failTime.IsZero() {
 		return nil, fmt.Errorf("%v: either success or failure result "+
 			"required", pairPrefix)
 	}
@@ -1209,6 +1736,11 @@ func getPair(amtMsat lnwire.MilliSatoshi, amtSat btcutil.Amount,
 		return 0, time.Time{}, errors.New("non-zero amount for " +
 			"success pairs requires non-zero timestamp")
 
+	// A failure with a timestamp but without an amount is an
+	// amount-independent failure, so we keep its timestamp.
+	case isFailure && timeSet:
+		return 0, time.Unix(timestamp, 0), nil
+
 	default:
 		return 0, time.Time{}, nil
 	}
@@ -1252,7 +1784,8 @@ func (s *Server) TrackPaymentV2(request *TrackPaymentRequest,
 		return err
 	}
 
-	log.Debugf("TrackPayment called for payment %v", payHash)
+	payLog := build.NewTraceLog(build.PaymentTraceID(payHash), log)
+	payLog.Debugf("TrackPayment called for payment %v", payHash)
 
 	// Make the subscription.
 	sub, err := s.subscribePayment(payHash)
@@ -1287,20 +1820,26 @@ func (s *Server) trackPayment(subscription routing.ControlTowerSubscriber,
 	identifier lntypes.Hash, stream Router_TrackPaymentV2Server,
 	noInflightUpdates bool) error {
 
+	filter := &paymentStreamFilter{
+		noInflightUpdates: noInflightUpdates,
+	}
 	err := s.trackPaymentStream(
-		stream.Context(), subscription, noInflightUpdates, stream.Send,
+		stream.Context(), subscription, filter, stream.Send,
 	)
 
+	payLog := build.NewTraceLog(build.PaymentTraceID(identifier), log)
+
 	// If the context is canceled, we don't return an error.
 	if errors.Is(err, context.Canceled) {
-		log.Infof("Payment stream %v canceled", identifier)
+		payLog.Infof("Payment stream %v canceled", identifier)
 
 		return nil
 	}
 
 	// Otherwise, we will log and return the error as the stream has
 	// received an error from the payment lifecycle.
-	log.Errorf("TrackPayment got error for payment %x: %v", identifier, err)
+	payLog.Errorf("TrackPayment got error for payment %x: %v", identifier,
+		err)
 
 	return err
 }
@@ -1313,6 +1852,8 @@ func (s *Server) TrackPayments(request *TrackPaymentsRequest,
 
 	router := s.cfg.RouterBackend
 
+	filter := newPaymentStreamFilter(request)
+
 	// Subscribe to payments.
 	subscription, err := router.Tower.SubscribeAllPayments()
 	if err != nil {
@@ -1321,8 +1862,7 @@ func (s *Server) TrackPayments(request *TrackPaymentsRequest,
 
 	// Stream updates to the client.
 	err = s.trackPaymentStream(
-		stream.Context(), subscription, request.NoInflightUpdates,
-		stream.Send,
+		stream.Context(), subscription, filter, stream.Send,
 	)
 
 	if errors.Is(err, context.Canceled) {
@@ -1332,10 +1872,88 @@ func (s *Server) TrackPayments(request *TrackPaymentsRequest,
 	return err
 }
 
+// paymentStreamFilter decides which payment updates are streamed to the
+// client and how much of them.
+type paymentStreamFilter struct {
+	// noInflightUpdates skips the updates of payments that aren't in a
+	// final state.
+	noInflightUpdates bool
+
+	// statuses is the set of payment states updates are streamed for. All
+	// states are streamed if empty.
+	statuses map[lnrpc.Payment_PaymentStatus]struct{}
+
+	// minAmt is the minimum value of the payments updates are streamed
+	// for.
+	minAmt lnwire.MilliSatoshi
+
+	// createdAfter skips the updates of payments that were created before
+	// this time, if set.
+	createdAfter time.Time
+
+	// omitHops strips the hops from the routes of the htlc attempts.
+	omitHops bool
+}
+
+// newPaymentStreamFilter creates the filter for the given TrackPayments
+// request.
+func newPaymentStreamFilter(req *TrackPaymentsRequest) *paymentStreamFilter {
+	filter := &paymentStreamFilter{
+		noInflightUpdates: req.NoInflightUpdates,
+		statuses: make(
+			map[lnrpc.Payment_PaymentStatus]struct{},
+			len(req.Statuses),
+		),
+		minAmt:   lnwire.MilliSatoshi(req.MinAmtMsat),
+		omitHops: req.OmitHops,
+	}
+
+	for _, status := range req.Statuses {
+		filter.statuses[status] = struct{}{}
+	}
+
+	if req.CreationDateStart != 0 {
+		filter.createdAfter = time.Unix(int64(req.CreationDateStart), 0)
+	}
+
+	return filter
+}
+
+// skipPayment returns true if no update should be streamed for the payment,
+// based on the fields of the stored payment.
+func (f *paymentStreamFilter) skipPayment(payment *channeldb.MPPayment) bool {
+	if f.noInflightUpdates {
+		switch payment.Status {
+		case channeldb.StatusInitiated, channeldb.StatusInFlight:
+			return true
+		}
+	}
+
+	if payment.Info.Value < f.minAmt {
+		return true
+	}
+
+	return payment.Info.CreationTime.Before(f.createdAfter)
+}
+
+// skipStatus returns true if no update should be streamed for a payment with
+// the given rpc status.
+func (f *paymentStreamFilter) skipStatus(
+	status lnrpc.Payment_PaymentStatus) bool {
+
+	if len(f.statuses) == 0 {
+		return false
+	}
+
+	_, ok := f.statuses[status]
+
+	return !ok
+}
+
 // trackPaymentStream streams payment updates to the client.
 func (s *Server) trackPaymentStream(context context.Context,
-	subscription routing.ControlTowerSubscriber, noInflightUpdates bool,
-	send func(*lnrpc.Payment) error) error {
+	subscription routing.ControlTowerSubscriber,
+	filter *paymentStreamFilter, send func(*lnrpc.Payment) error) error {
 
 	defer subscription.Close()
 
@@ -1352,14 +1970,10 @@ func (s *Server) trackPaymentStream(context context.Context,
 			log.Tracef("Payment %v updated to state %v",
 				result.Info.PaymentIdentifier, result.Status)
 
-			// Skip in-flight updates unless requested.
-			if noInflightUpdates {
-				if result.Status == channeldb.StatusInitiated {
-					continue
-				}
-				if result.Status == channeldb.StatusInFlight {
-					continue
-				}
+			// Skip in-flight updates unless requested, as well as
+			// all payments the client isn't interested in.
+			if filter.skipPayment(result) {
+				continue
 			}
 
 			rpcPayment, err := s.cfg.RouterBackend.MarshallPayment(
@@ -1369,6 +1983,21 @@ func (s *Server) trackPaymentStream(context context.Context,
 				return err
 			}
 
+			// The status is filtered on as the client sees it,
+			// which depends on whether it understands the
+			// initiated status.
+			if filter.skipStatus(rpcPayment.Status) {
+				continue
+			}
+
+			if filter.omitHops {
+				for _, htlc := range rpcPayment.Htlcs {
+					if htlc.Route != nil {
+						htlc.Route.Hops = nil
+					}
+				}
+			}
+
 			// Send event to the client.
 			err = send(rpcPayment)
 			if err != nil {
@@ -1388,6 +2017,11 @@ func (s *Server) trackPaymentStream(context context.Context,
 func (s *Server) BuildRoute(ctx context.Context,
 	req *BuildRouteRequest) (*BuildRouteResponse, error) {
 
+	if len(req.HopPubkeys) != 0 && len(req.Hops) != 0 {
+		return nil, errors.New("only one of hop_pubkeys and hops can " +
+			"be set")
+	}
+
 	// Unmarshall hop list.
 	hops := make([]route.Vertex, len(req.HopPubkeys))
 	for i, pubkeyBytes := range req.HopPubkeys {
@@ -1410,6 +2044,35 @@ func (s *Server) BuildRoute(ctx context.Context,
 		outgoingChan = &req.OutgoingChanId
 	}
 
+	if req.FinalCltvDelta == 0 {
+		req.FinalCltvDelta = int32(
+			s.cfg.RouterBackend.DefaultFinalCltvDelta,
+		)
+	}
+
+	// If the route is given as a list of hops, resolve its placeholders
+	// by pathfinding and collect the custom records of the hops.
+	var hopRecords []record.CustomSet
+	if len(req.Hops) != 0 {
+		findPath := func(source, target route.Vertex) ([]route.Vertex,
+			error) {
+
+			return s.findPathVertices(
+				source, target, amt, outgoingChan,
+				req.FinalCltvDelta,
+			)
+		}
+
+		var err error
+		hops, hopRecords, err = resolveBuildRouteHops(
+			s.cfg.RouterBackend.SelfNode, req.Hops, amt != nil,
+			findPath,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var payAddr *[32]byte
 	if len(req.PaymentAddr) != 0 {
 		var backingPayAddr [32]byte
@@ -1418,12 +2081,6 @@ func (s *Server) BuildRoute(ctx context.Context,
 		payAddr = &backingPayAddr
 	}
 
-	if req.FinalCltvDelta == 0 {
-		req.FinalCltvDelta = int32(
-			s.cfg.RouterBackend.DefaultFinalCltvDelta,
-		)
-	}
-
 	// Build the route and return it to the caller.
 	route, err := s.cfg.Router.BuildRoute(
 		amt, hops, outgoingChan, req.FinalCltvDelta, payAddr,
@@ -1432,6 +2089,14 @@ func (s *Server) BuildRoute(ctx context.Context,
 		return nil, err
 	}
 
+	// The route has a hop for every vertex, so the custom records can be
+	// attached by index.
+	for i, customRecords := range hopRecords {
+		if len(customRecords) != 0 {
+			route.Hops[i].CustomRecords = customRecords
+		}
+	}
+
 	rpcRoute, err := s.cfg.RouterBackend.MarshallRoute(route)
 	if err != nil {
 		return nil, err
@@ -1444,6 +2109,131 @@ func (s *Server) BuildRoute(ctx context.Context,
 	return routeResp, nil
 }
 
+// pathFinder returns the vertices of the intermediate nodes of a path from
+// the source to the target node.
+type pathFinder func(source, target route.Vertex) ([]route.Vertex, error)
+
+// resolveBuildRouteHops converts the hops of a BuildRoute request to the list
+// of vertices the route is built along and the custom records of each of
+// them. Placeholder hops are replaced by the vertices the path finder returns
+// for the path from the previous to the next hop.
+func resolveBuildRouteHops(self route.Vertex, rpcHops []*BuildRouteHop,
+	hasAmt bool, findPath pathFinder) ([]route.Vertex, []record.CustomSet,
+	error) {
+
+	var (
+		hops        []route.Vertex
+		hopRecords  []record.CustomSet
+		prev        = self
+		placeholder bool
+	)
+	for i, rpcHop := range rpcHops {
+		if len(rpcHop.Pubkey) == 0 {
+			switch {
+			case len(rpcHop.CustomRecords) != 0:
+				return nil, nil, fmt.Errorf("placeholder hop "+
+					"%v can't carry custom records", i)
+
+			case placeholder:
+				return nil, nil, fmt.Errorf("placeholder hop "+
+					"%v follows another placeholder", i)
+
+			case i == len(rpcHops)-1:
+				return nil, nil, errors.New("last hop can't " +
+					"be a placeholder")
+
+			case !hasAmt:
+				return nil, nil, errors.New("placeholder " +
+					"hops require an amount")
+			}
+
+			placeholder = true
+
+			continue
+		}
+
+		vertex, err := route.NewVertexFromBytes(rpcHop.Pubkey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		customRecords := record.CustomSet(rpcHop.CustomRecords)
+		if err := customRecords.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("hop %v: %w", i, err)
+		}
+
+		// Fill in the path to this hop if it follows a placeholder.
+		if placeholder {
+			path, err := findPath(prev, vertex)
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to find "+
+					"path from %v to %v: %w", prev, vertex,
+					err)
+			}
+
+			for _, pathVertex := range path {
+				hops = append(hops, pathVertex)
+				hopRecords = append(hopRecords, nil)
+			}
+
+			placeholder = false
+		}
+
+		hops = append(hops, vertex)
+		hopRecords = append(hopRecords, customRecords)
+		prev = vertex
+	}
+
+	return hops, hopRecords, nil
+}
+
+// findPathVertices uses pathfinding to find a path between the given nodes and
+// returns the vertices of the intermediate nodes of the path.
+func (s *Server) findPathVertices(source, target route.Vertex,
+	amt *lnwire.MilliSatoshi, outgoingChan *uint64,
+	finalCltvDelta int32) ([]route.Vertex, error) {
+
+	backend := s.cfg.RouterBackend
+	restrictions := &routing.RestrictParams{
+		FeeLimit: lnwire.MaxMilliSatoshi,
+		ProbabilitySource: func(fromNode, toNode route.Vertex,
+			amt lnwire.MilliSatoshi,
+			capacity btcutil.Amount) float64 {
+
+			return backend.MissionControl.GetProbability(
+				fromNode, toNode, amt, capacity,
+			)
+		},
+		CltvLimit: backend.MaxTotalTimelock,
+	}
+
+	// The outgoing channel only restricts a path that starts at our node.
+	if outgoingChan != nil && source == backend.SelfNode {
+		restrictions.OutgoingChannelIDs = []uint64{*outgoingChan}
+	}
+
+	routeReq, err := routing.NewRouteRequest(
+		source, &target, *amt, 0, restrictions, nil, nil, nil,
+		uint16(finalCltvDelta),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, _, err := backend.FindRoute(routeReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// The last hop of the route is the target itself.
+	path := make([]route.Vertex, 0, len(rt.Hops)-1)
+	for _, hop := range rt.Hops[:len(rt.Hops)-1] {
+		path = append(path, hop.PubKeyBytes)
+	}
+
+	return path, nil
+}
+
 // SubscribeHtlcEvents creates a uni-directional stream from the server to
 // the client which delivers a stream of htlc events.
 func (s *Server) SubscribeHtlcEvents(req *SubscribeHtlcEventsRequest,
@@ -1493,6 +2283,52 @@ func (s *Server) SubscribeHtlcEvents(req *SubscribeHtlcEventsRequest,
 	}
 }
 
+// SubscribeForwards creates a uni-directional stream from the server to the
+// client which delivers an update for every forward attempt: the add of the
+// outgoing htlc, and its settle or failure.
+func (s *Server) SubscribeForwards(_ *SubscribeForwardsRequest,
+	stream Router_SubscribeForwardsServer) error {
+
+	htlcClient, err := s.cfg.RouterBackend.SubscribeHtlcEvents()
+	if err != nil {
+		return err
+	}
+	defer htlcClient.Cancel()
+
+	tracker := newForwardTracker()
+	for {
+		select {
+		case event := <-htlcClient.Updates():
+			update, err := tracker.update(event)
+			if err != nil {
+				return err
+			}
+
+			// Skip events that aren't part of a forward.
+			if update == nil {
+				continue
+			}
+
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+
+		// If the stream's context is cancelled, return an error.
+		case <-stream.Context().Done():
+			log.Debugf("forward stream cancelled")
+			return stream.Context().Err()
+
+		// If the subscribe client terminates, exit with an error.
+		case <-htlcClient.Quit():
+			return errors.New("htlc event subscription terminated")
+
+		// If the server has been signalled to shut down, exit.
+		case <-s.quit:
+			return errServerShuttingDown
+		}
+	}
+}
+
 // HtlcInterceptor is a bidirectional stream for streaming interception
 // requests to the caller.
 // Upon connection it does the following:
@@ -1554,3 +2390,81 @@ func (s *Server) UpdateChanStatus(ctx context.Context,
 	}
 	return &UpdateChanStatusResponse{}, nil
 }
+
+// GetReputation returns the local reputation that this node tracks for its
+// peers, along with the revenue and general slot usage of its channels.
+func (s *Server) GetReputation(_ context.Context,
+	_ *GetReputationRequest) (*GetReputationResponse, error) {
+
+	report := s.cfg.RouterBackend.Reputation()
+
+	resp := &GetReputationResponse{
+		Peers:        make([]*PeerReputation, 0, len(report.Peers)),
+		Channels:     make([]*ChannelRevenue, 0, len(report.Channels)),
+		GeneralSlots: report.GeneralSlots,
+	}
+
+	for _, peer := range report.Peers {
+		pubKey := peer.PeerPubKey
+		resp.Peers = append(resp.Peers, &PeerReputation{
+			PubKey:                pubKey[:],
+			ReputationMsat:        peer.Reputation,
+			EndorsedHtlcsInFlight: peer.EndorsedInFlight,
+		})
+	}
+
+	for _, channel := range report.Channels {
+		resp.Channels = append(resp.Channels, &ChannelRevenue{
+			ChanId:            channel.ChanID.ToUint64(),
+			RevenueMsat:       channel.Revenue,
+			GeneralSlotsInUse: channel.GeneralSlotsInUse,
+		})
+	}
+
+	return resp, nil
+}
+
+// ListCircuits returns the in-flight circuits of the switch, ordered from the
+// oldest to the newest circuit.
+func (s *Server) ListCircuits(_ context.Context,
+	_ *ListCircuitsRequest) (*ListCircuitsResponse, error) {
+
+	circuits, err := s.cfg.RouterBackend.ListCircuits()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(circuits, func(i, j int) bool {
+		return circuits[i].AddedAt.Before(circuits[j].AddedAt)
+	})
+
+	now := time.Now()
+	resp := &ListCircuitsResponse{
+		Circuits: make([]*Circuit, 0, len(circuits)),
+	}
+	for _, circuit := range circuits {
+		age := now.Sub(circuit.AddedAt)
+		rpcCircuit := &Circuit{
+			IncomingChanId:  circuit.Incoming.ChanID.ToUint64(),
+			IncomingHtlcId:  circuit.Incoming.HtlcID,
+			Forwarded:       circuit.HasKeystone(),
+			PaymentHash:     circuit.PaymentHash[:],
+			IncomingAmtMsat: uint64(circuit.IncomingAmount),
+			OutgoingAmtMsat: uint64(circuit.OutgoingAmount),
+			IncomingExpiry:  circuit.IncomingTimeout,
+			OutgoingExpiry:  circuit.OutgoingTimeout,
+			AgeSeconds:      uint64(age.Seconds()),
+			LoadedFromDisk:  circuit.LoadedFromDisk,
+		}
+
+		if circuit.HasKeystone() {
+			outKey := circuit.Outgoing
+			rpcCircuit.OutgoingChanId = outKey.ChanID.ToUint64()
+			rpcCircuit.OutgoingHtlcId = outKey.HtlcID
+		}
+
+		resp.Circuits = append(resp.Circuits, rpcCircuit)
+	}
+
+	return resp, nil
+}
@@ -2,15 +2,20 @@ package routerrpc
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/queue"
+	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -50,7 +55,8 @@ func (s controlTowerSubscriberMock) Close() {
 }
 
 type controlTowerMock struct {
-	queue *queue.ConcurrentQueue
+	queue           *queue.ConcurrentQueue
+	idempotencyKeys map[string]lntypes.Hash
 	routing.ControlTower
 }
 
@@ -71,6 +77,25 @@ func (t *controlTowerMock) SubscribeAllPayments() (
 	}, nil
 }
 
+func (t *controlTowerMock) SubscribePayment(lntypes.Hash) (
+	routing.ControlTowerSubscriber, error) {
+
+	return &controlTowerSubscriberMock{
+		updates: t.queue.ChanOut(),
+	}, nil
+}
+
+func (t *controlTowerMock) FetchPaymentHashByIdempotencyKey(key []byte) (
+	lntypes.Hash, error) {
+
+	hash, ok := t.idempotencyKeys[string(key)]
+	if !ok {
+		return lntypes.Hash{}, channeldb.ErrPaymentNotInitiated
+	}
+
+	return hash, nil
+}
+
 // TestTrackPaymentsReturnsOnCancelContext tests whether TrackPayments returns
 // when the stream context is cancelled.
 func TestTrackPaymentsReturnsOnCancelContext(t *testing.T) {
@@ -218,6 +243,304 @@ func TestTrackPaymentsNoInflightUpdates(t *testing.T) {
 	require.Equal(t, lnrpc.Payment_SUCCEEDED, payment.Status)
 }
 
+// TestTrackPaymentsFilter tests that only the payment updates matching the
+// filter of the request are propagated to the client.
+func TestTrackPaymentsFilter(t *testing.T) {
+	start := time.Unix(1_000_000, 0)
+
+	request := &TrackPaymentsRequest{
+		Statuses: []lnrpc.Payment_PaymentStatus{
+			lnrpc.Payment_SUCCEEDED,
+		},
+		MinAmtMsat:        1000,
+		CreationDateStart: uint64(start.Unix()),
+		OmitHops:          true,
+	}
+	towerMock := makeControlTowerMock()
+
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	stream := makeStreamMock(streamCtx)
+	defer cancelStream()
+
+	fetchCapacity := func(uint64) (btcutil.Amount, error) {
+		return 0, errors.New("unknown channel")
+	}
+	server := &Server{
+		cfg: &Config{
+			RouterBackend: &RouterBackend{
+				Tower:                towerMock,
+				FetchChannelCapacity: fetchCapacity,
+			},
+		},
+	}
+
+	// Listen to payment updates in a goroutine.
+	go func() {
+		err := server.TrackPayments(request, stream)
+		require.Equal(t, context.Canceled, err)
+	}()
+
+	newPayment := func(value lnwire.MilliSatoshi, created time.Time,
+		status channeldb.PaymentStatus) *channeldb.MPPayment {
+
+		return &channeldb.MPPayment{
+			Info: &channeldb.PaymentCreationInfo{
+				PaymentIdentifier: [32]byte{byte(value)},
+				Value:             value,
+				CreationTime:      created,
+			},
+			HTLCs: []channeldb.HTLCAttempt{{
+				HTLCAttemptInfo: channeldb.HTLCAttemptInfo{
+					Route: route.Route{
+						TotalAmount: value,
+						Hops: []*route.Hop{{
+							ChannelID:    1,
+							AmtToForward: value,
+						}},
+					},
+				},
+			}},
+			Status: status,
+		}
+	}
+
+	// Enqueue payment updates that are filtered because of their amount,
+	// status and creation time, followed by one that matches the filter.
+	towerMock.queue.ChanIn() <- newPayment(
+		999, start, channeldb.StatusSucceeded,
+	)
+	towerMock.queue.ChanIn() <- newPayment(
+		1000, start, channeldb.StatusInFlight,
+	)
+	towerMock.queue.ChanIn() <- newPayment(
+		1000, start.Add(-time.Second), channeldb.StatusSucceeded,
+	)
+	towerMock.queue.ChanIn() <- newPayment(
+		1001, start, channeldb.StatusSucceeded,
+	)
+
+	// Only the last update is sent to the client, without the hops of its
+	// htlc attempt.
+	select {
+	case payment := <-stream.sentFromServer:
+		require.EqualValues(t, 1001, payment.ValueMsat)
+		require.Len(t, payment.Htlcs, 1)
+
+		rt := payment.Htlcs[0].Route
+		require.EqualValues(t, 1001, rt.TotalAmtMsat)
+		require.Empty(t, rt.Hops)
+
+	case <-time.After(time.Second):
+		require.FailNow(t, "deadline exceeded.")
+	}
+
+	require.Empty(t, stream.sentFromServer)
+}
+
+// TestSendPaymentV2IdempotencyKey tests that a payment request with the
+// idempotency key of an existing payment returns the update stream of that
+// payment instead of sending a new one.
+func TestSendPaymentV2IdempotencyKey(t *testing.T) {
+	towerMock := makeControlTowerMock()
+	towerMock.idempotencyKeys = map[string]lntypes.Hash{
+		"key": {1},
+	}
+
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	stream := makeStreamMock(streamCtx)
+	defer cancelStream()
+
+	server := &Server{
+		cfg: &Config{
+			RouterBackend: &RouterBackend{
+				Tower: towerMock,
+			},
+		},
+	}
+
+	// The request doesn't carry any payment details, so it would fail if
+	// the server attempted to send a new payment.
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.SendPaymentV2(&SendPaymentRequest{
+			IdempotencyKey: []byte("key"),
+		}, stream)
+	}()
+
+	towerMock.queue.ChanIn() <- &channeldb.MPPayment{
+		Info: &channeldb.PaymentCreationInfo{
+			PaymentIdentifier: lntypes.Hash{1},
+			IdempotencyKey:    []byte("key"),
+		},
+		Status: channeldb.StatusSucceeded,
+	}
+
+	select {
+	case payment := <-stream.sentFromServer:
+		require.Equal(t, lnrpc.Payment_SUCCEEDED, payment.Status)
+		require.Equal(t, []byte("key"), payment.IdempotencyKey)
+
+	case err := <-errChan:
+		require.FailNow(t, "unexpected return", err)
+
+	case <-time.After(time.Second):
+		require.FailNow(t, "deadline exceeded.")
+	}
+
+	// A canceled stream ends the tracking without an error.
+	cancelStream()
+	require.NoError(t, <-errChan)
+
+	// An unknown key is used to send a new payment, which fails here
+	// because the request is missing its destination.
+	err := server.SendPaymentV2(&SendPaymentRequest{
+		IdempotencyKey: []byte("other key"),
+	}, stream)
+	require.Error(t, err)
+}
+
+// TestResolveBuildRouteHops tests that the hops of a BuildRoute request are
+// resolved to the vertices of the route and their custom records.
+func TestResolveBuildRouteHops(t *testing.T) {
+	t.Parallel()
+
+	var (
+		self  = route.Vertex{1}
+		alice = route.Vertex{2}
+		bob   = route.Vertex{3}
+		carol = route.Vertex{4}
+
+		customRecords = map[uint64][]byte{
+			record.CustomTypeStart: {1, 2, 3},
+		}
+		invalidRecords = map[uint64][]byte{1: {1}}
+	)
+
+	// The path finder knows a path from us to Bob via Alice and a direct
+	// channel from Bob to Carol.
+	findPath := func(source, target route.Vertex) ([]route.Vertex, error) {
+		switch {
+		case source == self && target == bob:
+			return []route.Vertex{alice}, nil
+
+		case source == bob && target == carol:
+			return nil, nil
+		}
+
+		return nil, errors.New("no path")
+	}
+
+	placeholder := &BuildRouteHop{}
+
+	tests := []struct {
+		name       string
+		hops       []*BuildRouteHop
+		noAmt      bool
+		expHops    []route.Vertex
+		expRecords []record.CustomSet
+		expErr     bool
+	}{
+		{
+			name: "explicit hops with custom records",
+			hops: []*BuildRouteHop{
+				{Pubkey: alice[:]},
+				{Pubkey: bob[:], CustomRecords: customRecords},
+			},
+			expHops: []route.Vertex{alice, bob},
+			expRecords: []record.CustomSet{
+				nil, customRecords,
+			},
+		},
+		{
+			name: "placeholders",
+			hops: []*BuildRouteHop{
+				placeholder,
+				{Pubkey: bob[:]},
+				placeholder,
+				{
+					Pubkey:        carol[:],
+					CustomRecords: customRecords,
+				},
+			},
+			expHops: []route.Vertex{alice, bob, carol},
+			expRecords: []record.CustomSet{
+				nil, nil, customRecords,
+			},
+		},
+		{
+			name: "no path",
+			hops: []*BuildRouteHop{
+				placeholder,
+				{Pubkey: carol[:]},
+			},
+			expErr: true,
+		},
+		{
+			name: "placeholder without amount",
+			hops: []*BuildRouteHop{
+				placeholder,
+				{Pubkey: bob[:]},
+			},
+			noAmt:  true,
+			expErr: true,
+		},
+		{
+			name: "consecutive placeholders",
+			hops: []*BuildRouteHop{
+				placeholder,
+				placeholder,
+				{Pubkey: bob[:]},
+			},
+			expErr: true,
+		},
+		{
+			name: "placeholder as last hop",
+			hops: []*BuildRouteHop{
+				{Pubkey: alice[:]},
+				placeholder,
+			},
+			expErr: true,
+		},
+		{
+			name: "placeholder with custom records",
+			hops: []*BuildRouteHop{
+				{CustomRecords: customRecords},
+				{Pubkey: bob[:]},
+			},
+			expErr: true,
+		},
+		{
+			name: "custom records outside custom range",
+			hops: []*BuildRouteHop{
+				{
+					Pubkey:        bob[:],
+					CustomRecords: invalidRecords,
+				},
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			hops, records, err := resolveBuildRouteHops(
+				self, test.hops, !test.noAmt, findPath,
+			)
+			if test.expErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expHops, hops)
+			require.Equal(t, test.expRecords, records)
+		})
+	}
+}
+
 // TestIsLsp tests the isLSP heuristic. Combinations of different route hints
 // with different fees and cltv deltas are tested to ensure that the heuristic
 // correctly identifies whether a route leads to an LSP or not.
@@ -400,3 +723,53 @@ func TestIsLsp(t *testing.T) {
 		})
 	}
 }
+
+// TestToPairSnapshot tests that exported pair history is converted into a
+// mission control pair snapshot without loss of information.
+func TestToPairSnapshot(t *testing.T) {
+	t.Parallel()
+
+	from := route.Vertex{1}
+	to := route.Vertex{2}
+	ts := time.Unix(1700000000, 0)
+
+	// An amount-independent failure doesn't have an amount, but its
+	// timestamp must be kept.
+	snapshot, err := toPairSnapshot(&PairHistory{
+		NodeFrom: from[:],
+		NodeTo:   to[:],
+		History: &PairData{
+			FailTime: ts.Unix(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, routing.TimedPairResult{
+		FailTime: ts,
+	}, snapshot.TimedPairResult)
+
+	snapshot, err = toPairSnapshot(&PairHistory{
+		NodeFrom: from[:],
+		NodeTo:   to[:],
+		History: &PairData{
+			FailTime:       ts.Unix(),
+			FailAmtMsat:    2000,
+			SuccessTime:    ts.Unix() - 60,
+			SuccessAmtMsat: 1000,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, routing.TimedPairResult{
+		FailTime:    ts,
+		FailAmt:     2000,
+		SuccessTime: ts.Add(-time.Minute),
+		SuccessAmt:  1000,
+	}, snapshot.TimedPairResult)
+
+	// A pair without any result is rejected.
+	_, err = toPairSnapshot(&PairHistory{
+		NodeFrom: from[:],
+		NodeTo:   to[:],
+		History:  &PairData{},
+	})
+	require.ErrorContains(t, err, "either success or failure result")
+}
@@ -36,6 +36,11 @@ type RoutingConfig struct {
 	// control state to the DB.
 	McFlushInterval time.Duration `long:"mcflushinterval" description:"the timer interval to use to flush mission control state to the DB"`
 
+	// McHistoryHalfLife defines the half-life with which the persisted
+	// mission control pair history decays between it being stored on
+	// shutdown and loaded again on startup.
+	McHistoryHalfLife time.Duration `long:"mchistoryhalflife" description:"the half-life with which the mission control pair history that is persisted across restarts decays while lnd is offline, only used with a native SQL database backend; 0 disables the decay"`
+
 	// AprioriConfig defines parameters for the apriori probability.
 	AprioriConfig *AprioriConfig `group:"apriori" namespace:"apriori" description:"configuration for the apriori pathfinding probability estimator"`
 
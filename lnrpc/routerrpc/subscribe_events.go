@@ -254,8 +254,132 @@ func rpcOutgoingFailure(failureDetail htlcswitch.OutgoingFailure) (
 	case htlcswitch.OutgoingFailureForwardsDisabled:
 		return FailureDetail_FORWARDS_DISABLED, nil
 
+	case htlcswitch.OutgoingFailureForwardingLimit:
+		return FailureDetail_FORWARDING_LIMIT, nil
+
+	case htlcswitch.OutgoingFailureGeneralSlotsExhausted:
+		return FailureDetail_GENERAL_SLOTS_EXHAUSTED, nil
+
 	default:
 		return 0, fmt.Errorf("unknown outgoing failure "+
 			"detail: %v", failureDetail.FailureString())
 	}
 }
+
+// trackedAdd is a forwarded htlc whose add was delivered on a forward
+// subscription.
+type trackedAdd struct {
+	info      htlcswitch.HtlcInfo
+	timestamp time.Time
+}
+
+// forwardTracker converts the htlc events of the switch into forward updates.
+// It remembers the adds of the forwards that it has seen, so that their
+// settles and downstream failures, which don't carry any htlc information,
+// can be enriched with it.
+type forwardTracker struct {
+	adds map[htlcswitch.HtlcKey]trackedAdd
+}
+
+// newForwardTracker creates a new forward tracker.
+func newForwardTracker() *forwardTracker {
+	return &forwardTracker{
+		adds: make(map[htlcswitch.HtlcKey]trackedAdd),
+	}
+}
+
+// resolve stops tracking the add of the given htlc and sets its information
+// and hold time on the update, if the add was seen.
+func (f *forwardTracker) resolve(key htlcswitch.HtlcKey, timestamp time.Time,
+	update *ForwardUpdate) {
+
+	add, ok := f.adds[key]
+	if !ok {
+		return
+	}
+	delete(f.adds, key)
+
+	update.Info = rpcInfo(add.info)
+	update.HoldTimeNs = uint64(timestamp.Sub(add.timestamp))
+}
+
+// update returns the forward update for the given htlc event, or nil if the
+// event isn't part of a forward.
+func (f *forwardTracker) update(htlcEvent interface{}) (*ForwardUpdate,
+	error) {
+
+	var (
+		key       htlcswitch.HtlcKey
+		timestamp time.Time
+		update    = &ForwardUpdate{}
+	)
+
+	switch e := htlcEvent.(type) {
+	case *htlcswitch.ForwardingEvent:
+		if e.HtlcEventType != htlcswitch.HtlcEventTypeForward {
+			return nil, nil
+		}
+
+		key = e.HtlcKey
+		timestamp = e.Timestamp
+		f.adds[key] = trackedAdd{
+			info:      e.HtlcInfo,
+			timestamp: timestamp,
+		}
+
+		update.UpdateType = ForwardUpdate_ADD
+		update.Info = rpcInfo(e.HtlcInfo)
+
+	case *htlcswitch.SettleEvent:
+		if e.HtlcEventType != htlcswitch.HtlcEventTypeForward {
+			return nil, nil
+		}
+
+		key = e.HtlcKey
+		timestamp = e.Timestamp
+		update.UpdateType = ForwardUpdate_SETTLE
+		f.resolve(key, timestamp, update)
+
+	case *htlcswitch.ForwardingFailEvent:
+		if e.HtlcEventType != htlcswitch.HtlcEventTypeForward {
+			return nil, nil
+		}
+
+		key = e.HtlcKey
+		timestamp = e.Timestamp
+		update.UpdateType = ForwardUpdate_FAIL
+		update.FailureSource = ForwardUpdate_DOWNSTREAM
+		f.resolve(key, timestamp, update)
+
+	case *htlcswitch.LinkFailEvent:
+		if e.HtlcEventType != htlcswitch.HtlcEventTypeForward {
+			return nil, nil
+		}
+
+		failureCode, failReason, err := rpcFailReason(e.LinkError)
+		if err != nil {
+			return nil, err
+		}
+
+		key = e.HtlcKey
+		timestamp = e.Timestamp
+		update.UpdateType = ForwardUpdate_FAIL
+		update.FailureSource = ForwardUpdate_LOCAL
+		update.WireFailure = failureCode
+		update.FailureDetail = failReason
+		update.FailureString = e.LinkError.Error()
+		update.Info = rpcInfo(e.HtlcInfo)
+		f.resolve(key, timestamp, update)
+
+	default:
+		return nil, nil
+	}
+
+	update.IncomingChannelId = key.IncomingCircuit.ChanID.ToUint64()
+	update.OutgoingChannelId = key.OutgoingCircuit.ChanID.ToUint64()
+	update.IncomingHtlcId = key.IncomingCircuit.HtlcID
+	update.OutgoingHtlcId = key.OutgoingCircuit.HtlcID
+	update.TimestampNs = uint64(timestamp.UnixNano())
+
+	return update, nil
+}
@@ -0,0 +1,99 @@
+package routerrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForwardTracker tests that the forward tracker converts the htlc events
+// of forwards into forward updates, enriching settles and failures with the
+// information of their add.
+func TestForwardTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newForwardTracker()
+
+	key := htlcswitch.HtlcKey{
+		IncomingCircuit: htlcswitch.CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: 2,
+		},
+		OutgoingCircuit: htlcswitch.CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(3),
+			HtlcID: 4,
+		},
+	}
+	info := htlcswitch.HtlcInfo{
+		IncomingTimeLock: 140,
+		OutgoingTimeLock: 100,
+		IncomingAmt:      1100,
+		OutgoingAmt:      1000,
+	}
+	addTime := time.Unix(10, 0)
+
+	// Events of local sends are skipped.
+	update, err := tracker.update(&htlcswitch.ForwardingEvent{
+		HtlcKey:       key,
+		HtlcEventType: htlcswitch.HtlcEventTypeSend,
+	})
+	require.NoError(t, err)
+	require.Nil(t, update)
+
+	update, err = tracker.update(&htlcswitch.ForwardingEvent{
+		HtlcKey:       key,
+		HtlcInfo:      info,
+		HtlcEventType: htlcswitch.HtlcEventTypeForward,
+		Timestamp:     addTime,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ForwardUpdate_ADD, update.UpdateType)
+	require.Equal(t, rpcInfo(info), update.Info)
+	require.EqualValues(t, 1, update.IncomingChannelId)
+	require.EqualValues(t, 4, update.OutgoingHtlcId)
+
+	// The settle carries the information and hold time of the add.
+	update, err = tracker.update(&htlcswitch.SettleEvent{
+		HtlcKey:       key,
+		HtlcEventType: htlcswitch.HtlcEventTypeForward,
+		Timestamp:     addTime.Add(time.Second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ForwardUpdate_SETTLE, update.UpdateType)
+	require.Equal(t, rpcInfo(info), update.Info)
+	require.EqualValues(t, time.Second, update.HoldTimeNs)
+	require.Empty(t, tracker.adds)
+
+	// A downstream failure of an add that wasn't seen has no information.
+	update, err = tracker.update(&htlcswitch.ForwardingFailEvent{
+		HtlcKey:       key,
+		HtlcEventType: htlcswitch.HtlcEventTypeForward,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ForwardUpdate_FAIL, update.UpdateType)
+	require.Equal(t, ForwardUpdate_DOWNSTREAM, update.FailureSource)
+	require.Nil(t, update.Info)
+
+	// Local failures carry their reason.
+	update, err = tracker.update(&htlcswitch.LinkFailEvent{
+		HtlcKey:       key,
+		HtlcInfo:      info,
+		HtlcEventType: htlcswitch.HtlcEventTypeForward,
+		LinkError: htlcswitch.NewDetailedLinkError(
+			&lnwire.FailTemporaryChannelFailure{},
+			htlcswitch.OutgoingFailureInsufficientBalance,
+		),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ForwardUpdate_FAIL, update.UpdateType)
+	require.Equal(t, ForwardUpdate_LOCAL, update.FailureSource)
+	require.Equal(t, lnrpc.Failure_TEMPORARY_CHANNEL_FAILURE,
+		update.WireFailure)
+	require.Equal(t, FailureDetail_INSUFFICIENT_BALANCE,
+		update.FailureDetail)
+	require.Equal(t, rpcInfo(info), update.Info)
+}
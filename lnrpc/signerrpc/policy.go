@@ -0,0 +1,267 @@
+package signerrpc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// wildcard is the path element that matches any index.
+	wildcard = "*"
+
+	// hardenedSuffix is the suffix that marks a hardened path element.
+	hardenedSuffix = "'"
+)
+
+// pathElement is a single element of a derivation path pattern.
+type pathElement struct {
+	// matchAll is true if the element matches any index of the right kind.
+	matchAll bool
+
+	// hardened is true if the element only matches hardened indexes.
+	hardened bool
+
+	// index is the index the element matches if it's not a wildcard. It
+	// doesn't include the hardened key offset.
+	index uint32
+}
+
+// matches returns true if the given raw index of a derivation path matches
+// the element.
+func (e pathElement) matches(index uint32) bool {
+	isHardened := index >= hdkeychain.HardenedKeyStart
+	if isHardened != e.hardened {
+		return false
+	}
+
+	if e.matchAll {
+		return true
+	}
+
+	if isHardened {
+		index -= hdkeychain.HardenedKeyStart
+	}
+
+	return index == e.index
+}
+
+// String returns the element in its textual representation.
+func (e pathElement) String() string {
+	str := strconv.FormatUint(uint64(e.index), 10)
+	if e.matchAll {
+		str = wildcard
+	}
+	if e.hardened {
+		str += hardenedSuffix
+	}
+
+	return str
+}
+
+// PathPattern is a BIP32 derivation path pattern such as m/84'/0'/*'/*/*. A
+// "*" element matches any non-hardened index and a "*'" element matches any
+// hardened index.
+type PathPattern []pathElement
+
+// ParsePathPattern parses a derivation path pattern in its textual
+// representation.
+func ParsePathPattern(pattern string) (PathPattern, error) {
+	pattern = strings.TrimSpace(pattern)
+	if !strings.HasPrefix(pattern, "m/") {
+		return nil, fmt.Errorf("path pattern %q must start with m/",
+			pattern)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(pattern, "m/"), "/")
+	elements := make(PathPattern, len(parts))
+	for idx, part := range parts {
+		element := &elements[idx]
+
+		if strings.HasSuffix(part, hardenedSuffix) {
+			element.hardened = true
+			part = strings.TrimSuffix(part, hardenedSuffix)
+		}
+
+		if part == wildcard {
+			element.matchAll = true
+			continue
+		}
+
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid element %q in path "+
+				"pattern %q: %w", parts[idx], pattern, err)
+		}
+		element.index = uint32(index)
+	}
+
+	return elements, nil
+}
+
+// Matches returns true if the given derivation path, with hardened elements
+// including the hardened key offset, matches the pattern.
+func (p PathPattern) Matches(path []uint32) bool {
+	if len(path) != len(p) {
+		return false
+	}
+
+	for idx, element := range p {
+		if !element.matches(path[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the pattern in its textual representation.
+func (p PathPattern) String() string {
+	parts := make([]string, len(p))
+	for idx, element := range p {
+		parts[idx] = element.String()
+	}
+
+	return "m/" + strings.Join(parts, "/")
+}
+
+// DefaultAllowedPaths returns the derivation path patterns of all keys a
+// watch-only lnd node using the given coin type for its internal keys needs
+// signatures for: the keys of lnd's internal key families and the keys of the
+// default on-chain wallet accounts.
+func DefaultAllowedPaths(coinType uint32) []string {
+	paths := []string{
+		fmt.Sprintf("m/%d'/%d'/*'/0/*", keychain.BIP0043Purpose,
+			coinType),
+	}
+
+	// The btcwallet always uses the coin type 0 for the keys of its
+	// on-chain accounts.
+	for _, scope := range []waddrmgr.KeyScope{
+		waddrmgr.KeyScopeBIP0049Plus, waddrmgr.KeyScopeBIP0084,
+		waddrmgr.KeyScopeBIP0086,
+	} {
+		paths = append(paths, fmt.Sprintf("m/%d'/%d'/*'/*/*",
+			scope.Purpose, scope.Coin))
+	}
+
+	return paths
+}
+
+// PathPolicy decides which keys the remote signer is allowed to sign with,
+// based on their derivation paths.
+type PathPolicy struct {
+	// coinType is the coin type of lnd's internal key families.
+	coinType uint32
+
+	// patterns is the list of allowed derivation path patterns.
+	patterns []PathPattern
+}
+
+// NewPathPolicy creates a new path policy that allows all paths matching any
+// of the given patterns. The coin type is used to map the key locators of
+// lnd's internal keys to their derivation paths.
+func NewPathPolicy(coinType uint32, patterns []string) (*PathPolicy, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("at least one allowed path pattern is " +
+			"required")
+	}
+
+	policy := &PathPolicy{
+		coinType: coinType,
+		patterns: make([]PathPattern, len(patterns)),
+	}
+	for idx, pattern := range patterns {
+		parsed, err := ParsePathPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.patterns[idx] = parsed
+	}
+
+	return policy, nil
+}
+
+// AllowedPaths returns the allowed derivation path patterns in their textual
+// representation.
+func (p *PathPolicy) AllowedPaths() []string {
+	paths := make([]string, len(p.patterns))
+	for idx, pattern := range p.patterns {
+		paths[idx] = pattern.String()
+	}
+
+	return paths
+}
+
+// CheckPath returns an error if the given derivation path isn't allowed.
+func (p *PathPolicy) CheckPath(path []uint32) error {
+	for _, pattern := range p.patterns {
+		if pattern.Matches(path) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("derivation path %v is not allowed",
+		formatPath(path))
+}
+
+// CheckKeyLocator returns an error if the key of lnd's internal key family
+// described by the given key locator isn't allowed.
+func (p *PathPolicy) CheckKeyLocator(keyLoc keychain.KeyLocator) error {
+	return p.CheckPath(p.keyLocatorPath(keyLoc))
+}
+
+// CheckKeyFamily returns an error if not all keys of the given key family of
+// lnd's internal keys are allowed. This is used for keys that are identified by
+// their public key only, in which case the index of the key isn't known up
+// front.
+func (p *PathPolicy) CheckKeyFamily(family keychain.KeyFamily) error {
+	path := p.keyLocatorPath(keychain.KeyLocator{Family: family})
+	for _, pattern := range p.patterns {
+		if !pattern.Matches(path) {
+			continue
+		}
+
+		// The pattern must match any index of the family, not just the
+		// first one.
+		if pattern[len(pattern)-1].matchAll {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("not all keys of key family %d are allowed",
+		family)
+}
+
+// keyLocatorPath returns the derivation path of the given key locator within
+// the key scope of lnd's internal keys.
+func (p *PathPolicy) keyLocatorPath(keyLoc keychain.KeyLocator) []uint32 {
+	return []uint32{
+		keychain.BIP0043Purpose + hdkeychain.HardenedKeyStart,
+		p.coinType + hdkeychain.HardenedKeyStart,
+		uint32(keyLoc.Family) + hdkeychain.HardenedKeyStart,
+		0,
+		keyLoc.Index,
+	}
+}
+
+// formatPath returns the textual representation of the given derivation path.
+func formatPath(path []uint32) string {
+	parts := make([]string, len(path))
+	for idx, index := range path {
+		if index >= hdkeychain.HardenedKeyStart {
+			parts[idx] = fmt.Sprintf("%d'",
+				index-hdkeychain.HardenedKeyStart)
+			continue
+		}
+
+		parts[idx] = strconv.FormatUint(uint64(index), 10)
+	}
+
+	return "m/" + strings.Join(parts, "/")
+}
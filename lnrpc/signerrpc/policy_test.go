@@ -0,0 +1,149 @@
+package signerrpc
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+const h = hdkeychain.HardenedKeyStart
+
+// TestParsePathPattern tests that path patterns are parsed correctly and
+// rejected if they are malformed.
+func TestParsePathPattern(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		pattern string
+		err     string
+	}{{
+		name:    "fixed path",
+		pattern: "m/84'/0'/0'/0/0",
+	}, {
+		name:    "wildcards",
+		pattern: "m/1017'/1'/*'/0/*",
+	}, {
+		name:    "missing prefix",
+		pattern: "84'/0'/0'/0/0",
+		err:     "must start with m/",
+	}, {
+		name:    "invalid element",
+		pattern: "m/84'/x/0'",
+		err:     "invalid element \"x\"",
+	}, {
+		name:    "index out of range",
+		pattern: "m/2147483648",
+		err:     "invalid element \"2147483648\"",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pattern, err := ParsePathPattern(tc.pattern)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.pattern, pattern.String())
+		})
+	}
+}
+
+// TestPathPatternMatches tests that path patterns only match the paths they
+// describe.
+func TestPathPatternMatches(t *testing.T) {
+	t.Parallel()
+
+	pattern, err := ParsePathPattern("m/84'/0'/*'/*/*")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		path    []uint32
+		matches bool
+	}{{
+		name:    "first key",
+		path:    []uint32{84 + h, h, h, 0, 0},
+		matches: true,
+	}, {
+		name:    "change key of other account",
+		path:    []uint32{84 + h, h, 3 + h, 1, 1234},
+		matches: true,
+	}, {
+		name: "different purpose",
+		path: []uint32{86 + h, h, h, 0, 0},
+	}, {
+		name: "unhardened account",
+		path: []uint32{84 + h, h, 0, 0, 0},
+	}, {
+		name: "hardened index",
+		path: []uint32{84 + h, h, h, 0, h},
+	}, {
+		name: "too short",
+		path: []uint32{84 + h, h, h, 0},
+	}, {
+		name: "too long",
+		path: []uint32{84 + h, h, h, 0, 0, 0},
+	}}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.matches, pattern.Matches(tc.path), tc.name)
+	}
+}
+
+// TestPathPolicy tests that the path policy allows exactly the keys matching
+// its patterns.
+func TestPathPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPathPolicy(1, nil)
+	require.Error(t, err)
+
+	policy, err := NewPathPolicy(1, DefaultAllowedPaths(1))
+	require.NoError(t, err)
+	require.Equal(t, DefaultAllowedPaths(1), policy.AllowedPaths())
+
+	// All of lnd's internal keys and the keys of the default on-chain
+	// accounts are allowed by default.
+	require.NoError(t, policy.CheckKeyLocator(keychain.KeyLocator{
+		Family: keychain.KeyFamilyNodeKey,
+	}))
+	require.NoError(t, policy.CheckKeyLocator(keychain.KeyLocator{
+		Family: keychain.KeyFamilyRevocationBase,
+		Index:  42,
+	}))
+	require.NoError(t, policy.CheckKeyFamily(keychain.KeyFamilyMultiSig))
+	require.NoError(t, policy.CheckPath([]uint32{86 + h, h, h, 1, 7}))
+
+	// Other coin types, purposes or the external branch of lnd's key
+	// families aren't.
+	require.Error(t, policy.CheckPath([]uint32{1017 + h, h, h, 0, 0}))
+	require.Error(t, policy.CheckPath([]uint32{44 + h, h, h, 0, 0}))
+	require.Error(t, policy.CheckPath([]uint32{1017 + h, 1 + h, h, 1, 0}))
+
+	// A policy that only allows a single key of a family allows signing
+	// with that key through its key locator but doesn't allow scanning
+	// the whole family for it.
+	policy, err = NewPathPolicy(1, []string{"m/1017'/1'/6'/0/0"})
+	require.NoError(t, err)
+
+	nodeKey := keychain.KeyLocator{Family: keychain.KeyFamilyNodeKey}
+	require.NoError(t, policy.CheckKeyLocator(nodeKey))
+	require.Error(t, policy.CheckKeyLocator(keychain.KeyLocator{
+		Family: keychain.KeyFamilyNodeKey,
+		Index:  1,
+	}))
+	require.Error(t, policy.CheckKeyFamily(keychain.KeyFamilyNodeKey))
+	require.ErrorContains(
+		t, policy.CheckPath([]uint32{84 + h, h, h, 0, 0}),
+		"derivation path m/84'/0'/0'/0/0 is not allowed",
+	)
+}
@@ -0,0 +1,513 @@
+package signerrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnwallet/signpolicy"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PsbtSigner is the interface of the wallet component that signs PSBT inputs
+// using the BIP32 derivation information attached to them.
+type PsbtSigner interface {
+	// SignPsbt signs all inputs of the given packet that have witness UTXO
+	// and BIP32 derivation information attached and returns the indexes of
+	// the inputs it signed.
+	SignPsbt(packet *psbt.Packet) ([]uint32, error)
+}
+
+// KeyRing is the interface of the key ring component that uses lnd's internal
+// keys for ECDH and message signing.
+type KeyRing interface {
+	keychain.ECDHRing
+	keychain.MessageSignerRing
+}
+
+// Config is the set of dependencies the remote signer RPC server needs.
+type Config struct {
+	// Network is the name of the network the signer holds keys for.
+	Network string
+
+	// Policy decides which keys the signer is allowed to sign with.
+	Policy *PathPolicy
+
+	// PsbtSigner is used to sign PSBTs.
+	PsbtSigner PsbtSigner
+
+	// KeyRing is used for ECDH and message signing.
+	KeyRing KeyRing
+
+	// MuSig2Signer is used for MuSig2 signing sessions.
+	MuSig2Signer input.MuSig2Signer
+
+	// SignPolicy decides whether a PSBT spending funds of the on-chain
+	// wallet may be signed. A nil policy allows everything.
+	SignPolicy *signpolicy.Policy
+}
+
+// ServerShell is a shell struct holding a reference to the actual server. It is
+// used to register the gRPC service with the root server before we have the
+// necessary dependencies to populate the actual server.
+type ServerShell struct {
+	RemoteSignerServer
+}
+
+// Server is the remote signer RPC server. It only signs with keys whose
+// derivation paths are allowed by its policy and doesn't need any knowledge
+// about the channels or the wallet state of the node it signs for.
+type Server struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	UnimplementedRemoteSignerServer
+
+	cfg *Config
+}
+
+// A compile time check to ensure that Server fully implements the
+// RemoteSignerServer gRPC service.
+var _ RemoteSignerServer = (*Server)(nil)
+
+// New returns a new instance of the remote signer RPC server.
+func New(cfg *Config) (*Server, error) {
+	switch {
+	case cfg.Policy == nil:
+		return nil, fmt.Errorf("policy must be set")
+
+	case cfg.PsbtSigner == nil || cfg.KeyRing == nil ||
+		cfg.MuSig2Signer == nil:
+
+		return nil, fmt.Errorf("signing backends must be set")
+	}
+
+	return &Server{
+		cfg: cfg,
+	}, nil
+}
+
+// GetInfo returns the network the signer holds keys for and the derivation
+// paths it is willing to sign with.
+func (s *Server) GetInfo(_ context.Context,
+	_ *GetInfoRequest) (*GetInfoResponse, error) {
+
+	return &GetInfoResponse{
+		Network:      s.cfg.Network,
+		AllowedPaths: s.cfg.Policy.AllowedPaths(),
+	}, nil
+}
+
+// SignPsbt signs all inputs of the given PSBT that have BIP32 derivation
+// information and witness UTXO information attached. The request is rejected
+// if any input references a derivation path that isn't allowed.
+func (s *Server) SignPsbt(_ context.Context,
+	in *SignPsbtRequest) (*SignPsbtResponse, error) {
+
+	packet, err := psbt.NewFromRawBytes(
+		bytes.NewReader(in.FundedPsbt), false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PSBT: %w", err)
+	}
+
+	// We check the paths of exactly those inputs the PSBT signer will
+	// attempt to sign, which is the first derivation path of each input
+	// that has UTXO information and isn't finalized yet.
+	for idx, pIn := range packet.Inputs {
+		if pIn.WitnessUtxo == nil || len(pIn.FinalScriptWitness) > 0 ||
+			len(pIn.Bip32Derivation) == 0 {
+
+			continue
+		}
+
+		path := pIn.Bip32Derivation[0].Bip32Path
+		if err := s.cfg.Policy.CheckPath(path); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied,
+				"input %d: %v", idx, err)
+		}
+	}
+
+	if err := s.cfg.SignPolicy.CheckPsbt(packet); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	signedInputs, err := s.cfg.PsbtSigner.SignPsbt(packet)
+	if err != nil {
+		return nil, fmt.Errorf("error signing PSBT: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := packet.Serialize(&buf); err != nil {
+		return nil, fmt.Errorf("error serializing PSBT: %w", err)
+	}
+
+	log.Debugf("Signed %d input(s) of PSBT for transaction %v",
+		len(signedInputs), packet.UnsignedTx.TxHash())
+
+	return &SignPsbtResponse{
+		SignedPsbt:   buf.Bytes(),
+		SignedInputs: signedInputs,
+	}, nil
+}
+
+// DeriveSharedKey returns a shared secret key by performing Diffie-Hellman key
+// derivation between the ephemeral public key in the request and the key
+// specified in the key_desc parameter.
+func (s *Server) DeriveSharedKey(_ context.Context,
+	in *signrpc.SharedKeyRequest) (*signrpc.SharedKeyResponse, error) {
+
+	ephemeralPubkey, err := signrpc.ParseRawKeyBytes(in.EphemeralPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("error in ephemeral pubkey: %w", err)
+	}
+	if ephemeralPubkey == nil {
+		return nil, fmt.Errorf("must provide ephemeral pubkey")
+	}
+
+	// Unlike the signer RPC, we don't accept the deprecated key_loc field
+	// or default to the node key, the key must always be described
+	// explicitly.
+	if in.KeyDesc == nil || in.KeyDesc.KeyLoc == nil {
+		return nil, fmt.Errorf("key_desc.key_loc must be set")
+	}
+
+	pubKey, err := signrpc.ParseRawKeyBytes(in.KeyDesc.RawKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error in raw pubkey: %w", err)
+	}
+
+	keyDesc := keychain.KeyDescriptor{
+		KeyLocator: unmarshalKeyLocator(in.KeyDesc.KeyLoc),
+		PubKey:     pubKey,
+	}
+
+	// A key that is identified by its public key is found by scanning its
+	// key family, so all keys of the family must be allowed.
+	if pubKey != nil {
+		if keyDesc.Index != 0 {
+			return nil, fmt.Errorf("use either raw_key_bytes or " +
+				"key_index")
+		}
+
+		err = s.cfg.Policy.CheckKeyFamily(keyDesc.Family)
+	} else {
+		err = s.cfg.Policy.CheckKeyLocator(keyDesc.KeyLocator)
+	}
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	sharedKey, err := s.cfg.KeyRing.ECDH(keyDesc, ephemeralPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive shared key: %w", err)
+	}
+
+	return &signrpc.SharedKeyResponse{SharedKey: sharedKey[:]}, nil
+}
+
+// SignMessage signs a message with the key specified in the key locator.
+func (s *Server) SignMessage(_ context.Context,
+	in *signrpc.SignMessageReq) (*signrpc.SignMessageResp, error) {
+
+	switch {
+	case in.Msg == nil:
+		return nil, fmt.Errorf("a message to sign MUST be passed in")
+
+	case in.KeyLoc == nil:
+		return nil, fmt.Errorf("a key locator MUST be passed in")
+
+	case in.SchnorrSig && in.CompactSig:
+		return nil, fmt.Errorf("compact format can not be used for " +
+			"Schnorr signatures")
+
+	case !in.SchnorrSig && len(in.Tag) > 0:
+		return nil, fmt.Errorf("tag can only be used when the " +
+			"Schnorr signature option is set")
+
+	case bytes.HasPrefix(in.Tag, []byte(signrpc.BIP0340)):
+		return nil, fmt.Errorf("tag cannot have BIP0340 prefix")
+
+	case bytes.HasPrefix(in.Tag, chainhash.TagTapSighash):
+		return nil, fmt.Errorf("tag cannot be TapSighash")
+
+	case in.DoubleHash && len(in.Tag) > 0:
+		return nil, fmt.Errorf("double hash and tag can't be set at " +
+			"the same time")
+	}
+
+	keyLoc := unmarshalKeyLocator(in.KeyLoc)
+	err := s.cfg.Policy.CheckKeyLocator(keyLoc)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	var sigBytes []byte
+	switch {
+	case in.SchnorrSig:
+		sig, err := s.cfg.KeyRing.SignMessageSchnorr(
+			keyLoc, in.Msg, in.DoubleHash, in.SchnorrSigTapTweak,
+			in.Tag,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't sign the hash: %w", err)
+		}
+		sigBytes = sig.Serialize()
+
+	case in.CompactSig:
+		sigBytes, err = s.cfg.KeyRing.SignMessageCompact(
+			keyLoc, in.Msg, in.DoubleHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't sign the hash: %w", err)
+		}
+
+	default:
+		sig, err := s.cfg.KeyRing.SignMessage(
+			keyLoc, in.Msg, in.DoubleHash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't sign the hash: %w", err)
+		}
+
+		wireSig, err := lnwire.NewSigFromSignature(sig)
+		if err != nil {
+			return nil, fmt.Errorf("can't convert to wire "+
+				"format: %w", err)
+		}
+		sigBytes = wireSig.ToSignatureBytes()
+	}
+
+	return &signrpc.SignMessageResp{
+		Signature: sigBytes,
+	}, nil
+}
+
+// MuSig2CreateSession creates a new MuSig2 signing session using the local key
+// identified by the key locator.
+func (s *Server) MuSig2CreateSession(_ context.Context,
+	in *signrpc.MuSig2SessionRequest) (*signrpc.MuSig2SessionResponse,
+	error) {
+
+	version, err := signrpc.UnmarshalMuSig2Version(in.Version)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing version: %w", err)
+	}
+
+	if in.KeyLoc == nil {
+		return nil, fmt.Errorf("missing key_loc")
+	}
+	keyLoc := unmarshalKeyLocator(in.KeyLoc)
+	err = s.cfg.Policy.CheckKeyLocator(keyLoc)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	allSignerPubKeys, err := input.MuSig2ParsePubKeys(
+		version, in.AllSignerPubkeys,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing all signer public "+
+			"keys: %w", err)
+	}
+
+	// We participate a nonce ourselves, so we can't have more nonces than
+	// the total number of participants minus ourselves.
+	maxNonces := len(in.AllSignerPubkeys) - 1
+	if len(in.OtherSignerPublicNonces) > maxNonces {
+		return nil, fmt.Errorf("too many other signer public nonces, "+
+			"got %d but expected a maximum of %d",
+			len(in.OtherSignerPublicNonces), maxNonces)
+	}
+
+	var localNonces *musig2.Nonces
+	nonceLen := len(in.PregeneratedLocalNonce)
+	switch {
+	case nonceLen != 0 && nonceLen != musig2.SecNonceSize:
+		return nil, fmt.Errorf("local nonces must be %v bytes, "+
+			"instead was %v", musig2.SecNonceSize, nonceLen)
+
+	case nonceLen == musig2.SecNonceSize:
+		var secNonce [musig2.SecNonceSize]byte
+		copy(secNonce[:], in.PregeneratedLocalNonce)
+
+		localNonces = &musig2.Nonces{
+			SecNonce: secNonce,
+			PubNonce: signrpc.SecNonceToPubNonce(secNonce),
+		}
+	}
+
+	otherSignerNonces, err := signrpc.ParseMuSig2PublicNonces(
+		in.OtherSignerPublicNonces, true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing other nonces: %w", err)
+	}
+
+	tweaks, err := signrpc.UnmarshalTweaks(in.Tweaks, in.TaprootTweak)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling tweak options: %w",
+			err)
+	}
+
+	session, err := s.cfg.MuSig2Signer.MuSig2CreateSession(
+		version, keyLoc, allSignerPubKeys, tweaks, otherSignerNonces,
+		localNonces,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error registering session: %w", err)
+	}
+
+	var internalKeyBytes []byte
+	if session.TaprootTweak {
+		internalKeyBytes = schnorr.SerializePubKey(
+			session.TaprootInternalKey,
+		)
+	}
+
+	return &signrpc.MuSig2SessionResponse{
+		SessionId: session.SessionID[:],
+		CombinedKey: schnorr.SerializePubKey(
+			session.CombinedKey,
+		),
+		TaprootInternalKey: internalKeyBytes,
+		LocalPublicNonces:  session.PublicNonce[:],
+		HaveAllNonces:      session.HaveAllNonces,
+		Version:            in.Version,
+	}, nil
+}
+
+// MuSig2RegisterNonces registers one or more public nonces of other signing
+// participants for a session identified by its ID.
+func (s *Server) MuSig2RegisterNonces(_ context.Context,
+	in *signrpc.MuSig2RegisterNoncesRequest) (
+	*signrpc.MuSig2RegisterNoncesResponse, error) {
+
+	sessionID, err := signrpc.ParseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing session ID: %w", err)
+	}
+
+	otherSignerNonces, err := signrpc.ParseMuSig2PublicNonces(
+		in.OtherSignerPublicNonces, false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing other nonces: %w", err)
+	}
+
+	haveAllNonces, err := s.cfg.MuSig2Signer.MuSig2RegisterNonces(
+		sessionID, otherSignerNonces,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error registering nonces: %w", err)
+	}
+
+	return &signrpc.MuSig2RegisterNoncesResponse{
+		HaveAllNonces: haveAllNonces,
+	}, nil
+}
+
+// MuSig2Sign creates a partial signature using the local signing key that was
+// specified when the session was created.
+func (s *Server) MuSig2Sign(_ context.Context,
+	in *signrpc.MuSig2SignRequest) (*signrpc.MuSig2SignResponse, error) {
+
+	sessionID, err := signrpc.ParseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing session ID: %w", err)
+	}
+
+	// Schnorr signatures only work reliably if the message is 32 bytes.
+	msg := [sha256.Size]byte{}
+	if len(in.MessageDigest) != sha256.Size {
+		return nil, fmt.Errorf("invalid message digest size, got %d "+
+			"but expected %d", len(in.MessageDigest), sha256.Size)
+	}
+	copy(msg[:], in.MessageDigest)
+
+	partialSig, err := s.cfg.MuSig2Signer.MuSig2Sign(
+		sessionID, msg, in.Cleanup,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error signing: %w", err)
+	}
+
+	serializedPartialSig, err := input.SerializePartialSignature(partialSig)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing sig: %w", err)
+	}
+
+	return &signrpc.MuSig2SignResponse{
+		LocalPartialSignature: serializedPartialSig[:],
+	}, nil
+}
+
+// MuSig2CombineSig combines the given partial signature(s) with the local one,
+// if it already exists.
+func (s *Server) MuSig2CombineSig(_ context.Context,
+	in *signrpc.MuSig2CombineSigRequest) (
+	*signrpc.MuSig2CombineSigResponse, error) {
+
+	sessionID, err := signrpc.ParseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing session ID: %w", err)
+	}
+
+	partialSigs, err := signrpc.ParseMuSig2PartialSignatures(
+		in.OtherPartialSignatures,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing partial signatures: %w",
+			err)
+	}
+
+	finalSig, haveAllSigs, err := s.cfg.MuSig2Signer.MuSig2CombineSig(
+		sessionID, partialSigs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error combining signatures: %w", err)
+	}
+
+	resp := &signrpc.MuSig2CombineSigResponse{
+		HaveAllSignatures: haveAllSigs,
+	}
+	if haveAllSigs {
+		resp.FinalSignature = finalSig.Serialize()
+	}
+
+	return resp, nil
+}
+
+// MuSig2Cleanup removes a session from memory to free up resources.
+func (s *Server) MuSig2Cleanup(_ context.Context,
+	in *signrpc.MuSig2CleanupRequest) (*signrpc.MuSig2CleanupResponse,
+	error) {
+
+	sessionID, err := signrpc.ParseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing session ID: %w", err)
+	}
+
+	err = s.cfg.MuSig2Signer.MuSig2Cleanup(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("error cleaning up session: %w", err)
+	}
+
+	return &signrpc.MuSig2CleanupResponse{}, nil
+}
+
+// unmarshalKeyLocator parses the RPC key locator into its native counterpart.
+func unmarshalKeyLocator(keyLoc *signrpc.KeyLocator) keychain.KeyLocator {
+	return keychain.KeyLocator{
+		Family: keychain.KeyFamily(keyLoc.KeyFamily),
+		Index:  uint32(keyLoc.KeyIndex),
+	}
+}
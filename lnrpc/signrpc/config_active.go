@@ -6,6 +6,7 @@ package signrpc
 import (
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet/signpolicy"
 	"github.com/lightningnetwork/lnd/macaroons"
 )
 
@@ -36,4 +37,8 @@ type Config struct {
 	// KeyRing is an interface that the signer will use to derive any keys
 	// for signing messages.
 	KeyRing keychain.SecretKeyRing
+
+	// SignPolicy is the policy that decides whether a transaction spending
+	// funds of the wallet may be signed. A nil policy allows everything.
+	SignPolicy *signpolicy.Policy
 }
@@ -118,6 +118,50 @@ type SignerClient interface {
 	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
 	// releases. Backward compatibility is not guaranteed!
 	MuSig2Cleanup(ctx context.Context, in *MuSig2CleanupRequest, opts ...grpc.CallOption) (*MuSig2CleanupResponse, error)
+	// MuSig2RegisterNonceCommitments (experimental!) registers the commitments to
+	// the public nonces of all other signing participants of a session. This
+	// allows all participants to commit to their nonces before any of them is
+	// revealed. Once the commitments are registered, MuSig2RegisterNonces only
+	// accepts nonces that match one of them. The commitment of the local nonce is
+	// returned when creating the session.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2RegisterNonceCommitments(ctx context.Context, in *MuSig2RegisterNonceCommitmentsRequest, opts ...grpc.CallOption) (*MuSig2RegisterNonceCommitmentsResponse, error)
+	// MuSig2CombineAdaptorSig (experimental!) combines the given partial
+	// signature(s) of a session that was created with an adaptor point with the
+	// local one, which must already exist. Once a partial signature of all
+	// participants is registered, the adaptor signature will be combined,
+	// verified and returned.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2CombineAdaptorSig(ctx context.Context, in *MuSig2CombineAdaptorSigRequest, opts ...grpc.CallOption) (*MuSig2CombineAdaptorSigResponse, error)
+	// MuSig2AdaptSignature (experimental!) turns an adaptor signature into a
+	// final, full signature by adding the secret of the adaptor point to it.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2AdaptSignature(ctx context.Context, in *MuSig2AdaptSignatureRequest, opts ...grpc.CallOption) (*MuSig2AdaptSignatureResponse, error)
+	// MuSig2VerifyAdaptorSignature (experimental!) verifies that an adaptor
+	// signature becomes a valid signature for the combined key once it is adapted
+	// with the secret of the given adaptor point.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2VerifyAdaptorSignature(ctx context.Context, in *MuSig2VerifyAdaptorSignatureRequest, opts ...grpc.CallOption) (*MuSig2VerifyAdaptorSignatureResponse, error)
+	// MuSig2ExtractAdaptorSecret (experimental!) extracts the secret of the
+	// adaptor point from an adaptor signature and the final signature that was
+	// created by adapting it.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2ExtractAdaptorSecret(ctx context.Context, in *MuSig2ExtractAdaptorSecretRequest, opts ...grpc.CallOption) (*MuSig2ExtractAdaptorSecretResponse, error)
 }
 
 type signerClient struct {
@@ -227,6 +271,51 @@ func (c *signerClient) MuSig2Cleanup(ctx context.Context, in *MuSig2CleanupReque
 	return out, nil
 }
 
+func (c *signerClient) MuSig2RegisterNonceCommitments(ctx context.Context, in *MuSig2RegisterNonceCommitmentsRequest, opts ...grpc.CallOption) (*MuSig2RegisterNonceCommitmentsResponse, error) {
+	out := new(MuSig2RegisterNonceCommitmentsResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2RegisterNonceCommitments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2CombineAdaptorSig(ctx context.Context, in *MuSig2CombineAdaptorSigRequest, opts ...grpc.CallOption) (*MuSig2CombineAdaptorSigResponse, error) {
+	out := new(MuSig2CombineAdaptorSigResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2CombineAdaptorSig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2AdaptSignature(ctx context.Context, in *MuSig2AdaptSignatureRequest, opts ...grpc.CallOption) (*MuSig2AdaptSignatureResponse, error) {
+	out := new(MuSig2AdaptSignatureResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2AdaptSignature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2VerifyAdaptorSignature(ctx context.Context, in *MuSig2VerifyAdaptorSignatureRequest, opts ...grpc.CallOption) (*MuSig2VerifyAdaptorSignatureResponse, error) {
+	out := new(MuSig2VerifyAdaptorSignatureResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2VerifyAdaptorSignature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2ExtractAdaptorSecret(ctx context.Context, in *MuSig2ExtractAdaptorSecretRequest, opts ...grpc.CallOption) (*MuSig2ExtractAdaptorSecretResponse, error) {
+	out := new(MuSig2ExtractAdaptorSecretResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2ExtractAdaptorSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SignerServer is the server API for Signer service.
 // All implementations must embed UnimplementedSignerServer
 // for forward compatibility
@@ -331,6 +420,50 @@ type SignerServer interface {
 	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
 	// releases. Backward compatibility is not guaranteed!
 	MuSig2Cleanup(context.Context, *MuSig2CleanupRequest) (*MuSig2CleanupResponse, error)
+	// MuSig2RegisterNonceCommitments (experimental!) registers the commitments to
+	// the public nonces of all other signing participants of a session. This
+	// allows all participants to commit to their nonces before any of them is
+	// revealed. Once the commitments are registered, MuSig2RegisterNonces only
+	// accepts nonces that match one of them. The commitment of the local nonce is
+	// returned when creating the session.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2RegisterNonceCommitments(context.Context, *MuSig2RegisterNonceCommitmentsRequest) (*MuSig2RegisterNonceCommitmentsResponse, error)
+	// MuSig2CombineAdaptorSig (experimental!) combines the given partial
+	// signature(s) of a session that was created with an adaptor point with the
+	// local one, which must already exist. Once a partial signature of all
+	// participants is registered, the adaptor signature will be combined,
+	// verified and returned.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2CombineAdaptorSig(context.Context, *MuSig2CombineAdaptorSigRequest) (*MuSig2CombineAdaptorSigResponse, error)
+	// MuSig2AdaptSignature (experimental!) turns an adaptor signature into a
+	// final, full signature by adding the secret of the adaptor point to it.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2AdaptSignature(context.Context, *MuSig2AdaptSignatureRequest) (*MuSig2AdaptSignatureResponse, error)
+	// MuSig2VerifyAdaptorSignature (experimental!) verifies that an adaptor
+	// signature becomes a valid signature for the combined key once it is adapted
+	// with the secret of the given adaptor point.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2VerifyAdaptorSignature(context.Context, *MuSig2VerifyAdaptorSignatureRequest) (*MuSig2VerifyAdaptorSignatureResponse, error)
+	// MuSig2ExtractAdaptorSecret (experimental!) extracts the secret of the
+	// adaptor point from an adaptor signature and the final signature that was
+	// created by adapting it.
+	//
+	// NOTE: The MuSig2 BIP is not final yet and therefore this API must be
+	// considered to be HIGHLY EXPERIMENTAL and subject to change in upcoming
+	// releases. Backward compatibility is not guaranteed!
+	MuSig2ExtractAdaptorSecret(context.Context, *MuSig2ExtractAdaptorSecretRequest) (*MuSig2ExtractAdaptorSecretResponse, error)
 	mustEmbedUnimplementedSignerServer()
 }
 
@@ -371,6 +504,21 @@ func (UnimplementedSignerServer) MuSig2CombineSig(context.Context, *MuSig2Combin
 func (UnimplementedSignerServer) MuSig2Cleanup(context.Context, *MuSig2CleanupRequest) (*MuSig2CleanupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MuSig2Cleanup not implemented")
 }
+func (UnimplementedSignerServer) MuSig2RegisterNonceCommitments(context.Context, *MuSig2RegisterNonceCommitmentsRequest) (*MuSig2RegisterNonceCommitmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2RegisterNonceCommitments not implemented")
+}
+func (UnimplementedSignerServer) MuSig2CombineAdaptorSig(context.Context, *MuSig2CombineAdaptorSigRequest) (*MuSig2CombineAdaptorSigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2CombineAdaptorSig not implemented")
+}
+func (UnimplementedSignerServer) MuSig2AdaptSignature(context.Context, *MuSig2AdaptSignatureRequest) (*MuSig2AdaptSignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2AdaptSignature not implemented")
+}
+func (UnimplementedSignerServer) MuSig2VerifyAdaptorSignature(context.Context, *MuSig2VerifyAdaptorSignatureRequest) (*MuSig2VerifyAdaptorSignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2VerifyAdaptorSignature not implemented")
+}
+func (UnimplementedSignerServer) MuSig2ExtractAdaptorSecret(context.Context, *MuSig2ExtractAdaptorSecretRequest) (*MuSig2ExtractAdaptorSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2ExtractAdaptorSecret not implemented")
+}
 func (UnimplementedSignerServer) mustEmbedUnimplementedSignerServer() {}
 
 // UnsafeSignerServer may be embedded to opt out of forward compatibility for this service.
@@ -582,6 +730,96 @@ func _Signer_MuSig2Cleanup_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Signer_MuSig2RegisterNonceCommitments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2RegisterNonceCommitmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2RegisterNonceCommitments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2RegisterNonceCommitments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2RegisterNonceCommitments(ctx, req.(*MuSig2RegisterNonceCommitmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2CombineAdaptorSig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2CombineAdaptorSigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2CombineAdaptorSig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2CombineAdaptorSig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2CombineAdaptorSig(ctx, req.(*MuSig2CombineAdaptorSigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2AdaptSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2AdaptSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2AdaptSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2AdaptSignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2AdaptSignature(ctx, req.(*MuSig2AdaptSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2VerifyAdaptorSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2VerifyAdaptorSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2VerifyAdaptorSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2VerifyAdaptorSignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2VerifyAdaptorSignature(ctx, req.(*MuSig2VerifyAdaptorSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2ExtractAdaptorSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2ExtractAdaptorSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2ExtractAdaptorSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2ExtractAdaptorSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2ExtractAdaptorSecret(ctx, req.(*MuSig2ExtractAdaptorSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Signer_ServiceDesc is the grpc.ServiceDesc for Signer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -633,6 +871,26 @@ var Signer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MuSig2Cleanup",
 			Handler:    _Signer_MuSig2Cleanup_Handler,
 		},
+		{
+			MethodName: "MuSig2RegisterNonceCommitments",
+			Handler:    _Signer_MuSig2RegisterNonceCommitments_Handler,
+		},
+		{
+			MethodName: "MuSig2CombineAdaptorSig",
+			Handler:    _Signer_MuSig2CombineAdaptorSig_Handler,
+		},
+		{
+			MethodName: "MuSig2AdaptSignature",
+			Handler:    _Signer_MuSig2AdaptSignature_Handler,
+		},
+		{
+			MethodName: "MuSig2VerifyAdaptorSignature",
+			Handler:    _Signer_MuSig2VerifyAdaptorSignature_Handler,
+		},
+		{
+			MethodName: "MuSig2ExtractAdaptorSecret",
+			Handler:    _Signer_MuSig2ExtractAdaptorSecret_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "signrpc/signer.proto",
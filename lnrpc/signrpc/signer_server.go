@@ -33,9 +33,6 @@ const (
 	// SubServerConfigDispatcher instance recognize this as the name of the
 	// config file that we need.
 	subServerName = "SignRPC"
-
-	// BIP0340 is the prefix for BIP0340-related tagged hashes.
-	BIP0340 = "BIP0340"
 )
 
 var (
@@ -98,6 +95,26 @@ var (
 			Entity: "signer",
 			Action: "generate",
 		}},
+		"/signrpc.Signer/MuSig2RegisterNonceCommitments": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/MuSig2CombineAdaptorSig": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/MuSig2AdaptSignature": {{
+			Entity: "signer",
+			Action: "read",
+		}},
+		"/signrpc.Signer/MuSig2VerifyAdaptorSignature": {{
+			Entity: "signer",
+			Action: "read",
+		}},
+		"/signrpc.Signer/MuSig2ExtractAdaptorSecret": {{
+			Entity: "signer",
+			Action: "read",
+		}},
 	}
 
 	// DefaultSignerMacFilename is the default name of the signer macaroon
@@ -202,6 +219,14 @@ func (s *Server) Name() string {
 	return subServerName
 }
 
+// DefaultMacaroon returns the path of the signer macaroon file and the
+// operations it is baked with.
+//
+// NOTE: This is part of the lnrpc.DefaultMacaroonBaker interface.
+func (s *Server) DefaultMacaroon() (string, []bakery.Op) {
+	return s.cfg.SignerMacPath, macaroonOps
+}
+
 // RegisterWithRootServer will be called by the root gRPC server to direct a
 // sub RPC server to register itself with the main gRPC root server. Until this
 // is called, each sub-server won't be able to have
@@ -343,7 +368,7 @@ func (s *Server) SignOutputRaw(_ context.Context, in *SignReq) (*SignResp,
 		// If this method doesn't return nil, then we know that user is
 		// attempting to include a raw serialized pub key.
 		if keyDesc.GetRawKeyBytes() != nil {
-			targetPubKey, err = parseRawKeyBytes(
+			targetPubKey, err = ParseRawKeyBytes(
 				keyDesc.GetRawKeyBytes(),
 			)
 			if err != nil {
@@ -488,6 +513,39 @@ func (s *Server) SignOutputRaw(_ context.Context, in *SignReq) (*SignResp,
 	return resp, nil
 }
 
+// signReqPrevOutputs returns the previous outputs spent by the inputs of the
+// given transaction of a sign request, in the order of the inputs. They are
+// taken from the previous outputs of the request if it has one for every input,
+// and otherwise from the sign descriptors. Inputs that neither provide are
+// nil.
+func signReqPrevOutputs(txToSign *wire.MsgTx, in *SignReq) []*wire.TxOut {
+	prevOutputs := make([]*wire.TxOut, len(txToSign.TxIn))
+	if len(in.PrevOutputs) == len(prevOutputs) {
+		for idx, prevOut := range in.PrevOutputs {
+			prevOutputs[idx] = &wire.TxOut{
+				Value:    prevOut.Value,
+				PkScript: prevOut.PkScript,
+			}
+		}
+	}
+
+	for _, signDesc := range in.SignDescs {
+		idx := int(signDesc.InputIndex)
+		if signDesc.Output == nil || idx < 0 ||
+			idx >= len(prevOutputs) {
+
+			continue
+		}
+
+		prevOutputs[idx] = &wire.TxOut{
+			Value:    signDesc.Output.Value,
+			PkScript: signDesc.Output.PkScript,
+		}
+	}
+
+	return prevOutputs
+}
+
 // ComputeInputScript generates a complete InputIndex for the passed
 // transaction with the signature as defined within the passed SignDescriptor.
 // This method should be capable of generating the proper input script for both
@@ -522,6 +580,14 @@ func (s *Server) ComputeInputScript(ctx context.Context,
 		return nil, fmt.Errorf("unable to decode tx: %w", err)
 	}
 
+	// This call only signs for inputs of our on-chain wallet, so the signer
+	// policy needs to allow spending them.
+	prevOutputs := signReqPrevOutputs(&txToSign, in)
+	err := s.cfg.SignPolicy.CheckTx(&txToSign, prevOutputs)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		sigHashCache      = input.NewTxSigHashesV0Only(&txToSign)
 		prevOutputFetcher = txscript.NewMultiPrevOutFetcher(nil)
@@ -769,7 +835,7 @@ func (s *Server) DeriveSharedKey(_ context.Context, in *SharedKeyRequest) (
 	*SharedKeyResponse, error) {
 
 	// Check that EphemeralPubkey is valid.
-	ephemeralPubkey, err := parseRawKeyBytes(in.EphemeralPubkey)
+	ephemeralPubkey, err := ParseRawKeyBytes(in.EphemeralPubkey)
 	if err != nil {
 		return nil, fmt.Errorf("error in ephemeral pubkey: %w", err)
 	}
@@ -815,9 +881,9 @@ func (s *Server) DeriveSharedKey(_ context.Context, in *SharedKeyRequest) (
 	}
 
 	// Check the raw public key is valid. Notice that if the rawKeyBytes is
-	// empty, the parseRawKeyBytes won't return an error, a nil
+	// empty, the ParseRawKeyBytes won't return an error, a nil
 	// *btcec.PublicKey is returned instead.
-	pk, err := parseRawKeyBytes(rawKeyBytes)
+	pk, err := ParseRawKeyBytes(rawKeyBytes)
 	if err != nil {
 		return nil, fmt.Errorf("error in raw pubkey: %w", err)
 	}
@@ -901,41 +967,6 @@ func (s *Server) MuSig2CombineKeys(_ context.Context,
 	}, nil
 }
 
-// secNonceToPubNonce takes our two secret nonces, and produces their two
-// corresponding EC points, serialized in compressed format.
-//
-// NOTE: This was copied from btcsuite/btcec/musig2/nonces.go.
-func secNonceToPubNonce(secNonce [musig2.SecNonceSize]byte,
-) [musig2.PubNonceSize]byte {
-
-	var k1Mod, k2Mod btcec.ModNScalar
-	k1Mod.SetByteSlice(secNonce[:btcec.PrivKeyBytesLen])
-	k2Mod.SetByteSlice(secNonce[btcec.PrivKeyBytesLen:])
-
-	var r1, r2 btcec.JacobianPoint
-	btcec.ScalarBaseMultNonConst(&k1Mod, &r1)
-	btcec.ScalarBaseMultNonConst(&k2Mod, &r2)
-
-	// Next, we'll convert the key in jacobian format to a normal public
-	// key expressed in affine coordinates.
-	r1.ToAffine()
-	r2.ToAffine()
-	r1Pub := btcec.NewPublicKey(&r1.X, &r1.Y)
-	r2Pub := btcec.NewPublicKey(&r2.X, &r2.Y)
-
-	var pubNonce [musig2.PubNonceSize]byte
-
-	// The public nonces are serialized as: R1 || R2, where both keys are
-	// serialized in compressed format.
-	copy(pubNonce[:], r1Pub.SerializeCompressed())
-	copy(
-		pubNonce[btcec.PubKeyBytesLenCompressed:],
-		r2Pub.SerializeCompressed(),
-	)
-
-	return pubNonce
-}
-
 // MuSig2CreateSession creates a new MuSig2 signing session using the local
 // key identified by the key locator. The complete list of all public keys of
 // all signing parties must be provided, including the public key of the local
@@ -998,12 +1029,12 @@ func (s *Server) MuSig2CreateSession(_ context.Context,
 
 		localNonces = &musig2.Nonces{
 			SecNonce: secNonce,
-			PubNonce: secNonceToPubNonce(secNonce),
+			PubNonce: SecNonceToPubNonce(secNonce),
 		}
 	}
 
 	// Parse all other nonces we might already know.
-	otherSignerNonces, err := parseMuSig2PublicNonces(
+	otherSignerNonces, err := ParseMuSig2PublicNonces(
 		in.OtherSignerPublicNonces, true,
 	)
 	if err != nil {
@@ -1017,15 +1048,27 @@ func (s *Server) MuSig2CreateSession(_ context.Context,
 			err)
 	}
 
-	// Register the session with the internal wallet/signer now.
-	session, err := s.cfg.Signer.MuSig2CreateSession(
-		version, keyLoc, allSignerPubKeys, tweaks, otherSignerNonces,
-		localNonces,
-	)
+	// Register the session with the internal wallet/signer now. If an
+	// adaptor point is given, the session creates an adaptor signature,
+	// which only some signers support.
+	var session *input.MuSig2SessionInfo
+	if len(in.AdaptorPoint) > 0 {
+		session, err = s.createAdaptorSession(
+			version, keyLoc, allSignerPubKeys, tweaks,
+			otherSignerNonces, localNonces, in.AdaptorPoint,
+		)
+	} else {
+		session, err = s.cfg.Signer.MuSig2CreateSession(
+			version, keyLoc, allSignerPubKeys, tweaks,
+			otherSignerNonces, localNonces,
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error registering session: %w", err)
 	}
 
+	nonceCommitment := input.MuSig2NonceCommitment(session.PublicNonce)
+
 	var internalKeyBytes []byte
 	if session.TaprootTweak {
 		internalKeyBytes = schnorr.SerializePubKey(
@@ -1038,20 +1081,64 @@ func (s *Server) MuSig2CreateSession(_ context.Context,
 		CombinedKey: schnorr.SerializePubKey(
 			session.CombinedKey,
 		),
-		TaprootInternalKey: internalKeyBytes,
-		LocalPublicNonces:  session.PublicNonce[:],
-		HaveAllNonces:      session.HaveAllNonces,
-		Version:            in.Version,
+		TaprootInternalKey:   internalKeyBytes,
+		LocalPublicNonces:    session.PublicNonce[:],
+		HaveAllNonces:        session.HaveAllNonces,
+		Version:              in.Version,
+		LocalNonceCommitment: nonceCommitment[:],
 	}, nil
 }
 
+// createAdaptorSession creates a new MuSig2 signing session that produces an
+// adaptor signature for the given adaptor point.
+func (s *Server) createAdaptorSession(version input.MuSig2Version,
+	keyLoc keychain.KeyLocator, allSignerPubKeys []*btcec.PublicKey,
+	tweaks *input.MuSig2Tweaks,
+	otherSignerNonces [][musig2.PubNonceSize]byte,
+	localNonces *musig2.Nonces,
+	adaptorPointBytes []byte) (*input.MuSig2SessionInfo, error) {
+
+	adaptorSigner, err := s.adaptorSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	if version != input.MuSig2Version100RC2 {
+		return nil, fmt.Errorf("adaptor signatures are only " +
+			"supported for MuSig2 v1.0.0rc2")
+	}
+
+	adaptorPoint, err := btcec.ParsePubKey(adaptorPointBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing adaptor point: %w", err)
+	}
+
+	return adaptorSigner.MuSig2CreateAdaptorSession(
+		keyLoc, allSignerPubKeys, tweaks, otherSignerNonces,
+		localNonces, adaptorPoint,
+	)
+}
+
+// adaptorSigner returns the signer of the server if it supports MuSig2 adaptor
+// signatures and nonce commitments, which isn't the case in remote signing
+// mode.
+func (s *Server) adaptorSigner() (input.MuSig2AdaptorSigner, error) {
+	adaptorSigner, ok := s.cfg.Signer.(input.MuSig2AdaptorSigner)
+	if !ok {
+		return nil, fmt.Errorf("signer does not support MuSig2 " +
+			"adaptor signatures and nonce commitments")
+	}
+
+	return adaptorSigner, nil
+}
+
 // MuSig2RegisterNonces registers one or more public nonces of other signing
 // participants for a session identified by its ID.
 func (s *Server) MuSig2RegisterNonces(_ context.Context,
 	in *MuSig2RegisterNoncesRequest) (*MuSig2RegisterNoncesResponse, error) {
 
 	// Check session ID length.
-	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	sessionID, err := ParseMuSig2SessionID(in.SessionId)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing session ID: %w", err)
 	}
@@ -1062,7 +1149,7 @@ func (s *Server) MuSig2RegisterNonces(_ context.Context,
 	// register more nonces than there are signers (which would mean
 	// something is wrong in the signing setup). But we want at least a
 	// single nonce for each call.
-	otherSignerNonces, err := parseMuSig2PublicNonces(
+	otherSignerNonces, err := ParseMuSig2PublicNonces(
 		in.OtherSignerPublicNonces, false,
 	)
 	if err != nil {
@@ -1090,7 +1177,7 @@ func (s *Server) MuSig2Sign(_ context.Context,
 	in *MuSig2SignRequest) (*MuSig2SignResponse, error) {
 
 	// Check session ID length.
-	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	sessionID, err := ParseMuSig2SessionID(in.SessionId)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing session ID: %w", err)
 	}
@@ -1126,7 +1213,7 @@ func (s *Server) MuSig2CombineSig(_ context.Context,
 	in *MuSig2CombineSigRequest) (*MuSig2CombineSigResponse, error) {
 
 	// Check session ID length.
-	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	sessionID, err := ParseMuSig2SessionID(in.SessionId)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing session ID: %w", err)
 	}
@@ -1134,7 +1221,7 @@ func (s *Server) MuSig2CombineSig(_ context.Context,
 	// Parse all other signatures. This can be called multiple times, so we
 	// can't really sanity check how many we already have vs. how many the
 	// user supplied in this call.
-	partialSigs, err := parseMuSig2PartialSignatures(
+	partialSigs, err := ParseMuSig2PartialSignatures(
 		in.OtherPartialSignatures,
 	)
 	if err != nil {
@@ -1167,7 +1254,7 @@ func (s *Server) MuSig2Cleanup(_ context.Context,
 	in *MuSig2CleanupRequest) (*MuSig2CleanupResponse, error) {
 
 	// Check session ID length.
-	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	sessionID, err := ParseMuSig2SessionID(in.SessionId)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing session ID: %w", err)
 	}
@@ -1180,135 +1267,194 @@ func (s *Server) MuSig2Cleanup(_ context.Context,
 	return &MuSig2CleanupResponse{}, nil
 }
 
-// parseRawKeyBytes checks that the provided raw public key is valid and returns
-// the public key. A nil public key is returned if the length of the rawKeyBytes
-// is zero.
-func parseRawKeyBytes(rawKeyBytes []byte) (*btcec.PublicKey, error) {
-	switch {
-	case len(rawKeyBytes) == 33:
-		// If a proper raw key was provided, then we'll attempt
-		// to decode and parse it.
-		return btcec.ParsePubKey(rawKeyBytes)
+// MuSig2RegisterNonceCommitments registers the commitments to the public
+// nonces of all other signing participants of a session. Once the commitments
+// are registered, only nonces that match one of them are accepted.
+func (s *Server) MuSig2RegisterNonceCommitments(_ context.Context,
+	in *MuSig2RegisterNonceCommitmentsRequest) (
+	*MuSig2RegisterNonceCommitmentsResponse, error) {
 
-	case len(rawKeyBytes) == 0:
-		// No key is provided, return nil.
-		return nil, nil
+	adaptorSigner, err := s.adaptorSigner()
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		// If the user provided a raw key, but it's of the
-		// wrong length, then we'll return with an error.
-		return nil, fmt.Errorf("pubkey must be " +
-			"serialized in compressed format if " +
-			"specified")
+	// Check session ID length.
+	sessionID, err := ParseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing session ID: %w", err)
 	}
-}
 
-// parseMuSig2SessionID parses a MuSig2 session ID from a raw byte slice.
-func parseMuSig2SessionID(rawID []byte) (input.MuSig2SessionID, error) {
-	sessionID := input.MuSig2SessionID{}
+	commitments := make(
+		[][sha256.Size]byte, len(in.OtherSignerNonceCommitments),
+	)
+	for idx, commitment := range in.OtherSignerNonceCommitments {
+		if len(commitment) != sha256.Size {
+			return nil, fmt.Errorf("invalid nonce commitment "+
+				"length at index %d, got %d but expected %d",
+				idx, len(commitment), sha256.Size)
+		}
+		copy(commitments[idx][:], commitment)
+	}
 
-	// The session ID must be exact in its length.
-	if len(rawID) != sha256.Size {
-		return sessionID, fmt.Errorf("invalid session ID size, got "+
-			"%d but expected %d", len(rawID), sha256.Size)
+	err = adaptorSigner.MuSig2RegisterNonceCommitments(
+		sessionID, commitments,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error registering nonce commitments: "+
+			"%w", err)
 	}
-	copy(sessionID[:], rawID)
 
-	return sessionID, nil
+	return &MuSig2RegisterNonceCommitmentsResponse{}, nil
 }
 
-// parseMuSig2PublicNonces sanity checks and parses the other signers' public
-// nonces.
-func parseMuSig2PublicNonces(pubNonces [][]byte,
-	emptyAllowed bool) ([][musig2.PubNonceSize]byte, error) {
+// MuSig2CombineAdaptorSig combines the given partial signature(s) of an adaptor
+// session with the local one, which must already exist. Once a partial
+// signature of all participants is registered, the adaptor signature will be
+// combined, verified and returned.
+func (s *Server) MuSig2CombineAdaptorSig(_ context.Context,
+	in *MuSig2CombineAdaptorSigRequest) (*MuSig2CombineAdaptorSigResponse,
+	error) {
 
-	// For some calls the nonces are optional while for others it doesn't
-	// make any sense to not specify them (for example for the explicit
-	// nonce registration call there should be at least one nonce).
-	if !emptyAllowed && len(pubNonces) == 0 {
-		return nil, fmt.Errorf("at least one other signer public " +
-			"nonce is required")
+	adaptorSigner, err := s.adaptorSigner()
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse all other nonces. This can be called multiple times, so we
-	// can't really sanity check how many we already have vs. how many the
-	// user supplied in this call.
-	otherSignerNonces := make([][musig2.PubNonceSize]byte, len(pubNonces))
-	for idx, otherNonceBytes := range pubNonces {
-		if len(otherNonceBytes) != musig2.PubNonceSize {
-			return nil, fmt.Errorf("invalid public nonce at "+
-				"index %d: invalid length, got %d but "+
-				"expected %d", idx, len(otherNonceBytes),
-				musig2.PubNonceSize)
-		}
-		copy(otherSignerNonces[idx][:], otherNonceBytes)
+	// Check session ID length.
+	sessionID, err := ParseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing session ID: %w", err)
 	}
 
-	return otherSignerNonces, nil
-}
+	partialSigs, err := ParseMuSig2PartialSignatures(
+		in.OtherPartialSignatures,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing partial signatures: %w",
+			err)
+	}
 
-// parseMuSig2PartialSignatures sanity checks and parses the other signers'
-// partial signatures.
-func parseMuSig2PartialSignatures(
-	partialSignatures [][]byte) ([]*musig2.PartialSignature, error) {
+	adaptorSig, haveAllSigs, err := adaptorSigner.MuSig2CombineAdaptorSig(
+		sessionID, partialSigs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error combining signatures: %w", err)
+	}
+
+	resp := &MuSig2CombineAdaptorSigResponse{
+		HaveAllSignatures: haveAllSigs,
+	}
 
-	// We always want at least one partial signature.
-	if len(partialSignatures) == 0 {
-		return nil, fmt.Errorf("at least one partial signature is " +
-			"required")
+	if haveAllSigs {
+		sigBytes := adaptorSig.Serialize()
+		resp.AdaptorSignature = sigBytes[:]
 	}
 
-	parsedPartialSigs := make(
-		[]*musig2.PartialSignature, len(partialSignatures),
+	return resp, nil
+}
+
+// MuSig2AdaptSignature turns an adaptor signature into a final, full signature
+// by adding the secret of the adaptor point to it.
+func (s *Server) MuSig2AdaptSignature(_ context.Context,
+	in *MuSig2AdaptSignatureRequest) (*MuSig2AdaptSignatureResponse,
+	error) {
+
+	adaptorSig, err := input.ParseMuSig2AdaptorSignature(
+		in.AdaptorSignature,
 	)
-	for idx, otherPartialSigBytes := range partialSignatures {
-		sig, err := input.DeserializePartialSignature(
-			otherPartialSigBytes,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("invalid partial signature at "+
-				"index %d: %v", idx, err)
-		}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.AdaptorSecret) != btcec.PrivKeyBytesLen {
+		return nil, fmt.Errorf("invalid adaptor secret length, got "+
+			"%d but expected %d", len(in.AdaptorSecret),
+			btcec.PrivKeyBytesLen)
+	}
 
-		parsedPartialSigs[idx] = sig
+	var secret btcec.ModNScalar
+	if overflow := secret.SetByteSlice(in.AdaptorSecret); overflow {
+		return nil, fmt.Errorf("adaptor secret overflows")
 	}
 
-	return parsedPartialSigs, nil
+	finalSig := input.MuSig2AdaptSignature(adaptorSig, &secret)
+
+	return &MuSig2AdaptSignatureResponse{
+		FinalSignature: finalSig.Serialize(),
+	}, nil
 }
 
-// UnmarshalTweaks parses the RPC tweak descriptions into their native
-// counterpart.
-func UnmarshalTweaks(rpcTweaks []*TweakDesc,
-	taprootTweak *TaprootTweakDesc) (*input.MuSig2Tweaks, error) {
+// MuSig2VerifyAdaptorSignature verifies that an adaptor signature becomes a
+// valid signature for the combined key once it is adapted with the secret of
+// the given adaptor point.
+func (s *Server) MuSig2VerifyAdaptorSignature(_ context.Context,
+	in *MuSig2VerifyAdaptorSignatureRequest) (
+	*MuSig2VerifyAdaptorSignatureResponse, error) {
 
-	// Parse the generic tweaks first.
-	tweaks := &input.MuSig2Tweaks{
-		GenericTweaks: make([]musig2.KeyTweakDesc, len(rpcTweaks)),
+	adaptorSig, err := input.ParseMuSig2AdaptorSignature(
+		in.AdaptorSignature,
+	)
+	if err != nil {
+		return nil, err
 	}
-	for idx, rpcTweak := range rpcTweaks {
-		if len(rpcTweak.Tweak) == 0 {
-			return nil, fmt.Errorf("tweak cannot be empty")
-		}
 
-		copy(tweaks.GenericTweaks[idx].Tweak[:], rpcTweak.Tweak)
-		tweaks.GenericTweaks[idx].IsXOnly = rpcTweak.IsXOnly
+	adaptorPoint, err := btcec.ParsePubKey(in.AdaptorPoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing adaptor point: %w", err)
 	}
 
-	// Now parse the taproot specific tweak.
-	if taprootTweak != nil {
-		if taprootTweak.KeySpendOnly {
-			tweaks.TaprootBIP0086Tweak = true
-		} else {
-			if len(taprootTweak.ScriptRoot) == 0 {
-				return nil, fmt.Errorf("script root cannot " +
-					"be empty for non-keyspend")
-			}
+	combinedKey, err := schnorr.ParsePubKey(in.CombinedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing combined key: %w", err)
+	}
 
-			tweaks.TaprootTweak = taprootTweak.ScriptRoot
-		}
+	// Schnorr signatures only work reliably if the message is 32 bytes.
+	msg := [sha256.Size]byte{}
+	if len(in.MessageDigest) != sha256.Size {
+		return nil, fmt.Errorf("invalid message digest size, got %d "+
+			"but expected %d", len(in.MessageDigest), sha256.Size)
 	}
+	copy(msg[:], in.MessageDigest)
+
+	err = input.MuSig2VerifyAdaptorSignature(
+		adaptorSig, adaptorPoint, combinedKey, msg,
+	)
 
-	return tweaks, nil
+	return &MuSig2VerifyAdaptorSignatureResponse{
+		Valid: err == nil,
+	}, nil
+}
+
+// MuSig2ExtractAdaptorSecret extracts the secret of the adaptor point from an
+// adaptor signature and the final signature that was created by adapting it.
+func (s *Server) MuSig2ExtractAdaptorSecret(_ context.Context,
+	in *MuSig2ExtractAdaptorSecretRequest) (
+	*MuSig2ExtractAdaptorSecretResponse, error) {
+
+	adaptorSig, err := input.ParseMuSig2AdaptorSignature(
+		in.AdaptorSignature,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	finalSig, err := schnorr.ParseSignature(in.FinalSignature)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing final signature: %w",
+			err)
+	}
+
+	secret, err := input.MuSig2ExtractAdaptorSecret(adaptorSig, finalSig)
+	if err != nil {
+		return nil, err
+	}
+
+	secretBytes := secret.Bytes()
+
+	return &MuSig2ExtractAdaptorSecretResponse{
+		AdaptorSecret: secretBytes[:],
+	}, nil
 }
 
 // UnmarshalSignMethod parses the RPC sign method into the native counterpart.
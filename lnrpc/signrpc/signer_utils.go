@@ -1,11 +1,19 @@
 package signrpc
 
 import (
+	"crypto/sha256"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/lightningnetwork/lnd/input"
 )
 
+const (
+	// BIP0340 is the prefix for BIP0340-related tagged hashes.
+	BIP0340 = "BIP0340"
+)
+
 // UnmarshalMuSig2Version parses the RPC MuSig2 version into the native
 // counterpart.
 func UnmarshalMuSig2Version(rpcVersion MuSig2Version) (input.MuSig2Version,
@@ -40,3 +48,169 @@ func MarshalMuSig2Version(version input.MuSig2Version) (MuSig2Version, error) {
 		return 0, fmt.Errorf("unknown MuSig2 version <%d>", version)
 	}
 }
+
+// SecNonceToPubNonce takes our two secret nonces, and produces their two
+// corresponding EC points, serialized in compressed format.
+//
+// NOTE: This was copied from btcsuite/btcec/musig2/nonces.go.
+func SecNonceToPubNonce(secNonce [musig2.SecNonceSize]byte,
+) [musig2.PubNonceSize]byte {
+
+	var k1Mod, k2Mod btcec.ModNScalar
+	k1Mod.SetByteSlice(secNonce[:btcec.PrivKeyBytesLen])
+	k2Mod.SetByteSlice(secNonce[btcec.PrivKeyBytesLen:])
+
+	var r1, r2 btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(&k1Mod, &r1)
+	btcec.ScalarBaseMultNonConst(&k2Mod, &r2)
+
+	// Next, we'll convert the key in jacobian format to a normal public
+	// key expressed in affine coordinates.
+	r1.ToAffine()
+	r2.ToAffine()
+	r1Pub := btcec.NewPublicKey(&r1.X, &r1.Y)
+	r2Pub := btcec.NewPublicKey(&r2.X, &r2.Y)
+
+	var pubNonce [musig2.PubNonceSize]byte
+
+	// The public nonces are serialized as: R1 || R2, where both keys are
+	// serialized in compressed format.
+	copy(pubNonce[:], r1Pub.SerializeCompressed())
+	copy(
+		pubNonce[btcec.PubKeyBytesLenCompressed:],
+		r2Pub.SerializeCompressed(),
+	)
+
+	return pubNonce
+}
+
+// ParseRawKeyBytes checks that the provided raw public key is valid and returns
+// the public key. A nil public key is returned if the length of the rawKeyBytes
+// is zero.
+func ParseRawKeyBytes(rawKeyBytes []byte) (*btcec.PublicKey, error) {
+	switch {
+	case len(rawKeyBytes) == 33:
+		// If a proper raw key was provided, then we'll attempt
+		// to decode and parse it.
+		return btcec.ParsePubKey(rawKeyBytes)
+
+	case len(rawKeyBytes) == 0:
+		// No key is provided, return nil.
+		return nil, nil
+
+	default:
+		// If the user provided a raw key, but it's of the
+		// wrong length, then we'll return with an error.
+		return nil, fmt.Errorf("pubkey must be " +
+			"serialized in compressed format if " +
+			"specified")
+	}
+}
+
+// ParseMuSig2SessionID parses a MuSig2 session ID from a raw byte slice.
+func ParseMuSig2SessionID(rawID []byte) (input.MuSig2SessionID, error) {
+	sessionID := input.MuSig2SessionID{}
+
+	// The session ID must be exact in its length.
+	if len(rawID) != sha256.Size {
+		return sessionID, fmt.Errorf("invalid session ID size, got "+
+			"%d but expected %d", len(rawID), sha256.Size)
+	}
+	copy(sessionID[:], rawID)
+
+	return sessionID, nil
+}
+
+// ParseMuSig2PublicNonces sanity checks and parses the other signers' public
+// nonces.
+func ParseMuSig2PublicNonces(pubNonces [][]byte,
+	emptyAllowed bool) ([][musig2.PubNonceSize]byte, error) {
+
+	// For some calls the nonces are optional while for others it doesn't
+	// make any sense to not specify them (for example for the explicit
+	// nonce registration call there should be at least one nonce).
+	if !emptyAllowed && len(pubNonces) == 0 {
+		return nil, fmt.Errorf("at least one other signer public " +
+			"nonce is required")
+	}
+
+	// Parse all other nonces. This can be called multiple times, so we
+	// can't really sanity check how many we already have vs. how many the
+	// user supplied in this call.
+	otherSignerNonces := make([][musig2.PubNonceSize]byte, len(pubNonces))
+	for idx, otherNonceBytes := range pubNonces {
+		if len(otherNonceBytes) != musig2.PubNonceSize {
+			return nil, fmt.Errorf("invalid public nonce at "+
+				"index %d: invalid length, got %d but "+
+				"expected %d", idx, len(otherNonceBytes),
+				musig2.PubNonceSize)
+		}
+		copy(otherSignerNonces[idx][:], otherNonceBytes)
+	}
+
+	return otherSignerNonces, nil
+}
+
+// ParseMuSig2PartialSignatures sanity checks and parses the other signers'
+// partial signatures.
+func ParseMuSig2PartialSignatures(
+	partialSignatures [][]byte) ([]*musig2.PartialSignature, error) {
+
+	// We always want at least one partial signature.
+	if len(partialSignatures) == 0 {
+		return nil, fmt.Errorf("at least one partial signature is " +
+			"required")
+	}
+
+	parsedPartialSigs := make(
+		[]*musig2.PartialSignature, len(partialSignatures),
+	)
+	for idx, otherPartialSigBytes := range partialSignatures {
+		sig, err := input.DeserializePartialSignature(
+			otherPartialSigBytes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partial signature at "+
+				"index %d: %v", idx, err)
+		}
+
+		parsedPartialSigs[idx] = sig
+	}
+
+	return parsedPartialSigs, nil
+}
+
+// UnmarshalTweaks parses the RPC tweak descriptions into their native
+// counterpart.
+func UnmarshalTweaks(rpcTweaks []*TweakDesc,
+	taprootTweak *TaprootTweakDesc) (*input.MuSig2Tweaks, error) {
+
+	// Parse the generic tweaks first.
+	tweaks := &input.MuSig2Tweaks{
+		GenericTweaks: make([]musig2.KeyTweakDesc, len(rpcTweaks)),
+	}
+	for idx, rpcTweak := range rpcTweaks {
+		if len(rpcTweak.Tweak) == 0 {
+			return nil, fmt.Errorf("tweak cannot be empty")
+		}
+
+		copy(tweaks.GenericTweaks[idx].Tweak[:], rpcTweak.Tweak)
+		tweaks.GenericTweaks[idx].IsXOnly = rpcTweak.IsXOnly
+	}
+
+	// Now parse the taproot specific tweak.
+	if taprootTweak != nil {
+		if taprootTweak.KeySpendOnly {
+			tweaks.TaprootBIP0086Tweak = true
+		} else {
+			if len(taprootTweak.ScriptRoot) == 0 {
+				return nil, fmt.Errorf("script root cannot " +
+					"be empty for non-keyspend")
+			}
+
+			tweaks.TaprootTweak = taprootTweak.ScriptRoot
+		}
+	}
+
+	return tweaks, nil
+}
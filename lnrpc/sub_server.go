@@ -34,6 +34,16 @@ type SubServer interface {
 	Name() string
 }
 
+// DefaultMacaroonBaker is an optional interface that a sub-server implements
+// if it bakes a default macaroon with the default root key on startup. The
+// main RPC server uses it to re-issue that macaroon when the default root key
+// is rotated.
+type DefaultMacaroonBaker interface {
+	// DefaultMacaroon returns the path of the sub-server's default
+	// macaroon file and the operations the macaroon is baked with.
+	DefaultMacaroon() (string, []bakery.Op)
+}
+
 // GrpcHandler is the interface that should be registered with the root gRPC
 // server, and is the interface that implements the subserver's defined RPC.
 // Before the actual sub server has been created, this will be an empty shell
@@ -9,6 +9,7 @@ import (
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/lnwallet/signpolicy"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/sweep"
 )
@@ -76,4 +77,12 @@ type Config struct {
 	// CoinSelectionStrategy is the strategy that is used for selecting
 	// coins when funding a transaction.
 	CoinSelectionStrategy wallet.CoinSelectionStrategy
+
+	// AnchorReserve is the policy that determines the amount that is kept
+	// in the wallet to fee bump anchor channels.
+	AnchorReserve lnwallet.AnchorReservePolicy
+
+	// SignPolicy is the policy that decides whether a PSBT spending funds
+	// of the wallet may be signed. A nil policy allows everything.
+	SignPolicy *signpolicy.Policy
 }
@@ -178,6 +178,25 @@ type WalletKitClient interface {
 	// remain supported. This is an advanced API that depends on the internals of
 	// the UtxoSweeper, so things may change.
 	PendingSweeps(ctx context.Context, in *PendingSweepsRequest, opts ...grpc.CallOption) (*PendingSweepsResponse, error)
+	// lncli: `wallet pendingsweepsdetail`
+	// PendingSweepsDetail returns a preview of the batches lnd's central batching
+	// engine currently intends to sweep its pending inputs in. For each batch,
+	// the projected fee rate trajectory and the height of the next broadcast
+	// attempt are returned. Inputs whose timelocks haven't expired yet are
+	// returned separately along with the height at which they can first be
+	// swept.
+	//
+	// NOTE: This is an advanced API that depends on the internals of the
+	// UtxoSweeper, so things may change.
+	PendingSweepsDetail(ctx context.Context, in *PendingSweepsDetailRequest, opts ...grpc.CallOption) (*PendingSweepsDetailResponse, error)
+	// lncli: `wallet removesweep`
+	// RemoveSweepInput permanently removes an input from lnd's central batching
+	// engine, for example an uneconomical dust output of a force close. The input
+	// won't be batched or broadcast again, even after a restart, and the
+	// subsystem that offered the input abandons it. Inputs that are part of a
+	// sweeping transaction that is being published or has been published can't
+	// be removed.
+	RemoveSweepInput(ctx context.Context, in *RemoveSweepInputRequest, opts ...grpc.CallOption) (*RemoveSweepInputResponse, error)
 	// lncli: `wallet bumpfee`
 	// BumpFee is an endpoint that allows users to interact with lnd's sweeper
 	// directly. It takes an outpoint from an unconfirmed transaction and sends it
@@ -473,6 +492,24 @@ func (c *walletKitClient) PendingSweeps(ctx context.Context, in *PendingSweepsRe
 	return out, nil
 }
 
+func (c *walletKitClient) PendingSweepsDetail(ctx context.Context, in *PendingSweepsDetailRequest, opts ...grpc.CallOption) (*PendingSweepsDetailResponse, error) {
+	out := new(PendingSweepsDetailResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/PendingSweepsDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) RemoveSweepInput(ctx context.Context, in *RemoveSweepInputRequest, opts ...grpc.CallOption) (*RemoveSweepInputResponse, error) {
+	out := new(RemoveSweepInputResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/RemoveSweepInput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletKitClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
 	out := new(BumpFeeResponse)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/BumpFee", in, out, opts...)
@@ -689,6 +726,25 @@ type WalletKitServer interface {
 	// remain supported. This is an advanced API that depends on the internals of
 	// the UtxoSweeper, so things may change.
 	PendingSweeps(context.Context, *PendingSweepsRequest) (*PendingSweepsResponse, error)
+	// lncli: `wallet pendingsweepsdetail`
+	// PendingSweepsDetail returns a preview of the batches lnd's central batching
+	// engine currently intends to sweep its pending inputs in. For each batch,
+	// the projected fee rate trajectory and the height of the next broadcast
+	// attempt are returned. Inputs whose timelocks haven't expired yet are
+	// returned separately along with the height at which they can first be
+	// swept.
+	//
+	// NOTE: This is an advanced API that depends on the internals of the
+	// UtxoSweeper, so things may change.
+	PendingSweepsDetail(context.Context, *PendingSweepsDetailRequest) (*PendingSweepsDetailResponse, error)
+	// lncli: `wallet removesweep`
+	// RemoveSweepInput permanently removes an input from lnd's central batching
+	// engine, for example an uneconomical dust output of a force close. The input
+	// won't be batched or broadcast again, even after a restart, and the
+	// subsystem that offered the input abandons it. Inputs that are part of a
+	// sweeping transaction that is being published or has been published can't
+	// be removed.
+	RemoveSweepInput(context.Context, *RemoveSweepInputRequest) (*RemoveSweepInputResponse, error)
 	// lncli: `wallet bumpfee`
 	// BumpFee is an endpoint that allows users to interact with lnd's sweeper
 	// directly. It takes an outpoint from an unconfirmed transaction and sends it
@@ -855,6 +911,12 @@ func (UnimplementedWalletKitServer) EstimateFee(context.Context, *EstimateFeeReq
 func (UnimplementedWalletKitServer) PendingSweeps(context.Context, *PendingSweepsRequest) (*PendingSweepsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PendingSweeps not implemented")
 }
+func (UnimplementedWalletKitServer) PendingSweepsDetail(context.Context, *PendingSweepsDetailRequest) (*PendingSweepsDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PendingSweepsDetail not implemented")
+}
+func (UnimplementedWalletKitServer) RemoveSweepInput(context.Context, *RemoveSweepInputRequest) (*RemoveSweepInputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSweepInput not implemented")
+}
 func (UnimplementedWalletKitServer) BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BumpFee not implemented")
 }
@@ -1264,6 +1326,42 @@ func _WalletKit_PendingSweeps_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletKit_PendingSweepsDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingSweepsDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).PendingSweepsDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/PendingSweepsDetail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).PendingSweepsDetail(ctx, req.(*PendingSweepsDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_RemoveSweepInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSweepInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).RemoveSweepInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/RemoveSweepInput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).RemoveSweepInput(ctx, req.(*RemoveSweepInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletKit_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BumpFeeRequest)
 	if err := dec(in); err != nil {
@@ -1463,6 +1561,14 @@ var WalletKit_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PendingSweeps",
 			Handler:    _WalletKit_PendingSweeps_Handler,
 		},
+		{
+			MethodName: "PendingSweepsDetail",
+			Handler:    _WalletKit_PendingSweepsDetail_Handler,
+		},
+		{
+			MethodName: "RemoveSweepInput",
+			Handler:    _WalletKit_RemoveSweepInput_Handler,
+		},
 		{
 			MethodName: "BumpFee",
 			Handler:    _WalletKit_BumpFee_Handler,
@@ -16,6 +16,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
@@ -36,6 +37,7 @@ import (
 	"github.com/lightningnetwork/lnd/labels"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
@@ -102,6 +104,14 @@ var (
 			Entity: "onchain",
 			Action: "read",
 		}},
+		"/walletrpc.WalletKit/PendingSweepsDetail": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/RemoveSweepInput": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/walletrpc.WalletKit/BumpFee": {{
 			Entity: "onchain",
 			Action: "write",
@@ -324,6 +334,14 @@ func (w *WalletKit) Name() string {
 	return SubServerName
 }
 
+// DefaultMacaroon returns the path of the wallet kit macaroon file and the
+// operations it is baked with.
+//
+// NOTE: This is part of the lnrpc.DefaultMacaroonBaker interface.
+func (w *WalletKit) DefaultMacaroon() (string, []bakery.Op) {
+	return w.cfg.WalletKitMacPath, macaroonOps
+}
+
 // RegisterWithRootServer will be called by the root gRPC server to direct a
 // sub RPC server to register itself with the main gRPC root server. Until this
 // is called, each sub-server won't be able to have requests routed towards it.
@@ -842,6 +860,23 @@ func (w *WalletKit) EstimateFee(ctx context.Context,
 			"than 1")
 	}
 
+	// If multiple fee sources are configured, we'll also report which of
+	// them the estimate is based on.
+	estimator, ok := w.cfg.FeeEstimator.(chainfee.SourceEstimator)
+	if ok {
+		satPerKw, sources, err := estimator.EstimateFeePerKWWithSources(
+			uint32(req.ConfTarget),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &EstimateFeeResponse{
+			SatPerKw:   int64(satPerKw),
+			FeeSources: sources,
+		}, nil
+	}
+
 	satPerKw, err := w.cfg.FeeEstimator.EstimateFeePerKW(
 		uint32(req.ConfTarget),
 	)
@@ -909,6 +944,114 @@ func (w *WalletKit) PendingSweeps(ctx context.Context,
 	}, nil
 }
 
+// PendingSweepsDetail returns a preview of the batches the UtxoSweeper
+// currently intends to sweep its pending inputs in, along with the projected
+// fee rate trajectory and the next broadcast height of each batch.
+func (w *WalletKit) PendingSweepsDetail(ctx context.Context,
+	in *PendingSweepsDetailRequest) (*PendingSweepsDetailResponse, error) {
+
+	preview, err := w.cfg.Sweeper.PendingBatches()
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalPendingBatches(preview), nil
+}
+
+// RemoveSweepInput permanently removes an input from the UtxoSweeper. The
+// removal is persisted, so the input won't ever be swept again.
+func (w *WalletKit) RemoveSweepInput(_ context.Context,
+	in *RemoveSweepInputRequest) (*RemoveSweepInputResponse, error) {
+
+	op, err := UnmarshallOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid outpoint: %w", err)
+	}
+
+	err = w.cfg.Sweeper.RemoveInput(*op)
+	switch {
+	case errors.Is(err, lnwallet.ErrNotMine):
+		return nil, fmt.Errorf("input %v is not being swept", op)
+
+	case err != nil:
+		return nil, fmt.Errorf("unable to remove input %v: %w", op, err)
+	}
+
+	return &RemoveSweepInputResponse{
+		Status: "Successfully removed input from sweeper",
+	}, nil
+}
+
+// marshalPendingBatches converts a preview of the sweeper's batches into its
+// RPC format.
+func marshalPendingBatches(
+	preview *sweep.PendingBatches) *PendingSweepsDetailResponse {
+
+	resp := &PendingSweepsDetailResponse{
+		Batches: make([]*SweepBatch, 0, len(preview.Batches)),
+		ImmatureSweeps: make(
+			[]*ImmatureSweep, 0, len(preview.Immature),
+		),
+	}
+
+	for _, batch := range preview.Batches {
+		rpcBatch := &SweepBatch{
+			Inputs: make(
+				[]*lnrpc.OutPoint, 0, len(batch.Inputs),
+			),
+			Weight:              uint64(batch.Weight),
+			BudgetSat:           uint64(batch.Budget),
+			DeadlineHeight:      uint32(batch.DeadlineHeight),
+			NeedsWalletInput:    batch.NeedWalletInput,
+			NextBroadcastHeight: uint32(batch.NextBroadcastHeight),
+		}
+
+		for i := range batch.Inputs {
+			rpcBatch.Inputs = append(
+				rpcBatch.Inputs,
+				lnrpc.MarshalOutPoint(&batch.Inputs[i]),
+			)
+		}
+
+		batch.Txid.WhenSome(func(txid chainhash.Hash) {
+			rpcBatch.Txid = txid.String()
+		})
+
+		for _, p := range batch.FeeRates {
+			rpcBatch.FeeRateTrajectory = append(
+				rpcBatch.FeeRateTrajectory,
+				&FeeRateProjection{
+					Height: uint32(p.Height),
+					SatPerVbyte: uint64(
+						p.FeeRate.FeePerVByte(),
+					),
+					SatPerKw: uint64(p.FeeRate),
+				},
+			)
+		}
+
+		resp.Batches = append(resp.Batches, rpcBatch)
+	}
+
+	for op, height := range preview.Immature {
+		op := op
+		immature := &ImmatureSweep{
+			Outpoint:     lnrpc.MarshalOutPoint(&op),
+			MatureHeight: uint32(height),
+		}
+		resp.ImmatureSweeps = append(resp.ImmatureSweeps, immature)
+	}
+
+	// Sort the immature inputs so the ones that can be swept first come
+	// first.
+	sort.Slice(resp.ImmatureSweeps, func(i, j int) bool {
+		return resp.ImmatureSweeps[i].MatureHeight <
+			resp.ImmatureSweeps[j].MatureHeight
+	})
+
+	return resp
+}
+
 // UnmarshallOutPoint converts an outpoint from its lnrpc type to its canonical
 // type.
 func UnmarshallOutPoint(op *lnrpc.OutPoint) (*wire.OutPoint, error) {
@@ -969,6 +1112,11 @@ func validateBumpFeeRequest(in *BumpFeeRequest) (
 		satPerKwOpt = fn.Some(satPerKw)
 	}
 
+	if in.TargetConf != 0 && in.DeadlineHeight != 0 {
+		return satPerKwOpt, false, fmt.Errorf("either TargetConf or " +
+			"DeadlineHeight should be set, but not both")
+	}
+
 	var immediate bool
 	switch {
 	case in.Force && in.Immediate:
@@ -985,6 +1133,33 @@ func validateBumpFeeRequest(in *BumpFeeRequest) (
 	return satPerKwOpt, immediate, nil
 }
 
+// bumpFeeDeadline returns the deadline height requested by the BumpFeeRequest,
+// which can either be given relative to the current height or as an absolute
+// height. None is returned if no deadline is requested.
+//
+// TODO(yy): upgrade `falafel` so we can make this field optional. Atm we
+// cannot distinguish between user's not setting the field and setting it to
+// 0.
+func bumpFeeDeadline(in *BumpFeeRequest,
+	currentHeight int32) (fn.Option[int32], error) {
+
+	switch {
+	case in.TargetConf != 0:
+		return fn.Some(int32(in.TargetConf) + currentHeight), nil
+
+	case in.DeadlineHeight != 0:
+		if int32(in.DeadlineHeight) <= currentHeight {
+			return fn.None[int32](), fmt.Errorf("deadline height "+
+				"%d must be above the current height %d",
+				in.DeadlineHeight, currentHeight)
+		}
+
+		return fn.Some(int32(in.DeadlineHeight)), nil
+	}
+
+	return fn.None[int32](), nil
+}
+
 // prepareSweepParams creates the sweep params to be used for the sweeper. It
 // returns the new params and a bool indicating whether this is an existing
 // input.
@@ -997,6 +1172,11 @@ func (w *WalletKit) prepareSweepParams(in *BumpFeeRequest,
 		return sweep.Params{}, false, err
 	}
 
+	deadlineOpt, err := bumpFeeDeadline(in, currentHeight)
+	if err != nil {
+		return sweep.Params{}, false, err
+	}
+
 	// Get the current pending inputs.
 	inputMap, err := w.cfg.Sweeper.PendingInputs()
 	if err != nil {
@@ -1015,13 +1195,9 @@ func (w *WalletKit) prepareSweepParams(in *BumpFeeRequest,
 		params := sweep.Params{
 			Immediate:       immediate,
 			StartingFeeRate: feerate,
+			DeadlineHeight:  deadlineOpt,
 			Budget:          btcutil.Amount(in.Budget),
 		}
-		if in.TargetConf != 0 {
-			params.DeadlineHeight = fn.Some(
-				int32(in.TargetConf) + currentHeight,
-			)
-		}
 
 		return params, ok, nil
 	}
@@ -1038,14 +1214,8 @@ func (w *WalletKit) prepareSweepParams(in *BumpFeeRequest,
 	// For an existing input, we assign it first, then overwrite it if
 	// a deadline is requested.
 	deadline := inp.Params.DeadlineHeight
-
-	// Set the deadline if target conf is specified.
-	//
-	// TODO(yy): upgrade `falafel` so we can make this field optional. Atm
-	// we cannot distinguish between user's not setting the field and
-	// setting it to 0.
-	if in.TargetConf != 0 {
-		deadline = fn.Some(int32(in.TargetConf) + currentHeight)
+	if deadlineOpt.IsSome() {
+		deadline = deadlineOpt
 	}
 
 	// Prepare the new sweep params.
@@ -1193,6 +1363,24 @@ func (w *WalletKit) sweepNewInput(op *wire.OutPoint, currentHeight uint32,
 func (w *WalletKit) ListSweeps(ctx context.Context,
 	in *ListSweepsRequest) (*ListSweepsResponse, error) {
 
+	// Unless an end height is given, we include unconfirmed sweeps.
+	endHeight := int32(btcwallet.UnconfirmedHeight)
+	switch {
+	case in.EndHeight < 0:
+		return nil, fmt.Errorf("end height must not be negative")
+
+	case in.EndHeight > 0 && in.StartHeight < 0:
+		return nil, fmt.Errorf("end height cannot be used when only " +
+			"listing unconfirmed sweeps")
+
+	case in.EndHeight > 0 && in.StartHeight > in.EndHeight:
+		return nil, fmt.Errorf("start height %d is greater than end "+
+			"height %d", in.StartHeight, in.EndHeight)
+
+	case in.EndHeight > 0:
+		endHeight = in.EndHeight
+	}
+
 	sweeps, err := w.cfg.Sweeper.ListSweeps()
 	if err != nil {
 		return nil, err
@@ -1209,50 +1397,183 @@ func (w *WalletKit) ListSweeps(ctx context.Context,
 	// the wallet is still tracking. Sweeps are currently always swept to
 	// the default wallet account.
 	transactions, err := w.cfg.Wallet.ListTransactionDetails(
-		in.StartHeight, btcwallet.UnconfirmedHeight,
-		lnwallet.DefaultAccountName,
+		in.StartHeight, endHeight, lnwallet.DefaultAccountName,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		txids     []string
-		txDetails []*lnwallet.TransactionDetail
-	)
-
+	var sweepDetails []*lnwallet.TransactionDetail
 	for _, tx := range transactions {
 		_, ok := sweepTxns[tx.Hash.String()]
 		if !ok {
 			continue
 		}
 
-		// Add the txid or full tx details depending on whether we want
-		// verbose output or not.
-		if in.Verbose {
-			txDetails = append(txDetails, tx)
-		} else {
-			txids = append(txids, tx.Hash.String())
+		sweepDetails = append(sweepDetails, tx)
+	}
+
+	sweepDetails, nextPageToken, err := paginateSweeps(
+		sweepDetails, in.PageToken, in.MaxSweeps,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListSweepsResponse{
+		NextPageToken: nextPageToken,
+	}
+
+	if in.IncludeFees {
+		resp.Fees, err = w.sweepFees(sweepDetails)
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	// Add the txid or full tx details depending on whether we want
+	// verbose output or not.
 	if in.Verbose {
-		return &ListSweepsResponse{
-			Sweeps: &ListSweepsResponse_TransactionDetails{
-				TransactionDetails: lnrpc.RPCTransactionDetails(
-					txDetails,
-				),
-			},
-		}, nil
+		resp.Sweeps = &ListSweepsResponse_TransactionDetails{
+			TransactionDetails: lnrpc.RPCTransactionDetails(
+				sweepDetails,
+			),
+		}
+
+		return resp, nil
 	}
 
-	return &ListSweepsResponse{
-		Sweeps: &ListSweepsResponse_TransactionIds{
-			TransactionIds: &ListSweepsResponse_TransactionIDs{
-				TransactionIds: txids,
-			},
+	txids := make([]string, 0, len(sweepDetails))
+	for _, tx := range sweepDetails {
+		txids = append(txids, tx.Hash.String())
+	}
+
+	resp.Sweeps = &ListSweepsResponse_TransactionIds{
+		TransactionIds: &ListSweepsResponse_TransactionIDs{
+			TransactionIds: txids,
 		},
-	}, nil
+	}
+
+	return resp, nil
+}
+
+// paginateSweeps returns the page of at most maxSweeps sweeps that follows the
+// sweep the page token refers to, along with the token of the next page. The
+// page token is the txid of the last sweep of the previous page.
+func paginateSweeps(sweeps []*lnwallet.TransactionDetail, pageToken string,
+	maxSweeps uint32) ([]*lnwallet.TransactionDetail, string, error) {
+
+	if pageToken != "" {
+		start := -1
+		for i, sweep := range sweeps {
+			if sweep.Hash.String() == pageToken {
+				start = i + 1
+				break
+			}
+		}
+
+		// The sweep of the token may have been replaced by fee since
+		// the previous page was fetched.
+		if start == -1 {
+			return nil, "", fmt.Errorf("invalid page token %v, "+
+				"the sweep may have been replaced", pageToken)
+		}
+
+		sweeps = sweeps[start:]
+	}
+
+	if maxSweeps == 0 || len(sweeps) <= int(maxSweeps) {
+		return sweeps, "", nil
+	}
+
+	sweeps = sweeps[:maxSweeps]
+
+	return sweeps, sweeps[len(sweeps)-1].Hash.String(), nil
+}
+
+// sweepFees returns the fees paid by the given sweeps. As sweeps usually spend
+// outputs that don't belong to the wallet, such as outputs of our commitment
+// transactions, the wallet doesn't know their fee. Instead, we calculate it
+// from the values of the spent outputs, which we look up in the transactions
+// of all wallet accounts.
+func (w *WalletKit) sweepFees(
+	sweeps []*lnwallet.TransactionDetail) ([]*SweepFee, error) {
+
+	if len(sweeps) == 0 {
+		return nil, nil
+	}
+
+	walletTxns, err := w.cfg.Wallet.ListTransactionDetails(
+		0, btcwallet.UnconfirmedHeight, "",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make(map[chainhash.Hash]*wire.MsgTx, len(walletTxns))
+	for _, txDetail := range walletTxns {
+		tx := &wire.MsgTx{}
+		err := tx.Deserialize(bytes.NewReader(txDetail.RawTx))
+		if err != nil {
+			return nil, err
+		}
+
+		txns[txDetail.Hash] = tx
+	}
+
+	fees := make([]*SweepFee, 0, len(sweeps))
+	for _, sweep := range sweeps {
+		sweepTx, ok := txns[sweep.Hash]
+		if !ok {
+			continue
+		}
+
+		fee, ok := sweepFee(sweepTx, txns)
+		if !ok {
+			log.Debugf("Unable to determine fee of sweep %v, not "+
+				"all spent outputs are known", sweep.Hash)
+
+			continue
+		}
+
+		weight := lntypes.WeightUnit(
+			blockchain.GetTransactionWeight(btcutil.NewTx(sweepTx)),
+		)
+		feeRate := chainfee.NewSatPerKWeight(fee, weight)
+
+		fees = append(fees, &SweepFee{
+			Txid:        sweep.Hash.String(),
+			FeeSat:      int64(fee),
+			SatPerKw:    uint64(feeRate),
+			SatPerVbyte: uint64(feeRate.FeePerVByte()),
+		})
+	}
+
+	return fees, nil
+}
+
+// sweepFee calculates the fee paid by the sweep transaction from the values of
+// the outputs it spends, which are looked up in the given transactions. False
+// is returned if one of the spent outputs is unknown.
+func sweepFee(sweepTx *wire.MsgTx,
+	txns map[chainhash.Hash]*wire.MsgTx) (btcutil.Amount, bool) {
+
+	var fee btcutil.Amount
+	for _, txIn := range sweepTx.TxIn {
+		prevOut := txIn.PreviousOutPoint
+		prevTx, ok := txns[prevOut.Hash]
+		if !ok || int(prevOut.Index) >= len(prevTx.TxOut) {
+			return 0, false
+		}
+
+		fee += btcutil.Amount(prevTx.TxOut[prevOut.Index].Value)
+	}
+
+	for _, txOut := range sweepTx.TxOut {
+		fee -= btcutil.Amount(txOut.Value)
+	}
+
+	return fee, true
 }
 
 // LabelTransaction adds a label to a transaction.
@@ -2074,6 +2395,12 @@ func (w *WalletKit) SignPsbt(_ context.Context, req *SignPsbtRequest) (
 		}
 	}
 
+	// Make sure the signer policy allows us to sign the packet if it
+	// spends funds of our wallet.
+	if err := w.cfg.SignPolicy.CheckPsbt(packet); err != nil {
+		return nil, err
+	}
+
 	// Let the wallet do the heavy lifting. This will sign all inputs that
 	// we have the UTXO for. If some inputs can't be signed and don't have
 	// witness data attached, they will just be skipped.
@@ -2130,6 +2457,12 @@ func (w *WalletKit) FinalizePsbt(_ context.Context,
 		return nil, fmt.Errorf("PSBT is already fully signed")
 	}
 
+	// Finalizing always spends funds of our wallet, so the signer policy
+	// needs to allow it.
+	if err := w.cfg.SignPolicy.CheckWalletSpend(packet); err != nil {
+		return nil, err
+	}
+
 	// Let the wallet do the heavy lifting. This will sign all inputs that
 	// we have the UTXO for. If some inputs can't be signed and don't have
 	// witness data attached, this will fail.
@@ -2331,7 +2664,7 @@ func (w *WalletKit) ListAccounts(ctx context.Context,
 // RequiredReserve returns the minimum amount of satoshis that should be
 // kept in the wallet in order to fee bump anchor channels if necessary.
 // The value scales with the number of public anchor channels but is
-// capped at a maximum.
+// capped at a maximum, both of which are returned as well.
 func (w *WalletKit) RequiredReserve(ctx context.Context,
 	req *RequiredReserveRequest) (*RequiredReserveResponse, error) {
 
@@ -2345,7 +2678,9 @@ func (w *WalletKit) RequiredReserve(ctx context.Context,
 	reserved := w.cfg.Wallet.RequiredReserve(totalChans)
 
 	return &RequiredReserveResponse{
-		RequiredReserve: int64(reserved),
+		RequiredReserve:   int64(reserved),
+		ReservePerChannel: int64(w.cfg.AnchorReserve.PerChannel),
+		MaxReserve:        int64(w.cfg.AnchorReserve.Max),
 	}, nil
 }
 
@@ -17,11 +17,13 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/lightningnetwork/lnd/fn"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lntest/mock"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
+	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/stretchr/testify/require"
 )
 
@@ -631,3 +633,197 @@ func TestFundPsbtCoinSelect(t *testing.T) {
 		})
 	}
 }
+
+// TestPaginateSweeps tests that sweeps are paginated using the txid of the
+// last sweep of the previous page as the page token.
+func TestPaginateSweeps(t *testing.T) {
+	t.Parallel()
+
+	sweeps := make([]*lnwallet.TransactionDetail, 5)
+	for i := range sweeps {
+		sweeps[i] = &lnwallet.TransactionDetail{
+			Hash: chainhash.Hash{byte(i + 1)},
+		}
+	}
+
+	// Without a limit, all sweeps are returned on a single page.
+	page, token, err := paginateSweeps(sweeps, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, sweeps, page)
+	require.Empty(t, token)
+
+	// We should be able to walk through all sweeps in pages of two.
+	page, token, err = paginateSweeps(sweeps, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, sweeps[:2], page)
+	require.Equal(t, sweeps[1].Hash.String(), token)
+
+	page, token, err = paginateSweeps(sweeps, token, 2)
+	require.NoError(t, err)
+	require.Equal(t, sweeps[2:4], page)
+	require.Equal(t, sweeps[3].Hash.String(), token)
+
+	page, token, err = paginateSweeps(sweeps, token, 2)
+	require.NoError(t, err)
+	require.Equal(t, sweeps[4:], page)
+	require.Empty(t, token)
+
+	// A token of an unknown sweep is rejected.
+	_, _, err = paginateSweeps(sweeps, chainhash.Hash{9}.String(), 2)
+	require.Error(t, err)
+}
+
+// TestSweepFee tests that the fee of a sweep is calculated from the values of
+// the outputs it spends.
+func TestSweepFee(t *testing.T) {
+	t.Parallel()
+
+	prevTx := wire.NewMsgTx(2)
+	prevTx.AddTxOut(&wire.TxOut{Value: 10_000})
+	prevTx.AddTxOut(&wire.TxOut{Value: 20_000})
+
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  prevTx.TxHash(),
+			Index: 0,
+		},
+	})
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  prevTx.TxHash(),
+			Index: 1,
+		},
+	})
+	sweepTx.AddTxOut(&wire.TxOut{Value: 29_000})
+
+	txns := map[chainhash.Hash]*wire.MsgTx{
+		prevTx.TxHash(): prevTx,
+	}
+
+	fee, ok := sweepFee(sweepTx, txns)
+	require.True(t, ok)
+	require.Equal(t, btcutil.Amount(1_000), fee)
+
+	// If one of the spent outputs is unknown, no fee can be calculated.
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash: chainhash.Hash{1},
+		},
+	})
+	_, ok = sweepFee(sweepTx, txns)
+	require.False(t, ok)
+}
+
+// TestBumpFeeDeadline tests that the deadline of a BumpFeeRequest can be given
+// either relative to the current height or as an absolute height.
+func TestBumpFeeDeadline(t *testing.T) {
+	t.Parallel()
+
+	const currentHeight = 100
+
+	testCases := []struct {
+		name      string
+		req       *BumpFeeRequest
+		expected  fn.Option[int32]
+		expectErr bool
+	}{
+		{
+			name:     "no deadline",
+			req:      &BumpFeeRequest{},
+			expected: fn.None[int32](),
+		},
+		{
+			name: "target conf",
+			req: &BumpFeeRequest{
+				TargetConf: 6,
+			},
+			expected: fn.Some[int32](106),
+		},
+		{
+			name: "deadline height",
+			req: &BumpFeeRequest{
+				DeadlineHeight: 150,
+			},
+			expected: fn.Some[int32](150),
+		},
+		{
+			name: "deadline height not in the future",
+			req: &BumpFeeRequest{
+				DeadlineHeight: currentHeight,
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deadline, err := bumpFeeDeadline(tc.req, currentHeight)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, deadline)
+		})
+	}
+
+	// Setting both a target conf and a deadline height is rejected.
+	_, _, err := validateBumpFeeRequest(&BumpFeeRequest{
+		TargetConf:     6,
+		DeadlineHeight: 150,
+	})
+	require.Error(t, err)
+}
+
+// TestMarshalPendingBatches tests that a preview of the sweeper's batches is
+// converted into its RPC format.
+func TestMarshalPendingBatches(t *testing.T) {
+	t.Parallel()
+
+	txid := chainhash.Hash{1}
+	preview := &sweep.PendingBatches{
+		Batches: []*sweep.PendingBatch{{
+			Inputs:              []wire.OutPoint{{Hash: txid}},
+			Txid:                fn.Some(txid),
+			Weight:              500,
+			Budget:              1_000,
+			DeadlineHeight:      110,
+			NextBroadcastHeight: 101,
+			FeeRates: []sweep.FeeRateProjection{{
+				Height:  101,
+				FeeRate: chainfee.SatPerKWeight(250),
+			}},
+		}},
+		Immature: map[wire.OutPoint]int32{
+			{Index: 1}: 120,
+			{Index: 2}: 105,
+		},
+	}
+
+	resp := marshalPendingBatches(preview)
+	require.Len(t, resp.Batches, 1)
+
+	batch := resp.Batches[0]
+	require.Len(t, batch.Inputs, 1)
+	require.Equal(t, txid.String(), batch.Txid)
+	require.EqualValues(t, 500, batch.Weight)
+	require.EqualValues(t, 1_000, batch.BudgetSat)
+	require.EqualValues(t, 110, batch.DeadlineHeight)
+	require.EqualValues(t, 101, batch.NextBroadcastHeight)
+	require.Equal(t, []*FeeRateProjection{{
+		Height:      101,
+		SatPerVbyte: 1,
+		SatPerKw:    250,
+	}}, batch.FeeRateTrajectory)
+
+	// The immature inputs should be ordered by their mature heights.
+	require.Len(t, resp.ImmatureSweeps, 2)
+	require.EqualValues(t, 105, resp.ImmatureSweeps[0].MatureHeight)
+	require.EqualValues(t, 2, resp.ImmatureSweeps[0].Outpoint.OutputIndex)
+	require.EqualValues(t, 120, resp.ImmatureSweeps[1].MatureHeight)
+}
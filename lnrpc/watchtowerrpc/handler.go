@@ -10,6 +10,7 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
 	"google.golang.org/grpc"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
@@ -29,6 +30,10 @@ var (
 			Entity: "info",
 			Action: "read",
 		}},
+		"/watchtowerrpc.Watchtower/ListClients": {{
+			Entity: "info",
+			Action: "read",
+		}},
 	}
 
 	// ErrTowerNotActive signals that RPC calls cannot be processed because
@@ -179,6 +184,52 @@ func (c *Handler) GetInfo(ctx context.Context,
 	}, nil
 }
 
+// ListClients returns a summary of the data that is stored on the tower for
+// each of its clients, including the amount of disk space used by each of
+// them.
+func (c *Handler) ListClients(_ context.Context,
+	_ *ListClientsRequest) (*ListClientsResponse, error) {
+
+	if err := c.isActive(); err != nil {
+		return nil, err
+	}
+
+	clients, err := c.cfg.Tower.ListClients()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListClientsResponse{
+		Clients: make([]*TowerClient, 0, len(clients)),
+	}
+	for _, client := range clients {
+		resp.Clients = append(resp.Clients, marshallTowerClient(client))
+		resp.TotalDiskUsageBytes += client.DiskUsage
+	}
+
+	return resp, nil
+}
+
+// marshallTowerClient converts a tower client into its RPC representation.
+func marshallTowerClient(client wtdb.TowerClient) *TowerClient {
+	rpcClient := &TowerClient{
+		SessionId:      client.ID[:],
+		BlobType:       client.Policy.BlobType.String(),
+		MaxUpdates:     uint32(client.Policy.MaxUpdates),
+		LastApplied:    uint32(client.LastApplied),
+		NumUpdates:     client.NumUpdates,
+		DiskUsageBytes: client.DiskUsage,
+	}
+	if !client.CreatedAt.IsZero() {
+		rpcClient.CreatedAt = client.CreatedAt.Unix()
+	}
+	if !client.LastUpdate.IsZero() {
+		rpcClient.LastUpdate = client.LastUpdate.Unix()
+	}
+
+	return rpcClient
+}
+
 // isActive returns nil if the tower backend is initialized, and the Handler can
 // process RPC requests.
 func (c *Handler) isActive() error {
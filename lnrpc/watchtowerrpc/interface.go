@@ -4,6 +4,7 @@ import (
 	"net"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
 )
 
 // WatchtowerBackend abstracts access to the watchtower information that is
@@ -20,4 +21,8 @@ type WatchtowerBackend interface {
 	// ExternalIPs returns the addresses where the watchtower can be reached
 	// by clients externally.
 	ExternalIPs() []net.Addr
+
+	// ListClients returns a summary of the data stored on the tower for
+	// each of its clients.
+	ListClients() ([]wtdb.TowerClient, error)
 }
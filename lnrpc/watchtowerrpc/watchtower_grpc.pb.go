@@ -23,6 +23,12 @@ type WatchtowerClient interface {
 	// including its public key and URIs where the server is currently
 	// listening for clients.
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// lncli: `tower clients`
+	// ListClients returns the clients of the watchtower along with the number
+	// of state updates and the disk space that is used by each of them. Since
+	// clients authenticate every session with a fresh key, each session is
+	// listed as a separate client.
+	ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error)
 }
 
 type watchtowerClient struct {
@@ -42,6 +48,15 @@ func (c *watchtowerClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts
 	return out, nil
 }
 
+func (c *watchtowerClient) ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error) {
+	out := new(ListClientsResponse)
+	err := c.cc.Invoke(ctx, "/watchtowerrpc.Watchtower/ListClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WatchtowerServer is the server API for Watchtower service.
 // All implementations must embed UnimplementedWatchtowerServer
 // for forward compatibility
@@ -51,6 +66,12 @@ type WatchtowerServer interface {
 	// including its public key and URIs where the server is currently
 	// listening for clients.
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// lncli: `tower clients`
+	// ListClients returns the clients of the watchtower along with the number
+	// of state updates and the disk space that is used by each of them. Since
+	// clients authenticate every session with a fresh key, each session is
+	// listed as a separate client.
+	ListClients(context.Context, *ListClientsRequest) (*ListClientsResponse, error)
 	mustEmbedUnimplementedWatchtowerServer()
 }
 
@@ -61,6 +82,9 @@ type UnimplementedWatchtowerServer struct {
 func (UnimplementedWatchtowerServer) GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
 }
+func (UnimplementedWatchtowerServer) ListClients(context.Context, *ListClientsRequest) (*ListClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClients not implemented")
+}
 func (UnimplementedWatchtowerServer) mustEmbedUnimplementedWatchtowerServer() {}
 
 // UnsafeWatchtowerServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +116,24 @@ func _Watchtower_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Watchtower_ListClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchtowerServer).ListClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/watchtowerrpc.Watchtower/ListClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchtowerServer).ListClients(ctx, req.(*ListClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Watchtower_ServiceDesc is the grpc.ServiceDesc for Watchtower service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +145,10 @@ var Watchtower_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInfo",
 			Handler:    _Watchtower_GetInfo_Handler,
 		},
+		{
+			MethodName: "ListClients",
+			Handler:    _Watchtower_ListClients_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "watchtowerrpc/watchtower.proto",
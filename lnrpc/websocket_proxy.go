@@ -43,6 +43,10 @@ const (
 	// decode in the gRPC <-> WS proxy. gRPC has a similar setting used
 	// elsewhere.
 	MaxWsMsgSize = 4 * 1024 * 1024
+
+	// closeWriteTimeout is the maximum time we wait for the close message
+	// to be written once a stream ended.
+	closeWriteTimeout = time.Second
 )
 
 var (
@@ -144,9 +148,24 @@ func (p *WebsocketProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (p *WebsocketProxy) upgradeToWebSocketProxy(w http.ResponseWriter,
 	r *http.Request) {
 
-	conn, err := p.upgrader.Upgrade(w, r, nil)
+	// Allow certain headers to be forwarded, either from source headers
+	// or the special Sec-Websocket-Protocol header field.
+	forwardedHeaders := make(http.Header)
+	protocol := forwardHeaders(r.Header, forwardedHeaders)
+
+	// Browsers fail the WebSocket handshake if the server doesn't select
+	// any of the protocols they requested. So if the macaroon was sent as
+	// a protocol, we need to select that protocol.
+	var responseHeader http.Header
+	if protocol != "" {
+		responseHeader = http.Header{
+			HeaderWebSocketProtocol: []string{protocol},
+		}
+	}
+
+	conn, err := p.upgrader.Upgrade(w, r, responseHeader)
 	if err != nil {
-		p.logger.Errorf("error upgrading websocket:", err)
+		p.logger.Errorf("error upgrading websocket: %v", err)
 		return
 	}
 	defer func() {
@@ -165,13 +184,10 @@ func (p *WebsocketProxy) upgradeToWebSocketProxy(w http.ResponseWriter,
 		ctx, r.Method, r.URL.String(), requestForwarder,
 	)
 	if err != nil {
-		p.logger.Errorf("WS: error preparing request:", err)
+		p.logger.Errorf("WS: error preparing request: %v", err)
 		return
 	}
-
-	// Allow certain headers to be forwarded, either from source headers
-	// or the special Sec-Websocket-Protocol header field.
-	forwardHeaders(r.Header, request.Header)
+	request.Header = forwardedHeaders
 
 	// Also allow the target request method to be overwritten, as all
 	// WebSocket establishment calls MUST be GET requests.
@@ -354,13 +370,24 @@ func (p *WebsocketProxy) upgradeToWebSocketProxy(w http.ResponseWriter,
 	if err := responseForwarder.Err(); err != nil && !IsClosedConnError(err) {
 		p.logger.Errorf("WS: scanner err: %v", err)
 	}
+
+	// Let the client know the stream ended on our side, so it can tell a
+	// completed RPC apart from a connection that was lost. If the client
+	// closed the connection itself, this fails silently.
+	_ = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(closeWriteTimeout),
+	)
 }
 
 // forwardHeaders forwards certain allowed header fields from the source request
 // to the target request. Because browsers are limited in what header fields
 // they can send on the WebSocket setup call, we also allow additional fields to
-// be transported in the special Sec-Websocket-Protocol field.
-func forwardHeaders(source, target http.Header) {
+// be transported in the special Sec-Websocket-Protocol field. The protocol an
+// additional field was read from is returned, so it can be selected in the
+// handshake response.
+func forwardHeaders(source, target http.Header) string {
 	// Forward allowed header fields directly.
 	for header := range source {
 		headerName := textproto.CanonicalMIMEHeaderKey(header)
@@ -373,19 +400,33 @@ func forwardHeaders(source, target http.Header) {
 	// Browser aren't allowed to set custom header fields on WebSocket
 	// requests. We need to allow them to submit the macaroon as a WS
 	// protocol, which is the only allowed header. Set any "protocols" we
-	// declare valid as header fields on the forwarded request.
-	protocol := source.Get(HeaderWebSocketProtocol)
-	for key := range defaultProtocolsToAllow {
-		if strings.HasPrefix(protocol, key) {
-			// The format is "<protocol name>+<value>". We know the
-			// protocol string starts with the name so we only need
-			// to set the value.
-			values := strings.Split(
+	// declare valid as header fields on the forwarded request. Clients can
+	// request multiple protocols, either comma separated or in multiple
+	// header fields.
+	var selectedProtocol string
+	for _, protocols := range source.Values(HeaderWebSocketProtocol) {
+		for _, protocol := range strings.Split(protocols, ",") {
+			protocol = strings.TrimSpace(protocol)
+
+			// The format is "<protocol name>+<value>".
+			key, value, found := strings.Cut(
 				protocol, WebSocketProtocolDelimiter,
 			)
-			target.Set(key, values[1])
+			if !found || value == "" {
+				continue
+			}
+			if !defaultProtocolsToAllow[key] {
+				continue
+			}
+
+			target.Set(key, value)
+			if selectedProtocol == "" {
+				selectedProtocol = protocol
+			}
 		}
 	}
+
+	return selectedProtocol
 }
 
 // newRequestForwardingReader creates a new request forwarding pipe.
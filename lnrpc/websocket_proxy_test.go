@@ -0,0 +1,150 @@
+package lnrpc
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btclog"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForwardHeaders tests that the allowed header fields are forwarded and
+// that the macaroon can be sent as a WebSocket protocol.
+func TestForwardHeaders(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		header           http.Header
+		expectedMacaroon string
+		expectedProtocol string
+	}{{
+		name: "header field",
+		header: http.Header{
+			"Grpc-Metadata-Macaroon": []string{"0201"},
+		},
+		expectedMacaroon: "0201",
+	}, {
+		name: "single protocol",
+		header: http.Header{
+			HeaderWebSocketProtocol: []string{
+				"Grpc-Metadata-Macaroon+0201",
+			},
+		},
+		expectedMacaroon: "0201",
+		expectedProtocol: "Grpc-Metadata-Macaroon+0201",
+	}, {
+		name: "comma separated protocols",
+		header: http.Header{
+			HeaderWebSocketProtocol: []string{
+				"other, Grpc-Metadata-Macaroon+0201",
+			},
+		},
+		expectedMacaroon: "0201",
+		expectedProtocol: "Grpc-Metadata-Macaroon+0201",
+	}, {
+		name: "multiple protocol fields",
+		header: http.Header{
+			HeaderWebSocketProtocol: []string{
+				"other", "Grpc-Metadata-Macaroon+0201",
+			},
+		},
+		expectedMacaroon: "0201",
+		expectedProtocol: "Grpc-Metadata-Macaroon+0201",
+	}, {
+		name: "protocol without value",
+		header: http.Header{
+			HeaderWebSocketProtocol: []string{
+				"Grpc-Metadata-Macaroon",
+			},
+		},
+	}, {
+		name: "unknown protocol",
+		header: http.Header{
+			HeaderWebSocketProtocol: []string{"Unknown+0201"},
+		},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := make(http.Header)
+			protocol := forwardHeaders(tc.header, target)
+
+			require.Equal(t, tc.expectedProtocol, protocol)
+			require.Equal(
+				t, tc.expectedMacaroon,
+				target.Get("Grpc-Metadata-Macaroon"),
+			)
+		})
+	}
+}
+
+// TestWebSocketProxyStream tests that a server-streaming response is proxied
+// to the WebSocket, that the protocol the macaroon was sent in is selected and
+// that the WebSocket is closed normally once the stream ends.
+func TestWebSocketProxyStream(t *testing.T) {
+	t.Parallel()
+
+	backend := http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+
+		// Read the initial request message the client sent.
+		request, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(r.Method + "\n"))
+		_, _ = w.Write([]byte(r.Header.Get("Grpc-Metadata-Macaroon")))
+		_, _ = w.Write([]byte("\n"))
+		_, _ = w.Write(request)
+	})
+
+	proxy := NewWebSocketProxy(
+		backend, btclog.Disabled, time.Minute, time.Second,
+		[]*regexp.Regexp{},
+	)
+	server := httptest.NewServer(proxy)
+	t.Cleanup(server.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") +
+		"/v2/stream?method=POST"
+	dialer := websocket.Dialer{
+		Subprotocols: []string{"Grpc-Metadata-Macaroon+0201"},
+	}
+	conn, resp, err := dialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		_ = resp.Body.Close()
+	})
+
+	// Browsers only accept the handshake if one of the requested protocols
+	// is selected.
+	require.Equal(t, "Grpc-Metadata-Macaroon+0201", conn.Subprotocol())
+
+	err = conn.WriteMessage(websocket.TextMessage, []byte("{}"))
+	require.NoError(t, err)
+
+	for _, expected := range []string{"POST", "0201", "{}"} {
+		_, msg, err := conn.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, expected, string(msg))
+	}
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(
+		err, websocket.CloseNormalClosure,
+	), "unexpected error: %v", err)
+}
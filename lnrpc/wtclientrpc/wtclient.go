@@ -52,6 +52,10 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/wtclientrpc.WatchtowerClient/MigrateSessions": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/wtclientrpc.WatchtowerClient/ListTowers": {{
 			Entity: "offchain",
 			Action: "read",
@@ -64,6 +68,10 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/wtclientrpc.WatchtowerClient/SubscribeTowerHealth": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/wtclientrpc.WatchtowerClient/Policy": {{
 			Entity: "offchain",
 			Action: "read",
@@ -313,6 +321,40 @@ func (c *WatchtowerClient) TerminateSession(_ context.Context,
 	}, nil
 }
 
+// MigrateSessions re-backs up the latest revoked state of all channels to the
+// given tower. If a tower to migrate from is provided, its sessions are
+// terminated and the tower is deactivated first.
+func (c *WatchtowerClient) MigrateSessions(_ context.Context,
+	req *MigrateSessionsRequest) (*MigrateSessionsResponse, error) {
+
+	if err := c.isActive(); err != nil {
+		return nil, err
+	}
+
+	toPubKey, err := btcec.ParsePubKey(req.ToPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromPubKey *btcec.PublicKey
+	if len(req.FromPubkey) > 0 {
+		fromPubKey, err = btcec.ParsePubKey(req.FromPubkey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	migration, err := c.cfg.ClientMgr.MigrateSessions(toPubKey, fromPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MigrateSessionsResponse{
+		NumSessionsRetired: uint32(migration.NumSessionsRetired),
+		NumBackupsQueued:   uint32(migration.NumBackupsQueued),
+	}, nil
+}
+
 // ListTowers returns the list of watchtowers registered with the client.
 func (c *WatchtowerClient) ListTowers(ctx context.Context,
 	req *ListTowersRequest) (*ListTowersResponse, error) {
@@ -482,15 +524,98 @@ func (c *WatchtowerClient) Stats(_ context.Context,
 
 	stats := c.cfg.ClientMgr.Stats()
 
+	towerHealth := c.cfg.ClientMgr.TowerHealth()
+	rpcTowerHealth := make([]*TowerHealth, 0, len(towerHealth))
+	for _, health := range towerHealth {
+		rpcTowerHealth = append(
+			rpcTowerHealth, marshallTowerHealth(health),
+		)
+	}
+
 	return &StatsResponse{
 		NumBackups:           uint32(stats.NumTasksAccepted),
 		NumFailedBackups:     uint32(stats.NumTasksIneligible),
 		NumPendingBackups:    uint32(stats.NumTasksPending),
 		NumSessionsAcquired:  uint32(stats.NumSessionsAcquired),
 		NumSessionsExhausted: uint32(stats.NumSessionsExhausted),
+		TowerHealth:          rpcTowerHealth,
 	}, nil
 }
 
+// SubscribeTowerHealth returns a stream that receives an update every time a
+// watchtower becomes reachable or unreachable.
+func (c *WatchtowerClient) SubscribeTowerHealth(
+	_ *SubscribeTowerHealthRequest,
+	stream WatchtowerClient_SubscribeTowerHealthServer) error {
+
+	if err := c.isActive(); err != nil {
+		return err
+	}
+
+	sub, err := c.cfg.ClientMgr.SubscribeTowerHealth()
+	if err != nil {
+		return err
+	}
+	defer sub.Cancel()
+
+	for {
+		select {
+		case update := <-sub.Updates():
+			health, ok := update.(wtclient.TowerHealth)
+			if !ok {
+				return fmt.Errorf("unexpected tower health "+
+					"update: %T", update)
+			}
+
+			err := stream.Send(marshallTowerHealth(health))
+			if err != nil {
+				return err
+			}
+
+		case <-sub.Quit():
+			return errors.New("tower health subscription " +
+				"terminated")
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// marshallTowerHealth converts the health statistics of a tower into their
+// RPC representation.
+func marshallTowerHealth(health wtclient.TowerHealth) *TowerHealth {
+	var status TowerHealthStatus
+	switch health.Status {
+	case wtclient.TowerReachable:
+		status = TowerHealthStatus_REACHABLE
+
+	case wtclient.TowerUnreachable:
+		status = TowerHealthStatus_UNREACHABLE
+
+	default:
+		status = TowerHealthStatus_UNKNOWN
+	}
+
+	rpcHealth := &TowerHealth{
+		Pubkey:              health.IdentityKey.SerializeCompressed(),
+		Status:              status,
+		ProbeLatencyMs:      uint64(health.ProbeLatency.Milliseconds()),
+		NumBackupsAcked:     health.NumBackupsAcked,
+		BackupRoundTripMs:   uint64(health.BackupRoundTrip.Milliseconds()),
+		ConsecutiveFailures: health.ConsecutiveFailures,
+		LastError:           health.LastError,
+	}
+	if !health.LastStatusChange.IsZero() {
+		rpcHealth.LastStatusChange = health.LastStatusChange.Unix()
+	}
+	if !health.LastProbe.IsZero() {
+		rpcHealth.LastProbe = health.LastProbe.Unix()
+	}
+
+	return rpcHealth
+}
+
 // Policy returns the active watchtower client policy configuration.
 func (c *WatchtowerClient) Policy(ctx context.Context,
 	req *PolicyRequest) (*PolicyResponse, error) {
@@ -39,6 +39,13 @@ type WatchtowerClientClient interface {
 	// Terminate terminates the given session and marks it as terminal so that
 	// it is not used for backups anymore.
 	TerminateSession(ctx context.Context, in *TerminateSessionRequest, opts ...grpc.CallOption) (*TerminateSessionResponse, error)
+	// lncli: `wtclient migrate`
+	// MigrateSessions re-backs up the latest revoked state of all channels to
+	// the given tower, so that they are protected by it and not only the states
+	// that are created after the tower was added. If a tower to migrate from is
+	// provided, its sessions are terminated and the tower is deactivated first.
+	// The tower to migrate to must have been added with AddTower before.
+	MigrateSessions(ctx context.Context, in *MigrateSessionsRequest, opts ...grpc.CallOption) (*MigrateSessionsResponse, error)
 	// lncli: `wtclient towers`
 	// ListTowers returns the list of watchtowers registered with the client.
 	ListTowers(ctx context.Context, in *ListTowersRequest, opts ...grpc.CallOption) (*ListTowersResponse, error)
@@ -48,6 +55,11 @@ type WatchtowerClientClient interface {
 	// lncli: `wtclient stats`
 	// Stats returns the in-memory statistics of the client since startup.
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// lncli: `wtclient subscribehealth`
+	// SubscribeTowerHealth returns a stream that receives an update every time a
+	// watchtower becomes reachable or unreachable, as determined by the periodic
+	// tower probes and the backups sent to the tower.
+	SubscribeTowerHealth(ctx context.Context, in *SubscribeTowerHealthRequest, opts ...grpc.CallOption) (WatchtowerClient_SubscribeTowerHealthClient, error)
 	// lncli: `wtclient policy`
 	// Policy returns the active watchtower client policy configuration.
 	Policy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*PolicyResponse, error)
@@ -97,6 +109,15 @@ func (c *watchtowerClientClient) TerminateSession(ctx context.Context, in *Termi
 	return out, nil
 }
 
+func (c *watchtowerClientClient) MigrateSessions(ctx context.Context, in *MigrateSessionsRequest, opts ...grpc.CallOption) (*MigrateSessionsResponse, error) {
+	out := new(MigrateSessionsResponse)
+	err := c.cc.Invoke(ctx, "/wtclientrpc.WatchtowerClient/MigrateSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *watchtowerClientClient) ListTowers(ctx context.Context, in *ListTowersRequest, opts ...grpc.CallOption) (*ListTowersResponse, error) {
 	out := new(ListTowersResponse)
 	err := c.cc.Invoke(ctx, "/wtclientrpc.WatchtowerClient/ListTowers", in, out, opts...)
@@ -124,6 +145,38 @@ func (c *watchtowerClientClient) Stats(ctx context.Context, in *StatsRequest, op
 	return out, nil
 }
 
+func (c *watchtowerClientClient) SubscribeTowerHealth(ctx context.Context, in *SubscribeTowerHealthRequest, opts ...grpc.CallOption) (WatchtowerClient_SubscribeTowerHealthClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WatchtowerClient_ServiceDesc.Streams[0], "/wtclientrpc.WatchtowerClient/SubscribeTowerHealth", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &watchtowerClientSubscribeTowerHealthClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WatchtowerClient_SubscribeTowerHealthClient interface {
+	Recv() (*TowerHealth, error)
+	grpc.ClientStream
+}
+
+type watchtowerClientSubscribeTowerHealthClient struct {
+	grpc.ClientStream
+}
+
+func (x *watchtowerClientSubscribeTowerHealthClient) Recv() (*TowerHealth, error) {
+	m := new(TowerHealth)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *watchtowerClientClient) Policy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*PolicyResponse, error) {
 	out := new(PolicyResponse)
 	err := c.cc.Invoke(ctx, "/wtclientrpc.WatchtowerClient/Policy", in, out, opts...)
@@ -158,6 +211,13 @@ type WatchtowerClientServer interface {
 	// Terminate terminates the given session and marks it as terminal so that
 	// it is not used for backups anymore.
 	TerminateSession(context.Context, *TerminateSessionRequest) (*TerminateSessionResponse, error)
+	// lncli: `wtclient migrate`
+	// MigrateSessions re-backs up the latest revoked state of all channels to
+	// the given tower, so that they are protected by it and not only the states
+	// that are created after the tower was added. If a tower to migrate from is
+	// provided, its sessions are terminated and the tower is deactivated first.
+	// The tower to migrate to must have been added with AddTower before.
+	MigrateSessions(context.Context, *MigrateSessionsRequest) (*MigrateSessionsResponse, error)
 	// lncli: `wtclient towers`
 	// ListTowers returns the list of watchtowers registered with the client.
 	ListTowers(context.Context, *ListTowersRequest) (*ListTowersResponse, error)
@@ -167,6 +227,11 @@ type WatchtowerClientServer interface {
 	// lncli: `wtclient stats`
 	// Stats returns the in-memory statistics of the client since startup.
 	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// lncli: `wtclient subscribehealth`
+	// SubscribeTowerHealth returns a stream that receives an update every time a
+	// watchtower becomes reachable or unreachable, as determined by the periodic
+	// tower probes and the backups sent to the tower.
+	SubscribeTowerHealth(*SubscribeTowerHealthRequest, WatchtowerClient_SubscribeTowerHealthServer) error
 	// lncli: `wtclient policy`
 	// Policy returns the active watchtower client policy configuration.
 	Policy(context.Context, *PolicyRequest) (*PolicyResponse, error)
@@ -189,6 +254,9 @@ func (UnimplementedWatchtowerClientServer) DeactivateTower(context.Context, *Dea
 func (UnimplementedWatchtowerClientServer) TerminateSession(context.Context, *TerminateSessionRequest) (*TerminateSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TerminateSession not implemented")
 }
+func (UnimplementedWatchtowerClientServer) MigrateSessions(context.Context, *MigrateSessionsRequest) (*MigrateSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateSessions not implemented")
+}
 func (UnimplementedWatchtowerClientServer) ListTowers(context.Context, *ListTowersRequest) (*ListTowersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTowers not implemented")
 }
@@ -198,6 +266,9 @@ func (UnimplementedWatchtowerClientServer) GetTowerInfo(context.Context, *GetTow
 func (UnimplementedWatchtowerClientServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
 }
+func (UnimplementedWatchtowerClientServer) SubscribeTowerHealth(*SubscribeTowerHealthRequest, WatchtowerClient_SubscribeTowerHealthServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTowerHealth not implemented")
+}
 func (UnimplementedWatchtowerClientServer) Policy(context.Context, *PolicyRequest) (*PolicyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Policy not implemented")
 }
@@ -286,6 +357,24 @@ func _WatchtowerClient_TerminateSession_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WatchtowerClient_MigrateSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchtowerClientServer).MigrateSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wtclientrpc.WatchtowerClient/MigrateSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchtowerClientServer).MigrateSessions(ctx, req.(*MigrateSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WatchtowerClient_ListTowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListTowersRequest)
 	if err := dec(in); err != nil {
@@ -340,6 +429,27 @@ func _WatchtowerClient_Stats_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WatchtowerClient_SubscribeTowerHealth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTowerHealthRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WatchtowerClientServer).SubscribeTowerHealth(m, &watchtowerClientSubscribeTowerHealthServer{stream})
+}
+
+type WatchtowerClient_SubscribeTowerHealthServer interface {
+	Send(*TowerHealth) error
+	grpc.ServerStream
+}
+
+type watchtowerClientSubscribeTowerHealthServer struct {
+	grpc.ServerStream
+}
+
+func (x *watchtowerClientSubscribeTowerHealthServer) Send(m *TowerHealth) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WatchtowerClient_Policy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PolicyRequest)
 	if err := dec(in); err != nil {
@@ -381,6 +491,10 @@ var WatchtowerClient_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TerminateSession",
 			Handler:    _WatchtowerClient_TerminateSession_Handler,
 		},
+		{
+			MethodName: "MigrateSessions",
+			Handler:    _WatchtowerClient_MigrateSessions_Handler,
+		},
 		{
 			MethodName: "ListTowers",
 			Handler:    _WatchtowerClient_ListTowers_Handler,
@@ -398,6 +512,12 @@ var WatchtowerClient_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _WatchtowerClient_Policy_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTowerHealth",
+			Handler:       _WatchtowerClient_SubscribeTowerHealth_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "wtclientrpc/wtclient.proto",
 }
@@ -1,5 +1,5 @@
-//go:build bitcoind
-// +build bitcoind
+//go:build bitcoind || chainbackends
+// +build bitcoind chainbackends
 
 package lntest
 
@@ -17,9 +17,6 @@ import (
 	"github.com/lightningnetwork/lnd/lntest/port"
 )
 
-// logDirPattern is the pattern of the name of the temporary log directory.
-const logDirPattern = "%s/.backendlogs"
-
 // BitcoindBackendConfig is an implementation of the BackendConfig interface
 // backed by a Bitcoind node.
 type BitcoindBackendConfig struct {
@@ -85,12 +82,30 @@ func (b BitcoindBackendConfig) Name() string {
 	return "bitcoind"
 }
 
-// newBackend starts a bitcoind node with the given extra parameters and returns
-// a BitcoindBackendConfig for that node.
-func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
+// NewBitcoindBackend starts a bitcoind node with the txindex enabled and
+// returns a BitcoindBackendConfig for that node. If rpcPolling is set, lnd will
+// be configured to poll the bitcoind RPC for new blocks and transactions
+// instead of using ZMQ.
+func NewBitcoindBackend(miner string, netParams *chaincfg.Params,
 	rpcPolling bool) (*BitcoindBackendConfig, func() error, error) {
 
-	baseLogDir := fmt.Sprintf(logDirPattern, node.GetLogDir())
+	extraArgs := []string{
+		"-debug",
+		"-regtest",
+		"-txindex",
+		"-disablewallet",
+	}
+
+	return newBitcoindBackend(miner, netParams, extraArgs, rpcPolling)
+}
+
+// newBitcoindBackend starts a bitcoind node with the given extra parameters
+// and returns a BitcoindBackendConfig for that node.
+func newBitcoindBackend(miner string, netParams *chaincfg.Params,
+	extraArgs []string, rpcPolling bool) (*BitcoindBackendConfig,
+	func() error, error) {
+
+	baseLogDir, logSuffix := nextBackendLogDir()
 	if netParams != &chaincfg.RegressionNetParams {
 		return nil, nil, fmt.Errorf("only regtest supported")
 	}
@@ -148,7 +163,8 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
 		// After shutting down the chain backend, we'll make a copy of
 		// the log file before deleting the temporary log dir.
 		logDestination := fmt.Sprintf(
-			"%s/output_bitcoind_chainbackend.log", node.GetLogDir(),
+			"%s/output_bitcoind_chainbackend%s.log",
+			node.GetLogDir(), logSuffix,
 		)
 		err := node.CopyFile(logDestination, logFile)
 		if err != nil {
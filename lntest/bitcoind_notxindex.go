@@ -18,5 +18,5 @@ func NewBackend(miner string, netParams *chaincfg.Params) (
 		"-disablewallet",
 	}
 
-	return newBackend(miner, netParams, extraArgs, false)
+	return newBitcoindBackend(miner, netParams, extraArgs, false)
 }
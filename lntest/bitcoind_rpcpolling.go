@@ -12,12 +12,5 @@ import (
 func NewBackend(miner string, netParams *chaincfg.Params) (
 	*BitcoindBackendConfig, func() error, error) {
 
-	extraArgs := []string{
-		"-debug",
-		"-regtest",
-		"-txindex",
-		"-disablewallet",
-	}
-
-	return newBackend(miner, netParams, extraArgs, true)
+	return NewBitcoindBackend(miner, netParams, true)
 }
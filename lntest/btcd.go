@@ -1,5 +1,5 @@
-//go:build !bitcoind && !neutrino
-// +build !bitcoind,!neutrino
+//go:build (!bitcoind && !neutrino) || chainbackends
+// +build !bitcoind,!neutrino chainbackends
 
 package lntest
 
@@ -17,9 +17,6 @@ import (
 	"github.com/lightningnetwork/lnd/lntest/node"
 )
 
-// logDirPattern is the pattern of the name of the temporary log directory.
-const logDirPattern = "%s/.backendlogs"
-
 // BtcdBackendConfig is an implementation of the BackendConfig interface
 // backed by a btcd node.
 type BtcdBackendConfig struct {
@@ -71,13 +68,13 @@ func (b BtcdBackendConfig) Name() string {
 	return "btcd"
 }
 
-// NewBackend starts a new rpctest.Harness and returns a BtcdBackendConfig for
-// that node. miner should be set to the P2P address of the miner to connect
-// to.
-func NewBackend(miner string, netParams *chaincfg.Params) (
+// NewBtcdBackend starts a new rpctest.Harness and returns a BtcdBackendConfig
+// for that node. miner should be set to the P2P address of the miner to
+// connect to.
+func NewBtcdBackend(miner string, netParams *chaincfg.Params) (
 	*BtcdBackendConfig, func() error, error) {
 
-	baseLogDir := fmt.Sprintf(logDirPattern, node.GetLogDir())
+	baseLogDir, logSuffix := nextBackendLogDir()
 	args := []string{
 		"--rejectnonstd",
 		"--txindex",
@@ -145,7 +142,7 @@ func NewBackend(miner string, netParams *chaincfg.Params) (
 			logFile := fmt.Sprintf("%s/%s", logDir, file.Name())
 			newFilename := strings.Replace(
 				file.Name(), "btcd.log",
-				"output_btcd_chainbackend.log", 1,
+				"output_btcd_chainbackend"+logSuffix+".log", 1,
 			)
 			logDestination := fmt.Sprintf(
 				"%s/%s", node.GetLogDir(), newFilename,
@@ -0,0 +1,17 @@
+//go:build !bitcoind && !neutrino
+// +build !bitcoind,!neutrino
+
+package lntest
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// NewBackend starts a new rpctest.Harness and returns a BtcdBackendConfig for
+// that node. miner should be set to the P2P address of the miner to connect
+// to.
+func NewBackend(miner string, netParams *chaincfg.Params) (
+	*BtcdBackendConfig, func() error, error) {
+
+	return NewBtcdBackend(miner, netParams)
+}
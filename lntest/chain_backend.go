@@ -0,0 +1,73 @@
+package lntest
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/lntest/node"
+)
+
+// logDirPattern is the pattern of the name of the temporary log directory.
+const logDirPattern = "%s/.backendlogs"
+
+// ChainBackendType is the type of chain backend an lnd node can be connected
+// to.
+type ChainBackendType uint8
+
+const (
+	// ChainBackendBtcd is a btcd node.
+	ChainBackendBtcd ChainBackendType = iota
+
+	// ChainBackendBitcoind is a bitcoind node that notifies lnd about new
+	// blocks and transactions via ZMQ.
+	ChainBackendBitcoind
+
+	// ChainBackendBitcoindRPCPolling is a bitcoind node that is polled by
+	// lnd for new blocks and transactions.
+	ChainBackendBitcoindRPCPolling
+
+	// ChainBackendNeutrino is a neutrino light client running inside lnd
+	// that connects to the miner directly.
+	ChainBackendNeutrino
+)
+
+// String returns a human readable name of the chain backend type.
+func (c ChainBackendType) String() string {
+	switch c {
+	case ChainBackendBtcd:
+		return "btcd"
+
+	case ChainBackendBitcoind:
+		return "bitcoind"
+
+	case ChainBackendBitcoindRPCPolling:
+		return "bitcoind-rpcpolling"
+
+	case ChainBackendNeutrino:
+		return NeutrinoBackendName
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// backendCounter is a monotonically increasing counter that's used to give
+// each chain backend started by this process its own log files.
+var backendCounter uint32
+
+// nextBackendLogDir returns the temporary log directory for a new chain
+// backend and the suffix to be added to the name of its copied log file. The
+// first backend uses the plain names, any additional backend gets its counter
+// value appended so that the logs of the backends don't overwrite each other.
+func nextBackendLogDir() (string, string) {
+	baseLogDir := fmt.Sprintf(logDirPattern, node.GetLogDir())
+
+	id := atomic.AddUint32(&backendCounter, 1) - 1
+	if id == 0 {
+		return baseLogDir, ""
+	}
+
+	suffix := fmt.Sprintf("_%d", id)
+
+	return baseLogDir + suffix, suffix
+}
@@ -0,0 +1,37 @@
+//go:build chainbackends
+// +build chainbackends
+
+package lntest
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/lntest/node"
+)
+
+// NewChainBackend starts a new chain backend of the given type, and returns
+// its BackendConfig together with a cleanup function that stops it. miner
+// should be set to the P2P address of the miner to connect to. All chain
+// backends are only compiled in when building with the chainbackends tag.
+func NewChainBackend(backendType ChainBackendType, miner string,
+	netParams *chaincfg.Params) (node.BackendConfig, func() error, error) {
+
+	switch backendType {
+	case ChainBackendBtcd:
+		return NewBtcdBackend(miner, netParams)
+
+	case ChainBackendBitcoind:
+		return NewBitcoindBackend(miner, netParams, false)
+
+	case ChainBackendBitcoindRPCPolling:
+		return NewBitcoindBackend(miner, netParams, true)
+
+	case ChainBackendNeutrino:
+		return NewNeutrinoBackend(miner, netParams)
+
+	default:
+		return nil, nil, fmt.Errorf("unknown chain backend type: %v",
+			backendType)
+	}
+}
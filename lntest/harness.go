@@ -101,6 +101,10 @@ type HarnessTest struct {
 	// chainBackend.
 	stopChainBackend func()
 
+	// extraChainBackends holds the cleanup functions of the chain
+	// backends started by the test in addition to the default one.
+	extraChainBackends []func()
+
 	// cleaned specifies whether the cleanup has been applied for the
 	// current HarnessTest.
 	cleaned bool
@@ -364,6 +368,9 @@ func (h *HarnessTest) Stop() {
 	err := h.feeService.Stop()
 	require.NoError(h, err, "failed to stop fee service")
 
+	// Stop the extra chain backends started by the test.
+	h.stopExtraChainBackends()
+
 	// Stop the chainBackend.
 	h.stopChainBackend()
 
@@ -443,6 +450,8 @@ func (h *HarnessTest) Subtest(t *testing.T) *HarnessTest {
 		if st.Failed() {
 			st.Log("test failed, skipped cleanup")
 			st.shutdownAllNodes()
+			st.stopExtraChainBackends()
+
 			return
 		}
 
@@ -451,6 +460,8 @@ func (h *HarnessTest) Subtest(t *testing.T) *HarnessTest {
 		// test. For instance, a `Subtest(st)`.
 		if st.cleaned {
 			st.Log("test already cleaned, skipped cleanup")
+			st.stopExtraChainBackends()
+
 			return
 		}
 
@@ -463,6 +474,10 @@ func (h *HarnessTest) Subtest(t *testing.T) *HarnessTest {
 		// If found running nodes, shut them down.
 		st.shutdownNonStandbyNodes()
 
+		// Now that no node is using them anymore, stop the chain
+		// backends started by the test.
+		st.stopExtraChainBackends()
+
 		// We require the mempool to be cleaned from the test.
 		require.Empty(st, st.Miner.GetRawMempool(), "mempool not "+
 			"cleaned, please mine blocks to clean them all.")
@@ -586,6 +601,35 @@ func (h *HarnessTest) NewNode(name string,
 	return node
 }
 
+// NewNodeWithChainBackend creates a new node that uses the given chain
+// backend, which is usually created by NewChainBackend, and asserts its
+// creation. The node is guaranteed to have finished its initialization and all
+// its subservers are started.
+func (h *HarnessTest) NewNodeWithChainBackend(name string, extraArgs []string,
+	chainBackend node.BackendConfig) *node.HarnessNode {
+
+	node, err := h.manager.newNodeWithBackend(
+		h.T, name, extraArgs, nil, false, chainBackend,
+	)
+	require.NoErrorf(h, err, "unable to create new node for %s", name)
+
+	// Start the node.
+	err = node.Start(h.runCtx)
+	require.NoError(h, err, "failed to start node %s", node.Name())
+
+	return node
+}
+
+// stopExtraChainBackends stops all the chain backends started by the test via
+// NewChainBackend.
+func (h *HarnessTest) stopExtraChainBackends() {
+	for _, stop := range h.extraChainBackends {
+		stop()
+	}
+
+	h.extraChainBackends = nil
+}
+
 // Shutdown shuts down the given node and asserts that no errors occur.
 func (h *HarnessTest) Shutdown(node *node.HarnessNode) {
 	// The process may not be in a state to always shutdown immediately, so
@@ -657,7 +701,10 @@ func (h *HarnessTest) RestartNodeWithChanBackups(hn *node.HarnessNode,
 	h.WaitForBlockchainSync(hn)
 }
 
-// RestartNodeWithExtraArgs updates the node's config and restarts it.
+// RestartNodeWithExtraArgs updates the node's config and restarts it. The
+// node's data dir and wallet are preserved, so this can be used to test
+// changing the config of an existing node, e.g. an upgrade path. The given
+// args replace the node's current extra args.
 func (h *HarnessTest) RestartNodeWithExtraArgs(hn *node.HarnessNode,
 	extraArgs []string) {
 
@@ -0,0 +1,37 @@
+//go:build chainbackends
+// +build chainbackends
+
+package lntest
+
+import (
+	"github.com/lightningnetwork/lnd/lntest/node"
+	"github.com/lightningnetwork/lnd/lntest/wait"
+	"github.com/stretchr/testify/require"
+)
+
+// NewChainBackend starts a chain backend of the given type in addition to the
+// default one used by the test and connects it to the miner. Nodes can be
+// attached to it using NewNodeWithChainBackend. The backend is stopped when
+// the test finishes, after all the nodes have been shut down.
+func (h *HarnessTest) NewChainBackend(
+	backendType ChainBackendType) node.BackendConfig {
+
+	chainBackend, cleanUp, err := NewChainBackend(
+		backendType, h.Miner.P2PAddress(), harnessNetParams,
+	)
+	require.NoErrorf(h, err, "unable to start %v chain backend",
+		backendType)
+
+	h.extraChainBackends = append(h.extraChainBackends, func() {
+		require.NoErrorf(h, cleanUp(), "unable to stop %v chain "+
+			"backend", backendType)
+	})
+
+	// Give the chain backend some time to fully start up, re-trying if any
+	// errors in connecting to the miner are encountered.
+	err = wait.NoError(chainBackend.ConnectMiner, DefaultTimeout)
+	require.NoErrorf(h, err, "unable to connect %v chain backend to "+
+		"miner", backendType)
+
+	return chainBackend
+}
@@ -77,11 +77,22 @@ func (nm *nodeManager) nextNodeID() uint32 {
 func (nm *nodeManager) newNode(t *testing.T, name string, extraArgs []string,
 	password []byte, noAuth bool) (*node.HarnessNode, error) {
 
+	return nm.newNodeWithBackend(
+		t, name, extraArgs, password, noAuth, nm.chainBackend,
+	)
+}
+
+// newNodeWithBackend initializes a new HarnessNode that uses the given chain
+// backend instead of the default one of the node manager.
+func (nm *nodeManager) newNodeWithBackend(t *testing.T, name string,
+	extraArgs []string, password []byte, noAuth bool,
+	chainBackend node.BackendConfig) (*node.HarnessNode, error) {
+
 	cfg := &node.BaseNodeConfig{
 		Name:              name,
 		LogFilenamePrefix: nm.currentTestCase,
 		Password:          password,
-		BackendCfg:        nm.chainBackend,
+		BackendCfg:        chainBackend,
 		ExtraArgs:         extraArgs,
 		FeeURL:            nm.feeServiceURL,
 		DBBackend:         nm.dbBackend,
@@ -46,7 +46,7 @@ func (w *WalletController) FetchInputInfo(
 	utxo := &lnwallet.Utxo{
 		AddressType:   lnwallet.WitnessPubKey,
 		Value:         10 * btcutil.SatoshiPerBitcoin,
-		PkScript:      []byte("dummy"),
+		PkScript:      CoinPkScript,
 		Confirmations: 1,
 		OutPoint:      *prevOut,
 	}
@@ -73,8 +73,9 @@ func (w *WalletController) ConfirmedBalance(int32, string) (btcutil.Amount,
 func (w *WalletController) NewAddress(lnwallet.AddressType, bool,
 	string) (btcutil.Address, error) {
 
-	addr, _ := btcutil.NewAddressPubKey(
-		w.RootKey.PubKey().SerializeCompressed(), &chaincfg.MainNetParams,
+	pubKeyHash := btcutil.Hash160(w.RootKey.PubKey().SerializeCompressed())
+	addr, _ := btcutil.NewAddressWitnessPubKeyHash(
+		pubKeyHash, &chaincfg.MainNetParams,
 	)
 	return addr, nil
 }
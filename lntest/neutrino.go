@@ -1,5 +1,5 @@
-//go:build neutrino
-// +build neutrino
+//go:build neutrino || chainbackends
+// +build neutrino chainbackends
 
 package lntest
 
@@ -54,8 +54,9 @@ func (b NeutrinoBackendConfig) Name() string {
 	return NeutrinoBackendName
 }
 
-// NewBackend starts and returns a NeutrinoBackendConfig for the node.
-func NewBackend(miner string, _ *chaincfg.Params) (
+// NewNeutrinoBackend returns a NeutrinoBackendConfig for the node. No extra
+// process is started as lnd connects to the miner directly.
+func NewNeutrinoBackend(miner string, _ *chaincfg.Params) (
 	*NeutrinoBackendConfig, func() error, error) {
 
 	bd := &NeutrinoBackendConfig{
@@ -0,0 +1,15 @@
+//go:build neutrino
+// +build neutrino
+
+package lntest
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// NewBackend starts and returns a NeutrinoBackendConfig for the node.
+func NewBackend(miner string, netParams *chaincfg.Params) (
+	*NeutrinoBackendConfig, func() error, error) {
+
+	return NewNeutrinoBackend(miner, netParams)
+}
@@ -99,3 +99,18 @@ func (h *HarnessRPC) TrackPaymentV2(payHash []byte) TrackPaymentClient {
 
 	return client
 }
+
+type HtlcModifierClient invoicesrpc.Invoices_HtlcModifierClient
+
+// HtlcModifier makes a RPC call to the node's InvoicesClient and asserts.
+func (h *HarnessRPC) HtlcModifier() (HtlcModifierClient, context.CancelFunc) {
+	// HtlcModifier needs to have the context alive for the entire test
+	// case as the returned client will be used for send and receive events
+	// stream. Thus we use cancel context here instead of a timeout
+	// context.
+	ctxt, cancel := context.WithCancel(h.runCtx)
+	resp, err := h.Invoice.HtlcModifier(ctxt)
+	h.NoError(err, "HtlcModifier")
+
+	return resp, cancel
+}
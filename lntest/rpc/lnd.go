@@ -243,6 +243,20 @@ func (h *HarnessRPC) OpenChannel(req *lnrpc.OpenChannelRequest) OpenChanClient {
 	return stream
 }
 
+// EstimateChannelOpenFee makes a rpc call to LightningClient and asserts no
+// error.
+func (h *HarnessRPC) EstimateChannelOpenFee(
+	req *lnrpc.OpenChannelRequest) *lnrpc.EstimateChannelOpenFeeResponse {
+
+	ctxt, cancel := context.WithTimeout(h.runCtx, DefaultTimeout)
+	defer cancel()
+
+	resp, err := h.LN.EstimateChannelOpenFee(ctxt, req)
+	h.NoError(err, "EstimateChannelOpenFee")
+
+	return resp
+}
+
 type CloseChanClient lnrpc.Lightning_CloseChannelClient
 
 // CloseChannel makes a rpc call to LightningClient and returns the close
@@ -351,6 +365,31 @@ func (h *HarnessRPC) RestoreChanBackups(
 
 type AcceptorClient lnrpc.Lightning_ChannelAcceptorClient
 
+// SetChannelAcceptorPolicy makes a RPC call to the node's
+// SetChannelAcceptorPolicy and asserts.
+func (h *HarnessRPC) SetChannelAcceptorPolicy(
+	req *lnrpc.ChannelAcceptorPolicy) {
+
+	ctxt, cancel := context.WithTimeout(h.runCtx, DefaultTimeout)
+	defer cancel()
+
+	_, err := h.LN.SetChannelAcceptorPolicy(ctxt, req)
+	h.NoError(err, "SetChannelAcceptorPolicy")
+}
+
+// GetChannelAcceptorPolicy makes a RPC call to the node's
+// GetChannelAcceptorPolicy and asserts.
+func (h *HarnessRPC) GetChannelAcceptorPolicy() *lnrpc.ChannelAcceptorPolicy {
+	ctxt, cancel := context.WithTimeout(h.runCtx, DefaultTimeout)
+	defer cancel()
+
+	req := &lnrpc.GetChannelAcceptorPolicyRequest{}
+	resp, err := h.LN.GetChannelAcceptorPolicy(ctxt, req)
+	h.NoError(err, "GetChannelAcceptorPolicy")
+
+	return resp
+}
+
 // ChannelAcceptor makes a RPC call to the node's ChannelAcceptor and asserts.
 func (h *HarnessRPC) ChannelAcceptor() (AcceptorClient, context.CancelFunc) {
 	// Use runCtx here instead of a timeout context to keep the client
@@ -588,6 +627,18 @@ func (h *HarnessRPC) LookupInvoice(rHash []byte) *lnrpc.Invoice {
 	return resp
 }
 
+// UpdateKeysendPolicy makes a RPC call to the node's UpdateKeysendPolicy and
+// asserts.
+func (h *HarnessRPC) UpdateKeysendPolicy(
+	req *lnrpc.UpdateKeysendPolicyRequest) {
+
+	ctxt, cancel := context.WithTimeout(h.runCtx, DefaultTimeout)
+	defer cancel()
+
+	_, err := h.LN.UpdateKeysendPolicy(ctxt, req)
+	h.NoError(err, "UpdateKeysendPolicy")
+}
+
 // DecodePayReq makes a RPC call to node's DecodePayReq and asserts.
 func (h *HarnessRPC) DecodePayReq(req string) *lnrpc.PayReq {
 	ctxt, cancel := context.WithTimeout(h.runCtx, DefaultTimeout)
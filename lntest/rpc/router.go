@@ -165,6 +165,19 @@ func (h *HarnessRPC) XImportMissionControlAssertErr(
 	require.Error(h, err, "expect an error from x import mission control")
 }
 
+// XExportMissionControl makes a RPC call to the node's XExportMissionControl
+// and asserts.
+func (h *HarnessRPC) XExportMissionControl() *routerrpc.XExportMissionControlResponse {
+	ctxt, cancel := context.WithTimeout(h.runCtx, DefaultTimeout)
+	defer cancel()
+
+	req := &routerrpc.XExportMissionControlRequest{}
+	resp, err := h.Router.XExportMissionControl(ctxt, req)
+	h.NoError(err, "XExportMissionControl")
+
+	return resp
+}
+
 // BuildRoute makes a RPC call to the node's RouterClient and asserts.
 func (h *HarnessRPC) BuildRoute(
 	req *routerrpc.BuildRouteRequest) *routerrpc.BuildRouteResponse {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -276,3 +277,68 @@ func CalcStaticFeeBuffer(c lnrpc.CommitmentType, numHTLCs int) btcutil.Amount {
 
 	return feeBuffer.ToSatoshis()
 }
+
+// P2WKHInputs returns a list of num P2WKH input types that can be passed to
+// FundingFee.
+func P2WKHInputs(num int) []lnrpc.AddressType {
+	inputTypes := make([]lnrpc.AddressType, num)
+	for i := range inputTypes {
+		inputTypes[i] = lnrpc.AddressType_WITNESS_PUBKEY_HASH
+	}
+
+	return inputTypes
+}
+
+// FundingFee returns the fee the wallet estimates for a funding transaction at
+// the default fee rate. The transaction spends inputs of the given address
+// types into the funding output of a channel with the given commitment type
+// and an optional P2TR change output. This matches the estimate done by the
+// wallet's coin selection.
+func FundingFee(inputTypes []lnrpc.AddressType,
+	commitType lnrpc.CommitmentType, change bool) btcutil.Amount {
+
+	var weightEstimate input.TxWeightEstimator
+
+	// All inputs.
+	for _, inputType := range inputTypes {
+		switch inputType {
+		case lnrpc.AddressType_WITNESS_PUBKEY_HASH,
+			lnrpc.AddressType_UNUSED_WITNESS_PUBKEY_HASH:
+
+			weightEstimate.AddP2WKHInput()
+
+		case lnrpc.AddressType_NESTED_PUBKEY_HASH,
+			lnrpc.AddressType_UNUSED_NESTED_PUBKEY_HASH:
+
+			weightEstimate.AddNestedP2WKHInput()
+
+		case lnrpc.AddressType_TAPROOT_PUBKEY,
+			lnrpc.AddressType_UNUSED_TAPROOT_PUBKEY:
+
+			weightEstimate.AddTaprootKeySpendInput(
+				txscript.SigHashDefault,
+			)
+
+		default:
+			panic(fmt.Sprintf("unsupported funding input type %v",
+				inputType))
+		}
+	}
+
+	// The funding output, which is a P2TR output for taproot channels and
+	// a P2WSH multisig output otherwise.
+	if CommitTypeHasTaproot(commitType) {
+		weightEstimate.AddP2TROutput()
+	} else {
+		weightEstimate.AddP2WSHOutput()
+	}
+
+	// Optionally count a change output.
+	if change {
+		weightEstimate.AddP2TROutput()
+	}
+
+	feeRate := chainfee.SatPerKWeight(DefaultFeeRateSatPerKw)
+
+	return feeRate.FeeForWeight(weightEstimate.Weight())
+}
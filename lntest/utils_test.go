@@ -0,0 +1,103 @@
+package lntest
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFundingFee checks the funding fee estimate against a set of static test
+// vectors, which were derived from the weight estimation of the wallet's coin
+// selection at the default fee rate of 12500 sat/kw.
+func TestFundingFee(t *testing.T) {
+	t.Parallel()
+
+	var (
+		p2wkh  = lnrpc.AddressType_WITNESS_PUBKEY_HASH
+		np2wkh = lnrpc.AddressType_NESTED_PUBKEY_HASH
+		p2tr   = lnrpc.AddressType_TAPROOT_PUBKEY
+
+		anchors = lnrpc.CommitmentType_ANCHORS
+		taproot = lnrpc.CommitmentType_SIMPLE_TAPROOT
+	)
+
+	testCases := []struct {
+		name        string
+		inputTypes  []lnrpc.AddressType
+		commitType  lnrpc.CommitmentType
+		change      bool
+		expectedFee btcutil.Amount
+	}{
+		{
+			name:        "no inputs",
+			commitType:  anchors,
+			expectedFee: 2650,
+		},
+		{
+			name:        "one p2wkh input",
+			inputTypes:  []lnrpc.AddressType{p2wkh},
+			commitType:  anchors,
+			expectedFee: 6087,
+		},
+		{
+			name:        "one p2wkh input with change",
+			inputTypes:  []lnrpc.AddressType{p2wkh},
+			commitType:  anchors,
+			change:      true,
+			expectedFee: 8237,
+		},
+		{
+			name:        "two p2wkh inputs",
+			inputTypes:  P2WKHInputs(2),
+			commitType:  anchors,
+			expectedFee: 9500,
+		},
+		{
+			name:        "two p2wkh inputs with change",
+			inputTypes:  P2WKHInputs(2),
+			commitType:  anchors,
+			change:      true,
+			expectedFee: 11650,
+		},
+		{
+			name:        "one np2wkh input with change",
+			inputTypes:  []lnrpc.AddressType{np2wkh},
+			commitType:  anchors,
+			change:      true,
+			expectedFee: 9387,
+		},
+		{
+			name:        "one p2tr input with change",
+			inputTypes:  []lnrpc.AddressType{p2tr},
+			commitType:  anchors,
+			change:      true,
+			expectedFee: 7700,
+		},
+		{
+			name:        "taproot channel with one p2tr input",
+			inputTypes:  []lnrpc.AddressType{p2tr},
+			commitType:  taproot,
+			expectedFee: 5550,
+		},
+		{
+			name:        "taproot channel with mixed inputs",
+			inputTypes:  []lnrpc.AddressType{p2wkh, np2wkh, p2tr},
+			commitType:  taproot,
+			change:      true,
+			expectedFee: 15675,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fee := FundingFee(tc.inputTypes, tc.commitType, tc.change)
+			require.Equal(t, tc.expectedFee, fee)
+		})
+	}
+}
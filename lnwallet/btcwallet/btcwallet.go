@@ -12,6 +12,7 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/rpcclient"
@@ -105,6 +106,14 @@ type BtcWallet struct {
 
 	blockCache *blockcache.BlockCache
 
+	// externalAccount is the number of the account that holds the funds of
+	// the external signer, if one is configured.
+	externalAccount uint32
+
+	// lastExternalSig is the last transaction the external signer signed.
+	lastExternalSig *psbt.Packet
+	externalSigMtx  sync.Mutex
+
 	*input.MusigSessionManager
 }
 
@@ -434,6 +443,14 @@ func (b *BtcWallet) Start() error {
 		return err
 	}
 
+	// With an external signer, the on-chain funds are held in an account
+	// of the external device instead of the default account.
+	if b.cfg.ExternalSigner != nil {
+		if err := b.initExternalSigner(); err != nil {
+			return err
+		}
+	}
+
 	// Establish an RPC connection in addition to starting the goroutines
 	// in the underlying wallet.
 	if err := b.chain.Start(); err != nil {
@@ -512,7 +529,14 @@ func (b *BtcWallet) keyScopeForAccountAddr(accountName string,
 
 	// The default account spans across multiple key scopes, so the
 	// requested address type should already be valid for this account.
+	// With an external signer, all addresses of the default account are
+	// addresses of the external signer's account, which only supports a
+	// single address type.
 	if accountName == lnwallet.DefaultAccountName {
+		if b.cfg.ExternalSigner != nil {
+			return externalSignerScope, b.externalAccount, nil
+		}
+
 		return addrKeyScope, defaultAccount, nil
 	}
 
@@ -720,17 +744,16 @@ func newAccountNotFoundError(name string) error {
 // RequiredReserve returns the minimum amount of satoshis that should be
 // kept in the wallet in order to fee bump anchor channels if necessary.
 // The value scales with the number of public anchor channels but is
-// capped at a maximum.
+// capped at a maximum, both of which are set by the configured policy.
 func (b *BtcWallet) RequiredReserve(
 	numAnchorChans uint32) btcutil.Amount {
 
-	anchorChanReservedValue := lnwallet.AnchorChanReservedValue
-	reserved := btcutil.Amount(numAnchorChans) * anchorChanReservedValue
-	if reserved > lnwallet.MaxAnchorChanReservedValue {
-		reserved = lnwallet.MaxAnchorChanReservedValue
+	policy := lnwallet.DefaultAnchorReservePolicy()
+	if b.cfg.AnchorReserve != nil {
+		policy = *b.cfg.AnchorReserve
 	}
 
-	return reserved
+	return policy.Reserve(numAnchorChans)
 }
 
 // ListAddresses retrieves all the addresses along with their balance. An
@@ -995,9 +1018,24 @@ func (b *BtcWallet) SendOutputs(outputs []*wire.TxOut,
 		return nil, lnwallet.ErrInvalidMinconf
 	}
 
+	// With an external signer, the wallet can only select the coins and
+	// the device needs to sign the transaction.
+	keyScope, account := b.spendAccount()
+	if b.cfg.ExternalSigner != nil {
+		authoredTx, err := b.wallet.CreateSimpleTx(
+			keyScope, account, outputs, minConfs, feeSatPerKB,
+			strategy, false,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return b.publishExternallySigned(authoredTx.Tx, label)
+	}
+
 	return b.wallet.SendOutputs(
-		outputs, nil, defaultAccount, minConfs, feeSatPerKB,
-		strategy, label,
+		outputs, keyScope, account, minConfs, feeSatPerKB, strategy,
+		label,
 	)
 }
 
@@ -1047,9 +1085,11 @@ func (b *BtcWallet) CreateSimpleTx(outputs []*wire.TxOut,
 		}
 	}
 
+	keyScope, account := b.spendAccount()
+
 	return b.wallet.CreateSimpleTx(
-		nil, defaultAccount, outputs, minConfs, feeSatPerKB,
-		strategy, dryRun,
+		keyScope, account, outputs, minConfs, feeSatPerKB, strategy,
+		dryRun,
 	)
 }
 
@@ -1136,6 +1176,21 @@ func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32,
 		return nil, err
 	}
 
+	// With an external signer, the funds of its account are part of the
+	// default account's funds. They are marked as externally signed, so
+	// automated spends like the sweeper's can leave them alone.
+	if accountFilter == lnwallet.DefaultAccountName &&
+		b.cfg.ExternalSigner != nil {
+
+		externalOutputs, err := b.wallet.ListUnspent(
+			minConfs, maxConfs, b.cfg.ExternalSignerAccount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		unspentOutputs = append(unspentOutputs, externalOutputs...)
+	}
+
 	// Next, we'll run through all the regular outputs, only saving those
 	// which are p2wkh outputs or a p2wsh output nested within a p2sh output.
 	witnessOutputs := make([]*lnwallet.Utxo, 0, len(unspentOutputs))
@@ -1173,6 +1228,11 @@ func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32,
 				return nil, err
 			}
 
+			// Outputs of the external signer's account can only be
+			// signed by the device.
+			externallySigned := b.cfg.ExternalSigner != nil &&
+				output.Account == b.cfg.ExternalSignerAccount
+
 			utxo := &lnwallet.Utxo{
 				AddressType: addressType,
 				Value:       amt,
@@ -1181,7 +1241,8 @@ func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32,
 					Hash:  *txid,
 					Index: output.Vout,
 				},
-				Confirmations: output.Confirmations,
+				Confirmations:    output.Confirmations,
+				ExternallySigned: externallySigned,
 			}
 			witnessOutputs = append(witnessOutputs, utxo)
 		}
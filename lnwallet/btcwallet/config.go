@@ -8,6 +8,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/chain"
 	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/lightningnetwork/lnd/lnwallet"
 )
 
 var (
@@ -82,6 +83,22 @@ type Config struct {
 	// wallet exists and a watch-only one is created directly, or, if the
 	// wallet was previously converted to a watch-only already.
 	MigrateWatchOnly bool
+
+	// AnchorReserve is the policy that determines the amount that is kept
+	// in the wallet to fee bump anchor channels. If nil, the default
+	// policy is used.
+	AnchorReserve *lnwallet.AnchorReservePolicy
+
+	// ExternalSigner is an optional device, such as a hardware wallet,
+	// that holds the keys of the wallet's on-chain funds. If set, the
+	// default account's addresses are derived from the device and any
+	// spend of its funds is signed by it. The channel keys are still
+	// derived from the seed of the wallet.
+	ExternalSigner ExternalSigner
+
+	// ExternalSignerAccount is the name of the account that holds the
+	// funds of the external signer.
+	ExternalSignerAccount string
 }
 
 // NetworkDir returns the directory name of a network directory to hold wallet
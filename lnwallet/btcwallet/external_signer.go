@@ -0,0 +1,309 @@
+package btcwallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/input"
+)
+
+var (
+	// externalSignerScope is the key scope of the account that holds the
+	// funds of the external signer. P2WKH is the one address type every
+	// hardware wallet can sign for.
+	externalSignerScope = waddrmgr.KeyScopeBIP0084
+
+	// errExternalSignerSigHash is returned if a signature with a sighash
+	// type other than SIGHASH_ALL is requested from the external signer.
+	errExternalSignerSigHash = errors.New("external signer can only " +
+		"sign with SIGHASH_ALL")
+)
+
+// ExternalSigner is a device that holds the private keys of the wallet's
+// on-chain funds and signs for them through PSBTs, such as a hardware wallet.
+// The keys of the lnd channels are never held by the external signer.
+type ExternalSigner interface {
+	// MasterKeyFingerprint returns the master key fingerprint of the
+	// device as it appears in PSBT BIP32 derivation fields.
+	MasterKeyFingerprint() uint32
+
+	// AccountXPub returns the extended public key of the account at the
+	// given derivation path.
+	AccountXPub(path []uint32) (*hdkeychain.ExtendedKey, error)
+
+	// SignPsbt signs all inputs of the packet the device has the keys for
+	// and returns the packet with the partial signatures added.
+	SignPsbt(packet *psbt.Packet) (*psbt.Packet, error)
+}
+
+// initExternalSigner makes sure the account that holds the funds of the
+// external signer exists. On first startup, the account is imported from the
+// device, afterward the device is only needed for signing.
+func (b *BtcWallet) initExternalSigner() error {
+	var (
+		signer      = b.cfg.ExternalSigner
+		name        = b.cfg.ExternalSignerAccount
+		fingerprint = signer.MasterKeyFingerprint()
+	)
+
+	props, err := b.wallet.AccountPropertiesByName(
+		externalSignerScope, name,
+	)
+	switch {
+	case err == nil:
+		if props.MasterKeyFingerprint != fingerprint {
+			return fmt.Errorf("account '%s' belongs to a device "+
+				"with a different master key fingerprint",
+				name)
+		}
+
+		b.externalAccount = props.AccountNumber
+
+		return nil
+
+	case !waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound):
+		return err
+	}
+
+	path := []uint32{
+		externalSignerScope.Purpose + hdkeychain.HardenedKeyStart,
+		externalSignerScope.Coin + hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart,
+	}
+	accountPubKey, err := signer.AccountXPub(path)
+	if err != nil {
+		return fmt.Errorf("unable to fetch account xpub from external "+
+			"signer: %w", err)
+	}
+
+	addrType := waddrmgr.WitnessPubKey
+	props, err = b.wallet.ImportAccount(
+		name, accountPubKey, fingerprint, &addrType,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to import external signer account: "+
+			"%w", err)
+	}
+
+	log.Infof("Imported account '%s' of external signer", name)
+
+	b.externalAccount = props.AccountNumber
+
+	return nil
+}
+
+// spendAccount returns the key scope and account that on-chain payments of the
+// default account are funded from.
+func (b *BtcWallet) spendAccount() (*waddrmgr.KeyScope, uint32) {
+	if b.cfg.ExternalSigner != nil {
+		return &externalSignerScope, b.externalAccount
+	}
+
+	return nil, defaultAccount
+}
+
+// isExternalSignerScript returns true if the given output script pays to an
+// address of the external signer's account.
+func (b *BtcWallet) isExternalSignerScript(pkScript []byte) bool {
+	if b.cfg.ExternalSigner == nil {
+		return false
+	}
+
+	addr, _, _, err := b.wallet.ScriptForOutput(&wire.TxOut{
+		PkScript: pkScript,
+	})
+	if err != nil {
+		return false
+	}
+
+	scope, _, _ := addr.DerivationInfo()
+
+	return scope == externalSignerScope &&
+		addr.InternalAccount() == b.externalAccount
+}
+
+// externalDerivation returns the BIP32 derivation information of the given
+// output script of the external signer's account, as the device knows it.
+func (b *BtcWallet) externalDerivation(
+	pkScript []byte) (*psbt.Bip32Derivation, error) {
+
+	addr, _, _, err := b.wallet.ScriptForOutput(&wire.TxOut{
+		PkScript: pkScript,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scope, path, _ := addr.DerivationInfo()
+
+	return &psbt.Bip32Derivation{
+		PubKey:               addr.PubKey().SerializeCompressed(),
+		MasterKeyFingerprint: path.MasterKeyFingerprint,
+		Bip32Path: []uint32{
+			scope.Purpose + hdkeychain.HardenedKeyStart,
+			scope.Coin + hdkeychain.HardenedKeyStart,
+			path.Account,
+			path.Branch,
+			path.Index,
+		},
+	}, nil
+}
+
+// signExternally lets the external signer sign all inputs of the given
+// transaction that belong to its account. The signed packet of the last
+// transaction is cached, so callers that sign one input at a time only require
+// the user to confirm the transaction on the device once.
+func (b *BtcWallet) signExternally(tx *wire.MsgTx) (*psbt.Packet, error) {
+	b.externalSigMtx.Lock()
+	defer b.externalSigMtx.Unlock()
+
+	txid := tx.TxHash()
+	if b.lastExternalSig != nil &&
+		b.lastExternalSig.UnsignedTx.TxHash() == txid {
+
+		return b.lastExternalSig, nil
+	}
+
+	unsignedTx := tx.Copy()
+	for _, txIn := range unsignedTx.TxIn {
+		txIn.SignatureScript = nil
+		txIn.Witness = nil
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.wallet.DecorateInputs(packet, false); err != nil {
+		return nil, err
+	}
+
+	// Adding the derivation info for our change outputs allows the device
+	// to show the user which outputs leave the wallet.
+	for idx, txOut := range unsignedTx.TxOut {
+		if !b.isExternalSignerScript(txOut.PkScript) {
+			continue
+		}
+
+		derivation, err := b.externalDerivation(txOut.PkScript)
+		if err != nil {
+			return nil, err
+		}
+		packet.Outputs[idx].Bip32Derivation = []*psbt.Bip32Derivation{
+			derivation,
+		}
+	}
+
+	log.Infof("Requesting signature for transaction %v from external "+
+		"signer, please confirm it on the device", txid)
+
+	signed, err := b.cfg.ExternalSigner.SignPsbt(packet)
+	if err != nil {
+		return nil, fmt.Errorf("external signer failed to sign "+
+			"transaction %v: %w", txid, err)
+	}
+
+	if signed.UnsignedTx.TxHash() != txid {
+		return nil, fmt.Errorf("external signer returned a different "+
+			"transaction than %v", txid)
+	}
+
+	b.lastExternalSig = signed
+
+	return signed, nil
+}
+
+// externalInputScript returns the input script for an input of the external
+// signer's account.
+func (b *BtcWallet) externalInputScript(tx *wire.MsgTx,
+	signDesc *input.SignDescriptor) (*input.Script, error) {
+
+	if signDesc.HashType != txscript.SigHashAll {
+		return nil, errExternalSignerSigHash
+	}
+
+	signed, err := b.signExternally(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	in := signed.Inputs[signDesc.InputIndex]
+	if len(in.PartialSigs) != 1 {
+		return nil, fmt.Errorf("external signer did not sign input %d",
+			signDesc.InputIndex)
+	}
+
+	// The external signer's account only has P2WKH addresses, so the
+	// witness is just the signature and the public key.
+	return &input.Script{
+		Witness: wire.TxWitness{
+			in.PartialSigs[0].Signature, in.PartialSigs[0].PubKey,
+		},
+	}, nil
+}
+
+// finalizeExternalInputs signs and finalizes all inputs of the packet that
+// belong to the external signer's account.
+func (b *BtcWallet) finalizeExternalInputs(packet *psbt.Packet) error {
+	if err := b.wallet.DecorateInputs(packet, false); err != nil {
+		return err
+	}
+
+	var externalInputs []int
+	for idx, in := range packet.Inputs {
+		if in.WitnessUtxo == nil || len(in.FinalScriptWitness) > 0 {
+			continue
+		}
+
+		if b.isExternalSignerScript(in.WitnessUtxo.PkScript) {
+			externalInputs = append(externalInputs, idx)
+		}
+	}
+	if len(externalInputs) == 0 {
+		return nil
+	}
+
+	signed, err := b.signExternally(packet.UnsignedTx)
+	if err != nil {
+		return err
+	}
+
+	for _, idx := range externalInputs {
+		packet.Inputs[idx].PartialSigs = signed.Inputs[idx].PartialSigs
+		if err := psbt.Finalize(packet, idx); err != nil {
+			return fmt.Errorf("error finalizing input %d signed "+
+				"by external signer: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// publishExternallySigned lets the external signer sign the given transaction
+// that only spends inputs of its account and publishes it.
+func (b *BtcWallet) publishExternallySigned(tx *wire.MsgTx,
+	label string) (*wire.MsgTx, error) {
+
+	signed, err := b.signExternally(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := psbt.MaybeFinalizeAll(signed); err != nil {
+		return nil, fmt.Errorf("error finalizing transaction signed "+
+			"by external signer: %w", err)
+	}
+
+	finalTx, err := psbt.Extract(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return finalTx, b.PublishTransaction(finalTx, label)
+}
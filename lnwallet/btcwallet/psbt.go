@@ -158,6 +158,20 @@ func (b *BtcWallet) FundPsbt(packet *psbt.Packet, minConfs int32,
 // input/output/fee value validation, PSBT finalization). Any input that is
 // incomplete will be skipped.
 func (b *BtcWallet) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
+	return SignPsbtWithKeys(packet, b.DeriveKeyByBIP32Path)
+}
+
+// DeriveKeyByPathFunc is a function that derives the private key described by
+// a full BIP32 derivation path.
+type DeriveKeyByPathFunc func(path []uint32) (*btcec.PrivateKey, error)
+
+// SignPsbtWithKeys signs all unsigned inputs of the given packet the same way
+// SignPsbt does, but uses the given function to derive the signing keys from
+// the BIP32 derivation information of the inputs. This allows signers that
+// don't have a full wallet to sign PSBTs.
+func SignPsbtWithKeys(packet *psbt.Packet,
+	deriveKey DeriveKeyByPathFunc) ([]uint32, error) {
+
 	// In signedInputs we return the indices of psbt inputs that were signed
 	// by our wallet. This way the caller can check if any inputs were signed.
 	var signedInputs []uint32
@@ -207,7 +221,7 @@ func (b *BtcWallet) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
 		// it's a BIP49/84 key for normal on-chain funds or a key of the
 		// custom purpose 1017 key scope.
 		derivationInfo := in.Bip32Derivation[0]
-		privKey, err := b.deriveKeyByBIP32Path(derivationInfo.Bip32Path)
+		privKey, err := deriveKey(derivationInfo.Bip32Path)
 		if err != nil {
 			log.Warnf("SignPsbt: Skipping input %d, error "+
 				"deriving signing key: %v", idx, err)
@@ -578,6 +592,14 @@ func maybeTweakPrivKeyPsbt(unknowns []*psbt.Unknown,
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) FinalizePsbt(packet *psbt.Packet, accountName string) error {
+	// The inputs of an external signer need to be signed by the device
+	// first, the wallet can't sign them.
+	if b.cfg.ExternalSigner != nil {
+		if err := b.finalizeExternalInputs(packet); err != nil {
+			return err
+		}
+	}
+
 	var (
 		keyScope   *waddrmgr.KeyScope
 		accountNum uint32
@@ -280,7 +280,7 @@ func TestSignPsbt(t *testing.T) {
 		tc := tc
 
 		// This is the private key we're going to sign with.
-		privKey, err := w.deriveKeyByBIP32Path(tc.inputType.keyPath())
+		privKey, err := w.DeriveKeyByBIP32Path(tc.inputType.keyPath())
 		require.NoError(t, err)
 
 		txOut, witnessScript := tc.inputType.output(t, privKey)
@@ -85,10 +85,10 @@ func deriveFromKeyLoc(scopedMgr *waddrmgr.ScopedKeyManager,
 	return addr.(waddrmgr.ManagedPubKeyAddress).PrivKey()
 }
 
-// deriveKeyByBIP32Path derives a key described by a BIP32 path. We expect the
+// DeriveKeyByBIP32Path derives a key described by a BIP32 path. We expect the
 // first three elements of the path to be hardened according to BIP44, so they
 // must be a number >= 2^31.
-func (b *BtcWallet) deriveKeyByBIP32Path(path []uint32) (*btcec.PrivateKey,
+func (b *BtcWallet) DeriveKeyByBIP32Path(path []uint32) (*btcec.PrivateKey,
 	error) {
 
 	// Make sure we get a full path with exactly 5 elements. A path is
@@ -445,6 +445,12 @@ func (b *BtcWallet) SignOutputRaw(tx *wire.MsgTx,
 func (b *BtcWallet) ComputeInputScript(tx *wire.MsgTx,
 	signDesc *input.SignDescriptor) (*input.Script, error) {
 
+	// Inputs of the external signer's account can only be signed by the
+	// device.
+	if b.isExternalSignerScript(signDesc.Output.PkScript) {
+		return b.externalInputScript(tx, signDesc)
+	}
+
 	// If a tweak (single or double) is specified, then we'll need to use
 	// this tweak to derive the final private key to be used for signing
 	// this output.
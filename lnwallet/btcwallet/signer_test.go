@@ -189,7 +189,7 @@ func TestBip32KeyDerivation(t *testing.T) {
 		tc := tc
 
 		t.Run(tc.name, func(t *testing.T) {
-			privKey, err := w.deriveKeyByBIP32Path(tc.path)
+			privKey, err := w.DeriveKeyByBIP32Path(tc.path)
 
 			if tc.err == "" {
 				require.NoError(t, err)
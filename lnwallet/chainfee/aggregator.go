@@ -0,0 +1,224 @@
+package chainfee
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// errNoFeeSources is returned when an AggregateEstimator is created
+	// without any fee sources.
+	errNoFeeSources = errors.New("at least one fee source is required")
+)
+
+// FeeSource is a named fee estimator that is combined with other fee
+// estimators by an AggregateEstimator.
+type FeeSource struct {
+	// Name identifies the fee source. It is reported along with the fee
+	// estimates that are based on this source.
+	Name string
+
+	// Estimator is the fee estimator backing the fee source.
+	Estimator Estimator
+
+	// Weight is the weight of the estimates of this source in the
+	// weighted average of all sources. Sources with a weight of zero are
+	// only used as fallbacks if none of the weighted sources returns an
+	// estimate.
+	Weight uint32
+}
+
+// SourceEstimator is an Estimator that is able to report which fee sources
+// an estimate is based on.
+type SourceEstimator interface {
+	Estimator
+
+	// EstimateFeePerKWWithSources returns the estimated fee in sat/kw for
+	// the given confirmation target, along with the names of the fee
+	// sources the estimate is based on.
+	EstimateFeePerKWWithSources(numBlocks uint32) (SatPerKWeight,
+		[]string, error)
+}
+
+// AggregateEstimator is an Estimator that combines the estimates of multiple
+// fee sources. The estimates of all sources with a non-zero weight are
+// combined into their weighted average. If none of them returns an estimate,
+// the remaining sources are queried in their configured order and the first
+// estimate is used.
+type AggregateEstimator struct {
+	sources []FeeSource
+}
+
+// A compile-time assertion to ensure that AggregateEstimator implements the
+// SourceEstimator interface.
+var _ SourceEstimator = (*AggregateEstimator)(nil)
+
+// NewAggregateEstimator creates a new AggregateEstimator from the given fee
+// sources. The order of the sources determines the order in which they are
+// queried as fallbacks.
+func NewAggregateEstimator(sources []FeeSource) (*AggregateEstimator,
+	error) {
+
+	if len(sources) == 0 {
+		return nil, errNoFeeSources
+	}
+
+	names := make(map[string]struct{}, len(sources))
+	for _, source := range sources {
+		if _, ok := names[source.Name]; ok {
+			return nil, fmt.Errorf("duplicate fee source %v",
+				source.Name)
+		}
+		names[source.Name] = struct{}{}
+	}
+
+	return &AggregateEstimator{
+		sources: sources,
+	}, nil
+}
+
+// Start starts all fee sources.
+//
+// NOTE: This method is part of the Estimator interface.
+func (a *AggregateEstimator) Start() error {
+	for i, source := range a.sources {
+		if err := source.Estimator.Start(); err != nil {
+			// Stop the sources that were already started.
+			for _, started := range a.sources[:i] {
+				_ = started.Estimator.Stop()
+			}
+
+			return fmt.Errorf("unable to start fee source %v: %w",
+				source.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Stop stops all fee sources.
+//
+// NOTE: This method is part of the Estimator interface.
+func (a *AggregateEstimator) Stop() error {
+	var stopErr error
+	for _, source := range a.sources {
+		if err := source.Estimator.Stop(); err != nil {
+			log.Errorf("Unable to stop fee source %v: %v",
+				source.Name, err)
+
+			stopErr = err
+		}
+	}
+
+	return stopErr
+}
+
+// EstimateFeePerKW returns the estimated fee in sat/kw for the given
+// confirmation target.
+//
+// NOTE: This method is part of the Estimator interface.
+func (a *AggregateEstimator) EstimateFeePerKW(
+	numBlocks uint32) (SatPerKWeight, error) {
+
+	feeRate, _, err := a.EstimateFeePerKWWithSources(numBlocks)
+
+	return feeRate, err
+}
+
+// EstimateFeePerKWWithSources returns the estimated fee in sat/kw for the
+// given confirmation target, along with the names of the fee sources the
+// estimate is based on. The estimate is never below the relay fee of any of
+// the sources.
+//
+// NOTE: This method is part of the SourceEstimator interface.
+func (a *AggregateEstimator) EstimateFeePerKWWithSources(
+	numBlocks uint32) (SatPerKWeight, []string, error) {
+
+	feeRate, sources, err := a.estimate(numBlocks)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if relayFee := a.RelayFeePerKW(); feeRate < relayFee {
+		log.Debugf("Aggregated fee rate of %v is below the relay fee, "+
+			"using %v instead", feeRate, relayFee)
+
+		feeRate = relayFee
+	}
+
+	log.Debugf("Returning %v for conf target of %v from fee sources %v",
+		feeRate, numBlocks, sources)
+
+	return feeRate, sources, nil
+}
+
+// estimate returns the weighted average of the estimates of all weighted
+// sources, or the first estimate of the fallback sources if none of the
+// weighted sources returns an estimate.
+func (a *AggregateEstimator) estimate(numBlocks uint32) (SatPerKWeight,
+	[]string, error) {
+
+	var (
+		weightedSum int64
+		totalWeight int64
+		sources     []string
+		lastErr     error
+	)
+	for _, source := range a.sources {
+		if source.Weight == 0 {
+			continue
+		}
+
+		feeRate, err := source.Estimator.EstimateFeePerKW(numBlocks)
+		if err != nil {
+			log.Debugf("Fee source %v unable to estimate fee: %v",
+				source.Name, err)
+
+			lastErr = err
+			continue
+		}
+
+		weightedSum += int64(feeRate) * int64(source.Weight)
+		totalWeight += int64(source.Weight)
+		sources = append(sources, source.Name)
+	}
+
+	if totalWeight > 0 {
+		return SatPerKWeight(weightedSum / totalWeight), sources, nil
+	}
+
+	for _, source := range a.sources {
+		if source.Weight != 0 {
+			continue
+		}
+
+		feeRate, err := source.Estimator.EstimateFeePerKW(numBlocks)
+		if err != nil {
+			log.Debugf("Fallback fee source %v unable to estimate "+
+				"fee: %v", source.Name, err)
+
+			lastErr = err
+			continue
+		}
+
+		return feeRate, []string{source.Name}, nil
+	}
+
+	return 0, nil, fmt.Errorf("no fee source returned an estimate: %w",
+		lastErr)
+}
+
+// RelayFeePerKW returns the highest relay fee of all fee sources, so that
+// transactions are relayed by all of them.
+//
+// NOTE: This method is part of the Estimator interface.
+func (a *AggregateEstimator) RelayFeePerKW() SatPerKWeight {
+	var relayFee SatPerKWeight
+	for _, source := range a.sources {
+		if fee := source.Estimator.RelayFeePerKW(); fee > relayFee {
+			relayFee = fee
+		}
+	}
+
+	return relayFee
+}
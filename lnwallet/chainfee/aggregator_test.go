@@ -0,0 +1,131 @@
+package chainfee
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSource returns a fee source backed by a mock estimator that returns
+// the given fee rate, or an error if the fee rate is zero.
+func newTestSource(name string, weight uint32, feeRate,
+	relayFee SatPerKWeight) FeeSource {
+
+	estimator := &MockEstimator{}
+	if feeRate == 0 {
+		estimator.On("EstimateFeePerKW", uint32(6)).Return(
+			nil, errors.New("unavailable"),
+		)
+	} else {
+		estimator.On("EstimateFeePerKW", uint32(6)).Return(
+			feeRate, nil,
+		)
+	}
+	estimator.On("RelayFeePerKW").Return(relayFee)
+
+	return FeeSource{
+		Name:      name,
+		Estimator: estimator,
+		Weight:    weight,
+	}
+}
+
+// TestAggregateEstimator checks that the estimates of the weighted sources
+// are averaged, and that the fallback sources are used in order if none of
+// the weighted sources returns an estimate.
+func TestAggregateEstimator(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		sources         []FeeSource
+		expectedFeeRate SatPerKWeight
+		expectedSources []string
+		expectErr       bool
+	}{
+		{
+			name: "weighted average",
+			sources: []FeeSource{
+				newTestSource("a", 3, 1_000, 253),
+				newTestSource("b", 1, 5_000, 253),
+				newTestSource("c", 0, 10_000, 253),
+			},
+			expectedFeeRate: 2_000,
+			expectedSources: []string{"a", "b"},
+		},
+		{
+			name: "failed weighted source ignored",
+			sources: []FeeSource{
+				newTestSource("a", 1, 0, 253),
+				newTestSource("b", 1, 5_000, 253),
+			},
+			expectedFeeRate: 5_000,
+			expectedSources: []string{"b"},
+		},
+		{
+			name: "fallback order",
+			sources: []FeeSource{
+				newTestSource("a", 1, 0, 253),
+				newTestSource("b", 0, 0, 253),
+				newTestSource("c", 0, 3_000, 253),
+				newTestSource("d", 0, 4_000, 253),
+			},
+			expectedFeeRate: 3_000,
+			expectedSources: []string{"c"},
+		},
+		{
+			name: "relay fee floor",
+			sources: []FeeSource{
+				newTestSource("a", 1, 300, 253),
+				newTestSource("b", 1, 500, 1_000),
+			},
+			expectedFeeRate: 1_000,
+			expectedSources: []string{"a", "b"},
+		},
+		{
+			name: "no estimate",
+			sources: []FeeSource{
+				newTestSource("a", 1, 0, 253),
+				newTestSource("b", 0, 0, 253),
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			estimator, err := NewAggregateEstimator(tc.sources)
+			require.NoError(t, err)
+
+			feeRate, sources, err :=
+				estimator.EstimateFeePerKWWithSources(6)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFeeRate, feeRate)
+			require.Equal(t, tc.expectedSources, sources)
+		})
+	}
+}
+
+// TestNewAggregateEstimator checks that invalid fee sources are rejected.
+func TestNewAggregateEstimator(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAggregateEstimator(nil)
+	require.ErrorIs(t, err, errNoFeeSources)
+
+	_, err = NewAggregateEstimator([]FeeSource{
+		newTestSource("a", 1, 1_000, 253),
+		newTestSource("a", 0, 1_000, 253),
+	})
+	require.Error(t, err)
+}
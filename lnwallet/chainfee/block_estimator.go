@@ -0,0 +1,314 @@
+package chainfee
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/fn"
+)
+
+const (
+	// DefaultBlockFeeWindow is the default number of recent blocks the
+	// BlockEstimator derives its fee estimates from.
+	DefaultBlockFeeWindow = 12
+
+	// DefaultBlockPollInterval is the default interval in which the
+	// BlockEstimator checks for new blocks.
+	DefaultBlockPollInterval = time.Minute
+
+	// minBlockFeeSamples is the minimum number of blocks the BlockEstimator
+	// needs to have processed before it returns fee estimates.
+	minBlockFeeSamples = 3
+)
+
+var (
+	// errInsufficientBlocks is returned by the BlockEstimator if it
+	// hasn't processed enough blocks yet to estimate fees.
+	errInsufficientBlocks = errors.New("not enough blocks processed to " +
+		"estimate fees")
+)
+
+// BlockEstimatorConfig holds the configuration of a BlockEstimator.
+type BlockEstimatorConfig struct {
+	// ChainParams are the parameters of the chain, used to compute the
+	// block subsidy.
+	ChainParams *chaincfg.Params
+
+	// BestHeight returns the height of the best known block.
+	BestHeight func() (int32, error)
+
+	// FetchBlock returns the block at the given height of the main chain.
+	FetchBlock func(height int32) (*wire.MsgBlock, error)
+
+	// FeeFloor is the lowest fee rate that is returned. It is never below
+	// FeePerKwFloor.
+	FeeFloor SatPerKWeight
+
+	// Window is the number of recent blocks the fee estimates are derived
+	// from. DefaultBlockFeeWindow is used if it is zero.
+	Window uint32
+
+	// PollInterval is the interval in which new blocks are fetched.
+	// DefaultBlockPollInterval is used if it is zero.
+	PollInterval time.Duration
+}
+
+// BlockEstimator is an implementation of the Estimator interface for light
+// clients that don't have access to a mempool. It derives fee estimates from
+// the average fee rate of recent blocks, which is computed from the block
+// reward claimed by the coinbase transaction, so that no input values need to
+// be known. The estimate for a confirmation target of N blocks is the N-th
+// highest average fee rate of the recent blocks, which means that short
+// targets follow the most expensive recent blocks while long targets follow
+// the cheapest ones.
+type BlockEstimator struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *BlockEstimatorConfig
+
+	// mu protects feeRates.
+	mu sync.Mutex
+
+	// feeRates are the average fee rates of the recent blocks, keyed by
+	// their height. Blocks without any transactions besides the coinbase
+	// transaction don't have a fee rate.
+	feeRates map[int32]fn.Option[SatPerKWeight]
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile-time assertion to ensure that BlockEstimator implements the
+// Estimator interface.
+var _ Estimator = (*BlockEstimator)(nil)
+
+// NewBlockEstimator creates a new BlockEstimator from the given config.
+func NewBlockEstimator(cfg *BlockEstimatorConfig) *BlockEstimator {
+	if cfg.Window == 0 {
+		cfg.Window = DefaultBlockFeeWindow
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = DefaultBlockPollInterval
+	}
+
+	return &BlockEstimator{
+		cfg:      cfg,
+		feeRates: make(map[int32]fn.Option[SatPerKWeight]),
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start starts fetching the recent blocks in the background.
+//
+// NOTE: This method is part of the Estimator interface.
+func (b *BlockEstimator) Start() error {
+	b.started.Do(func() {
+		log.Infof("Starting block based fee estimator, window=%d "+
+			"blocks", b.cfg.Window)
+
+		b.wg.Add(1)
+		go b.blockPoller()
+	})
+
+	return nil
+}
+
+// Stop stops fetching blocks.
+//
+// NOTE: This method is part of the Estimator interface.
+func (b *BlockEstimator) Stop() error {
+	b.stopped.Do(func() {
+		close(b.quit)
+		b.wg.Wait()
+	})
+
+	return nil
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the estimated fee expressed in sat/kw.
+//
+// NOTE: This method is part of the Estimator interface.
+func (b *BlockEstimator) EstimateFeePerKW(
+	numBlocks uint32) (SatPerKWeight, error) {
+
+	b.mu.Lock()
+	feeRates := make([]SatPerKWeight, 0, len(b.feeRates))
+	for _, feeRate := range b.feeRates {
+		feeRate.WhenSome(func(feeRate SatPerKWeight) {
+			feeRates = append(feeRates, feeRate)
+		})
+	}
+	b.mu.Unlock()
+
+	if len(feeRates) < minBlockFeeSamples {
+		return 0, errInsufficientBlocks
+	}
+
+	feeRate := blockFeeEstimate(feeRates, numBlocks)
+	if floor := b.RelayFeePerKW(); feeRate < floor {
+		feeRate = floor
+	}
+
+	log.Debugf("Returning %v for conf target of %v from %d blocks",
+		feeRate, numBlocks, len(feeRates))
+
+	return feeRate, nil
+}
+
+// RelayFeePerKW returns the configured fee floor, which is never below
+// FeePerKwFloor.
+//
+// NOTE: This method is part of the Estimator interface.
+func (b *BlockEstimator) RelayFeePerKW() SatPerKWeight {
+	if b.cfg.FeeFloor < FeePerKwFloor {
+		return FeePerKwFloor
+	}
+
+	return b.cfg.FeeFloor
+}
+
+// blockPoller fetches the recent blocks once per poll interval.
+//
+// NOTE: This MUST be run as a goroutine.
+func (b *BlockEstimator) blockPoller() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.fetchRecentBlocks(); err != nil {
+			log.Errorf("Unable to fetch recent blocks for fee "+
+				"estimation: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-b.quit:
+			return
+		}
+	}
+}
+
+// fetchRecentBlocks fetches the blocks within the window that weren't
+// processed yet, and forgets about the blocks that left the window.
+func (b *BlockEstimator) fetchRecentBlocks() error {
+	bestHeight, err := b.cfg.BestHeight()
+	if err != nil {
+		return err
+	}
+
+	lowestHeight := bestHeight - int32(b.cfg.Window) + 1
+	if lowestHeight < 1 {
+		lowestHeight = 1
+	}
+
+	b.mu.Lock()
+	for height := range b.feeRates {
+		if height < lowestHeight {
+			delete(b.feeRates, height)
+		}
+	}
+	b.mu.Unlock()
+
+	for height := lowestHeight; height <= bestHeight; height++ {
+		b.mu.Lock()
+		_, ok := b.feeRates[height]
+		b.mu.Unlock()
+		if ok {
+			continue
+		}
+
+		block, err := b.cfg.FetchBlock(height)
+		if err != nil {
+			return err
+		}
+
+		feeRate := fn.None[SatPerKWeight]()
+		rate, ok := blockFeeRate(block, height, b.cfg.ChainParams)
+		if ok {
+			log.Debugf("Average fee rate of block %d is %v",
+				height, rate)
+
+			feeRate = fn.Some(rate)
+		} else {
+			log.Debugf("Skipping block %d without transactions "+
+				"for fee estimation", height)
+		}
+
+		b.mu.Lock()
+		b.feeRates[height] = feeRate
+		b.mu.Unlock()
+
+		select {
+		case <-b.quit:
+			return nil
+		default:
+		}
+	}
+
+	return nil
+}
+
+// blockFeeRate returns the average fee rate of the transactions in the given
+// block. The fees are derived from the reward claimed by the coinbase
+// transaction minus the block subsidy. False is returned if the block has no
+// transactions besides the coinbase transaction.
+func blockFeeRate(block *wire.MsgBlock, height int32,
+	params *chaincfg.Params) (SatPerKWeight, bool) {
+
+	if len(block.Transactions) < 2 {
+		return 0, false
+	}
+
+	coinbase := block.Transactions[0]
+
+	var reward int64
+	for _, txOut := range coinbase.TxOut {
+		reward += txOut.Value
+	}
+
+	// Miners may claim less than the full reward, in which case we can't
+	// tell the fees apart from the subsidy.
+	fees := reward - blockchain.CalcBlockSubsidy(height, params)
+	if fees < 0 {
+		fees = 0
+	}
+
+	weight := blockchain.GetBlockWeight(btcutil.NewBlock(block)) -
+		blockchain.GetTransactionWeight(btcutil.NewTx(coinbase))
+	if weight <= 0 {
+		return 0, false
+	}
+
+	return SatPerKWeight(fees * 1000 / weight), true
+}
+
+// blockFeeEstimate returns the numBlocks-th highest of the given fee rates,
+// or the lowest one if there are less fee rates.
+func blockFeeEstimate(feeRates []SatPerKWeight,
+	numBlocks uint32) SatPerKWeight {
+
+	sort.Slice(feeRates, func(i, j int) bool {
+		return feeRates[i] > feeRates[j]
+	})
+
+	idx := int(numBlocks) - 1
+	switch {
+	case idx < 0:
+		idx = 0
+
+	case idx >= len(feeRates):
+		idx = len(feeRates) - 1
+	}
+
+	return feeRates[idx]
+}
@@ -0,0 +1,203 @@
+package chainfee
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlock creates a block at the given height whose coinbase transaction
+// claims the block subsidy plus the given fees. If withTx is set, the block
+// contains a transaction besides the coinbase transaction.
+func testBlock(height int32, fees int64, withTx bool) *wire.MsgBlock {
+	subsidy := blockchain.CalcBlockSubsidy(
+		height, &chaincfg.RegressionNetParams,
+	)
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte{0x01, 0x02},
+	})
+	coinbase.AddTxOut(&wire.TxOut{
+		Value:    subsidy + fees,
+		PkScript: make([]byte, 22),
+	})
+
+	block := &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase},
+	}
+	if withTx {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{Index: 1},
+			SignatureScript:  make([]byte, 107),
+		})
+		tx.AddTxOut(&wire.TxOut{
+			Value:    1_000,
+			PkScript: make([]byte, 22),
+		})
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block
+}
+
+// txWeight returns the weight of a block created by testBlock, excluding its
+// coinbase transaction.
+func txWeight(block *wire.MsgBlock) int64 {
+	return blockchain.GetBlockWeight(btcutil.NewBlock(block)) -
+		blockchain.GetTransactionWeight(
+			btcutil.NewTx(block.Transactions[0]),
+		)
+}
+
+// TestBlockFeeRate checks that the average fee rate of a block is derived from
+// the reward claimed by its coinbase transaction.
+func TestBlockFeeRate(t *testing.T) {
+	t.Parallel()
+
+	params := &chaincfg.RegressionNetParams
+
+	// A block with fees has an average fee rate of the fees divided by the
+	// weight of its transactions.
+	block := testBlock(100, 50_000, true)
+	feeRate, ok := blockFeeRate(block, 100, params)
+	require.True(t, ok)
+	require.Equal(
+		t, SatPerKWeight(50_000*1000/txWeight(block)), feeRate,
+	)
+
+	// A miner claiming less than the subsidy results in a zero fee rate.
+	block = testBlock(100, -1_000, true)
+	feeRate, ok = blockFeeRate(block, 100, params)
+	require.True(t, ok)
+	require.Zero(t, feeRate)
+
+	// Blocks that only contain the coinbase transaction have no fee rate.
+	block = testBlock(100, 0, false)
+	_, ok = blockFeeRate(block, 100, params)
+	require.False(t, ok)
+}
+
+// TestBlockFeeEstimate checks that the N-th highest fee rate is returned for
+// a confirmation target of N blocks.
+func TestBlockFeeEstimate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		numBlocks uint32
+		expected  SatPerKWeight
+	}{
+		{numBlocks: 0, expected: 5_000},
+		{numBlocks: 1, expected: 5_000},
+		{numBlocks: 2, expected: 3_000},
+		{numBlocks: 4, expected: 1_000},
+		{numBlocks: 100, expected: 1_000},
+	}
+
+	for _, tc := range testCases {
+		feeRates := []SatPerKWeight{2_000, 5_000, 1_000, 3_000}
+		feeRate := blockFeeEstimate(feeRates, tc.numBlocks)
+		require.Equal(
+			t, tc.expected, feeRate, "numBlocks=%d", tc.numBlocks,
+		)
+	}
+}
+
+// TestBlockEstimator checks that the BlockEstimator only fetches blocks it
+// hasn't processed yet, forgets about blocks that left the window, and
+// enforces its fee floor.
+func TestBlockEstimator(t *testing.T) {
+	t.Parallel()
+
+	blocks := make(map[int32]*wire.MsgBlock)
+	for height := int32(1); height <= 10; height++ {
+		// Every fifth block only contains the coinbase transaction.
+		blocks[height] = testBlock(
+			height, int64(height)*10_000, height%5 != 0,
+		)
+	}
+
+	// The fee floor is above the fee rate of the first block.
+	params := &chaincfg.RegressionNetParams
+	firstFeeRate, ok := blockFeeRate(blocks[1], 1, params)
+	require.True(t, ok)
+	feeFloor := firstFeeRate + 1
+
+	var (
+		bestHeight int32 = 3
+		fetched          = make(map[int32]int)
+	)
+	estimator := NewBlockEstimator(&BlockEstimatorConfig{
+		ChainParams: params,
+		BestHeight: func() (int32, error) {
+			return bestHeight, nil
+		},
+		FetchBlock: func(height int32) (*wire.MsgBlock, error) {
+			fetched[height]++
+
+			block, ok := blocks[height]
+			if !ok {
+				return nil, errors.New("unknown block")
+			}
+
+			return block, nil
+		},
+		FeeFloor: feeFloor,
+		Window:   4,
+	})
+
+	// Without any processed blocks, no estimate is returned.
+	_, err := estimator.EstimateFeePerKW(1)
+	require.ErrorIs(t, err, errInsufficientBlocks)
+
+	// The first three blocks are enough to estimate fees. The lowest fee
+	// rate is below the floor.
+	require.NoError(t, estimator.fetchRecentBlocks())
+	feeRate, err := estimator.EstimateFeePerKW(1)
+	require.NoError(t, err)
+	require.Equal(
+		t, SatPerKWeight(30_000*1000/txWeight(blocks[3])), feeRate,
+	)
+
+	feeRate, err = estimator.EstimateFeePerKW(3)
+	require.NoError(t, err)
+	require.Equal(t, estimator.RelayFeePerKW(), feeRate)
+	require.Equal(t, feeFloor, feeRate)
+
+	// Moving the window forward only fetches the new blocks, including the
+	// block without transactions.
+	bestHeight = 5
+	require.NoError(t, estimator.fetchRecentBlocks())
+	require.NoError(t, estimator.fetchRecentBlocks())
+	for height := int32(1); height <= 5; height++ {
+		require.Equal(t, 1, fetched[height], "height=%d", height)
+	}
+
+	// The first block left the window, and the block without transactions
+	// isn't used for estimates, so that the lowest fee rate is the one of
+	// the second block.
+	feeRate, err = estimator.EstimateFeePerKW(3)
+	require.NoError(t, err)
+	require.Equal(
+		t, SatPerKWeight(20_000*1000/txWeight(blocks[2])), feeRate,
+	)
+
+	// Once the second block left the window as well, only two blocks
+	// with fee rates remain, which isn't enough for estimates.
+	estimator.cfg.Window = 3
+	bestHeight = 6
+	require.NoError(t, estimator.fetchRecentBlocks())
+	_, err = estimator.EstimateFeePerKW(1)
+	require.ErrorIs(t, err, errInsufficientBlocks)
+
+	// A failure to fetch a block is reported.
+	bestHeight = 11
+	require.Error(t, estimator.fetchRecentBlocks())
+}
@@ -1,6 +1,9 @@
 package chainfee
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +15,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/rpcclient"
 )
@@ -45,6 +50,23 @@ const (
 	// minimum feerate if we used the median of our peers' feefilter
 	// values.
 	filterCapConfTarget = uint32(1)
+
+	// FeeSignatureHeader is the HTTP header that carries the hex encoded
+	// BIP-340 signature over the SHA-256 hash of a fee API response body.
+	FeeSignatureHeader = "X-Fee-Signature"
+
+	// maxFeeResponseSize is the maximum size of a fee API response body
+	// that we read.
+	maxFeeResponseSize = 1 << 20
+
+	// maxFeeResponseAge is the maximum age of a signed fee API response,
+	// as indicated by its issued_at field, that we accept.
+	maxFeeResponseAge = 10 * time.Minute
+
+	// maxFeeResponseClockSkew is the maximum amount of time that the
+	// issued_at field of a signed fee API response may lie in the future,
+	// to allow for clock differences between us and the fee API.
+	maxFeeResponseClockSkew = time.Minute
 )
 
 var (
@@ -54,6 +76,19 @@ var (
 
 	// errEmptyCache is used when the fee rate cache is empty.
 	errEmptyCache = errors.New("fee rate cache is empty")
+
+	// errMissingFeeSignature is returned when a fee API response isn't
+	// signed although a public key to verify it is configured.
+	errMissingFeeSignature = errors.New("fee api response not signed")
+
+	// errInvalidFeeSignature is returned when the signature of a fee API
+	// response is invalid.
+	errInvalidFeeSignature = errors.New("invalid fee api response " +
+		"signature")
+
+	// errStaleFeeResponse is returned when a signed fee API response
+	// doesn't carry a valid issue time, or was issued too long ago.
+	errStaleFeeResponse = errors.New("stale fee api response")
 )
 
 // Estimator provides the ability to estimate on-chain transaction fees for
@@ -414,7 +449,15 @@ func (b *BitcoindEstimator) Start() error {
 // requires for a tx to enter its mempool. The returned fee will be the
 // maximum of the minimum relay fee and the minimum mempool fee.
 func (b *BitcoindEstimator) fetchMinMempoolFee() (SatPerKWeight, error) {
-	resp, err := b.bitcoindConn.RawRequest("getmempoolinfo", nil)
+	return fetchBitcoindMinMempoolFee(b.bitcoindConn)
+}
+
+// fetchBitcoindMinMempoolFee fetches the minimum fee that the given bitcoind
+// node requires for a tx to enter its mempool.
+func fetchBitcoindMinMempoolFee(
+	bitcoindConn *rpcclient.Client) (SatPerKWeight, error) {
+
+	resp, err := bitcoindConn.RawRequest("getmempoolinfo", nil)
 	if err != nil {
 		return 0, err
 	}
@@ -640,6 +683,71 @@ type WebAPIFeeSource interface {
 type SparseConfFeeSource struct {
 	// URL is the fee estimation API specified by the user.
 	URL string
+
+	// PubKey is an optional public key that the responses of the API must
+	// be signed with. If set, responses without a valid BIP-340
+	// signature over the SHA-256 hash of the body in the
+	// FeeSignatureHeader are rejected. As the signature doesn't prevent
+	// old responses from being replayed, the body must also contain the
+	// unix timestamp at which it was issued in the issued_at field, and
+	// responses older than maxFeeResponseAge are rejected.
+	PubKey *btcec.PublicKey
+}
+
+// verifyResponse checks that the signature of a response body is valid for the
+// configured public key, and that the response isn't stale at the given time.
+// Responses are always valid if no public key is configured.
+func (s SparseConfFeeSource) verifyResponse(body []byte, sigHex string,
+	now time.Time) error {
+
+	if s.PubKey == nil {
+		return nil
+	}
+
+	if sigHex == "" {
+		return errMissingFeeSignature
+	}
+
+	sigBytes, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidFeeSignature, err)
+	}
+
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidFeeSignature, err)
+	}
+
+	digest := sha256.Sum256(body)
+	if !sig.Verify(digest[:], s.PubKey) {
+		return errInvalidFeeSignature
+	}
+
+	// Now that we know the body was signed by the fee API, we make sure
+	// it was issued recently so that an old response can't be replayed.
+	var resp struct {
+		IssuedAt *int64 `json:"issued_at"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return err
+	}
+
+	if resp.IssuedAt == nil {
+		return fmt.Errorf("%w: missing issued_at", errStaleFeeResponse)
+	}
+
+	issuedAt := time.Unix(*resp.IssuedAt, 0)
+	switch {
+	case now.Sub(issuedAt) > maxFeeResponseAge:
+		return fmt.Errorf("%w: issued at %v", errStaleFeeResponse,
+			issuedAt)
+
+	case issuedAt.Sub(now) > maxFeeResponseClockSkew:
+		return fmt.Errorf("%w: issued at %v in the future",
+			errStaleFeeResponse, issuedAt)
+	}
+
+	return nil
 }
 
 // parseResponse attempts to parse the body of the response generated by the
@@ -692,9 +800,27 @@ func (s SparseConfFeeSource) GetFeeMap() (map[uint32]uint32, error) {
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeeResponseSize))
+	if err != nil {
+		log.Errorf("unable to read fee api response: %v", err)
+
+		return nil, err
+	}
+
+	// Before trusting the response, we'll make sure it was recently signed
+	// by the configured key, if any.
+	err = s.verifyResponse(
+		body, resp.Header.Get(FeeSignatureHeader), time.Now(),
+	)
+	if err != nil {
+		log.Errorf("unable to verify fee api response: %v", err)
+
+		return nil, err
+	}
+
 	// Once we've obtained the response, we'll instruct the WebAPIFeeSource
 	// to parse out the body to obtain our final result.
-	feesByBlockTarget, err := s.parseResponse(resp.Body)
+	feesByBlockTarget, err := s.parseResponse(bytes.NewReader(body))
 	if err != nil {
 		log.Errorf("unable to parse fee api response: %v", err)
 
@@ -2,10 +2,16 @@ package chainfee
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/stretchr/testify/require"
 )
@@ -131,6 +137,146 @@ func TestSparseConfFeeSource(t *testing.T) {
 	require.Error(t, err, "expected error when parsing bad JSON")
 }
 
+// TestSparseConfFeeSourceSignature checks that SparseConfFeeSource only
+// accepts recently issued API responses with a valid signature if a public key
+// is configured.
+func TestSparseConfFeeSourceSignature(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	testFees := map[uint32]uint32{1: 12345, 6: 5000}
+	newBody := func(issuedAt *time.Time) []byte {
+		resp := map[string]interface{}{
+			"fee_by_block_target": testFees,
+		}
+		if issuedAt != nil {
+			resp["issued_at"] = issuedAt.Unix()
+		}
+
+		body, err := json.Marshal(resp)
+		require.NoError(t, err)
+
+		return body
+	}
+
+	now := time.Now()
+	validBody := newBody(&now)
+
+	sign := func(key *btcec.PrivateKey, body []byte) string {
+		digest := sha256.Sum256(body)
+		sig, err := schnorr.Sign(key, digest[:])
+		require.NoError(t, err)
+
+		return hex.EncodeToString(sig.Serialize())
+	}
+
+	staleTime := now.Add(-maxFeeResponseAge - time.Minute)
+	staleBody := newBody(&staleTime)
+	futureTime := now.Add(maxFeeResponseClockSkew + time.Minute)
+	futureBody := newBody(&futureTime)
+	noTimeBody := newBody(nil)
+
+	testCases := []struct {
+		name      string
+		body      []byte
+		signature string
+		pubKey    *btcec.PublicKey
+		expectErr error
+	}{
+		{
+			name:   "no public key",
+			body:   noTimeBody,
+			pubKey: nil,
+		},
+		{
+			name:      "valid signature",
+			body:      validBody,
+			signature: sign(privKey, validBody),
+			pubKey:    privKey.PubKey(),
+		},
+		{
+			name:      "missing signature",
+			body:      validBody,
+			pubKey:    privKey.PubKey(),
+			expectErr: errMissingFeeSignature,
+		},
+		{
+			name:      "wrong key",
+			body:      validBody,
+			signature: sign(otherKey, validBody),
+			pubKey:    privKey.PubKey(),
+			expectErr: errInvalidFeeSignature,
+		},
+		{
+			name:      "malformed signature",
+			body:      validBody,
+			signature: "nothex",
+			pubKey:    privKey.PubKey(),
+			expectErr: errInvalidFeeSignature,
+		},
+		{
+			name:      "missing issue time",
+			body:      noTimeBody,
+			signature: sign(privKey, noTimeBody),
+			pubKey:    privKey.PubKey(),
+			expectErr: errStaleFeeResponse,
+		},
+		{
+			name:      "stale response",
+			body:      staleBody,
+			signature: sign(privKey, staleBody),
+			pubKey:    privKey.PubKey(),
+			expectErr: errStaleFeeResponse,
+		},
+		{
+			name:      "issued in the future",
+			body:      futureBody,
+			signature: sign(privKey, futureBody),
+			pubKey:    privKey.PubKey(),
+			expectErr: errStaleFeeResponse,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					if tc.signature != "" {
+						w.Header().Set(
+							FeeSignatureHeader,
+							tc.signature,
+						)
+					}
+					_, _ = w.Write(tc.body)
+				},
+			))
+			defer server.Close()
+
+			feeSource := SparseConfFeeSource{
+				URL:    server.URL,
+				PubKey: tc.pubKey,
+			}
+
+			fees, err := feeSource.GetFeeMap()
+			if tc.expectErr != nil {
+				require.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testFees, fees)
+		})
+	}
+}
+
 // TestWebAPIFeeEstimator checks that the WebAPIFeeEstimator returns fee rates
 // as expected.
 func TestWebAPIFeeEstimator(t *testing.T) {
@@ -0,0 +1,316 @@
+package chainfee
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/rpcclient"
+)
+
+const (
+	// projectedBlockWeight is the weight of the mempool transactions that
+	// fit into a single block. Some weight is reserved for the coinbase
+	// transaction.
+	projectedBlockWeight = blockchain.MaxBlockWeight - 4_000
+
+	// DefaultMempoolRefreshInterval is the default interval in which the
+	// MempoolEstimator fetches the transactions in the mempool of the
+	// backend node.
+	DefaultMempoolRefreshInterval = time.Minute
+)
+
+var (
+	// errEmptyMempoolEntry is returned when a mempool transaction has no
+	// weight.
+	errEmptyMempoolEntry = errors.New("mempool entry without weight")
+)
+
+// MempoolEntry describes a transaction in the mempool of the backend node.
+type MempoolEntry struct {
+	// Weight is the weight of the transaction.
+	Weight uint64
+
+	// FeeRate is the fee rate at which the transaction is mined. This
+	// takes unconfirmed ancestors of the transaction into account.
+	FeeRate SatPerKWeight
+}
+
+// MempoolEstimator is an implementation of the Estimator interface that
+// derives fee estimates from the transactions in the mempool of the backend
+// node. The transactions are sorted by their fee rate and split into
+// projected blocks. The estimate for a confirmation target of N blocks is the
+// lowest fee rate that is still included in the first N projected blocks. If
+// the mempool doesn't fill N blocks, the minimum relay fee is returned.
+type MempoolEstimator struct {
+	// fetchMempool returns the transactions in the mempool of the backend
+	// node.
+	fetchMempool func() ([]MempoolEntry, error)
+
+	// fetchMinFee returns the minimum fee rate that the backend node
+	// requires for transactions to enter its mempool.
+	fetchMinFee fetchFee
+
+	// refreshInterval is the interval in which the mempool transactions
+	// are fetched from the backend node.
+	refreshInterval time.Duration
+
+	// minFeeManager is used to keep track of the minimum fee, in sat/kw,
+	// that we should enforce.
+	minFeeManager *minFeeManager
+
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// entries are the mempool transactions sorted by decreasing fee rate.
+	entries []MempoolEntry
+
+	// lastRefresh is the time at which entries was fetched.
+	lastRefresh time.Time
+}
+
+// A compile-time assertion to ensure that MempoolEstimator implements the
+// Estimator interface.
+var _ Estimator = (*MempoolEstimator)(nil)
+
+// NewMempoolEstimator creates a new MempoolEstimator that fetches the mempool
+// transactions and the minimum relay fee with the given functions.
+func NewMempoolEstimator(fetchMempool func() ([]MempoolEntry, error),
+	fetchMinFee func() (SatPerKWeight, error),
+	refreshInterval time.Duration) *MempoolEstimator {
+
+	return &MempoolEstimator{
+		fetchMempool:    fetchMempool,
+		fetchMinFee:     fetchMinFee,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// NewBitcoindMempoolEstimator creates a new MempoolEstimator backed by the
+// mempool of the bitcoind node that the given rpc config connects to.
+func NewBitcoindMempoolEstimator(rpcConfig rpcclient.ConnConfig,
+	refreshInterval time.Duration) (*MempoolEstimator, error) {
+
+	rpcConfig.DisableConnectOnNew = true
+	rpcConfig.DisableAutoReconnect = false
+	rpcConfig.DisableTLS = true
+	rpcConfig.HTTPPostMode = true
+	chainConn, err := rpcclient.New(&rpcConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchMempool := func() ([]MempoolEntry, error) {
+		return fetchBitcoindMempool(chainConn)
+	}
+	fetchMinFee := func() (SatPerKWeight, error) {
+		return fetchBitcoindMinMempoolFee(chainConn)
+	}
+
+	return NewMempoolEstimator(
+		fetchMempool, fetchMinFee, refreshInterval,
+	), nil
+}
+
+// Start signals the Estimator to start any processes or goroutines
+// it needs to perform its duty.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolEstimator) Start() error {
+	relayFeeManager, err := newMinFeeManager(
+		defaultUpdateInterval, m.fetchMinFee,
+	)
+	if err != nil {
+		return err
+	}
+	m.minFeeManager = relayFeeManager
+
+	return nil
+}
+
+// Stop stops any spawned goroutines and cleans up the resources used
+// by the fee estimator.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolEstimator) Stop() error {
+	return nil
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the estimated fee expressed in sat/kw.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolEstimator) EstimateFeePerKW(
+	numBlocks uint32) (SatPerKWeight, error) {
+
+	if numBlocks < minBlockTarget {
+		numBlocks = minBlockTarget
+	}
+	if numBlocks > MaxBlockTarget {
+		numBlocks = MaxBlockTarget
+	}
+
+	entries, err := m.mempoolEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	relayFee := m.RelayFeePerKW()
+	feeRate := projectedFeeRate(entries, numBlocks)
+	if feeRate < relayFee {
+		feeRate = relayFee
+	}
+
+	log.Debugf("Returning %v for conf target of %v from %d mempool "+
+		"transactions", feeRate, numBlocks, len(entries))
+
+	return feeRate, nil
+}
+
+// RelayFeePerKW returns the minimum fee rate required for transactions to be
+// relayed.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolEstimator) RelayFeePerKW() SatPerKWeight {
+	return m.minFeeManager.fetchMinFee()
+}
+
+// mempoolEntries returns the mempool transactions sorted by decreasing fee
+// rate. They are fetched from the backend node at most once per refresh
+// interval.
+func (m *MempoolEstimator) mempoolEntries() ([]MempoolEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.entries != nil && time.Since(m.lastRefresh) < m.refreshInterval {
+		return m.entries, nil
+	}
+
+	entries, err := m.fetchMempool()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FeeRate > entries[j].FeeRate
+	})
+
+	m.entries = entries
+	m.lastRefresh = time.Now()
+
+	return entries, nil
+}
+
+// projectedFeeRate returns the lowest fee rate of the given mempool
+// transactions that is still included in the first numBlocks projected
+// blocks. The entries must be sorted by decreasing fee rate. Zero is returned
+// if the transactions don't fill numBlocks blocks.
+func projectedFeeRate(entries []MempoolEntry,
+	numBlocks uint32) SatPerKWeight {
+
+	targetWeight := uint64(numBlocks) * projectedBlockWeight
+
+	var weight uint64
+	for _, entry := range entries {
+		weight += entry.Weight
+		if weight >= targetWeight {
+			return entry.FeeRate
+		}
+	}
+
+	return 0
+}
+
+// fetchBitcoindMempool fetches the transactions in the mempool of the given
+// bitcoind node.
+func fetchBitcoindMempool(
+	bitcoindConn *rpcclient.Client) ([]MempoolEntry, error) {
+
+	verbose, err := json.Marshal(true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := bitcoindConn.RawRequest(
+		"getrawmempool", []json.RawMessage{verbose},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var mempool map[string]bitcoindMempoolEntry
+	if err := json.Unmarshal(resp, &mempool); err != nil {
+		return nil, err
+	}
+
+	entries := make([]MempoolEntry, 0, len(mempool))
+	for txid, rawEntry := range mempool {
+		entry, err := rawEntry.toMempoolEntry()
+		if err != nil {
+			log.Debugf("Skipping mempool transaction %v: %v", txid,
+				err)
+
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// bitcoindMempoolEntry is a transaction in the verbose getrawmempool response
+// of bitcoind.
+type bitcoindMempoolEntry struct {
+	VSize        uint64 `json:"vsize"`
+	Weight       uint64 `json:"weight"`
+	AncestorSize uint64 `json:"ancestorsize"`
+	Fees         struct {
+		Base     float64 `json:"base"`
+		Ancestor float64 `json:"ancestor"`
+	} `json:"fees"`
+}
+
+// toMempoolEntry converts the bitcoind mempool entry into a MempoolEntry. The
+// fee rate of a transaction is the lower of its own fee rate and the fee rate
+// of the package of the transaction and its unconfirmed ancestors, as the
+// transaction can't be mined without its ancestors.
+func (e bitcoindMempoolEntry) toMempoolEntry() (MempoolEntry, error) {
+	weight := e.Weight
+	if weight == 0 {
+		weight = e.VSize * blockchain.WitnessScaleFactor
+	}
+	if weight == 0 {
+		return MempoolEntry{}, errEmptyMempoolEntry
+	}
+
+	fee, err := btcutil.NewAmount(e.Fees.Base)
+	if err != nil {
+		return MempoolEntry{}, err
+	}
+	feeRate := SatPerKWeight(fee * 1000 / btcutil.Amount(weight))
+
+	if e.AncestorSize > 0 {
+		ancestorFee, err := btcutil.NewAmount(e.Fees.Ancestor)
+		if err != nil {
+			return MempoolEntry{}, err
+		}
+
+		ancestorWeight := e.AncestorSize * blockchain.WitnessScaleFactor
+		ancestorFeeRate := SatPerKWeight(
+			ancestorFee * 1000 / btcutil.Amount(ancestorWeight),
+		)
+		if ancestorFeeRate < feeRate {
+			feeRate = ancestorFeeRate
+		}
+	}
+
+	return MempoolEntry{
+		Weight:  weight,
+		FeeRate: feeRate,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package chainfee
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMempoolEstimator checks that fee rates are derived from the projected
+// blocks of the mempool, and that the mempool is only fetched once per
+// refresh interval.
+func TestMempoolEstimator(t *testing.T) {
+	t.Parallel()
+
+	// The mempool fills two and a half blocks at decreasing fee rates.
+	half := uint64(projectedBlockWeight / 2)
+	entries := []MempoolEntry{
+		{Weight: half, FeeRate: 2_000},
+		{Weight: half, FeeRate: 5_000},
+		{Weight: half, FeeRate: 1_000},
+		{Weight: half, FeeRate: 10_000},
+		{Weight: half, FeeRate: 500},
+	}
+
+	var numFetches int
+	fetchMempool := func() ([]MempoolEntry, error) {
+		numFetches++
+
+		fetched := make([]MempoolEntry, len(entries))
+		copy(fetched, entries)
+
+		return fetched, nil
+	}
+	fetchMinFee := func() (SatPerKWeight, error) {
+		return 1_000, nil
+	}
+
+	estimator := NewMempoolEstimator(fetchMempool, fetchMinFee, time.Hour)
+	require.NoError(t, estimator.Start())
+	t.Cleanup(func() {
+		require.NoError(t, estimator.Stop())
+	})
+
+	testCases := []struct {
+		numBlocks uint32
+		expected  SatPerKWeight
+	}{
+		// The first block is filled by the two transactions with the
+		// highest fee rates.
+		{numBlocks: 1, expected: 5_000},
+
+		// The lowest fee rate in the second block is below the relay
+		// fee, so the relay fee is used instead.
+		{numBlocks: 2, expected: 1_000},
+
+		// The mempool doesn't fill three blocks.
+		{numBlocks: 3, expected: 1_000},
+	}
+	for _, tc := range testCases {
+		feeRate, err := estimator.EstimateFeePerKW(tc.numBlocks)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, feeRate, "blocks=%d",
+			tc.numBlocks)
+	}
+
+	require.Equal(t, 1, numFetches)
+}
+
+// TestBitcoindMempoolEntry checks that the fee rate of bitcoind mempool
+// entries takes their unconfirmed ancestors into account.
+func TestBitcoindMempoolEntry(t *testing.T) {
+	t.Parallel()
+
+	var entry bitcoindMempoolEntry
+	err := json.Unmarshal([]byte(`{
+		"vsize": 250,
+		"weight": 1000,
+		"ancestorsize": 500,
+		"fees": {"base": 0.00010000, "ancestor": 0.00012000}
+	}`), &entry)
+	require.NoError(t, err)
+
+	// The transaction pays 10,000 sat/kw on its own, but the package with
+	// its ancestor only pays 12,000 sat for 2,000 weight units.
+	mempoolEntry, err := entry.toMempoolEntry()
+	require.NoError(t, err)
+	require.Equal(t, MempoolEntry{
+		Weight:  1_000,
+		FeeRate: 6_000,
+	}, mempoolEntry)
+
+	_, err = bitcoindMempoolEntry{}.toMempoolEntry()
+	require.ErrorIs(t, err, errEmptyMempoolEntry)
+}
@@ -15,9 +15,11 @@ import (
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/labels"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
 )
 
 var (
@@ -50,6 +52,12 @@ var (
 	// a peer that isn't either a p2wsh or p2tr address.
 	ErrInvalidShutdownScript = fmt.Errorf("invalid shutdown script")
 
+	// ErrCloseOutputsNotSupported is returned when the remote party
+	// requests additional close outputs even though we don't signal
+	// support for them.
+	ErrCloseOutputsNotSupported = fmt.Errorf("split close outputs not " +
+		"supported")
+
 	// errNoShutdownNonce is returned when a shutdown message is received
 	// w/o a nonce for a taproot channel.
 	errNoShutdownNonce = fmt.Errorf("shutdown nonce not populated")
@@ -138,6 +146,27 @@ type ChanCloseCfg struct {
 	// FeeEstimator is used to estimate the absolute starting co-op close
 	// fee.
 	FeeEstimator CoopFeeEstimator
+
+	// CloseOutputs are additional outputs that our settled balance is
+	// split across. The remainder of our balance is paid to our delivery
+	// script.
+	//
+	// NOTE: This must only be set if the remote peer signals the split
+	// close outputs feature.
+	CloseOutputs lnwire.CloseOutputs
+
+	// AcceptCloseOutputs signals that we advertise the split close outputs
+	// feature. If it isn't set, a Shutdown message of the remote party
+	// that requests additional close outputs is rejected.
+	AcceptCloseOutputs bool
+
+	// EnableRbf signals that the RBF based cooperative close protocol
+	// should be used once both parties have exchanged their shutdown
+	// messages, rather than the legacy ClosingSigned fee negotiation.
+	//
+	// NOTE: This must only be set if both parties signal the RBF coop
+	// close feature, and the channel isn't a taproot channel.
+	EnableRbf bool
 }
 
 // ChanCloser is a state machine that handles the cooperative channel closure
@@ -206,6 +235,10 @@ type ChanCloser struct {
 	// settled channel funds to.
 	remoteDeliveryScript []byte
 
+	// remoteCloseOutputs are the additional outputs that the remote party
+	// requested its settled balance to be split across.
+	remoteCloseOutputs lnwire.CloseOutputs
+
 	// locallyInitiated is true if we initiated the channel close.
 	locallyInitiated bool
 
@@ -213,6 +246,18 @@ type ChanCloser struct {
 	// we use to handle a specific race condition caused by the independent
 	// message processing queues.
 	cachedClosingSigned fn.Option[lnwire.ClosingSigned]
+
+	// rbfProposal is our latest RBF close proposal that still awaits the
+	// signature of the remote party.
+	rbfProposal fn.Option[rbfCloseProposal]
+
+	// lastRbfFeeRate is the fee rate of the latest RBF close transaction
+	// we broadcast as the closer. Any further bump must exceed it.
+	lastRbfFeeRate chainfee.SatPerKWeight
+
+	// cachedClosingComplete is a cached copy of a ClosingComplete that we
+	// received before our link finished flushing the channel.
+	cachedClosingComplete fn.Option[lnwire.ClosingComplete]
 }
 
 // calcCoopCloseFee computes an "ideal" absolute co-op close fee given the
@@ -289,6 +334,29 @@ func NewChanCloser(cfg ChanCloseCfg, deliveryScript []byte,
 // initFeeBaseline computes our ideal fee rate, and also the largest fee we'll
 // accept given information about the delivery script of the remote party.
 func (c *ChanCloser) initFeeBaseline() {
+	// Given the target fee-per-kw, we'll compute what our ideal _total_
+	// fee will be starting at for this fee negotiation.
+	c.idealFeeSat = c.estimateCloseFee(c.idealFeeRate)
+
+	// When we're the initiator, we'll want to also factor in the highest
+	// fee we want to pay. This'll either be 3x the ideal fee, or the
+	// specified explicit max fee.
+	c.maxFee = c.idealFeeSat * defaultMaxFeeMultiplier
+	if c.cfg.MaxFee > 0 {
+		c.maxFee = c.estimateCloseFee(c.cfg.MaxFee)
+	}
+
+	chancloserLog.Infof("Ideal fee for closure of ChannelPoint(%v) "+
+		"is: %v sat (max_fee=%v sat)", c.cfg.Channel.ChannelPoint(),
+		int64(c.idealFeeSat), int64(c.maxFee))
+}
+
+// estimateCloseFee returns the absolute fee of the closing transaction at the
+// given fee rate, taking the delivery scripts of both parties and any
+// additional close outputs into account.
+func (c *ChanCloser) estimateCloseFee(
+	feeRate chainfee.SatPerKWeight) btcutil.Amount {
+
 	// Depending on if a balance ends up being dust or not, we'll pass a
 	// nil TxOut into the EstimateFee call which can handle it.
 	var localTxOut, remoteTxOut *wire.TxOut
@@ -305,25 +373,39 @@ func (c *ChanCloser) initFeeBaseline() {
 		}
 	}
 
-	// Given the target fee-per-kw, we'll compute what our ideal _total_
-	// fee will be starting at for this fee negotiation.
-	c.idealFeeSat = c.cfg.FeeEstimator.EstimateFee(
-		0, localTxOut, remoteTxOut, c.idealFeeRate,
-	)
+	// Any additional close outputs increase the weight of the closing
+	// transaction.
+	return c.cfg.FeeEstimator.EstimateFee(
+		0, localTxOut, remoteTxOut, feeRate,
+	) + feeRate.FeeForWeight(c.closeOutputsWeight())
+}
 
-	// When we're the initiator, we'll want to also factor in the highest
-	// fee we want to pay. This'll either be 3x the ideal fee, or the
-	// specified explicit max fee.
-	c.maxFee = c.idealFeeSat * defaultMaxFeeMultiplier
-	if c.cfg.MaxFee > 0 {
-		c.maxFee = c.cfg.FeeEstimator.EstimateFee(
-			0, localTxOut, remoteTxOut, c.cfg.MaxFee,
-		)
+// closeOutputsWeight returns the weight that the additional close outputs of
+// both parties add to the closing transaction.
+func (c *ChanCloser) closeOutputsWeight() lntypes.WeightUnit {
+	var size lntypes.VByte
+	for _, txOut := range c.cfg.CloseOutputs {
+		size += lntypes.VByte(txOut.SerializeSize())
+	}
+	for _, txOut := range c.remoteCloseOutputs {
+		size += lntypes.VByte(txOut.SerializeSize())
 	}
 
-	chancloserLog.Infof("Ideal fee for closure of ChannelPoint(%v) "+
-		"is: %v sat (max_fee=%v sat)", c.cfg.Channel.ChannelPoint(),
-		int64(c.idealFeeSat), int64(c.maxFee))
+	return size.ToWU()
+}
+
+// closeOutputOpts returns the close options that split the settled balances
+// across the additional close outputs of both parties, if any.
+func (c *ChanCloser) closeOutputOpts() []lnwallet.ChanCloseOpt {
+	if len(c.cfg.CloseOutputs) == 0 && len(c.remoteCloseOutputs) == 0 {
+		return nil
+	}
+
+	return []lnwallet.ChanCloseOpt{
+		lnwallet.WithCoopCloseOutputs(
+			c.cfg.CloseOutputs, c.remoteCloseOutputs,
+		),
+	}
 }
 
 // initChanShutdown begins the shutdown process by un-registering the channel,
@@ -333,6 +415,11 @@ func (c *ChanCloser) initChanShutdown() (*lnwire.Shutdown, error) {
 	// this particular channel, advertising a shutdown request to our
 	// desired closing script.
 	shutdown := lnwire.NewShutdown(c.cid, c.localDeliveryScript)
+	if len(c.cfg.CloseOutputs) != 0 {
+		shutdown.CloseOutputs = lnwire.SomeCloseOutputs(
+			c.cfg.CloseOutputs,
+		)
+	}
 
 	// If this is a taproot channel, then we'll need to also generate a
 	// nonce that'll be used sign the co-op close transaction offer.
@@ -365,7 +452,7 @@ func (c *ChanCloser) initChanShutdown() (*lnwire.Shutdown, error) {
 	// message we are about to send in order to ensure that if a
 	// re-establish occurs then we will re-send the same Shutdown message.
 	shutdownInfo := channeldb.NewShutdownInfo(
-		c.localDeliveryScript, c.locallyInitiated,
+		c.localDeliveryScript, c.locallyInitiated, c.cfg.CloseOutputs,
 	)
 	err := c.cfg.Channel.MarkShutdownSent(shutdownInfo)
 	if err != nil {
@@ -490,6 +577,48 @@ func validateShutdownScript(disconnect func() error, upfrontScript,
 	return nil
 }
 
+// setRemoteCloseOutputs validates and records the additional close outputs
+// requested in the remote party's Shutdown message. Close outputs are rejected
+// if we don't signal support for them, or if an upfront shutdown script was
+// set, since it commits the remote party to a single payout script.
+func (c *ChanCloser) setRemoteCloseOutputs(msg lnwire.Shutdown) error {
+	var outputs lnwire.CloseOutputs
+	msg.CloseOutputs.WhenSome(
+		func(r tlv.RecordT[lnwire.CloseOutputsType,
+			lnwire.CloseOutputs]) {
+
+			outputs = r.Val
+		},
+	)
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	if !c.cfg.AcceptCloseOutputs {
+		return ErrCloseOutputsNotSupported
+	}
+
+	if len(c.cfg.Channel.RemoteUpfrontShutdownScript()) != 0 {
+		return ErrUpfrontShutdownScriptMismatch
+	}
+
+	for _, txOut := range outputs {
+		if !lnwallet.ValidateUpfrontShutdown(
+			txOut.PkScript, c.cfg.ChainParams,
+		) {
+
+			return ErrInvalidShutdownScript
+		}
+	}
+
+	chancloserLog.Infof("ChannelPoint(%v): remote party requested %v "+
+		"additional close outputs", c.chanPoint, len(outputs))
+
+	c.remoteCloseOutputs = outputs
+
+	return nil
+}
+
 // ReceiveShutdown takes a raw Shutdown message and uses it to try and advance
 // the ChanCloser state machine, failing if it is coming in at an invalid time.
 // If appropriate, it will also generate a Shutdown message of its own to send
@@ -542,6 +671,10 @@ func (c *ChanCloser) ReceiveShutdown(msg lnwire.Shutdown) (
 		// transaction.
 		c.remoteDeliveryScript = msg.Address
 
+		if err := c.setRemoteCloseOutputs(msg); err != nil {
+			return noShutdown, err
+		}
+
 		// We'll generate a shutdown message of our own to send across
 		// the wire.
 		localShutdown, err := c.initChanShutdown()
@@ -592,6 +725,10 @@ func (c *ChanCloser) ReceiveShutdown(msg lnwire.Shutdown) (
 		// script.
 		c.remoteDeliveryScript = msg.Address
 
+		if err := c.setRemoteCloseOutputs(msg); err != nil {
+			return noShutdown, err
+		}
+
 		// At this point, we can now start the fee negotiation state, by
 		// constructing and sending our initial signature for what we
 		// think the closing transaction should look like.
@@ -847,6 +984,7 @@ func (c *ChanCloser) ReceiveClosingSigned( //nolint:funlen
 			}
 		}
 
+		closeOpts = append(closeOpts, c.closeOutputOpts()...)
 		closeTx, _, err := c.cfg.Channel.CompleteCooperativeClose(
 			localSig, remoteSig, c.localDeliveryScript,
 			c.remoteDeliveryScript, remoteProposedFee, closeOpts...,
@@ -928,6 +1066,7 @@ func (c *ChanCloser) proposeCloseSigned(fee btcutil.Amount) (
 		}
 	}
 
+	closeOpts = append(closeOpts, c.closeOutputOpts()...)
 	rawSig, _, _, err := c.cfg.Channel.CreateCloseProposal(
 		fee, c.localDeliveryScript, c.remoteDeliveryScript,
 		closeOpts...,
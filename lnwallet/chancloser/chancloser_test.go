@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -144,6 +145,10 @@ type mockChannel struct {
 	chanType  channeldb.ChannelType
 	localKey  keychain.KeyDescriptor
 	remoteKey keychain.KeyDescriptor
+
+	remoteUpfrontScript lnwire.DeliveryAddress
+
+	closeSig input.Signature
 }
 
 func (m *mockChannel) ChannelPoint() wire.OutPoint {
@@ -171,7 +176,7 @@ func (m *mockChannel) AbsoluteThawHeight() (uint32, error) {
 }
 
 func (m *mockChannel) RemoteUpfrontShutdownScript() lnwire.DeliveryAddress {
-	return lnwire.DeliveryAddress{}
+	return m.remoteUpfrontScript
 }
 
 func (m *mockChannel) CreateCloseProposal(fee btcutil.Amount,
@@ -188,7 +193,7 @@ func (m *mockChannel) CreateCloseProposal(fee btcutil.Amount,
 		), nil, 0, nil
 	}
 
-	return nil, nil, 0, nil
+	return m.closeSig, nil, 0, nil
 }
 
 func (m *mockChannel) CompleteCooperativeClose(localSig,
@@ -608,3 +613,229 @@ func TestTaprootFastClose(t *testing.T) {
 	require.NotNil(t, tx)
 	require.True(t, oClosingSigned.IsNone())
 }
+
+// TestRemoteCloseOutputs tests that the additional close outputs requested by
+// the remote party are validated before they're used to split its balance.
+func TestRemoteCloseOutputs(t *testing.T) {
+	t.Parallel()
+
+	p2wkh := make([]byte, 22)
+	p2wkh[0], p2wkh[1] = txscript.OP_0, txscript.OP_DATA_20
+	p2wsh := make([]byte, 34)
+	p2wsh[0], p2wsh[1] = txscript.OP_0, txscript.OP_DATA_32
+
+	validOutputs := lnwire.CloseOutputs{
+		{Value: 50_000, PkScript: p2wkh},
+		{Value: 60_000, PkScript: p2wsh},
+	}
+
+	tests := []struct {
+		name           string
+		notSupported   bool
+		upfrontScript  lnwire.DeliveryAddress
+		outputs        lnwire.CloseOutputs
+		expectedErr    error
+		expectedOutput lnwire.CloseOutputs
+	}{
+		{
+			name:           "no close outputs",
+			expectedOutput: nil,
+		},
+		{
+			name:           "valid close outputs",
+			outputs:        validOutputs,
+			expectedOutput: validOutputs,
+		},
+		{
+			name:         "close outputs not supported",
+			notSupported: true,
+			outputs:      validOutputs,
+			expectedErr:  ErrCloseOutputsNotSupported,
+		},
+		{
+			name:           "no close outputs not supported",
+			notSupported:   true,
+			expectedOutput: nil,
+		},
+		{
+			name: "invalid close output script",
+			outputs: lnwire.CloseOutputs{
+				{Value: 50_000, PkScript: []byte{1, 2, 3}},
+			},
+			expectedErr: ErrInvalidShutdownScript,
+		},
+		{
+			name:          "upfront shutdown script set",
+			upfrontScript: p2wkh,
+			outputs:       validOutputs,
+			expectedErr:   ErrUpfrontShutdownScriptMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			closeCfg := ChanCloseCfg{
+				Channel: &mockChannel{
+					remoteUpfrontScript: test.upfrontScript,
+				},
+				ChainParams:        &chaincfg.SimNetParams,
+				AcceptCloseOutputs: !test.notSupported,
+			}
+			chanCloser := NewChanCloser(
+				closeCfg, nil, 0, 0, nil, false,
+			)
+
+			shutdown := lnwire.NewShutdown(
+				lnwire.ChannelID{}, p2wkh,
+			)
+			if len(test.outputs) != 0 {
+				shutdown.CloseOutputs = lnwire.SomeCloseOutputs(
+					test.outputs,
+				)
+			}
+
+			err := chanCloser.setRemoteCloseOutputs(*shutdown)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(
+				t, test.expectedOutput,
+				chanCloser.remoteCloseOutputs,
+			)
+		})
+	}
+}
+
+// newRbfChanCloser returns a ChanCloser that uses the RBF coop close protocol
+// and awaits the channel to be flushed. Any broadcast transaction is sent on
+// the returned channel.
+func newRbfChanCloser(t *testing.T, locallyInitiated bool) (*ChanCloser,
+	chan *wire.MsgTx) {
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	p2wkh := make([]byte, 22)
+	p2wkh[0], p2wkh[1] = txscript.OP_0, txscript.OP_DATA_20
+
+	broadcastChan := make(chan *wire.MsgTx, 1)
+	closeCfg := ChanCloseCfg{
+		Channel: &mockChannel{
+			closeSig: ecdsa.Sign(
+				privKey, chainhash.DoubleHashB([]byte("close")),
+			),
+		},
+		FeeEstimator: &mockCoopFeeEstimator{targetFee: 1_000},
+		BroadcastTx: func(tx *wire.MsgTx, _ string) error {
+			broadcastChan <- tx
+			return nil
+		},
+		EnableRbf: true,
+	}
+	chanCloser := NewChanCloser(
+		closeCfg, p2wkh, chainfee.FeePerKwFloor, 0, nil,
+		locallyInitiated,
+	)
+	chanCloser.remoteDeliveryScript = p2wkh
+	chanCloser.state = closeAwaitingFlush
+
+	return chanCloser, broadcastChan
+}
+
+// TestRbfCoopClose tests that the closer and closee of the RBF coop close
+// protocol are able to broadcast a closing transaction, and that either of
+// them is able to bump its fee afterwards.
+func TestRbfCoopClose(t *testing.T) {
+	t.Parallel()
+
+	closer, closerBroadcast := newRbfChanCloser(t, true)
+	closee, closeeBroadcast := newRbfChanCloser(t, false)
+
+	// The closer proposes a closing transaction at its ideal fee rate once
+	// the channel has been flushed.
+	oClosingComplete, err := closer.BeginRbfNegotiation()
+	require.NoError(t, err)
+	closingComplete := oClosingComplete.UnwrapOrFail(t)
+	require.Equal(t, btcutil.Amount(1_000), closingComplete.FeeSatoshis)
+	require.True(t, closingComplete.CloserAndClosee.IsSome())
+
+	// A fee bump is only possible once a closing transaction has been
+	// broadcast.
+	_, err = closer.ProposeRbfClose(chainfee.FeePerKwFloor*2, nil)
+	require.ErrorIs(t, err, ErrInvalidState)
+
+	// If the proposal reaches the closee before its side of the channel
+	// has been flushed, it's cached until the negotiation begins.
+	oClosingSig, err := closee.ReceiveClosingComplete(closingComplete)
+	require.NoError(t, err)
+	require.True(t, oClosingSig.IsNone())
+
+	oClosingComplete, err = closee.BeginRbfNegotiation()
+	require.NoError(t, err)
+	require.True(t, oClosingComplete.IsNone())
+
+	cached := closee.TakeCachedClosingComplete().UnwrapOrFail(t)
+	require.True(t, closee.TakeCachedClosingComplete().IsNone())
+
+	// The closee signs and broadcasts the closing transaction, and returns
+	// its signature in the same slot.
+	oClosingSig, err = closee.ReceiveClosingComplete(cached)
+	require.NoError(t, err)
+	closingSig := oClosingSig.UnwrapOrFail(t)
+	require.True(t, closingSig.CloserAndClosee.IsSome())
+	require.NotNil(t, <-closeeBroadcast)
+
+	_, err = closee.ClosingTx()
+	require.NoError(t, err)
+
+	// The closer broadcasts the closing transaction as well once it
+	// receives the signature of the closee.
+	require.NoError(t, closer.ReceiveClosingSig(closingSig))
+	require.NotNil(t, <-closerBroadcast)
+	require.ErrorIs(
+		t, closer.ReceiveClosingSig(closingSig), ErrNoRbfProposal,
+	)
+
+	// A bump must exceed the fee rate of the prior closing transaction,
+	// and only a single proposal can be outstanding at a time.
+	_, err = closer.ProposeRbfClose(chainfee.FeePerKwFloor, nil)
+	require.ErrorIs(t, err, ErrRbfFeeTooLow)
+
+	_, err = closer.ProposeRbfClose(chainfee.FeePerKwFloor*2, nil)
+	require.NoError(t, err)
+
+	_, err = closer.ProposeRbfClose(chainfee.FeePerKwFloor*3, nil)
+	require.ErrorIs(t, err, ErrRbfProposalPending)
+
+	// The closee is able to bump the fee as well, acting as the closer of
+	// its own proposal.
+	bump, err := closee.ProposeRbfClose(chainfee.FeePerKwFloor*2, nil)
+	require.NoError(t, err)
+
+	oClosingSig, err = closer.ReceiveClosingComplete(*bump)
+	require.NoError(t, err)
+	err = closee.ReceiveClosingSig(oClosingSig.UnwrapOrFail(t))
+	require.NoError(t, err)
+	require.NotNil(t, <-closerBroadcast)
+	require.NotNil(t, <-closeeBroadcast)
+}
+
+// TestRbfCoopCloseNotEnabled tests that the RBF close messages are rejected if
+// the RBF coop close protocol isn't used.
+func TestRbfCoopCloseNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	chanCloser, _ := newRbfChanCloser(t, true)
+	chanCloser.cfg.EnableRbf = false
+
+	_, err := chanCloser.BeginRbfNegotiation()
+	require.ErrorIs(t, err, ErrRbfNotEnabled)
+
+	_, err = chanCloser.ReceiveClosingComplete(lnwire.ClosingComplete{})
+	require.ErrorIs(t, err, ErrRbfNotEnabled)
+
+	err = chanCloser.ReceiveClosingSig(lnwire.ClosingSig{})
+	require.ErrorIs(t, err, ErrRbfNotEnabled)
+}
@@ -0,0 +1,462 @@
+package chancloser
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/mempool"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/labels"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+var (
+	// ErrRbfNotEnabled is returned when an RBF close message or fee bump
+	// is processed by a ChanCloser that doesn't use the RBF based
+	// cooperative close protocol.
+	ErrRbfNotEnabled = fmt.Errorf("rbf coop close not enabled")
+
+	// ErrRbfProposalPending is returned when we attempt to bump the fee of
+	// the closing transaction while our prior proposal still awaits the
+	// signature of the remote party.
+	ErrRbfProposalPending = fmt.Errorf("rbf close proposal still pending")
+
+	// ErrRbfFeeTooLow is returned when a fee bump doesn't exceed the fee
+	// rate of our prior closing transaction.
+	ErrRbfFeeTooLow = fmt.Errorf("fee rate must exceed the fee rate of " +
+		"the prior closing transaction")
+
+	// ErrNoRbfProposal is returned when we receive a ClosingSig while we
+	// don't have an outstanding RBF close proposal.
+	ErrNoRbfProposal = fmt.Errorf("no outstanding rbf close proposal")
+
+	// errNoClosingSig is returned when a ClosingComplete or ClosingSig
+	// message doesn't carry any signature.
+	errNoClosingSig = fmt.Errorf("no closing signature set")
+)
+
+// closingSigType denotes which of the signature slots of a ClosingComplete or
+// ClosingSig message a signature is sent in.
+type closingSigType uint8
+
+const (
+	// closerAndClosee is a signature for a closing transaction that pays
+	// out to both parties.
+	closerAndClosee closingSigType = iota
+
+	// closerNoClosee is a signature for a closing transaction that omits
+	// the dust output of the closee.
+	closerNoClosee
+
+	// noCloserClosee is a signature for a closing transaction that omits
+	// the dust output of the closer.
+	noCloserClosee
+)
+
+// rbfCloseProposal is an RBF close proposal we sent as the closer, which
+// awaits the signature of the remote party.
+type rbfCloseProposal struct {
+	// fee is the absolute fee of the proposed closing transaction.
+	fee btcutil.Amount
+
+	// feeRate is the fee rate the fee was derived from.
+	feeRate chainfee.SatPerKWeight
+
+	// sequence is the sequence of the funding input of the proposed
+	// closing transaction.
+	sequence uint32
+
+	// sigType is the signature slot our signature was sent in.
+	sigType closingSigType
+
+	// sig is our signature for the proposed closing transaction.
+	sig input.Signature
+
+	// req is the local request that triggered this proposal, if any.
+	req *htlcswitch.ChanClose
+}
+
+// setClosingSig places the signature in the given slot of the closing sigs.
+func setClosingSig(sigs *lnwire.ClosingSigs, sigType closingSigType,
+	sig lnwire.Sig) {
+
+	switch sigType {
+	case closerNoClosee:
+		sigs.CloserNoClosee = tlv.SomeRecordT(
+			tlv.NewRecordT[tlv.TlvType1](sig),
+		)
+
+	case noCloserClosee:
+		sigs.NoCloserClosee = tlv.SomeRecordT(
+			tlv.NewRecordT[tlv.TlvType2](sig),
+		)
+
+	default:
+		sigs.CloserAndClosee = tlv.SomeRecordT(
+			tlv.NewRecordT[tlv.TlvType3](sig),
+		)
+	}
+}
+
+// extractClosingSig returns the signature carried by the closing sigs along
+// with its slot. A signature covering both outputs takes precedence.
+func extractClosingSig(sigs lnwire.ClosingSigs) (closingSigType, lnwire.Sig,
+	error) {
+
+	var (
+		sigType closingSigType
+		sig     fn.Option[lnwire.Sig]
+	)
+	sigs.NoCloserClosee.WhenSome(
+		func(r tlv.RecordT[tlv.TlvType2, lnwire.Sig]) {
+			sigType, sig = noCloserClosee, fn.Some(r.Val)
+		},
+	)
+	sigs.CloserNoClosee.WhenSome(
+		func(r tlv.RecordT[tlv.TlvType1, lnwire.Sig]) {
+			sigType, sig = closerNoClosee, fn.Some(r.Val)
+		},
+	)
+	sigs.CloserAndClosee.WhenSome(
+		func(r tlv.RecordT[tlv.TlvType3, lnwire.Sig]) {
+			sigType, sig = closerAndClosee, fn.Some(r.Val)
+		},
+	)
+
+	wireSig, err := sig.UnwrapOrErr(errNoClosingSig)
+	if err != nil {
+		return 0, lnwire.Sig{}, err
+	}
+
+	return sigType, wireSig, nil
+}
+
+// RbfEnabled returns true if the RBF based cooperative close protocol is used
+// to close the channel.
+func (c *ChanCloser) RbfEnabled() bool {
+	return c.cfg.EnableRbf
+}
+
+// BeginRbfNegotiation is the RBF counterpart of BeginNegotiation. It should be
+// called once the channel has been flushed. If we initiated the shutdown, we
+// act as the closer and this method returns a ClosingComplete message that
+// proposes a closing transaction at our ideal fee rate. Otherwise, we wait for
+// the remote party to propose one.
+func (c *ChanCloser) BeginRbfNegotiation() (fn.Option[lnwire.ClosingComplete],
+	error) {
+
+	noClosingComplete := fn.None[lnwire.ClosingComplete]()
+
+	if !c.cfg.EnableRbf {
+		return noClosingComplete, ErrRbfNotEnabled
+	}
+
+	if c.state != closeAwaitingFlush {
+		return noClosingComplete, ErrInvalidState
+	}
+
+	// Before continuing, mark the channel as cooperatively closed with a
+	// nil txn, so our listchannels rpc reflects that the channel is being
+	// shutdown by the time the closing request returns.
+	err := c.cfg.Channel.MarkCoopBroadcasted(nil, c.locallyInitiated)
+	if err != nil {
+		return noClosingComplete, err
+	}
+
+	c.state = closeFeeNegotiation
+
+	if !c.locallyInitiated {
+		return noClosingComplete, nil
+	}
+
+	closingComplete, err := c.proposeRbfClose(c.idealFeeRate, c.closeReq)
+	if err != nil {
+		return noClosingComplete, fmt.Errorf("unable to sign new co "+
+			"op close offer: %w", err)
+	}
+
+	return fn.Some(*closingComplete), nil
+}
+
+// TakeCachedClosingComplete returns, and clears, any ClosingComplete message
+// that was received before the channel was flushed. The caller should process
+// it once BeginRbfNegotiation has been called.
+func (c *ChanCloser) TakeCachedClosingComplete() (
+	cached fn.Option[lnwire.ClosingComplete]) {
+
+	cached = c.cachedClosingComplete
+	c.cachedClosingComplete = fn.None[lnwire.ClosingComplete]()
+
+	return cached
+}
+
+// ProposeRbfClose bumps the fee of a closing transaction that has already
+// been broadcast by either party. It crafts a new closing transaction at the
+// given fee rate, of which we pay the full fee as the closer, and returns the
+// ClosingComplete message carrying our signature for it. The passed request,
+// if any, is notified once the transaction has been broadcast.
+func (c *ChanCloser) ProposeRbfClose(feeRate chainfee.SatPerKWeight,
+	req *htlcswitch.ChanClose) (*lnwire.ClosingComplete, error) {
+
+	if !c.cfg.EnableRbf {
+		return nil, ErrRbfNotEnabled
+	}
+
+	if c.state != closeFinished {
+		return nil, ErrInvalidState
+	}
+
+	return c.proposeRbfClose(feeRate, req)
+}
+
+// proposeRbfClose crafts a new closing transaction at the given fee rate and
+// returns the ClosingComplete message carrying our signature for it.
+func (c *ChanCloser) proposeRbfClose(feeRate chainfee.SatPerKWeight,
+	req *htlcswitch.ChanClose) (*lnwire.ClosingComplete, error) {
+
+	if c.rbfProposal.IsSome() {
+		return nil, ErrRbfProposalPending
+	}
+
+	if feeRate <= c.lastRbfFeeRate {
+		return nil, fmt.Errorf("%w: %v <= %v", ErrRbfFeeTooLow,
+			feeRate, c.lastRbfFeeRate)
+	}
+
+	fee := c.estimateCloseFee(feeRate)
+	sequence := uint32(mempool.MaxRBFSequence)
+
+	closeOpts := append([]lnwallet.ChanCloseOpt{
+		lnwallet.WithCustomPayer(lnwallet.CloseFeePayerLocal),
+		lnwallet.WithCustomSequence(sequence),
+		lnwallet.WithRbfCoopClose(),
+	}, c.closeOutputOpts()...)
+
+	rawSig, _, _, err := c.cfg.Channel.CreateCloseProposal(
+		fee, c.localDeliveryScript, c.remoteDeliveryScript,
+		closeOpts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	wireSig, err := lnwire.NewSigFromSignature(rawSig)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature slot signals which outputs we expect to end up in the
+	// closing transaction. As we're the closer, our output is the closer
+	// output.
+	sigType := closerAndClosee
+	switch {
+	case c.cfg.Channel.LocalBalanceDust():
+		sigType = noCloserClosee
+
+	case c.cfg.Channel.RemoteBalanceDust():
+		sigType = closerNoClosee
+	}
+
+	c.rbfProposal = fn.Some(rbfCloseProposal{
+		fee:      fee,
+		feeRate:  feeRate,
+		sequence: sequence,
+		sigType:  sigType,
+		sig:      rawSig,
+		req:      req,
+	})
+
+	chancloserLog.Infof("ChannelPoint(%v): proposing rbf close with fee "+
+		"of %v sat (%v)", c.chanPoint, int64(fee), feeRate)
+
+	closingComplete := &lnwire.ClosingComplete{
+		ChannelID:   c.cid,
+		FeeSatoshis: fee,
+		Sequence:    sequence,
+	}
+	setClosingSig(&closingComplete.ClosingSigs, sigType, wireSig)
+
+	return closingComplete, nil
+}
+
+// RbfCloseRequest returns the local request that triggered our outstanding
+// RBF close proposal, if any.
+func (c *ChanCloser) RbfCloseRequest() *htlcswitch.ChanClose {
+	var req *htlcswitch.ChanClose
+	c.rbfProposal.WhenSome(func(p rbfCloseProposal) {
+		req = p.req
+	})
+
+	return req
+}
+
+// ReceiveClosingComplete is called when we receive a ClosingComplete message
+// from the remote party, which acts as the closer and pays the full fee of
+// the proposed closing transaction. We'll verify their signature, broadcast
+// the fully signed closing transaction and return the ClosingSig message that
+// carries our own signature.
+func (c *ChanCloser) ReceiveClosingComplete(
+	msg lnwire.ClosingComplete) (fn.Option[lnwire.ClosingSig], error) {
+
+	noClosingSig := fn.None[lnwire.ClosingSig]()
+
+	if !c.cfg.EnableRbf {
+		return noClosingSig, ErrRbfNotEnabled
+	}
+
+	switch c.state {
+	// The remote party may have finished flushing the channel before our
+	// link did, so we'll process their proposal once we're ready.
+	case closeAwaitingFlush:
+		c.cachedClosingComplete = fn.Some(msg)
+		return noClosingSig, nil
+
+	case closeFeeNegotiation, closeFinished:
+
+	default:
+		return noClosingSig, ErrInvalidState
+	}
+
+	sigType, remoteWireSig, err := extractClosingSig(msg.ClosingSigs)
+	if err != nil {
+		return noClosingSig, err
+	}
+	remoteSig, err := remoteWireSig.ToSignature()
+	if err != nil {
+		return noClosingSig, err
+	}
+
+	closeOpts := append([]lnwallet.ChanCloseOpt{
+		lnwallet.WithCustomPayer(lnwallet.CloseFeePayerRemote),
+		lnwallet.WithCustomSequence(msg.Sequence),
+		lnwallet.WithRbfCoopClose(),
+	}, c.closeOutputOpts()...)
+
+	localSig, _, _, err := c.cfg.Channel.CreateCloseProposal(
+		msg.FeeSatoshis, c.localDeliveryScript, c.remoteDeliveryScript,
+		closeOpts...,
+	)
+	if err != nil {
+		return noClosingSig, err
+	}
+
+	closeTx, _, err := c.cfg.Channel.CompleteCooperativeClose(
+		localSig, remoteSig, c.localDeliveryScript,
+		c.remoteDeliveryScript, msg.FeeSatoshis, closeOpts...,
+	)
+	if err != nil {
+		return noClosingSig, err
+	}
+
+	chancloserLog.Infof("ChannelPoint(%v): accepted rbf close with fee "+
+		"of %v sat from remote party", c.chanPoint,
+		int64(msg.FeeSatoshis))
+
+	if err := c.publishRbfClose(closeTx); err != nil {
+		return noClosingSig, err
+	}
+
+	localWireSig, err := lnwire.NewSigFromSignature(localSig)
+	if err != nil {
+		return noClosingSig, err
+	}
+
+	closingSig := lnwire.ClosingSig{
+		ChannelID: c.cid,
+	}
+	setClosingSig(&closingSig.ClosingSigs, sigType, localWireSig)
+
+	return fn.Some(closingSig), nil
+}
+
+// ReceiveClosingSig is called when we receive a ClosingSig message from the
+// remote party in response to our outstanding RBF close proposal. Once their
+// signature has been verified, the fully signed closing transaction is
+// broadcast.
+func (c *ChanCloser) ReceiveClosingSig(msg lnwire.ClosingSig) error {
+	if !c.cfg.EnableRbf {
+		return ErrRbfNotEnabled
+	}
+
+	if c.state != closeFeeNegotiation && c.state != closeFinished {
+		return ErrInvalidState
+	}
+
+	proposal, err := c.rbfProposal.UnwrapOrErr(ErrNoRbfProposal)
+	if err != nil {
+		return err
+	}
+
+	// Whatever the outcome, the proposal has been answered, so a new one
+	// can be made.
+	c.rbfProposal = fn.None[rbfCloseProposal]()
+
+	_, remoteWireSig, err := extractClosingSig(msg.ClosingSigs)
+	if err != nil {
+		return err
+	}
+	remoteSig, err := remoteWireSig.ToSignature()
+	if err != nil {
+		return err
+	}
+
+	closeOpts := append([]lnwallet.ChanCloseOpt{
+		lnwallet.WithCustomPayer(lnwallet.CloseFeePayerLocal),
+		lnwallet.WithCustomSequence(proposal.sequence),
+		lnwallet.WithRbfCoopClose(),
+	}, c.closeOutputOpts()...)
+
+	closeTx, _, err := c.cfg.Channel.CompleteCooperativeClose(
+		proposal.sig, remoteSig, c.localDeliveryScript,
+		c.remoteDeliveryScript, proposal.fee, closeOpts...,
+	)
+	if err != nil {
+		return err
+	}
+
+	chancloserLog.Infof("ChannelPoint(%v): rbf close with fee of %v sat "+
+		"accepted by remote party", c.chanPoint, int64(proposal.fee))
+
+	c.lastRbfFeeRate = proposal.feeRate
+
+	return c.publishRbfClose(closeTx)
+}
+
+// publishRbfClose persists and broadcasts the passed closing transaction, and
+// transitions into the closeFinished state. As the remote party may have
+// already broadcast a conflicting closing transaction that pays a higher fee,
+// a failed broadcast isn't treated as an error.
+func (c *ChanCloser) publishRbfClose(closeTx *wire.MsgTx) error {
+	c.closingTx = closeTx
+	c.state = closeFinished
+
+	// Before publishing the closing tx, we persist it to the database,
+	// such that it can be republished if something goes wrong.
+	err := c.cfg.Channel.MarkCoopBroadcasted(closeTx, c.locallyInitiated)
+	if err != nil {
+		return err
+	}
+
+	chancloserLog.Infof("Broadcasting rbf cooperative close tx: %v",
+		newLogClosure(func() string {
+			return spew.Sdump(closeTx)
+		}),
+	)
+
+	chanID := c.cfg.Channel.ShortChanID()
+	closeLabel := labels.MakeLabel(labels.LabelTypeChannelClose, &chanID)
+
+	if err := c.cfg.BroadcastTx(closeTx, closeLabel); err != nil {
+		chancloserLog.Warnf("ChannelPoint(%v): unable to broadcast rbf "+
+			"close tx %v: %v", c.chanPoint, closeTx.TxHash(), err)
+	}
+
+	return nil
+}
@@ -100,6 +100,13 @@ type Request struct {
 	// set to zero, then zero conf outputs may be spent.
 	MinConfs int32
 
+	// ZeroConf should be set if the channel is a zero-conf channel. As
+	// such a channel is used before its funding transaction confirms, the
+	// client-selected outpoints aren't required to satisfy MinConfs, which
+	// allows to fund a zero-conf channel with unconfirmed coins while still
+	// using coin control.
+	ZeroConf bool
+
 	// SubtractFees should be set if we intend to spend exactly LocalAmt
 	// when opening the channel, subtracting the fees from the funding
 	// output. This can be used for instance to use all our remaining funds
@@ -119,6 +126,12 @@ type Request struct {
 	// output. By definition, this'll also use segwit v1 (taproot) for the
 	// funding output.
 	Musig2 bool
+
+	// DryRun if true, then the WalletAssembler only performs coin
+	// selection without leasing the selected coins. The returned intent
+	// can be inspected to learn about the would-be funding transaction
+	// but must not be used to actually fund a channel.
+	DryRun bool
 }
 
 // Intent is returned by an Assembler and represents the base functionality the
@@ -88,6 +88,28 @@ func selectInputs(amt btcutil.Amount, coins []wallet.Coin,
 	return 0, nil, &ErrInsufficientFunds{amt, satSelected}
 }
 
+// addInputWeight adds the weight of spending the given wallet coin to the
+// weight estimator.
+func addInputWeight(weightEstimate *input.TxWeightEstimator,
+	utxo wallet.Coin) error {
+
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(utxo.PkScript):
+		weightEstimate.AddP2WKHInput()
+
+	case txscript.IsPayToScriptHash(utxo.PkScript):
+		weightEstimate.AddNestedP2WKHInput()
+
+	case txscript.IsPayToTaproot(utxo.PkScript):
+		weightEstimate.AddTaprootKeySpendInput(txscript.SigHashDefault)
+
+	default:
+		return &errUnsupportedInput{utxo.PkScript}
+	}
+
+	return nil
+}
+
 // calculateFees returns for the specified utxos and fee rate two fee
 // estimates, one calculated using a change output and one without. The weight
 // added to the estimator from a change output is for a P2WKH output.
@@ -97,20 +119,9 @@ func calculateFees(utxos []wallet.Coin, feeRate chainfee.SatPerKWeight,
 
 	weightEstimate := existingWeight
 	for _, utxo := range utxos {
-		switch {
-		case txscript.IsPayToWitnessPubKeyHash(utxo.PkScript):
-			weightEstimate.AddP2WKHInput()
-
-		case txscript.IsPayToScriptHash(utxo.PkScript):
-			weightEstimate.AddNestedP2WKHInput()
-
-		case txscript.IsPayToTaproot(utxo.PkScript):
-			weightEstimate.AddTaprootKeySpendInput(
-				txscript.SigHashDefault,
-			)
-
-		default:
-			return 0, 0, &errUnsupportedInput{utxo.PkScript}
+		err := addInputWeight(&weightEstimate, utxo)
+		if err != nil {
+			return 0, 0, err
 		}
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
 )
 
 const (
@@ -71,6 +72,10 @@ type FullIntent struct {
 
 	// signer is the Assembler's instance of the Singer interface.
 	signer input.Signer
+
+	// dryRun indicates that the input coins weren't leased, so they must
+	// not be released when the intent is canceled.
+	dryRun bool
 }
 
 // BindKeys is a method unique to the FullIntent variant. This allows the
@@ -218,6 +223,14 @@ func (f *FullIntent) Outputs() []*wire.TxOut {
 //
 // NOTE: Part of the chanfunding.Intent interface.
 func (f *FullIntent) Cancel() {
+	// The coins of a dry run intent were never leased, so we must not
+	// release them as they might have been leased by someone else.
+	if f.dryRun {
+		f.ShimIntent.Cancel()
+
+		return
+	}
+
 	for _, coin := range f.InputCoins {
 		err := f.coinLeaser.ReleaseOutput(
 			LndInternalLockID, coin.OutPoint,
@@ -231,6 +244,29 @@ func (f *FullIntent) Cancel() {
 	f.ShimIntent.Cancel()
 }
 
+// Weight returns the estimated weight of the funding transaction described by
+// the intent. The weight only accounts for the inputs and outputs we know
+// about, which are our own inputs, the change outputs and the funding output.
+func (f *FullIntent) Weight() (lntypes.WeightUnit, error) {
+	// A funding output is either a P2WSH or a P2TR output, both of which
+	// have the same size.
+	var weightEstimate input.TxWeightEstimator
+	weightEstimate.AddP2WSHOutput()
+
+	for _, coin := range f.InputCoins {
+		err := addInputWeight(&weightEstimate, coin)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, changeOutput := range f.ChangeOutputs {
+		weightEstimate.AddOutput(changeOutput.PkScript)
+	}
+
+	return weightEstimate.Weight(), nil
+}
+
 // A compile-time check to ensure FullIntent meets the Intent interface.
 var _ Intent = (*FullIntent)(nil)
 
@@ -327,9 +363,25 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 			return err
 		}
 
+		// A zero-conf channel doesn't wait for its funding transaction
+		// to confirm, so the manually selected coins may be
+		// unconfirmed as well. We therefore check them against all of
+		// our unspent coins.
+		manualCoinsAvailable := allCoins
+		if r.ZeroConf && len(manuallySelectedCoins) > 0 &&
+			r.MinConfs > 0 {
+
+			manualCoinsAvailable, err = w.cfg.CoinSource.ListCoins(
+				0, math.MaxInt32,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Ensure that all manually selected coins remain unspent.
 		unspent := make(map[wire.OutPoint]struct{})
-		for _, coin := range allCoins {
+		for _, coin := range manualCoinsAvailable {
 			unspent[coin.OutPoint] = struct{}{}
 		}
 		for _, coin := range manuallySelectedCoins {
@@ -414,7 +466,12 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 				}
 
 				sumManual := sumCoins(manuallySelectedCoins)
-				sumAll := sumCoins(allCoins)
+
+				// The manually selected coins of a zero-conf
+				// channel may be unconfirmed, so we need to
+				// sum up the same set of coins they were
+				// checked against.
+				sumAll := sumCoins(manualCoinsAvailable)
 
 				// If sufficient reserve funds are available we
 				// don't have to provide for it during coin
@@ -521,8 +578,13 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 
 		// Lock the selected coins. These coins are now "reserved",
 		// this prevents concurrent funding requests from referring to
-		// and this double-spending the same set of coins.
+		// and this double-spending the same set of coins. A dry run
+		// doesn't lock anything as the coins won't be spent.
 		for _, coin := range selectedCoins {
+			if r.DryRun {
+				break
+			}
+
 			outpoint := coin.OutPoint
 
 			_, _, _, err = w.cfg.CoinLeaser.LeaseOutput(
@@ -544,6 +606,7 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 			coinLeaser: w.cfg.CoinLeaser,
 			coinSource: w.cfg.CoinSource,
 			signer:     w.cfg.Signer,
+			dryRun:     r.DryRun,
 		}
 
 		if changeOutput != nil {
@@ -0,0 +1,290 @@
+package chanfunding
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCoinSource is a simple CoinSource that returns a static set of coins.
+type mockCoinSource struct {
+	coins []wallet.Coin
+
+	// unconfirmed is the set of coins that are only returned if
+	// unconfirmed coins are requested.
+	unconfirmed map[wire.OutPoint]struct{}
+}
+
+// ListCoins returns all coins of the mock that satisfy the minimum number of
+// confirmations.
+func (m *mockCoinSource) ListCoins(minConfs int32,
+	_ int32) ([]wallet.Coin, error) {
+
+	var coins []wallet.Coin
+	for _, coin := range m.coins {
+		_, unconfirmed := m.unconfirmed[coin.OutPoint]
+		if unconfirmed && minConfs > 0 {
+			continue
+		}
+
+		coins = append(coins, coin)
+	}
+
+	return coins, nil
+}
+
+// CoinFromOutPoint returns the coin with the given outpoint.
+func (m *mockCoinSource) CoinFromOutPoint(
+	op wire.OutPoint) (*wallet.Coin, error) {
+
+	for _, coin := range m.coins {
+		if coin.OutPoint == op {
+			coin := coin
+			return &coin, nil
+		}
+	}
+
+	return nil, fmt.Errorf("coin %v not found", op)
+}
+
+// mockCoinLocker is a CoinSelectionLocker that simply runs the closure.
+type mockCoinLocker struct{}
+
+// WithCoinSelectLock runs the passed closure.
+func (m *mockCoinLocker) WithCoinSelectLock(f func() error) error {
+	return f()
+}
+
+// mockOutputLeaser is an OutputLeaser that records the leased outputs.
+type mockOutputLeaser struct {
+	leased map[wire.OutPoint]struct{}
+}
+
+// LeaseOutput records the given outpoint as leased.
+func (m *mockOutputLeaser) LeaseOutput(_ wtxmgr.LockID, o wire.OutPoint,
+	_ time.Duration) (time.Time, []byte, btcutil.Amount, error) {
+
+	m.leased[o] = struct{}{}
+
+	return time.Time{}, nil, 0, nil
+}
+
+// ReleaseOutput removes the given outpoint from the leased set.
+func (m *mockOutputLeaser) ReleaseOutput(_ wtxmgr.LockID,
+	o wire.OutPoint) error {
+
+	delete(m.leased, o)
+
+	return nil
+}
+
+// TestWalletAssemblerDryRun makes sure that a dry run coin selection results
+// in the same intent as a normal one, without leasing any coins.
+func TestWalletAssemblerDryRun(t *testing.T) {
+	t.Parallel()
+
+	coins := []wallet.Coin{{
+		TxOut: wire.TxOut{
+			PkScript: p2wkhScript,
+			Value:    btcutil.SatoshiPerBitcoin,
+		},
+		OutPoint: wire.OutPoint{Index: 1},
+	}}
+	leaser := &mockOutputLeaser{
+		leased: make(map[wire.OutPoint]struct{}),
+	}
+	assembler := NewWalletAssembler(WalletConfig{
+		CoinSource:            &mockCoinSource{coins: coins},
+		CoinSelectionStrategy: wallet.CoinSelectionLargest,
+		CoinSelectLocker:      &mockCoinLocker{},
+		CoinLeaser:            leaser,
+		DustLimit:             500,
+	})
+
+	changeAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		p2wkhScript[2:], &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+
+	feeRate := chainfee.SatPerKWeight(1000)
+	newRequest := func(dryRun bool) *Request {
+		return &Request{
+			LocalAmt: btcutil.SatoshiPerBitcoin / 2,
+			FeeRate:  feeRate,
+			ChangeAddr: func() (btcutil.Address, error) {
+				return changeAddr, nil
+			},
+			DryRun: dryRun,
+		}
+	}
+
+	// A dry run must not lease the selected coins.
+	dryIntent, err := assembler.ProvisionChannel(newRequest(true))
+	require.NoError(t, err)
+	require.Empty(t, leaser.leased)
+
+	// Canceling the dry run intent must not release anything either, so
+	// we lease the coin manually and make sure it stays leased.
+	leaser.leased[coins[0].OutPoint] = struct{}{}
+	dryIntent.Cancel()
+	require.Len(t, leaser.leased, 1)
+	delete(leaser.leased, coins[0].OutPoint)
+
+	// A normal run leases the coins and must result in the same
+	// selection.
+	intent, err := assembler.ProvisionChannel(newRequest(false))
+	require.NoError(t, err)
+	require.Len(t, leaser.leased, 1)
+
+	dryFullIntent, ok := dryIntent.(*FullIntent)
+	require.True(t, ok)
+	fullIntent, ok := intent.(*FullIntent)
+	require.True(t, ok)
+
+	require.Equal(t, fullIntent.InputCoins, dryFullIntent.InputCoins)
+	require.Equal(t, fullIntent.ChangeOutputs, dryFullIntent.ChangeOutputs)
+	require.Equal(
+		t, fullIntent.LocalFundingAmt(), dryFullIntent.LocalFundingAmt(),
+	)
+
+	// The weight of the intent must match the estimate used for the coin
+	// selection.
+	weight, err := dryFullIntent.Weight()
+	require.NoError(t, err)
+
+	var weightEstimate input.TxWeightEstimator
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WSHOutput()
+	weightEstimate.AddP2WKHOutput()
+	require.Equal(t, weightEstimate.Weight(), weight)
+
+	fee := btcutil.Amount(coins[0].Value) - dryFullIntent.LocalFundingAmt() -
+		btcutil.Amount(dryFullIntent.ChangeOutputs[0].Value)
+	require.Equal(t, fundingFee(feeRate, 1, true), fee)
+
+	intent.Cancel()
+	require.Empty(t, leaser.leased)
+}
+
+// TestWalletAssemblerZeroConfOutpoints makes sure that unconfirmed coins can
+// be manually selected to fund a zero-conf channel, both with a fixed local
+// amount and with fundmax.
+func TestWalletAssemblerZeroConfOutpoints(t *testing.T) {
+	t.Parallel()
+
+	confirmed := wallet.Coin{
+		TxOut: wire.TxOut{
+			PkScript: p2wkhScript,
+			Value:    btcutil.SatoshiPerBitcoin,
+		},
+		OutPoint: wire.OutPoint{Index: 1},
+	}
+	unconfirmed := wallet.Coin{
+		TxOut: wire.TxOut{
+			PkScript: p2wkhScript,
+			Value:    btcutil.SatoshiPerBitcoin,
+		},
+		OutPoint: wire.OutPoint{Index: 2},
+	}
+
+	changeAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		p2wkhScript[2:], &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+
+	coinSource := &mockCoinSource{
+		coins: []wallet.Coin{confirmed, unconfirmed},
+		unconfirmed: map[wire.OutPoint]struct{}{
+			unconfirmed.OutPoint: {},
+		},
+	}
+	strategy := wallet.CoinSelectionLargest
+
+	const halfBTC = btcutil.SatoshiPerBitcoin / 2
+
+	testCases := []struct {
+		name      string
+		zeroConf  bool
+		fundMax   bool
+		expectErr bool
+	}{
+		{
+			name:      "unconfirmed outpoint without zero-conf",
+			expectErr: true,
+		},
+		{
+			name:     "unconfirmed outpoint with zero-conf",
+			zeroConf: true,
+		},
+		{
+			name:     "unconfirmed outpoint with zero-conf fundmax",
+			zeroConf: true,
+			fundMax:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assembler := NewWalletAssembler(WalletConfig{
+				CoinSource:            coinSource,
+				CoinSelectionStrategy: strategy,
+				CoinSelectLocker:      &mockCoinLocker{},
+				DustLimit:             500,
+			})
+
+			req := &Request{
+				Outpoints: []wire.OutPoint{
+					unconfirmed.OutPoint,
+				},
+				MinConfs: 1,
+				ZeroConf: tc.zeroConf,
+				FeeRate:  chainfee.SatPerKWeight(1000),
+				ChangeAddr: func() (btcutil.Address, error) {
+					return changeAddr, nil
+				},
+				DryRun: true,
+			}
+			if tc.fundMax {
+				req.FundUpToMaxAmt = btcutil.SatoshiPerBitcoin
+				req.MinFundAmt = halfBTC
+				req.WalletReserve = halfBTC
+			} else {
+				req.LocalAmt = halfBTC
+			}
+
+			intent, err := assembler.ProvisionChannel(req)
+			if tc.expectErr {
+				require.ErrorContains(t, err, "already spent")
+				return
+			}
+			require.NoError(t, err)
+
+			fullIntent, ok := intent.(*FullIntent)
+			require.True(t, ok)
+			require.Equal(
+				t, []wallet.Coin{unconfirmed},
+				fullIntent.InputCoins,
+			)
+
+			// With fundmax, the confirmed coin covers the reserve,
+			// so the unconfirmed coin is spent entirely without
+			// any change.
+			if tc.fundMax {
+				require.Empty(t, fullIntent.ChangeOutputs)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/fn"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lntypes"
@@ -39,6 +40,11 @@ var (
 	// that has already been closed or is in the process of being closed.
 	ErrChanClosing = fmt.Errorf("channel is being closed, operation disallowed")
 
+	// ErrCloseOutputsExceedBalance is returned when the additional close
+	// outputs of a party can't be paid from its settled balance.
+	ErrCloseOutputsExceedBalance = errors.New("close outputs exceed " +
+		"balance")
+
 	// ErrNoWindow is returned when revocation window is exhausted.
 	ErrNoWindow = fmt.Errorf("unable to sign new commitment, the current" +
 		" revocation window is exhausted")
@@ -378,6 +384,19 @@ type PaymentDescriptor struct {
 	// blinded route (ie, not the introduction node) from update_add_htlc's
 	// TLVs.
 	BlindingPoint lnwire.BlindingPointRecord
+
+	// Endorsement is the optional experimental endorsement signal that
+	// was sent along with the htlc in update_add_htlc.
+	Endorsement *lnwire.Endorsement
+
+	// PaymentPoint is the optional experimental point this htlc is locked
+	// to. If set, the htlc is a point time locked contract that can only
+	// be settled by revealing the discrete logarithm of the point.
+	PaymentPoint *lnwire.PaymentPoint
+
+	// CustomRecords is the set of custom records an overlay protocol
+	// attached to the htlc.
+	CustomRecords lnwire.CustomRecords
 }
 
 // PayDescsFromRemoteLogUpdates converts a slice of LogUpdates received from the
@@ -419,6 +438,9 @@ func PayDescsFromRemoteLogUpdates(chanID lnwire.ShortChannelID, height uint64,
 					Index:  uint16(i),
 				},
 				BlindingPoint: pd.BlindingPoint,
+				PaymentPoint:  wireMsg.PaymentPoint,
+				CustomRecords: wireMsg.CustomRecords,
+				Endorsement:   wireMsg.Endorsement,
 			}
 			pd.OnionBlob = make([]byte, len(wireMsg.OnionBlob))
 			copy(pd.OnionBlob[:], wireMsg.OnionBlob[:])
@@ -743,6 +765,8 @@ func (c *commitment) toDiskCommit(ourCommit bool) *channeldb.ChannelCommitment {
 			LogIndex:      htlc.LogIndex,
 			Incoming:      false,
 			BlindingPoint: htlc.BlindingPoint,
+			PaymentPoint:  htlc.PaymentPoint,
+			CustomRecords: htlc.CustomRecords,
 		}
 		copy(h.OnionBlob[:], htlc.OnionBlob)
 
@@ -768,6 +792,8 @@ func (c *commitment) toDiskCommit(ourCommit bool) *channeldb.ChannelCommitment {
 			LogIndex:      htlc.LogIndex,
 			Incoming:      true,
 			BlindingPoint: htlc.BlindingPoint,
+			PaymentPoint:  htlc.PaymentPoint,
+			CustomRecords: htlc.CustomRecords,
 		}
 		copy(h.OnionBlob[:], htlc.OnionBlob)
 		if ourCommit && htlc.sig != nil {
@@ -867,6 +893,8 @@ func (lc *LightningChannel) diskHtlcToPayDesc(feeRate chainfee.SatPerKWeight,
 		theirPkScript:      theirP2WSH,
 		theirWitnessScript: theirWitnessScript,
 		BlindingPoint:      htlc.BlindingPoint,
+		PaymentPoint:       htlc.PaymentPoint,
+		CustomRecords:      htlc.CustomRecords,
 	}, nil
 }
 
@@ -1557,6 +1585,9 @@ func (lc *LightningChannel) logUpdateToPayDesc(logUpdate *channeldb.LogUpdate,
 			LogIndex:              logUpdate.LogIndex,
 			addCommitHeightRemote: commitHeight,
 			BlindingPoint:         wireMsg.BlindingPoint,
+			PaymentPoint:          wireMsg.PaymentPoint,
+			CustomRecords:         wireMsg.CustomRecords,
+			Endorsement:           wireMsg.Endorsement,
 		}
 		pd.OnionBlob = make([]byte, len(wireMsg.OnionBlob))
 		copy(pd.OnionBlob[:], wireMsg.OnionBlob[:])
@@ -1755,6 +1786,9 @@ func (lc *LightningChannel) remoteLogUpdateToPayDesc(logUpdate *channeldb.LogUpd
 			LogIndex:             logUpdate.LogIndex,
 			addCommitHeightLocal: commitHeight,
 			BlindingPoint:        wireMsg.BlindingPoint,
+			PaymentPoint:         wireMsg.PaymentPoint,
+			CustomRecords:        wireMsg.CustomRecords,
+			Endorsement:          wireMsg.Endorsement,
 		}
 		pd.OnionBlob = make([]byte, len(wireMsg.OnionBlob))
 		copy(pd.OnionBlob, wireMsg.OnionBlob[:])
@@ -3616,6 +3650,9 @@ func (lc *LightningChannel) createCommitDiff(
 				Expiry:        pd.Timeout,
 				PaymentHash:   pd.RHash,
 				BlindingPoint: pd.BlindingPoint,
+				PaymentPoint:  pd.PaymentPoint,
+				CustomRecords: pd.CustomRecords,
+				Endorsement:   pd.Endorsement,
 			}
 			copy(htlc.OnionBlob[:], pd.OnionBlob)
 			logUpdate.UpdateMsg = htlc
@@ -3754,6 +3791,9 @@ func (lc *LightningChannel) getUnsignedAckedUpdates() []channeldb.LogUpdate {
 				Expiry:        pd.Timeout,
 				PaymentHash:   pd.RHash,
 				BlindingPoint: pd.BlindingPoint,
+				PaymentPoint:  pd.PaymentPoint,
+				CustomRecords: pd.CustomRecords,
+				Endorsement:   pd.Endorsement,
 			}
 			copy(htlc.OnionBlob[:], pd.OnionBlob)
 			logUpdate.UpdateMsg = htlc
@@ -5754,6 +5794,9 @@ func (lc *LightningChannel) ReceiveRevocation(revMsg *lnwire.RevokeAndAck) (
 				Expiry:        pd.Timeout,
 				PaymentHash:   pd.RHash,
 				BlindingPoint: pd.BlindingPoint,
+				PaymentPoint:  pd.PaymentPoint,
+				CustomRecords: pd.CustomRecords,
+				Endorsement:   pd.Endorsement,
 			}
 			copy(htlc.OnionBlob[:], pd.OnionBlob)
 			logUpdate.UpdateMsg = htlc
@@ -6093,6 +6136,9 @@ func (lc *LightningChannel) htlcAddDescriptor(htlc *lnwire.UpdateAddHTLC,
 		OnionBlob:      htlc.OnionBlob[:],
 		OpenCircuitKey: openKey,
 		BlindingPoint:  htlc.BlindingPoint,
+		PaymentPoint:   htlc.PaymentPoint,
+		CustomRecords:  htlc.CustomRecords,
+		Endorsement:    htlc.Endorsement,
 	}
 }
 
@@ -6151,6 +6197,9 @@ func (lc *LightningChannel) ReceiveHTLC(htlc *lnwire.UpdateAddHTLC) (uint64, err
 		HtlcIndex:     lc.remoteUpdateLog.htlcCounter,
 		OnionBlob:     htlc.OnionBlob[:],
 		BlindingPoint: htlc.BlindingPoint,
+		PaymentPoint:  htlc.PaymentPoint,
+		CustomRecords: htlc.CustomRecords,
+		Endorsement:   htlc.Endorsement,
 	}
 
 	localACKedIndex := lc.remoteCommitChain.tail().ourMessageIndex
@@ -6221,6 +6270,14 @@ func (lc *LightningChannel) SettleHTLC(preimage [32]byte,
 		return ErrInvalidSettlePreimage{preimage[:], htlc.RHash[:]}
 	}
 
+	// If the htlc is locked to a payment point, the preimage also needs to
+	// be the discrete logarithm of that point.
+	if htlc.PaymentPoint != nil && !htlc.PaymentPoint.IsPaidBy(preimage) {
+		return ErrInvalidSettlePoint{
+			preimage[:], htlc.PaymentPoint.Point,
+		}
+	}
+
 	pd := &PaymentDescriptor{
 		Amount:           htlc.Amount,
 		RPreimage:        preimage,
@@ -6266,6 +6323,14 @@ func (lc *LightningChannel) ReceiveHTLCSettle(preimage [32]byte, htlcIndex uint6
 		return ErrInvalidSettlePreimage{preimage[:], htlc.RHash[:]}
 	}
 
+	// If the htlc is locked to a payment point, the preimage also needs to
+	// be the discrete logarithm of that point.
+	if htlc.PaymentPoint != nil && !htlc.PaymentPoint.IsPaidBy(preimage) {
+		return ErrInvalidSettlePoint{
+			preimage[:], htlc.PaymentPoint.Point,
+		}
+	}
+
 	pd := &PaymentDescriptor{
 		Amount:      htlc.Amount,
 		RPreimage:   preimage,
@@ -7758,6 +7823,22 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel,
 // close process.
 type chanCloseOpt struct {
 	musigSession *MusigSession
+
+	// feePayer is the party that pays the fee of the close transaction.
+	feePayer CloseFeePayer
+
+	// sequence is an optional sequence of the funding input of the close
+	// transaction.
+	sequence fn.Option[uint32]
+
+	// rbf indicates that the close transaction may replace a close
+	// transaction that was already signed.
+	rbf bool
+
+	// localCloseOutputs and remoteCloseOutputs are the additional outputs
+	// that split the settled balance of either party.
+	localCloseOutputs  []*wire.TxOut
+	remoteCloseOutputs []*wire.TxOut
 }
 
 // ChanCloseOpt is a closure type that cen be used to modify the set of default
@@ -7778,6 +7859,107 @@ func WithCoopCloseMusigSession(session *MusigSession) ChanCloseOpt {
 	}
 }
 
+// CloseFeePayer identifies the party that pays the fee of a co-op close
+// transaction.
+type CloseFeePayer uint8
+
+const (
+	// CloseFeePayerInitiator denotes that the initiator of the channel
+	// pays the fee, which is the default of the legacy close protocol.
+	CloseFeePayerInitiator CloseFeePayer = iota
+
+	// CloseFeePayerLocal denotes that the local party pays the fee.
+	CloseFeePayerLocal
+
+	// CloseFeePayerRemote denotes that the remote party pays the fee.
+	CloseFeePayerRemote
+)
+
+// WithCustomPayer can be used to override the party that pays the fee of the
+// co-op close transaction.
+func WithCustomPayer(payer CloseFeePayer) ChanCloseOpt {
+	return func(opts *chanCloseOpt) {
+		opts.feePayer = payer
+	}
+}
+
+// WithCustomSequence can be used to set the sequence of the funding input of
+// the co-op close transaction.
+func WithCustomSequence(sequence uint32) ChanCloseOpt {
+	return func(opts *chanCloseOpt) {
+		opts.sequence = fn.Some(sequence)
+	}
+}
+
+// WithRbfCoopClose signals that the co-op close transaction may replace a
+// close transaction that was already signed, as done by the RBF based close
+// protocol. The channel then may already be marked as closing.
+func WithRbfCoopClose() ChanCloseOpt {
+	return func(opts *chanCloseOpt) {
+		opts.rbf = true
+	}
+}
+
+// closeTxOptions returns the options that are used to construct the co-op
+// close transaction of the channel.
+func (lc *LightningChannel) closeTxOptions(opts *chanCloseOpt) []CloseTxOpt {
+	var closeTxOpts []CloseTxOpt
+
+	// If this is a taproot channel, then we use an RBF'able funding input.
+	if lc.channelState.ChanType.IsTaproot() || opts.rbf {
+		closeTxOpts = append(closeTxOpts, WithRBFCloseTx())
+	}
+
+	opts.sequence.WhenSome(func(sequence uint32) {
+		closeTxOpts = append(closeTxOpts, WithCloseTxSequence(sequence))
+	})
+
+	// Split the settled balances across any additional close outputs.
+	if len(opts.localCloseOutputs) != 0 ||
+		len(opts.remoteCloseOutputs) != 0 {
+
+		closeTxOpts = append(closeTxOpts, WithCloseTxOutputs(
+			opts.localCloseOutputs, opts.remoteCloseOutputs,
+		))
+	}
+
+	return closeTxOpts
+}
+
+// WithCoopCloseOutputs can be used to split the settled balances of the local
+// and remote party across additional outputs of the co-op close transaction.
+// The remainder of each balance is paid to the delivery script of its owner.
+func WithCoopCloseOutputs(local, remote []*wire.TxOut) ChanCloseOpt {
+	return func(opts *chanCloseOpt) {
+		opts.localCloseOutputs = local
+		opts.remoteCloseOutputs = remote
+	}
+}
+
+// validateCoopCloseOutputs checks that the additional close outputs of both
+// parties can be paid from their balances after fees.
+func (lc *LightningChannel) validateCoopCloseOutputs(opts *chanCloseOpt,
+	ourBalance, theirBalance btcutil.Amount) error {
+
+	_, err := ValidateCloseOutputs(
+		opts.localCloseOutputs, ourBalance,
+		lc.channelState.LocalChanCfg.DustLimit,
+	)
+	if err != nil {
+		return fmt.Errorf("local close outputs: %w", err)
+	}
+
+	_, err = ValidateCloseOutputs(
+		opts.remoteCloseOutputs, theirBalance,
+		lc.channelState.RemoteChanCfg.DustLimit,
+	)
+	if err != nil {
+		return fmt.Errorf("remote close outputs: %w", err)
+	}
+
+	return nil
+}
+
 // CreateCloseProposal is used by both parties in a cooperative channel close
 // workflow to generate proposed close transactions and signatures. This method
 // should only be executed once all pending HTLCs (if any) on the channel have
@@ -7796,35 +7978,36 @@ func (lc *LightningChannel) CreateCloseProposal(proposedFee btcutil.Amount,
 	lc.Lock()
 	defer lc.Unlock()
 
-	// If we're already closing the channel, then ignore this request.
-	if lc.isClosed {
-		// TODO(roasbeef): check to ensure no pending payments
-		return nil, nil, 0, ErrChanClosing
-	}
-
 	opts := defaultCloseOpts()
 	for _, optFunc := range closeOpts {
 		optFunc(opts)
 	}
 
+	// If we're already closing the channel, then ignore this request,
+	// unless the close transaction is going to be replaced.
+	if lc.isClosed && !opts.rbf {
+		// TODO(roasbeef): check to ensure no pending payments
+		return nil, nil, 0, ErrChanClosing
+	}
+
 	// Get the final balances after subtracting the proposed fee, taking
 	// care not to persist the adjusted balance, as the feeRate may change
 	// during the channel closing process.
 	ourBalance, theirBalance, err := CoopCloseBalance(
 		lc.channelState.ChanType, lc.channelState.IsInitiator,
-		proposedFee, lc.channelState.LocalCommitment,
+		proposedFee, lc.channelState.LocalCommitment, opts.feePayer,
 	)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
-	var closeTxOpts []CloseTxOpt
-
-	// If this is a taproot channel, then we use an RBF'able funding input.
-	if lc.channelState.ChanType.IsTaproot() {
-		closeTxOpts = append(closeTxOpts, WithRBFCloseTx())
+	err = lc.validateCoopCloseOutputs(opts, ourBalance, theirBalance)
+	if err != nil {
+		return nil, nil, 0, err
 	}
 
+	closeTxOpts := lc.closeTxOptions(opts)
+
 	closeTx := CreateCooperativeCloseTx(
 		fundingTxIn(lc.channelState), lc.channelState.LocalChanCfg.DustLimit,
 		lc.channelState.RemoteChanCfg.DustLimit, ourBalance, theirBalance,
@@ -7880,33 +8063,34 @@ func (lc *LightningChannel) CompleteCooperativeClose(
 	lc.Lock()
 	defer lc.Unlock()
 
-	// If the channel is already closing, then ignore this request.
-	if lc.isClosed {
-		// TODO(roasbeef): check to ensure no pending payments
-		return nil, 0, ErrChanClosing
-	}
-
 	opts := defaultCloseOpts()
 	for _, optFunc := range closeOpts {
 		optFunc(opts)
 	}
 
+	// If the channel is already closing, then ignore this request, unless
+	// the close transaction is going to be replaced.
+	if lc.isClosed && !opts.rbf {
+		// TODO(roasbeef): check to ensure no pending payments
+		return nil, 0, ErrChanClosing
+	}
+
 	// Get the final balances after subtracting the proposed fee.
 	ourBalance, theirBalance, err := CoopCloseBalance(
 		lc.channelState.ChanType, lc.channelState.IsInitiator,
-		proposedFee, lc.channelState.LocalCommitment,
+		proposedFee, lc.channelState.LocalCommitment, opts.feePayer,
 	)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	var closeTxOpts []CloseTxOpt
-
-	// If this is a taproot channel, then we use an RBF'able funding input.
-	if lc.channelState.ChanType.IsTaproot() {
-		closeTxOpts = append(closeTxOpts, WithRBFCloseTx())
+	err = lc.validateCoopCloseOutputs(opts, ourBalance, theirBalance)
+	if err != nil {
+		return nil, 0, err
 	}
 
+	closeTxOpts := lc.closeTxOptions(opts)
+
 	// Create the transaction used to return the current settled balance
 	// on this active channel back to both parties. In this current model,
 	// the initiator pays full fees for the cooperative close transaction.
@@ -8561,6 +8745,14 @@ type closeTxOpts struct {
 	// enableRBF indicates whether the cooperative close tx should signal
 	// RBF or not.
 	enableRBF bool
+
+	// localOutputs and remoteOutputs are additional outputs that are paid
+	// from the balance of the local and remote party respectively.
+	localOutputs  []*wire.TxOut
+	remoteOutputs []*wire.TxOut
+
+	// sequence is an optional custom sequence of the funding input.
+	sequence fn.Option[uint32]
 }
 
 // defaultCloseTxOpts returns a closeTxOpts struct with default values.
@@ -8581,6 +8773,52 @@ func WithRBFCloseTx() CloseTxOpt {
 	}
 }
 
+// WithCloseTxSequence sets the sequence of the funding input of the
+// cooperative close tx. It takes precedence over the sequence that signals
+// RBF.
+func WithCloseTxSequence(sequence uint32) CloseTxOpt {
+	return func(o *closeTxOpts) {
+		o.sequence = fn.Some(sequence)
+	}
+}
+
+// WithCloseTxOutputs adds the given outputs to the cooperative close tx. Their
+// values are paid from the balance of the local and remote party respectively,
+// the remainder of each balance is paid to its delivery script.
+func WithCloseTxOutputs(local, remote []*wire.TxOut) CloseTxOpt {
+	return func(o *closeTxOpts) {
+		o.localOutputs = local
+		o.remoteOutputs = remote
+	}
+}
+
+// ValidateCloseOutputs checks that the given additional close outputs can be
+// paid from the given balance, and that each output as well as the remainder
+// that is paid to the delivery script are above the dust limit. The remainder
+// is returned.
+func ValidateCloseOutputs(outputs []*wire.TxOut, balance,
+	dustLimit btcutil.Amount) (btcutil.Amount, error) {
+
+	remainder := balance
+	for _, txOut := range outputs {
+		value := btcutil.Amount(txOut.Value)
+		if value < dustLimit {
+			return 0, fmt.Errorf("close output of %v is below the "+
+				"dust limit of %v", value, dustLimit)
+		}
+
+		remainder -= value
+	}
+
+	if len(outputs) != 0 && remainder < dustLimit {
+		return 0, fmt.Errorf("%w: close outputs leave %v of the "+
+			"balance of %v", ErrCloseOutputsExceedBalance,
+			remainder, balance)
+	}
+
+	return remainder, nil
+}
+
 // CreateCooperativeCloseTx creates a transaction which if signed by both
 // parties, then broadcast cooperatively closes an active channel. The creation
 // of the closure transaction is modified by a boolean indicating if the party
@@ -8602,6 +8840,9 @@ func CreateCooperativeCloseTx(fundingTxIn wire.TxIn,
 	if opts.enableRBF {
 		fundingTxIn.Sequence = mempool.MaxRBFSequence
 	}
+	opts.sequence.WhenSome(func(sequence uint32) {
+		fundingTxIn.Sequence = sequence
+	})
 
 	// Construct the transaction to perform a cooperative closure of the
 	// channel. In the event that one side doesn't have any settled funds
@@ -8610,6 +8851,17 @@ func CreateCooperativeCloseTx(fundingTxIn wire.TxIn,
 	closeTx := wire.NewMsgTx(2)
 	closeTx.AddTxIn(&fundingTxIn)
 
+	// Pay the additional close outputs of both parties from their
+	// balances.
+	for _, txOut := range opts.localOutputs {
+		closeTx.AddTxOut(txOut)
+		ourBalance -= btcutil.Amount(txOut.Value)
+	}
+	for _, txOut := range opts.remoteOutputs {
+		closeTx.AddTxOut(txOut)
+		theirBalance -= btcutil.Amount(txOut.Value)
+	}
+
 	// Create both cooperative closure outputs, properly respecting the
 	// dust limits of both parties.
 	if ourBalance >= localDust {
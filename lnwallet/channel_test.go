@@ -799,6 +799,163 @@ func testCoopClose(t *testing.T, testCase *coopCloseTestCase) {
 	}
 }
 
+// TestCooperativeCloseWithCloseOutputs tests that the settled balances of both
+// parties can be split across additional outputs of the co-op close
+// transaction, and that outputs exceeding a balance are rejected.
+func TestCooperativeCloseWithCloseOutputs(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, err := CreateTestChannels(
+		t, channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err, "unable to create test channels")
+
+	aliceDeliveryScript := bobsPrivKey[:]
+	bobDeliveryScript := testHdSeed[:]
+
+	aliceOutputs := []*wire.TxOut{
+		{Value: 1_000_000, PkScript: []byte{1, 2, 3}},
+		{Value: 2_000_000, PkScript: []byte{4, 5, 6}},
+	}
+	bobOutputs := []*wire.TxOut{
+		{Value: 3_000_000, PkScript: []byte{7, 8, 9}},
+	}
+
+	aliceFeeRate := chainfee.SatPerKWeight(
+		aliceChannel.channelState.LocalCommitment.FeePerKw,
+	)
+	fee := aliceChannel.CalcFee(aliceFeeRate)
+
+	// Outputs that exceed the balance of a party are rejected.
+	tooLarge := []*wire.TxOut{
+		{
+			Value:    int64(aliceChannel.Capacity),
+			PkScript: []byte{1, 2, 3},
+		},
+	}
+	_, _, _, err = aliceChannel.CreateCloseProposal(
+		fee, aliceDeliveryScript, bobDeliveryScript,
+		WithCoopCloseOutputs(tooLarge, nil),
+	)
+	require.ErrorIs(t, err, ErrCloseOutputsExceedBalance)
+
+	aliceSig, _, _, err := aliceChannel.CreateCloseProposal(
+		fee, aliceDeliveryScript, bobDeliveryScript,
+		WithCoopCloseOutputs(aliceOutputs, bobOutputs),
+	)
+	require.NoError(t, err, "unable to create alice coop close proposal")
+
+	bobSig, _, _, err := bobChannel.CreateCloseProposal(
+		fee, bobDeliveryScript, aliceDeliveryScript,
+		WithCoopCloseOutputs(bobOutputs, aliceOutputs),
+	)
+	require.NoError(t, err, "unable to create bob coop close proposal")
+
+	// Both parties should end up with the same valid close transaction
+	// that includes the additional outputs.
+	bobTx, _, err := bobChannel.CompleteCooperativeClose(
+		bobSig, aliceSig, bobDeliveryScript, aliceDeliveryScript, fee,
+		WithCoopCloseOutputs(bobOutputs, aliceOutputs),
+	)
+	require.NoError(t, err, "unable to complete bob cooperative close")
+
+	aliceTx, aliceBalance, err := aliceChannel.CompleteCooperativeClose(
+		aliceSig, bobSig, aliceDeliveryScript, bobDeliveryScript, fee,
+		WithCoopCloseOutputs(aliceOutputs, bobOutputs),
+	)
+	require.NoError(t, err, "unable to complete alice cooperative close")
+
+	require.Equal(t, aliceTx.TxHash(), bobTx.TxHash())
+	require.Len(t, aliceTx.TxOut, 5)
+
+	// The remainder of Alice's balance is paid to her delivery script.
+	var aliceDeliveryValue int64
+	for _, txOut := range aliceTx.TxOut {
+		if bytes.Equal(txOut.PkScript, aliceDeliveryScript) {
+			aliceDeliveryValue = txOut.Value
+		}
+	}
+	require.EqualValues(
+		t, aliceBalance-3_000_000, aliceDeliveryValue,
+	)
+}
+
+// TestRbfCooperativeClose tests that the non-initiator is able to pay the full
+// fee of a co-op close transaction with a custom sequence, and that the
+// closing transaction can be re-signed at a higher fee after it's completed.
+func TestRbfCooperativeClose(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, err := CreateTestChannels(
+		t, channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err, "unable to create test channels")
+
+	aliceDeliveryScript := bobsPrivKey[:]
+	bobDeliveryScript := testHdSeed[:]
+
+	const sequence = 42
+
+	// Bob acts as the closer, so he pays the full fee, even though Alice
+	// is the initiator of the channel.
+	bobOpts := []ChanCloseOpt{
+		WithCustomPayer(CloseFeePayerLocal),
+		WithCustomSequence(sequence), WithRbfCoopClose(),
+	}
+	aliceOpts := []ChanCloseOpt{
+		WithCustomPayer(CloseFeePayerRemote),
+		WithCustomSequence(sequence), WithRbfCoopClose(),
+	}
+
+	for _, fee := range []btcutil.Amount{1_000, 2_000} {
+		bobSig, _, _, err := bobChannel.CreateCloseProposal(
+			fee, bobDeliveryScript, aliceDeliveryScript,
+			bobOpts...,
+		)
+		require.NoError(t, err, "unable to create bob proposal")
+
+		aliceSig, _, _, err := aliceChannel.CreateCloseProposal(
+			fee, aliceDeliveryScript, bobDeliveryScript,
+			aliceOpts...,
+		)
+		require.NoError(t, err, "unable to create alice proposal")
+
+		bobTx, bobBalance, err := bobChannel.CompleteCooperativeClose(
+			bobSig, aliceSig, bobDeliveryScript,
+			aliceDeliveryScript, fee, bobOpts...,
+		)
+		require.NoError(t, err, "unable to complete bob close")
+
+		aliceTx, aliceBalance, err :=
+			aliceChannel.CompleteCooperativeClose(
+				aliceSig, bobSig, aliceDeliveryScript,
+				bobDeliveryScript, fee, aliceOpts...,
+			)
+		require.NoError(t, err, "unable to complete alice close")
+
+		require.Equal(t, aliceTx.TxHash(), bobTx.TxHash())
+		require.EqualValues(t, sequence, bobTx.TxIn[0].Sequence)
+
+		// Alice gets the commitment fee back as the initiator, while
+		// Bob's balance is reduced by the full closing fee.
+		localCommit := aliceChannel.channelState.LocalCommitment
+		require.Equal(
+			t, localCommit.LocalBalance.ToSatoshis()+
+				localCommit.CommitFee, aliceBalance,
+		)
+		require.Equal(
+			t, bobChannel.channelState.LocalCommitment.
+				LocalBalance.ToSatoshis()-fee, bobBalance,
+		)
+	}
+
+	// Without the RBF option, a completed close can't be re-signed.
+	_, _, _, err = bobChannel.CreateCloseProposal(
+		3_000, bobDeliveryScript, aliceDeliveryScript,
+	)
+	require.ErrorIs(t, err, ErrChanClosing)
+}
+
 // TestForceClose checks that the resulting ForceCloseSummary is correct when a
 // peer is ForceClosing the channel. Will check outputs both above and below
 // the dust limit. Additionally, we'll ensure that the node which executed the
@@ -7197,6 +7354,71 @@ func TestDuplicateSettleRejection(t *testing.T) {
 	require.NoError(t, err, "unable to recv htlc cancel")
 }
 
+// TestPaymentPointSettle tests that an htlc that is locked to a payment point
+// can only be settled with the discrete logarithm of the point, and that the
+// point survives a restart of the channel.
+func TestPaymentPointSettle(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, err := CreateTestChannels(
+		t, channeldb.SimpleTaprootFeatureBit,
+	)
+	require.NoError(t, err, "unable to create test channels")
+
+	// We'll create two PTLCs, both using a preimage that is a valid
+	// scalar. The first one is locked to a point that doesn't match its
+	// preimage though.
+	htlcAmount := lnwire.NewMSatFromSatoshis(20000)
+	createPTLC := func(id int, secret byte, point *btcec.PublicKey) (
+		*lnwire.UpdateAddHTLC, [32]byte) {
+
+		htlc, _ := createHTLC(id, htlcAmount)
+
+		var preimage [32]byte
+		copy(preimage[:], bytes.Repeat([]byte{secret}, 32))
+		htlc.PaymentHash = sha256.Sum256(preimage[:])
+		htlc.PaymentPoint = lnwire.NewPaymentPoint(point)
+
+		return htlc, preimage
+	}
+
+	_, otherPoint := btcec.PrivKeyFromBytes(bytes.Repeat([]byte{0x2}, 32))
+	invalidPTLC, invalidPreimage := createPTLC(0, 0x1, otherPoint)
+
+	_, validPoint := btcec.PrivKeyFromBytes(bytes.Repeat([]byte{0x3}, 32))
+	validPTLC, validPreimage := createPTLC(1, 0x3, validPoint)
+
+	for _, htlc := range []*lnwire.UpdateAddHTLC{invalidPTLC, validPTLC} {
+		_, err := aliceChannel.AddHTLC(htlc, nil)
+		require.NoError(t, err, "alice unable to add htlc")
+		_, err = bobChannel.ReceiveHTLC(htlc)
+		require.NoError(t, err, "bob unable to recv htlc")
+	}
+
+	err = ForceStateTransition(aliceChannel, bobChannel)
+	require.NoError(t, err, "unable to complete state update")
+
+	// We'll restart both channels to make sure the payment points are
+	// restored from disk.
+	bobChannel, err = restartChannel(bobChannel)
+	require.NoError(t, err, "unable to restart channel")
+	aliceChannel, err = restartChannel(aliceChannel)
+	require.NoError(t, err, "unable to restart channel")
+
+	// Even though the preimage matches the payment hash of the first
+	// PTLC, both sides should reject settling it.
+	err = bobChannel.SettleHTLC(invalidPreimage, 0, nil, nil, nil)
+	require.ErrorAs(t, err, &ErrInvalidSettlePoint{})
+	err = aliceChannel.ReceiveHTLCSettle(invalidPreimage, 0)
+	require.ErrorAs(t, err, &ErrInvalidSettlePoint{})
+
+	// The second PTLC is settled by the discrete logarithm of its point.
+	err = bobChannel.SettleHTLC(validPreimage, 1, nil, nil, nil)
+	require.NoError(t, err, "unable to settle htlc")
+	err = aliceChannel.ReceiveHTLCSettle(validPreimage, 1)
+	require.NoError(t, err, "unable to recv htlc settle")
+}
+
 // TestChannelRestoreCommitHeight tests that the local and remote commit
 // heights of HTLCs are set correctly across restores.
 func TestChannelRestoreCommitHeight(t *testing.T) {
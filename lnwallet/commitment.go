@@ -962,8 +962,8 @@ func CreateCommitTx(chanType channeldb.ChannelType,
 // CoopCloseBalance returns the final balances that should be used to create
 // the cooperative close tx, given the channel type and transaction fee.
 func CoopCloseBalance(chanType channeldb.ChannelType, isInitiator bool,
-	coopCloseFee btcutil.Amount, localCommit channeldb.ChannelCommitment) (
-	btcutil.Amount, btcutil.Amount, error) {
+	coopCloseFee btcutil.Amount, localCommit channeldb.ChannelCommitment,
+	feePayer CloseFeePayer) (btcutil.Amount, btcutil.Amount, error) {
 
 	// Get both parties' balances from the latest commitment.
 	ourBalance := localCommit.LocalBalance.ToSatoshis()
@@ -979,21 +979,35 @@ func CoopCloseBalance(chanType channeldb.ChannelType, isInitiator bool,
 		initiatorDelta += 2 * anchorSize
 	}
 
-	// The initiator will pay the full coop close fee, subtract that value
-	// from their balance.
-	initiatorDelta -= coopCloseFee
-
 	if isInitiator {
 		ourBalance += initiatorDelta
 	} else {
 		theirBalance += initiatorDelta
 	}
 
-	// During fee negotiation it should always be verified that the
-	// initiator can pay the proposed fee, but we do a sanity check just to
-	// be sure here.
+	// By default, the initiator will pay the full coop close fee. With the
+	// RBF based close protocol, the party proposing the close transaction
+	// pays the fee instead.
+	localPays := isInitiator
+	switch feePayer {
+	case CloseFeePayerLocal:
+		localPays = true
+
+	case CloseFeePayerRemote:
+		localPays = false
+	}
+
+	if localPays {
+		ourBalance -= coopCloseFee
+	} else {
+		theirBalance -= coopCloseFee
+	}
+
+	// During fee negotiation it should always be verified that the fee
+	// payer can pay the proposed fee, but we do a sanity check just to be
+	// sure here.
 	if ourBalance < 0 || theirBalance < 0 {
-		return 0, 0, fmt.Errorf("initiator cannot afford proposed " +
+		return 0, 0, fmt.Errorf("fee payer cannot afford proposed " +
 			"coop close fee")
 	}
 
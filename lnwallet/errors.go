@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -192,6 +193,21 @@ func (e ErrInvalidSettlePreimage) Error() string {
 		e.preimage, e.rhash)
 }
 
+// ErrInvalidSettlePoint is returned when trying to settle an HTLC that is
+// locked to a payment point, but the preimage is not the discrete logarithm of
+// the point.
+type ErrInvalidSettlePoint struct {
+	preimage []byte
+	point    *btcec.PublicKey
+}
+
+// Error returns an error message with the offending preimage and intended
+// payment point.
+func (e ErrInvalidSettlePoint) Error() string {
+	return fmt.Sprintf("Invalid payment preimage %x for point %x",
+		e.preimage, e.point.SerializeCompressed())
+}
+
 // ErrUnknownHtlcIndex is returned when locally settling or failing an HTLC, but
 // the HTLC index is not known to the channel. This typically indicates that the
 // HTLC was already settled in a prior commitment.
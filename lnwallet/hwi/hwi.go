@@ -0,0 +1,196 @@
+package hwi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/lncfg"
+)
+
+// commandRunner executes the HWI command with the given arguments and returns
+// what it wrote to stdout.
+type commandRunner func(ctx context.Context, command string,
+	args ...string) ([]byte, error)
+
+// runCommand is the default commandRunner that executes the HWI binary.
+func runCommand(ctx context.Context, command string,
+	args ...string) ([]byte, error) {
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// HWI reports most errors as JSON on stdout with a non-zero
+		// exit code, so we only fail here if there is nothing to
+		// parse.
+		if stdout.Len() == 0 {
+			return nil, fmt.Errorf("error running %s: %w: %s",
+				command, err,
+				strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// Device is a hardware wallet that is accessed through the HWI command line
+// tool. Every call starts a new HWI process that connects to the device
+// identified by its master key fingerprint.
+type Device struct {
+	cfg *lncfg.HWI
+
+	// fingerprint is the master key fingerprint of the device in the
+	// integer representation used in PSBT BIP32 derivation fields.
+	fingerprint uint32
+
+	// chain is the name HWI uses for the network we're on.
+	chain string
+
+	run commandRunner
+}
+
+// New creates a new hardware wallet device from the given configuration.
+func New(cfg *lncfg.HWI, netParams *chaincfg.Params) (*Device, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := hex.DecodeString(cfg.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	var chain string
+	switch netParams.Name {
+	case chaincfg.MainNetParams.Name:
+		chain = "main"
+
+	case chaincfg.TestNet3Params.Name:
+		chain = "test"
+
+	case chaincfg.RegressionNetParams.Name:
+		chain = "regtest"
+
+	// Custom signets share the name with the default signet.
+	case chaincfg.SigNetParams.Name:
+		chain = "signet"
+
+	default:
+		return nil, fmt.Errorf("network %s is not supported by HWI",
+			netParams.Name)
+	}
+
+	return &Device{
+		cfg: cfg,
+
+		// The fingerprint is serialized as is into PSBTs, which use
+		// little endian for the integer representation.
+		fingerprint: binary.LittleEndian.Uint32(fingerprint),
+		chain:       chain,
+		run:         runCommand,
+	}, nil
+}
+
+// MasterKeyFingerprint returns the master key fingerprint of the device as it
+// appears in PSBT BIP32 derivation fields.
+func (d *Device) MasterKeyFingerprint() uint32 {
+	return d.fingerprint
+}
+
+// AccountXPub returns the extended public key of the account at the given
+// derivation path.
+func (d *Device) AccountXPub(path []uint32) (*hdkeychain.ExtendedKey, error) {
+	var resp struct {
+		XPub string `json:"xpub"`
+	}
+	if err := d.call(&resp, "getxpub", formatPath(path)); err != nil {
+		return nil, err
+	}
+
+	return hdkeychain.NewKeyFromString(resp.XPub)
+}
+
+// SignPsbt asks the device to sign all inputs of the packet it has the keys
+// for and returns the packet with the partial signatures added. The user needs
+// to confirm the transaction on the device.
+func (d *Device) SignPsbt(packet *psbt.Packet) (*psbt.Packet, error) {
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Psbt   string `json:"psbt"`
+		Signed bool   `json:"signed"`
+	}
+	if err := d.call(&resp, "signtx", encoded); err != nil {
+		return nil, err
+	}
+
+	if !resp.Signed {
+		return nil, errors.New("hardware wallet did not sign any " +
+			"input of the transaction")
+	}
+
+	return psbt.NewFromRawBytes(strings.NewReader(resp.Psbt), true)
+}
+
+// call runs the given HWI command against our device and decodes its JSON
+// response into resp.
+func (d *Device) call(resp interface{}, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.Timeout)
+	defer cancel()
+
+	args = append([]string{
+		"--fingerprint", d.cfg.Fingerprint, "--chain", d.chain,
+	}, args...)
+	out, err := d.run(ctx, d.cfg.Command, args...)
+	if err != nil {
+		return err
+	}
+
+	var hwiErr struct {
+		Error string `json:"error"`
+		Code  int    `json:"code"`
+	}
+	if err := json.Unmarshal(out, &hwiErr); err != nil {
+		return fmt.Errorf("unable to decode HWI response: %w", err)
+	}
+	if hwiErr.Error != "" {
+		return fmt.Errorf("HWI error %d: %s", hwiErr.Code,
+			hwiErr.Error)
+	}
+
+	return json.Unmarshal(out, resp)
+}
+
+// formatPath formats a derivation path in the notation HWI expects, for
+// example m/84h/0h/0h.
+func formatPath(path []uint32) string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, index := range path {
+		if index >= hdkeychain.HardenedKeyStart {
+			index -= hdkeychain.HardenedKeyStart
+			fmt.Fprintf(&sb, "/%dh", index)
+
+			continue
+		}
+
+		fmt.Fprintf(&sb, "/%d", index)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,148 @@
+package hwi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testXPub = "tpubDDXFHr67Ro2tHKVWG2gNjjijKUH1Lyv5NKFYdJnuaLGVNBVwyV5" +
+		"AbykhR43iy8wYozEMbw2QfmAqZhb8gnuL5mm9sZh8YsR6FjGAbew1xoT"
+)
+
+// fakeRunner records the arguments of the last HWI call and returns a canned
+// response.
+type fakeRunner struct {
+	args     []string
+	response string
+}
+
+func (f *fakeRunner) run(_ context.Context, _ string,
+	args ...string) ([]byte, error) {
+
+	f.args = args
+
+	return []byte(f.response), nil
+}
+
+// newTestDevice creates a device on regtest that uses the given fake runner.
+func newTestDevice(t *testing.T, runner *fakeRunner) *Device {
+	device, err := New(&lncfg.HWI{
+		Active:      true,
+		Command:     lncfg.DefaultHWICommand,
+		Fingerprint: "f23a9b01",
+		Account:     lncfg.DefaultHWIAccount,
+		Timeout:     time.Second,
+	}, &chaincfg.RegressionNetParams)
+	require.NoError(t, err)
+
+	device.run = runner.run
+
+	return device
+}
+
+// TestFormatPath tests that derivation paths are formatted the way HWI
+// expects them.
+func TestFormatPath(t *testing.T) {
+	t.Parallel()
+
+	const h = hdkeychain.HardenedKeyStart
+
+	require.Equal(t, "m", formatPath(nil))
+	require.Equal(t, "m/84h/0h/0h", formatPath([]uint32{84 + h, h, h}))
+	require.Equal(
+		t, "m/86h/1h/2h/1/5",
+		formatPath([]uint32{86 + h, 1 + h, 2 + h, 1, 5}),
+	)
+}
+
+// TestMasterKeyFingerprint tests that the fingerprint is converted to the
+// integer representation of PSBT derivation fields.
+func TestMasterKeyFingerprint(t *testing.T) {
+	t.Parallel()
+
+	device := newTestDevice(t, &fakeRunner{})
+
+	derivation := psbt.SerializeBIP32Derivation(
+		device.MasterKeyFingerprint(), nil,
+	)
+	require.Equal(t, []byte{0xf2, 0x3a, 0x9b, 0x01}, derivation)
+}
+
+// TestAccountXPub tests fetching an account xpub from the device.
+func TestAccountXPub(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{
+		response: `{"xpub": "` + testXPub + `"}`,
+	}
+	device := newTestDevice(t, runner)
+
+	const h = hdkeychain.HardenedKeyStart
+	xpub, err := device.AccountXPub([]uint32{84 + h, h, h})
+	require.NoError(t, err)
+	require.Equal(t, testXPub, xpub.String())
+	require.Equal(t, []string{
+		"--fingerprint", "f23a9b01", "--chain", "regtest", "getxpub",
+		"m/84h/0h/0h",
+	}, runner.args)
+
+	// Errors reported by HWI are returned as such.
+	runner.response = `{"error": "Could not find device with specified ` +
+		`fingerprint", "code": -3}`
+	_, err = device.AccountXPub([]uint32{84 + h, h, h})
+	require.ErrorContains(t, err, "HWI error -3: Could not find device")
+}
+
+// TestSignPsbt tests the PSBT round trip through the device.
+func TestSignPsbt(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0x51}})
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+
+	signedPacket, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	sig := ecdsa.Sign(privKey, make([]byte, 32)).Serialize()
+	signedPacket.Inputs[0].PartialSigs = []*psbt.PartialSig{{
+		PubKey:    privKey.PubKey().SerializeCompressed(),
+		Signature: append(sig, byte(txscript.SigHashAll)),
+	}}
+	encoded, err := signedPacket.B64Encode()
+	require.NoError(t, err)
+
+	runner := &fakeRunner{
+		response: `{"psbt": "` + encoded + `", "signed": true}`,
+	}
+	device := newTestDevice(t, runner)
+
+	signed, err := device.SignPsbt(packet)
+	require.NoError(t, err)
+	require.Len(t, signed.Inputs[0].PartialSigs, 1)
+
+	unsigned, err := packet.B64Encode()
+	require.NoError(t, err)
+	require.Equal(t, "signtx", runner.args[4])
+	require.Equal(t, unsigned, runner.args[5])
+
+	// If the device didn't sign anything, we fail.
+	runner.response = `{"psbt": "` + unsigned + `", "signed": false}`
+	_, err = device.SignPsbt(packet)
+	require.ErrorContains(t, err, "did not sign")
+}
@@ -127,6 +127,11 @@ type Utxo struct {
 	wire.OutPoint
 	Derivation *psbt.Bip32Derivation
 	PrevTx     *wire.MsgTx
+
+	// ExternallySigned is set if the output belongs to the account of the
+	// wallet's external signer, such as a hardware wallet, and can
+	// therefore only be spent with the device's approval.
+	ExternallySigned bool
 }
 
 // OutputDetail contains additional information on a destination address.
@@ -4,7 +4,9 @@ import (
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/mempool"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
@@ -85,3 +87,26 @@ func DustLimitForSize(scriptSize int) btcutil.Amount {
 func DustLimitUnknownWitness() btcutil.Amount {
 	return DustLimitForSize(input.UnknownWitnessSize)
 }
+
+// DustExposureLimit returns the maximum dust exposure of a channel with the
+// given dust exposure policy at the given commitment fee rate. If the policy
+// is empty, the given default limit is returned.
+func DustExposureLimit(policy channeldb.DustExposurePolicy,
+	feeRate chainfee.SatPerKWeight,
+	defaultLimit lnwire.MilliSatoshi) lnwire.MilliSatoshi {
+
+	switch {
+	// A fee rate based limit grows with the commitment fee rate, as more
+	// HTLCs are trimmed to dust at higher fee rates.
+	case policy.FeeRateMultiplier != 0:
+		return lnwire.MilliSatoshi(
+			uint64(feeRate) * policy.FeeRateMultiplier,
+		)
+
+	case policy.MaxExposure != 0:
+		return policy.MaxExposure
+
+	default:
+		return defaultLimit
+	}
+}
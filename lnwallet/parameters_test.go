@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/stretchr/testify/require"
@@ -93,3 +94,47 @@ func TestDustLimitForSize(t *testing.T) {
 		})
 	}
 }
+
+// TestDustExposureLimit tests that the dust exposure limit of a channel is
+// derived from its dust exposure policy.
+func TestDustExposureLimit(t *testing.T) {
+	t.Parallel()
+
+	const defaultLimit = lnwire.MilliSatoshi(500_000_000)
+
+	tests := []struct {
+		name          string
+		policy        channeldb.DustExposurePolicy
+		expectedLimit lnwire.MilliSatoshi
+	}{
+		{
+			name:          "default",
+			expectedLimit: defaultLimit,
+		},
+		{
+			name: "fixed",
+			policy: channeldb.DustExposurePolicy{
+				MaxExposure: 1_000_000,
+			},
+			expectedLimit: 1_000_000,
+		},
+		{
+			name: "fee rate multiplier",
+			policy: channeldb.DustExposurePolicy{
+				FeeRateMultiplier: 5_000,
+			},
+			expectedLimit: 12_500_000,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			limit := DustExposureLimit(
+				test.policy, 2_500, defaultLimit,
+			)
+			require.Equal(t, test.expectedLimit, limit)
+		})
+	}
+}
@@ -448,6 +448,7 @@ func NewChannelReservation(capacity, localFundingAmt btcutil.Amount,
 			InitialLocalBalance:  ourBalance,
 			InitialRemoteBalance: theirBalance,
 			Memo:                 req.Memo,
+			CustomBlob:           req.CustomBlob,
 		},
 		pushMSat:      req.PushMSat,
 		pendingChanID: req.PendingChanID,
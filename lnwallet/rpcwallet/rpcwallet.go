@@ -25,6 +25,7 @@ import (
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnrpc/signerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -62,8 +63,50 @@ type RPCKeyRing struct {
 
 	rpcTimeout time.Duration
 
-	signerClient signrpc.SignerClient
+	// signerClient is used for all signing requests apart from signing
+	// PSBTs. It is either the client of the remote signer's signrpc
+	// sub-server or of its RemoteSigner service, which both offer these
+	// RPCs.
+	signerClient signingClient
+
+	// walletClient is used to sign PSBTs if the remote signer doesn't
+	// support the RemoteSigner protocol.
 	walletClient walletrpc.WalletKitClient
+
+	// remoteSignerClient is only set if the remote signer supports the
+	// RemoteSigner protocol, in which case it is used to sign PSBTs.
+	remoteSignerClient signerrpc.RemoteSignerClient
+}
+
+// signingClient is the set of signing RPCs, other than signing PSBTs, that we
+// request from the remote signer. These are offered with the same messages by
+// both the signrpc sub-server and the RemoteSigner service.
+type signingClient interface {
+	DeriveSharedKey(ctx context.Context, in *signrpc.SharedKeyRequest,
+		opts ...grpc.CallOption) (*signrpc.SharedKeyResponse, error)
+
+	SignMessage(ctx context.Context, in *signrpc.SignMessageReq,
+		opts ...grpc.CallOption) (*signrpc.SignMessageResp, error)
+
+	MuSig2CreateSession(ctx context.Context,
+		in *signrpc.MuSig2SessionRequest,
+		opts ...grpc.CallOption) (*signrpc.MuSig2SessionResponse, error)
+
+	MuSig2RegisterNonces(ctx context.Context,
+		in *signrpc.MuSig2RegisterNoncesRequest,
+		opts ...grpc.CallOption) (*signrpc.MuSig2RegisterNoncesResponse,
+		error)
+
+	MuSig2Sign(ctx context.Context, in *signrpc.MuSig2SignRequest,
+		opts ...grpc.CallOption) (*signrpc.MuSig2SignResponse, error)
+
+	MuSig2CombineSig(ctx context.Context,
+		in *signrpc.MuSig2CombineSigRequest,
+		opts ...grpc.CallOption) (*signrpc.MuSig2CombineSigResponse,
+		error)
+
+	MuSig2Cleanup(ctx context.Context, in *signrpc.MuSig2CleanupRequest,
+		opts ...grpc.CallOption) (*signrpc.MuSig2CleanupResponse, error)
 }
 
 var _ keychain.SecretKeyRing = (*RPCKeyRing)(nil)
@@ -88,14 +131,101 @@ func NewRPCKeyRing(watchOnlyKeyRing keychain.SecretKeyRing,
 			"signing node through RPC: %v", err)
 	}
 
-	return &RPCKeyRing{
+	rpcKeyRing := &RPCKeyRing{
 		WalletController: watchOnlyWalletController,
 		watchOnlyKeyRing: watchOnlyKeyRing,
 		netParams:        netParams,
 		rpcTimeout:       remoteSigner.Timeout,
 		signerClient:     signrpc.NewSignerClient(rpcConn),
 		walletClient:     walletrpc.NewWalletKitClient(rpcConn),
-	}, nil
+	}
+
+	// By default, we use the signrpc and walletrpc sub-servers of the
+	// remote signer. If it also supports the RemoteSigner protocol, we
+	// switch to that instead.
+	remoteSignerClient, err := negotiateRemoteSigner(
+		rpcConn, remoteSigner.Timeout, netParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if remoteSignerClient != nil {
+		rpcKeyRing.signerClient = remoteSignerClient
+		rpcKeyRing.remoteSignerClient = remoteSignerClient
+	}
+
+	return rpcKeyRing, nil
+}
+
+// negotiateRemoteSigner queries the remote signer for the RemoteSigner
+// protocol. If the remote signer supports it, the client of the protocol is
+// returned once we made sure the remote signer holds the keys for our network.
+// Remote signers that only offer the signrpc and walletrpc sub-servers, like
+// lnd nodes of previous versions, reject the query as unimplemented. The query
+// is also rejected if our macaroon was baked for these sub-servers only. In
+// both cases nil is returned, so the sub-servers are used.
+func negotiateRemoteSigner(rpcConn *grpc.ClientConn, timeout time.Duration,
+	netParams *chaincfg.Params) (signerrpc.RemoteSignerClient, error) {
+
+	signerClient := signerrpc.NewRemoteSignerClient(rpcConn)
+	ctxt, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	info, err := signerClient.GetInfo(ctxt, &signerrpc.GetInfoRequest{})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		log.Infof("Remote signer doesn't support the RemoteSigner " +
+			"protocol, using its signrpc and walletrpc sub-servers")
+
+		return nil, nil
+
+	case err != nil:
+		log.Warnf("Unable to negotiate the RemoteSigner protocol, "+
+			"using the signrpc and walletrpc sub-servers of the "+
+			"remote signer: %v", err)
+
+		return nil, nil
+	}
+
+	// Make sure the remote signer holds the keys for the same network as
+	// we do, otherwise none of its signatures would be of any use to us.
+	if info.Network != netParams.Name {
+		return nil, fmt.Errorf("remote signer is running on network "+
+			"%v, expected %v", info.Network, netParams.Name)
+	}
+
+	log.Infof("Connected to remote signer using the RemoteSigner "+
+		"protocol, allowed derivation paths: %v", info.AllowedPaths)
+
+	return signerClient, nil
+}
+
+// remoteSignPsbt signs the given serialized PSBT with the remote signer and
+// returns the signed PSBT and the indices of the signed inputs. The PSBT is
+// signed through the RemoteSigner protocol if the remote signer supports it,
+// otherwise through its walletrpc sub-server.
+func (r *RPCKeyRing) remoteSignPsbt(ctx context.Context,
+	fundedPsbt []byte) ([]byte, []uint32, error) {
+
+	if r.remoteSignerClient != nil {
+		resp, err := r.remoteSignerClient.SignPsbt(
+			ctx, &signerrpc.SignPsbtRequest{FundedPsbt: fundedPsbt},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resp.SignedPsbt, resp.SignedInputs, nil
+	}
+
+	resp, err := r.walletClient.SignPsbt(
+		ctx, &walletrpc.SignPsbtRequest{FundedPsbt: fundedPsbt},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.SignedPsbt, resp.SignedInputs, nil
 }
 
 // NewAddress returns the next external or internal address for the
@@ -204,9 +334,7 @@ func (r *RPCKeyRing) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
 		return nil, fmt.Errorf("error serializing PSBT: %w", err)
 	}
 
-	resp, err := r.walletClient.SignPsbt(ctxt, &walletrpc.SignPsbtRequest{
-		FundedPsbt: buf.Bytes(),
-	})
+	signedPsbt, signedInputs, err := r.remoteSignPsbt(ctxt, buf.Bytes())
 	if err != nil {
 		considerShutdown(err)
 		return nil, fmt.Errorf("error signing PSBT in remote signer "+
@@ -214,7 +342,7 @@ func (r *RPCKeyRing) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
 	}
 
 	signedPacket, err := psbt.NewFromRawBytes(
-		bytes.NewReader(resp.SignedPsbt), false,
+		bytes.NewReader(signedPsbt), false,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing signed PSBT: %w", err)
@@ -228,7 +356,7 @@ func (r *RPCKeyRing) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
 	packet.Outputs = signedPacket.Outputs
 	packet.Unknowns = signedPacket.Unknowns
 
-	return resp.SignedInputs, nil
+	return signedInputs, nil
 }
 
 // FinalizePsbt expects a partial transaction with all inputs and outputs fully
@@ -894,6 +1022,10 @@ func (r *RPCKeyRing) remoteSign(tx *wire.MsgTx, signDesc *input.SignDescriptor,
 		return nil, fmt.Errorf("error converting TX into PSBT: %w", err)
 	}
 
+	// The remote signer's policy can only tell change outputs apart from
+	// outputs leaving the wallet if they carry their derivation info.
+	r.decorateOutputs(packet)
+
 	// We need to add witness information for all inputs! Otherwise, we'll
 	// have a problem when attempting to sign a taproot input!
 	for idx := range packet.Inputs {
@@ -1160,9 +1292,7 @@ func (r *RPCKeyRing) remoteSign(tx *wire.MsgTx, signDesc *input.SignDescriptor,
 		return nil, fmt.Errorf("error serializing PSBT: %w", err)
 	}
 
-	resp, err := r.walletClient.SignPsbt(
-		ctxt, &walletrpc.SignPsbtRequest{FundedPsbt: buf.Bytes()},
-	)
+	signedPsbt, _, err := r.remoteSignPsbt(ctxt, buf.Bytes())
 	if err != nil {
 		considerShutdown(err)
 		return nil, fmt.Errorf("error signing PSBT in remote signer "+
@@ -1170,7 +1300,7 @@ func (r *RPCKeyRing) remoteSign(tx *wire.MsgTx, signDesc *input.SignDescriptor,
 	}
 
 	signedPacket, err := psbt.NewFromRawBytes(
-		bytes.NewReader(resp.SignedPsbt), false,
+		bytes.NewReader(signedPsbt), false,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing signed PSBT: %w", err)
@@ -1304,6 +1434,45 @@ func connectRPC(hostPort, tlsCertPath, macaroonPath string,
 	return conn, nil
 }
 
+// decorateOutputs adds the BIP32 derivation information to all outputs of the
+// packet that pay to an address of our wallet and don't have any derivation
+// information yet.
+func (r *RPCKeyRing) decorateOutputs(packet *psbt.Packet) {
+	for idx, txOut := range packet.UnsignedTx.TxOut {
+		out := &packet.Outputs[idx]
+		if len(out.Bip32Derivation) > 0 ||
+			len(out.TaprootBip32Derivation) > 0 {
+
+			continue
+		}
+
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			txOut.PkScript, r.netParams,
+		)
+		if err != nil || len(addrs) != 1 {
+			continue
+		}
+
+		managedAddr, err := r.AddressInfo(addrs[0])
+		if err != nil {
+			continue
+		}
+
+		derivation, trDerivation, _, err :=
+			btcwallet.Bip32DerivationFromAddress(managedAddr)
+		if err != nil {
+			continue
+		}
+
+		out.Bip32Derivation = []*psbt.Bip32Derivation{derivation}
+		if managedAddr.AddrType() == waddrmgr.TaprootPubKey {
+			out.TaprootBip32Derivation = []*psbt.TaprootBip32Derivation{
+				trDerivation,
+			}
+		}
+	}
+}
+
 // packetFromTx creates a PSBT from a tx that potentially already contains
 // signed inputs.
 func packetFromTx(original *wire.MsgTx) (*psbt.Packet, error) {
@@ -0,0 +1,313 @@
+package seedsigner
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// accountPath is the hardened part of a derivation path up to the account
+// level, including the hardened key offsets.
+type accountPath [3]uint32
+
+// KeyRing derives all of lnd's keys directly from the master root key of the
+// wallet seed. It implements the subset of the keychain.SecretKeyRing that
+// doesn't need any wallet state, which is everything a remote signer needs.
+type KeyRing struct {
+	// rootKey is the extended master root private key.
+	rootKey *hdkeychain.ExtendedKey
+
+	// coinType is the coin type of lnd's internal key families.
+	coinType uint32
+
+	// accountKeys caches the extended private keys of the accounts we've
+	// derived keys from, since deriving the hardened account levels is by
+	// far the most expensive part of a key derivation.
+	accountKeys    map[accountPath]*hdkeychain.ExtendedKey
+	accountKeysMtx sync.Mutex
+}
+
+var _ keychain.ECDHRing = (*KeyRing)(nil)
+var _ keychain.MessageSignerRing = (*KeyRing)(nil)
+
+// NewKeyRing creates a new key ring from the given extended master root
+// private key. The coin type is the one lnd uses for its internal key
+// families.
+func NewKeyRing(rootKey *hdkeychain.ExtendedKey,
+	coinType uint32) (*KeyRing, error) {
+
+	if !rootKey.IsPrivate() || rootKey.Depth() != 0 {
+		return nil, fmt.Errorf("root key must be an extended master " +
+			"private key")
+	}
+
+	return &KeyRing{
+		rootKey:     rootKey,
+		coinType:    coinType,
+		accountKeys: make(map[accountPath]*hdkeychain.ExtendedKey),
+	}, nil
+}
+
+// accountKey returns the extended private key of the account described by the
+// given hardened path.
+func (k *KeyRing) accountKey(path accountPath) (*hdkeychain.ExtendedKey,
+	error) {
+
+	k.accountKeysMtx.Lock()
+	defer k.accountKeysMtx.Unlock()
+
+	if key, ok := k.accountKeys[path]; ok {
+		return key, nil
+	}
+
+	// The btcwallet derives all its keys with the non-standard derivation
+	// that doesn't pad private keys with leading zeros, so we need to do
+	// the same to arrive at the same keys.
+	key := k.rootKey
+	for _, index := range path {
+		var err error
+		key, err = key.DeriveNonStandard(index) // nolint:staticcheck
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	k.accountKeys[path] = key
+
+	return key, nil
+}
+
+// AccountPubKey returns the extended public key of the account with the given
+// purpose, coin type and account number, as it's needed to create the
+// watch-only wallet of the node the signer signs for.
+func (k *KeyRing) AccountPubKey(purpose, coinType,
+	account uint32) (*hdkeychain.ExtendedKey, error) {
+
+	accountKey, err := k.accountKey(accountPath{
+		purpose + hdkeychain.HardenedKeyStart,
+		coinType + hdkeychain.HardenedKeyStart,
+		account + hdkeychain.HardenedKeyStart,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accountKey.Neuter()
+}
+
+// MasterKeyFingerprint returns the fingerprint of the master root key, which
+// is the first four bytes of the hash160 of its public key.
+func (k *KeyRing) MasterKeyFingerprint() ([]byte, error) {
+	rootPubKey, err := k.rootKey.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return btcutil.Hash160(rootPubKey.SerializeCompressed())[:4], nil
+}
+
+// DeriveKeyByPath derives the private key described by a full BIP32 derivation
+// path. Only the paths of lnd's internal key families and of the wallet's
+// on-chain accounts are supported, with the same restrictions the btcwallet
+// based wallet applies.
+func (k *KeyRing) DeriveKeyByPath(path []uint32) (*btcec.PrivateKey, error) {
+	// Make sure we get a full path with exactly 5 elements, the first three
+	// of which are hardened:
+	//    m/purpose'/coinType'/account'/change/index
+	const expectedDerivationPathDepth = 5
+	if len(path) != expectedDerivationPathDepth {
+		return nil, fmt.Errorf("invalid BIP32 derivation path, "+
+			"expected path length %d, instead was %d",
+			expectedDerivationPathDepth, len(path))
+	}
+	for idx, element := range path[:3] {
+		if element < hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("invalid BIP32 derivation "+
+				"path, element at index %d is not hardened",
+				idx)
+		}
+	}
+
+	purpose := path[0] - hdkeychain.HardenedKeyStart
+	coinType := path[1] - hdkeychain.HardenedKeyStart
+	switch purpose {
+	case keychain.BIP0043Purpose:
+		if coinType != k.coinType {
+			return nil, fmt.Errorf("invalid BIP32 derivation "+
+				"path, expected coin type %d, instead was %d",
+				k.coinType, coinType)
+		}
+
+	// The btcwallet never uses a coin type other than 0 for the keys of its
+	// on-chain accounts.
+	case waddrmgr.KeyScopeBIP0049Plus.Purpose,
+		waddrmgr.KeyScopeBIP0084.Purpose,
+		waddrmgr.KeyScopeBIP0086.Purpose:
+
+		if coinType != 0 {
+			return nil, fmt.Errorf("invalid BIP32 derivation "+
+				"path, coin type must be 0 for purpose %d",
+				purpose)
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid BIP32 derivation path, "+
+			"unknown purpose %d", purpose)
+	}
+
+	accountKey, err := k.accountKey(accountPath{path[0], path[1], path[2]})
+	if err != nil {
+		return nil, err
+	}
+
+	branchKey, err := accountKey.DeriveNonStandard( // nolint:staticcheck
+		path[3],
+	)
+	if err != nil {
+		return nil, err
+	}
+	key, err := branchKey.DeriveNonStandard(path[4]) // nolint:staticcheck
+	if err != nil {
+		return nil, err
+	}
+
+	return key.ECPrivKey()
+}
+
+// deriveKeyByLocator derives the private key of lnd's internal key family
+// described by the given key locator.
+func (k *KeyRing) deriveKeyByLocator(
+	keyLoc keychain.KeyLocator) (*btcec.PrivateKey, error) {
+
+	return k.DeriveKeyByPath([]uint32{
+		keychain.BIP0043Purpose + hdkeychain.HardenedKeyStart,
+		k.coinType + hdkeychain.HardenedKeyStart,
+		uint32(keyLoc.Family) + hdkeychain.HardenedKeyStart,
+		0,
+		keyLoc.Index,
+	})
+}
+
+// DerivePrivKey attempts to derive the private key that corresponds to the
+// passed key descriptor. If the public key is set and the index is zero, the
+// key family is scanned for the public key, with a max of MaxKeyRangeScan
+// keys.
+func (k *KeyRing) DerivePrivKey(keyDesc keychain.KeyDescriptor) (
+	*btcec.PrivateKey, error) {
+
+	if keyDesc.PubKey == nil || keyDesc.Index > 0 {
+		return k.deriveKeyByLocator(keyDesc.KeyLocator)
+	}
+
+	keyLoc := keychain.KeyLocator{Family: keyDesc.Family}
+	for i := 0; i < keychain.MaxKeyRangeScan; i++ {
+		privKey, err := k.deriveKeyByLocator(keyLoc)
+		if err != nil {
+			return nil, err
+		}
+
+		if privKey.PubKey().IsEqual(keyDesc.PubKey) {
+			return privKey, nil
+		}
+
+		keyLoc.Index++
+	}
+
+	return nil, keychain.ErrCannotDerivePrivKey
+}
+
+// ECDH performs a scalar multiplication (ECDH-like operation) between the
+// target key descriptor and remote public key. The output returned will be
+// the sha256 of the resulting shared point serialized in compressed format.
+//
+// NOTE: This is part of the keychain.ECDHRing interface.
+func (k *KeyRing) ECDH(keyDesc keychain.KeyDescriptor,
+	pub *btcec.PublicKey) ([32]byte, error) {
+
+	privKey, err := k.DerivePrivKey(keyDesc)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	privKeyECDH := &keychain.PrivKeyECDH{PrivKey: privKey}
+	return privKeyECDH.ECDH(pub)
+}
+
+// SignMessage signs the given message, single or double SHA256 hashing it
+// first, with the private key described in the key locator.
+//
+// NOTE: This is part of the keychain.MessageSignerRing interface.
+func (k *KeyRing) SignMessage(keyLoc keychain.KeyLocator, msg []byte,
+	doubleHash bool) (*ecdsa.Signature, error) {
+
+	privKey, err := k.deriveKeyByLocator(keyLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return ecdsa.Sign(privKey, digest(msg, doubleHash)), nil
+}
+
+// SignMessageCompact signs the given message, single or double SHA256 hashing
+// it first, with the private key described in the key locator and returns
+// the signature in the compact, public key recoverable format.
+//
+// NOTE: This is part of the keychain.MessageSignerRing interface.
+func (k *KeyRing) SignMessageCompact(keyLoc keychain.KeyLocator, msg []byte,
+	doubleHash bool) ([]byte, error) {
+
+	privKey, err := k.deriveKeyByLocator(keyLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return ecdsa.SignCompact(privKey, digest(msg, doubleHash), true)
+}
+
+// SignMessageSchnorr uses the Schnorr signature algorithm to sign the given
+// message, single or double SHA256 hashing it first, with the private key
+// described in the key locator and the optional tweak applied to the private
+// key.
+//
+// NOTE: This is part of the keychain.MessageSignerRing interface.
+func (k *KeyRing) SignMessageSchnorr(keyLoc keychain.KeyLocator, msg []byte,
+	doubleHash bool, taprootTweak []byte,
+	tag []byte) (*schnorr.Signature, error) {
+
+	privKey, err := k.deriveKeyByLocator(keyLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(taprootTweak) > 0 {
+		privKey = txscript.TweakTaprootPrivKey(*privKey, taprootTweak)
+	}
+
+	// If a tag was provided, we need to take the tagged hash of the input.
+	if len(tag) > 0 {
+		taggedHash := chainhash.TaggedHash(tag, msg)
+		return schnorr.Sign(privKey, taggedHash[:])
+	}
+
+	return schnorr.Sign(privKey, digest(msg, doubleHash))
+}
+
+// digest returns the single or double SHA256 hash of the given message.
+func digest(msg []byte, doubleHash bool) []byte {
+	if doubleHash {
+		return chainhash.DoubleHashB(msg)
+	}
+
+	hash := sha256.Sum256(msg)
+	return hash[:]
+}
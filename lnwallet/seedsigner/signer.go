@@ -0,0 +1,48 @@
+package seedsigner
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+)
+
+// Signer is a remote signer that is backed by nothing but the master root key
+// of the wallet seed. It can sign PSBTs, perform ECDH, sign messages and take
+// part in MuSig2 sessions with all of lnd's keys, without running a wallet or
+// being connected to a chain backend.
+type Signer struct {
+	*KeyRing
+
+	*input.MusigSessionManager
+}
+
+var _ input.MuSig2Signer = (*Signer)(nil)
+
+// New creates a new signer from the given extended master root private key.
+// The coin type is the one lnd uses for its internal key families.
+func New(rootKey *hdkeychain.ExtendedKey, coinType uint32) (*Signer, error) {
+	keyRing, err := NewKeyRing(rootKey, coinType)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchPrivKey := func(
+		keyDesc *keychain.KeyDescriptor) (*btcec.PrivateKey, error) {
+
+		return keyRing.DerivePrivKey(*keyDesc)
+	}
+
+	return &Signer{
+		KeyRing:             keyRing,
+		MusigSessionManager: input.NewMusigSessionManager(fetchPrivKey),
+	}, nil
+}
+
+// SignPsbt signs all unsigned inputs of the given packet that have witness UTXO
+// and BIP32 derivation information attached, the same way lnd's wallet does.
+func (s *Signer) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
+	return btcwallet.SignPsbtWithKeys(packet, s.DeriveKeyByPath)
+}
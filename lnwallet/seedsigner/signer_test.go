@@ -0,0 +1,200 @@
+package seedsigner
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+const h = hdkeychain.HardenedKeyStart
+
+var (
+	// seedBytes is the same raw seed entropy the btcwallet tests use, so
+	// we can compare our keys to the ones the wallet derives.
+	seedBytes, _ = hex.DecodeString("4a7611b6979ba7c4bc5c5cd2239b2973")
+
+	// firstAddressPubKey is the public key of the key at m/84'/0'/0'/0/0.
+	firstAddressPubKey = "02b844aecf8250c29e46894147a7dae02de55a034a533b6" +
+		"0c6a6469294ee356ce4"
+
+	// firstAddressTaprootPubKey is the public key of the key at
+	// m/86'/0'/0'/0/0.
+	firstAddressTaprootPubKey = "03004113d6185c955d6e8f5922b50cc0ac3b64fa" +
+		"0979402604c5b887f07e3b5388"
+)
+
+// newTestSigner creates a signer from the test seed on regtest.
+func newTestSigner(t *testing.T) *Signer {
+	rootKey, err := hdkeychain.NewMaster(
+		seedBytes, &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+
+	signer, err := New(rootKey, chaincfg.RegressionNetParams.HDCoinType)
+	require.NoError(t, err)
+
+	return signer
+}
+
+// TestDeriveKeyByPath tests that the signer derives the same keys as the
+// btcwallet and applies the same restrictions to the derivation paths.
+func TestDeriveKeyByPath(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+
+	testCases := []struct {
+		name   string
+		path   []uint32
+		pubKey string
+		err    string
+	}{{
+		name:   "first BIP84 key",
+		path:   []uint32{84 + h, h, h, 0, 0},
+		pubKey: firstAddressPubKey,
+	}, {
+		name:   "first BIP86 key",
+		path:   []uint32{86 + h, h, h, 0, 0},
+		pubKey: firstAddressTaprootPubKey,
+	}, {
+		name: "lnd key",
+		path: []uint32{1017 + h, 1 + h, 6 + h, 0, 0},
+	}, {
+		name: "short path",
+		path: []uint32{84 + h, h, h, 0},
+		err:  "expected path length 5",
+	}, {
+		name: "unhardened account",
+		path: []uint32{84 + h, h, 0, 0, 0},
+		err:  "element at index 2 is not hardened",
+	}, {
+		name: "wrong coin type for lnd key",
+		path: []uint32{1017 + h, h, 6 + h, 0, 0},
+		err:  "expected coin type 1",
+	}, {
+		name: "wrong coin type for wallet key",
+		path: []uint32{84 + h, 1 + h, h, 0, 0},
+		err:  "coin type must be 0",
+	}, {
+		name: "unknown purpose",
+		path: []uint32{44 + h, h, h, 0, 0},
+		err:  "unknown purpose 44",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			privKey, err := signer.DeriveKeyByPath(tc.path)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.pubKey != "" {
+				require.Equal(t, tc.pubKey, hex.EncodeToString(
+					privKey.PubKey().SerializeCompressed(),
+				))
+			}
+		})
+	}
+}
+
+// TestDerivePrivKey tests that keys of lnd's internal key families can be
+// derived by their key locator and found by their public key.
+func TestDerivePrivKey(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+
+	keyLoc := keychain.KeyLocator{
+		Family: keychain.KeyFamilyMultiSig,
+		Index:  7,
+	}
+	privKey, err := signer.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+	})
+	require.NoError(t, err)
+
+	// Scanning the key family for the public key must arrive at the same
+	// private key.
+	scannedKey, err := signer.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{Family: keyLoc.Family},
+		PubKey:     privKey.PubKey(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, privKey.Serialize(), scannedKey.Serialize())
+
+	// ECDH with the key must be symmetric.
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	sharedKey, err := signer.ECDH(
+		keychain.KeyDescriptor{KeyLocator: keyLoc}, otherKey.PubKey(),
+	)
+	require.NoError(t, err)
+
+	otherECDH := &keychain.PrivKeyECDH{PrivKey: otherKey}
+	otherSharedKey, err := otherECDH.ECDH(privKey.PubKey())
+	require.NoError(t, err)
+	require.Equal(t, otherSharedKey, sharedKey)
+}
+
+// TestSignPsbt tests that the signer signs a P2WKH input of a PSBT with the
+// key described by its BIP32 derivation.
+func TestSignPsbt(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+
+	path := []uint32{84 + h, h, h, 0, 3}
+	privKey, err := signer.DeriveKeyByPath(path)
+	require.NoError(t, err)
+
+	pubKey := privKey.PubKey().SerializeCompressed()
+	pkScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(pubKey)).Script()
+	require.NoError(t, err)
+
+	prevOut := &wire.TxOut{Value: 100_000, PkScript: pkScript}
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 1}})
+	spendTx.AddTxOut(&wire.TxOut{Value: 90_000, PkScript: pkScript})
+
+	packet, err := psbt.NewFromUnsignedTx(spendTx)
+	require.NoError(t, err)
+	packet.Inputs[0].WitnessUtxo = prevOut
+	packet.Inputs[0].SighashType = txscript.SigHashAll
+	packet.Inputs[0].Bip32Derivation = []*psbt.Bip32Derivation{{
+		PubKey:    pubKey,
+		Bip32Path: path,
+	}}
+
+	signedInputs, err := signer.SignPsbt(packet)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, signedInputs)
+
+	require.NoError(t, psbt.MaybeFinalizeAll(packet))
+	finalTx, err := psbt.Extract(packet)
+	require.NoError(t, err)
+
+	vm, err := txscript.NewEngine(
+		prevOut.PkScript, finalTx, 0, txscript.StandardVerifyFlags, nil,
+		nil, prevOut.Value, txscript.NewCannedPrevOutputFetcher(
+			prevOut.PkScript, prevOut.Value,
+		),
+	)
+	require.NoError(t, err)
+	require.NoError(t, vm.Execute())
+}
@@ -0,0 +1,576 @@
+package signpolicy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+const (
+	// rateLimitWindow is the time window the maximum output value applies
+	// to.
+	rateLimitWindow = time.Hour
+)
+
+var (
+	// PsbtKeyTypeCoSignature is a custom/proprietary global PSBT key that
+	// holds the BIP-0340 Schnorr signature of the co-signer key over the
+	// transaction ID of the unsigned transaction. The value c5 is leet
+	// speak for "cs", short for "co-signature".
+	PsbtKeyTypeCoSignature = []byte{0xc5}
+
+	// ErrPolicyViolation is returned if a transaction violates the signer
+	// policy.
+	ErrPolicyViolation = errors.New("signer policy violation")
+
+	// scriptClasses maps the configuration names of the script classes to
+	// the script classes.
+	scriptClasses = map[string]txscript.ScriptClass{
+		"p2pkh":     txscript.PubKeyHashTy,
+		"p2sh":      txscript.ScriptHashTy,
+		"p2wkh":     txscript.WitnessV0PubKeyHashTy,
+		"p2wsh":     txscript.WitnessV0ScriptHashTy,
+		"p2tr":      txscript.WitnessV1TaprootTy,
+		"op_return": txscript.NullDataTy,
+	}
+
+	// walletPurposes are the purposes of the key scopes of the on-chain
+	// wallet accounts.
+	walletPurposes = map[uint32]struct{}{
+		waddrmgr.KeyScopeBIP0049Plus.Purpose: {},
+		waddrmgr.KeyScopeBIP0084.Purpose:     {},
+		waddrmgr.KeyScopeBIP0086.Purpose:     {},
+	}
+)
+
+// DeriveKeyFunc derives the private key described by a full BIP32 derivation
+// path.
+type DeriveKeyFunc func(path []uint32) (*btcec.PrivateKey, error)
+
+// Config is the configuration of a signer policy.
+type Config struct {
+	// MaxOutputValuePerHour is the maximum total value of outputs that
+	// don't pay back to the wallet, plus the fees paid, that can be signed
+	// for within any one hour window. Zero disables the limit.
+	MaxOutputValuePerHour btcutil.Amount
+
+	// MaxFee is the maximum absolute fee a transaction may pay. Zero
+	// disables the limit.
+	MaxFee btcutil.Amount
+
+	// MaxFeeRate is the maximum fee rate a transaction may pay. Zero
+	// disables the limit.
+	MaxFeeRate chainfee.SatPerKWeight
+
+	// AllowedScriptClasses are the script classes outputs that don't pay
+	// back to the wallet are allowed to use. If empty, all script classes
+	// are allowed.
+	AllowedScriptClasses []txscript.ScriptClass
+
+	// CoSignThreshold is the value from which on P2WSH and P2TR outputs
+	// that don't pay back to the wallet, which includes all channel funding
+	// outputs, require a co-signature. Zero disables co-signing.
+	CoSignThreshold btcutil.Amount
+
+	// CoSignerKey is the public key of the co-signer.
+	CoSignerKey *btcec.PublicKey
+
+	// DeriveKey is used to verify that an output annotated with a BIP32
+	// derivation actually pays back to the wallet.
+	DeriveKey DeriveKeyFunc
+
+	// Clock is the clock used for the hourly limit.
+	Clock clock.Clock
+}
+
+// spend is a transaction that was approved by the policy.
+type spend struct {
+	// timestamp is the time the transaction was first approved.
+	timestamp time.Time
+
+	// value is the total value of the outputs that don't pay back to the
+	// wallet, plus the fee.
+	value btcutil.Amount
+}
+
+// Policy decides whether a transaction spending funds of the on-chain wallet
+// may be signed. It protects the funds of the wallet against a compromised
+// watch-only node that requests signatures from its signer.
+//
+// NOTE: A nil policy allows all transactions.
+type Policy struct {
+	cfg *Config
+
+	// spends are the transactions approved within the current rate limit
+	// window, by their transaction ID. The same transaction is usually
+	// signed multiple times, once for every input, but only counts once.
+	spends    map[chainhash.Hash]spend
+	spendsMtx sync.Mutex
+}
+
+// New creates a new signer policy from the given config.
+func New(cfg *Config) (*Policy, error) {
+	if cfg.DeriveKey == nil {
+		return nil, fmt.Errorf("signer policy requires a key deriver")
+	}
+	if cfg.CoSignThreshold > 0 && cfg.CoSignerKey == nil {
+		return nil, fmt.Errorf("signer policy requires a co-signer " +
+			"key if the co-sign threshold is set")
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.NewDefaultClock()
+	}
+
+	return &Policy{
+		cfg:    cfg,
+		spends: make(map[chainhash.Hash]spend),
+	}, nil
+}
+
+// FromConfig creates a signer policy from the configuration options. Nil is
+// returned if no policy rule is configured.
+func FromConfig(cfg *lncfg.SignerPolicy,
+	deriveKey DeriveKeyFunc) (*Policy, error) {
+
+	if cfg == nil || !cfg.Enabled() {
+		return nil, nil
+	}
+
+	maxValue := btcutil.Amount(cfg.MaxOutputValuePerHour)
+	maxFeeRate := chainfee.SatPerKVByte(cfg.MaxFeeRate * 1000)
+	policyCfg := &Config{
+		MaxOutputValuePerHour: maxValue,
+		MaxFee:                btcutil.Amount(cfg.MaxFee),
+		MaxFeeRate:            maxFeeRate.FeePerKWeight(),
+		CoSignThreshold:       btcutil.Amount(cfg.CoSignThreshold),
+		DeriveKey:             deriveKey,
+	}
+
+	for _, name := range cfg.AllowedScriptClasses {
+		class, ok := scriptClasses[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown script class %q", name)
+		}
+
+		policyCfg.AllowedScriptClasses = append(
+			policyCfg.AllowedScriptClasses, class,
+		)
+	}
+
+	if cfg.CoSignerPubKey != "" {
+		keyBytes, err := hex.DecodeString(cfg.CoSignerPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid co-signer key: %w", err)
+		}
+
+		policyCfg.CoSignerKey, err = btcec.ParsePubKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid co-signer key: %w", err)
+		}
+	}
+
+	return New(policyCfg)
+}
+
+// CheckPsbt checks the given PSBT against the policy if it spends any funds of
+// the on-chain wallet, which is the case if any of its inputs that is going to
+// be signed is derived from a key of a wallet account. Signatures for all other
+// keys, such as those for channel commitment transactions, are always allowed.
+// Outputs are only considered to pay back to the wallet if they are annotated
+// with the BIP32 derivation of their key. All inputs must carry their witness
+// UTXO so that the fee can be checked.
+func (p *Policy) CheckPsbt(packet *psbt.Packet) error {
+	if p == nil || !spendsWalletFunds(packet) {
+		return nil
+	}
+
+	return p.check(
+		packet.UnsignedTx, witnessUtxos(packet), packet.Outputs,
+		packet.Unknowns,
+	)
+}
+
+// CheckWalletSpend checks the given PSBT against the policy, assuming it spends
+// funds of the on-chain wallet.
+func (p *Policy) CheckWalletSpend(packet *psbt.Packet) error {
+	if p == nil {
+		return nil
+	}
+
+	return p.check(
+		packet.UnsignedTx, witnessUtxos(packet), packet.Outputs,
+		packet.Unknowns,
+	)
+}
+
+// CheckTx checks the given transaction that spends funds of the on-chain
+// wallet against the policy. The previous outputs spent by the inputs must be
+// given in the order of the inputs. Since a plain transaction carries no
+// derivation information, all its outputs are considered to leave the wallet
+// and it can't carry a co-signature.
+func (p *Policy) CheckTx(tx *wire.MsgTx, prevOutputs []*wire.TxOut) error {
+	if p == nil {
+		return nil
+	}
+
+	return p.check(tx, prevOutputs, nil, nil)
+}
+
+// check checks the given transaction against the policy and records it as
+// approved if it passes. The previous outputs of the inputs are used to
+// determine the fee of the transaction, which is counted as leaving the
+// wallet.
+func (p *Policy) check(tx *wire.MsgTx, prevOutputs []*wire.TxOut,
+	outputs []psbt.POutput, unknowns []*psbt.Unknown) error {
+
+	txid := tx.TxHash()
+	fee, err := p.checkFee(tx, prevOutputs)
+	if err != nil {
+		return err
+	}
+
+	var (
+		externalValue = fee
+		needsCoSig    bool
+	)
+	for idx, txOut := range tx.TxOut {
+		if idx < len(outputs) && p.isOwnOutput(txOut, &outputs[idx]) {
+			continue
+		}
+
+		class := txscript.GetScriptClass(txOut.PkScript)
+		if !p.isAllowedClass(class) {
+			return fmt.Errorf("%w: output %d uses script class %v "+
+				"which is not allowed", ErrPolicyViolation, idx,
+				class)
+		}
+
+		value := btcutil.Amount(txOut.Value)
+		externalValue += value
+
+		isFundingCandidate := class == txscript.WitnessV0ScriptHashTy ||
+			class == txscript.WitnessV1TaprootTy
+		if p.cfg.CoSignThreshold > 0 && isFundingCandidate &&
+			value >= p.cfg.CoSignThreshold {
+
+			needsCoSig = true
+		}
+	}
+
+	coSigned := p.hasValidCoSignature(txid, unknowns)
+	if needsCoSig && !coSigned {
+		return fmt.Errorf("%w: transaction %v has an output of at "+
+			"least %v that requires a co-signature",
+			ErrPolicyViolation, txid, p.cfg.CoSignThreshold)
+	}
+
+	// A co-signed transaction was explicitly approved, so it doesn't count
+	// towards the limit.
+	if p.cfg.MaxOutputValuePerHour == 0 || coSigned {
+		return nil
+	}
+
+	return p.recordSpend(txid, externalValue)
+}
+
+// checkFee returns the fee paid by the given transaction, and makes sure it
+// doesn't exceed the maximum fee and fee rate. The fee rate is calculated for
+// the weight of the transaction once its inputs are signed, assuming the
+// inputs are key spends of the wallet.
+func (p *Policy) checkFee(tx *wire.MsgTx,
+	prevOutputs []*wire.TxOut) (btcutil.Amount, error) {
+
+	if len(prevOutputs) != len(tx.TxIn) {
+		return 0, fmt.Errorf("%w: expected witness UTXOs for %d "+
+			"inputs, got %d", ErrPolicyViolation, len(tx.TxIn),
+			len(prevOutputs))
+	}
+
+	var (
+		totalIn  btcutil.Amount
+		totalOut btcutil.Amount
+		weight   input.TxWeightEstimator
+	)
+	for idx, prevOut := range prevOutputs {
+		if prevOut == nil {
+			return 0, fmt.Errorf("%w: input %d has no witness UTXO",
+				ErrPolicyViolation, idx)
+		}
+
+		totalIn += btcutil.Amount(prevOut.Value)
+
+		switch txscript.GetScriptClass(prevOut.PkScript) {
+		case txscript.WitnessV0PubKeyHashTy:
+			weight.AddP2WKHInput()
+
+		case txscript.ScriptHashTy:
+			weight.AddNestedP2WKHInput()
+
+		case txscript.WitnessV1TaprootTy:
+			weight.AddTaprootKeySpendInput(txscript.SigHashDefault)
+
+		// For any other input we can't tell the size of its witness,
+		// so we only account for the input itself, which overestimates
+		// the fee rate.
+		default:
+			weight.AddWitnessInput(0)
+		}
+	}
+	for _, txOut := range tx.TxOut {
+		totalOut += btcutil.Amount(txOut.Value)
+		weight.AddTxOutput(txOut)
+	}
+
+	fee := totalIn - totalOut
+	if fee < 0 {
+		return 0, fmt.Errorf("%w: outputs of %v exceed inputs of %v",
+			ErrPolicyViolation, totalOut, totalIn)
+	}
+
+	if p.cfg.MaxFee > 0 && fee > p.cfg.MaxFee {
+		return 0, fmt.Errorf("%w: fee of %v exceeds the maximum fee "+
+			"of %v", ErrPolicyViolation, fee, p.cfg.MaxFee)
+	}
+
+	feeRate := chainfee.NewSatPerKWeight(fee, weight.Weight())
+	if p.cfg.MaxFeeRate > 0 && feeRate > p.cfg.MaxFeeRate {
+		return 0, fmt.Errorf("%w: fee rate of %v exceeds the maximum "+
+			"fee rate of %v", ErrPolicyViolation,
+			feeRate.FeePerVByte(), p.cfg.MaxFeeRate.FeePerVByte())
+	}
+
+	return fee, nil
+}
+
+// recordSpend records the approval of a transaction, if the hourly limit
+// isn't exceeded by it.
+func (p *Policy) recordSpend(txid chainhash.Hash,
+	value btcutil.Amount) error {
+
+	p.spendsMtx.Lock()
+	defer p.spendsMtx.Unlock()
+
+	now := p.cfg.Clock.Now()
+
+	var total btcutil.Amount
+	for spendTxid, s := range p.spends {
+		if now.Sub(s.timestamp) >= rateLimitWindow {
+			delete(p.spends, spendTxid)
+			continue
+		}
+
+		total += s.value
+	}
+
+	// We already approved this exact transaction, possibly for a different
+	// input.
+	if _, ok := p.spends[txid]; ok {
+		return nil
+	}
+
+	if total+value > p.cfg.MaxOutputValuePerHour {
+		return fmt.Errorf("%w: signing transaction %v with %v leaving "+
+			"the wallet including fees would exceed the limit of "+
+			"%v per hour (%v already signed within the last hour)",
+			ErrPolicyViolation, txid, value,
+			p.cfg.MaxOutputValuePerHour, total)
+	}
+
+	p.spends[txid] = spend{
+		timestamp: now,
+		value:     value,
+	}
+
+	return nil
+}
+
+// isAllowedClass returns true if outputs that don't pay back to the wallet
+// may use the given script class.
+func (p *Policy) isAllowedClass(class txscript.ScriptClass) bool {
+	if len(p.cfg.AllowedScriptClasses) == 0 {
+		return true
+	}
+
+	for _, allowed := range p.cfg.AllowedScriptClasses {
+		if class == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasValidCoSignature returns true if the given PSBT fields contain a valid
+// signature of the co-signer key over the transaction ID.
+func (p *Policy) hasValidCoSignature(txid chainhash.Hash,
+	unknowns []*psbt.Unknown) bool {
+
+	if p.cfg.CoSignerKey == nil {
+		return false
+	}
+
+	for _, u := range unknowns {
+		if !bytes.Equal(u.Key, PsbtKeyTypeCoSignature) {
+			continue
+		}
+
+		sig, err := schnorr.ParseSignature(u.Value)
+		if err != nil {
+			log.Debugf("Invalid co-signature for transaction %v: "+
+				"%v", txid, err)
+			continue
+		}
+
+		if sig.Verify(txid[:], p.cfg.CoSignerKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOwnOutput returns true if the output is annotated with the BIP32
+// derivation of a key of a wallet account and actually pays to that key.
+func (p *Policy) isOwnOutput(txOut *wire.TxOut, out *psbt.POutput) bool {
+	for _, derivation := range out.Bip32Derivation {
+		pubKey, err := p.deriveWalletPubKey(derivation.Bip32Path)
+		if err != nil {
+			continue
+		}
+
+		if !bytes.Equal(
+			pubKey.SerializeCompressed(), derivation.PubKey,
+		) {
+
+			continue
+		}
+
+		if paysToKey(txOut.PkScript, pubKey) {
+			return true
+		}
+	}
+
+	for _, derivation := range out.TaprootBip32Derivation {
+		pubKey, err := p.deriveWalletPubKey(derivation.Bip32Path)
+		if err != nil {
+			continue
+		}
+
+		if !bytes.Equal(
+			schnorr.SerializePubKey(pubKey), derivation.XOnlyPubKey,
+		) {
+
+			continue
+		}
+
+		if paysToKey(txOut.PkScript, pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deriveWalletPubKey derives the public key described by the given derivation
+// path, if it is the path of a key of a wallet account.
+func (p *Policy) deriveWalletPubKey(path []uint32) (*btcec.PublicKey, error) {
+	if !isWalletPath(path) {
+		return nil, fmt.Errorf("path is not a wallet account path")
+	}
+
+	privKey, err := p.cfg.DeriveKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return privKey.PubKey(), nil
+}
+
+// paysToKey returns true if the given output script is one of the scripts the
+// wallet uses to receive funds to the given key.
+func paysToKey(pkScript []byte, pubKey *btcec.PublicKey) bool {
+	p2wkh, err := input.WitnessPubKeyHash(pubKey.SerializeCompressed())
+	if err != nil {
+		return false
+	}
+	if bytes.Equal(pkScript, p2wkh) {
+		return true
+	}
+
+	np2wkh, err := input.GenerateP2SH(p2wkh)
+	if err == nil && bytes.Equal(pkScript, np2wkh) {
+		return true
+	}
+
+	p2tr, err := input.PayToTaprootScript(
+		txscript.ComputeTaprootKeyNoScript(pubKey),
+	)
+
+	return err == nil && bytes.Equal(pkScript, p2tr)
+}
+
+// witnessUtxos returns the witness UTXOs of the inputs of the given PSBT, which
+// are nil for inputs that don't have one.
+func witnessUtxos(packet *psbt.Packet) []*wire.TxOut {
+	prevOutputs := make([]*wire.TxOut, len(packet.Inputs))
+	for idx, in := range packet.Inputs {
+		prevOutputs[idx] = in.WitnessUtxo
+	}
+
+	return prevOutputs
+}
+
+// spendsWalletFunds returns true if any input of the given PSBT that is going
+// to be signed is derived from a key of a wallet account.
+func spendsWalletFunds(packet *psbt.Packet) bool {
+	for _, in := range packet.Inputs {
+		isFinal := len(in.FinalScriptWitness) > 0 ||
+			len(in.FinalScriptSig) > 0
+		if isFinal {
+			continue
+		}
+
+		for _, derivation := range in.Bip32Derivation {
+			if isWalletPath(derivation.Bip32Path) {
+				return true
+			}
+		}
+		for _, derivation := range in.TaprootBip32Derivation {
+			if isWalletPath(derivation.Bip32Path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isWalletPath returns true if the given derivation path is within the key
+// scope of one of the wallet accounts.
+func isWalletPath(path []uint32) bool {
+	if len(path) == 0 || path[0] < hdkeychain.HardenedKeyStart {
+		return false
+	}
+
+	purpose := path[0] - hdkeychain.HardenedKeyStart
+	_, ok := walletPurposes[purpose]
+
+	return ok
+}
@@ -0,0 +1,454 @@
+package signpolicy
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/lnwallet/seedsigner"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	hkStart = hdkeychain.HardenedKeyStart
+
+	// testFee is the fee paid by the packets created by the test harness.
+	testFee = 1_000
+)
+
+var (
+	seedBytes, _ = hex.DecodeString("4a7611b6979ba7c4bc5c5cd2239b2973")
+
+	testTime = time.Unix(1700000000, 0)
+
+	walletInputPath  = []uint32{84 + hkStart, hkStart, hkStart, 0, 0}
+	channelInputPath = []uint32{1017 + hkStart, 1 + hkStart, hkStart, 0, 0}
+	changePath       = []uint32{84 + hkStart, hkStart, hkStart, 1, 0}
+	taprootPath      = []uint32{86 + hkStart, hkStart, hkStart, 1, 0}
+)
+
+// testHarness bundles a policy with the key ring it verifies outputs with.
+type testHarness struct {
+	t       *testing.T
+	keyRing *seedsigner.KeyRing
+	clock   *clock.TestClock
+	policy  *Policy
+
+	// nextInput makes sure every packet spends a distinct outpoint and
+	// therefore has a distinct transaction ID.
+	nextInput uint32
+}
+
+// newTestHarness creates a policy from the given config, filling in the key
+// deriver and a test clock.
+func newTestHarness(t *testing.T, cfg *Config) *testHarness {
+	rootKey, err := hdkeychain.NewMaster(
+		seedBytes, &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+
+	keyRing, err := seedsigner.NewKeyRing(rootKey, 1)
+	require.NoError(t, err)
+
+	testClock := clock.NewTestClock(testTime)
+	cfg.DeriveKey = keyRing.DeriveKeyByPath
+	cfg.Clock = testClock
+
+	policy, err := New(cfg)
+	require.NoError(t, err)
+
+	return &testHarness{
+		t:       t,
+		keyRing: keyRing,
+		clock:   testClock,
+		policy:  policy,
+	}
+}
+
+// pubKey returns the public key at the given path.
+func (h *testHarness) pubKey(path []uint32) *btcec.PublicKey {
+	privKey, err := h.keyRing.DeriveKeyByPath(path)
+	require.NoError(h.t, err)
+
+	return privKey.PubKey()
+}
+
+// packet creates a PSBT spending a single P2WKH input with the key at the given
+// path to the given outputs, paying testFee.
+func (h *testHarness) packet(inputPath []uint32,
+	outputs ...*wire.TxOut) *psbt.Packet {
+
+	h.nextInput++
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: h.nextInput},
+	})
+
+	inputValue := int64(testFee)
+	for _, txOut := range outputs {
+		tx.AddTxOut(txOut)
+		inputValue += txOut.Value
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(h.t, err)
+
+	pkScript, err := input.WitnessPubKeyHash(
+		h.pubKey(inputPath).SerializeCompressed(),
+	)
+	require.NoError(h.t, err)
+
+	packet.Inputs[0].WitnessUtxo = &wire.TxOut{
+		Value:    inputValue,
+		PkScript: pkScript,
+	}
+	packet.Inputs[0].Bip32Derivation = []*psbt.Bip32Derivation{{
+		PubKey:    h.pubKey(inputPath).SerializeCompressed(),
+		Bip32Path: inputPath,
+	}}
+
+	return packet
+}
+
+// changeOutput returns a P2WKH output paying to the key at the given path.
+func (h *testHarness) changeOutput(value int64) *wire.TxOut {
+	pkScript, err := input.WitnessPubKeyHash(
+		h.pubKey(changePath).SerializeCompressed(),
+	)
+	require.NoError(h.t, err)
+
+	return &wire.TxOut{Value: value, PkScript: pkScript}
+}
+
+// decorateChange adds the derivation info of the change key to the output
+// with the given index.
+func (h *testHarness) decorateChange(packet *psbt.Packet, idx int) {
+	packet.Outputs[idx].Bip32Derivation = []*psbt.Bip32Derivation{{
+		PubKey:    h.pubKey(changePath).SerializeCompressed(),
+		Bip32Path: changePath,
+	}}
+}
+
+// externalOutput returns an output of the given script class paying to a
+// random key.
+func externalOutput(t *testing.T, value int64,
+	class txscript.ScriptClass) *wire.TxOut {
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey()
+
+	var pkScript []byte
+	switch class {
+	case txscript.WitnessV0PubKeyHashTy:
+		pkScript, err = input.WitnessPubKeyHash(
+			pubKey.SerializeCompressed(),
+		)
+
+	case txscript.WitnessV0ScriptHashTy:
+		pkScript, err = input.WitnessScriptHash(
+			pubKey.SerializeCompressed(),
+		)
+
+	case txscript.WitnessV1TaprootTy:
+		pkScript, err = input.PayToTaprootScript(pubKey)
+
+	default:
+		t.Fatalf("unsupported script class %v", class)
+	}
+	require.NoError(t, err)
+
+	return &wire.TxOut{Value: value, PkScript: pkScript}
+}
+
+// coSign adds the co-signature of the given key to the packet.
+func coSign(t *testing.T, packet *psbt.Packet, key *btcec.PrivateKey) {
+	txid := packet.UnsignedTx.TxHash()
+	sig, err := schnorr.Sign(key, txid[:])
+	require.NoError(t, err)
+
+	packet.Unknowns = append(packet.Unknowns, &psbt.Unknown{
+		Key:   PsbtKeyTypeCoSignature,
+		Value: sig.Serialize(),
+	})
+}
+
+// TestMaxOutputValuePerHour tests that the value leaving the wallet is limited
+// per hour and that change outputs and channel signatures don't count.
+func TestMaxOutputValuePerHour(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t, &Config{
+		MaxOutputValuePerHour: btcutil.SatoshiPerBitcoin,
+	})
+	p2wkh := txscript.WitnessV0PubKeyHashTy
+
+	// A payment of 0.6 BTC with a verifiable change output is allowed. The
+	// change doesn't count towards the limit.
+	first := h.packet(
+		walletInputPath, externalOutput(t, 60_000_000, p2wkh),
+		h.changeOutput(500_000_000),
+	)
+	h.decorateChange(first, 1)
+	require.NoError(t, h.policy.CheckPsbt(first))
+
+	// Signing the same transaction again, for example for another input,
+	// doesn't count twice.
+	require.NoError(t, h.policy.CheckPsbt(first))
+
+	// A change output with a derivation path that doesn't match its key is
+	// counted as leaving the wallet.
+	second := h.packet(
+		walletInputPath, externalOutput(t, 10_000_000, p2wkh),
+		externalOutput(t, 500_000_000, p2wkh),
+	)
+	h.decorateChange(second, 1)
+	require.ErrorIs(t, h.policy.CheckPsbt(second), ErrPolicyViolation)
+
+	// Another 0.5 BTC exceed the limit, even as a plain transaction.
+	third := h.packet(walletInputPath, externalOutput(t, 50_000_000, p2wkh))
+	require.ErrorIs(t, h.policy.CheckPsbt(third), ErrPolicyViolation)
+	require.ErrorIs(
+		t, h.policy.CheckTx(third.UnsignedTx, witnessUtxos(third)),
+		ErrPolicyViolation,
+	)
+
+	// Transactions that don't spend wallet funds, such as commitment
+	// transactions, are not affected by the policy.
+	commitment := h.packet(
+		channelInputPath, externalOutput(t, 500_000_000, p2wkh),
+	)
+	require.NoError(t, h.policy.CheckPsbt(commitment))
+
+	// Unless we know they spend from the wallet.
+	require.ErrorIs(
+		t, h.policy.CheckWalletSpend(commitment), ErrPolicyViolation,
+	)
+
+	// Once an hour has passed, the 0.5 BTC can be signed.
+	h.clock.SetTime(testTime.Add(time.Hour))
+	require.NoError(t, h.policy.CheckPsbt(third))
+}
+
+// TestFees tests that the fee of a transaction is limited and counts towards
+// the hourly limit, and that it requires the witness UTXOs of all inputs.
+func TestFees(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t, &Config{
+		MaxOutputValuePerHour: 100_000 + 2*testFee,
+		MaxFee:                5_000,
+		MaxFeeRate:            chainfee.SatPerVByte(20).FeePerKWeight(),
+	})
+	p2wkh := txscript.WitnessV0PubKeyHashTy
+
+	// A payment of 50k sats paying the test fee of 1k sats at roughly 9
+	// sat/vbyte is allowed.
+	first := h.packet(walletInputPath, externalOutput(t, 50_000, p2wkh))
+	require.NoError(t, h.policy.CheckPsbt(first))
+
+	// The fee counts towards the hourly limit, so another payment of 50k
+	// sats still fits, but one of 50k and a single sat doesn't.
+	tooMuch := h.packet(walletInputPath, externalOutput(t, 50_001, p2wkh))
+	require.ErrorIs(t, h.policy.CheckPsbt(tooMuch), ErrPolicyViolation)
+
+	second := h.packet(walletInputPath, externalOutput(t, 50_000, p2wkh))
+	require.NoError(t, h.policy.CheckPsbt(second))
+
+	h.clock.SetTime(testTime.Add(time.Hour))
+
+	// A fee above the maximum fee rate is rejected, even though it is
+	// not above the maximum absolute fee.
+	highRate := h.packet(walletInputPath, externalOutput(t, 1_000, p2wkh))
+	highRate.Inputs[0].WitnessUtxo.Value += 4_000
+	require.ErrorIs(t, h.policy.CheckPsbt(highRate), ErrPolicyViolation)
+
+	// A fee above the maximum absolute fee is rejected too, even though
+	// the fee rate is below the maximum with enough outputs.
+	outputs := make([]*wire.TxOut, 0, 50)
+	for i := 0; i < 50; i++ {
+		outputs = append(outputs, externalOutput(t, 1_000, p2wkh))
+	}
+	highFee := h.packet(walletInputPath, outputs...)
+	highFee.Inputs[0].WitnessUtxo.Value += 4_500
+	require.ErrorIs(t, h.policy.CheckPsbt(highFee), ErrPolicyViolation)
+
+	highFee.Inputs[0].WitnessUtxo.Value -= 1_000
+	require.NoError(t, h.policy.CheckPsbt(highFee))
+
+	// Outputs that exceed the inputs are rejected.
+	negative := h.packet(walletInputPath, externalOutput(t, 1_000, p2wkh))
+	negative.Inputs[0].WitnessUtxo.Value = 999
+	require.ErrorIs(t, h.policy.CheckPsbt(negative), ErrPolicyViolation)
+
+	// Inputs without a witness UTXO are rejected, as the fee can't be
+	// determined.
+	noUtxo := h.packet(walletInputPath, externalOutput(t, 1_000, p2wkh))
+	noUtxo.Inputs[0].WitnessUtxo = nil
+	require.ErrorIs(t, h.policy.CheckPsbt(noUtxo), ErrPolicyViolation)
+	require.ErrorIs(
+		t, h.policy.CheckTx(noUtxo.UnsignedTx, nil), ErrPolicyViolation,
+	)
+}
+
+// TestAllowedScriptClasses tests that only outputs leaving the wallet are
+// restricted to the allowed script classes.
+func TestAllowedScriptClasses(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t, &Config{
+		AllowedScriptClasses: []txscript.ScriptClass{
+			txscript.WitnessV0PubKeyHashTy,
+			txscript.WitnessV0ScriptHashTy,
+		},
+	})
+
+	allowed := h.packet(
+		walletInputPath,
+		externalOutput(t, 1000, txscript.WitnessV0PubKeyHashTy),
+		externalOutput(t, 1000, txscript.WitnessV0ScriptHashTy),
+	)
+	require.NoError(t, h.policy.CheckPsbt(allowed))
+
+	notAllowed := h.packet(
+		walletInputPath,
+		externalOutput(t, 1000, txscript.WitnessV1TaprootTy),
+	)
+	require.ErrorIs(t, h.policy.CheckPsbt(notAllowed), ErrPolicyViolation)
+
+	// A taproot change output of the wallet is always allowed.
+	internalKey := h.pubKey(taprootPath)
+	pkScript, err := input.PayToTaprootScript(
+		txscript.ComputeTaprootKeyNoScript(internalKey),
+	)
+	require.NoError(t, err)
+
+	change := h.packet(
+		walletInputPath, &wire.TxOut{Value: 1000, PkScript: pkScript},
+	)
+	trDerivation := &psbt.TaprootBip32Derivation{
+		XOnlyPubKey: schnorr.SerializePubKey(internalKey),
+		Bip32Path:   taprootPath,
+	}
+	change.Outputs[0].TaprootBip32Derivation = append(
+		change.Outputs[0].TaprootBip32Derivation, trDerivation,
+	)
+	require.NoError(t, h.policy.CheckPsbt(change))
+}
+
+// TestCoSignThreshold tests that large channel funding outputs require a
+// co-signature, which also exempts the transaction from the hourly limit.
+func TestCoSignThreshold(t *testing.T) {
+	t.Parallel()
+
+	coSignerKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	h := newTestHarness(t, &Config{
+		MaxOutputValuePerHour: 1_000_000,
+		CoSignThreshold:       1_000_000,
+		CoSignerKey:           coSignerKey.PubKey(),
+	})
+
+	// A small funding output doesn't need a co-signature.
+	small := h.packet(
+		walletInputPath,
+		externalOutput(t, 990_000, txscript.WitnessV1TaprootTy),
+	)
+	require.NoError(t, h.policy.CheckPsbt(small))
+
+	// A large one does, and a signature of any other key isn't enough.
+	large := h.packet(
+		walletInputPath,
+		externalOutput(t, 5_000_000, txscript.WitnessV0ScriptHashTy),
+	)
+	require.ErrorIs(t, h.policy.CheckPsbt(large), ErrPolicyViolation)
+
+	coSign(t, large, otherKey)
+	require.ErrorIs(t, h.policy.CheckPsbt(large), ErrPolicyViolation)
+
+	// With the co-signature the transaction is allowed, even though it is
+	// way above the hourly limit.
+	coSign(t, large, coSignerKey)
+	require.NoError(t, h.policy.CheckPsbt(large))
+
+	// A co-signature for a different transaction doesn't help.
+	other := h.packet(
+		walletInputPath,
+		externalOutput(t, 5_000_000, txscript.WitnessV0ScriptHashTy),
+	)
+	other.Unknowns = large.Unknowns
+	require.ErrorIs(t, h.policy.CheckPsbt(other), ErrPolicyViolation)
+}
+
+// TestFromConfig tests the creation of the policy from the configuration
+// options.
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	deriveKey := func([]uint32) (*btcec.PrivateKey, error) {
+		return nil, nil
+	}
+
+	// No rules configured means no policy.
+	policy, err := FromConfig(&lncfg.SignerPolicy{}, deriveKey)
+	require.NoError(t, err)
+	require.Nil(t, policy)
+
+	// A nil policy allows everything.
+	require.NoError(t, policy.CheckTx(wire.NewMsgTx(2), nil))
+
+	_, err = FromConfig(&lncfg.SignerPolicy{
+		AllowedScriptClasses: []string{"p2wkh", "p2pk"},
+	}, deriveKey)
+	require.ErrorContains(t, err, "unknown script class \"p2pk\"")
+
+	_, err = FromConfig(&lncfg.SignerPolicy{
+		CoSignThreshold: 1,
+		CoSignerPubKey:  "02abcd",
+	}, deriveKey)
+	require.ErrorContains(t, err, "invalid co-signer key")
+
+	coSignerKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	policy, err = FromConfig(&lncfg.SignerPolicy{
+		MaxOutputValuePerHour: 5,
+		MaxFee:                6,
+		MaxFeeRate:            7,
+		AllowedScriptClasses:  []string{"p2tr", "op_return"},
+		CoSignThreshold:       10,
+		CoSignerPubKey: hex.EncodeToString(
+			coSignerKey.PubKey().SerializeCompressed(),
+		),
+	}, deriveKey)
+	require.NoError(t, err)
+	require.Equal(t, btcutil.Amount(5), policy.cfg.MaxOutputValuePerHour)
+	require.Equal(t, btcutil.Amount(6), policy.cfg.MaxFee)
+	require.Equal(
+		t, chainfee.SatPerVByte(7), policy.cfg.MaxFeeRate.FeePerVByte(),
+	)
+	require.Equal(t, []txscript.ScriptClass{
+		txscript.WitnessV1TaprootTy, txscript.NullDataTy,
+	}, policy.cfg.AllowedScriptClasses)
+	require.True(t, policy.cfg.CoSignerKey.IsEqual(coSignerKey.PubKey()))
+
+	// The transaction ID is used as the co-signed message as is.
+	var txid chainhash.Hash
+	require.False(t, policy.hasValidCoSignature(txid, nil))
+}
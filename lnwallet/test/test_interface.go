@@ -2969,7 +2969,7 @@ func testSingleFunderExternalFundingTx(miner *rpctest.Harness,
 	aliceChanFunder := chanfunding.NewWalletAssembler(
 		chanfunding.WalletConfig{
 			CoinSource: lnwallet.NewCoinSource(
-				alice, allowUtxo,
+				alice, "", allowUtxo,
 			),
 			CoinSelectLocker:      alice,
 			CoinLeaser:            alice,
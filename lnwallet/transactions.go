@@ -192,6 +192,25 @@ func SetStateNumHint(commitTx *wire.MsgTx, stateNum uint64,
 	return nil
 }
 
+// HasStateNumHint returns true if the given transaction has the sequence and
+// locktime layout of a commitment transaction with an encoded state number
+// hint, as set by SetStateNumHint. It can be used to tell a commitment
+// transaction apart from a cooperative close transaction, which doesn't carry
+// a state hint.
+func HasStateNumHint(tx *wire.MsgTx) bool {
+	if len(tx.TxIn) != 1 {
+		return false
+	}
+
+	// The upper byte of the sequence only has the disable bit set, while
+	// the upper byte of the locktime only has the timelock shift set.
+	const upperByte = 0xFF000000
+	sequence := tx.TxIn[0].Sequence
+
+	return sequence&upperByte == wire.SequenceLockTimeDisabled &&
+		tx.LockTime&upperByte == TimelockShift
+}
+
 // GetStateNumHint recovers the current state number given a commitment
 // transaction which has previously had the state number encoded within it via
 // setStateNumHint and a shared obfuscator.
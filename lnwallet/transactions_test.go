@@ -535,6 +535,11 @@ func TestCommitTxStateHint(t *testing.T) {
 				}
 			}
 
+			if !HasStateNumHint(commitTx) && !test.shouldFail {
+				t.Fatalf("state hint of state %v not detected",
+					stateNum)
+			}
+
 			extractedStateNum := GetStateNumHint(commitTx, obfuscator)
 			if extractedStateNum != stateNum && !test.shouldFail {
 				t.Fatalf("state number mismatched, expected %v, got %v",
@@ -547,6 +552,32 @@ func TestCommitTxStateHint(t *testing.T) {
 	}
 }
 
+// TestHasStateNumHint asserts that transactions without an encoded state hint,
+// such as cooperative close transactions, aren't mistaken for commitment
+// transactions.
+func TestHasStateNumHint(t *testing.T) {
+	t.Parallel()
+
+	var obfuscator [StateHintSize]byte
+	copy(obfuscator[:], testHdSeed[:StateHintSize])
+
+	commitTx := wire.NewMsgTx(2)
+	commitTx.AddTxIn(&wire.TxIn{})
+	require.NoError(t, SetStateNumHint(commitTx, 42, obfuscator))
+	require.True(t, HasStateNumHint(commitTx))
+
+	closeTx := wire.NewMsgTx(2)
+	closeTx.AddTxIn(&wire.TxIn{Sequence: wire.MaxTxInSequenceNum})
+	require.False(t, HasStateNumHint(closeTx))
+
+	closeTx.TxIn[0].Sequence = wire.MaxTxInSequenceNum - 2
+	closeTx.LockTime = 800_000
+	require.False(t, HasStateNumHint(closeTx))
+
+	commitTx.AddTxIn(&wire.TxIn{})
+	require.False(t, HasStateNumHint(commitTx))
+}
+
 // testSpendValidation ensures that we're able to spend all outputs in the
 // commitment transaction that we create.
 func testSpendValidation(t *testing.T, tweakless bool) {
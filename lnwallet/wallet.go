@@ -25,6 +25,7 @@ import (
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
 	"github.com/lightningnetwork/lnd/lnwallet/chanvalidate"
@@ -51,6 +52,38 @@ const (
 	MaxAnchorChanReservedValue = 10 * AnchorChanReservedValue
 )
 
+// AnchorReservePolicy determines the amount that is kept in the wallet to fee
+// bump the commitments of anchor channels on force close.
+type AnchorReservePolicy struct {
+	// PerChannel is the amount reserved for each public anchor channel.
+	PerChannel btcutil.Amount
+
+	// Max caps the reserve, such that nodes with a high number of channels
+	// don't have to keep around a very large amount for the unlikely
+	// scenario that they all close at the same time.
+	Max btcutil.Amount
+}
+
+// DefaultAnchorReservePolicy returns the anchor reserve policy that reserves
+// AnchorChanReservedValue per channel up to MaxAnchorChanReservedValue.
+func DefaultAnchorReservePolicy() AnchorReservePolicy {
+	return AnchorReservePolicy{
+		PerChannel: AnchorChanReservedValue,
+		Max:        MaxAnchorChanReservedValue,
+	}
+}
+
+// Reserve returns the amount that should be kept in the wallet for the given
+// number of public anchor channels.
+func (p AnchorReservePolicy) Reserve(numAnchorChans uint32) btcutil.Amount {
+	reserved := btcutil.Amount(numAnchorChans) * p.PerChannel
+	if reserved > p.Max {
+		reserved = p.Max
+	}
+
+	return reserved
+}
+
 var (
 	// ErrPsbtFundingRequired is the error that is returned during the
 	// contribution handling process if the process should be paused for
@@ -148,6 +181,11 @@ type InitFundingReserveMsg struct {
 	// allocated towards channel funding.
 	Outpoints []wire.OutPoint
 
+	// Account is the name of the wallet account whose UTXOs should be used
+	// to fund the channel. If empty, the default account is used. This is
+	// only taken into account if no ChanFunder is set.
+	Account string
+
 	// RemoteChanReserve is the channel reserve we required for the remote
 	// peer.
 	RemoteChanReserve btcutil.Amount
@@ -209,6 +247,11 @@ type InitFundingReserveMsg struct {
 	// channel that will be useful to our future selves.
 	Memo []byte
 
+	// CustomBlob is an optional blob of custom channel data that
+	// auxiliary funding controllers attached to the channel. It is stored
+	// with the channel state once the reservation completes.
+	CustomBlob []byte
+
 	// err is a channel in which all errors will be sent across. Will be
 	// nil if this initial set is successful.
 	//
@@ -854,22 +897,7 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 	// If no chanFunder was provided, then we'll assume the default
 	// assembler, which is backed by the wallet's internal coin selection.
 	if req.ChanFunder == nil {
-		// We use the P2WSH dust limit since it is larger than the
-		// P2WPKH dust limit and to avoid threading through two
-		// different dust limits.
-		cfg := chanfunding.WalletConfig{
-			CoinSource: NewCoinSource(
-				l, req.AllowUtxoForFunding,
-			),
-			CoinSelectLocker: l,
-			CoinLeaser:       l,
-			Signer:           l.Cfg.Signer,
-			DustLimit: DustLimitForSize(
-				input.P2WSHSize,
-			),
-			CoinSelectionStrategy: l.Cfg.CoinSelectionStrategy,
-		}
-		req.ChanFunder = chanfunding.NewWalletAssembler(cfg)
+		req.ChanFunder = l.newWalletAssembler(req)
 	} else {
 		_, isPsbtFunder := req.ChanFunder.(*chanfunding.PsbtAssembler)
 		enforceNewReservedValue = !isPsbtFunder
@@ -897,56 +925,13 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 	// funder in the attached request to provision the inputs/outputs
 	// that'll ultimately be used to construct the funding transaction.
 	if !ok {
-		var err error
-		var numAnchorChans int
-
-		// Get the number of anchor channels to determine if there is a
-		// reserved value that must be respected when funding up to the
-		// maximum amount. Since private channels (most likely) won't be
-		// used for routing other than the last hop, they bear a smaller
-		// risk that we must force close them in order to resolve a HTLC
-		// up/downstream. Hence we exclude them from the count of anchor
-		// channels in order to attribute the respective anchor amount
-		// to the channel capacity.
-		if req.FundUpToMaxAmt > 0 && req.MinFundAmt > 0 {
-			numAnchorChans, err = l.CurrentNumAnchorChans()
-			if err != nil {
-				req.err <- err
-				req.resp <- nil
-				return
-			}
-
-			isPublic := req.Flags&lnwire.FFAnnounceChannel != 0
-			if hasAnchors && isPublic {
-				numAnchorChans++
-			}
+		fundingReq, err := l.newFundingRequest(req, false)
+		if err != nil {
+			req.err <- err
+			req.resp <- nil
+			return
 		}
 
-		// Coin selection is done on the basis of sat/kw, so we'll use
-		// the fee rate passed in to perform coin selection.
-		fundingReq := &chanfunding.Request{
-			RemoteAmt:         req.RemoteFundingAmt,
-			LocalAmt:          req.LocalFundingAmt,
-			FundUpToMaxAmt:    req.FundUpToMaxAmt,
-			MinFundAmt:        req.MinFundAmt,
-			RemoteChanReserve: req.RemoteChanReserve,
-			PushAmt: lnwire.MilliSatoshi.ToSatoshis(
-				req.PushMSat,
-			),
-			WalletReserve: l.RequiredReserve(
-				uint32(numAnchorChans),
-			),
-			Outpoints:    req.Outpoints,
-			MinConfs:     req.MinConfs,
-			SubtractFees: req.SubtractFees,
-			FeeRate:      req.FundingFeePerKw,
-			ChangeAddr: func() (btcutil.Address, error) {
-				return l.NewAddress(
-					TaprootPubkey, true, DefaultAccountName,
-				)
-			},
-			Musig2: req.CommitType == CommitmentTypeSimpleTaproot,
-		}
 		fundingIntent, err = req.ChanFunder.ProvisionChannel(
 			fundingReq,
 		)
@@ -1067,6 +1052,109 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 		reservation.pendingChanID, reservation.reservationID)
 }
 
+// FundingEstimate is the result of a dry run coin selection for the funding
+// transaction of a new channel.
+type FundingEstimate struct {
+	// Inputs are the wallet UTXOs that would be spent by the funding
+	// transaction.
+	Inputs []*Utxo
+
+	// ChangeAmt is the amount that would be sent back to the wallet as
+	// change. This is zero if the funding transaction has no change
+	// output.
+	ChangeAmt btcutil.Amount
+
+	// LocalFundingAmt is the amount we would commit to the channel.
+	LocalFundingAmt btcutil.Amount
+
+	// Capacity is the capacity of the resulting channel.
+	Capacity btcutil.Amount
+
+	// Fee is the fee the funding transaction would pay.
+	Fee btcutil.Amount
+
+	// Weight is the estimated weight of the funding transaction.
+	Weight lntypes.WeightUnit
+
+	// FeeRate is the effective fee rate of the funding transaction.
+	FeeRate chainfee.SatPerKWeight
+}
+
+// EstimateFundingTx runs the same coin selection that a funding reservation
+// would use for the given request, but without creating a reservation or
+// leasing any coins. The request must not carry a custom ChanFunder.
+func (l *LightningWallet) EstimateFundingTx(
+	req *InitFundingReserveMsg) (*FundingEstimate, error) {
+
+	if req.ChanFunder != nil {
+		return nil, fmt.Errorf("funding fee estimation is only " +
+			"supported for wallet funded channels")
+	}
+
+	if req.LocalFundingAmt == 0 && req.FundUpToMaxAmt == 0 {
+		return nil, ErrZeroCapacity()
+	}
+
+	fundingReq, err := l.newFundingRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	fundingIntent, err := l.newWalletAssembler(req).ProvisionChannel(
+		fundingReq,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	fullIntent, ok := fundingIntent.(*chanfunding.FullIntent)
+	if !ok {
+		return nil, fmt.Errorf("unexpected funding intent %T",
+			fundingIntent)
+	}
+
+	// Funding the channel must not violate our reserved value for anchor
+	// channel fee bumping, the same as for a real reservation.
+	isPublic := req.Flags&lnwire.FFAnnounceChannel != 0
+	err = l.enforceNewReservedValue(
+		fundingIntent, isPublic, req.CommitType.HasAnchors(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := fullIntent.Weight()
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &FundingEstimate{
+		LocalFundingAmt: fullIntent.LocalFundingAmt(),
+		Capacity: fullIntent.LocalFundingAmt() +
+			fullIntent.RemoteFundingAmt(),
+		Weight: weight,
+	}
+
+	var totalIn btcutil.Amount
+	for _, coin := range fullIntent.InputCoins {
+		utxo, err := l.FetchInputInfo(&coin.OutPoint)
+		if err != nil {
+			return nil, err
+		}
+
+		estimate.Inputs = append(estimate.Inputs, utxo)
+		totalIn += btcutil.Amount(coin.Value)
+	}
+	for _, changeOutput := range fullIntent.ChangeOutputs {
+		estimate.ChangeAmt += btcutil.Amount(changeOutput.Value)
+	}
+
+	estimate.Fee = totalIn - estimate.LocalFundingAmt - estimate.ChangeAmt
+	estimate.FeeRate = chainfee.NewSatPerKWeight(estimate.Fee, weight)
+
+	return estimate, nil
+}
+
 // enforceReservedValue enforces that the wallet, upon a new channel being
 // opened, meets the minimum amount of funds required for each advertised anchor
 // channel.
@@ -2533,17 +2621,138 @@ func (l *LightningWallet) CancelRebroadcast(txid chainhash.Hash) {
 	}
 }
 
+// newWalletAssembler creates the default chanfunding.Assembler that is backed
+// by the wallet's internal coin selection.
+func (l *LightningWallet) newWalletAssembler(
+	req *InitFundingReserveMsg) *chanfunding.WalletAssembler {
+
+	// We use the P2WSH dust limit since it is larger than the P2WPKH dust
+	// limit and to avoid threading through two different dust limits.
+	cfg := chanfunding.WalletConfig{
+		CoinSource: NewCoinSource(
+			l, req.Account, req.AllowUtxoForFunding,
+		),
+		CoinSelectLocker:      l,
+		CoinLeaser:            l,
+		Signer:                l.Cfg.Signer,
+		DustLimit:             DustLimitForSize(input.P2WSHSize),
+		CoinSelectionStrategy: l.Cfg.CoinSelectionStrategy,
+	}
+
+	return chanfunding.NewWalletAssembler(cfg)
+}
+
+// newFundingRequest creates the chanfunding.Request that is used to provision
+// the funding transaction of the given reservation request. If dryRun is set,
+// the request won't lease any coins or derive new change addresses.
+func (l *LightningWallet) newFundingRequest(req *InitFundingReserveMsg,
+	dryRun bool) (*chanfunding.Request, error) {
+
+	var (
+		numAnchorChans int
+		err            error
+	)
+
+	// Get the number of anchor channels to determine if there is a
+	// reserved value that must be respected when funding up to the
+	// maximum amount. Since private channels (most likely) won't be used
+	// for routing other than the last hop, they bear a smaller risk that
+	// we must force close them in order to resolve a HTLC up/downstream.
+	// Hence we exclude them from the count of anchor channels in order to
+	// attribute the respective anchor amount to the channel capacity.
+	if req.FundUpToMaxAmt > 0 && req.MinFundAmt > 0 {
+		numAnchorChans, err = l.CurrentNumAnchorChans()
+		if err != nil {
+			return nil, err
+		}
+
+		isPublic := req.Flags&lnwire.FFAnnounceChannel != 0
+		if req.CommitType.HasAnchors() && isPublic {
+			numAnchorChans++
+		}
+	}
+
+	// Coin selection is done on the basis of sat/kw, so we'll use the fee
+	// rate passed in to perform coin selection.
+	return &chanfunding.Request{
+		RemoteAmt:         req.RemoteFundingAmt,
+		LocalAmt:          req.LocalFundingAmt,
+		FundUpToMaxAmt:    req.FundUpToMaxAmt,
+		MinFundAmt:        req.MinFundAmt,
+		RemoteChanReserve: req.RemoteChanReserve,
+		PushAmt:           lnwire.MilliSatoshi.ToSatoshis(req.PushMSat),
+		WalletReserve:     l.RequiredReserve(uint32(numAnchorChans)),
+		Outpoints:         req.Outpoints,
+		MinConfs:          req.MinConfs,
+		ZeroConf:          req.ZeroConf,
+		SubtractFees:      req.SubtractFees,
+		FeeRate:           req.FundingFeePerKw,
+		ChangeAddr: func() (btcutil.Address, error) {
+			return l.fundingChangeAddr(req.Account, dryRun)
+		},
+		Musig2: req.CommitType == CommitmentTypeSimpleTaproot,
+		DryRun: dryRun,
+	}, nil
+}
+
+// fundingChangeAddr returns a change address for a funding transaction that
+// draws its inputs from the given account. The change is sent back to the same
+// account using the first address type the account supports. If the account
+// can't derive any addresses (for example the catch-all imported account), the
+// change is sent to the default account instead. If dryRun is set, the last
+// unused address is returned instead of deriving a new one.
+func (l *LightningWallet) fundingChangeAddr(account string,
+	dryRun bool) (btcutil.Address, error) {
+
+	newAddr := func(addrType AddressType,
+		account string) (btcutil.Address, error) {
+
+		if dryRun {
+			return l.LastUnusedAddress(addrType, account)
+		}
+
+		return l.NewAddress(addrType, true, account)
+	}
+
+	if account != "" && account != DefaultAccountName {
+		addrTypes := []AddressType{
+			TaprootPubkey, WitnessPubKey, NestedWitnessPubKey,
+		}
+		for _, addrType := range addrTypes {
+			addr, err := newAddr(addrType, account)
+			if err == nil {
+				return addr, nil
+			}
+		}
+
+		walletLog.Warnf("Unable to derive change address for "+
+			"account %v, using default account instead", account)
+	}
+
+	return newAddr(TaprootPubkey, DefaultAccountName)
+}
+
 // CoinSource is a wrapper around the wallet that implements the
 // chanfunding.CoinSource interface.
 type CoinSource struct {
 	wallet    *LightningWallet
+	account   string
 	allowUtxo func(Utxo) bool
 }
 
-// NewCoinSource creates a new instance of the CoinSource wrapper struct.
-func NewCoinSource(w *LightningWallet, allowUtxo func(Utxo) bool) *CoinSource {
+// NewCoinSource creates a new instance of the CoinSource wrapper struct. Only
+// UTXOs of the given wallet account are exposed by the coin source. If the
+// account is empty, the default account is used.
+func NewCoinSource(w *LightningWallet, account string,
+	allowUtxo func(Utxo) bool) *CoinSource {
+
+	if account == "" {
+		account = DefaultAccountName
+	}
+
 	return &CoinSource{
 		wallet:    w,
+		account:   account,
 		allowUtxo: allowUtxo,
 	}
 }
@@ -2553,8 +2762,8 @@ func NewCoinSource(w *LightningWallet, allowUtxo func(Utxo) bool) *CoinSource {
 func (c *CoinSource) ListCoins(minConfs int32,
 	maxConfs int32) ([]wallet.Coin, error) {
 
-	utxos, err := c.wallet.ListUnspentWitnessFromDefaultAccount(
-		minConfs, maxConfs,
+	utxos, err := c.wallet.ListUnspentWitness(
+		minConfs, maxConfs, c.account,
 	)
 	if err != nil {
 		return nil, err
@@ -2594,6 +2803,30 @@ func (c *CoinSource) CoinFromOutPoint(op wire.OutPoint) (*wallet.Coin, error) {
 		return nil, err
 	}
 
+	// Coins of the default account don't need any further checks, for any
+	// other account we make sure the coin actually belongs to it.
+	if c.account != DefaultAccountName {
+		utxos, err := c.wallet.ListUnspentWitness(
+			0, math.MaxInt32, c.account,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, utxo := range utxos {
+			if utxo.OutPoint == op {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("outpoint %v does not belong "+
+				"to account %v", op, c.account)
+		}
+	}
+
 	return &wallet.Coin{
 		TxOut: wire.TxOut{
 			Value:    int64(inputInfo.Value),
@@ -1,8 +1,11 @@
 package lnwallet
 
 import (
+	"math"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/stretchr/testify/require"
 )
 
@@ -32,3 +35,120 @@ func TestRegisterFundingIntent(t *testing.T) {
 	err = lw.RegisterFundingIntent(testID, nil)
 	require.ErrorIs(err, ErrDuplicatePendingChanID)
 }
+
+// accountWalletController is a mock wallet controller that keeps UTXOs per
+// account.
+type accountWalletController struct {
+	mockWalletController
+
+	accountUtxos map[string][]*Utxo
+}
+
+// ListUnspentWitness returns the UTXOs of the given account.
+func (w *accountWalletController) ListUnspentWitness(_, _ int32,
+	account string) ([]*Utxo, error) {
+
+	return w.accountUtxos[account], nil
+}
+
+// TestCoinSourceAccount checks that the CoinSource only exposes the coins of
+// the account it was created for.
+func TestCoinSourceAccount(t *testing.T) {
+	t.Parallel()
+
+	defaultUtxo := &Utxo{
+		Value:    btcutil.SatoshiPerBitcoin,
+		PkScript: CoinPkScript,
+		OutPoint: wire.OutPoint{Index: 0},
+	}
+	customUtxo := &Utxo{
+		Value:    2 * btcutil.SatoshiPerBitcoin,
+		PkScript: CoinPkScript,
+		OutPoint: wire.OutPoint{Index: 1},
+	}
+
+	lw, err := NewLightningWallet(Config{
+		WalletController: &accountWalletController{
+			accountUtxos: map[string][]*Utxo{
+				DefaultAccountName: {defaultUtxo},
+				"custom":           {customUtxo},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// An empty account name falls back to the default account.
+	defaultSource := NewCoinSource(lw, "", nil)
+	coins, err := defaultSource.ListCoins(0, math.MaxInt32)
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, defaultUtxo.OutPoint, coins[0].OutPoint)
+
+	// The custom account only returns its own coins.
+	customSource := NewCoinSource(lw, "custom", nil)
+	coins, err = customSource.ListCoins(0, math.MaxInt32)
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, customUtxo.OutPoint, coins[0].OutPoint)
+
+	// Manually selected coins must belong to the custom account.
+	coin, err := customSource.CoinFromOutPoint(customUtxo.OutPoint)
+	require.NoError(t, err)
+	require.Equal(t, customUtxo.OutPoint, coin.OutPoint)
+
+	_, err = customSource.CoinFromOutPoint(defaultUtxo.OutPoint)
+	require.ErrorContains(t, err, "does not belong to account custom")
+}
+
+// TestAnchorReservePolicy checks that the anchor reserve scales with the
+// number of channels and is capped at the maximum of the policy.
+func TestAnchorReservePolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		policy   AnchorReservePolicy
+		numChans uint32
+		expected btcutil.Amount
+	}{
+		{
+			name:     "default policy below cap",
+			policy:   DefaultAnchorReservePolicy(),
+			numChans: 3,
+			expected: 3 * AnchorChanReservedValue,
+		},
+		{
+			name:     "default policy capped",
+			policy:   DefaultAnchorReservePolicy(),
+			numChans: 100,
+			expected: MaxAnchorChanReservedValue,
+		},
+		{
+			name: "custom policy",
+			policy: AnchorReservePolicy{
+				PerChannel: 25_000,
+				Max:        1_000_000,
+			},
+			numChans: 30,
+			expected: 750_000,
+		},
+		{
+			name:     "no reserve",
+			policy:   AnchorReservePolicy{},
+			numChans: 30,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(
+				t, tc.expected, tc.policy.Reserve(tc.numChans),
+			)
+		})
+	}
+}
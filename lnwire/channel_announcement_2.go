@@ -0,0 +1,243 @@
+package lnwire
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	chanAnn2ChainHashType      tlv.Type = 0
+	chanAnn2FeaturesType       tlv.Type = 2
+	chanAnn2ShortChanIDType    tlv.Type = 4
+	chanAnn2CapacityType       tlv.Type = 6
+	chanAnn2NodeID1Type        tlv.Type = 8
+	chanAnn2NodeID2Type        tlv.Type = 10
+	chanAnn2BitcoinKey1Type    tlv.Type = 12
+	chanAnn2BitcoinKey2Type    tlv.Type = 14
+	chanAnn2MerkleRootHashType tlv.Type = 16
+	chanAnn2SignatureType      tlv.Type = 160
+)
+
+// ChannelAnnouncement2 is the gossip 1.75 version of the channel announcement
+// message. Unlike the legacy ChannelAnnouncement, it is a pure TLV message
+// which is signed by a single Schnorr signature over the MuSig2 aggregate of
+// the node and bitcoin keys. This allows channels that are funded by a P2TR
+// output to be announced to the network.
+type ChannelAnnouncement2 struct {
+	// ChainHash denotes the target chain that this channel was opened
+	// within. It defaults to the genesis hash of the bitcoin mainnet and
+	// is omitted on the wire if it has that value.
+	ChainHash chainhash.Hash
+
+	// Features is the feature vector that encodes the features supported
+	// by the target channel.
+	Features *RawFeatureVector
+
+	// ShortChannelID is the unique description of the funding
+	// transaction.
+	ShortChannelID ShortChannelID
+
+	// Capacity is the number of satoshis of the funding output.
+	Capacity uint64
+
+	// NodeID1 is the numerically-lesser public key ID of one of the
+	// channel operators.
+	NodeID1 [33]byte
+
+	// NodeID2 is the numerically-greater public key ID of one of the
+	// channel operators.
+	NodeID2 [33]byte
+
+	// BitcoinKey1 is the public key of the key used by Node1 in the
+	// construction of the funding output. If it is not set, the funding
+	// output is claimed to be a P2TR output that commits to NodeID1 and
+	// NodeID2 instead.
+	BitcoinKey1 fn.Option[[33]byte]
+
+	// BitcoinKey2 is the public key of the key used by Node2 in the
+	// construction of the funding output.
+	BitcoinKey2 fn.Option[[33]byte]
+
+	// MerkleRootHash is the hash used to tweak the internal key of the
+	// funding output, if the output has a script path.
+	MerkleRootHash fn.Option[[32]byte]
+
+	// Signature is a Schnorr signature over the serialization of all the
+	// fields of the message outside of the signature range, created with
+	// the MuSig2 aggregate of the node keys and bitcoin keys.
+	Signature Sig
+
+	// ExtraOpaqueData is the raw TLV stream of all the records that we
+	// don't know about. We hold onto them so that we're able to validate
+	// the signature over them and relay them to our peers.
+	ExtraOpaqueData ExtraOpaqueData
+}
+
+// A compile time check to ensure ChannelAnnouncement2 implements the
+// lnwire.Message interface.
+var _ Message = (*ChannelAnnouncement2)(nil)
+
+// records returns the TLV records of all the known fields that are set on
+// the message.
+func (a *ChannelAnnouncement2) records() []tlv.Record {
+	var records []tlv.Record
+
+	if a.ChainHash != *chaincfg.MainNetParams.GenesisHash {
+		records = append(records, tlv.MakePrimitiveRecord(
+			chanAnn2ChainHashType, (*[32]byte)(&a.ChainHash),
+		))
+	}
+
+	features := a.Features
+	if features == nil {
+		features = NewRawFeatureVector()
+	}
+
+	records = append(records,
+		features.Record(chanAnn2FeaturesType),
+		tlv.MakeStaticRecord(
+			chanAnn2ShortChanIDType, &a.ShortChannelID, 8,
+			EShortChannelID, DShortChannelID,
+		),
+		tlv.MakePrimitiveRecord(chanAnn2CapacityType, &a.Capacity),
+		tlv.MakePrimitiveRecord(chanAnn2NodeID1Type, &a.NodeID1),
+		tlv.MakePrimitiveRecord(chanAnn2NodeID2Type, &a.NodeID2),
+	)
+
+	a.BitcoinKey1.WhenSome(func(key [33]byte) {
+		records = append(records, tlv.MakePrimitiveRecord(
+			chanAnn2BitcoinKey1Type, &key,
+		))
+	})
+	a.BitcoinKey2.WhenSome(func(key [33]byte) {
+		records = append(records, tlv.MakePrimitiveRecord(
+			chanAnn2BitcoinKey2Type, &key,
+		))
+	})
+	a.MerkleRootHash.WhenSome(func(hash [32]byte) {
+		records = append(records, tlv.MakePrimitiveRecord(
+			chanAnn2MerkleRootHashType, &hash,
+		))
+	})
+
+	return append(records, tlv.MakePrimitiveRecord(
+		chanAnn2SignatureType, &a.Signature.bytes,
+	))
+}
+
+// Decode deserializes a serialized ChannelAnnouncement2 stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (a *ChannelAnnouncement2) Decode(r io.Reader, _ uint32) error {
+	var (
+		chainHash, merkleRootHash          [32]byte
+		nodeID1, nodeID2, btcKey1, btcKey2 [33]byte
+		features                           = NewRawFeatureVector()
+		scid                               ShortChannelID
+		capacity                           uint64
+		sig                                Sig
+	)
+	typeMap, extra, err := decodeGossipV2Msg(
+		r,
+		tlv.MakePrimitiveRecord(chanAnn2ChainHashType, &chainHash),
+		features.Record(chanAnn2FeaturesType),
+		tlv.MakeStaticRecord(
+			chanAnn2ShortChanIDType, &scid, 8, EShortChannelID,
+			DShortChannelID,
+		),
+		tlv.MakePrimitiveRecord(chanAnn2CapacityType, &capacity),
+		tlv.MakePrimitiveRecord(chanAnn2NodeID1Type, &nodeID1),
+		tlv.MakePrimitiveRecord(chanAnn2NodeID2Type, &nodeID2),
+		tlv.MakePrimitiveRecord(chanAnn2BitcoinKey1Type, &btcKey1),
+		tlv.MakePrimitiveRecord(chanAnn2BitcoinKey2Type, &btcKey2),
+		tlv.MakePrimitiveRecord(
+			chanAnn2MerkleRootHashType, &merkleRootHash,
+		),
+		tlv.MakePrimitiveRecord(chanAnn2SignatureType, &sig.bytes),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = requireGossipV2Types(
+		typeMap, chanAnn2ShortChanIDType, chanAnn2CapacityType,
+		chanAnn2NodeID1Type, chanAnn2NodeID2Type, chanAnn2SignatureType,
+	)
+	if err != nil {
+		return err
+	}
+
+	*a = ChannelAnnouncement2{
+		ChainHash:       *chaincfg.MainNetParams.GenesisHash,
+		Features:        features,
+		ShortChannelID:  scid,
+		Capacity:        capacity,
+		NodeID1:         nodeID1,
+		NodeID2:         nodeID2,
+		Signature:       sig,
+		ExtraOpaqueData: extra,
+	}
+
+	// The signature of a gossip v2 message is always a Schnorr signature.
+	a.Signature.ForceSchnorr()
+
+	if _, ok := typeMap[chanAnn2ChainHashType]; ok {
+		a.ChainHash = chainHash
+	}
+	if _, ok := typeMap[chanAnn2BitcoinKey1Type]; ok {
+		a.BitcoinKey1 = fn.Some(btcKey1)
+	}
+	if _, ok := typeMap[chanAnn2BitcoinKey2Type]; ok {
+		a.BitcoinKey2 = fn.Some(btcKey2)
+	}
+	if _, ok := typeMap[chanAnn2MerkleRootHashType]; ok {
+		a.MerkleRootHash = fn.Some(merkleRootHash)
+	}
+
+	return nil
+}
+
+// Encode serializes the target ChannelAnnouncement2 into the passed
+// io.Writer observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (a *ChannelAnnouncement2) Encode(w *bytes.Buffer, _ uint32) error {
+	return encodeGossipV2Msg(w, a.records(), a.ExtraOpaqueData, false)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (a *ChannelAnnouncement2) MsgType() MessageType {
+	return MsgChannelAnnouncement2
+}
+
+// DataToSign returns the part of the message that should be signed, which
+// is the serialization of all records outside of the signature range.
+func (a *ChannelAnnouncement2) DataToSign() ([]byte, error) {
+	var w bytes.Buffer
+	err := encodeGossipV2Msg(&w, a.records(), a.ExtraOpaqueData, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// DigestToSign returns the tagged hash of the message that is covered by its
+// signature.
+func (a *ChannelAnnouncement2) DigestToSign() (*chainhash.Hash, error) {
+	data, err := a.DataToSign()
+	if err != nil {
+		return nil, err
+	}
+
+	return MsgHash("channel_announcement_2", "signature", data), nil
+}
@@ -0,0 +1,344 @@
+package lnwire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	chanUpd2ChainHashType       tlv.Type = 0
+	chanUpd2ShortChanIDType     tlv.Type = 2
+	chanUpd2BlockHeightType     tlv.Type = 4
+	chanUpd2DisableFlagsType    tlv.Type = 6
+	chanUpd2SecondPeerType      tlv.Type = 8
+	chanUpd2CLTVExpiryDeltaType tlv.Type = 10
+	chanUpd2HTLCMinMsatType     tlv.Type = 12
+	chanUpd2HTLCMaxMsatType     tlv.Type = 14
+	chanUpd2FeeBaseMsatType     tlv.Type = 16
+	chanUpd2FeeProportionalType tlv.Type = 18
+	chanUpd2SignatureType       tlv.Type = 160
+
+	// DefaultCLTVExpiryDelta is the CLTV expiry delta of a ChannelUpdate2
+	// that doesn't carry the field explicitly.
+	DefaultCLTVExpiryDelta = uint16(80)
+
+	// DefaultHTLCMinimumMsat is the minimum HTLC value of a ChannelUpdate2
+	// that doesn't carry the field explicitly.
+	DefaultHTLCMinimumMsat = MilliSatoshi(1)
+
+	// DefaultFeeBaseMsat is the base fee of a ChannelUpdate2 that doesn't
+	// carry the field explicitly.
+	DefaultFeeBaseMsat = uint32(1000)
+
+	// DefaultFeeProportionalMillionths is the proportional fee of a
+	// ChannelUpdate2 that doesn't carry the field explicitly.
+	DefaultFeeProportionalMillionths = uint32(1)
+)
+
+// ChanUpdateDisableFlags is a bitfield that signals in which directions a
+// channel is disabled in a ChannelUpdate2.
+type ChanUpdateDisableFlags uint8
+
+const (
+	// ChanUpdateDisableIncoming is set if the sender of the update won't
+	// accept HTLCs from its channel peer.
+	ChanUpdateDisableIncoming ChanUpdateDisableFlags = 1 << iota
+
+	// ChanUpdateDisableOutgoing is set if the sender of the update won't
+	// forward HTLCs to its channel peer.
+	ChanUpdateDisableOutgoing
+)
+
+// IsEnabled returns true if the channel isn't disabled in any direction.
+func (c ChanUpdateDisableFlags) IsEnabled() bool {
+	return c == 0
+}
+
+// IncomingDisabled returns true if the incoming direction is disabled.
+func (c ChanUpdateDisableFlags) IncomingDisabled() bool {
+	return c&ChanUpdateDisableIncoming == ChanUpdateDisableIncoming
+}
+
+// OutgoingDisabled returns true if the outgoing direction is disabled.
+func (c ChanUpdateDisableFlags) OutgoingDisabled() bool {
+	return c&ChanUpdateDisableOutgoing == ChanUpdateDisableOutgoing
+}
+
+// String returns the bitfield flags as a string.
+func (c ChanUpdateDisableFlags) String() string {
+	return fmt.Sprintf("%08b", c)
+}
+
+// ChannelUpdate2 is the gossip 1.75 version of the channel update message.
+// Unlike the legacy ChannelUpdate, it is a pure TLV message which is signed
+// with a Schnorr signature and which uses block heights instead of
+// timestamps to order updates. Fields that have their default value are
+// omitted on the wire.
+type ChannelUpdate2 struct {
+	// ChainHash denotes the target chain that this channel was opened
+	// within. It defaults to the genesis hash of the bitcoin mainnet.
+	ChainHash chainhash.Hash
+
+	// ShortChannelID is the unique description of the funding transaction.
+	ShortChannelID ShortChannelID
+
+	// BlockHeight is used in place of a timestamp to order the updates of
+	// a channel. A node must not create an update with a block height
+	// above the current best block height.
+	BlockHeight uint32
+
+	// DisableFlags signals in which directions the channel is disabled.
+	DisableFlags ChanUpdateDisableFlags
+
+	// SecondPeer is set if the update was created by node 2 of the
+	// channel, and is therefore used in place of the direction bit.
+	SecondPeer bool
+
+	// CLTVExpiryDelta is the minimum number of blocks this node requires
+	// to be added to the expiry of HTLCs. It defaults to 80.
+	CLTVExpiryDelta uint16
+
+	// HTLCMinimumMsat is the minimum HTLC value which will be accepted.
+	// It defaults to 1.
+	HTLCMinimumMsat MilliSatoshi
+
+	// HTLCMaximumMsat is the maximum HTLC value which will be accepted.
+	// If it is zero, the field wasn't set and the capacity of the channel
+	// is to be used instead.
+	HTLCMaximumMsat MilliSatoshi
+
+	// FeeBaseMsat is the base fee that must be used for incoming HTLCs on
+	// this channel. It defaults to 1000.
+	FeeBaseMsat uint32
+
+	// FeeProportionalMillionths is the fee rate that will be charged per
+	// millionth of a satoshi. It defaults to 1.
+	FeeProportionalMillionths uint32
+
+	// Signature is a Schnorr signature over the serialization of all the
+	// fields of the message outside of the signature range, created with
+	// the node key of the sender.
+	Signature Sig
+
+	// ExtraOpaqueData is the raw TLV stream of all the records that we
+	// don't know about. We hold onto them so that we're able to validate
+	// the signature over them and relay them to our peers.
+	ExtraOpaqueData ExtraOpaqueData
+}
+
+// A compile time check to ensure ChannelUpdate2 implements the lnwire.Message
+// interface.
+var _ Message = (*ChannelUpdate2)(nil)
+
+// tuint32Record returns a truncated uint32 record of the given type.
+func tuint32Record(typ tlv.Type, val *uint32) tlv.Record {
+	return tlv.MakeDynamicRecord(
+		typ, val, func() uint64 {
+			return tlv.SizeTUint32(*val)
+		}, tlv.ETUint32, tlv.DTUint32,
+	)
+}
+
+// tuint64Record returns a truncated uint64 record of the given type.
+func tuint64Record(typ tlv.Type, val *uint64) tlv.Record {
+	return tlv.MakeDynamicRecord(
+		typ, val, func() uint64 {
+			return tlv.SizeTUint64(*val)
+		}, tlv.ETUint64, tlv.DTUint64,
+	)
+}
+
+// secondPeerRecord returns the zero length record that signals that an update
+// was created by the second peer of a channel.
+func secondPeerRecord() tlv.Record {
+	return tlv.MakeStaticRecord(
+		chanUpd2SecondPeerType, nil, 0, tlv.ENOP, tlv.DNOP,
+	)
+}
+
+// records returns the TLV records of all the known fields that don't have
+// their default value.
+func (c *ChannelUpdate2) records() []tlv.Record {
+	var records []tlv.Record
+
+	if c.ChainHash != *chaincfg.MainNetParams.GenesisHash {
+		records = append(records, tlv.MakePrimitiveRecord(
+			chanUpd2ChainHashType, (*[32]byte)(&c.ChainHash),
+		))
+	}
+
+	records = append(records,
+		tlv.MakeStaticRecord(
+			chanUpd2ShortChanIDType, &c.ShortChannelID, 8,
+			EShortChannelID, DShortChannelID,
+		),
+		tlv.MakePrimitiveRecord(chanUpd2BlockHeightType, &c.BlockHeight),
+	)
+
+	if c.DisableFlags != 0 {
+		records = append(records, tlv.MakePrimitiveRecord(
+			chanUpd2DisableFlagsType, (*uint8)(&c.DisableFlags),
+		))
+	}
+	if c.SecondPeer {
+		records = append(records, secondPeerRecord())
+	}
+	if c.CLTVExpiryDelta != DefaultCLTVExpiryDelta {
+		records = append(records, tlv.MakePrimitiveRecord(
+			chanUpd2CLTVExpiryDeltaType, &c.CLTVExpiryDelta,
+		))
+	}
+	if c.HTLCMinimumMsat != DefaultHTLCMinimumMsat {
+		records = append(records, tuint64Record(
+			chanUpd2HTLCMinMsatType, (*uint64)(&c.HTLCMinimumMsat),
+		))
+	}
+	if c.HTLCMaximumMsat != 0 {
+		records = append(records, tuint64Record(
+			chanUpd2HTLCMaxMsatType, (*uint64)(&c.HTLCMaximumMsat),
+		))
+	}
+	if c.FeeBaseMsat != DefaultFeeBaseMsat {
+		records = append(records, tuint32Record(
+			chanUpd2FeeBaseMsatType, &c.FeeBaseMsat,
+		))
+	}
+	if c.FeeProportionalMillionths != DefaultFeeProportionalMillionths {
+		records = append(records, tuint32Record(
+			chanUpd2FeeProportionalType,
+			&c.FeeProportionalMillionths,
+		))
+	}
+
+	return append(records, tlv.MakePrimitiveRecord(
+		chanUpd2SignatureType, &c.Signature.bytes,
+	))
+}
+
+// Decode deserializes a serialized ChannelUpdate2 stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *ChannelUpdate2) Decode(r io.Reader, _ uint32) error {
+	var (
+		chainHash    [32]byte
+		scid         ShortChannelID
+		blockHeight  uint32
+		disableFlags uint8
+		cltvDelta    = DefaultCLTVExpiryDelta
+		htlcMin      = uint64(DefaultHTLCMinimumMsat)
+		htlcMax      uint64
+		feeBase      = DefaultFeeBaseMsat
+		feeRate      = DefaultFeeProportionalMillionths
+		sig          Sig
+	)
+	typeMap, extra, err := decodeGossipV2Msg(
+		r,
+		tlv.MakePrimitiveRecord(chanUpd2ChainHashType, &chainHash),
+		tlv.MakeStaticRecord(
+			chanUpd2ShortChanIDType, &scid, 8, EShortChannelID,
+			DShortChannelID,
+		),
+		tlv.MakePrimitiveRecord(chanUpd2BlockHeightType, &blockHeight),
+		tlv.MakePrimitiveRecord(chanUpd2DisableFlagsType, &disableFlags),
+		secondPeerRecord(),
+		tlv.MakePrimitiveRecord(chanUpd2CLTVExpiryDeltaType, &cltvDelta),
+		tuint64Record(chanUpd2HTLCMinMsatType, &htlcMin),
+		tuint64Record(chanUpd2HTLCMaxMsatType, &htlcMax),
+		tuint32Record(chanUpd2FeeBaseMsatType, &feeBase),
+		tuint32Record(chanUpd2FeeProportionalType, &feeRate),
+		tlv.MakePrimitiveRecord(chanUpd2SignatureType, &sig.bytes),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = requireGossipV2Types(
+		typeMap, chanUpd2ShortChanIDType, chanUpd2BlockHeightType,
+		chanUpd2SignatureType,
+	)
+	if err != nil {
+		return err
+	}
+
+	*c = ChannelUpdate2{
+		ChainHash:                 *chaincfg.MainNetParams.GenesisHash,
+		ShortChannelID:            scid,
+		BlockHeight:               blockHeight,
+		DisableFlags:              ChanUpdateDisableFlags(disableFlags),
+		CLTVExpiryDelta:           cltvDelta,
+		HTLCMinimumMsat:           MilliSatoshi(htlcMin),
+		HTLCMaximumMsat:           MilliSatoshi(htlcMax),
+		FeeBaseMsat:               feeBase,
+		FeeProportionalMillionths: feeRate,
+		Signature:                 sig,
+		ExtraOpaqueData:           extra,
+	}
+
+	// The signature of a gossip v2 message is always a Schnorr signature.
+	c.Signature.ForceSchnorr()
+
+	if _, ok := typeMap[chanUpd2ChainHashType]; ok {
+		c.ChainHash = chainHash
+	}
+	if _, ok := typeMap[chanUpd2SecondPeerType]; ok {
+		c.SecondPeer = true
+	}
+
+	return nil
+}
+
+// Encode serializes the target ChannelUpdate2 into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *ChannelUpdate2) Encode(w *bytes.Buffer, _ uint32) error {
+	return encodeGossipV2Msg(w, c.records(), c.ExtraOpaqueData, false)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *ChannelUpdate2) MsgType() MessageType {
+	return MsgChannelUpdate2
+}
+
+// DataToSign returns the part of the message that should be signed, which
+// is the serialization of all records outside of the signature range.
+func (c *ChannelUpdate2) DataToSign() ([]byte, error) {
+	var w bytes.Buffer
+	err := encodeGossipV2Msg(&w, c.records(), c.ExtraOpaqueData, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// DigestToSign returns the tagged hash of the message that is covered by its
+// signature.
+func (c *ChannelUpdate2) DigestToSign() (*chainhash.Hash, error) {
+	data, err := c.DataToSign()
+	if err != nil {
+		return nil, err
+	}
+
+	return MsgHash("channel_update_2", "signature", data), nil
+}
+
+// Direction returns the direction of the channel that this update applies
+// to, which is 0 for updates created by node 1 and 1 for updates created by
+// node 2. This corresponds to the direction bit of the legacy ChannelUpdate.
+func (c *ChannelUpdate2) Direction() ChanUpdateChanFlags {
+	if c.SecondPeer {
+		return ChanUpdateDirection
+	}
+
+	return 0
+}
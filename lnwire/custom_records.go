@@ -0,0 +1,123 @@
+package lnwire
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	// MinCustomRecordsTlvType is the minimum custom records TLV type as
+	// defined in BOLT 01. Overlay protocols that attach their own data to
+	// messages must use types in this range.
+	MinCustomRecordsTlvType = 65536
+)
+
+// CustomRecords stores a set of custom key/value pairs that are attached to a
+// message by an overlay protocol. The values are opaque to lnd.
+type CustomRecords map[uint64][]byte
+
+// NewCustomRecordsFromTlvTypeMap returns the custom records that were parsed
+// from a TLV stream but weren't decoded into a known record. Known records
+// don't carry a value in the type map, so they are skipped.
+func NewCustomRecordsFromTlvTypeMap(tlvMap tlv.TypeMap) CustomRecords {
+	var customRecords CustomRecords
+	for typ, value := range tlvMap {
+		if uint64(typ) < MinCustomRecordsTlvType || value == nil {
+			continue
+		}
+
+		if customRecords == nil {
+			customRecords = make(CustomRecords)
+		}
+		customRecords[uint64(typ)] = value
+	}
+
+	return customRecords
+}
+
+// Validate checks that all custom records are in the custom type range.
+func (c CustomRecords) Validate() error {
+	for key := range c {
+		if key < MinCustomRecordsTlvType {
+			return fmt.Errorf("custom records entry with TLV "+
+				"type %v is below the min allowed type %v", key,
+				MinCustomRecordsTlvType)
+		}
+	}
+
+	return nil
+}
+
+// Copy returns a deep copy of the custom records.
+func (c CustomRecords) Copy() CustomRecords {
+	if c == nil {
+		return nil
+	}
+
+	customRecords := make(CustomRecords, len(c))
+	for key, value := range c {
+		customRecords[key] = append([]byte(nil), value...)
+	}
+
+	return customRecords
+}
+
+// RecordProducers returns the custom records as a slice of record producers
+// that can be packed into the extra data of a message.
+func (c CustomRecords) RecordProducers() []tlv.RecordProducer {
+	records := tlv.MapToRecords(c)
+
+	producers := make([]tlv.RecordProducer, 0, len(records))
+	for _, record := range records {
+		producers = append(producers, &recordProducer{record})
+	}
+
+	return producers
+}
+
+// Serialize encodes the custom records as a canonical TLV stream.
+func (c CustomRecords) Serialize() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	var b ExtraOpaqueData
+	if err := b.PackRecords(c.RecordProducers()...); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ParseCustomRecords decodes a TLV stream that was encoded with Serialize.
+func ParseCustomRecords(b []byte) (CustomRecords, error) {
+	extraData := ExtraOpaqueData(b)
+	tlvMap, err := extraData.ExtractRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	customRecords := NewCustomRecordsFromTlvTypeMap(tlvMap)
+
+	// Any type that was dropped because it is outside of the custom range
+	// means the stream wasn't a valid set of custom records.
+	if len(customRecords) != len(tlvMap) {
+		return nil, fmt.Errorf("custom records contain TLV types "+
+			"below the min allowed type %v",
+			MinCustomRecordsTlvType)
+	}
+
+	return customRecords, nil
+}
+
+// recordProducer is a simple helper struct that implements the
+// tlv.RecordProducer interface for an already assembled record.
+type recordProducer struct {
+	record tlv.Record
+}
+
+// Record returns the underlying record.
+func (r *recordProducer) Record() tlv.Record {
+	return r.record
+}
@@ -0,0 +1,81 @@
+package lnwire
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCustomRecordsValidate tests that only records in the custom TLV range
+// are accepted.
+func TestCustomRecordsValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, CustomRecords{}.Validate())
+	require.NoError(t, CustomRecords{
+		MinCustomRecordsTlvType: []byte{1},
+	}.Validate())
+	require.Error(t, CustomRecords{
+		MinCustomRecordsTlvType - 1: []byte{1},
+	}.Validate())
+}
+
+// TestUpdateAddHTLCCustomRecords tests that custom records survive an encode
+// and decode round trip of update_add_htlc next to the known records, and that
+// records outside of the custom range are refused.
+func TestUpdateAddHTLCCustomRecords(t *testing.T) {
+	t.Parallel()
+
+	endorsement := Endorsement(1)
+	customRecords := CustomRecords{
+		MinCustomRecordsTlvType + 1: []byte{1, 2, 3},
+		MinCustomRecordsTlvType + 3: []byte{4},
+	}
+	msg := &UpdateAddHTLC{
+		Endorsement:   &endorsement,
+		CustomRecords: customRecords,
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, msg.Encode(&b, 0))
+
+	var decoded UpdateAddHTLC
+	require.NoError(t, decoded.Decode(&b, 0))
+	require.Equal(t, customRecords, decoded.CustomRecords)
+	require.Equal(t, &endorsement, decoded.Endorsement)
+
+	msg.CustomRecords = CustomRecords{1: []byte{1}}
+	require.Error(t, msg.Encode(&bytes.Buffer{}, 0))
+}
+
+// TestCustomRecordsSerialize tests that custom records can be serialized into
+// a standalone TLV stream and parsed back.
+func TestCustomRecordsSerialize(t *testing.T) {
+	t.Parallel()
+
+	customRecords := CustomRecords{
+		MinCustomRecordsTlvType:     []byte{1, 2, 3},
+		MinCustomRecordsTlvType + 7: []byte{4, 5},
+	}
+
+	b, err := customRecords.Serialize()
+	require.NoError(t, err)
+
+	parsed, err := ParseCustomRecords(b)
+	require.NoError(t, err)
+	require.Equal(t, customRecords, parsed)
+
+	// An empty stream results in no records.
+	parsed, err = ParseCustomRecords(nil)
+	require.NoError(t, err)
+	require.Empty(t, parsed)
+
+	// Streams with types below the custom range are refused.
+	var extraData ExtraOpaqueData
+	require.NoError(t, extraData.PackRecords(
+		CustomRecords{1: []byte{1}}.RecordProducers()...,
+	))
+	_, err = ParseCustomRecords(extraData)
+	require.Error(t, err)
+}
@@ -171,6 +171,24 @@ const (
 	// sender-generated preimages according to BOLT XX.
 	AMPOptional FeatureBit = 31
 
+	// OnionMessagesRequired is a required feature bit that signals that
+	// the node forwards onion messages as defined in BOLT 4.
+	OnionMessagesRequired FeatureBit = 38
+
+	// OnionMessagesOptional is an optional feature bit that signals that
+	// the node forwards onion messages as defined in BOLT 4.
+	OnionMessagesOptional FeatureBit = 39
+
+	// ProvideStorageRequired is a required feature bit that signals that
+	// the node stores small blobs for its peers as defined by
+	// option_provide_storage.
+	ProvideStorageRequired FeatureBit = 42
+
+	// ProvideStorageOptional is an optional feature bit that signals that
+	// the node stores small blobs for its peers as defined by
+	// option_provide_storage.
+	ProvideStorageOptional FeatureBit = 43
+
 	// ExplicitChannelTypeRequired is a required bit that denotes that a
 	// connection established with this node is to use explicit channel
 	// commitment types for negotiation instead of the existing implicit
@@ -241,6 +259,46 @@ const (
 	// TODO: Decide on actual feature bit value.
 	ScriptEnforcedLeaseOptional FeatureBit = 2023
 
+	// SplitCloseOutputsRequired is a required feature bit that signals
+	// that the node accepts additional close outputs in the Shutdown
+	// message, which split the settled balance of the sender across
+	// multiple outputs of the cooperative close transaction.
+	//
+	// TODO: Decide on actual feature bit value.
+	SplitCloseOutputsRequired FeatureBit = 2024
+
+	// SplitCloseOutputsOptional is an optional feature bit that signals
+	// that the node accepts additional close outputs in the Shutdown
+	// message, which split the settled balance of the sender across
+	// multiple outputs of the cooperative close transaction.
+	//
+	// TODO: Decide on actual feature bit value.
+	SplitCloseOutputsOptional FeatureBit = 2025
+
+	// RbfCoopCloseRequired is a required feature bit that signals that the
+	// node supports the RBF based cooperative close protocol, which lets
+	// either party bump the fee of the closing transaction by paying the
+	// fee from its own output.
+	RbfCoopCloseRequired FeatureBit = 60
+
+	// RbfCoopCloseOptional is an optional feature bit that signals that
+	// the node supports the RBF based cooperative close protocol, which
+	// lets either party bump the fee of the closing transaction by paying
+	// the fee from its own output.
+	RbfCoopCloseOptional FeatureBit = 61
+
+	// PtlcRequiredStaging is a required feature bit that signals that the
+	// node accepts point time locked contracts on simple taproot channels.
+	// This is a feature bit used for experiments while PTLCs are not yet
+	// specified.
+	PtlcRequiredStaging FeatureBit = 2026
+
+	// PtlcOptionalStaging is an optional feature bit that signals that the
+	// node accepts point time locked contracts on simple taproot channels.
+	// This is a feature bit used for experiments while PTLCs are not yet
+	// specified.
+	PtlcOptionalStaging FeatureBit = 2027
+
 	// SimpleTaprootChannelsRequredFinal is a required bit that indicates
 	// the node is able to create taproot-native channels. This is the
 	// final feature bit to be used once the channel type is finalized.
@@ -311,6 +369,10 @@ var Features = map[FeatureBit]string{
 	WumboChannelsOptional:                "wumbo-channels",
 	AMPRequired:                          "amp",
 	AMPOptional:                          "amp",
+	OnionMessagesRequired:                "onion-messages",
+	OnionMessagesOptional:                "onion-messages",
+	ProvideStorageRequired:               "provide-storage",
+	ProvideStorageOptional:               "provide-storage",
 	PaymentMetadataOptional:              "payment-metadata",
 	PaymentMetadataRequired:              "payment-metadata",
 	ExplicitChannelTypeOptional:          "explicit-commitment-type",
@@ -319,6 +381,12 @@ var Features = map[FeatureBit]string{
 	KeysendRequired:                      "keysend",
 	ScriptEnforcedLeaseRequired:          "script-enforced-lease",
 	ScriptEnforcedLeaseOptional:          "script-enforced-lease",
+	SplitCloseOutputsRequired:            "split-close-outputs",
+	SplitCloseOutputsOptional:            "split-close-outputs",
+	RbfCoopCloseRequired:                 "rbf-coop-close",
+	RbfCoopCloseOptional:                 "rbf-coop-close",
+	PtlcRequiredStaging:                  "ptlc-x",
+	PtlcOptionalStaging:                  "ptlc-x",
 	ScidAliasRequired:                    "scid-alias",
 	ScidAliasOptional:                    "scid-alias",
 	ZeroConfRequired:                     "zero-conf",
@@ -879,6 +879,39 @@ func FuzzInvalidOnionPayload(f *testing.F) {
 	})
 }
 
+func FuzzOnionMessage(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Prefix with MsgOnionMessage.
+		data = prefixWithMsgType(data, MsgOnionMessage)
+
+		// Pass the message into our general fuzz harness for wire
+		// messages!
+		harness(t, data)
+	})
+}
+
+func FuzzPeerStorage(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Prefix with MsgPeerStorage.
+		data = prefixWithMsgType(data, MsgPeerStorage)
+
+		// Pass the message into our general fuzz harness for wire
+		// messages!
+		harness(t, data)
+	})
+}
+
+func FuzzPeerStorageRetrieval(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Prefix with MsgPeerStorageRetrieval.
+		data = prefixWithMsgType(data, MsgPeerStorageRetrieval)
+
+		// Pass the message into our general fuzz harness for wire
+		// messages!
+		harness(t, data)
+	})
+}
+
 func FuzzClosingSig(f *testing.F) {
 	f.Fuzz(func(t *testing.T, data []byte) {
 		// Prefix with ClosingSig.
@@ -900,3 +933,25 @@ func FuzzClosingComplete(f *testing.F) {
 		harness(t, data)
 	})
 }
+
+func FuzzChannelAnnouncement2(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Prefix with MsgChannelAnnouncement2.
+		data = prefixWithMsgType(data, MsgChannelAnnouncement2)
+
+		// Pass the message into our general fuzz harness for wire
+		// messages!
+		harness(t, data)
+	})
+}
+
+func FuzzChannelUpdate2(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Prefix with MsgChannelUpdate2.
+		data = prefixWithMsgType(data, MsgChannelUpdate2)
+
+		// Pass the message into our general fuzz harness for wire
+		// messages!
+		harness(t, data)
+	})
+}
@@ -0,0 +1,181 @@
+package lnwire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// GossipVersion denotes the version of the gossip protocol that a channel
+// announcement or channel update was received with.
+type GossipVersion uint8
+
+const (
+	// GossipVersion1 is the legacy gossip protocol in which channel
+	// announcements are signed with ECDSA and prove ownership of a P2WSH
+	// funding output.
+	GossipVersion1 GossipVersion = iota
+
+	// GossipVersion2 is the gossip 1.75 protocol in which channel
+	// announcements are pure TLV messages signed with a single Schnorr
+	// signature and can announce P2TR funding outputs.
+	GossipVersion2
+)
+
+// String returns a human-readable description of the gossip version.
+func (v GossipVersion) String() string {
+	switch v {
+	case GossipVersion1:
+		return "v1"
+	case GossipVersion2:
+		return "v2"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(v))
+	}
+}
+
+const (
+	// gossipV2SigRangeStart is the first TLV type of the range that is
+	// reserved for signatures in gossip v2 messages. Records in this range
+	// are not covered by the message signature.
+	gossipV2SigRangeStart = 160
+
+	// gossipV2SigRangeEnd is the last TLV type of the range that is
+	// reserved for signatures in gossip v2 messages.
+	gossipV2SigRangeEnd = 239
+)
+
+// inGossipV2SigRange returns true if the given TLV type is within the range
+// reserved for signatures.
+func inGossipV2SigRange(typ tlv.Type) bool {
+	return typ >= gossipV2SigRangeStart && typ <= gossipV2SigRangeEnd
+}
+
+// MsgHash computes the BIP340 tagged hash of the given serialized gossip v2
+// message that is covered by the signature field with the given name. The tag
+// is the concatenation of "lightning", the message name and the field name.
+func MsgHash(msgName, fieldName string, msg []byte) *chainhash.Hash {
+	tag := []byte("lightning" + msgName + fieldName)
+
+	return chainhash.TaggedHash(tag, msg)
+}
+
+// requireGossipV2Types returns an error if any of the given types wasn't
+// found in the decoded message.
+func requireGossipV2Types(typeMap tlv.TypeMap, types ...tlv.Type) error {
+	for _, typ := range types {
+		if _, ok := typeMap[typ]; !ok {
+			return fmt.Errorf("missing required type %d", typ)
+		}
+	}
+
+	return nil
+}
+
+// encodeGossipV2Msg encodes the given known records along with the unknown
+// records carried in extra as a single canonical TLV stream. If signedOnly is
+// true, all records within the signature range are omitted, which yields the
+// serialization that is covered by the message signature.
+func encodeGossipV2Msg(w io.Writer, records []tlv.Record,
+	extra ExtraOpaqueData, signedOnly bool) error {
+
+	known := make(map[tlv.Type]struct{}, len(records))
+	for _, record := range records {
+		known[record.Type()] = struct{}{}
+	}
+
+	// The extra data only ever carries records we don't know about, so we
+	// parse it without any known records to get hold of their raw values.
+	if len(extra) != 0 {
+		stream, err := tlv.NewStream()
+		if err != nil {
+			return err
+		}
+
+		typeMap, err := stream.DecodeWithParsedTypes(
+			bytes.NewReader(extra),
+		)
+		if err != nil {
+			return err
+		}
+
+		extraRecords := make(map[uint64][]byte, len(typeMap))
+		for typ, value := range typeMap {
+			if _, ok := known[typ]; ok {
+				return fmt.Errorf("extra data contains known "+
+					"type %d", typ)
+			}
+
+			extraRecords[uint64(typ)] = value
+		}
+
+		records = append(records, tlv.MapToRecords(extraRecords)...)
+	}
+
+	toEncode := make([]tlv.Record, 0, len(records))
+	for _, record := range records {
+		if signedOnly && inGossipV2SigRange(record.Type()) {
+			continue
+		}
+
+		toEncode = append(toEncode, record)
+	}
+	tlv.SortRecords(toEncode)
+
+	stream, err := tlv.NewStream(toEncode...)
+	if err != nil {
+		return err
+	}
+
+	return stream.Encode(w)
+}
+
+// decodeGossipV2Msg decodes a gossip v2 message from the passed reader into
+// the given records. The returned type map contains the types of all known
+// records that were found, and any unknown records are returned as the raw
+// TLV stream in the ExtraOpaqueData.
+func decodeGossipV2Msg(r io.Reader, records ...tlv.Record) (tlv.TypeMap,
+	ExtraOpaqueData, error) {
+
+	tlv.SortRecords(records)
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Since gossip messages are provided by a potentially malicious peer,
+	// we use the P2P decoding variant.
+	typeMap, err := stream.DecodeWithParsedTypesP2P(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extraRecords := make(map[uint64][]byte)
+	for typ, value := range typeMap {
+		if value == nil {
+			continue
+		}
+
+		extraRecords[uint64(typ)] = value
+	}
+
+	if len(extraRecords) == 0 {
+		return typeMap, nil, nil
+	}
+
+	extraStream, err := tlv.NewStream(tlv.MapToRecords(extraRecords)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var extra bytes.Buffer
+	if err := extraStream.Encode(&extra); err != nil {
+		return nil, nil, err
+	}
+
+	return typeMap, extra.Bytes(), nil
+}
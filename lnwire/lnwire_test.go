@@ -166,6 +166,26 @@ func randRawFeatureVector(r *rand.Rand) *RawFeatureVector {
 	return featureVec
 }
 
+// randGossipV2ExtraData returns either no extra data or a TLV stream with a
+// single unknown odd record, as it would be carried by a gossip v2 message.
+func randGossipV2ExtraData(t *testing.T, r *rand.Rand) ExtraOpaqueData {
+	if r.Intn(2) == 0 {
+		return nil
+	}
+
+	value := make([]byte, r.Intn(100))
+	_, err := r.Read(value)
+	require.NoError(t, err)
+
+	stream, err := tlv.NewStream(tlv.MakePrimitiveRecord(1001, &value))
+	require.NoError(t, err)
+
+	var extra bytes.Buffer
+	require.NoError(t, stream.Encode(&extra))
+
+	return extra.Bytes()
+}
+
 func randTCP4Addr(r *rand.Rand) (*net.TCPAddr, error) {
 	var ip [4]byte
 	if _, err := r.Read(ip[:]); err != nil {
@@ -710,6 +730,20 @@ func TestLightningWireProtocol(t *testing.T) {
 				req.ShutdownNonce = someLocalNonce[ShutdownNonceType](r)
 			}
 
+			if r.Int31()%2 == 0 {
+				outputs := make(CloseOutputs, r.Intn(3)+1)
+				for i := range outputs {
+					pkScript, err := randDeliveryAddress(r)
+					require.NoError(t, err)
+
+					outputs[i] = &wire.TxOut{
+						Value:    r.Int63(),
+						PkScript: pkScript,
+					}
+				}
+				req.CloseOutputs = SomeCloseOutputs(outputs)
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgClosingSigned: func(v []reflect.Value, r *rand.Rand) {
@@ -1067,6 +1101,83 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			v[0] = reflect.ValueOf(req)
 		},
+		MsgChannelAnnouncement2: func(v []reflect.Value, r *rand.Rand) {
+			req := ChannelAnnouncement2{
+				ShortChannelID: NewShortChanIDFromInt(
+					uint64(r.Int63()),
+				),
+				Capacity: uint64(r.Int63()),
+				Features: randRawFeatureVector(r),
+			}
+
+			_, err := r.Read(req.ChainHash[:])
+			require.NoError(t, err)
+
+			req.NodeID1, err = randRawKey()
+			require.NoError(t, err)
+			req.NodeID2, err = randRawKey()
+			require.NoError(t, err)
+
+			if r.Intn(2) == 0 {
+				btcKey1, err := randRawKey()
+				require.NoError(t, err)
+				btcKey2, err := randRawKey()
+				require.NoError(t, err)
+
+				req.BitcoinKey1 = fn.Some(btcKey1)
+				req.BitcoinKey2 = fn.Some(btcKey2)
+			}
+
+			if r.Intn(2) == 0 {
+				var merkleRootHash [32]byte
+				_, err := r.Read(merkleRootHash[:])
+				require.NoError(t, err)
+
+				req.MerkleRootHash = fn.Some(merkleRootHash)
+			}
+
+			var sig [64]byte
+			_, err = r.Read(sig[:])
+			require.NoError(t, err)
+
+			req.Signature, err = NewSigFromSchnorrRawSignature(sig[:])
+			require.NoError(t, err)
+
+			req.ExtraOpaqueData = randGossipV2ExtraData(t, r)
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgChannelUpdate2: func(v []reflect.Value, r *rand.Rand) {
+			req := ChannelUpdate2{
+				ShortChannelID: NewShortChanIDFromInt(
+					uint64(r.Int63()),
+				),
+				BlockHeight: uint32(r.Int31()),
+				DisableFlags: ChanUpdateDisableFlags(
+					r.Int31n(4),
+				),
+				SecondPeer:                r.Intn(2) == 0,
+				CLTVExpiryDelta:           uint16(r.Int31()),
+				HTLCMinimumMsat:           MilliSatoshi(r.Int63()),
+				HTLCMaximumMsat:           MilliSatoshi(r.Int63()),
+				FeeBaseMsat:               uint32(r.Int31()),
+				FeeProportionalMillionths: uint32(r.Int31()),
+			}
+
+			_, err := r.Read(req.ChainHash[:])
+			require.NoError(t, err)
+
+			var sig [64]byte
+			_, err = r.Read(sig[:])
+			require.NoError(t, err)
+
+			req.Signature, err = NewSigFromSchnorrRawSignature(sig[:])
+			require.NoError(t, err)
+
+			req.ExtraOpaqueData = randGossipV2ExtraData(t, r)
+
+			v[0] = reflect.ValueOf(req)
+		},
 		MsgAnnounceSignatures: func(v []reflect.Value, r *rand.Rand) {
 			var err error
 			req := AnnounceSignatures{
@@ -1371,8 +1482,70 @@ func TestLightningWireProtocol(t *testing.T) {
 				)
 			}
 
+			// Set the endorsement signal 50% of the time.
+			if r.Int31()%2 == 0 {
+				endorsement := Endorsement(r.Int31n(2))
+				req.Endorsement = &endorsement
+			}
+
+			// Set a payment point 50% of the time.
+			if r.Int31()%2 == 0 {
+				pubkey, err := randPubKey()
+				require.NoError(t, err)
+
+				req.PaymentPoint = NewPaymentPoint(pubkey)
+			}
+
+			// Attach an odd custom record 50% of the time.
+			if r.Int31()%2 == 0 {
+				value := make([]byte, 1+r.Intn(32))
+				_, err := r.Read(value)
+				require.NoError(t, err)
+
+				req.CustomRecords = CustomRecords{
+					MinCustomRecordsTlvType + 1: value,
+				}
+			}
+
 			v[0] = reflect.ValueOf(*req)
 		},
+		MsgOnionMessage: func(v []reflect.Value, r *rand.Rand) {
+			pathKey, err := randPubKey()
+			require.NoError(t, err)
+
+			req := OnionMessage{
+				PathKey:   pathKey,
+				OnionBlob: make([]byte, 1+r.Intn(2000)),
+				ExtraData: make([]byte, 0),
+			}
+
+			_, err = r.Read(req.OnionBlob)
+			require.NoError(t, err)
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgPeerStorage: func(v []reflect.Value, r *rand.Rand) {
+			req := PeerStorage{
+				Blob:      make([]byte, r.Intn(2000)),
+				ExtraData: make([]byte, 0),
+			}
+
+			_, err := r.Read(req.Blob)
+			require.NoError(t, err)
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgPeerStorageRetrieval: func(v []reflect.Value, r *rand.Rand) {
+			req := PeerStorageRetrieval{
+				Blob:      make([]byte, r.Intn(2000)),
+				ExtraData: make([]byte, 0),
+			}
+
+			_, err := r.Read(req.Blob)
+			require.NoError(t, err)
+
+			v[0] = reflect.ValueOf(req)
+		},
 	}
 
 	// With the above types defined, we'll now generate a slice of
@@ -1595,6 +1768,36 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgOnionMessage,
+			scenario: func(m OnionMessage) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgPeerStorage,
+			scenario: func(m PeerStorage) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgPeerStorageRetrieval,
+			scenario: func(m PeerStorageRetrieval) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgChannelAnnouncement2,
+			scenario: func(m ChannelAnnouncement2) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgChannelUpdate2,
+			scenario: func(m ChannelUpdate2) bool {
+				return mainScenario(&m)
+			},
+		},
 	}
 	for _, test := range tests {
 		var config *quick.Config
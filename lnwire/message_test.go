@@ -290,6 +290,9 @@ func makeAllMessages(t testing.TB, r *rand.Rand) []lnwire.Message {
 	msgAll = append(msgAll, newMsgGossipTimestampRange(t, r))
 	msgAll = append(msgAll, newMsgQueryShortChanIDsZlib(t, r))
 	msgAll = append(msgAll, newMsgReplyChannelRangeZlib(t, r))
+	msgAll = append(msgAll, newMsgOnionMessage(t, r))
+	msgAll = append(msgAll, newMsgPeerStorage(t, r))
+	msgAll = append(msgAll, newMsgPeerStorageRetrieval(t, r))
 
 	return msgAll
 }
@@ -883,6 +886,47 @@ func newMsgGossipTimestampRange(t testing.TB,
 	return msg
 }
 
+func newMsgOnionMessage(t testing.TB, r *rand.Rand) *lnwire.OnionMessage {
+	t.Helper()
+
+	onion := make([]byte, 1366)
+	_, err := r.Read(onion)
+	require.NoError(t, err, "unable to read onion blob")
+
+	msg := lnwire.NewOnionMessage(randPubKey(t), onion)
+	msg.ExtraData = createExtraData(t, r)
+
+	return msg
+}
+
+func newMsgPeerStorage(t testing.TB, r *rand.Rand) *lnwire.PeerStorage {
+	t.Helper()
+
+	blob := make([]byte, 1000)
+	_, err := r.Read(blob)
+	require.NoError(t, err, "unable to read peer storage blob")
+
+	msg := lnwire.NewPeerStorage(blob)
+	msg.ExtraData = createExtraData(t, r)
+
+	return msg
+}
+
+func newMsgPeerStorageRetrieval(t testing.TB,
+	r *rand.Rand) *lnwire.PeerStorageRetrieval {
+
+	t.Helper()
+
+	blob := make([]byte, 1000)
+	_, err := r.Read(blob)
+	require.NoError(t, err, "unable to read peer storage blob")
+
+	msg := lnwire.NewPeerStorageRetrieval(blob)
+	msg.ExtraData = createExtraData(t, r)
+
+	return msg
+}
+
 func randRawKey(t testing.TB) [33]byte {
 	t.Helper()
 
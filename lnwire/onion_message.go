@@ -0,0 +1,89 @@
+package lnwire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// OnionMessage is a message that carries an onion encrypted payload along a
+// blinded path through the network as defined in BOLT 4. Unlike HTLCs, onion
+// messages are not bound to a channel and don't carry any value, which allows
+// them to be used as a generic transport for application level protocols.
+type OnionMessage struct {
+	// PathKey is the blinding point that the receiving node uses to derive
+	// the shared secret of its hop in the blinded path.
+	PathKey *btcec.PublicKey
+
+	// OnionBlob is the serialized onion message packet. Its routing info
+	// is usually 1300 bytes long, but larger packets are allowed for
+	// messages with bigger payloads.
+	OnionBlob []byte
+
+	// ExtraData is the set of data that was appended to this message to
+	// fill out the full maximum transport message size. These fields can
+	// be used to specify optional data such as custom TLV fields.
+	ExtraData ExtraOpaqueData
+}
+
+// NewOnionMessage creates a new onion message with the given path key and
+// serialized onion packet.
+func NewOnionMessage(pathKey *btcec.PublicKey, onion []byte) *OnionMessage {
+	return &OnionMessage{
+		PathKey:   pathKey,
+		OnionBlob: onion,
+	}
+}
+
+// A compile time check to ensure OnionMessage implements the lnwire.Message
+// interface.
+var _ Message = (*OnionMessage)(nil)
+
+// Decode deserializes a serialized OnionMessage stored in the passed io.Reader
+// observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (o *OnionMessage) Decode(r io.Reader, _ uint32) error {
+	if err := ReadElements(r, &o.PathKey); err != nil {
+		return err
+	}
+
+	var l [2]byte
+	if _, err := io.ReadFull(r, l[:]); err != nil {
+		return err
+	}
+	onionLen := binary.BigEndian.Uint16(l[:])
+
+	o.OnionBlob = make([]byte, onionLen)
+	if _, err := io.ReadFull(r, o.OnionBlob); err != nil {
+		return err
+	}
+
+	return ReadElements(r, &o.ExtraData)
+}
+
+// Encode serializes the target OnionMessage into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (o *OnionMessage) Encode(w *bytes.Buffer, _ uint32) error {
+	if err := WritePublicKey(w, o.PathKey); err != nil {
+		return err
+	}
+
+	if err := writeDataWithLength(w, o.OnionBlob); err != nil {
+		return err
+	}
+
+	return WriteBytes(w, o.ExtraData)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (o *OnionMessage) MsgType() MessageType {
+	return MsgOnionMessage
+}
@@ -0,0 +1,41 @@
+package lnwire
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestOnionMessageCustomOverride tests that onion messages are parsed as
+// custom messages if their type is overridden, so that they can still be
+// handled by external applications.
+//
+// NOTE: This test isn't run in parallel as it modifies the global custom
+// message overrides.
+func TestOnionMessageCustomOverride(t *testing.T) {
+	pathKey, err := randPubKey()
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	msg := NewOnionMessage(pathKey, []byte{1, 2, 3})
+	_, err = WriteMessage(&b, msg, 0)
+	require.NoError(t, err)
+
+	// Without an override, the message is parsed as an onion message.
+	parsed, err := ReadMessage(bytes.NewReader(b.Bytes()), 0)
+	require.NoError(t, err)
+	require.IsType(t, &OnionMessage{}, parsed)
+
+	require.NoError(t, SetCustomOverrides([]uint16{MsgOnionMessage}))
+	t.Cleanup(func() {
+		require.NoError(t, SetCustomOverrides(nil))
+	})
+
+	// With the override in place, the message is handed over as a custom
+	// message.
+	parsed, err = ReadMessage(bytes.NewReader(b.Bytes()), 0)
+	require.NoError(t, err)
+	require.IsType(t, &Custom{}, parsed)
+	require.EqualValues(t, MsgOnionMessage, parsed.MsgType())
+}
@@ -0,0 +1,166 @@
+package lnwire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// MaxPeerStorageBlobSize is the maximum size of a blob that can be stored by
+// a peer, as limited by the maximum size of the peer_storage message.
+const MaxPeerStorageBlobSize = 65531
+
+// PeerStorage is sent to a peer that signals option_provide_storage to ask it
+// to store the given blob on our behalf. The peer sends the latest stored blob
+// back to us in a PeerStorageRetrieval message whenever we reconnect, which
+// allows us to recover the blob if we lose our data. The blob should be
+// encrypted, as it is handed out to an untrusted peer.
+type PeerStorage struct {
+	// Blob is the data the peer stores for us. It replaces any blob the
+	// peer stored for us before.
+	Blob []byte
+
+	// ExtraData is the set of data that was appended to this message to
+	// fill out the full maximum transport message size. These fields can
+	// be used to specify optional data such as custom TLV fields.
+	ExtraData ExtraOpaqueData
+}
+
+// NewPeerStorage creates a new PeerStorage message with the given blob.
+func NewPeerStorage(blob []byte) *PeerStorage {
+	return &PeerStorage{
+		Blob: blob,
+	}
+}
+
+// A compile time check to ensure PeerStorage implements the lnwire.Message
+// interface.
+var _ Message = (*PeerStorage)(nil)
+
+// Decode deserializes a serialized PeerStorage stored in the passed io.Reader
+// observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (p *PeerStorage) Decode(r io.Reader, _ uint32) error {
+	blob, err := readPeerStorageBlob(r)
+	if err != nil {
+		return err
+	}
+	p.Blob = blob
+
+	return ReadElements(r, &p.ExtraData)
+}
+
+// Encode serializes the target PeerStorage into the passed io.Writer observing
+// the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (p *PeerStorage) Encode(w *bytes.Buffer, _ uint32) error {
+	if err := writePeerStorageBlob(w, p.Blob); err != nil {
+		return err
+	}
+
+	return WriteBytes(w, p.ExtraData)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (p *PeerStorage) MsgType() MessageType {
+	return MsgPeerStorage
+}
+
+// PeerStorageRetrieval is sent to a peer on reconnection and returns the
+// latest blob that the peer asked us to store with a PeerStorage message.
+type PeerStorageRetrieval struct {
+	// Blob is the latest data the peer asked us to store.
+	Blob []byte
+
+	// ExtraData is the set of data that was appended to this message to
+	// fill out the full maximum transport message size. These fields can
+	// be used to specify optional data such as custom TLV fields.
+	ExtraData ExtraOpaqueData
+}
+
+// NewPeerStorageRetrieval creates a new PeerStorageRetrieval message with the
+// given blob.
+func NewPeerStorageRetrieval(blob []byte) *PeerStorageRetrieval {
+	return &PeerStorageRetrieval{
+		Blob: blob,
+	}
+}
+
+// A compile time check to ensure PeerStorageRetrieval implements the
+// lnwire.Message interface.
+var _ Message = (*PeerStorageRetrieval)(nil)
+
+// Decode deserializes a serialized PeerStorageRetrieval stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (p *PeerStorageRetrieval) Decode(r io.Reader, _ uint32) error {
+	blob, err := readPeerStorageBlob(r)
+	if err != nil {
+		return err
+	}
+	p.Blob = blob
+
+	return ReadElements(r, &p.ExtraData)
+}
+
+// Encode serializes the target PeerStorageRetrieval into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (p *PeerStorageRetrieval) Encode(w *bytes.Buffer, _ uint32) error {
+	if err := writePeerStorageBlob(w, p.Blob); err != nil {
+		return err
+	}
+
+	return WriteBytes(w, p.ExtraData)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (p *PeerStorageRetrieval) MsgType() MessageType {
+	return MsgPeerStorageRetrieval
+}
+
+// readPeerStorageBlob reads a length prefixed peer storage blob from the
+// reader.
+func readPeerStorageBlob(r io.Reader) ([]byte, error) {
+	var l [2]byte
+	if _, err := io.ReadFull(r, l[:]); err != nil {
+		return nil, err
+	}
+	blobLen := binary.BigEndian.Uint16(l[:])
+
+	if blobLen > MaxPeerStorageBlobSize {
+		return nil, fmt.Errorf("peer storage blob of %d bytes "+
+			"exceeds maximum of %d bytes", blobLen,
+			MaxPeerStorageBlobSize)
+	}
+
+	blob := make([]byte, blobLen)
+	if _, err := io.ReadFull(r, blob); err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+// writePeerStorageBlob writes the length prefixed peer storage blob to the
+// buffer.
+func writePeerStorageBlob(w *bytes.Buffer, blob []byte) error {
+	if len(blob) > MaxPeerStorageBlobSize {
+		return fmt.Errorf("peer storage blob of %d bytes exceeds "+
+			"maximum of %d bytes", len(blob),
+			MaxPeerStorageBlobSize)
+	}
+
+	return writeDataWithLength(w, blob)
+}
@@ -0,0 +1,43 @@
+package lnwire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerStorageMaxBlobSize tests that peer storage blobs exceeding the
+// maximum size can neither be encoded nor decoded.
+func TestPeerStorageMaxBlobSize(t *testing.T) {
+	t.Parallel()
+
+	// A blob of the maximum size can be encoded and decoded.
+	var b bytes.Buffer
+	msg := NewPeerStorage(make([]byte, MaxPeerStorageBlobSize))
+	require.NoError(t, msg.Encode(&b, 0))
+
+	var decoded PeerStorage
+	require.NoError(t, decoded.Decode(bytes.NewReader(b.Bytes()), 0))
+	require.Len(t, decoded.Blob, MaxPeerStorageBlobSize)
+
+	// A larger blob can't be encoded.
+	b.Reset()
+	msg = NewPeerStorage(make([]byte, MaxPeerStorageBlobSize+1))
+	require.Error(t, msg.Encode(&b, 0))
+
+	retrieval := NewPeerStorageRetrieval(
+		make([]byte, MaxPeerStorageBlobSize+1),
+	)
+	require.Error(t, retrieval.Encode(&b, 0))
+
+	// A message announcing a larger blob is rejected before the blob is
+	// read.
+	var l [2]byte
+	binary.BigEndian.PutUint16(l[:], MaxPeerStorageBlobSize+1)
+	require.Error(t, decoded.Decode(bytes.NewReader(l[:]), 0))
+
+	var decodedRetrieval PeerStorageRetrieval
+	require.Error(t, decodedRetrieval.Decode(bytes.NewReader(l[:]), 0))
+}
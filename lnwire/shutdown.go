@@ -2,17 +2,31 @@ package lnwire
 
 import (
 	"bytes"
+	"encoding/binary"
+	"fmt"
 	"io"
 
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/tlv"
 )
 
+// MaxCloseOutputs is the maximum number of additional close outputs that can
+// be requested in a Shutdown message.
+const MaxCloseOutputs = 16
+
 type (
 	// ShutdownNonceType is the type of the shutdown nonce TLV record.
 	ShutdownNonceType = tlv.TlvType8
 
 	// ShutdownNonceTLV is the TLV record that contains the shutdown nonce.
 	ShutdownNonceTLV = tlv.OptionalRecordT[ShutdownNonceType, Musig2Nonce]
+
+	// CloseOutputsType is the type of the close outputs TLV record.
+	CloseOutputsType = tlv.TlvType9
+
+	// CloseOutputsTLV is the TLV record that contains the additional
+	// close outputs of the sender.
+	CloseOutputsTLV = tlv.OptionalRecordT[CloseOutputsType, CloseOutputs]
 )
 
 // SomeShutdownNonce returns a ShutdownNonceTLV with the given nonce.
@@ -22,6 +36,122 @@ func SomeShutdownNonce(nonce Musig2Nonce) ShutdownNonceTLV {
 	)
 }
 
+// SomeCloseOutputs returns a CloseOutputsTLV with the given outputs.
+func SomeCloseOutputs(outputs CloseOutputs) CloseOutputsTLV {
+	return tlv.SomeRecordT(
+		tlv.NewRecordT[CloseOutputsType, CloseOutputs](outputs),
+	)
+}
+
+// CloseOutputs is a list of outputs that the sender of a Shutdown message
+// wants to be paid from its settled balance in addition to its delivery
+// address. Each output has a fixed value, the remainder of the settled balance
+// is paid to the delivery address.
+type CloseOutputs []*wire.TxOut
+
+// Record returns a TLV record that can be used to encode/decode the close
+// outputs.
+func (c *CloseOutputs) Record() tlv.Record {
+	return tlv.MakeDynamicRecord(
+		0, c, c.encodedSize, closeOutputsEncoder, closeOutputsDecoder,
+	)
+}
+
+// encodedSize returns the number of bytes the encoded close outputs take up.
+func (c *CloseOutputs) encodedSize() uint64 {
+	size := tlv.VarIntSize(uint64(len(*c)))
+	for _, txOut := range *c {
+		scriptLen := uint64(len(txOut.PkScript))
+		size += 8 + tlv.VarIntSize(scriptLen) + scriptLen
+	}
+
+	return size
+}
+
+// closeOutputsEncoder is a custom TLV encoder for the CloseOutputs type.
+func closeOutputsEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
+	v, ok := val.(*CloseOutputs)
+	if !ok {
+		return tlv.NewTypeForEncodingErr(val, "lnwire.CloseOutputs")
+	}
+
+	if err := tlv.WriteVarInt(w, uint64(len(*v)), buf); err != nil {
+		return err
+	}
+
+	for _, txOut := range *v {
+		binary.BigEndian.PutUint64(buf[:], uint64(txOut.Value))
+		if _, err := w.Write(buf[:]); err != nil {
+			return err
+		}
+
+		scriptLen := uint64(len(txOut.PkScript))
+		if err := tlv.WriteVarInt(w, scriptLen, buf); err != nil {
+			return err
+		}
+		if _, err := w.Write(txOut.PkScript); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// closeOutputsDecoder is a custom TLV decoder for the CloseOutputs type.
+func closeOutputsDecoder(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	v, ok := val.(*CloseOutputs)
+	if !ok {
+		return tlv.NewTypeForDecodingErr(
+			val, "lnwire.CloseOutputs", l, l,
+		)
+	}
+
+	// Limit the reader to the length of the record, so a malformed length
+	// can't make us read beyond it.
+	lr := io.LimitReader(r, int64(l))
+
+	numOutputs, err := tlv.ReadVarInt(lr, buf)
+	if err != nil {
+		return err
+	}
+	if numOutputs > MaxCloseOutputs {
+		return fmt.Errorf("too many close outputs: %v", numOutputs)
+	}
+
+	outputs := make(CloseOutputs, 0, numOutputs)
+	for i := uint64(0); i < numOutputs; i++ {
+		if _, err := io.ReadFull(lr, buf[:]); err != nil {
+			return err
+		}
+		value := binary.BigEndian.Uint64(buf[:])
+
+		scriptLen, err := tlv.ReadVarInt(lr, buf)
+		if err != nil {
+			return err
+		}
+		if scriptLen > deliveryAddressMaxSize {
+			return fmt.Errorf("close output script too long: %v",
+				scriptLen)
+		}
+
+		pkScript := make([]byte, scriptLen)
+		if _, err := io.ReadFull(lr, pkScript); err != nil {
+			return err
+		}
+
+		outputs = append(outputs, &wire.TxOut{
+			Value:    int64(value),
+			PkScript: pkScript,
+		})
+	}
+
+	*v = outputs
+
+	return nil
+}
+
 // Shutdown is sent by either side in order to initiate the cooperative closure
 // of a channel. This message is sparse as both sides implicitly have the
 // information necessary to construct a transaction that will send the settled
@@ -38,6 +168,11 @@ type Shutdown struct {
 	// co-op sign offer.
 	ShutdownNonce ShutdownNonceTLV
 
+	// CloseOutputs are the outputs, in addition to Address, that the
+	// sender's settled balance should be split across. It may only be set
+	// if both peers signal the split close outputs feature.
+	CloseOutputs CloseOutputsTLV
+
 	// ExtraData is the set of data that was appended to this message to
 	// fill out the full maximum transport message size. These fields can
 	// be used to specify optional data such as custom TLV fields.
@@ -72,7 +207,8 @@ func (s *Shutdown) Decode(r io.Reader, pver uint32) error {
 	}
 
 	musigNonce := s.ShutdownNonce.Zero()
-	typeMap, err := tlvRecords.ExtractRecords(&musigNonce)
+	closeOutputs := s.CloseOutputs.Zero()
+	typeMap, err := tlvRecords.ExtractRecords(&musigNonce, &closeOutputs)
 	if err != nil {
 		return err
 	}
@@ -81,6 +217,9 @@ func (s *Shutdown) Decode(r io.Reader, pver uint32) error {
 	if val, ok := typeMap[s.ShutdownNonce.TlvType()]; ok && val == nil {
 		s.ShutdownNonce = tlv.SomeRecordT(musigNonce)
 	}
+	if val, ok := typeMap[s.CloseOutputs.TlvType()]; ok && val == nil {
+		s.CloseOutputs = tlv.SomeRecordT(closeOutputs)
+	}
 
 	if len(tlvRecords) != 0 {
 		s.ExtraData = tlvRecords
@@ -94,12 +233,17 @@ func (s *Shutdown) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (s *Shutdown) Encode(w *bytes.Buffer, pver uint32) error {
-	recordProducers := make([]tlv.RecordProducer, 0, 1)
+	recordProducers := make([]tlv.RecordProducer, 0, 2)
 	s.ShutdownNonce.WhenSome(
 		func(nonce tlv.RecordT[ShutdownNonceType, Musig2Nonce]) {
 			recordProducers = append(recordProducers, &nonce)
 		},
 	)
+	s.CloseOutputs.WhenSome(
+		func(outputs tlv.RecordT[CloseOutputsType, CloseOutputs]) {
+			recordProducers = append(recordProducers, &outputs)
+		},
+	)
 	err := EncodeMessageExtraData(&s.ExtraData, recordProducers...)
 	if err != nil {
 		return err
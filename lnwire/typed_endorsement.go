@@ -0,0 +1,75 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	// ExperimentalEndorsementType is the type of the experimental record
+	// used to signal the endorsement of an HTLC in update_add_htlc.
+	ExperimentalEndorsementType tlv.Type = 106823
+)
+
+// Endorsement signals whether the sender of an HTLC vouches for it being
+// resolved in a timely manner. Nodes use the signal to decide whether the
+// HTLC may make use of the protected resources of their channels.
+type Endorsement uint8
+
+const (
+	// ExperimentalUnendorsed signals that the HTLC is not endorsed.
+	ExperimentalUnendorsed Endorsement = 0
+
+	// ExperimentalEndorsed signals that the HTLC is endorsed.
+	ExperimentalEndorsed Endorsement = 1
+)
+
+// IsEndorsed returns true if the endorsement is set and signals an endorsed
+// HTLC.
+func (e *Endorsement) IsEndorsed() bool {
+	return e != nil && *e == ExperimentalEndorsed
+}
+
+// String returns a human-readable description of the endorsement signal.
+func (e Endorsement) String() string {
+	switch e {
+	case ExperimentalUnendorsed:
+		return "unendorsed"
+
+	case ExperimentalEndorsed:
+		return "endorsed"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Record returns a TLV record that can be used to encode/decode the
+// Endorsement type from a given TLV stream.
+func (e *Endorsement) Record() tlv.Record {
+	return tlv.MakeStaticRecord(
+		ExperimentalEndorsementType, e, 1, endorsementEncoder,
+		endorsementDecoder,
+	)
+}
+
+// endorsementEncoder is a custom TLV encoder for the Endorsement record.
+func endorsementEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*Endorsement); ok {
+		return tlv.EUint8T(w, uint8(*v), buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "lnwire.Endorsement")
+}
+
+// endorsementDecoder is a custom TLV decoder for the Endorsement record.
+func endorsementDecoder(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	if v, ok := val.(*Endorsement); ok {
+		return tlv.DUint8(r, (*uint8)(v), buf, l)
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "lnwire.Endorsement", l, 1)
+}
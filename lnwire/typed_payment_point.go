@@ -0,0 +1,57 @@
+package lnwire
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	// ExperimentalPaymentPointType is the type of the experimental record
+	// used to lock an HTLC to a payment point in update_add_htlc. The type
+	// is even so that peers that don't understand point time locked
+	// contracts reject the update instead of silently treating it as a
+	// regular HTLC.
+	ExperimentalPaymentPointType tlv.Type = 106826
+)
+
+// PaymentPoint is the point an experimental point time locked contract (PTLC)
+// is locked to. The HTLC can only be settled by revealing the discrete
+// logarithm of the point, which replaces the preimage of a regular HTLC as the
+// proof of payment.
+type PaymentPoint struct {
+	// Point is the public key whose private key settles the PTLC.
+	Point *btcec.PublicKey
+}
+
+// NewPaymentPoint returns a new payment point record for the given point.
+func NewPaymentPoint(point *btcec.PublicKey) *PaymentPoint {
+	return &PaymentPoint{Point: point}
+}
+
+// IsPaidBy returns true if the given scalar is the discrete logarithm of the
+// payment point.
+func (p *PaymentPoint) IsPaidBy(secret [32]byte) bool {
+	if p == nil || p.Point == nil {
+		return false
+	}
+
+	var s btcec.ModNScalar
+	if overflow := s.SetBytes(&secret); overflow != 0 || s.IsZero() {
+		return false
+	}
+
+	var point btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(&s, &point)
+	point.ToAffine()
+
+	return btcec.NewPublicKey(&point.X, &point.Y).IsEqual(p.Point)
+}
+
+// Record returns a TLV record that can be used to encode/decode the
+// PaymentPoint type from a given TLV stream.
+func (p *PaymentPoint) Record() tlv.Record {
+	return tlv.MakeStaticRecord(
+		ExperimentalPaymentPointType, &p.Point, 33, tlv.EPubKey,
+		tlv.DPubKey,
+	)
+}
@@ -0,0 +1,53 @@
+package lnwire
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaymentPointEncodeDecode tests that we're able to properly encode and
+// decode payment points within TLV streams.
+func TestPaymentPointEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	paymentPoint := NewPaymentPoint(privKey.PubKey())
+
+	var extraData ExtraOpaqueData
+	require.NoError(t, extraData.PackRecords(paymentPoint))
+
+	var paymentPoint2 PaymentPoint
+	tlvs, err := extraData.ExtractRecords(&paymentPoint2)
+	require.NoError(t, err)
+
+	require.Contains(t, tlvs, ExperimentalPaymentPointType)
+	require.True(t, paymentPoint.Point.IsEqual(paymentPoint2.Point))
+}
+
+// TestPaymentPointIsPaidBy tests that only the discrete logarithm of the
+// payment point is accepted as its proof of payment.
+func TestPaymentPointIsPaidBy(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	var secret, otherSecret [32]byte
+	copy(secret[:], privKey.Serialize())
+	copy(otherSecret[:], otherKey.Serialize())
+
+	paymentPoint := NewPaymentPoint(privKey.PubKey())
+	require.True(t, paymentPoint.IsPaidBy(secret))
+	require.False(t, paymentPoint.IsPaidBy(otherSecret))
+	require.False(t, paymentPoint.IsPaidBy([32]byte{}))
+
+	var nilPoint *PaymentPoint
+	require.False(t, nilPoint.IsPaidBy(secret))
+}
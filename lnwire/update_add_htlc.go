@@ -72,6 +72,20 @@ type UpdateAddHTLC struct {
 	// next hop for this htlc.
 	BlindingPoint BlindingPointRecord
 
+	// Endorsement is the optional experimental endorsement signal of the
+	// htlc.
+	Endorsement *Endorsement
+
+	// PaymentPoint is the optional experimental point that turns the htlc
+	// into a point time locked contract. If set, the htlc can only be
+	// settled by revealing the discrete logarithm of the point.
+	PaymentPoint *PaymentPoint
+
+	// CustomRecords is the set of custom records in the custom TLV range
+	// that an overlay protocol attached to the htlc. Records with an even
+	// type are only understood by peers that run the same overlay.
+	CustomRecords CustomRecords
+
 	// ExtraData is the set of data that was appended to this message to
 	// fill out the full maximum transport message size. These fields can
 	// be used to specify optional data such as custom TLV fields.
@@ -104,8 +118,14 @@ func (c *UpdateAddHTLC) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
+	var (
+		endorsement  Endorsement
+		paymentPoint PaymentPoint
+	)
 	blindingRecord := c.BlindingPoint.Zero()
-	tlvMap, err := c.ExtraData.ExtractRecords(&blindingRecord)
+	tlvMap, err := c.ExtraData.ExtractRecords(
+		&blindingRecord, &endorsement, &paymentPoint,
+	)
 	if err != nil {
 		return err
 	}
@@ -114,6 +134,16 @@ func (c *UpdateAddHTLC) Decode(r io.Reader, pver uint32) error {
 		c.BlindingPoint = tlv.SomeRecordT(blindingRecord)
 	}
 
+	if val, ok := tlvMap[ExperimentalEndorsementType]; ok && val == nil {
+		c.Endorsement = &endorsement
+	}
+
+	if val, ok := tlvMap[ExperimentalPaymentPointType]; ok && val == nil {
+		c.PaymentPoint = &paymentPoint
+	}
+
+	c.CustomRecords = NewCustomRecordsFromTlvTypeMap(tlvMap)
+
 	// Set extra data to nil if we didn't parse anything out of it so that
 	// we can use assert.Equal in tests.
 	if len(tlvMap) == 0 {
@@ -152,7 +182,8 @@ func (c *UpdateAddHTLC) Encode(w *bytes.Buffer, pver uint32) error {
 		return err
 	}
 
-	// Only include blinding point in extra data if present.
+	// Only include blinding point, endorsement, payment point and custom
+	// records in extra data if present.
 	var records []tlv.RecordProducer
 
 	c.BlindingPoint.WhenSome(func(b tlv.RecordT[BlindingPointTlvType,
@@ -161,6 +192,19 @@ func (c *UpdateAddHTLC) Encode(w *bytes.Buffer, pver uint32) error {
 		records = append(records, &b)
 	})
 
+	if c.Endorsement != nil {
+		records = append(records, c.Endorsement)
+	}
+
+	if c.PaymentPoint != nil {
+		records = append(records, c.PaymentPoint)
+	}
+
+	if err := c.CustomRecords.Validate(); err != nil {
+		return err
+	}
+	records = append(records, c.CustomRecords.RecordProducers()...)
+
 	err := EncodeMessageExtraData(&c.ExtraData, records...)
 	if err != nil {
 		return err
@@ -248,3 +248,51 @@ func GetCustomCaveatCondition(mac *macaroon.Macaroon,
 	// We didn't find a condition for the given custom caveat name.
 	return ""
 }
+
+// CustomCaveat is a custom caveat of a macaroon.
+type CustomCaveat struct {
+	// Name is the name of the custom caveat.
+	Name string
+
+	// Condition is the condition of the custom caveat, which is empty for
+	// caveats that only have a name (marker caveats).
+	Condition string
+}
+
+// CustomCaveats returns all custom caveats of the given macaroon in the order
+// in which they were added.
+func CustomCaveats(mac *macaroon.Macaroon) []CustomCaveat {
+	if mac == nil {
+		return nil
+	}
+
+	var (
+		caveats []CustomCaveat
+		prefix  = []byte(CondLndCustom + " ")
+	)
+	for _, caveat := range mac.Caveats() {
+		// The caveat id has a format of
+		// "lnd-custom [custom-caveat-name] [custom-caveat-condition]"
+		// where the condition is optional.
+		if !bytes.HasPrefix(caveat.Id, prefix) {
+			continue
+		}
+
+		outerCondition := string(caveat.Id[len(prefix):])
+		parts := strings.SplitN(outerCondition, " ", 2)
+		if parts[0] == "" {
+			continue
+		}
+
+		customCaveat := CustomCaveat{
+			Name: parts[0],
+		}
+		if len(parts) == 2 {
+			customCaveat.Condition = parts[1]
+		}
+
+		caveats = append(caveats, customCaveat)
+	}
+
+	return caveats
+}
@@ -156,4 +156,18 @@ func TestCustomConstraint(t *testing.T) {
 		testMacaroon, "unit-test",
 	)
 	require.Equal(t, customCaveatCondition, "")
+
+	// Multiple custom caveats should be listed with their conditions in
+	// the order they were added, independent of whether they have a
+	// condition or not.
+	constraintFunc = macaroons.CustomConstraint("acme.account", "1234")
+	require.NoError(t, constraintFunc(testMacaroon))
+	require.Equal(
+		t, []macaroons.CustomCaveat{
+			{Name: "unit-test"},
+			{Name: "acme.account", Condition: "1234"},
+		},
+		macaroons.CustomCaveats(testMacaroon),
+	)
+	require.Empty(t, macaroons.CustomCaveats(nil))
 }
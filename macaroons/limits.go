@@ -0,0 +1,679 @@
+package macaroons
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	macaroon "gopkg.in/macaroon.v2"
+)
+
+const (
+	// CondMaxPaymentAmount is the first party caveat condition name that
+	// limits the amount in millisatoshis a single payment made with the
+	// macaroon can spend, including the fee limit of the payment.
+	CondMaxPaymentAmount = "max-payment-msat"
+
+	// CondDailySpendLimit is the first party caveat condition name that
+	// limits the cumulative amount in millisatoshis all payments made
+	// with the macaroon can spend within a rolling window of 24 hours.
+	CondDailySpendLimit = "daily-spend-msat"
+
+	// CondRateLimit is the first party caveat condition name that limits
+	// the number of RPC calls that can be made with the macaroon within a
+	// rolling window of one minute.
+	CondRateLimit = "calls-per-minute"
+
+	// rateLimitWindow is the window the calls-per-minute caveat applies
+	// to.
+	rateLimitWindow = time.Minute
+
+	// spendLimitWindow is the window the daily spend limit caveat applies
+	// to.
+	spendLimitWindow = 24 * time.Hour
+)
+
+var (
+	// ErrRateLimitExceeded is returned if a macaroon was used for more
+	// calls than its calls-per-minute caveat allows.
+	ErrRateLimitExceeded = errors.New("macaroon rate limit exceeded")
+
+	// ErrSpendLimitExceeded is returned if a payment would exceed one of
+	// the spend limits of the macaroon used to make it.
+	ErrSpendLimitExceeded = errors.New("macaroon spend limit exceeded")
+
+	// ErrSpendNotLimited is returned if a macaroon with spend limits is
+	// used for an RPC that can move funds but doesn't enforce the limits.
+	ErrSpendNotLimited = errors.New("macaroon with spend limits cannot " +
+		"be used for RPCs that don't enforce them")
+
+	// spendingOps are the permissions that allow an RPC to move funds out
+	// of the node. A macaroon with spend limits can only be used for RPCs
+	// that require any of these permissions if the RPC was registered to
+	// enforce the limits.
+	spendingOps = []bakery.Op{
+		{Entity: "onchain", Action: "write"},
+		{Entity: "offchain", Action: "write"},
+	}
+)
+
+// MaxPaymentAmountConstraint limits the amount a single payment made with the
+// macaroon can spend, including fees.
+func MaxPaymentAmountConstraint(amtMsat uint64) func(*macaroon.Macaroon) error {
+	return limitConstraint(CondMaxPaymentAmount, amtMsat)
+}
+
+// DailySpendLimitConstraint limits the cumulative amount all payments made
+// with the macaroon can spend within 24 hours, including fees.
+func DailySpendLimitConstraint(amtMsat uint64) func(*macaroon.Macaroon) error {
+	return limitConstraint(CondDailySpendLimit, amtMsat)
+}
+
+// RateLimitConstraint limits the number of RPC calls that can be made with the
+// macaroon within a minute.
+func RateLimitConstraint(callsPerMinute uint32) func(*macaroon.Macaroon) error {
+	return limitConstraint(CondRateLimit, uint64(callsPerMinute))
+}
+
+// limitConstraint returns a constraint that adds a caveat with the given
+// condition name and a numeric limit to a macaroon.
+func limitConstraint(cond string, limit uint64) func(*macaroon.Macaroon) error {
+	return func(mac *macaroon.Macaroon) error {
+		if limit == 0 {
+			return fmt.Errorf("%s limit must be greater than 0",
+				cond)
+		}
+
+		caveat := checkers.Condition(
+			cond, strconv.FormatUint(limit, 10),
+		)
+		return mac.AddFirstPartyCaveat([]byte(caveat))
+	}
+}
+
+// limitChecker returns a Checker for the given limit caveat condition. The
+// checker only makes sure the limit is well formed since the limits depend on
+// state that is tracked and enforced by the Service itself.
+func limitChecker(cond string) Checker {
+	return func() (string, checkers.Func) {
+		return cond, func(_ context.Context, _, arg string) error {
+			_, err := parseLimit(arg)
+			return err
+		}
+	}
+}
+
+// parseLimit parses the numeric argument of a limit caveat.
+func parseLimit(arg string) (uint64, error) {
+	limit, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit %q: %w", arg, err)
+	}
+	if limit == 0 {
+		return 0, fmt.Errorf("invalid limit %q: must be greater than "+
+			"0", arg)
+	}
+
+	return limit, nil
+}
+
+// macaroonLimits holds all limit caveats of a single macaroon. Since a
+// macaroon holder can add more caveats of the same kind, every one of them
+// needs to be enforced.
+type macaroonLimits struct {
+	maxPayment     []uint64
+	dailySpend     []uint64
+	callsPerMinute []uint64
+}
+
+// spendLimited returns true if the macaroon has any spend limit caveats.
+func (l *macaroonLimits) spendLimited() bool {
+	return len(l.maxPayment) > 0 || len(l.dailySpend) > 0
+}
+
+// parseLimits extracts all limit caveats from the given macaroon.
+func parseLimits(mac *macaroon.Macaroon) (*macaroonLimits, error) {
+	limits := &macaroonLimits{}
+	for _, caveat := range mac.Caveats() {
+		// Third party caveats can't be limit caveats.
+		if len(caveat.VerificationId) > 0 {
+			continue
+		}
+
+		cond, arg, err := checkers.ParseCaveat(string(caveat.Id))
+		if err != nil {
+			continue
+		}
+
+		var target *[]uint64
+		switch cond {
+		case CondMaxPaymentAmount:
+			target = &limits.maxPayment
+
+		case CondDailySpendLimit:
+			target = &limits.dailySpend
+
+		case CondRateLimit:
+			target = &limits.callsPerMinute
+
+		default:
+			continue
+		}
+
+		limit, err := parseLimit(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		// The state of a limit is tracked per value, so we only count
+		// duplicate caveats once.
+		if !slices.Contains(*target, limit) {
+			*target = append(*target, limit)
+		}
+	}
+
+	return limits, nil
+}
+
+// limitKey identifies the state of a single limit caveat of a macaroon. The
+// limit itself is part of the key so that a holder cannot reset the state of
+// an existing limit by adding another caveat of the same kind.
+type limitKey struct {
+	macaroonID string
+	limit      uint64
+}
+
+// dbKey returns the key the state of the limit is stored under.
+func (k limitKey) dbKey() []byte {
+	key := make([]byte, len(k.macaroonID)+8)
+	copy(key, k.macaroonID)
+	binary.BigEndian.PutUint64(key[len(k.macaroonID):], k.limit)
+
+	return key
+}
+
+// spendEntry is a single payment that was counted towards a spend limit.
+type spendEntry struct {
+	// id identifies the payment, so that it can be refunded.
+	id uint64
+
+	timestamp time.Time
+	amtMsat   uint64
+}
+
+var (
+	// limitsBucketName is the name of the top level bucket that stores
+	// the state of the limit caveats of all macaroons.
+	limitsBucketName = []byte("maclimits")
+
+	// callsBucketName is the name of the bucket within the limits bucket
+	// that stores the calls counted towards the rate limits, keyed by
+	// the limitKey.
+	callsBucketName = []byte("calls")
+
+	// spendsBucketName is the name of the bucket within the limits bucket
+	// that stores the payments counted towards the daily spend limits,
+	// keyed by the limitKey.
+	spendsBucketName = []byte("spends")
+)
+
+// limiter keeps track of the calls and payments made with limited macaroons.
+// If a database is set, the state is persisted, so that restarting lnd doesn't
+// reset the limits.
+type limiter struct {
+	clock clock.Clock
+
+	// db is the database the state is persisted in. If nil, the state is
+	// only kept in memory.
+	db kvdb.Backend
+
+	mu     sync.Mutex
+	calls  map[limitKey][]time.Time
+	spends map[limitKey][]spendEntry
+
+	// nextSpendID is the ID of the next payment counted towards a spend
+	// limit.
+	nextSpendID uint64
+}
+
+// newLimiter creates a new limiter that uses the given clock and persists its
+// state in the given database, if not nil.
+func newLimiter(clock clock.Clock, db kvdb.Backend) *limiter {
+	return &limiter{
+		clock:  clock,
+		db:     db,
+		calls:  make(map[limitKey][]time.Time),
+		spends: make(map[limitKey][]spendEntry),
+
+		// The IDs only need to be unique among the payments within
+		// the window of the spend limits, which also holds across
+		// restarts if we start with the current time.
+		nextSpendID: uint64(clock.Now().UnixNano()),
+	}
+}
+
+// fetchCalls returns the calls counted towards the given rate limit, loading
+// them from the database if they're not in memory yet.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (l *limiter) fetchCalls(key limitKey) ([]time.Time, error) {
+	if calls, ok := l.calls[key]; ok || l.db == nil {
+		return calls, nil
+	}
+
+	var calls []time.Time
+	err := kvdb.View(l.db, func(tx kvdb.RTx) error {
+		value := readLimitState(tx, callsBucketName, key)
+		if len(value)%8 != 0 {
+			return fmt.Errorf("invalid rate limit state")
+		}
+
+		for i := 0; i < len(value); i += 8 {
+			nanos := binary.BigEndian.Uint64(value[i:])
+			calls = append(calls, time.Unix(0, int64(nanos)))
+		}
+
+		return nil
+	}, func() {
+		calls = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.calls[key] = calls
+
+	return calls, nil
+}
+
+// fetchSpends returns the payments counted towards the given spend limit,
+// loading them from the database if they're not in memory yet.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (l *limiter) fetchSpends(key limitKey) ([]spendEntry, error) {
+	if spends, ok := l.spends[key]; ok || l.db == nil {
+		return spends, nil
+	}
+
+	var spends []spendEntry
+	err := kvdb.View(l.db, func(tx kvdb.RTx) error {
+		value := readLimitState(tx, spendsBucketName, key)
+		if len(value)%24 != 0 {
+			return fmt.Errorf("invalid spend limit state")
+		}
+
+		for i := 0; i < len(value); i += 24 {
+			entry := value[i : i+24]
+			nanos := binary.BigEndian.Uint64(entry[8:])
+			spends = append(spends, spendEntry{
+				id:        binary.BigEndian.Uint64(entry),
+				timestamp: time.Unix(0, int64(nanos)),
+				amtMsat:   binary.BigEndian.Uint64(entry[16:]),
+			})
+		}
+
+		return nil
+	}, func() {
+		spends = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.spends[key] = spends
+
+	return spends, nil
+}
+
+// storeCalls persists the calls counted towards the given rate limits.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (l *limiter) storeCalls(keys []limitKey) error {
+	if l.db == nil {
+		return nil
+	}
+
+	return kvdb.Update(l.db, func(tx kvdb.RwTx) error {
+		for _, key := range keys {
+			calls := l.calls[key]
+			value := make([]byte, 0, len(calls)*8)
+			for _, call := range calls {
+				value = binary.BigEndian.AppendUint64(
+					value, uint64(call.UnixNano()),
+				)
+			}
+
+			err := writeLimitState(tx, callsBucketName, key, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// storeSpends persists the payments counted towards the given spend limits.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (l *limiter) storeSpends(keys []limitKey) error {
+	if l.db == nil {
+		return nil
+	}
+
+	return kvdb.Update(l.db, func(tx kvdb.RwTx) error {
+		for _, key := range keys {
+			spends := l.spends[key]
+			value := make([]byte, 0, len(spends)*24)
+			for _, entry := range spends {
+				nanos := uint64(entry.timestamp.UnixNano())
+				value = binary.BigEndian.AppendUint64(
+					value, entry.id,
+				)
+				value = binary.BigEndian.AppendUint64(
+					value, nanos,
+				)
+				value = binary.BigEndian.AppendUint64(
+					value, entry.amtMsat,
+				)
+			}
+
+			err := writeLimitState(tx, spendsBucketName, key, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// readLimitState returns the stored state of the given limit from the bucket
+// with the given name, or nil if there is none.
+func readLimitState(tx kvdb.RTx, bucketName []byte, key limitKey) []byte {
+	limitsBucket := tx.ReadBucket(limitsBucketName)
+	if limitsBucket == nil {
+		return nil
+	}
+
+	bucket := limitsBucket.NestedReadBucket(bucketName)
+	if bucket == nil {
+		return nil
+	}
+
+	return bucket.Get(key.dbKey())
+}
+
+// writeLimitState stores the state of the given limit in the bucket with the
+// given name. An empty state is deleted, so that limits that weren't used
+// within their window don't take up any space.
+func writeLimitState(tx kvdb.RwTx, bucketName []byte, key limitKey,
+	value []byte) error {
+
+	limitsBucket, err := tx.CreateTopLevelBucket(limitsBucketName)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := limitsBucket.CreateBucketIfNotExists(bucketName)
+	if err != nil {
+		return err
+	}
+
+	if len(value) == 0 {
+		return bucket.Delete(key.dbKey())
+	}
+
+	return bucket.Put(key.dbKey(), value)
+}
+
+// allowCall records a call made with the macaroon with the given ID if none of
+// the given calls-per-minute limits is exceeded.
+func (l *limiter) allowCall(macaroonID string, limits []uint64) error {
+	if len(limits) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	cutoff := now.Add(-rateLimitWindow)
+
+	// Make sure all limits allow the call before we record it anywhere.
+	keys := make([]limitKey, 0, len(limits))
+	for _, limit := range limits {
+		key := limitKey{macaroonID: macaroonID, limit: limit}
+		keys = append(keys, key)
+
+		calls, err := l.fetchCalls(key)
+		if err != nil {
+			return err
+		}
+		for len(calls) > 0 && !calls[0].After(cutoff) {
+			calls = calls[1:]
+		}
+		l.calls[key] = calls
+
+		if uint64(len(calls)) >= limit {
+			return fmt.Errorf("%w: at most %d calls per minute "+
+				"allowed", ErrRateLimitExceeded, limit)
+		}
+	}
+
+	for _, key := range keys {
+		l.calls[key] = append(l.calls[key], now)
+	}
+
+	return l.storeCalls(keys)
+}
+
+// spend records a payment of the given amount made with the macaroon with the
+// given ID if none of the given daily spend limits is exceeded. The returned
+// ID identifies the payment for a refund.
+func (l *limiter) spend(macaroonID string, limits []uint64,
+	amtMsat uint64) (uint64, error) {
+
+	if len(limits) == 0 {
+		return 0, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	cutoff := now.Add(-spendLimitWindow)
+
+	// Make sure all limits allow the payment before we record it
+	// anywhere.
+	keys := make([]limitKey, 0, len(limits))
+	for _, limit := range limits {
+		key := limitKey{macaroonID: macaroonID, limit: limit}
+		keys = append(keys, key)
+
+		spends, err := l.fetchSpends(key)
+		if err != nil {
+			return 0, err
+		}
+
+		var total uint64
+		for len(spends) > 0 && !spends[0].timestamp.After(cutoff) {
+			spends = spends[1:]
+		}
+		for _, entry := range spends {
+			total += entry.amtMsat
+		}
+		l.spends[key] = spends
+
+		if total+amtMsat > limit || total+amtMsat < total {
+			return 0, fmt.Errorf("%w: payment of %d msat exceeds "+
+				"remaining daily limit of %d msat",
+				ErrSpendLimitExceeded, amtMsat, limit-total)
+		}
+	}
+
+	id := l.nextSpendID
+	l.nextSpendID++
+
+	for _, key := range keys {
+		l.spends[key] = append(l.spends[key], spendEntry{
+			id:        id,
+			timestamp: now,
+			amtMsat:   amtMsat,
+		})
+	}
+
+	if err := l.storeSpends(keys); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// refund removes the payment with the given ID from the given daily spend
+// limits of the macaroon with the given ID, so that its amount can be spent
+// again.
+func (l *limiter) refund(macaroonID string, limits []uint64, id uint64) error {
+	if len(limits) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	keys := make([]limitKey, 0, len(limits))
+	for _, limit := range limits {
+		key := limitKey{macaroonID: macaroonID, limit: limit}
+		keys = append(keys, key)
+
+		spends, err := l.fetchSpends(key)
+		if err != nil {
+			return err
+		}
+
+		l.spends[key] = slices.DeleteFunc(
+			slices.Clone(spends), func(entry spendEntry) bool {
+				return entry.id == id
+			},
+		)
+	}
+
+	return l.storeSpends(keys)
+}
+
+// RegisterSpendLimitedMethod marks the given absolute gRPC URI as enforcing
+// the spend limits of macaroons by calling AuthorizeSpend for every payment it
+// makes. Macaroons with spend limits are rejected for all other RPCs that can
+// move funds.
+func (svc *Service) RegisterSpendLimitedMethod(fullMethod string) {
+	svc.spendLimitedMethods[fullMethod] = struct{}{}
+}
+
+// enforceLimits makes sure the given macaroon is allowed to be used for the
+// RPC with the given URI and required permissions with respect to its limit
+// caveats. Every successful call is counted towards the rate limits of the
+// macaroon.
+func (svc *Service) enforceLimits(mac *macaroon.Macaroon,
+	requiredPermissions []bakery.Op, fullMethod string) error {
+
+	limits, err := parseLimits(mac)
+	if err != nil {
+		return err
+	}
+
+	if limits.spendLimited() && requiresSpending(requiredPermissions) {
+		if _, ok := svc.spendLimitedMethods[fullMethod]; !ok {
+			return fmt.Errorf("%w: %s", ErrSpendNotLimited,
+				fullMethod)
+		}
+	}
+
+	macaroonID := hex.EncodeToString(mac.Id())
+	return svc.limiter.allowCall(macaroonID, limits.callsPerMinute)
+}
+
+// AuthorizeSpend checks a payment of the given amount against the spend limits
+// of the macaroon in the given context and counts it towards the daily spend
+// limits. The amount should include the maximum fee the payment is allowed to
+// pay. The returned function refunds the amount to the daily spend limits and
+// must be called if the payment fails. A nil error is returned if the context
+// doesn't contain a macaroon.
+func (svc *Service) AuthorizeSpend(ctx context.Context,
+	amtMsat uint64) (func() error, error) {
+
+	noRefund := func() error { return nil }
+
+	macHex, err := RawMacaroonFromContext(ctx)
+	if err != nil {
+		return noRefund, nil
+	}
+
+	macBytes, err := hex.DecodeString(macHex)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return nil, err
+	}
+
+	limits, err := parseLimits(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, limit := range limits.maxPayment {
+		if amtMsat > limit {
+			return nil, fmt.Errorf("%w: payment of %d msat "+
+				"exceeds limit of %d msat per payment",
+				ErrSpendLimitExceeded, amtMsat, limit)
+		}
+	}
+
+	if len(limits.dailySpend) == 0 {
+		return noRefund, nil
+	}
+
+	macaroonID := hex.EncodeToString(mac.Id())
+	id, err := svc.limiter.spend(macaroonID, limits.dailySpend, amtMsat)
+	if err != nil {
+		return nil, err
+	}
+
+	var refundOnce sync.Once
+	return func() error {
+		var err error
+		refundOnce.Do(func() {
+			err = svc.limiter.refund(
+				macaroonID, limits.dailySpend, id,
+			)
+		})
+
+		return err
+	}, nil
+}
+
+// requiresSpending returns true if any of the given permissions allows moving
+// funds out of the node.
+func requiresSpending(permissions []bakery.Op) bool {
+	for _, op := range permissions {
+		for _, spendingOp := range spendingOps {
+			if op == spendingOp {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package macaroons
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLimiterWindows makes sure the limiter only takes the calls and payments
+// within the rolling window of the respective limit into account.
+func TestLimiterWindows(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1_700_000_000, 0))
+	l := newLimiter(testClock, nil)
+	spend := func(macaroonID string, limits []uint64,
+		amtMsat uint64) error {
+
+		_, err := l.spend(macaroonID, limits, amtMsat)
+		return err
+	}
+
+	// Two calls per minute are allowed, the third one is rejected.
+	require.NoError(t, l.allowCall("mac", []uint64{2}))
+	testClock.SetTime(testClock.Now().Add(30 * time.Second))
+	require.NoError(t, l.allowCall("mac", []uint64{2}))
+	err := l.allowCall("mac", []uint64{2})
+	require.ErrorIs(t, err, ErrRateLimitExceeded)
+
+	// Other macaroons are not affected.
+	require.NoError(t, l.allowCall("other", []uint64{2}))
+
+	// A rejected call must not be counted towards any of the limits, so
+	// a more permissive limit still has room for one more call.
+	err = l.allowCall("mac", []uint64{3, 2})
+	require.ErrorIs(t, err, ErrRateLimitExceeded)
+
+	// Once the first call left the window, a new one is allowed again.
+	testClock.SetTime(testClock.Now().Add(31 * time.Second))
+	require.NoError(t, l.allowCall("mac", []uint64{2}))
+	err = l.allowCall("mac", []uint64{2})
+	require.ErrorIs(t, err, ErrRateLimitExceeded)
+
+	// The daily spend limit is cumulative.
+	require.NoError(t, spend("mac", []uint64{1000}, 600))
+	testClock.SetTime(testClock.Now().Add(12 * time.Hour))
+	require.ErrorIs(t, spend("mac", []uint64{1000}, 401),
+		ErrSpendLimitExceeded)
+	require.NoError(t, spend("mac", []uint64{1000}, 400))
+	require.ErrorIs(t, spend("mac", []uint64{1000}, 1),
+		ErrSpendLimitExceeded)
+
+	// After the first payment left the window, its amount is available
+	// again.
+	testClock.SetTime(testClock.Now().Add(12 * time.Hour))
+	require.NoError(t, spend("mac", []uint64{1000}, 600))
+	require.ErrorIs(t, spend("mac", []uint64{1000}, 1),
+		ErrSpendLimitExceeded)
+
+	// An amount that would overflow the total must be rejected.
+	require.ErrorIs(t, spend("new", []uint64{1000}, ^uint64(0)),
+		ErrSpendLimitExceeded)
+}
+
+// TestLimiterPersistence makes sure the calls and payments counted towards the
+// limits, as well as refunds, survive a restart of the limiter.
+func TestLimiterPersistence(t *testing.T) {
+	t.Parallel()
+
+	db, err := kvdb.Create(
+		kvdb.BoltBackendName, filepath.Join(t.TempDir(), "limits.db"),
+		true, kvdb.DefaultDBTimeout,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	testClock := clock.NewTestClock(time.Unix(1_700_000_000, 0))
+	l := newLimiter(testClock, db)
+
+	require.NoError(t, l.allowCall("mac", []uint64{1}))
+	_, err = l.spend("mac", []uint64{1000}, 600)
+	require.NoError(t, err)
+	id, err := l.spend("mac", []uint64{1000}, 400)
+	require.NoError(t, err)
+	require.NoError(t, l.refund("mac", []uint64{1000}, id))
+
+	// A new limiter using the same database continues where the previous
+	// one left off.
+	l = newLimiter(testClock, db)
+	err = l.allowCall("mac", []uint64{1})
+	require.ErrorIs(t, err, ErrRateLimitExceeded)
+	_, err = l.spend("mac", []uint64{1000}, 401)
+	require.ErrorIs(t, err, ErrSpendLimitExceeded)
+	_, err = l.spend("mac", []uint64{1000}, 400)
+	require.NoError(t, err)
+
+	// Once the calls and payments left their windows, they no longer count
+	// towards the limits.
+	testClock.SetTime(testClock.Now().Add(spendLimitWindow))
+	require.NoError(t, l.allowCall("mac", []uint64{1}))
+	_, err = l.spend("mac", []uint64{1000}, 1000)
+	require.NoError(t, err)
+}
@@ -0,0 +1,344 @@
+package macaroons
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// rootKeyVersionBucketName is the name of the bucket that stores the
+	// active version of each root key ID that was rotated at least once.
+	rootKeyVersionBucketName = []byte("macrootkeyversions")
+
+	// rootKeyVersionSeparator separates the root key ID from the version
+	// in the database key of a rotated root key. Version 0 of a root key
+	// is stored under the plain root key ID to stay compatible with
+	// macaroons that were baked before root keys could be rotated.
+	rootKeyVersionSeparator = []byte("/v")
+
+	// ErrRootKeyNotFound is returned if a root key ID that should be
+	// rotated doesn't exist.
+	ErrRootKeyNotFound = errors.New("root key not found")
+
+	// ErrActiveVersionInvalidation is returned if the active version of a
+	// root key is requested to be invalidated.
+	ErrActiveVersionInvalidation = errors.New("cannot invalidate the " +
+		"active root key version")
+
+	// ErrRotationUnsupported is returned if the root key store of the
+	// service doesn't support versioned root keys.
+	ErrRotationUnsupported = errors.New("root key store doesn't support " +
+		"root key rotation")
+)
+
+// VersionedRootKeyStore is an interface for root key stores that keep multiple
+// versions of a root key. New macaroons are always baked with the active
+// version while macaroons baked with previous versions stay valid until their
+// version is invalidated.
+type VersionedRootKeyStore interface {
+	// RotateRootKey creates a new version of the root key with the given
+	// ID and makes it the active version. The new version is returned.
+	RotateRootKey(rootKeyID []byte) (uint32, error)
+
+	// InvalidateRootKeyVersions removes all versions of the root key with
+	// the given ID that are older than the given version, which makes all
+	// macaroons baked with them invalid. The removed versions are
+	// returned.
+	InvalidateRootKeyVersions(rootKeyID []byte,
+		beforeVersion uint32) ([]uint32, error)
+
+	// RootKeyVersions returns the active version and all valid versions of
+	// the root key with the given ID.
+	RootKeyVersions(rootKeyID []byte) (uint32, []uint32, error)
+}
+
+// A compile time check to ensure RootKeyStorage implements the
+// VersionedRootKeyStore interface.
+var _ VersionedRootKeyStore = (*RootKeyStorage)(nil)
+
+// versionedRootKeyID returns the database key of the given version of a root
+// key.
+func versionedRootKeyID(rootKeyID []byte, version uint32) []byte {
+	if version == 0 {
+		return rootKeyID
+	}
+
+	id := make([]byte, 0, len(rootKeyID)+len(rootKeyVersionSeparator)+10)
+	id = append(id, rootKeyID...)
+	id = append(id, rootKeyVersionSeparator...)
+
+	return strconv.AppendUint(id, uint64(version), 10)
+}
+
+// parseVersionedRootKeyID splits the given database key into the root key ID
+// and the version of the root key.
+func parseVersionedRootKeyID(dbKey []byte) ([]byte, uint32) {
+	idx := bytes.LastIndex(dbKey, rootKeyVersionSeparator)
+	if idx < 0 {
+		return dbKey, 0
+	}
+
+	versionStr := string(dbKey[idx+len(rootKeyVersionSeparator):])
+	version, err := strconv.ParseUint(versionStr, 10, 32)
+	if err != nil || version == 0 {
+		return dbKey, 0
+	}
+
+	return dbKey[:idx], uint32(version)
+}
+
+// activeRootKeyVersion returns the active version of the root key with the
+// given ID. Root keys that were never rotated are at version 0.
+func activeRootKeyVersion(tx kvdb.RTx, rootKeyID []byte) uint32 {
+	bucket := tx.ReadBucket(rootKeyVersionBucketName)
+	if bucket == nil {
+		return 0
+	}
+
+	version := bucket.Get(rootKeyID)
+	if len(version) != 4 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(version)
+}
+
+// rootKeyVersions returns all versions of the root key with the given ID that
+// are stored in the given bucket, sorted in ascending order.
+func rootKeyVersions(bucket walletdb.ReadBucket,
+	rootKeyID []byte) ([]uint32, error) {
+
+	var versions []uint32
+	err := bucket.ForEach(func(k, _ []byte) error {
+		id, version := parseVersionedRootKeyID(k)
+		if bytes.Equal(id, rootKeyID) {
+			versions = append(versions, version)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+
+	return versions, nil
+}
+
+// RotateRootKey creates a new version of the root key with the given ID and
+// makes it the active version. The new version is returned.
+//
+// NOTE: This is part of the VersionedRootKeyStore interface.
+func (r *RootKeyStorage) RotateRootKey(rootKeyID []byte) (uint32, error) {
+	r.encKeyMtx.RLock()
+	defer r.encKeyMtx.RUnlock()
+
+	if r.encKey == nil {
+		return 0, ErrStoreLocked
+	}
+
+	if len(rootKeyID) == 0 {
+		return 0, ErrMissingRootKeyID
+	}
+	if bytes.Equal(rootKeyID, encryptionKeyID) {
+		return 0, ErrKeyValueForbidden
+	}
+
+	var newVersion uint32
+	err := kvdb.Update(r.Backend, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(rootKeyBucketName)
+		if bucket == nil {
+			return ErrRootKeyBucketNotFound
+		}
+
+		// We only rotate root keys that are in use. Otherwise a typo
+		// in the ID would silently create a new root key.
+		version := activeRootKeyVersion(tx, rootKeyID)
+		if bucket.Get(versionedRootKeyID(rootKeyID, version)) == nil {
+			return fmt.Errorf("%w: %s", ErrRootKeyNotFound,
+				rootKeyID)
+		}
+
+		newVersion = version + 1
+		_, err := generateAndStoreNewRootKey(
+			bucket, versionedRootKeyID(rootKeyID, newVersion),
+			r.encKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		versionBucket, err := tx.CreateTopLevelBucket(
+			rootKeyVersionBucketName,
+		)
+		if err != nil {
+			return err
+		}
+
+		var versionBytes [4]byte
+		binary.BigEndian.PutUint32(versionBytes[:], newVersion)
+
+		return versionBucket.Put(rootKeyID, versionBytes[:])
+	}, func() {
+		newVersion = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return newVersion, nil
+}
+
+// InvalidateRootKeyVersions removes all versions of the root key with the
+// given ID that are older than the given version, which makes all macaroons
+// baked with them invalid. The removed versions are returned.
+//
+// NOTE: This is part of the VersionedRootKeyStore interface.
+func (r *RootKeyStorage) InvalidateRootKeyVersions(rootKeyID []byte,
+	beforeVersion uint32) ([]uint32, error) {
+
+	r.encKeyMtx.RLock()
+	defer r.encKeyMtx.RUnlock()
+
+	if r.encKey == nil {
+		return nil, ErrStoreLocked
+	}
+
+	if len(rootKeyID) == 0 {
+		return nil, ErrMissingRootKeyID
+	}
+
+	var invalidated []uint32
+	err := kvdb.Update(r.Backend, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(rootKeyBucketName)
+		if bucket == nil {
+			return ErrRootKeyBucketNotFound
+		}
+
+		// New macaroons are baked with the active version, so it must
+		// always stay valid.
+		activeVersion := activeRootKeyVersion(tx, rootKeyID)
+		if beforeVersion > activeVersion {
+			return fmt.Errorf("%w: active version is %d",
+				ErrActiveVersionInvalidation, activeVersion)
+		}
+
+		versions, err := rootKeyVersions(bucket, rootKeyID)
+		if err != nil {
+			return err
+		}
+
+		for _, version := range versions {
+			if version >= beforeVersion {
+				continue
+			}
+
+			err := bucket.Delete(
+				versionedRootKeyID(rootKeyID, version),
+			)
+			if err != nil {
+				return err
+			}
+
+			invalidated = append(invalidated, version)
+		}
+
+		return nil
+	}, func() {
+		invalidated = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invalidated, nil
+}
+
+// RootKeyVersions returns the active version and all valid versions of the
+// root key with the given ID.
+//
+// NOTE: This is part of the VersionedRootKeyStore interface.
+func (r *RootKeyStorage) RootKeyVersions(rootKeyID []byte) (uint32, []uint32,
+	error) {
+
+	r.encKeyMtx.RLock()
+	defer r.encKeyMtx.RUnlock()
+
+	if r.encKey == nil {
+		return 0, nil, ErrStoreLocked
+	}
+
+	var (
+		activeVersion uint32
+		versions      []uint32
+	)
+	err := kvdb.View(r.Backend, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(rootKeyBucketName)
+		if bucket == nil {
+			return ErrRootKeyBucketNotFound
+		}
+
+		activeVersion = activeRootKeyVersion(tx, rootKeyID)
+
+		var err error
+		versions, err = rootKeyVersions(bucket, rootKeyID)
+
+		return err
+	}, func() {
+		activeVersion = 0
+		versions = nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return activeVersion, versions, nil
+}
+
+// RotateRootKey creates a new version of the root key with the given ID that
+// is used for all macaroons baked with that ID from now on. Macaroons baked
+// with previous versions stay valid until they are invalidated.
+func (svc *Service) RotateRootKey(rootKeyID []byte) (uint32, error) {
+	store, ok := svc.rks.(VersionedRootKeyStore)
+	if !ok {
+		return 0, ErrRotationUnsupported
+	}
+
+	return store.RotateRootKey(rootKeyID)
+}
+
+// InvalidateRootKeyVersions invalidates all macaroons baked with a version of
+// the root key with the given ID that is older than the given version.
+func (svc *Service) InvalidateRootKeyVersions(rootKeyID []byte,
+	beforeVersion uint32) ([]uint32, error) {
+
+	store, ok := svc.rks.(VersionedRootKeyStore)
+	if !ok {
+		return nil, ErrRotationUnsupported
+	}
+
+	return store.InvalidateRootKeyVersions(rootKeyID, beforeVersion)
+}
+
+// RootKeyVersions returns the active version and all valid versions of the
+// root key with the given ID.
+func (svc *Service) RootKeyVersions(rootKeyID []byte) (uint32, []uint32,
+	error) {
+
+	store, ok := svc.rks.(VersionedRootKeyStore)
+	if !ok {
+		return 0, nil, ErrRotationUnsupported
+	}
+
+	return store.RootKeyVersions(rootKeyID)
+}
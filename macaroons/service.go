@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/kvdb"
 	"google.golang.org/grpc/metadata"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
@@ -98,6 +100,14 @@ type Service struct {
 	// StatelessInit denotes if the service was initialized in the stateless
 	// mode where no macaroon files should be created on disk.
 	StatelessInit bool
+
+	// spendLimitedMethods is the set of absolute gRPC URIs that enforce
+	// the spend limits of macaroons for every payment they make.
+	spendLimitedMethods map[string]struct{}
+
+	// limiter tracks the calls and payments made with macaroons that have
+	// limit caveats.
+	limiter *limiter
 }
 
 // NewService returns a service backed by the macaroon DB backend. The `checks`
@@ -105,8 +115,9 @@ type Service struct {
 // or a custom checker if desired. This constructor prevents double-registration
 // of checkers to prevent panics, so listing the same checker more than once is
 // not harmful. Default checkers, such as those for `allow`, `time-before`,
-// `declared`, and `error` caveats are registered automatically and don't need
-// to be added.
+// `declared`, and `error` caveats, as well as those for the limit caveats that
+// are enforced by the service itself, are registered automatically and don't
+// need to be added.
 func NewService(keyStore bakery.RootKeyStore, location string,
 	statelessInit bool, checks ...Checker) (*Service, error) {
 
@@ -124,6 +135,10 @@ func NewService(keyStore bakery.RootKeyStore, location string,
 	// Register all custom caveat checkers with the bakery's checker.
 	// TODO(aakselrod): Add more checks as required.
 	checker := svc.Checker.FirstPartyCaveatChecker.(*checkers.Checker)
+	checks = append(
+		checks, limitChecker(CondMaxPaymentAmount),
+		limitChecker(CondDailySpendLimit), limitChecker(CondRateLimit),
+	)
 	for _, check := range checks {
 		cond, fun := check()
 		if !isRegistered(checker, cond) {
@@ -131,11 +146,21 @@ func NewService(keyStore bakery.RootKeyStore, location string,
 		}
 	}
 
+	// The state of the limit caveats is persisted in the same database
+	// as the root keys, if the store is backed by one.
+	var limitsDB kvdb.Backend
+	if store, ok := keyStore.(*RootKeyStorage); ok {
+		limitsDB = store.Backend
+	}
+	limiter := newLimiter(clock.NewDefaultClock(), limitsDB)
+
 	return &Service{
-		Bakery:             *svc,
-		rks:                keyStore,
-		ExternalValidators: make(map[string]MacaroonValidator),
-		StatelessInit:      statelessInit,
+		Bakery:              *svc,
+		rks:                 keyStore,
+		ExternalValidators:  make(map[string]MacaroonValidator),
+		StatelessInit:       statelessInit,
+		spendLimitedMethods: make(map[string]struct{}),
+		limiter:             limiter,
 	}, nil
 }
 
@@ -237,10 +262,10 @@ func (svc *Service) CheckMacAuth(ctx context.Context, macBytes []byte,
 	authChecker := svc.Checker.Auth(macaroon.Slice{mac})
 	_, err = authChecker.Allow(ctx, requiredPermissions...)
 
-	// If the macaroon contains broad permissions and checks out, we're
-	// done.
+	// If the macaroon contains broad permissions and checks out, we only
+	// need to enforce its limits.
 	if err == nil {
-		return nil
+		return svc.enforceLimits(mac, requiredPermissions, fullMethod)
 	}
 
 	// To also allow the special permission of "uri:<FullMethod>" to be a
@@ -250,7 +275,11 @@ func (svc *Service) CheckMacAuth(ctx context.Context, macBytes []byte,
 		Entity: PermissionEntityCustomURI,
 		Action: fullMethod,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	return svc.enforceLimits(mac, requiredPermissions, fullMethod)
 }
 
 // Close closes the database that underlies the RootKeyStore and zeroes the
@@ -360,3 +360,114 @@ func TestMacaroonVersionDecode(t *testing.T) {
 		require.ErrorIs(t, err, macaroons.ErrInvalidID)
 	})
 }
+
+// TestMacaroonLimits tests that the spend and rate limit caveats of a macaroon
+// are enforced by the service.
+func TestMacaroonLimits(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestRootKeyStorage(t)
+	rootKeyStore, err := macaroons.NewRootKeyStorage(db)
+	require.NoError(t, err)
+	service, err := macaroons.NewService(rootKeyStore, "lnd", false)
+	require.NoError(t, err, "Error creating new service")
+	defer service.Close()
+
+	err = service.CreateUnlock(&defaultPw)
+	require.NoError(t, err, "Error unlocking root key storage")
+
+	const (
+		payMethod   = "/lnrpc.Lightning/SendPaymentSync"
+		coinsMethod = "/lnrpc.Lightning/SendCoins"
+		infoMethod  = "/lnrpc.Lightning/GetInfo"
+	)
+	service.RegisterSpendLimitedMethod(payMethod)
+
+	offchainWrite := bakery.Op{Entity: "offchain", Action: "write"}
+	onchainWrite := bakery.Op{Entity: "onchain", Action: "write"}
+	infoRead := bakery.Op{Entity: "info", Action: "read"}
+
+	mac, err := service.NewMacaroon(
+		context.TODO(), macaroons.DefaultRootKeyID, offchainWrite,
+		onchainWrite, infoRead,
+	)
+	require.NoError(t, err)
+
+	limitedMac, err := macaroons.AddConstraints(
+		mac.M(), macaroons.MaxPaymentAmountConstraint(1000),
+		macaroons.DailySpendLimitConstraint(1500),
+		macaroons.RateLimitConstraint(3),
+	)
+	require.NoError(t, err)
+	macBytes, err := limitedMac.MarshalBinary()
+	require.NoError(t, err)
+
+	md := metadata.New(map[string]string{
+		"macaroon": hex.EncodeToString(macBytes),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	// The macaroon can be used for RPCs that don't move funds and for
+	// the payment RPC that enforces the limits, but not for any other
+	// RPC that can move funds.
+	err = service.ValidateMacaroon(ctx, []bakery.Op{infoRead}, infoMethod)
+	require.NoError(t, err)
+	err = service.ValidateMacaroon(
+		ctx, []bakery.Op{offchainWrite}, payMethod,
+	)
+	require.NoError(t, err)
+	err = service.ValidateMacaroon(
+		ctx, []bakery.Op{onchainWrite}, coinsMethod,
+	)
+	require.ErrorIs(t, err, macaroons.ErrSpendNotLimited)
+
+	// A single payment can't exceed the per payment limit and all
+	// payments together can't exceed the daily limit.
+	_, err = service.AuthorizeSpend(ctx, 1001)
+	require.ErrorIs(t, err, macaroons.ErrSpendLimitExceeded)
+	_, err = service.AuthorizeSpend(ctx, 1000)
+	require.NoError(t, err)
+	_, err = service.AuthorizeSpend(ctx, 501)
+	require.ErrorIs(t, err, macaroons.ErrSpendLimitExceeded)
+	refund, err := service.AuthorizeSpend(ctx, 500)
+	require.NoError(t, err)
+
+	// Once a failed payment is refunded, its amount can be spent again.
+	// Refunding the same payment twice has no effect.
+	require.NoError(t, refund())
+	require.NoError(t, refund())
+	_, err = service.AuthorizeSpend(ctx, 500)
+	require.NoError(t, err)
+	_, err = service.AuthorizeSpend(ctx, 1)
+	require.ErrorIs(t, err, macaroons.ErrSpendLimitExceeded)
+
+	// The spent amount is persisted, so a new service using the same
+	// database enforces the daily limit as well.
+	restartedService, err := macaroons.NewService(
+		rootKeyStore, "lnd", false,
+	)
+	require.NoError(t, err)
+	_, err = restartedService.AuthorizeSpend(ctx, 1)
+	require.ErrorIs(t, err, macaroons.ErrSpendLimitExceeded)
+
+	// The macaroon was successfully used for two calls so far, so only
+	// one more call is allowed within the minute.
+	err = service.ValidateMacaroon(ctx, []bakery.Op{infoRead}, infoMethod)
+	require.NoError(t, err)
+	err = service.ValidateMacaroon(ctx, []bakery.Op{infoRead}, infoMethod)
+	require.ErrorIs(t, err, macaroons.ErrRateLimitExceeded)
+
+	// A macaroon without limits is not affected.
+	macBytes, err = mac.M().MarshalBinary()
+	require.NoError(t, err)
+	md = metadata.New(map[string]string{
+		"macaroon": hex.EncodeToString(macBytes),
+	})
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+	err = service.ValidateMacaroon(
+		ctx, []bakery.Op{onchainWrite}, coinsMethod,
+	)
+	require.NoError(t, err)
+	_, err = service.AuthorizeSpend(ctx, 1_000_000)
+	require.NoError(t, err)
+}
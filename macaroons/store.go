@@ -202,7 +202,9 @@ func (r *RootKeyStorage) ChangePassword(oldPw, newPw []byte) error {
 				return nil
 			}
 
-			if bytes.Equal(k, DefaultRootKeyID) {
+			// Any version of the default root key will do.
+			id, _ := parseVersionedRootKeyID(k)
+			if bytes.Equal(id, DefaultRootKeyID) {
 				foundDefaultRootKey = true
 			}
 
@@ -306,6 +308,10 @@ func (r *RootKeyStorage) RootKey(ctx context.Context) ([]byte, []byte, error) {
 		if bucket == nil {
 			return ErrRootKeyBucketNotFound
 		}
+
+		// New macaroons are always baked with the active version of
+		// the root key.
+		id = versionedRootKeyID(id, activeRootKeyVersion(tx, id))
 		dbKey := bucket.Get(id)
 
 		// If there's a root key stored in the bucket, decrypt it and
@@ -353,8 +359,12 @@ func (r *RootKeyStorage) GenerateNewRootKey() error {
 		// The default root key should be created even if it does not
 		// yet exist, so we do this separately from the rest of the
 		// root keys.
+		defaultID := versionedRootKeyID(
+			DefaultRootKeyID,
+			activeRootKeyVersion(tx, DefaultRootKeyID),
+		)
 		_, err := generateAndStoreNewRootKey(
-			bucket, DefaultRootKeyID, r.encKey,
+			bucket, defaultID, r.encKey,
 		)
 		if err != nil {
 			return err
@@ -367,7 +377,7 @@ func (r *RootKeyStorage) GenerateNewRootKey() error {
 				return nil
 			}
 
-			if bytes.Equal(k, DefaultRootKeyID) {
+			if bytes.Equal(k, defaultID) {
 				return nil
 			}
 
@@ -402,7 +412,12 @@ func (r *RootKeyStorage) SetRootKey(rootKey []byte) error {
 			return ErrRootKeyBucketNotFound
 		}
 
-		return bucket.Put(DefaultRootKeyID, encryptedKey)
+		defaultID := versionedRootKeyID(
+			DefaultRootKeyID,
+			activeRootKeyVersion(tx, DefaultRootKeyID),
+		)
+
+		return bucket.Put(defaultID, encryptedKey)
 	}, func() {})
 }
 
@@ -460,12 +475,23 @@ func (r *RootKeyStorage) ListMacaroonIDs(_ context.Context) ([][]byte, error) {
 	// root key IDs we want.
 	err := kvdb.View(r.Backend, func(tx kvdb.RTx) error {
 		// appendRootKey is a function closure that appends root key ID
-		// to rootKeySlice.
+		// to rootKeySlice. Multiple versions of a root key are only
+		// listed once.
+		seen := make(map[string]struct{})
 		appendRootKey := func(k, _ []byte) error {
 			// Only append when the key value is not encryptedKeyID.
-			if !bytes.Equal(k, encryptionKeyID) {
-				rootKeySlice = append(rootKeySlice, k)
+			if bytes.Equal(k, encryptionKeyID) {
+				return nil
 			}
+
+			id, _ := parseVersionedRootKeyID(k)
+			if _, ok := seen[string(id)]; ok {
+				return nil
+			}
+			seen[string(id)] = struct{}{}
+
+			rootKeySlice = append(rootKeySlice, id)
+
 			return nil
 		}
 
@@ -509,18 +535,33 @@ func (r *RootKeyStorage) DeleteMacaroonID(
 	err := kvdb.Update(r.Backend, func(tx kvdb.RwTx) error {
 		bucket := tx.ReadWriteBucket(rootKeyBucketName)
 
-		// Check the key can be found. If not, return nil.
-		if bucket.Get(rootKeyID) == nil {
+		// Check the key can be found in any version. If not, return
+		// nil.
+		versions, err := rootKeyVersions(bucket, rootKeyID)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
 			return nil
 		}
 
-		// Once the key is found, we do the deletion.
-		if err := bucket.Delete(rootKeyID); err != nil {
-			return err
+		// Once the key is found, we delete all of its versions.
+		for _, version := range versions {
+			err := bucket.Delete(
+				versionedRootKeyID(rootKeyID, version),
+			)
+			if err != nil {
+				return err
+			}
 		}
 		rootKeyIDDeleted = rootKeyID
 
-		return nil
+		versionBucket := tx.ReadWriteBucket(rootKeyVersionBucketName)
+		if versionBucket == nil {
+			return nil
+		}
+
+		return versionBucket.Delete(rootKeyID)
 	}, func() {
 		rootKeyIDDeleted = nil
 	})
@@ -271,3 +271,102 @@ func TestStoreChangePassword(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, rootKey2, rootKeyDB2)
 }
+
+// TestStoreRotateRootKey tests that a root key can be rotated, that previous
+// versions stay valid until they are invalidated and that the versions are
+// handled correctly by the other store operations.
+func TestStoreRotateRootKey(t *testing.T) {
+	_, store := newTestStore(t)
+
+	// The store must be unlocked to rotate a root key.
+	_, err := store.RotateRootKey(macaroons.DefaultRootKeyID)
+	require.Equal(t, macaroons.ErrStoreLocked, err)
+
+	pw := []byte("weks")
+	err = store.CreateUnlock(&pw)
+	require.NoError(t, err)
+
+	// A root key that was never used can't be rotated.
+	_, err = store.RotateRootKey(macaroons.DefaultRootKeyID)
+	require.ErrorIs(t, err, macaroons.ErrRootKeyNotFound)
+
+	rootKey0, id0, err := store.RootKey(defaultRootKeyIDContext)
+	require.NoError(t, err)
+	require.Equal(t, macaroons.DefaultRootKeyID, id0)
+
+	// After the rotation, new macaroons are baked with a new root key
+	// that has a new ID, while the previous one is still available.
+	version, err := store.RotateRootKey(macaroons.DefaultRootKeyID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, version)
+
+	rootKey1, id1, err := store.RootKey(defaultRootKeyIDContext)
+	require.NoError(t, err)
+	require.Equal(t, []byte("0/v1"), id1)
+	require.NotEqual(t, rootKey0, rootKey1)
+
+	key, err := store.Get(context.Background(), id0)
+	require.NoError(t, err)
+	require.Equal(t, rootKey0, key)
+
+	version, err = store.RotateRootKey(macaroons.DefaultRootKeyID)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, version)
+
+	active, versions, err := store.RootKeyVersions(
+		macaroons.DefaultRootKeyID,
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, active)
+	require.Equal(t, []uint32{0, 1, 2}, versions)
+
+	// Setting the root key replaces the active version.
+	rootKey := make([]byte, 32)
+	_, err = rand.Read(rootKey)
+	require.NoError(t, err)
+	require.NoError(t, store.SetRootKey(rootKey))
+	rootKey2, _, err := store.RootKey(defaultRootKeyIDContext)
+	require.NoError(t, err)
+	require.Equal(t, rootKey, rootKey2)
+
+	// Multiple versions are only listed once.
+	_, _, err = store.RootKey(nonDefaultRootKeyIDContext)
+	require.NoError(t, err)
+	ids, err := store.ListMacaroonIDs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1}, macaroons.DefaultRootKeyID}, ids)
+
+	// The active version can't be invalidated.
+	_, err = store.InvalidateRootKeyVersions(macaroons.DefaultRootKeyID, 3)
+	require.ErrorIs(t, err, macaroons.ErrActiveVersionInvalidation)
+
+	invalidated, err := store.InvalidateRootKeyVersions(
+		macaroons.DefaultRootKeyID, 2,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 1}, invalidated)
+
+	_, err = store.Get(context.Background(), id0)
+	require.Error(t, err)
+	_, err = store.Get(context.Background(), id1)
+	require.Error(t, err)
+
+	// Changing the password still works without version 0 of the default
+	// root key.
+	newPw := []byte("newpassword")
+	require.NoError(t, store.ChangePassword(pw, newPw))
+	rootKeyDB2, _, err := store.RootKey(defaultRootKeyIDContext)
+	require.NoError(t, err)
+	require.Equal(t, rootKey2, rootKeyDB2)
+
+	// Deleting a root key ID removes all of its versions.
+	_, err = store.RotateRootKey([]byte{1})
+	require.NoError(t, err)
+	deleted, err := store.DeleteMacaroonID(context.Background(), []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, deleted)
+
+	_, versions, err = store.RootKeyVersions([]byte{1})
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
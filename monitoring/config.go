@@ -0,0 +1,15 @@
+package monitoring
+
+import (
+	"github.com/lightningnetwork/lnd/subscribe"
+)
+
+// NodeMetricsConfig holds the dependencies of the metrics that are collected
+// from the running node.
+type NodeMetricsConfig struct {
+	// NumPeers returns the number of connected peers.
+	NumPeers func() int
+
+	// SubscribeHtlcEvents subscribes to the HTLC events of the switch.
+	SubscribeHtlcEvents func() (*subscribe.Client, error)
+}
@@ -0,0 +1,35 @@
+//go:build !monitoring
+// +build !monitoring
+
+package monitoring
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+// ObservePathFinding is a no-op as monitoring is disabled.
+func ObservePathFinding(_ time.Duration, _ error) {}
+
+// ObserveSweepPublished is a no-op as monitoring is disabled.
+func ObserveSweepPublished(_ *wire.MsgTx, _ btcutil.Amount,
+	_ chainfee.SatPerKWeight) {
+}
+
+// InstrumentBackend returns the given backend unchanged as monitoring is
+// disabled.
+func InstrumentBackend(_ string, db kvdb.Backend) kvdb.Backend {
+	return db
+}
+
+// StartNodeMetrics is required for lnd to compile so that the node metrics
+// can be hidden behind a build tag.
+func StartNodeMetrics(_ NodeMetricsConfig) (func(), error) {
+	return nil, fmt.Errorf("lnd must be built with the monitoring tag " +
+		"to enable node metrics")
+}
@@ -0,0 +1,316 @@
+//go:build monitoring
+// +build monitoring
+
+package monitoring
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// namespace is the Prometheus namespace of the metrics of lnd.
+	namespace = "lnd"
+)
+
+var (
+	// htlcEvents counts the HTLC events of the switch by the kind of the
+	// event and the type of the HTLC.
+	htlcEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "htlcswitch",
+		Name:      "events_total",
+		Help:      "Number of HTLC events of the switch.",
+	}, []string{"event", "type"})
+
+	// forwardedAmount sums up the outgoing amount of the settled
+	// forwards.
+	forwardedAmount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "htlcswitch",
+		Name:      "forwarded_msat_total",
+		Help:      "Outgoing amount of the settled forwards.",
+	})
+
+	// forwardingFees sums up the fees earned by the settled forwards.
+	forwardingFees = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "htlcswitch",
+		Name:      "forwarding_fees_msat_total",
+		Help:      "Fees earned by the settled forwards.",
+	})
+
+	// pathFindingDuration tracks the latency of path finding for
+	// payments.
+	pathFindingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "routing",
+			Name:      "pathfinding_duration_seconds",
+			Help:      "Latency of path finding for payments.",
+			Buckets: prometheus.ExponentialBuckets(
+				0.001, 2, 15,
+			),
+		}, []string{"result"},
+	)
+
+	// sweepFees tracks the fees of the published sweep transactions.
+	sweepFees = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sweep",
+		Name:      "tx_fee_sat",
+		Help:      "Fee of the published sweep transactions.",
+		Buckets:   prometheus.ExponentialBuckets(100, 2, 15),
+	})
+
+	// sweepFeeRates tracks the fee rates of the published sweep
+	// transactions.
+	sweepFeeRates = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "sweep",
+		Name:      "tx_fee_rate_sat_per_vbyte",
+		Help:      "Fee rate of the published sweep transactions.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	})
+
+	// sweepTxs counts the published sweep transactions.
+	sweepTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sweep",
+		Name:      "txs_published_total",
+		Help:      "Number of published sweep transactions.",
+	})
+
+	// dbTxDuration tracks the latency of the database transactions.
+	dbTxDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "tx_duration_seconds",
+		Help:      "Latency of the database transactions.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	}, []string{"db", "type"})
+
+	// nodeCollectors are the collectors of the node metrics that don't
+	// depend on a running server.
+	nodeCollectors = []prometheus.Collector{
+		htlcEvents, forwardedAmount, forwardingFees,
+		pathFindingDuration, sweepFees, sweepFeeRates, sweepTxs,
+		dbTxDuration,
+	}
+)
+
+// ObservePathFinding records the duration of a path finding attempt for a
+// payment.
+func ObservePathFinding(duration time.Duration, err error) {
+	result := "found"
+	if err != nil {
+		result = "not_found"
+	}
+
+	pathFindingDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// ObserveSweepPublished records the fee of a published sweep transaction.
+func ObserveSweepPublished(_ *wire.MsgTx, fee btcutil.Amount,
+	feeRate chainfee.SatPerKWeight) {
+
+	sweepTxs.Inc()
+	sweepFees.Observe(float64(fee))
+	sweepFeeRates.Observe(float64(feeRate.FeePerVByte()))
+}
+
+// InstrumentBackend returns a database backend that records the latency of
+// the transactions of the given backend under the given name.
+func InstrumentBackend(name string, db kvdb.Backend) kvdb.Backend {
+	instrumented := &instrumentedDB{
+		Backend: db,
+		name:    name,
+	}
+
+	// We make sure not to hide the batching support of the backend, as
+	// kvdb.Batch falls back to a plain update without it.
+	if _, ok := db.(walletdb.BatchDB); ok {
+		return &instrumentedBatchDB{instrumentedDB: instrumented}
+	}
+
+	return instrumented
+}
+
+// instrumentedDB is a database backend that records the latency of its
+// managed transactions.
+type instrumentedDB struct {
+	kvdb.Backend
+
+	name string
+}
+
+// observe records the duration of a transaction of the given type that
+// started at the given time.
+func (d *instrumentedDB) observe(txType string, start time.Time) {
+	dbTxDuration.WithLabelValues(d.name, txType).Observe(
+		time.Since(start).Seconds(),
+	)
+}
+
+// View opens a database read transaction and executes the function f with the
+// transaction passed as a parameter.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (d *instrumentedDB) View(f func(tx walletdb.ReadTx) error,
+	reset func()) error {
+
+	defer d.observe("read", time.Now())
+
+	return d.Backend.View(f, reset)
+}
+
+// Update opens a database read/write transaction and executes the function f
+// with the transaction passed as a parameter.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (d *instrumentedDB) Update(f func(tx walletdb.ReadWriteTx) error,
+	reset func()) error {
+
+	defer d.observe("write", time.Now())
+
+	return d.Backend.Update(f, reset)
+}
+
+// instrumentedBatchDB is an instrumented database backend that supports
+// batching.
+type instrumentedBatchDB struct {
+	*instrumentedDB
+}
+
+// Batch attempts to combine the invocation of several transaction functions
+// into a single database write transaction.
+//
+// NOTE: This is part of the walletdb.BatchDB interface.
+func (d *instrumentedBatchDB) Batch(
+	f func(tx walletdb.ReadWriteTx) error) error {
+
+	defer d.observe("batch", time.Now())
+
+	// The backend is known to support batching, see InstrumentBackend.
+	batchDB, _ := d.Backend.(walletdb.BatchDB)
+
+	return batchDB.Batch(f)
+}
+
+// StartNodeMetrics starts collecting the metrics of the running node. The
+// returned function stops the collection.
+func StartNodeMetrics(cfg NodeMetricsConfig) (func(), error) {
+	if cfg.NumPeers == nil || cfg.SubscribeHtlcEvents == nil {
+		return nil, errors.New("incomplete node metrics config")
+	}
+
+	peers := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "peers",
+		Name:      "connected",
+		Help:      "Number of connected peers.",
+	}, func() float64 {
+		return float64(cfg.NumPeers())
+	})
+	if err := prometheus.Register(peers); err != nil {
+		return nil, err
+	}
+
+	htlcClient, err := cfg.SubscribeHtlcEvents()
+	if err != nil {
+		prometheus.Unregister(peers)
+
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		collectHtlcEvents(htlcClient.Updates(), htlcClient.Quit())
+	}()
+
+	log.Infof("Started collecting node metrics")
+
+	return func() {
+		htlcClient.Cancel()
+		wg.Wait()
+
+		prometheus.Unregister(peers)
+	}, nil
+}
+
+// collectHtlcEvents records the events of the switch until the subscription
+// is canceled.
+func collectHtlcEvents(updates <-chan interface{}, quit <-chan struct{}) {
+	// The switch doesn't report the amounts of settled forwards, so we
+	// keep track of the forwards that are in flight.
+	inFlight := make(map[htlcswitch.HtlcKey]htlcswitch.HtlcInfo)
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			switch event := update.(type) {
+			case *htlcswitch.ForwardingEvent:
+				htlcEvents.WithLabelValues(
+					"forward", event.HtlcEventType.String(),
+				).Inc()
+
+				if event.HtlcEventType ==
+					htlcswitch.HtlcEventTypeForward {
+
+					inFlight[event.HtlcKey] = event.HtlcInfo
+				}
+
+			case *htlcswitch.ForwardingFailEvent:
+				htlcEvents.WithLabelValues(
+					"forward_fail",
+					event.HtlcEventType.String(),
+				).Inc()
+
+				delete(inFlight, event.HtlcKey)
+
+			case *htlcswitch.LinkFailEvent:
+				htlcEvents.WithLabelValues(
+					"link_fail",
+					event.HtlcEventType.String(),
+				).Inc()
+
+			case *htlcswitch.SettleEvent:
+				htlcEvents.WithLabelValues(
+					"settle", event.HtlcEventType.String(),
+				).Inc()
+
+				info, ok := inFlight[event.HtlcKey]
+				if !ok {
+					continue
+				}
+				delete(inFlight, event.HtlcKey)
+
+				forwardedAmount.Add(float64(info.OutgoingAmt))
+				if info.IncomingAmt > info.OutgoingAmt {
+					fee := info.IncomingAmt -
+						info.OutgoingAmt
+					forwardingFees.Add(float64(fee))
+				}
+			}
+
+		case <-quit:
+			return
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 )
@@ -35,6 +36,9 @@ func ExportPrometheusMetrics(grpcServer *grpc.Server, cfg lncfg.Prometheus) erro
 
 		grpc_prometheus.Register(grpcServer)
 
+		// Expose the metrics of the node itself as well.
+		prometheus.MustRegister(nodeCollectors...)
+
 		// Enable the histograms which can allow plotting latency
 		// distributions of inbound calls. However we guard this behind
 		// another flag as this can generate a lot of additional data,
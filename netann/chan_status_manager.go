@@ -36,6 +36,21 @@ var (
 	// was manually disabled.
 	ErrEnableManuallyDisabledChan = errors.New("unable to enable channel " +
 		"which was manually disabled")
+
+	// ErrEnableFailureDisabledChan signals that an automatic / background
+	// request to enable a channel could not be completed because the
+	// channel was disabled after too many consecutive forwarding failures
+	// and its re-enable timeout hasn't expired yet.
+	ErrEnableFailureDisabledChan = errors.New("unable to enable channel " +
+		"which was disabled due to forwarding failures")
+
+	// ErrInvalidFailureDisableConstraints signals that the
+	// ChanStatusManager could not be initialized because the re-enable
+	// timeouts of channels disabled due to forwarding failures were
+	// malformed.
+	ErrInvalidFailureDisableConstraints = errors.New("chan-failure-" +
+		"reenable-timeout must be positive and must not exceed " +
+		"chan-failure-reenable-max-timeout")
 )
 
 // ChanStatusConfig holds parameters and resources required by the
@@ -63,6 +78,10 @@ type ChanStatusConfig struct {
 	ApplyChannelUpdate func(*lnwire.ChannelUpdate, *wire.OutPoint,
 		bool) error
 
+	// NotifyForwardingStatus is called after an update enabling or
+	// disabling forwarding over a channel was applied.
+	NotifyForwardingStatus func(wire.OutPoint, bool)
+
 	// DB stores the set of channels that are to be monitored.
 	DB DB
 
@@ -87,6 +106,27 @@ type ChanStatusConfig struct {
 	// manager to check if the channels being monitored have become
 	// inactive.
 	ChanStatusSampleInterval time.Duration
+
+	// MaxForwardingFailures is the number of consecutive local forwarding
+	// failures over a channel after which the channel is temporarily
+	// disabled. A value of zero never disables channels due to forwarding
+	// failures.
+	MaxForwardingFailures uint32
+
+	// FailureReenableTimeout is the duration a channel stays disabled
+	// after it was disabled due to forwarding failures for the first
+	// time. Each time the channel is disabled again without a successful
+	// forward in between, the duration is doubled.
+	FailureReenableTimeout time.Duration
+
+	// MaxFailureReenableTimeout is the maximum duration a channel stays
+	// disabled due to forwarding failures.
+	MaxFailureReenableTimeout time.Duration
+
+	// NotifyFailureDisabled is called after a channel was disabled due to
+	// the given number of consecutive forwarding failures. The channel
+	// will be re-enabled at the given time at the earliest.
+	NotifyFailureDisabled func(wire.OutPoint, uint32, time.Time)
 }
 
 // ChanStatusManager facilitates requests to enable or disable a channel via a
@@ -121,6 +161,15 @@ type ChanStatusManager struct {
 	// state management into the primary event loop.
 	autoRequests chan statusRequest
 
+	// forwardingResults pipes the results of forwards over our channels
+	// into the primary event loop.
+	forwardingResults chan forwardingResult
+
+	// forwardingFailures tracks the forwarding failures of channels that
+	// failed to forward since their last successful forward. Access to
+	// the map is serialized by the statusManager's event loop.
+	forwardingFailures map[wire.OutPoint]*forwardingFailures
+
 	// statusSampleTicker fires at the interval prescribed by
 	// ChanStatusSampleInterval to check if channels in chanStates have
 	// become inactive.
@@ -158,6 +207,17 @@ func NewChanStatusManager(cfg *ChanStatusConfig) (*ChanStatusManager, error) {
 
 	}
 
+	// If channels are disabled due to forwarding failures, they must be
+	// re-enabled eventually.
+	if cfg.MaxForwardingFailures > 0 {
+		if cfg.FailureReenableTimeout <= 0 {
+			return nil, ErrInvalidFailureDisableConstraints
+		}
+		if cfg.FailureReenableTimeout > cfg.MaxFailureReenableTimeout {
+			return nil, ErrInvalidFailureDisableConstraints
+		}
+	}
+
 	return &ChanStatusManager{
 		cfg:                cfg,
 		ourPubKeyBytes:     cfg.OurPubKey.SerializeCompressed(),
@@ -166,6 +226,8 @@ func NewChanStatusManager(cfg *ChanStatusConfig) (*ChanStatusManager, error) {
 		enableRequests:     make(chan statusRequest),
 		disableRequests:    make(chan statusRequest),
 		autoRequests:       make(chan statusRequest),
+		forwardingResults:  make(chan forwardingResult),
+		forwardingFailures: make(map[wire.OutPoint]*forwardingFailures),
 		quit:               make(chan struct{}),
 	}, nil
 }
@@ -287,6 +349,52 @@ func (m *ChanStatusManager) RequestAuto(outpoint wire.OutPoint) error {
 	return m.submitRequest(m.autoRequests, outpoint, true)
 }
 
+// ReportForwardingResult reports whether an HTLC was successfully forwarded
+// over the channel identified by the provided outpoint. If
+// MaxForwardingFailures consecutive forwards over an enabled channel fail
+// locally, a new announcement will be signed with the disabled bit set and
+// broadcast to the network. The channel is then re-enabled after a timeout that
+// is doubled each time the channel is disabled again without a successful
+// forward in between.
+//
+// NOTE: The state of channels disabled due to forwarding failures isn't
+// persisted. After a restart, they are re-enabled once their peer reconnects.
+func (m *ChanStatusManager) ReportForwardingResult(outpoint wire.OutPoint,
+	success bool) {
+
+	if m.cfg.MaxForwardingFailures == 0 {
+		return
+	}
+
+	select {
+	case m.forwardingResults <- forwardingResult{
+		outpoint: outpoint,
+		success:  success,
+	}:
+	case <-m.quit:
+	}
+}
+
+// forwardingResult is passed to the statusManager to report whether a forward
+// over a particular channel point succeeded.
+type forwardingResult struct {
+	outpoint wire.OutPoint
+	success  bool
+}
+
+// forwardingFailures tracks the forwarding failures of a channel since its
+// last successful forward.
+type forwardingFailures struct {
+	// consecutive is the number of consecutive forwarding failures since
+	// the channel was last enabled.
+	consecutive uint32
+
+	// reenableTimeout is the duration the channel was disabled for when it
+	// was last disabled due to forwarding failures. It is zero if the
+	// channel wasn't disabled since its last successful forward.
+	reenableTimeout time.Duration
+}
+
 // statusRequest is passed to the statusManager to request a change in status
 // for a particular channel point.  The exact action is governed by passing the
 // request through one of the enableRequests or disableRequests channels.
@@ -349,6 +457,10 @@ func (m *ChanStatusManager) statusManager() {
 		case req := <-m.autoRequests:
 			req.errChan <- m.processAutoRequest(req.outpoint)
 
+		// Process any reported forwarding results.
+		case res := <-m.forwardingResults:
+			m.processForwardingResult(res.outpoint, res.success)
+
 		// Use long-polling to detect when channels become inactive.
 		case <-m.statusSampleTicker.C:
 			// First, do a sweep and mark any ChanStatusEnabled
@@ -363,6 +475,11 @@ func (m *ChanStatusManager) statusManager() {
 			// if the inactive chan timeout has elapsed.
 			m.disableInactiveChannels()
 
+			// Finally, re-enable any channels that were disabled
+			// due to forwarding failures if their re-enable timeout
+			// has elapsed.
+			m.reenableFailureDisabledChannels()
+
 		case <-m.quit:
 			return
 		}
@@ -376,6 +493,9 @@ func (m *ChanStatusManager) statusManager() {
 //   - If the channel was in the ManuallyDisabled state and manual = false,
 //     the request will be ignored and ErrEnableManuallyDisabledChan will be
 //     returned.
+//   - If the channel was in the FailureDisabled state and manual = false,
+//     the request will be ignored and ErrEnableFailureDisabledChan will be
+//     returned.
 //   - Otherwise, the status of the channel in chanStates will be
 //     ChanStatusEnabled and the method will return nil.
 //
@@ -411,6 +531,14 @@ func (m *ChanStatusManager) processEnableRequest(outpoint wire.OutPoint,
 		log.Debugf("Channel(%v) already enabled, canceling scheduled "+
 			"disable", outpoint)
 
+	// The channel was disabled due to forwarding failures, only a manual
+	// request may enable it before its re-enable timeout expires.
+	case ChanStatusFailureDisabled:
+		if !manual {
+			return ErrEnableFailureDisabledChan
+		}
+		fallthrough
+
 	// We'll sign a new update if the channel is still disabled.
 	case ChanStatusManuallyDisabled:
 		if !manual {
@@ -427,6 +555,12 @@ func (m *ChanStatusManager) processEnableRequest(outpoint wire.OutPoint,
 		}
 	}
 
+	// A manually enabled channel starts over with a clean record of
+	// forwarding failures.
+	if manual {
+		delete(m.forwardingFailures, outpoint)
+	}
+
 	m.chanStates.markEnabled(outpoint)
 
 	return nil
@@ -470,6 +604,7 @@ func (m *ChanStatusManager) processDisableRequest(outpoint wire.OutPoint,
 		m.chanStates.markManuallyDisabled(outpoint)
 	} else if status != ChanStatusManuallyDisabled {
 		delete(m.chanStates, outpoint)
+		delete(m.forwardingFailures, outpoint)
 	}
 
 	return nil
@@ -496,6 +631,76 @@ func (m *ChanStatusManager) processAutoRequest(outpoint wire.OutPoint) error {
 	return nil
 }
 
+// processForwardingResult records the result of a forward over the given
+// outpoint. A successful forward clears the channel's record of forwarding
+// failures. If the channel is enabled and the number of consecutive failures
+// reaches MaxForwardingFailures, the channel is disabled and its state will be
+// ChanStatusFailureDisabled until the re-enable timeout expires.
+func (m *ChanStatusManager) processForwardingResult(outpoint wire.OutPoint,
+	success bool) {
+
+	if success {
+		delete(m.forwardingFailures, outpoint)
+		return
+	}
+
+	curState, err := m.getOrInitChanStatus(outpoint)
+	if err != nil {
+		log.Debugf("Unable to retrieve chan status for Channel(%v): %v",
+			outpoint, err)
+
+		return
+	}
+
+	// Failures of channels that aren't enabled, or are already scheduled
+	// to be disabled because they became inactive, are expected and
+	// therefore not counted.
+	if curState.Status != ChanStatusEnabled {
+		return
+	}
+
+	failures, ok := m.forwardingFailures[outpoint]
+	if !ok {
+		failures = &forwardingFailures{}
+		m.forwardingFailures[outpoint] = failures
+	}
+
+	failures.consecutive++
+	if failures.consecutive < m.cfg.MaxForwardingFailures {
+		return
+	}
+
+	// Double the duration the channel is disabled for if it was already
+	// disabled before without a successful forward in between.
+	timeout := m.cfg.FailureReenableTimeout
+	if failures.reenableTimeout > 0 {
+		timeout = 2 * failures.reenableTimeout
+	}
+	if timeout > m.cfg.MaxFailureReenableTimeout {
+		timeout = m.cfg.MaxFailureReenableTimeout
+	}
+
+	log.Infof("Announcing channel(%v) disabled [%d forwarding failures], "+
+		"re-enabling in %v", outpoint, failures.consecutive, timeout)
+
+	err = m.signAndSendNextUpdate(outpoint, true)
+	if err != nil {
+		log.Errorf("Unable to sign update disabling channel(%v): %v",
+			outpoint, err)
+
+		return
+	}
+
+	numFailures := failures.consecutive
+	failures.consecutive = 0
+	failures.reenableTimeout = timeout
+
+	reenableTime := time.Now().Add(timeout)
+	m.chanStates.markFailureDisabled(outpoint, reenableTime)
+
+	m.cfg.NotifyFailureDisabled(outpoint, numFailures, reenableTime)
+}
+
 // markPendingInactiveChannels performs a sweep of the database's active
 // channels and determines which, if any, should have a disable announcement
 // scheduled. Once an active channel is determined to be pending-inactive, one
@@ -585,6 +790,7 @@ func (m *ChanStatusManager) disableInactiveChannels() {
 					"consideration for passive disabling",
 					outpoint)
 				delete(m.chanStates, outpoint)
+				delete(m.forwardingFailures, outpoint)
 			}
 
 			continue
@@ -595,6 +801,58 @@ func (m *ChanStatusManager) disableInactiveChannels() {
 	}
 }
 
+// reenableFailureDisabledChannels scans through the set of monitored channels,
+// and broadcasts an enable update for any channels that were disabled due to
+// forwarding failures whose ReenableTime has been superseded by the current
+// time. Channels that became inactive in the meantime are treated as regular
+// disabled channels instead, which are enabled once their peer reconnects.
+func (m *ChanStatusManager) reenableFailureDisabledChannels() {
+	now := time.Now()
+	for outpoint, state := range m.chanStates {
+		// Ignore statuses that are not disabled due to forwarding
+		// failures.
+		if state.Status != ChanStatusFailureDisabled {
+			continue
+		}
+
+		// Ignore statuses for which the re-enable timeout has not
+		// expired.
+		if state.ReenableTime.After(now) {
+			continue
+		}
+
+		chanID := lnwire.NewChanIDFromOutPoint(outpoint)
+		if !m.cfg.IsChannelActive(chanID) {
+			log.Debugf("Channel(%v) inactive after re-enable "+
+				"timeout, awaiting enable request", outpoint)
+
+			m.chanStates.markDisabled(outpoint)
+
+			continue
+		}
+
+		log.Infof("Announcing channel(%v) enabled [re-enable timeout "+
+			"expired]", outpoint)
+
+		err := m.signAndSendNextUpdate(outpoint, false)
+		if err != nil {
+			log.Errorf("Unable to sign update enabling "+
+				"channel(%v): %v", outpoint, err)
+
+			// If the edge was not found, the channel has likely
+			// been closed, so we stop tracking it.
+			if errors.Is(err, channeldb.ErrEdgeNotFound) {
+				delete(m.chanStates, outpoint)
+				delete(m.forwardingFailures, outpoint)
+			}
+
+			continue
+		}
+
+		m.chanStates.markEnabled(outpoint)
+	}
+}
+
 // fetchChannels returns the working set of channels managed by the
 // ChanStatusManager. The returned channels are filtered to only contain public
 // channels.
@@ -642,7 +900,14 @@ func (m *ChanStatusManager) signAndSendNextUpdate(outpoint wire.OutPoint,
 		return err
 	}
 
-	return m.cfg.ApplyChannelUpdate(chanUpdate, &outpoint, private)
+	err = m.cfg.ApplyChannelUpdate(chanUpdate, &outpoint, private)
+	if err != nil {
+		return err
+	}
+
+	m.cfg.NotifyForwardingStatus(outpoint, disabled)
+
+	return nil
 }
 
 // fetchLastChanUpdateByOutPoint fetches the latest policy for our direction of
@@ -333,8 +333,18 @@ func newManagerCfg(t *testing.T, numChannels int,
 		MessageSigner:            netann.NewNodeSigner(privKeySigner),
 		IsChannelActive:          htlcSwitch.HasActiveLink,
 		ApplyChannelUpdate:       graph.ApplyChannelUpdate,
+		NotifyForwardingStatus:   func(wire.OutPoint, bool) {},
 		DB:                       graph,
 		Graph:                    graph,
+
+		// Channels are disabled after three consecutive forwarding
+		// failures, for one second initially.
+		MaxForwardingFailures:     3,
+		FailureReenableTimeout:    time.Second,
+		MaxFailureReenableTimeout: 2 * time.Second,
+		NotifyFailureDisabled: func(wire.OutPoint, uint32,
+			time.Time) {
+		},
 	}
 
 	return cfg, graph, htlcSwitch
@@ -407,6 +417,22 @@ func (h *testHarness) markInactive(channels []*channeldb.OpenChannel) {
 	}
 }
 
+// reportForwardingResults reports the given number of forwarding results for
+// all of the passed channels.
+func (h *testHarness) reportForwardingResults(
+	channels []*channeldb.OpenChannel, success bool, num int) {
+
+	h.t.Helper()
+
+	for i := 0; i < num; i++ {
+		for _, channel := range channels {
+			h.mgr.ReportForwardingResult(
+				channel.FundingOutpoint, success,
+			)
+		}
+	}
+}
+
 // assertEnables requests enables for all of the passed channels, and asserts
 // that the errors returned from RequestEnable matches expErr.
 func (h *testHarness) assertEnables(channels []*channeldb.OpenChannel, expErr error,
@@ -906,6 +932,106 @@ var stateMachineTests = []stateMachineTest{
 			)
 		},
 	},
+	{
+		name:         "disable after forwarding failures",
+		startActive:  true,
+		startEnabled: true,
+		fn: func(h testHarness) {
+			// A successful forward in between resets the number
+			// of consecutive failures, so no updates are sent.
+			h.reportForwardingResults(h.graph.chans(), false, 2)
+			h.reportForwardingResults(h.graph.chans(), true, 1)
+			h.reportForwardingResults(h.graph.chans(), false, 2)
+			h.assertNoUpdates(500 * time.Millisecond)
+
+			// The third consecutive failure disables the channels.
+			h.reportForwardingResults(h.graph.chans(), false, 1)
+			h.assertUpdates(
+				h.graph.chans(), false, 500*time.Millisecond,
+			)
+
+			// Request enables with manual = false should fail
+			// before the re-enable timeout expired.
+			h.assertEnables(
+				h.graph.chans(),
+				netann.ErrEnableFailureDisabledChan, false,
+			)
+
+			// Expect to see them all enabled on the network again
+			// once the re-enable timeout expired.
+			h.assertUpdates(
+				h.graph.chans(), true, h.safeDisableTimeout,
+			)
+		},
+	},
+	{
+		name:         "forwarding failure backoff",
+		startActive:  true,
+		startEnabled: true,
+		fn: func(h testHarness) {
+			// Disable the channels due to forwarding failures and
+			// wait until they are re-enabled.
+			h.reportForwardingResults(h.graph.chans(), false, 3)
+			h.assertUpdates(
+				h.graph.chans(), false, 500*time.Millisecond,
+			)
+			h.assertUpdates(
+				h.graph.chans(), true, h.safeDisableTimeout,
+			)
+
+			// Failing again without a successful forward in
+			// between disables the channels for twice as long.
+			h.reportForwardingResults(h.graph.chans(), false, 3)
+			h.assertUpdates(
+				h.graph.chans(), false, 500*time.Millisecond,
+			)
+			h.assertNoUpdates(time.Second)
+			h.assertUpdates(
+				h.graph.chans(), true, h.safeDisableTimeout,
+			)
+		},
+	},
+	{
+		name:         "manual enable after forwarding failures",
+		startActive:  true,
+		startEnabled: true,
+		fn: func(h testHarness) {
+			h.reportForwardingResults(h.graph.chans(), false, 3)
+			h.assertUpdates(
+				h.graph.chans(), false, 500*time.Millisecond,
+			)
+
+			// Request enables with manual = true should succeed.
+			h.assertEnables(h.graph.chans(), nil, true)
+			h.assertUpdates(
+				h.graph.chans(), true, 500*time.Millisecond,
+			)
+		},
+	},
+	{
+		name:         "inactive after forwarding failures",
+		startActive:  true,
+		startEnabled: true,
+		fn: func(h testHarness) {
+			h.reportForwardingResults(h.graph.chans(), false, 3)
+			h.assertUpdates(
+				h.graph.chans(), false, 500*time.Millisecond,
+			)
+
+			// Channels that are inactive once the re-enable
+			// timeout expired are not re-enabled.
+			h.markInactive(h.graph.chans())
+			h.assertNoUpdates(h.safeDisableTimeout)
+
+			// Once active again, they can be enabled by automatic
+			// requests.
+			h.markActive(h.graph.chans())
+			h.assertEnables(h.graph.chans(), nil, false)
+			h.assertUpdates(
+				h.graph.chans(), true, 500*time.Millisecond,
+			)
+		},
+	},
 }
 
 // TestChanStatusManagerStateMachine tests the possible state transitions that
@@ -2,8 +2,10 @@ package netann
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/fn"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
@@ -84,3 +86,55 @@ func CreateChanAnnouncement(chanProof *models.ChannelAuthProof,
 
 	return chanAnn, edge1Ann, edge2Ann, nil
 }
+
+// CreateChanAnnouncement2 is the gossip v2 counterpart of
+// CreateChanAnnouncement. It re-creates the signed ChannelAnnouncement2 of the
+// given channel along with the ChannelUpdate2 messages of its policies.
+func CreateChanAnnouncement2(chanInfo *models.ChannelEdgeInfo,
+	e1, e2 *models.ChannelEdgePolicy) (*lnwire.ChannelAnnouncement2,
+	*lnwire.ChannelUpdate2, *lnwire.ChannelUpdate2, error) {
+
+	if chanInfo.AuthProof == nil {
+		return nil, nil, nil, fmt.Errorf("channel %v has no auth proof",
+			chanInfo.ChannelID)
+	}
+
+	chanAnn := &lnwire.ChannelAnnouncement2{
+		ChainHash:       chanInfo.ChainHash,
+		Features:        lnwire.NewRawFeatureVector(),
+		ShortChannelID:  lnwire.NewShortChanIDFromInt(chanInfo.ChannelID),
+		Capacity:        uint64(chanInfo.Capacity),
+		NodeID1:         chanInfo.NodeKey1Bytes,
+		NodeID2:         chanInfo.NodeKey2Bytes,
+		BitcoinKey1:     fn.Some(chanInfo.BitcoinKey1Bytes),
+		BitcoinKey2:     fn.Some(chanInfo.BitcoinKey2Bytes),
+		ExtraOpaqueData: chanInfo.ExtraOpaqueData,
+	}
+
+	err := chanAnn.Features.Decode(bytes.NewReader(chanInfo.Features))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	chanAnn.Signature, err = lnwire.NewSigFromSchnorrRawSignature(
+		chanInfo.AuthProof.Signature,
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var edge1Ann, edge2Ann *lnwire.ChannelUpdate2
+	if e1 != nil {
+		edge1Ann, err = ChannelUpdate2FromEdge(chanInfo, e1)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if e2 != nil {
+		edge2Ann, err = ChannelUpdate2FromEdge(chanInfo, e2)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return chanAnn, edge1Ann, edge2Ann, nil
+}
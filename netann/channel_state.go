@@ -38,6 +38,13 @@ const (
 	// Otherwise, the network might be cluttered with channels that are
 	// advertised as enabled, but don't actually work or even exist.
 	ChanStatusManuallyDisabled
+
+	// ChanStatusFailureDisabled indicates that the channel's last
+	// announcement has the disabled bit set because too many consecutive
+	// local forwarding failures occurred over the channel. Channels in this
+	// state ignore automatic / background attempts to re-enable the
+	// channel, and are re-enabled once their ReenableTime has passed.
+	ChanStatusFailureDisabled
 )
 
 // ChannelState describes the ChanStatusManager's view of a channel, and
@@ -54,6 +61,13 @@ type ChannelState struct {
 	// NOTE: This field is only non-zero if status is
 	// ChanStatusPendingDisabled.
 	SendDisableTime time.Time
+
+	// ReenableTime is the earliest time at which the ChanStatusManager
+	// will send a new enable announcement on behalf of this channel.
+	//
+	// NOTE: This field is only non-zero if status is
+	// ChanStatusFailureDisabled.
+	ReenableTime time.Time
 }
 
 // channelStates is a map of channel outpoints to their channelState. All
@@ -93,3 +107,14 @@ func (s *channelStates) markPendingDisabled(outpoint wire.OutPoint,
 		SendDisableTime: sendDisableTime,
 	}
 }
+
+// markFailureDisabled creates a channelState using ChanStatusFailureDisabled
+// and sets the ChannelState's ReenableTime to reenableTime.
+func (s *channelStates) markFailureDisabled(outpoint wire.OutPoint,
+	reenableTime time.Time) {
+
+	(*s)[outpoint] = ChannelState{
+		Status:       ChanStatusFailureDisabled,
+		ReenableTime: reenableTime,
+	}
+}
@@ -152,3 +152,45 @@ func ChannelUpdateFromEdge(info *models.ChannelEdgeInfo,
 
 	return update, nil
 }
+
+// ChannelUpdate2FromEdge reconstructs a signed ChannelUpdate2 from the given
+// gossip v2 edge info and policy.
+func ChannelUpdate2FromEdge(info *models.ChannelEdgeInfo,
+	policy *models.ChannelEdgePolicy) (*lnwire.ChannelUpdate2, error) {
+
+	chanID := lnwire.NewShortChanIDFromInt(policy.ChannelID)
+	update := &lnwire.ChannelUpdate2{
+		ChainHash:       info.ChainHash,
+		ShortChannelID:  chanID,
+		BlockHeight:     policy.BlockHeight,
+		DisableFlags:    policy.DisableFlags,
+		CLTVExpiryDelta: policy.TimeLockDelta,
+		HTLCMinimumMsat: policy.MinHTLC,
+		FeeBaseMsat:     uint32(policy.FeeBaseMSat),
+		ExtraOpaqueData: policy.ExtraOpaqueData,
+	}
+
+	// The direction bit of the policy tells us whether the update was
+	// created by the second peer of the channel.
+	direction := policy.ChannelFlags & lnwire.ChanUpdateDirection
+	update.SecondPeer = direction != 0
+	update.FeeProportionalMillionths = uint32(
+		policy.FeeProportionalMillionths,
+	)
+
+	// The max_htlc field is only set on the policy's message flags if it
+	// was part of the signed update.
+	if policy.MessageFlags.HasMaxHtlc() {
+		update.HTLCMaximumMsat = policy.MaxHTLC
+	}
+
+	var err error
+	update.Signature, err = lnwire.NewSigFromSchnorrRawSignature(
+		policy.SigBytes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return update, nil
+}
@@ -0,0 +1,251 @@
+package netann
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// DefaultMaxHTLCMinChange is the default minimum change of the
+	// max_htlc of a channel, in percent of its current value, for which a
+	// new channel update is announced.
+	DefaultMaxHTLCMinChange = 10
+
+	// DefaultMaxHTLCInterval is the default interval at which the
+	// max_htlc of all channels is updated.
+	DefaultMaxHTLCInterval = 10 * time.Minute
+)
+
+// ChannelBalance describes how much can currently be sent over a channel.
+type ChannelBalance struct {
+	// Spendable is the amount that can currently be sent over the
+	// channel.
+	Spendable lnwire.MilliSatoshi
+
+	// MaxPendingAmount is the largest max_htlc that can be set for the
+	// channel.
+	MaxPendingAmount lnwire.MilliSatoshi
+}
+
+// MaxHTLCManagerConfig holds parameters and resources required by the
+// MaxHTLCManager to perform its duty.
+type MaxHTLCManagerConfig struct {
+	// OurPubKey is the public key identifying this node on the network.
+	OurPubKey *btcec.PublicKey
+
+	// Graph stores the channel info and policies of our channels.
+	Graph ChannelGraph
+
+	// FetchBalances returns the balances of our active channels, keyed
+	// by their channel point.
+	FetchBalances func() (map[wire.OutPoint]ChannelBalance, error)
+
+	// ApplyMaxHTLC sets the max_htlc of the given channel and announces
+	// the updated policy to the network.
+	ApplyMaxHTLC func(chanPoint wire.OutPoint,
+		maxHTLC lnwire.MilliSatoshi) error
+
+	// Percent is the percentage of the spendable balance of a channel
+	// that its max_htlc is set to.
+	Percent uint32
+
+	// MinChangePercent is the minimum change of the max_htlc of a channel
+	// relative to its current value that causes a new channel update to
+	// be announced. It prevents small balance changes from flooding the
+	// network with channel updates.
+	MinChangePercent uint32
+
+	// Interval is the interval at which the max_htlc of all channels is
+	// checked. It should be at least as large as the interval in which
+	// our peers accept new channel updates for the same channel, so that
+	// the updates aren't rate limited.
+	Interval time.Duration
+
+	// Clock is the time source used to schedule the checks.
+	Clock clock.Clock
+}
+
+// MaxHTLCManager keeps the max_htlc of our channels at a percentage of their
+// spendable balance. Senders then no longer attempt to route HTLCs over our
+// depleted channels that we would fail anyway.
+type MaxHTLCManager struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *MaxHTLCManagerConfig
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewMaxHTLCManager creates a new MaxHTLCManager from the given config.
+func NewMaxHTLCManager(cfg *MaxHTLCManagerConfig) (*MaxHTLCManager, error) {
+	if cfg.Percent == 0 || cfg.Percent > 100 {
+		return nil, fmt.Errorf("percent must be between 1 and 100")
+	}
+
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+
+	return &MaxHTLCManager{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}, nil
+}
+
+// Start starts the MaxHTLCManager.
+func (m *MaxHTLCManager) Start() error {
+	m.started.Do(func() {
+		log.Infof("Max HTLC manager starting, max_htlc=%d%% of "+
+			"spendable balance", m.cfg.Percent)
+
+		m.wg.Add(1)
+		go m.run()
+	})
+
+	return nil
+}
+
+// Stop stops the MaxHTLCManager.
+func (m *MaxHTLCManager) Stop() error {
+	m.stopped.Do(func() {
+		log.Info("Max HTLC manager shutting down...")
+		defer log.Debug("Max HTLC manager shutdown complete")
+
+		close(m.quit)
+		m.wg.Wait()
+	})
+
+	return nil
+}
+
+// run updates the max_htlc of our channels once per interval until the
+// manager is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (m *MaxHTLCManager) run() {
+	defer m.wg.Done()
+
+	for {
+		select {
+		case <-m.cfg.Clock.TickAfter(m.cfg.Interval):
+		case <-m.quit:
+			return
+		}
+
+		if err := m.updateAll(); err != nil {
+			log.Errorf("Unable to update max_htlc of channels: %v",
+				err)
+		}
+	}
+}
+
+// updateAll updates the max_htlc of all channels whose spendable balance
+// changed enough since their last update.
+func (m *MaxHTLCManager) updateAll() error {
+	balances, err := m.cfg.FetchBalances()
+	if err != nil {
+		return err
+	}
+
+	var numUpdates int
+	for chanPoint, balance := range balances {
+		current, minHTLC, err := m.fetchHtlcLimits(chanPoint)
+		if err != nil {
+			log.Debugf("Unable to fetch policy of %v, not "+
+				"updating its max_htlc: %v", chanPoint, err)
+
+			continue
+		}
+
+		target := targetMaxHTLC(balance, m.cfg.Percent, minHTLC)
+		if !exceedsMinChange(current, target, m.cfg.MinChangePercent) {
+			continue
+		}
+
+		log.Debugf("Updating max_htlc of %v from %v to %v", chanPoint,
+			current, target)
+
+		if err := m.cfg.ApplyMaxHTLC(chanPoint, target); err != nil {
+			log.Errorf("Unable to update max_htlc of %v: %v",
+				chanPoint, err)
+
+			continue
+		}
+		numUpdates++
+	}
+
+	if numUpdates > 0 {
+		log.Infof("Updated max_htlc of %d channel(s)", numUpdates)
+	}
+
+	return nil
+}
+
+// fetchHtlcLimits returns the current max_htlc and min_htlc of the channel.
+func (m *MaxHTLCManager) fetchHtlcLimits(chanPoint wire.OutPoint) (
+	lnwire.MilliSatoshi, lnwire.MilliSatoshi, error) {
+
+	info, edge1, edge2, err := m.cfg.Graph.FetchChannelEdgesByOutpoint(
+		&chanPoint,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	update, err := ExtractChannelUpdate(
+		m.cfg.OurPubKey.SerializeCompressed(), info, edge1, edge2,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return update.HtlcMaximumMsat, update.HtlcMinimumMsat, nil
+}
+
+// targetMaxHTLC returns the max_htlc for a channel with the given balance. It
+// is never below the min_htlc of the channel, nor above its max pending
+// amount.
+func targetMaxHTLC(balance ChannelBalance, percent uint32,
+	minHTLC lnwire.MilliSatoshi) lnwire.MilliSatoshi {
+
+	target := balance.Spendable * lnwire.MilliSatoshi(percent) / 100
+	if target > balance.MaxPendingAmount {
+		target = balance.MaxPendingAmount
+	}
+
+	// A max_htlc of zero isn't valid, so at least a single msat must be
+	// allowed.
+	if minHTLC == 0 {
+		minHTLC = 1
+	}
+	if target < minHTLC {
+		target = minHTLC
+	}
+
+	return target
+}
+
+// exceedsMinChange returns true if the change from the current to the target
+// max_htlc is at least minChangePercent of the current max_htlc.
+func exceedsMinChange(current, target lnwire.MilliSatoshi,
+	minChangePercent uint32) bool {
+
+	if current == target {
+		return false
+	}
+
+	diff := target - current
+	if current > target {
+		diff = current - target
+	}
+
+	return diff*100 >= current*lnwire.MilliSatoshi(minChangePercent)
+}
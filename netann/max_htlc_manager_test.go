@@ -0,0 +1,166 @@
+package netann_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntest/wait"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/netann"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMaxHTLCManager checks that the max_htlc of channels is set to the
+// configured percentage of their spendable balance, and that small changes
+// aren't announced.
+func TestMaxHTLCManager(t *testing.T) {
+	t.Parallel()
+
+	const interval = time.Minute
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	graph := newMockGraph(t, 3, true, true, privKey.PubKey())
+	chans := graph.chans()
+	for _, c := range chans {
+		policies := []*models.ChannelEdgePolicy{
+			graph.chanPols1[c.FundingOutpoint],
+			graph.chanPols2[c.FundingOutpoint],
+		}
+		for _, policy := range policies {
+			policy.MinHTLC = 1_000
+			policy.MaxHTLC = 1_000_000
+		}
+	}
+
+	balances := map[wire.OutPoint]netann.ChannelBalance{
+		// The max_htlc of the first channel is raised to half of its
+		// spendable balance.
+		chans[0].FundingOutpoint: {
+			Spendable:        10_000_000,
+			MaxPendingAmount: 100_000_000,
+		},
+
+		// The max_htlc of the second channel would only change by 5%,
+		// so it isn't updated.
+		chans[1].FundingOutpoint: {
+			Spendable:        2_100_000,
+			MaxPendingAmount: 100_000_000,
+		},
+
+		// The max_htlc of the depleted third channel is lowered to its
+		// min_htlc.
+		chans[2].FundingOutpoint: {
+			Spendable:        0,
+			MaxPendingAmount: 100_000_000,
+		},
+
+		// Channels without a known policy are skipped.
+		randOutpoint(t): {
+			Spendable:        10_000_000,
+			MaxPendingAmount: 100_000_000,
+		},
+	}
+
+	startTime := time.Now()
+
+	// The tick signal is buffered, so that the manager can still be
+	// stopped while it schedules its next check.
+	tickSignal := make(chan time.Duration, 1)
+	testClock := clock.NewTestClockWithTickSignal(startTime, tickSignal)
+
+	applied := make(chan map[wire.OutPoint]lnwire.MilliSatoshi, 1)
+	updates := make(map[wire.OutPoint]lnwire.MilliSatoshi)
+
+	cfg := &netann.MaxHTLCManagerConfig{
+		OurPubKey: privKey.PubKey(),
+		Graph:     graph,
+		FetchBalances: func() (map[wire.OutPoint]netann.ChannelBalance,
+			error) {
+
+			// Report the updates of the previous check, if any.
+			if len(updates) > 0 {
+				applied <- updates
+				updates = make(
+					map[wire.OutPoint]lnwire.MilliSatoshi,
+				)
+			}
+
+			return balances, nil
+		},
+		ApplyMaxHTLC: func(chanPoint wire.OutPoint,
+			maxHTLC lnwire.MilliSatoshi) error {
+
+			updates[chanPoint] = maxHTLC
+			return nil
+		},
+		Percent:          50,
+		MinChangePercent: 10,
+		Interval:         interval,
+		Clock:            testClock,
+	}
+	manager, err := netann.NewMaxHTLCManager(cfg)
+	require.NoError(t, err)
+	require.NoError(t, manager.Start())
+	t.Cleanup(func() {
+		require.NoError(t, manager.Stop())
+	})
+
+	// advance waits for the next check to be scheduled and then moves the
+	// clock forward to trigger it.
+	step := 0
+	advance := func() {
+		t.Helper()
+
+		select {
+		case <-tickSignal:
+		case <-time.After(wait.DefaultTimeout):
+			t.Fatalf("next check not scheduled")
+		}
+
+		step++
+		testClock.SetTime(startTime.Add(time.Duration(step) * interval))
+	}
+
+	// The updates of the first check are reported by the second one.
+	advance()
+	advance()
+
+	select {
+	case u := <-applied:
+		require.Equal(t, map[wire.OutPoint]lnwire.MilliSatoshi{
+			chans[0].FundingOutpoint: 5_000_000,
+			chans[2].FundingOutpoint: 1_000,
+		}, u)
+
+	case <-time.After(wait.DefaultTimeout):
+		t.Fatalf("max_htlc not updated")
+	}
+}
+
+// TestMaxHTLCManagerConfig checks that invalid configs are rejected.
+func TestMaxHTLCManagerConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := netann.NewMaxHTLCManager(&netann.MaxHTLCManagerConfig{
+		Percent:  0,
+		Interval: time.Minute,
+	})
+	require.Error(t, err)
+
+	_, err = netann.NewMaxHTLCManager(&netann.MaxHTLCManagerConfig{
+		Percent:  101,
+		Interval: time.Minute,
+	})
+	require.Error(t, err)
+
+	_, err = netann.NewMaxHTLCManager(&netann.MaxHTLCManagerConfig{
+		Percent: 50,
+	})
+	require.Error(t, err)
+}
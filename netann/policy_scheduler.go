@@ -0,0 +1,316 @@
+package netann
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrPolicySchedulerExiting signals that a shutdown of the
+	// PolicyScheduler has already been requested.
+	ErrPolicySchedulerExiting = errors.New("policy scheduler exiting")
+
+	// ErrNoRampChannels signals that a policy ramp couldn't be scheduled
+	// because none of its channels has a known policy.
+	ErrNoRampChannels = errors.New("no channels with known policies to " +
+		"ramp")
+)
+
+// FeePolicy is the outgoing fee policy of a channel.
+type FeePolicy struct {
+	// BaseFee is the base fee charged for each forwarded HTLC.
+	BaseFee lnwire.MilliSatoshi
+
+	// FeeRate is the proportional fee rate in parts per million.
+	FeeRate uint32
+}
+
+// PolicyRamp describes a gradual transition of the fee policies of a set of
+// channels towards a target fee policy.
+type PolicyRamp struct {
+	// ChanPoints is the set of channels whose fees are ramped.
+	ChanPoints []wire.OutPoint
+
+	// Target is the fee policy that the channels will end up with once the
+	// ramp is completed.
+	Target FeePolicy
+
+	// Duration is the time it takes to reach the target fee policy.
+	Duration time.Duration
+
+	// ApplyFees applies the given fee policy to the channel. It is called
+	// once for each channel and step of the ramp, and with the target fee
+	// policy in the last step.
+	ApplyFees func(chanPoint wire.OutPoint, fees FeePolicy) error
+}
+
+// PolicySchedulerConfig holds parameters and resources required by the
+// PolicyScheduler to perform its duty.
+type PolicySchedulerConfig struct {
+	// OurPubKey is the public key identifying this node on the network.
+	OurPubKey *btcec.PublicKey
+
+	// Graph stores the channel info and policies of our channels.
+	Graph ChannelGraph
+
+	// StepInterval is the interval between two consecutive fee updates of
+	// a ramp. It should be at least as large as the interval in which our
+	// peers accept new channel updates for the same channel, so that the
+	// intermediate updates aren't rate limited.
+	StepInterval time.Duration
+
+	// Clock is the time source used to schedule the steps of a ramp.
+	Clock clock.Clock
+}
+
+// policyRamp is a scheduled PolicyRamp along with the fee policies that its
+// channels had when it was scheduled.
+type policyRamp struct {
+	*PolicyRamp
+
+	// start holds the initial fee policy of each channel of the ramp.
+	start map[wire.OutPoint]FeePolicy
+
+	// numSteps is the number of fee updates that the ramp consists of.
+	numSteps uint32
+}
+
+// PolicyScheduler applies fee policy changes gradually. Instead of jumping to
+// the target policy right away, which may cause HTLCs to fail that were routed
+// based on the old policy, the fees are moved towards the target in several
+// steps that are spaced out far enough for every update to be propagated
+// through the network.
+//
+// NOTE: Scheduled ramps are kept in memory only and don't survive a restart.
+type PolicyScheduler struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *PolicySchedulerConfig
+
+	// ramps maps each channel to the ramp that currently controls its
+	// fees. A ramp skips the channels it no longer controls.
+	ramps map[wire.OutPoint]*policyRamp
+
+	// mu protects the ramps map and serializes the fee updates of the
+	// ramps with their cancellation.
+	mu sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewPolicyScheduler creates a new PolicyScheduler from the given config.
+func NewPolicyScheduler(cfg *PolicySchedulerConfig) (*PolicyScheduler,
+	error) {
+
+	if cfg.StepInterval <= 0 {
+		return nil, fmt.Errorf("step interval must be positive")
+	}
+
+	return &PolicyScheduler{
+		cfg:   cfg,
+		ramps: make(map[wire.OutPoint]*policyRamp),
+		quit:  make(chan struct{}),
+	}, nil
+}
+
+// Start starts the PolicyScheduler.
+func (s *PolicyScheduler) Start() error {
+	s.started.Do(func() {
+		log.Info("Policy scheduler starting")
+	})
+
+	return nil
+}
+
+// Stop stops the PolicyScheduler. Ramps that are in progress are aborted.
+func (s *PolicyScheduler) Stop() error {
+	s.stopped.Do(func() {
+		log.Info("Policy scheduler shutting down...")
+		defer log.Debug("Policy scheduler shutdown complete")
+
+		close(s.quit)
+		s.wg.Wait()
+	})
+
+	return nil
+}
+
+// Schedule starts ramping the fees of the channels of the given ramp towards
+// its target. The first step is applied right away, the remaining ones once
+// per step interval. Channels whose current policy isn't known are skipped.
+// Any ramp previously scheduled for one of the channels is superseded.
+func (s *PolicyScheduler) Schedule(ramp *PolicyRamp) error {
+	r := &policyRamp{
+		PolicyRamp: ramp,
+		start:      make(map[wire.OutPoint]FeePolicy),
+		numSteps:   1,
+	}
+
+	for _, chanPoint := range ramp.ChanPoints {
+		fees, err := s.fetchFeePolicy(chanPoint)
+		if err != nil {
+			log.Warnf("Unable to fetch policy of %v, not ramping "+
+				"its fees: %v", chanPoint, err)
+
+			continue
+		}
+
+		r.start[chanPoint] = fees
+	}
+
+	if len(r.start) == 0 {
+		return ErrNoRampChannels
+	}
+
+	if ramp.Duration > 0 {
+		r.numSteps += uint32(ramp.Duration / s.cfg.StepInterval)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.quit:
+		return ErrPolicySchedulerExiting
+	default:
+	}
+
+	for chanPoint := range r.start {
+		s.ramps[chanPoint] = r
+	}
+
+	log.Infof("Ramping fees of %d channel(s) to base_fee=%v, "+
+		"fee_rate=%v in %d step(s)", len(r.start), ramp.Target.BaseFee,
+		ramp.Target.FeeRate, r.numSteps)
+
+	s.wg.Add(1)
+	go s.runRamp(r)
+
+	return nil
+}
+
+// Cancel aborts the ramps of the given channels, leaving their fees at the
+// level of the last applied step. If no channels are given, all ramps are
+// aborted.
+func (s *PolicyScheduler) Cancel(chanPoints ...wire.OutPoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(chanPoints) == 0 {
+		s.ramps = make(map[wire.OutPoint]*policyRamp)
+		return
+	}
+
+	for _, chanPoint := range chanPoints {
+		delete(s.ramps, chanPoint)
+	}
+}
+
+// fetchFeePolicy returns the current outgoing fee policy of the channel.
+func (s *PolicyScheduler) fetchFeePolicy(chanPoint wire.OutPoint) (FeePolicy,
+	error) {
+
+	info, edge1, edge2, err := s.cfg.Graph.FetchChannelEdgesByOutpoint(
+		&chanPoint,
+	)
+	if err != nil {
+		return FeePolicy{}, err
+	}
+
+	update, err := ExtractChannelUpdate(
+		s.cfg.OurPubKey.SerializeCompressed(), info, edge1, edge2,
+	)
+	if err != nil {
+		return FeePolicy{}, err
+	}
+
+	return FeePolicy{
+		BaseFee: lnwire.MilliSatoshi(update.BaseFee),
+		FeeRate: update.FeeRate,
+	}, nil
+}
+
+// runRamp applies the steps of the ramp until it is completed, all of its
+// channels were taken over by other ramps or the scheduler is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *PolicyScheduler) runRamp(r *policyRamp) {
+	defer s.wg.Done()
+
+	for step := uint32(1); step <= r.numSteps; step++ {
+		if step > 1 {
+			select {
+			case <-s.cfg.Clock.TickAfter(s.cfg.StepInterval):
+			case <-s.quit:
+				return
+			}
+		}
+
+		if !s.applyStep(r, step) {
+			return
+		}
+	}
+}
+
+// applyStep applies the fees of the given step to all channels that are still
+// controlled by the ramp. It returns false if there are no such channels.
+func (s *PolicyScheduler) applyStep(r *policyRamp, step uint32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var numChans int
+	for chanPoint, start := range r.start {
+		if s.ramps[chanPoint] != r {
+			continue
+		}
+		numChans++
+
+		fees := interpolateFees(start, r.Target, step, r.numSteps)
+		if err := r.ApplyFees(chanPoint, fees); err != nil {
+			log.Errorf("Unable to apply fees of step %d/%d to "+
+				"%v: %v", step, r.numSteps, chanPoint, err)
+		}
+
+		if step == r.numSteps {
+			delete(s.ramps, chanPoint)
+		}
+	}
+
+	if numChans > 0 {
+		log.Debugf("Applied fee ramp step %d/%d to %d channel(s)",
+			step, r.numSteps, numChans)
+	}
+
+	return numChans > 0
+}
+
+// interpolateFees returns the fee policy of the given step of a linear ramp
+// from start to target that consists of numSteps steps.
+func interpolateFees(start, target FeePolicy, step,
+	numSteps uint32) FeePolicy {
+
+	interpolate := func(from, to uint64) uint64 {
+		delta := (int64(to) - int64(from)) * int64(step) /
+			int64(numSteps)
+
+		return uint64(int64(from) + delta)
+	}
+
+	return FeePolicy{
+		BaseFee: lnwire.MilliSatoshi(interpolate(
+			uint64(start.BaseFee), uint64(target.BaseFee),
+		)),
+		FeeRate: uint32(interpolate(
+			uint64(start.FeeRate), uint64(target.FeeRate),
+		)),
+	}
+}
@@ -0,0 +1,155 @@
+package netann_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/channeldb/models"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/lntest/wait"
+	"github.com/lightningnetwork/lnd/netann"
+	"github.com/stretchr/testify/require"
+)
+
+// appliedFees is a fee policy that was applied to a channel by a ramp.
+type appliedFees struct {
+	chanPoint wire.OutPoint
+	fees      netann.FeePolicy
+}
+
+// TestPolicySchedulerRamp checks that the scheduler ramps the fees of all
+// channels in steps, and that cancelled channels are no longer updated.
+func TestPolicySchedulerRamp(t *testing.T) {
+	t.Parallel()
+
+	const interval = time.Minute
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	graph := newMockGraph(t, 2, true, true, privKey.PubKey())
+	chans := graph.chans()
+	for _, c := range chans {
+		policies := []*models.ChannelEdgePolicy{
+			graph.chanPols1[c.FundingOutpoint],
+			graph.chanPols2[c.FundingOutpoint],
+		}
+		for _, policy := range policies {
+			policy.FeeBaseMSat = 1000
+			policy.FeeProportionalMillionths = 100
+		}
+	}
+
+	startTime := time.Now()
+	tickSignal := make(chan time.Duration)
+	testClock := clock.NewTestClockWithTickSignal(startTime, tickSignal)
+
+	cfg := &netann.PolicySchedulerConfig{
+		OurPubKey:    privKey.PubKey(),
+		Graph:        graph,
+		StepInterval: interval,
+		Clock:        testClock,
+	}
+	scheduler, err := netann.NewPolicyScheduler(cfg)
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Start())
+	t.Cleanup(func() {
+		require.NoError(t, scheduler.Stop())
+	})
+
+	applied := make(chan appliedFees, 2)
+	applyFees := func(chanPoint wire.OutPoint,
+		fees netann.FeePolicy) error {
+
+		applied <- appliedFees{chanPoint, fees}
+		return nil
+	}
+
+	unknownChan := randOutpoint(t)
+	err = scheduler.Schedule(&netann.PolicyRamp{
+		ChanPoints: []wire.OutPoint{
+			chans[0].FundingOutpoint, chans[1].FundingOutpoint,
+			unknownChan,
+		},
+		Target:    netann.FeePolicy{BaseFee: 2000, FeeRate: 500},
+		Duration:  3 * interval,
+		ApplyFees: applyFees,
+	})
+	require.NoError(t, err)
+
+	// assertStep asserts that the given fees are applied to the channels.
+	assertStep := func(fees netann.FeePolicy, chanPoints ...wire.OutPoint) {
+		t.Helper()
+
+		var updated []wire.OutPoint
+		for range chanPoints {
+			select {
+			case a := <-applied:
+				require.Equal(t, fees, a.fees)
+				updated = append(updated, a.chanPoint)
+
+			case <-time.After(wait.DefaultTimeout):
+				t.Fatalf("fees not applied")
+			}
+		}
+		require.ElementsMatch(t, chanPoints, updated)
+	}
+
+	// advance waits for the next step to be scheduled and then moves the
+	// clock forward to trigger it.
+	step := 0
+	advance := func() {
+		t.Helper()
+
+		select {
+		case <-tickSignal:
+		case <-time.After(wait.DefaultTimeout):
+			t.Fatalf("next step not scheduled")
+		}
+
+		step++
+		testClock.SetTime(startTime.Add(time.Duration(step) * interval))
+	}
+
+	// The first step is applied right away to both known channels.
+	assertStep(
+		netann.FeePolicy{BaseFee: 1250, FeeRate: 200},
+		chans[0].FundingOutpoint, chans[1].FundingOutpoint,
+	)
+
+	advance()
+	assertStep(
+		netann.FeePolicy{BaseFee: 1500, FeeRate: 300},
+		chans[0].FundingOutpoint, chans[1].FundingOutpoint,
+	)
+
+	// Once the ramp of the first channel is cancelled, only the second
+	// one is updated until the target is reached.
+	scheduler.Cancel(chans[0].FundingOutpoint)
+
+	advance()
+	assertStep(
+		netann.FeePolicy{BaseFee: 1750, FeeRate: 400},
+		chans[1].FundingOutpoint,
+	)
+
+	advance()
+	assertStep(
+		netann.FeePolicy{BaseFee: 2000, FeeRate: 500},
+		chans[1].FundingOutpoint,
+	)
+
+	select {
+	case a := <-applied:
+		t.Fatalf("unexpected fee update: %v", a)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Scheduling a ramp without any known channels fails.
+	err = scheduler.Schedule(&netann.PolicyRamp{
+		ChanPoints: []wire.OutPoint{unknownChan},
+	})
+	require.ErrorIs(t, err, netann.ErrNoRampChannels)
+}
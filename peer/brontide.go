@@ -36,6 +36,7 @@ import (
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/invoices"
 	"github.com/lightningnetwork/lnd/lnpeer"
+	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnutils"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
@@ -48,6 +49,7 @@ import (
 	"github.com/lightningnetwork/lnd/ticker"
 	"github.com/lightningnetwork/lnd/tlv"
 	"github.com/lightningnetwork/lnd/watchtower/wtclient"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -87,6 +89,15 @@ const (
 	// torTimeoutMultiplier is the scaling factor we use on network timeouts
 	// for Tor peers.
 	torTimeoutMultiplier = 3
+
+	// onionMessageRate is the number of onion messages per second that we
+	// accept from a single peer. Onion messages exceeding this rate are
+	// dropped.
+	onionMessageRate = 10
+
+	// onionMessageBurst is the number of onion messages that a peer can
+	// send us in a burst before being rate limited.
+	onionMessageBurst = 50
 )
 
 var (
@@ -358,6 +369,19 @@ type Config struct {
 	// from the peer.
 	HandleCustomMessage func(peer [33]byte, msg *lnwire.Custom) error
 
+	// HandleOnionMessage is called whenever an onion message is received
+	// from the peer. If it is nil, onion messages are dropped.
+	HandleOnionMessage func(peer [33]byte,
+		msg *lnwire.OnionMessage) error
+
+	// HandlePeerStorage is called whenever the peer sends us a backup to
+	// store for it. If it is nil, the backups of the peer are dropped.
+	HandlePeerStorage func(peer [33]byte, blob []byte) error
+
+	// HandlePeerStorageRetrieval is called whenever the peer returns the
+	// backup it stores for us. If it is nil, the backup is dropped.
+	HandlePeerStorageRetrieval func(peer [33]byte, blob []byte) error
+
 	// GetAliases is passed to created links so the Switch and link can be
 	// aware of the channel's aliases.
 	GetAliases func(base lnwire.ShortChannelID) []lnwire.ShortChannelID
@@ -381,6 +405,10 @@ type Config struct {
 	// invalid.
 	DisallowRouteBlinding bool
 
+	// AuxTrafficShapers is the set of aux traffic shapers that are handed
+	// to the links of the peer's channels.
+	AuxTrafficShapers []htlcswitch.AuxTrafficShaper
+
 	// Quit is the server's quit channel. If this is closed, we halt operation.
 	Quit chan struct{}
 }
@@ -400,6 +428,11 @@ type Brontide struct {
 	bytesReceived uint64
 	bytesSent     uint64
 
+	// msgsReceived and msgsSent count the messages exchanged with the
+	// peer by their type.
+	msgsReceived msgCounter
+	msgsSent     msgCounter
+
 	// isTorConnection is a flag that indicates whether or not we believe
 	// the remote peer is a tor connection. It is not always possible to
 	// know this with certainty but we have heuristics we use that should
@@ -517,6 +550,10 @@ type Brontide struct {
 	// potentially holding lots of un-consumed events.
 	channelEventClient *subscribe.Client
 
+	// onionMsgLimiter limits the rate of onion messages that we process
+	// from this peer.
+	onionMsgLimiter *rate.Limiter
+
 	startReady chan struct{}
 	quit       chan struct{}
 	wg         sync.WaitGroup
@@ -556,6 +593,8 @@ func NewBrontide(cfg Config) *Brontide {
 		log:                build.NewPrefixLog(logPrefix, peerLog),
 	}
 
+	p.onionMsgLimiter = rate.NewLimiter(onionMessageRate, onionMessageBurst)
+
 	if cfg.Conn != nil && cfg.Conn.RemoteAddr() != nil {
 		remoteAddr := cfg.Conn.RemoteAddr().String()
 		p.isTorConnection = strings.Contains(remoteAddr, ".onion") ||
@@ -822,6 +861,22 @@ func (p *Brontide) taprootShutdownAllowed() bool {
 		p.LocalFeatures().HasFeature(lnwire.ShutdownAnySegwitOptional)
 }
 
+// rbfCoopCloseAllowed returns true if both parties have negotiated the RBF
+// coop close feature, and the given channel can be closed using it.
+func (p *Brontide) rbfCoopCloseAllowed(
+	channel *lnwallet.LightningChannel) bool {
+
+	// The ClosingComplete and ClosingSig messages don't carry the musig2
+	// nonces that are required to sign the closing transaction of a
+	// taproot channel.
+	if channel.ChanType().IsTaproot() {
+		return false
+	}
+
+	return p.RemoteFeatures().HasFeature(lnwire.RbfCoopCloseOptional) &&
+		p.LocalFeatures().HasFeature(lnwire.RbfCoopCloseOptional)
+}
+
 // QuitSignal is a method that should return a channel which will be sent upon
 // or closed once the backing peer exits. This allows callers using the
 // interface to cancel any processing in the event the backing implementation
@@ -1064,7 +1119,8 @@ func (p *Brontide) loadActiveChannels(chans []*channeldb.OpenChannel) (
 			}
 
 			chanCloser, err := p.createChanCloser(
-				lnChan, info.DeliveryScript.Val, feePerKw, nil,
+				lnChan, info.DeliveryScript.Val,
+				shutdownCloseOutputs(info), feePerKw, nil,
 				info.LocalInitiator.Val,
 			)
 			if err != nil {
@@ -1194,6 +1250,8 @@ func (p *Brontide) addLink(chanPoint *wire.OutPoint,
 		GetAliases:              p.cfg.GetAliases,
 		PreviouslySentShutdown:  shutdownMsg,
 		DisallowRouteBlinding:   p.cfg.DisallowRouteBlinding,
+		AllowPTLCs:              p.hasNegotiatedPtlc() && lnChan.ChanType().IsTaproot(),
+		AuxTrafficShapers:       p.cfg.AuxTrafficShapers,
 	}
 
 	// Before adding our new link, purge the switch of any pending or live
@@ -1362,6 +1420,7 @@ func (p *Brontide) readNextMessage() (lnwire.Message, error) {
 		return nil, err
 	}
 
+	p.msgsReceived.inc(nextMsg.MsgType())
 	p.logWireMessage(nextMsg, true)
 
 	return nextMsg, nil
@@ -1774,6 +1833,18 @@ out:
 			case <-p.quit:
 				break out
 			}
+		case *lnwire.ClosingComplete:
+			select {
+			case p.chanCloseMsgs <- &closeMsg{msg.ChannelID, msg}:
+			case <-p.quit:
+				break out
+			}
+		case *lnwire.ClosingSig:
+			select {
+			case p.chanCloseMsgs <- &closeMsg{msg.ChannelID, msg}:
+			case <-p.quit:
+				break out
+			}
 
 		case *lnwire.Warning:
 			targetChan = msg.ChanID
@@ -1821,6 +1892,8 @@ out:
 
 		case *lnwire.ChannelUpdate,
 			*lnwire.ChannelAnnouncement,
+			*lnwire.ChannelUpdate2,
+			*lnwire.ChannelAnnouncement2,
 			*lnwire.NodeAnnouncement,
 			*lnwire.AnnounceSignatures,
 			*lnwire.GossipTimestampRange,
@@ -1838,6 +1911,15 @@ out:
 				p.log.Errorf("%v", err)
 			}
 
+		case *lnwire.OnionMessage:
+			p.handleOnionMessage(msg)
+
+		case *lnwire.PeerStorage:
+			p.handlePeerStorage(msg)
+
+		case *lnwire.PeerStorageRetrieval:
+			p.handlePeerStorageRetrieval(msg)
+
 		default:
 			// If the message we received is unknown to us, store
 			// the type to track the failure.
@@ -1873,6 +1955,62 @@ func (p *Brontide) handleCustomMessage(msg *lnwire.Custom) error {
 	return p.cfg.HandleCustomMessage(p.PubKey(), msg)
 }
 
+// handleOnionMessage hands the given onion message to the onion message
+// handler, unless the peer exceeds its onion message rate limit. Onion messages
+// are best-effort, so failing to process one isn't treated as a peer error.
+func (p *Brontide) handleOnionMessage(msg *lnwire.OnionMessage) {
+	if p.cfg.HandleOnionMessage == nil {
+		p.log.Debugf("Dropping onion message, onion messages are " +
+			"disabled")
+
+		return
+	}
+
+	if !p.onionMsgLimiter.Allow() {
+		p.log.Debugf("Dropping onion message, rate limit exceeded")
+
+		return
+	}
+
+	if err := p.cfg.HandleOnionMessage(p.PubKey(), msg); err != nil {
+		p.log.Debugf("Unable to handle onion message: %v", err)
+	}
+}
+
+// handlePeerStorage hands the backup the peer wants us to store to the peer
+// storage handler. Storing backups is a courtesy to our peers, so failing to
+// store one isn't treated as a peer error.
+func (p *Brontide) handlePeerStorage(msg *lnwire.PeerStorage) {
+	if p.cfg.HandlePeerStorage == nil {
+		p.log.Debugf("Dropping peer storage, peer storage is disabled")
+
+		return
+	}
+
+	if err := p.cfg.HandlePeerStorage(p.PubKey(), msg.Blob); err != nil {
+		p.log.Debugf("Unable to store peer backup: %v", err)
+	}
+}
+
+// handlePeerStorageRetrieval hands the backup the peer stores for us to the
+// peer storage retrieval handler.
+func (p *Brontide) handlePeerStorageRetrieval(
+	msg *lnwire.PeerStorageRetrieval) {
+
+	if p.cfg.HandlePeerStorageRetrieval == nil {
+		p.log.Debugf("Dropping peer storage retrieval, peer storage " +
+			"is disabled")
+
+		return
+	}
+
+	err := p.cfg.HandlePeerStorageRetrieval(p.PubKey(), msg.Blob)
+	if err != nil {
+		p.log.Warnf("Unable to handle backup returned by peer: %v",
+			err)
+	}
+}
+
 // isLoadedFromDisk returns true if the provided channel ID is loaded from
 // disk.
 //
@@ -2038,6 +2176,13 @@ func messageSummary(msg lnwire.Message) string {
 		return fmt.Sprintf("chan_id=%v, fee_sat=%v", msg.ChannelID,
 			msg.FeeSatoshis)
 
+	case *lnwire.ClosingComplete:
+		return fmt.Sprintf("chan_id=%v, fee_sat=%v, sequence=%v",
+			msg.ChannelID, msg.FeeSatoshis, msg.Sequence)
+
+	case *lnwire.ClosingSig:
+		return fmt.Sprintf("chan_id=%v", msg.ChannelID)
+
 	case *lnwire.UpdateAddHTLC:
 		var blindingPoint []byte
 		msg.BlindingPoint.WhenSome(
@@ -2094,6 +2239,16 @@ func messageSummary(msg lnwire.Message) string {
 			msg.ShortChannelID.ToUint64(), msg.MessageFlags,
 			msg.ChannelFlags, time.Unix(int64(msg.Timestamp), 0))
 
+	case *lnwire.ChannelAnnouncement2:
+		return fmt.Sprintf("chain_hash=%v, short_chan_id=%v",
+			msg.ChainHash, msg.ShortChannelID.ToUint64())
+
+	case *lnwire.ChannelUpdate2:
+		return fmt.Sprintf("chain_hash=%v, short_chan_id=%v, "+
+			"disable_flags=%v, block_height=%v", msg.ChainHash,
+			msg.ShortChannelID.ToUint64(), msg.DisableFlags,
+			msg.BlockHeight)
+
 	case *lnwire.NodeAnnouncement:
 		return fmt.Sprintf("node=%x, update_time=%v",
 			msg.NodeID, time.Unix(int64(msg.Timestamp), 0))
@@ -2138,6 +2293,16 @@ func messageSummary(msg lnwire.Message) string {
 			time.Unix(int64(msg.FirstTimestamp), 0),
 			msg.TimestampRange)
 
+	case *lnwire.OnionMessage:
+		return fmt.Sprintf("path_key=%x, onion_len=%d",
+			msg.PathKey.SerializeCompressed(), len(msg.OnionBlob))
+
+	case *lnwire.PeerStorage:
+		return fmt.Sprintf("blob_len=%d", len(msg.Blob))
+
+	case *lnwire.PeerStorageRetrieval:
+		return fmt.Sprintf("blob_len=%d", len(msg.Blob))
+
 	case *lnwire.Custom:
 		return fmt.Sprintf("type=%d", msg.Type)
 	}
@@ -2156,7 +2321,23 @@ func (p *Brontide) logWireMessage(msg lnwire.Message, read bool) {
 		summaryPrefix = "Sending"
 	}
 
-	p.log.Debugf("%v", newLogClosure(func() string {
+	// Messages that belong to the lifecycle of a payment are logged with
+	// its trace ID.
+	msgLog := p.log
+	switch m := msg.(type) {
+	case *lnwire.UpdateAddHTLC:
+		msgLog = build.NewTraceLog(
+			build.PaymentTraceID(m.PaymentHash), p.log,
+		)
+
+	case *lnwire.UpdateFulfillHTLC:
+		preimage := lntypes.Preimage(m.PaymentPreimage)
+		msgLog = build.NewTraceLog(
+			build.PaymentTraceID(preimage.Hash()), p.log,
+		)
+	}
+
+	msgLog.Debugf("%v", newLogClosure(func() string {
 		// Debug summary of message.
 		summary := messageSummary(msg)
 		if len(summary) > 0 {
@@ -2184,7 +2365,7 @@ func (p *Brontide) logWireMessage(msg lnwire.Message, read bool) {
 		prefix = "writeMessage to peer"
 	}
 
-	p.log.Tracef(prefix+": %v", newLogClosure(func() string {
+	msgLog.Tracef(prefix+": %v", newLogClosure(func() string {
 		return spew.Sdump(msg)
 	}))
 }
@@ -2259,6 +2440,10 @@ func (p *Brontide) writeMessage(msg lnwire.Message) error {
 		return err
 	}
 
+	// The message is buffered on the connection now, a failed flush is
+	// retried without writing the message again.
+	p.msgsSent.inc(msg.MsgType())
+
 	return flushMsg()
 }
 
@@ -2420,6 +2605,12 @@ func (p *Brontide) PingTime() int64 {
 	return p.pingManager.GetPingTimeMicroSeconds()
 }
 
+// PingTimeHistory returns the round trip times of the most recent pings to
+// the peer, oldest first.
+func (p *Brontide) PingTimeHistory() []time.Duration {
+	return p.pingManager.GetPingHistory()
+}
+
 // queueMsg adds the lnwire.Message to the back of the high priority send queue.
 // If the errChan is non-nil, an error is sent back if the msg failed to queue
 // or failed to write, and nil otherwise.
@@ -2639,6 +2830,15 @@ func (p *Brontide) reenableActiveChannels() {
 
 			continue
 
+		// Channels disabled due to forwarding failures are re-enabled
+		// by the ChanStatusManager once their timeout expired.
+		case errors.Is(err, netann.ErrEnableFailureDisabledChan):
+			p.log.Debugf("Channel(%v) was disabled due to "+
+				"forwarding failures, ignoring automatic "+
+				"enable request", chanPoint)
+
+			continue
+
 		// If the channel is reported as inactive, we will give it
 		// another chance. When handling the request, ChanStatusManager
 		// will check whether the link is active or not. One of the
@@ -2729,7 +2929,7 @@ func (p *Brontide) fetchActiveChanCloser(chanID lnwire.ChannelID) (
 	}
 
 	chanCloser, err = p.createChanCloser(
-		channel, deliveryScript, feePerKw, nil, false,
+		channel, deliveryScript, nil, feePerKw, nil, false,
 	)
 	if err != nil {
 		p.log.Errorf("unable to create chan closer: %v", err)
@@ -2926,15 +3126,19 @@ func (p *Brontide) restartCoopClose(lnChan *lnwallet.LightningChannel) (
 		return nil, nil
 	}
 
-	var deliveryScript []byte
+	var (
+		deliveryScript []byte
+		closeOutputs   lnwire.CloseOutputs
+	)
 
 	shutdownInfo, err := c.ShutdownInfo()
 	switch {
-	// We have previously stored the delivery script that we need to use
-	// in the shutdown message. Re-use this script.
+	// We have previously stored the delivery script and close outputs that
+	// we need to use in the shutdown message. Re-use them.
 	case err == nil:
 		shutdownInfo.WhenSome(func(info channeldb.ShutdownInfo) {
 			deliveryScript = info.DeliveryScript.Val
+			closeOutputs = shutdownCloseOutputs(info)
 		})
 
 	// An error other than ErrNoShutdownInfo was returned
@@ -2971,7 +3175,8 @@ func (p *Brontide) restartCoopClose(lnChan *lnwallet.LightningChannel) (
 	)
 
 	chanCloser, err := p.createChanCloser(
-		lnChan, deliveryScript, feePerKw, nil, locallyInitiated,
+		lnChan, deliveryScript, closeOutputs, feePerKw, nil,
+		locallyInitiated,
 	)
 	if err != nil {
 		p.log.Errorf("unable to create chan closer: %v", err)
@@ -2995,11 +3200,24 @@ func (p *Brontide) restartCoopClose(lnChan *lnwallet.LightningChannel) (
 	return shutdownMsg, nil
 }
 
+// shutdownCloseOutputs returns the additional close outputs that were persisted
+// as part of the given shutdown info, if any.
+func shutdownCloseOutputs(info channeldb.ShutdownInfo) lnwire.CloseOutputs {
+	var closeOutputs lnwire.CloseOutputs
+	info.CloseOutputs.WhenSome(
+		func(r tlv.RecordT[tlv.TlvType2, lnwire.CloseOutputs]) {
+			closeOutputs = r.Val
+		},
+	)
+
+	return closeOutputs
+}
+
 // createChanCloser constructs a ChanCloser from the passed parameters and is
 // used to de-duplicate code.
 func (p *Brontide) createChanCloser(channel *lnwallet.LightningChannel,
-	deliveryScript lnwire.DeliveryAddress, fee chainfee.SatPerKWeight,
-	req *htlcswitch.ChanClose,
+	deliveryScript lnwire.DeliveryAddress, closeOutputs lnwire.CloseOutputs,
+	fee chainfee.SatPerKWeight, req *htlcswitch.ChanClose,
 	locallyInitiated bool) (*chancloser.ChanCloser, error) {
 
 	_, startingHeight, err := p.cfg.ChainIO.GetBestBlock()
@@ -3014,6 +3232,12 @@ func (p *Brontide) createChanCloser(channel *lnwallet.LightningChannel,
 		maxFee = req.MaxFee
 	}
 
+	// We only accept additional close outputs requested by the remote
+	// party if we signal support for them.
+	acceptCloseOutputs := p.LocalFeatures().HasFeature(
+		lnwire.SplitCloseOutputsOptional,
+	)
+
 	chanCloser := chancloser.NewChanCloser(
 		chancloser.ChanCloseCfg{
 			Channel:      channel,
@@ -3029,8 +3253,11 @@ func (p *Brontide) createChanCloser(channel *lnwallet.LightningChannel,
 			Disconnect: func() error {
 				return p.cfg.DisconnectPeer(p.IdentityKey())
 			},
-			ChainParams: &p.cfg.Wallet.Cfg.NetParams,
-			Quit:        p.quit,
+			ChainParams:        &p.cfg.Wallet.Cfg.NetParams,
+			Quit:               p.quit,
+			CloseOutputs:       closeOutputs,
+			AcceptCloseOutputs: acceptCloseOutputs,
+			EnableRbf:          p.rbfCoopCloseAllowed(channel),
 		},
 		deliveryScript,
 		fee,
@@ -3042,11 +3269,65 @@ func (p *Brontide) createChanCloser(channel *lnwallet.LightningChannel,
 	return chanCloser, nil
 }
 
+// checkCloseOutputs returns an error if our settled balance in the given
+// channel can't be split across additional close outputs.
+func (p *Brontide) checkCloseOutputs(channel *lnwallet.LightningChannel) error {
+	if !p.LocalFeatures().HasFeature(lnwire.SplitCloseOutputsOptional) {
+		return fmt.Errorf("split close outputs not enabled, start " +
+			"lnd with --protocol.split-close-outputs")
+	}
+
+	if !p.remoteFeatures.HasFeature(lnwire.SplitCloseOutputsOptional) {
+		return fmt.Errorf("peer %x does not support split close "+
+			"outputs", p.PubKey())
+	}
+
+	if len(channel.LocalUpfrontShutdownScript()) != 0 {
+		return fmt.Errorf("close outputs can't be used with an " +
+			"upfront shutdown script")
+	}
+
+	return nil
+}
+
+// handleRbfCloseReq bumps the fee of the closing transaction of a channel that
+// is being closed using the RBF coop close protocol, by sending a new close
+// proposal to the remote party.
+func (p *Brontide) handleRbfCloseReq(chanCloser *chancloser.ChanCloser,
+	req *htlcswitch.ChanClose) {
+
+	closingComplete, err := chanCloser.ProposeRbfClose(
+		req.TargetFeePerKw, req,
+	)
+	if err != nil {
+		err = fmt.Errorf("unable to bump fee of closing tx for "+
+			"ChannelPoint(%v): %w", req.ChanPoint, err)
+		p.log.Error(err)
+		req.Err <- err
+
+		return
+	}
+
+	p.queueMsg(closingComplete, nil)
+}
+
 // handleLocalCloseReq kicks-off the workflow to execute a cooperative or
 // forced unilateral closure of the channel initiated by a local subsystem.
 func (p *Brontide) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 	chanID := lnwire.NewChanIDFromOutPoint(*req.ChanPoint)
 
+	// If the channel is already being closed using the RBF coop close
+	// protocol, then the request bumps the fee of the closing transaction.
+	// We check this first, as the channel is no longer active once a
+	// closing transaction has been broadcast.
+	chanCloser, ok := p.activeChanCloses[chanID]
+	if ok && chanCloser.RbfEnabled() &&
+		req.CloseType == contractcourt.CloseRegular {
+
+		p.handleRbfCloseReq(chanCloser, req)
+		return
+	}
+
 	channel, ok := p.activeChannels.Load(chanID)
 
 	// Though this function can't be called for pending channels, we still
@@ -3064,6 +3345,19 @@ func (p *Brontide) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 	// out this channel on-chain, so we execute the cooperative channel
 	// closure workflow.
 	case contractcourt.CloseRegular:
+		// If a closing transaction has already been negotiated without
+		// the RBF coop close protocol, then its fee can't be bumped,
+		// and a new closure can't be started either.
+		coopBroadcasted := channeldb.ChanStatusCoopBroadcasted
+		if channel.State().HasChanStatus(coopBroadcasted) {
+			err := fmt.Errorf("cannot close channel %v: "+
+				"cooperative close already in progress",
+				req.ChanPoint)
+			p.log.Error(err)
+			req.Err <- err
+			return
+		}
+
 		// First, we'll choose a delivery address that we'll use to send the
 		// funds to in the case of a successful negotiation.
 
@@ -3092,8 +3386,23 @@ func (p *Brontide) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 			}
 		}
 
+		// Splitting our balance across additional outputs requires
+		// the remote peer to understand the close outputs in our
+		// Shutdown message. As an upfront shutdown script commits us
+		// to a single payout script, both can't be combined.
+		if len(req.CloseOutputs) != 0 {
+			err := p.checkCloseOutputs(channel)
+			if err != nil {
+				p.log.Errorf("cannot close channel %v: %v",
+					req.ChanPoint, err)
+				req.Err <- err
+				return
+			}
+		}
+
 		chanCloser, err := p.createChanCloser(
-			channel, deliveryScript, req.TargetFeePerKw, req, true,
+			channel, deliveryScript, req.CloseOutputs,
+			req.TargetFeePerKw, req, true,
 		)
 		if err != nil {
 			p.log.Errorf(err.Error())
@@ -3187,6 +3496,7 @@ func (p *Brontide) handleLinkFailure(failure linkFailureReport) {
 
 		closeTx, err := p.cfg.ChainArb.ForceCloseContract(
 			failure.chanPoint,
+			contractcourt.ForceCloseFeePreference{},
 		)
 		if err != nil {
 			p.log.Errorf("unable to force close "+
@@ -3328,6 +3638,101 @@ func (p *Brontide) finalizeChanClosure(chanCloser *chancloser.ChanCloser) {
 		})
 }
 
+// finalizeRbfClosure is the RBF counterpart of finalizeChanClosure, which is
+// called each time a new closing transaction has been broadcast. The passed
+// request, if any, is notified of the new closing transaction. The state
+// machine is kept around, so the fee of the closing transaction can be bumped
+// until one of the closing transactions confirms.
+func (p *Brontide) finalizeRbfClosure(chanCloser *chancloser.ChanCloser,
+	req *htlcswitch.ChanClose, firstClose bool) {
+
+	closingTx, err := chanCloser.ClosingTx()
+	if err != nil {
+		p.log.Error(err)
+		if req != nil {
+			req.Err <- err
+		}
+
+		return
+	}
+
+	closingTxid := closingTx.TxHash()
+	if req != nil {
+		req.Updates <- &PendingUpdate{
+			Txid: closingTxid[:],
+		}
+	}
+
+	// The indexes of the channel only need to be cleared, and the
+	// confirmation only needs to be awaited, once.
+	if !firstClose {
+		return
+	}
+
+	chanPoint := chanCloser.Channel().ChannelPoint()
+	p.WipeChannel(&chanPoint)
+
+	// If this channel closure is not locally initiated, closeReq will be
+	// nil, so any error is ignored.
+	closeReq := chanCloser.CloseRequest()
+	errChan := make(chan error, 1)
+	if closeReq != nil {
+		errChan = closeReq.Err
+	}
+
+	// As any of the closing transactions may confirm, we'll wait for the
+	// funding output to be spent rather than for a specific transaction.
+	fundingScript := chanCloser.Channel().FundingTxOut().PkScript
+	go waitForCoopCloseSpend(chanCloser.NegotiationHeight(),
+		p.cfg.ChainNotifier, errChan, &chanPoint, fundingScript,
+		func(closingTxid chainhash.Hash) {
+			// Respond to the local subsystem which requested the
+			// channel closure.
+			if closeReq != nil {
+				closeReq.Updates <- &ChannelCloseUpdate{
+					ClosingTxid: closingTxid[:],
+					Success:     true,
+				}
+			}
+		})
+}
+
+// waitForCoopCloseSpend uses the passed notifier to wait until the funding
+// output of the channel has been spent on chain, and then executes the
+// callback with the txid of the spending transaction. If any error is
+// encountered, then it will be sent over the errChan.
+func waitForCoopCloseSpend(bestHeight uint32,
+	notifier chainntnfs.ChainNotifier, errChan chan error,
+	chanPoint *wire.OutPoint, fundingScript []byte,
+	cb func(chainhash.Hash)) {
+
+	peerLog.Infof("Waiting for confirmation of close of ChannelPoint(%v)",
+		chanPoint)
+
+	spendNtfn, err := notifier.RegisterSpendNtfn(
+		chanPoint, fundingScript, bestHeight,
+	)
+	if err != nil {
+		if errChan != nil {
+			errChan <- err
+		}
+		return
+	}
+	defer spendNtfn.Cancel()
+
+	// In the case that the ChainNotifier is shutting down, all subscriber
+	// notification channels will be closed, generating a nil receive.
+	spend, ok := <-spendNtfn.Spend
+	if !ok {
+		return
+	}
+
+	peerLog.Infof("ChannelPoint(%v) is now closed by %v at height %v",
+		chanPoint, spend.SpenderTxHash, spend.SpendingHeight)
+
+	cb(*spend.SpenderTxHash)
+}
+
 // WaitForChanToClose uses the passed notifier to wait until the channel has
 // been detected as closed on chain and then concludes by executing the
 // following actions: the channel point will be sent over the settleChan, and
@@ -3447,6 +3852,14 @@ func (p *Brontide) hasNegotiatedScidAlias() bool {
 	return peerHas && localHas
 }
 
+// hasNegotiatedPtlc returns true if we've negotiated the experimental ptlc
+// feature bit with the peer.
+func (p *Brontide) hasNegotiatedPtlc() bool {
+	peerHas := p.remoteFeatures.HasFeature(lnwire.PtlcOptionalStaging)
+	localHas := p.cfg.Features.HasFeature(lnwire.PtlcOptionalStaging)
+	return peerHas && localHas
+}
+
 // sendInitMsg sends the Init message to the remote peer. This message contains
 // our currently supported local and global features.
 func (p *Brontide) sendInitMsg(legacyChan bool) error {
@@ -3754,6 +4167,28 @@ func (p *Brontide) handleCloseMsg(msg *closeMsg) {
 		p.Disconnect(err)
 	}
 
+	// Once a closing transaction has been broadcast, the channel can only
+	// be closed on chain. A failed fee bump therefore leaves the channel
+	// as is, and only the request that triggered the bump is failed.
+	handleRbfErr := func(err error, req *htlcswitch.ChanClose) {
+		if _, txErr := chanCloser.ClosingTx(); txErr != nil {
+			handleErr(err)
+			return
+		}
+
+		err = fmt.Errorf("unable to process rbf close msg: %w", err)
+		p.log.Warn(err)
+
+		if req != nil {
+			req.Err <- err
+		}
+
+		p.queueMsg(&lnwire.Warning{
+			ChanID: msg.cid,
+			Data:   lnwire.WarningData(err.Error()),
+		}, nil)
+	}
+
 	// Next, we'll process the next message using the target state machine.
 	// We'll either continue negotiation, or halt.
 	switch typed := msg.msg.(type) {
@@ -3794,7 +4229,39 @@ func (p *Brontide) handleCloseMsg(msg *closeMsg) {
 			})
 		})
 
+		beginRbfNegotiation := func() {
+			oComplete, err := chanCloser.BeginRbfNegotiation()
+			if err != nil {
+				handleErr(err)
+				return
+			}
+
+			oComplete.WhenSome(func(msg lnwire.ClosingComplete) {
+				p.queueMsg(&msg, nil)
+			})
+
+			// A proposal of the remote party that arrived before
+			// the channel was flushed is handed back to the
+			// channelManager goroutine to be processed.
+			cached := chanCloser.TakeCachedClosingComplete()
+			cached.WhenSome(func(cc lnwire.ClosingComplete) {
+				go func() {
+					select {
+					case p.chanCloseMsgs <- &closeMsg{
+						msg.cid, &cc,
+					}:
+					case <-p.quit:
+					}
+				}()
+			})
+		}
+
 		beginNegotiation := func() {
+			if chanCloser.RbfEnabled() {
+				beginRbfNegotiation()
+				return
+			}
+
 			oClosingSigned, err := chanCloser.BeginNegotiation()
 			if err != nil {
 				handleErr(err)
@@ -3830,6 +4297,44 @@ func (p *Brontide) handleCloseMsg(msg *closeMsg) {
 			p.queueMsg(&msg, nil)
 		})
 
+	case *lnwire.ClosingComplete:
+		_, err := chanCloser.ClosingTx()
+		firstClose := err != nil
+
+		oClosingSig, err := chanCloser.ReceiveClosingComplete(*typed)
+		if err != nil {
+			handleRbfErr(err, nil)
+			return
+		}
+
+		// If no signature is returned, the proposal was cached until
+		// our side of the channel is flushed.
+		if oClosingSig.IsNone() {
+			return
+		}
+
+		oClosingSig.WhenSome(func(msg lnwire.ClosingSig) {
+			p.queueMsg(&msg, nil)
+		})
+
+		p.finalizeRbfClosure(chanCloser, nil, firstClose)
+
+		return
+
+	case *lnwire.ClosingSig:
+		_, err := chanCloser.ClosingTx()
+		firstClose := err != nil
+
+		req := chanCloser.RbfCloseRequest()
+		if err := chanCloser.ReceiveClosingSig(*typed); err != nil {
+			handleRbfErr(err, req)
+			return
+		}
+
+		p.finalizeRbfClosure(chanCloser, req, firstClose)
+
+		return
+
 	default:
 		panic("impossible closeMsg type")
 	}
@@ -3905,6 +4410,17 @@ func (p *Brontide) BytesSent() uint64 {
 	return atomic.LoadUint64(&p.bytesSent)
 }
 
+// MessagesReceived returns the number of messages received from the peer by
+// their type.
+func (p *Brontide) MessagesReceived() map[lnwire.MessageType]uint64 {
+	return p.msgsReceived.snapshot()
+}
+
+// MessagesSent returns the number of messages sent to the peer by their type.
+func (p *Brontide) MessagesSent() map[lnwire.MessageType]uint64 {
+	return p.msgsSent.snapshot()
+}
+
 // LastRemotePingPayload returns the last payload the remote party sent as part
 // of their ping.
 func (p *Brontide) LastRemotePingPayload() []byte {
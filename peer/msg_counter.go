@@ -0,0 +1,38 @@
+package peer
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// msgCounter counts the messages that are exchanged with a peer by their
+// type. The zero value is ready to use.
+type msgCounter struct {
+	counts map[lnwire.MessageType]uint64
+	mu     sync.Mutex
+}
+
+// inc increments the count of messages of the given type.
+func (c *msgCounter) inc(msgType lnwire.MessageType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[lnwire.MessageType]uint64)
+	}
+	c.counts[msgType]++
+}
+
+// snapshot returns a copy of the message counts.
+func (c *msgCounter) snapshot() map[lnwire.MessageType]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[lnwire.MessageType]uint64, len(c.counts))
+	for msgType, count := range c.counts {
+		counts[msgType] = count
+	}
+
+	return counts
+}
@@ -0,0 +1,31 @@
+package peer
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMsgCounter tests that messages are counted by their type and that
+// snapshots aren't affected by later messages.
+func TestMsgCounter(t *testing.T) {
+	t.Parallel()
+
+	var counter msgCounter
+	require.Empty(t, counter.snapshot())
+
+	counter.inc(lnwire.MsgPing)
+	counter.inc(lnwire.MsgPing)
+	counter.inc(lnwire.MsgUpdateAddHTLC)
+
+	snapshot := counter.snapshot()
+	require.Equal(t, map[lnwire.MessageType]uint64{
+		lnwire.MsgPing:          2,
+		lnwire.MsgUpdateAddHTLC: 1,
+	}, snapshot)
+
+	counter.inc(lnwire.MsgPing)
+	require.EqualValues(t, 2, snapshot[lnwire.MsgPing])
+	require.EqualValues(t, 3, counter.snapshot()[lnwire.MsgPing])
+}
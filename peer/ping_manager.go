@@ -10,6 +10,10 @@ import (
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
+// pingHistorySize is the number of ping RTTs that are kept in the ping
+// history.
+const pingHistorySize = 10
+
 // PingManagerConfig is a structure containing various parameters that govern
 // how the PingManager behaves.
 type PingManagerConfig struct {
@@ -53,6 +57,10 @@ type PingManager struct {
 	// TODO(roasbeef): also use a WMA or EMA?
 	pingTime atomic.Pointer[time.Duration]
 
+	// pingHistory holds the RTTs of the most recent pings, oldest first.
+	pingHistory   []time.Duration
+	pingHistoryMu sync.Mutex
+
 	// pingLastSend is the time when we sent our last ping message.
 	// To be used atomically.
 	pingLastSend *time.Time
@@ -186,8 +194,7 @@ func (m *PingManager) pingHandler() {
 			// Compute RTT of ping and save that for future
 			// querying.
 			if lastPing != nil {
-				rtt := time.Since(*lastPing)
-				m.pingTime.Store(&rtt)
+				m.recordPingTime(time.Since(*lastPing))
 			}
 
 		case <-m.quit:
@@ -239,6 +246,31 @@ func (m *PingManager) resetPingState() {
 	}
 }
 
+// recordPingTime stores the RTT of the latest ping and adds it to the ping
+// history, evicting the oldest RTT once the history is full.
+func (m *PingManager) recordPingTime(rtt time.Duration) {
+	m.pingTime.Store(&rtt)
+
+	m.pingHistoryMu.Lock()
+	defer m.pingHistoryMu.Unlock()
+
+	m.pingHistory = append(m.pingHistory, rtt)
+	if len(m.pingHistory) > pingHistorySize {
+		m.pingHistory = m.pingHistory[1:]
+	}
+}
+
+// GetPingHistory returns the RTTs of the most recent pings, oldest first.
+func (m *PingManager) GetPingHistory() []time.Duration {
+	m.pingHistoryMu.Lock()
+	defer m.pingHistoryMu.Unlock()
+
+	history := make([]time.Duration, len(m.pingHistory))
+	copy(history, m.pingHistory)
+
+	return history
+}
+
 // GetPingTimeMicroSeconds reports back the RTT calculated by the pingManager.
 func (m *PingManager) GetPingTimeMicroSeconds() int64 {
 	rtt := m.pingTime.Load()
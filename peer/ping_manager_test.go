@@ -86,3 +86,25 @@ func TestPingManager(t *testing.T) {
 		mgr.Stop()
 	}
 }
+
+// TestPingManagerHistory tests that the ping history keeps the most recent
+// RTTs, oldest first.
+func TestPingManagerHistory(t *testing.T) {
+	t.Parallel()
+
+	mgr := NewPingManager(&PingManagerConfig{})
+	require.Empty(t, mgr.GetPingHistory())
+	require.EqualValues(t, -1, mgr.GetPingTimeMicroSeconds())
+
+	for i := 1; i <= pingHistorySize+2; i++ {
+		mgr.recordPingTime(time.Duration(i) * time.Millisecond)
+	}
+
+	history := mgr.GetPingHistory()
+	require.Len(t, history, pingHistorySize)
+	require.Equal(t, 3*time.Millisecond, history[0])
+	require.Equal(t, (pingHistorySize+2)*time.Millisecond,
+		history[pingHistorySize-1])
+	require.EqualValues(t, (pingHistorySize+2)*1000,
+		mgr.GetPingTimeMicroSeconds())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/davecgh/go-spew/spew"
@@ -85,6 +86,55 @@ func ValidateChannelAnn(a *lnwire.ChannelAnnouncement) error {
 
 }
 
+// ValidateChannelAnn2 validates the gossip v2 channel announcement message by
+// checking that its Schnorr signature covers the announcement and was created
+// by the MuSig2 aggregate of the node keys and, if set, the bitcoin keys.
+func ValidateChannelAnn2(a *lnwire.ChannelAnnouncement2) error {
+	// Either both or none of the bitcoin keys must be set.
+	if a.BitcoinKey1.IsSome() != a.BitcoinKey2.IsSome() {
+		return errors.New("only one bitcoin key set in channel " +
+			"announcement")
+	}
+
+	rawKeys := [][33]byte{a.NodeID1, a.NodeID2}
+	a.BitcoinKey1.WhenSome(func(key [33]byte) {
+		rawKeys = append(rawKeys, key)
+	})
+	a.BitcoinKey2.WhenSome(func(key [33]byte) {
+		rawKeys = append(rawKeys, key)
+	})
+
+	keys := make([]*btcec.PublicKey, 0, len(rawKeys))
+	for _, rawKey := range rawKeys {
+		key, err := btcec.ParsePubKey(rawKey[:])
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, key)
+	}
+
+	aggKey, _, _, err := musig2.AggregateKeys(keys, true)
+	if err != nil {
+		return err
+	}
+
+	digest, err := a.DigestToSign()
+	if err != nil {
+		return err
+	}
+
+	sig, err := a.Signature.ToSignature()
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(digest[:], aggKey.FinalKey) {
+		return errors.New("can't verify channel announcement signature")
+	}
+
+	return nil
+}
+
 // ValidateNodeAnn validates the node announcement by ensuring that the
 // attached signature is needed a signature of the node announcement under the
 // specified node public key.
@@ -188,3 +238,67 @@ func ValidateChannelUpdateFields(capacity btcutil.Amount,
 
 	return nil
 }
+
+// ValidateChannelUpdate2Ann validates the gossip v2 channel update message by
+// checking (1) that the included Schnorr signature covers the update and has
+// been created by the node's private key, and (2) that its fields are sane.
+func ValidateChannelUpdate2Ann(pubKey *btcec.PublicKey,
+	capacity btcutil.Amount, a *lnwire.ChannelUpdate2) error {
+
+	if err := ValidateChannelUpdate2Fields(capacity, a); err != nil {
+		return err
+	}
+
+	return VerifyChannelUpdate2Signature(a, pubKey)
+}
+
+// VerifyChannelUpdate2Signature verifies that the gossip v2 channel update
+// message was signed by the party with the given node public key.
+func VerifyChannelUpdate2Signature(msg *lnwire.ChannelUpdate2,
+	pubKey *btcec.PublicKey) error {
+
+	digest, err := msg.DigestToSign()
+	if err != nil {
+		return fmt.Errorf("unable to reconstruct message data: %w", err)
+	}
+
+	nodeSig, err := msg.Signature.ToSignature()
+	if err != nil {
+		return err
+	}
+
+	if !nodeSig.Verify(digest[:], pubKey) {
+		return fmt.Errorf("invalid signature for channel update %v",
+			spew.Sdump(msg))
+	}
+
+	return nil
+}
+
+// ValidateChannelUpdate2Fields validates the fields of a gossip v2 channel
+// update. As the max_htlc field defaults to the capacity of the channel, it's
+// only checked if it's set.
+func ValidateChannelUpdate2Fields(capacity btcutil.Amount,
+	msg *lnwire.ChannelUpdate2) error {
+
+	maxHtlc := msg.HTLCMaximumMsat
+	if maxHtlc == 0 {
+		return nil
+	}
+
+	if maxHtlc < msg.HTLCMinimumMsat {
+		return errors.Errorf("invalid max htlc for channel "+
+			"update %v", spew.Sdump(msg))
+	}
+
+	// For light clients, the capacity will not be set so we'll skip
+	// checking whether the MaxHTLC value respects the channel's
+	// capacity.
+	capacityMsat := lnwire.NewMSatFromSatoshis(capacity)
+	if capacityMsat != 0 && maxHtlc > capacityMsat {
+		return errors.Errorf("max_htlc (%v) for channel update "+
+			"greater than capacity (%v)", maxHtlc, capacityMsat)
+	}
+
+	return nil
+}
@@ -78,6 +78,10 @@ type ControlTower interface {
 	// hash.
 	FetchPayment(paymentHash lntypes.Hash) (dbMPPayment, error)
 
+	// FetchPaymentHashByIdempotencyKey returns the hash of the payment
+	// that was initialized with the given idempotency key.
+	FetchPaymentHashByIdempotencyKey(key []byte) (lntypes.Hash, error)
+
 	// FailPayment transitions a payment into the Failed state, and records
 	// the ultimate reason the payment failed. Note that this should only
 	// be called when all active attempts are already failed. After
@@ -278,6 +282,14 @@ func (p *controlTower) FetchPayment(paymentHash lntypes.Hash) (
 	return p.db.FetchPayment(paymentHash)
 }
 
+// FetchPaymentHashByIdempotencyKey returns the hash of the payment that was
+// initialized with the given idempotency key.
+func (p *controlTower) FetchPaymentHashByIdempotencyKey(key []byte) (
+	lntypes.Hash, error) {
+
+	return p.db.FetchPaymentHashByIdempotencyKey(key)
+}
+
 // FailPayment transitions a payment into the Failed state, and records the
 // reason the payment failed. After invoking this method, InitPayment should
 // return nil on its next call for this payment hash, allowing the switch to
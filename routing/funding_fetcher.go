@@ -0,0 +1,157 @@
+package routing
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/neutrino/cache/lru"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// cachedFundingTx is a funding transaction stored in the funding tx cache.
+type cachedFundingTx struct {
+	tx *wire.MsgTx
+}
+
+// Size returns the "size" of an entry. We return 1 as we just want to limit
+// the total number of entries rather than do accurate size accounting.
+func (c *cachedFundingTx) Size() (uint64, error) {
+	return 1, nil
+}
+
+// fundingTxFetcher validates announced channels against the chain backend.
+// It optionally bounds the number of concurrent block fetches and UTXO
+// lookups, and caches the funding transactions it fetched. This allows light
+// clients, for which every validation requires downloading full blocks from
+// the P2P network, to validate the graph without being overwhelmed by the
+// number of announcements received during the initial graph sync.
+type fundingTxFetcher struct {
+	chain lnwallet.BlockChainIO
+
+	// sem bounds the number of concurrent calls to the chain backend. It
+	// is nil if the number of calls is unbounded.
+	sem chan struct{}
+
+	// cache holds the funding transactions that were recently fetched,
+	// indexed by their short channel ID. It is nil if caching is disabled.
+	cache *lru.Cache[uint64, *cachedFundingTx]
+}
+
+// newFundingTxFetcher creates a new fundingTxFetcher. A maxConcurrent of zero
+// doesn't bound the number of concurrent calls and a cacheSize of zero
+// disables the cache.
+func newFundingTxFetcher(chain lnwallet.BlockChainIO, maxConcurrent,
+	cacheSize int) *fundingTxFetcher {
+
+	f := &fundingTxFetcher{
+		chain: chain,
+	}
+	if maxConcurrent > 0 {
+		f.sem = make(chan struct{}, maxConcurrent)
+	}
+	if cacheSize > 0 {
+		f.cache = lru.NewCache[uint64, *cachedFundingTx](
+			uint64(cacheSize),
+		)
+	}
+
+	return f
+}
+
+// acquire blocks until a slot for a call to the chain backend is available or
+// the quit channel is closed.
+func (f *fundingTxFetcher) acquire(quit <-chan struct{}) error {
+	if f.sem == nil {
+		return nil
+	}
+
+	select {
+	case f.sem <- struct{}{}:
+		return nil
+
+	case <-quit:
+		return ErrRouterShuttingDown
+	}
+}
+
+// release frees a slot acquired with acquire.
+func (f *fundingTxFetcher) release() {
+	if f.sem == nil {
+		return
+	}
+
+	<-f.sem
+}
+
+// fetchFundingTx returns the funding transaction identified by the passed
+// short channel ID.
+//
+// TODO(roasbeef): replace with call to GetBlockTransaction? (would allow to
+// later use getblocktxn)
+func (f *fundingTxFetcher) fetchFundingTx(chanID *lnwire.ShortChannelID,
+	quit <-chan struct{}) (*wire.MsgTx, error) {
+
+	scid := chanID.ToUint64()
+	if f.cache != nil {
+		if cached, err := f.cache.Get(scid); err == nil {
+			log.Tracef("Using cached funding tx for "+
+				"ChannelID(%v)", chanID)
+
+			return cached.tx.Copy(), nil
+		}
+	}
+
+	if err := f.acquire(quit); err != nil {
+		return nil, err
+	}
+	defer f.release()
+
+	// First fetch the block hash by the block number encoded, then use
+	// that hash to fetch the block itself.
+	blockNum := int64(chanID.BlockHeight)
+	blockHash, err := f.chain.GetBlockHash(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	fundingBlock, err := f.chain.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// As a sanity check, ensure that the advertised transaction index is
+	// within the bounds of the total number of transactions within a
+	// block.
+	numTxns := uint32(len(fundingBlock.Transactions))
+	if chanID.TxIndex > numTxns-1 {
+		return nil, fmt.Errorf("tx_index=#%v "+
+			"is out of range (max_index=%v), network_chan_id=%v",
+			chanID.TxIndex, numTxns-1, chanID)
+	}
+
+	fundingTx := fundingBlock.Transactions[chanID.TxIndex].Copy()
+	if f.cache != nil {
+		_, err := f.cache.Put(scid, &cachedFundingTx{
+			tx: fundingTx.Copy(),
+		})
+		if err != nil {
+			log.Warnf("Unable to cache funding tx for "+
+				"ChannelID(%v): %v", chanID, err)
+		}
+	}
+
+	return fundingTx, nil
+}
+
+// getUtxo returns the funding output at the given outpoint if it is still
+// unspent.
+func (f *fundingTxFetcher) getUtxo(op *wire.OutPoint, pkScript []byte,
+	heightHint uint32, quit chan struct{}) (*wire.TxOut, error) {
+
+	if err := f.acquire(quit); err != nil {
+		return nil, err
+	}
+	defer f.release()
+
+	return f.chain.GetUtxo(op, pkScript, heightHint, quit)
+}
@@ -0,0 +1,98 @@
+package routing
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// countingChain wraps a mockChain and counts the number of fetched blocks.
+type countingChain struct {
+	*mockChain
+
+	numBlocks atomic.Int32
+}
+
+func (c *countingChain) GetBlock(
+	blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+
+	c.numBlocks.Add(1)
+
+	return c.mockChain.GetBlock(blockHash)
+}
+
+// TestFundingTxFetcherCache tests that funding transactions are only fetched
+// from the chain once if the cache is enabled.
+func TestFundingTxFetcherCache(t *testing.T) {
+	t.Parallel()
+
+	const fundingHeight = 100
+
+	chain := &countingChain{mockChain: newMockChain(fundingHeight)}
+
+	fundingTx := wire.NewMsgTx(2)
+	fundingTx.AddTxOut(wire.NewTxOut(100_000, []byte{0x51}))
+	chain.addBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{fundingTx},
+	}, fundingHeight, 0)
+
+	chanID := lnwire.ShortChannelID{BlockHeight: fundingHeight}
+	quit := make(chan struct{})
+
+	// Without a cache, every fetch results in a block fetch.
+	fetcher := newFundingTxFetcher(chain, 1, 0)
+	for i := 0; i < 2; i++ {
+		tx, err := fetcher.fetchFundingTx(&chanID, quit)
+		require.NoError(t, err)
+		require.Equal(t, fundingTx.TxHash(), tx.TxHash())
+	}
+	require.EqualValues(t, 2, chain.numBlocks.Load())
+
+	// With a cache, only the first fetch results in a block fetch.
+	chain.numBlocks.Store(0)
+	fetcher = newFundingTxFetcher(chain, 1, 10)
+	for i := 0; i < 2; i++ {
+		tx, err := fetcher.fetchFundingTx(&chanID, quit)
+		require.NoError(t, err)
+		require.Equal(t, fundingTx.TxHash(), tx.TxHash())
+	}
+	require.EqualValues(t, 1, chain.numBlocks.Load())
+
+	// A transaction index that is out of range isn't cached.
+	chanID.TxIndex = 1
+	_, err := fetcher.fetchFundingTx(&chanID, quit)
+	require.ErrorContains(t, err, "out of range")
+	_, err = fetcher.fetchFundingTx(&chanID, quit)
+	require.ErrorContains(t, err, "out of range")
+	require.EqualValues(t, 3, chain.numBlocks.Load())
+}
+
+// TestFundingTxFetcherConcurrency tests that the fetcher doesn't allow more
+// than the configured number of concurrent calls to the chain backend.
+func TestFundingTxFetcherConcurrency(t *testing.T) {
+	t.Parallel()
+
+	fetcher := newFundingTxFetcher(newMockChain(0), 1, 0)
+
+	quit := make(chan struct{})
+	require.NoError(t, fetcher.acquire(quit))
+
+	// With the only slot taken, a fetch blocks until the router shuts
+	// down.
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := fetcher.getUtxo(&wire.OutPoint{}, nil, 0, quit)
+		errChan <- err
+	}()
+
+	close(quit)
+	require.ErrorIs(t, <-errChan, ErrRouterShuttingDown)
+
+	// Once the slot is released, it can be acquired again.
+	fetcher.release()
+	require.NoError(t, fetcher.acquire(make(chan struct{})))
+}
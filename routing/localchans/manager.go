@@ -29,6 +29,10 @@ type Manager struct {
 	PropagateChanPolicyUpdate func(
 		edgesToUpdate []discovery.EdgeWithInfo) error
 
+	// NotifyPolicyUpdate is called for every channel a new forwarding
+	// policy was applied to.
+	NotifyPolicyUpdate func(wire.OutPoint, models.ForwardingPolicy)
+
 	// ForAllOutgoingChannels is required to iterate over all our local
 	// channels.
 	ForAllOutgoingChannels func(cb func(kvdb.RTx,
@@ -177,6 +181,11 @@ func (r *Manager) UpdatePolicy(newSchema routing.ChannelPolicy,
 	// Update active links.
 	r.UpdateForwardingPolicies(policiesToUpdate)
 
+	// Finally, let subscribers know about the applied policies.
+	for chanPoint, policy := range policiesToUpdate {
+		r.NotifyPolicyUpdate(chanPoint, policy)
+	}
+
 	return failedUpdates, nil
 }
 
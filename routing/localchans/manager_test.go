@@ -75,6 +75,13 @@ func TestManager(t *testing.T) {
 		}
 	}
 
+	var notifiedPolicies []wire.OutPoint
+	notifyPolicyUpdate := func(chanPoint wire.OutPoint,
+		_ models.ForwardingPolicy) {
+
+		notifiedPolicies = append(notifiedPolicies, chanPoint)
+	}
+
 	propagateChanPolicyUpdate := func(
 		edgesToUpdate []discovery.EdgeWithInfo) error {
 
@@ -140,6 +147,7 @@ func TestManager(t *testing.T) {
 	manager := Manager{
 		UpdateForwardingPolicies:  updateForwardingPolicies,
 		PropagateChanPolicyUpdate: propagateChanPolicyUpdate,
+		NotifyPolicyUpdate:        notifyPolicyUpdate,
 		ForAllOutgoingChannels:    forAllOutgoingChannels,
 		FetchChannel:              fetchChannel,
 	}
@@ -240,6 +248,7 @@ func TestManager(t *testing.T) {
 			currentPolicy = test.currentPolicy
 			channelSet = test.channelSet
 			expectedNumUpdates = test.expectedNumUpdates
+			notifiedPolicies = nil
 
 			failedUpdates, err := manager.UpdatePolicy(test.newPolicy,
 				test.specifiedChanPoints...)
@@ -255,6 +264,11 @@ func TestManager(t *testing.T) {
 			}
 
 			require.Equal(t, test.expectErr, err)
+
+			// Every applied policy should have been notified.
+			require.Len(
+				t, notifiedPolicies, test.expectedNumUpdates,
+			)
 		})
 	}
 }
@@ -3,6 +3,7 @@ package routing
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -50,6 +51,10 @@ const (
 	// to the database.
 	DefaultMcFlushInterval = time.Second
 
+	// DefaultMcHistoryHalfLife is the default half-life with which the
+	// persisted pair history decays while it isn't loaded.
+	DefaultMcHistoryHalfLife = 24 * time.Hour
+
 	// prevSuccessProbability is the assumed probability for node pairs that
 	// successfully relayed the previous attempt.
 	prevSuccessProbability = 0.95
@@ -78,6 +83,11 @@ var (
 	// ErrInvalidFailureInterval is returned if we get an invalid failure
 	// interval.
 	ErrInvalidFailureInterval = errors.New("failure interval must be >= 0")
+
+	// ErrInvalidHistoryHalfLife is returned if we get a negative pair
+	// history half-life.
+	ErrInvalidHistoryHalfLife = errors.New("history half-life must be " +
+		">= 0")
 )
 
 // NodeResults contains previous results from a node to its peers.
@@ -106,6 +116,14 @@ type MissionControl struct {
 
 	store *missionControlStore
 
+	// pairStore persists the pair history across restarts. It is nil if
+	// the pair history isn't persisted.
+	pairStore MissionControlPairStore
+
+	// historyHalfLife is the half-life with which the persisted pair
+	// history decays while it isn't loaded.
+	historyHalfLife time.Duration
+
 	// estimator is the probability estimator that is used with the payment
 	// results that mission control collects.
 	estimator Estimator
@@ -136,6 +154,18 @@ type MissionControlConfig struct {
 	// since the previously recorded failure before the failure amount may
 	// be raised.
 	MinFailureRelaxInterval time.Duration
+
+	// PairStore is an optional store that persists the pair history,
+	// including imported results, across restarts. It is only used when
+	// mission control is created.
+	PairStore MissionControlPairStore
+
+	// HistoryHalfLife is the half-life with which the persisted pair
+	// history decays between it being stored and loaded again. Success
+	// amounts are halved and failure amounts are doubled with every
+	// half-life. Zero disables the decay. It is only used when mission
+	// control is created.
+	HistoryHalfLife time.Duration
 }
 
 func (c *MissionControlConfig) validate() error {
@@ -147,13 +177,18 @@ func (c *MissionControlConfig) validate() error {
 		return ErrInvalidFailureInterval
 	}
 
+	if c.HistoryHalfLife < 0 {
+		return ErrInvalidHistoryHalfLife
+	}
+
 	return nil
 }
 
 // String returns a string representation of a mission control config.
 func (c *MissionControlConfig) String() string {
 	return fmt.Sprintf("maximum history: %v, minimum failure relax "+
-		"interval: %v", c.MaxMcHistory, c.MinFailureRelaxInterval)
+		"interval: %v, history half-life: %v", c.MaxMcHistory,
+		c.MinFailureRelaxInterval, c.HistoryHalfLife)
 }
 
 // TimedPairResult describes a timestamped pair result.
@@ -205,30 +240,44 @@ type paymentResult struct {
 	failure            lnwire.FailureMessage
 }
 
-// NewMissionControl returns a new instance of missionControl.
+// NewMissionControl returns a new instance of missionControl for the default
+// namespace.
 func NewMissionControl(db kvdb.Backend, self route.Vertex,
 	cfg *MissionControlConfig) (*MissionControl, error) {
 
-	log.Debugf("Instantiating mission control with config: %v, %v", cfg,
-		cfg.Estimator)
+	return newNamespacedMissionControl(
+		db, self, cfg, DefaultMissionControlNamespace,
+	)
+}
+
+// newNamespacedMissionControl returns a new instance of missionControl that
+// keeps its results in the given namespace.
+func newNamespacedMissionControl(db kvdb.Backend, self route.Vertex,
+	cfg *MissionControlConfig, namespace string) (*MissionControl, error) {
+
+	log.Debugf("Instantiating mission control for namespace %v with "+
+		"config: %v, %v", namespace, cfg, cfg.Estimator)
 
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
 
 	store, err := newMissionControlStore(
-		db, cfg.MaxMcHistory, cfg.McFlushInterval,
+		db, namespace, cfg.MaxMcHistory, cfg.McFlushInterval,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	state := newMissionControlState(cfg.MinFailureRelaxInterval)
 	mc := &MissionControl{
-		state:     newMissionControlState(cfg.MinFailureRelaxInterval),
-		now:       time.Now,
-		selfNode:  self,
-		store:     store,
-		estimator: cfg.Estimator,
+		state:           state,
+		now:             time.Now,
+		selfNode:        self,
+		store:           store,
+		pairStore:       cfg.PairStore,
+		historyHalfLife: cfg.HistoryHalfLife,
+		estimator:       cfg.Estimator,
 	}
 
 	if err := mc.init(); err != nil {
@@ -249,6 +298,14 @@ func (m *MissionControl) StopStoreTicker() {
 	defer log.Debug("Mission control store ticker stopped")
 
 	m.store.stop()
+
+	m.Lock()
+	defer m.Unlock()
+
+	if err := m.storePairs(); err != nil {
+		log.Errorf("Unable to store mission control pair history: %v",
+			err)
+	}
 }
 
 // init initializes mission control with historical data.
@@ -266,12 +323,98 @@ func (m *MissionControl) init() error {
 		m.applyPaymentResult(result)
 	}
 
+	// The persisted pair history is merged in after the payment results,
+	// so that it only adds results that are newer than the ones that we
+	// already reconstructed.
+	if m.pairStore != nil {
+		pairs, storedTime, err := m.pairStore.FetchPairs()
+		if err != nil {
+			return err
+		}
+
+		age := m.now().Sub(storedTime)
+		decayed := make([]MissionControlPairSnapshot, 0, len(pairs))
+		for _, pair := range pairs {
+			result, ok := decayPairResult(
+				pair.TimedPairResult, age, m.historyHalfLife,
+			)
+			if !ok {
+				continue
+			}
+
+			decayed = append(decayed, MissionControlPairSnapshot{
+				Pair:            pair.Pair,
+				TimedPairResult: result,
+			})
+		}
+
+		imported := m.state.importSnapshot(
+			&MissionControlSnapshot{Pairs: decayed}, false,
+		)
+
+		log.Debugf("Loaded %v persisted mission control pairs with "+
+			"%v results", len(decayed), imported)
+	}
+
 	log.Debugf("Mission control state reconstruction finished: "+
 		"n=%v, time=%v", len(results), time.Since(start))
 
 	return nil
 }
 
+// storePairs persists the current pair history if a pair store is configured.
+//
+// NOTE: The caller must hold the mission control lock.
+func (m *MissionControl) storePairs() error {
+	if m.pairStore == nil {
+		return nil
+	}
+
+	return m.pairStore.StorePairs(m.state.getSnapshot().Pairs, m.now())
+}
+
+// decayPairResult decays the given pair result according to the time that
+// passed since it was stored. Success amounts are halved and failure amounts
+// doubled with every half-life, so that the stored knowledge gradually loses
+// its influence. A zero half-life disables the decay. False is returned if
+// nothing remains of the result.
+func decayPairResult(result TimedPairResult, age,
+	halfLife time.Duration) (TimedPairResult, bool) {
+
+	if halfLife == 0 || age <= 0 {
+		return result, true
+	}
+
+	factor := math.Exp2(-float64(age) / float64(halfLife))
+
+	result.SuccessAmt = lnwire.MilliSatoshi(
+		float64(result.SuccessAmt) * factor,
+	)
+	if result.SuccessAmt == 0 {
+		result.SuccessTime = time.Time{}
+	}
+
+	// Amount-independent failures, which have a zero amount, are kept as
+	// they are. The penalty of failures already fades with their age.
+	// Other failures are dropped once they exceed the maximum amount that
+	// could ever be sent.
+	if result.FailAmt != 0 {
+		maxAmt := lnwire.NewMSatFromSatoshis(btcutil.MaxSatoshi)
+		failAmt := float64(result.FailAmt) / factor
+
+		if failAmt > float64(maxAmt) {
+			result.FailTime = time.Time{}
+			result.FailAmt = 0
+		} else {
+			result.FailAmt = lnwire.MilliSatoshi(failAmt)
+		}
+	}
+
+	remaining := !result.FailTime.IsZero() || !result.SuccessTime.IsZero()
+
+	return result, remaining
+}
+
 // GetConfig returns the config that mission control is currently configured
 // with. All fields are copied by value, so we do not need to worry about
 // mutation.
@@ -284,6 +427,8 @@ func (m *MissionControl) GetConfig() *MissionControlConfig {
 		MaxMcHistory:            m.store.maxRecords,
 		McFlushInterval:         m.store.flushInterval,
 		MinFailureRelaxInterval: m.state.minFailureRelaxInterval,
+		PairStore:               m.pairStore,
+		HistoryHalfLife:         m.historyHalfLife,
 	}
 }
 
@@ -323,6 +468,10 @@ func (m *MissionControl) ResetHistory() error {
 
 	m.state.resetHistory()
 
+	if err := m.storePairs(); err != nil {
+		return err
+	}
+
 	log.Debugf("Mission control history cleared")
 
 	return nil
@@ -361,8 +510,8 @@ func (m *MissionControl) GetHistorySnapshot() *MissionControlSnapshot {
 }
 
 // ImportHistory imports the set of mission control results provided to our
-// in-memory state. These results are not persisted, so will not survive
-// restarts.
+// in-memory state. These results are only persisted, and therefore survive
+// restarts, if a pair store is configured.
 func (m *MissionControl) ImportHistory(history *MissionControlSnapshot,
 	force bool) error {
 
@@ -380,7 +529,7 @@ func (m *MissionControl) ImportHistory(history *MissionControlSnapshot,
 
 	log.Infof("Imported %v results to mission control", imported)
 
-	return nil
+	return m.storePairs()
 }
 
 // GetPairHistorySnapshot returns the stored history for a given node pair.
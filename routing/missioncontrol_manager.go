@@ -0,0 +1,251 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// DefaultMissionControlNamespace is the namespace of the mission
+	// control that is used if no namespace is specified.
+	DefaultMissionControlNamespace = "default"
+)
+
+var (
+	// ErrUnknownMissionControlNamespace is returned if a mission control
+	// namespace is requested that doesn't exist.
+	ErrUnknownMissionControlNamespace = errors.New("unknown mission " +
+		"control namespace")
+
+	// ErrMissionControlNamespaceExists is returned when adding a mission
+	// control namespace that already exists.
+	ErrMissionControlNamespaceExists = errors.New("mission control " +
+		"namespace already exists")
+
+	// ErrDefaultMissionControlNamespace is returned when trying to delete
+	// the default mission control namespace.
+	ErrDefaultMissionControlNamespace = errors.New("default mission " +
+		"control namespace can't be deleted")
+
+	// namespaceRegex is the pattern that mission control namespace names
+	// must match.
+	namespaceRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+)
+
+// MissionControlManager manages the mission control namespaces. Every
+// namespace has its own mission control with a separate reliability history,
+// so that different kinds of payments, for example probes, rebalances and
+// customer payments, don't influence each other.
+type MissionControlManager struct {
+	db       kvdb.Backend
+	selfNode route.Vertex
+
+	// defaultMC is the mission control of the default namespace.
+	defaultMC *MissionControl
+
+	// namespaces holds the mission control of every namespace, including
+	// the default one.
+	namespaces map[string]*MissionControl
+
+	// running is true once the store tickers were started.
+	running bool
+
+	sync.Mutex
+}
+
+// NewMissionControlManager creates the mission control of the default
+// namespace and of all namespaces that were added previously.
+func NewMissionControlManager(db kvdb.Backend, self route.Vertex,
+	cfg *MissionControlConfig) (*MissionControlManager, error) {
+
+	defaultMC, err := NewMissionControl(db, self, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MissionControlManager{
+		db:        db,
+		selfNode:  self,
+		defaultMC: defaultMC,
+		namespaces: map[string]*MissionControl{
+			DefaultMissionControlNamespace: defaultMC,
+		},
+	}
+
+	namespaces, err := fetchMissionControlNamespaces(db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaces {
+		mc, err := m.newMissionControl(namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		m.namespaces[namespace] = mc
+	}
+
+	return m, nil
+}
+
+// newMissionControl creates the mission control of the given namespace with
+// the current config of the default namespace.
+func (m *MissionControlManager) newMissionControl(
+	namespace string) (*MissionControl, error) {
+
+	cfg := m.defaultMC.GetConfig()
+
+	// Only the pair history of the default namespace is persisted.
+	cfg.PairStore = nil
+
+	return newNamespacedMissionControl(m.db, m.selfNode, cfg, namespace)
+}
+
+// DefaultMissionControl returns the mission control of the default namespace.
+func (m *MissionControlManager) DefaultMissionControl() *MissionControl {
+	return m.defaultMC
+}
+
+// GetNamespacedStore returns the mission control of the given namespace. An
+// empty namespace selects the default namespace.
+func (m *MissionControlManager) GetNamespacedStore(
+	namespace string) (*MissionControl, error) {
+
+	if namespace == "" {
+		return m.defaultMC, nil
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	mc, ok := m.namespaces[namespace]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v",
+			ErrUnknownMissionControlNamespace, namespace)
+	}
+
+	return mc, nil
+}
+
+// AddNamespace adds a new namespace with an empty reliability history.
+func (m *MissionControlManager) AddNamespace(namespace string) error {
+	if !namespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("invalid mission control namespace %q, "+
+			"expected 1-64 alphanumeric characters, dashes or "+
+			"underscores", namespace)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.namespaces[namespace]; ok {
+		return fmt.Errorf("%w: %v", ErrMissionControlNamespaceExists,
+			namespace)
+	}
+
+	mc, err := m.newMissionControl(namespace)
+	if err != nil {
+		return err
+	}
+
+	if m.running {
+		mc.RunStoreTicker()
+	}
+
+	m.namespaces[namespace] = mc
+
+	log.Infof("Added mission control namespace %v", namespace)
+
+	return nil
+}
+
+// DeleteNamespace deletes the given namespace together with its reliability
+// history.
+func (m *MissionControlManager) DeleteNamespace(namespace string) error {
+	if namespace == DefaultMissionControlNamespace {
+		return ErrDefaultMissionControlNamespace
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	mc, ok := m.namespaces[namespace]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownMissionControlNamespace,
+			namespace)
+	}
+
+	if m.running {
+		mc.StopStoreTicker()
+	}
+
+	if err := mc.store.deleteNamespace(); err != nil {
+		return err
+	}
+
+	delete(m.namespaces, namespace)
+
+	log.Infof("Deleted mission control namespace %v", namespace)
+
+	return nil
+}
+
+// ListNamespaces returns the names of all namespaces in alphabetical order.
+func (m *MissionControlManager) ListNamespaces() []string {
+	m.Lock()
+	defer m.Unlock()
+
+	namespaces := make([]string, 0, len(m.namespaces))
+	for namespace := range m.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces
+}
+
+// SetConfig validates the config provided and applies it to the mission
+// control of every namespace if it is valid.
+func (m *MissionControlManager) SetConfig(cfg *MissionControlConfig) error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, mc := range m.namespaces {
+		if err := mc.SetConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunStoreTickers runs the store tickers of all namespaces.
+func (m *MissionControlManager) RunStoreTickers() {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, mc := range m.namespaces {
+		mc.RunStoreTicker()
+	}
+
+	m.running = true
+}
+
+// StopStoreTickers stops the store tickers of all namespaces.
+func (m *MissionControlManager) StopStoreTickers() {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, mc := range m.namespaces {
+		mc.StopStoreTicker()
+	}
+
+	m.running = false
+}
@@ -0,0 +1,126 @@
+package routing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMissionControlManager creates a mission control manager on the given
+// database.
+func newTestMissionControlManager(t *testing.T,
+	db kvdb.Backend) *MissionControlManager {
+
+	estimator, err := NewAprioriEstimator(AprioriConfig{
+		PenaltyHalfLife:       testPenaltyHalfLife,
+		AprioriHopProbability: testAprioriHopProbability,
+		AprioriWeight:         testAprioriWeight,
+		CapacityFraction:      testCapacityFraction,
+	})
+	require.NoError(t, err)
+
+	mgr, err := NewMissionControlManager(
+		db, mcTestSelf, &MissionControlConfig{Estimator: estimator},
+	)
+	require.NoError(t, err)
+
+	return mgr
+}
+
+// TestMissionControlManager tests that mission control namespaces keep
+// separate reliability histories that survive restarts.
+func TestMissionControlManager(t *testing.T) {
+	t.Parallel()
+
+	db, err := kvdb.Create(
+		kvdb.BoltBackendName, filepath.Join(t.TempDir(), "mc.db"),
+		true, kvdb.DefaultDBTimeout,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	mgr := newTestMissionControlManager(t, db)
+	require.Equal(
+		t, []string{DefaultMissionControlNamespace},
+		mgr.ListNamespaces(),
+	)
+
+	// Invalid and duplicate namespaces are rejected.
+	require.Error(t, mgr.AddNamespace(""))
+	require.Error(t, mgr.AddNamespace("no spaces"))
+	require.ErrorIs(
+		t, mgr.AddNamespace(DefaultMissionControlNamespace),
+		ErrMissionControlNamespaceExists,
+	)
+
+	require.NoError(t, mgr.AddNamespace("probes"))
+	require.Equal(
+		t, []string{DefaultMissionControlNamespace, "probes"},
+		mgr.ListNamespaces(),
+	)
+
+	// An empty namespace selects the default one.
+	defaultMC, err := mgr.GetNamespacedStore("")
+	require.NoError(t, err)
+	require.Equal(t, mgr.DefaultMissionControl(), defaultMC)
+
+	_, err = mgr.GetNamespacedStore("unknown")
+	require.ErrorIs(t, err, ErrUnknownMissionControlNamespace)
+
+	// A failure that is reported to the probes namespace doesn't affect
+	// the default namespace.
+	probes, err := mgr.GetNamespacedStore("probes")
+	require.NoError(t, err)
+
+	errorSourceIdx := 1
+	_, err = probes.ReportPaymentFail(
+		0, mcTestRoute, &errorSourceIdx,
+		lnwire.NewTemporaryChannelFailure(nil),
+	)
+	require.NoError(t, err)
+	require.NoError(t, probes.store.storeResults())
+
+	require.Len(t, probes.GetHistorySnapshot().Pairs, 2)
+	require.Empty(t, defaultMC.GetHistorySnapshot().Pairs)
+
+	// After a restart, the namespace and its history are loaded again.
+	mgr = newTestMissionControlManager(t, db)
+	require.Equal(
+		t, []string{DefaultMissionControlNamespace, "probes"},
+		mgr.ListNamespaces(),
+	)
+
+	probes, err = mgr.GetNamespacedStore("probes")
+	require.NoError(t, err)
+	require.Len(t, probes.GetHistorySnapshot().Pairs, 2)
+	require.Empty(t, mgr.DefaultMissionControl().GetHistorySnapshot().Pairs)
+
+	// The default namespace can't be deleted, other namespaces are
+	// deleted together with their history.
+	require.ErrorIs(
+		t, mgr.DeleteNamespace(DefaultMissionControlNamespace),
+		ErrDefaultMissionControlNamespace,
+	)
+	require.NoError(t, mgr.DeleteNamespace("probes"))
+	require.ErrorIs(
+		t, mgr.DeleteNamespace("probes"),
+		ErrUnknownMissionControlNamespace,
+	)
+
+	mgr = newTestMissionControlManager(t, db)
+	require.Equal(
+		t, []string{DefaultMissionControlNamespace},
+		mgr.ListNamespaces(),
+	)
+
+	// A namespace that is added again starts with an empty history.
+	require.NoError(t, mgr.AddNamespace("probes"))
+	probes, err = mgr.GetNamespacedStore("probes")
+	require.NoError(t, err)
+	require.Empty(t, probes.GetHistorySnapshot().Pairs)
+}
@@ -0,0 +1,220 @@
+package routing
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/lightningnetwork/lnd/sqldb/sqlc"
+)
+
+// MissionControlPairStore persists the pair history of mission control. Unlike
+// the payment results of the mission control store, the pair history also
+// contains imported results, which therefore survive restarts.
+type MissionControlPairStore interface {
+	// FetchPairs returns the persisted pair history together with the
+	// time at which it was stored.
+	FetchPairs() ([]MissionControlPairSnapshot, time.Time, error)
+
+	// StorePairs replaces the persisted pair history with the given one,
+	// which is stored at the given time.
+	StorePairs(pairs []MissionControlPairSnapshot,
+		storedTime time.Time) error
+}
+
+// SQLMissionControlQueries is an interface that defines the set of operations
+// that can be executed against the mission control SQL database.
+type SQLMissionControlQueries interface {
+	InsertMissionControlPair(ctx context.Context,
+		arg sqlc.InsertMissionControlPairParams) error
+
+	FetchMissionControlPairs(
+		ctx context.Context) ([]sqlc.MissionControlPair, error)
+
+	DeleteMissionControlPairs(ctx context.Context) error
+}
+
+// SQLMissionControlQueriesTxOptions defines the set of db txn options the
+// SQLMissionControlQueries understands.
+type SQLMissionControlQueriesTxOptions struct {
+	// readOnly governs if a read only transaction is needed or not.
+	readOnly bool
+}
+
+// ReadOnly returns true if the transaction should be read only.
+//
+// NOTE: This implements the TxOptions.
+func (a *SQLMissionControlQueriesTxOptions) ReadOnly() bool {
+	return a.readOnly
+}
+
+// NewSQLMissionControlQueryReadTx creates a new read transaction option set.
+func NewSQLMissionControlQueryReadTx() SQLMissionControlQueriesTxOptions {
+	return SQLMissionControlQueriesTxOptions{
+		readOnly: true,
+	}
+}
+
+// BatchedSQLMissionControlQueries is a version of the SQLMissionControlQueries
+// that's capable of batched database operations.
+type BatchedSQLMissionControlQueries interface {
+	SQLMissionControlQueries
+
+	sqldb.BatchedTx[SQLMissionControlQueries]
+}
+
+// SQLMissionControlPairStore is a native SQL implementation of the mission
+// control pair store.
+type SQLMissionControlPairStore struct {
+	db BatchedSQLMissionControlQueries
+}
+
+// A compile-time constraint to ensure SQLMissionControlPairStore implements
+// the MissionControlPairStore interface.
+var _ MissionControlPairStore = (*SQLMissionControlPairStore)(nil)
+
+// NewSQLMissionControlPairStore creates a new SQLMissionControlPairStore
+// instance given an open BatchedSQLMissionControlQueries storage backend.
+func NewSQLMissionControlPairStore(
+	db BatchedSQLMissionControlQueries) *SQLMissionControlPairStore {
+
+	return &SQLMissionControlPairStore{
+		db: db,
+	}
+}
+
+// FetchPairs returns the persisted pair history together with the time at
+// which it was stored.
+//
+// NOTE: This is part of the MissionControlPairStore interface.
+func (s *SQLMissionControlPairStore) FetchPairs() (
+	[]MissionControlPairSnapshot, time.Time, error) {
+
+	var (
+		pairs      []MissionControlPairSnapshot
+		storedTime time.Time
+	)
+
+	readTxOpts := NewSQLMissionControlQueryReadTx()
+
+	ctx := context.TODO()
+
+	txBody := func(db SQLMissionControlQueries) error {
+		rows, err := db.FetchMissionControlPairs(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			pair, err := unmarshalMissionControlPair(row)
+			if err != nil {
+				return err
+			}
+
+			pairs = append(pairs, *pair)
+
+			// All pairs are replaced at once, so they share the
+			// same stored time.
+			storedTime = time.Unix(0, row.StoredTimeNs)
+		}
+
+		return nil
+	}
+	err := s.db.ExecTx(ctx, &readTxOpts, txBody, func() {
+		pairs = nil
+		storedTime = time.Time{}
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return pairs, storedTime, nil
+}
+
+// StorePairs replaces the persisted pair history with the given one, which is
+// stored at the given time.
+//
+// NOTE: This is part of the MissionControlPairStore interface.
+func (s *SQLMissionControlPairStore) StorePairs(
+	pairs []MissionControlPairSnapshot, storedTime time.Time) error {
+
+	var writeTxOpts SQLMissionControlQueriesTxOptions
+
+	ctx := context.TODO()
+
+	txBody := func(db SQLMissionControlQueries) error {
+		if err := db.DeleteMissionControlPairs(ctx); err != nil {
+			return err
+		}
+
+		for _, pair := range pairs {
+			successTime := marshalPairTime(pair.SuccessTime)
+			params := sqlc.InsertMissionControlPairParams{
+				NodeFrom:       pair.Pair.From[:],
+				NodeTo:         pair.Pair.To[:],
+				FailTimeNs:     marshalPairTime(pair.FailTime),
+				FailAmtMsat:    int64(pair.FailAmt),
+				SuccessTimeNs:  successTime,
+				SuccessAmtMsat: int64(pair.SuccessAmt),
+				StoredTimeNs:   storedTime.UnixNano(),
+			}
+
+			err := db.InsertMissionControlPair(ctx, params)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return s.db.ExecTx(ctx, &writeTxOpts, txBody, func() {})
+}
+
+// marshalPairTime converts the given pair result time into nanoseconds since
+// the unix epoch. A zero time, which marks the absence of a result, is stored
+// as zero.
+func marshalPairTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano()
+}
+
+// unmarshalPairTime is the inverse of marshalPairTime.
+func unmarshalPairTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ns)
+}
+
+// unmarshalMissionControlPair converts a pair read from the database into a
+// pair snapshot.
+func unmarshalMissionControlPair(
+	row sqlc.MissionControlPair) (*MissionControlPairSnapshot, error) {
+
+	from, err := route.NewVertexFromBytes(row.NodeFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := route.NewVertexFromBytes(row.NodeTo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MissionControlPairSnapshot{
+		Pair: NewDirectedNodePair(from, to),
+		TimedPairResult: TimedPairResult{
+			FailTime:    unmarshalPairTime(row.FailTimeNs),
+			FailAmt:     lnwire.MilliSatoshi(row.FailAmtMsat),
+			SuccessTime: unmarshalPairTime(row.SuccessTimeNs),
+			SuccessAmt:  lnwire.MilliSatoshi(row.SuccessAmtMsat),
+		},
+	}, nil
+}
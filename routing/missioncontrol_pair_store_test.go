@@ -0,0 +1,80 @@
+package routing
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSQLMissionControlPairStore creates a SQL mission control pair store
+// backed by a fresh sqlite database.
+func newTestSQLMissionControlPairStore(
+	t *testing.T) *SQLMissionControlPairStore {
+
+	sqlDB := sqldb.NewTestSqliteDB(t).BaseDB
+	executor := sqldb.NewTransactionExecutor(
+		sqlDB, func(tx *sql.Tx) SQLMissionControlQueries {
+			return sqlDB.WithTx(tx)
+		},
+	)
+
+	return NewSQLMissionControlPairStore(executor)
+}
+
+// TestSQLMissionControlPairStore tests that the SQL pair store persists and
+// replaces the pair history.
+func TestSQLMissionControlPairStore(t *testing.T) {
+	t.Parallel()
+
+	store := newTestSQLMissionControlPairStore(t)
+
+	// An empty store doesn't return any pairs.
+	pairs, _, err := store.FetchPairs()
+	require.NoError(t, err)
+	require.Empty(t, pairs)
+
+	var (
+		node1 = route.Vertex{1}
+		node2 = route.Vertex{2}
+		node3 = route.Vertex{3}
+	)
+
+	storedTime := time.Unix(0, testTime.UnixNano())
+	history := []MissionControlPairSnapshot{
+		{
+			Pair: NewDirectedNodePair(node1, node2),
+			TimedPairResult: TimedPairResult{
+				FailTime:    storedTime.Add(-time.Hour),
+				FailAmt:     2000,
+				SuccessTime: storedTime.Add(-time.Minute),
+				SuccessAmt:  1000,
+			},
+		},
+		{
+			// An amount-independent failure without a success.
+			Pair: NewDirectedNodePair(node2, node3),
+			TimedPairResult: TimedPairResult{
+				FailTime: storedTime.Add(-time.Second),
+			},
+		},
+	}
+	require.NoError(t, store.StorePairs(history, storedTime))
+
+	pairs, fetchedTime, err := store.FetchPairs()
+	require.NoError(t, err)
+	require.Equal(t, history, pairs)
+	require.True(t, storedTime.Equal(fetchedTime))
+
+	// Storing the pairs again replaces the previous history.
+	storedTime = storedTime.Add(time.Hour)
+	require.NoError(t, store.StorePairs(history[1:], storedTime))
+
+	pairs, fetchedTime, err = store.FetchPairs()
+	require.NoError(t, err)
+	require.Equal(t, history[1:], pairs)
+	require.True(t, storedTime.Equal(fetchedTime))
+}
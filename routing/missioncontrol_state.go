@@ -213,8 +213,9 @@ func (m *missionControlState) getSnapshot() *MissionControlSnapshot {
 }
 
 // importSnapshot takes an existing snapshot and merges it with our current
-// state if the result provided are fresher than our current results. It returns
-// the number of pairs that were used.
+// state if the result provided are fresher than our current results. If force
+// is set, the imported pairs replace our current results instead. It returns
+// the number of results that were used.
 func (m *missionControlState) importSnapshot(snapshot *MissionControlSnapshot,
 	force bool) int {
 
@@ -227,31 +228,65 @@ func (m *missionControlState) importSnapshot(snapshot *MissionControlSnapshot,
 		results, found := m.getLastPairResult(fromNode)
 		if !found {
 			results = make(map[route.Vertex]TimedPairResult)
+			m.lastPairResult[fromNode] = results
+		}
+
+		// A forced import replaces the pair as is, so that exported
+		// state can be restored exactly.
+		if force {
+			results[toNode] = pair.TimedPairResult
+
+			if !pair.FailTime.IsZero() {
+				imported++
+			}
+			if !pair.SuccessTime.IsZero() {
+				imported++
+			}
+
+			continue
 		}
 
 		lastResult := results[toNode]
 
-		failResult := failPairResult(pair.FailAmt)
-		imported += m.importResult(
-			lastResult.FailTime, pair.FailTime, failResult,
-			fromNode, toNode, force,
-		)
-
-		successResult := successPairResult(pair.SuccessAmt)
-		imported += m.importResult(
-			lastResult.SuccessTime, pair.SuccessTime, successResult,
-			fromNode, toNode, force,
-		)
+		importFail := func() int {
+			return m.importResult(
+				lastResult.FailTime, pair.FailTime,
+				failPairResult(pair.FailAmt), fromNode, toNode,
+			)
+		}
+		importSuccess := func() int {
+			return m.importResult(
+				lastResult.SuccessTime, pair.SuccessTime,
+				successPairResult(pair.SuccessAmt), fromNode,
+				toNode,
+			)
+		}
+
+		// Apply the results in the order in which they were recorded,
+		// so that the later one takes precedence like it did
+		// originally.
+		if pair.SuccessTime.Before(pair.FailTime) {
+			imported += importSuccess()
+			imported += importFail()
+		} else {
+			imported += importFail()
+			imported += importSuccess()
+		}
 	}
 
 	return imported
 }
 
 func (m *missionControlState) importResult(currentTs, importedTs time.Time,
-	importedResult pairResult, fromNode, toNode route.Vertex,
-	force bool) int {
+	importedResult pairResult, fromNode, toNode route.Vertex) int {
+
+	// A zero timestamp marks the absence of a result. Importing it would
+	// distort the other result of the pair.
+	if importedTs.IsZero() {
+		return 0
+	}
 
-	if !force && currentTs.After(importedTs) {
+	if currentTs.After(importedTs) {
 		log.Debugf("Not setting pair result for %v->%v (%v) "+
 			"success=%v, timestamp %v older than last result %v",
 			fromNode, toNode, importedResult.amt,
@@ -261,7 +296,7 @@ func (m *missionControlState) importResult(currentTs, importedTs time.Time,
 	}
 
 	m.setLastPairResult(
-		fromNode, toNode, importedTs, &importedResult, force,
+		fromNode, toNode, importedTs, &importedResult, false,
 	)
 
 	return 1
@@ -91,3 +91,51 @@ func TestMissionControlStateSetLastPairResult(t *testing.T) {
 	}
 	require.Equal(t, expected, result[to])
 }
+
+// TestMissionControlStateImportSnapshot tests that an exported snapshot is
+// restored exactly when it is imported into an empty state, and that forced
+// imports replace the existing pair history.
+func TestMissionControlStateImportSnapshot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from = route.Vertex{1}
+		to   = route.Vertex{2}
+	)
+
+	// Report a success that is followed by an amount-independent failure,
+	// which leaves a success time without a success amount behind.
+	state := newMissionControlState(0)
+	state.setLastPairResult(
+		from, to, testTime, &pairResult{amt: 1000, success: true},
+		false,
+	)
+	state.setLastPairResult(
+		from, to, testTime.Add(time.Minute), &pairResult{}, false,
+	)
+	snapshot := state.getSnapshot()
+
+	// Importing the snapshot applies the results in the order in which
+	// they were recorded, so the pair history is restored exactly.
+	imported := newMissionControlState(0)
+	require.Equal(t, 2, imported.importSnapshot(snapshot, false))
+	require.Equal(t, snapshot, imported.getSnapshot())
+
+	// A non-forced import of an older result doesn't change the pair, and
+	// the absent failure of the imported pair doesn't affect it either.
+	olderSuccess := &MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{{
+			Pair: NewDirectedNodePair(from, to),
+			TimedPairResult: TimedPairResult{
+				SuccessTime: testTime.Add(-time.Minute),
+				SuccessAmt:  5000,
+			},
+		}},
+	}
+	require.Zero(t, imported.importSnapshot(olderSuccess, false))
+	require.Equal(t, snapshot, imported.getSnapshot())
+
+	// A forced import replaces the pair history as is.
+	require.Equal(t, 1, imported.importSnapshot(olderSuccess, true))
+	require.Equal(t, olderSuccess, imported.getSnapshot())
+}
@@ -16,10 +16,14 @@ import (
 )
 
 var (
-	// resultsKey is the fixed key under which the attempt results are
-	// stored.
+	// resultsKey is the fixed key under which the attempt results of the
+	// default namespace are stored.
 	resultsKey = []byte("missioncontrol-results")
 
+	// namespacesKey is the fixed key of the bucket that contains a nested
+	// results bucket for every namespace other than the default one.
+	namespacesKey = []byte("missioncontrol-namespaces")
+
 	// Big endian is the preferred byte order, due to cursor scans over
 	// integer keys iterating in order.
 	byteOrder = binary.BigEndian
@@ -43,6 +47,10 @@ type missionControlStore struct {
 	db      kvdb.Backend
 	queueMx sync.Mutex
 
+	// namespace is the mission control namespace whose results are kept
+	// in this store.
+	namespace string
+
 	// queue stores all pending payment results not yet added to the store.
 	queue *list.List
 
@@ -63,7 +71,7 @@ type missionControlStore struct {
 	flushInterval time.Duration
 }
 
-func newMissionControlStore(db kvdb.Backend, maxRecords int,
+func newMissionControlStore(db kvdb.Backend, namespace string, maxRecords int,
 	flushInterval time.Duration) (*missionControlStore, error) {
 
 	var (
@@ -71,9 +79,18 @@ func newMissionControlStore(db kvdb.Backend, maxRecords int,
 		keysMap map[string]struct{}
 	)
 
+	store := &missionControlStore{
+		done:          make(chan struct{}),
+		db:            db,
+		namespace:     namespace,
+		queue:         list.New(),
+		maxRecords:    maxRecords,
+		flushInterval: flushInterval,
+	}
+
 	// Create buckets if not yet existing.
 	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
-		resultsBucket, err := tx.CreateTopLevelBucket(resultsKey)
+		resultsBucket, err := store.createResultsBucket(tx)
 		if err != nil {
 			return fmt.Errorf("cannot create results bucket: %w",
 				err)
@@ -96,15 +113,56 @@ func newMissionControlStore(db kvdb.Backend, maxRecords int,
 		return nil, err
 	}
 
-	return &missionControlStore{
-		done:          make(chan struct{}),
-		db:            db,
-		queue:         list.New(),
-		keys:          keys,
-		keysMap:       keysMap,
-		maxRecords:    maxRecords,
-		flushInterval: flushInterval,
-	}, nil
+	store.keys = keys
+	store.keysMap = keysMap
+
+	return store, nil
+}
+
+// createResultsBucket creates the results bucket of the store's namespace if
+// it doesn't exist yet. The results of the default namespace are kept in a top
+// level bucket for backwards compatibility.
+func (b *missionControlStore) createResultsBucket(
+	tx kvdb.RwTx) (kvdb.RwBucket, error) {
+
+	if b.namespace == DefaultMissionControlNamespace {
+		return tx.CreateTopLevelBucket(resultsKey)
+	}
+
+	namespaces, err := tx.CreateTopLevelBucket(namespacesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaces.CreateBucketIfNotExists([]byte(b.namespace))
+}
+
+// readResultsBucket returns the results bucket of the store's namespace.
+func (b *missionControlStore) readResultsBucket(tx kvdb.RTx) kvdb.RBucket {
+	if b.namespace == DefaultMissionControlNamespace {
+		return tx.ReadBucket(resultsKey)
+	}
+
+	namespaces := tx.ReadBucket(namespacesKey)
+	if namespaces == nil {
+		return nil
+	}
+
+	return namespaces.NestedReadBucket([]byte(b.namespace))
+}
+
+// deleteResultsBucket deletes the results bucket of the store's namespace.
+func (b *missionControlStore) deleteResultsBucket(tx kvdb.RwTx) error {
+	if b.namespace == DefaultMissionControlNamespace {
+		return tx.DeleteTopLevelBucket(resultsKey)
+	}
+
+	namespaces := tx.ReadWriteBucket(namespacesKey)
+	if namespaces == nil {
+		return kvdb.ErrBucketNotFound
+	}
+
+	return namespaces.DeleteNestedBucket([]byte(b.namespace))
 }
 
 // clear removes all results from the db.
@@ -113,11 +171,11 @@ func (b *missionControlStore) clear() error {
 	defer b.queueMx.Unlock()
 
 	err := kvdb.Update(b.db, func(tx kvdb.RwTx) error {
-		if err := tx.DeleteTopLevelBucket(resultsKey); err != nil {
+		if err := b.deleteResultsBucket(tx); err != nil {
 			return err
 		}
 
-		_, err := tx.CreateTopLevelBucket(resultsKey)
+		_, err := b.createResultsBucket(tx)
 		return err
 	}, func() {})
 
@@ -134,7 +192,10 @@ func (b *missionControlStore) fetchAll() ([]*paymentResult, error) {
 	var results []*paymentResult
 
 	err := kvdb.View(b.db, func(tx kvdb.RTx) error {
-		resultBucket := tx.ReadBucket(resultsKey)
+		resultBucket := b.readResultsBucket(tx)
+		if resultBucket == nil {
+			return kvdb.ErrBucketNotFound
+		}
 		results = make([]*paymentResult, 0)
 
 		return resultBucket.ForEach(func(k, v []byte) error {
@@ -313,7 +374,10 @@ func (b *missionControlStore) storeResults() error {
 	)
 
 	err := kvdb.Update(b.db, func(tx kvdb.RwTx) error {
-		bucket := tx.ReadWriteBucket(resultsKey)
+		bucket, err := b.createResultsBucket(tx)
+		if err != nil {
+			return err
+		}
 
 		for e := l.Front(); e != nil; e = e.Next() {
 			pr := e.Value.(*paymentResult)
@@ -392,3 +456,45 @@ func getResultKey(rp *paymentResult) []byte {
 
 	return keyBytes[:]
 }
+
+// deleteNamespace removes the store's namespace together with all its results
+// from the db.
+func (b *missionControlStore) deleteNamespace() error {
+	b.queueMx.Lock()
+	defer b.queueMx.Unlock()
+
+	err := kvdb.Update(b.db, b.deleteResultsBucket, func() {})
+	if err != nil {
+		return err
+	}
+
+	b.queue = list.New()
+
+	return nil
+}
+
+// fetchMissionControlNamespaces returns the namespaces other than the default
+// one that have a results bucket in the db.
+func fetchMissionControlNamespaces(db kvdb.Backend) ([]string, error) {
+	var namespaces []string
+
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(namespacesKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, _ []byte) error {
+			namespaces = append(namespaces, string(k))
+
+			return nil
+		})
+	}, func() {
+		namespaces = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaces, nil
+}
@@ -44,7 +44,9 @@ func TestMissionControlStore(t *testing.T) {
 		require.NoError(t, db.Close())
 	})
 
-	store, err := newMissionControlStore(db, testMaxRecords, time.Second)
+	store, err := newMissionControlStore(
+		db, DefaultMissionControlNamespace, testMaxRecords, time.Second,
+	)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -114,7 +116,9 @@ func TestMissionControlStore(t *testing.T) {
 	}
 
 	// Recreate store to test pruning.
-	store, err = newMissionControlStore(db, testMaxRecords, time.Second)
+	store, err = newMissionControlStore(
+		db, DefaultMissionControlNamespace, testMaxRecords, time.Second,
+	)
 	if err != nil {
 		t.Fatal(err)
 	}
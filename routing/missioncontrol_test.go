@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing/route"
@@ -47,6 +48,11 @@ type mcTestContext struct {
 	db     kvdb.Backend
 	dbPath string
 
+	// pairStore and historyHalfLife are passed to mission control when
+	// it is (re)started.
+	pairStore       MissionControlPairStore
+	historyHalfLife time.Duration
+
 	pid uint64
 }
 
@@ -88,6 +94,7 @@ func (ctx *mcTestContext) restartMc() {
 	// them here before fetching back everything in NewMissionControl.
 	if ctx.mc != nil {
 		require.NoError(ctx.t, ctx.mc.store.storeResults())
+		ctx.mc.StopStoreTicker()
 	}
 
 	aCfg := AprioriConfig{
@@ -100,8 +107,11 @@ func (ctx *mcTestContext) restartMc() {
 	require.NoError(ctx.t, err)
 
 	mc, err := NewMissionControl(
-		ctx.db, mcTestSelf,
-		&MissionControlConfig{Estimator: estimator},
+		ctx.db, mcTestSelf, &MissionControlConfig{
+			Estimator:       estimator,
+			PairStore:       ctx.pairStore,
+			HistoryHalfLife: ctx.historyHalfLife,
+		},
 	)
 	if err != nil {
 		ctx.t.Fatal(err)
@@ -230,3 +240,163 @@ func TestMissionControlChannelUpdate(t *testing.T) {
 	)
 	ctx.expectP(100, 0)
 }
+
+// TestMissionControlPairHistory tests that imported pair history survives
+// restarts if a pair store is configured, and that it decays according to the
+// time that passed since it was stored.
+func TestMissionControlPairHistory(t *testing.T) {
+	const halfLife = time.Hour
+
+	ctx := createMcTestContext(t)
+	ctx.pairStore = newTestSQLMissionControlPairStore(t)
+	ctx.restartMc()
+
+	pair := NewDirectedNodePair(mcTestNode1, mcTestNode2)
+	imported := TimedPairResult{
+		FailTime:    testTime.Add(time.Minute),
+		FailAmt:     2000,
+		SuccessTime: testTime,
+		SuccessAmt:  1000,
+	}
+	err := ctx.mc.ImportHistory(&MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{{
+			Pair:            pair,
+			TimedPairResult: imported,
+		}},
+	}, false)
+	require.NoError(t, err)
+
+	// The imported history is persisted, so it is still known after a
+	// restart.
+	ctx.restartMc()
+	result := ctx.mc.GetPairHistorySnapshot(pair.From, pair.To)
+	require.Equal(t, imported.SuccessAmt, result.SuccessAmt)
+	require.Equal(t, imported.FailAmt, result.FailAmt)
+	require.True(t, imported.SuccessTime.Equal(result.SuccessTime))
+	require.True(t, imported.FailTime.Equal(result.FailTime))
+
+	// Pretend that the history was stored one half-life ago. Mission
+	// control is created in real time, so we expect the amounts to be
+	// about halved and doubled.
+	err = ctx.pairStore.StorePairs(
+		ctx.mc.GetHistorySnapshot().Pairs, time.Now().Add(-halfLife),
+	)
+	require.NoError(t, err)
+
+	ctx.mc = nil
+	ctx.historyHalfLife = halfLife
+	ctx.restartMc()
+
+	result = ctx.mc.GetPairHistorySnapshot(pair.From, pair.To)
+	require.InDelta(t, 500, int64(result.SuccessAmt), 1)
+	require.InDelta(t, 4000, int64(result.FailAmt), 1)
+	require.True(t, imported.SuccessTime.Equal(result.SuccessTime))
+	require.True(t, imported.FailTime.Equal(result.FailTime))
+
+	// Resetting mission control also clears the persisted history.
+	require.NoError(t, ctx.mc.ResetHistory())
+	ctx.restartMc()
+	require.Empty(t, ctx.mc.GetHistorySnapshot().Pairs)
+}
+
+// TestDecayPairResult tests the decay of persisted pair results.
+func TestDecayPairResult(t *testing.T) {
+	t.Parallel()
+
+	const halfLife = time.Hour
+
+	maxAmt := lnwire.NewMSatFromSatoshis(btcutil.MaxSatoshi)
+
+	tests := []struct {
+		name      string
+		result    TimedPairResult
+		age       time.Duration
+		halfLife  time.Duration
+		expected  TimedPairResult
+		remaining bool
+	}{
+		{
+			name: "decay disabled",
+			result: TimedPairResult{
+				FailTime: testTime,
+				FailAmt:  1000,
+			},
+			age:      halfLife,
+			halfLife: 0,
+			expected: TimedPairResult{
+				FailTime: testTime,
+				FailAmt:  1000,
+			},
+			remaining: true,
+		},
+		{
+			name: "two half-lives",
+			result: TimedPairResult{
+				FailTime:    testTime,
+				FailAmt:     1000,
+				SuccessTime: testTime,
+				SuccessAmt:  800,
+			},
+			age:      2 * halfLife,
+			halfLife: halfLife,
+			expected: TimedPairResult{
+				FailTime:    testTime,
+				FailAmt:     4000,
+				SuccessTime: testTime,
+				SuccessAmt:  200,
+			},
+			remaining: true,
+		},
+		{
+			name: "amount-independent failure",
+			result: TimedPairResult{
+				FailTime: testTime,
+			},
+			age:      halfLife,
+			halfLife: halfLife,
+			expected: TimedPairResult{
+				FailTime: testTime,
+			},
+			remaining: true,
+		},
+		{
+			name: "success decayed",
+			result: TimedPairResult{
+				FailTime:    testTime,
+				SuccessTime: testTime,
+				SuccessAmt:  1,
+			},
+			age:      halfLife,
+			halfLife: halfLife,
+			expected: TimedPairResult{
+				FailTime: testTime,
+			},
+			remaining: true,
+		},
+		{
+			name: "failure above max amount",
+			result: TimedPairResult{
+				FailTime: testTime,
+				FailAmt:  maxAmt,
+			},
+			age:       halfLife,
+			halfLife:  halfLife,
+			expected:  TimedPairResult{},
+			remaining: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, remaining := decayPairResult(
+				test.result, test.age, test.halfLife,
+			)
+			require.Equal(t, test.expected, result)
+			require.Equal(t, test.remaining, remaining)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package routing
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -502,6 +503,21 @@ func (m *mockControlTowerOld) FetchPayment(phash lntypes.Hash) (
 	return m.fetchPayment(phash)
 }
 
+func (m *mockControlTowerOld) FetchPaymentHashByIdempotencyKey(
+	key []byte) (lntypes.Hash, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	for hash, p := range m.payments {
+		if len(key) != 0 && bytes.Equal(p.info.IdempotencyKey, key) {
+			return hash, nil
+		}
+	}
+
+	return lntypes.Hash{}, channeldb.ErrPaymentNotInitiated
+}
+
 func (m *mockControlTowerOld) fetchPayment(phash lntypes.Hash) (
 	*channeldb.MPPayment, error) {
 
@@ -772,6 +788,13 @@ func (m *mockControlTower) FetchPayment(phash lntypes.Hash) (
 	return payment, args.Error(1)
 }
 
+func (m *mockControlTower) FetchPaymentHashByIdempotencyKey(key []byte) (
+	lntypes.Hash, error) {
+
+	args := m.Called(key)
+	return args.Get(0).(lntypes.Hash), args.Error(1)
+}
+
 func (m *mockControlTower) FetchInFlightPayments() (
 	[]*channeldb.MPPayment, error) {
 
@@ -348,9 +348,11 @@ func newRoute(sourceVertex route.Vertex,
 // is the fee itself plus a time lock penalty added to it. This benefits
 // channels with shorter time lock deltas and shorter (hops) routes in general.
 // RiskFactor controls the influence of time lock on route selection. This is
-// currently a fixed value, but might be configurable in the future.
+// currently a fixed value, but the influence of both the fee and the time lock
+// penalty can be scaled by the given cost weights.
 func edgeWeight(lockedAmt lnwire.MilliSatoshi, fee lnwire.MilliSatoshi,
-	timeLockDelta uint16) int64 {
+	timeLockDelta uint16, weights CostWeights) int64 {
+
 	// timeLockPenalty is the penalty for the time lock delta of this channel.
 	// It is controlled by RiskFactorBillionths and scales proportional
 	// to the amount that will pass through channel. Rationale is that it if
@@ -358,7 +360,50 @@ func edgeWeight(lockedAmt lnwire.MilliSatoshi, fee lnwire.MilliSatoshi,
 	timeLockPenalty := int64(lockedAmt) * int64(timeLockDelta) *
 		RiskFactorBillionths / 1000000000
 
-	return int64(fee) + timeLockPenalty
+	return int64(weights.Fee*float64(fee) +
+		weights.TimeLock*float64(timeLockPenalty))
+}
+
+// CostWeights scales the components of the cost that path finding minimizes.
+// They allow a caller to express whether it prefers cheap, fast or reliable
+// routes.
+type CostWeights struct {
+	// Fee is the weight of the fees that are paid to the nodes of the
+	// route.
+	Fee float64
+
+	// TimeLock is the weight of the penalty for the time that the payment
+	// amount is locked up in the route.
+	TimeLock float64
+
+	// Probability is the weight of the virtual cost of a failed payment
+	// attempt, which penalizes routes with a low success probability.
+	Probability float64
+}
+
+// DefaultCostWeights are the cost weights that are used if a caller doesn't
+// specify its own.
+var DefaultCostWeights = CostWeights{
+	Fee:         1,
+	TimeLock:    1,
+	Probability: 1,
+}
+
+// Validate checks that the cost weights can be used for path finding.
+func (c *CostWeights) Validate() error {
+	for _, w := range []float64{c.Fee, c.TimeLock, c.Probability} {
+		// The negated comparison also catches NaN values.
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return fmt.Errorf("cost weight %v must be a finite, "+
+				"non-negative number", w)
+		}
+	}
+
+	if c.Fee == 0 && c.TimeLock == 0 && c.Probability == 0 {
+		return errors.New("at least one cost weight must be positive")
+	}
+
+	return nil
 }
 
 // graphParams wraps the set of graph parameters passed to findPath.
@@ -433,6 +478,10 @@ type RestrictParams struct {
 	// BlindedPayment is necessary to determine the hop size of the
 	// last/exit hop.
 	BlindedPayment *BlindedPayment
+
+	// CostWeights scales the components of the cost that path finding
+	// minimizes. If nil, DefaultCostWeights are used.
+	CostWeights *CostWeights
 }
 
 // PathFindingConfig defines global parameters that control the trade-off in
@@ -704,6 +753,16 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 	// be used.
 	absoluteAttemptCost := defaultAttemptCost * (1/(0.5-timePref/2) - 1)
 
+	// Apply the cost weights of the caller, if any.
+	weights := DefaultCostWeights
+	if r.CostWeights != nil {
+		if err := r.CostWeights.Validate(); err != nil {
+			return nil, 0, err
+		}
+		weights = *r.CostWeights
+	}
+	absoluteAttemptCost *= weights.Probability
+
 	log.Debugf("Pathfinding absolute attempt cost: %v sats",
 		absoluteAttemptCost/1000)
 
@@ -826,7 +885,9 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 		// weight composed of the fee that this node will charge and
 		// the amount that will be locked for timeLockDelta blocks in
 		// the HTLC that is handed out to fromVertex.
-		weight := edgeWeight(netAmountToReceive, fee, timeLockDelta)
+		weight := edgeWeight(
+			netAmountToReceive, fee, timeLockDelta, weights,
+		)
 
 		// Compute the tentative weight to this new channel/edge
 		// which is the weight from our toNode to the target node
@@ -2793,6 +2793,7 @@ func runProbabilityRouting(t *testing.T, useCache bool) {
 		expectedChan   uint64
 		amount         btcutil.Amount
 		timePref       float64
+		costWeights    *CostWeights
 	}{
 		// Test two variations with probabilities that should multiply
 		// to the same total route probability. In both cases the three
@@ -2877,6 +2878,52 @@ func runProbabilityRouting(t *testing.T, useCache bool) {
 			expectedChan:   0,
 			amount:         100,
 		},
+
+		// Doubling the probability weight doubles the attempt penalty
+		// to 20. The three hop distance becomes 13 + 20 / 0.4 = 63 and
+		// the two hop distance 25 + 20 / 0.7 = 54, so the more
+		// reliable two hop route is selected.
+		{
+			name: "probability weight",
+			p10:  0.8, p11: 0.5, p20: 0.7,
+			minProbability: 0.1,
+			expectedChan:   20,
+			amount:         100,
+			costWeights: &CostWeights{
+				Fee:         1,
+				TimeLock:    1,
+				Probability: 2,
+			},
+		},
+
+		// Without a probability weight, only the fees matter and the
+		// cheaper three hop route is selected although the two hop
+		// route is more reliable.
+		{
+			name: "fee only weights",
+			p10:  0.5, p11: 0.8, p20: 0.85,
+			minProbability: 0.1,
+			expectedChan:   10,
+			amount:         100,
+			costWeights: &CostWeights{
+				Fee:      1,
+				TimeLock: 1,
+			},
+		},
+
+		// Without a fee weight, only the probability matters. The
+		// three hop distance is 10 / 0.4 = 25 and the two hop distance
+		// 10 / 0.7 = 14.
+		{
+			name: "probability only weights",
+			p10:  0.8, p11: 0.5, p20: 0.7,
+			minProbability: 0.1,
+			expectedChan:   20,
+			amount:         100,
+			costWeights: &CostWeights{
+				Probability: 1,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2885,7 +2932,7 @@ func runProbabilityRouting(t *testing.T, useCache bool) {
 		t.Run(tc.name, func(t *testing.T) {
 			testProbabilityRouting(
 				t, useCache, tc.amount, tc.timePref,
-				tc.p10, tc.p11, tc.p20,
+				tc.costWeights, tc.p10, tc.p11, tc.p20,
 				tc.minProbability, tc.expectedChan,
 			)
 		})
@@ -2893,7 +2940,7 @@ func runProbabilityRouting(t *testing.T, useCache bool) {
 }
 
 func testProbabilityRouting(t *testing.T, useCache bool,
-	paymentAmt btcutil.Amount, timePref float64,
+	paymentAmt btcutil.Amount, timePref float64, costWeights *CostWeights,
 	p10, p11, p20, minProbability float64,
 	expectedChan uint64) {
 
@@ -2956,6 +3003,7 @@ func testProbabilityRouting(t *testing.T, useCache bool,
 	}
 
 	ctx.timePref = timePref
+	ctx.restrictParams.CostWeights = costWeights
 
 	path, err := ctx.findPath(
 		target, lnwire.NewMSatFromSatoshis(paymentAmt),
@@ -3788,3 +3836,50 @@ func TestLastHopPayloadSize(t *testing.T) {
 		})
 	}
 }
+
+// TestCostWeightsValidate tests that invalid path finding cost weights are
+// rejected.
+func TestCostWeightsValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		weights CostWeights
+		valid   bool
+	}{{
+		name:    "default weights",
+		weights: DefaultCostWeights,
+		valid:   true,
+	}, {
+		name:    "single positive weight",
+		weights: CostWeights{TimeLock: 0.5},
+		valid:   true,
+	}, {
+		name:    "all zero",
+		weights: CostWeights{},
+	}, {
+		name:    "negative weight",
+		weights: CostWeights{Fee: 1, Probability: -1},
+	}, {
+		name:    "nan weight",
+		weights: CostWeights{Fee: math.NaN()},
+	}, {
+		name:    "infinite weight",
+		weights: CostWeights{Fee: math.Inf(1)},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.weights.Validate()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
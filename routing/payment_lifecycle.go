@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btclog"
 	"github.com/davecgh/go-spew/spew"
 	sphinx "github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/build"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/channeldb/models"
 	"github.com/lightningnetwork/lnd/htlcswitch"
@@ -32,6 +34,14 @@ type paymentLifecycle struct {
 	timeoutChan   <-chan time.Time
 	currentHeight int32
 
+	// splitStrategy is the split strategy of the payment, which is
+	// recorded with every attempt.
+	splitStrategy SplitStrategy
+
+	// missionControl is the mission control that the results of the
+	// payment's attempts are reported to.
+	missionControl MissionController
+
 	// quit is closed to signal the sub goroutines of the payment lifecycle
 	// to stop.
 	quit chan struct{}
@@ -47,13 +57,18 @@ type paymentLifecycle struct {
 	// except in unit test, where we use a much simpler resultCollector to
 	// decouple the test flow for the payment lifecycle.
 	resultCollector func(attempt *channeldb.HTLCAttempt)
+
+	// log is the logger of the payment, which attaches the trace ID of
+	// the payment to every log entry.
+	log btclog.Logger
 }
 
 // newPaymentLifecycle initiates a new payment lifecycle and returns it.
 func newPaymentLifecycle(r *ChannelRouter, feeLimit lnwire.MilliSatoshi,
 	identifier lntypes.Hash, paySession PaymentSession,
 	shardTracker shards.ShardTracker, timeout time.Duration,
-	currentHeight int32) *paymentLifecycle {
+	currentHeight int32, splitStrategy SplitStrategy,
+	missionControl MissionController) *paymentLifecycle {
 
 	p := &paymentLifecycle{
 		router:          r,
@@ -62,8 +77,13 @@ func newPaymentLifecycle(r *ChannelRouter, feeLimit lnwire.MilliSatoshi,
 		paySession:      paySession,
 		shardTracker:    shardTracker,
 		currentHeight:   currentHeight,
+		splitStrategy:   splitStrategy,
+		missionControl:  missionControl,
 		quit:            make(chan struct{}),
 		resultCollected: make(chan error, 1),
+		log: build.NewTraceLog(
+			build.PaymentTraceID(identifier), log,
+		),
 	}
 
 	// Mount the result collector.
@@ -137,7 +157,7 @@ func (p *paymentLifecycle) decideNextStep(
 			return stepExit, nil
 		}
 
-		log.Tracef("Waiting for attempt results for payment %v",
+		p.log.Tracef("Waiting for attempt results for payment %v",
 			p.identifier)
 
 		// Otherwise we wait for one HTLC attempt then continue
@@ -152,7 +172,7 @@ func (p *paymentLifecycle) decideNextStep(
 				return stepExit, err
 			}
 
-			log.Tracef("Received attempt result for payment %v",
+			p.log.Tracef("Received attempt result for payment %v",
 				p.identifier)
 
 		case <-p.router.quit:
@@ -184,7 +204,7 @@ func (p *paymentLifecycle) resumePayment() ([32]byte, *route.Route, error) {
 	for _, a := range payment.InFlightHTLCs() {
 		a := a
 
-		log.Infof("Resuming payment shard %v for payment %v",
+		p.log.Infof("Resuming payment shard %v for payment %v",
 			a.AttemptID, p.identifier)
 
 		p.resultCollector(&a)
@@ -192,7 +212,7 @@ func (p *paymentLifecycle) resumePayment() ([32]byte, *route.Route, error) {
 
 	// exitWithErr is a helper closure that logs and returns an error.
 	exitWithErr := func(err error) ([32]byte, *route.Route, error) {
-		log.Errorf("Payment %v with status=%v failed: %v",
+		p.log.Errorf("Payment %v with status=%v failed: %v",
 			p.identifier, payment.GetStatus(), err)
 		return [32]byte{}, nil, err
 	}
@@ -214,7 +234,7 @@ lifecycle:
 		ps := payment.GetState()
 		remainingFees := p.calcFeeBudget(ps.FeesPaid)
 
-		log.Debugf("Payment %v: status=%v, active_shards=%v, "+
+		p.log.Debugf("Payment %v: status=%v, active_shards=%v, "+
 			"rem_value=%v, fee_limit=%v", p.identifier,
 			payment.GetStatus(), ps.NumAttemptsInFlight,
 			ps.RemainingAmt, remainingFees)
@@ -271,13 +291,13 @@ lifecycle:
 		// next iteration in case there are results for inflight HTLCs
 		// that still need to be collected.
 		if rt == nil {
-			log.Errorf("No route found for payment %v",
+			p.log.Errorf("No route found for payment %v",
 				p.identifier)
 
 			continue lifecycle
 		}
 
-		log.Tracef("Found route: %s", spew.Sdump(rt.Hops))
+		p.log.Tracef("Found route: %s", spew.Sdump(rt.Hops))
 
 		// We found a route to try, create a new HTLC attempt to try.
 		attempt, err := p.registerAttempt(rt, ps.RemainingAmt)
@@ -305,7 +325,7 @@ lifecycle:
 	// Optionally delete the failed attempts from the database.
 	err = p.router.cfg.Control.DeleteFailedAttempts(p.identifier)
 	if err != nil {
-		log.Errorf("Error deleting failed htlc attempts for payment "+
+		p.log.Errorf("Error deleting failed htlc attempts for payment "+
 			"%v: %v", p.identifier, err)
 	}
 
@@ -322,7 +342,7 @@ lifecycle:
 func (p *paymentLifecycle) checkTimeout() error {
 	select {
 	case <-p.timeoutChan:
-		log.Warnf("payment attempt not completed before timeout")
+		p.log.Warnf("payment attempt not completed before timeout")
 
 		// By marking the payment failed, depending on whether it has
 		// inflight HTLCs or not, its status will now either be
@@ -365,7 +385,8 @@ func (p *paymentLifecycle) requestRoute(
 	}
 
 	// Otherwise we need to handle the error.
-	log.Warnf("Failed to find route for payment %v: %v", p.identifier, err)
+	p.log.Warnf("Failed to find route for payment %v: %v", p.identifier,
+		err)
 
 	// If the error belongs to `noRouteError` set, it means a non-critical
 	// error has happened during path finding and we will mark the payment
@@ -381,7 +402,7 @@ func (p *paymentLifecycle) requestRoute(
 	// a terminal condition and fail the payment no matter it has inflight
 	// HTLCs or not.
 	failureCode := routeErr.FailureReason()
-	log.Warnf("Marking payment %v permanently failed with no route: %v",
+	p.log.Warnf("Marking payment %v permanently failed with no route: %v",
 		p.identifier, failureCode)
 
 	err = p.router.cfg.Control.FailPayment(p.identifier, failureCode)
@@ -418,19 +439,19 @@ type attemptResult struct {
 // will send a nil error to channel `resultCollected` to indicate there's an
 // result.
 func (p *paymentLifecycle) collectResultAsync(attempt *channeldb.HTLCAttempt) {
-	log.Debugf("Collecting result for attempt %v in payment %v",
+	p.log.Debugf("Collecting result for attempt %v in payment %v",
 		attempt.AttemptID, p.identifier)
 
 	go func() {
 		// Block until the result is available.
 		_, err := p.collectResult(attempt)
 		if err != nil {
-			log.Errorf("Error collecting result for attempt %v "+
+			p.log.Errorf("Error collecting result for attempt %v "+
 				"in payment %v: %v", attempt.AttemptID,
 				p.identifier, err)
 		}
 
-		log.Debugf("Result collected for attempt %v in payment %v",
+		p.log.Debugf("Result collected for attempt %v in payment %v",
 			attempt.AttemptID, p.identifier)
 
 		// Once the result is collected, we signal it by writing the
@@ -440,7 +461,7 @@ func (p *paymentLifecycle) collectResultAsync(attempt *channeldb.HTLCAttempt) {
 		case p.resultCollected <- err:
 
 		case <-p.quit:
-			log.Debugf("Lifecycle exiting while collecting "+
+			p.log.Debugf("Lifecycle exiting while collecting "+
 				"result for payment %v", p.identifier)
 
 		case <-p.router.quit:
@@ -478,7 +499,7 @@ func (p *paymentLifecycle) collectResult(attempt *channeldb.HTLCAttempt) (
 	// creation into htlcswitch so it's only constructed when there's a
 	// failure message we need to decode.
 	if err != nil {
-		log.Debugf("Unable to generate circuit for attempt %v: %v",
+		p.log.Debugf("Unable to generate circuit for attempt %v: %v",
 			attempt.AttemptID, err)
 
 		return p.failAttempt(attempt.AttemptID, err)
@@ -504,7 +525,7 @@ func (p *paymentLifecycle) collectResult(attempt *channeldb.HTLCAttempt) (
 	)
 	// Handle the switch error.
 	if err != nil {
-		log.Errorf("Failed getting result for attemptID %d "+
+		p.log.Errorf("Failed getting result for attemptID %d "+
 			"from switch: %v", attempt.AttemptID, err)
 
 		return p.handleSwitchErr(attempt, err)
@@ -537,15 +558,15 @@ func (p *paymentLifecycle) collectResult(attempt *channeldb.HTLCAttempt) (
 	}
 
 	// We successfully got a payment result back from the switch.
-	log.Debugf("Payment %v succeeded with pid=%v",
+	p.log.Debugf("Payment %v succeeded with pid=%v",
 		p.identifier, attempt.AttemptID)
 
 	// Report success to mission control.
-	err = p.router.cfg.MissionControl.ReportPaymentSuccess(
+	err = p.missionControl.ReportPaymentSuccess(
 		attempt.AttemptID, &attempt.Route,
 	)
 	if err != nil {
-		log.Errorf("Error reporting payment success to mc: %v", err)
+		p.log.Errorf("Error reporting payment success to mc: %v", err)
 	}
 
 	// In case of success we atomically store settle result to the DB move
@@ -558,7 +579,7 @@ func (p *paymentLifecycle) collectResult(attempt *channeldb.HTLCAttempt) (
 		},
 	)
 	if err != nil {
-		log.Errorf("Error settling attempt %v for payment %v with "+
+		p.log.Errorf("Error settling attempt %v for payment %v with "+
 			"preimage %v: %v", attempt.AttemptID, p.identifier,
 			result.Preimage, err)
 
@@ -589,6 +610,16 @@ func (p *paymentLifecycle) registerAttempt(rt *route.Route,
 		return nil, err
 	}
 
+	// Record the split decision that led to the amount of this attempt.
+	// Attempts that are sent along a given route don't have a remaining
+	// amount, as they aren't subject to splitting.
+	if remainingAmt != 0 {
+		attempt.SplitInfo = &channeldb.HTLCSplitInfo{
+			Strategy:     uint8(p.splitStrategy),
+			RemainingAmt: remainingAmt,
+		}
+	}
+
 	// Before sending this HTLC to the switch, we checkpoint the fresh
 	// paymentID and route to the DB. This lets us know on startup the ID
 	// of the payment that we attempted to send, such that we can query the
@@ -656,7 +687,7 @@ func (p *paymentLifecycle) createNewPaymentAttempt(rt *route.Route,
 func (p *paymentLifecycle) sendAttempt(
 	attempt *channeldb.HTLCAttempt) (*attemptResult, error) {
 
-	log.Debugf("Attempting to send payment %v (pid=%v)", p.identifier,
+	p.log.Debugf("Attempting to send payment %v (pid=%v)", p.identifier,
 		attempt.AttemptID)
 
 	rt := attempt.Route
@@ -680,7 +711,7 @@ func (p *paymentLifecycle) sendAttempt(
 		&rt, attempt.Hash[:], attempt.SessionKey(),
 	)
 	if err != nil {
-		log.Errorf("Failed to create onion blob: attempt=%d in "+
+		p.log.Errorf("Failed to create onion blob: attempt=%d in "+
 			"payment=%v, err:%v", attempt.AttemptID,
 			p.identifier, err)
 
@@ -695,13 +726,13 @@ func (p *paymentLifecycle) sendAttempt(
 	// restart.
 	err = p.router.cfg.Payer.SendHTLC(firstHop, attempt.AttemptID, htlcAdd)
 	if err != nil {
-		log.Errorf("Failed sending attempt %d for payment %v to "+
+		p.log.Errorf("Failed sending attempt %d for payment %v to "+
 			"switch: %v", attempt.AttemptID, p.identifier, err)
 
 		return p.handleSwitchErr(attempt, err)
 	}
 
-	log.Debugf("Attempt %v for payment %v successfully sent to switch, "+
+	p.log.Debugf("Attempt %v for payment %v successfully sent to switch, "+
 		"route: %v", attempt.AttemptID, p.identifier, &attempt.Route)
 
 	return &attemptResult{
@@ -715,7 +746,7 @@ func (p *paymentLifecycle) failPaymentAndAttempt(
 	attemptID uint64, reason *channeldb.FailureReason,
 	sendErr error) (*attemptResult, error) {
 
-	log.Errorf("Payment %v failed: final_outcome=%v, raw_err=%v",
+	p.log.Errorf("Payment %v failed: final_outcome=%v, raw_err=%v",
 		p.identifier, *reason, sendErr)
 
 	// Fail the payment via control tower.
@@ -725,7 +756,7 @@ func (p *paymentLifecycle) failPaymentAndAttempt(
 	// might make another attempt while we are failing the payment.
 	err := p.router.cfg.Control.FailPayment(p.identifier, *reason)
 	if err != nil {
-		log.Errorf("Unable to fail payment: %v", err)
+		p.log.Errorf("Unable to fail payment: %v", err)
 		return nil, err
 	}
 
@@ -755,11 +786,11 @@ func (p *paymentLifecycle) handleSwitchErr(attempt *channeldb.HTLCAttempt,
 		msg lnwire.FailureMessage) (*attemptResult, error) {
 
 		// Report outcome to mission control.
-		reason, err := p.router.cfg.MissionControl.ReportPaymentFail(
+		reason, err := p.missionControl.ReportPaymentFail(
 			attemptID, &attempt.Route, srcIdx, msg,
 		)
 		if err != nil {
-			log.Errorf("Error reporting payment result to mc: %v",
+			p.log.Errorf("Error reporting payment result to mc: %v",
 				err)
 
 			reason = &internalErrorReason
@@ -780,15 +811,15 @@ func (p *paymentLifecycle) handleSwitchErr(attempt *channeldb.HTLCAttempt,
 	// case we can safely send a new payment attempt, and wait for its
 	// result to be available.
 	if errors.Is(sendErr, htlcswitch.ErrPaymentIDNotFound) {
-		log.Debugf("Attempt ID %v for payment %v not found in the "+
+		p.log.Debugf("Attempt ID %v for payment %v not found in the "+
 			"Switch, retrying.", attempt.AttemptID, p.identifier)
 
 		return p.failAttempt(attemptID, sendErr)
 	}
 
 	if sendErr == htlcswitch.ErrUnreadableFailureMessage {
-		log.Warn("Unreadable failure when sending htlc: id=%v, hash=%v",
-			attempt.AttemptID, attempt.Hash)
+		p.log.Warn("Unreadable failure when sending htlc: "+
+			"id=%v, hash=%v", attempt.AttemptID, attempt.Hash)
 
 		// Since this error message cannot be decrypted, we will send a
 		// nil error message to our mission controller and fail the
@@ -833,7 +864,7 @@ func (p *paymentLifecycle) handleSwitchErr(attempt *channeldb.HTLCAttempt,
 		)
 	}
 
-	log.Tracef("Node=%v reported failure when sending htlc",
+	p.log.Tracef("Node=%v reported failure when sending htlc",
 		failureSourceIdx)
 
 	return reportAndFail(&failureSourceIdx, failureMessage)
@@ -850,7 +881,7 @@ func (p *paymentLifecycle) handleFailureMessage(rt *route.Route,
 
 	// It makes no sense to apply our own channel updates.
 	if errorSourceIdx == 0 {
-		log.Errorf("Channel update of ourselves received")
+		p.log.Errorf("Channel update of ourselves received")
 
 		return nil
 	}
@@ -867,7 +898,7 @@ func (p *paymentLifecycle) handleFailureMessage(rt *route.Route,
 	errVertex := rt.Hops[errorSourceIdx-1].PubKeyBytes
 	errSource, err := btcec.ParsePubKey(errVertex[:])
 	if err != nil {
-		log.Errorf("Cannot parse pubkey: idx=%v, pubkey=%v",
+		p.log.Errorf("Cannot parse pubkey: idx=%v, pubkey=%v",
 			errorSourceIdx, errVertex)
 
 		return err
@@ -898,7 +929,7 @@ func (p *paymentLifecycle) handleFailureMessage(rt *route.Route,
 		if !p.paySession.UpdateAdditionalEdge(
 			update, errSource, policy) {
 
-			log.Debugf("Invalid channel update received: node=%v",
+			p.log.Debugf("Invalid channel update received: node=%v",
 				errVertex)
 		}
 		return nil
@@ -906,7 +937,7 @@ func (p *paymentLifecycle) handleFailureMessage(rt *route.Route,
 
 	// Apply channel update to the channel edge policy in our db.
 	if !p.router.applyChannelUpdate(update) {
-		log.Debugf("Invalid channel update received: node=%v",
+		p.log.Debugf("Invalid channel update received: node=%v",
 			errVertex)
 	}
 	return nil
@@ -916,7 +947,7 @@ func (p *paymentLifecycle) handleFailureMessage(rt *route.Route,
 func (p *paymentLifecycle) failAttempt(attemptID uint64,
 	sendError error) (*attemptResult, error) {
 
-	log.Warnf("Attempt %v for payment %v failed: %v", attemptID,
+	p.log.Warnf("Attempt %v for payment %v failed: %v", attemptID,
 		p.identifier, sendError)
 
 	failInfo := marshallError(
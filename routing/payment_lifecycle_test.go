@@ -34,6 +34,7 @@ func createTestPaymentLifecycle() *paymentLifecycle {
 	return &paymentLifecycle{
 		router:     rt,
 		identifier: paymentHash,
+		log:        log,
 	}
 }
 
@@ -88,7 +89,8 @@ func newTestPaymentLifecycle(t *testing.T) (*paymentLifecycle, *mockers) {
 	// Create a test payment lifecycle with no fee limit and no timeout.
 	p := newPaymentLifecycle(
 		rt, noFeeLimit, paymentHash, mockPaymentSession,
-		mockShardTracker, 0, 0,
+		mockShardTracker, 0, 0, SplitStrategyHalving,
+		mockMissionControl,
 	)
 
 	// Create a mock payment which is returned from mockControlTower.
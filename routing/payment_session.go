@@ -33,6 +33,44 @@ func ValidateCLTVLimit(limit uint32, delta uint16, includePad bool) error {
 	return nil
 }
 
+// SplitStrategy determines how the amount of a payment is reduced if no route
+// can be found for it, so that the payment is completed in multiple parts.
+type SplitStrategy uint8
+
+const (
+	// SplitStrategyHalving repeatedly halves the amount until a route is
+	// found.
+	SplitStrategyHalving SplitStrategy = iota
+
+	// SplitStrategyEqual splits the remaining amount into an increasing
+	// number of equally sized parts until a route is found for one of
+	// them.
+	SplitStrategyEqual
+
+	// SplitStrategyCapacity reduces the amount to the largest amount that
+	// a single local channel can carry. If a local channel can carry the
+	// amount, the bottleneck is elsewhere in the network and the amount
+	// is halved instead.
+	SplitStrategyCapacity
+)
+
+// String returns a human-readable name of the split strategy.
+func (s SplitStrategy) String() string {
+	switch s {
+	case SplitStrategyHalving:
+		return "halving"
+
+	case SplitStrategyEqual:
+		return "equal"
+
+	case SplitStrategyCapacity:
+		return "capacity"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
 // noRouteError encodes a non-critical error encountered during path finding.
 type noRouteError uint8
 
@@ -260,6 +298,7 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 		PaymentAddr:        p.payment.PaymentAddr,
 		Amp:                p.payment.amp,
 		Metadata:           p.payment.Metadata,
+		CostWeights:        p.payment.CostWeights,
 	}
 
 	finalHtlcExpiry := int32(height) + int32(finalCltvDelta)
@@ -276,6 +315,13 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 		maxAmt = *p.payment.MaxShardAmt
 	}
 
+	// Keep track of the amount that is split and the number of parts it
+	// is split into for equal splitting.
+	var (
+		splitAmt = maxAmt
+		parts    = uint32(1)
+	)
+
 	for {
 		// Get a routing graph.
 		routingGraph, cleanup, err := p.getRoutingGraph()
@@ -310,6 +356,25 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 			maxAmt, p.payment.TimePref, finalHtlcExpiry,
 		)
 
+		// For capacity-aware splitting, determine the largest amount
+		// that a single local channel can carry while the graph and
+		// bandwidth hints are still at hand.
+		var maxLocalAmt lnwire.MilliSatoshi
+		if err == errNoPathFound &&
+			p.payment.SplitStrategy == SplitStrategyCapacity {
+
+			var balanceErr error
+			maxLocalAmt, _, balanceErr = getOutgoingBalance(
+				sourceVertex, p.outgoingChans(),
+				bandwidthHints, routingGraph,
+			)
+			if balanceErr != nil {
+				cleanup()
+
+				return nil, balanceErr
+			}
+		}
+
 		// Close routing graph.
 		cleanup()
 
@@ -351,8 +416,39 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 			}
 
 			// This is where the magic happens. If we can't find a
-			// route, try it for half the amount.
-			maxAmt /= 2
+			// route, try it for a smaller amount as determined by
+			// the split strategy.
+			switch p.payment.SplitStrategy {
+			case SplitStrategyEqual:
+				// Don't split into more parts than we are
+				// allowed to send.
+				parts++
+				if parts > p.payment.MaxParts-activeShards {
+					p.log.Debugf("not splitting because "+
+						"%v equal parts exceed the "+
+						"shard limit", parts)
+
+					return nil, errNoPathFound
+				}
+
+				// Round up so that the parts add up to at
+				// least the split amount.
+				n := lnwire.MilliSatoshi(parts)
+				maxAmt = (splitAmt + n - 1) / n
+
+			case SplitStrategyCapacity:
+				if maxLocalAmt > 0 && maxLocalAmt < maxAmt {
+					maxAmt = maxLocalAmt
+				} else {
+					maxAmt /= 2
+				}
+
+			default:
+				maxAmt /= 2
+			}
+
+			p.log.Debugf("splitting with strategy %v, trying "+
+				"amt=%v", p.payment.SplitStrategy, maxAmt)
 
 			// Put a lower bound on the minimum shard size.
 			if maxAmt < p.minShardAmt {
@@ -402,6 +498,21 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 	}
 }
 
+// outgoingChans returns the set of channels that the payment may use for the
+// first hop. It is nil if any channel may be used.
+func (p *paymentSession) outgoingChans() map[uint64]struct{} {
+	if len(p.payment.OutgoingChannelIDs) == 0 {
+		return nil
+	}
+
+	outgoingChans := make(map[uint64]struct{})
+	for _, outChan := range p.payment.OutgoingChannelIDs {
+		outgoingChans[outChan] = struct{}{}
+	}
+
+	return outgoingChans
+}
+
 // UpdateAdditionalEdge updates the channel edge policy for a private edge. It
 // validates the message signature and checks it's up to date, then applies the
 // updates to the supplied policy. It returns a boolean to indicate whether
@@ -1,6 +1,8 @@
 package routing
 
 import (
+	"time"
+
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/channeldb/models"
@@ -42,6 +44,11 @@ type SessionSource struct {
 	// PathFindingConfig defines global parameters that control the
 	// trade-off in path finding between fees and probability.
 	PathFindingConfig PathFindingConfig
+
+	// ObservePathFinding is an optional function that is called with the
+	// duration and the result of every path finding attempt of the
+	// payment sessions.
+	ObservePathFinding func(time.Duration, error)
 }
 
 // getRoutingGraph returns a routing graph and a clean-up function for
@@ -72,17 +79,51 @@ func (m *SessionSource) NewPaymentSession(p *LightningPayment) (
 		)
 	}
 
+	// Payments that use their own mission control namespace also take
+	// its reliability history into account for path finding.
+	missionControl := m.MissionControl
+	if p.MissionControl != nil {
+		missionControl = p.MissionControl
+	}
+
 	session, err := newPaymentSession(
 		p, getBandwidthHints, m.getRoutingGraph,
-		m.MissionControl, m.PathFindingConfig,
+		missionControl, m.PathFindingConfig,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if m.ObservePathFinding != nil {
+		session.pathFinder = observedPathFinder(
+			session.pathFinder, m.ObservePathFinding,
+		)
+	}
+
 	return session, nil
 }
 
+// observedPathFinder wraps the given path finding algorithm to report the
+// duration and the result of every call to the given function.
+func observedPathFinder(finder pathFinder,
+	observe func(time.Duration, error)) pathFinder {
+
+	return func(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
+		source, target route.Vertex, amt lnwire.MilliSatoshi,
+		timePref float64, finalHtlcExpiry int32) ([]*unifiedEdge,
+		float64, error) {
+
+		start := time.Now()
+		path, probability, err := finder(
+			g, r, cfg, source, target, amt, timePref,
+			finalHtlcExpiry,
+		)
+		observe(time.Since(start), err)
+
+		return path, probability, err
+	}
+}
+
 // NewPaymentSessionEmpty creates a new paymentSession instance that is empty,
 // and will be exhausted immediately. Used for failure reporting to
 // missioncontrol for resumed payment we don't want to make more attempts for.
@@ -252,6 +252,144 @@ func TestRequestRoute(t *testing.T) {
 	}
 }
 
+// TestRequestRouteSplitStrategy tests that the payment session reduces the
+// amount of a shard according to the split strategy of the payment.
+func TestRequestRouteSplitStrategy(t *testing.T) {
+	t.Parallel()
+
+	const (
+		amt       = lnwire.MilliSatoshi(100_000_000)
+		routeable = lnwire.MilliSatoshi(30_000_000)
+	)
+
+	testCases := []struct {
+		name          string
+		strategy      SplitStrategy
+		maxParts      uint32
+		expectedAmts  []lnwire.MilliSatoshi
+		expectNoRoute bool
+	}{{
+		name:     "halving",
+		strategy: SplitStrategyHalving,
+		maxParts: 16,
+		expectedAmts: []lnwire.MilliSatoshi{
+			100_000_000, 50_000_000, 25_000_000,
+		},
+	}, {
+		name:     "equal",
+		strategy: SplitStrategyEqual,
+		maxParts: 16,
+		expectedAmts: []lnwire.MilliSatoshi{
+			100_000_000, 50_000_000, 33_333_334, 25_000_000,
+		},
+	}, {
+		name:     "equal shard limit",
+		strategy: SplitStrategyEqual,
+		maxParts: 3,
+		expectedAmts: []lnwire.MilliSatoshi{
+			100_000_000, 50_000_000, 33_333_334,
+		},
+		expectNoRoute: true,
+	}, {
+		// The largest local channel can carry 40k sat, so the amount
+		// is first reduced to that. As that channel can carry the
+		// next attempt, the amount is halved afterwards.
+		name:     "capacity",
+		strategy: SplitStrategyCapacity,
+		maxParts: 16,
+		expectedAmts: []lnwire.MilliSatoshi{
+			100_000_000, 40_000_000, 20_000_000,
+		},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			payment := &LightningPayment{
+				CltvLimit:      30,
+				FinalCLTVDelta: 8,
+				Amount:         amt,
+				FeeLimit:       1000,
+				MaxParts:       tc.maxParts,
+				PaymentAddr:    &[32]byte{1},
+				DestFeatures: lnwire.NewFeatureVector(
+					lnwire.NewRawFeatureVector(
+						lnwire.TLVOnionPayloadOptional,
+						lnwire.PaymentAddrOptional,
+						lnwire.MPPOptional,
+					), lnwire.Features,
+				),
+				SplitStrategy: tc.strategy,
+			}
+			require.NoError(t, payment.SetPaymentHash([32]byte{}))
+
+			hints := &mockBandwidthHints{
+				hints: map[uint64]lnwire.MilliSatoshi{
+					1: 40_000_000,
+					2: 20_000_000,
+				},
+			}
+
+			session, err := newPaymentSession(
+				payment,
+				func(routingGraph) (bandwidthHints, error) {
+					return hints, nil
+				},
+				func() (routingGraph, func(), error) {
+					return &splitSessionGraph{}, func() {},
+						nil
+				},
+				&MissionControl{}, PathFindingConfig{},
+			)
+			require.NoError(t, err)
+
+			// Only find a path for amounts that are small enough.
+			path := []*unifiedEdge{{
+				policy: &models.CachedEdgePolicy{
+					ToNodePubKey: func() route.Vertex {
+						return route.Vertex{}
+					},
+					ToNodeFeatures: payment.DestFeatures,
+				},
+			}}
+
+			var amts []lnwire.MilliSatoshi
+			session.pathFinder = func(_ *graphParams,
+				_ *RestrictParams, _ *PathFindingConfig,
+				_, _ route.Vertex, amt lnwire.MilliSatoshi,
+				_ float64, _ int32) ([]*unifiedEdge, float64,
+				error) {
+
+				amts = append(amts, amt)
+				if amt > routeable {
+					return nil, 0, errNoPathFound
+				}
+
+				return path, 1.0, nil
+			}
+
+			rt, err := session.RequestRoute(
+				payment.Amount, payment.FeeLimit, 0, 10,
+			)
+			require.Equal(t, tc.expectedAmts, amts)
+
+			if tc.expectNoRoute {
+				require.ErrorIs(t, err, errNoPathFound)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(
+				t, tc.expectedAmts[len(tc.expectedAmts)-1],
+				rt.ReceiverAmt(),
+			)
+		})
+	}
+}
+
 type sessionGraph struct {
 	routingGraph
 }
@@ -259,3 +397,60 @@ type sessionGraph struct {
 func (g *sessionGraph) sourceNode() route.Vertex {
 	return route.Vertex{}
 }
+
+// splitSessionGraph is a session graph whose source node has two local
+// channels.
+type splitSessionGraph struct {
+	sessionGraph
+}
+
+func (g *splitSessionGraph) forEachNodeChannel(_ route.Vertex,
+	cb func(channel *channeldb.DirectedChannel) error) error {
+
+	for _, chanID := range []uint64{1, 2} {
+		err := cb(&channeldb.DirectedChannel{
+			ChannelID:    chanID,
+			OutPolicySet: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TestObservedPathFinder tests that the observed path finder reports the
+// result of every path finding attempt.
+func TestObservedPathFinder(t *testing.T) {
+	t.Parallel()
+
+	var findErr error
+	finder := func(_ *graphParams, _ *RestrictParams,
+		_ *PathFindingConfig, _, _ route.Vertex, _ lnwire.MilliSatoshi,
+		_ float64, _ int32) ([]*unifiedEdge, float64, error) {
+
+		return nil, 0, findErr
+	}
+
+	var observed []error
+	observe := func(duration time.Duration, err error) {
+		require.GreaterOrEqual(t, duration, time.Duration(0))
+		observed = append(observed, err)
+	}
+
+	observedFinder := observedPathFinder(finder, observe)
+
+	_, _, err := observedFinder(
+		nil, nil, nil, route.Vertex{}, route.Vertex{}, 0, 0, 0,
+	)
+	require.NoError(t, err)
+
+	findErr = errNoPathFound
+	_, _, err = observedFinder(
+		nil, nil, nil, route.Vertex{}, route.Vertex{}, 0, 0, 0,
+	)
+	require.ErrorIs(t, err, errNoPathFound)
+
+	require.Equal(t, []error{nil, errNoPathFound}, observed)
+}
@@ -51,6 +51,10 @@ const (
 	// if a channel should be pruned or not.
 	DefaultChannelPruneExpiry = time.Duration(time.Hour * 24 * 14)
 
+	// DefaultGraphPruneInterval is the default interval at which the graph
+	// is examined for zombie channels.
+	DefaultGraphPruneInterval = time.Hour
+
 	// DefaultFirstTimePruneDelay is the time we'll wait after startup
 	// before attempting to prune the graph for zombie channels. We don't
 	// do it immediately after startup to allow lnd to start up without
@@ -365,6 +369,15 @@ type Config struct {
 	// the channel is marked as a zombie channel eligible for pruning.
 	ChannelPruneExpiry time.Duration
 
+	// NewChanPruneExpiry is the prune expiry of channels that were
+	// confirmed less than NewChanAge blocks ago. If zero,
+	// ChannelPruneExpiry applies to all channels.
+	NewChanPruneExpiry time.Duration
+
+	// NewChanAge is the number of blocks since their confirmation for
+	// which channels are subject to the NewChanPruneExpiry.
+	NewChanAge uint32
+
 	// GraphPruneInterval is used as an interval to determine how often we
 	// should examine the channel graph to garbage collect zombie channels.
 	GraphPruneInterval time.Duration
@@ -394,6 +407,18 @@ type Config struct {
 	// from blocking initial usage of the daemon.
 	AssumeChannelValid bool
 
+	// MaxConcurrentChanValidations is the maximum number of concurrent
+	// block fetches and UTXO lookups used to validate announced channels.
+	// If zero, the number of concurrent lookups is only bounded by the
+	// validation barrier.
+	MaxConcurrentChanValidations int
+
+	// FundingTxCacheSize is the number of funding transactions of
+	// announced channels that are cached to avoid fetching their block
+	// again when the channel is re-announced. If zero, no funding
+	// transactions are cached.
+	FundingTxCacheSize int
+
 	// PathFindingConfig defines global path finding parameters.
 	PathFindingConfig PathFindingConfig
 
@@ -486,6 +511,10 @@ type ChannelRouter struct {
 	// consistency between the various database accesses.
 	channelEdgeMtx *multimutex.Mutex[uint64]
 
+	// fundingFetcher is used to fetch the funding transactions and
+	// outputs of announced channels from the chain backend.
+	fundingFetcher *fundingTxFetcher
+
 	// statTicker is a resumable ticker that logs the router's progress as
 	// it discovers channels or receives updates.
 	statTicker ticker.Ticker
@@ -494,6 +523,9 @@ type ChannelRouter struct {
 	// announcements over a window of defaultStatInterval.
 	stats *routerStats
 
+	// pruneStats tracks the graph prune actions since startup.
+	pruneStats pruneStats
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
@@ -528,6 +560,10 @@ func New(cfg Config) (*ChannelRouter, error) {
 		stats:             new(routerStats),
 		quit:              make(chan struct{}),
 	}
+	r.fundingFetcher = newFundingTxFetcher(
+		cfg.Chain, cfg.MaxConcurrentChanValidations,
+		cfg.FundingTxCacheSize,
+	)
 
 	return r, nil
 }
@@ -714,7 +750,8 @@ func (r *ChannelRouter) Start() error {
 			// be tried.
 			_, _, err := r.sendPayment(
 				0, payment.Info.PaymentIdentifier, 0,
-				paySession, shardTracker,
+				paySession, shardTracker, SplitStrategyHalving,
+				r.cfg.MissionControl,
 			)
 			if err != nil {
 				log.Errorf("Resuming payment %v failed: %v.",
@@ -894,6 +931,8 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 
 	log.Infof("Graph pruning complete: %v channels were closed since "+
 		"height %v", len(closedChans), pruneHeight)
+	r.pruneStats.addClosedChans(len(closedChans))
+
 	return nil
 }
 
@@ -902,10 +941,10 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 // true if the policy update from node 1 is considered a zombie, the second
 // boolean is that of node 2, and the final boolean is true if the channel
 // is considered a zombie.
-func (r *ChannelRouter) isZombieChannel(e1,
+func (r *ChannelRouter) isZombieChannel(info *models.ChannelEdgeInfo, e1,
 	e2 *models.ChannelEdgePolicy) (bool, bool, bool) {
 
-	chanExpiry := r.cfg.ChannelPruneExpiry
+	chanExpiry := r.chanPruneExpiry(info.ChannelID)
 
 	e1Zombie := e1 == nil || time.Since(e1.LastUpdate) >= chanExpiry
 	e2Zombie := e2 == nil || time.Since(e2.LastUpdate) >= chanExpiry
@@ -918,7 +957,37 @@ func (r *ChannelRouter) isZombieChannel(e1,
 		e2Time = e2.LastUpdate
 	}
 
-	return e1Zombie, e2Zombie, r.IsZombieChannel(e1Time, e2Time)
+	return e1Zombie, e2Zombie, r.isZombie(chanExpiry, e1Time, e2Time)
+}
+
+// chanPruneExpiry returns the duration after which the channel with the given
+// ID is considered a zombie if it hasn't received any update. Channels that
+// were confirmed less than NewChanAge blocks ago are subject to the
+// NewChanPruneExpiry, if set.
+func (r *ChannelRouter) chanPruneExpiry(chanID uint64) time.Duration {
+	if r.cfg.NewChanPruneExpiry == 0 {
+		return r.cfg.ChannelPruneExpiry
+	}
+
+	bestHeight := atomic.LoadUint32(&r.bestHeight)
+	confHeight := lnwire.NewShortChanIDFromInt(chanID).BlockHeight
+	if confHeight+r.cfg.NewChanAge > bestHeight {
+		return r.cfg.NewChanPruneExpiry
+	}
+
+	return r.cfg.ChannelPruneExpiry
+}
+
+// minPruneExpiry returns the shortest prune expiry that applies to any
+// channel.
+func (r *ChannelRouter) minPruneExpiry() time.Duration {
+	if r.cfg.NewChanPruneExpiry != 0 &&
+		r.cfg.NewChanPruneExpiry < r.cfg.ChannelPruneExpiry {
+
+		return r.cfg.NewChanPruneExpiry
+	}
+
+	return r.cfg.ChannelPruneExpiry
 }
 
 // IsZombieChannel takes the timestamps of the latest channel updates for a
@@ -927,7 +996,13 @@ func (r *ChannelRouter) isZombieChannel(e1,
 func (r *ChannelRouter) IsZombieChannel(updateTime1,
 	updateTime2 time.Time) bool {
 
-	chanExpiry := r.cfg.ChannelPruneExpiry
+	return r.isZombie(r.cfg.ChannelPruneExpiry, updateTime1, updateTime2)
+}
+
+// isZombie returns true if a channel with the given update timestamps is
+// considered a zombie based on the given prune expiry.
+func (r *ChannelRouter) isZombie(chanExpiry time.Duration, updateTime1,
+	updateTime2 time.Time) bool {
 
 	e1Zombie := updateTime1.IsZero() ||
 		time.Since(updateTime1) >= chanExpiry
@@ -954,7 +1029,6 @@ func (r *ChannelRouter) IsZombieChannel(updateTime1,
 // periodically to keep a healthy, lively routing table.
 func (r *ChannelRouter) pruneZombieChans() error {
 	chansToPrune := make(map[uint64]struct{})
-	chanExpiry := r.cfg.ChannelPruneExpiry
 
 	log.Infof("Examining channel graph for zombie channels")
 
@@ -983,7 +1057,9 @@ func (r *ChannelRouter) pruneZombieChans() error {
 			return nil
 		}
 
-		e1Zombie, e2Zombie, isZombieChan := r.isZombieChannel(e1, e2)
+		e1Zombie, e2Zombie, isZombieChan := r.isZombieChannel(
+			info, e1, e2,
+		)
 
 		if e1Zombie {
 			log.Tracef("Node1 pubkey=%x of chan_id=%v is zombie",
@@ -995,8 +1071,8 @@ func (r *ChannelRouter) pruneZombieChans() error {
 				info.NodeKey2Bytes, info.ChannelID)
 		}
 
-		// If either edge hasn't been updated for a period of
-		// chanExpiry, then we'll mark the channel itself as eligible
+		// If either edge hasn't been updated for the prune expiry of
+		// the channel, then we'll mark the channel itself as eligible
 		// for graph pruning.
 		if !isZombieChan {
 			return nil
@@ -1038,8 +1114,10 @@ func (r *ChannelRouter) pruneZombieChans() error {
 		}
 	}
 
+	// We fetch all channels that weren't updated within the shortest
+	// prune expiry, the expiry of each channel is checked above.
 	startTime := time.Unix(0, 0)
-	endTime := time.Now().Add(-1 * chanExpiry)
+	endTime := time.Now().Add(-1 * r.minPruneExpiry())
 	oldEdges, err := r.cfg.Graph.ChanUpdatesInHorizon(startTime, endTime)
 	if err != nil {
 		return fmt.Errorf("unable to fetch expired channel updates "+
@@ -1052,6 +1130,7 @@ func (r *ChannelRouter) pruneZombieChans() error {
 
 	log.Infof("Pruning %v zombie channels", len(chansToPrune))
 	if len(chansToPrune) == 0 {
+		r.pruneStats.addZombiePrune(0)
 		return nil
 	}
 
@@ -1068,6 +1147,7 @@ func (r *ChannelRouter) pruneZombieChans() error {
 	if err != nil {
 		return fmt.Errorf("unable to delete zombie channels: %w", err)
 	}
+	r.pruneStats.addZombiePrune(len(toPrune))
 
 	// With the channels pruned, we'll also attempt to prune any nodes that
 	// were a part of them.
@@ -1434,6 +1514,7 @@ func (r *ChannelRouter) updateGraphWithClosedChannels(
 
 	log.Infof("Block %v (height=%v) closed %v channels", chainUpdate.Hash,
 		blockHeight, len(chansClosed))
+	r.pruneStats.addClosedChans(len(chansClosed))
 
 	if len(chansClosed) == 0 {
 		return err
@@ -1501,11 +1582,12 @@ func (r *ChannelRouter) addZombieEdge(chanID uint64) error {
 }
 
 // makeFundingScript is used to make the funding script for both segwit v0 and
-// segwit v1 (taproot) channels.
+// segwit v1 (taproot) channels. Channels that were announced with
+// GossipVersion2 are always funded by a taproot output.
 //
 // TODO(roasbeef: export and use elsewhere?
-func makeFundingScript(bitcoinKey1, bitcoinKey2 []byte,
-	chanFeatures []byte) ([]byte, error) {
+func makeFundingScript(bitcoinKey1, bitcoinKey2 []byte, chanFeatures []byte,
+	version lnwire.GossipVersion) ([]byte, error) {
 
 	legacyFundingScript := func() ([]byte, error) {
 		witnessScript, err := input.GenMultiSigScript(
@@ -1522,6 +1604,30 @@ func makeFundingScript(bitcoinKey1, bitcoinKey2 []byte,
 		return pkScript, nil
 	}
 
+	taprootFundingScript := func() ([]byte, error) {
+		pubKey1, err := btcec.ParsePubKey(bitcoinKey1)
+		if err != nil {
+			return nil, err
+		}
+		pubKey2, err := btcec.ParsePubKey(bitcoinKey2)
+		if err != nil {
+			return nil, err
+		}
+
+		fundingScript, _, err := input.GenTaprootFundingScript(
+			pubKey1, pubKey2, 0,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return fundingScript, nil
+	}
+
+	if version == lnwire.GossipVersion2 {
+		return taprootFundingScript()
+	}
+
 	if len(chanFeatures) == 0 {
 		return legacyFundingScript()
 	}
@@ -1542,23 +1648,7 @@ func makeFundingScript(bitcoinKey1, bitcoinKey2 []byte,
 		lnwire.SimpleTaprootChannelsOptionalStaging,
 	) {
 
-		pubKey1, err := btcec.ParsePubKey(bitcoinKey1)
-		if err != nil {
-			return nil, err
-		}
-		pubKey2, err := btcec.ParsePubKey(bitcoinKey2)
-		if err != nil {
-			return nil, err
-		}
-
-		fundingScript, _, err := input.GenTaprootFundingScript(
-			pubKey1, pubKey2, 0,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		return fundingScript, nil
+		return taprootFundingScript()
 	}
 
 	return legacyFundingScript()
@@ -1675,7 +1765,7 @@ func (r *ChannelRouter) processUpdate(msg interface{},
 		// reality.
 		fundingPkScript, err := makeFundingScript(
 			msg.BitcoinKey1Bytes[:], msg.BitcoinKey2Bytes[:],
-			msg.Features,
+			msg.Features, msg.Version,
 		)
 		if err != nil {
 			return err
@@ -1706,7 +1796,7 @@ func (r *ChannelRouter) processUpdate(msg interface{},
 		// Now that we have the funding outpoint of the channel, ensure
 		// that it hasn't yet been spent. If so, then this channel has
 		// been closed so we'll ignore it.
-		chanUtxo, err := r.cfg.Chain.GetUtxo(
+		chanUtxo, err := r.fundingFetcher.getUtxo(
 			fundingPoint, fundingPkScript, channelID.BlockHeight,
 			r.quit,
 		)
@@ -1723,9 +1813,21 @@ func (r *ChannelRouter) processUpdate(msg interface{},
 				msg.ChannelID, fundingPoint, err)
 		}
 
+		// Channels announced with gossip v2 commit to their capacity
+		// in the announcement, so it must match the funding output.
+		chanValue := btcutil.Amount(chanUtxo.Value)
+		if msg.Version == lnwire.GossipVersion2 &&
+			msg.Capacity != chanValue {
+
+			return newErrf(ErrInvalidFundingOutput, "announced "+
+				"capacity %v of chan_id=%v doesn't match "+
+				"funding output value %v", msg.Capacity,
+				msg.ChannelID, chanValue)
+		}
+
 		// TODO(roasbeef): this is a hack, needs to be removed
 		// after commitment fees are dynamic.
-		msg.Capacity = btcutil.Amount(chanUtxo.Value)
+		msg.Capacity = chanValue
 		msg.ChannelPoint = *fundingPoint
 		if err := r.cfg.Graph.AddChannelEdge(msg, op...); err != nil {
 			return errors.Errorf("unable to add edge: %v", err)
@@ -1777,7 +1879,8 @@ func (r *ChannelRouter) processUpdate(msg interface{},
 		// If the channel is marked as a zombie in our database, and
 		// we consider this a stale update, then we should not apply the
 		// policy.
-		isStaleUpdate := time.Since(msg.LastUpdate) > r.cfg.ChannelPruneExpiry
+		isStaleUpdate := time.Since(msg.LastUpdate) >
+			r.chanPruneExpiry(msg.ChannelID)
 		if isZombie && isStaleUpdate {
 			return newErrf(ErrIgnored, "ignoring stale update "+
 				"(flags=%v|%v) for zombie chan_id=%v",
@@ -1801,6 +1904,11 @@ func (r *ChannelRouter) processUpdate(msg interface{},
 		// newer than what we already know of we can exit early.
 		switch {
 
+		// Policies received with gossip v2 are ordered by their block
+		// height instead of their timestamp, which has already been
+		// checked by the gossiper.
+		case msg.Version == lnwire.GossipVersion2:
+
 		// A flag set of 0 indicates this is an announcement for the
 		// "first" node in the channel.
 		case msg.ChannelFlags&lnwire.ChanUpdateDirection == 0:
@@ -1855,7 +1963,7 @@ func (r *ChannelRouter) fetchFundingTxWrapper(chanID *lnwire.ShortChannelID) (
 	errChan := make(chan error, 1)
 
 	go func() {
-		tx, err := r.fetchFundingTx(chanID)
+		tx, err := r.fundingFetcher.fetchFundingTx(chanID, r.quit)
 		if err != nil {
 			errChan <- err
 			return
@@ -1876,39 +1984,6 @@ func (r *ChannelRouter) fetchFundingTxWrapper(chanID *lnwire.ShortChannelID) (
 	}
 }
 
-// fetchFundingTx returns the funding transaction identified by the passed
-// short channel ID.
-//
-// TODO(roasbeef): replace with call to GetBlockTransaction? (would allow to
-// later use getblocktxn)
-func (r *ChannelRouter) fetchFundingTx(
-	chanID *lnwire.ShortChannelID) (*wire.MsgTx, error) {
-
-	// First fetch the block hash by the block number encoded, then use
-	// that hash to fetch the block itself.
-	blockNum := int64(chanID.BlockHeight)
-	blockHash, err := r.cfg.Chain.GetBlockHash(blockNum)
-	if err != nil {
-		return nil, err
-	}
-	fundingBlock, err := r.cfg.Chain.GetBlock(blockHash)
-	if err != nil {
-		return nil, err
-	}
-
-	// As a sanity check, ensure that the advertised transaction index is
-	// within the bounds of the total number of transactions within a
-	// block.
-	numTxns := uint32(len(fundingBlock.Transactions))
-	if chanID.TxIndex > numTxns-1 {
-		return nil, fmt.Errorf("tx_index=#%v "+
-			"is out of range (max_index=%v), network_chan_id=%v",
-			chanID.TxIndex, numTxns-1, chanID)
-	}
-
-	return fundingBlock.Transactions[chanID.TxIndex].Copy(), nil
-}
-
 // routingMsg couples a routing related routing topology update to the
 // error channel.
 type routingMsg struct {
@@ -2324,9 +2399,27 @@ type LightningPayment struct {
 	// value in between for a mix.
 	TimePref float64
 
+	// CostWeights scales the components of the cost that path finding
+	// minimizes for this payment. If nil, the default weights are used.
+	CostWeights *CostWeights
+
+	// SplitStrategy determines how the payment is split into multiple
+	// parts if no route can be found for the full amount.
+	SplitStrategy SplitStrategy
+
+	// MissionControl is the mission control of the namespace that the
+	// payment uses for path finding and reports its results to. If nil,
+	// the router's mission control is used.
+	MissionControl MissionController
+
 	// Metadata is additional data that is sent along with the payment to
 	// the payee.
 	Metadata []byte
+
+	// IdempotencyKey is an optional key supplied by the caller that is
+	// stored with the payment. Only a single payment can be created for a
+	// given key.
+	IdempotencyKey []byte
 }
 
 // AMPOptions houses information that must be known in order to send an AMP
@@ -2392,6 +2485,7 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte,
 	return r.sendPayment(
 		payment.FeeLimit, payment.Identifier(),
 		payment.PayAttemptTimeout, paySession, shardTracker,
+		payment.SplitStrategy, r.missionControlFor(payment),
 	)
 }
 
@@ -2412,6 +2506,7 @@ func (r *ChannelRouter) SendPaymentAsync(payment *LightningPayment,
 		_, _, err := r.sendPayment(
 			payment.FeeLimit, payment.Identifier(),
 			payment.PayAttemptTimeout, ps, st,
+			payment.SplitStrategy, r.missionControlFor(payment),
 		)
 		if err != nil {
 			log.Errorf("Payment %x failed: %v",
@@ -2420,6 +2515,17 @@ func (r *ChannelRouter) SendPaymentAsync(payment *LightningPayment,
 	}()
 }
 
+// missionControlFor returns the mission control that the given payment uses.
+func (r *ChannelRouter) missionControlFor(
+	payment *LightningPayment) MissionController {
+
+	if payment.MissionControl != nil {
+		return payment.MissionControl
+	}
+
+	return r.cfg.MissionControl
+}
+
 // spewPayment returns a log closures that provides a spewed string
 // representation of the passed payment.
 func spewPayment(payment *LightningPayment) logClosure {
@@ -2437,6 +2543,11 @@ func spewPayment(payment *LightningPayment) logClosure {
 		}
 		p := *payment
 		p.RouteHints = routeHints
+
+		// The mission control is omitted, as it holds the whole
+		// reliability history of its namespace.
+		p.MissionControl = nil
+
 		return spew.Sdump(p)
 	})
 }
@@ -2455,14 +2566,16 @@ func (r *ChannelRouter) PreparePayment(payment *LightningPayment) (
 	}
 
 	// Record this payment hash with the ControlTower, ensuring it is not
-	// already in-flight.
-	//
-	// TODO(roasbeef): store records as part of creation info?
+	// already in-flight. The custom records and metadata are stored with
+	// it, so applications can correlate the payment with their own data.
 	info := &channeldb.PaymentCreationInfo{
 		PaymentIdentifier: payment.Identifier(),
 		Value:             payment.Amount,
 		CreationTime:      r.cfg.Clock.Now(),
 		PaymentRequest:    payment.PaymentRequest,
+		DestCustomRecords: payment.DestCustomRecords,
+		Metadata:          payment.Metadata,
+		IdempotencyKey:    payment.IdempotencyKey,
 	}
 
 	// Create a new ShardTracker that we'll use during the life cycle of
@@ -2553,6 +2666,8 @@ func (r *ChannelRouter) sendToRoute(htlcHash lntypes.Hash, rt *route.Route,
 		Value:             amt,
 		CreationTime:      r.cfg.Clock.Now(),
 		PaymentRequest:    nil,
+		DestCustomRecords: finalHop.CustomRecords,
+		Metadata:          finalHop.Metadata,
 	}
 
 	err := r.cfg.Control.InitPayment(paymentIdentifier, info)
@@ -2587,6 +2702,7 @@ func (r *ChannelRouter) sendToRoute(htlcHash lntypes.Hash, rt *route.Route,
 	// - no current block height.
 	p := newPaymentLifecycle(
 		r, 0, paymentIdentifier, nil, shardTracker, 0, 0,
+		SplitStrategyHalving, r.cfg.MissionControl,
 	)
 
 	// We found a route to try, create a new HTLC attempt to try.
@@ -2682,8 +2798,9 @@ func (r *ChannelRouter) sendToRoute(htlcHash lntypes.Hash, rt *route.Route,
 // the ControlTower.
 func (r *ChannelRouter) sendPayment(feeLimit lnwire.MilliSatoshi,
 	identifier lntypes.Hash, timeout time.Duration,
-	paySession PaymentSession,
-	shardTracker shards.ShardTracker) ([32]byte, *route.Route, error) {
+	paySession PaymentSession, shardTracker shards.ShardTracker,
+	splitStrategy SplitStrategy,
+	missionControl MissionController) ([32]byte, *route.Route, error) {
 
 	// We'll also fetch the current block height so we can properly
 	// calculate the required HTLC time locks within the route.
@@ -2696,7 +2813,8 @@ func (r *ChannelRouter) sendPayment(feeLimit lnwire.MilliSatoshi,
 	// can resume the payment from the current state.
 	p := newPaymentLifecycle(
 		r, feeLimit, identifier, paySession,
-		shardTracker, timeout, currentHeight,
+		shardTracker, timeout, currentHeight, splitStrategy,
+		missionControl,
 	)
 
 	return p.resumePayment()
@@ -3011,8 +3129,10 @@ func (r *ChannelRouter) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
 			}
 		}
 
-		// Otherwise, we'll fall back to our usual ChannelPruneExpiry.
-		return time.Since(timestamp) > r.cfg.ChannelPruneExpiry
+		// Otherwise, we'll fall back to the prune expiry of the
+		// channel.
+		expiry := r.chanPruneExpiry(chanID.ToUint64())
+		return time.Since(timestamp) > expiry
 	}
 
 	// If we don't know of the edge, then it means it's fresh (thus not
@@ -3044,7 +3164,20 @@ func (r *ChannelRouter) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) MarkEdgeLive(chanID lnwire.ShortChannelID) error {
-	return r.cfg.Graph.MarkEdgeLive(chanID.ToUint64())
+	err := r.cfg.Graph.MarkEdgeLive(chanID.ToUint64())
+	if err != nil {
+		return err
+	}
+
+	r.pruneStats.incZombiesResurrected()
+
+	return nil
+}
+
+// PruneStats returns the number of graph prune actions that were taken since
+// the router was started.
+func (r *ChannelRouter) PruneStats() PruneStats {
+	return r.pruneStats.snapshot()
 }
 
 // ErrNoChannel is returned when a route cannot be built because there are no
@@ -3111,16 +3244,23 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 	}
 
 	sourceNode := r.selfNode.PubKeyBytes
-	unifiers, senderAmt, err := getRouteUnifiers(
-		sourceNode, hops, useMinAmt, runningAmt, outgoingChans,
-		r.cachedGraph, bandwidthHints,
+	unifiers, err := getRouteUnifiers(
+		sourceNode, hops, outgoingChans, r.cachedGraph,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pathEdges, senderAmt, err := senderAmtBackwardPass(
+		sourceNode, hops, unifiers, useMinAmt, runningAmt,
+		bandwidthHints,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	pathEdges, receiverAmt, err := getPathEdges(
-		sourceNode, senderAmt, unifiers, bandwidthHints, hops,
+	receiverAmt, err := receiverAmtForwardPass(
+		sourceNode, hops, senderAmt, pathEdges,
 	)
 	if err != nil {
 		return nil, err
@@ -3139,52 +3279,88 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 	)
 }
 
-// getRouteUnifiers returns a list of edge unifiers for the given route.
+// hopFromNode returns the node that forwards over the channel at the given
+// position of the route.
+func hopFromNode(source route.Vertex, hops []route.Vertex,
+	position int) route.Vertex {
+
+	if position == 0 {
+		return source
+	}
+
+	return hops[position-1]
+}
+
+// getRouteUnifiers returns a list of edge unifiers for the given route. The
+// inbound fees of all nodes except the final one are taken into account.
 func getRouteUnifiers(source route.Vertex, hops []route.Vertex,
-	useMinAmt bool, runningAmt lnwire.MilliSatoshi,
-	outgoingChans map[uint64]struct{}, graph routingGraph,
-	bandwidthHints *bandwidthManager) ([]*edgeUnifier, lnwire.MilliSatoshi,
-	error) {
+	outgoingChans map[uint64]struct{},
+	graph routingGraph) ([]*edgeUnifier, error) {
 
 	// Allocate a list that will contain the edge unifiers for this route.
 	unifiers := make([]*edgeUnifier, len(hops))
 
-	// Traverse hops backwards to accumulate fees in the running amounts.
-	for i := len(hops) - 1; i >= 0; i-- {
-		toNode := hops[i]
-
-		var fromNode route.Vertex
-		if i == 0 {
-			fromNode = source
-		} else {
-			fromNode = hops[i-1]
-		}
-
-		localChan := i == 0
+	for i, toNode := range hops {
+		fromNode := hopFromNode(source, hops, i)
 
 		// Build unified policies for this hop based on the channels
-		// known in the graph. Don't use inbound fees.
-		//
-		// TODO: Add inbound fees support for BuildRoute.
+		// known in the graph. The exit hop doesn't charge inbound
+		// fees.
+		isExitHop := i == len(hops)-1
 		u := newNodeEdgeUnifier(
-			source, toNode, false, outgoingChans,
+			source, toNode, !isExitHop, outgoingChans,
 		)
 
 		err := u.addGraphPolicies(graph)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 
 		// Exit if there are no channels.
 		edgeUnifier, ok := u.edgeUnifiers[fromNode]
 		if !ok {
 			log.Errorf("Cannot find policy for node %v", fromNode)
-			return nil, 0, ErrNoChannel{
+			return nil, ErrNoChannel{
 				fromNode: fromNode,
 				position: i,
 			}
 		}
 
+		unifiers[i] = edgeUnifier
+	}
+
+	return unifiers, nil
+}
+
+// senderAmtBackwardPass traverses the route backwards to select an edge for
+// each hop that can carry the amount needed to deliver runningAmt to the final
+// hop. It returns the selected edges and the total amount, including fees, to
+// send. If useMinAmt is set, the amount is increased where needed to satisfy
+// the minimum HTLC sizes along the route.
+func senderAmtBackwardPass(source route.Vertex, hops []route.Vertex,
+	unifiers []*edgeUnifier, useMinAmt bool,
+	runningAmt lnwire.MilliSatoshi,
+	bandwidthHints bandwidthHints) ([]*unifiedEdge, lnwire.MilliSatoshi,
+	error) {
+
+	if len(unifiers) == 0 {
+		return nil, 0, fmt.Errorf("no unifiers provided")
+	}
+
+	pathEdges := make([]*unifiedEdge, len(unifiers))
+
+	// nextOutFee is the outbound fee that the "to" node of the current
+	// hop charges for forwarding over the next hop. Any inbound fee
+	// discount of that node is capped by it, so that its total fee can't
+	// turn negative. The final hop doesn't forward and charges no fees.
+	var nextOutFee lnwire.MilliSatoshi
+
+	// Traverse hops backwards to accumulate fees in the running amount,
+	// which is the amount that the "to" node needs to receive net of its
+	// inbound fee.
+	for i := len(unifiers) - 1; i >= 0; i-- {
+		edgeUnifier := unifiers[i]
+
 		// If using min amt, increase amt if needed.
 		if useMinAmt {
 			min := edgeUnifier.minAmt()
@@ -3194,8 +3370,12 @@ func getRouteUnifiers(source route.Vertex, hops []route.Vertex,
 		}
 
 		// Get an edge for the specific amount that we want to forward.
-		edge := edgeUnifier.getEdge(runningAmt, bandwidthHints, 0)
+		edge := edgeUnifier.getEdge(
+			runningAmt, bandwidthHints, nextOutFee,
+		)
 		if edge == nil {
+			fromNode := hopFromNode(source, hops, i)
+
 			log.Errorf("Cannot find policy with amt=%v for node %v",
 				runningAmt, fromNode)
 
@@ -3205,55 +3385,107 @@ func getRouteUnifiers(source route.Vertex, hops []route.Vertex,
 			}
 		}
 
-		// Add fee for this hop.
-		if !localChan {
-			runningAmt += edge.policy.ComputeFee(runningAmt)
+		// The amount that is sent over the channel includes the inbound
+		// fee of the "to" node.
+		inboundFee := calcCappedInboundFee(
+			edge, runningAmt, nextOutFee,
+		)
+		runningAmt += lnwire.MilliSatoshi(inboundFee)
+
+		// Add the outbound fee that the "from" node charges for this
+		// hop. We don't pay fees to ourselves for the local channel.
+		nextOutFee = 0
+		if i > 0 {
+			nextOutFee = edge.policy.ComputeFee(runningAmt)
+			runningAmt += nextOutFee
 		}
 
 		log.Tracef("Select channel %v at position %v",
 			edge.policy.ChannelID, i)
 
-		unifiers[i] = edgeUnifier
+		pathEdges[i] = edge
 	}
 
-	return unifiers, runningAmt, nil
+	return pathEdges, runningAmt, nil
 }
 
-// getPathEdges returns the edges that make up the path and the total amount,
-// including fees, to send the payment.
-func getPathEdges(source route.Vertex, receiverAmt lnwire.MilliSatoshi,
-	unifiers []*edgeUnifier, bandwidthHints *bandwidthManager,
-	hops []route.Vertex) ([]*unifiedEdge,
-	lnwire.MilliSatoshi, error) {
+// receiverAmtForwardPass returns the amount that the final hop receives if the
+// given amount is sent along the path edges. Because the amount may have been
+// increased in the backward pass, fees need to be recalculated and amount
+// ranges re-checked.
+func receiverAmtForwardPass(source route.Vertex, hops []route.Vertex,
+	runningAmt lnwire.MilliSatoshi,
+	pathEdges []*unifiedEdge) (lnwire.MilliSatoshi, error) {
 
-	// Now that we arrived at the start of the route and found out the route
-	// total amount, we make a forward pass. Because the amount may have
-	// been increased in the backward pass, fees need to be recalculated and
-	// amount ranges re-checked.
-	var pathEdges []*unifiedEdge
-	for i, unifier := range unifiers {
-		edge := unifier.getEdge(receiverAmt, bandwidthHints, 0)
-		if edge == nil {
-			fromNode := source
-			if i > 0 {
-				fromNode = hops[i-1]
-			}
+	if len(pathEdges) == 0 {
+		return 0, fmt.Errorf("no edges to forward through")
+	}
 
-			return nil, 0, ErrNoChannel{
+	for i, edge := range pathEdges {
+		// Decrease the amount to send while going forward.
+		if i > 0 {
+			runningAmt = outgoingFromIncoming(
+				runningAmt, pathEdges[i-1], edge,
+			)
+		}
+
+		if !edge.amtInRange(runningAmt) {
+			fromNode := hopFromNode(source, hops, i)
+
+			log.Errorf("Amount %v not in range for node %v",
+				runningAmt, fromNode)
+
+			return 0, ErrNoChannel{
 				fromNode: fromNode,
 				position: i,
 			}
 		}
+	}
 
-		if i > 0 {
-			// Decrease the amount to send while going forward.
-			receiverAmt -= edge.policy.ComputeFeeFromIncoming(
-				receiverAmt,
-			)
-		}
+	return runningAmt, nil
+}
 
-		pathEdges = append(pathEdges, edge)
+// incomingFromOutgoing returns the amount that a node needs to receive over
+// the incoming edge to forward the given amount over the outgoing edge. It
+// takes into account the outbound fee of the outgoing edge and the inbound fee
+// of the incoming edge, and, like a forwarding node, treats a negative total
+// fee as zero.
+func incomingFromOutgoing(outgoingAmt lnwire.MilliSatoshi,
+	incoming, outgoing *unifiedEdge) lnwire.MilliSatoshi {
+
+	outboundFee := outgoing.policy.ComputeFee(outgoingAmt)
+
+	// The inbound fee is based on the outgoing amount plus the outbound
+	// fee.
+	netAmt := outgoingAmt + outboundFee
+	inboundFee := incoming.inboundFees.CalcFee(netAmt)
+
+	if int64(outboundFee)+inboundFee < 0 {
+		return outgoingAmt
+	}
+
+	return netAmt + lnwire.MilliSatoshi(inboundFee)
+}
+
+// outgoingFromIncoming returns the largest amount that a node forwards over the
+// outgoing edge when it receives the given amount over the incoming edge. It is
+// the inverse of incomingFromOutgoing, which is searched for as the fees are
+// rounded and the total fee is capped at zero.
+func outgoingFromIncoming(incomingAmt lnwire.MilliSatoshi,
+	incoming, outgoing *unifiedEdge) lnwire.MilliSatoshi {
+
+	// As the total fee is never negative, the outgoing amount is at most
+	// the incoming amount.
+	low, high := lnwire.MilliSatoshi(0), incomingAmt
+	for low < high {
+		mid := low + (high-low+1)/2
+		amt := incomingFromOutgoing(mid, incoming, outgoing)
+		if amt <= incomingAmt {
+			low = mid
+		} else {
+			high = mid - 1
+		}
 	}
 
-	return pathEdges, receiverAmt, nil
+	return low
 }
@@ -6,6 +6,7 @@ import (
 	"image/color"
 	"math"
 	"math/rand"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -2278,6 +2279,51 @@ func TestPruneChannelGraphStaleEdges(t *testing.T) {
 	}
 }
 
+// TestPruneChannelGraphNewChanHorizon tests that channels that were confirmed
+// less than NewChanAge blocks ago are pruned according to the
+// NewChanPruneExpiry, while older channels use the ChannelPruneExpiry.
+func TestPruneChannelGraphNewChanHorizon(t *testing.T) {
+	t.Parallel()
+
+	const startingHeight = 100
+
+	// Both channels were last updated two hours ago, which is beyond the
+	// horizon of new channels, but within the default one.
+	timestamp := time.Now().Add(-2 * time.Hour)
+	oldChanID := lnwire.ShortChannelID{BlockHeight: 50}.ToUint64()
+	newChanID := lnwire.ShortChannelID{BlockHeight: 95}.ToUint64()
+	testChannels := []*testChannel{
+		symmetricTestChannel("b", "c", 100000, &testChannelPolicy{
+			LastUpdate: timestamp,
+		}, oldChanID),
+		symmetricTestChannel("d", "e", 100000, &testChannelPolicy{
+			LastUpdate: timestamp,
+		}, newChanID),
+	}
+
+	testGraph, err := createTestGraphFromChannels(
+		t, true, testChannels, "a",
+	)
+	require.NoError(t, err, "unable to create test graph")
+
+	ctx := createTestCtxFromGraphInstance(
+		t, startingHeight, testGraph, false,
+	)
+	ctx.router.cfg.NewChanPruneExpiry = time.Hour
+	ctx.router.cfg.NewChanAge = 10
+
+	assertChannelsPruned(t, ctx.graph, testChannels)
+
+	// Only the new channel should be pruned, which is reflected in the
+	// prune stats.
+	require.NoError(t, ctx.router.pruneZombieChans())
+	assertChannelsPruned(t, ctx.graph, testChannels, newChanID)
+
+	stats := ctx.router.PruneStats()
+	require.EqualValues(t, 1, stats.NumZombieChansPruned)
+	require.False(t, stats.LastZombiePrune.IsZero())
+}
+
 // TestPruneChannelGraphDoubleDisabled test that we can properly prune channels
 // with both edges disabled from our channel graph.
 func TestPruneChannelGraphDoubleDisabled(t *testing.T) {
@@ -3165,52 +3211,145 @@ func TestBuildRoute(t *testing.T) {
 	}
 }
 
-// TestGetPathEdges tests that the getPathEdges function returns the expected
-// edges and amount when given a set of unifiers and does not panic.
-func TestGetPathEdges(t *testing.T) {
+// TestRouteAmtPasses tests that the backward and forward passes used to build
+// a route take the outbound fees and the, possibly capped, inbound fees of the
+// intermediate nodes into account.
+func TestRouteAmtPasses(t *testing.T) {
 	t.Parallel()
 
-	const startingBlockHeight = 101
-	ctx := createTestCtxFromFile(t, startingBlockHeight, basicGraphFilePath)
+	const receiverAmt = lnwire.MilliSatoshi(1_000_000)
 
-	testCases := []struct {
-		sourceNode     route.Vertex
-		amt            lnwire.MilliSatoshi
-		unifiers       []*edgeUnifier
-		bandwidthHints *bandwidthManager
-		hops           []route.Vertex
-
-		expectedEdges []*models.CachedEdgePolicy
-		expectedAmt   lnwire.MilliSatoshi
-		expectedErr   string
-	}{{
-		sourceNode: ctx.aliases["roasbeef"],
-		unifiers: []*edgeUnifier{
-			{
-				edges:     []*unifiedEdge{},
-				localChan: true,
+	source := route.Vertex{1}
+	hops := []route.Vertex{{2}, {3}, {4}}
+
+	newEdge := func(chanID uint64, baseFee lnwire.MilliSatoshi,
+		feeRate lnwire.MilliSatoshi,
+		inboundFee models.InboundFee) *unifiedEdge {
+
+		return &unifiedEdge{
+			policy: &models.CachedEdgePolicy{
+				ChannelID:                 chanID,
+				FeeBaseMSat:               baseFee,
+				FeeProportionalMillionths: feeRate,
 			},
+			capacity:         btcutil.SatoshiPerBitcoin,
+			hopPayloadSizeFn: defaultHopPayloadSize,
+			inboundFees:      inboundFee,
+		}
+	}
+
+	inboundDiscount := models.InboundFee{Base: -500, Rate: -1000}
+
+	testCases := []struct {
+		name           string
+		firstInbound   models.InboundFee
+		expectedSender lnwire.MilliSatoshi
+	}{
+		{
+			// The first hop charges an outbound fee of 2004 msat
+			// and gets an inbound discount of 1506 msat. The
+			// second hop charges an outbound fee of 4000 msat and
+			// an inbound fee of 702 msat.
+			name:           "inbound discount",
+			firstInbound:   inboundDiscount,
+			expectedSender: receiverAmt + 2004 - 1506 + 4000 + 702,
 		},
-		expectedErr: fmt.Sprintf("no matching outgoing channel "+
-			"available for node 0 (%v)", ctx.aliases["roasbeef"]),
-	}}
+		{
+			// The inbound discount of the first hop exceeds its
+			// outbound fee, so its total fee is zero.
+			name:           "capped inbound discount",
+			firstInbound:   models.InboundFee{Base: -10_000},
+			expectedSender: receiverAmt + 4000 + 702,
+		},
+	}
 
 	for _, tc := range testCases {
-		pathEdges, amt, err := getPathEdges(
-			tc.sourceNode, tc.amt, tc.unifiers, tc.bandwidthHints,
-			tc.hops,
-		)
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			edges := []*unifiedEdge{
+				newEdge(1, 0, 0, tc.firstInbound),
+				newEdge(2, 1000, 1000, models.InboundFee{
+					Base: 200, Rate: 500,
+				}),
+				newEdge(3, 2000, 2000, models.InboundFee{}),
+			}
+
+			unifiers := make([]*edgeUnifier, len(edges))
+			for i, edge := range edges {
+				unifiers[i] = &edgeUnifier{
+					edges:     []*unifiedEdge{edge},
+					localChan: i == 0,
+				}
+			}
+
+			pathEdges, senderAmt, err := senderAmtBackwardPass(
+				source, hops, unifiers, false, receiverAmt,
+				&mockBandwidthHints{},
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSender, senderAmt)
+			require.Len(t, pathEdges, len(edges))
+
+			amt, err := receiverAmtForwardPass(
+				source, hops, senderAmt, pathEdges,
+			)
+			require.NoError(t, err)
+			require.Equal(t, receiverAmt, amt)
+		})
+	}
 
-		if tc.expectedErr != "" {
-			require.Error(t, err)
-			require.ErrorContains(t, err, tc.expectedErr)
+	// Building a route without any hops fails without panicking.
+	_, _, err := senderAmtBackwardPass(
+		source, nil, nil, false, receiverAmt, &mockBandwidthHints{},
+	)
+	require.Error(t, err)
 
-			continue
+	_, err = receiverAmtForwardPass(source, nil, receiverAmt, nil)
+	require.Error(t, err)
+}
+
+// TestOutgoingFromIncoming tests that outgoingFromIncoming returns the largest
+// outgoing amount whose incoming amount doesn't exceed the given one.
+func TestOutgoingFromIncoming(t *testing.T) {
+	t.Parallel()
+
+	inboundFees := []models.InboundFee{
+		{},
+		{Base: 1000, Rate: 2000},
+		{Base: -1000, Rate: -2000},
+		{Base: -100_000},
+	}
+
+	for _, inboundFee := range inboundFees {
+		incoming := &unifiedEdge{inboundFees: inboundFee}
+		outgoing := &unifiedEdge{
+			policy: &models.CachedEdgePolicy{
+				FeeBaseMSat:               1000,
+				FeeProportionalMillionths: 1500,
+			},
 		}
 
-		require.NoError(t, err)
-		require.Equal(t, pathEdges, tc.expectedEdges)
-		require.Equal(t, amt, tc.expectedAmt)
+		for _, outgoingAmt := range []lnwire.MilliSatoshi{
+			0, 1, 999, 1_000_000, 123_456_789,
+		} {
+			incomingAmt := incomingFromOutgoing(
+				outgoingAmt, incoming, outgoing,
+			)
+
+			amt := outgoingFromIncoming(
+				incomingAmt, incoming, outgoing,
+			)
+			require.GreaterOrEqual(t, amt, outgoingAmt)
+			require.LessOrEqual(t, incomingFromOutgoing(
+				amt, incoming, outgoing,
+			), incomingAmt)
+			require.Greater(t, incomingFromOutgoing(
+				amt+1, incoming, outgoing,
+			), incomingAmt)
+		}
 	}
 }
 
@@ -3540,6 +3679,60 @@ func TestSendToRouteSkipTempErrSuccess(t *testing.T) {
 	payment.AssertExpectations(t)
 }
 
+// TestSendToRouteCreationInfo checks that the custom records and metadata of
+// the final hop are stored with the payment.
+func TestSendToRouteCreationInfo(t *testing.T) {
+	t.Parallel()
+
+	var (
+		payHash lntypes.Hash
+		payAmt  = lnwire.MilliSatoshi(10000)
+	)
+
+	node, err := createTestNode()
+	require.NoError(t, err)
+
+	customRecords := record.CustomSet{record.CustomTypeStart: {1, 2}}
+	metadata := []byte{3, 4}
+
+	hops := []*route.Hop{
+		{
+			ChannelID:        1,
+			PubKeyBytes:      node.PubKeyBytes,
+			AmtToForward:     payAmt,
+			OutgoingTimeLock: 120,
+			CustomRecords:    customRecords,
+			Metadata:         metadata,
+		},
+	}
+	rt, err := route.NewRouteFromHops(payAmt, 100, node.PubKeyBytes, hops)
+	require.NoError(t, err)
+
+	controlTower := &mockControlTower{}
+	router := &ChannelRouter{cfg: &Config{
+		Control: controlTower,
+		Clock:   clock.NewTestClock(time.Unix(1, 0)),
+	}}
+
+	// Fail the payment init, we're only interested in the creation info
+	// that is passed to the control tower.
+	isExpectedInfo := func(info *channeldb.PaymentCreationInfo) bool {
+		sameRecords := reflect.DeepEqual(
+			customRecords, info.DestCustomRecords,
+		)
+
+		return sameRecords && bytes.Equal(metadata, info.Metadata)
+	}
+	controlTower.On(
+		"InitPayment", payHash, mock.MatchedBy(isExpectedInfo),
+	).Return(errDummy)
+
+	_, err = router.SendToRoute(payHash, rt)
+	require.ErrorIs(t, err, errDummy)
+
+	controlTower.AssertExpectations(t)
+}
+
 // TestSendToRouteSkipTempErrNonMPP checks that an error is return when
 // skipping temp error for non-MPP.
 func TestSendToRouteSkipTempErrNonMPP(t *testing.T) {
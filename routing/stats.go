@@ -67,3 +67,61 @@ func (g *routerStats) String() string {
 	g.mu.RUnlock()
 	return str
 }
+
+// PruneStats holds the number of graph prune actions that were taken by the
+// router since it was started.
+type PruneStats struct {
+	// NumZombieChansPruned is the number of channels that were pruned
+	// from the graph as zombies.
+	NumZombieChansPruned uint64
+
+	// NumClosedChansPruned is the number of channels that were pruned
+	// from the graph as their funding output was spent.
+	NumClosedChansPruned uint64
+
+	// NumZombiesResurrected is the number of zombie channels that were
+	// marked as live again.
+	NumZombiesResurrected uint64
+
+	// LastZombiePrune is the time of the last zombie pruning run. It is
+	// zero if the graph wasn't pruned of zombies yet.
+	LastZombiePrune time.Time
+}
+
+// pruneStats tracks the graph prune actions of the router.
+type pruneStats struct {
+	stats PruneStats
+
+	mu sync.RWMutex
+}
+
+// addZombiePrune records a zombie pruning run in which the given number of
+// channels were pruned.
+func (p *pruneStats) addZombiePrune(numPruned int) {
+	p.mu.Lock()
+	p.stats.NumZombieChansPruned += uint64(numPruned)
+	p.stats.LastZombiePrune = time.Now()
+	p.mu.Unlock()
+}
+
+// addClosedChans increments the number of closed channels that were pruned.
+func (p *pruneStats) addClosedChans(numPruned int) {
+	p.mu.Lock()
+	p.stats.NumClosedChansPruned += uint64(numPruned)
+	p.mu.Unlock()
+}
+
+// incZombiesResurrected increments the number of resurrected zombies.
+func (p *pruneStats) incZombiesResurrected() {
+	p.mu.Lock()
+	p.stats.NumZombiesResurrected++
+	p.mu.Unlock()
+}
+
+// snapshot returns a copy of the current prune stats.
+func (p *pruneStats) snapshot() PruneStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.stats
+}
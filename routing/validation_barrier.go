@@ -103,29 +103,11 @@ func (v *ValidationBarrier) InitJobDependencies(job interface{}) {
 	// that are involved in this channel. This goes for both the wire
 	// type,s and also the types that we use within the database.
 	case *lnwire.ChannelAnnouncement:
+		v.initChanAnnSignals(msg.ShortChannelID, msg.NodeID1, msg.NodeID2)
 
-		// We ensure that we only create a new announcement signal iff,
-		// one doesn't already exist, as there may be duplicate
-		// announcements.  We'll close this signal once the
-		// ChannelAnnouncement has been validated. This will result in
-		// all the dependent jobs being unlocked so they can finish
-		// execution themselves.
-		if _, ok := v.chanAnnFinSignal[msg.ShortChannelID]; !ok {
-			// We'll create the channel that we close after we
-			// validate this announcement. All dependants will
-			// point to this same channel, so they'll be unblocked
-			// at the same time.
-			signals := &validationSignals{
-				allow: make(chan struct{}),
-				deny:  make(chan struct{}),
-			}
-
-			v.chanAnnFinSignal[msg.ShortChannelID] = signals
-			v.chanEdgeDependencies[msg.ShortChannelID] = signals
+	case *lnwire.ChannelAnnouncement2:
+		v.initChanAnnSignals(msg.ShortChannelID, msg.NodeID1, msg.NodeID2)
 
-			v.nodeAnnDependencies[route.Vertex(msg.NodeID1)] = signals
-			v.nodeAnnDependencies[route.Vertex(msg.NodeID2)] = signals
-		}
 	case *models.ChannelEdgeInfo:
 
 		shortID := lnwire.NewShortChanIDFromInt(msg.ChannelID)
@@ -148,6 +130,8 @@ func (v *ValidationBarrier) InitJobDependencies(job interface{}) {
 		return
 	case *lnwire.ChannelUpdate:
 		return
+	case *lnwire.ChannelUpdate2:
+		return
 	case *lnwire.NodeAnnouncement:
 		// TODO(roasbeef): node ann needs to wait on existing channel updates
 		return
@@ -159,6 +143,37 @@ func (v *ValidationBarrier) InitJobDependencies(job interface{}) {
 	}
 }
 
+// initChanAnnSignals sets up the signals that the dependants of the channel
+// announcement with the given short channel ID and node keys wait on.
+//
+// NOTE: This method must be called with the mutex held.
+func (v *ValidationBarrier) initChanAnnSignals(scid lnwire.ShortChannelID,
+	nodeID1, nodeID2 [33]byte) {
+
+	// We ensure that we only create a new announcement signal iff, one
+	// doesn't already exist, as there may be duplicate announcements.
+	// We'll close this signal once the ChannelAnnouncement has been
+	// validated. This will result in all the dependent jobs being
+	// unlocked so they can finish execution themselves.
+	if _, ok := v.chanAnnFinSignal[scid]; ok {
+		return
+	}
+
+	// We'll create the channel that we close after we validate this
+	// announcement. All dependants will point to this same channel, so
+	// they'll be unblocked at the same time.
+	signals := &validationSignals{
+		allow: make(chan struct{}),
+		deny:  make(chan struct{}),
+	}
+
+	v.chanAnnFinSignal[scid] = signals
+	v.chanEdgeDependencies[scid] = signals
+
+	v.nodeAnnDependencies[route.Vertex(nodeID1)] = signals
+	v.nodeAnnDependencies[route.Vertex(nodeID2)] = signals
+}
+
 // CompleteJob returns a free slot to the set of available job slots. This
 // should be called once a job has been fully completed. Otherwise, slots may
 // not be returned to the internal scheduling, causing a deadlock when a new
@@ -208,6 +223,12 @@ func (v *ValidationBarrier) WaitForDependants(job interface{}) error {
 		jobDesc = fmt.Sprintf("job=lnwire.ChannelUpdate, scid=%v",
 			msg.ShortChannelID.ToUint64())
 
+	case *lnwire.ChannelUpdate2:
+		signals, ok = v.chanEdgeDependencies[msg.ShortChannelID]
+
+		jobDesc = fmt.Sprintf("job=lnwire.ChannelUpdate2, scid=%v",
+			msg.ShortChannelID.ToUint64())
+
 	case *lnwire.NodeAnnouncement:
 		vertex := route.Vertex(msg.NodeID)
 		signals, ok = v.nodeAnnDependencies[vertex]
@@ -220,6 +241,7 @@ func (v *ValidationBarrier) WaitForDependants(job interface{}) error {
 		// TODO(roasbeef): need to wait on chan ann?
 	case *models.ChannelEdgeInfo:
 	case *lnwire.ChannelAnnouncement:
+	case *lnwire.ChannelAnnouncement2:
 	}
 
 	// Release the lock once the above read is finished.
@@ -276,17 +298,10 @@ func (v *ValidationBarrier) SignalDependants(job interface{}, allow bool) {
 			delete(v.chanAnnFinSignal, shortID)
 		}
 	case *lnwire.ChannelAnnouncement:
-		finSignals, ok := v.chanAnnFinSignal[msg.ShortChannelID]
-		if ok {
-			if allow {
-				close(finSignals.allow)
-			} else {
-				close(finSignals.deny)
-			}
-			delete(v.chanAnnFinSignal, msg.ShortChannelID)
-		}
+		v.signalChanAnnDependants(msg.ShortChannelID, allow)
 
-		delete(v.chanEdgeDependencies, msg.ShortChannelID)
+	case *lnwire.ChannelAnnouncement2:
+		v.signalChanAnnDependants(msg.ShortChannelID, allow)
 
 	// For all other job types, we'll delete the tracking entries from the
 	// map, as if we reach this point, then all dependants have already
@@ -297,6 +312,8 @@ func (v *ValidationBarrier) SignalDependants(job interface{}, allow bool) {
 		delete(v.nodeAnnDependencies, route.Vertex(msg.NodeID))
 	case *lnwire.ChannelUpdate:
 		delete(v.chanEdgeDependencies, msg.ShortChannelID)
+	case *lnwire.ChannelUpdate2:
+		delete(v.chanEdgeDependencies, msg.ShortChannelID)
 	case *models.ChannelEdgePolicy:
 		shortID := lnwire.NewShortChanIDFromInt(msg.ChannelID)
 		delete(v.chanEdgeDependencies, shortID)
@@ -305,3 +322,23 @@ func (v *ValidationBarrier) SignalDependants(job interface{}, allow bool) {
 		return
 	}
 }
+
+// signalChanAnnDependants allows or denies the dependants of the channel
+// announcement with the given short channel ID.
+//
+// NOTE: This method must be called with the mutex held.
+func (v *ValidationBarrier) signalChanAnnDependants(scid lnwire.ShortChannelID,
+	allow bool) {
+
+	finSignals, ok := v.chanAnnFinSignal[scid]
+	if ok {
+		if allow {
+			close(finSignals.allow)
+		} else {
+			close(finSignals.deny)
+		}
+		delete(v.chanAnnFinSignal, scid)
+	}
+
+	delete(v.chanEdgeDependencies, scid)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -443,9 +444,9 @@ func (r *InterceptorChain) Permissions() map[string][]bakery.Op {
 
 // RegisterMiddleware registers a new middleware that will handle request/
 // response interception for all RPC messages that are initiated with a custom
-// macaroon caveat. The name of the custom caveat a middleware is handling is
-// also its unique identifier. Only one middleware can be registered for each
-// custom caveat.
+// macaroon caveat. The name of the middleware is its unique identifier. The
+// middleware is inserted into the chain according to its priority, after all
+// middlewares that have a lower or the same priority.
 func (r *InterceptorChain) RegisterMiddleware(mw *MiddlewareHandler) error {
 	r.Lock()
 	defer r.Unlock()
@@ -457,21 +458,27 @@ func (r *InterceptorChain) RegisterMiddleware(mw *MiddlewareHandler) error {
 			"registered", mw.middlewareName)
 	}
 
-	// For now, we only want one middleware per custom caveat name. If we
-	// allowed multiple middlewares handling the same caveat there would be
-	// a need for extra call chaining logic, and they could overwrite each
-	// other's responses.
-	for _, middleware := range r.registeredMiddleware {
-		if middleware.customCaveatName == mw.customCaveatName {
-			return fmt.Errorf("a middleware is already registered "+
-				"for the custom caveat name '%s': %v",
-				mw.customCaveatName, middleware.middlewareName)
-		}
-	}
+	// Multiple middlewares can handle the same custom caveat. Their
+	// priority defines the order in which they are called, and each of
+	// them sees the message as modified by the previous one. We insert the
+	// new middleware after all middlewares with a lower or equal priority
+	// to keep the registration order for equal priorities.
+	index := sort.Search(len(r.registeredMiddleware), func(i int) bool {
+		priority := r.registeredMiddleware[i].policy.Priority
+		return priority > mw.policy.Priority
+	})
+	r.registeredMiddleware = append(r.registeredMiddleware, nil)
+	copy(
+		r.registeredMiddleware[index+1:],
+		r.registeredMiddleware[index:],
+	)
+	r.registeredMiddleware[index] = mw
 
-	r.registeredMiddleware = append(r.registeredMiddleware, mw)
-	index := len(r.registeredMiddleware) - 1
-	r.registeredMiddlewareNames[mw.middlewareName] = index
+	// Re-initialise the middleware look-up map with the updated indexes.
+	r.registeredMiddlewareNames = make(map[string]int)
+	for i, middleware := range r.registeredMiddleware {
+		r.registeredMiddlewareNames[middleware.middlewareName] = i
+	}
 
 	return nil
 }
@@ -519,9 +526,9 @@ func (r *InterceptorChain) CustomCaveatSupported(customCaveatName string) error
 	// macaroon would not be validated against the limitations that the
 	// custom caveat implicate. Since the map is keyed by the _name_ of the
 	// middleware, we need to loop through all of them to see if one has
-	// the given custom macaroon caveat name.
+	// the given custom macaroon caveat name or a namespace it belongs to.
 	for _, middleware := range r.registeredMiddleware {
-		if middleware.customCaveatName == customCaveatName {
+		if middleware.handlesCaveat(customCaveatName) {
 			return nil
 		}
 	}
@@ -981,22 +988,25 @@ func (r *InterceptorChain) acceptStream(requestID uint64,
 		// If there is a custom caveat in the macaroon, make sure the
 		// middleware registered for it. Or if a middleware registered
 		// for read-only mode, it also gets the request.
-		hasCustomCaveat := macaroons.HasCustomCaveat(
-			msg.Macaroon, middleware.customCaveatName,
-		)
-		if !hasCustomCaveat && !middleware.readOnly {
+		caveats := middleware.matchMacaroon(msg.Macaroon)
+		if len(caveats) == 0 && !middleware.readOnly {
 			continue
 		}
 
-		msg.CustomCaveatCondition = macaroons.GetCustomCaveatCondition(
-			msg.Macaroon, middleware.customCaveatName,
-		)
+		msg.setCustomCaveats(caveats)
 
 		resp, err := middleware.intercept(requestID, msg)
 
-		// Error during interception itself.
+		// Error during interception itself. Depending on the policy
+		// of the middleware we either abort or skip it.
 		if err != nil {
-			return err
+			if !middleware.policy.FailOpen {
+				return err
+			}
+
+			logFailOpen(middleware, requestID, err)
+
+			continue
 		}
 
 		// Error returned from middleware client.
@@ -1032,22 +1042,26 @@ func (r *InterceptorChain) interceptMessage(ctx context.Context,
 		// If there is a custom caveat in the macaroon, make sure the
 		// middleware registered for it. Or if a middleware registered
 		// for read-only mode, it also gets the request.
-		hasCustomCaveat := macaroons.HasCustomCaveat(
-			msg.Macaroon, middleware.customCaveatName,
-		)
-		if !hasCustomCaveat && !middleware.readOnly {
+		caveats := middleware.matchMacaroon(msg.Macaroon)
+		if len(caveats) == 0 && !middleware.readOnly {
 			continue
 		}
 
-		msg.CustomCaveatCondition = macaroons.GetCustomCaveatCondition(
-			msg.Macaroon, middleware.customCaveatName,
-		)
+		msg.setCustomCaveats(caveats)
 
 		resp, err := middleware.intercept(requestID, msg)
 
-		// Error during interception itself.
+		// Error during interception itself. Depending on the policy
+		// of the middleware we either abort or pass on the unmodified
+		// message.
 		if err != nil {
-			return nil, err
+			if !middleware.policy.FailOpen {
+				return nil, err
+			}
+
+			logFailOpen(middleware, requestID, err)
+
+			continue
 		}
 
 		// Error returned from middleware client.
@@ -1065,6 +1079,14 @@ func (r *InterceptorChain) interceptMessage(ctx context.Context,
 	return currentMessage, nil
 }
 
+// logFailOpen logs that a request is let through because the given fail-open
+// middleware failed to intercept it.
+func logFailOpen(middleware *MiddlewareHandler, requestID uint64, err error) {
+	log.Warnf("Middleware '%s' failed to intercept request %d, "+
+		"continuing because of its fail-open policy: %v",
+		middleware.middlewareName, requestID, err)
+}
+
 // serverStreamWrapper is a struct that wraps a server stream in a way that all
 // requests and responses can be intercepted individually.
 type serverStreamWrapper struct {
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,6 +33,28 @@ var (
 	errClientQuit = errors.New("interceptor RPC client quit")
 )
 
+// MiddlewarePolicy holds the settings that define where a middleware is placed
+// within the chain of registered middlewares, which macaroon caveats it is
+// responsible for and how failures to intercept a message are treated.
+type MiddlewarePolicy struct {
+	// Priority is the position of the middleware within the chain.
+	// Middlewares with a lower priority are invoked first. Middlewares
+	// with the same priority are invoked in registration order.
+	Priority int32
+
+	// CaveatNamespaces is a list of custom caveat namespaces the
+	// middleware is responsible for. A namespace matches all custom
+	// caveats with the same name or a name that starts with the namespace
+	// followed by a dot.
+	CaveatNamespaces []string
+
+	// FailOpen indicates that a request should continue as if the
+	// middleware accepted it if the middleware fails to give feedback in
+	// time or disconnects. An explicit rejection of the middleware always
+	// aborts the request.
+	FailOpen bool
+}
+
 // MiddlewareHandler is a type that communicates with a middleware over the
 // established bi-directional RPC stream. It sends messages to the middleware
 // whenever the custom business logic implemented there should give feedback to
@@ -49,6 +72,8 @@ type MiddlewareHandler struct {
 
 	customCaveatName string
 
+	policy MiddlewarePolicy
+
 	receive func() (*lnrpc.RPCMiddlewareResponse, error)
 
 	send func(request *lnrpc.RPCMiddlewareRequest) error
@@ -70,8 +95,9 @@ type MiddlewareHandler struct {
 }
 
 // NewMiddlewareHandler creates a new handler for the middleware with the given
-// name and custom caveat name.
+// name, custom caveat name and policy.
 func NewMiddlewareHandler(name, customCaveatName string, readOnly bool,
+	policy MiddlewarePolicy,
 	receive func() (*lnrpc.RPCMiddlewareResponse, error),
 	send func(request *lnrpc.RPCMiddlewareRequest) error,
 	timeout time.Duration, params *chaincfg.Params,
@@ -87,10 +113,15 @@ func NewMiddlewareHandler(name, customCaveatName string, readOnly bool,
 		"received to/from a client that has a macaroon with that "+
 		"custom caveat.", name, customCaveatName, readOnly)
 
+	log.Infof("Middleware '%s' registered with priority=%d, "+
+		"caveat_namespaces=%v, fail_open=%v", name, policy.Priority,
+		policy.CaveatNamespaces, policy.FailOpen)
+
 	return &MiddlewareHandler{
 		middlewareName:    name,
 		customCaveatName:  customCaveatName,
 		readOnly:          readOnly,
+		policy:            policy,
 		receive:           receive,
 		send:              send,
 		interceptRequests: make(chan *interceptRequest),
@@ -101,6 +132,44 @@ func NewMiddlewareHandler(name, customCaveatName string, readOnly bool,
 	}
 }
 
+// handlesCaveat returns true if the middleware is responsible for the custom
+// caveat with the given name, either because it registered for exactly that
+// name or because the name falls into one of its caveat namespaces.
+func (h *MiddlewareHandler) handlesCaveat(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	if h.customCaveatName == name {
+		return true
+	}
+
+	for _, namespace := range h.policy.CaveatNamespaces {
+		if name == namespace || strings.HasPrefix(name, namespace+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchMacaroon returns all custom caveats of the given macaroon the
+// middleware is responsible for, in the order in which they were added to the
+// macaroon. The result is empty if the macaroon doesn't contain any such
+// caveat.
+func (h *MiddlewareHandler) matchMacaroon(
+	mac *macaroon.Macaroon) []macaroons.CustomCaveat {
+
+	var matches []macaroons.CustomCaveat
+	for _, caveat := range macaroons.CustomCaveats(mac) {
+		if h.handlesCaveat(caveat.Name) {
+			matches = append(matches, caveat)
+		}
+	}
+
+	return matches
+}
+
 // intercept handles the full interception lifecycle of a single middleware
 // event (stream authentication, request interception or response interception).
 // The lifecycle consists of sending a message to the middleware, receiving a
@@ -369,7 +438,8 @@ type InterceptionRequest struct {
 	RawMacaroon []byte
 
 	// CustomCaveatName is the name of the custom caveat that the middleware
-	// was intercepting for.
+	// was intercepting for. For a middleware that is scoped to caveat
+	// namespaces this is the first matching caveat of the macaroon.
 	CustomCaveatName string
 
 	// CustomCaveatCondition is the condition of the custom caveat that the
@@ -377,6 +447,10 @@ type InterceptionRequest struct {
 	// that only have a name (marker caveats).
 	CustomCaveatCondition string
 
+	// CustomCaveats are all custom caveats of the macaroon that the
+	// middleware is responsible for, including the one above.
+	CustomCaveats []macaroons.CustomCaveat
+
 	// FullURI is the full RPC method URI that was invoked.
 	FullURI string
 
@@ -393,6 +467,21 @@ type InterceptionRequest struct {
 	IsError bool
 }
 
+// setCustomCaveats sets the custom caveats the middleware handling the request
+// is responsible for. The first of them is also set as the single custom caveat
+// of the request, for middlewares that only expect one.
+func (r *InterceptionRequest) setCustomCaveats(
+	caveats []macaroons.CustomCaveat) {
+
+	r.CustomCaveats = caveats
+	r.CustomCaveatName = ""
+	r.CustomCaveatCondition = ""
+	if len(caveats) > 0 {
+		r.CustomCaveatName = caveats[0].Name
+		r.CustomCaveatCondition = caveats[0].Condition
+	}
+}
+
 // NewMessageInterceptionRequest creates a new interception request for either
 // a request or response message.
 func NewMessageInterceptionRequest(ctx context.Context,
@@ -491,6 +580,16 @@ func (r *InterceptionRequest) ToRPC(requestID,
 		MsgId:                 msgID,
 		RawMacaroon:           r.RawMacaroon,
 		CustomCaveatCondition: r.CustomCaveatCondition,
+		CustomCaveatName:      r.CustomCaveatName,
+	}
+
+	for _, caveat := range r.CustomCaveats {
+		rpcRequest.CustomCaveats = append(
+			rpcRequest.CustomCaveats, &lnrpc.MacaroonCustomCaveat{
+				Name:      caveat.Name,
+				Condition: caveat.Condition,
+			},
+		)
 	}
 
 	switch r.Type {
@@ -1,11 +1,16 @@
 package rpcperms
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon.v2"
 )
 
 // TestReplaceProtoMsg makes sure the proto message replacement works as
@@ -88,3 +93,182 @@ func jsonEqual(t *testing.T, expected, actual interface{}) {
 
 	require.JSONEq(t, string(expectedJSON), string(actualJSON))
 }
+
+// newTestMiddleware creates a middleware handler that never answers any
+// intercept requests, so every interception runs into the given timeout.
+func newTestMiddleware(name, caveatName string, readOnly bool,
+	policy MiddlewarePolicy, timeout time.Duration) *MiddlewareHandler {
+
+	return NewMiddlewareHandler(
+		name, caveatName, readOnly, policy,
+		func() (*lnrpc.RPCMiddlewareResponse, error) {
+			return nil, nil
+		},
+		func(*lnrpc.RPCMiddlewareRequest) error {
+			return nil
+		}, timeout, &chaincfg.RegressionNetParams,
+		make(chan struct{}),
+	)
+}
+
+// TestMiddlewareHandlesCaveat makes sure a middleware is responsible for the
+// exact custom caveat it registered for and for all caveats in its namespaces.
+func TestMiddlewareHandlesCaveat(t *testing.T) {
+	policy := MiddlewarePolicy{
+		CaveatNamespaces: []string{"acme", "other.sub"},
+	}
+	mw := newTestMiddleware("test-mw", "exact-caveat", false, policy, 0)
+
+	require.True(t, mw.handlesCaveat("exact-caveat"))
+	require.True(t, mw.handlesCaveat("acme"))
+	require.True(t, mw.handlesCaveat("acme.account"))
+	require.True(t, mw.handlesCaveat("acme.session.id"))
+	require.True(t, mw.handlesCaveat("other.sub.thing"))
+	require.False(t, mw.handlesCaveat("acmesession"))
+	require.False(t, mw.handlesCaveat("other"))
+	require.False(t, mw.handlesCaveat(""))
+
+	mac, err := macaroon.New(
+		[]byte("root-key"), []byte("id"), "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+	require.Empty(t, mw.matchMacaroon(mac))
+
+	err = macaroons.CustomConstraint("unrelated", "foo")(mac)
+	require.NoError(t, err)
+	err = macaroons.CustomConstraint("acme.account", "1234")(mac)
+	require.NoError(t, err)
+	err = macaroons.CustomConstraint("acme.session", "abcd")(mac)
+	require.NoError(t, err)
+	err = macaroons.CustomConstraint("exact-caveat", "")(mac)
+	require.NoError(t, err)
+
+	// All caveats the middleware is responsible for should be matched, in
+	// the order they were added to the macaroon.
+	caveats := mw.matchMacaroon(mac)
+	require.Equal(t, []macaroons.CustomCaveat{
+		{Name: "acme.account", Condition: "1234"},
+		{Name: "acme.session", Condition: "abcd"},
+		{Name: "exact-caveat"},
+	}, caveats)
+
+	// The first caveat is also passed as the single custom caveat for
+	// middlewares that only expect one, and all of them are passed on.
+	req := &InterceptionRequest{Type: TypeStreamAuth}
+	req.setCustomCaveats(caveats)
+	rpcReq, err := req.ToRPC(1, 2)
+	require.NoError(t, err)
+	require.Equal(t, "acme.account", rpcReq.CustomCaveatName)
+	require.Equal(t, "1234", rpcReq.CustomCaveatCondition)
+	require.Len(t, rpcReq.CustomCaveats, 3)
+	require.Equal(t, "acme.session", rpcReq.CustomCaveats[1].Name)
+	require.Equal(t, "abcd", rpcReq.CustomCaveats[1].Condition)
+
+	// Resetting the caveats for a read-only middleware clears them.
+	req.setCustomCaveats(nil)
+	require.Empty(t, req.CustomCaveatName)
+	require.Empty(t, req.CustomCaveatCondition)
+	require.Empty(t, req.CustomCaveats)
+
+	// The namespace should also be taken into account when checking
+	// whether a custom caveat is supported at all.
+	chain := NewInterceptorChain(log, false, nil)
+	require.Error(t, chain.CustomCaveatSupported("acme.account"))
+	require.NoError(t, chain.RegisterMiddleware(mw))
+	require.NoError(t, chain.CustomCaveatSupported("acme.account"))
+	require.NoError(t, chain.CustomCaveatSupported("exact-caveat"))
+	require.Error(t, chain.CustomCaveatSupported("unrelated"))
+}
+
+// TestRegisterMiddlewareOrder makes sure middlewares are ordered by their
+// priority and by their registration order for equal priorities.
+func TestRegisterMiddlewareOrder(t *testing.T) {
+	chain := NewInterceptorChain(log, false, nil)
+
+	register := func(name string, priority int32) {
+		policy := MiddlewarePolicy{Priority: priority}
+		mw := newTestMiddleware(name, "same-caveat", false, policy, 0)
+		require.NoError(t, chain.RegisterMiddleware(mw))
+	}
+
+	register("mw-a", 10)
+	register("mw-b", 0)
+	register("mw-c", 10)
+	register("mw-d", -5)
+
+	// Multiple middlewares can share a caveat name but names must still
+	// be unique.
+	policy := MiddlewarePolicy{}
+	err := chain.RegisterMiddleware(
+		newTestMiddleware("mw-a", "another", false, policy, 0),
+	)
+	require.ErrorContains(t, err, "already registered")
+
+	names := func() []string {
+		var result []string
+		for i, mw := range chain.registeredMiddleware {
+			name := mw.middlewareName
+			result = append(result, name)
+			require.Equal(
+				t, i, chain.registeredMiddlewareNames[name],
+			)
+		}
+
+		return result
+	}
+	require.Equal(t, []string{"mw-d", "mw-b", "mw-a", "mw-c"}, names())
+
+	chain.RemoveMiddleware("mw-b")
+	require.Equal(t, []string{"mw-d", "mw-a", "mw-c"}, names())
+
+	register("mw-e", 5)
+	require.Equal(t, []string{"mw-d", "mw-e", "mw-a", "mw-c"}, names())
+}
+
+// TestInterceptFailurePolicy makes sure a failure to intercept a message only
+// aborts the request if the middleware uses the fail-closed policy.
+func TestInterceptFailurePolicy(t *testing.T) {
+	const timeout = 10 * time.Millisecond
+
+	ctx := context.Background()
+	req := &lnrpc.GetInfoRequest{}
+	method := "/lnrpc.Lightning/GetInfo"
+
+	// A read-only middleware intercepts all requests. Without anyone
+	// answering, the interception runs into the timeout.
+	closedChain := NewInterceptorChain(log, false, nil)
+	closedMW := newTestMiddleware(
+		"fail-closed", "", true, MiddlewarePolicy{}, timeout,
+	)
+	require.NoError(t, closedChain.RegisterMiddleware(closedMW))
+
+	_, err := closedChain.interceptMessage(
+		ctx, TypeRequest, 1, false, method, req,
+	)
+	require.ErrorIs(t, err, ErrTimeoutReached)
+
+	err = closedChain.acceptStream(1, &InterceptionRequest{
+		Type:    TypeStreamAuth,
+		FullURI: method,
+	})
+	require.ErrorIs(t, err, ErrTimeoutReached)
+
+	openChain := NewInterceptorChain(log, false, nil)
+	openMW := newTestMiddleware(
+		"fail-open", "", true, MiddlewarePolicy{FailOpen: true},
+		timeout,
+	)
+	require.NoError(t, openChain.RegisterMiddleware(openMW))
+
+	resp, err := openChain.interceptMessage(
+		ctx, TypeRequest, 1, false, method, req,
+	)
+	require.NoError(t, err)
+	require.Equal(t, req, resp)
+
+	err = openChain.acceptStream(1, &InterceptionRequest{
+		Type:    TypeStreamAuth,
+		FullURI: method,
+	})
+	require.NoError(t, err)
+}
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -36,6 +37,7 @@ import (
 	proxy "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/build"
+	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/chainreg"
 	"github.com/lightningnetwork/lnd/chanacceptor"
 	"github.com/lightningnetwork/lnd/chanbackup"
@@ -59,6 +61,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -66,8 +69,10 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwallet/chancloser"
 	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
+	"github.com/lightningnetwork/lnd/lnwallet/signpolicy"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/netann"
 	"github.com/lightningnetwork/lnd/peer"
 	"github.com/lightningnetwork/lnd/peernotifier"
 	"github.com/lightningnetwork/lnd/record"
@@ -91,6 +96,16 @@ const (
 	// to issuing an estimate for if a fee pre fence doesn't specify an
 	// explicit conf target or fee rate.
 	defaultNumBlocksEstimate = 6
+
+	// maxReserveReductionAttempts is the number of times we'll try to
+	// reduce the amount of an on-chain send in order to keep the anchor
+	// reserve before giving up.
+	maxReserveReductionAttempts = 3
+
+	// abandonSpendProofTimeout is the maximum time we wait for the chain
+	// notifier to report the spend of the funding output of a channel
+	// that is about to be abandoned.
+	abandonSpendProofTimeout = time.Minute
 )
 
 var (
@@ -319,6 +334,13 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/EstimateChannelOpenFee": {{
+			Entity: "onchain",
+			Action: "read",
+		}, {
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/OpenChannelSync": {{
 			Entity: "onchain",
 			Action: "write",
@@ -422,6 +444,10 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "invoices",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/UpdateKeysendPolicy": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/SubscribeTransactions": {{
 			Entity: "onchain",
 			Action: "read",
@@ -442,6 +468,10 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/RefreshChannelEdge": {{
+			Entity: "info",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/GetNodeInfo": {{
 			Entity: "info",
 			Action: "read",
@@ -490,6 +520,22 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/UpdateChannelConstraints": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/UpdateChannelTags": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SetAnchorSweepPolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListAnchorSweepPolicies": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/ForwardingHistory": {{
 			Entity: "offchain",
 			Action: "read",
@@ -498,6 +544,13 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/RequestForceCloseFromBackup": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ExportChannelBackup": {{
 			Entity: "offchain",
 			Action: "read",
@@ -521,6 +574,38 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/SetChannelAcceptorPolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/GetChannelAcceptorPolicy": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/SetPeerPolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListPeerPolicies": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/UpdatePeerConnectionPolicy": {{
+			Entity: "peers",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListPeerConnectionPolicies": {{
+			Entity: "peers",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/UpdatePeerGossipPolicy": {{
+			Entity: "peers",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListPeerGossipPolicies": {{
+			Entity: "peers",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/BakeMacaroon": {{
 			Entity: "macaroon",
 			Action: "generate",
@@ -533,6 +618,13 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "macaroon",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/RotateMacaroonRootKey": {{
+			Entity: "macaroon",
+			Action: "generate",
+		}, {
+			Entity: "macaroon",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ListPermissions": {{
 			Entity: "info",
 			Action: "read",
@@ -564,14 +656,70 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/SendOnionMessage": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SubscribeOnionMessages": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/LookupHtlcResolution": {{
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/MigrateDatabase": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/GetDatabaseInfo": {{
+			Entity: "info",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/BackupDatabase": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ListAliases": {{
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/signerrpc.RemoteSigner/GetInfo": {{
+			Entity: "signer",
+			Action: "read",
+		}},
+		"/signerrpc.RemoteSigner/SignPsbt": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signerrpc.RemoteSigner/DeriveSharedKey": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signerrpc.RemoteSigner/SignMessage": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signerrpc.RemoteSigner/MuSig2CreateSession": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signerrpc.RemoteSigner/MuSig2RegisterNonces": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signerrpc.RemoteSigner/MuSig2Sign": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signerrpc.RemoteSigner/MuSig2CombineSig": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signerrpc.RemoteSigner/MuSig2Cleanup": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
 	}
 }
 
@@ -598,6 +746,11 @@ type rpcServer struct {
 	subServers      []lnrpc.SubServer
 	subGrpcHandlers []lnrpc.GrpcHandler
 
+	// remoteSigner is the shell of the remote signer RPC server that
+	// allows a watch-only node to use our wallet as its remote signer. The
+	// actual server is created once all dependencies are available.
+	remoteSigner *signerrpc.ServerShell
+
 	// routerBackend contains the backend implementation of the router
 	// rpc sub server.
 	routerBackend *routerrpc.RouterBackend
@@ -655,6 +808,7 @@ func newRPCServer(cfg *Config, interceptorChain *rpcperms.InterceptorChain,
 	return &rpcServer{
 		cfg:              cfg,
 		subGrpcHandlers:  subServerHandlers,
+		remoteSigner:     &signerrpc.ServerShell{},
 		interceptorChain: interceptorChain,
 		implCfg:          implCfg,
 		quit:             make(chan struct{}, 1),
@@ -725,21 +879,27 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 
 			return info.NodeKey1Bytes, info.NodeKey2Bytes, nil
 		},
-		FindRoute:              s.chanRouter.FindRoute,
-		MissionControl:         s.missionControl,
-		ActiveNetParams:        r.cfg.ActiveNetParams.Params,
-		Tower:                  s.controlTower,
-		MaxTotalTimelock:       r.cfg.MaxOutgoingCltvExpiry,
-		DefaultFinalCltvDelta:  uint16(r.cfg.Bitcoin.TimeLockDelta),
-		SubscribeHtlcEvents:    s.htlcNotifier.SubscribeHtlcEvents,
-		InterceptableForwarder: s.interceptableSwitch,
+		FindRoute:                s.chanRouter.FindRoute,
+		MissionControl:           s.missionControl,
+		MissionControlNamespaces: s.missionControlMgr,
+		ActiveNetParams:          r.cfg.ActiveNetParams.Params,
+		Tower:                    s.controlTower,
+		MaxTotalTimelock:         r.cfg.MaxOutgoingCltvExpiry,
+		DefaultFinalCltvDelta:    uint16(r.cfg.Bitcoin.TimeLockDelta),
+		SubscribeHtlcEvents:      s.htlcNotifier.SubscribeHtlcEvents,
+		InterceptableForwarder:   s.interceptableSwitch,
 		SetChannelEnabled: func(outpoint wire.OutPoint) error {
 			return s.chanStatusMgr.RequestEnable(outpoint, true)
 		},
 		SetChannelDisabled: func(outpoint wire.OutPoint) error {
 			return s.chanStatusMgr.RequestDisable(outpoint, true)
 		},
+		DeletePayment: func(hash lntypes.Hash) error {
+			return s.miscDB.DeletePayment(hash, false)
+		},
 		SetChannelAuto:     s.chanStatusMgr.RequestAuto,
+		Reputation:         s.htlcSwitch.Reputation,
+		ListCircuits:       s.htlcSwitch.ListCircuits,
 		UseStatusInitiated: subServerCgs.RouterRPC.UseStatusInitiated,
 	}
 
@@ -754,6 +914,28 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 		return parseAddr(addr, r.cfg.net)
 	}
 
+	// If this node acts as the signer of a watch-only node, its signing
+	// RPCs enforce the configured signer policy. Deriving the keys of
+	// outputs paying back to the wallet requires a wallet with private
+	// keys.
+	var signPolicy *signpolicy.Policy
+	if r.cfg.SignerPolicy.Enabled() {
+		walletController := s.cc.Wallet.WalletController
+		wallet, ok := walletController.(*btcwallet.BtcWallet)
+		if !ok || r.cfg.RemoteSigner.Enable {
+			return fmt.Errorf("signer policy can only be used " +
+				"with a wallet that has private keys")
+		}
+
+		signPolicy, err = signpolicy.FromConfig(
+			r.cfg.SignerPolicy, wallet.DeriveKeyByBIP32Path,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create signer policy: %w",
+				err)
+		}
+	}
+
 	var (
 		subServers     []lnrpc.SubServer
 		subServerPerms []lnrpc.MacaroonPerms
@@ -766,12 +948,13 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 	// TODO(roasbeef): extend sub-sever config to have both (local vs remote) DB
 	err = subServerCgs.PopulateDependencies(
 		r.cfg, s.cc, r.cfg.networkDir, macService, atpl, invoiceRegistry,
-		s.htlcSwitch, r.cfg.ActiveNetParams.Params, s.chanRouter,
-		routerBackend, s.nodeSigner, s.graphDB, s.chanStateDB,
+		s.invoicesDB, s.fwdingLog, s.htlcSwitch,
+		r.cfg.ActiveNetParams.Params, s.chanRouter, routerBackend, s.nodeSigner, s.graphDB, s.chanStateDB,
 		s.sweeper, tower, s.towerClientMgr, r.cfg.net.ResolveTCPAddr,
 		genInvoiceFeatures, genAmpInvoiceFeatures,
 		s.getNodeAnnouncement, s.updateAndBrodcastSelfNode, parseAddr,
-		rpcsLog, s.aliasMgr.GetPeerAlias,
+		rpcsLog, s.aliasMgr.GetPeerAlias, s.getChannelUptime,
+		signPolicy,
 	)
 	if err != nil {
 		return err
@@ -834,8 +1017,30 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 		}
 	}
 
+	// Our wallet can also act as the remote signer of a watch-only node,
+	// signing with all keys such a node would request signatures for.
+	signerPolicy, err := signerrpc.NewPathPolicy(
+		r.cfg.ActiveNetParams.CoinType,
+		signerrpc.DefaultAllowedPaths(r.cfg.ActiveNetParams.CoinType),
+	)
+	if err != nil {
+		return err
+	}
+	remoteSigner, err := signerrpc.New(&signerrpc.Config{
+		Network:      r.cfg.ActiveNetParams.Name,
+		Policy:       signerPolicy,
+		PsbtSigner:   s.cc.Wallet,
+		KeyRing:      s.cc.KeyRing,
+		MuSig2Signer: s.cc.Signer,
+		SignPolicy:   signPolicy,
+	})
+	if err != nil {
+		return err
+	}
+
 	// Finally, with all the set up complete, add the last dependencies to
 	// the rpc server.
+	r.remoteSigner.RemoteSignerServer = remoteSigner
 	r.server = s
 	r.subServers = subServers
 	r.routerBackend = routerBackend
@@ -843,6 +1048,18 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 	r.macService = macService
 	r.selfNode = selfNode.PubKeyBytes
 
+	// The synchronous payment RPCs enforce the spend limits of the
+	// macaroons used to call them, so macaroons with such limits can be
+	// used for them.
+	if macService != nil {
+		macService.RegisterSpendLimitedMethod(
+			"/lnrpc.Lightning/SendPaymentSync",
+		)
+		macService.RegisterSpendLimitedMethod(
+			"/lnrpc.Lightning/SendToRouteSync",
+		)
+	}
+
 	graphCacheDuration := r.cfg.Caches.RPCGraphCacheDuration
 	if graphCacheDuration != 0 {
 		r.graphCacheEvictor = time.AfterFunc(graphCacheDuration, func() {
@@ -868,6 +1085,10 @@ func (r *rpcServer) RegisterWithGrpcServer(grpcServer *grpc.Server) error {
 	// Register the main RPC server.
 	lnrpc.RegisterLightningServer(grpcServer, r)
 
+	// Register the remote signer RPC server, which is always available to
+	// allow watch-only nodes to use our wallet for signing.
+	signerrpc.RegisterRemoteSignerServer(grpcServer, r.remoteSigner)
+
 	// Now the main RPC server has been registered, we'll iterate through
 	// all the sub-RPC servers and register them to ensure that requests
 	// are properly routed towards them.
@@ -1074,35 +1295,82 @@ func allowCORS(handler http.Handler, origins []string) http.Handler {
 
 // sendCoinsOnChain makes an on-chain transaction in or to send coins to one or
 // more addresses specified in the passed payment map. The payment map maps an
-// address to a specified output value to be sent to that address.
+// address to a specified output value to be sent to that address. If
+// respectReserve is set and the payment map contains a single address, the
+// value sent to that address is reduced in case the transaction would
+// otherwise invalidate the wallet's anchor reserve. The amount that was
+// eventually sent is returned alongside the transaction hash.
 func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
 	feeRate chainfee.SatPerKWeight, minConfs int32, label string,
-	strategy wallet.CoinSelectionStrategy) (*chainhash.Hash, error) {
+	strategy wallet.CoinSelectionStrategy,
+	respectReserve bool) (*chainhash.Hash, btcutil.Amount, error) {
+
+	if respectReserve && len(paymentMap) != 1 {
+		return nil, 0, fmt.Errorf("respecting the anchor reserve is " +
+			"only supported when sending to a single address")
+	}
 
 	outputs, err := addrPairsToOutputs(paymentMap, r.cfg.ActiveNetParams.Params)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// We first do a dry run, to sanity check we won't spend our wallet
-	// balance below the reserved amount.
-	authoredTx, err := r.server.cc.Wallet.CreateSimpleTx(
-		outputs, feeRate, minConfs, strategy, true,
-	)
-	if err != nil {
-		return nil, err
-	}
+	// balance below the reserved amount. If we are allowed to reduce the
+	// amount sent, we'll retry a few times as a smaller amount might lead
+	// to a different coin selection and therefore a different fee.
+	for attempt := 0; ; attempt++ {
+		authoredTx, err := r.server.cc.Wallet.CreateSimpleTx(
+			outputs, feeRate, minConfs, strategy, true,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
 
-	// Check the authored transaction and use the explicitly set change index
-	// to make sure that the wallet reserved balance is not invalidated.
-	_, err = r.server.cc.Wallet.CheckReservedValueTx(
-		lnwallet.CheckReservedValueTxReq{
-			Tx:          authoredTx.Tx,
-			ChangeIndex: &authoredTx.ChangeIndex,
-		},
-	)
-	if err != nil {
-		return nil, err
+		// Check the authored transaction and use the explicitly set
+		// change index to make sure that the wallet reserved balance
+		// is not invalidated.
+		reservedVal, err := r.server.cc.Wallet.CheckReservedValueTx(
+			lnwallet.CheckReservedValueTxReq{
+				Tx:          authoredTx.Tx,
+				ChangeIndex: &authoredTx.ChangeIndex,
+			},
+		)
+		if err == nil {
+			break
+		}
+
+		if !respectReserve || attempt >= maxReserveReductionAttempts ||
+			!errors.Is(err, lnwallet.ErrReservedValueInvalidated) {
+
+			return nil, 0, err
+		}
+
+		// The change output is the only part of the transaction that
+		// goes back to the wallet, so we'll reduce the amount sent by
+		// the difference between the change and the reserve.
+		var change btcutil.Amount
+		if authoredTx.ChangeIndex >= 0 {
+			change = btcutil.Amount(
+				authoredTx.Tx.TxOut[authoredTx.ChangeIndex].Value,
+			)
+		}
+		shortfall := reservedVal - change
+
+		reducedAmt := btcutil.Amount(outputs[0].Value) - shortfall
+		dustLimit := lnwallet.DustLimitForSize(len(outputs[0].PkScript))
+		if reducedAmt <= dustLimit {
+			return nil, 0, fmt.Errorf("unable to keep anchor "+
+				"reserve of %v: remaining amount would be "+
+				"dust: %w", reservedVal, err)
+		}
+
+		rpcsLog.Debugf("Reducing amount sent from %v to %v to keep "+
+			"anchor reserve of %v",
+			btcutil.Amount(outputs[0].Value), reducedAmt,
+			reservedVal)
+
+		outputs[0].Value = int64(reducedAmt)
 	}
 
 	// If that checks out, we're fairly confident that creating sending to
@@ -1111,11 +1379,16 @@ func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
 		outputs, feeRate, minConfs, label, strategy,
 	)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	var amtSent btcutil.Amount
+	for _, output := range outputs {
+		amtSent += btcutil.Amount(output.Value)
 	}
 
 	txHash := tx.TxHash()
-	return &txHash, nil
+	return &txHash, amtSent, nil
 }
 
 // ListUnspent returns useful information about each unspent output owned by
@@ -1342,11 +1615,20 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		return nil, err
 	}
 
-	var txid *chainhash.Hash
+	var (
+		txid    *chainhash.Hash
+		amtSent btcutil.Amount
+	)
 
 	wallet := r.server.cc.Wallet
 	maxFeeRate := r.cfg.Sweeper.MaxFeeRate.FeePerKWeight()
 
+	// Keeping an amount in the wallet only makes sense when sweeping it.
+	if in.ReserveAmount != 0 && !in.SendAll {
+		return nil, fmt.Errorf("reserve amount can only be set while " +
+			"SendAll is active")
+	}
+
 	// If the send all flag is active, then we'll attempt to sweep all the
 	// coins in the wallet in a single transaction (if possible),
 	// otherwise, we'll respect the amount, and attempt a regular 2-output
@@ -1359,22 +1641,68 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 				"active")
 		}
 
+		// The kept amount is sent back to a taproot change address,
+		// so it must not be dust.
+		keepAmt := btcutil.Amount(in.ReserveAmount)
+		dustLimit := lnwallet.DustLimitForSize(input.P2TRSize)
+		if keepAmt < 0 || (keepAmt > 0 && keepAmt < dustLimit) {
+			return nil, fmt.Errorf("reserve amount must be zero or "+
+				"at least the dust limit of %v", dustLimit)
+		}
+
 		_, bestHeight, err := r.server.cc.ChainIO.GetBestBlock()
 		if err != nil {
 			return nil, err
 		}
 
-		// With the sweeper instance created, we can now generate a
-		// transaction that will sweep ALL outputs from the wallet in a
-		// single transaction. This will be generated in a concurrent
-		// safe manner, so no need to worry about locking. The tx will
-		// pay to the change address created above if we needed to
-		// reserve any value, the rest will go to targetAddr.
-		sweepTxPkg, err := sweep.CraftSweepAllTx(
-			feePerKw, maxFeeRate, uint32(bestHeight), nil,
-			targetAddr, wallet, wallet, wallet.WalletController,
-			r.server.cc.Signer, minConfs,
-		)
+		// We'll need the script of the target address to report the
+		// amount that was eventually swept to it.
+		targetScript, err := txscript.PayToAddrScript(targetAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		// craftSweep generates a transaction that will sweep ALL outputs
+		// from the wallet in a single transaction, except for the given
+		// amount which is sent back to a fresh change address of our
+		// wallet. This will be generated in a concurrent safe manner,
+		// so no need to worry about locking.
+		craftSweep := func(keepAmt btcutil.Amount) (*sweep.WalletSweepPackage,
+			error) {
+
+			var outputs []sweep.DeliveryAddr
+			if keepAmt > 0 {
+				// We'll request a change address from the
+				// wallet, where we'll send the kept value back
+				// to. This ensures this is an address the
+				// wallet knows about, allowing us to pass the
+				// reserved value check.
+				changeAddr, err := r.server.cc.Wallet.NewAddress(
+					lnwallet.TaprootPubkey, true,
+					lnwallet.DefaultAccountName,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				outputs = append(outputs, sweep.DeliveryAddr{
+					Addr: changeAddr,
+					Amt:  keepAmt,
+				})
+			}
+
+			return sweep.CraftSweepAllTx(
+				feePerKw, maxFeeRate, uint32(bestHeight),
+				outputs, targetAddr, wallet, wallet,
+				wallet.WalletController,
+				r.server.cc.Signer, minConfs,
+			)
+		}
+
+		// We'll first try to keep only the amount the caller asked us
+		// to keep in the wallet, if any. The rest will go to
+		// targetAddr.
+		sweepTxPkg, err := craftSweep(keepAmt)
 		if err != nil {
 			return nil, err
 		}
@@ -1402,33 +1730,9 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 				"send_all, trying with change output",
 				reservedVal)
 
-			// We'll request a change address from the wallet,
-			// where we'll send this reserved value back to. This
-			// ensures this is an address the wallet knows about,
-			// allowing us to pass the reserved value check.
-			changeAddr, err := r.server.cc.Wallet.NewAddress(
-				lnwallet.TaprootPubkey, true,
-				lnwallet.DefaultAccountName,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			// Send the reserved value to this change address, the
+			// Send the reserved value to a change address, the
 			// remaining funds will go to the targetAddr.
-			outputs := []sweep.DeliveryAddr{
-				{
-					Addr: changeAddr,
-					Amt:  reservedVal,
-				},
-			}
-
-			sweepTxPkg, err = sweep.CraftSweepAllTx(
-				feePerKw, maxFeeRate, uint32(bestHeight),
-				outputs, targetAddr, wallet, wallet,
-				wallet.WalletController,
-				r.server.cc.Signer, minConfs,
-			)
+			sweepTxPkg, err = craftSweep(reservedVal)
 			if err != nil {
 				return nil, err
 			}
@@ -1469,6 +1773,14 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 
 		sweepTXID := sweepTxPkg.SweepTx.TxHash()
 		txid = &sweepTXID
+
+		// Everything that isn't sent back to our wallet as the reserved
+		// value ends up at the target address.
+		for _, txOut := range sweepTxPkg.SweepTx.TxOut {
+			if bytes.Equal(txOut.PkScript, targetScript) {
+				amtSent += btcutil.Amount(txOut.Value)
+			}
+		}
 	} else {
 
 		// We'll now construct out payment map, and use the wallet's
@@ -1477,15 +1789,17 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		// while we instruct the wallet to send this transaction.
 		paymentMap := map[string]int64{targetAddr.String(): in.Amount}
 		err := wallet.WithCoinSelectLock(func() error {
-			newTXID, err := r.sendCoinsOnChain(
+			newTXID, sent, err := r.sendCoinsOnChain(
 				paymentMap, feePerKw, minConfs,
 				label, coinSelectionStrategy,
+				in.RespectAnchorReserve,
 			)
 			if err != nil {
 				return err
 			}
 
 			txid = newTXID
+			amtSent = sent
 
 			return nil
 		})
@@ -1494,9 +1808,13 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		}
 	}
 
-	rpcsLog.Infof("[sendcoins] spend generated txid: %v", txid.String())
+	rpcsLog.Infof("[sendcoins] spend generated txid: %v, amt=%v",
+		txid.String(), amtSent)
 
-	return &lnrpc.SendCoinsResponse{Txid: txid.String()}, nil
+	return &lnrpc.SendCoinsResponse{
+		Txid:       txid.String(),
+		AmountSent: int64(amtSent),
+	}, nil
 }
 
 // SendMany handles a request for a transaction create multiple specified
@@ -1549,9 +1867,9 @@ func (r *rpcServer) SendMany(ctx context.Context,
 	// happen to also be concurrently executing.
 	wallet := r.server.cc.Wallet
 	err = wallet.WithCoinSelectLock(func() error {
-		sendManyTXID, err := r.sendCoinsOnChain(
+		sendManyTXID, _, err := r.sendCoinsOnChain(
 			in.AddrToAmount, feePerKw, minConfs,
-			label, coinSelectionStrategy,
+			label, coinSelectionStrategy, in.RespectAnchorReserve,
 		)
 		if err != nil {
 			return err
@@ -2006,6 +2324,12 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 		"allocation(us=%v, them=%v)", in.NodePubkey,
 		in.LocalFundingAmount, in.PushSat)
 
+	// Fill in the parameters that aren't set explicitly with the defaults
+	// of the peer, if any.
+	if err := r.applyPeerPolicy(in, isSync); err != nil {
+		return nil, fmt.Errorf("unable to apply peer policy: %w", err)
+	}
+
 	localFundingAmt := btcutil.Amount(in.LocalFundingAmount)
 	remoteInitialBalance := btcutil.Amount(in.PushSat)
 
@@ -2036,6 +2360,14 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 			"the channel opening")
 	}
 
+	// The account only restricts the coins that the internal wallet
+	// selects, so it doesn't make sense in combination with a funding
+	// shim.
+	if in.FundingShim != nil && in.Account != "" {
+		return nil, fmt.Errorf("cannot provide a funding shim while " +
+			"restricting the funding to a wallet account")
+	}
+
 	// If the FundMax flag is set, ensure that the acceptable minimum local
 	// amount adheres to the amount to be pushed to the remote, and to
 	// current rules, while also respecting the settings for the maximum
@@ -2082,6 +2414,24 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 		channelFeeRate = &in.FeeRate
 	}
 
+	// Make sure the provided parameters of the initial forwarding policy
+	// are sane. The time lock delta is subject to the same limits as in a
+	// policy update.
+	if in.TimeLockDelta != 0 && (in.TimeLockDelta < minTimeLockDelta ||
+		in.TimeLockDelta > uint32(MaxTimeLockDelta)) {
+
+		return nil, fmt.Errorf("time lock delta of %v must be "+
+			"between %v and %v", in.TimeLockDelta,
+			minTimeLockDelta, MaxTimeLockDelta)
+	}
+
+	fwdMinHTLC := lnwire.MilliSatoshi(in.FwdMinHtlcMsat)
+	fwdMaxHTLC := lnwire.MilliSatoshi(in.FwdMaxHtlcMsat)
+	if fwdMaxHTLC != 0 && fwdMinHTLC > fwdMaxHTLC {
+		return nil, fmt.Errorf("fwd min htlc (%v) must not exceed "+
+			"fwd max htlc (%v)", fwdMinHTLC, fwdMaxHTLC)
+	}
+
 	// Ensure that the remote channel reserve does not exceed 20% of the
 	// channel capacity.
 	if !in.FundMax && remoteChanReserve >= localFundingAmt/5 {
@@ -2312,6 +2662,9 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 		LocalFundingAmt: localFundingAmt,
 		BaseFee:         channelBaseFee,
 		FeeRate:         channelFeeRate,
+		TimeLockDelta:   in.TimeLockDelta,
+		FwdMinHTLC:      fwdMinHTLC,
+		FwdMaxHTLC:      fwdMaxHTLC,
 		PushAmt: lnwire.NewMSatFromSatoshis(
 			remoteInitialBalance,
 		),
@@ -2330,6 +2683,7 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 		MinFundAmt:        minFundAmt,
 		Memo:              []byte(in.Memo),
 		Outpoints:         outpoints,
+		Account:           in.Account,
 	}, nil
 }
 
@@ -2503,6 +2857,67 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	}
 }
 
+// EstimateChannelOpenFee runs the coin selection of the funding transaction for
+// the given OpenChannelRequest in dry run mode. It returns the inputs that would
+// be spent, the change amount, the fee and the resulting channel capacity
+// without creating a pending channel or locking any UTXOs.
+func (r *rpcServer) EstimateChannelOpenFee(_ context.Context,
+	in *lnrpc.OpenChannelRequest) (*lnrpc.EstimateChannelOpenFeeResponse,
+	error) {
+
+	if err := r.canOpenChannel(); err != nil {
+		return nil, err
+	}
+
+	if in.FundingShim != nil {
+		return nil, fmt.Errorf("fee estimation is not supported for " +
+			"channels funded with a funding shim")
+	}
+
+	req, err := r.parseOpenChannelReq(in, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The channel type is negotiated with the peer, so we need it to be
+	// connected to get the same result as an actual channel opening.
+	req.Peer, err = r.server.FindPeer(req.TargetPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := r.server.fundingMgr.EstimateFundingFee(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalFundingEstimate(
+		estimate, r.cfg.ActiveNetParams.Params,
+	)
+}
+
+// marshalFundingEstimate converts the given funding estimate to its RPC
+// representation.
+func marshalFundingEstimate(estimate *lnwallet.FundingEstimate,
+	params *chaincfg.Params) (*lnrpc.EstimateChannelOpenFeeResponse,
+	error) {
+
+	inputs, err := lnrpc.MarshalUtxos(estimate.Inputs, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.EstimateChannelOpenFeeResponse{
+		Inputs:             inputs,
+		ChangeAmountSat:    int64(estimate.ChangeAmt),
+		LocalFundingAmount: int64(estimate.LocalFundingAmt),
+		Capacity:           int64(estimate.Capacity),
+		FeeSat:             int64(estimate.Fee),
+		Weight:             int64(estimate.Weight),
+		SatPerVbyte:        uint64(estimate.FeeRate.FeePerVByte()),
+	}, nil
+}
+
 // BatchOpenChannel attempts to open multiple single-funded channels in a
 // single transaction in an atomic way. This means either all channel open
 // requests succeed at once or all attempts are aborted if any of them fail.
@@ -2597,14 +3012,6 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		return fmt.Errorf("must specify channel point in close channel")
 	}
 
-	// If force closing a channel, the fee set in the commitment transaction
-	// is used.
-	if in.Force && (in.SatPerByte != 0 || in.SatPerVbyte != 0 || // nolint:staticcheck
-		in.TargetConf != 0) {
-
-		return fmt.Errorf("force closing a channel uses a pre-defined fee")
-	}
-
 	force := in.Force
 	index := in.ChannelPoint.OutputIndex
 	txid, err := lnrpc.GetChanPointFundingTxid(in.GetChannelPoint())
@@ -2651,35 +3058,30 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		return err
 	}
 
+	// Our balance can only be split across additional outputs if the
+	// closing transaction is negotiated with the peer.
+	if force && len(in.CloseOutputs) != 0 {
+		return fmt.Errorf("close outputs can only be used for a " +
+			"cooperative close")
+	}
+
+	// If a cooperative closing transaction has already been broadcast for
+	// a channel that is closed using the RBF coop close protocol, then a
+	// regular close request bumps the fee of the closing transaction.
+	rbfBump := !force &&
+		channel.HasChanStatus(channeldb.ChanStatusCoopBroadcasted)
+
 	// If a force closure was requested, then we'll handle all the details
 	// around the creation and broadcast of the unilateral closure
 	// transaction here rather than going to the switch as we don't require
 	// interaction from the peer.
 	if force {
-
-		// As we're force closing this channel, as a precaution, we'll
-		// ensure that the switch doesn't continue to see this channel
-		// as eligible for forwarding HTLC's. If the peer is online,
-		// then we'll also purge all of its indexes.
-		remotePub := channel.IdentityPub
-		if peer, err := r.server.FindPeer(remotePub); err == nil {
-			// TODO(roasbeef): actually get the active channel
-			// instead too?
-			//  * so only need to grab from database
-			peer.WipeChannel(&channel.FundingOutpoint)
-		} else {
-			chanID := lnwire.NewChanIDFromOutPoint(
-				channel.FundingOutpoint,
-			)
-			r.server.htlcSwitch.RemoveLink(chanID)
+		feePref, err := r.forceCloseFeePreference(in, channel)
+		if err != nil {
+			return err
 		}
 
-		// With the necessary indexes cleaned up, we'll now force close
-		// the channel.
-		chainArbitrator := r.server.chainArb
-		closingTx, err := chainArbitrator.ForceCloseContract(
-			*chanPoint,
-		)
+		closingTx, err := r.forceCloseChannel(channel, feePref)
 		if err != nil {
 			rpcsLog.Errorf("unable to force close transaction: %v", err)
 			return err
@@ -2705,6 +3107,11 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 					Success:     true,
 				}
 			})
+	} else if rbfBump {
+		updateChan, errChan, err = r.bumpCoopCloseFee(in, channel)
+		if err != nil {
+			return err
+		}
 	} else {
 		// If this is a frozen channel, then we only allow the co-op
 		// close to proceed if we were the responder to this channel if
@@ -2792,12 +3199,17 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 			}
 		}
 
+		closeOutputs, err := r.parseCloseOutputs(in.CloseOutputs)
+		if err != nil {
+			return err
+		}
+
 		maxFee := chainfee.SatPerKVByte(
 			in.MaxFeePerVbyte * 1000,
 		).FeePerKWeight()
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(
 			chanPoint, contractcourt.CloseRegular, feeRate,
-			maxFee, deliveryScript,
+			maxFee, deliveryScript, closeOutputs,
 		)
 	}
 
@@ -2844,6 +3256,14 @@ out:
 				rpcsLog.Infof("[closechannel] close completed: "+
 					"txid(%v)", h)
 				break out
+
+			// A fee bump is done once the replacement closing
+			// transaction has been broadcast. The stream of the
+			// original close request reports the confirmation.
+			case *peer.PendingUpdate:
+				if rbfBump {
+					break out
+				}
 			}
 		case <-r.quit:
 			return nil
@@ -2853,6 +3273,57 @@ out:
 	return nil
 }
 
+// bumpCoopCloseFee asks the peer of a channel that is closed using the RBF
+// coop close protocol to bump the fee of the closing transaction to the fee
+// rate of the passed request. The returned channels deliver the update for
+// the replacement closing transaction, or an error.
+func (r *rpcServer) bumpCoopCloseFee(in *lnrpc.CloseChannelRequest,
+	channel *channeldb.OpenChannel) (chan interface{}, chan error, error) {
+
+	// The outputs of the closing transaction were fixed by the shutdown
+	// messages, so only the fee can be changed.
+	if len(in.DeliveryAddress) > 0 || len(in.CloseOutputs) != 0 {
+		return nil, nil, fmt.Errorf("delivery address and close " +
+			"outputs can't be changed when bumping the fee of a " +
+			"cooperative close")
+	}
+
+	targetConf := maybeUseDefaultConf(
+		in.SatPerByte, in.SatPerVbyte, uint32(in.TargetConf),
+	)
+	feeRate, err := lnrpc.CalculateFeeRate(
+		uint64(in.SatPerByte), in.SatPerVbyte, // nolint:staticcheck
+		targetConf, r.server.cc.FeeEstimator,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The link of the channel has already been removed from the switch,
+	// so we'll hand the request to the peer directly.
+	remotePeer, err := r.server.FindPeer(channel.IdentityPub)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to bump fee of closing "+
+			"tx while peer is offline: %w", err)
+	}
+
+	rpcsLog.Debugf("[closechannel] bumping fee of closing tx for "+
+		"ChannelPoint(%v) to %v", channel.FundingOutpoint,
+		int64(feeRate))
+
+	updateChan := make(chan interface{}, 2)
+	errChan := make(chan error, 1)
+	remotePeer.HandleLocalCloseChanReqs(&htlcswitch.ChanClose{
+		CloseType:      contractcourt.CloseRegular,
+		ChanPoint:      &channel.FundingOutpoint,
+		TargetFeePerKw: feeRate,
+		Updates:        updateChan,
+		Err:            errChan,
+	})
+
+	return updateChan, errChan, nil
+}
+
 func createRPCCloseUpdate(update interface{}) (
 	*lnrpc.CloseStatusUpdate, error) {
 
@@ -2952,22 +3423,20 @@ func (r *rpcServer) abandonChan(chanPoint *wire.OutPoint,
 // AbandonChannel removes all channel state from the database except for a
 // close summary. This method can be used to get rid of permanently unusable
 // channels due to bugs fixed in newer versions of lnd.
-func (r *rpcServer) AbandonChannel(_ context.Context,
+func (r *rpcServer) AbandonChannel(ctx context.Context,
 	in *lnrpc.AbandonChannelRequest) (*lnrpc.AbandonChannelResponse, error) {
 
-	// If this isn't the dev build, then we won't allow the RPC to be
-	// executed, as it's an advanced feature and won't be activated in
-	// regular production/release builds except for the explicit case of
-	// externally funded channels that are still pending. Due to repeated
-	// requests, we also allow this requirement to be overwritten by a new
-	// flag that attests to the user knowing what they're doing and the risk
-	// associated with the command/RPC.
-	if !in.IKnowWhatIAmDoing && !in.PendingFundingShimOnly &&
-		!build.IsDevBuild() {
-
-		return nil, fmt.Errorf("AbandonChannel RPC call only " +
-			"available in dev builds")
-	}
+	// If this isn't the dev build, then we'll only allow the RPC to be
+	// executed if the funding output of the channel is provably spent, as
+	// it's an advanced feature that can lead to a loss of funds if used on
+	// active channels. The exception is the explicit case of externally
+	// funded channels that are still pending. Due to repeated requests, we
+	// also allow this requirement to be overwritten by a flag that attests
+	// to the user knowing what they're doing and the risk associated with
+	// the command/RPC.
+	requireSpendProof := in.RequireSpendProof ||
+		(!in.IKnowWhatIAmDoing && !in.PendingFundingShimOnly &&
+			!build.IsDevBuild())
 
 	// We'll parse out the arguments to we can obtain the chanPoint of the
 	// target channel.
@@ -2985,12 +3454,36 @@ func (r *rpcServer) AbandonChannel(_ context.Context,
 		return nil, err
 	}
 
+	var spend *chainntnfs.SpendDetail
 	dbChan, err := r.server.chanStateDB.FetchChannel(nil, *chanPoint)
 	switch {
 	// If the channel isn't found in the set of open channels, then we can
-	// continue on as it can't be loaded into the link/peer.
+	// continue on as it can't be loaded into the link/peer. If we need to
+	// prove that its funding output was spent, we'll derive the funding
+	// output from the historical channel state.
 	case err == channeldb.ErrChannelNotFound:
-		break
+		if !requireSpendProof {
+			break
+		}
+
+		histChan, err := r.server.chanStateDB.FetchHistoricalChannel(
+			chanPoint,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch channel %v to "+
+				"prove the spend of its funding output: %w",
+				chanPoint, err)
+		}
+
+		spend, err = r.proveFundingSpent(ctx, histChan)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.checkAbandonSpend(*chanPoint, spend)
+		if err != nil {
+			return nil, err
+		}
 
 	// If the channel is still known to be open, then before we modify any
 	// on-disk state, we'll remove the channel from the switch and peer
@@ -3012,6 +3505,18 @@ func (r *rpcServer) AbandonChannel(_ context.Context,
 				"funded or not pending", chanPoint)
 		}
 
+		if requireSpendProof {
+			spend, err = r.proveFundingSpent(ctx, dbChan)
+			if err != nil {
+				return nil, err
+			}
+
+			err = r.checkAbandonSpend(*chanPoint, spend)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// We'll mark the channel as borked before we remove the state
 		// from the switch/peer so it won't be loaded back in if the
 		// peer reconnects.
@@ -3032,7 +3537,152 @@ func (r *rpcServer) AbandonChannel(_ context.Context,
 		return nil, err
 	}
 
-	return &lnrpc.AbandonChannelResponse{}, nil
+	resp := &lnrpc.AbandonChannelResponse{}
+	if spend != nil {
+		resp.SpendingTxid = spend.SpenderTxHash.String()
+		resp.SpendHeight = uint32(spend.SpendingHeight)
+	}
+
+	return resp, nil
+}
+
+// checkAbandonSpend makes sure that abandoning a channel whose funding output
+// was spent by the given spend doesn't interfere with the resolution of its
+// close. Only cooperative closes and fully resolved closes are allowed, as the
+// channel arbitrator and its resolvers of an unresolved force close would
+// otherwise be stopped before all outputs are swept.
+func (r *rpcServer) checkAbandonSpend(chanPoint wire.OutPoint,
+	spend *chainntnfs.SpendDetail) error {
+
+	summary, err := r.server.chanStateDB.FetchClosedChannel(&chanPoint)
+	switch {
+	case err == nil:
+		if summary.CloseType == channeldb.CooperativeClose ||
+			!summary.IsPending {
+
+			return nil
+		}
+
+		return fmt.Errorf("close of channel %v is not fully resolved "+
+			"yet, wait for its resolvers to finish", chanPoint)
+
+	case !errors.Is(err, channeldb.ErrClosedChannelNotFound):
+		return err
+	}
+
+	// Without a close summary, the close wasn't processed by the channel
+	// arbitrator yet. We only allow abandoning the channel if the funding
+	// output was spent by a cooperative close transaction, as there's
+	// nothing to resolve in that case. A commitment transaction carries a
+	// state hint that a cooperative close transaction doesn't have.
+	if lnwallet.HasStateNumHint(spend.SpendingTx) {
+		return fmt.Errorf("funding output of channel %v was spent by "+
+			"commitment transaction %v, which must be resolved "+
+			"before abandoning the channel", chanPoint,
+			spend.SpenderTxHash)
+	}
+
+	return nil
+}
+
+// proveFundingSpent waits for the chain notifier to report the spend of the
+// funding output of the given channel, and then verifies that the spending
+// transaction is included in the block of the best chain at the reported
+// height. An error is returned if the funding output isn't spent within
+// abandonSpendProofTimeout.
+func (r *rpcServer) proveFundingSpent(ctx context.Context,
+	channel *channeldb.OpenChannel) (*chainntnfs.SpendDetail, error) {
+
+	chanPoint := channel.FundingOutpoint
+	localKey := channel.LocalChanCfg.MultiSigKey.PubKey
+	remoteKey := channel.RemoteChanCfg.MultiSigKey.PubKey
+
+	var (
+		pkScript []byte
+		err      error
+	)
+	if channel.ChanType.IsTaproot() {
+		pkScript, _, err = input.GenTaprootFundingScript(
+			localKey, remoteKey, 0,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		multiSigScript, err := input.GenMultiSigScript(
+			localKey.SerializeCompressed(),
+			remoteKey.SerializeCompressed(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err = input.WitnessScriptHash(multiSigScript)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// As a height hint, we'll use the height the funding transaction was
+	// broadcast at, falling back to the confirmation height of the funding
+	// transaction. The short channel ID of zero-conf channels is an alias,
+	// so we can't use its height.
+	heightHint := channel.BroadcastHeight()
+	if heightHint == 0 && !channel.IsZeroConf() {
+		heightHint = channel.ShortChanID().BlockHeight
+	}
+
+	spendEvent, err := r.server.cc.ChainNotifier.RegisterSpendNtfn(
+		&chanPoint, pkScript, heightHint,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer spendEvent.Cancel()
+
+	var spend *chainntnfs.SpendDetail
+	select {
+	case details, ok := <-spendEvent.Spend:
+		if !ok {
+			return nil, chainntnfs.ErrChainNotifierShuttingDown
+		}
+		spend = details
+
+	case <-time.After(abandonSpendProofTimeout):
+		return nil, fmt.Errorf("funding output of channel %v isn't "+
+			"spent on chain, refusing to abandon channel", chanPoint)
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	case <-r.quit:
+		return nil, ErrServerShuttingDown
+	}
+
+	// Rather than trusting the notifier, we'll fetch the block at the
+	// spend height from the best chain and verify that it includes the
+	// spending transaction.
+	blockHash, err := r.server.cc.ChainIO.GetBlockHash(
+		int64(spend.SpendingHeight),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch block hash at height "+
+			"%d: %w", spend.SpendingHeight, err)
+	}
+	block, err := r.server.cc.ChainIO.GetBlock(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch block %v: %w",
+			blockHash, err)
+	}
+	if err := chainntnfs.VerifySpendInBlock(spend, block); err != nil {
+		return nil, fmt.Errorf("unable to prove spend of funding "+
+			"output of channel %v: %w", chanPoint, err)
+	}
+
+	rpcsLog.Infof("Funding output of channel %v proven spent by %v at "+
+		"height %d", chanPoint, spend.SpenderTxHash,
+		spend.SpendingHeight)
+
+	return spend, nil
 }
 
 // GetInfo returns general information concerning the lightning node including
@@ -3209,10 +3859,41 @@ func (r *rpcServer) GetRecoveryInfo(ctx context.Context,
 func (r *rpcServer) ListPeers(ctx context.Context,
 	in *lnrpc.ListPeersRequest) (*lnrpc.ListPeersResponse, error) {
 
+	// The peers are sorted by their public key, so that the response can
+	// be paginated.
 	serverPeers := r.server.Peers()
+	sort.Slice(serverPeers, func(i, j int) bool {
+		iPub, jPub := serverPeers[i].PubKey(), serverPeers[j].PubKey()
+		return bytes.Compare(iPub[:], jPub[:]) < 0
+	})
+
+	var cmpCursor func(*peer.Brontide) int
+	if in.PubKeyOffset != "" {
+		cursor, err := hex.DecodeString(in.PubKeyOffset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pub_key_offset: %w",
+				err)
+		}
+
+		cmpCursor = func(serverPeer *peer.Brontide) int {
+			pubKey := serverPeer.PubKey()
+			return bytes.Compare(pubKey[:], cursor)
+		}
+	}
+
+	serverPeers = lnrpc.PaginateAfterCursor(
+		serverPeers, cmpCursor, in.NumMaxPeers, in.Reversed,
+	)
+
 	resp := &lnrpc.ListPeersResponse{
 		Peers: make([]*lnrpc.Peer, 0, len(serverPeers)),
 	}
+	if len(serverPeers) > 0 {
+		firstPub := serverPeers[0].PubKey()
+		lastPub := serverPeers[len(serverPeers)-1].PubKey()
+		resp.FirstPubKeyOffset = hex.EncodeToString(firstPub[:])
+		resp.LastPubKeyOffset = hex.EncodeToString(lastPub[:])
+	}
 
 	for _, serverPeer := range serverPeers {
 		var (
@@ -3277,6 +3958,21 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 			SyncType:        lnrpcSyncType,
 			Features:        features,
 			LastPingPayload: serverPeer.LastRemotePingPayload(),
+			MessagesSent: marshalMessageCounts(
+				serverPeer.MessagesSent(),
+			),
+			MessagesReceived: marshalMessageCounts(
+				serverPeer.MessagesReceived(),
+			),
+			Uptime: int64(
+				time.Since(serverPeer.StartTime()).Seconds(),
+			),
+		}
+
+		for _, rtt := range serverPeer.PingTimeHistory() {
+			rpcPeer.PingTimeHistory = append(
+				rpcPeer.PingTimeHistory, rtt.Microseconds(),
+			)
 		}
 
 		var peerErrors []interface{}
@@ -3338,6 +4034,25 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 	return resp, nil
 }
 
+// marshalMessageCounts converts the message counts of a peer into a map keyed
+// by the message type name. Message types that lnd doesn't know are keyed by
+// their numeric type.
+func marshalMessageCounts(
+	counts map[lnwire.MessageType]uint64) map[string]uint64 {
+
+	rpcCounts := make(map[string]uint64, len(counts))
+	for msgType, count := range counts {
+		name := msgType.String()
+		if name == "<unknown>" {
+			name = strconv.Itoa(int(msgType))
+		}
+
+		rpcCounts[name] = count
+	}
+
+	return rpcCounts
+}
+
 // SubscribePeerEvents returns a uni-directional stream (server -> client)
 // for notifying the client of peer online and offline events.
 func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
@@ -3508,6 +4223,17 @@ func (r *rpcServer) WalletBalance(ctx context.Context,
 		uint32(currentNumAnchorChans),
 	)
 
+	// Wallet outputs that the sweeper is attempting to sweep, for example
+	// to CPFP a transaction, can't be spent either.
+	minConfs := in.MinConfs
+	if minConfs <= 0 {
+		minConfs = 1
+	}
+	pendingSweepBalance, err := r.pendingSweepBalance(minConfs, in.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	rpcsLog.Debugf("[walletbalance] Total balance=%v (confirmed=%v, "+
 		"unconfirmed=%v)", totalBalance, confirmedBalance,
 		unconfirmedBalance)
@@ -3519,9 +4245,67 @@ func (r *rpcServer) WalletBalance(ctx context.Context,
 		LockedBalance:             int64(lockedBalance),
 		ReservedBalanceAnchorChan: int64(requiredReserve),
 		AccountBalance:            rpcAccountBalances,
+		BalanceBreakdown: newWalletBalanceBreakdown(
+			confirmedBalance, lockedBalance, requiredReserve,
+			pendingSweepBalance,
+		),
 	}, nil
 }
 
+// pendingSweepBalance returns the total value of the wallet outputs with at
+// least minConfs confirmations that the sweeper is attempting to sweep.
+func (r *rpcServer) pendingSweepBalance(minConfs int32,
+	account string) (btcutil.Amount, error) {
+
+	pendingInputs, err := r.server.sweeper.PendingInputs()
+	if err != nil {
+		return 0, err
+	}
+	if len(pendingInputs) == 0 {
+		return 0, nil
+	}
+
+	utxos, err := r.server.cc.Wallet.ListUnspentWitness(
+		minConfs, math.MaxInt32, account,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var balance btcutil.Amount
+	for _, utxo := range utxos {
+		if _, ok := pendingInputs[utxo.OutPoint]; ok {
+			balance += utxo.Value
+		}
+	}
+
+	return balance, nil
+}
+
+// newWalletBalanceBreakdown splits the confirmed balance into the part that
+// can be spent and the parts that are held back for pending sweeps and as
+// anchor reserve. The anchor reserve is capped by the confirmed balance that
+// isn't used by pending sweeps.
+func newWalletBalanceBreakdown(confirmed, leased, anchorReserve,
+	pendingSweep btcutil.Amount) *lnrpc.WalletBalanceBreakdown {
+
+	available := confirmed - pendingSweep
+	if available < 0 {
+		available = 0
+	}
+
+	if anchorReserve > available {
+		anchorReserve = available
+	}
+
+	return &lnrpc.WalletBalanceBreakdown{
+		SpendableBalance:     int64(available - anchorReserve),
+		AnchorReserveBalance: int64(anchorReserve),
+		LeasedBalance:        int64(leased),
+		PendingSweepBalance:  int64(pendingSweep),
+	}
+}
+
 // ChannelBalance returns the total available channel flow across all open
 // channels in satoshis.
 func (r *rpcServer) ChannelBalance(ctx context.Context,
@@ -3613,18 +4397,11 @@ type (
 	waitingCloseChannels []*lnrpc.PendingChannelsResponse_WaitingCloseChannel
 )
 
-// fetchPendingOpenChannels queries the database for a list of channels that
-// have pending open state. The returned result is used in the response of the
-// PendingChannels RPC.
-func (r *rpcServer) fetchPendingOpenChannels() (pendingOpenChannels, error) {
-	// First, we'll populate the response with all the channels that are
-	// soon to be opened. We can easily fetch this data from the database
-	// and map the db struct to the proto response.
-	channels, err := r.server.chanStateDB.FetchPendingChannels()
-	if err != nil {
-		rpcsLog.Errorf("unable to fetch pending channels: %v", err)
-		return nil, err
-	}
+// fetchPendingOpenChannels maps the given channels that have pending open
+// state to the proto response. The returned result is used in the response of
+// the PendingChannels RPC.
+func (r *rpcServer) fetchPendingOpenChannels(
+	channels []*channeldb.OpenChannel) (pendingOpenChannels, error) {
 
 	_, currentHeight, err := r.server.cc.ChainIO.GetBestBlock()
 	if err != nil {
@@ -3685,22 +4462,49 @@ func (r *rpcServer) fetchPendingOpenChannels() (pendingOpenChannels, error) {
 	return result, nil
 }
 
-// fetchPendingForceCloseChannels queries the database for a list of channels
+// fetchPendingForceCloseSummaries queries the database for a list of channels
 // that have their closing transactions confirmed but not fully resolved yet.
-// The returned result is used in the response of the PendingChannels RPC.
-func (r *rpcServer) fetchPendingForceCloseChannels() (pendingForceClose,
-	int64, error) {
+func (r *rpcServer) fetchPendingForceCloseSummaries() (
+	[]*channeldb.ChannelCloseSummary, error) {
 
-	_, currentHeight, err := r.server.cc.ChainIO.GetBestBlock()
+	channels, err := r.server.chanStateDB.FetchClosedChannels(true)
 	if err != nil {
-		return nil, 0, err
+		rpcsLog.Errorf("unable to fetch closed channels: %v", err)
+		return nil, err
 	}
 
-	// Next, we'll examine the channels that are soon to be closed so we
-	// can populate these fields within the response.
-	channels, err := r.server.chanStateDB.FetchClosedChannels(true)
+	result := make([]*channeldb.ChannelCloseSummary, 0, len(channels))
+	for _, pendingClose := range channels {
+		switch pendingClose.CloseType {
+
+		// A coop closed channel should never be in the "pending close"
+		// state. If a node upgraded from an older lnd version in the
+		// middle of a their channel confirming, it will be in this
+		// state. We log a warning that the channel will not be included
+		// in the now deprecated pending close channels field.
+		case channeldb.CooperativeClose:
+			rpcsLog.Warnf("channel %v cooperatively closed and "+
+				"in pending close state",
+				pendingClose.ChanPoint)
+
+		case channeldb.LocalForceClose, channeldb.RemoteForceClose:
+			result = append(result, pendingClose)
+		}
+	}
+
+	return result, nil
+}
+
+// fetchPendingForceCloseChannels maps the given channels that are pending
+// force closing to the proto response, populating it with the resolution
+// reports of their contracts. The returned result is used in the response of
+// the PendingChannels RPC.
+func (r *rpcServer) fetchPendingForceCloseChannels(
+	channels []*channeldb.ChannelCloseSummary) (pendingForceClose, int64,
+	error) {
+
+	_, currentHeight, err := r.server.cc.ChainIO.GetBestBlock()
 	if err != nil {
-		rpcsLog.Errorf("unable to fetch closed channels: %v", err)
 		return nil, 0, err
 	}
 
@@ -3774,16 +4578,6 @@ func (r *rpcServer) fetchPendingForceCloseChannels() (pendingForceClose,
 
 		switch pendingClose.CloseType {
 
-		// A coop closed channel should never be in the "pending close"
-		// state. If a node upgraded from an older lnd version in the
-		// middle of a their channel confirming, it will be in this
-		// state. We log a warning that the channel will not be included
-		// in the now deprecated pending close channels field.
-		case channeldb.CooperativeClose:
-			rpcsLog.Warnf("channel %v cooperatively closed and "+
-				"in pending close state",
-				pendingClose.ChanPoint)
-
 		// If the channel was force closed, then we'll need to query
 		// the utxoNursery for additional information.
 		// TODO(halseth): distinguish remote and local case?
@@ -3826,22 +4620,13 @@ func (r *rpcServer) fetchPendingForceCloseChannels() (pendingForceClose,
 	return result, limboBalance, nil
 }
 
-// fetchWaitingCloseChannels queries the database for a list of channels
-// that have their closing transactions broadcast but not confirmed yet.
-// The returned result is used in the response of the PendingChannels RPC.
+// fetchWaitingCloseChannels maps the given channels that have their closing
+// transactions broadcast but not confirmed yet to the proto response. The
+// returned result is used in the response of the PendingChannels RPC.
 func (r *rpcServer) fetchWaitingCloseChannels(
+	channels []*channeldb.OpenChannel,
 	includeRawTx bool) (waitingCloseChannels, int64, error) {
 
-	// We'll also fetch all channels that are open, but have had their
-	// commitment broadcasted, meaning they are waiting for the closing
-	// transaction to confirm.
-	channels, err := r.server.chanStateDB.FetchWaitingCloseChannels()
-	if err != nil {
-		rpcsLog.Errorf("unable to fetch channels waiting close: %v",
-			err)
-		return nil, 0, err
-	}
-
 	result := make(waitingCloseChannels, 0)
 	limboBalance := int64(0)
 
@@ -4010,39 +4795,178 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 	resp := &lnrpc.PendingChannelsResponse{}
 
 	// First, we find all the channels that will soon be opened.
-	pendingOpenChannels, err := r.fetchPendingOpenChannels()
+	pendingOpen, err := r.server.chanStateDB.FetchPendingChannels()
 	if err != nil {
+		rpcsLog.Errorf("unable to fetch pending channels: %v", err)
 		return nil, err
 	}
-	resp.PendingOpenChannels = pendingOpenChannels
 
 	// Second, we fetch all channels that considered pending force closing.
 	// This means the channels here have their closing transactions
 	// confirmed but not considered fully resolved yet. For instance, they
 	// may have a second level HTLCs to be resolved onchain.
-	pendingCloseChannels, limbo, err := r.fetchPendingForceCloseChannels()
+	pendingForceClose, err := r.fetchPendingForceCloseSummaries()
 	if err != nil {
 		return nil, err
 	}
-	resp.PendingForceClosingChannels = pendingCloseChannels
-	resp.TotalLimboBalance = limbo
 
 	// Third, we fetch all channels that are open, but have had their
 	// commitment broadcasted, meaning they are waiting for the closing
 	// transaction to confirm.
+	waitingClose, err := r.server.chanStateDB.FetchWaitingCloseChannels()
+	if err != nil {
+		rpcsLog.Errorf("unable to fetch channels waiting close: %v",
+			err)
+		return nil, err
+	}
+
+	// All pending channels are mapped to the response, as the total limbo
+	// balance covers all of them, not just the requested page.
+	pendingOpenChannels, err := r.fetchPendingOpenChannels(pendingOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingCloseChannels, limbo, err := r.fetchPendingForceCloseChannels(
+		pendingForceClose,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.TotalLimboBalance = limbo
+
 	waitingCloseChannels, limbo, err := r.fetchWaitingCloseChannels(
-		in.IncludeRawTx,
+		waitingClose, in.IncludeRawTx,
 	)
 	if err != nil {
 		return nil, err
 	}
-	resp.WaitingCloseChannels = waitingCloseChannels
 	resp.TotalLimboBalance += limbo
 
-	return resp, nil
-}
+	// The three lists are paginated together, ordered by channel point,
+	// so that a channel keeps its position if its state changes between
+	// two queries.
+	type pendingChannel struct {
+		chanPoint wire.OutPoint
+		addToResp func()
+	}
+	var channels []pendingChannel
+	type rpcPendingChannel = lnrpc.PendingChannelsResponse_PendingChannel
+	addChannel := func(channel *rpcPendingChannel, addToResp func()) error {
 
-// arbitratorPopulateForceCloseResp populates the pending channels response
+		chanPoint, err := wire.NewOutPointFromString(
+			channel.ChannelPoint,
+		)
+		if err != nil {
+			return err
+		}
+
+		channels = append(channels, pendingChannel{
+			chanPoint: *chanPoint,
+			addToResp: addToResp,
+		})
+
+		return nil
+	}
+
+	for _, c := range pendingOpenChannels {
+		c := c
+		err := addChannel(c.Channel, func() {
+			resp.PendingOpenChannels = append(
+				resp.PendingOpenChannels, c,
+			)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range pendingCloseChannels {
+		c := c
+		err := addChannel(c.Channel, func() {
+			resp.PendingForceClosingChannels = append(
+				resp.PendingForceClosingChannels, c,
+			)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range waitingCloseChannels {
+		c := c
+		err := addChannel(c.Channel, func() {
+			resp.WaitingCloseChannels = append(
+				resp.WaitingCloseChannels, c,
+			)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(channels, func(a, b pendingChannel) int {
+		return compareOutPoints(a.chanPoint, b.chanPoint)
+	})
+	cmpCursor, err := chanPointCursor(
+		in.ChanPointOffset, func(c pendingChannel) wire.OutPoint {
+			return c.chanPoint
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	page := lnrpc.PaginateAfterCursor(
+		channels, cmpCursor, in.NumMaxChannels, in.Reversed,
+	)
+	if len(page) > 0 {
+		resp.FirstChanPointOffset = page[0].chanPoint.String()
+		resp.LastChanPointOffset = page[len(page)-1].chanPoint.String()
+	}
+	for _, c := range page {
+		c.addToResp()
+	}
+
+	return resp, nil
+}
+
+// compareOutPoints orders the given outpoints by their txid and then by their
+// output index. It is used to paginate channels by their channel point.
+func compareOutPoints(a, b wire.OutPoint) int {
+	if c := bytes.Compare(a.Hash[:], b.Hash[:]); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.Index < b.Index:
+		return -1
+	case a.Index > b.Index:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// chanPointCursor parses the channel point offset of a paginated request into
+// a function that compares the channel point of an item to it. An empty offset
+// results in a nil function, which refers to the start of the items.
+func chanPointCursor[T any](offset string,
+	chanPoint func(T) wire.OutPoint) (func(T) int, error) {
+
+	if offset == "" {
+		return nil, nil
+	}
+
+	cursor, err := wire.NewOutPointFromString(offset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chan_point_offset: %w", err)
+	}
+
+	return func(item T) int {
+		return compareOutPoints(chanPoint(item), *cursor)
+	}, nil
+}
+
+// arbitratorPopulateForceCloseResp populates the pending channels response
 // message with channel resolution information from the contract resolvers.
 func (r *rpcServer) arbitratorPopulateForceCloseResp(chanPoint *wire.OutPoint,
 	currentHeight int32,
@@ -4195,11 +5119,53 @@ func (r *rpcServer) ClosedChannels(ctx context.Context,
 
 	// In order to make the response easier to parse for clients, we'll
 	// sort the set of closed channels by their closing height before
-	// serializing the proto response.
-	sort.Slice(dbChannels, func(i, j int) bool {
-		return dbChannels[i].CloseHeight < dbChannels[j].CloseHeight
-	})
+	// serializing the proto response. Channels closed at the same height
+	// are ordered by their channel point, so that the response can be
+	// paginated.
+	compareClosed := func(a, b *channeldb.ChannelCloseSummary) int {
+		if a.CloseHeight != b.CloseHeight {
+			if a.CloseHeight < b.CloseHeight {
+				return -1
+			}
+			return 1
+		}
 
+		return compareOutPoints(a.ChanPoint, b.ChanPoint)
+	}
+	slices.SortFunc(dbChannels, compareClosed)
+
+	// Closed channels are never removed, so the channel point offset of a
+	// previous page always refers to a known channel, whose closing height
+	// we need to locate it.
+	var cmpCursor func(*channeldb.ChannelCloseSummary) int
+	if in.ChanPointOffset != "" {
+		cursorPoint, err := wire.NewOutPointFromString(
+			in.ChanPointOffset,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chan_point_offset: %w",
+				err)
+		}
+
+		idx := slices.IndexFunc(dbChannels,
+			func(c *channeldb.ChannelCloseSummary) bool {
+				return c.ChanPoint == *cursorPoint
+			},
+		)
+		if idx == -1 {
+			return nil, fmt.Errorf("unknown chan_point_offset %v",
+				cursorPoint)
+		}
+
+		cursor := dbChannels[idx]
+		cmpCursor = func(c *channeldb.ChannelCloseSummary) int {
+			return compareClosed(c, cursor)
+		}
+	}
+
+	closedChannels := make(
+		[]*channeldb.ChannelCloseSummary, 0, len(dbChannels),
+	)
 	for _, dbChannel := range dbChannels {
 		if dbChannel.IsPending {
 			continue
@@ -4232,6 +5198,20 @@ func (r *rpcServer) ClosedChannels(ctx context.Context,
 			}
 		}
 
+		closedChannels = append(closedChannels, dbChannel)
+	}
+
+	// Only the requested page of channels is looked up in the historical
+	// channel bucket and serialized.
+	page := lnrpc.PaginateAfterCursor(
+		closedChannels, cmpCursor, in.NumMaxChannels, in.Reversed,
+	)
+	if len(page) > 0 {
+		resp.FirstChanPointOffset = page[0].ChanPoint.String()
+		resp.LastChanPointOffset = page[len(page)-1].ChanPoint.String()
+	}
+
+	for _, dbChannel := range page {
 		channel, err := r.createRPCClosedChannel(dbChannel)
 		if err != nil {
 			return nil, err
@@ -4272,6 +5252,152 @@ func (r *rpcServer) LookupHtlcResolution(
 	}, nil
 }
 
+// MigrateDatabase copies the channel state from the kvdb store to the native
+// SQL store in batches, sending an update with the progress and the estimated
+// remaining time of the current bucket after each batch.
+func (r *rpcServer) MigrateDatabase(req *lnrpc.MigrateDatabaseRequest,
+	updateStream lnrpc.Lightning_MigrateDatabaseServer) error {
+
+	store := r.server.chanStateSQLStore
+	if store == nil {
+		return status.Error(codes.Unavailable, "cannot migrate the "+
+			"channel state without --db.dual-write-channel-state")
+	}
+
+	ctx, cancel := context.WithCancel(updateStream.Context())
+	defer cancel()
+
+	var (
+		sendErr     error
+		bucket      string
+		bucketStart time.Time
+	)
+	progress := func(p channeldb.SQLMigrationProgress) {
+		if sendErr != nil {
+			return
+		}
+
+		// The ETA is estimated from the speed the records of the
+		// current bucket were migrated with so far.
+		if p.Bucket != bucket {
+			bucket = p.Bucket
+			bucketStart = time.Now()
+		}
+
+		var eta time.Duration
+		if p.RecordsDone > 0 && p.RecordsTotal > p.RecordsDone {
+			elapsed := time.Since(bucketStart)
+			remaining := p.RecordsTotal - p.RecordsDone
+			eta = elapsed * time.Duration(remaining) /
+				time.Duration(p.RecordsDone)
+		}
+
+		sendErr = updateStream.Send(&lnrpc.MigrateDatabaseUpdate{
+			Bucket:       p.Bucket,
+			RecordsDone:  p.RecordsDone,
+			RecordsTotal: p.RecordsTotal,
+			EtaSeconds:   int64(eta.Seconds()),
+		})
+		if sendErr != nil {
+			cancel()
+		}
+	}
+
+	rpcsLog.Infof("Migrating channel state to the native SQL store with "+
+		"batch size %d", req.BatchSize)
+
+	err := r.server.chanStateDB.MigrateToSQL(
+		ctx, store, int(req.BatchSize), progress,
+	)
+	switch {
+	case sendErr != nil:
+		return sendErr
+
+	case err != nil:
+		rpcsLog.Errorf("Unable to migrate channel state: %v", err)
+		return err
+	}
+
+	rpcsLog.Infof("Channel state migrated to the native SQL store")
+
+	return updateStream.Send(&lnrpc.MigrateDatabaseUpdate{
+		Done: true,
+	})
+}
+
+// GetDatabaseInfo returns the database backend lnd is running with and the
+// connection pool statistics of the native SQL store.
+func (r *rpcServer) GetDatabaseInfo(_ context.Context,
+	_ *lnrpc.GetDatabaseInfoRequest) (*lnrpc.GetDatabaseInfoResponse,
+	error) {
+
+	resp := &lnrpc.GetDatabaseInfoResponse{
+		Backend: r.cfg.DB.Backend,
+	}
+
+	store := r.server.nativeSQLStore
+	if store == nil {
+		return resp, nil
+	}
+
+	stats := store.Stats()
+	resp.NativeSql = true
+	resp.PoolStats = &lnrpc.DatabasePoolStats{
+		MaxOpenConnections: uint32(stats.MaxOpenConnections),
+		OpenConnections:    uint32(stats.OpenConnections),
+		InUse:              uint32(stats.InUse),
+		Idle:               uint32(stats.Idle),
+		WaitCount:          uint64(stats.WaitCount),
+		WaitDurationMs:     uint64(stats.WaitDuration.Milliseconds()),
+		MaxIdleClosed:      uint64(stats.MaxIdleClosed),
+		MaxIdleTimeClosed:  uint64(stats.MaxIdleTimeClosed),
+		MaxLifetimeClosed:  uint64(stats.MaxLifetimeClosed),
+	}
+
+	return resp, nil
+}
+
+// BackupDatabase writes a consistent snapshot of all SQL databases to the
+// given directory while lnd keeps running.
+func (r *rpcServer) BackupDatabase(ctx context.Context,
+	in *lnrpc.BackupDatabaseRequest) (*lnrpc.BackupDatabaseResponse,
+	error) {
+
+	if r.server.dbBackup == nil {
+		return nil, status.Errorf(codes.Unimplemented, "database "+
+			"backups are not supported for the %v backend",
+			r.cfg.DB.Backend)
+	}
+
+	if !filepath.IsAbs(in.DestDir) {
+		return nil, status.Error(codes.InvalidArgument, "dest_dir "+
+			"must be an absolute path")
+	}
+
+	info, err := os.Stat(in.DestDir)
+	switch {
+	case err != nil:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid "+
+			"dest_dir: %v", err)
+
+	case !info.IsDir():
+		return nil, status.Errorf(codes.InvalidArgument, "dest_dir "+
+			"%v is not a directory", in.DestDir)
+	}
+
+	rpcsLog.Infof("Backing up databases to %v", in.DestDir)
+
+	files, err := r.server.dbBackup(ctx, in.DestDir)
+	if err != nil {
+		rpcsLog.Errorf("Unable to back up databases: %v", err)
+		return nil, err
+	}
+
+	return &lnrpc.BackupDatabaseResponse{
+		Files: files,
+	}, nil
+}
+
 // ListChannels returns a description of all the open channels that this node
 // is a participant in.
 func (r *rpcServer) ListChannels(ctx context.Context,
@@ -4299,9 +5425,23 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 		return nil, err
 	}
 
+	allTags, err := r.server.chanStateDB.FetchAllChannelTags()
+	if err != nil {
+		return nil, err
+	}
+
 	rpcsLog.Debugf("[listchannels] fetched %v channels from DB",
 		len(dbChannels))
 
+	// listedChannel is a channel that matches the filters of the request
+	// along with the state it's serialized with.
+	type listedChannel struct {
+		dbChannel *channeldb.OpenChannel
+		isActive  bool
+		tags      []string
+	}
+
+	listedChannels := make([]listedChannel, 0, len(dbChannels))
 	for _, dbChannel := range dbChannels {
 		nodePub := dbChannel.IdentityPub
 		nodePubBytes := nodePub.SerializeCompressed()
@@ -4313,6 +5453,13 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 			continue
 		}
 
+		// If the caller requested the channels of a channel group,
+		// skip any that aren't tagged with it.
+		tags := allTags[chanPoint]
+		if in.Tag != "" && !slices.Contains(tags, in.Tag) {
+			continue
+		}
+
 		var peerOnline bool
 		if _, err := r.server.FindPeer(nodePub); err == nil {
 			peerOnline = true
@@ -4330,12 +5477,7 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 		// Next, we'll determine whether we should add this channel to
 		// our list depending on the type of channels requested to us.
 		isActive := peerOnline && linkActive
-		channel, err := createRPCOpenChannel(
-			r, dbChannel, isActive, in.PeerAliasLookup,
-		)
-		if err != nil {
-			return nil, err
-		}
+		private := isPrivate(dbChannel)
 
 		// We'll only skip returning this channel if we were requested
 		// for a specific kind and this channel doesn't satisfy it.
@@ -4344,12 +5486,58 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 			continue
 		case in.InactiveOnly && isActive:
 			continue
-		case in.PublicOnly && channel.Private:
+		case in.PublicOnly && private:
 			continue
-		case in.PrivateOnly && !channel.Private:
+		case in.PrivateOnly && !private:
 			continue
 		}
 
+		listedChannels = append(listedChannels, listedChannel{
+			dbChannel: dbChannel,
+			isActive:  isActive,
+			tags:      tags,
+		})
+	}
+
+	// The channels are ordered by their channel point, which stays a valid
+	// cursor even if the channel is closed before the next page is
+	// requested. Only the requested page of channels is serialized, which
+	// requires looking up their commitments and state.
+	slices.SortFunc(listedChannels, func(a, b listedChannel) int {
+		return compareOutPoints(
+			a.dbChannel.FundingOutpoint,
+			b.dbChannel.FundingOutpoint,
+		)
+	})
+	cmpCursor, err := chanPointCursor(
+		in.ChanPointOffset, func(c listedChannel) wire.OutPoint {
+			return c.dbChannel.FundingOutpoint
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	page := lnrpc.PaginateAfterCursor(
+		listedChannels, cmpCursor, in.NumMaxChannels, in.Reversed,
+	)
+	if len(page) > 0 {
+		first := page[0].dbChannel.FundingOutpoint
+		last := page[len(page)-1].dbChannel.FundingOutpoint
+		resp.FirstChanPointOffset = first.String()
+		resp.LastChanPointOffset = last.String()
+	}
+
+	for _, listed := range page {
+		channel, err := createRPCOpenChannel(
+			r, listed.dbChannel, listed.isActive,
+			in.PeerAliasLookup,
+		)
+		if err != nil {
+			return nil, err
+		}
+		channel.Tags = listed.tags
+
 		resp.Channels = append(resp.Channels, channel)
 	}
 
@@ -4941,6 +6129,21 @@ func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
 					},
 				}
 
+			case channelnotifier.FundingTimeoutEvent:
+				closedChannel, err := r.createRPCClosedChannel(
+					event.CloseSummary,
+				)
+				if err != nil {
+					return err
+				}
+
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_FUNDING_TIMEOUT_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_FundingTimeoutChannel{
+						FundingTimeoutChannel: closedChannel,
+					},
+				}
+
 			case channelnotifier.ActiveChannelEvent:
 				update = &lnrpc.ChannelEventUpdate{
 					Type: lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL,
@@ -4988,6 +6191,99 @@ func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
 					},
 				}
 
+			case channelnotifier.CloseConfirmedEvent:
+				closedChannel, err := r.createRPCClosedChannel(
+					event.CloseSummary,
+				)
+				if err != nil {
+					return err
+				}
+
+				var blocksTilMaturity uint32
+				maturity := event.MaturityHeight
+				closeHeight := event.CloseSummary.CloseHeight
+				if maturity > closeHeight {
+					blocksTilMaturity = maturity - closeHeight
+				}
+
+				confirmed := &lnrpc.CloseConfirmedUpdate{
+					CloseSummary:      closedChannel,
+					MaturityHeight:    maturity,
+					BlocksTilMaturity: blocksTilMaturity,
+				}
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_CLOSE_CONFIRMED_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_CloseConfirmedChannel{
+						CloseConfirmedChannel: confirmed,
+					},
+				}
+
+			case channelnotifier.PolicyUpdateEvent:
+				policyUpdate := &lnrpc.ChannelPolicyUpdate{
+					ChannelPoint: marshalChannelPoint(
+						event.ChannelPoint,
+					),
+					Policy: marshalForwardingPolicy(
+						event.Policy,
+					),
+				}
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_POLICY_UPDATE,
+					Channel: &lnrpc.ChannelEventUpdate_PolicyUpdate{
+						PolicyUpdate: policyUpdate,
+					},
+				}
+
+			case channelnotifier.ForwardingStatusEvent:
+				chanPoint := marshalChannelPoint(
+					event.ChannelPoint,
+				)
+
+				if event.Disabled {
+					update = &lnrpc.ChannelEventUpdate{
+						Type: lnrpc.ChannelEventUpdate_FORWARDING_DISABLED_CHANNEL,
+						Channel: &lnrpc.ChannelEventUpdate_ForwardingDisabledChannel{
+							ForwardingDisabledChannel: chanPoint,
+						},
+					}
+				} else {
+					update = &lnrpc.ChannelEventUpdate{
+						Type: lnrpc.ChannelEventUpdate_FORWARDING_ENABLED_CHANNEL,
+						Channel: &lnrpc.ChannelEventUpdate_ForwardingEnabledChannel{
+							ForwardingEnabledChannel: chanPoint,
+						},
+					}
+				}
+
+			case channelnotifier.FlappingChannelEvent:
+				flapping := &lnrpc.FlappingChannelUpdate{
+					ChannelPoint: marshalChannelPoint(
+						event.ChannelPoint,
+					),
+					FlapCount: int32(event.FlapCount),
+				}
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_FLAPPING_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_FlappingChannel{
+						FlappingChannel: flapping,
+					},
+				}
+
+			case channelnotifier.FailureDisabledChannelEvent:
+				disabled := &lnrpc.FailureDisabledChannelUpdate{
+					ChannelPoint: marshalChannelPoint(
+						event.ChannelPoint,
+					),
+					NumFailures:  event.NumFailures,
+					ReenableTime: event.ReenableTime.Unix(),
+				}
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_FAILURE_DISABLED_CHANNEL,
+					Channel: &lnrpc.ChannelEventUpdate_FailureDisabledChannel{
+						FailureDisabledChannel: disabled,
+					},
+				}
+
 			default:
 				return fmt.Errorf("unexpected channel event update: %v", event)
 			}
@@ -5011,6 +6307,32 @@ func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
 	}
 }
 
+// marshalChannelPoint converts the given outpoint to its RPC representation.
+func marshalChannelPoint(op *wire.OutPoint) *lnrpc.ChannelPoint {
+	return &lnrpc.ChannelPoint{
+		FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+			FundingTxidBytes: op.Hash[:],
+		},
+		OutputIndex: op.Index,
+	}
+}
+
+// marshalForwardingPolicy converts the given forwarding policy of a channel to
+// its RPC representation.
+func marshalForwardingPolicy(
+	policy models.ForwardingPolicy) *lnrpc.RoutingPolicy {
+
+	return &lnrpc.RoutingPolicy{
+		TimeLockDelta:           policy.TimeLockDelta,
+		MinHtlc:                 int64(policy.MinHTLCOut),
+		FeeBaseMsat:             int64(policy.BaseFee),
+		FeeRateMilliMsat:        int64(policy.FeeRate),
+		MaxHtlcMsat:             uint64(policy.MaxHTLC),
+		InboundFeeBaseMsat:      policy.InboundFee.Base,
+		InboundFeeRateMilliMsat: policy.InboundFee.Rate,
+	}
+}
+
 // paymentStream enables different types of payment streams, such as:
 // lnrpc.Lightning_SendPaymentServer and lnrpc.Lightning_SendToRouteServer to
 // execute sendPayment. We use this struct as a sort of bridge to enable code
@@ -5452,6 +6774,47 @@ func (r *rpcServer) dispatchPaymentIntent(
 	}, nil
 }
 
+// authorizeSpend checks the given payment intent against the spend limits of
+// the macaroon used for the call, if any. The amount checked includes the
+// maximum fee the payment is allowed to pay. The returned function refunds the
+// amount to the spend limits and must be called if the payment fails.
+func (r *rpcServer) authorizeSpend(ctx context.Context,
+	payIntent *rpcPaymentIntent) (func() error, error) {
+
+	if r.macService == nil {
+		return func() error { return nil }, nil
+	}
+
+	amt := payIntent.msat + payIntent.feeLimit
+	if payIntent.route != nil {
+		amt = payIntent.route.TotalAmount
+	}
+
+	return r.macService.AuthorizeSpend(ctx, uint64(amt))
+}
+
+// refundIfFailed refunds the spend limits charged for the payment with the
+// given hash if the payment failed or was never initiated. A payment that is
+// still in flight, for example because lnd is shutting down, isn't refunded.
+func (r *rpcServer) refundIfFailed(payHash lntypes.Hash, refund func() error) {
+	failed, err := r.routerBackend.PaymentFailed(payHash)
+	if err != nil {
+		rpcsLog.Errorf("Unable to fetch status of payment %v: %v",
+			payHash, err)
+
+		return
+	}
+
+	if !failed {
+		return
+	}
+
+	if err := refund(); err != nil {
+		rpcsLog.Errorf("Unable to refund spend limit of payment %v: %v",
+			payHash, err)
+	}
+}
+
 // sendPayment takes a paymentStream (a source of pre-built routes or payment
 // requests) and continually attempt to dispatch payment requests written to
 // the write end of the stream. Responses will also be streamed back to the
@@ -5677,7 +7040,7 @@ sendLoop:
 func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	nextPayment *lnrpc.SendRequest) (*lnrpc.SendResponse, error) {
 
-	return r.sendPaymentSync(&rpcPaymentRequest{
+	return r.sendPaymentSync(ctx, &rpcPaymentRequest{
 		SendRequest: nextPayment,
 	})
 }
@@ -5698,12 +7061,12 @@ func (r *rpcServer) SendToRouteSync(ctx context.Context,
 		return nil, err
 	}
 
-	return r.sendPaymentSync(paymentRequest)
+	return r.sendPaymentSync(ctx, paymentRequest)
 }
 
 // sendPaymentSync is the synchronous variant of sendPayment. It will block and
 // wait until the payment has been fully completed.
-func (r *rpcServer) sendPaymentSync(
+func (r *rpcServer) sendPaymentSync(ctx context.Context,
 	nextPayment *rpcPaymentRequest) (*lnrpc.SendResponse, error) {
 
 	// We don't allow payments to be sent while the daemon itself is still
@@ -5720,9 +7083,20 @@ func (r *rpcServer) sendPaymentSync(
 		return nil, err
 	}
 
+	// Make sure the payment is within the spend limits of the macaroon
+	// used for the call.
+	refund, err := r.authorizeSpend(ctx, &payIntent)
+	if err != nil {
+		return nil, err
+	}
+
 	// With the payment validated, we'll now attempt to dispatch the
-	// payment.
+	// payment. If it fails, its amount no longer counts towards the spend
+	// limits.
 	resp, saveErr := r.dispatchPaymentIntent(&payIntent)
+	if saveErr != nil || resp.Err != nil {
+		r.refundIfFailed(payIntent.rHash, refund)
+	}
 	switch {
 	case saveErr != nil:
 		return nil, saveErr
@@ -5768,7 +7142,8 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		GenAmpInvoiceFeatures: func() *lnwire.FeatureVector {
 			return r.server.featureMgr.Get(feature.SetInvoiceAmp)
 		},
-		GetAlias: r.server.aliasMgr.GetPeerAlias,
+		GetAlias:         r.server.aliasMgr.GetPeerAlias,
+		GetChannelUptime: r.server.getChannelUptime,
 	}
 
 	value, err := lnrpc.UnmarshallAmt(invoice.Value, invoice.ValueMsat)
@@ -5781,6 +7156,14 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+
+	hintStrategy, err := invoicesrpc.UnmarshalHintStrategy(
+		invoice.HintStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	addInvoiceData := &invoicesrpc.AddInvoiceData{
 		Memo:            invoice.Memo,
 		Value:           value,
@@ -5791,6 +7174,8 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		Private:         invoice.Private,
 		RouteHints:      routeHints,
 		Amp:             invoice.IsAmp,
+		HintStrategy:    hintStrategy,
+		NumHints:        invoice.NumHints,
 	}
 
 	if invoice.RPreimage != nil {
@@ -5928,6 +7313,39 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 	return resp, nil
 }
 
+// UpdateKeysendPolicy replaces the policy spontaneous keysend and AMP payments
+// are checked against.
+func (r *rpcServer) UpdateKeysendPolicy(_ context.Context,
+	in *lnrpc.UpdateKeysendPolicyRequest) (
+	*lnrpc.UpdateKeysendPolicyResponse, error) {
+
+	policy := invoices.KeysendPolicy{
+		AcceptKeySend:  in.AcceptKeysend,
+		AcceptAMP:      in.AcceptAmp,
+		MinAmt:         lnwire.MilliSatoshi(in.MinAmtMsat),
+		MaxAmt:         lnwire.MilliSatoshi(in.MaxAmtMsat),
+		AllowedSenders: make(map[route.Vertex]struct{}),
+	}
+
+	for _, sender := range in.AllowedSenders {
+		vertex, err := route.NewVertexFromBytes(sender)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed sender: %w",
+				err)
+		}
+
+		policy.AllowedSenders[vertex] = struct{}{}
+	}
+
+	if err := r.server.invoices.UpdateKeysendPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[updatekeysendpolicy] updated keysend policy")
+
+	return &lnrpc.UpdateKeysendPolicyResponse{}, nil
+}
+
 // SubscribeInvoices returns a uni-directional stream (server -> client) for
 // notifying the client of newly added/settled invoices.
 func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
@@ -6059,6 +7477,15 @@ func (r *rpcServer) GetTransactions(ctx context.Context,
 func (r *rpcServer) DescribeGraph(ctx context.Context,
 	req *lnrpc.ChannelGraphRequest) (*lnrpc.ChannelGraph, error) {
 
+	// A request for the changes since a given time or for a single page of
+	// the graph only returns a part of it, so it can't be served from or
+	// populate the cache.
+	if req.SinceTimestamp != 0 || req.NodeOffset != "" ||
+		req.MaxNodes != 0 || req.ChanIdOffset != 0 || req.MaxEdges != 0 {
+
+		return r.describePartialGraph(ctx, req)
+	}
+
 	resp := &lnrpc.ChannelGraph{}
 	includeUnannounced := req.IncludeUnannounced
 
@@ -6128,6 +7555,152 @@ func (r *rpcServer) DescribeGraph(ctx context.Context,
 	return resp, nil
 }
 
+// describePartialGraph returns the nodes and edges of the graph that were
+// updated at or after the requested time, starting after the requested
+// offsets and limited to the requested number of nodes and edges. If the
+// channel graph is mirrored to the native SQL store, the query is served from
+// its indexes, otherwise the kvdb graph is scanned.
+func (r *rpcServer) describePartialGraph(ctx context.Context,
+	req *lnrpc.ChannelGraphRequest) (*lnrpc.ChannelGraph, error) {
+
+	var nodeOffset route.Vertex
+	if req.NodeOffset != "" {
+		var err error
+		nodeOffset, err = route.NewVertexFromStr(req.NodeOffset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node offset: %w", err)
+		}
+	}
+
+	var (
+		since = time.Unix(int64(req.SinceTimestamp), 0)
+		resp  = &lnrpc.ChannelGraph{}
+	)
+
+	// Do not include unannounced channels unless specifically requested,
+	// just like a full DescribeGraph call.
+	addEdge := func(edgeInfo *models.ChannelEdgeInfo,
+		c1, c2 *models.ChannelEdgePolicy) {
+
+		if !req.IncludeUnannounced && edgeInfo.AuthProof == nil {
+			return
+		}
+
+		resp.Edges = append(resp.Edges, marshalDBEdge(edgeInfo, c1, c2))
+		resp.LastChanIdOffset = edgeInfo.ChannelID
+	}
+
+	if store := r.server.graphSQLStore; store != nil {
+		nodes, err := store.ListNodes(
+			ctx, nodeOffset, since, req.MaxNodes,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			resp.Nodes = append(resp.Nodes, marshalNode(node))
+		}
+
+		// As unannounced channels may be filtered out, we keep
+		// fetching channels until the page is full or there are no
+		// more channels left.
+		afterChanID := req.ChanIdOffset
+		for {
+			channels, err := store.ListChannels(
+				ctx, afterChanID, since, req.MaxEdges,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, channel := range channels {
+				afterChanID = channel.Info.ChannelID
+				addEdge(channel.Info, channel.Policy1, channel.Policy2)
+
+				if req.MaxEdges != 0 &&
+					len(resp.Edges) == int(req.MaxEdges) {
+
+					break
+				}
+			}
+
+			if req.MaxEdges == 0 ||
+				len(channels) < int(req.MaxEdges) ||
+				len(resp.Edges) == int(req.MaxEdges) {
+
+				break
+			}
+		}
+	} else {
+		// The kvdb graph iterates over the nodes ordered by their
+		// public key and over the channels ordered by their ID, so we
+		// can skip everything up to the offsets and stop early once
+		// the limits are reached.
+		errDone := errors.New("done")
+
+		err := r.server.graphDB.ForEachNode(func(_ kvdb.RTx,
+			node *channeldb.LightningNode) error {
+
+			if bytes.Compare(node.PubKeyBytes[:], nodeOffset[:]) <= 0 {
+				return nil
+			}
+			if req.SinceTimestamp != 0 && node.LastUpdate.Before(since) {
+				return nil
+			}
+
+			resp.Nodes = append(resp.Nodes, marshalNode(node))
+			if req.MaxNodes != 0 &&
+				len(resp.Nodes) == int(req.MaxNodes) {
+
+				return errDone
+			}
+
+			return nil
+		})
+		if err != nil && !errors.Is(err, errDone) {
+			return nil, err
+		}
+
+		err = r.server.graphDB.ForEachChannel(func(
+			edgeInfo *models.ChannelEdgeInfo,
+			c1, c2 *models.ChannelEdgePolicy) error {
+
+			if edgeInfo.ChannelID <= req.ChanIdOffset {
+				return nil
+			}
+
+			// A channel is considered updated if any of its
+			// policies was updated.
+			if req.SinceTimestamp != 0 &&
+				(c1 == nil || c1.LastUpdate.Before(since)) &&
+				(c2 == nil || c2.LastUpdate.Before(since)) {
+
+				return nil
+			}
+
+			addEdge(edgeInfo, c1, c2)
+			if req.MaxEdges != 0 &&
+				len(resp.Edges) == int(req.MaxEdges) {
+
+				return errDone
+			}
+
+			return nil
+		})
+		if err != nil && !errors.Is(err, errDone) &&
+			!errors.Is(err, channeldb.ErrGraphNoEdgesFound) {
+
+			return nil, err
+		}
+	}
+
+	if len(resp.Nodes) > 0 {
+		resp.LastNodeOffset = resp.Nodes[len(resp.Nodes)-1].PubKey
+	}
+
+	return resp, nil
+}
+
 // marshalExtraOpaqueData marshals the given tlv data. If the tlv stream is
 // malformed or empty, an empty map is returned. This makes the method safe to
 // use on unvalidated data.
@@ -6341,6 +7914,45 @@ func (r *rpcServer) GetChanInfo(_ context.Context,
 	return channelEdge, nil
 }
 
+// RefreshChannelEdge queries our gossip peers for the announcement and the
+// latest updates of a channel. A zombie channel is resurrected first, so that
+// its announcement is accepted again.
+func (r *rpcServer) RefreshChannelEdge(_ context.Context,
+	in *lnrpc.RefreshChannelEdgeRequest) (*lnrpc.RefreshChannelEdgeResponse,
+	error) {
+
+	if in.ChanId == 0 {
+		return nil, fmt.Errorf("chan_id must be specified")
+	}
+	chanID := lnwire.NewShortChanIDFromInt(in.ChanId)
+
+	isZombie, _, _ := r.server.graphDB.IsZombieEdge(in.ChanId)
+	if isZombie {
+		err := r.server.chanRouter.MarkEdgeLive(chanID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resurrect zombie "+
+				"channel %v: %w", chanID, err)
+		}
+
+		rpcsLog.Infof("[refreshchanneledge] resurrected zombie "+
+			"channel %v", chanID)
+	}
+
+	syncMgr := r.server.authGossiper.SyncManager()
+	numQueried, err := syncMgr.QueryChanIDs(chanID)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[refreshchanneledge] queried %d peers for channel %v",
+		numQueried, chanID)
+
+	return &lnrpc.RefreshChannelEdgeResponse{
+		WasZombie:       isZombie,
+		NumPeersQueried: uint32(numQueried),
+	}, nil
+}
+
 // GetNodeInfo returns the latest advertised and aggregate authenticated
 // channel information for the specified node identified by its public key.
 func (r *rpcServer) GetNodeInfo(ctx context.Context,
@@ -6579,6 +8191,7 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 		MaxChannelSize:       int64(maxChannelSize),
 		MedianChannelSizeSat: int64(medianChanSize),
 		NumZombieChans:       numZombies,
+		PruneStats:           marshalPruneStats(r.server.chanRouter),
 	}
 
 	// Similarly, if we don't have any channels, then we'll also set the
@@ -6591,6 +8204,24 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 	return netInfo, nil
 }
 
+// marshalPruneStats converts the graph prune stats of the router into their
+// rpc representation.
+func marshalPruneStats(router *routing.ChannelRouter) *lnrpc.GraphPruneStats {
+	stats := router.PruneStats()
+
+	var lastZombiePrune int64
+	if !stats.LastZombiePrune.IsZero() {
+		lastZombiePrune = stats.LastZombiePrune.Unix()
+	}
+
+	return &lnrpc.GraphPruneStats{
+		NumZombieChansPruned:  stats.NumZombieChansPruned,
+		NumClosedChansPruned:  stats.NumClosedChansPruned,
+		NumZombiesResurrected: stats.NumZombiesResurrected,
+		LastZombiePrune:       lastZombiePrune,
+	}
+}
+
 // StopDaemon will send a shutdown request to the interrupt handler, triggering
 // a graceful shutdown of the daemon.
 func (r *rpcServer) StopDaemon(_ context.Context,
@@ -7010,7 +8641,7 @@ const feeBase float64 = 1000000
 // FeeReport allows the caller to obtain a report detailing the current fee
 // schedule enforced by the node globally for each channel.
 func (r *rpcServer) FeeReport(ctx context.Context,
-	_ *lnrpc.FeeReportRequest) (*lnrpc.FeeReportResponse, error) {
+	req *lnrpc.FeeReportRequest) (*lnrpc.FeeReportResponse, error) {
 
 	channelGraph := r.server.graphDB
 	selfNode, err := channelGraph.SourceNode()
@@ -7018,6 +8649,25 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 		return nil, err
 	}
 
+	// If the report is restricted to a channel group, we'll only include
+	// the channels tagged with it.
+	var taggedChanIDs map[lnwire.ShortChannelID]struct{}
+	if req.Tag != "" {
+		taggedChanIDs, err = r.fetchTaggedChanIDs(req.Tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+	isTagged := func(chanID lnwire.ShortChannelID) bool {
+		if req.Tag == "" {
+			return true
+		}
+
+		_, ok := taggedChanIDs[chanID]
+
+		return ok
+	}
+
 	var feeReports []*lnrpc.ChannelFeeReport
 	err = channelGraph.ForEachNodeChannel(nil, selfNode.PubKeyBytes,
 		func(_ kvdb.RTx, chanInfo *models.ChannelEdgeInfo,
@@ -7030,6 +8680,13 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 					"channel %v ", chanInfo.ChannelID)
 			}
 
+			chanID := lnwire.NewShortChanIDFromInt(
+				chanInfo.ChannelID,
+			)
+			if !isTagged(chanID) {
+				return nil
+			}
+
 			// We'll compute the effective fee rate by converting
 			// from a fixed point fee rate to a floating point fee
 			// rate. The fee rate field in the database the amount
@@ -7068,7 +8725,7 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 		return nil, err
 	}
 
-	fwdEventLog := r.server.miscDB.ForwardingLog()
+	fwdEventLog := r.server.fwdingLog
 
 	// computeFeeSum is a helper function that computes the total fees for
 	// a particular time slice described by a forwarding event query.
@@ -7093,6 +8750,10 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 			// Otherwise, we'll tally up an accumulate the total
 			// fees for this time slice.
 			for _, event := range timeSlice.ForwardingEvents {
+				if !isTagged(event.OutgoingChanID) {
+					continue
+				}
+
 				fee := event.AmtIn - event.AmtOut
 				totalFees += fee
 			}
@@ -7118,6 +8779,29 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 			"events: %v", err)
 	}
 
+	// If a time window was requested, we'll also compute the profit and
+	// loss of each channel within it.
+	if req.StartTime != 0 || req.EndTime != 0 {
+		startTime := time.Unix(int64(req.StartTime), 0)
+		endTime := now
+		if req.EndTime != 0 {
+			endTime = time.Unix(int64(req.EndTime), 0)
+		}
+
+		if startTime.After(endTime) {
+			return nil, fmt.Errorf("start_time must not be after " +
+				"end_time")
+		}
+
+		feeReports, err = r.addChannelProfits(
+			feeReports, startTime, endTime, isTagged,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute channel "+
+				"profits: %w", err)
+		}
+	}
+
 	// In addition to returning the current fee schedule for each channel.
 	// We'll also perform a series of queries to obtain the total fees
 	// earned over the past day, week, and month.
@@ -7159,694 +8843,2556 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 	}, nil
 }
 
-// minFeeRate is the smallest permitted fee rate within the network. This is
-// derived by the fact that fee rates are computed using a fixed point of
-// 1,000,000. As a result, the smallest representable fee rate is 1e-6, or
-// 0.000001, or 0.0001%.
-const minFeeRate = 1e-6
+// channelProfit tracks the revenue and the costs of a channel within a time
+// window.
+type channelProfit struct {
+	// earnedFees are the fees earned by forwarding HTLCs out through the
+	// channel.
+	earnedFees lnwire.MilliSatoshi
 
-// UpdateChannelPolicy allows the caller to update the channel forwarding policy
-// for all channels globally, or a particular channel.
-func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
-	req *lnrpc.PolicyUpdateRequest) (*lnrpc.PolicyUpdateResponse, error) {
+	// rebalanceCosts are the fees paid for circular rebalances that moved
+	// liquidity into the channel.
+	rebalanceCosts lnwire.MilliSatoshi
 
-	var targetChans []wire.OutPoint
-	switch scope := req.Scope.(type) {
-	// If the request is targeting all active channels, then we don't need
-	// target any channels by their channel point.
-	case *lnrpc.PolicyUpdateRequest_Global:
+	// onChainCosts is the share of the fees of the funding and closing
+	// transactions that falls into the time window.
+	onChainCosts lnwire.MilliSatoshi
+}
 
-	// Otherwise, we're targeting an individual channel by its channel
-	// point.
-	case *lnrpc.PolicyUpdateRequest_ChanPoint:
-		txid, err := lnrpc.GetChanPointFundingTxid(scope.ChanPoint)
-		if err != nil {
-			return nil, err
-		}
-		targetChans = append(targetChans, wire.OutPoint{
-			Hash:  *txid,
-			Index: scope.ChanPoint.OutputIndex,
-		})
-	default:
-		return nil, fmt.Errorf("unknown scope: %v", scope)
+// profit returns the earned fees minus all costs of the channel.
+func (c *channelProfit) profit() int64 {
+	return int64(c.earnedFees) - int64(c.rebalanceCosts) -
+		int64(c.onChainCosts)
+}
+
+// apply populates the profit and loss fields of the given fee report.
+func (c *channelProfit) apply(report *lnrpc.ChannelFeeReport) {
+	report.EarnedFeeMsat = uint64(c.earnedFees)
+	report.RebalanceCostMsat = uint64(c.rebalanceCosts)
+	report.OnchainCostMsat = uint64(c.onChainCosts)
+	report.ProfitMsat = c.profit()
+}
+
+// amortizeCost returns the share of the given cost that falls into the time
+// window, if the cost is spread linearly over the given lifetime. The full cost
+// is returned if the lifetime is empty and lies within the window.
+func amortizeCost(cost lnwire.MilliSatoshi, lifeStart, lifeEnd, windowStart,
+	windowEnd time.Time) lnwire.MilliSatoshi {
+
+	overlapStart := lifeStart
+	if windowStart.After(overlapStart) {
+		overlapStart = windowStart
+	}
+	overlapEnd := lifeEnd
+	if windowEnd.Before(overlapEnd) {
+		overlapEnd = windowEnd
 	}
 
-	var feeRateFixed uint32
+	if overlapEnd.Before(overlapStart) {
+		return 0
+	}
 
-	switch {
-	// The request should use either the fee rate in percent, or the new
-	// ppm rate, but not both.
-	case req.FeeRate != 0 && req.FeeRatePpm != 0:
-		errMsg := "cannot set both FeeRate and FeeRatePpm at the " +
-			"same time"
+	lifetime := lifeEnd.Sub(lifeStart)
+	if lifetime <= 0 {
+		return cost
+	}
 
-		return nil, status.Errorf(codes.InvalidArgument, errMsg)
+	share := overlapEnd.Sub(overlapStart).Seconds() / lifetime.Seconds()
 
-	// If the request is using fee_rate.
-	case req.FeeRate != 0:
-		// As a sanity check, if the fee isn't zero, we'll ensure that
-		// the passed fee rate is below 1e-6, or the lowest allowed
-		// non-zero fee rate expressible within the protocol.
-		if req.FeeRate != 0 && req.FeeRate < minFeeRate {
-			return nil, fmt.Errorf("fee rate of %v is too "+
-				"small, min fee rate is %v", req.FeeRate,
-				minFeeRate)
+	return lnwire.MilliSatoshi(float64(cost) * share)
+}
+
+// addRebalanceCosts attributes the fees of the HTLCs of the given payment that
+// were settled within the time window to the channel of their last hop, if the
+// payment is a circular rebalance to our own node.
+func addRebalanceCosts(costs map[lnwire.ShortChannelID]lnwire.MilliSatoshi,
+	payment *channeldb.MPPayment, self route.Vertex, windowStart,
+	windowEnd time.Time) {
+
+	for _, htlc := range payment.HTLCs {
+		if htlc.Settle == nil {
+			continue
 		}
 
-		// We'll also need to convert the floating point fee rate we
-		// accept over RPC to the fixed point rate that we use within
-		// the protocol. We do this by multiplying the passed fee rate
-		// by the fee base. This gives us the fixed point, scaled by 1
-		// million that's used within the protocol.
-		//
-		// Because of the inaccurate precision of the IEEE 754
-		// standard, we need to round the product of feerate and
-		// feebase.
-		feeRateFixed = uint32(math.Round(req.FeeRate * feeBase))
+		settleTime := htlc.Settle.SettleTime
+		if settleTime.Before(windowStart) ||
+			settleTime.After(windowEnd) {
 
-	// Otherwise, we use the fee_rate_ppm parameter.
-	case req.FeeRatePpm != 0:
-		feeRateFixed = req.FeeRatePpm
-	}
+			continue
+		}
 
-	// We'll also ensure that the user isn't setting a CLTV delta that
-	// won't give outgoing HTLCs enough time to fully resolve if needed.
-	if req.TimeLockDelta < minTimeLockDelta {
-		return nil, fmt.Errorf("time lock delta of %v is too small, "+
-			"minimum supported is %v", req.TimeLockDelta,
-			minTimeLockDelta)
-	} else if req.TimeLockDelta > uint32(MaxTimeLockDelta) {
-		return nil, fmt.Errorf("time lock delta of %v is too big, "+
-			"maximum supported is %v", req.TimeLockDelta,
-			MaxTimeLockDelta)
+		hops := htlc.Route.Hops
+		if len(hops) == 0 || hops[len(hops)-1].PubKeyBytes != self {
+			continue
+		}
+
+		chanID := lnwire.NewShortChanIDFromInt(
+			hops[len(hops)-1].ChannelID,
+		)
+		costs[chanID] += htlc.Route.TotalFees()
 	}
+}
 
-	// By default, positive inbound fees are rejected.
-	if !r.cfg.AcceptPositiveInboundFees && req.InboundFee != nil {
-		if req.InboundFee.BaseFeeMsat > 0 {
-			return nil, fmt.Errorf("positive values for inbound "+
-				"base fee msat are not supported: %v",
-				req.InboundFee.BaseFeeMsat)
-		}
-		if req.InboundFee.FeeRatePpm > 0 {
-			return nil, fmt.Errorf("positive values for inbound "+
-				"fee rate ppm are not supported: %v",
-				req.InboundFee.FeeRatePpm)
+// addChannelProfits populates the profit and loss fields of the given fee
+// reports for the given time window. Reports for the channels that were closed
+// after the start of the window are added to the returned reports.
+func (r *rpcServer) addChannelProfits(reports []*lnrpc.ChannelFeeReport,
+	startTime, endTime time.Time,
+	isTagged func(lnwire.ShortChannelID) bool) (
+	[]*lnrpc.ChannelFeeReport, error) {
+
+	profits := make(map[lnwire.ShortChannelID]*channelProfit)
+	profitOf := func(chanID lnwire.ShortChannelID) *channelProfit {
+		if _, ok := profits[chanID]; !ok {
+			profits[chanID] = &channelProfit{}
 		}
+
+		return profits[chanID]
 	}
 
-	// If no inbound fees have been specified, we indicate with an empty
-	// option that the previous inbound fee should be retained during the
-	// edge update.
-	inboundFee := fn.None[models.InboundFee]()
-	if req.InboundFee != nil {
-		inboundFee = fn.Some(models.InboundFee{
-			Base: req.InboundFee.BaseFeeMsat,
-			Rate: req.InboundFee.FeeRatePpm,
-		})
+	// First, we'll attribute the fees of the settled forwards within the
+	// window to their outgoing channel.
+	query := channeldb.ForwardingEventQuery{
+		StartTime:    startTime,
+		EndTime:      endTime,
+		NumMaxEvents: channeldb.MaxResponseEvents,
 	}
+	for {
+		timeSlice, err := r.server.fwdingLog.Query(query)
+		if err != nil {
+			return nil, err
+		}
 
-	baseFeeMsat := lnwire.MilliSatoshi(req.BaseFeeMsat)
-	feeSchema := routing.FeeSchema{
-		BaseFee:    baseFeeMsat,
-		FeeRate:    feeRateFixed,
-		InboundFee: inboundFee,
+		if len(timeSlice.ForwardingEvents) == 0 {
+			break
+		}
+
+		for _, event := range timeSlice.ForwardingEvents {
+			profit := profitOf(event.OutgoingChanID)
+			profit.earnedFees += event.AmtIn - event.AmtOut
+		}
+
+		query.IndexOffset = timeSlice.LastIndexOffset
 	}
 
-	maxHtlc := lnwire.MilliSatoshi(req.MaxHtlcMsat)
-	var minHtlc *lnwire.MilliSatoshi
-	if req.MinHtlcMsatSpecified {
-		min := lnwire.MilliSatoshi(req.MinHtlcMsat)
-		minHtlc = &min
+	// Next, we'll attribute the fees of our circular rebalances to the
+	// channel that received the liquidity.
+	rebalanceCosts := make(map[lnwire.ShortChannelID]lnwire.MilliSatoshi)
+	paymentsQuery := channeldb.PaymentsQuery{
+		MaxPayments:       1000,
+		CreationDateStart: startTime.Unix(),
+		CreationDateEnd:   endTime.Unix(),
 	}
+	for {
+		resp, err := r.server.miscDB.QueryPayments(paymentsQuery)
+		if err != nil {
+			return nil, err
+		}
 
-	chanPolicy := routing.ChannelPolicy{
-		FeeSchema:     feeSchema,
-		TimeLockDelta: req.TimeLockDelta,
-		MaxHTLC:       maxHtlc,
-		MinHTLC:       minHtlc,
+		if len(resp.Payments) == 0 {
+			break
+		}
+
+		for _, payment := range resp.Payments {
+			addRebalanceCosts(
+				rebalanceCosts, payment, r.selfNode, startTime,
+				endTime,
+			)
+		}
+
+		paymentsQuery.IndexOffset = resp.LastIndexOffset
+	}
+	for chanID, cost := range rebalanceCosts {
+		profitOf(chanID).rebalanceCosts += cost
 	}
 
-	rpcsLog.Debugf("[updatechanpolicy] updating channel policy "+
-		"base_fee=%v, rate_fixed=%v, time_lock_delta: %v, "+
-		"min_htlc=%v, max_htlc=%v, targets=%v",
-		req.BaseFeeMsat, feeRateFixed, req.TimeLockDelta,
-		minHtlc, maxHtlc,
-		spew.Sdump(targetChans))
+	// Finally, we'll amortize the on-chain costs of our open channels and
+	// of the channels that were closed within the window.
+	openChannels, err := r.server.chanStateDB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range openChannels {
+		chanID := channel.ShortChanID()
+		if channel.IsPending || !isTagged(chanID) {
+			continue
+		}
 
-	// With the scope resolved, we'll now send this to the local channel
-	// manager so it can propagate the new policy for our target channel(s).
-	failedUpdates, err := r.server.localChanMgr.UpdatePolicy(chanPolicy,
-		targetChans...)
+		cost, err := r.channelOnChainCost(
+			channel, nil, startTime, endTime,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// The fee report of a confirmed zero-conf channel may refer to
+		// it by its alias or by its confirmed short channel ID.
+		profitOf(chanID).onChainCosts += cost
+		if channel.IsZeroConf() && channel.ZeroConfConfirmed() {
+			realChanID := channel.ZeroConfRealScid()
+			profitOf(realChanID).onChainCosts += cost
+		}
+	}
+
+	reported := make(map[lnwire.ShortChannelID]*lnrpc.ChannelFeeReport)
+	for _, report := range reports {
+		chanID := lnwire.NewShortChanIDFromInt(report.ChanId)
+		reported[chanID] = report
+	}
+
+	closedChannels, err := r.server.chanStateDB.FetchClosedChannels(false)
 	if err != nil {
 		return nil, err
 	}
+	for _, summary := range closedChannels {
+		chanID := summary.ShortChanID
+		if chanID.BlockHeight == 0 || !isTagged(chanID) {
+			continue
+		}
 
-	return &lnrpc.PolicyUpdateResponse{
-		FailedUpdates: failedUpdates,
-	}, nil
-}
+		// The historical channel is needed to determine whether we
+		// paid the on-chain fees. It isn't available for channels
+		// that were closed by old versions of lnd.
+		channel, err := r.server.chanStateDB.FetchHistoricalChannel(
+			&summary.ChanPoint,
+		)
+		if err != nil {
+			rpcsLog.Debugf("Unable to fetch historical channel "+
+				"%v: %v", summary.ChanPoint, err)
 
-// ForwardingHistory allows the caller to query the htlcswitch for a record of
-// all HTLC's forwarded within the target time range, and integer offset within
-// that time range. If no time-range is specified, then the first chunk of the
-// past 24 hrs of forwarding history are returned.
+			continue
+		}
 
-// A list of forwarding events are returned. The size of each forwarding event
-// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
-// In order to safely stay under this max limit, we'll return 50k events per
-// response.  Each response has the index offset of the last entry. The index
-// offset can be provided to the request to allow the caller to skip a series
-// of records.
-func (r *rpcServer) ForwardingHistory(ctx context.Context,
-	req *lnrpc.ForwardingHistoryRequest) (*lnrpc.ForwardingHistoryResponse,
-	error) {
+		closeTime := time.Now()
+		if !summary.IsPending {
+			closeTime, err = r.blockTime(summary.CloseHeight)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	// Before we perform the queries below, we'll instruct the switch to
-	// flush any pending events to disk. This ensure we get a complete
-	// snapshot at this particular time.
-	if err := r.server.htlcSwitch.FlushForwardingEvents(); err != nil {
-		return nil, fmt.Errorf("unable to flush forwarding "+
-			"events: %v", err)
+		// Channels that were closed before the window didn't earn
+		// anything within it.
+		if closeTime.Before(startTime) {
+			continue
+		}
+
+		cost, err := r.channelOnChainCost(
+			channel, summary, startTime, endTime,
+		)
+		if err != nil {
+			return nil, err
+		}
+		profitOf(chanID).onChainCosts += cost
+
+		// The edge of a channel with a pending close is still part of
+		// the graph, so it might already have a report.
+		report, ok := reported[chanID]
+		if !ok {
+			report = &lnrpc.ChannelFeeReport{
+				ChanId:       chanID.ToUint64(),
+				ChannelPoint: summary.ChanPoint.String(),
+			}
+			reported[chanID] = report
+			reports = append(reports, report)
+		}
+		report.Closed = true
 	}
 
-	var (
-		startTime, endTime time.Time
+	for chanID, report := range reported {
+		if profit, ok := profits[chanID]; ok {
+			profit.apply(report)
+		}
+	}
 
-		numEvents uint32
-	)
+	return reports, nil
+}
 
-	// startTime defaults to the Unix epoch (0 unixtime, or
-	// midnight 01-01-1970).
-	startTime = time.Unix(int64(req.StartTime), 0)
+// blockTime returns the timestamp of the block at the given height.
+func (r *rpcServer) blockTime(height uint32) (time.Time, error) {
+	blockHash, err := r.server.cc.ChainIO.GetBlockHash(int64(height))
+	if err != nil {
+		return time.Time{}, err
+	}
 
-	// If the end time wasn't specified, assume a default end time of now.
-	if req.EndTime == 0 {
-		now := time.Now()
-		endTime = now
-	} else {
-		endTime = time.Unix(int64(req.EndTime), 0)
+	header, err := r.server.cc.ChainIO.GetBlockHeader(blockHash)
+	if err != nil {
+		return time.Time{}, err
 	}
 
-	// If the number of events wasn't specified, then we'll default to
-	// returning the last 100 events.
-	numEvents = req.NumMaxEvents
-	if numEvents == 0 {
-		numEvents = 100
+	return header.Timestamp, nil
+}
+
+// channelOnChainCost returns the share of the fees of the funding and closing
+// transactions of a channel that falls into the time window. The fees are only
+// attributed to the channel if we're the initiator, who pays them. The close
+// summary is nil for channels that are still open.
+func (r *rpcServer) channelOnChainCost(channel *channeldb.OpenChannel,
+	summary *channeldb.ChannelCloseSummary, startTime,
+	endTime time.Time) (lnwire.MilliSatoshi, error) {
+
+	if !channel.IsInitiator {
+		return 0, nil
 	}
 
-	// Next, we'll map the proto request into a format that is understood by
-	// the forwarding log.
-	eventQuery := channeldb.ForwardingEventQuery{
-		StartTime:    startTime,
-		EndTime:      endTime,
-		IndexOffset:  req.IndexOffset,
-		NumMaxEvents: numEvents,
+	// Unconfirmed zero-conf channels don't have an on-chain lifetime yet.
+	confHeight := channel.ShortChanID().BlockHeight
+	if channel.IsZeroConf() {
+		if !channel.ZeroConfConfirmed() {
+			return 0, nil
+		}
+
+		confHeight = channel.ZeroConfRealScid().BlockHeight
 	}
-	timeSlice, err := r.server.miscDB.ForwardingLog().Query(eventQuery)
+
+	openTime, err := r.blockTime(confHeight)
 	if err != nil {
-		return nil, fmt.Errorf("unable to query forwarding log: %w",
-			err)
+		return 0, err
 	}
 
-	// chanToPeerAlias caches previously looked up channel information.
-	chanToPeerAlias := make(map[lnwire.ShortChannelID]string)
+	// The wallet only knows the fee of the funding transaction if it was
+	// funded from the wallet.
+	var fees btcutil.Amount
+	fundingTx, err := r.server.cc.Wallet.GetTransactionDetails(
+		&channel.FundingOutpoint.Hash,
+	)
+	if err == nil {
+		fees += btcutil.Amount(fundingTx.TotalFees)
+	}
 
-	// Helper function to extract a peer's node alias given its SCID.
-	getRemoteAlias := func(chanID lnwire.ShortChannelID) (string, error) {
-		// If we'd previously seen this chanID then return the cached
-		// peer alias.
-		if peerAlias, ok := chanToPeerAlias[chanID]; ok {
-			return peerAlias, nil
+	closeTime := time.Now()
+	if summary != nil {
+		if !summary.IsPending {
+			closeTime, err = r.blockTime(summary.CloseHeight)
+			if err != nil {
+				return 0, err
+			}
 		}
 
-		// Else call the server to look up the peer alias.
-		edge, err := r.GetChanInfo(ctx, &lnrpc.ChanInfoRequest{
-			ChanId: chanID.ToUint64(),
+		fees += r.closingTxFee(summary)
+	}
+
+	return amortizeCost(
+		lnwire.NewMSatFromSatoshis(fees), openTime, closeTime,
+		startTime, endTime,
+	), nil
+}
+
+// closingTxFee returns the fee of the closing transaction of a channel, which
+// is the capacity of the channel minus the value of the closing outputs. Zero
+// is returned if the closing transaction isn't known to the wallet.
+func (r *rpcServer) closingTxFee(
+	summary *channeldb.ChannelCloseSummary) btcutil.Amount {
+
+	closingTx, err := r.server.cc.Wallet.GetTransactionDetails(
+		&summary.ClosingTXID,
+	)
+	if err != nil {
+		return 0
+	}
+
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(closingTx.RawTx)); err != nil {
+		return 0
+	}
+
+	outputValue := btcutil.Amount(0)
+	for _, txOut := range tx.TxOut {
+		outputValue += btcutil.Amount(txOut.Value)
+	}
+
+	if outputValue >= summary.Capacity {
+		return 0
+	}
+
+	return summary.Capacity - outputValue
+}
+
+// minFeeRate is the smallest permitted fee rate within the network. This is
+// derived by the fact that fee rates are computed using a fixed point of
+// 1,000,000. As a result, the smallest representable fee rate is 1e-6, or
+// 0.000001, or 0.0001%.
+const minFeeRate = 1e-6
+
+// UpdateChannelPolicy allows the caller to update the channel forwarding policy
+// for all channels globally, or a particular channel.
+func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
+	req *lnrpc.PolicyUpdateRequest) (*lnrpc.PolicyUpdateResponse, error) {
+
+	var targetChans []wire.OutPoint
+	switch scope := req.Scope.(type) {
+	// If the request is targeting all active channels, then we don't need
+	// target any channels by their channel point.
+	case *lnrpc.PolicyUpdateRequest_Global:
+
+	// Otherwise, we're targeting an individual channel by its channel
+	// point.
+	case *lnrpc.PolicyUpdateRequest_ChanPoint:
+		txid, err := lnrpc.GetChanPointFundingTxid(scope.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+		targetChans = append(targetChans, wire.OutPoint{
+			Hash:  *txid,
+			Index: scope.ChanPoint.OutputIndex,
 		})
+
+	// Otherwise, we're targeting all open channels with a peer.
+	case *lnrpc.PolicyUpdateRequest_PeerPubkey:
+		pubKey, err := btcec.ParsePubKey(scope.PeerPubkey)
 		if err != nil {
-			return "", err
+			return nil, fmt.Errorf("unable to parse peer pubkey: "+
+				"%w", err)
 		}
 
-		remotePub := edge.Node1Pub
-		if r.selfNode.String() == edge.Node1Pub {
-			remotePub = edge.Node2Pub
+		channels, err := r.server.chanStateDB.FetchOpenChannels(pubKey)
+		if err != nil {
+			return nil, err
 		}
 
-		vertex, err := route.NewVertexFromStr(remotePub)
+		for _, channel := range channels {
+			if channel.IsPending {
+				continue
+			}
+
+			targetChans = append(
+				targetChans, channel.FundingOutpoint,
+			)
+		}
+
+		// An empty set of target channels would result in a global
+		// update, so we'll bail out instead.
+		if len(targetChans) == 0 {
+			return nil, fmt.Errorf("no open channels with peer %x",
+				scope.PeerPubkey)
+		}
+
+	// Otherwise, we're targeting all open channels of a channel group.
+	case *lnrpc.PolicyUpdateRequest_ChannelGroup:
+		if scope.ChannelGroup == "" {
+			return nil, fmt.Errorf("channel group must be set")
+		}
+
+		chanPoints, err := r.server.chanStateDB.FetchChannelsByTag(
+			scope.ChannelGroup,
+		)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		peer, err := r.server.graphDB.FetchLightningNode(nil, vertex)
+		// The tags of closed channels are retained, so we'll only
+		// target the ones that are still open.
+		channels, err := r.server.chanStateDB.FetchAllOpenChannels()
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		// Cache the peer alias.
-		chanToPeerAlias[chanID] = peer.Alias
+		openChans := make(map[wire.OutPoint]struct{}, len(channels))
+		for _, channel := range channels {
+			openChans[channel.FundingOutpoint] = struct{}{}
+		}
 
-		return peer.Alias, nil
+		for _, chanPoint := range chanPoints {
+			if _, ok := openChans[chanPoint]; ok {
+				targetChans = append(targetChans, chanPoint)
+			}
+		}
+
+		// An empty set of target channels would result in a global
+		// update, so we'll bail out instead.
+		if len(targetChans) == 0 {
+			return nil, fmt.Errorf("no open channels in channel "+
+				"group %v", scope.ChannelGroup)
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown scope: %v", scope)
 	}
 
-	// TODO(roasbeef): add settlement latency?
-	//  * use FPE on all records?
+	var feeRateFixed uint32
 
-	// With the events retrieved, we'll now map them into the proper proto
-	// response.
-	//
-	// TODO(roasbeef): show in ns for the outside?
-	fwdingEvents := make(
+	switch {
+	// The request should use either the fee rate in percent, or the new
+	// ppm rate, but not both.
+	case req.FeeRate != 0 && req.FeeRatePpm != 0:
+		errMsg := "cannot set both FeeRate and FeeRatePpm at the " +
+			"same time"
+
+		return nil, status.Errorf(codes.InvalidArgument, errMsg)
+
+	// If the request is using fee_rate.
+	case req.FeeRate != 0:
+		// As a sanity check, if the fee isn't zero, we'll ensure that
+		// the passed fee rate is below 1e-6, or the lowest allowed
+		// non-zero fee rate expressible within the protocol.
+		if req.FeeRate != 0 && req.FeeRate < minFeeRate {
+			return nil, fmt.Errorf("fee rate of %v is too "+
+				"small, min fee rate is %v", req.FeeRate,
+				minFeeRate)
+		}
+
+		// We'll also need to convert the floating point fee rate we
+		// accept over RPC to the fixed point rate that we use within
+		// the protocol. We do this by multiplying the passed fee rate
+		// by the fee base. This gives us the fixed point, scaled by 1
+		// million that's used within the protocol.
+		//
+		// Because of the inaccurate precision of the IEEE 754
+		// standard, we need to round the product of feerate and
+		// feebase.
+		feeRateFixed = uint32(math.Round(req.FeeRate * feeBase))
+
+	// Otherwise, we use the fee_rate_ppm parameter.
+	case req.FeeRatePpm != 0:
+		feeRateFixed = req.FeeRatePpm
+	}
+
+	// We'll also ensure that the user isn't setting a CLTV delta that
+	// won't give outgoing HTLCs enough time to fully resolve if needed.
+	if req.TimeLockDelta < minTimeLockDelta {
+		return nil, fmt.Errorf("time lock delta of %v is too small, "+
+			"minimum supported is %v", req.TimeLockDelta,
+			minTimeLockDelta)
+	} else if req.TimeLockDelta > uint32(MaxTimeLockDelta) {
+		return nil, fmt.Errorf("time lock delta of %v is too big, "+
+			"maximum supported is %v", req.TimeLockDelta,
+			MaxTimeLockDelta)
+	}
+
+	// By default, positive inbound fees are rejected.
+	if !r.cfg.AcceptPositiveInboundFees && req.InboundFee != nil {
+		if req.InboundFee.BaseFeeMsat > 0 {
+			return nil, fmt.Errorf("positive values for inbound "+
+				"base fee msat are not supported: %v",
+				req.InboundFee.BaseFeeMsat)
+		}
+		if req.InboundFee.FeeRatePpm > 0 {
+			return nil, fmt.Errorf("positive values for inbound "+
+				"fee rate ppm are not supported: %v",
+				req.InboundFee.FeeRatePpm)
+		}
+	}
+
+	// If no inbound fees have been specified, we indicate with an empty
+	// option that the previous inbound fee should be retained during the
+	// edge update.
+	inboundFee := fn.None[models.InboundFee]()
+	if req.InboundFee != nil {
+		inboundFee = fn.Some(models.InboundFee{
+			Base: req.InboundFee.BaseFeeMsat,
+			Rate: req.InboundFee.FeeRatePpm,
+		})
+	}
+
+	baseFeeMsat := lnwire.MilliSatoshi(req.BaseFeeMsat)
+	feeSchema := routing.FeeSchema{
+		BaseFee:    baseFeeMsat,
+		FeeRate:    feeRateFixed,
+		InboundFee: inboundFee,
+	}
+
+	maxHtlc := lnwire.MilliSatoshi(req.MaxHtlcMsat)
+	var minHtlc *lnwire.MilliSatoshi
+	if req.MinHtlcMsatSpecified {
+		min := lnwire.MilliSatoshi(req.MinHtlcMsat)
+		minHtlc = &min
+	}
+
+	chanPolicy := routing.ChannelPolicy{
+		FeeSchema:     feeSchema,
+		TimeLockDelta: req.TimeLockDelta,
+		MaxHTLC:       maxHtlc,
+		MinHTLC:       minHtlc,
+	}
+
+	if req.DustExposure != nil {
+		err := r.updateDustExposurePolicies(
+			req.DustExposure, targetChans,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rpcsLog.Debugf("[updatechanpolicy] updating channel policy "+
+		"base_fee=%v, rate_fixed=%v, time_lock_delta: %v, "+
+		"min_htlc=%v, max_htlc=%v, targets=%v",
+		req.BaseFeeMsat, feeRateFixed, req.TimeLockDelta,
+		minHtlc, maxHtlc,
+		spew.Sdump(targetChans))
+
+	// If a ramp duration is set, the policy scheduler gradually moves the
+	// fees of the target channels towards the new ones.
+	if req.RampDurationSeconds > 0 {
+		rampDuration := time.Duration(req.RampDurationSeconds) *
+			time.Second
+
+		err := r.schedulePolicyRamp(
+			chanPolicy, rampDuration, targetChans,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &lnrpc.PolicyUpdateResponse{}, nil
+	}
+
+	// Otherwise, this update supersedes any ramp that is still in progress
+	// for the target channels.
+	r.server.policyScheduler.Cancel(targetChans...)
+
+	// With the scope resolved, we'll now send this to the local channel
+	// manager so it can propagate the new policy for our target channel(s).
+	failedUpdates, err := r.server.localChanMgr.UpdatePolicy(chanPolicy,
+		targetChans...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.PolicyUpdateResponse{
+		FailedUpdates: failedUpdates,
+	}, nil
+}
+
+// updateDustExposurePolicies stores the given dust exposure policy for the
+// target channels, or all open channels if no target channels are given, and
+// applies it to the switch.
+func (r *rpcServer) updateDustExposurePolicies(
+	rpcPolicy *lnrpc.DustExposurePolicy, targetChans []wire.OutPoint) error {
+
+	if rpcPolicy.MaxDustHtlcExposureMsat != 0 &&
+		rpcPolicy.FeeRateMultiplier != 0 {
+
+		return status.Error(codes.InvalidArgument, "cannot set both "+
+			"max_dust_htlc_exposure_msat and fee_rate_multiplier")
+	}
+
+	policy := channeldb.DustExposurePolicy{
+		MaxExposure: lnwire.MilliSatoshi(
+			rpcPolicy.MaxDustHtlcExposureMsat,
+		),
+		FeeRateMultiplier: rpcPolicy.FeeRateMultiplier,
+	}
+
+	if len(targetChans) == 0 {
+		channels, err := r.server.chanStateDB.FetchAllOpenChannels()
+		if err != nil {
+			return err
+		}
+
+		for _, channel := range channels {
+			targetChans = append(
+				targetChans, channel.FundingOutpoint,
+			)
+		}
+	}
+
+	policies := make(
+		map[wire.OutPoint]channeldb.DustExposurePolicy, len(targetChans),
+	)
+	for _, chanPoint := range targetChans {
+		// Policies can only be set for channels that we know of.
+		_, err := r.server.chanStateDB.FetchChannel(nil, chanPoint)
+		if err != nil {
+			return fmt.Errorf("unable to find channel %v: %w",
+				chanPoint, err)
+		}
+
+		err = r.server.chanStateDB.SetDustExposurePolicy(
+			chanPoint, policy,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to store dust exposure "+
+				"policy of %v: %w", chanPoint, err)
+		}
+
+		policies[chanPoint] = policy
+	}
+
+	rpcsLog.Infof("Updated dust exposure policy of %d channels: %+v",
+		len(policies), policy)
+
+	r.server.htlcSwitch.UpdateDustExposurePolicies(policies)
+
+	return nil
+}
+
+// schedulePolicyRamp schedules a gradual update of the fees of the target
+// channels, or all open channels if no target channels are given, towards the
+// fees of the given policy. The remaining fields of the policy are applied with
+// every step of the ramp.
+func (r *rpcServer) schedulePolicyRamp(chanPolicy routing.ChannelPolicy,
+	rampDuration time.Duration, targetChans []wire.OutPoint) error {
+
+	if len(targetChans) == 0 {
+		channels, err := r.server.chanStateDB.FetchAllOpenChannels()
+		if err != nil {
+			return err
+		}
+
+		for _, channel := range channels {
+			targetChans = append(
+				targetChans, channel.FundingOutpoint,
+			)
+		}
+	}
+
+	applyFees := func(chanPoint wire.OutPoint,
+		fees netann.FeePolicy) error {
+
+		policy := chanPolicy
+		policy.BaseFee = fees.BaseFee
+		policy.FeeRate = fees.FeeRate
+
+		failedUpdates, err := r.server.localChanMgr.UpdatePolicy(
+			policy, chanPoint,
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(failedUpdates) > 0 {
+			return fmt.Errorf("policy update failed: %v",
+				failedUpdates[0].UpdateError)
+		}
+
+		return nil
+	}
+
+	return r.server.policyScheduler.Schedule(&netann.PolicyRamp{
+		ChanPoints: targetChans,
+		Target: netann.FeePolicy{
+			BaseFee: chanPolicy.BaseFee,
+			FeeRate: chanPolicy.FeeRate,
+		},
+		Duration:  rampDuration,
+		ApplyFees: applyFees,
+	})
+}
+
+// UpdateChannelConstraints sets the limits for the HTLCs that are forwarded
+// over a channel. Limits that are set to zero aren't enforced.
+func (r *rpcServer) UpdateChannelConstraints(_ context.Context,
+	req *lnrpc.UpdateChannelConstraintsRequest) (
+	*lnrpc.UpdateChannelConstraintsResponse, error) {
+
+	if req.ChanPoint == nil {
+		return nil, fmt.Errorf("chan_point must be set")
+	}
+
+	txid, err := lnrpc.GetChanPointFundingTxid(req.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: req.ChanPoint.OutputIndex,
+	}
+
+	if (req.HtlcRateLimit == 0) != (req.HtlcRateIntervalSeconds == 0) {
+		return nil, fmt.Errorf("htlc_rate_limit and " +
+			"htlc_rate_interval_seconds must be set together")
+	}
+
+	// Limits can only be set for channels that we know of.
+	_, err = r.server.chanStateDB.FetchChannel(nil, chanPoint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find channel %v: %w",
+			chanPoint, err)
+	}
+
+	rateInterval := time.Duration(req.HtlcRateIntervalSeconds) * time.Second
+	limits := channeldb.ForwardingLimits{
+		MaxHtlcs:     req.MaxForwardedHtlcs,
+		MaxInFlight:  lnwire.MilliSatoshi(req.MaxForwardedInFlightMsat),
+		RateLimit:    req.HtlcRateLimit,
+		RateInterval: rateInterval,
+	}
+
+	err = r.server.chanStateDB.SetForwardingLimits(chanPoint, limits)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store forwarding limits: %w",
+			err)
+	}
+
+	rpcsLog.Infof("Updated forwarding limits of ChannelPoint(%v): %+v",
+		chanPoint, limits)
+
+	r.server.htlcSwitch.UpdateForwardingLimits(
+		map[wire.OutPoint]channeldb.ForwardingLimits{
+			chanPoint: limits,
+		},
+	)
+
+	return &lnrpc.UpdateChannelConstraintsResponse{}, nil
+}
+
+// UpdateChannelTags adds tags to or removes tags from a channel, which allows
+// operators to organize their channels in named groups.
+func (r *rpcServer) UpdateChannelTags(_ context.Context,
+	req *lnrpc.UpdateChannelTagsRequest) (*lnrpc.UpdateChannelTagsResponse,
+	error) {
+
+	if req.ChanPoint == nil {
+		return nil, fmt.Errorf("chan_point must be set")
+	}
+
+	txid, err := lnrpc.GetChanPointFundingTxid(req.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: req.ChanPoint.OutputIndex,
+	}
+
+	// Tags can only be set for channels that we know of. As the tags are
+	// retained after a channel is closed, closed channels can be tagged
+	// as well.
+	_, err = r.server.chanStateDB.FetchChannel(nil, chanPoint)
+	if errors.Is(err, channeldb.ErrChannelNotFound) {
+		_, err = r.server.chanStateDB.FetchClosedChannel(&chanPoint)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to find channel %v: %w",
+			chanPoint, err)
+	}
+
+	tags, err := r.server.chanStateDB.UpdateChannelTags(
+		chanPoint, req.AddTags, req.RemoveTags, req.Replace,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update channel tags: %w",
+			err)
+	}
+
+	rpcsLog.Infof("Updated tags of ChannelPoint(%v): %v", chanPoint, tags)
+
+	return &lnrpc.UpdateChannelTagsResponse{
+		Tags: tags,
+	}, nil
+}
+
+// fetchTaggedChanIDs returns the short channel IDs of all open and closed
+// channels that are tagged with the given channel tag.
+func (r *rpcServer) fetchTaggedChanIDs(
+	tag string) (map[lnwire.ShortChannelID]struct{}, error) {
+
+	chanPoints, err := r.server.chanStateDB.FetchChannelsByTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := make(map[wire.OutPoint]struct{}, len(chanPoints))
+	for _, chanPoint := range chanPoints {
+		tagged[chanPoint] = struct{}{}
+	}
+
+	chanIDs := make(map[lnwire.ShortChannelID]struct{})
+	openChannels, err := r.server.chanStateDB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range openChannels {
+		if _, ok := tagged[channel.FundingOutpoint]; !ok {
+			continue
+		}
+
+		chanIDs[channel.ShortChanID()] = struct{}{}
+
+		// Forwards over a confirmed zero-conf channel may refer to it
+		// by its alias or by its confirmed short channel ID.
+		if channel.IsZeroConf() && channel.ZeroConfConfirmed() {
+			chanIDs[channel.ZeroConfRealScid()] = struct{}{}
+		}
+	}
+
+	closedChannels, err := r.server.chanStateDB.FetchClosedChannels(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range closedChannels {
+		if _, ok := tagged[summary.ChanPoint]; ok {
+			chanIDs[summary.ShortChanID] = struct{}{}
+		}
+	}
+
+	return chanIDs, nil
+}
+
+// SetAnchorSweepPolicy sets the policy that overrides the deadline and the
+// budget of the CPFP of a channel's commitment transaction, either for a
+// single channel or for all channels with a peer.
+func (r *rpcServer) SetAnchorSweepPolicy(_ context.Context,
+	req *lnrpc.SetAnchorSweepPolicyRequest) (
+	*lnrpc.SetAnchorSweepPolicyResponse, error) {
+
+	policy, err := unmarshalAnchorSweepPolicy(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	chanStateDB := r.server.chanStateDB
+	switch target := req.Target.(type) {
+	case *lnrpc.SetAnchorSweepPolicyRequest_ChanPoint:
+		txid, err := lnrpc.GetChanPointFundingTxid(target.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+		chanPoint := wire.OutPoint{
+			Hash:  *txid,
+			Index: target.ChanPoint.OutputIndex,
+		}
+
+		err = chanStateDB.SetChannelAnchorSweepPolicy(chanPoint, policy)
+		if err != nil {
+			return nil, fmt.Errorf("unable to store anchor sweep "+
+				"policy: %w", err)
+		}
+
+		rpcsLog.Infof("Updated anchor sweep policy of "+
+			"ChannelPoint(%v): %+v", chanPoint, policy)
+
+	case *lnrpc.SetAnchorSweepPolicyRequest_Peer:
+		peer, err := route.NewVertexFromBytes(target.Peer)
+		if err != nil {
+			return nil, err
+		}
+
+		err = chanStateDB.SetPeerAnchorSweepPolicy(peer, policy)
+		if err != nil {
+			return nil, fmt.Errorf("unable to store anchor sweep "+
+				"policy: %w", err)
+		}
+
+		rpcsLog.Infof("Updated anchor sweep policy of peer %v: %+v",
+			peer, policy)
+
+	default:
+		return nil, fmt.Errorf("either chan_point or peer must be set")
+	}
+
+	return &lnrpc.SetAnchorSweepPolicyResponse{}, nil
+}
+
+// ListAnchorSweepPolicies returns the anchor sweep policies of all channels
+// and peers that have one.
+func (r *rpcServer) ListAnchorSweepPolicies(_ context.Context,
+	_ *lnrpc.ListAnchorSweepPoliciesRequest) (
+	*lnrpc.ListAnchorSweepPoliciesResponse, error) {
+
+	chanPolicies, peerPolicies, err :=
+		r.server.chanStateDB.FetchAllAnchorSweepPolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListAnchorSweepPoliciesResponse{
+		ChannelPolicies: make(
+			map[string]*lnrpc.AnchorSweepPolicy, len(chanPolicies),
+		),
+		PeerPolicies: make(
+			map[string]*lnrpc.AnchorSweepPolicy, len(peerPolicies),
+		),
+	}
+	for chanPoint, policy := range chanPolicies {
+		resp.ChannelPolicies[chanPoint.String()] =
+			marshalAnchorSweepPolicy(policy)
+	}
+	for peer, policy := range peerPolicies {
+		resp.PeerPolicies[peer.String()] =
+			marshalAnchorSweepPolicy(policy)
+	}
+
+	return resp, nil
+}
+
+// unmarshalAnchorSweepPolicy validates the given rpc anchor sweep policy and
+// converts it to the policy that is stored in the database. A nil policy
+// results in an empty policy.
+func unmarshalAnchorSweepPolicy(
+	rpcPolicy *lnrpc.AnchorSweepPolicy) (channeldb.AnchorSweepPolicy,
+	error) {
+
+	if rpcPolicy == nil {
+		return channeldb.AnchorSweepPolicy{}, nil
+	}
+
+	ratio := rpcPolicy.BudgetRatio
+	if ratio != 0 && (ratio < contractcourt.MinBudgetRatio || ratio > 1) {
+		return channeldb.AnchorSweepPolicy{}, fmt.Errorf("budget "+
+			"ratio must be between %v and 1",
+			contractcourt.MinBudgetRatio)
+	}
+
+	maxBudget := btcutil.Amount(rpcPolicy.MaxBudgetSat)
+	if maxBudget != 0 && maxBudget < contractcourt.MinBudgetValue {
+		return channeldb.AnchorSweepPolicy{}, fmt.Errorf("max budget "+
+			"must be at least %v", contractcourt.MinBudgetValue)
+	}
+
+	if rpcPolicy.MinValueAtStakeSat < 0 {
+		return channeldb.AnchorSweepPolicy{}, fmt.Errorf("min value " +
+			"at stake must not be negative")
+	}
+
+	return channeldb.AnchorSweepPolicy{
+		MaxDeadline:     rpcPolicy.MaxDeadlineBlocks,
+		BudgetRatio:     ratio,
+		MaxBudget:       maxBudget,
+		MinValueAtStake: btcutil.Amount(rpcPolicy.MinValueAtStakeSat),
+	}, nil
+}
+
+// marshalAnchorSweepPolicy converts the given anchor sweep policy to its rpc
+// representation.
+func marshalAnchorSweepPolicy(
+	policy channeldb.AnchorSweepPolicy) *lnrpc.AnchorSweepPolicy {
+
+	return &lnrpc.AnchorSweepPolicy{
+		MaxDeadlineBlocks:  policy.MaxDeadline,
+		BudgetRatio:        policy.BudgetRatio,
+		MaxBudgetSat:       int64(policy.MaxBudget),
+		MinValueAtStakeSat: int64(policy.MinValueAtStake),
+	}
+}
+
+// ForwardingHistory allows the caller to query the htlcswitch for a record of
+// all HTLC's forwarded within the target time range, and integer offset within
+// that time range. If no time-range is specified, then the first chunk of the
+// past 24 hrs of forwarding history are returned.
+
+// A list of forwarding events are returned. The size of each forwarding event
+// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
+// In order to safely stay under this max limit, we'll return 50k events per
+// response.  Each response has the index offset of the last entry. The index
+// offset can be provided to the request to allow the caller to skip a series
+// of records.
+func (r *rpcServer) ForwardingHistory(ctx context.Context,
+	req *lnrpc.ForwardingHistoryRequest) (*lnrpc.ForwardingHistoryResponse,
+	error) {
+
+	// Before we perform the queries below, we'll instruct the switch to
+	// flush any pending events to disk. This ensure we get a complete
+	// snapshot at this particular time.
+	if err := r.server.htlcSwitch.FlushForwardingEvents(); err != nil {
+		return nil, fmt.Errorf("unable to flush forwarding "+
+			"events: %v", err)
+	}
+
+	var (
+		startTime, endTime time.Time
+
+		numEvents uint32
+	)
+
+	// startTime defaults to the Unix epoch (0 unixtime, or
+	// midnight 01-01-1970).
+	startTime = time.Unix(int64(req.StartTime), 0)
+
+	// If the end time wasn't specified, assume a default end time of now.
+	if req.EndTime == 0 {
+		now := time.Now()
+		endTime = now
+	} else {
+		endTime = time.Unix(int64(req.EndTime), 0)
+	}
+
+	// If the number of events wasn't specified, then we'll default to
+	// returning the last 100 events.
+	numEvents = req.NumMaxEvents
+	if numEvents == 0 {
+		numEvents = 100
+	}
+
+	// Next, we'll map the proto request into a format that is understood by
+	// the forwarding log.
+	eventQuery := channeldb.ForwardingEventQuery{
+		StartTime:       startTime,
+		EndTime:         endTime,
+		IndexOffset:     req.IndexOffset,
+		NumMaxEvents:    numEvents,
+		IncludeFailures: req.IncludeFailures,
+	}
+
+	// If the request is restricted to a channel group, we'll only return
+	// the events of the channels tagged with it.
+	if req.Tag != "" {
+		chanIDs, err := r.fetchTaggedChanIDs(req.Tag)
+		if err != nil {
+			return nil, err
+		}
+
+		// A query without any channels in the filter would return
+		// the events of all channels.
+		if len(chanIDs) == 0 {
+			return &lnrpc.ForwardingHistoryResponse{
+				LastOffsetIndex: req.IndexOffset,
+			}, nil
+		}
+
+		eventQuery.ChanIDs = chanIDs
+	}
+	timeSlice, err := r.server.fwdingLog.Query(eventQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query forwarding log: %w",
+			err)
+	}
+
+	// chanToPeerAlias caches previously looked up channel information.
+	chanToPeerAlias := make(map[lnwire.ShortChannelID]string)
+
+	// Helper function to extract a peer's node alias given its SCID.
+	getRemoteAlias := func(chanID lnwire.ShortChannelID) (string, error) {
+		// If we'd previously seen this chanID then return the cached
+		// peer alias.
+		if peerAlias, ok := chanToPeerAlias[chanID]; ok {
+			return peerAlias, nil
+		}
+
+		// Else call the server to look up the peer alias.
+		edge, err := r.GetChanInfo(ctx, &lnrpc.ChanInfoRequest{
+			ChanId: chanID.ToUint64(),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		remotePub := edge.Node1Pub
+		if r.selfNode.String() == edge.Node1Pub {
+			remotePub = edge.Node2Pub
+		}
+
+		vertex, err := route.NewVertexFromStr(remotePub)
+		if err != nil {
+			return "", err
+		}
+
+		peer, err := r.server.graphDB.FetchLightningNode(nil, vertex)
+		if err != nil {
+			return "", err
+		}
+
+		// Cache the peer alias.
+		chanToPeerAlias[chanID] = peer.Alias
+
+		return peer.Alias, nil
+	}
+
+	// TODO(roasbeef): add settlement latency?
+	//  * use FPE on all records?
+
+	// With the events retrieved, we'll now map them into the proper proto
+	// response.
+	//
+	// TODO(roasbeef): show in ns for the outside?
+	fwdingEvents := make(
 		[]*lnrpc.ForwardingEvent, len(timeSlice.ForwardingEvents),
 	)
-	resp := &lnrpc.ForwardingHistoryResponse{
-		ForwardingEvents: fwdingEvents,
-		LastOffsetIndex:  timeSlice.LastIndexOffset,
+	resp := &lnrpc.ForwardingHistoryResponse{
+		ForwardingEvents: fwdingEvents,
+		LastOffsetIndex:  timeSlice.LastIndexOffset,
+	}
+	for i, event := range timeSlice.ForwardingEvents {
+		amtInMsat := event.AmtIn
+		amtOutMsat := event.AmtOut
+		feeMsat := event.AmtIn - event.AmtOut
+
+		resp.ForwardingEvents[i] = &lnrpc.ForwardingEvent{
+			Timestamp:   uint64(event.Timestamp.Unix()),
+			TimestampNs: uint64(event.Timestamp.UnixNano()),
+			ChanIdIn:    event.IncomingChanID.ToUint64(),
+			ChanIdOut:   event.OutgoingChanID.ToUint64(),
+			AmtIn:       uint64(amtInMsat.ToSatoshis()),
+			AmtOut:      uint64(amtOutMsat.ToSatoshis()),
+			Fee:         uint64(feeMsat.ToSatoshis()),
+			FeeMsat:     uint64(feeMsat),
+			AmtInMsat:   uint64(amtInMsat),
+			AmtOutMsat:  uint64(amtOutMsat),
+			ExpiryIn:    event.IncomingExpiry,
+			ExpiryOut:   event.OutgoingExpiry,
+			Failed:      event.Failed,
+		}
+
+		// The effective fee rate is relative to the outgoing amount,
+		// like the proportional fee of a channel policy.
+		if amtOutMsat > 0 {
+			resp.ForwardingEvents[i].EffectiveFeePpm = uint64(
+				feeMsat * 1_000_000 / amtOutMsat,
+			)
+		}
+
+		if req.PeerAliasLookup {
+			aliasIn, err := getRemoteAlias(event.IncomingChanID)
+			if err != nil {
+				aliasIn = fmt.Sprintf("unable to lookup peer "+
+					"alias: %v", err)
+			}
+			aliasOut, err := getRemoteAlias(event.OutgoingChanID)
+			if err != nil {
+				aliasOut = fmt.Sprintf("unable to lookup peer"+
+					"alias: %v", err)
+			}
+			resp.ForwardingEvents[i].PeerAliasIn = aliasIn
+			resp.ForwardingEvents[i].PeerAliasOut = aliasOut
+		}
+	}
+
+	return resp, nil
+}
+
+// ExportChannelBackup attempts to return an encrypted static channel backup
+// for the target channel identified by it channel point. The backup is
+// encrypted with a key generated from the aezeed seed of the user. The
+// returned backup can either be restored using the RestoreChannelBackup method
+// once lnd is running, or via the InitWallet and UnlockWallet methods from the
+// WalletUnlocker service.
+func (r *rpcServer) ExportChannelBackup(ctx context.Context,
+	in *lnrpc.ExportChannelBackupRequest) (*lnrpc.ChannelBackup, error) {
+
+	// First, we'll convert the lnrpc channel point into a wire.OutPoint
+	// that we can manipulate.
+	txid, err := lnrpc.GetChanPointFundingTxid(in.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: in.ChanPoint.OutputIndex,
+	}
+
+	// Next, we'll attempt to fetch a channel backup for this channel from
+	// the database. If this channel has been closed, or the outpoint is
+	// unknown, then we'll return an error
+	unpackedBackup, err := chanbackup.FetchBackupForChan(
+		chanPoint, r.server.chanStateDB, r.server.addrSource,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// At this point, we have an unpacked backup (plaintext) so we'll now
+	// attempt to serialize and encrypt it in order to create a packed
+	// backup.
+	packedBackups, err := chanbackup.PackStaticChanBackups(
+		[]chanbackup.Single{*unpackedBackup},
+		r.server.cc.KeyRing,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("packing of back ups failed: %w", err)
+	}
+
+	// Before we proceed, we'll ensure that we received a backup for this
+	// channel, otherwise, we'll bail out.
+	packedBackup, ok := packedBackups[chanPoint]
+	if !ok {
+		return nil, fmt.Errorf("expected single backup for "+
+			"ChannelPoint(%v), got %v", chanPoint,
+			len(packedBackup))
+	}
+
+	return &lnrpc.ChannelBackup{
+		ChanPoint:  in.ChanPoint,
+		ChanBackup: packedBackup,
+	}, nil
+}
+
+// VerifyChanBackup allows a caller to verify the integrity of a channel backup
+// snapshot. This method will accept both either a packed Single or a packed
+// Multi. Specifying both will result in an error.
+func (r *rpcServer) VerifyChanBackup(ctx context.Context,
+	in *lnrpc.ChanBackupSnapshot) (*lnrpc.VerifyChanBackupResponse, error) {
+
+	switch {
+	// If neither a Single or Multi has been specified, then we have nothing
+	// to verify.
+	case in.GetSingleChanBackups() == nil && in.GetMultiChanBackup() == nil:
+		return nil, errors.New("either a Single or Multi channel " +
+			"backup must be specified")
+
+	// Either a Single or a Multi must be specified, but not both.
+	case in.GetSingleChanBackups() != nil && in.GetMultiChanBackup() != nil:
+		return nil, errors.New("either a Single or Multi channel " +
+			"backup must be specified, but not both")
+
+	// If a Single is specified then we'll only accept one of them to allow
+	// the caller to map the valid/invalid state for each individual Single.
+	case in.GetSingleChanBackups() != nil:
+		chanBackupsProtos := in.GetSingleChanBackups().ChanBackups
+		if len(chanBackupsProtos) != 1 {
+			return nil, errors.New("only one Single is accepted " +
+				"at a time")
+		}
+
+		// First, we'll convert the raw byte slice into a type we can
+		// work with a bit better.
+		chanBackup := chanbackup.PackedSingles(
+			[][]byte{chanBackupsProtos[0].ChanBackup},
+		)
+
+		// With our PackedSingles created, we'll attempt to unpack the
+		// backup. If this fails, then we know the backup is invalid for
+		// some reason.
+		_, err := chanBackup.Unpack(r.server.cc.KeyRing)
+		if err != nil {
+			return nil, fmt.Errorf("invalid single channel "+
+				"backup: %v", err)
+		}
+
+	case in.GetMultiChanBackup() != nil:
+		// We'll convert the raw byte slice into a PackedMulti that we
+		// can easily work with.
+		packedMultiBackup := in.GetMultiChanBackup().MultiChanBackup
+		packedMulti := chanbackup.PackedMulti(packedMultiBackup)
+
+		// We'll now attempt to unpack the Multi. If this fails, then we
+		// know it's invalid.
+		_, err := packedMulti.Unpack(r.server.cc.KeyRing)
+		if err != nil {
+			return nil, fmt.Errorf("invalid multi channel backup: "+
+				"%v", err)
+		}
+	}
+
+	return &lnrpc.VerifyChanBackupResponse{}, nil
+}
+
+// createBackupSnapshot converts the passed Single backup into a snapshot which
+// contains individual packed single backups, as well as a single packed multi
+// backup.
+func (r *rpcServer) createBackupSnapshot(backups []chanbackup.Single) (
+	*lnrpc.ChanBackupSnapshot, error) {
+
+	// Once we have the set of back ups, we'll attempt to pack them all
+	// into a series of single channel backups.
+	singleChanPackedBackups, err := chanbackup.PackStaticChanBackups(
+		backups, r.server.cc.KeyRing,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to pack set of chan "+
+			"backups: %v", err)
+	}
+
+	// Now that we have our set of single packed backups, we'll morph that
+	// into a form that the proto response requires.
+	numBackups := len(singleChanPackedBackups)
+	singleBackupResp := &lnrpc.ChannelBackups{
+		ChanBackups: make([]*lnrpc.ChannelBackup, 0, numBackups),
+	}
+	for chanPoint, singlePackedBackup := range singleChanPackedBackups {
+		txid := chanPoint.Hash
+		rpcChanPoint := &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+				FundingTxidBytes: txid[:],
+			},
+			OutputIndex: chanPoint.Index,
+		}
+
+		singleBackupResp.ChanBackups = append(
+			singleBackupResp.ChanBackups,
+			&lnrpc.ChannelBackup{
+				ChanPoint:  rpcChanPoint,
+				ChanBackup: singlePackedBackup,
+			},
+		)
+	}
+
+	// In addition, to the set of single chan backups, we'll also create a
+	// single multi-channel backup which can be serialized into a single
+	// file for safe storage.
+	var b bytes.Buffer
+	unpackedMultiBackup := chanbackup.Multi{
+		StaticBackups: backups,
+	}
+	err = unpackedMultiBackup.PackToWriter(&b, r.server.cc.KeyRing)
+	if err != nil {
+		return nil, fmt.Errorf("unable to multi-pack backups: %w", err)
+	}
+
+	multiBackupResp := &lnrpc.MultiChanBackup{
+		MultiChanBackup: b.Bytes(),
+	}
+	for _, singleBackup := range singleBackupResp.ChanBackups {
+		multiBackupResp.ChanPoints = append(
+			multiBackupResp.ChanPoints, singleBackup.ChanPoint,
+		)
+	}
+
+	return &lnrpc.ChanBackupSnapshot{
+		SingleChanBackups: singleBackupResp,
+		MultiChanBackup:   multiBackupResp,
+	}, nil
+}
+
+// ExportAllChannelBackups returns static channel backups for all existing
+// channels known to lnd. A set of regular singular static channel backups for
+// each channel are returned. Additionally, a multi-channel backup is returned
+// as well, which contains a single encrypted blob containing the backups of
+// each channel.
+func (r *rpcServer) ExportAllChannelBackups(ctx context.Context,
+	in *lnrpc.ChanBackupExportRequest) (*lnrpc.ChanBackupSnapshot, error) {
+
+	// First, we'll attempt to read back ups for ALL currently opened
+	// channels from disk.
+	allUnpackedBackups, err := chanbackup.FetchStaticChanBackups(
+		r.server.chanStateDB, r.server.addrSource,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch all static chan "+
+			"backups: %v", err)
+	}
+
+	// With the backups assembled, we'll create a full snapshot.
+	return r.createBackupSnapshot(allUnpackedBackups)
+}
+
+// RestoreChannelBackups accepts a set of singular channel backups, or a single
+// encrypted multi-chan backup and attempts to recover any funds remaining
+// within the channel. If we're able to unpack the backup, then the new channel
+// will be shown under listchannels, as well as pending channels. If requested,
+// only the selected channels are restored, or the channels contained in the
+// backup are only listed without restoring any of them.
+func (r *rpcServer) RestoreChannelBackups(ctx context.Context,
+	in *lnrpc.RestoreChanBackupRequest) (*lnrpc.RestoreBackupResponse, error) {
+
+	// The server hasn't yet started, so it won't be able to service any of
+	// our requests, so we'll bail early here.
+	if !r.server.Started() {
+		return nil, ErrServerNotActive
+	}
+
+	// First, we'll make our implementation of the
+	// chanbackup.ChannelRestorer interface which we'll use to properly
+	// restore either a set of chanbackup.Single or chanbackup.Multi
+	// backups.
+	chanRestorer := &chanDBRestorer{
+		db:         r.server.chanStateDB,
+		secretKeys: r.server.cc.KeyRing,
+		chainArb:   r.server.chainArb,
+	}
+
+	// We'll accept either a list of Single backups, or a single Multi
+	// backup which contains several single backups.
+	var backups []chanbackup.Single
+	switch {
+	case in.GetChanBackups() != nil:
+		chanBackupsProtos := in.GetChanBackups()
+
+		// Now that we know what type of backup we're working with,
+		// we'll parse them all out into a more suitable format.
+		packedBackups := make([][]byte, 0, len(chanBackupsProtos.ChanBackups))
+		for _, chanBackup := range chanBackupsProtos.ChanBackups {
+			packedBackups = append(
+				packedBackups, chanBackup.ChanBackup,
+			)
+		}
+
+		var err error
+		backups, err = chanbackup.PackedSingles(packedBackups).Unpack(
+			r.server.cc.KeyRing,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unpack single "+
+				"backups: %v", err)
+		}
+
+	case in.GetMultiChanBackup() != nil:
+		packedMulti := chanbackup.PackedMulti(in.GetMultiChanBackup())
+		multi, err := packedMulti.Unpack(r.server.cc.KeyRing)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unpack chan "+
+				"backup: %v", err)
+		}
+		backups = multi.StaticBackups
+	}
+
+	// If only some of the channels should be restored, we'll narrow down
+	// the backups to those channels.
+	chanPoints := make([]wire.OutPoint, 0, len(in.ChanPoints))
+	for _, chanPoint := range in.ChanPoints {
+		txid, err := lnrpc.GetChanPointFundingTxid(chanPoint)
+		if err != nil {
+			return nil, err
+		}
+		chanPoints = append(chanPoints, wire.OutPoint{
+			Hash:  *txid,
+			Index: chanPoint.OutputIndex,
+		})
+	}
+	selected, err := chanbackup.SelectSingles(backups, chanPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	isSelected := make(map[wire.OutPoint]bool, len(selected))
+	for _, backup := range selected {
+		isSelected[backup.FundingOutpoint] = true
+	}
+
+	resp := &lnrpc.RestoreBackupResponse{
+		Channels: make([]*lnrpc.ChannelBackupInfo, 0, len(backups)),
+	}
+	for _, backup := range backups {
+		info := marshallChannelBackupInfo(backup)
+		info.Selected = isSelected[backup.FundingOutpoint]
+		resp.Channels = append(resp.Channels, info)
+	}
+
+	// In a dry run, we only list the channels contained in the backup.
+	if in.DryRun {
+		return resp, nil
+	}
+
+	// With our backups obtained, we'll now restore them which will write
+	// the new backups to disk, and then attempt to connect out to any
+	// peers that we know of which were our prior channel peers.
+	err = chanbackup.Recover(selected, chanRestorer, r.server)
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore chan backups: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RequestForceCloseFromBackup attempts to recover the funds of channels that
+// were restored from a static channel backup or that lost their state, and
+// reports the recovery progress of each channel.
+func (r *rpcServer) RequestForceCloseFromBackup(_ context.Context,
+	in *lnrpc.RequestForceCloseFromBackupRequest) (
+	*lnrpc.RequestForceCloseFromBackupResponse, error) {
+
+	if !r.server.Started() {
+		return nil, ErrServerNotActive
+	}
+
+	chanPoints := make([]wire.OutPoint, 0, len(in.ChanPoints))
+	for _, chanPoint := range in.ChanPoints {
+		txid, err := lnrpc.GetChanPointFundingTxid(chanPoint)
+		if err != nil {
+			return nil, err
+		}
+		chanPoints = append(chanPoints, wire.OutPoint{
+			Hash:  *txid,
+			Index: chanPoint.OutputIndex,
+		})
+	}
+
+	// Without a selection, we'll recover all channels that lost their
+	// state, including the ones that were already closed so that their
+	// progress is reported.
+	if len(chanPoints) == 0 {
+		var err error
+		chanPoints, err = r.fetchChannelsWithoutState()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &lnrpc.RequestForceCloseFromBackupResponse{
+		Channels: make(
+			[]*lnrpc.ChannelRecoveryStatus, 0, len(chanPoints),
+		),
+	}
+	for _, chanPoint := range chanPoints {
+		status, err := r.rescueChannel(
+			chanPoint, in.PublishLocalCommitment, in.StatusOnly,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to recover "+
+				"ChannelPoint(%v): %w", chanPoint, err)
+		}
+
+		resp.Channels = append(resp.Channels, status)
+	}
+
+	return resp, nil
+}
+
+// fetchChannelsWithoutState returns the funding outpoints of all open and
+// closed channels that were restored from a backup or that lost their state.
+func (r *rpcServer) fetchChannelsWithoutState() ([]wire.OutPoint, error) {
+	lostState := func(channel *channeldb.OpenChannel) bool {
+		return channel.HasChanStatus(channeldb.ChanStatusRestored) ||
+			channel.HasChanStatus(channeldb.ChanStatusLocalDataLoss)
+	}
+
+	openChannels, err := r.server.chanStateDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	var chanPoints []wire.OutPoint
+	for _, channel := range openChannels {
+		if lostState(channel) {
+			chanPoints = append(chanPoints, channel.FundingOutpoint)
+		}
+	}
+
+	// The status of closed channels is kept in their historical record,
+	// which doesn't exist for channels that were closed by older
+	// versions.
+	closedChannels, err := r.server.chanStateDB.FetchClosedChannels(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range closedChannels {
+		channel, err := r.server.chanStateDB.FetchHistoricalChannel(
+			&summary.ChanPoint,
+		)
+		if err != nil {
+			continue
+		}
+
+		if lostState(channel) {
+			chanPoints = append(chanPoints, summary.ChanPoint)
+		}
+	}
+
+	return chanPoints, nil
+}
+
+// rescueChannel takes the next step to recover the funds of the given channel,
+// unless statusOnly is set, and returns its recovery status. Our latest
+// commitment is only published if publishLocal is set.
+func (r *rpcServer) rescueChannel(chanPoint wire.OutPoint, publishLocal,
+	statusOnly bool) (*lnrpc.ChannelRecoveryStatus, error) {
+
+	status := &lnrpc.ChannelRecoveryStatus{
+		ChannelPoint: chanPoint.String(),
+	}
+
+	channel, err := r.server.chanStateDB.FetchChannel(nil, chanPoint)
+
+	// If the channel isn't open anymore, it was closed on-chain, so we'll
+	// only report whether all of its outputs were resolved.
+	if errors.Is(err, channeldb.ErrChannelNotFound) {
+		summary, err := r.server.chanStateDB.FetchClosedChannel(
+			&chanPoint,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		status.RemotePubkey = hex.EncodeToString(
+			summary.RemotePub.SerializeCompressed(),
+		)
+		status.ClosingTxid = summary.ClosingTXID.String()
+
+		if summary.IsPending {
+			status.State = lnrpc.ChannelRecoveryStatus_CLOSING
+			status.Detail = "waiting for the closing transaction " +
+				"to confirm and our funds to be swept"
+
+			return status, nil
+		}
+
+		status.State = lnrpc.ChannelRecoveryStatus_RECOVERED
+		status.Detail = "channel is fully closed"
+
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remotePub := channel.IdentityPub
+	status.RemotePubkey = hex.EncodeToString(
+		remotePub.SerializeCompressed(),
+	)
+
+	// If we already published a closing transaction, we're only waiting
+	// for it to confirm.
+	closingTx, err := channel.BroadcastedCommitment()
+	if errors.Is(err, channeldb.ErrNoCloseTx) {
+		closingTx, err = channel.BroadcastedCooperative()
+	}
+	switch {
+	case err == nil && closingTx != nil:
+		status.State = lnrpc.ChannelRecoveryStatus_CLOSING
+		status.ClosingTxid = closingTx.TxHash().String()
+		status.Detail = "waiting for the closing transaction to " +
+			"confirm"
+
+		return status, nil
+
+	case err != nil && !errors.Is(err, channeldb.ErrNoCloseTx):
+		return nil, err
+	}
+
+	// If we still have the full state of the channel, we can publish our
+	// latest commitment ourselves.
+	if !channel.HasChanStatus(channeldb.ChanStatusRestored) &&
+		!channel.HasChanStatus(channeldb.ChanStatusLocalDataLoss) {
+
+		if statusOnly || !publishLocal {
+			status.State = lnrpc.
+				ChannelRecoveryStatus_LOCAL_COMMITMENT_AVAILABLE
+			status.Detail = "our latest commitment can be published"
+
+			return status, nil
+		}
+
+		closingTx, err := r.forceCloseChannel(
+			channel, contractcourt.ForceCloseFeePreference{},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		status.State = lnrpc.ChannelRecoveryStatus_CLOSING
+		status.ClosingTxid = closingTx.TxHash().String()
+		status.Detail = "published our latest commitment"
+
+		return status, nil
+	}
+
+	// Otherwise, only the peer can publish its commitment. If it isn't
+	// connected, we'll make sure that we keep trying to connect to it. On
+	// connection, our channel reestablish message asks it to force close
+	// the channel.
+	peer, err := r.server.FindPeer(remotePub)
+	if err != nil {
+		status.State = lnrpc.ChannelRecoveryStatus_WAITING_FOR_PEER
+		status.Detail = "peer is not connected"
+		if statusOnly {
+			return status, nil
+		}
+
+		addrs, err := r.server.addrSource.AddrsForNode(remotePub)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			status.Detail = "peer is not connected and has no " +
+				"known addresses"
+
+			return status, nil
+		}
+
+		for _, addr := range addrs {
+			netAddr := &lnwire.NetAddress{
+				IdentityKey: remotePub,
+				Address:     addr,
+			}
+			err := r.server.ConnectToPeer(
+				netAddr, true, r.cfg.ConnectionTimeout,
+			)
+			if err != nil {
+				rpcsLog.Warnf("Unable to connect to %v to "+
+					"recover ChannelPoint(%v): %v", netAddr,
+					chanPoint, err)
+			}
+		}
+		status.Detail = "peer is not connected, connecting to it"
+
+		return status, nil
+	}
+
+	status.State = lnrpc.ChannelRecoveryStatus_FORCE_CLOSE_REQUESTED
+	status.Detail = "waiting for the peer to publish its commitment"
+	if statusOnly {
+		return status, nil
+	}
+
+	// As an alternative to the data loss protection protocol, which some
+	// peers don't act upon, we'll resend our channel reestablish message
+	// together with an error, which peers are required to respond to by
+	// failing the channel.
+	chanSync, err := channel.ChanSyncMsg()
+	if err != nil {
+		return nil, err
+	}
+	errMsg := &lnwire.Error{
+		ChanID: lnwire.NewChanIDFromOutPoint(chanPoint),
+		Data: lnwire.ErrorData("channel state lost, please force " +
+			"close the channel"),
+	}
+	if err := peer.SendMessage(false, chanSync, errMsg); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("Requested peer %x to force close ChannelPoint(%v)",
+		remotePub.SerializeCompressed(), chanPoint)
+
+	return status, nil
+}
+
+// parseCloseOutputs decodes the additional outputs that our settled balance is
+// split across in a cooperative close.
+func (r *rpcServer) parseCloseOutputs(
+	rpcOutputs []*lnrpc.CloseOutput) (lnwire.CloseOutputs, error) {
+
+	if len(rpcOutputs) > lnwire.MaxCloseOutputs {
+		return nil, fmt.Errorf("at most %d close outputs can be "+
+			"specified", lnwire.MaxCloseOutputs)
+	}
+
+	params := r.cfg.ActiveNetParams.Params
+	closeOutputs := make(lnwire.CloseOutputs, 0, len(rpcOutputs))
+	for _, rpcOutput := range rpcOutputs {
+		if rpcOutput.AmountSat <= 0 {
+			return nil, fmt.Errorf("close output to %v must have "+
+				"a positive amount", rpcOutput.Address)
+		}
+
+		addr, err := btcutil.DecodeAddress(rpcOutput.Address, params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid close output address: "+
+				"%v", err)
+		}
+
+		if !addr.IsForNet(params) {
+			return nil, fmt.Errorf("close output address is not "+
+				"for %s", params.Name)
+		}
+
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		closeOutputs = append(closeOutputs, &wire.TxOut{
+			Value:    rpcOutput.AmountSat,
+			PkScript: pkScript,
+		})
 	}
-	for i, event := range timeSlice.ForwardingEvents {
-		amtInMsat := event.AmtIn
-		amtOutMsat := event.AmtOut
-		feeMsat := event.AmtIn - event.AmtOut
 
-		resp.ForwardingEvents[i] = &lnrpc.ForwardingEvent{
-			Timestamp:   uint64(event.Timestamp.Unix()),
-			TimestampNs: uint64(event.Timestamp.UnixNano()),
-			ChanIdIn:    event.IncomingChanID.ToUint64(),
-			ChanIdOut:   event.OutgoingChanID.ToUint64(),
-			AmtIn:       uint64(amtInMsat.ToSatoshis()),
-			AmtOut:      uint64(amtOutMsat.ToSatoshis()),
-			Fee:         uint64(feeMsat.ToSatoshis()),
-			FeeMsat:     uint64(feeMsat),
-			AmtInMsat:   uint64(amtInMsat),
-			AmtOutMsat:  uint64(amtOutMsat),
+	return closeOutputs, nil
+}
+
+// forceCloseFeePreference returns the fee preference that is used to CPFP the
+// commitment of a force closed channel based on the fee related fields of the
+// close request. If none of them are set, the deadline and budget derived from
+// the HTLCs at stake are used.
+func (r *rpcServer) forceCloseFeePreference(in *lnrpc.CloseChannelRequest,
+	channel *channeldb.OpenChannel) (contractcourt.ForceCloseFeePreference,
+	error) {
+
+	var feePref contractcourt.ForceCloseFeePreference
+
+	satPerByte := uint64(in.SatPerByte) // nolint:staticcheck
+	if satPerByte == 0 && in.SatPerVbyte == 0 && in.TargetConf == 0 {
+		return feePref, nil
+	}
+
+	// Without anchors, the fee of the commitment transaction can't be
+	// raised, so it's always confirmed using its pre-defined fee.
+	if !channel.ChanType.HasAnchors() {
+		return feePref, fmt.Errorf("force closing a channel without " +
+			"anchors uses a pre-defined fee")
+	}
+
+	if in.TargetConf < 0 {
+		return feePref, fmt.Errorf("target_conf must not be negative")
+	}
+
+	// The confirmation target is used as the deadline of the CPFP, so we
+	// fall back to the default one if only a fee rate is specified.
+	feePref.ConfTarget = uint32(in.TargetConf)
+	if feePref.ConfTarget == 0 {
+		feePref.ConfTarget = defaultNumBlocksEstimate
+	}
+
+	// A manual fee rate takes precedence over the fee rate estimated for
+	// the confirmation target.
+	var err error
+	if satPerByte != 0 || in.SatPerVbyte != 0 {
+		feePref.FeeRate, err = lnrpc.CalculateFeeRate(
+			satPerByte, in.SatPerVbyte, 0, r.server.cc.FeeEstimator,
+		)
+	} else {
+		feePref.FeeRate, err = lnrpc.CalculateFeeRate(
+			0, 0, feePref.ConfTarget, r.server.cc.FeeEstimator,
+		)
+	}
+	if err != nil {
+		return feePref, err
+	}
+
+	if in.MaxFeePerVbyte != 0 {
+		feePref.MaxFeeRate = chainfee.SatPerKVByte(
+			in.MaxFeePerVbyte * 1000,
+		).FeePerKWeight()
+
+		if feePref.MaxFeeRate < feePref.FeeRate {
+			return feePref, fmt.Errorf("max_fee_per_vbyte must "+
+				"not be below the fee rate of %v sat/vbyte",
+				feePref.FeeRate.FeePerVByte())
 		}
+	}
 
-		if req.PeerAliasLookup {
-			aliasIn, err := getRemoteAlias(event.IncomingChanID)
+	rpcsLog.Debugf("[closechannel] force closing ChannelPoint(%v) with "+
+		"conf_target=%v, fee_rate=%v, max_fee_rate=%v",
+		channel.FundingOutpoint, feePref.ConfTarget, feePref.FeeRate,
+		feePref.MaxFeeRate)
+
+	return feePref, nil
+}
+
+// forceCloseChannel removes the channel from the switch and publishes our
+// latest commitment, which is returned. The fee preference determines how
+// urgently the commitment is CPFPed using its anchor.
+func (r *rpcServer) forceCloseChannel(channel *channeldb.OpenChannel,
+	feePref contractcourt.ForceCloseFeePreference) (*wire.MsgTx, error) {
+
+	// As we're force closing this channel, as a precaution, we'll ensure
+	// that the switch doesn't continue to see this channel as eligible
+	// for forwarding HTLC's. If the peer is online, then we'll also purge
+	// all of its indexes.
+	remotePub := channel.IdentityPub
+	if peer, err := r.server.FindPeer(remotePub); err == nil {
+		// TODO(roasbeef): actually get the active channel instead too?
+		//  * so only need to grab from database
+		peer.WipeChannel(&channel.FundingOutpoint)
+	} else {
+		chanID := lnwire.NewChanIDFromOutPoint(channel.FundingOutpoint)
+		r.server.htlcSwitch.RemoveLink(chanID)
+	}
+
+	// With the necessary indexes cleaned up, we'll now force close the
+	// channel.
+	return r.server.chainArb.ForceCloseContract(
+		channel.FundingOutpoint, feePref,
+	)
+}
+
+// marshallChannelBackupInfo returns the RPC description of the channel that is
+// contained in the given backup.
+func marshallChannelBackupInfo(
+	backup chanbackup.Single) *lnrpc.ChannelBackupInfo {
+
+	addrs := make([]string, 0, len(backup.Addresses))
+	for _, addr := range backup.Addresses {
+		addrs = append(addrs, addr.String())
+	}
+
+	var commitmentType lnrpc.CommitmentType
+	switch backup.Version {
+	case chanbackup.DefaultSingleVersion:
+		commitmentType = lnrpc.CommitmentType_LEGACY
+
+	case chanbackup.TweaklessCommitVersion:
+		commitmentType = lnrpc.CommitmentType_STATIC_REMOTE_KEY
+
+	case chanbackup.AnchorsCommitVersion,
+		chanbackup.AnchorsZeroFeeHtlcTxCommitVersion:
+
+		commitmentType = lnrpc.CommitmentType_ANCHORS
+
+	case chanbackup.ScriptEnforcedLeaseVersion:
+		commitmentType = lnrpc.CommitmentType_SCRIPT_ENFORCED_LEASE
+
+	case chanbackup.SimpleTaprootVersion:
+		commitmentType = lnrpc.CommitmentType_SIMPLE_TAPROOT
+
+	default:
+		commitmentType = lnrpc.CommitmentType_UNKNOWN_COMMITMENT_TYPE
+	}
+
+	return &lnrpc.ChannelBackupInfo{
+		ChannelPoint: backup.FundingOutpoint.String(),
+		RemotePubkey: hex.EncodeToString(
+			backup.RemoteNodePub.SerializeCompressed(),
+		),
+		RemoteAddresses: addrs,
+		Capacity:        int64(backup.Capacity),
+		CommitmentType:  commitmentType,
+		Initiator:       backup.IsInitiator,
+		ChanId:          backup.ShortChannelID.ToUint64(),
+	}
+}
+
+// SubscribeChannelBackups allows a client to sub-subscribe to the most up to
+// date information concerning the state of all channel back ups. Each time a
+// new channel is added, we return the new set of channels, along with a
+// multi-chan backup containing the backup info for all channels. Each time a
+// channel is closed, we send a new update, which contains new new chan back
+// ups, but the updated set of encrypted multi-chan backups with the closed
+// channel(s) removed.
+func (r *rpcServer) SubscribeChannelBackups(req *lnrpc.ChannelBackupSubscription,
+	updateStream lnrpc.Lightning_SubscribeChannelBackupsServer) error {
+
+	// First, we'll subscribe to the primary channel notifier so we can
+	// obtain events for new pending/opened/closed channels.
+	chanSubscription, err := r.server.channelNotifier.SubscribeChannelEvents()
+	if err != nil {
+		return err
+	}
+
+	defer chanSubscription.Cancel()
+	for {
+		select {
+		// A new event has been sent by the channel notifier, we'll
+		// assemble, then sling out a new event to the client.
+		case e := <-chanSubscription.Updates():
+			// TODO(roasbeef): batch dispatch ntnfs
+
+			switch e.(type) {
+
+			// We only care about new/closed channels, so we'll
+			// skip any events for active/inactive channels.
+			// To make the subscription behave the same way as the
+			// synchronous call and the file based backup, we also
+			// include pending channels in the update.
+			case channelnotifier.ActiveChannelEvent:
+				continue
+			case channelnotifier.InactiveChannelEvent:
+				continue
+			case channelnotifier.ActiveLinkEvent:
+				continue
+			case channelnotifier.InactiveLinkEvent:
+				continue
+			case channelnotifier.CloseConfirmedEvent:
+				continue
+			case channelnotifier.PolicyUpdateEvent:
+				continue
+			case channelnotifier.ForwardingStatusEvent:
+				continue
+			case channelnotifier.FlappingChannelEvent:
+				continue
+			case channelnotifier.FailureDisabledChannelEvent:
+				continue
+			}
+
+			// Now that we know the channel state has changed,
+			// we'll obtains the current set of single channel
+			// backups from disk.
+			chanBackups, err := chanbackup.FetchStaticChanBackups(
+				r.server.chanStateDB, r.server.addrSource,
+			)
 			if err != nil {
-				aliasIn = fmt.Sprintf("unable to lookup peer "+
-					"alias: %v", err)
+				return fmt.Errorf("unable to fetch all "+
+					"static chan backups: %v", err)
 			}
-			aliasOut, err := getRemoteAlias(event.OutgoingChanID)
+
+			// With our backups obtained, we'll pack them into a
+			// snapshot and send them back to the client.
+			backupSnapshot, err := r.createBackupSnapshot(
+				chanBackups,
+			)
 			if err != nil {
-				aliasOut = fmt.Sprintf("unable to lookup peer"+
-					"alias: %v", err)
+				return err
 			}
-			resp.ForwardingEvents[i].PeerAliasIn = aliasIn
-			resp.ForwardingEvents[i].PeerAliasOut = aliasOut
+			err = updateStream.Send(backupSnapshot)
+			if err != nil {
+				return err
+			}
+
+		// The response stream's context for whatever reason has been
+		// closed. If context is closed by an exceeded deadline we will
+		// return an error.
+		case <-updateStream.Context().Done():
+			if errors.Is(updateStream.Context().Err(), context.Canceled) {
+				return nil
+			}
+			return updateStream.Context().Err()
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// ChannelAcceptor dispatches a bi-directional streaming RPC in which
+// OpenChannel requests are sent to the client and the client responds with
+// a boolean that tells LND whether or not to accept the channel. This allows
+// node operators to specify their own criteria for accepting inbound channels
+// through a single persistent connection.
+func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer) error {
+	chainedAcceptor := r.chanPredicate
+
+	// Create a new RPCAcceptor which will send requests into the
+	// newRequests channel when it receives them.
+	rpcAcceptor := chanacceptor.NewRPCAcceptor(
+		stream.Recv, stream.Send, r.cfg.AcceptorTimeout,
+		r.cfg.ActiveNetParams.Params, r.quit,
+	)
+
+	// Add the RPCAcceptor to the ChainedAcceptor and defer its removal.
+	id := chainedAcceptor.AddAcceptor(rpcAcceptor)
+	defer chainedAcceptor.RemoveAcceptor(id)
+
+	// Run the rpc acceptor, which will accept requests for channel
+	// acceptance decisions from our chained acceptor, send them to the
+	// channel acceptor and listen for and report responses. This function
+	// blocks, and will exit if the rpcserver receives the instruction to
+	// shutdown, or the client cancels.
+	return rpcAcceptor.Run()
+}
+
+// SetChannelAcceptorPolicy replaces the declarative policy that inbound channel
+// requests are checked against.
+func (r *rpcServer) SetChannelAcceptorPolicy(_ context.Context,
+	in *lnrpc.ChannelAcceptorPolicy) (
+	*lnrpc.SetChannelAcceptorPolicyResponse, error) {
+
+	if r.server.acceptorPolicy == nil {
+		return nil, errors.New("channel acceptor policy not enabled, " +
+			"start lnd with --acceptorpolicy")
+	}
+
+	policy := funding.AcceptorPolicy{
+		MinCapacity:       btcutil.Amount(in.MinCapacitySat),
+		MaxPendingPerPeer: in.MaxPendingPerPeer,
+	}
+
+	for _, rpcType := range in.CommitmentTypes {
+		commitType, err := walletCommitmentType(rpcType)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.CommitmentTypes = append(
+			policy.CommitmentTypes, commitType,
+		)
+	}
+
+	for _, ipRange := range in.AllowedIpRanges {
+		_, ipNet, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range %v: %w",
+				ipRange, err)
+		}
+
+		policy.AllowedIPRanges = append(policy.AllowedIPRanges, ipNet)
+	}
+
+	if len(in.ZeroConfPeers) > 0 && !r.cfg.ProtocolOptions.ZeroConf() {
+		return nil, fmt.Errorf("zero-conf peers require the zero-conf " +
+			"protocol option")
+	}
+
+	policy.ZeroConfPeers = make(map[route.Vertex]struct{})
+	for _, peer := range in.ZeroConfPeers {
+		vertex, err := route.NewVertexFromBytes(peer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zero-conf peer: %w",
+				err)
+		}
+
+		policy.ZeroConfPeers[vertex] = struct{}{}
+	}
+
+	r.server.acceptorPolicy.SetPolicy(policy)
+
+	rpcsLog.Infof("[setacceptorpolicy] updated channel acceptor policy")
+
+	return &lnrpc.SetChannelAcceptorPolicyResponse{}, nil
+}
+
+// GetChannelAcceptorPolicy returns the channel acceptor policy that is
+// currently in use.
+func (r *rpcServer) GetChannelAcceptorPolicy(_ context.Context,
+	_ *lnrpc.GetChannelAcceptorPolicyRequest) (*lnrpc.ChannelAcceptorPolicy,
+	error) {
+
+	if r.server.acceptorPolicy == nil {
+		return nil, errors.New("channel acceptor policy not enabled, " +
+			"start lnd with --acceptorpolicy")
+	}
+
+	policy := r.server.acceptorPolicy.Policy()
+
+	resp := &lnrpc.ChannelAcceptorPolicy{
+		MinCapacitySat:    uint64(policy.MinCapacity),
+		MaxPendingPerPeer: policy.MaxPendingPerPeer,
+	}
+
+	for _, commitType := range policy.CommitmentTypes {
+		resp.CommitmentTypes = append(
+			resp.CommitmentTypes,
+			rpcCommitmentTypeFromWallet(commitType),
+		)
+	}
+
+	for _, ipNet := range policy.AllowedIPRanges {
+		resp.AllowedIpRanges = append(
+			resp.AllowedIpRanges, ipNet.String(),
+		)
+	}
+
+	for vertex := range policy.ZeroConfPeers {
+		vertex := vertex
+		resp.ZeroConfPeers = append(resp.ZeroConfPeers, vertex[:])
+	}
+
+	return resp, nil
+}
+
+// SetPeerPolicy sets the default parameters of the channels we open to a peer.
+func (r *rpcServer) SetPeerPolicy(_ context.Context,
+	in *lnrpc.SetPeerPolicyRequest) (*lnrpc.SetPeerPolicyResponse, error) {
+
+	peer, err := route.NewVertexFromBytes(in.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer: %w", err)
+	}
+
+	policy, err := unmarshalPeerPolicy(in.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if *policy == (channeldb.PeerPolicy{}) {
+		err = r.server.miscDB.DeletePeerPolicy(peer)
+		if err != nil {
+			return nil, err
 		}
+
+		rpcsLog.Infof("[setpeerpolicy] removed policy of peer %v",
+			peer)
+
+		return &lnrpc.SetPeerPolicyResponse{}, nil
 	}
 
-	return resp, nil
+	if err := r.server.miscDB.WritePeerPolicy(peer, policy); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[setpeerpolicy] updated policy of peer %v", peer)
+
+	return &lnrpc.SetPeerPolicyResponse{}, nil
 }
 
-// ExportChannelBackup attempts to return an encrypted static channel backup
-// for the target channel identified by it channel point. The backup is
-// encrypted with a key generated from the aezeed seed of the user. The
-// returned backup can either be restored using the RestoreChannelBackup method
-// once lnd is running, or via the InitWallet and UnlockWallet methods from the
-// WalletUnlocker service.
-func (r *rpcServer) ExportChannelBackup(ctx context.Context,
-	in *lnrpc.ExportChannelBackupRequest) (*lnrpc.ChannelBackup, error) {
+// ListPeerPolicies returns the default channel parameters of all peers that
+// have a policy set.
+func (r *rpcServer) ListPeerPolicies(_ context.Context,
+	_ *lnrpc.ListPeerPoliciesRequest) (*lnrpc.ListPeerPoliciesResponse,
+	error) {
 
-	// First, we'll convert the lnrpc channel point into a wire.OutPoint
-	// that we can manipulate.
-	txid, err := lnrpc.GetChanPointFundingTxid(in.ChanPoint)
+	policies, err := r.server.miscDB.ReadPeerPolicies()
 	if err != nil {
 		return nil, err
 	}
-	chanPoint := wire.OutPoint{
-		Hash:  *txid,
-		Index: in.ChanPoint.OutputIndex,
+
+	resp := &lnrpc.ListPeerPoliciesResponse{
+		Policies: make(map[string]*lnrpc.PeerPolicy, len(policies)),
+	}
+	for peer, policy := range policies {
+		resp.Policies[peer.String()] = marshalPeerPolicy(policy)
 	}
 
-	// Next, we'll attempt to fetch a channel backup for this channel from
-	// the database. If this channel has been closed, or the outpoint is
-	// unknown, then we'll return an error
-	unpackedBackup, err := chanbackup.FetchBackupForChan(
-		chanPoint, r.server.chanStateDB, r.server.addrSource,
-	)
-	if err != nil {
-		return nil, err
+	return resp, nil
+}
+
+// unmarshalPeerPolicy validates the given rpc peer policy and converts it to
+// the policy that is stored in the database. A nil policy results in an empty
+// policy.
+func unmarshalPeerPolicy(
+	rpcPolicy *lnrpc.PeerPolicy) (*channeldb.PeerPolicy, error) {
+
+	if rpcPolicy == nil {
+		return &channeldb.PeerPolicy{}, nil
 	}
 
-	// At this point, we have an unpacked backup (plaintext) so we'll now
-	// attempt to serialize and encrypt it in order to create a packed
-	// backup.
-	packedBackups, err := chanbackup.PackStaticChanBackups(
-		[]chanbackup.Single{*unpackedBackup},
-		r.server.cc.KeyRing,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("packing of back ups failed: %w", err)
+	if rpcPolicy.CommitmentType !=
+		lnrpc.CommitmentType_UNKNOWN_COMMITMENT_TYPE {
+
+		_, err := walletCommitmentType(rpcPolicy.CommitmentType)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Before we proceed, we'll ensure that we received a backup for this
-	// channel, otherwise, we'll bail out.
-	packedBackup, ok := packedBackups[chanPoint]
-	if !ok {
-		return nil, fmt.Errorf("expected single backup for "+
-			"ChannelPoint(%v), got %v", chanPoint,
-			len(packedBackup))
+	if rpcPolicy.RemoteCsvDelay > math.MaxUint16 {
+		return nil, fmt.Errorf("remote csv delay (%v) cannot be "+
+			"greater than %v", rpcPolicy.RemoteCsvDelay,
+			math.MaxUint16)
 	}
 
-	return &lnrpc.ChannelBackup{
-		ChanPoint:  in.ChanPoint,
-		ChanBackup: packedBackup,
+	if rpcPolicy.RemoteMaxHtlcs > input.MaxHTLCNumber/2 {
+		return nil, fmt.Errorf("remote-max-htlcs (%v) cannot be "+
+			"greater than %v", rpcPolicy.RemoteMaxHtlcs,
+			input.MaxHTLCNumber/2)
+	}
+
+	return &channeldb.PeerPolicy{
+		SatPerVByte:    rpcPolicy.SatPerVbyte,
+		CommitmentType: uint8(rpcPolicy.CommitmentType),
+		RemoteCsvDelay: uint16(rpcPolicy.RemoteCsvDelay),
+		RemoteMaxHtlcs: uint16(rpcPolicy.RemoteMaxHtlcs),
+		Private:        rpcPolicy.Private,
 	}, nil
 }
 
-// VerifyChanBackup allows a caller to verify the integrity of a channel backup
-// snapshot. This method will accept both either a packed Single or a packed
-// Multi. Specifying both will result in an error.
-func (r *rpcServer) VerifyChanBackup(ctx context.Context,
-	in *lnrpc.ChanBackupSnapshot) (*lnrpc.VerifyChanBackupResponse, error) {
+// marshalPeerPolicy converts a peer policy stored in the database to its rpc
+// representation.
+func marshalPeerPolicy(policy *channeldb.PeerPolicy) *lnrpc.PeerPolicy {
+	return &lnrpc.PeerPolicy{
+		SatPerVbyte:    policy.SatPerVByte,
+		CommitmentType: lnrpc.CommitmentType(policy.CommitmentType),
+		RemoteCsvDelay: uint32(policy.RemoteCsvDelay),
+		RemoteMaxHtlcs: uint32(policy.RemoteMaxHtlcs),
+		Private:        policy.Private,
+	}
+}
 
-	switch {
-	// If neither a Single or Multi has been specified, then we have nothing
-	// to verify.
-	case in.GetSingleChanBackups() == nil && in.GetMultiChanBackup() == nil:
-		return nil, errors.New("either a Single or Multi channel " +
-			"backup must be specified")
+// UpdatePeerConnectionPolicy sets how the connection to a peer is maintained.
+func (r *rpcServer) UpdatePeerConnectionPolicy(_ context.Context,
+	in *lnrpc.UpdatePeerConnectionPolicyRequest) (
+	*lnrpc.UpdatePeerConnectionPolicyResponse, error) {
 
-	// Either a Single or a Multi must be specified, but not both.
-	case in.GetSingleChanBackups() != nil && in.GetMultiChanBackup() != nil:
-		return nil, errors.New("either a Single or Multi channel " +
-			"backup must be specified, but not both")
+	pubKey, err := btcec.ParsePubKey(in.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer: %w", err)
+	}
 
-	// If a Single is specified then we'll only accept one of them to allow
-	// the caller to map the valid/invalid state for each individual Single.
-	case in.GetSingleChanBackups() != nil:
-		chanBackupsProtos := in.GetSingleChanBackups().ChanBackups
-		if len(chanBackupsProtos) != 1 {
-			return nil, errors.New("only one Single is accepted " +
-				"at a time")
-		}
+	policy := unmarshalPeerConnectionPolicy(in.Policy)
+	err = r.server.UpdatePeerConnectionPolicy(pubKey, policy)
+	if err != nil {
+		return nil, err
+	}
 
-		// First, we'll convert the raw byte slice into a type we can
-		// work with a bit better.
-		chanBackup := chanbackup.PackedSingles(
-			[][]byte{chanBackupsProtos[0].ChanBackup},
-		)
+	rpcsLog.Infof("[updatepeerconnpolicy] updated connection policy of "+
+		"peer %x", in.PubKey)
 
-		// With our PackedSingles created, we'll attempt to unpack the
-		// backup. If this fails, then we know the backup is invalid for
-		// some reason.
-		_, err := chanBackup.Unpack(r.server.cc.KeyRing)
-		if err != nil {
-			return nil, fmt.Errorf("invalid single channel "+
-				"backup: %v", err)
-		}
+	return &lnrpc.UpdatePeerConnectionPolicyResponse{}, nil
+}
 
-	case in.GetMultiChanBackup() != nil:
-		// We'll convert the raw byte slice into a PackedMulti that we
-		// can easily work with.
-		packedMultiBackup := in.GetMultiChanBackup().MultiChanBackup
-		packedMulti := chanbackup.PackedMulti(packedMultiBackup)
+// ListPeerConnectionPolicies returns the connection policies of all peers that
+// have one.
+func (r *rpcServer) ListPeerConnectionPolicies(_ context.Context,
+	_ *lnrpc.ListPeerConnectionPoliciesRequest) (
+	*lnrpc.ListPeerConnectionPoliciesResponse, error) {
 
-		// We'll now attempt to unpack the Multi. If this fails, then we
-		// know it's invalid.
-		_, err := packedMulti.Unpack(r.server.cc.KeyRing)
-		if err != nil {
-			return nil, fmt.Errorf("invalid multi channel backup: "+
-				"%v", err)
-		}
+	policies, err := r.server.miscDB.ReadPeerConnectionPolicies()
+	if err != nil {
+		return nil, err
 	}
 
-	return &lnrpc.VerifyChanBackupResponse{}, nil
+	resp := &lnrpc.ListPeerConnectionPoliciesResponse{
+		Policies: make(
+			map[string]*lnrpc.PeerConnectionPolicy, len(policies),
+		),
+	}
+	for peer, policy := range policies {
+		resp.Policies[peer.String()] = marshalPeerConnectionPolicy(
+			policy,
+		)
+	}
+
+	return resp, nil
 }
 
-// createBackupSnapshot converts the passed Single backup into a snapshot which
-// contains individual packed single backups, as well as a single packed multi
-// backup.
-func (r *rpcServer) createBackupSnapshot(backups []chanbackup.Single) (
-	*lnrpc.ChanBackupSnapshot, error) {
+// unmarshalPeerConnectionPolicy converts the given rpc peer connection policy
+// to the policy that is stored in the database. A nil policy results in an
+// empty policy.
+func unmarshalPeerConnectionPolicy(
+	rpcPolicy *lnrpc.PeerConnectionPolicy) *channeldb.PeerConnectionPolicy {
 
-	// Once we have the set of back ups, we'll attempt to pack them all
-	// into a series of single channel backups.
-	singleChanPackedBackups, err := chanbackup.PackStaticChanBackups(
-		backups, r.server.cc.KeyRing,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to pack set of chan "+
-			"backups: %v", err)
+	if rpcPolicy == nil {
+		return &channeldb.PeerConnectionPolicy{}
 	}
 
-	// Now that we have our set of single packed backups, we'll morph that
-	// into a form that the proto response requires.
-	numBackups := len(singleChanPackedBackups)
-	singleBackupResp := &lnrpc.ChannelBackups{
-		ChanBackups: make([]*lnrpc.ChannelBackup, 0, numBackups),
+	return &channeldb.PeerConnectionPolicy{
+		AlwaysReconnect: rpcPolicy.AlwaysReconnect,
+		MaxBackoff: time.Duration(rpcPolicy.MaxBackoffSeconds) *
+			time.Second,
+		PreferredAddress: rpcPolicy.PreferredAddress,
+		TorOnly:          rpcPolicy.TorOnly,
 	}
-	for chanPoint, singlePackedBackup := range singleChanPackedBackups {
-		txid := chanPoint.Hash
-		rpcChanPoint := &lnrpc.ChannelPoint{
-			FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
-				FundingTxidBytes: txid[:],
-			},
-			OutputIndex: chanPoint.Index,
-		}
+}
 
-		singleBackupResp.ChanBackups = append(
-			singleBackupResp.ChanBackups,
-			&lnrpc.ChannelBackup{
-				ChanPoint:  rpcChanPoint,
-				ChanBackup: singlePackedBackup,
-			},
-		)
+// marshalPeerConnectionPolicy converts a peer connection policy stored in the
+// database to its rpc representation.
+func marshalPeerConnectionPolicy(
+	policy *channeldb.PeerConnectionPolicy) *lnrpc.PeerConnectionPolicy {
+
+	return &lnrpc.PeerConnectionPolicy{
+		AlwaysReconnect:   policy.AlwaysReconnect,
+		MaxBackoffSeconds: uint32(policy.MaxBackoff / time.Second),
+		PreferredAddress:  policy.PreferredAddress,
+		TorOnly:           policy.TorOnly,
 	}
+}
 
-	// In addition, to the set of single chan backups, we'll also create a
-	// single multi-channel backup which can be serialized into a single
-	// file for safe storage.
-	var b bytes.Buffer
-	unpackedMultiBackup := chanbackup.Multi{
-		StaticBackups: backups,
+// UpdatePeerGossipPolicy sets how gossip is exchanged with a peer.
+func (r *rpcServer) UpdatePeerGossipPolicy(_ context.Context,
+	in *lnrpc.UpdatePeerGossipPolicyRequest) (
+	*lnrpc.UpdatePeerGossipPolicyResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(in.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer: %w", err)
 	}
-	err = unpackedMultiBackup.PackToWriter(&b, r.server.cc.KeyRing)
+
+	policy := unmarshalPeerGossipPolicy(in.Policy)
+	err = r.server.UpdatePeerGossipPolicy(pubKey, policy)
 	if err != nil {
-		return nil, fmt.Errorf("unable to multi-pack backups: %w", err)
+		return nil, err
 	}
 
-	multiBackupResp := &lnrpc.MultiChanBackup{
-		MultiChanBackup: b.Bytes(),
+	rpcsLog.Infof("[updatepeergossippolicy] updated gossip policy of "+
+		"peer %x", in.PubKey)
+
+	return &lnrpc.UpdatePeerGossipPolicyResponse{}, nil
+}
+
+// ListPeerGossipPolicies returns the gossip policies of all peers that have
+// one.
+func (r *rpcServer) ListPeerGossipPolicies(_ context.Context,
+	_ *lnrpc.ListPeerGossipPoliciesRequest) (
+	*lnrpc.ListPeerGossipPoliciesResponse, error) {
+
+	policies, err := r.server.miscDB.ReadPeerGossipPolicies()
+	if err != nil {
+		return nil, err
 	}
-	for _, singleBackup := range singleBackupResp.ChanBackups {
-		multiBackupResp.ChanPoints = append(
-			multiBackupResp.ChanPoints, singleBackup.ChanPoint,
-		)
+
+	resp := &lnrpc.ListPeerGossipPoliciesResponse{
+		Policies: make(
+			map[string]*lnrpc.PeerGossipPolicy, len(policies),
+		),
+	}
+	for peer, policy := range policies {
+		resp.Policies[peer.String()] = &lnrpc.PeerGossipPolicy{
+			DisableGossip: policy.DisableGossip,
+			RateLimit:     policy.RateLimit,
+		}
 	}
 
-	return &lnrpc.ChanBackupSnapshot{
-		SingleChanBackups: singleBackupResp,
-		MultiChanBackup:   multiBackupResp,
-	}, nil
+	return resp, nil
 }
 
-// ExportAllChannelBackups returns static channel backups for all existing
-// channels known to lnd. A set of regular singular static channel backups for
-// each channel are returned. Additionally, a multi-channel backup is returned
-// as well, which contains a single encrypted blob containing the backups of
-// each channel.
-func (r *rpcServer) ExportAllChannelBackups(ctx context.Context,
-	in *lnrpc.ChanBackupExportRequest) (*lnrpc.ChanBackupSnapshot, error) {
+// unmarshalPeerGossipPolicy converts the given rpc peer gossip policy to the
+// policy that is stored in the database. A nil policy results in an empty
+// policy.
+func unmarshalPeerGossipPolicy(
+	rpcPolicy *lnrpc.PeerGossipPolicy) *channeldb.PeerGossipPolicy {
 
-	// First, we'll attempt to read back ups for ALL currently opened
-	// channels from disk.
-	allUnpackedBackups, err := chanbackup.FetchStaticChanBackups(
-		r.server.chanStateDB, r.server.addrSource,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to fetch all static chan "+
-			"backups: %v", err)
+	if rpcPolicy == nil {
+		return &channeldb.PeerGossipPolicy{}
 	}
 
-	// With the backups assembled, we'll create a full snapshot.
-	return r.createBackupSnapshot(allUnpackedBackups)
+	return &channeldb.PeerGossipPolicy{
+		DisableGossip: rpcPolicy.DisableGossip,
+		RateLimit:     rpcPolicy.RateLimit,
+	}
 }
 
-// RestoreChannelBackups accepts a set of singular channel backups, or a single
-// encrypted multi-chan backup and attempts to recover any funds remaining
-// within the channel. If we're able to unpack the backup, then the new channel
-// will be shown under listchannels, as well as pending channels.
-func (r *rpcServer) RestoreChannelBackups(ctx context.Context,
-	in *lnrpc.RestoreChanBackupRequest) (*lnrpc.RestoreBackupResponse, error) {
+// applyPeerPolicy sets the parameters of the open channel request that aren't
+// set explicitly to the defaults of the policy of the peer, if there is one.
+func (r *rpcServer) applyPeerPolicy(in *lnrpc.OpenChannelRequest,
+	isSync bool) error {
 
-	// The server hasn't yet started, so it won't be able to service any of
-	// our requests, so we'll bail early here.
-	if !r.server.Started() {
-		return nil, ErrServerNotActive
+	pubKeyBytes := in.NodePubkey
+	if len(pubKeyBytes) == 0 && isSync {
+		var err error
+		pubKeyBytes, err = hex.DecodeString(
+			in.NodePubkeyString, // nolint:staticcheck
+		)
+		if err != nil {
+			return err
+		}
 	}
 
-	// First, we'll make our implementation of the
-	// chanbackup.ChannelRestorer interface which we'll use to properly
-	// restore either a set of chanbackup.Single or chanbackup.Multi
-	// backups.
-	chanRestorer := &chanDBRestorer{
-		db:         r.server.chanStateDB,
-		secretKeys: r.server.cc.KeyRing,
-		chainArb:   r.server.chainArb,
+	// An invalid public key is rejected when the request is parsed, so
+	// there's nothing to apply here.
+	peer, err := route.NewVertexFromBytes(pubKeyBytes)
+	if err != nil {
+		return nil
 	}
 
-	// We'll accept either a list of Single backups, or a single Multi
-	// backup which contains several single backups.
+	policy, err := r.server.miscDB.ReadPeerPolicy(peer)
 	switch {
-	case in.GetChanBackups() != nil:
-		chanBackupsProtos := in.GetChanBackups()
+	case errors.Is(err, channeldb.ErrNoPeerPolicy):
+		return nil
 
-		// Now that we know what type of backup we're working with,
-		// we'll parse them all out into a more suitable format.
-		packedBackups := make([][]byte, 0, len(chanBackupsProtos.ChanBackups))
-		for _, chanBackup := range chanBackupsProtos.ChanBackups {
-			packedBackups = append(
-				packedBackups, chanBackup.ChanBackup,
-			)
-		}
+	case err != nil:
+		return err
+	}
+
+	applyPeerPolicyDefaults(in, policy)
+
+	return nil
+}
 
-		// With our backups obtained, we'll now restore them which will
-		// write the new backups to disk, and then attempt to connect
-		// out to any peers that we know of which were our prior
-		// channel peers.
-		err := chanbackup.UnpackAndRecoverSingles(
-			chanbackup.PackedSingles(packedBackups),
-			r.server.cc.KeyRing, chanRestorer, r.server,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("unable to unpack single "+
-				"backups: %v", err)
-		}
+// applyPeerPolicyDefaults sets the parameters of the open channel request that
+// aren't set explicitly to the defaults of the given peer policy.
+func applyPeerPolicyDefaults(in *lnrpc.OpenChannelRequest,
+	policy *channeldb.PeerPolicy) {
 
-	case in.GetMultiChanBackup() != nil:
-		packedMultiBackup := in.GetMultiChanBackup()
+	// The fee rate of the policy is only used if the request neither
+	// sets a fee rate nor a confirmation target.
+	feeRateSet := in.SatPerVbyte != 0 || in.TargetConf != 0 ||
+		in.SatPerByte != 0 // nolint:staticcheck
+	if !feeRateSet && policy.SatPerVByte != 0 {
+		in.SatPerVbyte = policy.SatPerVByte
+	}
 
-		// With our backups obtained, we'll now restore them which will
-		// write the new backups to disk, and then attempt to connect
-		// out to any peers that we know of which were our prior
-		// channel peers.
-		packedMulti := chanbackup.PackedMulti(packedMultiBackup)
-		err := chanbackup.UnpackAndRecoverMulti(
-			packedMulti, r.server.cc.KeyRing, chanRestorer,
-			r.server,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("unable to unpack chan "+
-				"backup: %v", err)
-		}
+	if in.CommitmentType == lnrpc.CommitmentType_UNKNOWN_COMMITMENT_TYPE {
+		in.CommitmentType = lnrpc.CommitmentType(policy.CommitmentType)
 	}
 
-	return &lnrpc.RestoreBackupResponse{}, nil
-}
+	if in.RemoteCsvDelay == 0 {
+		in.RemoteCsvDelay = uint32(policy.RemoteCsvDelay)
+	}
 
-// SubscribeChannelBackups allows a client to sub-subscribe to the most up to
-// date information concerning the state of all channel back ups. Each time a
-// new channel is added, we return the new set of channels, along with a
-// multi-chan backup containing the backup info for all channels. Each time a
-// channel is closed, we send a new update, which contains new new chan back
-// ups, but the updated set of encrypted multi-chan backups with the closed
-// channel(s) removed.
-func (r *rpcServer) SubscribeChannelBackups(req *lnrpc.ChannelBackupSubscription,
-	updateStream lnrpc.Lightning_SubscribeChannelBackupsServer) error {
+	if in.RemoteMaxHtlcs == 0 {
+		in.RemoteMaxHtlcs = uint32(policy.RemoteMaxHtlcs)
+	}
 
-	// First, we'll subscribe to the primary channel notifier so we can
-	// obtain events for new pending/opened/closed channels.
-	chanSubscription, err := r.server.channelNotifier.SubscribeChannelEvents()
-	if err != nil {
-		return err
+	if policy.Private {
+		in.Private = true
 	}
+}
 
-	defer chanSubscription.Cancel()
-	for {
-		select {
-		// A new event has been sent by the channel notifier, we'll
-		// assemble, then sling out a new event to the client.
-		case e := <-chanSubscription.Updates():
-			// TODO(roasbeef): batch dispatch ntnfs
+// walletCommitmentType converts an rpc commitment type to the wallet's
+// commitment type.
+func walletCommitmentType(
+	commitType lnrpc.CommitmentType) (lnwallet.CommitmentType, error) {
 
-			switch e.(type) {
+	switch commitType {
+	case lnrpc.CommitmentType_LEGACY:
+		return lnwallet.CommitmentTypeLegacy, nil
 
-			// We only care about new/closed channels, so we'll
-			// skip any events for active/inactive channels.
-			// To make the subscription behave the same way as the
-			// synchronous call and the file based backup, we also
-			// include pending channels in the update.
-			case channelnotifier.ActiveChannelEvent:
-				continue
-			case channelnotifier.InactiveChannelEvent:
-				continue
-			case channelnotifier.ActiveLinkEvent:
-				continue
-			case channelnotifier.InactiveLinkEvent:
-				continue
-			}
+	case lnrpc.CommitmentType_STATIC_REMOTE_KEY:
+		return lnwallet.CommitmentTypeTweakless, nil
 
-			// Now that we know the channel state has changed,
-			// we'll obtains the current set of single channel
-			// backups from disk.
-			chanBackups, err := chanbackup.FetchStaticChanBackups(
-				r.server.chanStateDB, r.server.addrSource,
-			)
-			if err != nil {
-				return fmt.Errorf("unable to fetch all "+
-					"static chan backups: %v", err)
-			}
+	case lnrpc.CommitmentType_ANCHORS:
+		return lnwallet.CommitmentTypeAnchorsZeroFeeHtlcTx, nil
 
-			// With our backups obtained, we'll pack them into a
-			// snapshot and send them back to the client.
-			backupSnapshot, err := r.createBackupSnapshot(
-				chanBackups,
-			)
-			if err != nil {
-				return err
-			}
-			err = updateStream.Send(backupSnapshot)
-			if err != nil {
-				return err
-			}
+	case lnrpc.CommitmentType_SCRIPT_ENFORCED_LEASE:
+		return lnwallet.CommitmentTypeScriptEnforcedLease, nil
 
-		// The response stream's context for whatever reason has been
-		// closed. If context is closed by an exceeded deadline we will
-		// return an error.
-		case <-updateStream.Context().Done():
-			if errors.Is(updateStream.Context().Err(), context.Canceled) {
-				return nil
-			}
-			return updateStream.Context().Err()
+	case lnrpc.CommitmentType_SIMPLE_TAPROOT:
+		return lnwallet.CommitmentTypeSimpleTaproot, nil
 
-		case <-r.quit:
-			return nil
-		}
+	default:
+		return 0, fmt.Errorf("unhandled commitment type: %v",
+			commitType)
 	}
 }
 
-// ChannelAcceptor dispatches a bi-directional streaming RPC in which
-// OpenChannel requests are sent to the client and the client responds with
-// a boolean that tells LND whether or not to accept the channel. This allows
-// node operators to specify their own criteria for accepting inbound channels
-// through a single persistent connection.
-func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer) error {
-	chainedAcceptor := r.chanPredicate
+// rpcCommitmentTypeFromWallet converts the wallet's commitment type to an rpc
+// commitment type.
+func rpcCommitmentTypeFromWallet(
+	commitType lnwallet.CommitmentType) lnrpc.CommitmentType {
 
-	// Create a new RPCAcceptor which will send requests into the
-	// newRequests channel when it receives them.
-	rpcAcceptor := chanacceptor.NewRPCAcceptor(
-		stream.Recv, stream.Send, r.cfg.AcceptorTimeout,
-		r.cfg.ActiveNetParams.Params, r.quit,
-	)
+	switch commitType {
+	case lnwallet.CommitmentTypeLegacy:
+		return lnrpc.CommitmentType_LEGACY
 
-	// Add the RPCAcceptor to the ChainedAcceptor and defer its removal.
-	id := chainedAcceptor.AddAcceptor(rpcAcceptor)
-	defer chainedAcceptor.RemoveAcceptor(id)
+	case lnwallet.CommitmentTypeTweakless:
+		return lnrpc.CommitmentType_STATIC_REMOTE_KEY
 
-	// Run the rpc acceptor, which will accept requests for channel
-	// acceptance decisions from our chained acceptor, send them to the
-	// channel acceptor and listen for and report responses. This function
-	// blocks, and will exit if the rpcserver receives the instruction to
-	// shutdown, or the client cancels.
-	return rpcAcceptor.Run()
+	case lnwallet.CommitmentTypeAnchorsZeroFeeHtlcTx:
+		return lnrpc.CommitmentType_ANCHORS
+
+	case lnwallet.CommitmentTypeScriptEnforcedLease:
+		return lnrpc.CommitmentType_SCRIPT_ENFORCED_LEASE
+
+	case lnwallet.CommitmentTypeSimpleTaproot:
+		return lnrpc.CommitmentType_SIMPLE_TAPROOT
+
+	default:
+		return lnrpc.CommitmentType_UNKNOWN_COMMITMENT_TYPE
+	}
 }
 
 // BakeMacaroon allows the creation of a new macaroon with custom read and write
-// permissions. No first-party caveats are added since this can be done offline.
+// permissions. Apart from the spend and rate limits that are enforced by lnd
+// itself, no first-party caveats are added since this can be done offline.
 // If the --allow-external-permissions flag is set, the RPC will allow
 // external permissions that LND is not aware of.
 func (r *rpcServer) BakeMacaroon(ctx context.Context,
@@ -7923,7 +11469,30 @@ func (r *rpcServer) BakeMacaroon(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	newMacBytes, err := newMac.M().MarshalBinary()
+
+	// Add the limits that were requested for the new macaroon.
+	var limits []macaroons.Constraint
+	if req.MaxPaymentMsat > 0 {
+		limits = append(limits, macaroons.MaxPaymentAmountConstraint(
+			req.MaxPaymentMsat,
+		))
+	}
+	if req.DailySpendLimitMsat > 0 {
+		limits = append(limits, macaroons.DailySpendLimitConstraint(
+			req.DailySpendLimitMsat,
+		))
+	}
+	if req.CallsPerMinute > 0 {
+		limits = append(limits, macaroons.RateLimitConstraint(
+			req.CallsPerMinute,
+		))
+	}
+	limitedMac, err := macaroons.AddConstraints(newMac.M(), limits...)
+	if err != nil {
+		return nil, err
+	}
+
+	newMacBytes, err := limitedMac.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
@@ -7991,6 +11560,157 @@ func (r *rpcServer) DeleteMacaroonID(ctx context.Context,
 	}, nil
 }
 
+// RotateMacaroonRootKey creates a new version of a macaroon root key and/or
+// invalidates previous versions of it. If the default root key is rotated, the
+// default macaroons are re-issued with the new version.
+func (r *rpcServer) RotateMacaroonRootKey(ctx context.Context,
+	req *lnrpc.RotateMacaroonRootKeyRequest) (
+	*lnrpc.RotateMacaroonRootKeyResponse, error) {
+
+	// If the --no-macaroons flag is used to start lnd, the macaroon service
+	// is not initialized. Therefore we can't rotate any root keys.
+	if r.macService == nil {
+		return nil, errMacaroonDisabled
+	}
+
+	if req.SkipRotation && req.InvalidateBeforeVersion == 0 {
+		return nil, fmt.Errorf("either rotate the root key or " +
+			"invalidate previous versions")
+	}
+
+	// Root key IDs are stored as the string value of their digits, see
+	// BakeMacaroon.
+	rootKeyID := []byte(strconv.FormatUint(req.RootKeyId, 10))
+
+	resp := &lnrpc.RotateMacaroonRootKeyResponse{
+		RootKeyId: req.RootKeyId,
+	}
+	if !req.SkipRotation {
+		version, err := r.macService.RotateRootKey(rootKeyID)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcsLog.Infof("Rotated macaroon root key %d to version %d",
+			req.RootKeyId, version)
+
+		// The default macaroons are baked with the default root key,
+		// so we re-issue them with the new version.
+		if bytes.Equal(rootKeyID, macaroons.DefaultRootKeyID) {
+			err := r.reissueDefaultMacaroons(ctx, resp)
+			if err != nil {
+				return nil, fmt.Errorf("unable to re-issue "+
+					"default macaroons: %w", err)
+			}
+		}
+	}
+
+	if req.InvalidateBeforeVersion > 0 {
+		invalidated, err := r.macService.InvalidateRootKeyVersions(
+			rootKeyID, req.InvalidateBeforeVersion,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcsLog.Infof("Invalidated versions %v of macaroon root key "+
+			"%d", invalidated, req.RootKeyId)
+
+		resp.InvalidatedVersions = invalidated
+	}
+
+	activeVersion, versions, err := r.macService.RootKeyVersions(
+		rootKeyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.ActiveVersion = activeVersion
+	resp.ValidVersions = versions
+
+	return resp, nil
+}
+
+// reissueDefaultMacaroons bakes new default macaroons, including the ones of
+// all active sub-servers, with the active version of the default root key and
+// adds them to the given response. Unless lnd runs in stateless init mode, the
+// macaroon files on disk are replaced as well.
+func (r *rpcServer) reissueDefaultMacaroons(ctx context.Context,
+	resp *lnrpc.RotateMacaroonRootKeyResponse) error {
+
+	type defaultMacaroon struct {
+		path        string
+		permissions []bakery.Op
+		fileMode    os.FileMode
+		target      *string
+	}
+
+	defaultMacaroons := []defaultMacaroon{{
+		path:        r.cfg.AdminMacPath,
+		permissions: adminPermissions(),
+		fileMode:    adminMacaroonFilePermissions,
+		target:      &resp.AdminMacaroon,
+	}, {
+		path:        r.cfg.ReadMacPath,
+		permissions: readPermissions,
+		fileMode:    0644,
+		target:      &resp.ReadonlyMacaroon,
+	}, {
+		path:        r.cfg.InvoiceMacPath,
+		permissions: invoicePermissions,
+		fileMode:    0644,
+		target:      &resp.InvoiceMacaroon,
+	}}
+
+	// Sub-servers that bake a default macaroon on startup get theirs
+	// re-issued too, otherwise it would stop working once the previous
+	// root key versions are invalidated.
+	subServerMacs := make(map[string]*string)
+	for _, subServer := range r.subServers {
+		baker, ok := subServer.(lnrpc.DefaultMacaroonBaker)
+		if !ok {
+			continue
+		}
+
+		macHex := new(string)
+		subServerMacs[subServer.Name()] = macHex
+
+		path, permissions := baker.DefaultMacaroon()
+		defaultMacaroons = append(defaultMacaroons, defaultMacaroon{
+			path:        path,
+			permissions: permissions,
+			fileMode:    0644,
+			target:      macHex,
+		})
+	}
+
+	for _, mac := range defaultMacaroons {
+		macBytes, err := bakeMacaroon(
+			ctx, r.macService, mac.permissions,
+		)
+		if err != nil {
+			return err
+		}
+		*mac.target = hex.EncodeToString(macBytes)
+
+		if r.macService.StatelessInit {
+			continue
+		}
+
+		err = os.WriteFile(mac.path, macBytes, mac.fileMode)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp.SubServerMacaroons = make(map[string]string, len(subServerMacs))
+	for name, macHex := range subServerMacs {
+		resp.SubServerMacaroons[name] = *macHex
+	}
+
+	return nil
+}
+
 // ListPermissions lists all RPC method URIs and their required macaroon
 // permissions to access them.
 func (r *rpcServer) ListPermissions(_ context.Context,
@@ -8274,20 +11994,57 @@ func (r *rpcServer) RegisterRPCMiddleware(
 
 	readOnly := registerMsg.ReadOnlyMode
 	caveatName := registerMsg.CustomMacaroonCaveatName
+	namespaces := registerMsg.CaveatNamespaces
 	switch {
 	case readOnly && len(caveatName) > 0:
 		return fmt.Errorf("cannot set read-only and custom caveat " +
 			"name at the same time")
 
-	case !readOnly && len(caveatName) < nameMinLength:
+	case readOnly && len(namespaces) > 0:
+		return fmt.Errorf("cannot set read-only and caveat " +
+			"namespaces at the same time")
+
+	case !readOnly && len(namespaces) == 0 &&
+		len(caveatName) < nameMinLength:
+
 		return fmt.Errorf("need to set either custom caveat name "+
-			"of at least %d characters or read-only mode",
-			nameMinLength)
+			"of at least %d characters, caveat namespaces or "+
+			"read-only mode", nameMinLength)
+	}
+
+	for _, namespace := range namespaces {
+		if len(namespace) < nameMinLength {
+			return fmt.Errorf("invalid caveat namespace '%s', "+
+				"use namespace of at least %d characters",
+				namespace, nameMinLength)
+		}
+
+		if strings.ContainsAny(namespace, " \t\n") {
+			return fmt.Errorf("invalid caveat namespace '%s', "+
+				"white space is not allowed", namespace)
+		}
+	}
+
+	var failOpen bool
+	switch registerMsg.FailurePolicy {
+	case lnrpc.MiddlewareFailurePolicy_FAIL_CLOSED:
+
+	case lnrpc.MiddlewareFailurePolicy_FAIL_OPEN:
+		failOpen = true
+
+	default:
+		return fmt.Errorf("unknown middleware failure policy %v",
+			registerMsg.FailurePolicy)
 	}
 
+	policy := rpcperms.MiddlewarePolicy{
+		Priority:         registerMsg.Priority,
+		CaveatNamespaces: namespaces,
+		FailOpen:         failOpen,
+	}
 	middleware := rpcperms.NewMiddlewareHandler(
 		registerMsg.MiddlewareName,
-		caveatName, readOnly, stream.Recv, stream.Send,
+		caveatName, readOnly, policy, stream.Recv, stream.Send,
 		r.cfg.RPCMiddleware.InterceptTimeout,
 		r.cfg.ActiveNetParams.Params, r.quit,
 	)
@@ -8392,6 +12149,75 @@ func (r *rpcServer) SubscribeCustomMessages(req *lnrpc.SubscribeCustomMessagesRe
 	}
 }
 
+// SendOnionMessage sends an onion message to a connected peer.
+func (r *rpcServer) SendOnionMessage(_ context.Context,
+	req *lnrpc.SendOnionMessageRequest) (*lnrpc.SendOnionMessageResponse,
+	error) {
+
+	peer, err := route.NewVertexFromBytes(req.Peer)
+	if err != nil {
+		return nil, err
+	}
+
+	pathKey, err := btcec.ParsePubKey(req.PathKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path key: %w", err)
+	}
+
+	if len(req.Onion) == 0 {
+		return nil, errors.New("onion must be set")
+	}
+
+	err = r.server.SendOnionMessage(peer, pathKey, req.Onion)
+	switch {
+	case err == ErrPeerNotConnected:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	return &lnrpc.SendOnionMessageResponse{}, nil
+}
+
+// SubscribeOnionMessages subscribes to a stream of incoming onion messages
+// that are addressed to us.
+func (r *rpcServer) SubscribeOnionMessages(
+	_ *lnrpc.SubscribeOnionMessagesRequest,
+	server lnrpc.Lightning_SubscribeOnionMessagesServer) error {
+
+	client, err := r.server.SubscribeOnionMessages()
+	if err != nil {
+		return err
+	}
+	defer client.Cancel()
+
+	for {
+		select {
+		case <-client.Quit():
+			return errors.New("shutdown")
+
+		case <-server.Context().Done():
+			return server.Context().Err()
+
+		case update := <-client.Updates():
+			onionMsg := update.(*OnionMessage)
+
+			err := server.Send(&lnrpc.OnionMessageUpdate{
+				Peer: onionMsg.Peer[:],
+				PathKey: onionMsg.Msg.PathKey.
+					SerializeCompressed(),
+				Onion:     onionMsg.Msg.OnionBlob,
+				PathId:    onionMsg.Processed.PathID,
+				ReplyPath: onionMsg.Processed.ReplyPath,
+				Contents:  onionMsg.Processed.Contents,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // ListAliases returns the set of all aliases we have ever allocated along with
 // their base SCID's and possibly a separate confirmed SCID in the case of
 // zero-conf.
@@ -2,13 +2,294 @@ package lnd
 
 import (
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAllPermissions(t *testing.T) {
 	perms := GetAllPermissions()
 
-	// Currently there are there are 16 entity:action pairs in use.
-	assert.Equal(t, len(perms), 16)
+	// Currently there are there are 18 entity:action pairs in use.
+	assert.Equal(t, len(perms), 18)
+}
+
+// TestWalletBalanceBreakdown tests that the confirmed wallet balance is split
+// into the spendable balance and the funds that are held back.
+func TestWalletBalanceBreakdown(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		confirmed     btcutil.Amount
+		leased        btcutil.Amount
+		anchorReserve btcutil.Amount
+		pendingSweep  btcutil.Amount
+		expected      *lnrpc.WalletBalanceBreakdown
+	}{
+		{
+			name:          "all held back funds covered",
+			confirmed:     100_000,
+			leased:        20_000,
+			anchorReserve: 10_000,
+			pendingSweep:  30_000,
+			expected: &lnrpc.WalletBalanceBreakdown{
+				SpendableBalance:     60_000,
+				AnchorReserveBalance: 10_000,
+				LeasedBalance:        20_000,
+				PendingSweepBalance:  30_000,
+			},
+		},
+		{
+			name:          "reserve capped by available balance",
+			confirmed:     15_000,
+			anchorReserve: 10_000,
+			pendingSweep:  10_000,
+			expected: &lnrpc.WalletBalanceBreakdown{
+				SpendableBalance:     0,
+				AnchorReserveBalance: 5_000,
+				PendingSweepBalance:  10_000,
+			},
+		},
+		{
+			name:          "empty wallet",
+			anchorReserve: 10_000,
+			expected:      &lnrpc.WalletBalanceBreakdown{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			breakdown := newWalletBalanceBreakdown(
+				tc.confirmed, tc.leased, tc.anchorReserve,
+				tc.pendingSweep,
+			)
+			require.Equal(t, tc.expected, breakdown)
+		})
+	}
+}
+
+// TestApplyPeerPolicyDefaults tests that only the parameters of an open
+// channel request that aren't set explicitly are taken from the peer policy.
+func TestApplyPeerPolicyDefaults(t *testing.T) {
+	t.Parallel()
+
+	policy := &channeldb.PeerPolicy{
+		SatPerVByte:    10,
+		CommitmentType: uint8(lnrpc.CommitmentType_ANCHORS),
+		RemoteCsvDelay: 288,
+		RemoteMaxHtlcs: 50,
+		Private:        true,
+	}
+
+	// An empty request takes all parameters from the policy.
+	req := &lnrpc.OpenChannelRequest{}
+	applyPeerPolicyDefaults(req, policy)
+	require.EqualValues(t, 10, req.SatPerVbyte)
+	require.Equal(t, lnrpc.CommitmentType_ANCHORS, req.CommitmentType)
+	require.EqualValues(t, 288, req.RemoteCsvDelay)
+	require.EqualValues(t, 50, req.RemoteMaxHtlcs)
+	require.True(t, req.Private)
+
+	// Explicitly set parameters must be kept. A confirmation target also
+	// overrides the fee rate of the policy.
+	req = &lnrpc.OpenChannelRequest{
+		TargetConf:     3,
+		CommitmentType: lnrpc.CommitmentType_SIMPLE_TAPROOT,
+		RemoteCsvDelay: 144,
+		RemoteMaxHtlcs: 30,
+	}
+	applyPeerPolicyDefaults(req, policy)
+	require.Zero(t, req.SatPerVbyte)
+	require.EqualValues(t, 3, req.TargetConf)
+	require.Equal(
+		t, lnrpc.CommitmentType_SIMPLE_TAPROOT, req.CommitmentType,
+	)
+	require.EqualValues(t, 144, req.RemoteCsvDelay)
+	require.EqualValues(t, 30, req.RemoteMaxHtlcs)
+
+	// An empty policy doesn't change the request.
+	req = &lnrpc.OpenChannelRequest{}
+	applyPeerPolicyDefaults(req, &channeldb.PeerPolicy{})
+	require.Zero(t, req.SatPerVbyte)
+	require.Equal(
+		t, lnrpc.CommitmentType_UNKNOWN_COMMITMENT_TYPE,
+		req.CommitmentType,
+	)
+	require.Zero(t, req.RemoteCsvDelay)
+	require.Zero(t, req.RemoteMaxHtlcs)
+	require.False(t, req.Private)
+}
+
+// TestAmortizeCost tests that costs are spread linearly over the lifetime of a
+// channel.
+func TestAmortizeCost(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+	lifeStart := time.Unix(1_700_000_000, 0)
+	lifeEnd := lifeStart.Add(10 * day)
+
+	testCases := []struct {
+		name        string
+		windowStart time.Time
+		windowEnd   time.Time
+		lifeEnd     time.Time
+		expected    lnwire.MilliSatoshi
+	}{
+		{
+			name:        "window covers lifetime",
+			windowStart: lifeStart.Add(-day),
+			windowEnd:   lifeEnd.Add(day),
+			lifeEnd:     lifeEnd,
+			expected:    10_000,
+		},
+		{
+			name:        "window within lifetime",
+			windowStart: lifeStart.Add(2 * day),
+			windowEnd:   lifeStart.Add(4 * day),
+			lifeEnd:     lifeEnd,
+			expected:    2_000,
+		},
+		{
+			name:        "window overlaps end of lifetime",
+			windowStart: lifeStart.Add(9 * day),
+			windowEnd:   lifeEnd.Add(5 * day),
+			lifeEnd:     lifeEnd,
+			expected:    1_000,
+		},
+		{
+			name:        "window before lifetime",
+			windowStart: lifeStart.Add(-2 * day),
+			windowEnd:   lifeStart.Add(-day),
+			lifeEnd:     lifeEnd,
+			expected:    0,
+		},
+		{
+			name:        "empty lifetime within window",
+			windowStart: lifeStart.Add(-day),
+			windowEnd:   lifeStart.Add(day),
+			lifeEnd:     lifeStart,
+			expected:    10_000,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cost := amortizeCost(
+				10_000, lifeStart, tc.lifeEnd, tc.windowStart,
+				tc.windowEnd,
+			)
+			require.Equal(t, tc.expected, cost)
+		})
+	}
+}
+
+// TestAddRebalanceCosts tests that the fees of settled payments to our own node
+// are attributed to the channel of the last hop.
+func TestAddRebalanceCosts(t *testing.T) {
+	t.Parallel()
+
+	self := route.Vertex{1}
+	other := route.Vertex{2}
+	settleTime := time.Unix(1_700_000_000, 0)
+
+	newAttempt := func(dest route.Vertex, lastChan uint64,
+		settled bool) channeldb.HTLCAttempt {
+
+		attempt := channeldb.HTLCAttempt{
+			HTLCAttemptInfo: channeldb.HTLCAttemptInfo{
+				Route: route.Route{
+					TotalAmount: 1_050,
+					Hops: []*route.Hop{
+						{
+							PubKeyBytes:  other,
+							ChannelID:    1,
+							AmtToForward: 1_000,
+						},
+						{
+							PubKeyBytes:  dest,
+							ChannelID:    lastChan,
+							AmtToForward: 1_000,
+						},
+					},
+				},
+			},
+		}
+		if settled {
+			attempt.Settle = &channeldb.HTLCSettleInfo{
+				SettleTime: settleTime,
+			}
+		}
+
+		return attempt
+	}
+
+	payment := &channeldb.MPPayment{
+		HTLCs: []channeldb.HTLCAttempt{
+			newAttempt(self, 2, true),
+			newAttempt(self, 2, true),
+			newAttempt(self, 3, false),
+			newAttempt(other, 4, true),
+		},
+	}
+
+	costs := make(map[lnwire.ShortChannelID]lnwire.MilliSatoshi)
+	addRebalanceCosts(
+		costs, payment, self, settleTime.Add(-time.Hour),
+		settleTime.Add(time.Hour),
+	)
+	require.Equal(t, map[lnwire.ShortChannelID]lnwire.MilliSatoshi{
+		lnwire.NewShortChanIDFromInt(2): 100,
+	}, costs)
+
+	// Attempts that were settled outside of the window are ignored.
+	costs = make(map[lnwire.ShortChannelID]lnwire.MilliSatoshi)
+	addRebalanceCosts(
+		costs, payment, self, settleTime.Add(time.Second),
+		settleTime.Add(time.Hour),
+	)
+	require.Empty(t, costs)
+}
+
+// TestMarshalFundingEstimate makes sure the fee rate of a funding estimate is
+// returned in sat/vbyte.
+func TestMarshalFundingEstimate(t *testing.T) {
+	t.Parallel()
+
+	estimate := &lnwallet.FundingEstimate{
+		ChangeAmt:       400_000,
+		LocalFundingAmt: 500_000,
+		Capacity:        500_000,
+		Fee:             1_540,
+		Weight:          616,
+		FeeRate:         chainfee.SatPerKVByte(10_000).FeePerKWeight(),
+	}
+
+	resp, err := marshalFundingEstimate(
+		estimate, &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+	require.NotZero(t, resp.SatPerVbyte)
+	require.EqualValues(t, 10, resp.SatPerVbyte)
+	require.EqualValues(t, estimate.Fee, resp.FeeSat)
+	require.EqualValues(t, estimate.Weight, resp.Weight)
+	require.EqualValues(t, estimate.Capacity, resp.Capacity)
 }
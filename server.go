@@ -9,6 +9,8 @@ import (
 	"math/big"
 	prand "math/rand"
 	"net"
+	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -57,6 +59,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet/chanfunding"
 	"github.com/lightningnetwork/lnd/lnwallet/rpcwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/monitoring"
 	"github.com/lightningnetwork/lnd/nat"
 	"github.com/lightningnetwork/lnd/netann"
 	"github.com/lightningnetwork/lnd/peer"
@@ -66,6 +69,7 @@ import (
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/routing/localchans"
 	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/sqldb"
 	"github.com/lightningnetwork/lnd/subscribe"
 	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/lightningnetwork/lnd/ticker"
@@ -170,6 +174,13 @@ type server struct {
 
 	chanStatusMgr *netann.ChanStatusManager
 
+	policyScheduler *netann.PolicyScheduler
+
+	// maxHTLCMgr keeps the max_htlc of our channels at a percentage of
+	// their spendable balance. It is nil if routing.maxhtlcpercent isn't
+	// set.
+	maxHTLCMgr *netann.MaxHTLCManager
+
 	// listenAddrs is the list of addresses the server is currently
 	// listening on.
 	listenAddrs []net.Addr
@@ -209,6 +220,10 @@ type server struct {
 	persistentConnReqs     map[string][]*connmgr.ConnReq
 	persistentRetryCancels map[string]chan struct{}
 
+	// peerConnPolicies holds the connection policies of peers, keyed by
+	// the string of their compressed pubkey.
+	peerConnPolicies map[string]*peerConnPolicy
+
 	// peerErrors keeps a set of peer error buffers for peers that have
 	// disconnected from us. This allows us to track historic peer errors
 	// over connections. The string of the peer's compressed pubkey is used
@@ -238,10 +253,32 @@ type server struct {
 
 	fundingMgr *funding.Manager
 
+	// acceptorPolicy evaluates inbound channel requests against the
+	// channel acceptor policy configured over RPC. It is nil unless the
+	// acceptor policy is enabled in the config.
+	acceptorPolicy *funding.PolicyAcceptor
+
 	graphDB *channeldb.ChannelGraph
 
+	// graphSQLStore is the native SQL store the channel graph is mirrored
+	// to. This is nil if dual-write mode isn't enabled.
+	graphSQLStore *channeldb.SQLGraphStore
+
 	chanStateDB *channeldb.ChannelStateDB
 
+	// chanStateSQLStore is the native SQL store the channel state is
+	// mirrored to. This is nil if dual-write mode isn't enabled.
+	chanStateSQLStore *channeldb.SQLChannelStore
+
+	// nativeSQLStore is the native SQL store of the node. This is nil if
+	// the use-native-sql flag isn't set.
+	nativeSQLStore *sqldb.BaseDB
+
+	// dbBackup writes a consistent snapshot of the SQL databases to a
+	// directory. This is nil if lnd doesn't run with a SQL database
+	// backend.
+	dbBackup func(ctx context.Context, destDir string) ([]string, error)
+
 	addrSource chanbackup.AddressSource
 
 	// miscDB is the DB that contains all "other" databases within the main
@@ -250,6 +287,9 @@ type server struct {
 
 	invoicesDB invoices.InvoiceDB
 
+	// fwdingLog is the store of the forwarding events of the switch.
+	fwdingLog channeldb.ForwardingEventStore
+
 	aliasMgr *aliasmgr.Manager
 
 	htlcSwitch *htlcswitch.Switch
@@ -270,6 +310,10 @@ type server struct {
 
 	missionControl *routing.MissionControl
 
+	// missionControlMgr manages the mission control namespaces. The
+	// mission control of the default namespace is missionControl.
+	missionControlMgr *routing.MissionControlManager
+
 	chanRouter *routing.ChannelRouter
 
 	controlTower routing.ControlTower
@@ -316,6 +360,15 @@ type server struct {
 	// channelNotifier to be notified of newly opened and closed channels.
 	chanSubSwapper *chanbackup.SubSwapper
 
+	// scbReplicator pushes every update of the channel backup file to the
+	// configured remote targets. It is nil if no targets are configured.
+	scbReplicator *chanbackup.ReplicatingSwapper
+
+	// peerStorage distributes every update of the channel backup to our
+	// peers that store backups for us. It is nil if peer storage is
+	// disabled.
+	peerStorage *chanbackup.PeerStorageSwapper
+
 	// chanEventStore tracks the behaviour of channels and their remote peers to
 	// provide insights into their health and performance.
 	chanEventStore *chanfitness.ChannelEventStore
@@ -327,6 +380,8 @@ type server struct {
 
 	customMessageServer *subscribe.Server
 
+	onionMessageServer *subscribe.Server
+
 	// txPublisher is a publisher with fee-bumping capability.
 	txPublisher *sweep.TxPublisher
 
@@ -426,6 +481,19 @@ type CustomMessage struct {
 	Msg *lnwire.Custom
 }
 
+// OnionMessage is an onion message that is received from a peer and that is
+// addressed to us.
+type OnionMessage struct {
+	// Peer is the pubkey of the peer that sent us the message.
+	Peer [33]byte
+
+	// Msg is the onion wire message.
+	Msg *lnwire.OnionMessage
+
+	// Processed is the decrypted final hop payload of the message.
+	Processed *hop.ProcessedOnionMessage
+}
+
 // parseAddr parses an address from its string format to a net.Addr.
 func parseAddr(address string, netCfg tor.Net) (net.Addr, error) {
 	var (
@@ -550,6 +618,11 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		CustomFeatures:           cfg.ProtocolOptions.CustomFeatures(),
 		NoTaprootChans:           !cfg.ProtocolOptions.TaprootChans,
 		NoRouteBlinding:          cfg.ProtocolOptions.NoRouteBlinding(),
+		NoOnionMessages:          cfg.ProtocolOptions.NoOnionMessages(),
+		NoPeerStorage:            cfg.ProtocolOptions.NoPeerStorage(),
+		NoRbfCoopClose:           !cfg.ProtocolOptions.RbfCoopCloseEnabled(),
+		NoSplitCloseOutputs:      !cfg.ProtocolOptions.SplitCloseOutputsEnabled(),
+		NoPtlc:                   !cfg.ProtocolOptions.PtlcEnabled(),
 	})
 	if err != nil {
 		return nil, err
@@ -573,12 +646,18 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		addrSource:     dbs.ChanStateDB,
 		miscDB:         dbs.ChanStateDB,
 		invoicesDB:     dbs.InvoiceDB,
+		fwdingLog:      dbs.ForwardingLog,
 		cc:             cc,
 		sigPool:        lnwallet.NewSigPool(cfg.Workers.Sig, cc.Signer),
 		writePool:      writePool,
 		readPool:       readPool,
 		chansToRestore: chansToRestore,
 
+		chanStateSQLStore: dbs.ChanStateSQLStore,
+		graphSQLStore:     dbs.GraphSQLStore,
+		nativeSQLStore:    dbs.NativeSQLStore,
+		dbBackup:          dbs.Backup,
+
 		channelNotifier: channelnotifier.New(
 			dbs.ChanStateDB.ChannelStateDB(),
 		),
@@ -600,6 +679,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		persistentConnReqs:      make(map[string][]*connmgr.ConnReq),
 		persistentPeerAddrs:     make(map[string][]*lnwire.NetAddress),
 		persistentRetryCancels:  make(map[string]chan struct{}),
+		peerConnPolicies:        make(map[string]*peerConnPolicy),
 		peerErrors:              make(map[string]*queue.CircularBuffer),
 		ignorePeerTermination:   make(map[*peer.Brontide]struct{}),
 		scheduledPeerConnection: make(map[string]func()),
@@ -612,6 +692,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		peerDisconnectedListeners: make(map[string][]chan<- struct{}),
 
 		customMessageServer: subscribe.NewServer(),
+		onionMessageServer:  subscribe.NewServer(),
 
 		tlsManager: tlsManager,
 
@@ -626,8 +707,22 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 	expiryWatcher := invoices.NewInvoiceExpiryWatcher(
 		clock.NewDefaultClock(), cfg.Invoices.HoldExpiryDelta,
-		uint32(currentHeight), currentHash, cc.ChainNotifier,
+		cfg.Invoices.HoldExpiryGracePeriod, uint32(currentHeight),
+		currentHash, cc.ChainNotifier,
 	)
+
+	// The sender allowlist of the keysend policy is checked against the
+	// peer of the channel a spontaneous payment arrives over.
+	registryConfig.ChannelPeer = func(
+		scid lnwire.ShortChannelID) (route.Vertex, error) {
+
+		link, err := s.htlcSwitch.GetLinkByShortID(scid)
+		if err != nil {
+			return route.Vertex{}, err
+		}
+
+		return route.Vertex(link.PeerPubKey()), nil
+	}
 	s.invoices = invoices.NewRegistry(
 		dbs.InvoiceDB, expiryWatcher, &registryConfig,
 	)
@@ -661,7 +756,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 			peer.HandleLocalCloseChanReqs(request)
 		},
-		FwdingLog:              dbs.ChanStateDB.ForwardingLog(),
+		FwdingLog:              dbs.ForwardingLog,
 		SwitchPackager:         channeldb.NewSwitchPackager(),
 		ExtractErrorEncrypter:  s.sphinx.ExtractErrorEncrypter,
 		FetchLastChannelUpdate: s.fetchLastChanUpdate(),
@@ -677,10 +772,27 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		DustThreshold:          thresholdMSats,
 		SignAliasUpdate:        s.signAliasUpdate,
 		IsAlias:                aliasmgr.IsAlias,
+		ResolutionPeriod:       cfg.Htlcswitch.ResolutionPeriod,
+		GeneralSlots:           cfg.Htlcswitch.GeneralSlots,
 	}, uint32(currentHeight))
 	if err != nil {
 		return nil, err
 	}
+
+	// Restore the forwarding limits that were set for our channels.
+	fwdLimits, err := s.chanStateDB.FetchAllForwardingLimits()
+	if err != nil {
+		return nil, err
+	}
+	s.htlcSwitch.UpdateForwardingLimits(fwdLimits)
+
+	// Restore the dust exposure policies that were set for our channels.
+	dustPolicies, err := s.chanStateDB.FetchAllDustExposurePolicies()
+	if err != nil {
+		return nil, err
+	}
+	s.htlcSwitch.UpdateDustExposurePolicies(dustPolicies)
+
 	s.interceptableSwitch, err = htlcswitch.NewInterceptableSwitch(
 		&htlcswitch.InterceptableSwitchConfig{
 			Switch:             s.htlcSwitch,
@@ -708,8 +820,14 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		MessageSigner:            s.nodeSigner,
 		IsChannelActive:          s.htlcSwitch.HasActiveLink,
 		ApplyChannelUpdate:       s.applyChannelUpdate,
+		NotifyForwardingStatus:   s.channelNotifier.NotifyForwardingStatusEvent,
 		DB:                       s.chanStateDB,
 		Graph:                    dbs.GraphDB.ChannelGraph(),
+
+		MaxForwardingFailures:     cfg.ChanDisableForwardingFailures,
+		FailureReenableTimeout:    cfg.ChanFailureReenableTimeout,
+		MaxFailureReenableTimeout: cfg.ChanFailureReenableMaxTimeout,
+		NotifyFailureDisabled:     s.channelNotifier.NotifyFailureDisabledChannelEvent,
 	}
 
 	chanStatusMgr, err := netann.NewChanStatusManager(chanStatusMgrCfg)
@@ -718,6 +836,43 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	}
 	s.chanStatusMgr = chanStatusMgr
 
+	// Gradual policy updates are spaced out by the interval in which our
+	// peers accept new updates for the same channel, so that none of the
+	// intermediate updates are rate limited.
+	rampStepInterval := cfg.Gossip.ChannelUpdateInterval
+	if rampStepInterval <= 0 {
+		rampStepInterval = discovery.DefaultChannelUpdateInterval
+	}
+	s.policyScheduler, err = netann.NewPolicyScheduler(
+		&netann.PolicySchedulerConfig{
+			OurPubKey:    nodeKeyDesc.PubKey,
+			Graph:        dbs.GraphDB.ChannelGraph(),
+			StepInterval: rampStepInterval,
+			Clock:        clock.NewDefaultClock(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Routing.MaxHtlcPercent != 0 {
+		s.maxHTLCMgr, err = netann.NewMaxHTLCManager(
+			&netann.MaxHTLCManagerConfig{
+				OurPubKey:        nodeKeyDesc.PubKey,
+				Graph:            dbs.GraphDB.ChannelGraph(),
+				FetchBalances:    s.fetchChannelBalances,
+				ApplyMaxHTLC:     s.applyMaxHTLC,
+				Percent:          cfg.Routing.MaxHtlcPercent,
+				MinChangePercent: cfg.Routing.MaxHtlcMinChange,
+				Interval:         cfg.Routing.MaxHtlcInterval,
+				Clock:            clock.NewDefaultClock(),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// If enabled, use either UPnP or NAT-PMP to automatically configure
 	// port forwarding for users behind a NAT.
 	if cfg.NAT {
@@ -929,13 +1084,16 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		MaxMcHistory:            routingConfig.MaxMcHistory,
 		McFlushInterval:         routingConfig.McFlushInterval,
 		MinFailureRelaxInterval: routing.DefaultMinFailureRelaxInterval,
+		PairStore:               dbs.MissionControlPairStore,
+		HistoryHalfLife:         routingConfig.McHistoryHalfLife,
 	}
-	s.missionControl, err = routing.NewMissionControl(
+	s.missionControlMgr, err = routing.NewMissionControlManager(
 		dbs.ChanStateDB, selfNode.PubKeyBytes, mcCfg,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("can't create mission control: %w", err)
 	}
+	s.missionControl = s.missionControlMgr.DefaultMissionControl()
 
 	srvrLog.Debugf("Instantiating payment session source with config: "+
 		"AttemptCost=%v + %v%%, MinRouteProbability=%v",
@@ -962,6 +1120,10 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		GetLink:           s.htlcSwitch.GetLinkByShortID,
 		PathFindingConfig: pathFindingConfig,
 	}
+	if cfg.Prometheus.Enabled() {
+		paymentSessionSource.ObservePathFinding =
+			monitoring.ObservePathFinding
+	}
 
 	paymentControl := channeldb.NewPaymentControl(dbs.ChanStateDB)
 
@@ -969,25 +1131,41 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 	strictPruning := (cfg.Bitcoin.Node == "neutrino" ||
 		cfg.Routing.StrictZombiePruning)
+
+	// In strict graph validation mode, neutrino nodes bound the number of
+	// blocks fetched concurrently to validate announced channels and cache
+	// the fetched funding transactions.
+	var maxChanValidations, fundingTxCacheSize int
+	if cfg.Bitcoin.Node == "neutrino" &&
+		cfg.NeutrinoMode.StrictGraphValidation {
+
+		maxChanValidations = cfg.NeutrinoMode.GraphValidationWorkers
+		fundingTxCacheSize = cfg.NeutrinoMode.GraphValidationCacheSize
+	}
+
 	s.chanRouter, err = routing.New(routing.Config{
-		Graph:               chanGraph,
-		Chain:               cc.ChainIO,
-		ChainView:           cc.ChainView,
-		Notifier:            cc.ChainNotifier,
-		Payer:               s.htlcSwitch,
-		Control:             s.controlTower,
-		MissionControl:      s.missionControl,
-		SessionSource:       paymentSessionSource,
-		ChannelPruneExpiry:  routing.DefaultChannelPruneExpiry,
-		GraphPruneInterval:  time.Hour,
-		FirstTimePruneDelay: routing.DefaultFirstTimePruneDelay,
-		GetLink:             s.htlcSwitch.GetLinkByShortID,
-		AssumeChannelValid:  cfg.Routing.AssumeChannelValid,
-		NextPaymentID:       sequencer.NextID,
-		PathFindingConfig:   pathFindingConfig,
-		Clock:               clock.NewDefaultClock(),
-		StrictZombiePruning: strictPruning,
-		IsAlias:             aliasmgr.IsAlias,
+		Graph:                        chanGraph,
+		Chain:                        cc.ChainIO,
+		ChainView:                    cc.ChainView,
+		Notifier:                     cc.ChainNotifier,
+		Payer:                        s.htlcSwitch,
+		Control:                      s.controlTower,
+		MissionControl:               s.missionControl,
+		SessionSource:                paymentSessionSource,
+		ChannelPruneExpiry:           cfg.Routing.ZombieHorizon,
+		NewChanPruneExpiry:           cfg.Routing.NewChanZombieHorizon,
+		NewChanAge:                   cfg.Routing.NewChanAge,
+		GraphPruneInterval:           cfg.Routing.GraphPruneInterval,
+		FirstTimePruneDelay:          routing.DefaultFirstTimePruneDelay,
+		GetLink:                      s.htlcSwitch.GetLinkByShortID,
+		AssumeChannelValid:           cfg.Routing.AssumeChannelValid,
+		NextPaymentID:                sequencer.NextID,
+		MaxConcurrentChanValidations: maxChanValidations,
+		FundingTxCacheSize:           fundingTxCacheSize,
+		PathFindingConfig:            pathFindingConfig,
+		Clock:                        clock.NewDefaultClock(),
+		StrictZombiePruning:          strictPruning,
+		IsAlias:                      aliasmgr.IsAlias,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %w", err)
@@ -1040,12 +1218,14 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		GetAlias:                s.aliasMgr.GetPeerAlias,
 		FindChannel:             s.findChannel,
 		IsStillZombieChannel:    s.chanRouter.IsZombieChannel,
+		PeerRateLimit:           cfg.Gossip.PeerRateLimit,
 	}, nodeKeyDesc)
 
 	s.localChanMgr = &localchans.Manager{
 		ForAllOutgoingChannels:    s.chanRouter.ForAllOutgoingChannels,
 		PropagateChanPolicyUpdate: s.authGossiper.PropagateChanPolicyUpdate,
 		UpdateForwardingPolicies:  s.htlcSwitch.UpdateForwardingPolicies,
+		NotifyPolicyUpdate:        s.channelNotifier.NotifyPolicyUpdateEvent,
 		FetchChannel:              s.chanStateDB.FetchChannel,
 	}
 
@@ -1067,6 +1247,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 	aggregator := sweep.NewBudgetAggregator(
 		cc.FeeEstimator, sweep.DefaultMaxInputsPerTx,
+		cfg.Sweeper.HTLCAggregationWindow,
 	)
 
 	s.txPublisher = sweep.NewTxPublisher(sweep.TxPublisherConfig{
@@ -1076,7 +1257,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		Notifier:  cc.ChainNotifier,
 	})
 
-	s.sweeper = sweep.New(&sweep.UtxoSweeperConfig{
+	sweeperCfg := &sweep.UtxoSweeperConfig{
 		FeeEstimator:         cc.FeeEstimator,
 		GenSweepScript:       newSweepPkScriptGen(cc.Wallet),
 		Signer:               cc.Wallet.Cfg.Signer,
@@ -1089,7 +1270,12 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		Aggregator:           aggregator,
 		Publisher:            s.txPublisher,
 		NoDeadlineConfTarget: cfg.Sweeper.NoDeadlineConfTarget,
-	})
+	}
+	if cfg.Prometheus.Enabled() {
+		sweeperCfg.NotifySweepPublished =
+			monitoring.ObserveSweepPublished
+	}
+	s.sweeper = sweep.New(sweeperCfg)
 
 	s.utxoNursery = contractcourt.NewUtxoNursery(&contractcourt.NurseryConfig{
 		ChainIO:             cc.ChainIO,
@@ -1112,7 +1298,9 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		// Instruct the switch to close the channel.  Provide no close out
 		// delivery script or target fee per kw because user input is not
 		// available when the remote peer closes the channel.
-		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, 0, nil)
+		s.htlcSwitch.CloseLink(
+			chanPoint, closureType, 0, 0, nil, nil,
+		)
 	}
 
 	// We will use the following channel to reliably hand off contract
@@ -1221,6 +1409,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		Sweeper:                       s.sweeper,
 		Registry:                      s.invoices,
 		NotifyClosedChannel:           s.channelNotifier.NotifyClosedChannelEvent,
+		NotifyCloseConfirmed:          s.channelNotifier.NotifyCloseConfirmedEvent,
 		NotifyFullyResolvedChannel:    s.channelNotifier.NotifyFullyResolvedChannelEvent,
 		OnionProcessor:                s.sphinx,
 		PaymentsExpirationGracePeriod: cfg.PaymentsExpirationGracePeriod,
@@ -1490,14 +1679,36 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		EnableUpfrontShutdown:         cfg.EnableUpfrontShutdown,
 		MaxAnchorsCommitFeeRate: chainfee.SatPerKVByte(
 			s.cfg.MaxCommitFeeRateAnchors * 1000).FeePerKWeight(),
-		DeleteAliasEdge:   deleteAliasEdge,
-		AliasManager:      s.aliasMgr,
-		IsSweeperOutpoint: s.sweeper.IsSweeperOutpoint,
+		DeleteAliasEdge:            deleteAliasEdge,
+		AliasManager:               s.aliasMgr,
+		IsSweeperOutpoint:          s.sweeper.IsSweeperOutpoint,
+		InitiatorFundingTimeout:    cfg.Funding.InitiatorTimeout,
+		ResponderFundingTimeout:    cfg.Funding.ResponderTimeout,
+		DoubleSpendTimedOutFunding: cfg.Funding.DoubleSpendOnTimeout,
+		NotifyFundingTimeoutEvent:  s.channelNotifier.NotifyFundingTimeoutEvent,
+		AuxFundingControllers:      funding.RegisteredAuxFundingControllers(),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// If enabled, create the acceptor that enforces the channel acceptor
+	// policy. It starts out with an empty policy that accepts all
+	// channels.
+	if cfg.AcceptorPolicy {
+		s.acceptorPolicy = funding.NewPolicyAcceptor(
+			funding.PolicyAcceptorConfig{
+				FindPeer: func(pub *btcec.PublicKey) (
+					lnpeer.Peer, error) {
+
+					return s.FindPeer(pub)
+				},
+				FetchPendingChannels: s.chanStateDB.
+					FetchPendingChannels,
+			},
+		)
+	}
+
 	// Next, we'll assemble the sub-system that will maintain an on-disk
 	// static backup of the latest channel state.
 	chanNotifier := &channelNotifier{
@@ -1511,8 +1722,45 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	if err != nil {
 		return nil, err
 	}
+
+	// If any remote targets are configured, we'll wrap the backup file so
+	// that every update of it is replicated to those targets.
+	var backupSwapper chanbackup.Swapper = backupFile
+	scbReplicators, err := newSCBReplicators(cfg.SCBReplication)
+	if err != nil {
+		return nil, err
+	}
+	if len(scbReplicators) > 0 {
+		s.scbReplicator = chanbackup.NewReplicatingSwapper(
+			backupFile, &chanbackup.ReplicationConfig{
+				Replicators: scbReplicators,
+				Timeout:     cfg.SCBReplication.Timeout,
+				MinBackoff:  cfg.SCBReplication.MinBackoff,
+				MaxBackoff:  cfg.SCBReplication.MaxBackoff,
+			},
+		)
+		backupSwapper = s.scbReplicator
+	}
+
+	// Unless peer storage is disabled, we'll also hand every update of
+	// the backup to our peers, which return it whenever we reconnect.
+	if !cfg.ProtocolOptions.NoPeerStorage() {
+		recoveredBackup := chanbackup.NewMultiFile(filepath.Join(
+			filepath.Dir(cfg.BackupFilePath),
+			chanbackup.DefaultRecoveredBackupFileName,
+		))
+		s.peerStorage = chanbackup.NewPeerStorageSwapper(
+			backupSwapper, &chanbackup.PeerStorageConfig{
+				KeyRing:         s.cc.KeyRing,
+				BroadcastBackup: s.broadcastPeerStorage,
+				RecoveredBackup: recoveredBackup,
+			},
+		)
+		backupSwapper = s.peerStorage
+	}
+
 	s.chanSubSwapper, err = chanbackup.NewSubSwapper(
-		startingChans, chanNotifier, s.cc.KeyRing, backupFile,
+		startingChans, chanNotifier, s.cc.KeyRing, backupSwapper,
 	)
 	if err != nil {
 		return nil, err
@@ -1535,6 +1783,8 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		ReadFlapCount:   s.miscDB.ReadFlapCount,
 		WriteFlapCount:  s.miscDB.WriteFlapCounts,
 		FlapCountTicker: ticker.New(chanfitness.FlapCountFlushRate),
+		NotifyFlappingChannel: s.channelNotifier.
+			NotifyFlappingChannelEvent,
 	})
 
 	if cfg.WtClient.Active {
@@ -1606,6 +1856,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			FetchClosedChannel:     fetchClosedChannel,
 			BuildBreachRetribution: buildBreachRetribution,
 			SessionCloseRange:      cfg.WtClient.SessionCloseRange,
+			HealthCheckInterval:    cfg.WtClient.HealthCheckInterval,
 			ChainNotifier:          s.cc.ChainNotifier,
 			SubscribeChannelEvents: func() (subscribe.Subscription,
 				error) {
@@ -1875,6 +2126,12 @@ func (s *server) Start() error {
 		}
 		cleanup = cleanup.add(s.customMessageServer.Stop)
 
+		if err := s.onionMessageServer.Start(); err != nil {
+			startErr = err
+			return
+		}
+		cleanup = cleanup.add(s.onionMessageServer.Stop)
+
 		if s.hostAnn != nil {
 			if err := s.hostAnn.Start(); err != nil {
 				startErr = err
@@ -2037,6 +2294,34 @@ func (s *server) Start() error {
 		}
 		cleanup = cleanup.add(s.chanStatusMgr.Stop)
 
+		// If channels are disabled after consecutive forwarding
+		// failures, we'll report the results of our forwards to the
+		// channel status manager.
+		if s.cfg.ChanDisableForwardingFailures > 0 {
+			htlcClient, err := s.htlcNotifier.SubscribeHtlcEvents()
+			if err != nil {
+				startErr = err
+				return
+			}
+
+			s.wg.Add(1)
+			go s.watchForwardingResults(htlcClient)
+		}
+
+		if err := s.policyScheduler.Start(); err != nil {
+			startErr = err
+			return
+		}
+		cleanup = cleanup.add(s.policyScheduler.Stop)
+
+		if s.maxHTLCMgr != nil {
+			if err := s.maxHTLCMgr.Start(); err != nil {
+				startErr = err
+				return
+			}
+			cleanup = cleanup.add(s.maxHTLCMgr.Stop)
+		}
+
 		if err := s.chanEventStore.Start(); err != nil {
 			startErr = err
 			return
@@ -2046,9 +2331,9 @@ func (s *server) Start() error {
 			return nil
 		})
 
-		s.missionControl.RunStoreTicker()
+		s.missionControlMgr.RunStoreTickers()
 		cleanup.add(func() error {
-			s.missionControl.StopStoreTicker()
+			s.missionControlMgr.StopStoreTickers()
 			return nil
 		})
 
@@ -2084,6 +2369,16 @@ func (s *server) Start() error {
 			}
 		}
 
+		// The replicator must be started before the chanSubSwapper, as
+		// the latter updates the backup file on startup.
+		if s.scbReplicator != nil {
+			if err := s.scbReplicator.Start(); err != nil {
+				startErr = err
+				return
+			}
+			cleanup = cleanup.add(s.scbReplicator.Stop)
+		}
+
 		if err := s.chanSubSwapper.Start(); err != nil {
 			startErr = err
 			return
@@ -2164,6 +2459,14 @@ func (s *server) Start() error {
 			startErr = err
 			return
 		}
+		if err := s.loadPeerConnPolicies(); err != nil {
+			startErr = err
+			return
+		}
+		if err := s.loadPeerGossipPolicies(); err != nil {
+			startErr = err
+			return
+		}
 		if err := s.establishPersistentConnections(); err != nil {
 			startErr = err
 			return
@@ -2264,6 +2567,15 @@ func (s *server) Stop() error {
 		if err := s.chanStatusMgr.Stop(); err != nil {
 			srvrLog.Warnf("failed to stop chanStatusMgr: %v", err)
 		}
+		if err := s.policyScheduler.Stop(); err != nil {
+			srvrLog.Warnf("failed to stop policyScheduler: %v", err)
+		}
+		if s.maxHTLCMgr != nil {
+			if err := s.maxHTLCMgr.Stop(); err != nil {
+				srvrLog.Warnf("failed to stop maxHTLCMgr: %v",
+					err)
+			}
+		}
 		if err := s.htlcSwitch.Stop(); err != nil {
 			srvrLog.Warnf("failed to stop htlcSwitch: %v", err)
 		}
@@ -2310,6 +2622,12 @@ func (s *server) Stop() error {
 		if err := s.chanSubSwapper.Stop(); err != nil {
 			srvrLog.Warnf("failed to stop chanSubSwapper: %v", err)
 		}
+		if s.scbReplicator != nil {
+			if err := s.scbReplicator.Stop(); err != nil {
+				srvrLog.Warnf("failed to stop scbReplicator: "+
+					"%v", err)
+			}
+		}
 		if err := s.cc.ChainNotifier.Stop(); err != nil {
 			srvrLog.Warnf("Unable to stop ChainNotifier: %v", err)
 		}
@@ -2318,7 +2636,7 @@ func (s *server) Stop() error {
 				err)
 		}
 		s.chanEventStore.Stop()
-		s.missionControl.StopStoreTicker()
+		s.missionControlMgr.StopStoreTickers()
 
 		// Disconnect from each active peers to ensure that
 		// peerTerminationWatchers signal completion to each peer.
@@ -2969,6 +3287,183 @@ func (s *server) findChannel(node *btcec.PublicKey, chanID lnwire.ChannelID) (
 	return nil, fmt.Errorf("unable to find channel")
 }
 
+// fetchChannelBalances returns the spendable balance of all channels with an
+// active link, keyed by their channel point.
+func (s *server) fetchChannelBalances() (
+	map[wire.OutPoint]netann.ChannelBalance, error) {
+
+	channels, err := s.chanStateDB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[wire.OutPoint]netann.ChannelBalance)
+	for _, channel := range channels {
+		if channel.IsPending {
+			continue
+		}
+
+		chanPoint := channel.FundingOutpoint
+		link, err := s.htlcSwitch.GetLink(
+			lnwire.NewChanIDFromOutPoint(chanPoint),
+		)
+		if err != nil {
+			continue
+		}
+
+		constraints := channel.LocalChanCfg.ChannelConstraints
+		balances[chanPoint] = netann.ChannelBalance{
+			Spendable:        link.Bandwidth(),
+			MaxPendingAmount: constraints.MaxPendingAmount,
+		}
+	}
+
+	return balances, nil
+}
+
+// applyMaxHTLC sets the max_htlc of the given channel, leaving the remaining
+// fields of its policy unchanged, and announces the updated policy.
+func (s *server) applyMaxHTLC(chanPoint wire.OutPoint,
+	maxHTLC lnwire.MilliSatoshi) error {
+
+	info, edge1, edge2, err := s.graphDB.FetchChannelEdgesByOutpoint(
+		&chanPoint,
+	)
+	if err != nil {
+		return err
+	}
+
+	update, err := netann.ExtractChannelUpdate(
+		s.identityECDH.PubKey().SerializeCompressed(), info, edge1,
+		edge2,
+	)
+	if err != nil {
+		return err
+	}
+
+	policy := routing.ChannelPolicy{
+		FeeSchema: routing.FeeSchema{
+			BaseFee:    lnwire.MilliSatoshi(update.BaseFee),
+			FeeRate:    update.FeeRate,
+			InboundFee: fn.None[models.InboundFee](),
+		},
+		TimeLockDelta: uint32(update.TimeLockDelta),
+		MaxHTLC:       maxHTLC,
+	}
+
+	failedUpdates, err := s.localChanMgr.UpdatePolicy(policy, chanPoint)
+	if err != nil {
+		return err
+	}
+
+	if len(failedUpdates) > 0 {
+		return fmt.Errorf("policy update failed: %v",
+			failedUpdates[0].UpdateError)
+	}
+
+	return nil
+}
+
+// watchForwardingResults reports the results of forwards over our channels to
+// the channel status manager, so that channels are temporarily disabled after
+// too many consecutive local forwarding failures.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *server) watchForwardingResults(client *subscribe.Client) {
+	defer s.wg.Done()
+	defer client.Cancel()
+
+	for {
+		var (
+			outgoing lnwire.ShortChannelID
+			success  bool
+		)
+
+		select {
+		case update := <-client.Updates():
+			switch event := update.(type) {
+			// The htlc was added to the outgoing channel.
+			case *htlcswitch.ForwardingEvent:
+				if event.HtlcEventType !=
+					htlcswitch.HtlcEventTypeForward {
+
+					continue
+				}
+
+				outgoing = event.OutgoingCircuit.ChanID
+				success = true
+
+			// The htlc couldn't be added to the outgoing channel.
+			case *htlcswitch.LinkFailEvent:
+				if event.HtlcEventType !=
+					htlcswitch.HtlcEventTypeForward ||
+					event.Incoming {
+
+					continue
+				}
+
+				if !isOutgoingChannelFailure(event.LinkError) {
+					continue
+				}
+
+				outgoing = event.OutgoingCircuit.ChanID
+
+			default:
+				continue
+			}
+
+		case <-client.Quit():
+			return
+
+		case <-s.quit:
+			return
+		}
+
+		link, err := s.htlcSwitch.GetLinkByShortID(outgoing)
+		if err != nil {
+			continue
+		}
+
+		s.chanStatusMgr.ReportForwardingResult(
+			link.ChannelPoint(), success,
+		)
+	}
+}
+
+// isOutgoingChannelFailure returns true if the given link error indicates that
+// the outgoing channel itself is unable to forward htlcs, rather than the htlc
+// violating the policy of the channel.
+func isOutgoingChannelFailure(linkErr *htlcswitch.LinkError) bool {
+	if linkErr == nil {
+		return false
+	}
+
+	switch linkErr.FailureDetail {
+	case htlcswitch.OutgoingFailureLinkNotEligible,
+		htlcswitch.OutgoingFailureDownstreamHtlcAdd,
+		htlcswitch.OutgoingFailureOnChainTimeout:
+
+		return true
+
+	default:
+		return false
+	}
+}
+
+// getChannelUptime returns the time the peer of the given channel was observed
+// online and the total time the channel was monitored by the channel event
+// store.
+func (s *server) getChannelUptime(chanPoint wire.OutPoint,
+	peer route.Vertex) (time.Duration, time.Duration, error) {
+
+	info, err := s.chanEventStore.GetChanInfo(chanPoint, peer)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return info.Uptime, info.Lifetime, nil
+}
+
 // getNodeAnnouncement fetches the current, fully signed node announcement.
 func (s *server) getNodeAnnouncement() lnwire.NodeAnnouncement {
 	s.mu.Lock()
@@ -3197,6 +3692,43 @@ func (s *server) establishPersistentConnections() error {
 		return err
 	}
 
+	// Finally, we'll also connect to the peers whose connection policy
+	// asks us to always reconnect, even if we don't have any channels with
+	// them. Their preferred address is added when connecting.
+	s.mu.RLock()
+	var alwaysReconnectPeers []string
+	for pubStr, policy := range s.peerConnPolicies {
+		if policy.AlwaysReconnect {
+			alwaysReconnectPeers = append(
+				alwaysReconnectPeers, pubStr,
+			)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, pubStr := range alwaysReconnectPeers {
+		if _, ok := nodeAddrsMap[pubStr]; ok {
+			continue
+		}
+
+		pubKey, err := btcec.ParsePubKey([]byte(pubStr))
+		if err != nil {
+			return err
+		}
+
+		addrs, err := s.fetchNodeAdvertisedAddrs(pubKey)
+		if err != nil && !errors.Is(err, errNoAdvertisedAddr) &&
+			!errors.Is(err, channeldb.ErrGraphNodeNotFound) {
+
+			return err
+		}
+
+		nodeAddrsMap[pubStr] = &nodeAddresses{
+			pubKey:    pubKey,
+			addresses: addrs,
+		}
+	}
+
 	srvrLog.Debugf("Establishing %v persistent connections on start",
 		len(nodeAddrsMap))
 
@@ -3213,8 +3745,10 @@ func (s *server) establishPersistentConnections() error {
 		// persistent connection with. We set the value to false to
 		// indicate that we should not continue to reconnect if the
 		// number of channels returns to zero, since this peer has not
-		// been requested as perm by the user.
-		s.persistentPeers[pubStr] = false
+		// been requested as perm by the user, unless its connection
+		// policy asks us to always reconnect.
+		policy, ok := s.peerConnPolicies[pubStr]
+		s.persistentPeers[pubStr] = ok && policy.AlwaysReconnect
 		if _, ok := s.persistentPeersBackoff[pubStr]; !ok {
 			s.persistentPeersBackoff[pubStr] = s.cfg.MinBackoff
 		}
@@ -3288,37 +3822,346 @@ func (s *server) prunePersistentPeerConnection(compressedPubKey [33]byte) {
 	s.mu.Unlock()
 }
 
-// BroadcastMessage sends a request to the server to broadcast a set of
-// messages to all peers other than the one specified by the `skips` parameter.
-// All messages sent via BroadcastMessage will be queued for lazy delivery to
-// the target peers.
-//
-// NOTE: This function is safe for concurrent access.
-func (s *server) BroadcastMessage(skips map[route.Vertex]struct{},
-	msgs ...lnwire.Message) error {
+// peerConnPolicy is the connection policy of a peer together with its
+// resolved preferred address.
+type peerConnPolicy struct {
+	*channeldb.PeerConnectionPolicy
 
-	// Filter out peers found in the skips map. We synchronize access to
-	// peersByPub throughout this process to ensure we deliver messages to
-	// exact set of peers present at the time of invocation.
-	s.mu.RLock()
-	peers := make([]*peer.Brontide, 0, len(s.peersByPub))
-	for pubStr, sPeer := range s.peersByPub {
-		if skips != nil {
-			if _, ok := skips[sPeer.PubKey()]; ok {
-				srvrLog.Tracef("Skipping %x in broadcast with "+
-					"pubStr=%x", sPeer.PubKey(), pubStr)
-				continue
-			}
-		}
+	// preferredAddr is the resolved preferred address of the peer. It is
+	// nil if the policy doesn't have a preferred address.
+	preferredAddr net.Addr
+}
 
-		peers = append(peers, sPeer)
+// isOnionAddr returns true if the given address is a Tor onion address.
+func isOnionAddr(addr net.Addr) bool {
+	_, ok := addr.(*tor.OnionAddr)
+	return ok
+}
+
+// newPeerConnPolicy validates the given connection policy and resolves its
+// preferred address.
+func (s *server) newPeerConnPolicy(
+	policy *channeldb.PeerConnectionPolicy) (*peerConnPolicy, error) {
+
+	if policy.TorOnly && !s.cfg.Tor.Active {
+		return nil, errors.New("tor only connections require tor to " +
+			"be active")
 	}
-	s.mu.RUnlock()
 
-	// Iterate over all known peers, dispatching a go routine to enqueue
-	// all messages to each of peers.
-	var wg sync.WaitGroup
-	for _, sPeer := range peers {
+	if policy.MaxBackoff != 0 && policy.MaxBackoff < s.cfg.MinBackoff {
+		return nil, fmt.Errorf("max backoff (%v) cannot be smaller "+
+			"than the min backoff (%v)", policy.MaxBackoff,
+			s.cfg.MinBackoff)
+	}
+
+	connPolicy := &peerConnPolicy{
+		PeerConnectionPolicy: policy,
+	}
+
+	if policy.PreferredAddress == "" {
+		return connPolicy, nil
+	}
+
+	addr, err := parseAddr(policy.PreferredAddress, s.cfg.net)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse preferred address: %w",
+			err)
+	}
+
+	if policy.TorOnly && !isOnionAddr(addr) {
+		return nil, errors.New("preferred address of a tor only peer " +
+			"must be an onion address")
+	}
+
+	connPolicy.preferredAddr = addr
+
+	return connPolicy, nil
+}
+
+// loadPeerConnPolicies loads the connection policies of all peers from the
+// database. A preferred address that can't be resolved is ignored, so that a
+// temporary resolution failure doesn't prevent us from starting.
+func (s *server) loadPeerConnPolicies() error {
+	policies, err := s.miscDB.ReadPeerConnectionPolicies()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for peer, policy := range policies {
+		connPolicy, err := s.newPeerConnPolicy(policy)
+		if err != nil {
+			srvrLog.Warnf("Ignoring preferred address of peer %v: "+
+				"%v", peer, err)
+
+			connPolicy = &peerConnPolicy{
+				PeerConnectionPolicy: policy,
+			}
+		}
+
+		s.peerConnPolicies[string(peer[:])] = connPolicy
+	}
+
+	return nil
+}
+
+// persistentConnAddrs returns the addresses we attempt to connect to for the
+// given persistent peer, taking its connection policy into account. The
+// preferred address of the peer comes first, and only onion addresses are
+// returned if the peer may only be reached over Tor.
+//
+// NOTE: This MUST be called with the server's mutex held.
+func (s *server) persistentConnAddrs(pubKeyStr string) []*lnwire.NetAddress {
+	policy, ok := s.peerConnPolicies[pubKeyStr]
+	if !ok {
+		return s.persistentPeerAddrs[pubKeyStr]
+	}
+
+	var (
+		addrs []*lnwire.NetAddress
+		seen  = make(map[string]struct{})
+	)
+	addAddr := func(addr *lnwire.NetAddress) {
+		if policy.TorOnly && !isOnionAddr(addr.Address) {
+			return
+		}
+
+		if _, ok := seen[addr.String()]; ok {
+			return
+		}
+		seen[addr.String()] = struct{}{}
+
+		addrs = append(addrs, addr)
+	}
+
+	if policy.preferredAddr != nil {
+		pubKey, err := btcec.ParsePubKey([]byte(pubKeyStr))
+		if err != nil {
+			srvrLog.Errorf("Unable to parse peer pubkey %x: %v",
+				pubKeyStr, err)
+		} else {
+			addAddr(&lnwire.NetAddress{
+				IdentityKey: pubKey,
+				Address:     policy.preferredAddr,
+				ChainNet:    s.cfg.ActiveNetParams.Net,
+			})
+		}
+	}
+
+	for _, addr := range s.persistentPeerAddrs[pubKeyStr] {
+		addAddr(addr)
+	}
+
+	return addrs
+}
+
+// UpdatePeerConnectionPolicy persists the connection policy of a peer and
+// applies it to the connection to the peer. An empty policy removes the
+// connection policy of the peer.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) UpdatePeerConnectionPolicy(pubKey *btcec.PublicKey,
+	policy *channeldb.PeerConnectionPolicy) error {
+
+	if pubKey.IsEqual(s.identityECDH.PubKey()) {
+		return errors.New("cannot set connection policy of self")
+	}
+
+	peer := route.NewVertex(pubKey)
+	pubStr := string(peer[:])
+
+	remove := *policy == channeldb.PeerConnectionPolicy{}
+
+	var connPolicy *peerConnPolicy
+	if !remove {
+		var err error
+		connPolicy, err = s.newPeerConnPolicy(policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Look up the advertised addresses of the peer before acquiring the
+	// lock, in case we need to start connecting to it.
+	advertisedAddrs, err := s.fetchNodeAdvertisedAddrs(pubKey)
+	if err != nil && !errors.Is(err, errNoAdvertisedAddr) &&
+		!errors.Is(err, channeldb.ErrGraphNodeNotFound) {
+
+		return err
+	}
+
+	// We also need to know whether we have channels with the peer in case
+	// it shouldn't be reconnected to anymore.
+	channels, err := s.chanStateDB.FetchOpenChannels(pubKey)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		err = s.miscDB.DeletePeerConnectionPolicy(peer)
+	} else {
+		err = s.miscDB.WritePeerConnectionPolicy(peer, policy)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+
+	oldPolicy, hadPolicy := s.peerConnPolicies[pubStr]
+	wasAlwaysReconnect := hadPolicy && oldPolicy.AlwaysReconnect
+	if remove {
+		delete(s.peerConnPolicies, pubStr)
+	} else {
+		s.peerConnPolicies[pubStr] = connPolicy
+	}
+
+	_, connectErr := s.findPeerByPubStr(pubStr)
+	connected := connectErr == nil
+
+	switch {
+	// The peer should always be reconnected to, so we mark it as a
+	// permanent persistent peer and start connecting to it if we aren't
+	// connected already.
+	case !remove && policy.AlwaysReconnect:
+		s.persistentPeers[pubStr] = true
+		if _, ok := s.persistentPeersBackoff[pubStr]; !ok {
+			s.persistentPeersBackoff[pubStr] = s.cfg.MinBackoff
+		}
+
+		chainNet := s.cfg.ActiveNetParams.Net
+		if len(s.persistentPeerAddrs[pubStr]) == 0 {
+			for _, addr := range advertisedAddrs {
+				s.persistentPeerAddrs[pubStr] = append(
+					s.persistentPeerAddrs[pubStr],
+					&lnwire.NetAddress{
+						IdentityKey: pubKey,
+						Address:     addr,
+						ChainNet:    chainNet,
+					},
+				)
+			}
+		}
+
+	// The peer was only kept because of its connection policy, so we'll
+	// fall back to the default behavior of only reconnecting while we
+	// have channels with it.
+	case wasAlwaysReconnect:
+		if _, ok := s.persistentPeers[pubStr]; ok {
+			s.persistentPeers[pubStr] = false
+		}
+	}
+
+	// Refresh the connection requests of the peer, so that they reflect
+	// the addresses of the new policy, if we're trying to connect to it.
+	_, persistent := s.persistentPeers[pubStr]
+	reconnect := persistent && !connected &&
+		(len(s.persistentConnReqs[pubStr]) > 0 ||
+			(!remove && policy.AlwaysReconnect))
+
+	s.mu.Unlock()
+
+	srvrLog.Infof("Updated connection policy of peer %v", peer)
+
+	if wasAlwaysReconnect && len(channels) == 0 &&
+		(remove || !policy.AlwaysReconnect) {
+
+		s.prunePersistentPeerConnection(peer)
+
+		return nil
+	}
+
+	if reconnect {
+		s.connectToPersistentPeer(pubStr)
+	}
+
+	return nil
+}
+
+// loadPeerGossipPolicies loads the gossip policies of all peers from the
+// database and hands them to the gossip sync manager.
+func (s *server) loadPeerGossipPolicies() error {
+	policies, err := s.miscDB.ReadPeerGossipPolicies()
+	if err != nil {
+		return err
+	}
+
+	syncMgr := s.authGossiper.SyncManager()
+	for peer, policy := range policies {
+		syncMgr.SetPeerGossipPolicy(peer, policy)
+	}
+
+	return nil
+}
+
+// UpdatePeerGossipPolicy persists the gossip policy of a peer and applies it
+// to the gossip exchange with the peer. An empty policy removes the gossip
+// policy of the peer.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) UpdatePeerGossipPolicy(pubKey *btcec.PublicKey,
+	policy *channeldb.PeerGossipPolicy) error {
+
+	if pubKey.IsEqual(s.identityECDH.PubKey()) {
+		return errors.New("cannot set gossip policy of self")
+	}
+
+	peer := route.NewVertex(pubKey)
+	syncMgr := s.authGossiper.SyncManager()
+
+	if *policy == (channeldb.PeerGossipPolicy{}) {
+		if err := s.miscDB.DeletePeerGossipPolicy(peer); err != nil {
+			return err
+		}
+
+		syncMgr.SetPeerGossipPolicy(peer, nil)
+	} else {
+		err := s.miscDB.WritePeerGossipPolicy(peer, policy)
+		if err != nil {
+			return err
+		}
+
+		syncMgr.SetPeerGossipPolicy(peer, policy)
+	}
+
+	srvrLog.Infof("Updated gossip policy of peer %v", peer)
+
+	return nil
+}
+
+// BroadcastMessage sends a request to the server to broadcast a set of
+// messages to all peers other than the one specified by the `skips` parameter.
+// All messages sent via BroadcastMessage will be queued for lazy delivery to
+// the target peers.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) BroadcastMessage(skips map[route.Vertex]struct{},
+	msgs ...lnwire.Message) error {
+
+	// Filter out peers found in the skips map. We synchronize access to
+	// peersByPub throughout this process to ensure we deliver messages to
+	// exact set of peers present at the time of invocation.
+	s.mu.RLock()
+	peers := make([]*peer.Brontide, 0, len(s.peersByPub))
+	for pubStr, sPeer := range s.peersByPub {
+		if skips != nil {
+			if _, ok := skips[sPeer.PubKey()]; ok {
+				srvrLog.Tracef("Skipping %x in broadcast with "+
+					"pubStr=%x", sPeer.PubKey(), pubStr)
+				continue
+			}
+		}
+
+		peers = append(peers, sPeer)
+	}
+	s.mu.RUnlock()
+
+	// Iterate over all known peers, dispatching a go routine to enqueue
+	// all messages to each of peers.
+	var wg sync.WaitGroup
+	for _, sPeer := range peers {
 		srvrLog.Debugf("Sending %v messages to peer %x", len(msgs),
 			sPeer.PubKey())
 
@@ -3471,11 +4314,19 @@ func (s *server) nextPeerBackoff(pubStr string,
 		return s.cfg.MinBackoff
 	}
 
+	// The connection policy of the peer may lower the ceiling of the
+	// backoff.
+	maxBackoff := s.cfg.MaxBackoff
+	policy, ok := s.peerConnPolicies[pubStr]
+	if ok && policy.MaxBackoff != 0 {
+		maxBackoff = policy.MaxBackoff
+	}
+
 	// If the peer failed to start properly, we'll just use the previous
 	// backoff to compute the subsequent randomized exponential backoff
 	// duration. This will roughly double on average.
 	if startTime.IsZero() {
-		return computeNextBackoff(backoff, s.cfg.MaxBackoff)
+		return computeNextBackoff(backoff, maxBackoff)
 	}
 
 	// The peer succeeded in starting. If the connection didn't last long
@@ -3483,7 +4334,7 @@ func (s *server) nextPeerBackoff(pubStr string,
 	// with this peer.
 	connDuration := time.Since(startTime)
 	if connDuration < defaultStableConnDuration {
-		return computeNextBackoff(backoff, s.cfg.MaxBackoff)
+		return computeNextBackoff(backoff, maxBackoff)
 	}
 
 	// The peer succeed in starting and this was stable peer, so we'll
@@ -3491,7 +4342,7 @@ func (s *server) nextPeerBackoff(pubStr string,
 	// applying randomized exponential backoff. We'll only apply this in the
 	// case that:
 	//   reb(curBackoff) - connDuration > cfg.MinBackoff
-	relaxedBackoff := computeNextBackoff(backoff, s.cfg.MaxBackoff) - connDuration
+	relaxedBackoff := computeNextBackoff(backoff, maxBackoff) - connDuration
 	if relaxedBackoff > s.cfg.MinBackoff {
 		return relaxedBackoff
 	}
@@ -3789,6 +4640,171 @@ func (s *server) SubscribeCustomMessages() (*subscribe.Client, error) {
 	return s.customMessageServer.Subscribe()
 }
 
+// handleOnionMessage processes an incoming onion message. Messages that are
+// addressed to us are dispatched to subscribers, all others are relayed to the
+// next hop of their path.
+func (s *server) handleOnionMessage(peer [33]byte,
+	msg *lnwire.OnionMessage) error {
+
+	processed, err := s.sphinx.ProcessOnionMessage(msg)
+	if err != nil {
+		return fmt.Errorf("unable to process onion message: %w", err)
+	}
+
+	if processed.IsFinal {
+		srvrLog.Debugf("Onion message received: peer=%x", peer)
+
+		return s.onionMessageServer.SendUpdate(&OnionMessage{
+			Peer:      peer,
+			Msg:       msg,
+			Processed: processed,
+		})
+	}
+
+	// If the next hop is given by a channel, we relay the message to the
+	// peer of that channel.
+	var nextPeer [33]byte
+	switch {
+	case processed.NextNode != nil:
+		copy(nextPeer[:], processed.NextNode.SerializeCompressed())
+
+	default:
+		nextChan := processed.NextChannel
+		link, err := s.htlcSwitch.GetLinkByShortID(nextChan)
+		if err != nil {
+			return fmt.Errorf("unknown onion message channel "+
+				"%v: %w", nextChan, err)
+		}
+		nextPeer = link.PeerPubKey()
+	}
+
+	// Onion messages are only relayed to connected peers, we don't
+	// establish new connections for them.
+	target, err := s.FindPeerByPubStr(string(nextPeer[:]))
+	if err != nil {
+		return fmt.Errorf("onion message peer %x not connected: %w",
+			nextPeer, err)
+	}
+
+	if !target.RemoteFeatures().HasFeature(lnwire.OnionMessagesOptional) {
+		return fmt.Errorf("onion message peer %x does not support "+
+			"onion messages", nextPeer)
+	}
+
+	srvrLog.Tracef("Relaying onion message from %x to %x", peer,
+		nextPeer)
+
+	return target.SendMessageLazy(false, processed.NextMessage)
+}
+
+// hasOpenChannels returns true if we have any open channels with the given
+// peer.
+func (s *server) hasOpenChannels(peerPub *btcec.PublicKey) (bool, error) {
+	channels, err := s.chanStateDB.FetchOpenChannels(peerPub)
+	if err != nil {
+		return false, err
+	}
+
+	return len(channels) > 0, nil
+}
+
+// handlePeerStorage stores the backup that the given peer sent us. We only
+// store backups of peers we have open channels with, so that arbitrary peers
+// can't use our disk space.
+func (s *server) handlePeerStorage(peer [33]byte, blob []byte) error {
+	peerPub, err := btcec.ParsePubKey(peer[:])
+	if err != nil {
+		return err
+	}
+
+	hasChannels, err := s.hasOpenChannels(peerPub)
+	if err != nil {
+		return err
+	}
+	if !hasChannels {
+		return fmt.Errorf("no open channels with peer %x", peer)
+	}
+
+	srvrLog.Debugf("Storing backup of %d bytes for peer %x", len(blob),
+		peer)
+
+	return s.chanStateDB.SetPeerStorage(peer, blob)
+}
+
+// handlePeerStorageRetrieval processes the backup that the given peer stores
+// for us.
+func (s *server) handlePeerStorageRetrieval(peer [33]byte,
+	blob []byte) error {
+
+	srvrLog.Debugf("Received backup of %d bytes from peer %x", len(blob),
+		peer)
+
+	return s.peerStorage.HandleRetrievedBackup(peer, blob)
+}
+
+// sendPeerStorage sends the given backup to the peer to store it for us, if
+// the peer supports peer storage and we have open channels with it.
+func (s *server) sendPeerStorage(p *peer.Brontide,
+	backup chanbackup.PackedMulti) {
+
+	if !p.RemoteFeatures().HasFeature(lnwire.ProvideStorageOptional) {
+		return
+	}
+
+	hasChannels, err := s.hasOpenChannels(p.IdentityKey())
+	if err != nil {
+		srvrLog.Errorf("Unable to fetch channels with peer %x: %v",
+			p.PubKey(), err)
+
+		return
+	}
+	if !hasChannels {
+		return
+	}
+
+	err = p.SendMessageLazy(false, lnwire.NewPeerStorage(backup))
+	if err != nil {
+		srvrLog.Debugf("Unable to send backup to peer %x: %v",
+			p.PubKey(), err)
+	}
+}
+
+// broadcastPeerStorage sends the given backup to all connected peers that
+// store backups for us.
+func (s *server) broadcastPeerStorage(backup chanbackup.PackedMulti) {
+	for _, p := range s.Peers() {
+		s.sendPeerStorage(p, backup)
+	}
+}
+
+// exchangePeerStorage returns the backup we store for the newly connected
+// peer, and sends it our latest backup.
+func (s *server) exchangePeerStorage(p *peer.Brontide) {
+	blob, err := s.chanStateDB.FetchPeerStorage(p.PubKey())
+	switch {
+	case err != nil:
+		srvrLog.Errorf("Unable to fetch backup of peer %x: %v",
+			p.PubKey(), err)
+
+	case blob != nil:
+		msg := lnwire.NewPeerStorageRetrieval(blob)
+		if err := p.SendMessageLazy(false, msg); err != nil {
+			srvrLog.Debugf("Unable to return backup to peer "+
+				"%x: %v", p.PubKey(), err)
+		}
+	}
+
+	if backup := s.peerStorage.LatestBackup(); backup != nil {
+		s.sendPeerStorage(p, backup)
+	}
+}
+
+// SubscribeOnionMessages subscribes to a stream of incoming onion messages
+// that are addressed to us.
+func (s *server) SubscribeOnionMessages() (*subscribe.Client, error) {
+	return s.onionMessageServer.Subscribe()
+}
+
 // peerConnected is a function that handles initialization a newly connected
 // peer by adding it to the server's global list of all active peers, and
 // starting all the goroutines the peer needs to function properly. The inbound
@@ -3907,12 +4923,24 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		RequestAlias:           s.aliasMgr.RequestAlias,
 		AddLocalAlias:          s.aliasMgr.AddLocalAlias,
 		DisallowRouteBlinding:  s.cfg.ProtocolOptions.NoRouteBlinding(),
+		AuxTrafficShapers:      htlcswitch.RegisteredAuxTrafficShapers(),
 		Quit:                   s.quit,
 	}
 
 	copy(pCfg.PubKeyBytes[:], peerAddr.IdentityKey.SerializeCompressed())
 	copy(pCfg.ServerPubKey[:], s.identityECDH.PubKey().SerializeCompressed())
 
+	// Onion messages are only processed if we signal support for them.
+	if !s.cfg.ProtocolOptions.NoOnionMessages() {
+		pCfg.HandleOnionMessage = s.handleOnionMessage
+	}
+
+	// Likewise, backups are only exchanged if we signal peer storage.
+	if s.peerStorage != nil {
+		pCfg.HandlePeerStorage = s.handlePeerStorage
+		pCfg.HandlePeerStorageRetrieval = s.handlePeerStorageRetrieval
+	}
+
 	p := peer.NewBrontide(pCfg)
 
 	// TODO(roasbeef): update IP address for link-node
@@ -4014,6 +5042,12 @@ func (s *server) peerInitializer(p *peer.Brontide) {
 	// was successful, and to begin watching the peer's wait group.
 	close(ready)
 
+	// Exchange the backups we store for each other now that the peer is
+	// active.
+	if s.peerStorage != nil {
+		s.exchangePeerStorage(p)
+	}
+
 	pubStr := string(p.IdentityKey().SerializeCompressed())
 
 	s.mu.Lock()
@@ -4151,6 +5185,13 @@ func (s *server) peerTerminationWatcher(p *peer.Brontide, ready chan struct{}) {
 			break
 		}
 
+		// We can still reconnect to the peer if its connection
+		// policy has a preferred address.
+		policy, ok := s.peerConnPolicies[pubStr]
+		if ok && policy.preferredAddr != nil {
+			break
+		}
+
 		// If we are, the peer's address won't be known
 		// to us (we'll see a private address, which is
 		// the address used by our onion service to dial
@@ -4243,8 +5284,9 @@ func (s *server) connectToPersistentPeer(pubKeyStr string) {
 	// connection requests for the associated address and then any leftover
 	// entries will indicate which addresses we should create new
 	// connection requests for.
+	addrs := s.persistentConnAddrs(pubKeyStr)
 	addrMap := make(map[string]*lnwire.NetAddress)
-	for _, addr := range s.persistentPeerAddrs[pubKeyStr] {
+	for _, addr := range addrs {
 		addrMap[addr.String()] = addr
 	}
 
@@ -4288,14 +5330,19 @@ func (s *server) connectToPersistentPeer(pubKeyStr string) {
 	}
 
 	// Any addresses left in addrMap are new ones that we have not made
-	// connection requests for. So create new connection requests for those.
-	// If there is more than one address in the address map, stagger the
-	// creation of the connection requests for those.
+	// connection requests for. So create new connection requests for those
+	// in the order of their preference. If there is more than one address
+	// in the address map, stagger the creation of the connection requests
+	// for those.
 	go func() {
 		ticker := time.NewTicker(multiAddrConnectionStagger)
 		defer ticker.Stop()
 
-		for _, addr := range addrMap {
+		for _, addr := range addrs {
+			if _, ok := addrMap[addr.String()]; !ok {
+				continue
+			}
+
 			// Send the persistent connection request to the
 			// connection manager, saving the request itself so we
 			// can cancel/restart the process as needed.
@@ -4400,6 +5447,15 @@ func (s *server) ConnectToPeer(addr *lnwire.NetAddress,
 
 	// Peer was not found, continue to pursue connection with peer.
 
+	// Make sure that we respect the connection policy of the peer.
+	policy, ok := s.peerConnPolicies[targetPub]
+	if ok && policy.TorOnly && !isOnionAddr(addr.Address) {
+		s.mu.Unlock()
+		return fmt.Errorf("the connection policy of peer %x only "+
+			"allows connections over Tor",
+			addr.IdentityKey.SerializeCompressed())
+	}
+
 	// If there's already a pending connection request for this pubkey,
 	// then we ignore this request to ensure we don't create a redundant
 	// connection.
@@ -4720,6 +5776,108 @@ func (s *server) SendCustomMessage(peerPub [33]byte, msgType lnwire.MessageType,
 	return peer.SendMessageLazy(true, msg)
 }
 
+// SendOnionMessage sends an onion message to the peer with the specified
+// pubkey.
+func (s *server) SendOnionMessage(peerPub [33]byte, pathKey *btcec.PublicKey,
+	onion []byte) error {
+
+	if s.cfg.ProtocolOptions.NoOnionMessages() {
+		return errors.New("onion messages are disabled")
+	}
+
+	peer, err := s.FindPeerByPubStr(string(peerPub[:]))
+	if err != nil {
+		return err
+	}
+
+	// We'll wait until the peer is active.
+	select {
+	case <-peer.ActiveSignal():
+	case <-peer.QuitSignal():
+		return fmt.Errorf("peer %x disconnected", peerPub)
+	case <-s.quit:
+		return ErrServerShuttingDown
+	}
+
+	if !peer.RemoteFeatures().HasFeature(lnwire.OnionMessagesOptional) {
+		return fmt.Errorf("peer %x does not support onion messages",
+			peerPub)
+	}
+
+	return peer.SendMessageLazy(
+		false, lnwire.NewOnionMessage(pathKey, onion),
+	)
+}
+
+// newSCBReplicators creates the remote targets that the static channel backup
+// is replicated to.
+func newSCBReplicators(
+	cfg *lncfg.SCBReplication) ([]chanbackup.Replicator, error) {
+
+	var replicators []chanbackup.Replicator
+
+	if len(cfg.WebhookURLs) > 0 {
+		headers := make(http.Header)
+		for _, header := range cfg.WebhookHeaders {
+			name, value, ok := strings.Cut(header, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid webhook "+
+					"header %q, expected 'Name: value'",
+					header)
+			}
+			headers.Add(
+				strings.TrimSpace(name),
+				strings.TrimSpace(value),
+			)
+		}
+
+		for _, webhookURL := range cfg.WebhookURLs {
+			replicator, err := chanbackup.NewHTTPReplicator(
+				webhookURL, headers,
+			)
+			if err != nil {
+				return nil, err
+			}
+			replicators = append(replicators, replicator)
+		}
+	}
+
+	if cfg.S3Enabled() {
+		replicator, err := chanbackup.NewS3Replicator(
+			&chanbackup.S3Config{
+				Endpoint:        cfg.S3Endpoint,
+				Region:          cfg.S3Region,
+				Bucket:          cfg.S3Bucket,
+				Key:             cfg.S3Key,
+				AccessKeyID:     cfg.S3AccessKeyID,
+				SecretAccessKey: cfg.S3SecretAccessKey,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		replicators = append(replicators, replicator)
+	}
+
+	if cfg.SFTPEnabled() {
+		replicator, err := chanbackup.NewSFTPReplicator(
+			&chanbackup.SFTPConfig{
+				Addr:           cfg.SFTPAddr,
+				User:           cfg.SFTPUser,
+				PrivateKeyPath: cfg.SFTPPrivateKeyPath,
+				KnownHostsPath: cfg.SFTPKnownHostsPath,
+				Path:           cfg.SFTPPath,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		replicators = append(replicators, replicator)
+	}
+
+	return replicators, nil
+}
+
 // newSweepPkScriptGen creates closure that generates a new public key script
 // which should be used to sweep any funds into the on-chain wallet.
 // Specifically, the script generated is a version 0, pay-to-witness-pubkey-hash
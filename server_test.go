@@ -1,9 +1,16 @@
 package lnd
 
 import (
+	"net"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/chainreg"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
+	"github.com/stretchr/testify/require"
 )
 
 // TestShouldPeerBootstrap tests that we properly skip network bootstrap for
@@ -80,3 +87,71 @@ func TestShouldPeerBootstrap(t *testing.T) {
 		}
 	}
 }
+
+// TestPersistentConnAddrs tests that the connection policy of a peer orders
+// and filters the addresses we connect to.
+func TestPersistentConnAddrs(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey()
+	pubStr := string(pubKey.SerializeCompressed())
+
+	newAddr := func(addr net.Addr) *lnwire.NetAddress {
+		return &lnwire.NetAddress{
+			IdentityKey: pubKey,
+			Address:     addr,
+			ChainNet:    chainreg.BitcoinTestNetParams.Net,
+		}
+	}
+
+	tcpAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+	onionAddr := &tor.OnionAddr{
+		OnionService: "3g2upl4pq6kufc4m.onion",
+		Port:         9735,
+	}
+	preferredAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 9736}
+
+	s := &server{
+		cfg: &Config{
+			ActiveNetParams: chainreg.BitcoinTestNetParams,
+		},
+		persistentPeerAddrs: map[string][]*lnwire.NetAddress{
+			pubStr: {newAddr(tcpAddr), newAddr(onionAddr)},
+		},
+		peerConnPolicies: make(map[string]*peerConnPolicy),
+	}
+
+	// Without a policy, the stored addresses are used as is.
+	require.Equal(t, []*lnwire.NetAddress{
+		newAddr(tcpAddr), newAddr(onionAddr),
+	}, s.persistentConnAddrs(pubStr))
+
+	// The preferred address comes first.
+	s.peerConnPolicies[pubStr] = &peerConnPolicy{
+		PeerConnectionPolicy: &channeldb.PeerConnectionPolicy{
+			PreferredAddress: preferredAddr.String(),
+		},
+		preferredAddr: preferredAddr,
+	}
+	require.Equal(t, []*lnwire.NetAddress{
+		newAddr(preferredAddr), newAddr(tcpAddr), newAddr(onionAddr),
+	}, s.persistentConnAddrs(pubStr))
+
+	// A preferred address that is also advertised isn't duplicated.
+	s.peerConnPolicies[pubStr].preferredAddr = onionAddr
+	require.Equal(t, []*lnwire.NetAddress{
+		newAddr(onionAddr), newAddr(tcpAddr),
+	}, s.persistentConnAddrs(pubStr))
+
+	// Only onion addresses are used for tor only peers.
+	s.peerConnPolicies[pubStr] = &peerConnPolicy{
+		PeerConnectionPolicy: &channeldb.PeerConnectionPolicy{
+			TorOnly: true,
+		},
+	}
+	require.Equal(t, []*lnwire.NetAddress{
+		newAddr(onionAddr),
+	}, s.persistentConnAddrs(pubStr))
+}
@@ -0,0 +1,67 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+)
+
+// checkBackupDest makes sure a backup doesn't overwrite an existing file.
+func checkBackupDest(destPath string) error {
+	_, err := os.Stat(destPath)
+	switch {
+	case err == nil:
+		return fmt.Errorf("backup file %v already exists", destPath)
+
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+
+	return nil
+}
+
+// BackupPostgres writes a consistent snapshot of the Postgres database the
+// DSN points to to destPath by invoking pg_dump, which needs to be installed
+// on the host lnd runs on. The snapshot is written in pg_dump's custom format
+// and can be restored with pg_restore.
+func BackupPostgres(ctx context.Context, dsn, destPath string) error {
+	if err := checkBackupDest(destPath); err != nil {
+		return err
+	}
+
+	pgDump, err := exec.LookPath("pg_dump")
+	if err != nil {
+		return fmt.Errorf("unable to find pg_dump: %w", err)
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return err
+	}
+
+	// We pass the password through the environment instead of the command
+	// line to not expose it in the process list.
+	env := os.Environ()
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			env = append(env, "PGPASSWORD="+password)
+			u.User = url.User(u.User.Username())
+		}
+	}
+
+	cmd := exec.CommandContext(
+		ctx, pgDump, "--format=custom", "--file="+destPath,
+		"--dbname="+u.String(),
+	)
+	cmd.Env = env
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("pg_dump failed: %w: %s", err, output)
+	}
+
+	return nil
+}
@@ -34,10 +34,16 @@ type SqliteConfig struct {
 //
 //nolint:lll
 type PostgresConfig struct {
-	Dsn            string        `long:"dsn" description:"Database connection string."`
-	Timeout        time.Duration `long:"timeout" description:"Database connection timeout. Set to zero to disable."`
-	MaxConnections int           `long:"maxconnections" description:"The maximum number of open connections to the database. Set to zero for unlimited."`
-	SkipMigrations bool          `long:"skipmigrations" description:"Skip applying migrations on startup."`
+	Dsn                string        `long:"dsn" description:"Database connection string."`
+	Timeout            time.Duration `long:"timeout" description:"Database connection timeout. Set to zero to disable."`
+	MaxConnections     int           `long:"maxconnections" description:"The maximum number of open connections to the database. Set to zero for unlimited."`
+	MaxIdleConnections int           `long:"maxidleconnections" description:"The maximum number of idle connections kept in the pool of the native SQL store. Set to zero to keep as many idle connections as open connections are permitted."`
+	ConnMaxLifetime    time.Duration `long:"connmaxlifetime" description:"The maximum amount of time a connection of the native SQL store may be reused. Set to zero to use the default of 5 minutes."`
+	ConnMaxIdleTime    time.Duration `long:"connmaxidletime" description:"The maximum amount of time a connection of the native SQL store may be idle before it's closed. Set to zero to keep idle connections until their lifetime expires."`
+	StatementTimeout   time.Duration `long:"statementtimeout" description:"The maximum amount of time a statement of the native SQL store may run before it's aborted by the database. Set to zero to disable."`
+	ReplicaDsn         string        `long:"replicadsn" description:"Connection string of an optional read-only replica of the database. Heavy read queries of the native SQL store that tolerate slightly stale data are served by the replica."`
+	ReplicaMaxLag      time.Duration `long:"replicamaxlag" description:"The maximum replication lag of the read replica. Read queries fall back to the primary database while the replica lags further behind."`
+	SkipMigrations     bool          `long:"skipmigrations" description:"Skip applying migrations on startup."`
 }
 
 func (p *PostgresConfig) Validate() error {
@@ -51,5 +57,32 @@ func (p *PostgresConfig) Validate() error {
 		return fmt.Errorf("invalid DSN: %w", err)
 	}
 
+	if p.ReplicaDsn != "" {
+		if _, err := url.Parse(p.ReplicaDsn); err != nil {
+			return fmt.Errorf("invalid replica DSN: %w", err)
+		}
+	}
+
+	switch {
+	case p.ReplicaMaxLag < 0:
+		return fmt.Errorf("replicamaxlag must not be negative")
+
+	case p.MaxIdleConnections < 0:
+		return fmt.Errorf("maxidleconnections must not be negative")
+
+	case p.ConnMaxLifetime < 0:
+		return fmt.Errorf("connmaxlifetime must not be negative")
+
+	case p.ConnMaxIdleTime < 0:
+		return fmt.Errorf("connmaxidletime must not be negative")
+
+	// Postgres expects the statement timeout in milliseconds.
+	case p.StatementTimeout < 0 ||
+		(p.StatementTimeout > 0 && p.StatementTimeout < time.Millisecond):
+
+		return fmt.Errorf("statementtimeout must either be zero or at " +
+			"least 1ms")
+	}
+
 	return nil
 }
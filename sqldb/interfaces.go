@@ -361,12 +361,24 @@ type BaseDB struct {
 	*sql.DB
 
 	*sqlc.Queries
+
+	// replica is an optional read replica that read only transactions
+	// which allow it are served by while it's healthy.
+	replica *ReadReplica
 }
 
 // BeginTx wraps the normal sql specific BeginTx method with the TxOptions
 // interface. This interface is then mapped to the concrete sql tx options
-// struct.
+// struct. Read only transactions with ReplicaTxOptions that allow it are
+// started on the read replica, if one is configured and healthy.
 func (s *BaseDB) BeginTx(ctx context.Context, opts TxOptions) (*sql.Tx, error) {
+	if s.replica != nil && s.replica.Healthy() && opts.ReadOnly() {
+		replicaOpts, ok := opts.(ReplicaTxOptions)
+		if ok && replicaOpts.AllowReplica() {
+			return s.replica.beginTx(ctx)
+		}
+	}
+
 	sqlOptions := sql.TxOptions{
 		Isolation: sql.LevelSerializable,
 		ReadOnly:  opts.ReadOnly(),
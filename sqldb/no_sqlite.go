@@ -2,7 +2,10 @@
 
 package sqldb
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // SqliteStore is a database store implementation that uses a sqlite backend.
 type SqliteStore struct {
@@ -16,3 +19,9 @@ type SqliteStore struct {
 func NewSqliteStore(cfg *SqliteConfig, dbPath string) (*SqliteStore, error) {
 	return nil, fmt.Errorf("SQLite backend not supported in WebAssembly")
 }
+
+// BackupSqliteFile writes a consistent snapshot of the SQLite database at
+// srcPath to destPath using SQLite's online backup API.
+func BackupSqliteFile(_ context.Context, _, _ string) error {
+	return fmt.Errorf("SQLite backend not supported in WebAssembly")
+}
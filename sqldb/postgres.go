@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -62,6 +63,65 @@ func getDatabaseNameFromDSN(dsn string) (string, error) {
 	return path.Base(u.Path), nil
 }
 
+// setStatementTimeoutInDSN returns the DSN with the statement_timeout runtime
+// parameter set to the given timeout, which makes Postgres abort any statement
+// of the connection that runs longer.
+func setStatementTimeoutInDSN(dsn string, timeout time.Duration) (string,
+	error) {
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set(
+		"statement_timeout", strconv.FormatInt(timeout.Milliseconds(), 10),
+	)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// openPostgresDB opens a connection pool to the Postgres database the DSN
+// points to and applies the pool settings of the config.
+func openPostgresDB(cfg *PostgresConfig, dsn string) (*sql.DB, error) {
+	var err error
+	if cfg.StatementTimeout > 0 {
+		dsn, err = setStatementTimeoutInDSN(dsn, cfg.StatementTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rawDB, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	maxConns := defaultMaxConns
+	if cfg.MaxConnections > 0 {
+		maxConns = cfg.MaxConnections
+	}
+
+	maxIdleConns := maxConns
+	if cfg.MaxIdleConnections > 0 {
+		maxIdleConns = cfg.MaxIdleConnections
+	}
+
+	connLifetime := connIdleLifetime
+	if cfg.ConnMaxLifetime > 0 {
+		connLifetime = cfg.ConnMaxLifetime
+	}
+
+	rawDB.SetMaxOpenConns(maxConns)
+	rawDB.SetMaxIdleConns(maxIdleConns)
+	rawDB.SetConnMaxLifetime(connLifetime)
+	rawDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return rawDB, nil
+}
+
 // PostgresStore is a database store implementation that uses a Postgres
 // backend.
 type PostgresStore struct {
@@ -84,20 +144,11 @@ func NewPostgresStore(cfg *PostgresConfig) (*PostgresStore, error) {
 		return nil, err
 	}
 
-	rawDB, err := sql.Open("pgx", cfg.Dsn)
+	rawDB, err := openPostgresDB(cfg, cfg.Dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	maxConns := defaultMaxConns
-	if cfg.MaxConnections > 0 {
-		maxConns = cfg.MaxConnections
-	}
-
-	rawDB.SetMaxOpenConns(maxConns)
-	rawDB.SetMaxIdleConns(maxConns)
-	rawDB.SetConnMaxLifetime(connIdleLifetime)
-
 	if !cfg.SkipMigrations {
 		// Now that the database is open, populate the database with
 		// our set of schemas based on our embedded in-memory file
@@ -127,6 +178,31 @@ func NewPostgresStore(cfg *PostgresConfig) (*PostgresStore, error) {
 		}
 	}
 
+	// If a read replica is configured, read transactions that allow it
+	// are served by the replica while its replication lag is below the
+	// threshold.
+	var replica *ReadReplica
+	if cfg.ReplicaDsn != "" {
+		sanitizedDSN, err := replacePasswordInDSN(cfg.ReplicaDsn)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Using SQL read replica '%s'", sanitizedDSN)
+
+		replicaDB, err := openPostgresDB(cfg, cfg.ReplicaDsn)
+		if err != nil {
+			return nil, err
+		}
+
+		maxLag := DefaultReplicaMaxLag
+		if cfg.ReplicaMaxLag > 0 {
+			maxLag = cfg.ReplicaMaxLag
+		}
+
+		replica = newPostgresReadReplica(replicaDB, maxLag)
+		replica.Start()
+	}
+
 	queries := sqlc.New(rawDB)
 
 	return &PostgresStore{
@@ -134,6 +210,19 @@ func NewPostgresStore(cfg *PostgresConfig) (*PostgresStore, error) {
 		BaseDB: &BaseDB{
 			DB:      rawDB,
 			Queries: queries,
+			replica: replica,
 		},
 	}, nil
 }
+
+// Close closes the read replica, if one is configured, and the primary
+// database.
+func (s *PostgresStore) Close() error {
+	if s.replica != nil {
+		if err := s.replica.Stop(); err != nil {
+			log.Errorf("Unable to close read replica: %v", err)
+		}
+	}
+
+	return s.DB.Close()
+}
@@ -0,0 +1,181 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultReplicaMaxLag is the default maximum replication lag of a
+	// read replica before read transactions fall back to the primary.
+	DefaultReplicaMaxLag = 10 * time.Second
+
+	// replicaLagCheckInterval is the interval in which the replication lag
+	// of a read replica is checked.
+	replicaLagCheckInterval = 5 * time.Second
+)
+
+// postgresReplicaLagQuery returns the replication lag of a Postgres standby
+// in seconds. A standby that has replayed all WAL it received isn't lagging,
+// even if the last replayed transaction is old because the primary hasn't
+// been written to in a while. A server that isn't a standby reports no lag.
+const postgresReplicaLagQuery = `
+SELECT CASE
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(
+		EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0
+	)
+END`
+
+// ReplicaTxOptions is an optional extension of the TxOptions for read only
+// transactions that tolerate slightly stale data and may therefore be served
+// by a read replica.
+type ReplicaTxOptions interface {
+	TxOptions
+
+	// AllowReplica returns true if the transaction may be served by a
+	// read replica.
+	AllowReplica() bool
+}
+
+// ReadReplica is a read-only database replica that read transactions which
+// tolerate stale data can be served by. Its replication lag is checked
+// periodically and it's only used while the lag is below the configured
+// threshold, otherwise transactions fall back to the primary.
+type ReadReplica struct {
+	db *sql.DB
+
+	maxLag time.Duration
+
+	// queryLag returns the current replication lag of the replica.
+	queryLag func(ctx context.Context) (time.Duration, error)
+
+	healthy atomic.Bool
+
+	started sync.Once
+	stopped sync.Once
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newReadReplica creates a new read replica that is considered healthy as
+// long as the lag returned by queryLag is below maxLag.
+func newReadReplica(db *sql.DB, maxLag time.Duration,
+	queryLag func(ctx context.Context) (time.Duration, error)) *ReadReplica {
+
+	return &ReadReplica{
+		db:       db,
+		maxLag:   maxLag,
+		queryLag: queryLag,
+		quit:     make(chan struct{}),
+	}
+}
+
+// newPostgresReadReplica creates a new read replica for a Postgres standby.
+func newPostgresReadReplica(db *sql.DB, maxLag time.Duration) *ReadReplica {
+	queryLag := func(ctx context.Context) (time.Duration, error) {
+		var lagSeconds float64
+		err := db.QueryRowContext(
+			ctx, postgresReplicaLagQuery,
+		).Scan(&lagSeconds)
+		if err != nil {
+			return 0, err
+		}
+
+		return time.Duration(lagSeconds * float64(time.Second)), nil
+	}
+
+	return newReadReplica(db, maxLag, queryLag)
+}
+
+// Start checks the replication lag once and then keeps checking it in the
+// background until Stop is called.
+func (r *ReadReplica) Start() {
+	r.started.Do(func() {
+		r.checkLag()
+
+		r.wg.Add(1)
+		go r.lagChecker()
+	})
+}
+
+// Stop stops checking the replication lag and closes the replica database.
+func (r *ReadReplica) Stop() error {
+	var err error
+	r.stopped.Do(func() {
+		close(r.quit)
+		r.wg.Wait()
+
+		err = r.db.Close()
+	})
+
+	return err
+}
+
+// Healthy returns true if the replication lag of the replica was below the
+// threshold at the last check.
+func (r *ReadReplica) Healthy() bool {
+	return r.healthy.Load()
+}
+
+// lagChecker periodically checks the replication lag of the replica.
+//
+// NOTE: This MUST be run as a goroutine.
+func (r *ReadReplica) lagChecker() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(replicaLagCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.checkLag()
+
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// checkLag queries the replication lag of the replica and updates its health
+// accordingly. A replica whose lag can't be determined is considered
+// unhealthy.
+func (r *ReadReplica) checkLag() {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), DefaultStoreTimeout,
+	)
+	defer cancel()
+
+	lag, err := r.queryLag(ctx)
+	healthy := err == nil && lag <= r.maxLag
+
+	wasHealthy := r.healthy.Swap(healthy)
+	switch {
+	case err != nil && wasHealthy:
+		log.Warnf("Unable to query read replica lag, falling back to "+
+			"primary: %v", err)
+
+	case !healthy && wasHealthy:
+		log.Warnf("Read replica lag of %v exceeds %v, falling back "+
+			"to primary", lag, r.maxLag)
+
+	case healthy && !wasHealthy:
+		log.Infof("Read replica lag of %v is within %v, using "+
+			"replica for reads", lag, r.maxLag)
+	}
+}
+
+// beginTx starts a new read only transaction on the replica. As hot standbys
+// don't support serializable transactions, the transaction uses repeatable
+// read isolation, which still provides a consistent snapshot for reads.
+func (r *ReadReplica) beginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+}
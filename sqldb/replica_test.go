@@ -0,0 +1,130 @@
+//go:build !js && !(windows && (arm || 386)) && !(linux && (ppc64 || mips || mipsle || mips64))
+
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// replicaTxOptions is a TxOptions implementation that allows a read replica.
+type replicaTxOptions struct {
+	allowReplica bool
+}
+
+// ReadOnly returns true if the transaction should be read only.
+func (r *replicaTxOptions) ReadOnly() bool {
+	return true
+}
+
+// AllowReplica returns true if the transaction may be served by a read
+// replica.
+func (r *replicaTxOptions) AllowReplica() bool {
+	return r.allowReplica
+}
+
+// readOnlyTxOptions is a TxOptions implementation for read only transactions
+// that doesn't know about read replicas.
+type readOnlyTxOptions struct{}
+
+// ReadOnly returns true if the transaction should be read only.
+func (r *readOnlyTxOptions) ReadOnly() bool {
+	return true
+}
+
+// TestReadReplica tests that read transactions that allow it are served by a
+// healthy read replica, and fall back to the primary if the replica lags too
+// far behind or its lag can't be determined.
+func TestReadReplica(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// We use two databases that each store their own name, so we can tell
+	// which of them served a transaction.
+	openDB := func(name string) *sql.DB {
+		db, err := sql.Open("sqlite", filepath.Join(dir, name))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = db.Close()
+		})
+
+		_, err = db.ExecContext(ctx, "CREATE TABLE info (name TEXT)")
+		require.NoError(t, err)
+		_, err = db.ExecContext(
+			ctx, "INSERT INTO info (name) VALUES (?)", name,
+		)
+		require.NoError(t, err)
+
+		return db
+	}
+
+	var (
+		lag    time.Duration
+		lagErr error
+	)
+	replica := newReadReplica(
+		openDB("replica"), time.Second,
+		func(context.Context) (time.Duration, error) {
+			return lag, lagErr
+		},
+	)
+	baseDB := &BaseDB{
+		DB:      openDB("primary"),
+		replica: replica,
+	}
+
+	assertServedBy := func(opts TxOptions, expected string) {
+		t.Helper()
+
+		tx, err := baseDB.BeginTx(ctx, opts)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, tx.Rollback())
+		}()
+
+		var name string
+		err = tx.QueryRowContext(
+			ctx, "SELECT name FROM info",
+		).Scan(&name)
+		require.NoError(t, err)
+		require.Equal(t, expected, name)
+	}
+
+	allowReplica := &replicaTxOptions{allowReplica: true}
+	noReplica := &replicaTxOptions{}
+
+	// Before the lag was checked, the replica isn't used.
+	assertServedBy(allowReplica, "primary")
+
+	// Once the lag is known to be within the threshold, only transactions
+	// that allow it are served by the replica.
+	replica.checkLag()
+	require.True(t, replica.Healthy())
+	assertServedBy(allowReplica, "replica")
+	assertServedBy(noReplica, "primary")
+	assertServedBy(&readOnlyTxOptions{}, "primary")
+
+	// If the replica lags too far behind, we fall back to the primary.
+	lag = 2 * time.Second
+	replica.checkLag()
+	require.False(t, replica.Healthy())
+	assertServedBy(allowReplica, "primary")
+
+	// The replica is used again once it caught up.
+	lag = 0
+	replica.checkLag()
+	assertServedBy(allowReplica, "replica")
+
+	// A replica whose lag can't be determined isn't used either.
+	lagErr = errors.New("replica unavailable")
+	replica.checkLag()
+	assertServedBy(allowReplica, "primary")
+}
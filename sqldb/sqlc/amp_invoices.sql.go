@@ -14,7 +14,7 @@ import (
 const fetchAMPSubInvoiceHTLCs = `-- name: FetchAMPSubInvoiceHTLCs :many
 SELECT 
     amp.set_id, amp.root_share, amp.child_index, amp.hash, amp.preimage, 
-    invoice_htlcs.id, invoice_htlcs.chan_id, invoice_htlcs.htlc_id, invoice_htlcs.amount_msat, invoice_htlcs.total_mpp_msat, invoice_htlcs.accept_height, invoice_htlcs.accept_time, invoice_htlcs.expiry_height, invoice_htlcs.state, invoice_htlcs.resolve_time, invoice_htlcs.invoice_id
+    invoice_htlcs.id, invoice_htlcs.chan_id, invoice_htlcs.htlc_id, invoice_htlcs.amount_msat, invoice_htlcs.total_mpp_msat, invoice_htlcs.accept_height, invoice_htlcs.accept_time, invoice_htlcs.expiry_height, invoice_htlcs.state, invoice_htlcs.resolve_time, invoice_htlcs.invoice_id, invoice_htlcs.wire_amount_msat
 FROM amp_sub_invoice_htlcs amp
 INNER JOIN invoice_htlcs ON amp.htlc_id = invoice_htlcs.id
 WHERE amp.invoice_id = $1
@@ -30,22 +30,23 @@ type FetchAMPSubInvoiceHTLCsParams struct {
 }
 
 type FetchAMPSubInvoiceHTLCsRow struct {
-	SetID        []byte
-	RootShare    []byte
-	ChildIndex   int64
-	Hash         []byte
-	Preimage     []byte
-	ID           int64
-	ChanID       string
-	HtlcID       int64
-	AmountMsat   int64
-	TotalMppMsat sql.NullInt64
-	AcceptHeight int32
-	AcceptTime   time.Time
-	ExpiryHeight int32
-	State        int16
-	ResolveTime  sql.NullTime
-	InvoiceID    int64
+	SetID          []byte
+	RootShare      []byte
+	ChildIndex     int64
+	Hash           []byte
+	Preimage       []byte
+	ID             int64
+	ChanID         string
+	HtlcID         int64
+	AmountMsat     int64
+	TotalMppMsat   sql.NullInt64
+	AcceptHeight   int32
+	AcceptTime     time.Time
+	ExpiryHeight   int32
+	State          int16
+	ResolveTime    sql.NullTime
+	InvoiceID      int64
+	WireAmountMsat sql.NullInt64
 }
 
 func (q *Queries) FetchAMPSubInvoiceHTLCs(ctx context.Context, arg FetchAMPSubInvoiceHTLCsParams) ([]FetchAMPSubInvoiceHTLCsRow, error) {
@@ -74,6 +75,7 @@ func (q *Queries) FetchAMPSubInvoiceHTLCs(ctx context.Context, arg FetchAMPSubIn
 			&i.State,
 			&i.ResolveTime,
 			&i.InvoiceID,
+			&i.WireAmountMsat,
 		); err != nil {
 			return nil, err
 		}
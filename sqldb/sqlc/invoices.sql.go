@@ -315,7 +315,7 @@ func (q *Queries) GetInvoiceHTLCCustomRecords(ctx context.Context, invoiceID int
 }
 
 const getInvoiceHTLCs = `-- name: GetInvoiceHTLCs :many
-SELECT id, chan_id, htlc_id, amount_msat, total_mpp_msat, accept_height, accept_time, expiry_height, state, resolve_time, invoice_id
+SELECT id, chan_id, htlc_id, amount_msat, total_mpp_msat, accept_height, accept_time, expiry_height, state, resolve_time, invoice_id, wire_amount_msat
 FROM invoice_htlcs
 WHERE invoice_id = $1
 `
@@ -341,6 +341,7 @@ func (q *Queries) GetInvoiceHTLCs(ctx context.Context, invoiceID int64) ([]Invoi
 			&i.State,
 			&i.ResolveTime,
 			&i.InvoiceID,
+			&i.WireAmountMsat,
 		); err != nil {
 			return nil, err
 		}
@@ -427,23 +428,24 @@ func (q *Queries) InsertInvoiceFeature(ctx context.Context, arg InsertInvoiceFea
 const insertInvoiceHTLC = `-- name: InsertInvoiceHTLC :one
 INSERT INTO invoice_htlcs (
     htlc_id, chan_id, amount_msat, total_mpp_msat, accept_height, accept_time,
-    expiry_height, state, resolve_time, invoice_id
+    expiry_height, state, resolve_time, invoice_id, wire_amount_msat
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING id
 `
 
 type InsertInvoiceHTLCParams struct {
-	HtlcID       int64
-	ChanID       string
-	AmountMsat   int64
-	TotalMppMsat sql.NullInt64
-	AcceptHeight int32
-	AcceptTime   time.Time
-	ExpiryHeight int32
-	State        int16
-	ResolveTime  sql.NullTime
-	InvoiceID    int64
+	HtlcID         int64
+	ChanID         string
+	AmountMsat     int64
+	TotalMppMsat   sql.NullInt64
+	AcceptHeight   int32
+	AcceptTime     time.Time
+	ExpiryHeight   int32
+	State          int16
+	ResolveTime    sql.NullTime
+	InvoiceID      int64
+	WireAmountMsat sql.NullInt64
 }
 
 func (q *Queries) InsertInvoiceHTLC(ctx context.Context, arg InsertInvoiceHTLCParams) (int64, error) {
@@ -458,6 +460,7 @@ func (q *Queries) InsertInvoiceHTLC(ctx context.Context, arg InsertInvoiceHTLCPa
 		arg.State,
 		arg.ResolveTime,
 		arg.InvoiceID,
+		arg.WireAmountMsat,
 	)
 	var id int64
 	err := row.Scan(&id)
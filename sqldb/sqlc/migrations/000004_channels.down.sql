@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS channel_fwd_packages;
+DROP INDEX IF EXISTS channel_revocation_log_commit_tx_hash_idx;
+DROP TABLE IF EXISTS channel_revocation_log;
+DROP TABLE IF EXISTS channel_commitments;
+DROP INDEX IF EXISTS channels_short_channel_id_idx;
+DROP INDEX IF EXISTS channels_peer_pubkey_idx;
+DROP TABLE IF EXISTS channels;
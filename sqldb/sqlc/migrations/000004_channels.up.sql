@@ -0,0 +1,112 @@
+-- channels contains the static information of all channels known to the
+-- channel state store, both open and closed ones.
+CREATE TABLE IF NOT EXISTS channels (
+    id BIGINT PRIMARY KEY,
+
+    -- chan_point is the serialized funding outpoint of the channel.
+    chan_point BLOB NOT NULL UNIQUE,
+
+    -- chain_hash is the genesis hash of the chain the channel was opened on.
+    chain_hash BLOB NOT NULL,
+
+    -- peer_pubkey is the compressed identity public key of the channel peer.
+    peer_pubkey BLOB NOT NULL,
+
+    -- short_channel_id is the short channel ID of the channel. It is updated
+    -- once the funding transaction confirms.
+    short_channel_id BIGINT NOT NULL,
+
+    -- chan_type is the bit field of the channel type.
+    chan_type BIGINT NOT NULL,
+
+    -- capacity_sat is the total capacity of the channel in satoshis.
+    capacity_sat BIGINT NOT NULL,
+
+    -- is_initiator is true if we opened the channel.
+    is_initiator BOOLEAN NOT NULL,
+
+    -- close_summary is the serialized close summary of the channel. It is
+    -- NULL for as long as the channel is open.
+    close_summary BLOB,
+
+    -- close_height is the block height at which the channel was closed.
+    close_height INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS channels_peer_pubkey_idx ON channels(peer_pubkey);
+CREATE INDEX IF NOT EXISTS channels_short_channel_id_idx ON channels(short_channel_id);
+
+-- channel_commitments contains the latest local and remote commitment of each
+-- open channel.
+CREATE TABLE IF NOT EXISTS channel_commitments (
+    -- channel_id is the reference to the channel the commitment belongs to.
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+
+    -- is_local is true for our commitment and false for the commitment of
+    -- the remote party.
+    is_local BOOLEAN NOT NULL,
+
+    -- commit_height is the height of the commitment.
+    commit_height BIGINT NOT NULL,
+
+    -- commitment is the serialized commitment.
+    commitment BLOB NOT NULL,
+
+    UNIQUE (channel_id, is_local)
+);
+
+-- channel_revocation_log contains an entry for each revoked commitment of the
+-- remote party. The entries are needed to punish a remote party that
+-- broadcasts a revoked state.
+CREATE TABLE IF NOT EXISTS channel_revocation_log (
+    -- channel_id is the reference to the channel the entry belongs to.
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+
+    -- commit_height is the height of the revoked commitment.
+    commit_height BIGINT NOT NULL,
+
+    -- commit_tx_hash is the hash of the revoked commitment transaction.
+    commit_tx_hash BLOB NOT NULL,
+
+    -- entry is the serialized revocation log entry.
+    entry BLOB NOT NULL,
+
+    UNIQUE (channel_id, commit_height)
+);
+
+CREATE INDEX IF NOT EXISTS channel_revocation_log_commit_tx_hash_idx ON channel_revocation_log(commit_tx_hash);
+
+-- channel_fwd_packages contains the forwarding packages of each open channel,
+-- which track the htlcs locked in by a remote commitment until they are fully
+-- forwarded or resolved.
+CREATE TABLE IF NOT EXISTS channel_fwd_packages (
+    -- channel_id is the reference to the channel the package belongs to.
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+
+    -- height is the remote commitment height that locked in the package.
+    height BIGINT NOT NULL,
+
+    -- state is the forwarding state of the package.
+    state SMALLINT NOT NULL,
+
+    -- adds is the serialized list of adds locked in by the commitment.
+    adds BLOB NOT NULL,
+
+    -- settle_fails is the serialized list of settles and fails locked in by
+    -- the commitment.
+    settle_fails BLOB NOT NULL,
+
+    -- fwd_filter is the serialized filter of adds that are forwarded. It is
+    -- NULL until the package has been processed.
+    fwd_filter BLOB,
+
+    -- ack_filter is the serialized filter of adds that have been fully
+    -- resolved.
+    ack_filter BLOB NOT NULL,
+
+    -- settle_fail_filter is the serialized filter of settles and fails that
+    -- have been delivered to the incoming link.
+    settle_fail_filter BLOB NOT NULL,
+
+    UNIQUE (channel_id, height)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS forwarding_events_timestamp_ns_idx;
+DROP TABLE IF EXISTS forwarding_events;
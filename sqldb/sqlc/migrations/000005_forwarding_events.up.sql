@@ -0,0 +1,34 @@
+-- forwarding_events contains the settled and failed HTLC forwards of the
+-- node.
+CREATE TABLE IF NOT EXISTS forwarding_events (
+    id BIGINT PRIMARY KEY,
+
+    -- timestamp_ns is the time the forward was completed in nanoseconds
+    -- since the unix epoch.
+    timestamp_ns BIGINT NOT NULL,
+
+    -- incoming_chan_id is the short channel ID of the incoming channel.
+    incoming_chan_id BIGINT NOT NULL,
+
+    -- outgoing_chan_id is the short channel ID of the outgoing channel.
+    outgoing_chan_id BIGINT NOT NULL,
+
+    -- amt_in_msat is the amount of the incoming HTLC in millisatoshis.
+    amt_in_msat BIGINT NOT NULL,
+
+    -- amt_out_msat is the amount of the outgoing HTLC in millisatoshis.
+    amt_out_msat BIGINT NOT NULL,
+
+    -- incoming_expiry is the expiry height of the incoming HTLC. It is zero
+    -- if it isn't known.
+    incoming_expiry INTEGER NOT NULL,
+
+    -- outgoing_expiry is the expiry height of the outgoing HTLC. It is zero
+    -- if it isn't known.
+    outgoing_expiry INTEGER NOT NULL,
+
+    -- failed is true if the outgoing HTLC failed instead of being settled.
+    failed BOOLEAN NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS forwarding_events_timestamp_ns_idx ON forwarding_events(timestamp_ns);
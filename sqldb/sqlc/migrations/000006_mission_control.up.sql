@@ -0,0 +1,32 @@
+-- mission_control_pairs contains the pair history of mission control. It is
+-- the knowledge about the liquidity between two nodes that was learnt from
+-- payment attempts or imported from other nodes.
+CREATE TABLE IF NOT EXISTS mission_control_pairs (
+    -- node_from is the public key of the sending node of the pair.
+    node_from BLOB NOT NULL,
+
+    -- node_to is the public key of the receiving node of the pair.
+    node_to BLOB NOT NULL,
+
+    -- fail_time_ns is the time of the last failure in nanoseconds since the
+    -- unix epoch. It is zero if no failure is known.
+    fail_time_ns BIGINT NOT NULL,
+
+    -- fail_amt_msat is the amount of the last failure in millisatoshis.
+    fail_amt_msat BIGINT NOT NULL,
+
+    -- success_time_ns is the time of the last success in nanoseconds since
+    -- the unix epoch. It is zero if no success is known.
+    success_time_ns BIGINT NOT NULL,
+
+    -- success_amt_msat is the highest amount in millisatoshis that was
+    -- forwarded successfully.
+    success_amt_msat BIGINT NOT NULL,
+
+    -- stored_time_ns is the time at which the pair was stored in nanoseconds
+    -- since the unix epoch. The pair history decays from this time on until
+    -- it is loaded again.
+    stored_time_ns BIGINT NOT NULL,
+
+    PRIMARY KEY (node_from, node_to)
+);
@@ -0,0 +1,127 @@
+-- graph_nodes contains all nodes of the channel graph, including the nodes we
+-- only know from a channel announcement.
+CREATE TABLE IF NOT EXISTS graph_nodes (
+    id BIGINT PRIMARY KEY,
+
+    -- pub_key is the compressed identity public key of the node.
+    pub_key BLOB NOT NULL UNIQUE,
+
+    -- last_update is the unix timestamp of the latest node announcement. It
+    -- is zero if no node announcement was received yet.
+    last_update BIGINT NOT NULL,
+
+    -- alias is the alias the node announced.
+    alias TEXT NOT NULL,
+
+    -- node_data is the serialized node, including its addresses, features
+    -- and the signature of its announcement.
+    node_data BLOB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS graph_nodes_last_update_idx ON graph_nodes(last_update);
+
+-- graph_source_node references the node that is the source of path finding,
+-- which is our own node. The table holds at most one row.
+CREATE TABLE IF NOT EXISTS graph_source_node (
+    node_id BIGINT PRIMARY KEY REFERENCES graph_nodes(id) ON DELETE CASCADE
+);
+
+-- graph_channels contains the static information of all channels of the
+-- channel graph.
+CREATE TABLE IF NOT EXISTS graph_channels (
+    id BIGINT PRIMARY KEY,
+
+    -- scid is the short channel ID of the channel.
+    scid BIGINT NOT NULL UNIQUE,
+
+    -- node_key_1 is the public key of the first node of the channel in
+    -- lexicographical order.
+    node_key_1 BLOB NOT NULL,
+
+    -- node_key_2 is the public key of the second node of the channel in
+    -- lexicographical order.
+    node_key_2 BLOB NOT NULL,
+
+    -- chan_point is the serialized funding outpoint of the channel.
+    chan_point BLOB NOT NULL,
+
+    -- capacity_sat is the capacity of the channel in satoshis.
+    capacity_sat BIGINT NOT NULL,
+
+    -- last_update is the unix timestamp of the latest channel update of
+    -- either direction. It is zero if no channel update was received yet.
+    last_update BIGINT NOT NULL,
+
+    -- edge_info is the serialized channel announcement, including the
+    -- bitcoin keys, features and the authentication proof.
+    edge_info BLOB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS graph_channels_node_key_1_idx ON graph_channels(node_key_1);
+CREATE INDEX IF NOT EXISTS graph_channels_node_key_2_idx ON graph_channels(node_key_2);
+CREATE INDEX IF NOT EXISTS graph_channels_chan_point_idx ON graph_channels(chan_point);
+CREATE INDEX IF NOT EXISTS graph_channels_last_update_idx ON graph_channels(last_update);
+
+-- graph_channel_policies contains the routing policies of both directions of
+-- the graph channels.
+CREATE TABLE IF NOT EXISTS graph_channel_policies (
+    -- channel_id is the reference to the channel the policy belongs to.
+    channel_id BIGINT NOT NULL REFERENCES graph_channels(id) ON DELETE CASCADE,
+
+    -- direction is zero for the policy of the first node and one for the
+    -- policy of the second node.
+    direction SMALLINT NOT NULL,
+
+    -- last_update is the unix timestamp of the channel update.
+    last_update BIGINT NOT NULL,
+
+    -- disabled is true if the channel is disabled in this direction.
+    disabled BOOLEAN NOT NULL,
+
+    -- time_lock_delta is the CLTV delta of the policy.
+    time_lock_delta INTEGER NOT NULL,
+
+    -- min_htlc_msat is the minimum HTLC amount in millisatoshis.
+    min_htlc_msat BIGINT NOT NULL,
+
+    -- max_htlc_msat is the maximum HTLC amount in millisatoshis.
+    max_htlc_msat BIGINT NOT NULL,
+
+    -- fee_base_msat is the base fee in millisatoshis.
+    fee_base_msat BIGINT NOT NULL,
+
+    -- fee_rate_ppm is the proportional fee in millionths.
+    fee_rate_ppm BIGINT NOT NULL,
+
+    -- policy is the serialized channel update.
+    policy BLOB NOT NULL,
+
+    UNIQUE (channel_id, direction)
+);
+
+CREATE INDEX IF NOT EXISTS graph_channel_policies_last_update_idx ON graph_channel_policies(last_update);
+
+-- graph_zombie_channels contains the channels that were removed from the
+-- graph as zombies and may only be resurrected by a fresh channel update.
+CREATE TABLE IF NOT EXISTS graph_zombie_channels (
+    -- scid is the short channel ID of the zombie channel.
+    scid BIGINT PRIMARY KEY,
+
+    -- node_key_1 is the public key of the node that may resurrect the
+    -- channel with an update of the first direction.
+    node_key_1 BLOB NOT NULL,
+
+    -- node_key_2 is the public key of the node that may resurrect the
+    -- channel with an update of the second direction.
+    node_key_2 BLOB NOT NULL
+);
+
+-- graph_prune_log contains the blocks the graph was pruned with, which tell
+-- whether the graph is in sync with the UTXO set.
+CREATE TABLE IF NOT EXISTS graph_prune_log (
+    -- block_height is the height of the block.
+    block_height BIGINT PRIMARY KEY,
+
+    -- block_hash is the hash of the block.
+    block_hash BLOB NOT NULL
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tower_state_updates_hint_idx;
+DROP INDEX IF EXISTS tower_sessions_last_update_at_idx;
+DROP TABLE IF EXISTS tower_lookout_tip;
+DROP TABLE IF EXISTS tower_state_updates;
+DROP TABLE IF EXISTS tower_sessions;
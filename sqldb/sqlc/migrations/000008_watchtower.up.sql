@@ -0,0 +1,63 @@
+-- tower_sessions contains the sessions that watchtower clients negotiated
+-- with our tower.
+CREATE TABLE IF NOT EXISTS tower_sessions (
+    id BIGINT PRIMARY KEY,
+
+    -- session_id is the public key the client authenticates the session
+    -- with. It identifies the client towards the tower.
+    session_id BLOB NOT NULL UNIQUE,
+
+    -- policy is the serialized policy that was negotiated for the session.
+    policy BLOB NOT NULL,
+
+    -- last_applied is the sequence number of the last state update that was
+    -- accepted for the session.
+    last_applied INTEGER NOT NULL,
+
+    -- client_last_applied is the last applied value the client echoed back.
+    client_last_applied INTEGER NOT NULL,
+
+    -- reward_address is the script the tower's reward is paid to. It is
+    -- empty for altruist sessions.
+    reward_address BLOB,
+
+    -- created_at is the unix timestamp at which the session was created.
+    created_at BIGINT NOT NULL,
+
+    -- last_update_at is the unix timestamp of the last state update of the
+    -- session. It equals created_at until the first update is received.
+    last_update_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tower_sessions_last_update_at_idx ON tower_sessions(last_update_at);
+
+-- tower_state_updates contains the encrypted justice data that clients sent
+-- for their sessions.
+CREATE TABLE IF NOT EXISTS tower_state_updates (
+    -- session_id references the session the update belongs to.
+    session_id BIGINT NOT NULL REFERENCES tower_sessions(id) ON DELETE CASCADE,
+
+    -- hint is the breach hint derived from the txid of the revoked
+    -- commitment transaction.
+    hint BLOB NOT NULL,
+
+    -- seq_num is the sequence number of the update within the session.
+    seq_num INTEGER NOT NULL,
+
+    -- encrypted_blob is the encrypted justice kit of the update.
+    encrypted_blob BLOB NOT NULL,
+
+    PRIMARY KEY (session_id, hint)
+);
+
+CREATE INDEX IF NOT EXISTS tower_state_updates_hint_idx ON tower_state_updates(hint);
+
+-- tower_lookout_tip contains the last block the lookout searched for
+-- breaches. The table holds at most one row.
+CREATE TABLE IF NOT EXISTS tower_lookout_tip (
+    -- block_hash is the hash of the block.
+    block_hash BLOB NOT NULL,
+
+    -- block_height is the height of the block.
+    block_height BIGINT NOT NULL
+);
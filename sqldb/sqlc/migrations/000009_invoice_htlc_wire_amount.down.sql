@@ -0,0 +1 @@
+ALTER TABLE invoice_htlcs DROP COLUMN wire_amount_msat;
@@ -0,0 +1,4 @@
+-- wire_amount_msat is the amount an htlc actually carried if an htlc modifier
+-- changed the amount it pays to its invoice, which is stored in amount_msat.
+-- It is NULL if the amount wasn't modified.
+ALTER TABLE invoice_htlcs ADD COLUMN wire_amount_msat BIGINT;
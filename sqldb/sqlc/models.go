@@ -28,6 +28,103 @@ type AmpSubInvoiceHtlc struct {
 	Preimage   []byte
 }
 
+type Channel struct {
+	ID             int64
+	ChanPoint      []byte
+	ChainHash      []byte
+	PeerPubkey     []byte
+	ShortChannelID int64
+	ChanType       int64
+	CapacitySat    int64
+	IsInitiator    bool
+	CloseSummary   []byte
+	CloseHeight    sql.NullInt32
+}
+
+type ChannelCommitment struct {
+	ChannelID    int64
+	IsLocal      bool
+	CommitHeight int64
+	Commitment   []byte
+}
+
+type ChannelFwdPackage struct {
+	ChannelID        int64
+	Height           int64
+	State            int16
+	Adds             []byte
+	SettleFails      []byte
+	FwdFilter        []byte
+	AckFilter        []byte
+	SettleFailFilter []byte
+}
+
+type ChannelRevocationLog struct {
+	ChannelID    int64
+	CommitHeight int64
+	CommitTxHash []byte
+	Entry        []byte
+}
+
+type ForwardingEvent struct {
+	ID             int64
+	TimestampNs    int64
+	IncomingChanID int64
+	OutgoingChanID int64
+	AmtInMsat      int64
+	AmtOutMsat     int64
+	IncomingExpiry int32
+	OutgoingExpiry int32
+	Failed         bool
+}
+
+type GraphChannel struct {
+	ID          int64
+	Scid        int64
+	NodeKey1    []byte
+	NodeKey2    []byte
+	ChanPoint   []byte
+	CapacitySat int64
+	LastUpdate  int64
+	EdgeInfo    []byte
+}
+
+type GraphChannelPolicy struct {
+	ChannelID     int64
+	Direction     int16
+	LastUpdate    int64
+	Disabled      bool
+	TimeLockDelta int32
+	MinHtlcMsat   int64
+	MaxHtlcMsat   int64
+	FeeBaseMsat   int64
+	FeeRatePpm    int64
+	Policy        []byte
+}
+
+type GraphNode struct {
+	ID         int64
+	PubKey     []byte
+	LastUpdate int64
+	Alias      string
+	NodeData   []byte
+}
+
+type GraphPruneLog struct {
+	BlockHeight int64
+	BlockHash   []byte
+}
+
+type GraphSourceNode struct {
+	NodeID int64
+}
+
+type GraphZombieChannel struct {
+	Scid     int64
+	NodeKey1 []byte
+	NodeKey2 []byte
+}
+
 type Invoice struct {
 	ID                 int64
 	Hash               []byte
@@ -68,17 +165,18 @@ type InvoiceFeature struct {
 }
 
 type InvoiceHtlc struct {
-	ID           int64
-	ChanID       string
-	HtlcID       int64
-	AmountMsat   int64
-	TotalMppMsat sql.NullInt64
-	AcceptHeight int32
-	AcceptTime   time.Time
-	ExpiryHeight int32
-	State        int16
-	ResolveTime  sql.NullTime
-	InvoiceID    int64
+	ID             int64
+	ChanID         string
+	HtlcID         int64
+	AmountMsat     int64
+	TotalMppMsat   sql.NullInt64
+	AcceptHeight   int32
+	AcceptTime     time.Time
+	ExpiryHeight   int32
+	State          int16
+	ResolveTime    sql.NullTime
+	InvoiceID      int64
+	WireAmountMsat sql.NullInt64
 }
 
 type InvoiceHtlcCustomRecord struct {
@@ -91,3 +189,36 @@ type InvoiceSequence struct {
 	Name         string
 	CurrentValue int64
 }
+
+type MissionControlPair struct {
+	NodeFrom       []byte
+	NodeTo         []byte
+	FailTimeNs     int64
+	FailAmtMsat    int64
+	SuccessTimeNs  int64
+	SuccessAmtMsat int64
+	StoredTimeNs   int64
+}
+
+type TowerLookoutTip struct {
+	BlockHash   []byte
+	BlockHeight int64
+}
+
+type TowerSession struct {
+	ID                int64
+	SessionID         []byte
+	Policy            []byte
+	LastApplied       int32
+	ClientLastApplied int32
+	RewardAddress     []byte
+	CreatedAt         int64
+	LastUpdateAt      int64
+}
+
+type TowerStateUpdate struct {
+	SessionID     int64
+	Hint          []byte
+	SeqNum        int32
+	EncryptedBlob []byte
+}
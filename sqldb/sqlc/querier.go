@@ -10,13 +10,45 @@ import (
 )
 
 type Querier interface {
+	CountForwardingEvents(ctx context.Context) (int64, error)
+	CountGraphNodes(ctx context.Context) (int64, error)
+	CountRevocationLogEntries(ctx context.Context, channelID int64) (int64, error)
+	CountRevocationLogEntriesInRange(ctx context.Context, arg CountRevocationLogEntriesInRangeParams) (int64, error)
+	CountTowerSessions(ctx context.Context) (int64, error)
 	DeleteCanceledInvoices(ctx context.Context) (sql.Result, error)
+	DeleteChannelCommitments(ctx context.Context, channelID int64) error
+	DeleteFwdPkg(ctx context.Context, arg DeleteFwdPkgParams) error
+	DeleteFwdPkgs(ctx context.Context, channelID int64) error
+	DeleteGraphChannel(ctx context.Context, scid int64) error
+	DeleteGraphChannelPolicies(ctx context.Context, channelID int64) error
+	DeleteGraphNode(ctx context.Context, pubKey []byte) error
+	DeleteGraphPruneLogEntries(ctx context.Context, blockHeight int64) error
+	DeleteGraphSourceNode(ctx context.Context) error
+	DeleteGraphZombieChannel(ctx context.Context, scid int64) error
+	DeleteInactiveTowerSessions(ctx context.Context, lastUpdateAt int64) (sql.Result, error)
 	DeleteInvoice(ctx context.Context, arg DeleteInvoiceParams) (sql.Result, error)
+	DeleteMissionControlPairs(ctx context.Context) error
+	DeleteRevocationLog(ctx context.Context, channelID int64) error
+	DeleteTowerLookoutTip(ctx context.Context) error
+	DeleteTowerSession(ctx context.Context, sessionID []byte) error
+	DeleteUnconnectedGraphNodes(ctx context.Context) (sql.Result, error)
 	FetchAMPSubInvoiceHTLCs(ctx context.Context, arg FetchAMPSubInvoiceHTLCsParams) ([]FetchAMPSubInvoiceHTLCsRow, error)
 	FetchAMPSubInvoices(ctx context.Context, arg FetchAMPSubInvoicesParams) ([]AmpSubInvoice, error)
+	FetchFwdPkgs(ctx context.Context, channelID int64) ([]ChannelFwdPackage, error)
+	FetchMissionControlPairs(ctx context.Context) ([]MissionControlPair, error)
 	FetchSettledAMPSubInvoices(ctx context.Context, arg FetchSettledAMPSubInvoicesParams) ([]FetchSettledAMPSubInvoicesRow, error)
+	FetchTowerStateUpdatesByHint(ctx context.Context, hint []byte) ([]FetchTowerStateUpdatesByHintRow, error)
 	FilterInvoices(ctx context.Context, arg FilterInvoicesParams) ([]Invoice, error)
 	GetAMPInvoiceID(ctx context.Context, setID []byte) (int64, error)
+	GetChannel(ctx context.Context, chanPoint []byte) (Channel, error)
+	GetChannelCommitment(ctx context.Context, arg GetChannelCommitmentParams) (ChannelCommitment, error)
+	GetFwdPkg(ctx context.Context, arg GetFwdPkgParams) (ChannelFwdPackage, error)
+	GetGraphChannel(ctx context.Context, scid int64) (GraphChannel, error)
+	GetGraphChannelPolicies(ctx context.Context, channelID int64) ([]GraphChannelPolicy, error)
+	GetGraphNode(ctx context.Context, pubKey []byte) (GraphNode, error)
+	GetGraphPruneTip(ctx context.Context) (GraphPruneLog, error)
+	GetGraphSourceNode(ctx context.Context) (GraphNode, error)
+	GetGraphZombieChannel(ctx context.Context, scid int64) (GraphZombieChannel, error)
 	// This method may return more than one invoice if filter using multiple fields
 	// from different invoices. It is the caller's responsibility to ensure that
 	// we bubble up an error in those cases.
@@ -24,11 +56,28 @@ type Querier interface {
 	GetInvoiceFeatures(ctx context.Context, invoiceID int64) ([]InvoiceFeature, error)
 	GetInvoiceHTLCCustomRecords(ctx context.Context, invoiceID int64) ([]GetInvoiceHTLCCustomRecordsRow, error)
 	GetInvoiceHTLCs(ctx context.Context, invoiceID int64) ([]InvoiceHtlc, error)
+	GetOpenChannelIDBySCID(ctx context.Context, shortChannelID int64) (int64, error)
+	GetRevocationLogEntry(ctx context.Context, arg GetRevocationLogEntryParams) (ChannelRevocationLog, error)
+	GetTowerLookoutTip(ctx context.Context) (TowerLookoutTip, error)
+	GetTowerSession(ctx context.Context, sessionID []byte) (TowerSession, error)
 	InsertAMPSubInvoiceHTLC(ctx context.Context, arg InsertAMPSubInvoiceHTLCParams) error
+	InsertChannelCommitment(ctx context.Context, arg InsertChannelCommitmentParams) error
+	InsertForwardingEvent(ctx context.Context, arg InsertForwardingEventParams) error
+	InsertFwdPkg(ctx context.Context, arg InsertFwdPkgParams) error
+	InsertGraphShellNode(ctx context.Context, arg InsertGraphShellNodeParams) error
+	InsertGraphSourceNode(ctx context.Context, nodeID int64) error
 	InsertInvoice(ctx context.Context, arg InsertInvoiceParams) (int64, error)
 	InsertInvoiceFeature(ctx context.Context, arg InsertInvoiceFeatureParams) error
 	InsertInvoiceHTLC(ctx context.Context, arg InsertInvoiceHTLCParams) (int64, error)
 	InsertInvoiceHTLCCustomRecord(ctx context.Context, arg InsertInvoiceHTLCCustomRecordParams) error
+	InsertMissionControlPair(ctx context.Context, arg InsertMissionControlPairParams) error
+	InsertRevocationLogEntry(ctx context.Context, arg InsertRevocationLogEntryParams) error
+	InsertTowerLookoutTip(ctx context.Context, arg InsertTowerLookoutTipParams) error
+	ListGraphChannels(ctx context.Context, arg ListGraphChannelsParams) ([]GraphChannel, error)
+	ListGraphNodeChannels(ctx context.Context, pubKey []byte) ([]GraphChannel, error)
+	ListGraphNodes(ctx context.Context, arg ListGraphNodesParams) ([]GraphNode, error)
+	ListTowerClients(ctx context.Context) ([]ListTowerClientsRow, error)
+	MarkChannelClosed(ctx context.Context, arg MarkChannelClosedParams) error
 	NextInvoiceSettleIndex(ctx context.Context) (int64, error)
 	OnAMPSubInvoiceCanceled(ctx context.Context, arg OnAMPSubInvoiceCanceledParams) error
 	OnAMPSubInvoiceCreated(ctx context.Context, arg OnAMPSubInvoiceCreatedParams) error
@@ -36,13 +85,29 @@ type Querier interface {
 	OnInvoiceCanceled(ctx context.Context, arg OnInvoiceCanceledParams) error
 	OnInvoiceCreated(ctx context.Context, arg OnInvoiceCreatedParams) error
 	OnInvoiceSettled(ctx context.Context, arg OnInvoiceSettledParams) error
+	QueryForwardingEvents(ctx context.Context, arg QueryForwardingEventsParams) ([]ForwardingEvent, error)
 	UpdateAMPSubInvoiceHTLCPreimage(ctx context.Context, arg UpdateAMPSubInvoiceHTLCPreimageParams) (sql.Result, error)
 	UpdateAMPSubInvoiceState(ctx context.Context, arg UpdateAMPSubInvoiceStateParams) error
+	UpdateFwdPkgAckFilter(ctx context.Context, arg UpdateFwdPkgAckFilterParams) error
+	UpdateFwdPkgFwdFilter(ctx context.Context, arg UpdateFwdPkgFwdFilterParams) error
+	UpdateFwdPkgSettleFailFilter(ctx context.Context, arg UpdateFwdPkgSettleFailFilterParams) error
+	UpdateGraphChannelLastUpdate(ctx context.Context, arg UpdateGraphChannelLastUpdateParams) error
 	UpdateInvoiceAmountPaid(ctx context.Context, arg UpdateInvoiceAmountPaidParams) (sql.Result, error)
 	UpdateInvoiceHTLC(ctx context.Context, arg UpdateInvoiceHTLCParams) error
 	UpdateInvoiceHTLCs(ctx context.Context, arg UpdateInvoiceHTLCsParams) error
 	UpdateInvoiceState(ctx context.Context, arg UpdateInvoiceStateParams) (sql.Result, error)
+	UpdateTowerSessionLastApplied(ctx context.Context, arg UpdateTowerSessionLastAppliedParams) error
 	UpsertAMPSubInvoice(ctx context.Context, arg UpsertAMPSubInvoiceParams) (sql.Result, error)
+	UpsertChannel(ctx context.Context, arg UpsertChannelParams) (int64, error)
+	UpsertChannelCommitment(ctx context.Context, arg UpsertChannelCommitmentParams) error
+	UpsertFwdPkg(ctx context.Context, arg UpsertFwdPkgParams) error
+	UpsertGraphChannel(ctx context.Context, arg UpsertGraphChannelParams) (int64, error)
+	UpsertGraphChannelPolicy(ctx context.Context, arg UpsertGraphChannelPolicyParams) error
+	UpsertGraphNode(ctx context.Context, arg UpsertGraphNodeParams) (int64, error)
+	UpsertGraphPruneLogEntry(ctx context.Context, arg UpsertGraphPruneLogEntryParams) error
+	UpsertGraphZombieChannel(ctx context.Context, arg UpsertGraphZombieChannelParams) error
+	UpsertTowerSession(ctx context.Context, arg UpsertTowerSessionParams) error
+	UpsertTowerStateUpdate(ctx context.Context, arg UpsertTowerStateUpdateParams) error
 }
 
 var _ Querier = (*Queries)(nil)
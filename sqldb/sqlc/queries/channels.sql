@@ -0,0 +1,132 @@
+-- name: UpsertChannel :one
+INSERT INTO channels (
+    chan_point, chain_hash, peer_pubkey, short_channel_id, chan_type,
+    capacity_sat, is_initiator
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (chan_point) DO UPDATE SET
+    short_channel_id = EXCLUDED.short_channel_id,
+    chan_type = EXCLUDED.chan_type
+RETURNING id;
+
+-- name: GetChannel :one
+SELECT *
+FROM channels
+WHERE chan_point = $1;
+
+-- name: GetOpenChannelIDBySCID :one
+SELECT id
+FROM channels
+WHERE short_channel_id = $1 AND close_summary IS NULL;
+
+-- name: MarkChannelClosed :exec
+UPDATE channels
+SET close_summary = $2, close_height = $3
+WHERE id = $1;
+
+-- name: UpsertChannelCommitment :exec
+INSERT INTO channel_commitments (
+    channel_id, is_local, commit_height, commitment
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (channel_id, is_local) DO UPDATE SET
+    commit_height = EXCLUDED.commit_height,
+    commitment = EXCLUDED.commitment;
+
+-- name: InsertChannelCommitment :exec
+INSERT INTO channel_commitments (
+    channel_id, is_local, commit_height, commitment
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (channel_id, is_local) DO NOTHING;
+
+-- name: GetChannelCommitment :one
+SELECT *
+FROM channel_commitments
+WHERE channel_id = $1 AND is_local = $2;
+
+-- name: DeleteChannelCommitments :exec
+DELETE FROM channel_commitments
+WHERE channel_id = $1;
+
+-- name: InsertRevocationLogEntry :exec
+INSERT INTO channel_revocation_log (
+    channel_id, commit_height, commit_tx_hash, entry
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (channel_id, commit_height) DO NOTHING;
+
+-- name: GetRevocationLogEntry :one
+SELECT *
+FROM channel_revocation_log
+WHERE channel_id = $1 AND commit_height = $2;
+
+-- name: CountRevocationLogEntries :one
+SELECT COUNT(*)
+FROM channel_revocation_log
+WHERE channel_id = $1;
+
+-- name: CountRevocationLogEntriesInRange :one
+SELECT COUNT(*)
+FROM channel_revocation_log
+WHERE channel_id = $1 AND commit_height >= $2 AND commit_height <= $3;
+
+-- name: DeleteRevocationLog :exec
+DELETE FROM channel_revocation_log
+WHERE channel_id = $1;
+
+-- name: UpsertFwdPkg :exec
+INSERT INTO channel_fwd_packages (
+    channel_id, height, state, adds, settle_fails, fwd_filter, ack_filter,
+    settle_fail_filter
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) ON CONFLICT (channel_id, height) DO UPDATE SET
+    state = EXCLUDED.state,
+    adds = EXCLUDED.adds,
+    settle_fails = EXCLUDED.settle_fails,
+    fwd_filter = EXCLUDED.fwd_filter,
+    ack_filter = EXCLUDED.ack_filter,
+    settle_fail_filter = EXCLUDED.settle_fail_filter;
+
+-- name: InsertFwdPkg :exec
+INSERT INTO channel_fwd_packages (
+    channel_id, height, state, adds, settle_fails, fwd_filter, ack_filter,
+    settle_fail_filter
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) ON CONFLICT (channel_id, height) DO NOTHING;
+
+-- name: GetFwdPkg :one
+SELECT *
+FROM channel_fwd_packages
+WHERE channel_id = $1 AND height = $2;
+
+-- name: FetchFwdPkgs :many
+SELECT *
+FROM channel_fwd_packages
+WHERE channel_id = $1
+ORDER BY height ASC;
+
+-- name: UpdateFwdPkgFwdFilter :exec
+UPDATE channel_fwd_packages
+SET state = $3, fwd_filter = $4
+WHERE channel_id = $1 AND height = $2;
+
+-- name: UpdateFwdPkgAckFilter :exec
+UPDATE channel_fwd_packages
+SET ack_filter = $3
+WHERE channel_id = $1 AND height = $2;
+
+-- name: UpdateFwdPkgSettleFailFilter :exec
+UPDATE channel_fwd_packages
+SET settle_fail_filter = $3
+WHERE channel_id = $1 AND height = $2;
+
+-- name: DeleteFwdPkg :exec
+DELETE FROM channel_fwd_packages
+WHERE channel_id = $1 AND height = $2;
+
+-- name: DeleteFwdPkgs :exec
+DELETE FROM channel_fwd_packages
+WHERE channel_id = $1;
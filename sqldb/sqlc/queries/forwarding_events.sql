@@ -0,0 +1,20 @@
+-- name: InsertForwardingEvent :exec
+INSERT INTO forwarding_events (
+    timestamp_ns, incoming_chan_id, outgoing_chan_id, amt_in_msat,
+    amt_out_msat, incoming_expiry, outgoing_expiry, failed
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: QueryForwardingEvents :many
+SELECT *
+FROM forwarding_events
+WHERE timestamp_ns >= @start_time AND timestamp_ns <= @end_time AND (
+    failed = FALSE OR failed = @include_failures
+)
+ORDER BY timestamp_ns, id
+LIMIT @num_limit OFFSET @num_offset;
+
+-- name: CountForwardingEvents :one
+SELECT COUNT(*)
+FROM forwarding_events;
@@ -0,0 +1,160 @@
+-- name: UpsertGraphNode :one
+INSERT INTO graph_nodes (
+    pub_key, last_update, alias, node_data
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (pub_key) DO UPDATE SET
+    last_update = EXCLUDED.last_update,
+    alias = EXCLUDED.alias,
+    node_data = EXCLUDED.node_data
+RETURNING id;
+
+-- name: InsertGraphShellNode :exec
+INSERT INTO graph_nodes (
+    pub_key, last_update, alias, node_data
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (pub_key) DO NOTHING;
+
+-- name: GetGraphNode :one
+SELECT *
+FROM graph_nodes
+WHERE pub_key = $1;
+
+-- name: DeleteGraphNode :exec
+DELETE FROM graph_nodes
+WHERE pub_key = $1;
+
+-- name: ListGraphNodes :many
+SELECT *
+FROM graph_nodes
+WHERE pub_key > @after_pub_key AND last_update >= @min_last_update
+ORDER BY pub_key
+LIMIT @num_limit;
+
+-- name: CountGraphNodes :one
+SELECT COUNT(*)
+FROM graph_nodes;
+
+-- name: DeleteUnconnectedGraphNodes :execresult
+DELETE FROM graph_nodes
+WHERE id NOT IN (SELECT node_id FROM graph_source_node) AND NOT EXISTS (
+    SELECT 1 FROM graph_channels c WHERE c.node_key_1 = graph_nodes.pub_key
+) AND NOT EXISTS (
+    SELECT 1 FROM graph_channels c WHERE c.node_key_2 = graph_nodes.pub_key
+);
+
+-- name: DeleteGraphSourceNode :exec
+DELETE FROM graph_source_node;
+
+-- name: InsertGraphSourceNode :exec
+INSERT INTO graph_source_node (node_id)
+VALUES ($1);
+
+-- name: GetGraphSourceNode :one
+SELECT n.*
+FROM graph_nodes n
+JOIN graph_source_node s ON s.node_id = n.id;
+
+-- name: UpsertGraphChannel :one
+INSERT INTO graph_channels (
+    scid, node_key_1, node_key_2, chan_point, capacity_sat, last_update,
+    edge_info
+) VALUES (
+    $1, $2, $3, $4, $5, 0, $6
+) ON CONFLICT (scid) DO UPDATE SET
+    node_key_1 = EXCLUDED.node_key_1,
+    node_key_2 = EXCLUDED.node_key_2,
+    chan_point = EXCLUDED.chan_point,
+    capacity_sat = EXCLUDED.capacity_sat,
+    edge_info = EXCLUDED.edge_info
+RETURNING id;
+
+-- name: GetGraphChannel :one
+SELECT *
+FROM graph_channels
+WHERE scid = $1;
+
+-- name: DeleteGraphChannel :exec
+DELETE FROM graph_channels
+WHERE scid = $1;
+
+-- name: ListGraphChannels :many
+SELECT *
+FROM graph_channels
+WHERE scid > @after_scid AND last_update >= @min_last_update
+ORDER BY scid
+LIMIT @num_limit;
+
+-- name: ListGraphNodeChannels :many
+SELECT *
+FROM graph_channels
+WHERE node_key_1 = @pub_key OR node_key_2 = @pub_key
+ORDER BY scid;
+
+-- name: UpdateGraphChannelLastUpdate :exec
+UPDATE graph_channels
+SET last_update = @last_update
+WHERE id = @id AND last_update < @last_update;
+
+-- name: UpsertGraphChannelPolicy :exec
+INSERT INTO graph_channel_policies (
+    channel_id, direction, last_update, disabled, time_lock_delta,
+    min_htlc_msat, max_htlc_msat, fee_base_msat, fee_rate_ppm, policy
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) ON CONFLICT (channel_id, direction) DO UPDATE SET
+    last_update = EXCLUDED.last_update,
+    disabled = EXCLUDED.disabled,
+    time_lock_delta = EXCLUDED.time_lock_delta,
+    min_htlc_msat = EXCLUDED.min_htlc_msat,
+    max_htlc_msat = EXCLUDED.max_htlc_msat,
+    fee_base_msat = EXCLUDED.fee_base_msat,
+    fee_rate_ppm = EXCLUDED.fee_rate_ppm,
+    policy = EXCLUDED.policy;
+
+-- name: GetGraphChannelPolicies :many
+SELECT *
+FROM graph_channel_policies
+WHERE channel_id = $1
+ORDER BY direction;
+
+-- name: DeleteGraphChannelPolicies :exec
+DELETE FROM graph_channel_policies
+WHERE channel_id = $1;
+
+-- name: UpsertGraphZombieChannel :exec
+INSERT INTO graph_zombie_channels (
+    scid, node_key_1, node_key_2
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (scid) DO UPDATE SET
+    node_key_1 = EXCLUDED.node_key_1,
+    node_key_2 = EXCLUDED.node_key_2;
+
+-- name: GetGraphZombieChannel :one
+SELECT *
+FROM graph_zombie_channels
+WHERE scid = $1;
+
+-- name: DeleteGraphZombieChannel :exec
+DELETE FROM graph_zombie_channels
+WHERE scid = $1;
+
+-- name: UpsertGraphPruneLogEntry :exec
+INSERT INTO graph_prune_log (
+    block_height, block_hash
+) VALUES (
+    $1, $2
+) ON CONFLICT (block_height) DO UPDATE SET
+    block_hash = EXCLUDED.block_hash;
+
+-- name: GetGraphPruneTip :one
+SELECT *
+FROM graph_prune_log
+ORDER BY block_height DESC
+LIMIT 1;
+
+-- name: DeleteGraphPruneLogEntries :exec
+DELETE FROM graph_prune_log
+WHERE block_height >= $1;
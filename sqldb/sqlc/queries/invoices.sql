@@ -131,9 +131,9 @@ WHERE state = 2;
 -- name: InsertInvoiceHTLC :one
 INSERT INTO invoice_htlcs (
     htlc_id, chan_id, amount_msat, total_mpp_msat, accept_height, accept_time,
-    expiry_height, state, resolve_time, invoice_id
+    expiry_height, state, resolve_time, invoice_id, wire_amount_msat
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING id;
 
 -- name: GetInvoiceHTLCs :many
@@ -0,0 +1,15 @@
+-- name: InsertMissionControlPair :exec
+INSERT INTO mission_control_pairs (
+    node_from, node_to, fail_time_ns, fail_amt_msat, success_time_ns,
+    success_amt_msat, stored_time_ns
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: FetchMissionControlPairs :many
+SELECT *
+FROM mission_control_pairs
+ORDER BY node_from, node_to;
+
+-- name: DeleteMissionControlPairs :exec
+DELETE FROM mission_control_pairs;
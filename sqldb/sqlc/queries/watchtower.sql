@@ -0,0 +1,77 @@
+-- name: UpsertTowerSession :exec
+INSERT INTO tower_sessions (
+    session_id, policy, last_applied, client_last_applied, reward_address,
+    created_at, last_update_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (session_id) DO UPDATE SET
+    policy = EXCLUDED.policy,
+    last_applied = EXCLUDED.last_applied,
+    client_last_applied = EXCLUDED.client_last_applied,
+    reward_address = EXCLUDED.reward_address,
+    created_at = EXCLUDED.created_at,
+    last_update_at = EXCLUDED.last_update_at;
+
+-- name: GetTowerSession :one
+SELECT *
+FROM tower_sessions
+WHERE session_id = $1;
+
+-- name: UpdateTowerSessionLastApplied :exec
+UPDATE tower_sessions
+SET last_applied = $2, client_last_applied = $3, last_update_at = $4
+WHERE id = $1;
+
+-- name: DeleteTowerSession :exec
+DELETE FROM tower_sessions
+WHERE session_id = $1;
+
+-- name: DeleteInactiveTowerSessions :execresult
+DELETE FROM tower_sessions
+WHERE last_update_at < $1;
+
+-- name: CountTowerSessions :one
+SELECT COUNT(*)
+FROM tower_sessions;
+
+-- name: ListTowerClients :many
+SELECT
+    s.session_id, s.policy, s.last_applied, s.created_at, s.last_update_at,
+    COUNT(u.hint) AS num_updates,
+    CAST(COALESCE(SUM(LENGTH(u.encrypted_blob)), 0) AS BIGINT) AS disk_usage
+FROM tower_sessions s
+LEFT JOIN tower_state_updates u ON u.session_id = s.id
+GROUP BY s.id
+ORDER BY s.id;
+
+-- name: UpsertTowerStateUpdate :exec
+INSERT INTO tower_state_updates (
+    session_id, hint, seq_num, encrypted_blob
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (session_id, hint) DO UPDATE SET
+    seq_num = EXCLUDED.seq_num,
+    encrypted_blob = EXCLUDED.encrypted_blob;
+
+-- name: FetchTowerStateUpdatesByHint :many
+SELECT
+    s.session_id, s.policy, s.last_applied, s.client_last_applied,
+    s.reward_address, u.hint, u.seq_num, u.encrypted_blob
+FROM tower_state_updates u
+JOIN tower_sessions s ON s.id = u.session_id
+WHERE u.hint = $1
+ORDER BY s.id;
+
+-- name: DeleteTowerLookoutTip :exec
+DELETE FROM tower_lookout_tip;
+
+-- name: InsertTowerLookoutTip :exec
+INSERT INTO tower_lookout_tip (
+    block_hash, block_height
+) VALUES (
+    $1, $2
+);
+
+-- name: GetTowerLookoutTip :one
+SELECT *
+FROM tower_lookout_tip;
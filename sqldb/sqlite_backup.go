@@ -0,0 +1,68 @@
+//go:build !js && !(windows && (arm || 386)) && !(linux && (ppc64 || mips || mipsle || mips64))
+
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"modernc.org/sqlite"
+)
+
+// sqliteBackuper is the interface of the modernc.org/sqlite driver connection
+// that gives access to SQLite's online backup API.
+type sqliteBackuper interface {
+	NewBackup(dstURI string) (*sqlite.Backup, error)
+}
+
+// BackupSqliteFile writes a consistent snapshot of the SQLite database at
+// srcPath to destPath using SQLite's online backup API. The source database
+// may be in use by other connections while the backup is created.
+func BackupSqliteFile(ctx context.Context, srcPath, destPath string) error {
+	if err := checkBackupDest(destPath); err != nil {
+		return err
+	}
+
+	// The backup needs to wait for writers of the source database to
+	// finish, so we use the same busy timeout as our regular connections.
+	sqliteOptions := make(url.Values)
+	sqliteOptions.Add(sqliteOptionPrefix, "busy_timeout=5000")
+	dsn := fmt.Sprintf("%v?%v", srcPath, sqliteOptions.Encode())
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		src, ok := driverConn.(sqliteBackuper)
+		if !ok {
+			return fmt.Errorf("sqlite driver doesn't support " +
+				"online backups")
+		}
+
+		backup, err := src.NewBackup(destPath)
+		if err != nil {
+			return err
+		}
+
+		// We copy all pages in a single step, which holds a read lock
+		// on the source database until the backup is complete and
+		// therefore results in a consistent snapshot.
+		if _, err := backup.Step(-1); err != nil {
+			_ = backup.Finish()
+			return err
+		}
+
+		return backup.Finish()
+	})
+}
@@ -0,0 +1,60 @@
+//go:build !js && !(windows && (arm || 386)) && !(linux && (ppc64 || mips || mipsle || mips64))
+
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBackupSqliteFile tests that a backup of a SQLite database that is in use
+// contains all committed data, and that an existing backup isn't overwritten.
+func TestBackupSqliteFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.db")
+	destPath := filepath.Join(dir, "backup.db")
+
+	srcDB, err := sql.Open("sqlite", srcPath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, srcDB.Close())
+	})
+
+	_, err = srcDB.ExecContext(
+		ctx, "CREATE TABLE records (id INTEGER PRIMARY KEY, data BLOB)",
+	)
+	require.NoError(t, err)
+	_, err = srcDB.ExecContext(
+		ctx, "INSERT INTO records (data) VALUES (?), (?)",
+		[]byte{1}, []byte{2},
+	)
+	require.NoError(t, err)
+
+	// The source database is still open while we create the backup.
+	require.NoError(t, BackupSqliteFile(ctx, srcPath, destPath))
+
+	backupDB, err := sql.Open("sqlite", destPath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, backupDB.Close())
+	})
+
+	var numRecords int
+	err = backupDB.QueryRowContext(
+		ctx, "SELECT COUNT(*) FROM records",
+	).Scan(&numRecords)
+	require.NoError(t, err)
+	require.Equal(t, 2, numRecords)
+
+	// A second backup to the same file must fail.
+	require.ErrorContains(
+		t, BackupSqliteFile(ctx, srcPath, destPath), "already exists",
+	)
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btclog"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/chainreg"
@@ -13,6 +15,7 @@ import (
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/invoices"
 	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnrpc/accountingrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/autopilotrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/devrpc"
@@ -24,10 +27,12 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/watchtowerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/wtclientrpc"
+	"github.com/lightningnetwork/lnd/lnwallet/signpolicy"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/netann"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/lightningnetwork/lnd/watchtower"
 	"github.com/lightningnetwork/lnd/watchtower/wtclient"
@@ -67,6 +72,10 @@ type subRPCServerConfigs struct {
 	// as a gRPC service.
 	PeersRPC *peersrpc.Config `group:"peersrpc" namespace:"peersrpc"`
 
+	// AccountingRPC is a sub-RPC server that exports the financial
+	// history of the node as a ledger.
+	AccountingRPC *accountingrpc.Config `group:"accountingrpc" namespace:"accountingrpc"`
+
 	// NeutrinoKitRPC is a sub-RPC server that exposes functionality allowing
 	// a client to interact with a running neutrino node.
 	NeutrinoKitRPC *neutrinorpc.Config `group:"neutrinorpc" namespace:"neutrinorpc"`
@@ -104,6 +113,8 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 	networkDir string, macService *macaroons.Service,
 	atpl *autopilot.Manager,
 	invoiceRegistry *invoices.InvoiceRegistry,
+	invoiceDB invoices.InvoiceDB,
+	fwdingLog channeldb.ForwardingEventStore,
 	htlcSwitch *htlcswitch.Switch,
 	activeNetParams *chaincfg.Params,
 	chanRouter *routing.ChannelRouter,
@@ -122,7 +133,10 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 		modifiers ...netann.NodeAnnModifier) error,
 	parseAddr func(addr string) (net.Addr, error),
 	rpcLogger btclog.Logger,
-	getAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)) error {
+	getAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error),
+	getChannelUptime func(wire.OutPoint, route.Vertex) (time.Duration,
+		time.Duration, error),
+	signPolicy *signpolicy.Policy) error {
 
 	// First, we'll use reflect to obtain a version of the config struct
 	// that allows us to programmatically inspect its fields.
@@ -163,6 +177,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("KeyRing").Set(
 				reflect.ValueOf(cc.KeyRing),
 			)
+			subCfgValue.FieldByName("SignPolicy").Set(
+				reflect.ValueOf(signPolicy),
+			)
 
 		case *walletrpc.Config:
 			subCfgValue := extractReflectValue(subCfg)
@@ -202,6 +219,12 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 					cc.Wallet.Cfg.CoinSelectionStrategy,
 				),
 			)
+			subCfgValue.FieldByName("AnchorReserve").Set(
+				reflect.ValueOf(*cfg.anchorReservePolicy()),
+			)
+			subCfgValue.FieldByName("SignPolicy").Set(
+				reflect.ValueOf(signPolicy),
+			)
 
 		case *autopilotrpc.Config:
 			subCfgValue := extractReflectValue(subCfg)
@@ -226,6 +249,14 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 				reflect.ValueOf(cc.ChainIO),
 			)
 
+			// The mempool notifier is only available with the
+			// bitcoind and btcd backends.
+			if cc.MempoolNotifier != nil {
+				subCfgValue.FieldByName("MempoolNotifier").Set(
+					reflect.ValueOf(cc.MempoolNotifier),
+				)
+			}
+
 		case *invoicesrpc.Config:
 			subCfgValue := extractReflectValue(subCfg)
 
@@ -266,6 +297,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("GetAlias").Set(
 				reflect.ValueOf(getAlias),
 			)
+			subCfgValue.FieldByName("GetChannelUptime").Set(
+				reflect.ValueOf(getChannelUptime),
+			)
 
 		case *neutrinorpc.Config:
 			subCfgValue := extractReflectValue(subCfg)
@@ -332,6 +366,37 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 				reflect.ValueOf(updateNodeAnnouncement),
 			)
 
+		case *accountingrpc.Config:
+			subCfgValue := extractReflectValue(subCfg)
+
+			subCfgValue.FieldByName("ChanStateDB").Set(
+				reflect.ValueOf(chanStateDB),
+			)
+			subCfgValue.FieldByName("PaymentsDB").Set(
+				reflect.ValueOf(chanStateDB.GetParentDB()),
+			)
+			subCfgValue.FieldByName("InvoiceDB").Set(
+				reflect.ValueOf(&invoiceDB).Elem(),
+			)
+			subCfgValue.FieldByName("ForwardingLog").Set(
+				reflect.ValueOf(&fwdingLog).Elem(),
+			)
+			flushFwdEvents := htlcSwitch.FlushForwardingEvents
+			subCfgValue.FieldByName("FlushForwardingEvents").Set(
+				reflect.ValueOf(flushFwdEvents),
+			)
+			subCfgValue.FieldByName("Wallet").Set(
+				reflect.ValueOf(cc.Wc),
+			)
+			subCfgValue.FieldByName("ChainIO").Set(
+				reflect.ValueOf(cc.ChainIO),
+			)
+			subCfgValue.FieldByName("SelfNode").Set(
+				reflect.ValueOf(route.Vertex(
+					getNodeAnnouncement().NodeID,
+				)),
+			)
+
 		default:
 			return fmt.Errorf("unknown field: %v, %T", fieldName,
 				cfg)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/fn"
 	"github.com/lightningnetwork/lnd/input"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -31,18 +32,30 @@ type BudgetAggregator struct {
 	// maxInputs specifies the maximum number of inputs allowed in a single
 	// sweep tx.
 	maxInputs uint32
+
+	// htlcAggregationWindow is the number of blocks by which the deadlines
+	// of second-level HTLC claims may differ for them to still be swept in
+	// the same transaction. Zero means only claims with the same deadline
+	// are aggregated.
+	htlcAggregationWindow int32
 }
 
 // Compile-time constraint to ensure BudgetAggregator implements UtxoAggregator.
 var _ UtxoAggregator = (*BudgetAggregator)(nil)
 
 // NewBudgetAggregator creates a new instance of a BudgetAggregator.
-func NewBudgetAggregator(estimator chainfee.Estimator,
-	maxInputs uint32) *BudgetAggregator {
+//
+// The htlcAggregationWindow allows second-level HTLC claims, which may come
+// from different force closed channels, to be swept in the same transaction
+// if their deadlines differ by at most the given number of blocks. The
+// aggregated claims are swept using the earliest of their deadlines.
+func NewBudgetAggregator(estimator chainfee.Estimator, maxInputs uint32,
+	htlcAggregationWindow uint32) *BudgetAggregator {
 
 	return &BudgetAggregator{
-		estimator: estimator,
-		maxInputs: maxInputs,
+		estimator:             estimator,
+		maxInputs:             maxInputs,
+		htlcAggregationWindow: int32(htlcAggregationWindow),
 	}
 }
 
@@ -57,6 +70,9 @@ type clusterGroup map[int32][]SweeperInput
 // 5. optionally split a cluster if it exceeds the max input limit.
 // 6. create input sets from each of the clusters.
 // 7. create input sets for each of the exclusive inputs.
+//
+// NOTE: second-level HTLC claims are grouped into the cluster of the earliest
+// deadline within the HTLC aggregation window.
 func (b *BudgetAggregator) ClusterInputs(inputs InputsMap) []InputSet {
 	// Filter out inputs that have a budget below min relay fee.
 	filteredInputs := b.filterInputs(inputs)
@@ -70,6 +86,10 @@ func (b *BudgetAggregator) ClusterInputs(inputs InputsMap) []InputSet {
 	// grouping exclusive inputs may jeopardize non-exclusive inputs.
 	exclusiveInputs := make(map[wire.OutPoint]clusterGroup)
 
+	// htlcClaims is the list of second-level HTLC claims that are
+	// clustered based on the HTLC aggregation window.
+	var htlcClaims []SweeperInput
+
 	// Iterate all the inputs and group them based on their specified
 	// deadline heights.
 	for _, input := range filteredInputs {
@@ -87,6 +107,12 @@ func (b *BudgetAggregator) ClusterInputs(inputs InputsMap) []InputSet {
 			continue
 		}
 
+		if b.htlcAggregationWindow > 0 && isHtlcClaim(input) {
+			htlcClaims = append(htlcClaims, *input)
+
+			continue
+		}
+
 		cluster, ok := clusters[height]
 		if !ok {
 			cluster = make([]SweeperInput, 0)
@@ -96,6 +122,32 @@ func (b *BudgetAggregator) ClusterInputs(inputs InputsMap) []InputSet {
 		clusters[height] = cluster
 	}
 
+	// Add the HTLC claims to the clusters. Starting with the claim of the
+	// earliest deadline, all claims whose deadline is within the window
+	// are put in the cluster of that deadline. The deadline of the claims
+	// is moved forward accordingly, which only affects the copies used to
+	// create the input sets.
+	sort.Slice(htlcClaims, func(i, j int) bool {
+		return htlcClaims[i].DeadlineHeight <
+			htlcClaims[j].DeadlineHeight
+	})
+	var windowStart int32
+	for i, input := range htlcClaims {
+		if i == 0 || input.DeadlineHeight >
+			windowStart+b.htlcAggregationWindow {
+
+			windowStart = input.DeadlineHeight
+		}
+
+		log.Tracef("HTLC claim %v with deadline %v is clustered at "+
+			"deadline %v", input.OutPoint(), input.DeadlineHeight,
+			windowStart)
+
+		input.DeadlineHeight = windowStart
+		input.params.DeadlineHeight = fn.Some(windowStart)
+		clusters[windowStart] = append(clusters[windowStart], input)
+	}
+
 	// Now that we have the clusters, we can create the input sets.
 	//
 	// NOTE: cannot pre-allocate the slice since we don't know the number
@@ -373,6 +425,23 @@ func splitOnLocktime(inputs []SweeperInput) map[uint32][]SweeperInput {
 	return result
 }
 
+// isHtlcClaim returns true if the input claims the output of an HTLC on a
+// commitment transaction with a second-level HTLC transaction that can be
+// aggregated with other inputs.
+func isHtlcClaim(inp *SweeperInput) bool {
+	switch inp.WitnessType() {
+	case input.HtlcOfferedTimeoutSecondLevelInputConfirmed,
+		input.HtlcAcceptedSuccessSecondLevelInputConfirmed,
+		input.TaprootHtlcLocalOfferedTimeout,
+		input.TaprootHtlcAcceptedLocalSuccess:
+
+		return true
+
+	default:
+		return false
+	}
+}
+
 // isDustOutput checks if the given output is considered as dust.
 func isDustOutput(output *wire.TxOut) bool {
 	// Fetch the dust limit for this output.
@@ -150,7 +150,7 @@ func TestBudgetAggregatorFilterInputs(t *testing.T) {
 
 	// Init the budget aggregator with the mocked estimator and zero max
 	// num of inputs.
-	b := NewBudgetAggregator(estimator, 0)
+	b := NewBudgetAggregator(estimator, 0, 0)
 
 	// Call the method under test.
 	result := b.filterInputs(inputs)
@@ -214,7 +214,7 @@ func TestBudgetAggregatorSortInputs(t *testing.T) {
 	}
 
 	// Init the budget aggregator with zero max num of inputs.
-	b := NewBudgetAggregator(nil, 0)
+	b := NewBudgetAggregator(nil, 0, 0)
 
 	// Call the method under test.
 	result := b.sortInputs(inputs)
@@ -279,7 +279,7 @@ func TestBudgetAggregatorCreateInputSets(t *testing.T) {
 	}
 
 	// Create a budget aggregator with max number of inputs set to 2.
-	b := NewBudgetAggregator(nil, 2)
+	b := NewBudgetAggregator(nil, 2, 0)
 
 	// Create test cases.
 	testCases := []struct {
@@ -540,7 +540,7 @@ func TestBudgetInputSetClusterInputs(t *testing.T) {
 	}
 
 	// Create a budget aggregator with a max number of inputs set to 100.
-	b := NewBudgetAggregator(estimator, DefaultMaxInputsPerTx)
+	b := NewBudgetAggregator(estimator, DefaultMaxInputsPerTx, 0)
 
 	// Call the method under test.
 	result := b.ClusterInputs(inputs)
@@ -572,6 +572,72 @@ func TestBudgetInputSetClusterInputs(t *testing.T) {
 	require.Contains(t, deadlines, deadline2)
 }
 
+// TestBudgetAggregatorHtlcAggregationWindow checks that second-level HTLC
+// claims whose deadlines are within the HTLC aggregation window are clustered
+// into the same input set, using the earliest of their deadlines, while other
+// inputs are still clustered by their exact deadline.
+func TestBudgetAggregatorHtlcAggregationWindow(t *testing.T) {
+	t.Parallel()
+
+	estimator := &chainfee.MockEstimator{}
+	defer estimator.AssertExpectations(t)
+	estimator.On("RelayFeePerKW").Return(chainfee.FeePerKwFloor).Once()
+
+	inputs := make(InputsMap)
+	addInput := func(index uint32, wt input.WitnessType,
+		deadline int32) {
+
+		op := wire.OutPoint{Hash: chainhash.Hash{1}, Index: index}
+
+		inp := &input.MockInput{}
+		t.Cleanup(func() {
+			inp.AssertExpectations(t)
+		})
+
+		inp.On("OutPoint").Return(op)
+		inp.On("WitnessType").Return(wt)
+		inp.On("RequiredTxOut").Return(nil)
+		inp.On("RequiredLockTime").Return(uint32(0), false)
+
+		inputs[op] = &SweeperInput{
+			Input: inp,
+			params: Params{
+				Budget:         100_000,
+				DeadlineHeight: fn.Some(deadline),
+			},
+			DeadlineHeight: deadline,
+		}
+	}
+
+	// The first three HTLC claims are within the window of the first one,
+	// so they're swept together. The last one is outside of the window and
+	// starts a new cluster.
+	addInput(1, input.HtlcAcceptedSuccessSecondLevelInputConfirmed, 100)
+	addInput(2, input.HtlcOfferedTimeoutSecondLevelInputConfirmed, 103)
+	addInput(3, input.TaprootHtlcAcceptedLocalSuccess, 106)
+	addInput(4, input.HtlcAcceptedSuccessSecondLevelInputConfirmed, 107)
+
+	// Inputs that aren't HTLC claims are only clustered with inputs of the
+	// same deadline.
+	addInput(5, input.CommitmentTimeLock, 103)
+
+	b := NewBudgetAggregator(estimator, DefaultMaxInputsPerTx, 6)
+	result := b.ClusterInputs(inputs)
+	require.Len(t, result, 3)
+
+	sets := make(map[int32][]uint32)
+	for _, set := range result {
+		height := set.DeadlineHeight()
+		for _, inp := range set.Inputs() {
+			sets[height] = append(sets[height], inp.OutPoint().Index)
+		}
+	}
+
+	require.ElementsMatch(t, []uint32{1, 2, 3}, sets[100])
+	require.ElementsMatch(t, []uint32{5}, sets[103])
+	require.ElementsMatch(t, []uint32{4}, sets[107])
+}
+
 // TestSplitOnLocktime asserts `splitOnLocktime` works as expected.
 func TestSplitOnLocktime(t *testing.T) {
 	t.Parallel()
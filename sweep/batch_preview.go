@@ -0,0 +1,334 @@
+package sweep
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+// FeeRateProjection is the fee rate the fee bumper is projected to use for a
+// batch at a given block height.
+type FeeRateProjection struct {
+	// Height is the block height the fee rate is used at.
+	Height int32
+
+	// FeeRate is the projected fee rate.
+	FeeRate chainfee.SatPerKWeight
+}
+
+// PendingBatch describes a set of inputs that the UtxoSweeper sweeps, or
+// intends to sweep, in a single transaction.
+type PendingBatch struct {
+	// Inputs is the set of inputs in the batch. Wallet inputs that may be
+	// added to the batch to pay for fees aren't included.
+	Inputs []wire.OutPoint
+
+	// Txid is the hash of the sweeping transaction if the batch has
+	// already been published.
+	Txid fn.Option[chainhash.Hash]
+
+	// Weight is the estimated weight of the sweeping transaction.
+	Weight lntypes.WeightUnit
+
+	// Budget is the total budget of the inputs in the batch.
+	Budget btcutil.Amount
+
+	// DeadlineHeight is the height by which the batch should be confirmed.
+	DeadlineHeight int32
+
+	// NeedWalletInput is true if wallet inputs must be added to the batch
+	// to pay for its fees or required outputs.
+	NeedWalletInput bool
+
+	// NextBroadcastHeight is the height at which the batch will be
+	// (re-)broadcast next.
+	NextBroadcastHeight int32
+
+	// FeeRates is the projected fee rate trajectory of the batch, starting
+	// at the next broadcast height and ending once the max fee rate is
+	// reached.
+	FeeRates []FeeRateProjection
+}
+
+// PendingBatches is a preview of how the UtxoSweeper intends to sweep its
+// pending inputs.
+type PendingBatches struct {
+	// Batches is the list of published and intended batches.
+	Batches []*PendingBatch
+
+	// Immature maps inputs that can't be swept yet due to their timelocks
+	// to the height at which they will first be offered to a batch.
+	Immature map[wire.OutPoint]int32
+}
+
+// pendingBatchesReq is an internal message we'll use to represent an external
+// caller's intent to preview the batches of the pending inputs.
+type pendingBatchesReq struct {
+	respChan chan *PendingBatches
+	errChan  chan error
+}
+
+// PendingBatches returns a preview of the batches that the UtxoSweeper
+// currently intends to sweep its pending inputs in, along with the projected
+// fee rates of each batch. Inputs whose sweeping transaction is about to be
+// published aren't included.
+func (s *UtxoSweeper) PendingBatches() (*PendingBatches, error) {
+	respChan := make(chan *PendingBatches, 1)
+	errChan := make(chan error, 1)
+	select {
+	case s.pendingBatchesReqs <- &pendingBatchesReq{
+		respChan: respChan,
+		errChan:  errChan,
+	}:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	select {
+	case batches := <-respChan:
+		return batches, nil
+	case err := <-errChan:
+		return nil, err
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+}
+
+// handlePendingBatchesReq handles a request to preview the batches of the
+// pending inputs.
+func (s *UtxoSweeper) handlePendingBatchesReq(req *pendingBatchesReq) {
+	batches, err := s.pendingBatches()
+	if err != nil {
+		req.errChan <- err
+		return
+	}
+
+	req.respChan <- batches
+}
+
+// pendingBatches groups the published inputs by their sweeping transactions
+// and runs the aggregator over the inputs that are ready to be swept, in the
+// same way the next sweeping attempt would.
+func (s *UtxoSweeper) pendingBatches() (*PendingBatches, error) {
+	nextHeight := s.currentHeight + 1
+
+	// The delivery script is only used to estimate the weight of the
+	// sweeping transaction, so we'll use a P2TR placeholder rather than
+	// deriving a new address if there isn't one yet.
+	pkScript := s.currentOutputScript
+	if pkScript == nil {
+		pkScript = make([]byte, input.P2TRSize)
+		pkScript[0] = txscript.OP_1
+		pkScript[1] = txscript.OP_DATA_32
+	}
+
+	maxFeeRate := s.cfg.MaxFeeRate.FeePerKWeight()
+
+	preview := &PendingBatches{
+		Immature: make(map[wire.OutPoint]int32),
+	}
+
+	ready := make(InputsMap)
+	published := make(map[chainhash.Hash][]*SweeperInput)
+	for op, inp := range s.inputs {
+		switch {
+		case inp.terminated(), inp.state == PendingPublish:
+			continue
+
+		case inp.state == Published:
+			inp.rbf.WhenSome(func(rbf RBFInfo) {
+				published[rbf.Txid] = append(
+					published[rbf.Txid], inp,
+				)
+			})
+
+			continue
+		}
+
+		readyHeight := s.readyHeight(inp)
+		if readyHeight > s.currentHeight {
+			preview.Immature[op] = readyHeight
+			continue
+		}
+
+		ready[op] = inp
+	}
+
+	// The published batches are fee bumped by the publisher in every new
+	// block, starting from their current fee rate.
+	for txid, inputs := range published {
+		batch := &PendingBatch{
+			Txid:                fn.Some(txid),
+			DeadlineHeight:      inputs[0].DeadlineHeight,
+			NextBroadcastHeight: nextHeight,
+		}
+
+		var startingFeeRate chainfee.SatPerKWeight
+		sweepInputs := make([]input.Input, 0, len(inputs))
+		for _, inp := range inputs {
+			batch.Inputs = append(batch.Inputs, inp.OutPoint())
+			batch.Budget += inp.params.Budget
+			if inp.DeadlineHeight < batch.DeadlineHeight {
+				batch.DeadlineHeight = inp.DeadlineHeight
+			}
+
+			inp.rbf.WhenSome(func(rbf RBFInfo) {
+				startingFeeRate = rbf.FeeRate
+			})
+
+			sweepInputs = append(sweepInputs, inp.Input)
+		}
+
+		err := s.addBatchProjection(
+			batch, sweepInputs, pkScript, maxFeeRate,
+			fn.Some(startingFeeRate),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		preview.Batches = append(preview.Batches, batch)
+	}
+
+	// The remaining inputs will be clustered and broadcast in the next
+	// block, or immediately if they are new and forced.
+	for _, set := range s.cfg.Aggregator.ClusterInputs(ready) {
+		batch := &PendingBatch{
+			Budget:              set.Budget(),
+			DeadlineHeight:      set.DeadlineHeight(),
+			NeedWalletInput:     set.NeedWalletInput(),
+			NextBroadcastHeight: nextHeight,
+		}
+		for _, inp := range set.Inputs() {
+			batch.Inputs = append(batch.Inputs, inp.OutPoint())
+		}
+
+		err := s.addBatchProjection(
+			batch, set.Inputs(), pkScript, maxFeeRate,
+			set.StartingFeeRate(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		preview.Batches = append(preview.Batches, batch)
+	}
+
+	// Sort the batches by their deadlines so the most urgent ones come
+	// first.
+	sort.SliceStable(preview.Batches, func(i, j int) bool {
+		return preview.Batches[i].DeadlineHeight <
+			preview.Batches[j].DeadlineHeight
+	})
+
+	return preview, nil
+}
+
+// addBatchProjection estimates the weight of the batch and projects the fee
+// rates the fee bumper will use for it from its next broadcast height on.
+func (s *UtxoSweeper) addBatchProjection(batch *PendingBatch,
+	inputs []input.Input, pkScript []byte,
+	maxFeeRate chainfee.SatPerKWeight,
+	startingFeeRate fn.Option[chainfee.SatPerKWeight]) error {
+
+	weight, err := calcSweepTxWeight(inputs, pkScript)
+	if err != nil {
+		return err
+	}
+	batch.Weight = weight
+
+	req := &BumpRequest{
+		Budget:          batch.Budget,
+		Inputs:          inputs,
+		DeadlineHeight:  batch.DeadlineHeight,
+		DeliveryAddress: pkScript,
+		MaxFeeRate:      maxFeeRate,
+		StartingFeeRate: startingFeeRate,
+	}
+	maxFeeRateAllowed, err := req.MaxFeeRateAllowed()
+	if err != nil {
+		return err
+	}
+
+	batch.FeeRates, err = projectFeeRates(
+		maxFeeRateAllowed, batch.NextBroadcastHeight,
+		batch.DeadlineHeight, s.cfg.FeeEstimator, startingFeeRate,
+	)
+
+	return err
+}
+
+// readyHeight returns the height from which on the input can be swept, which
+// is determined by its absolute and relative timelocks.
+func (s *UtxoSweeper) readyHeight(inp *SweeperInput) int32 {
+	readyHeight := s.currentHeight
+
+	locktime, _ := inp.RequiredLockTime()
+	if int32(locktime) > readyHeight {
+		readyHeight = int32(locktime)
+	}
+
+	// The sweeper publishes the sweeping transaction of a CSV locked input
+	// one block before its expiry, so it can be confirmed at the expiry.
+	csvExpiry := int32(inp.BlocksToMaturity()+inp.HeightHint()) - 1
+	if csvExpiry > readyHeight {
+		readyHeight = csvExpiry
+	}
+
+	return readyHeight
+}
+
+// projectFeeRates returns the fee rates a linear fee function created at the
+// given height uses in each block until it reaches the max fee rate.
+func projectFeeRates(maxFeeRate chainfee.SatPerKWeight, height,
+	deadline int32, estimator chainfee.Estimator,
+	startingFeeRate fn.Option[chainfee.SatPerKWeight]) (
+	[]FeeRateProjection, error) {
+
+	// If the batch already pays the max fee rate, it won't be bumped any
+	// further.
+	if startingFeeRate.UnwrapOr(0) >= maxFeeRate {
+		return []FeeRateProjection{{
+			Height:  height,
+			FeeRate: maxFeeRate,
+		}}, nil
+	}
+
+	confTarget := calcCurrentConfTarget(height, deadline)
+	f, err := NewLinearFeeFunction(
+		maxFeeRate, confTarget, estimator, startingFeeRate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []FeeRateProjection{{
+		Height:  height,
+		FeeRate: f.FeeRate(),
+	}}
+	for f.FeeRate() < maxFeeRate {
+		_, err := f.Increment()
+		if errors.Is(err, ErrMaxPosition) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		height++
+		rates = append(rates, FeeRateProjection{
+			Height:  height,
+			FeeRate: f.FeeRate(),
+		})
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,124 @@
+package sweep
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/fn"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProjectFeeRates checks that the projected fee rates follow the linear
+// fee function until the max fee rate is reached.
+func TestProjectFeeRates(t *testing.T) {
+	t.Parallel()
+
+	const (
+		height     = int32(100)
+		maxFeeRate = chainfee.SatPerKWeight(2000)
+	)
+
+	testCases := []struct {
+		name            string
+		deadline        int32
+		startingFeeRate chainfee.SatPerKWeight
+		expected        []FeeRateProjection
+	}{
+		{
+			name:            "linear increase until deadline",
+			deadline:        height + 3,
+			startingFeeRate: 1000,
+			expected: []FeeRateProjection{
+				{Height: height, FeeRate: 1000},
+				{Height: height + 1, FeeRate: 1500},
+				{Height: height + 2, FeeRate: maxFeeRate},
+			},
+		},
+		{
+			name:            "already at max fee rate",
+			deadline:        height + 10,
+			startingFeeRate: maxFeeRate + 1,
+			expected: []FeeRateProjection{
+				{Height: height, FeeRate: maxFeeRate},
+			},
+		},
+		{
+			name:            "deadline passed",
+			deadline:        height - 1,
+			startingFeeRate: 1000,
+			expected: []FeeRateProjection{
+				{Height: height, FeeRate: maxFeeRate},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rates, err := projectFeeRates(
+				maxFeeRate, height, tc.deadline, nil,
+				fn.Some(tc.startingFeeRate),
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rates)
+		})
+	}
+}
+
+// TestReadyHeight checks that the height from which on an input can be swept
+// takes both its absolute and relative timelocks into account.
+func TestReadyHeight(t *testing.T) {
+	t.Parallel()
+
+	s := New(nil)
+	s.currentHeight = testHeight
+
+	testCases := []struct {
+		name             string
+		locktime         uint32
+		blocksToMaturity uint32
+		heightHint       uint32
+		expected         int32
+	}{
+		{
+			name:       "no timelocks",
+			heightHint: uint32(testHeight - 10),
+			expected:   testHeight,
+		},
+		{
+			name:       "absolute timelock",
+			locktime:   uint32(testHeight + 5),
+			heightHint: uint32(testHeight - 10),
+			expected:   testHeight + 5,
+		},
+		{
+			name:             "relative timelock",
+			blocksToMaturity: 20,
+			heightHint:       uint32(testHeight - 10),
+			expected:         testHeight + 9,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			inp := &input.MockInput{}
+			defer inp.AssertExpectations(t)
+
+			inp.On("RequiredLockTime").Return(tc.locktime, true)
+			inp.On("BlocksToMaturity").Return(tc.blocksToMaturity)
+			inp.On("HeightHint").Return(tc.heightHint)
+
+			require.Equal(t, tc.expected, s.readyHeight(
+				&SweeperInput{Input: inp},
+			))
+		})
+	}
+}
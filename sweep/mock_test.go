@@ -62,6 +62,22 @@ func (s *MockSweeperStore) DeleteTx(txid chainhash.Hash) error {
 	return args.Error(0)
 }
 
+// ExcludeInput persists that the given input has been permanently removed
+// from the sweeper.
+func (s *MockSweeperStore) ExcludeInput(op wire.OutPoint) error {
+	args := s.Called(op)
+
+	return args.Error(0)
+}
+
+// IsExcludedInput returns true if the given input has been permanently
+// removed from the sweeper.
+func (s *MockSweeperStore) IsExcludedInput(op wire.OutPoint) (bool, error) {
+	args := s.Called(op)
+
+	return args.Bool(0), args.Error(1)
+}
+
 // Compile-time constraint to ensure MockSweeperStore implements SweeperStore.
 var _ SweeperStore = (*MockSweeperStore)(nil)
 
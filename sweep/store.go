@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
@@ -19,6 +20,13 @@ var (
 	// maps: txHash -> TxRecord
 	txHashesBucketKey = []byte("sweeper-tx-hashes")
 
+	// excludedInputsBucketKey is the key that points to a bucket containing
+	// the outpoints of all inputs that were permanently removed from the
+	// sweeper.
+	//
+	// maps: outpoint -> unix timestamp of the removal
+	excludedInputsBucketKey = []byte("sweeper-excluded-inputs")
+
 	// utxnChainPrefix is the bucket prefix for nursery buckets.
 	utxnChainPrefix = []byte("utxn")
 
@@ -34,6 +42,9 @@ var (
 
 	errNoTxHashesBucket = errors.New("tx hashes bucket does not exist")
 
+	errNoExcludedInputsBucket = errors.New("excluded inputs bucket does " +
+		"not exist")
+
 	// ErrTxNotFound is returned when querying using a txid that's not
 	// found in our db.
 	ErrTxNotFound = errors.New("tx not found")
@@ -135,6 +146,14 @@ type SweeperStore interface {
 
 	// DeleteTx removes a tx specified by the hash from the store.
 	DeleteTx(hash chainhash.Hash) error
+
+	// ExcludeInput persists that the given input has been permanently
+	// removed from the sweeper and must never be swept again.
+	ExcludeInput(op wire.OutPoint) error
+
+	// IsExcludedInput returns true if the given input has been permanently
+	// removed from the sweeper.
+	IsExcludedInput(op wire.OutPoint) (bool, error)
 }
 
 type sweeperStore struct {
@@ -146,6 +165,11 @@ func NewSweeperStore(db kvdb.Backend, chainHash *chainhash.Hash) (
 	SweeperStore, error) {
 
 	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(excludedInputsBucketKey)
+		if err != nil {
+			return err
+		}
+
 		if tx.ReadWriteBucket(txHashesBucketKey) != nil {
 			return nil
 		}
@@ -390,5 +414,64 @@ func (s *sweeperStore) DeleteTx(txid chainhash.Hash) error {
 	}, func() {})
 }
 
+// ExcludeInput persists that the given input has been permanently removed
+// from the sweeper.
+func (s *sweeperStore) ExcludeInput(op wire.OutPoint) error {
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &op); err != nil {
+		return err
+	}
+
+	var removedAt [8]byte
+	byteOrder.PutUint64(removedAt[:], uint64(time.Now().Unix()))
+
+	return kvdb.Update(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(excludedInputsBucketKey)
+		if bucket == nil {
+			return errNoExcludedInputsBucket
+		}
+
+		return bucket.Put(key.Bytes(), removedAt[:])
+	}, func() {})
+}
+
+// IsExcludedInput returns true if the given input has been permanently
+// removed from the sweeper.
+func (s *sweeperStore) IsExcludedInput(op wire.OutPoint) (bool, error) {
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &op); err != nil {
+		return false, err
+	}
+
+	var excluded bool
+	err := kvdb.View(s.db, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(excludedInputsBucketKey)
+		if bucket == nil {
+			return errNoExcludedInputsBucket
+		}
+
+		excluded = bucket.Get(key.Bytes()) != nil
+
+		return nil
+	}, func() {
+		excluded = false
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return excluded, nil
+}
+
+// writeOutpoint serializes an outpoint as the key of the excluded inputs
+// bucket.
+func writeOutpoint(w io.Writer, op *wire.OutPoint) error {
+	if _, err := w.Write(op.Hash[:]); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, op.Index)
+}
+
 // Compile-time constraint to ensure sweeperStore implements SweeperStore.
 var _ SweeperStore = (*sweeperStore)(nil)
@@ -220,3 +220,43 @@ func TestDeleteTx(t *testing.T) {
 	err = store.DeleteTx(chainhash.Hash{4, 5, 6})
 	require.NoError(t, err)
 }
+
+// TestExcludedInputs asserts that the removal of inputs is persisted.
+func TestExcludedInputs(t *testing.T) {
+	t.Parallel()
+
+	cdb, err := channeldb.MakeTestDB(t)
+	require.NoError(t, err)
+
+	// Create a testing store.
+	chain := chainhash.Hash{}
+	store, err := NewSweeperStore(cdb, &chain)
+	require.NoError(t, err)
+
+	op1 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	op2 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 2}
+
+	// No input should be excluded initially.
+	excluded, err := store.IsExcludedInput(op1)
+	require.NoError(t, err)
+	require.False(t, excluded)
+
+	// Exclude the first input, which should leave the second one alone.
+	require.NoError(t, store.ExcludeInput(op1))
+
+	excluded, err = store.IsExcludedInput(op1)
+	require.NoError(t, err)
+	require.True(t, excluded)
+
+	excluded, err = store.IsExcludedInput(op2)
+	require.NoError(t, err)
+	require.False(t, excluded)
+
+	// The exclusion should survive re-opening the store.
+	store, err = NewSweeperStore(cdb, &chain)
+	require.NoError(t, err)
+
+	excluded, err = store.IsExcludedInput(op1)
+	require.NoError(t, err)
+	require.True(t, excluded)
+}
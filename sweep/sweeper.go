@@ -36,6 +36,16 @@ var (
 	// it is/has already been stopped.
 	ErrSweeperShuttingDown = errors.New("utxo sweeper shutting down")
 
+	// ErrInputRemoved is returned in case an input was permanently removed
+	// from the sweeper by the user.
+	ErrInputRemoved = errors.New("input was removed from the sweeper")
+
+	// ErrInputPublishing is returned when attempting to remove an input
+	// that is part of a sweeping transaction that is being published or has
+	// been published.
+	ErrInputPublishing = errors.New("input is part of a published sweep " +
+		"tx")
+
 	// DefaultDeadlineDelta defines a default deadline delta (1 week) to be
 	// used when sweeping inputs with no deadline pressure.
 	DefaultDeadlineDelta = int32(1008)
@@ -274,6 +284,13 @@ type updateResp struct {
 	err        error
 }
 
+// removeReq is an internal message we'll use to represent an external caller's
+// intent to permanently remove an input from the sweeper.
+type removeReq struct {
+	input   wire.OutPoint
+	errChan chan error
+}
+
 // UtxoSweeper is responsible for sweeping outputs back into the wallet
 type UtxoSweeper struct {
 	started uint32 // To be used atomically.
@@ -289,10 +306,19 @@ type UtxoSweeper struct {
 	// UtxoSweeper is attempting to sweep.
 	pendingSweepsReqs chan *pendingSweepsReq
 
+	// pendingBatchesReqs is a channel that will be sent requests by
+	// external callers in order to retrieve a preview of the batches the
+	// UtxoSweeper intends to sweep its pending inputs in.
+	pendingBatchesReqs chan *pendingBatchesReq
+
 	// updateReqs is a channel that will be sent requests by external
 	// callers who wish to bump the fee rate of a given input.
 	updateReqs chan *updateReq
 
+	// removeReqs is a channel that will be sent requests by external
+	// callers who wish to permanently remove an input from the sweeper.
+	removeReqs chan *removeReq
+
 	// inputs is the total set of inputs the UtxoSweeper has been requested
 	// to sweep.
 	inputs InputsMap
@@ -361,6 +387,12 @@ type UtxoSweeperConfig struct {
 	// NoDeadlineConfTarget is the conf target to use when sweeping
 	// non-time-sensitive outputs.
 	NoDeadlineConfTarget uint32
+
+	// NotifySweepPublished is an optional function that is called with
+	// every sweep tx that was published, including the txns that replace
+	// an earlier sweep tx.
+	NotifySweepPublished func(tx *wire.MsgTx, fee btcutil.Amount,
+		feeRate chainfee.SatPerKWeight)
 }
 
 // Result is the struct that is pushed through the result channel. Callers can
@@ -395,6 +427,9 @@ func New(cfg *UtxoSweeperConfig) *UtxoSweeper {
 		quit:              make(chan struct{}),
 		inputs:            make(InputsMap),
 		bumpResultChan:    make(chan *BumpResult, 100),
+
+		pendingBatchesReqs: make(chan *pendingBatchesReq),
+		removeReqs:         make(chan *removeReq),
 	}
 }
 
@@ -659,6 +694,11 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 		case req := <-s.pendingSweepsReqs:
 			s.handlePendingSweepsReq(req)
 
+		// A new external request has been received to preview the
+		// batches of the inputs we're currently attempting to sweep.
+		case req := <-s.pendingBatchesReqs:
+			s.handlePendingBatchesReq(req)
+
 		// A new external request has been received to bump the fee rate
 		// of a given input.
 		case req := <-s.updateReqs:
@@ -674,6 +714,11 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 				s.sweepPendingInputs(inputs)
 			}
 
+		// A new external request has been received to permanently
+		// remove an input from the sweeper.
+		case req := <-s.removeReqs:
+			req.errChan <- s.handleRemoveReq(req)
+
 		case result := <-s.bumpResultChan:
 			// Handle the bump event.
 			err := s.handleBumpEvent(result)
@@ -1153,6 +1198,62 @@ func (s *UtxoSweeper) handleUpdateReq(req *updateReq) (
 	return resultChan, nil
 }
 
+// RemoveInput permanently removes a pending input from the UtxoSweeper. The
+// input won't be batched or broadcast again, and its removal is persisted so
+// it's also ignored when it's offered again after a restart. This can be used
+// to abandon sweeping inputs that are uneconomical to sweep, such as dust
+// outputs of a force close. Inputs that are part of a sweeping transaction
+// that is being published or has been published can't be removed.
+func (s *UtxoSweeper) RemoveInput(input wire.OutPoint) error {
+	errChan := make(chan error, 1)
+	select {
+	case s.removeReqs <- &removeReq{
+		input:   input,
+		errChan: errChan,
+	}:
+	case <-s.quit:
+		return ErrSweeperShuttingDown
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-s.quit:
+		return ErrSweeperShuttingDown
+	}
+}
+
+// handleRemoveReq handles a request to permanently remove a pending input. The
+// removal is persisted before the listeners of the input are notified with
+// ErrInputRemoved.
+func (s *UtxoSweeper) handleRemoveReq(req *removeReq) error {
+	sweeperInput, ok := s.inputs[req.input]
+	if !ok || sweeperInput.terminated() {
+		return lnwallet.ErrNotMine
+	}
+
+	switch sweeperInput.state {
+	case PendingPublish, Published:
+		return fmt.Errorf("%w: state=%v", ErrInputPublishing,
+			sweeperInput.state)
+	}
+
+	if err := s.cfg.Store.ExcludeInput(req.input); err != nil {
+		return fmt.Errorf("persist removal of input %v: %w", req.input,
+			err)
+	}
+
+	log.Infof("Removed input %v from sweeper", req.input)
+
+	s.signalResult(sweeperInput, Result{Err: ErrInputRemoved})
+
+	// Mark the input as excluded so it's removed from the sweeper's
+	// inputs in the next iteration.
+	sweeperInput.state = Excluded
+
+	return nil
+}
+
 // ListSweeps returns a list of the sweeps recorded by the sweep store.
 func (s *UtxoSweeper) ListSweeps() ([]chainhash.Hash, error) {
 	return s.cfg.Store.ListSweeps()
@@ -1192,6 +1293,22 @@ func (s *UtxoSweeper) handleNewInput(input *sweepInputMessage) error {
 		return nil
 	}
 
+	// If the input was removed from the sweeper by the user before, we
+	// won't ever attempt to sweep it again.
+	excluded, err := s.cfg.Store.IsExcludedInput(outpoint)
+	if err != nil {
+		log.Errorf("Unable to check whether input %v was removed: %v",
+			outpoint, err)
+	}
+	if excluded {
+		log.Infof("Ignoring input %v as it was removed from the "+
+			"sweeper", outpoint)
+
+		input.resultChan <- Result{Err: ErrInputRemoved}
+
+		return nil
+	}
+
 	// This is a new input, and we want to query the mempool to see if this
 	// input has already been spent. If so, we'll start the input with
 	// state Published and attach the RBFInfo.
@@ -1681,7 +1798,13 @@ func (s *UtxoSweeper) handleBumpEventTxReplaced(r *BumpResult) error {
 	}
 
 	// Mark the inputs as published using the replacing tx.
-	return s.markInputsPublished(tr, r.Tx.TxIn)
+	if err := s.markInputsPublished(tr, r.Tx.TxIn); err != nil {
+		return err
+	}
+
+	s.notifySweepPublished(r)
+
+	return nil
 }
 
 // handleBumpEventTxPublished handles the case where the sweeping tx has been
@@ -1704,6 +1827,8 @@ func (s *UtxoSweeper) handleBumpEventTxPublished(r *BumpResult) error {
 	log.Debugf("Published sweep tx %v, num_inputs=%v, height=%v",
 		tx.TxHash(), len(tx.TxIn), s.currentHeight)
 
+	s.notifySweepPublished(r)
+
 	// If there's no error, remove the output script. Otherwise
 	// keep it so that it can be reused for the next transaction
 	// and causes no address inflation.
@@ -1712,6 +1837,16 @@ func (s *UtxoSweeper) handleBumpEventTxPublished(r *BumpResult) error {
 	return nil
 }
 
+// notifySweepPublished notifies the subscriber of the config, if any, about
+// the sweep tx that was published by the given result.
+func (s *UtxoSweeper) notifySweepPublished(r *BumpResult) {
+	if s.cfg.NotifySweepPublished == nil {
+		return
+	}
+
+	s.cfg.NotifySweepPublished(r.Tx, r.Fee, r.FeeRate)
+}
+
 // handleBumpEvent handles the result sent from the bumper based on its event
 // type.
 //
@@ -12,6 +12,7 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/fn"
 	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -901,9 +902,17 @@ func TestHandleBumpEventTxPublished(t *testing.T) {
 	store := &MockSweeperStore{}
 	defer store.AssertExpectations(t)
 
-	// Create a test sweeper.
+	// Create a test sweeper that records the published sweep txns.
+	var published []*wire.MsgTx
 	s := New(&UtxoSweeperConfig{
 		Store: store,
+		NotifySweepPublished: func(tx *wire.MsgTx, fee btcutil.Amount,
+			feeRate chainfee.SatPerKWeight) {
+
+			require.EqualValues(t, 1000, fee)
+			require.EqualValues(t, 253, feeRate)
+			published = append(published, tx)
+		},
 	})
 
 	// Create a testing outpoint.
@@ -928,13 +937,17 @@ func TestHandleBumpEventTxPublished(t *testing.T) {
 
 	// Create a testing bump result.
 	br := &BumpResult{
-		Tx:    tx,
-		Event: TxPublished,
+		Tx:      tx,
+		Event:   TxPublished,
+		Fee:     1000,
+		FeeRate: 253,
 	}
 
 	// Mock the store to save the new tx record.
 	store.On("StoreTx", &TxRecord{
 		Txid:      tx.TxHash(),
+		FeeRate:   253,
+		Fee:       1000,
 		Published: true,
 	}).Return(nil).Once()
 
@@ -944,6 +957,9 @@ func TestHandleBumpEventTxPublished(t *testing.T) {
 
 	// Assert the state of the input is updated.
 	require.Equal(t, Published, s.inputs[op].state)
+
+	// Assert the published tx was notified.
+	require.Equal(t, []*wire.MsgTx{tx}, published)
 }
 
 // TestMonitorFeeBumpResult checks that the fee bump monitor loop correctly
@@ -1107,3 +1123,56 @@ func TestMonitorFeeBumpResult(t *testing.T) {
 		})
 	}
 }
+
+// TestHandleRemoveReq checks that removing an input persists the removal,
+// notifies its listeners and marks it as excluded, and that inputs which are
+// being published can't be removed.
+func TestHandleRemoveReq(t *testing.T) {
+	t.Parallel()
+
+	store := &MockSweeperStore{}
+	defer store.AssertExpectations(t)
+
+	s := New(&UtxoSweeperConfig{
+		Store: store,
+	})
+
+	op := wire.OutPoint{Index: 1}
+	inp := &input.MockInput{}
+	defer inp.AssertExpectations(t)
+	inp.On("OutPoint").Return(op)
+
+	resultChan := make(chan Result, 1)
+	s.inputs[op] = &SweeperInput{
+		Input:     inp,
+		state:     PublishFailed,
+		listeners: []chan Result{resultChan},
+	}
+
+	published := wire.OutPoint{Index: 2}
+	s.inputs[published] = &SweeperInput{state: Published}
+
+	// Removing an unknown input should fail.
+	err := s.handleRemoveReq(&removeReq{input: wire.OutPoint{Index: 3}})
+	require.ErrorIs(t, err, lnwallet.ErrNotMine)
+
+	// Removing a published input should fail.
+	err = s.handleRemoveReq(&removeReq{input: published})
+	require.ErrorIs(t, err, ErrInputPublishing)
+	require.Equal(t, Published, s.inputs[published].state)
+
+	// A failure to persist the removal should leave the input untouched.
+	store.On("ExcludeInput", op).Return(errDummy).Once()
+	err = s.handleRemoveReq(&removeReq{input: op})
+	require.ErrorIs(t, err, errDummy)
+	require.Equal(t, PublishFailed, s.inputs[op].state)
+	require.Empty(t, resultChan)
+
+	// Finally, a successful removal should notify the listener.
+	store.On("ExcludeInput", op).Return(nil).Once()
+	require.NoError(t, s.handleRemoveReq(&removeReq{input: op}))
+	require.Equal(t, Excluded, s.inputs[op].state)
+
+	result := <-resultChan
+	require.ErrorIs(t, result.Err, ErrInputRemoved)
+}
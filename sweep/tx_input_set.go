@@ -301,6 +301,16 @@ func (b *BudgetInputSet) AddWalletInputs(wallet Wallet) error {
 
 	// Add wallet inputs to the set until the specified budget is covered.
 	for _, utxo := range utxos {
+		// Outputs of an external signer, such as a hardware wallet,
+		// can't be signed without the user approving them on the
+		// device, so we never use them to fund a sweep.
+		if utxo.ExternallySigned {
+			log.Debugf("Skipped externally signed wallet input: "+
+				"op=%v, amt=%v", utxo.OutPoint, utxo.Value)
+
+			continue
+		}
+
 		input, err := createWalletTxInput(utxo)
 		if err != nil {
 			return err
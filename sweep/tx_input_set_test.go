@@ -386,6 +386,57 @@ func TestAddWalletInputNotEnoughInputs(t *testing.T) {
 	require.Equal(t, pi, set.inputs[0])
 }
 
+// TestAddWalletInputSkipsExternallySigned checks that wallet utxos of an
+// external signer are never used to cover the budget of a sweep.
+func TestAddWalletInputSkipsExternallySigned(t *testing.T) {
+	t.Parallel()
+
+	wallet := &MockWallet{}
+	defer wallet.AssertExpectations(t)
+
+	// Specify the min and max confs used in
+	// ListUnspentWitnessFromDefaultAccount.
+	min, max := int32(1), int32(math.MaxInt32)
+
+	// Assume the desired budget is 10k satoshis.
+	const budget = 10_000
+
+	// Create a mock input. As no wallet input is added, it is never
+	// asked for its required outputs.
+	mockInput := &input.MockInput{}
+	defer mockInput.AssertExpectations(t)
+
+	// Create a pending input that requires 10k satoshis.
+	pi := &SweeperInput{
+		Input:  mockInput,
+		params: Params{Budget: budget},
+	}
+
+	// Create a wallet utxo of an external signer that could cover the
+	// budget on its own.
+	utxo := &lnwallet.Utxo{
+		AddressType:      lnwallet.WitnessPubKey,
+		Value:            budget * 2,
+		ExternallySigned: true,
+	}
+
+	// Mock the wallet to return the above utxo.
+	wallet.On("ListUnspentWitnessFromDefaultAccount",
+		min, max).Return([]*lnwallet.Utxo{utxo}, nil).Once()
+
+	// Initialize an input set with the pending input.
+	set := BudgetInputSet{inputs: []*SweeperInput{pi}}
+
+	// Add wallet inputs to the input set, which should give us an error as
+	// the only wallet utxo is skipped.
+	err := set.AddWalletInputs(wallet)
+	require.ErrorIs(t, err, ErrNotEnoughInputs)
+
+	// Check that the budget set is still in its initial state.
+	require.Len(t, set.inputs, 1)
+	require.Equal(t, pi, set.inputs[0])
+}
+
 // TestAddWalletInputSuccess checks that when there are enough wallet utxos,
 // they are added to the input set.
 func TestAddWalletInputSuccess(t *testing.T) {
@@ -294,8 +294,61 @@ func CraftSweepAllTx(feeRate, maxFeeRate chainfee.SatPerKWeight,
 	// Now that we've locked all the potential outputs to sweep, we'll
 	// assemble an input for each of them, so we can hand it off to the
 	// sweeper to generate and sign a transaction for us.
+	inputsToSweep, err := walletUtxoInputs(allOutputs)
+	if err != nil {
+		unlockOutputs()
+
+		return nil, err
+	}
+
+	// Create a list of TxOuts from the given delivery addresses.
+	var txOuts []*wire.TxOut
+	for _, d := range deliveryAddrs {
+		pkScript, err := txscript.PayToAddrScript(d.Addr)
+		if err != nil {
+			unlockOutputs()
+
+			return nil, err
+		}
+
+		txOuts = append(txOuts, &wire.TxOut{
+			PkScript: pkScript,
+			Value:    int64(d.Amt),
+		})
+	}
+
+	// Next, we'll convert the change addr to a pkScript that we can use
+	// to create the sweep transaction.
+	changePkScript, err := txscript.PayToAddrScript(changeAddr)
+	if err != nil {
+		unlockOutputs()
+
+		return nil, err
+	}
+
+	// Finally, we'll ask the sweeper to craft a sweep transaction which
+	// respects our fee preference and targets all the UTXOs of the wallet.
+	sweepTx, _, err := createSweepTx(
+		inputsToSweep, txOuts, changePkScript, blockHeight,
+		feeRate, maxFeeRate, signer,
+	)
+	if err != nil {
+		unlockOutputs()
+
+		return nil, err
+	}
+
+	return &WalletSweepPackage{
+		SweepTx:            sweepTx,
+		CancelSweepAttempt: unlockOutputs,
+	}, nil
+}
+
+// walletUtxoInputs maps the passed wallet UTXOs to inputs that can be swept
+// using the wallet's signer.
+func walletUtxoInputs(utxos []*lnwallet.Utxo) ([]input.Input, error) {
 	var inputsToSweep []input.Input
-	for _, output := range allOutputs {
+	for _, output := range utxos {
 		// As we'll be signing for outputs under control of the wallet,
 		// we only need to populate the output value and output script.
 		// The rest of the items will be populated internally within
@@ -333,8 +386,6 @@ func CraftSweepAllTx(feeRate, maxFeeRate chainfee.SatPerKWeight,
 		// All other output types we count as unknown and will fail to
 		// sweep.
 		default:
-			unlockOutputs()
-
 			return nil, fmt.Errorf("unable to sweep coins, "+
 				"unknown script: %x", pkScript[:])
 		}
@@ -348,45 +399,30 @@ func CraftSweepAllTx(feeRate, maxFeeRate chainfee.SatPerKWeight,
 		inputsToSweep = append(inputsToSweep, &input)
 	}
 
-	// Create a list of TxOuts from the given delivery addresses.
-	var txOuts []*wire.TxOut
-	for _, d := range deliveryAddrs {
-		pkScript, err := txscript.PayToAddrScript(d.Addr)
-		if err != nil {
-			unlockOutputs()
+	return inputsToSweep, nil
+}
 
-			return nil, err
-		}
+// CraftWalletUtxoSweepTx crafts a transaction that sweeps the passed wallet
+// UTXOs to the change address at the target fee rate. The caller is
+// responsible for making sure that the UTXOs aren't spent concurrently. The
+// signed transaction and its fee are returned.
+func CraftWalletUtxoSweepTx(utxos []*lnwallet.Utxo,
+	feeRate, maxFeeRate chainfee.SatPerKWeight, blockHeight uint32,
+	changeAddr btcutil.Address, signer input.Signer) (*wire.MsgTx,
+	btcutil.Amount, error) {
 
-		txOuts = append(txOuts, &wire.TxOut{
-			PkScript: pkScript,
-			Value:    int64(d.Amt),
-		})
+	inputsToSweep, err := walletUtxoInputs(utxos)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	// Next, we'll convert the change addr to a pkScript that we can use
-	// to create the sweep transaction.
 	changePkScript, err := txscript.PayToAddrScript(changeAddr)
 	if err != nil {
-		unlockOutputs()
-
-		return nil, err
+		return nil, 0, err
 	}
 
-	// Finally, we'll ask the sweeper to craft a sweep transaction which
-	// respects our fee preference and targets all the UTXOs of the wallet.
-	sweepTx, _, err := createSweepTx(
-		inputsToSweep, txOuts, changePkScript, blockHeight,
-		feeRate, maxFeeRate, signer,
+	return createSweepTx(
+		inputsToSweep, nil, changePkScript, blockHeight, feeRate,
+		maxFeeRate, signer,
 	)
-	if err != nil {
-		unlockOutputs()
-
-		return nil, err
-	}
-
-	return &WalletSweepPackage{
-		SweepTx:            sweepTx,
-		CancelSweepAttempt: unlockOutputs,
-	}, nil
 }
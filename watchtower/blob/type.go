@@ -133,6 +133,21 @@ func (t Type) CommitmentType(chanType *channeldb.ChannelType) (CommitmentType,
 	}
 }
 
+// BlobSize returns the size in bytes of the encrypted blobs of the blob Type.
+func (t Type) BlobSize() (int, error) {
+	commitType, err := t.CommitmentType(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	kit, err := commitType.EmptyJusticeKit()
+	if err != nil {
+		return 0, err
+	}
+
+	return Size(kit), nil
+}
+
 // Has returns true if the Type has the passed flag enabled.
 func (t Type) Has(flag Flag) bool {
 	return Flag(t)&flag == flag
@@ -21,6 +21,14 @@ type Conf struct {
 	// WriteTimeout specifies the duration the tower will wait when trying
 	// to write a message from a client before hanging up.
 	WriteTimeout time.Duration `long:"writetimeout" description:"Duration the watchtower server will wait for messages to be written before hanging up on client connections"`
+
+	// ClientStorageQuota is the maximum number of bytes of encrypted
+	// justice data the tower stores for a single client.
+	ClientStorageQuota uint64 `long:"clientstoragequota" description:"The maximum number of bytes of encrypted justice data the watchtower stores for a single client. Sessions that could exceed the quota with their maximum number of updates are rejected. Set to 0 to not limit the storage of clients"`
+
+	// InactiveSessionExpiry is the duration after which sessions without
+	// any state update are deleted.
+	InactiveSessionExpiry time.Duration `long:"inactivesessionexpiry" description:"Delete sessions that didn't receive a state update for this long, for example because their client never deleted them after its channels were closed. Requires db.use-native-sql. Set to 0 to keep sessions until their client deletes them"`
 }
 
 // DefaultConf returns a Conf with some default values filled in.
@@ -94,5 +102,17 @@ func (c *Conf) Apply(cfg *Config,
 		cfg.WriteTimeout = c.WriteTimeout
 	}
 
+	// If the Config has no client storage quota, we will use the parsed
+	// Conf value.
+	if cfg.ClientStorageQuota == 0 {
+		cfg.ClientStorageQuota = c.ClientStorageQuota
+	}
+
+	// If the Config has no inactive session expiry, we will use the
+	// parsed Conf value.
+	if cfg.InactiveSessionExpiry == 0 {
+		cfg.InactiveSessionExpiry = c.InactiveSessionExpiry
+	}
+
 	return cfg, nil
 }
@@ -90,6 +90,17 @@ type Config struct {
 	// the server's replies.
 	WriteTimeout time.Duration
 
+	// ClientStorageQuota is the maximum number of bytes of encrypted
+	// justice data the tower stores for a single client. If zero, the
+	// storage of clients isn't limited.
+	ClientStorageQuota uint64
+
+	// InactiveSessionExpiry is the duration after which sessions without
+	// any state update are deleted. It requires a DB that implements the
+	// wtserver.SessionCleaner interface. If zero, sessions are only
+	// deleted by their clients.
+	InactiveSessionExpiry time.Duration
+
 	// TorController allows the watchtower to optionally setup an onion hidden
 	// service.
 	TorController *tor.Controller
@@ -10,4 +10,10 @@ var (
 	// ErrNoNetwork signals that no tor.Net is provided in the Config, which
 	// prevents resolution of listening addresses.
 	ErrNoNetwork = errors.New("no network specified, must be tor or clearnet")
+
+	// ErrSessionCleanupUnsupported signals that an inactive session expiry
+	// was configured, but the database doesn't track the activity of
+	// sessions.
+	ErrSessionCleanupUnsupported = errors.New("inactive session cleanup " +
+		"is not supported by the tower database")
 )
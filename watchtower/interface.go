@@ -4,6 +4,7 @@ import (
 	"net"
 
 	"github.com/lightningnetwork/lnd/watchtower/lookout"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
 	"github.com/lightningnetwork/lnd/watchtower/wtserver"
 )
 
@@ -13,6 +14,10 @@ import (
 type DB interface {
 	lookout.DB
 	wtserver.DB
+
+	// ListClients returns a summary of the data that is stored for each
+	// client of the tower.
+	ListClients() ([]wtdb.TowerClient, error)
 }
 
 // AddressNormalizer is a function signature that allows the tower to resolve
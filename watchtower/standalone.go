@@ -9,6 +9,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnencrypt"
 	"github.com/lightningnetwork/lnd/tor"
 	"github.com/lightningnetwork/lnd/watchtower/lookout"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
 	"github.com/lightningnetwork/lnd/watchtower/wtserver"
 )
 
@@ -82,16 +83,31 @@ func New(cfg *Config) (*Standalone, error) {
 		listeners = append(listeners, listener)
 	}
 
+	// Inactive sessions can only be cleaned up if the database tracks the
+	// time of their last update.
+	var sessionCleaner wtserver.SessionCleaner
+	if cfg.InactiveSessionExpiry > 0 {
+		cleaner, ok := cfg.DB.(wtserver.SessionCleaner)
+		if !ok {
+			return nil, ErrSessionCleanupUnsupported
+		}
+
+		sessionCleaner = cleaner
+	}
+
 	// Initialize the server with its required resources.
 	server, err := wtserver.New(&wtserver.Config{
-		ChainHash:     cfg.ChainHash,
-		DB:            cfg.DB,
-		NodeKeyECDH:   cfg.NodeKeyECDH,
-		Listeners:     listeners,
-		ReadTimeout:   cfg.ReadTimeout,
-		WriteTimeout:  cfg.WriteTimeout,
-		NewAddress:    cfg.NewAddress,
-		DisableReward: true,
+		ChainHash:             cfg.ChainHash,
+		DB:                    cfg.DB,
+		NodeKeyECDH:           cfg.NodeKeyECDH,
+		Listeners:             listeners,
+		ReadTimeout:           cfg.ReadTimeout,
+		WriteTimeout:          cfg.WriteTimeout,
+		NewAddress:            cfg.NewAddress,
+		DisableReward:         true,
+		MaxClientStorage:      cfg.ClientStorageQuota,
+		SessionCleaner:        sessionCleaner,
+		InactiveSessionExpiry: cfg.InactiveSessionExpiry,
 	})
 	if err != nil {
 		return nil, err
@@ -225,3 +241,11 @@ func (w *Standalone) ExternalIPs() []net.Addr {
 
 	return addrs
 }
+
+// ListClients returns a summary of the data that is stored for each client of
+// the watchtower.
+//
+// NOTE: Part of the watchtowerrpc.WatchtowerBackend interface.
+func (w *Standalone) ListClients() ([]wtdb.TowerClient, error) {
+	return w.cfg.DB.ListClients()
+}
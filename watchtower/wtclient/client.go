@@ -172,6 +172,10 @@ type clientCfg struct {
 	Policy wtpolicy.Policy
 
 	getSweepScript func(lnwire.ChannelID) ([]byte, bool)
+
+	// recordBackup records the outcome of sending a backup to a tower.
+	recordBackup func(wtdb.TowerID, *btcec.PublicKey, time.Duration,
+		error)
 }
 
 // client manages backing up revoked states for all states that fall under a
@@ -1061,6 +1065,7 @@ func (c *client) newSessionQueue(s *ClientSession,
 		Log:                    c.log,
 		BuildBreachRetribution: c.cfg.BuildBreachRetribution,
 		TaskPipeline:           c.pipeline,
+		RecordBackup:           c.cfg.recordBackup,
 	}, updates)
 }
 
@@ -463,6 +463,7 @@ type harnessCfg struct {
 	noRegisterChan0    bool
 	noAckCreateSession bool
 	noServerStart      bool
+	healthInterval     time.Duration
 }
 
 func newClientDB(t *testing.T) *wtdb.ClientDB {
@@ -546,6 +547,7 @@ func newHarness(t *testing.T, cfg harnessCfg) *testHarness {
 		SessionCloseRange:  1,
 		MaxTasksInMemQueue: 2,
 	}
+	h.clientCfg.HealthCheckInterval = cfg.healthInterval
 
 	h.clientCfg.BuildBreachRetribution = func(id lnwire.ChannelID,
 		commitHeight uint64) (*lnwallet.BreachRetribution,
@@ -2891,6 +2893,191 @@ var clientTests = []clientTest{
 			require.EqualValues(h.t, 2, totalUpdates)
 		},
 	},
+	{
+		// Show that migrating sessions to a new tower re-backs up the
+		// latest state of a channel to the new tower, and retires the
+		// sessions with the old tower.
+		name: "migrate sessions to a new tower",
+		cfg: harnessCfg{
+			localBalance:  localBalance,
+			remoteBalance: remoteBalance,
+			policy: wtpolicy.Policy{
+				TxPolicy:   defaultTxPolicy,
+				MaxUpdates: 5,
+			},
+		},
+		fn: func(h *testHarness) {
+			const (
+				numUpdates = 5
+				chanIDInt  = 0
+			)
+
+			// Back up a few states to the first tower.
+			hints := h.advanceChannelN(chanIDInt, numUpdates)
+			h.backupStates(chanIDInt, 0, numUpdates-2, nil)
+			h.server.waitForUpdates(
+				hints[:numUpdates-2], waitTime,
+			)
+
+			// Migrating to a tower that wasn't added fails.
+			server2 := newServerHarness(
+				h.t, h.net, towerAddr2Str, nil,
+			)
+			_, err := h.clientMgr.MigrateSessions(
+				server2.addr.IdentityKey,
+				h.server.addr.IdentityKey,
+			)
+			require.ErrorIs(h.t, err, wtdb.ErrTowerNotFound)
+
+			// So is migrating from a tower to itself.
+			_, err = h.clientMgr.MigrateSessions(
+				h.server.addr.IdentityKey,
+				h.server.addr.IdentityKey,
+			)
+			require.ErrorIs(h.t, err, wtclient.ErrMigrateToSameTower)
+
+			// Add the new tower and migrate the sessions of the
+			// first tower to it.
+			server2.start()
+			h.addTower(server2.addr)
+
+			migration, err := h.clientMgr.MigrateSessions(
+				server2.addr.IdentityKey,
+				h.server.addr.IdentityKey,
+			)
+			require.NoError(h.t, err)
+			require.Equal(h.t, 1, migration.NumSessionsRetired)
+			require.Equal(h.t, 1, migration.NumBackupsQueued)
+
+			// The latest state that was backed up to the first
+			// tower should now also be backed up to the new tower.
+			server2.waitForUpdates(
+				hints[numUpdates-3:numUpdates-2], waitTime,
+			)
+
+			// The sessions with the first tower are terminated and
+			// the tower isn't used anymore.
+			dbTower, err := h.clientDB.LoadTower(
+				h.server.addr.IdentityKey,
+			)
+			require.NoError(h.t, err)
+			sessions, err := h.clientDB.ListClientSessions(
+				&dbTower.ID,
+			)
+			require.NoError(h.t, err)
+			require.Len(h.t, sessions, 1)
+			for _, session := range sessions {
+				require.Equal(
+					h.t, wtdb.CSessionTerminal,
+					session.Status,
+				)
+			}
+
+			resp, err := h.clientMgr.LookupTower(
+				h.server.addr.IdentityKey,
+			)
+			require.NoError(h.t, err)
+			tower, ok := resp[blob.TypeAltruistTaprootCommit]
+			require.True(h.t, ok)
+			require.False(h.t, tower.ActiveSessionCandidate)
+
+			// Migrating back to the deactivated tower fails.
+			_, err = h.clientMgr.MigrateSessions(
+				h.server.addr.IdentityKey, nil,
+			)
+			require.ErrorIs(
+				h.t, err, wtclient.ErrMigrateToInactiveTower,
+			)
+
+			// New states are backed up to the new tower.
+			h.backupStates(chanIDInt, numUpdates-2, numUpdates, nil)
+			server2.waitForUpdates(
+				hints[numUpdates-3:], waitTime,
+			)
+		},
+	},
+	{
+		// Show that the health of a tower is tracked from the probes
+		// and backups, and that subscribers are notified once the
+		// tower becomes unreachable.
+		name: "tower health monitoring",
+		cfg: harnessCfg{
+			localBalance:  localBalance,
+			remoteBalance: remoteBalance,
+			policy: wtpolicy.Policy{
+				TxPolicy:   defaultTxPolicy,
+				MaxUpdates: 5,
+			},
+			healthInterval: 100 * time.Millisecond,
+		},
+		fn: func(h *testHarness) {
+			const (
+				numUpdates = 2
+				chanIDInt  = 0
+			)
+
+			sub, err := h.clientMgr.SubscribeTowerHealth()
+			require.NoError(h.t, err)
+			defer sub.Cancel()
+
+			waitForStatus := func(
+				status wtclient.TowerHealthStatus) {
+
+				h.t.Helper()
+
+				timeout := time.After(waitTime)
+				for {
+					var update interface{}
+					select {
+					case update = <-sub.Updates():
+					case <-timeout:
+						h.t.Fatalf("tower not %v", status)
+					}
+
+					th, ok := update.(wtclient.TowerHealth)
+					require.True(h.t, ok)
+
+					if th.Status == status {
+						return
+					}
+				}
+			}
+
+			// The periodic probe should find the tower reachable.
+			waitForStatus(wtclient.TowerReachable)
+
+			// Back up a few states and assert that the acks of the
+			// tower are recorded.
+			hints := h.advanceChannelN(chanIDInt, numUpdates)
+			h.backupStates(chanIDInt, 0, numUpdates, nil)
+			h.server.waitForUpdates(hints, waitTime)
+
+			err = wait.Predicate(func() bool {
+				towers := h.clientMgr.TowerHealth()
+				if len(towers) != 1 {
+					return false
+				}
+
+				return towers[0].NumBackupsAcked == numUpdates
+			}, waitTime)
+			require.NoError(h.t, err)
+
+			// Once the tower goes offline, the next probe marks it
+			// as unreachable.
+			h.server.stop()
+			waitForStatus(wtclient.TowerUnreachable)
+
+			towers := h.clientMgr.TowerHealth()
+			require.Len(h.t, towers, 1)
+			require.True(
+				h.t, towers[0].IdentityKey.IsEqual(
+					h.server.addr.IdentityKey,
+				),
+			)
+			require.NotZero(h.t, towers[0].ConsecutiveFailures)
+			require.NotEmpty(h.t, towers[0].LastError)
+		},
+	},
 }
 
 // TestClient executes the client test suite, asserting the ability to backup
@@ -39,4 +39,14 @@ var (
 	// create a new session with a tower with a session key that has already
 	// been used in the past.
 	ErrSessionKeyAlreadyUsed = errors.New("session key already used")
+
+	// ErrMigrateToSameTower is returned when sessions are migrated from a
+	// tower to itself.
+	ErrMigrateToSameTower = errors.New("cannot migrate sessions to the " +
+		"tower they are migrated from")
+
+	// ErrMigrateToInactiveTower is returned when sessions are migrated to
+	// a tower that is not active.
+	ErrMigrateToInactiveTower = errors.New("tower to migrate sessions " +
+		"to is not active")
 )
@@ -47,6 +47,22 @@ type ClientManager interface {
 	// meaning that it will not be used again.
 	TerminateSession(id wtdb.SessionID) error
 
+	// TowerHealth returns the in-memory health statistics of the towers
+	// that were probed or used for backups since startup.
+	TowerHealth() []TowerHealth
+
+	// SubscribeTowerHealth returns a subscription that receives a
+	// TowerHealth update every time a tower becomes reachable or
+	// unreachable.
+	SubscribeTowerHealth() (*subscribe.Client, error)
+
+	// MigrateSessions re-backs up the latest revoked state of all
+	// registered channels so that they are protected by the given tower
+	// and not only the states that are created after the tower was added.
+	// If a tower to migrate from is provided, its sessions are terminated
+	// and the tower is deactivated before the states are re-backed up.
+	MigrateSessions(to, from *btcec.PublicKey) (*SessionMigration, error)
+
 	// Stats returns the in-memory statistics of the client since startup.
 	Stats() ClientStats
 
@@ -154,6 +170,11 @@ type Config struct {
 	// MaxTasksInMemQueue is the maximum number of backup tasks that should
 	// be kept in-memory. Any more tasks will overflow to disk.
 	MaxTasksInMemQueue uint64
+
+	// HealthCheckInterval is the interval at which all active towers are
+	// probed to check that they can still be reached. Towers aren't probed
+	// if it is zero.
+	HealthCheckInterval time.Duration
 }
 
 // Manager manages the various tower clients that are active. A client is
@@ -174,6 +195,8 @@ type Manager struct {
 
 	closableSessionQueue *sessionCloseMinHeap
 
+	towerHealth *towerHealthMonitor
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -210,6 +233,21 @@ func NewManager(config *Config, policies ...wtpolicy.Policy) (*Manager, error) {
 		quit:                 make(chan struct{}),
 	}
 
+	m.towerHealth = newTowerHealthMonitor(&towerHealthConfig{
+		ProbeInterval: cfg.HealthCheckInterval,
+		ListTowers: func() ([]*wtdb.Tower, error) {
+			return cfg.DB.ListTowers(func(tower *wtdb.Tower) bool {
+				return tower.Status == wtdb.TowerStatusActive
+			})
+		},
+		ProbeTower: func(tower *wtdb.Tower) error {
+			return probeTower(
+				tower, cfg.ChainHash, cfg.AuthDial, cfg.Dial,
+				cfg.ReadTimeout, cfg.WriteTimeout,
+			)
+		},
+	})
+
 	for _, policy := range policies {
 		if err = policy.Validate(); err != nil {
 			return nil, err
@@ -239,6 +277,7 @@ func (m *Manager) newClient(policy wtpolicy.Policy) error {
 		Config:         m.cfg,
 		Policy:         policy,
 		getSweepScript: m.getSweepScript,
+		recordBackup:   m.towerHealth.recordBackup,
 	}
 
 	client, err := newClient(cfg)
@@ -320,6 +359,12 @@ func (m *Manager) Start() error {
 		m.wg.Add(1)
 		go m.handleClosableSessions(blockEvents)
 
+		if err := m.towerHealth.start(); err != nil {
+			returnErr = err
+
+			return
+		}
+
 		m.clientsMu.Lock()
 		defer m.clientsMu.Unlock()
 
@@ -349,6 +394,10 @@ func (m *Manager) Stop() error {
 				returnErr = err
 			}
 		}
+
+		if err := m.towerHealth.stop(); err != nil {
+			returnErr = err
+		}
 	})
 
 	return returnErr
@@ -437,6 +486,11 @@ func (m *Manager) RemoveTower(key *btcec.PublicKey, addr net.Addr) error {
 		return err
 	}
 
+	// If the tower was removed entirely, we no longer track its health.
+	if addr == nil {
+		m.towerHealth.forget(dbTower.ID)
+	}
+
 	return nil
 }
 
@@ -457,6 +511,151 @@ func (m *Manager) TerminateSession(id wtdb.SessionID) error {
 	return m.cfg.DB.TerminateSession(id)
 }
 
+// SessionMigration summarizes the result of a session migration.
+type SessionMigration struct {
+	// NumSessionsRetired is the number of sessions with the tower that was
+	// migrated from that were terminated.
+	NumSessionsRetired int
+
+	// NumBackupsQueued is the number of revoked states that were queued to
+	// be backed up again.
+	NumBackupsQueued int
+}
+
+// MigrateSessions re-backs up the latest revoked state of all registered
+// channels so that they are protected by the given tower and not only the
+// states that are created after the tower was added. If a tower to migrate
+// from is provided, its sessions are terminated and the tower is deactivated
+// before the states are re-backed up.
+//
+// NOTE: The re-backed up states are handed to the client's active towers like
+// any other backup. Deactivating the tower that is migrated from ensures that
+// they're sent to the new tower if it's the only other active tower.
+func (m *Manager) MigrateSessions(to, from *btcec.PublicKey) (
+	*SessionMigration, error) {
+
+	select {
+	case <-m.quit:
+		return nil, ErrClientExiting
+	default:
+	}
+
+	if from != nil && from.IsEqual(to) {
+		return nil, ErrMigrateToSameTower
+	}
+
+	// The tower we migrate to must have been added and be active so that
+	// it's considered for the sessions the backups are sent to.
+	toTower, err := m.cfg.DB.LoadTower(to)
+	if err != nil {
+		return nil, err
+	}
+	if toTower.Status != wtdb.TowerStatusActive {
+		return nil, ErrMigrateToInactiveTower
+	}
+
+	var migration SessionMigration
+	if from != nil {
+		numRetired, err := m.retireTower(from)
+		if err != nil {
+			return nil, err
+		}
+
+		migration.NumSessionsRetired = numRetired
+	}
+
+	// Collect the latest state that was backed up for each of the
+	// channels that are registered with the client.
+	type latestState struct {
+		chanID   lnwire.ChannelID
+		height   uint64
+		blobType blob.Type
+	}
+
+	m.backupMu.Lock()
+	var states []latestState
+	for chanID, info := range m.chanInfos {
+		blobType, ok := m.chanBlobType[chanID]
+		if !ok {
+			continue
+		}
+
+		info.MaxHeight.WhenSome(func(height uint64) {
+			states = append(states, latestState{
+				chanID:   chanID,
+				height:   height,
+				blobType: blobType,
+			})
+		})
+	}
+	m.backupMu.Unlock()
+
+	m.clientsMu.Lock()
+	defer m.clientsMu.Unlock()
+
+	for _, state := range states {
+		client, ok := m.clients[state.blobType]
+		if !ok {
+			continue
+		}
+
+		log.Debugf("Re-queueing backup for chanid=%v at height=%d",
+			state.chanID, state.height)
+
+		err := client.backupState(&state.chanID, state.height)
+		if err != nil {
+			return nil, err
+		}
+
+		migration.NumBackupsQueued++
+	}
+
+	log.Infof("Migrated sessions to tower %x: %d sessions retired, %d "+
+		"backups queued", to.SerializeCompressed(),
+		migration.NumSessionsRetired, migration.NumBackupsQueued)
+
+	return &migration, nil
+}
+
+// retireTower terminates all the active sessions with the given tower and
+// deactivates the tower so that it's not used anymore. The number of
+// terminated sessions is returned.
+func (m *Manager) retireTower(key *btcec.PublicKey) (int, error) {
+	tower, err := m.cfg.DB.LoadTower(key)
+	if err != nil {
+		return 0, err
+	}
+
+	sessions, err := m.cfg.DB.ListClientSessions(&tower.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	// The sessions are terminated while the tower is still active so that
+	// their un-acked updates are handed to the remaining sessions.
+	var numRetired int
+	for id, session := range sessions {
+		if session.Status != wtdb.CSessionActive {
+			continue
+		}
+
+		if err := m.TerminateSession(id); err != nil {
+			return 0, fmt.Errorf("unable to terminate session %s: "+
+				"%w", id, err)
+		}
+
+		numRetired++
+	}
+
+	if tower.Status == wtdb.TowerStatusActive {
+		if err := m.DeactivateTower(key); err != nil {
+			return 0, err
+		}
+	}
+
+	return numRetired, nil
+}
+
 // DeactivateTower sets the given tower's status to inactive so that it is not
 // considered for session negotiation. Its sessions will also not be used while
 // the tower is inactive.
@@ -526,6 +725,18 @@ func (m *Manager) Stats() ClientStats {
 	return resp
 }
 
+// TowerHealth returns the in-memory health statistics of the towers that were
+// probed or used for backups since startup.
+func (m *Manager) TowerHealth() []TowerHealth {
+	return m.towerHealth.snapshot()
+}
+
+// SubscribeTowerHealth returns a subscription that receives a TowerHealth
+// update every time a tower becomes reachable or unreachable.
+func (m *Manager) SubscribeTowerHealth() (*subscribe.Client, error) {
+	return m.towerHealth.subscribe()
+}
+
 // RegisteredTowers retrieves the list of watchtowers being used by the various
 // clients.
 func (m *Manager) RegisteredTowers(opts ...wtdb.ClientSessionListOption) (
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btclog"
 	"github.com/lightningnetwork/lnd/input"
@@ -89,6 +90,12 @@ type sessionQueueConfig struct {
 	// Log specifies the desired log output, which should be prefixed by the
 	// client type, e.g. anchor or legacy.
 	Log btclog.Logger
+
+	// RecordBackup, if set, is called with the round trip of every backup
+	// that is acked by the tower, or with an error if the tower couldn't
+	// be dialed.
+	RecordBackup func(wtdb.TowerID, *btcec.PublicKey, time.Duration,
+		error)
 }
 
 // sessionQueue implements a reliable queue that will encrypt and send accepted
@@ -402,6 +409,8 @@ func (q *sessionQueue) drainBackups() {
 			q.log.Errorf("SessionQueue(%s) unable to dial tower "+
 				"at any available Addresses: %v", q.ID(), err)
 
+			q.recordBackup(0, err)
+
 			q.increaseBackoff()
 			select {
 			case <-time.After(q.retryBackoff):
@@ -430,6 +439,7 @@ func (q *sessionQueue) drainBackups() {
 		}
 
 		// Now, send the state update to the tower and wait for a reply.
+		start := time.Now()
 		err = q.sendStateUpdate(conn, stateUpdate, sendInit, isPending)
 		if err != nil {
 			q.log.Errorf("SessionQueue(%s) unable to send state "+
@@ -446,6 +456,8 @@ func (q *sessionQueue) drainBackups() {
 		q.log.Infof("SessionQueue(%s) uploaded %v seqnum=%d",
 			q.ID(), backupID, stateUpdate.SeqNum)
 
+		q.recordBackup(time.Since(start), nil)
+
 		// If the last task was backed up successfully, we'll exit and
 		// continue once more tasks are added to the queue. We'll also
 		// clear any accumulated backoff as this batch was able to be
@@ -466,6 +478,15 @@ func (q *sessionQueue) drainBackups() {
 	}
 }
 
+// recordBackup reports the outcome of sending a backup to the session's tower.
+func (q *sessionQueue) recordBackup(roundTrip time.Duration, err error) {
+	if q.cfg.RecordBackup == nil {
+		return
+	}
+
+	q.cfg.RecordBackup(q.tower.ID, q.tower.IdentityKey, roundTrip, err)
+}
+
 // nextStateUpdate returns the next wtwire.StateUpdate to upload to the tower.
 // If any committed updates are present, this method will reconstruct the state
 // update from the committed update using the current last applied value found
@@ -0,0 +1,415 @@
+package wtclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/subscribe"
+	"github.com/lightningnetwork/lnd/tor"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtserver"
+	"github.com/lightningnetwork/lnd/watchtower/wtwire"
+)
+
+// ErrNoTowerAddresses is returned when a tower without any addresses is
+// probed.
+var ErrNoTowerAddresses = errors.New("tower has no addresses")
+
+// TowerHealthStatus describes whether a tower could be reached the last time
+// it was probed or used for a backup.
+type TowerHealthStatus uint8
+
+const (
+	// TowerHealthUnknown indicates that the tower hasn't been probed or
+	// used for a backup since startup.
+	TowerHealthUnknown TowerHealthStatus = iota
+
+	// TowerReachable indicates that the last probe of or backup to the
+	// tower succeeded.
+	TowerReachable
+
+	// TowerUnreachable indicates that the last probe of or backup to the
+	// tower failed.
+	TowerUnreachable
+)
+
+// String returns a human-readable representation of the tower status.
+func (s TowerHealthStatus) String() string {
+	switch s {
+	case TowerHealthUnknown:
+		return "unknown"
+
+	case TowerReachable:
+		return "reachable"
+
+	case TowerUnreachable:
+		return "unreachable"
+
+	default:
+		return fmt.Sprintf("TowerHealthStatus(%d)", s)
+	}
+}
+
+// TowerHealth holds the in-memory health statistics of a tower since startup.
+type TowerHealth struct {
+	// ID is the unique database identifier of the tower.
+	ID wtdb.TowerID
+
+	// IdentityKey is the public key of the tower.
+	IdentityKey *btcec.PublicKey
+
+	// Status is the current status of the tower.
+	Status TowerHealthStatus
+
+	// LastStatusChange is the time at which the status of the tower last
+	// changed.
+	LastStatusChange time.Time
+
+	// LastProbe is the time of the last probe of the tower. It is the zero
+	// time if the tower hasn't been probed yet.
+	LastProbe time.Time
+
+	// ProbeLatency is the time it took to connect to the tower and
+	// exchange Init messages with it during the last successful probe.
+	ProbeLatency time.Duration
+
+	// NumBackupsAcked is the number of backups that were acked by the
+	// tower.
+	NumBackupsAcked uint64
+
+	// BackupRoundTrip is the time it took the tower to ack the last
+	// backup that was sent to it.
+	BackupRoundTrip time.Duration
+
+	// ConsecutiveFailures is the number of probes and backups that failed
+	// in a row.
+	ConsecutiveFailures uint32
+
+	// LastError is the error of the last failed probe or backup.
+	LastError string
+}
+
+// towerHealthConfig holds the configuration of a towerHealthMonitor.
+type towerHealthConfig struct {
+	// ProbeInterval is the interval at which all active towers are
+	// probed. Towers aren't probed if it is zero.
+	ProbeInterval time.Duration
+
+	// ListTowers returns the towers that should be probed.
+	ListTowers func() ([]*wtdb.Tower, error)
+
+	// ProbeTower connects to a tower and checks that it is able to
+	// negotiate sessions with it.
+	ProbeTower func(*wtdb.Tower) error
+}
+
+// towerHealthMonitor tracks the health of the client's towers. It
+// periodically probes all active towers and records the outcome of the
+// backups that are sent to them. Subscribers are notified with a TowerHealth
+// update whenever the status of a tower changes.
+type towerHealthMonitor struct {
+	cfg *towerHealthConfig
+
+	towers   map[wtdb.TowerID]*TowerHealth
+	towersMu sync.Mutex
+
+	notifier *subscribe.Server
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newTowerHealthMonitor creates a new towerHealthMonitor.
+func newTowerHealthMonitor(cfg *towerHealthConfig) *towerHealthMonitor {
+	return &towerHealthMonitor{
+		cfg:      cfg,
+		towers:   make(map[wtdb.TowerID]*TowerHealth),
+		notifier: subscribe.NewServer(),
+		quit:     make(chan struct{}),
+	}
+}
+
+// start starts the notifier and, if enabled, the periodic tower probes.
+func (t *towerHealthMonitor) start() error {
+	if err := t.notifier.Start(); err != nil {
+		return err
+	}
+
+	if t.cfg.ProbeInterval > 0 {
+		t.wg.Add(1)
+		go t.probeTowers()
+	}
+
+	return nil
+}
+
+// stop stops the periodic tower probes and the notifier.
+func (t *towerHealthMonitor) stop() error {
+	close(t.quit)
+	t.wg.Wait()
+
+	return t.notifier.Stop()
+}
+
+// probeTowers probes all active towers every time the probe interval
+// elapses.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (t *towerHealthMonitor) probeTowers() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(t.cfg.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			towers, err := t.cfg.ListTowers()
+			if err != nil {
+				log.Errorf("Unable to list towers to probe: %v",
+					err)
+
+				continue
+			}
+
+			for _, tower := range towers {
+				start := time.Now()
+				err := t.cfg.ProbeTower(tower)
+				t.recordProbe(
+					tower.ID, tower.IdentityKey,
+					time.Since(start), err,
+				)
+
+				select {
+				case <-t.quit:
+					return
+				default:
+				}
+			}
+
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// recordProbe records the outcome of a probe of the given tower.
+func (t *towerHealthMonitor) recordProbe(id wtdb.TowerID,
+	key *btcec.PublicKey, latency time.Duration, probeErr error) {
+
+	t.towersMu.Lock()
+	defer t.towersMu.Unlock()
+
+	health := t.getOrCreate(id, key)
+	health.LastProbe = time.Now()
+
+	if probeErr != nil {
+		log.Warnf("Probe of tower %x failed: %v",
+			key.SerializeCompressed(), probeErr)
+
+		t.recordFailure(health, probeErr)
+
+		return
+	}
+
+	log.Debugf("Probe of tower %x succeeded after %v",
+		key.SerializeCompressed(), latency)
+
+	health.ProbeLatency = latency
+	t.recordSuccess(health)
+}
+
+// recordBackup records the outcome of sending a backup to the given tower.
+// The round trip is only meaningful if the backup succeeded.
+func (t *towerHealthMonitor) recordBackup(id wtdb.TowerID,
+	key *btcec.PublicKey, roundTrip time.Duration, backupErr error) {
+
+	t.towersMu.Lock()
+	defer t.towersMu.Unlock()
+
+	health := t.getOrCreate(id, key)
+
+	if backupErr != nil {
+		t.recordFailure(health, backupErr)
+
+		return
+	}
+
+	health.NumBackupsAcked++
+	health.BackupRoundTrip = roundTrip
+	t.recordSuccess(health)
+}
+
+// forget removes the health statistics of the given tower.
+func (t *towerHealthMonitor) forget(id wtdb.TowerID) {
+	t.towersMu.Lock()
+	defer t.towersMu.Unlock()
+
+	delete(t.towers, id)
+}
+
+// snapshot returns a copy of the health statistics of all towers, ordered by
+// their ID.
+func (t *towerHealthMonitor) snapshot() []TowerHealth {
+	t.towersMu.Lock()
+	defer t.towersMu.Unlock()
+
+	towers := make([]TowerHealth, 0, len(t.towers))
+	for _, health := range t.towers {
+		towers = append(towers, *health)
+	}
+
+	sort.Slice(towers, func(i, j int) bool {
+		return towers[i].ID < towers[j].ID
+	})
+
+	return towers
+}
+
+// subscribe returns a client that receives a TowerHealth update every time
+// the status of a tower changes.
+func (t *towerHealthMonitor) subscribe() (*subscribe.Client, error) {
+	return t.notifier.Subscribe()
+}
+
+// getOrCreate returns the health statistics of the given tower, creating
+// them if they don't exist yet.
+//
+// NOTE: The caller must hold towersMu.
+func (t *towerHealthMonitor) getOrCreate(id wtdb.TowerID,
+	key *btcec.PublicKey) *TowerHealth {
+
+	health, ok := t.towers[id]
+	if !ok {
+		health = &TowerHealth{
+			ID:          id,
+			IdentityKey: key,
+		}
+		t.towers[id] = health
+	}
+
+	return health
+}
+
+// recordSuccess marks the tower as reachable.
+//
+// NOTE: The caller must hold towersMu.
+func (t *towerHealthMonitor) recordSuccess(health *TowerHealth) {
+	health.ConsecutiveFailures = 0
+	t.setStatus(health, TowerReachable)
+}
+
+// recordFailure marks the tower as unreachable.
+//
+// NOTE: The caller must hold towersMu.
+func (t *towerHealthMonitor) recordFailure(health *TowerHealth, err error) {
+	health.ConsecutiveFailures++
+	health.LastError = err.Error()
+	t.setStatus(health, TowerUnreachable)
+}
+
+// setStatus updates the status of the tower and notifies all subscribers if
+// it changed.
+//
+// NOTE: The caller must hold towersMu.
+func (t *towerHealthMonitor) setStatus(health *TowerHealth,
+	status TowerHealthStatus) {
+
+	if health.Status == status {
+		return
+	}
+
+	log.Infof("Tower %x is now %v (was %v)",
+		health.IdentityKey.SerializeCompressed(), status,
+		health.Status)
+
+	health.Status = status
+	health.LastStatusChange = time.Now()
+
+	if err := t.notifier.SendUpdate(*health); err != nil {
+		log.Errorf("Unable to send tower health update: %v", err)
+	}
+}
+
+// probeTower connects to each of the tower's addresses until a connection can
+// be established, and exchanges Init messages with the tower to check that it
+// supports altruist sessions on our chain. This is the first step of every
+// session negotiation and backup, but it doesn't create a session on the
+// tower. An ephemeral key is used to connect, so that probes can't be linked
+// to the client's sessions.
+func probeTower(tower *wtdb.Tower, chainHash chainhash.Hash,
+	authDial AuthDialer, dial tor.DialFunc, readTimeout,
+	writeTimeout time.Duration) error {
+
+	if len(tower.Addresses) == 0 {
+		return ErrNoTowerAddresses
+	}
+
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return err
+	}
+	localKey := &keychain.PrivKeyECDH{PrivKey: privKey}
+
+	var conn wtserver.Peer
+	for _, addr := range tower.Addresses {
+		conn, err = authDial(localKey, &lnwire.NetAddress{
+			IdentityKey: tower.IdentityKey,
+			Address:     addr,
+		}, dial)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("unable to dial tower: %w", err)
+	}
+	defer conn.Close()
+
+	localInit := wtwire.NewInitMessage(
+		lnwire.NewRawFeatureVector(wtwire.AltruistSessionsRequired),
+		chainHash,
+	)
+
+	var b bytes.Buffer
+	if _, err := wtwire.WriteMessage(&b, localInit, 0); err != nil {
+		return err
+	}
+
+	err = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(b.Bytes()); err != nil {
+		return fmt.Errorf("unable to send Init: %w", err)
+	}
+
+	err = conn.SetReadDeadline(time.Now().Add(readTimeout))
+	if err != nil {
+		return err
+	}
+	rawMsg, err := conn.ReadNextMessage()
+	if err != nil {
+		return fmt.Errorf("unable to read Init: %w", err)
+	}
+
+	remoteMsg, err := wtwire.ReadMessage(bytes.NewReader(rawMsg), 0)
+	if err != nil {
+		return err
+	}
+
+	remoteInit, ok := remoteMsg.(*wtwire.Init)
+	if !ok {
+		return fmt.Errorf("tower responded with %T to Init", remoteMsg)
+	}
+
+	return localInit.CheckRemoteInit(remoteInit, wtwire.FeatureNames)
+}
@@ -0,0 +1,585 @@
+package wtdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/lightningnetwork/lnd/sqldb/sqlc"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+)
+
+// SQLTowerQueries is an interface that defines the set of operations that can
+// be executed against the watchtower server SQL database.
+type SQLTowerQueries interface {
+	UpsertTowerSession(ctx context.Context,
+		arg sqlc.UpsertTowerSessionParams) error
+
+	GetTowerSession(ctx context.Context,
+		sessionID []byte) (sqlc.TowerSession, error)
+
+	UpdateTowerSessionLastApplied(ctx context.Context,
+		arg sqlc.UpdateTowerSessionLastAppliedParams) error
+
+	DeleteTowerSession(ctx context.Context, sessionID []byte) error
+
+	DeleteInactiveTowerSessions(ctx context.Context,
+		lastUpdateAt int64) (sql.Result, error)
+
+	CountTowerSessions(ctx context.Context) (int64, error)
+
+	ListTowerClients(ctx context.Context) ([]sqlc.ListTowerClientsRow,
+		error)
+
+	UpsertTowerStateUpdate(ctx context.Context,
+		arg sqlc.UpsertTowerStateUpdateParams) error
+
+	FetchTowerStateUpdatesByHint(ctx context.Context,
+		hint []byte) ([]sqlc.FetchTowerStateUpdatesByHintRow, error)
+
+	DeleteTowerLookoutTip(ctx context.Context) error
+
+	InsertTowerLookoutTip(ctx context.Context,
+		arg sqlc.InsertTowerLookoutTipParams) error
+
+	GetTowerLookoutTip(ctx context.Context) (sqlc.TowerLookoutTip, error)
+}
+
+// SQLTowerQueriesTxOptions defines the set of db txn options the
+// SQLTowerQueries understands.
+type SQLTowerQueriesTxOptions struct {
+	// readOnly governs if a read only transaction is needed or not.
+	readOnly bool
+}
+
+// ReadOnly returns true if the transaction should be read only.
+//
+// NOTE: This implements the TxOptions.
+func (a *SQLTowerQueriesTxOptions) ReadOnly() bool {
+	return a.readOnly
+}
+
+// NewSQLTowerQueryReadTx creates a new read transaction option set.
+func NewSQLTowerQueryReadTx() SQLTowerQueriesTxOptions {
+	return SQLTowerQueriesTxOptions{
+		readOnly: true,
+	}
+}
+
+// BatchedSQLTowerQueries is a version of the SQLTowerQueries that's capable of
+// batched database operations.
+type BatchedSQLTowerQueries interface {
+	SQLTowerQueries
+
+	sqldb.BatchedTx[SQLTowerQueries]
+}
+
+// SQLTowerDB is a native SQL implementation of the database of the watchtower
+// server. In addition to the bolt TowerDB, it keeps track of when sessions
+// were created and last updated, which allows inactive sessions to be cleaned
+// up.
+type SQLTowerDB struct {
+	db BatchedSQLTowerQueries
+
+	clock clock.Clock
+}
+
+// NewSQLTowerDB creates a new SQLTowerDB instance given an open
+// BatchedSQLTowerQueries storage backend.
+func NewSQLTowerDB(db BatchedSQLTowerQueries, clock clock.Clock) *SQLTowerDB {
+	return &SQLTowerDB{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// GetSessionInfo retrieves the session for the passed session id. An error is
+// returned if the session could not be found.
+func (s *SQLTowerDB) GetSessionInfo(id *SessionID) (*SessionInfo, error) {
+	var (
+		ctx        = context.TODO()
+		readTxOpts = NewSQLTowerQueryReadTx()
+		session    *SessionInfo
+	)
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLTowerQueries) error {
+		dbSession, err := getSQLTowerSession(ctx, db, id)
+		if err != nil {
+			return err
+		}
+
+		session, err = unmarshalSessionInfo(
+			dbSession.SessionID, dbSession.Policy,
+			dbSession.LastApplied, dbSession.ClientLastApplied,
+			dbSession.RewardAddress,
+		)
+
+		return err
+	}, func() {
+		session = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// InsertSessionInfo records a negotiated session in the tower database. An
+// error is returned if the session already exists.
+func (s *SQLTowerDB) InsertSessionInfo(session *SessionInfo) error {
+	var (
+		ctx         = context.TODO()
+		writeTxOpts SQLTowerQueriesTxOptions
+	)
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLTowerQueries) error {
+		dbSession, err := getSQLTowerSession(ctx, db, &session.ID)
+		switch {
+		case errors.Is(err, ErrSessionNotFound):
+			// proceed.
+
+		case err != nil:
+			return err
+
+		case dbSession.LastApplied > 0:
+			return ErrSessionAlreadyExists
+		}
+
+		// Perform a quick sanity check on the session policy before
+		// accepting.
+		if err := session.Policy.Validate(); err != nil {
+			return err
+		}
+
+		var policy bytes.Buffer
+		if err := WriteElement(&policy, session.Policy); err != nil {
+			return err
+		}
+
+		now := s.clock.Now().Unix()
+
+		return db.UpsertTowerSession(ctx, sqlc.UpsertTowerSessionParams{
+			SessionID:         session.ID[:],
+			Policy:            policy.Bytes(),
+			LastApplied:       int32(session.LastApplied),
+			ClientLastApplied: int32(session.ClientLastApplied),
+			RewardAddress:     session.RewardAddress,
+			CreatedAt:         now,
+			LastUpdateAt:      now,
+		})
+	}, func() {})
+}
+
+// InsertStateUpdate stores an update sent by the client after validating that
+// the update is well-formed in the context of other updates sent for the same
+// session. This include verifying that the sequence number is incremented
+// properly and the last applied values echoed by the client are sane.
+func (s *SQLTowerDB) InsertStateUpdate(update *SessionStateUpdate) (uint16,
+	error) {
+
+	var (
+		ctx         = context.TODO()
+		writeTxOpts SQLTowerQueriesTxOptions
+		lastApplied uint16
+	)
+	err := s.db.ExecTx(ctx, &writeTxOpts, func(db SQLTowerQueries) error {
+		dbSession, err := getSQLTowerSession(ctx, db, &update.ID)
+		if err != nil {
+			return err
+		}
+
+		session, err := unmarshalSessionInfo(
+			dbSession.SessionID, dbSession.Policy,
+			dbSession.LastApplied, dbSession.ClientLastApplied,
+			dbSession.RewardAddress,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Assert that the blob is the correct size for the session's
+		// blob type.
+		expBlobSize, err := session.Policy.BlobType.BlobSize()
+		if err != nil {
+			return err
+		}
+		if len(update.EncryptedBlob) != expBlobSize {
+			return ErrInvalidBlobSize
+		}
+
+		// Validate the update against the current state of the session.
+		err = session.AcceptUpdateSequence(
+			update.SeqNum, update.LastApplied,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Validation succeeded, therefore the update is committed and
+		// the session's last applied value is equal to the update's
+		// sequence number.
+		lastApplied = session.LastApplied
+
+		err = db.UpdateTowerSessionLastApplied(
+			ctx, sqlc.UpdateTowerSessionLastAppliedParams{
+				ID:                dbSession.ID,
+				LastApplied:       int32(session.LastApplied),
+				ClientLastApplied: int32(session.ClientLastApplied),
+				LastUpdateAt:      s.clock.Now().Unix(),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		return db.UpsertTowerStateUpdate(
+			ctx, sqlc.UpsertTowerStateUpdateParams{
+				SessionID:     dbSession.ID,
+				Hint:          update.Hint[:],
+				SeqNum:        int32(update.SeqNum),
+				EncryptedBlob: update.EncryptedBlob,
+			},
+		)
+	}, func() {
+		lastApplied = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return lastApplied, nil
+}
+
+// DeleteSession removes all data associated with a particular session id from
+// the tower's database.
+func (s *SQLTowerDB) DeleteSession(target SessionID) error {
+	var (
+		ctx         = context.TODO()
+		writeTxOpts SQLTowerQueriesTxOptions
+	)
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLTowerQueries) error {
+		// Fail if the session doesn't exist.
+		_, err := getSQLTowerSession(ctx, db, &target)
+		if err != nil {
+			return err
+		}
+
+		// The state updates of the session are removed along with it.
+		return db.DeleteTowerSession(ctx, target[:])
+	}, func() {})
+}
+
+// DeleteInactiveSessions removes all sessions, along with their state updates,
+// that were last updated before the given cutoff and returns their number.
+func (s *SQLTowerDB) DeleteInactiveSessions(cutoff time.Time) (int64, error) {
+	var (
+		ctx         = context.TODO()
+		writeTxOpts SQLTowerQueriesTxOptions
+		numDeleted  int64
+	)
+	err := s.db.ExecTx(ctx, &writeTxOpts, func(db SQLTowerQueries) error {
+		result, err := db.DeleteInactiveTowerSessions(
+			ctx, cutoff.Unix(),
+		)
+		if err != nil {
+			return err
+		}
+
+		numDeleted, err = result.RowsAffected()
+
+		return err
+	}, func() {
+		numDeleted = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numDeleted, nil
+}
+
+// ListClients returns a summary of the data that is stored for each client
+// of the tower.
+func (s *SQLTowerDB) ListClients() ([]TowerClient, error) {
+	var (
+		ctx        = context.TODO()
+		readTxOpts = NewSQLTowerQueryReadTx()
+		clients    []TowerClient
+	)
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLTowerQueries) error {
+		rows, err := db.ListTowerClients(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			var policy wtpolicy.Policy
+			err := ReadElement(bytes.NewReader(row.Policy), &policy)
+			if err != nil {
+				return err
+			}
+
+			client := TowerClient{
+				Policy:      policy,
+				LastApplied: uint16(row.LastApplied),
+				NumUpdates:  uint64(row.NumUpdates),
+				DiskUsage:   uint64(row.DiskUsage),
+				CreatedAt:   time.Unix(row.CreatedAt, 0),
+				LastUpdate:  time.Unix(row.LastUpdateAt, 0),
+			}
+			copy(client.ID[:], row.SessionID)
+
+			clients = append(clients, client)
+		}
+
+		return nil
+	}, func() {
+		clients = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// QueryMatches searches against all known state updates for any that match the
+// passed breachHints. More than one Match will be returned for a given hint if
+// they exist in the database.
+func (s *SQLTowerDB) QueryMatches(breachHints []blob.BreachHint) ([]Match,
+	error) {
+
+	var (
+		ctx        = context.TODO()
+		readTxOpts = NewSQLTowerQueryReadTx()
+		matches    []Match
+	)
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLTowerQueries) error {
+		for _, hint := range breachHints {
+			rows, err := db.FetchTowerStateUpdatesByHint(
+				ctx, hint[:],
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				session, err := unmarshalSessionInfo(
+					row.SessionID, row.Policy,
+					row.LastApplied, row.ClientLastApplied,
+					row.RewardAddress,
+				)
+				if err != nil {
+					return err
+				}
+
+				matches = append(matches, Match{
+					ID:            session.ID,
+					SeqNum:        uint16(row.SeqNum),
+					Hint:          hint,
+					EncryptedBlob: row.EncryptedBlob,
+					SessionInfo:   session,
+				})
+			}
+		}
+
+		return nil
+	}, func() {
+		matches = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// SetLookoutTip stores the provided epoch as the latest lookout tip epoch in
+// the tower database.
+func (s *SQLTowerDB) SetLookoutTip(epoch *chainntnfs.BlockEpoch) error {
+	var (
+		ctx         = context.TODO()
+		writeTxOpts SQLTowerQueriesTxOptions
+	)
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLTowerQueries) error {
+		return setSQLLookoutTip(ctx, db, epoch)
+	}, func() {})
+}
+
+// GetLookoutTip retrieves the current lookout tip block epoch from the tower
+// database. A nil epoch is returned if no tip was set yet.
+func (s *SQLTowerDB) GetLookoutTip() (*chainntnfs.BlockEpoch, error) {
+	var (
+		ctx        = context.TODO()
+		readTxOpts = NewSQLTowerQueryReadTx()
+		epoch      *chainntnfs.BlockEpoch
+	)
+	err := s.db.ExecTx(ctx, &readTxOpts, func(db SQLTowerQueries) error {
+		tip, err := db.GetTowerLookoutTip(ctx)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+
+		case err != nil:
+			return err
+		}
+
+		var hash chainhash.Hash
+		copy(hash[:], tip.BlockHash)
+		epoch = &chainntnfs.BlockEpoch{
+			Hash:   &hash,
+			Height: int32(tip.BlockHeight),
+		}
+
+		return nil
+	}, func() {
+		epoch = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return epoch, nil
+}
+
+// MigrateFromKV copies the sessions, state updates and lookout tip of the
+// given bolt tower database to the SQL database. The migration is skipped if
+// the SQL database already contains any sessions. The times at which the
+// migrated sessions were created and last updated are set to the current
+// time, since the bolt database doesn't track them.
+func (s *SQLTowerDB) MigrateFromKV(ctx context.Context, kvDB *TowerDB) error {
+	var writeTxOpts SQLTowerQueriesTxOptions
+
+	return s.db.ExecTx(ctx, &writeTxOpts, func(db SQLTowerQueries) error {
+		numSessions, err := db.CountTowerSessions(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The tower database has already been migrated.
+		if numSessions > 0 {
+			return nil
+		}
+
+		now := s.clock.Now().Unix()
+		err = kvDB.ForEachSession(func(session *SessionInfo) error {
+			var policy bytes.Buffer
+			err := WriteElement(&policy, session.Policy)
+			if err != nil {
+				return err
+			}
+
+			return db.UpsertTowerSession(
+				ctx, sqlc.UpsertTowerSessionParams{
+					SessionID: session.ID[:],
+					Policy:    policy.Bytes(),
+					LastApplied: int32(
+						session.LastApplied,
+					),
+					ClientLastApplied: int32(
+						session.ClientLastApplied,
+					),
+					RewardAddress: session.RewardAddress,
+					CreatedAt:     now,
+					LastUpdateAt:  now,
+				},
+			)
+		})
+		if err != nil {
+			return err
+		}
+
+		err = kvDB.ForEachStateUpdate(func(u *SessionStateUpdate) error {
+			dbSession, err := getSQLTowerSession(ctx, db, &u.ID)
+			switch {
+			// Updates of sessions that no longer exist can't be
+			// matched anymore, so they aren't migrated.
+			case errors.Is(err, ErrSessionNotFound):
+				return nil
+
+			case err != nil:
+				return err
+			}
+
+			return db.UpsertTowerStateUpdate(
+				ctx, sqlc.UpsertTowerStateUpdateParams{
+					SessionID:     dbSession.ID,
+					Hint:          u.Hint[:],
+					SeqNum:        int32(u.SeqNum),
+					EncryptedBlob: u.EncryptedBlob,
+				},
+			)
+		})
+		if err != nil {
+			return err
+		}
+
+		epoch, err := kvDB.GetLookoutTip()
+		if err != nil || epoch == nil {
+			return err
+		}
+
+		return setSQLLookoutTip(ctx, db, epoch)
+	}, func() {})
+}
+
+// getSQLTowerSession fetches the session with the given ID, returning
+// ErrSessionNotFound if it doesn't exist.
+func getSQLTowerSession(ctx context.Context, db SQLTowerQueries,
+	id *SessionID) (sqlc.TowerSession, error) {
+
+	session, err := db.GetTowerSession(ctx, id[:])
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, ErrSessionNotFound
+	}
+
+	return session, err
+}
+
+// setSQLLookoutTip replaces the lookout tip with the given epoch.
+func setSQLLookoutTip(ctx context.Context, db SQLTowerQueries,
+	epoch *chainntnfs.BlockEpoch) error {
+
+	if err := db.DeleteTowerLookoutTip(ctx); err != nil {
+		return err
+	}
+
+	return db.InsertTowerLookoutTip(ctx, sqlc.InsertTowerLookoutTipParams{
+		BlockHash:   epoch.Hash[:],
+		BlockHeight: int64(epoch.Height),
+	})
+}
+
+// unmarshalSessionInfo creates a SessionInfo from its database columns.
+func unmarshalSessionInfo(id, policy []byte, lastApplied,
+	clientLastApplied int32, rewardAddress []byte) (*SessionInfo, error) {
+
+	// The kv store always decodes the reward address as a non-nil slice,
+	// so we do the same for empty addresses read from the database.
+	if rewardAddress == nil {
+		rewardAddress = []byte{}
+	}
+
+	session := &SessionInfo{
+		LastApplied:       uint16(lastApplied),
+		ClientLastApplied: uint16(clientLastApplied),
+		RewardAddress:     rewardAddress,
+	}
+	copy(session.ID[:], id)
+
+	err := ReadElement(bytes.NewReader(policy), &session.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
@@ -0,0 +1,193 @@
+package wtdb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/clock"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/sqldb"
+	"github.com/lightningnetwork/lnd/watchtower/blob"
+	"github.com/lightningnetwork/lnd/watchtower/wtdb"
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSQLTowerDB creates a SQL tower database backed by a fresh sqlite
+// database.
+func newTestSQLTowerDB(t *testing.T, clock clock.Clock) *wtdb.SQLTowerDB {
+	sqlDB := sqldb.NewTestSqliteDB(t).BaseDB
+	executor := sqldb.NewTransactionExecutor(
+		sqlDB, func(tx *sql.Tx) wtdb.SQLTowerQueries {
+			return sqlDB.WithTx(tx)
+		},
+	)
+
+	return wtdb.NewSQLTowerDB(executor, clock)
+}
+
+// newTestSession creates a session with the given id that accepts anchor
+// channel updates.
+func newTestSession(id *wtdb.SessionID) *wtdb.SessionInfo {
+	return &wtdb.SessionInfo{
+		ID: *id,
+		Policy: wtpolicy.Policy{
+			TxPolicy: wtpolicy.TxPolicy{
+				BlobType:     blob.TypeAltruistAnchorCommit,
+				SweepFeeRate: wtpolicy.DefaultSweepFeeRate,
+			},
+			MaxUpdates: 100,
+		},
+		RewardAddress: []byte{},
+	}
+}
+
+// TestSQLTowerDBListClients tests that the SQL tower database reports the
+// number of updates and the disk usage of each client.
+func TestSQLTowerDBListClients(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1_700_000_000, 0)
+	testClock := clock.NewTestClock(start)
+	db := newTestSQLTowerDB(t, testClock)
+
+	id1, id2 := id(1), id(2)
+	require.NoError(t, db.InsertSessionInfo(newTestSession(id1)))
+	require.NoError(t, db.InsertSessionInfo(newTestSession(id2)))
+
+	testClock.SetTime(start.Add(time.Minute))
+	var diskUsage uint64
+	for i := 1; i <= 3; i++ {
+		update := updateFromInt(id1, i, 0)
+		_, err := db.InsertStateUpdate(update)
+		require.NoError(t, err)
+
+		diskUsage += uint64(len(update.EncryptedBlob))
+	}
+
+	clients, err := db.ListClients()
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+
+	clientsByID := make(map[wtdb.SessionID]wtdb.TowerClient)
+	for _, client := range clients {
+		clientsByID[client.ID] = client
+	}
+
+	client1 := clientsByID[*id1]
+	require.EqualValues(t, 3, client1.LastApplied)
+	require.EqualValues(t, 3, client1.NumUpdates)
+	require.Equal(t, diskUsage, client1.DiskUsage)
+	require.Equal(t, start.Unix(), client1.CreatedAt.Unix())
+	require.Equal(t, start.Add(time.Minute).Unix(),
+		client1.LastUpdate.Unix())
+
+	client2 := clientsByID[*id2]
+	require.Zero(t, client2.NumUpdates)
+	require.Zero(t, client2.DiskUsage)
+	require.Equal(t, start.Unix(), client2.LastUpdate.Unix())
+}
+
+// TestSQLTowerDBDeleteInactiveSessions tests that only the sessions that
+// haven't been updated since the cutoff are removed together with their
+// state updates.
+func TestSQLTowerDBDeleteInactiveSessions(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1_700_000_000, 0)
+	testClock := clock.NewTestClock(start)
+	db := newTestSQLTowerDB(t, testClock)
+
+	id1, id2 := id(1), id(2)
+	require.NoError(t, db.InsertSessionInfo(newTestSession(id1)))
+	require.NoError(t, db.InsertSessionInfo(newTestSession(id2)))
+
+	update1 := updateFromInt(id1, 1, 0)
+	_, err := db.InsertStateUpdate(update1)
+	require.NoError(t, err)
+
+	// Only the second session receives an update after an hour.
+	testClock.SetTime(start.Add(time.Hour))
+	update2 := updateFromInt(id2, 1, 0)
+	_, err = db.InsertStateUpdate(update2)
+	require.NoError(t, err)
+
+	numDeleted, err := db.DeleteInactiveSessions(
+		start.Add(time.Minute),
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, numDeleted)
+
+	_, err = db.GetSessionInfo(id1)
+	require.ErrorIs(t, err, wtdb.ErrSessionNotFound)
+	_, err = db.GetSessionInfo(id2)
+	require.NoError(t, err)
+
+	matches, err := db.QueryMatches([]blob.BreachHint{
+		update1.Hint, update2.Hint,
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, update2.Hint, matches[0].Hint)
+}
+
+// TestSQLTowerDBMigrateFromKV tests that the sessions, state updates and
+// lookout tip of a kv tower database are copied to the SQL tower database.
+func TestSQLTowerDBMigrateFromKV(t *testing.T) {
+	t.Parallel()
+
+	dbCfg := &kvdb.BoltConfig{DBTimeout: kvdb.DefaultDBTimeout}
+	bdb, err := wtdb.NewBoltBackendCreator(
+		true, t.TempDir(), "watchtower.db",
+	)(dbCfg)
+	require.NoError(t, err)
+
+	kvDB, err := wtdb.OpenTowerDB(bdb)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, kvDB.Close())
+	})
+
+	id1 := id(1)
+	session := newTestSession(id1)
+	require.NoError(t, kvDB.InsertSessionInfo(session))
+
+	update := updateFromInt(id1, 1, 0)
+	_, err = kvDB.InsertStateUpdate(update)
+	require.NoError(t, err)
+
+	epoch := epochFromInt(1)
+	require.NoError(t, kvDB.SetLookoutTip(epoch))
+
+	db := newTestSQLTowerDB(t, clock.NewDefaultClock())
+	ctx := context.Background()
+	require.NoError(t, db.MigrateFromKV(ctx, kvDB))
+
+	assertMigrated := func() {
+		t.Helper()
+
+		migrated, err := db.GetSessionInfo(id1)
+		require.NoError(t, err)
+		require.Equal(t, session.Policy, migrated.Policy)
+		require.EqualValues(t, 1, migrated.LastApplied)
+
+		matches, err := db.QueryMatches(
+			[]blob.BreachHint{update.Hint},
+		)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		require.Equal(t, update.EncryptedBlob,
+			matches[0].EncryptedBlob)
+
+		tip, err := db.GetLookoutTip()
+		require.NoError(t, err)
+		require.Equal(t, epoch, tip)
+	}
+	assertMigrated()
+
+	// Running the migration again is a no-op.
+	require.NoError(t, db.MigrateFromKV(ctx, kvDB))
+	assertMigrated()
+}
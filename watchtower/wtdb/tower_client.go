@@ -0,0 +1,39 @@
+package wtdb
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/watchtower/wtpolicy"
+)
+
+// TowerClient summarizes the data a client stores on the tower. Clients are
+// identified by the key they authenticate with, which is the session ID.
+// Since clients use a fresh key for every session they negotiate, each of
+// their sessions appears as a distinct client to the tower.
+type TowerClient struct {
+	// ID is the session ID, which is the public key of the client.
+	ID SessionID
+
+	// Policy is the policy that was negotiated for the session.
+	Policy wtpolicy.Policy
+
+	// LastApplied is the sequence number of the last accepted state
+	// update.
+	LastApplied uint16
+
+	// NumUpdates is the number of state updates that are stored for the
+	// client.
+	NumUpdates uint64
+
+	// DiskUsage is the number of bytes of encrypted justice data that are
+	// stored for the client.
+	DiskUsage uint64
+
+	// CreatedAt is the time at which the session was created. It is the
+	// zero time if the database doesn't track it.
+	CreatedAt time.Time
+
+	// LastUpdate is the time of the last state update of the client. It
+	// is the zero time if the database doesn't track it.
+	LastUpdate time.Time
+}
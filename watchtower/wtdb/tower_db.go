@@ -479,6 +479,113 @@ func (t *TowerDB) QueryMatches(breachHints []blob.BreachHint) ([]Match, error) {
 	return matches, nil
 }
 
+// ListClients returns a summary of the data that is stored for each client
+// of the tower. The bolt database doesn't track when sessions were created or
+// last updated, so those times are left empty.
+func (t *TowerDB) ListClients() ([]TowerClient, error) {
+	var clients []TowerClient
+	err := kvdb.View(t.db, func(tx kvdb.RTx) error {
+		sessions := tx.ReadBucket(sessionsBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		updateIndex := tx.ReadBucket(updateIndexBkt)
+		if updateIndex == nil {
+			return ErrUninitializedDB
+		}
+
+		return sessions.ForEach(func(k, v []byte) error {
+			var session SessionInfo
+			err := session.Decode(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			hints, err := getHintsForSession(
+				updateIndex, &session.ID,
+			)
+			if err != nil {
+				return err
+			}
+
+			blobSize, err := session.Policy.BlobType.BlobSize()
+			if err != nil {
+				return err
+			}
+
+			numUpdates := uint64(len(hints))
+			clients = append(clients, TowerClient{
+				ID:          session.ID,
+				Policy:      session.Policy,
+				LastApplied: session.LastApplied,
+				NumUpdates:  numUpdates,
+				DiskUsage:   numUpdates * uint64(blobSize),
+			})
+
+			return nil
+		})
+	}, func() {
+		clients = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// ForEachSession calls the given callback for every session stored in the
+// tower database.
+func (t *TowerDB) ForEachSession(cb func(*SessionInfo) error) error {
+	return kvdb.View(t.db, func(tx kvdb.RTx) error {
+		sessions := tx.ReadBucket(sessionsBkt)
+		if sessions == nil {
+			return ErrUninitializedDB
+		}
+
+		return sessions.ForEach(func(k, v []byte) error {
+			var session SessionInfo
+			err := session.Decode(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			return cb(&session)
+		})
+	}, func() {})
+}
+
+// ForEachStateUpdate calls the given callback for every state update stored
+// in the tower database.
+func (t *TowerDB) ForEachStateUpdate(
+	cb func(*SessionStateUpdate) error) error {
+
+	return kvdb.View(t.db, func(tx kvdb.RTx) error {
+		updates := tx.ReadBucket(updatesBkt)
+		if updates == nil {
+			return ErrUninitializedDB
+		}
+
+		return updates.ForEach(func(hint, _ []byte) error {
+			updatesForHint := updates.NestedReadBucket(hint)
+			if updatesForHint == nil {
+				return nil
+			}
+
+			return updatesForHint.ForEach(func(_, v []byte) error {
+				var update SessionStateUpdate
+				err := update.Decode(bytes.NewReader(v))
+				if err != nil {
+					return err
+				}
+
+				return cb(&update)
+			})
+		})
+	}, func() {})
+}
+
 // SetLookoutTip stores the provided epoch as the latest lookout tip epoch in
 // the tower database.
 func (t *TowerDB) SetLookoutTip(epoch *chainntnfs.BlockEpoch) error {
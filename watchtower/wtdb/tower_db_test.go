@@ -7,6 +7,7 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/clock"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/watchtower"
 	"github.com/lightningnetwork/lnd/watchtower/blob"
@@ -636,6 +637,12 @@ func TestTowerDB(t *testing.T) {
 				return db
 			},
 		},
+		{
+			name: "native sql",
+			init: func(t *testing.T) watchtower.DB {
+				return newTestSQLTowerDB(t, clock.NewDefaultClock())
+			},
+		},
 		{
 			name: "mock",
 			init: func(t *testing.T) watchtower.DB {
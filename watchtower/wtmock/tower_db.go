@@ -166,6 +166,35 @@ func (db *TowerDB) QueryMatches(
 	return matches, nil
 }
 
+// ListClients returns a summary of the data that is stored for each client
+// of the tower.
+func (db *TowerDB) ListClients() ([]wtdb.TowerClient, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	clients := make([]wtdb.TowerClient, 0, len(db.sessions))
+	for id, info := range db.sessions {
+		client := wtdb.TowerClient{
+			ID:          id,
+			Policy:      info.Policy,
+			LastApplied: info.LastApplied,
+		}
+		for _, sessionUpdates := range db.blobs {
+			update, ok := sessionUpdates[id]
+			if !ok {
+				continue
+			}
+
+			client.NumUpdates++
+			client.DiskUsage += uint64(len(update.EncryptedBlob))
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
 // SetLookoutTip stores the provided epoch as the latest lookout tip epoch in
 // the tower database.
 func (db *TowerDB) SetLookoutTip(epoch *chainntnfs.BlockEpoch) error {
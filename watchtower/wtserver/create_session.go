@@ -65,6 +65,31 @@ func (s *Server) handleCreateSession(peer Peer, id *wtdb.SessionID,
 		)
 	}
 
+	// If the storage of clients is limited, reject sessions that could
+	// exceed the limit once all of their updates are used.
+	if s.cfg.MaxClientStorage > 0 {
+		blobSize, err := req.BlobType.BlobSize()
+		if err != nil {
+			log.Errorf("Unable to determine blob size for %s: %v",
+				id, err)
+			return s.replyCreateSession(
+				peer, id, wtwire.CodeTemporaryFailure, 0, nil,
+			)
+		}
+
+		maxStorage := uint64(req.MaxUpdates) * uint64(blobSize)
+		if maxStorage > s.cfg.MaxClientStorage {
+			log.Debugf("Rejecting CreateSession from %s, max "+
+				"updates %d exceed client storage quota", id,
+				req.MaxUpdates)
+			return s.replyCreateSession(
+				peer, id,
+				wtwire.CreateSessionCodeRejectMaxUpdates, 0,
+				nil,
+			)
+		}
+	}
+
 	// Now that we've established that this session does not exist in the
 	// database, retrieve the sweep address that will be given to the
 	// client. This address is to be included by the client when signing
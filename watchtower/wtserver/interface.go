@@ -68,3 +68,12 @@ type DB interface {
 	// id from the tower's database.
 	DeleteSession(wtdb.SessionID) error
 }
+
+// SessionCleaner is implemented by tower databases that track the activity of
+// sessions and are able to delete inactive ones.
+type SessionCleaner interface {
+	// DeleteInactiveSessions removes all sessions, along with their state
+	// updates, that didn't receive a state update since the given cutoff.
+	// It returns the number of deleted sessions.
+	DeleteInactiveSessions(cutoff time.Time) (int64, error)
+}
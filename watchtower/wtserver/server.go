@@ -27,6 +27,10 @@ var (
 	ErrServerExiting = errors.New("server shutting down")
 )
 
+// sessionCleanupInterval is the interval at which the server deletes inactive
+// sessions, if enabled.
+const sessionCleanupInterval = time.Hour
+
 // Config abstracts the primary components and dependencies of the server.
 type Config struct {
 	// DB provides persistent access to the server's sessions and for
@@ -67,6 +71,21 @@ type Config struct {
 	// DisableReward causes the server to reject any session creation
 	// attempts that request rewards.
 	DisableReward bool
+
+	// MaxClientStorage is the maximum number of bytes of encrypted justice
+	// data that is stored for a single client. Sessions that could exceed
+	// it with their maximum number of updates are rejected. If zero, the
+	// storage of a client isn't limited.
+	MaxClientStorage uint64
+
+	// SessionCleaner is used to delete sessions that didn't receive a
+	// state update within the InactiveSessionExpiry. If nil, sessions are
+	// only deleted on request of their client.
+	SessionCleaner SessionCleaner
+
+	// InactiveSessionExpiry is the duration after its last state update
+	// at which a session is deleted by the SessionCleaner.
+	InactiveSessionExpiry time.Duration
 }
 
 // Server houses the state required to handle watchtower peers. It's primary job
@@ -133,6 +152,13 @@ func (s *Server) Start() error {
 		s.wg.Add(1)
 		go s.peerHandler()
 
+		if s.cfg.SessionCleaner != nil &&
+			s.cfg.InactiveSessionExpiry > 0 {
+
+			s.wg.Add(1)
+			go s.cleanupInactiveSessions()
+		}
+
 		s.connMgr.Start()
 
 		log.Infof("Watchtower server started successfully")
@@ -201,6 +227,42 @@ func (s *Server) peerHandler() {
 	}
 }
 
+// cleanupInactiveSessions periodically deletes the sessions that didn't
+// receive a state update within the InactiveSessionExpiry. This reclaims the
+// storage of clients that never deleted their sessions, for example because
+// they went offline for good after their channels were closed.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (s *Server) cleanupInactiveSessions() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(sessionCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		cutoff := time.Now().Add(-s.cfg.InactiveSessionExpiry)
+		numDeleted, err := s.cfg.SessionCleaner.DeleteInactiveSessions(
+			cutoff,
+		)
+		switch {
+		case err != nil:
+			log.Errorf("Unable to delete inactive sessions: %v",
+				err)
+
+		case numDeleted > 0:
+			log.Infof("Deleted %d sessions without state updates "+
+				"since %v", numDeleted, cutoff)
+		}
+
+		select {
+		case <-ticker.C:
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
 // handleClient processes a series watchtower messages sent by a client. The
 // client may either send:
 //   - a single CreateSession message.
@@ -53,6 +53,17 @@ func initServer(t *testing.T, db wtserver.DB,
 
 	t.Helper()
 
+	return initServerWithQuota(t, db, timeout, 0)
+}
+
+// initServerWithQuota creates and starts a new server that limits the storage
+// each client can use to maxClientStorage bytes. A limit of zero doesn't
+// restrict the storage of clients.
+func initServerWithQuota(t *testing.T, db wtserver.DB, timeout time.Duration,
+	maxClientStorage uint64) wtserver.Interface {
+
+	t.Helper()
+
 	if db == nil {
 		db = wtmock.NewTowerDB()
 	}
@@ -64,7 +75,8 @@ func initServer(t *testing.T, db wtserver.DB,
 		NewAddress: func() (btcutil.Address, error) {
 			return addr, nil
 		},
-		ChainHash: testnetChainHash,
+		ChainHash:        testnetChainHash,
+		MaxClientStorage: maxClientStorage,
 	})
 	require.NoError(t, err, "unable to create server")
 
@@ -159,6 +171,7 @@ type createSessionTestCase struct {
 	expReply        *wtwire.CreateSessionReply
 	expDupReply     *wtwire.CreateSessionReply
 	sendStateUpdate bool
+	maxStorage      uint64
 }
 
 var createSessionTests = []createSessionTestCase{
@@ -270,6 +283,44 @@ var createSessionTests = []createSessionTestCase{
 			Data: []byte{},
 		},
 	},
+	{
+		name: "reject max updates exceeding storage quota",
+		initMsg: wtwire.NewInitMessage(
+			lnwire.NewRawFeatureVector(),
+			testnetChainHash,
+		),
+		createMsg: &wtwire.CreateSession{
+			BlobType:     blob.TypeAltruistCommit,
+			MaxUpdates:   1000,
+			RewardBase:   0,
+			RewardRate:   0,
+			SweepFeeRate: 10000,
+		},
+		expReply: &wtwire.CreateSessionReply{
+			Code: wtwire.CreateSessionCodeRejectMaxUpdates,
+			Data: []byte{},
+		},
+		maxStorage: 999 * uint64(len(testBlob)),
+	},
+	{
+		name: "accept max updates within storage quota",
+		initMsg: wtwire.NewInitMessage(
+			lnwire.NewRawFeatureVector(),
+			testnetChainHash,
+		),
+		createMsg: &wtwire.CreateSession{
+			BlobType:     blob.TypeAltruistCommit,
+			MaxUpdates:   1000,
+			RewardBase:   0,
+			RewardRate:   0,
+			SweepFeeRate: 10000,
+		},
+		expReply: &wtwire.CreateSessionReply{
+			Code: wtwire.CodeOK,
+			Data: []byte{},
+		},
+		maxStorage: 1000 * uint64(len(testBlob)),
+	},
 	// TODO(conner): add policy rejection tests
 }
 
@@ -288,7 +339,9 @@ func TestServerCreateSession(t *testing.T) {
 func testServerCreateSession(t *testing.T, i int, test createSessionTestCase) {
 	const timeoutDuration = 500 * time.Millisecond
 
-	s := initServer(t, nil, timeoutDuration)
+	s := initServerWithQuota(
+		t, nil, timeoutDuration, test.maxStorage,
+	)
 
 	localPub := randPubKey(t)
 